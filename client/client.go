@@ -28,6 +28,16 @@ type ctxKey struct{}
 // Client represents a generic client that sends data to any receiver supported by the OT receiver
 type Client struct {
 	IP string
+
+	// Subject is the identity authenticated by the receiver, it is empty when the receiver
+	// does not require authentication.
+	Subject string
+	// Groups are the groups the authenticated subject belongs to.
+	Groups []string
+
+	// Metadata holds the request metadata, gRPC metadata or HTTP headers, kept by the receiver.
+	// The keys are lowercase.
+	Metadata map[string][]string
 }
 
 // NewContext takes an existing context and derives a new context with the client value stored on it
@@ -41,24 +51,32 @@ func FromContext(ctx context.Context) (*Client, bool) {
 	return c, ok
 }
 
-// FromGRPC takes a GRPC context and tries to extract client information from it
+// FromGRPC takes a GRPC context and tries to extract client information from it.
+// The client already stored on the context, for instance by an authenticator, is returned first.
 func FromGRPC(ctx context.Context) (*Client, bool) {
+	if c, ok := FromContext(ctx); ok {
+		return c, true
+	}
 	if p, ok := peer.FromContext(ctx); ok {
 		ip := parseIP(p.Addr.String())
 		if ip != "" {
-			return &Client{ip}, true
+			return &Client{IP: ip}, true
 		}
 	}
 	return nil, false
 }
 
-// FromHTTP takes a net/http Request object and tries to extract client information from it
+// FromHTTP takes a net/http Request object and tries to extract client information from it.
+// The client already stored on the request context, for instance by an authenticator, is returned first.
 func FromHTTP(r *http.Request) (*Client, bool) {
+	if c, ok := FromContext(r.Context()); ok {
+		return c, true
+	}
 	ip := parseIP(r.RemoteAddr)
 	if ip == "" {
 		return nil, false
 	}
-	return &Client{ip}, true
+	return &Client{IP: ip}, true
 }
 
 func parseIP(source string) string {
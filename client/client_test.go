@@ -30,7 +30,7 @@ func TestClientContext(t *testing.T) {
 		"1.1.1.1", "127.0.0.1", "1111", "ip",
 	}
 	for _, ip := range ips {
-		ctx := NewContext(context.Background(), &Client{ip})
+		ctx := NewContext(context.Background(), &Client{IP: ip})
 		c, ok := FromContext(ctx)
 		assert.True(t, ok)
 		assert.NotNil(t, c)
@@ -58,3 +58,17 @@ func TestParsingHTTP(t *testing.T) {
 	assert.NotNil(t, client)
 	assert.Equal(t, client.IP, "192.168.1.2")
 }
+
+func TestParsingWithClientInContext(t *testing.T) {
+	authenticated := &Client{IP: "192.168.1.3", Subject: "subject", Groups: []string{"group"}}
+	ctx := NewContext(context.Background(), authenticated)
+
+	client, ok := FromGRPC(peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.168.1.1")}}))
+	assert.True(t, ok)
+	assert.Equal(t, authenticated, client)
+
+	req := (&http.Request{RemoteAddr: "192.168.1.2"}).WithContext(ctx)
+	client, ok = FromHTTP(req)
+	assert.True(t, ok)
+	assert.Equal(t, authenticated, client)
+}
@@ -35,11 +35,19 @@ const accessorsSliceTestTemplate = `func Test${structName}_${fieldName}(t *testi
 const accessorsMessageValueTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
 	return new${returnType}(&(*ms.orig).${originFieldName})
+}
+
+// Has${fieldName} returns true if the ${lowerFieldName} associated with this ${structName} is set.
+// The ${lowerFieldName} is embedded in the OTLP struct, an empty ${lowerFieldName} is reported as not set.
+func (ms ${structName}) Has${fieldName}() bool {
+	return (*ms.orig).${originFieldName}.Size() != 0
 }`
 
 const accessorsMessageValueTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
 	ms := New${structName}()
+	assert.False(t, ms.Has${fieldName}())
 	fillTest${returnType}(ms.${fieldName}())
+	assert.True(t, ms.Has${fieldName}())
 	assert.EqualValues(t, generateTest${returnType}(), ms.${fieldName}())
 }`
 
@@ -76,6 +84,22 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 	return ${returnType}((*ms.orig).${originFieldName})
 }`
 
+const accessorsOneofTemplate = `// Has${fieldName} returns true if the ${lowerFieldName} of this ${structName} is set.
+func (ms ${structName}) Has${fieldName}() bool {
+	return (*ms.orig).${originFieldName} != nil
+}`
+
+const accessorsOneofTestTemplate = `func Test${structName}_Has${fieldName}(t *testing.T) {
+	ms := New${structName}()
+	assert.False(t, ms.Has${fieldName}())
+	(*ms.orig).${originFieldName} = ${testValue}
+	assert.True(t, ms.Has${fieldName}())
+}`
+
+const diffPrimitiveTemplate = `	if ${notEqual} {
+		diffs = append(diffs, fmt.Sprintf("%s.${fieldName}: %v != %v", path, ms.${fieldName}()${format}, other.${fieldName}()${format}))
+	}`
+
 type baseField interface {
 	generateAccessors(ms baseStruct, sb *strings.Builder)
 
@@ -84,6 +108,8 @@ type baseField interface {
 	generateSetWithTestValue(sb *strings.Builder)
 
 	generateCopyToValue(sb *strings.Builder)
+
+	generateDiffValue(sb *strings.Builder)
 }
 
 type sliceField struct {
@@ -132,6 +158,10 @@ func (sf *sliceField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tms." + sf.fieldName + "().CopyTo(dest." + sf.fieldName + "())")
 }
 
+func (sf *sliceField) generateDiffValue(sb *strings.Builder) {
+	sb.WriteString("\tdiffs = ms." + sf.fieldName + "().diff(path+\"." + sf.fieldName + "\", other." + sf.fieldName + "(), diffs)")
+}
+
 var _ baseField = (*sliceField)(nil)
 
 type messageValueField struct {
@@ -184,6 +214,10 @@ func (mf *messageValueField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tms." + mf.fieldName + "().CopyTo(dest." + mf.fieldName + "())")
 }
 
+func (mf *messageValueField) generateDiffValue(sb *strings.Builder) {
+	sb.WriteString("\tdiffs = ms." + mf.fieldName + "().diff(path+\"." + mf.fieldName + "\", other." + mf.fieldName + "(), diffs)")
+}
+
 var _ baseField = (*messageValueField)(nil)
 
 type primitiveField struct {
@@ -238,6 +272,10 @@ func (pf *primitiveField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tdest.Set" + pf.fieldName + "(ms." + pf.fieldName + "())")
 }
 
+func (pf *primitiveField) generateDiffValue(sb *strings.Builder) {
+	generatePrimitiveDiffValue(pf.fieldName, pf.returnType, sb)
+}
+
 var _ baseField = (*primitiveField)(nil)
 
 // Types that has defined a custom type (e.g. "type Timestamp uint64")
@@ -303,19 +341,80 @@ func (ptf *primitiveTypedField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\tdest.Set" + ptf.fieldName + "(ms." + ptf.fieldName + "())")
 }
 
+func (ptf *primitiveTypedField) generateDiffValue(sb *strings.Builder) {
+	generatePrimitiveDiffValue(ptf.fieldName, ptf.returnType, sb)
+}
+
 var _ baseField = (*primitiveTypedField)(nil)
 
-// oneofField is used in case where the proto defines an "oneof".
+// generatePrimitiveDiffValue writes the comparison of a primitive field. The
+// slices are compared with the "<elementType>SlicesEqual" functions and the IDs
+// are printed in hex.
+func generatePrimitiveDiffValue(fieldName string, returnType string, sb *strings.Builder) {
+	sb.WriteString(os.Expand(diffPrimitiveTemplate, func(name string) string {
+		switch name {
+		case "fieldName":
+			return fieldName
+		case "notEqual":
+			if strings.HasPrefix(returnType, "[]") {
+				return "!" + strings.TrimPrefix(returnType, "[]") + "SlicesEqual(ms." + fieldName + "(), other." + fieldName + "())"
+			}
+			return "ms." + fieldName + "() != other." + fieldName + "()"
+		case "format":
+			if returnType == "TraceID" || returnType == "SpanID" {
+				return ".HexString()"
+			}
+			return ""
+		default:
+			panic(name)
+		}
+	}))
+}
+
+// oneofField is used in case where the proto defines an "oneof". Only the presence accessor is generated, the
+// accessors of the values are manually coded.
 type oneofField struct {
+	fieldName       string
 	copyFuncName    string
+	diffFuncName    string
 	originFieldName string
 	testVal         string
 	fillTestName    string
 }
 
-func (one oneofField) generateAccessors(baseStruct, *strings.Builder) {}
+func (one oneofField) generateAccessors(ms baseStruct, sb *strings.Builder) {
+	sb.WriteString(os.Expand(accessorsOneofTemplate, func(name string) string {
+		switch name {
+		case "structName":
+			return ms.getName()
+		case "fieldName":
+			return one.fieldName
+		case "lowerFieldName":
+			return strings.ToLower(one.fieldName)
+		case "originFieldName":
+			return one.originFieldName
+		default:
+			panic(name)
+		}
+	}))
+}
 
-func (one oneofField) generateAccessorsTest(baseStruct, *strings.Builder) {}
+func (one oneofField) generateAccessorsTest(ms baseStruct, sb *strings.Builder) {
+	sb.WriteString(os.Expand(accessorsOneofTestTemplate, func(name string) string {
+		switch name {
+		case "structName":
+			return ms.getName()
+		case "fieldName":
+			return one.fieldName
+		case "originFieldName":
+			return one.originFieldName
+		case "testValue":
+			return one.testVal
+		default:
+			panic(name)
+		}
+	}))
+}
 
 func (one oneofField) generateSetWithTestValue(sb *strings.Builder) {
 	sb.WriteString("\t(*tv.orig)." + one.originFieldName + " = " + one.testVal + "\n")
@@ -326,4 +425,8 @@ func (one oneofField) generateCopyToValue(sb *strings.Builder) {
 	sb.WriteString("\t" + one.copyFuncName + "(ms.orig, dest.orig)")
 }
 
+func (one oneofField) generateDiffValue(sb *strings.Builder) {
+	sb.WriteString("\tdiffs = " + one.diffFuncName + "(path, ms, other, diffs)")
+}
+
 var _ baseField = (*oneofField)(nil)
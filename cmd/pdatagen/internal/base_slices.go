@@ -125,12 +125,101 @@ func (es ${structName}) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new ${structName} can be initialized:
+// es := New${structName}()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es ${structName}) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*${originName}, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the ${structName} by one and set the
 // given ${elementName} at that new position.  The original ${elementName}
 // could still be referenced so do not reuse it after passing it to this
 // method.
 func (es ${structName}) Append(e ${elementName}) {
 	*es.orig = append(*es.orig, e.orig)
+}
+
+// AppendEmpty will append to the end of the slice an empty ${elementName}.
+// It returns the newly added ${elementName}.
+func (es ${structName}) AppendEmpty() ${elementName} {
+	*es.orig = append(*es.orig, &${originName}{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es ${structName}) RemoveIf(f func(${elementName}) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es ${structName}) Equal(other ${structName}) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es ${structName}) Diff(other ${structName}) []string {
+	return es.diff("${structName}", other, nil)
+}
+
+func (es ${structName}) diff(path string, other ${structName}, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the ${elementName} elements within ${structName} given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b ${elementName}) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es ${structName}) Sort(less func(a, b ${elementName}) bool) ${structName} {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
 }`
 
 const slicePtrTestTemplate = `func Test${structName}(t *testing.T) {
@@ -232,6 +321,23 @@ func Test${structName}_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func Test${structName}_EnsureCapacity(t *testing.T) {
+	es := generateTest${structName}()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTest${structName}(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTest${structName}(), es)
+}
+
 func Test${structName}_Append(t *testing.T) {
 	es := generateTest${structName}()
 
@@ -245,6 +351,75 @@ func Test${structName}_Append(t *testing.T) {
 	assert.EqualValues(t, value.orig, es.At(8).orig)
 
 	assert.Equal(t, 9, es.Len())
+}
+
+func Test${structName}_AppendEmpty(t *testing.T) {
+	es := generateTest${structName}()
+	emptyVal := New${elementName}()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTest${elementName}(e)
+	assert.EqualValues(t, generateTest${elementName}(), es.At(7))
+}
+
+func Test${structName}_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := New${structName}()
+	emptySlice.RemoveIf(func(el ${elementName}) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTest${structName}()
+	expectedSlice := New${structName}()
+	pos := 0
+	filtered.RemoveIf(func(el ${elementName}) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func Test${structName}_Equal(t *testing.T) {
+	es := generateTest${structName}()
+	assert.True(t, es.Equal(generateTest${structName}()))
+	assert.True(t, New${structName}().Equal(New${structName}()))
+	assert.Equal(t, []string{"${structName}: length 7 != 0"}, es.Diff(New${structName}()))
+
+	// Test Equal with a different element
+	other := generateTest${structName}()
+	other.Resize(6)
+	other.Append(New${elementName}())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "${structName}[6]."), diff)
+	}
+}
+
+func Test${structName}_Sort(t *testing.T) {
+	es := generateTest${structName}()
+	emptyVal := New${elementName}()
+	es.Append(New${elementName}())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b ${elementName}) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTest${elementName}()))
+	}
 }`
 
 const slicePtrGenerateTest = `func generateTest${structName}() ${structName} {
@@ -361,12 +536,101 @@ func (es ${structName}) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new ${structName} can be initialized:
+// es := New${structName}()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es ${structName}) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]${originName}, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the ${structName} by one and set the
 // given ${elementName} at that new position.  The original ${elementName}
 // could still be referenced so do not reuse it after passing it to this
 // method.
 func (es ${structName}) Append(e ${elementName}) {
 	*es.orig = append(*es.orig, *e.orig)
+}
+
+// AppendEmpty will append to the end of the slice an empty ${elementName}.
+// It returns the newly added ${elementName}.
+func (es ${structName}) AppendEmpty() ${elementName} {
+	*es.orig = append(*es.orig, ${originName}{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es ${structName}) RemoveIf(f func(${elementName}) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = ${originName}{}
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es ${structName}) Equal(other ${structName}) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es ${structName}) Diff(other ${structName}) []string {
+	return es.diff("${structName}", other, nil)
+}
+
+func (es ${structName}) diff(path string, other ${structName}, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the ${elementName} elements within ${structName} given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b ${elementName}) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es ${structName}) Sort(less func(a, b ${elementName}) bool) ${structName} {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
 }`
 
 const sliceValueTestTemplate = `func Test${structName}(t *testing.T) {
@@ -468,6 +732,23 @@ func Test${structName}_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func Test${structName}_EnsureCapacity(t *testing.T) {
+	es := generateTest${structName}()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTest${structName}(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTest${structName}(), es)
+}
+
 func Test${structName}_Append(t *testing.T) {
 	es := generateTest${structName}()
 
@@ -481,6 +762,75 @@ func Test${structName}_Append(t *testing.T) {
 	assert.EqualValues(t, value, es.At(8))
 
 	assert.Equal(t, 9, es.Len())
+}
+
+func Test${structName}_AppendEmpty(t *testing.T) {
+	es := generateTest${structName}()
+	emptyVal := New${elementName}()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTest${elementName}(e)
+	assert.EqualValues(t, generateTest${elementName}(), es.At(7))
+}
+
+func Test${structName}_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := New${structName}()
+	emptySlice.RemoveIf(func(el ${elementName}) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTest${structName}()
+	expectedSlice := New${structName}()
+	pos := 0
+	filtered.RemoveIf(func(el ${elementName}) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func Test${structName}_Equal(t *testing.T) {
+	es := generateTest${structName}()
+	assert.True(t, es.Equal(generateTest${structName}()))
+	assert.True(t, New${structName}().Equal(New${structName}()))
+	assert.Equal(t, []string{"${structName}: length 7 != 0"}, es.Diff(New${structName}()))
+
+	// Test Equal with a different element
+	other := generateTest${structName}()
+	other.Resize(6)
+	other.Append(New${elementName}())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "${structName}[6]."), diff)
+	}
+}
+
+func Test${structName}_Sort(t *testing.T) {
+	es := generateTest${structName}()
+	emptyVal := New${elementName}()
+	es.Append(New${elementName}())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b ${elementName}) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTest${elementName}()))
+	}
 }`
 
 const sliceValueGenerateTest = `func generateTest${structName}() ${structName} {
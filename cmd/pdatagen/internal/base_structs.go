@@ -46,11 +46,35 @@ func (ms ${structName}) CopyTo(dest ${structName}) {`
 
 const messageValueCopyToFooterTemplate = `}`
 
+const messageValueDiffHeaderTemplate = `// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms ${structName}) Equal(other ${structName}) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms ${structName}) Diff(other ${structName}) []string {
+	return ms.diff("${structName}", other, nil)
+}
+
+func (ms ${structName}) diff(path string, other ${structName}, diffs []string) []string {`
+
+const messageValueDiffFooterTemplate = `	return diffs
+}`
+
 const messageValueTestTemplate = `
 func Test${structName}_CopyTo(t *testing.T) {
 	ms := New${structName}()
 	generateTest${structName}().CopyTo(ms)
 	assert.EqualValues(t, generateTest${structName}(), ms)
+}
+
+func Test${structName}_Equal(t *testing.T) {
+	ms := generateTest${structName}()
+	assert.True(t, ms.Equal(generateTest${structName}()))
+	assert.Empty(t, ms.Diff(generateTest${structName}()))
+	assert.False(t, ms.Equal(New${structName}()))
+	assert.NotEmpty(t, ms.Diff(New${structName}()))
 }`
 
 const messageValueGenerateTestTemplate = `func generateTest${structName}() ${structName} {
@@ -121,6 +145,22 @@ func (ms *messageValueStruct) generateStruct(sb *strings.Builder) {
 	sb.WriteString(os.Expand(messageValueCopyToFooterTemplate, func(name string) string {
 		panic(name)
 	}))
+	sb.WriteString(newLine + newLine)
+	sb.WriteString(os.Expand(messageValueDiffHeaderTemplate, func(name string) string {
+		switch name {
+		case "structName":
+			return ms.structName
+		default:
+			panic(name)
+		}
+	}))
+	// Write the comparison of all the fields for the struct
+	for _, f := range ms.fields {
+		sb.WriteString(newLine)
+		f.generateDiffValue(sb)
+	}
+	sb.WriteString(newLine)
+	sb.WriteString(messageValueDiffFooterTemplate)
 }
 
 func (ms *messageValueStruct) generateTests(sb *strings.Builder) {
@@ -17,9 +17,14 @@ package internal
 var commonFile = &File{
 	Name: "common",
 	imports: []string{
+		`"fmt"`,
+		`"sort"`,
+		`"strconv"`,
+		``,
 		`otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"`,
 	},
 	testImports: []string{
+		`"strings"`,
 		`"testing"`,
 		``,
 		`"github.com/stretchr/testify/assert"`,
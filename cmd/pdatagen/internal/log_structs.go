@@ -17,10 +17,15 @@ package internal
 var logFile = &File{
 	Name: "log",
 	imports: []string{
-		`otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"`,
+		`"fmt"`,
+		`"sort"`,
+		`"strconv"`,
+		``,
+		`"go.opentelemetry.io/collector/internal/data"`,
 		`otlplogs "go.opentelemetry.io/collector/internal/data/protogen/logs/v1"`,
 	},
 	testImports: []string{
+		`"strings"`,
 		`"testing"`,
 		``,
 		`"github.com/stretchr/testify/assert"`,
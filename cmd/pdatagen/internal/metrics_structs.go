@@ -17,9 +17,15 @@ package internal
 var metricsFile = &File{
 	Name: "metrics",
 	imports: []string{
+		`"fmt"`,
+		`"sort"`,
+		`"strconv"`,
+		``,
+		`"go.opentelemetry.io/collector/internal/data"`,
 		`otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"`,
 	},
 	testImports: []string{
+		`"strings"`,
 		`"testing"`,
 		``,
 		`"github.com/stretchr/testify/assert"`,
@@ -360,6 +366,8 @@ var intExemplar = &messageValueStruct{
 			originFieldName: "FilteredLabels",
 			returnSlice:     stringMap,
 		},
+		traceIDField,
+		spanIDField,
 	},
 }
 
@@ -383,6 +391,8 @@ var doubleExemplar = &messageValueStruct{
 			originFieldName: "FilteredLabels",
 			returnSlice:     stringMap,
 		},
+		traceIDField,
+		spanIDField,
 	},
 }
 
@@ -412,6 +422,10 @@ var countField = &primitiveField{
 	testVal:         "uint64(17)",
 }
 
+// The sum of the histogram and summary data points is a proto3 scalar without
+// presence in the OTLP version used, an absent sum is decoded as 0. Presence
+// accessors (HasSum) can only be generated once the proto declares the field
+// as optional.
 var intSumField = &primitiveField{
 	fieldName:       "Sum",
 	originFieldName: "Sum",
@@ -486,7 +500,9 @@ var aggregationTemporalityField = &primitiveTypedField{
 }
 
 var oneofDataField = &oneofField{
+	fieldName:       "Data",
 	copyFuncName:    "copyData",
+	diffFuncName:    "diffData",
 	originFieldName: "Data",
 	testVal:         "&otlpmetrics.Metric_IntGauge{IntGauge: &otlpmetrics.IntGauge{}}",
 	fillTestName:    "IntGauge",
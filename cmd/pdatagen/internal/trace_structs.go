@@ -17,11 +17,15 @@ package internal
 var traceFile = &File{
 	Name: "trace",
 	imports: []string{
+		`"fmt"`,
+		`"sort"`,
+		`"strconv"`,
+		``,
 		`"go.opentelemetry.io/collector/internal/data"`,
-		`otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"`,
 		`otlptrace "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"`,
 	},
 	testImports: []string{
+		`"strings"`,
 		`"testing"`,
 		``,
 		`"github.com/stretchr/testify/assert"`,
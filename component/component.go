@@ -71,8 +71,26 @@ const (
 	KindProcessor
 	KindExporter
 	KindExtension
+	KindConnector
 )
 
+// String returns the name of the kind as used in the configuration, e.g. "receiver".
+func (k Kind) String() string {
+	switch k {
+	case KindReceiver:
+		return "receiver"
+	case KindProcessor:
+		return "processor"
+	case KindExporter:
+		return "exporter"
+	case KindExtension:
+		return "extension"
+	case KindConnector:
+		return "connector"
+	}
+	return "unknown"
+}
+
 // Factory interface must be implemented by all component factories.
 type Factory interface {
 	// Type gets the type of the component created by this factory.
@@ -29,4 +29,7 @@ var (
 
 	// ErrNilNextConsumer indicates an error on nil next consumer.
 	ErrNilNextConsumer = errors.New("nil nextConsumer")
+
+	// ErrPanic indicates that a component panicked, the errors converted from the recovered panics wrap it.
+	ErrPanic = errors.New("panic")
 )
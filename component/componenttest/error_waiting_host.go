@@ -43,6 +43,9 @@ func (ews *ErrorWaitingHost) ReportFatalError(err error) {
 	ews.errorChan <- err
 }
 
+// ReportComponentStatus ignores the status events, only the fatal errors are waited for.
+func (ews *ErrorWaitingHost) ReportComponentStatus(_ *component.StatusEvent) {}
+
 // WaitForFatalError waits the given amount of time until an error is reported via
 // ReportFatalError. It returns the error, if any, and a bool to indicated if
 // an error was received before the time out.
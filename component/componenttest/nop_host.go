@@ -31,6 +31,8 @@ func NewNopHost() component.Host {
 
 func (nh *nopHost) ReportFatalError(_ error) {}
 
+func (nh *nopHost) ReportComponentStatus(_ *component.StatusEvent) {}
+
 func (nh *nopHost) GetFactory(_ component.Kind, _ configmodels.Type) component.Factory {
 	return nil
 }
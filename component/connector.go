@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// Connector bridges pipelines: it is used as an exporter in the pipelines it consumes data from, and as a receiver
+// in the pipelines it feeds. The data types of these pipelines may differ, for example a connector can consume
+// traces and produce metrics computed from the spans.
+type Connector interface {
+	Component
+}
+
+// TracesConnector is a connector consuming the traces exported by its pipelines.
+type TracesConnector interface {
+	Connector
+	consumer.Traces
+}
+
+// MetricsConnector is a connector consuming the metrics exported by its pipelines.
+type MetricsConnector interface {
+	Connector
+	consumer.Metrics
+}
+
+// LogsConnector is a connector consuming the logs exported by its pipelines.
+type LogsConnector interface {
+	Connector
+	consumer.Logs
+}
+
+// ConnectorCreateParams is passed to ConnectorFactory.Create* functions.
+type ConnectorCreateParams struct {
+	// Logger that the factory can use during creation and can pass to the created
+	// component to be used later as well.
+	Logger *zap.Logger
+
+	// ApplicationStartInfo can be used by components for informational purposes
+	ApplicationStartInfo ApplicationStartInfo
+}
+
+// ConnectorFactory can create connectors. A connector is created for each pair of the data type of the pipelines
+// exporting to it and of the data type of the pipelines receiving from it. The Create* functions are named after
+// these data types, e.g. CreateTracesToMetricsConnector creates a connector consuming traces and producing metrics.
+// If the connector type does not support a pair of data types configerror.ErrDataTypeIsNotSupported is returned.
+type ConnectorFactory interface {
+	Factory
+
+	// CreateDefaultConfig creates the default configuration for the Connector.
+	// This method can be called multiple times depending on the pipeline
+	// configuration and should not cause side-effects that prevent the creation
+	// of multiple instances of the Connector.
+	// The object returned by this method needs to pass the checks implemented by
+	// 'configcheck.ValidateConfig'. It is recommended to have such check in the
+	// tests of any implementation of the Factory interface.
+	CreateDefaultConfig() configmodels.Connector
+
+	// CreateTracesToTracesConnector creates a connector consuming traces and producing traces.
+	CreateTracesToTracesConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Traces,
+	) (TracesConnector, error)
+
+	// CreateTracesToMetricsConnector creates a connector consuming traces and producing metrics.
+	CreateTracesToMetricsConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Metrics,
+	) (TracesConnector, error)
+
+	// CreateTracesToLogsConnector creates a connector consuming traces and producing logs.
+	CreateTracesToLogsConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Logs,
+	) (TracesConnector, error)
+
+	// CreateMetricsToTracesConnector creates a connector consuming metrics and producing traces.
+	CreateMetricsToTracesConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Traces,
+	) (MetricsConnector, error)
+
+	// CreateMetricsToMetricsConnector creates a connector consuming metrics and producing metrics.
+	CreateMetricsToMetricsConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Metrics,
+	) (MetricsConnector, error)
+
+	// CreateMetricsToLogsConnector creates a connector consuming metrics and producing logs.
+	CreateMetricsToLogsConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Logs,
+	) (MetricsConnector, error)
+
+	// CreateLogsToTracesConnector creates a connector consuming logs and producing traces.
+	CreateLogsToTracesConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Traces,
+	) (LogsConnector, error)
+
+	// CreateLogsToMetricsConnector creates a connector consuming logs and producing metrics.
+	CreateLogsToMetricsConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Metrics,
+	) (LogsConnector, error)
+
+	// CreateLogsToLogsConnector creates a connector consuming logs and producing logs.
+	CreateLogsToLogsConnector(
+		ctx context.Context,
+		params ConnectorCreateParams,
+		cfg configmodels.Connector,
+		nextConsumer consumer.Logs,
+	) (LogsConnector, error)
+}
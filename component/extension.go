@@ -46,6 +46,36 @@ type PipelineWatcher interface {
 	NotReady() error
 }
 
+// StorageExtension is an extra interface for Extension hosted by the OpenTelemetry
+// Collector that provides local persistence to the other components, for example to
+// keep the content of a queue or the position read in a file across restarts.
+// Components find it by iterating the extensions returned by Host.GetExtensions.
+type StorageExtension interface {
+	Extension
+
+	// GetClient returns a client to the key-value store identified by the kind and
+	// the full name of the component requesting it, and by name which allows a
+	// component to use several stores. The same store is returned across restarts.
+	// The caller must close the client once done with it.
+	GetClient(ctx context.Context, kind Kind, componentName string, name string) (StorageClient, error)
+}
+
+// StorageClient is the interface to a key-value store provided by a StorageExtension.
+// It is safe to be used concurrently.
+type StorageClient interface {
+	// Get returns the value stored for the key, or nil if the key is not found.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores the value for the key, replacing any previous value.
+	Set(ctx context.Context, key string, value []byte) error
+
+	// Delete removes the key, deleting a key which is not found is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// Close releases the resources held by the client, it must not be used after.
+	Close(ctx context.Context) error
+}
+
 // ExtensionCreateParams is passed to ExtensionFactory.Create* functions.
 type ExtensionCreateParams struct {
 	// Logger that the factory can use during creation and can pass to the created
@@ -26,6 +26,12 @@ type Host interface {
 	// from) after its start function had already returned.
 	ReportFatalError(err error)
 
+	// ReportComponentStatus is used to report to the host a transition of the component to a status, e.g. from
+	// StatusOK to StatusRecoverableError when an exporter cannot reach its destination and back to StatusOK when it
+	// recovered. Unlike ReportFatalError it does not terminate the collector, the status is published to the
+	// extensions implementing StatusWatcher and on the statusz zPage.
+	ReportComponentStatus(event *StatusEvent)
+
 	// GetFactory of the specified kind. Returns the factory for a component type.
 	// This allows components to create other components. For example:
 	//   func (r MyReceiver) Start(host component.Host) error {
@@ -58,6 +58,30 @@ type ProcessorCapabilities struct {
 	// does not modify the data it MUST set this flag to false. If the processor creates
 	// a copy of the data before modifying then this flag can be safely set to false.
 	MutatesConsumedData bool
+
+	// MutatesConsumedTraces, MutatesConsumedMetrics and MutatesConsumedLogs are set to true
+	// if the processor modifies the input data of this signal only. They allow a processor
+	// consuming several signals to declare that it modifies the data of some of them, the
+	// receivers only cloning the data for the pipelines of these signals.
+	MutatesConsumedTraces  bool
+	MutatesConsumedMetrics bool
+	MutatesConsumedLogs    bool
+}
+
+// MutatesConsumed returns true if the processor modifies the input data of the given data type.
+func (pc ProcessorCapabilities) MutatesConsumed(dataType configmodels.DataType) bool {
+	if pc.MutatesConsumedData {
+		return true
+	}
+	switch dataType {
+	case configmodels.TracesDataType:
+		return pc.MutatesConsumedTraces
+	case configmodels.MetricsDataType:
+		return pc.MutatesConsumedMetrics
+	case configmodels.LogsDataType:
+		return pc.MutatesConsumedLogs
+	}
+	return false
 }
 
 // ProcessorCreateParams is passed to Create* functions in ProcessorFactory.
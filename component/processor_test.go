@@ -89,3 +89,17 @@ func TestFactoriesBuilder(t *testing.T) {
 		assert.Equal(t, c.out, out)
 	}
 }
+
+func TestProcessorCapabilitiesMutatesConsumed(t *testing.T) {
+	all := ProcessorCapabilities{MutatesConsumedData: true}
+	assert.True(t, all.MutatesConsumed(configmodels.TracesDataType))
+	assert.True(t, all.MutatesConsumed(configmodels.MetricsDataType))
+	assert.True(t, all.MutatesConsumed(configmodels.LogsDataType))
+
+	metricsOnly := ProcessorCapabilities{MutatesConsumedMetrics: true}
+	assert.False(t, metricsOnly.MutatesConsumed(configmodels.TracesDataType))
+	assert.True(t, metricsOnly.MutatesConsumed(configmodels.MetricsDataType))
+	assert.False(t, metricsOnly.MutatesConsumed(configmodels.LogsDataType))
+
+	assert.False(t, ProcessorCapabilities{}.MutatesConsumed(configmodels.TracesDataType))
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"time"
+)
+
+// Status is the health status of a component reported to the host at runtime.
+type Status int
+
+const (
+	// StatusOK is reported when the component works as expected, including when it recovered from an error.
+	StatusOK Status = iota
+	// StatusRecoverableError is reported when the component encountered an error it may recover from, e.g. an
+	// exporter that cannot reach its destination.
+	StatusRecoverableError
+	// StatusPermanentError is reported when the component encountered an error it cannot recover from, but which does
+	// not require the collector to terminate, unlike the errors passed to Host.ReportFatalError.
+	StatusPermanentError
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "OK"
+	case StatusRecoverableError:
+		return "RecoverableError"
+	case StatusPermanentError:
+		return "PermanentError"
+	}
+	return "Unknown"
+}
+
+// StatusEvent is a transition of a component to a status.
+type StatusEvent struct {
+	Status Status
+	// Err is the error causing the transition to an error status.
+	Err error
+	// Timestamp is the time of the transition, the host sets it when it is zero.
+	Timestamp time.Time
+}
+
+// StatusSource identifies the component that reported a status event.
+type StatusSource struct {
+	Kind Kind
+	// Name is the full name of the component in the configuration.
+	Name string
+	// Pipeline is the name of the pipeline of a processor, or the name of the processor chain for a processor of a
+	// chain shared by several pipelines. The other components are not bound to a single pipeline.
+	Pipeline string
+}
+
+// StatusWatcher is an extra interface for Extension hosted by the OpenTelemetry Collector that is to be implemented
+// by extensions interested in the status events reported by the components, e.g. a health check.
+type StatusWatcher interface {
+	// ComponentStatusChanged notifies the Extension that a component reported a status event. It is called
+	// synchronously from Host.ReportComponentStatus and must not block.
+	ComponentStatusChanged(source StatusSource, event *StatusEvent)
+}
@@ -20,15 +20,19 @@ package config
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 // These are errors that can be returned by Load(). Note that error codes are not part
@@ -42,6 +46,9 @@ const (
 	errUnknownType
 	errDuplicateName
 	errUnmarshalTopLevelStructureError
+	errExpandValues
+	errInvalidComponentConfig
+	errInvalidProcessorChainReference
 )
 
 const (
@@ -72,6 +79,9 @@ const (
 	// processorsKeyName is the configuration key name for processors section.
 	processorsKeyName = "processors"
 
+	// connectorsKeyName is the configuration key name for connectors section.
+	connectorsKeyName = "connectors"
+
 	// pipelinesKeyName is the configuration key name for pipelines section.
 	pipelinesKeyName = "pipelines"
 )
@@ -80,21 +90,34 @@ type configSettings struct {
 	Receivers  map[string]map[string]interface{} `mapstructure:"receivers"`
 	Processors map[string]map[string]interface{} `mapstructure:"processors"`
 	Exporters  map[string]map[string]interface{} `mapstructure:"exporters"`
+	Connectors map[string]map[string]interface{} `mapstructure:"connectors"`
 	Extensions map[string]map[string]interface{} `mapstructure:"extensions"`
 	Service    serviceSettings                   `mapstructure:"service"`
 }
 
 type serviceSettings struct {
-	Extensions []string                    `mapstructure:"extensions"`
-	Pipelines  map[string]pipelineSettings `mapstructure:"pipelines"`
+	Extensions      []string                      `mapstructure:"extensions"`
+	ProcessorChains map[string][]string           `mapstructure:"processor_chains"`
+	FeatureGates    []string                      `mapstructure:"feature_gates"`
+	Pipelines       map[string]pipelineSettings   `mapstructure:"pipelines"`
+	ShutdownTimeout time.Duration                 `mapstructure:"shutdown_timeout"`
+	Telemetry       configmodels.ServiceTelemetry `mapstructure:"telemetry"`
 }
 
 type pipelineSettings struct {
-	Receivers  []string `mapstructure:"receivers"`
-	Processors []string `mapstructure:"processors"`
-	Exporters  []string `mapstructure:"exporters"`
+	Receivers         []string `mapstructure:"receivers"`
+	Processors        []string `mapstructure:"processors"`
+	Exporters         []string `mapstructure:"exporters"`
+	FanoutErrorPolicy string   `mapstructure:"fanout_error_policy"`
 }
 
+// Prefixes and separator of the ${...} references expanded in the configuration values.
+const (
+	envReferencePrefix    = "env:"
+	fileReferencePrefix   = "file:"
+	defaultValueSeparator = ":-"
+)
+
 // typeAndNameSeparator is the separator that is used between type and name in type/name composite keys.
 const typeAndNameSeparator = "/"
 
@@ -156,6 +179,12 @@ func Load(
 	}
 	config.Processors = processors
 
+	connectors, err := loadConnectors(v.GetStringMap(connectorsKeyName), factories.Connectors)
+	if err != nil {
+		return nil, err
+	}
+	config.Connectors = connectors
+
 	// Load the service and its data pipelines.
 	service, err := loadService(rawCfg.Service)
 	if err != nil {
@@ -163,6 +192,14 @@ func Load(
 	}
 	config.Service = service
 
+	// Validate the component settings, all the invalid configurations are reported.
+	if err := ValidateComponentConfigs(&config); err != nil {
+		return nil, &configError{
+			code: errInvalidComponentConfig,
+			msg:  err.Error(),
+		}
+	}
+
 	return &config, nil
 }
 
@@ -226,6 +263,13 @@ func errorUnmarshalError(component string, fullName string, err error) error {
 	}
 }
 
+func errorExpandError(component string, fullName string, err error) error {
+	return &configError{
+		code: errExpandValues,
+		msg:  fmt.Sprintf("error expanding %s configuration for %s: %v", component, fullName, err),
+	}
+}
+
 func errorDuplicateName(component string, fullName string) error {
 	return &configError{
 		code: errDuplicateName,
@@ -240,7 +284,6 @@ func loadExtensions(exts map[string]interface{}, factories map[configmodels.Type
 	// Iterate over extensions and create a config for each.
 	for key, value := range exts {
 		componentConfig := viperFromStringMap(cast.ToStringMap(value))
-		expandEnvConfig(componentConfig)
 
 		// Decode the key into type and fullName components.
 		typeStr, fullName, err := DecodeTypeAndName(key)
@@ -253,11 +296,16 @@ func loadExtensions(exts map[string]interface{}, factories map[configmodels.Type
 		if factory == nil {
 			return nil, errorUnknownType(extensionsKeyName, typeStr, fullName)
 		}
+		if err = expandEnvConfig(componentConfig); err != nil {
+			return nil, errorExpandError(extensionsKeyName, fullName, err)
+		}
 
 		// Create the default config for this extension
 		extensionCfg := factory.CreateDefaultConfig()
 		extensionCfg.SetName(fullName)
-		expandEnvLoadedConfig(extensionCfg)
+		if err = expandEnvLoadedConfig(extensionCfg); err != nil {
+			return nil, errorExpandError(extensionsKeyName, fullName, err)
+		}
 
 		// Now that the default config struct is created we can Unmarshal into it
 		// and it will apply user-defined config on top of the default.
@@ -279,10 +327,14 @@ func loadExtensions(exts map[string]interface{}, factories map[configmodels.Type
 func loadService(rawService serviceSettings) (configmodels.Service, error) {
 	var ret configmodels.Service
 	ret.Extensions = rawService.Extensions
+	ret.ShutdownTimeout = rawService.ShutdownTimeout
+	ret.Telemetry = rawService.Telemetry
+	ret.ProcessorChains = rawService.ProcessorChains
+	ret.FeatureGates = rawService.FeatureGates
 
 	// Process the pipelines first so in case of error on them it can be properly
 	// reported.
-	pipelines, err := loadPipelines(rawService.Pipelines)
+	pipelines, err := loadPipelines(rawService.Pipelines, rawService.ProcessorChains)
 	ret.Pipelines = pipelines
 
 	return ret, err
@@ -293,7 +345,9 @@ func LoadReceiver(componentConfig *viper.Viper, typeStr configmodels.Type, fullN
 	// Create the default config for this receiver.
 	receiverCfg := factory.CreateDefaultConfig()
 	receiverCfg.SetName(fullName)
-	expandEnvLoadedConfig(receiverCfg)
+	if err := expandEnvLoadedConfig(receiverCfg); err != nil {
+		return nil, errorExpandError(receiversKeyName, fullName, err)
+	}
 
 	// Now that the default config struct is created we can Unmarshal into it
 	// and it will apply user-defined config on top of the default.
@@ -312,7 +366,6 @@ func loadReceivers(recvs map[string]interface{}, factories map[configmodels.Type
 	// Iterate over input map and create a config for each.
 	for key, value := range recvs {
 		componentConfig := viperFromStringMap(cast.ToStringMap(value))
-		expandEnvConfig(componentConfig)
 
 		// Decode the key into type and fullName components.
 		typeStr, fullName, err := DecodeTypeAndName(key)
@@ -325,6 +378,9 @@ func loadReceivers(recvs map[string]interface{}, factories map[configmodels.Type
 		if factory == nil {
 			return nil, errorUnknownType(receiversKeyName, typeStr, fullName)
 		}
+		if err = expandEnvConfig(componentConfig); err != nil {
+			return nil, errorExpandError(receiversKeyName, fullName, err)
+		}
 
 		receiverCfg, err := LoadReceiver(componentConfig, typeStr, fullName, factory)
 
@@ -349,7 +405,6 @@ func loadExporters(exps map[string]interface{}, factories map[configmodels.Type]
 	// Iterate over Exporters and create a config for each.
 	for key, value := range exps {
 		componentConfig := viperFromStringMap(cast.ToStringMap(value))
-		expandEnvConfig(componentConfig)
 
 		// Decode the key into type and fullName components.
 		typeStr, fullName, err := DecodeTypeAndName(key)
@@ -362,11 +417,16 @@ func loadExporters(exps map[string]interface{}, factories map[configmodels.Type]
 		if factory == nil {
 			return nil, errorUnknownType(exportersKeyName, typeStr, fullName)
 		}
+		if err = expandEnvConfig(componentConfig); err != nil {
+			return nil, errorExpandError(exportersKeyName, fullName, err)
+		}
 
 		// Create the default config for this exporter
 		exporterCfg := factory.CreateDefaultConfig()
 		exporterCfg.SetName(fullName)
-		expandEnvLoadedConfig(exporterCfg)
+		if err = expandEnvLoadedConfig(exporterCfg); err != nil {
+			return nil, errorExpandError(exportersKeyName, fullName, err)
+		}
 
 		// Now that the default config struct is created we can Unmarshal into it
 		// and it will apply user-defined config on top of the default.
@@ -392,7 +452,6 @@ func loadProcessors(procs map[string]interface{}, factories map[configmodels.Typ
 	// Iterate over processors and create a config for each.
 	for key, value := range procs {
 		componentConfig := viperFromStringMap(cast.ToStringMap(value))
-		expandEnvConfig(componentConfig)
 
 		// Decode the key into type and fullName components.
 		typeStr, fullName, err := DecodeTypeAndName(key)
@@ -405,11 +464,16 @@ func loadProcessors(procs map[string]interface{}, factories map[configmodels.Typ
 		if factory == nil {
 			return nil, errorUnknownType(processorsKeyName, typeStr, fullName)
 		}
+		if err = expandEnvConfig(componentConfig); err != nil {
+			return nil, errorExpandError(processorsKeyName, fullName, err)
+		}
 
 		// Create the default config for this processor.
 		processorCfg := factory.CreateDefaultConfig()
 		processorCfg.SetName(fullName)
-		expandEnvLoadedConfig(processorCfg)
+		if err = expandEnvLoadedConfig(processorCfg); err != nil {
+			return nil, errorExpandError(processorsKeyName, fullName, err)
+		}
 
 		// Now that the default config struct is created we can Unmarshal into it
 		// and it will apply user-defined config on top of the default.
@@ -428,7 +492,54 @@ func loadProcessors(procs map[string]interface{}, factories map[configmodels.Typ
 	return processors, nil
 }
 
-func loadPipelines(pipelinesConfig map[string]pipelineSettings) (configmodels.Pipelines, error) {
+func loadConnectors(conns map[string]interface{}, factories map[configmodels.Type]component.ConnectorFactory) (configmodels.Connectors, error) {
+	// Prepare resulting map.
+	connectors := make(configmodels.Connectors)
+
+	// Iterate over connectors and create a config for each.
+	for key, value := range conns {
+		componentConfig := viperFromStringMap(cast.ToStringMap(value))
+
+		// Decode the key into type and fullName components.
+		typeStr, fullName, err := DecodeTypeAndName(key)
+		if err != nil {
+			return nil, errorInvalidTypeAndNameKey(connectorsKeyName, key, err)
+		}
+
+		// Find connector factory based on "type" that we read from config source.
+		factory := factories[typeStr]
+		if factory == nil {
+			return nil, errorUnknownType(connectorsKeyName, typeStr, fullName)
+		}
+		if err = expandEnvConfig(componentConfig); err != nil {
+			return nil, errorExpandError(connectorsKeyName, fullName, err)
+		}
+
+		// Create the default config for this connector.
+		connectorCfg := factory.CreateDefaultConfig()
+		connectorCfg.SetName(fullName)
+		if err = expandEnvLoadedConfig(connectorCfg); err != nil {
+			return nil, errorExpandError(connectorsKeyName, fullName, err)
+		}
+
+		// Now that the default config struct is created we can Unmarshal into it
+		// and it will apply user-defined config on top of the default.
+		unm := unmarshaler(factory)
+		if err := unm(componentConfig, connectorCfg); err != nil {
+			return nil, errorUnmarshalError(connectorsKeyName, fullName, err)
+		}
+
+		if connectors[fullName] != nil {
+			return nil, errorDuplicateName(connectorsKeyName, fullName)
+		}
+
+		connectors[fullName] = connectorCfg
+	}
+
+	return connectors, nil
+}
+
+func loadPipelines(pipelinesConfig map[string]pipelineSettings, processorChains map[string][]string) (configmodels.Pipelines, error) {
 	// Prepare resulting map.
 	pipelines := make(configmodels.Pipelines)
 
@@ -455,8 +566,15 @@ func loadPipelines(pipelinesConfig map[string]pipelineSettings) (configmodels.Pi
 
 		pipelineCfg.Name = fullName
 		pipelineCfg.Receivers = rawPipeline.Receivers
-		pipelineCfg.Processors = rawPipeline.Processors
+		pipelineCfg.Processors, pipelineCfg.ProcessorChain, err = expandProcessorChains(rawPipeline.Processors, processorChains)
+		if err != nil {
+			return nil, &configError{
+				code: errInvalidProcessorChainReference,
+				msg:  fmt.Sprintf("pipeline %q: %v", fullName, err),
+			}
+		}
 		pipelineCfg.Exporters = rawPipeline.Exporters
+		pipelineCfg.FanoutErrorPolicy = configmodels.FanoutErrorPolicy(rawPipeline.FanoutErrorPolicy)
 
 		if pipelines[fullName] != nil {
 			return nil, errorDuplicateName(pipelinesKeyName, fullName)
@@ -468,85 +586,166 @@ func loadPipelines(pipelinesConfig map[string]pipelineSettings) (configmodels.Pi
 	return pipelines, nil
 }
 
+// expandProcessorChains replaces the reference to a processor chain in the processors of a pipeline by the
+// processors of the chain, it returns the expanded processors and the name of the referenced chain. The processors of
+// a chain are shared by the pipelines referencing it, so the chain must be the last of the processors.
+func expandProcessorChains(processors []string, processorChains map[string][]string) ([]string, string, error) {
+	if len(processorChains) == 0 {
+		return processors, "", nil
+	}
+	for i, name := range processors {
+		chain, ok := processorChains[name]
+		if !ok {
+			continue
+		}
+		if i != len(processors)-1 {
+			return nil, "", fmt.Errorf("processor chain %q must be the last of the processors", name)
+		}
+		expanded := make([]string, 0, i+len(chain))
+		expanded = append(expanded, processors[:i]...)
+		return append(expanded, chain...), name, nil
+	}
+	return processors, "", nil
+}
+
 // expandEnvConfig creates a new viper config with expanded values for all the values (simple, list or map value).
 // It does not expand the keys.
-func expandEnvConfig(v *viper.Viper) {
+func expandEnvConfig(v *viper.Viper) error {
 	for _, k := range v.AllKeys() {
-		v.Set(k, expandStringValues(v.Get(k)))
+		value, err := expandStringValues(v.Get(k))
+		if err != nil {
+			return err
+		}
+		v.Set(k, value)
 	}
+	return nil
 }
 
-func expandStringValues(value interface{}) interface{} {
+func expandStringValues(value interface{}) (interface{}, error) {
 	switch v := value.(type) {
 	default:
-		return v
+		return v, nil
 	case string:
 		return expandEnv(v)
 	case []interface{}:
 		nslice := make([]interface{}, 0, len(v))
 		for _, vint := range v {
-			nslice = append(nslice, expandStringValues(vint))
+			value, err := expandStringValues(vint)
+			if err != nil {
+				return nil, err
+			}
+			nslice = append(nslice, value)
 		}
-		return nslice
+		return nslice, nil
 	case map[interface{}]interface{}:
 		nmap := make(map[interface{}]interface{}, len(v))
 		for k, vint := range v {
-			nmap[k] = expandStringValues(vint)
+			value, err := expandStringValues(vint)
+			if err != nil {
+				return nil, err
+			}
+			nmap[k] = value
 		}
-		return nmap
+		return nmap, nil
 	}
 }
 
 // expandEnvLoadedConfig is a utility function that goes recursively through a config object
 // and tries to expand environment variables in its string fields.
-func expandEnvLoadedConfig(s interface{}) {
-	expandEnvLoadedConfigPointer(s)
+func expandEnvLoadedConfig(s interface{}) error {
+	return expandEnvLoadedConfigPointer(s)
 }
 
-func expandEnvLoadedConfigPointer(s interface{}) {
+func expandEnvLoadedConfigPointer(s interface{}) error {
 	// Check that the value given is indeed a pointer, otherwise safely stop the search here
 	value := reflect.ValueOf(s)
 	if value.Kind() != reflect.Ptr {
-		return
+		return nil
 	}
 	// Run expandLoadedConfigValue on the value behind the pointer
-	expandEnvLoadedConfigValue(value.Elem())
+	return expandEnvLoadedConfigValue(value.Elem())
 }
 
-func expandEnvLoadedConfigValue(value reflect.Value) {
+func expandEnvLoadedConfigValue(value reflect.Value) error {
 	// The value given is a string, we expand it (if allowed)
 	if value.Kind() == reflect.String && value.CanSet() {
-		value.SetString(expandEnv(value.String()))
+		return expandEnvString(value)
 	}
 	// The value given is a struct, we go through its fields
 	if value.Kind() == reflect.Struct {
 		for i := 0; i < value.NumField(); i++ {
 			field := value.Field(i) // Returns the content of the field
-			if field.CanSet() {     // Only try to modify a field if it can be modified (eg. skip unexported private fields)
-				switch field.Kind() {
-				case reflect.String: // The current field is a string, we want to expand it
-					field.SetString(expandEnv(field.String())) // Expand env variables in the string
-				case reflect.Ptr: // The current field is a pointer
-					expandEnvLoadedConfigPointer(field.Interface()) // Run the expansion function on the pointer
-				case reflect.Struct: // The current field is a nested struct
-					expandEnvLoadedConfigValue(field) // Go through the nested struct
-				}
+			if !field.CanSet() {    // Only try to modify a field if it can be modified (eg. skip unexported private fields)
+				continue
+			}
+			var err error
+			switch field.Kind() {
+			case reflect.String: // The current field is a string, we want to expand it
+				err = expandEnvString(field) // Expand env variables in the string
+			case reflect.Ptr: // The current field is a pointer
+				err = expandEnvLoadedConfigPointer(field.Interface()) // Run the expansion function on the pointer
+			case reflect.Struct: // The current field is a nested struct
+				err = expandEnvLoadedConfigValue(field) // Go through the nested struct
+			}
+			if err != nil {
+				return err
 			}
 		}
 	}
+	return nil
+}
+
+func expandEnvString(value reflect.Value) error {
+	expanded, err := expandEnv(value.String())
+	if err != nil {
+		return err
+	}
+	value.SetString(expanded)
+	return nil
 }
 
-func expandEnv(s string) string {
-	return os.Expand(s, func(str string) string {
+// expandEnv expands the env var and file references in s, returning the error of the first file that cannot be read.
+func expandEnv(s string) (string, error) {
+	var err error
+	expanded := os.Expand(s, func(str string) string {
 		// This allows escaping environment variable substitution via $$, e.g.
 		// - $FOO will be substituted with env var FOO
 		// - $$FOO will be replaced with $FOO
 		// - $$$FOO will be replaced with $ + substituted env var FOO
+		// The escape also applies to the references below, $${file:/path} is replaced with ${file:/path}.
 		if str == "$" {
 			return "$"
 		}
-		return os.Getenv(str)
+		// - ${FOO} and ${env:FOO} will be substituted with env var FOO
+		// - ${FOO:-default} and ${env:FOO:-default} will be replaced with default when FOO is not set or empty
+		// - ${file:/path/to/file} will be replaced with the content of the file, without its trailing newline
+		value, expandErr := expandReference(str)
+		if expandErr != nil && err == nil {
+			err = expandErr
+		}
+		return value
 	})
+	return expanded, err
+}
+
+func expandReference(str string) (string, error) {
+	if strings.HasPrefix(str, fileReferencePrefix) {
+		path := strings.TrimPrefix(str, fileReferencePrefix)
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("cannot expand ${%s}: %w", str, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	name := strings.TrimPrefix(str, envReferencePrefix)
+	if i := strings.Index(name, defaultValueSeparator); i >= 0 {
+		if value := os.Getenv(name[:i]); value != "" {
+			return value, nil
+		}
+		return name[i+len(defaultValueSeparator):], nil
+	}
+	return os.Getenv(name), nil
 }
 
 func unmarshaler(factory component.Factory) component.CustomUnmarshaler {
@@ -587,3 +786,46 @@ func viperFromStringMap(data map[string]interface{}) *viper.Viper {
 	_ = v.MergeConfigMap(cast.ToStringMap(data))
 	return v
 }
+
+// ValidateComponentConfigs calls the Validate method of the component configurations implementing
+// configmodels.CustomValidator, the returned error combines the errors of all the invalid configurations.
+func ValidateComponentConfigs(cfg *configmodels.Config) error {
+	var errs []error
+	validate := func(kind string, name string, config interface{}) {
+		if v, ok := config.(configmodels.CustomValidator); ok {
+			if err := v.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("invalid configuration of %s %q: %w", kind, name, err))
+			}
+		}
+	}
+
+	for _, name := range sortedNames(cfg.Receivers) {
+		validate("receiver", name, cfg.Receivers[name])
+	}
+	for _, name := range sortedNames(cfg.Processors) {
+		validate("processor", name, cfg.Processors[name])
+	}
+	for _, name := range sortedNames(cfg.Exporters) {
+		validate("exporter", name, cfg.Exporters[name])
+	}
+	for _, name := range sortedNames(cfg.Connectors) {
+		validate("connector", name, cfg.Connectors[name])
+	}
+	for _, name := range sortedNames(cfg.Extensions) {
+		validate("extension", name, cfg.Extensions[name])
+	}
+
+	return consumererror.Combine(errs)
+}
+
+// sortedNames returns the keys of a map of component configurations sorted, so that the errors are reported in a
+// deterministic order.
+func sortedNames(components interface{}) []string {
+	keys := reflect.ValueOf(components).MapKeys()
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.String())
+	}
+	sort.Strings(names)
+	return names
+}
@@ -15,9 +15,11 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,6 +46,30 @@ func TestDecodeConfig(t *testing.T) {
 	assert.Equal(t, 2, len(config.Service.Extensions))
 	assert.Equal(t, "exampleextension/0", config.Service.Extensions[0])
 	assert.Equal(t, "exampleextension/1", config.Service.Extensions[1])
+	assert.Equal(t, 20*time.Second, config.Service.ShutdownTimeout)
+	assert.Equal(t,
+		configmodels.MetricsTelemetry{
+			Level:   "detailed",
+			Address: "localhost:9999",
+			OTLP: &configmodels.OTLPMetricsTelemetry{
+				OTLPTelemetryEndpoint: configmodels.OTLPTelemetryEndpoint{
+					Endpoint: "localhost:4317",
+					Insecure: true,
+					Headers:  map[string]string{"x-scope": "collector"},
+				},
+				Interval: 30 * time.Second,
+			},
+		},
+		config.Service.Telemetry.Metrics)
+	assert.Equal(t,
+		configmodels.TracesTelemetry{
+			OTLP: &configmodels.OTLPTelemetryEndpoint{
+				Endpoint: "localhost:4317",
+				Insecure: true,
+			},
+			SamplingRatio: 0.5,
+		},
+		config.Service.Telemetry.Traces)
 
 	// Verify receivers
 	assert.Equal(t, 2, len(config.Receivers), "Incorrect receivers count")
@@ -130,6 +156,68 @@ func TestDecodeConfig(t *testing.T) {
 		"Did not load pipeline config correctly")
 }
 
+func TestDecodeConfig_Connectors(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	assert.NoError(t, err)
+
+	// Load the config
+	config, err := loadConfigFile(t, path.Join(".", "testdata", "valid-config-with-connectors.yaml"), factories)
+	require.NoError(t, err, "Unable to load config")
+	require.NoError(t, config.Validate())
+
+	// Verify connectors
+	assert.Equal(t, 2, len(config.Connectors), "Incorrect connectors count")
+	assert.Equal(t,
+		&testcomponents.ExampleConnectorCfg{
+			ConnectorSettings: configmodels.ConnectorSettings{
+				TypeVal: "exampleconnector",
+				NameVal: "exampleconnector",
+			},
+			ExtraSetting: "some connector string",
+		},
+		config.Connectors["exampleconnector"],
+		"Did not load connector config correctly")
+	assert.Equal(t,
+		&testcomponents.ExampleConnectorCfg{
+			ConnectorSettings: configmodels.ConnectorSettings{
+				TypeVal: "exampleconnector",
+				NameVal: "exampleconnector/2",
+			},
+			ExtraSetting: "some other string",
+		},
+		config.Connectors["exampleconnector/2"],
+		"Did not load connector config correctly")
+
+	// Verify the pipelines reference the connectors as exporters and receivers.
+	assert.Equal(t, 3, len(config.Service.Pipelines), "Incorrect pipelines count")
+	assert.Equal(t, []string{"exampleconnector", "exampleconnector/2"}, config.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, configmodels.FanoutErrorPolicyIsolate, config.Service.Pipelines["traces"].FanoutErrorPolicy)
+	assert.Equal(t, []string{"exampleconnector"}, config.Service.Pipelines["traces/forwarded"].Receivers)
+	assert.Equal(t, []string{"exampleconnector/2"}, config.Service.Pipelines["metrics"].Receivers)
+}
+
+func TestDecodeConfig_ProcessorChains(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	assert.NoError(t, err)
+
+	// Load the config
+	config, err := loadConfigFile(t, path.Join(".", "testdata", "valid-config-with-processor-chains.yaml"), factories)
+	require.NoError(t, err, "Unable to load config")
+	require.NoError(t, config.Validate())
+
+	// Verify the references to the chains are replaced by their processors.
+	assert.Equal(t, map[string][]string{"common": {"exampleprocessor", "exampleprocessor/2"}}, config.Service.ProcessorChains)
+	assert.Equal(t, []string{"exampleprocessor", "exampleprocessor/2"}, config.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, "common", config.Service.Pipelines["traces"].ProcessorChain)
+	assert.Equal(t,
+		[]string{"exampleprocessor/2", "exampleprocessor", "exampleprocessor/2"},
+		config.Service.Pipelines["traces/2"].Processors)
+	assert.Equal(t, "common", config.Service.Pipelines["traces/2"].ProcessorChain)
+	assert.Nil(t, config.Service.Pipelines["metrics"].Processors)
+	assert.Empty(t, config.Service.Pipelines["metrics"].ProcessorChain)
+	assert.Equal(t, []string{"example.gate", "-example.other"}, config.Service.FeatureGates)
+}
+
 func TestSimpleConfig(t *testing.T) {
 	var testCases = []struct {
 		name string // test case name (also file name containing config yaml)
@@ -406,6 +494,79 @@ func TestEscapedEnvVars(t *testing.T) {
 		"Did not load pipeline config correctly")
 }
 
+func TestReferences(t *testing.T) {
+	assert.NoError(t, os.Setenv("RECEIVERS_EXAMPLERECEIVER_REFERENCE", "extra value"))
+	assert.NoError(t, os.Setenv("RECEIVERS_EXAMPLERECEIVER_REFERENCE_2", "recv value"))
+	defer func() {
+		assert.NoError(t, os.Unsetenv("RECEIVERS_EXAMPLERECEIVER_REFERENCE"))
+		assert.NoError(t, os.Unsetenv("RECEIVERS_EXAMPLERECEIVER_REFERENCE_2"))
+	}()
+
+	factories, err := testcomponents.ExampleComponents()
+	assert.NoError(t, err)
+
+	config, err := loadConfigFile(t, path.Join(".", "testdata", "simple-config-with-references.yaml"), factories)
+	require.NoError(t, err, "Unable to load config")
+
+	assert.Equal(t,
+		&testcomponents.ExampleReceiver{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: "examplereceiver",
+				NameVal: "examplereceiver",
+			},
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "localhost:1234",
+			},
+			ExtraSetting: "extra value",
+			ExtraMapSetting: map[string]string{
+				"recv.1": "default",
+				"recv.2": "recv value",
+				"recv.3": "s3cr3t",
+				"recv.4": "${file:testdata/secret.txt}",
+				"recv.5": "beforeafter",
+			},
+			ExtraListSetting: []string{"Bearer s3cr3t"},
+		},
+		config.Receivers["examplereceiver"],
+		"Did not load receiver config correctly")
+}
+
+func TestExpandEnv(t *testing.T) {
+	assert.NoError(t, os.Setenv("EXPAND_ENV_VALUE", "value"))
+	defer func() {
+		assert.NoError(t, os.Unsetenv("EXPAND_ENV_VALUE"))
+	}()
+
+	tests := []struct {
+		in       string
+		expected string
+		err      bool
+	}{
+		{in: "$EXPAND_ENV_VALUE", expected: "value"},
+		{in: "${EXPAND_ENV_VALUE}", expected: "value"},
+		{in: "${env:EXPAND_ENV_VALUE}", expected: "value"},
+		{in: "${env:EXPAND_ENV_VALUE:-default}", expected: "value"},
+		{in: "${env:EXPAND_ENV_MISSING:-default}", expected: "default"},
+		{in: "${EXPAND_ENV_MISSING:-default:with:colons}", expected: "default:with:colons"},
+		{in: "${env:EXPAND_ENV_MISSING}", expected: ""},
+		{in: "$${env:EXPAND_ENV_VALUE}", expected: "${env:EXPAND_ENV_VALUE}"},
+		{in: "${file:testdata/secret.txt}", expected: "s3cr3t"},
+		{in: "$${file:testdata/secret.txt}", expected: "${file:testdata/secret.txt}"},
+		{in: "${file:testdata/missing-secret.txt}", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			out, err := expandEnv(test.in)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, out)
+		})
+	}
+}
+
 func TestDecodeConfig_Invalid(t *testing.T) {
 
 	var testCases = []struct {
@@ -430,12 +591,14 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 		{name: "unknown-exporter-type", expected: errUnknownType, expectedMessage: "exporters"},
 		{name: "unknown-processor-type", expected: errUnknownType, expectedMessage: "processors"},
 		{name: "unknown-pipeline-type", expected: errUnknownType, expectedMessage: "pipelines"},
+		{name: "unknown-connector-type", expected: errUnknownType, expectedMessage: "connectors"},
 
 		{name: "duplicate-extension", expected: errDuplicateName, expectedMessage: "extensions"},
 		{name: "duplicate-receiver", expected: errDuplicateName, expectedMessage: "receivers"},
 		{name: "duplicate-exporter", expected: errDuplicateName, expectedMessage: "exporters"},
 		{name: "duplicate-processor", expected: errDuplicateName, expectedMessage: "processors"},
 		{name: "duplicate-pipeline", expected: errDuplicateName, expectedMessage: "pipelines"},
+		{name: "duplicate-connector", expected: errDuplicateName, expectedMessage: "connectors"},
 
 		{name: "invalid-top-level-section", expected: errUnmarshalTopLevelStructureError, expectedMessage: "top level"},
 		{name: "invalid-extension-section", expected: errUnmarshalTopLevelStructureError, expectedMessage: "extensions"},
@@ -452,6 +615,10 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 		{name: "invalid-processor-sub-config", expected: errUnmarshalTopLevelStructureError},
 		{name: "invalid-receiver-sub-config", expected: errUnmarshalTopLevelStructureError},
 		{name: "invalid-pipeline-sub-config", expected: errUnmarshalTopLevelStructureError},
+
+		{name: "invalid-file-reference", expected: errExpandValues, expectedMessage: "missing-secret.txt"},
+
+		{name: "invalid-processor-chain-not-last", expected: errInvalidProcessorChainReference, expectedMessage: "must be the last"},
 	}
 
 	factories, err := testcomponents.ExampleComponents()
@@ -726,3 +893,34 @@ func TestExpandEnvLoadedConfigUnexportedField(t *testing.T) {
 		ExportedStringValue:   "replaced_value",
 	}, config)
 }
+
+type validatableExporterConfig struct {
+	configmodels.ExporterSettings
+	err error
+}
+
+func (cfg *validatableExporterConfig) Validate() error {
+	return cfg.err
+}
+
+func TestValidateComponentConfigs(t *testing.T) {
+	cfg := &configmodels.Config{
+		Receivers: configmodels.Receivers{
+			"nop": &configmodels.ReceiverSettings{TypeVal: "nop", NameVal: "nop"},
+		},
+		Exporters: configmodels.Exporters{
+			"valid":     &validatableExporterConfig{ExporterSettings: configmodels.ExporterSettings{TypeVal: "test", NameVal: "valid"}},
+			"invalid/2": &validatableExporterConfig{err: errors.New("second error")},
+			"invalid/1": &validatableExporterConfig{err: errors.New("first error")},
+		},
+	}
+
+	err := ValidateComponentConfigs(cfg)
+	require.Error(t, err)
+	assert.Equal(t, `[invalid configuration of exporter "invalid/1": first error; `+
+		`invalid configuration of exporter "invalid/2": second error]`, err.Error())
+
+	delete(cfg.Exporters, "invalid/1")
+	delete(cfg.Exporters, "invalid/2")
+	assert.NoError(t, ValidateComponentConfigs(cfg))
+}
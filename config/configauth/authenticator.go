@@ -21,6 +21,8 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/client"
 )
 
 var (
@@ -74,7 +76,8 @@ func defaultUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.Unary
 		return nil, err
 	}
 
-	return handler(ctx, req)
+	c, _ := client.FromGRPC(ctx)
+	return handler(contextWithClient(ctx, c), req)
 }
 
 func defaultStreamInterceptor(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler, authenticate authenticateFunc) error {
@@ -84,11 +87,33 @@ func defaultStreamInterceptor(srv interface{}, stream grpc.ServerStream, _ *grpc
 		return errMetadataNotFound
 	}
 
-	// TODO: how to replace the context from the stream?
-	_, err := authenticate(ctx, headers)
+	ctx, err := authenticate(ctx, headers)
 	if err != nil {
 		return err
 	}
 
-	return handler(srv, stream)
+	c, _ := client.FromGRPC(ctx)
+	return handler(srv, &authenticatedServerStream{ServerStream: stream, ctx: contextWithClient(ctx, c)})
+}
+
+// authenticatedServerStream replaces the context of the stream with the one returned by the authenticator.
+type authenticatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// contextWithClient stores the identity authenticated on the context in a copy of the client, so it is propagated to
+// the pipeline with the data received from the client.
+func contextWithClient(ctx context.Context, c *client.Client) context.Context {
+	authenticated := &client.Client{}
+	if c != nil {
+		*authenticated = *c
+	}
+	authenticated.Subject, _ = SubjectFromContext(ctx)
+	authenticated.Groups, _ = GroupsFromContext(ctx)
+	return client.NewContext(ctx, authenticated)
 }
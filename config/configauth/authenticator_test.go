@@ -22,6 +22,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/client"
 )
 
 func TestNewAuthenticator(t *testing.T) {
@@ -185,6 +187,33 @@ func TestDefaultStreamInterceptorMissingMetadata(t *testing.T) {
 	assert.Equal(t, errMetadataNotFound, err)
 }
 
+func TestDefaultInterceptorsPropagateIdentity(t *testing.T) {
+	// prepare
+	authFunc := func(ctx context.Context, _ map[string][]string) (context.Context, error) {
+		return NewContext(ctx, "my-subject", []string{"my-group"}), nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "some-auth-data"))
+	ctx = client.NewContext(ctx, &client.Client{IP: "1.2.3.4"})
+	expected := &client.Client{IP: "1.2.3.4", Subject: "my-subject", Groups: []string{"my-group"}}
+
+	// test
+	_, err := defaultUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		c, ok := client.FromGRPC(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, expected, c)
+		return nil, nil
+	}, authFunc)
+	assert.NoError(t, err)
+
+	err = defaultStreamInterceptor(nil, &mockServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		c, ok := client.FromGRPC(stream.Context())
+		assert.True(t, ok)
+		assert.Equal(t, expected, c)
+		return nil
+	}, authFunc)
+	assert.NoError(t, err)
+}
+
 type mockServerStream struct {
 	grpc.ServerStream
 	ctx context.Context
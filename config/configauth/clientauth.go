@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// ClientAuthentication defines the auth settings for the exporter.
+type ClientAuthentication struct {
+	// AuthenticatorName is the full name of the extension providing the ClientAuthenticator used by the exporter, for
+	// instance "oauth2client".
+	AuthenticatorName string `mapstructure:"authenticator"`
+}
+
+// ClientAuthenticator is implemented by the extensions adding authentication data to the requests sent by the
+// exporters. The extensions register themselves with RegisterClientAuthenticator when they start, under their full
+// name, and the exporters refer to them by this name in their "authenticator" setting.
+type ClientAuthenticator interface {
+	// RoundTripper returns a round tripper adding the authentication data to the HTTP requests sent with base.
+	RoundTripper(base http.RoundTripper) http.RoundTripper
+
+	// PerRPCCredentials returns the credentials adding the authentication data to the gRPC calls.
+	PerRPCCredentials() credentials.PerRPCCredentials
+}
+
+// TokenProvider is implemented by the ClientAuthenticators able to provide their access token directly, for the
+// components authenticating outside of HTTP and gRPC, like with the OAUTHBEARER SASL mechanism of Kafka.
+type TokenProvider interface {
+	// Token returns the current access token, fetching a new one when it expired.
+	Token() (string, error)
+}
+
+var clientAuthenticators = newRegistry()
+
+// RegisterClientAuthenticator makes the authenticator available to the exporters under the given name.
+func RegisterClientAuthenticator(name string, authenticator ClientAuthenticator) error {
+	return clientAuthenticators.register(name, authenticator)
+}
+
+// UnregisterClientAuthenticator removes the authenticator registered under the given name.
+func UnregisterClientAuthenticator(name string) {
+	clientAuthenticators.unregister(name)
+}
+
+func getClientAuthenticator(name string) (ClientAuthenticator, error) {
+	authenticator, err := clientAuthenticators.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return authenticator.(ClientAuthenticator), nil
+}
+
+// GetTokenProvider returns the TokenProvider of the authenticator registered under the given name, the components
+// should call it every time they need a token as the extension providing it can start after them.
+func GetTokenProvider(name string) (TokenProvider, error) {
+	authenticator, err := getClientAuthenticator(name)
+	if err != nil {
+		return nil, err
+	}
+	provider, ok := authenticator.(TokenProvider)
+	if !ok {
+		return nil, fmt.Errorf("authenticator %q does not provide access tokens", name)
+	}
+	return provider, nil
+}
+
+// ToRoundTripper wraps the round tripper so the requests are authenticated. The authenticator is looked up on every
+// request as the extension providing it starts after the exporter is created, the requests fail while no
+// authenticator is registered.
+func (a *ClientAuthentication) ToRoundTripper(base http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		authenticator, err := getClientAuthenticator(a.AuthenticatorName)
+		if err != nil {
+			return nil, err
+		}
+		return authenticator.RoundTripper(base).RoundTrip(req)
+	})
+}
+
+// ToPerRPCCredentials returns the credentials authenticating the gRPC calls, the authenticator is looked up on every
+// call like for ToRoundTripper.
+func (a *ClientAuthentication) ToPerRPCCredentials() credentials.PerRPCCredentials {
+	return &registeredPerRPCCredentials{name: a.AuthenticatorName}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type registeredPerRPCCredentials struct {
+	name string
+}
+
+func (c *registeredPerRPCCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	authenticator, err := getClientAuthenticator(c.name)
+	if err != nil {
+		return nil, err
+	}
+	return authenticator.PerRPCCredentials().GetRequestMetadata(ctx, uri...)
+}
+
+// RequireTransportSecurity always returns true, passing credentials in plain-text connections is a bad idea.
+func (c *registeredPerRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+)
+
+type mockClientAuthenticator struct{}
+
+func (mockClientAuthenticator) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("Authorization", "Bearer token")
+		return base.RoundTrip(req)
+	})
+}
+
+func (mockClientAuthenticator) PerRPCCredentials() credentials.PerRPCCredentials {
+	return &mockPerRPCCredentials{}
+}
+
+type mockPerRPCCredentials struct{}
+
+func (*mockPerRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer token"}, nil
+}
+
+func (*mockPerRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+func TestClientAuthenticationRoundTripper(t *testing.T) {
+	// prepare
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+	httpClient := &http.Client{Transport: (&ClientAuthentication{AuthenticatorName: "mock/client"}).ToRoundTripper(http.DefaultTransport)}
+
+	// test
+	_, err := httpClient.Get(server.URL)
+
+	// verify
+	assert.Error(t, err)
+	assert.Empty(t, authorization)
+
+	// test
+	require.NoError(t, RegisterClientAuthenticator("mock/client", mockClientAuthenticator{}))
+	defer UnregisterClientAuthenticator("mock/client")
+	assert.True(t, errors.Is(RegisterClientAuthenticator("mock/client", mockClientAuthenticator{}), errAuthenticatorAlreadyRegistered))
+	resp, err := httpClient.Get(server.URL)
+
+	// verify
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "Bearer token", authorization)
+}
+
+func TestClientAuthenticationPerRPCCredentials(t *testing.T) {
+	// prepare
+	creds := (&ClientAuthentication{AuthenticatorName: "mock/rpc"}).ToPerRPCCredentials()
+	assert.True(t, creds.RequireTransportSecurity())
+
+	// test
+	_, err := creds.GetRequestMetadata(context.Background())
+
+	// verify
+	assert.EqualError(t, err, `authenticator "mock/rpc" not found`)
+
+	// test
+	require.NoError(t, RegisterClientAuthenticator("mock/rpc", mockClientAuthenticator{}))
+	defer UnregisterClientAuthenticator("mock/rpc")
+	md, err := creds.GetRequestMetadata(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token"}, md)
+}
+
+type mockTokenProvider struct {
+	mockClientAuthenticator
+}
+
+func (mockTokenProvider) Token() (string, error) {
+	return "token", nil
+}
+
+func TestGetTokenProvider(t *testing.T) {
+	_, err := GetTokenProvider("mock/token")
+	assert.EqualError(t, err, `authenticator "mock/token" not found`)
+
+	require.NoError(t, RegisterClientAuthenticator("mock/token", mockTokenProvider{}))
+	defer UnregisterClientAuthenticator("mock/token")
+	provider, err := GetTokenProvider("mock/token")
+	require.NoError(t, err)
+	token, err := provider.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	require.NoError(t, RegisterClientAuthenticator("mock/notoken", mockClientAuthenticator{}))
+	defer UnregisterClientAuthenticator("mock/notoken")
+	_, err = GetTokenProvider("mock/notoken")
+	assert.EqualError(t, err, `authenticator "mock/notoken" does not provide access tokens`)
+}
@@ -22,11 +22,15 @@ import (
 
 // Authentication defines the auth settings for the receiver
 type Authentication struct {
+	// AuthenticatorName is the full name of the extension providing the ServerAuthenticator used by the receiver, for
+	// instance "oidc" or "bearertokenauth/internal". When set, the attribute and OIDC settings below are ignored.
+	AuthenticatorName string `mapstructure:"authenticator"`
+
 	// The attribute (header name) to look for auth data. Optional, default value: "authentication".
 	Attribute string `mapstructure:"attribute"`
 
 	// OIDC configures this receiver to use the given OIDC provider as the backend for the authentication mechanism.
-	// Required.
+	// Required unless an authenticator is set.
 	OIDC *OIDC `mapstructure:"oidc"`
 }
 
@@ -56,6 +60,18 @@ type OIDC struct {
 
 // ToServerOptions builds a set of server options ready to be used by the gRPC server
 func (a *Authentication) ToServerOptions() ([]grpc.ServerOption, error) {
+	if a.AuthenticatorName != "" {
+		authenticate := registeredAuthenticateFunc(a.AuthenticatorName)
+		return []grpc.ServerOption{
+			grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return defaultUnaryInterceptor(ctx, req, info, handler, authenticate)
+			}),
+			grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return defaultStreamInterceptor(srv, stream, info, handler, authenticate)
+			}),
+		}, nil
+	}
+
 	auth, err := NewAuthenticator(*a)
 	if err != nil {
 		return nil, err
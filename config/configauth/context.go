@@ -24,6 +24,12 @@ var (
 type subjectType struct{}
 type groupsType struct{}
 
+// NewContext returns a context holding the subject authenticated by an authenticator and the groups it belongs to.
+func NewContext(ctx context.Context, subject string, groups []string) context.Context {
+	ctx = context.WithValue(ctx, subjectKey, subject)
+	return context.WithValue(ctx, groupsKey, groups)
+}
+
 // SubjectFromContext returns a list of groups the subject in the context belongs to
 func SubjectFromContext(ctx context.Context) (string, bool) {
 	value, ok := ctx.Value(subjectKey).(string)
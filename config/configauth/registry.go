@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var errAuthenticatorAlreadyRegistered = errors.New("authenticator already registered")
+
+// registry holds the authenticators registered by the extensions, by the full name of the extension.
+type registry struct {
+	mu             sync.RWMutex
+	authenticators map[string]interface{}
+}
+
+func newRegistry() *registry {
+	return &registry{authenticators: map[string]interface{}{}}
+}
+
+func (r *registry) register(name string, authenticator interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.authenticators[name]; ok {
+		return fmt.Errorf("%w: %q", errAuthenticatorAlreadyRegistered, name)
+	}
+	r.authenticators[name] = authenticator
+	return nil
+}
+
+func (r *registry) unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.authenticators, name)
+}
+
+func (r *registry) get(name string) (interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	authenticator, ok := r.authenticators[name]
+	if !ok {
+		return nil, fmt.Errorf("authenticator %q not found", name)
+	}
+	return authenticator, nil
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// ServerAuthenticator is implemented by the extensions providing an authentication mechanism to the receivers. The
+// extensions register themselves with RegisterServerAuthenticator when they start, under their full name, and the
+// receivers refer to them by this name in their "authenticator" setting.
+type ServerAuthenticator interface {
+	// Authenticate checks whether the headers of the request/RPC hold valid auth data. The context holds the peer of
+	// the request, including its TLS information, and the keys of the headers are lowercase. Successfully authenticated calls return a nil error and a context
+	// holding the authenticated identity, see NewContext.
+	Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error)
+}
+
+var serverAuthenticators = newRegistry()
+
+// RegisterServerAuthenticator makes the authenticator available to the receivers under the given name.
+func RegisterServerAuthenticator(name string, authenticator ServerAuthenticator) error {
+	return serverAuthenticators.register(name, authenticator)
+}
+
+// UnregisterServerAuthenticator removes the authenticator registered under the given name.
+func UnregisterServerAuthenticator(name string) {
+	serverAuthenticators.unregister(name)
+}
+
+// registeredAuthenticateFunc returns a function authenticating with the authenticator registered under the given
+// name. The authenticator is looked up on every call as the extension providing it starts after the receiver is
+// created, the calls fail while no authenticator is registered.
+func registeredAuthenticateFunc(name string) authenticateFunc {
+	return func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		authenticator, err := serverAuthenticators.get(name)
+		if err != nil {
+			return ctx, err
+		}
+		return authenticator.(ServerAuthenticator).Authenticate(ctx, headers)
+	}
+}
+
+// ToHTTPHandler wraps the handler so the requests are authenticated before being handled, the identity is
+// propagated with the client stored on the request context. Only the authenticators provided by extensions are
+// supported for HTTP servers.
+func (a *Authentication) ToHTTPHandler(next http.Handler) http.Handler {
+	authenticate := registeredAuthenticateFunc(a.AuthenticatorName)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if r.TLS != nil {
+			// The TLS information is stored the same way as for gRPC so the authenticators handle both alike.
+			ctx = peer.NewContext(ctx, &peer.Peer{
+				Addr:     remoteAddr(r.RemoteAddr),
+				AuthInfo: credentials.TLSInfo{State: *r.TLS},
+			})
+		}
+
+		headers := make(map[string][]string, len(r.Header))
+		for k, v := range r.Header {
+			headers[strings.ToLower(k)] = v
+		}
+		ctx, err := authenticate(ctx, headers)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		c, _ := client.FromHTTP(r)
+		next.ServeHTTP(w, r.WithContext(contextWithClient(ctx, c)))
+	})
+}
+
+// remoteAddr is the net.Addr of the remote address of an HTTP request.
+type remoteAddr string
+
+var _ net.Addr = remoteAddr("")
+
+func (a remoteAddr) Network() string {
+	return "tcp"
+}
+
+func (a remoteAddr) String() string {
+	return string(a)
+}
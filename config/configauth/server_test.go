@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+type mockServerAuthenticator struct {
+	authenticate authenticateFunc
+}
+
+func (m *mockServerAuthenticator) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	return m.authenticate(ctx, headers)
+}
+
+func TestRegisterServerAuthenticator(t *testing.T) {
+	// prepare
+	authenticator := &mockServerAuthenticator{
+		authenticate: func(ctx context.Context, _ map[string][]string) (context.Context, error) {
+			return NewContext(ctx, "my-subject", nil), nil
+		},
+	}
+	authenticate := registeredAuthenticateFunc("mock/register")
+
+	// test
+	_, err := authenticate(context.Background(), nil)
+	assert.EqualError(t, err, `authenticator "mock/register" not found`)
+
+	require.NoError(t, RegisterServerAuthenticator("mock/register", authenticator))
+	assert.True(t, errors.Is(RegisterServerAuthenticator("mock/register", authenticator), errAuthenticatorAlreadyRegistered))
+
+	ctx, err := authenticate(context.Background(), nil)
+	assert.NoError(t, err)
+	subject, ok := SubjectFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "my-subject", subject)
+
+	UnregisterServerAuthenticator("mock/register")
+	_, err = authenticate(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestToServerOptionsWithAuthenticator(t *testing.T) {
+	// test
+	opts, err := (&Authentication{AuthenticatorName: "mock"}).ToServerOptions()
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, opts, 2)
+}
+
+func TestToHTTPHandler(t *testing.T) {
+	// prepare
+	authenticator := &mockServerAuthenticator{
+		authenticate: func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+			if len(headers["authorization"]) != 1 || headers["authorization"][0] != "Bearer token" {
+				return ctx, errNotAuthenticated
+			}
+			p, ok := peer.FromContext(ctx)
+			require.True(t, ok)
+			tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+			require.True(t, ok)
+			return NewContext(ctx, tlsInfo.State.ServerName, []string{"my-group"}), nil
+		},
+	}
+	require.NoError(t, RegisterServerAuthenticator("mock/http", authenticator))
+	defer UnregisterServerAuthenticator("mock/http")
+
+	var handled *client.Client
+	handler := (&Authentication{AuthenticatorName: "mock/http"}).ToHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled, _ = client.FromHTTP(r)
+	}))
+
+	// test
+	req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+	req.RemoteAddr = "1.2.3.4:5678"
+	req.TLS = &tls.ConnectionState{ServerName: "my-subject"}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// verify
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Nil(t, handled)
+
+	// test
+	req.Header.Set("Authorization", "Bearer token")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// verify
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, &client.Client{IP: "1.2.3.4", Subject: "my-subject", Groups: []string{"my-group"}}, handled)
+}
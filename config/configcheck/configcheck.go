@@ -49,6 +49,9 @@ func ValidateConfigFromFactories(factories component.Factories) error {
 	for _, factory := range factories.Extensions {
 		configs = append(configs, factory.CreateDefaultConfig())
 	}
+	for _, factory := range factories.Connectors {
+		configs = append(configs, factory.CreateDefaultConfig())
+	}
 
 	for _, config := range configs {
 		if err := ValidateConfig(config); err != nil {
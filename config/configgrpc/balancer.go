@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+// LeastRequestBalancerName is the name of the balancer sending every RPC to the server with the fewest RPCs in
+// progress, so that the slower servers receive less load than with round_robin.
+const LeastRequestBalancerName = "least_request"
+
+func init() {
+	balancer.Register(leastRequestBuilder{})
+}
+
+type leastRequestBuilder struct{}
+
+func (leastRequestBuilder) Name() string {
+	return LeastRequestBalancerName
+}
+
+func (leastRequestBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	// Every client connection has its own picker builder, the RPCs in progress are counted per client connection.
+	pb := &leastRequestPickerBuilder{pending: map[balancer.SubConn]*int64{}}
+	return base.NewBalancerBuilder(LeastRequestBalancerName, pb, base.Config{HealthCheck: true}).Build(cc, opts)
+}
+
+type leastRequestPickerBuilder struct {
+	mu sync.Mutex
+	// pending are the counters of the RPCs in progress of the ready SubConns, kept across the pickers.
+	pending map[balancer.SubConn]*int64
+}
+
+func (b *leastRequestPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p := &leastRequestPicker{}
+	pending := make(map[balancer.SubConn]*int64, len(info.ReadySCs))
+	for sc := range info.ReadySCs {
+		counter, ok := b.pending[sc]
+		if !ok {
+			counter = new(int64)
+		}
+		pending[sc] = counter
+		p.subConns = append(p.subConns, sc)
+		p.pending = append(p.pending, counter)
+	}
+	// The SubConns not ready anymore are forgotten, their RPCs in progress decrement the counters referenced
+	// by the previous pickers.
+	b.pending = pending
+	// Start at a random index so that the first server in the list is not favored when the counters are equal.
+	p.next = rand.Intn(len(p.subConns))
+	return p
+}
+
+type leastRequestPicker struct {
+	// subConns and pending are immutable, pending[i] counts the RPCs in progress of subConns[i].
+	subConns []balancer.SubConn
+	pending  []*int64
+
+	mu   sync.Mutex
+	next int
+}
+
+func (p *leastRequestPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	p.mu.Lock()
+	picked := p.next
+	for i := 1; i < len(p.subConns); i++ {
+		idx := (p.next + i) % len(p.subConns)
+		if atomic.LoadInt64(p.pending[idx]) < atomic.LoadInt64(p.pending[picked]) {
+			picked = idx
+		}
+	}
+	p.next = (p.next + 1) % len(p.subConns)
+	p.mu.Unlock()
+
+	counter := p.pending[picked]
+	atomic.AddInt64(counter, 1)
+	return balancer.PickResult{
+		SubConn: p.subConns[picked],
+		Done: func(balancer.DoneInfo) {
+			atomic.AddInt64(counter, -1)
+		},
+	}, nil
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	otelcol "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
+)
+
+type fakeSubConn struct {
+	name string
+}
+
+func (*fakeSubConn) UpdateAddresses([]resolver.Address) {}
+
+func (*fakeSubConn) Connect() {}
+
+func TestLeastRequestBalancerRegistered(t *testing.T) {
+	assert.NotNil(t, balancer.Get(LeastRequestBalancerName))
+	assert.True(t, validateBalancerName(LeastRequestBalancerName))
+}
+
+func TestLeastRequestPicker(t *testing.T) {
+	sc1, sc2, sc3 := &fakeSubConn{"sc1"}, &fakeSubConn{"sc2"}, &fakeSubConn{"sc3"}
+	pb := &leastRequestPickerBuilder{pending: map[balancer.SubConn]*int64{}}
+	p := pb.Build(base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{
+		sc1: {}, sc2: {}, sc3: {},
+	}})
+
+	// Every server receives an RPC before any receives a second one.
+	picked := map[balancer.SubConn]balancer.PickResult{}
+	for i := 0; i < 3; i++ {
+		res, err := p.Pick(balancer.PickInfo{})
+		require.NoError(t, err)
+		picked[res.SubConn] = res
+	}
+	assert.Len(t, picked, 3)
+
+	// The server having completed its RPC is picked, whatever the order.
+	picked[sc2].Done(balancer.DoneInfo{})
+	for i := 0; i < 3; i++ {
+		res, err := p.Pick(balancer.PickInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, sc2, res.SubConn)
+		res.Done(balancer.DoneInfo{})
+	}
+
+	// The RPCs in progress are still counted by the pickers built when the servers change.
+	p = pb.Build(base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{
+		sc1: {}, sc2: {},
+	}})
+	res, err := p.Pick(balancer.PickInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, sc2, res.SubConn)
+	assert.Len(t, pb.pending, 2)
+}
+
+func TestLeastRequestPickerNoSubConn(t *testing.T) {
+	pb := &leastRequestPickerBuilder{pending: map[balancer.SubConn]*int64{}}
+	p := pb.Build(base.PickerBuildInfo{})
+	_, err := p.Pick(balancer.PickInfo{})
+	assert.Equal(t, balancer.ErrNoSubConnAvailable, err)
+}
+
+type countingTraceServer struct {
+	exports int64
+}
+
+func (s *countingTraceServer) Export(context.Context, *otelcol.ExportTraceServiceRequest) (*otelcol.ExportTraceServiceResponse, error) {
+	atomic.AddInt64(&s.exports, 1)
+	return &otelcol.ExportTraceServiceResponse{}, nil
+}
+
+func TestLeastRequestBalancer(t *testing.T) {
+	var addrs []resolver.Address
+	var servers []*countingTraceServer
+	for i := 0; i < 2; i++ {
+		ln, err := net.Listen("tcp", "localhost:0")
+		require.NoError(t, err)
+		s := grpc.NewServer()
+		ts := &countingTraceServer{}
+		otelcol.RegisterTraceServiceServer(s, ts)
+		go func() {
+			_ = s.Serve(ln)
+		}()
+		defer s.Stop()
+		addrs = append(addrs, resolver.Address{Addr: ln.Addr().String()})
+		servers = append(servers, ts)
+	}
+
+	r := manual.NewBuilderWithScheme("test")
+	r.InitialState(resolver.State{Addresses: addrs})
+	gcs := &GRPCClientSettings{
+		Endpoint: "test:///servers",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		BalancerName: LeastRequestBalancerName,
+	}
+	opts, err := gcs.ToDialOptions()
+	require.NoError(t, err)
+	grpcClientConn, err := grpc.Dial(gcs.Endpoint, append(opts, grpc.WithResolvers(r))...)
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+	client := otelcol.NewTraceServiceClient(grpcClientConn)
+
+	// The RPCs are spread across both servers once they are ready.
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFunc()
+	assert.Eventually(t, func() bool {
+		_, err := client.Export(ctx, &otelcol.ExportTraceServiceRequest{}, grpc.WaitForReady(true))
+		require.NoError(t, err)
+		return atomic.LoadInt64(&servers[0].exports) > 0 && atomic.LoadInt64(&servers[1].exports) > 0
+	}, 5*time.Second, time.Millisecond)
+}
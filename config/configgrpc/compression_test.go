@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/internal"
+	otelcol "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+var compressions = []string{CompressionGzip, CompressionZstd, CompressionSnappy}
+
+func testPayload(t testing.TB) []byte {
+	req := internal.TracesToOtlp(testdata.GenerateTraceDataManySpansSameResource(100).InternalRep())
+	payload, err := req.Marshal()
+	require.NoError(t, err)
+	return payload
+}
+
+func TestCompressorRoundTrip(t *testing.T) {
+	payload := testPayload(t)
+	for _, compression := range compressions {
+		t.Run(compression, func(t *testing.T) {
+			compressor := encoding.GetCompressor(GetGRPCCompressionKey(compression))
+			require.NotNil(t, compressor)
+			assert.Equal(t, compression, compressor.Name())
+
+			// Run several times to reuse the pooled writers and readers.
+			for i := 0; i < 3; i++ {
+				buf := &bytes.Buffer{}
+				w, err := compressor.Compress(buf)
+				require.NoError(t, err)
+				_, err = w.Write(payload)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+				assert.Less(t, buf.Len(), len(payload))
+
+				r, err := compressor.Decompress(buf)
+				require.NoError(t, err)
+				got, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, payload, got)
+			}
+		})
+	}
+}
+
+func TestZstdDecompressBomb(t *testing.T) {
+	// The zeros compress to a few KiB, the streaming encoder does not record
+	// the decompressed size in the frame.
+	bomb := &bytes.Buffer{}
+	encoder, err := zstd.NewWriter(bomb)
+	require.NoError(t, err)
+	_, err = io.Copy(encoder, io.LimitReader(zeroReader{}, zstdMaxDecodedSize+1<<20))
+	require.NoError(t, err)
+	require.NoError(t, encoder.Close())
+	require.Less(t, bomb.Len(), 1<<20)
+
+	compressor := encoding.GetCompressor(zstdName)
+	_, err = compressor.Decompress(bytes.NewReader(bomb.Bytes()))
+	assert.Equal(t, zstd.ErrDecoderSizeExceeded, err)
+}
+
+// zeroReader reads an infinite stream of zeros.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+type recordingTraceServer struct {
+	spans int
+}
+
+func (rts *recordingTraceServer) Export(_ context.Context, req *otelcol.ExportTraceServiceRequest) (*otelcol.ExportTraceServiceResponse, error) {
+	for _, rs := range req.ResourceSpans {
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			rts.spans += len(ils.Spans)
+		}
+	}
+	return &otelcol.ExportTraceServiceResponse{}, nil
+}
+
+func TestCompressionReception(t *testing.T) {
+	for _, compression := range compressions {
+		t.Run(compression, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "localhost:0")
+			require.NoError(t, err)
+			gss := &GRPCServerSettings{}
+			opts, err := gss.ToServerOption()
+			require.NoError(t, err)
+			s := grpc.NewServer(opts...)
+			server := &recordingTraceServer{}
+			otelcol.RegisterTraceServiceServer(s, server)
+			go func() {
+				_ = s.Serve(ln)
+			}()
+			defer s.Stop()
+
+			gcs := &GRPCClientSettings{
+				Endpoint:    ln.Addr().String(),
+				Compression: compression,
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+			}
+			clientOpts, err := gcs.ToDialOptions()
+			require.NoError(t, err)
+			grpcClientConn, err := grpc.Dial(gcs.Endpoint, clientOpts...)
+			require.NoError(t, err)
+			defer grpcClientConn.Close()
+
+			ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancelFunc()
+			req := internal.TracesToOtlp(testdata.GenerateTraceDataManySpansSameResource(10).InternalRep())
+			_, err = otelcol.NewTraceServiceClient(grpcClientConn).Export(ctx, req, grpc.WaitForReady(true))
+			require.NoError(t, err)
+			assert.Equal(t, 10, server.spans)
+		})
+	}
+}
+
+// BenchmarkCompression compares the CPU cost of the compressions, the
+// compressed size being reported as a percentage of the payload size.
+func BenchmarkCompression(b *testing.B) {
+	payload := testPayload(b)
+	for _, compression := range compressions {
+		compressor := encoding.GetCompressor(GetGRPCCompressionKey(compression))
+		b.Run(fmt.Sprintf("%s/compress", compression), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(payload)))
+			buf := &bytes.Buffer{}
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				w, _ := compressor.Compress(buf)
+				_, _ = w.Write(payload)
+				_ = w.Close()
+			}
+			b.ReportMetric(float64(100*buf.Len())/float64(len(payload)), "%size")
+		})
+
+		buf := &bytes.Buffer{}
+		w, _ := compressor.Compress(buf)
+		_, _ = w.Write(payload)
+		_ = w.Close()
+		compressed := buf.Bytes()
+		b.Run(fmt.Sprintf("%s/decompress", compression), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(payload)))
+			for i := 0; i < b.N; i++ {
+				r, _ := compressor.Decompress(bytes.NewReader(compressed))
+				_, _ = ioutil.ReadAll(r)
+			}
+		})
+	}
+}
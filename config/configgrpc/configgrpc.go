@@ -16,6 +16,7 @@
 package configgrpc
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
 
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -36,6 +38,8 @@ import (
 const (
 	CompressionUnsupported = ""
 	CompressionGzip        = "gzip"
+	CompressionZstd        = "zstd"
+	CompressionSnappy      = "snappy"
 
 	PerRPCAuthTypeBearer = "bearer"
 )
@@ -43,12 +47,14 @@ const (
 var (
 	// Map of opentelemetry compression types to grpc registered compression types
 	grpcCompressionKeyMap = map[string]string{
-		CompressionGzip: gzip.Name,
+		CompressionGzip:   gzip.Name,
+		CompressionZstd:   zstdName,
+		CompressionSnappy: snappyName,
 	}
 )
 
 // Allowed balancer names to be set in grpclb_policy to discover the servers
-var allowedBalancerNames = []string{roundrobin.Name, grpc.PickFirstBalancerName}
+var allowedBalancerNames = []string{roundrobin.Name, grpc.PickFirstBalancerName, LeastRequestBalancerName}
 
 // KeepaliveClientConfig exposes the keepalive.ClientParameters to be used by the exporter.
 // Refer to the original data-structure for the meaning of each parameter:
@@ -67,7 +73,7 @@ type GRPCClientSettings struct {
 	Endpoint string `mapstructure:"endpoint"`
 
 	// The compression key for supported compression types within
-	// collector. Currently the only supported mode is `gzip`.
+	// collector. The supported modes are `gzip`, `zstd` and `snappy`.
 	Compression string `mapstructure:"compression"`
 
 	// TLSSetting struct exposes TLS client configuration.
@@ -95,13 +101,34 @@ type GRPCClientSettings struct {
 	// PerRPCAuth parameter configures the client to send authentication data on a per-RPC basis.
 	PerRPCAuth *PerRPCAuthConfig `mapstructure:"per_rpc_auth"`
 
+	// Auth configures the authenticator extension adding authentication data to every RPC, it requires TLS.
+	Auth *configauth.ClientAuthentication `mapstructure:"auth,omitempty"`
+
+	// ProxyURL is the URL of the proxy the client connects through, with the http scheme for an HTTP proxy supporting
+	// the CONNECT method or the socks5 scheme for a SOCKS5 proxy. When empty, the proxy set in the HTTPS_PROXY
+	// environment variable is used.
+	ProxyURL string `mapstructure:"proxy_url"`
+
+	// ForwardMetadata are the keys of the client metadata, included by the receiver, sent as request metadata.
+	ForwardMetadata []string `mapstructure:"forward_metadata,omitempty"`
+
 	// Sets the balancer in grpclb_policy to discover the servers. Default is pick_first
 	// https://github.com/grpc/grpc-go/blob/master/examples/features/load_balancing/README.md
 	BalancerName string `mapstructure:"balancer_name"`
+
+	// DNSResolutionInterval is the interval at which the names of the endpoints with the dns scheme, e.g.
+	// dns:///gateway.observability.svc:4317, are resolved again, so that the servers added behind the name
+	// are balanced too. The gRPC dns resolver does not resolve a name more than once every 30 seconds.
+	// If zero, the names are resolved again only when a connection fails.
+	DNSResolutionInterval time.Duration `mapstructure:"dns_resolution_interval"`
 }
 
+// KeepaliveServerConfig is the configuration for keepalive.
 type KeepaliveServerConfig struct {
-	ServerParameters  *KeepaliveServerParameters  `mapstructure:"server_parameters,omitempty"`
+	// ServerParameters configure how the server pings the clients and closes the idle or old connections.
+	ServerParameters *KeepaliveServerParameters `mapstructure:"server_parameters,omitempty"`
+	// EnforcementPolicy configures how often the clients are allowed to ping the server, the server closes the
+	// connections of the clients pinging more often.
 	EnforcementPolicy *KeepaliveEnforcementPolicy `mapstructure:"enforcement_policy,omitempty"`
 }
 
@@ -118,19 +145,56 @@ type PerRPCAuthConfig struct {
 // The same default values as keepalive.ServerParameters are applicable and get applied by the server.
 // See https://godoc.org/google.golang.org/grpc/keepalive#ServerParameters for details.
 type KeepaliveServerParameters struct {
-	MaxConnectionIdle     time.Duration `mapstructure:"max_connection_idle,omitempty"`
-	MaxConnectionAge      time.Duration `mapstructure:"max_connection_age,omitempty"`
+	// MaxConnectionIdle is the duration after which the connections without RPC are closed.
+	MaxConnectionIdle time.Duration `mapstructure:"max_connection_idle,omitempty"`
+	// MaxConnectionAge is the duration, with a +/-10% jitter, after which the connections are closed. It makes
+	// the long-lived clients reconnect, and be balanced across the replicas of the server behind a load balancer.
+	MaxConnectionAge time.Duration `mapstructure:"max_connection_age,omitempty"`
+	// MaxConnectionAgeGrace is the duration the RPC in progress have to complete once MaxConnectionAge is
+	// reached, before the connections are forcibly closed.
 	MaxConnectionAgeGrace time.Duration `mapstructure:"max_connection_age_grace,omitempty"`
-	Time                  time.Duration `mapstructure:"time,omitempty"`
-	Timeout               time.Duration `mapstructure:"timeout,omitempty"`
+	// Time is the duration without activity after which the server pings the clients.
+	Time time.Duration `mapstructure:"time,omitempty"`
+	// Timeout is the duration the server waits for the ping acknowledgement before closing the connection.
+	Timeout time.Duration `mapstructure:"timeout,omitempty"`
 }
 
 // KeepaliveEnforcementPolicy allow configuration of the keepalive.EnforcementPolicy.
 // The same default values as keepalive.EnforcementPolicy are applicable and get applied by the server.
 // See https://godoc.org/google.golang.org/grpc/keepalive#EnforcementPolicy for details.
 type KeepaliveEnforcementPolicy struct {
-	MinTime             time.Duration `mapstructure:"min_time,omitempty"`
-	PermitWithoutStream bool          `mapstructure:"permit_without_stream,omitempty"`
+	// MinTime is the minimum duration the clients should wait between two pings.
+	MinTime time.Duration `mapstructure:"min_time,omitempty"`
+	// PermitWithoutStream allows the clients to ping when there is no RPC in progress.
+	PermitWithoutStream bool `mapstructure:"permit_without_stream,omitempty"`
+}
+
+// validate returns an error if the keepalive settings are invalid.
+func (ksc *KeepaliveServerConfig) validate() error {
+	if sp := ksc.ServerParameters; sp != nil {
+		durations := []struct {
+			name  string
+			value time.Duration
+		}{
+			{"max_connection_idle", sp.MaxConnectionIdle},
+			{"max_connection_age", sp.MaxConnectionAge},
+			{"max_connection_age_grace", sp.MaxConnectionAgeGrace},
+			{"time", sp.Time},
+			{"timeout", sp.Timeout},
+		}
+		for _, d := range durations {
+			if d.value < 0 {
+				return fmt.Errorf("keepalive %s must not be negative", d.name)
+			}
+		}
+		if sp.MaxConnectionAgeGrace > 0 && sp.MaxConnectionAge == 0 {
+			return errors.New("keepalive max_connection_age_grace requires max_connection_age")
+		}
+	}
+	if ep := ksc.EnforcementPolicy; ep != nil && ep.MinTime < 0 {
+		return errors.New("keepalive min_time must not be negative")
+	}
+	return nil
 }
 
 type GRPCServerSettings struct {
@@ -144,8 +208,8 @@ type GRPCServerSettings struct {
 	// MaxRecvMsgSizeMiB sets the maximum size (in MiB) of messages accepted by the server.
 	MaxRecvMsgSizeMiB uint64 `mapstructure:"max_recv_msg_size_mib"`
 
-	// MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport.
-	// It has effect only for streaming RPCs.
+	// MaxConcurrentStreams sets the limit on the number of concurrent streams to each ServerTransport, that is
+	// the number of RPC in progress on each client connection, unary or streaming.
 	MaxConcurrentStreams uint32 `mapstructure:"max_concurrent_streams"`
 
 	// ReadBufferSize for gRPC server. See grpc.ReadBufferSize
@@ -161,6 +225,10 @@ type GRPCServerSettings struct {
 
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth,omitempty"`
+
+	// IncludeMetadata are the request metadata keys whose values are carried with the received data through the
+	// pipeline, so that the processors and exporters can use them, e.g. the header carrying the tenant.
+	IncludeMetadata []string `mapstructure:"include_metadata,omitempty"`
 }
 
 // ToDialOptions maps configgrpc.GRPCClientSettings to a slice of dial options for gRPC
@@ -211,6 +279,25 @@ func (gcs *GRPCClientSettings) ToDialOptions() ([]grpc.DialOption, error) {
 		}
 	}
 
+	if gcs.Auth != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(gcs.Auth.ToPerRPCCredentials()))
+	}
+
+	if gcs.ProxyURL != "" {
+		dialer, err := newProxyDialer(gcs.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithContextDialer(dialer))
+	}
+
+	if len(gcs.ForwardMetadata) > 0 {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(forwardMetadataUnaryInterceptor(gcs.ForwardMetadata)),
+			grpc.WithChainStreamInterceptor(forwardMetadataStreamInterceptor(gcs.ForwardMetadata)),
+		)
+	}
+
 	if gcs.BalancerName != "" {
 		valid := validateBalancerName(gcs.BalancerName)
 		if !valid {
@@ -219,6 +306,16 @@ func (gcs *GRPCClientSettings) ToDialOptions() ([]grpc.DialOption, error) {
 		opts = append(opts, grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, gcs.BalancerName)))
 	}
 
+	if gcs.DNSResolutionInterval < 0 {
+		return nil, errors.New("dns_resolution_interval must not be negative")
+	}
+	if gcs.DNSResolutionInterval > 0 {
+		opts = append(opts, grpc.WithResolvers(&periodicResolverBuilder{
+			Builder:  resolver.Get("dns"),
+			interval: gcs.DNSResolutionInterval,
+		}))
+	}
+
 	return opts, nil
 }
 
@@ -244,6 +341,8 @@ func (gss *GRPCServerSettings) ToServerOption() ([]grpc.ServerOption, error) {
 		if err != nil {
 			return nil, err
 		}
+		// The protocol is set on the loaded config, the reloaded configs selected for every connection use it too.
+		tlsCfg.NextProtos = []string{"h2"}
 		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
 	}
 
@@ -268,6 +367,9 @@ func (gss *GRPCServerSettings) ToServerOption() ([]grpc.ServerOption, error) {
 	// The following shows the server code for applying default grpc.ServerOptions.
 	// https://github.com/grpc/grpc-go/blob/120728e1f775e40a2a764341939b78d666b08260/internal/transport/http2_server.go#L184-L200
 	if gss.Keepalive != nil {
+		if err := gss.Keepalive.validate(); err != nil {
+			return nil, err
+		}
 		if gss.Keepalive.ServerParameters != nil {
 			svrParams := gss.Keepalive.ServerParameters
 			opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -299,6 +401,15 @@ func (gss *GRPCServerSettings) ToServerOption() ([]grpc.ServerOption, error) {
 		opts = append(opts, authOpts...)
 	}
 
+	// The chained interceptors run after the authentication ones, the client metadata is added to the authenticated
+	// client.
+	if len(gss.IncludeMetadata) > 0 {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(includeMetadataUnaryInterceptor(gss.IncludeMetadata)),
+			grpc.ChainStreamInterceptor(includeMetadataStreamInterceptor(gss.IncludeMetadata)),
+		)
+	}
+
 	return opts, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -58,15 +59,16 @@ func TestAllGrpcClientSettings(t *testing.T) {
 			Timeout:             time.Second,
 			PermitWithoutStream: true,
 		},
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-		WaitForReady:    true,
-		PerRPCAuth:      nil,
-		BalancerName:    "round_robin",
+		ReadBufferSize:        1024,
+		WriteBufferSize:       1024,
+		WaitForReady:          true,
+		PerRPCAuth:            nil,
+		BalancerName:          "round_robin",
+		DNSResolutionInterval: time.Minute,
 	}
 	opts, err := gcs.ToDialOptions()
 	assert.NoError(t, err)
-	assert.Len(t, opts, 6)
+	assert.Len(t, opts, 7)
 }
 
 func TestDefaultGrpcServerSettings(t *testing.T) {
@@ -187,6 +189,16 @@ func TestGRPCClientSettingsError(t *testing.T) {
 				BalancerName:    "test",
 			},
 		},
+		{
+			err: "^dns_resolution_interval must not be negative$",
+			settings: GRPCClientSettings{
+				Endpoint: "dns:///localhost:1234",
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				DNSResolutionInterval: -time.Second,
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -245,6 +257,36 @@ func TestGRPCServerSettingsError(t *testing.T) {
 				},
 			},
 		},
+		{
+			err: "^keepalive max_connection_age must not be negative$",
+			settings: GRPCServerSettings{
+				Keepalive: &KeepaliveServerConfig{
+					ServerParameters: &KeepaliveServerParameters{
+						MaxConnectionAge: -time.Second,
+					},
+				},
+			},
+		},
+		{
+			err: "^keepalive max_connection_age_grace requires max_connection_age$",
+			settings: GRPCServerSettings{
+				Keepalive: &KeepaliveServerConfig{
+					ServerParameters: &KeepaliveServerParameters{
+						MaxConnectionAgeGrace: time.Second,
+					},
+				},
+			},
+		},
+		{
+			err: "^keepalive min_time must not be negative$",
+			settings: GRPCServerSettings{
+				Keepalive: &KeepaliveServerConfig{
+					EnforcementPolicy: &KeepaliveEnforcementPolicy{
+						MinTime: -time.Second,
+					},
+				},
+			},
+		},
 		{
 			err: "^failed to load TLS config: failed to load client CA CertPool: failed to load CA /doesnt/exist:",
 			settings: GRPCServerSettings{
@@ -292,6 +334,14 @@ func TestGetGRPCCompressionKey(t *testing.T) {
 		t.Error("Capitalization of CompressionGzip should not matter")
 	}
 
+	if GetGRPCCompressionKey("zstd") != CompressionZstd {
+		t.Error("zstd is marked as supported but returned unsupported")
+	}
+
+	if GetGRPCCompressionKey("snappy") != CompressionSnappy {
+		t.Error("snappy is marked as supported but returned unsupported")
+	}
+
 	if GetGRPCCompressionKey("badType") != CompressionUnsupported {
 		t.Error("badType is not supported but was returned as supported")
 	}
@@ -486,6 +536,46 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	s.Stop()
 }
 
+func TestMaxConnectionAge(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		Keepalive: &KeepaliveServerConfig{
+			ServerParameters: &KeepaliveServerParameters{
+				MaxConnectionAge:      200 * time.Millisecond,
+				MaxConnectionAgeGrace: 100 * time.Millisecond,
+			},
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	opts, err := gss.ToServerOption()
+	require.NoError(t, err)
+	s := grpc.NewServer(opts...)
+	otelcol.RegisterTraceServiceServer(s, &grpcTraceServer{})
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer s.Stop()
+
+	grpcClientConn, err := grpc.Dial(ln.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+	client := otelcol.NewTraceServiceClient(grpcClientConn)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFunc()
+	_, err = client.Export(ctx, &otelcol.ExportTraceServiceRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	require.Equal(t, connectivity.Ready, grpcClientConn.GetState())
+
+	// The server closes the connection once it is too old, the client reconnects for the next RPC.
+	require.True(t, grpcClientConn.WaitForStateChange(ctx, connectivity.Ready))
+	_, err = client.Export(ctx, &otelcol.ExportTraceServiceRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+}
+
 type grpcTraceServer struct{}
 
 func (gts *grpcTraceServer) Export(context.Context, *otelcol.ExportTraceServiceRequest) (*otelcol.ExportTraceServiceResponse, error) {
@@ -508,6 +598,18 @@ func TestWithPerRPCAuthBearerToken(t *testing.T) {
 	assert.Len(t, dialOpts, 2) // WithInsecure and WithPerRPCCredentials
 }
 
+func TestWithClientAuthenticator(t *testing.T) {
+	// test
+	gcs := &GRPCClientSettings{
+		Auth: &configauth.ClientAuthentication{AuthenticatorName: "oauth2client"},
+	}
+	dialOpts, err := gcs.ToDialOptions()
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, dialOpts, 2) // WithInsecure and WithPerRPCCredentials
+}
+
 func TestWithPerRPCAuthInvalidAuthType(t *testing.T) {
 	// test
 	gcs := &GRPCClientSettings{
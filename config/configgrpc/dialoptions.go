@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+var (
+	dialOptionsMu sync.RWMutex
+	dialOptions   = map[configmodels.Type][]grpc.DialOption{}
+)
+
+// RegisterDialOptions registers dial options added to the gRPC clients of the exporters of the given type, after the
+// options built from their settings. It lets distributions add options which cannot be configured, for instance
+// interceptors or custom dialers, and must be called before the exporters are created.
+func RegisterDialOptions(exporterType configmodels.Type, opts ...grpc.DialOption) {
+	dialOptionsMu.Lock()
+	defer dialOptionsMu.Unlock()
+	dialOptions[exporterType] = append(dialOptions[exporterType], opts...)
+}
+
+// RegisteredDialOptions returns the dial options registered for the exporters of the given type.
+func RegisteredDialOptions(exporterType configmodels.Type) []grpc.DialOption {
+	dialOptionsMu.RLock()
+	defer dialOptionsMu.RUnlock()
+	return append([]grpc.DialOption(nil), dialOptions[exporterType]...)
+}
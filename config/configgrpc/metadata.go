@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// includeMetadataUnaryInterceptor stores the included keys of the request metadata on the client.
+func includeMetadataUnaryInterceptor(keys []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(contextWithMetadata(ctx, keys), req)
+	}
+}
+
+// includeMetadataStreamInterceptor stores the included keys of the stream metadata on the client.
+func includeMetadataStreamInterceptor(keys []string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &metadataServerStream{ServerStream: stream, ctx: contextWithMetadata(stream.Context(), keys)})
+	}
+}
+
+// metadataServerStream replaces the context of the stream with the one carrying the client metadata.
+type metadataServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *metadataServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// contextWithMetadata stores on the context a copy of the client carrying the values of the given keys in the
+// incoming metadata.
+func contextWithMetadata(ctx context.Context, keys []string) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	included := make(map[string][]string, len(keys))
+	for _, key := range keys {
+		if values := md.Get(key); len(values) > 0 {
+			included[strings.ToLower(key)] = values
+		}
+	}
+
+	c := &client.Client{}
+	if existing, ok := client.FromGRPC(ctx); ok {
+		*c = *existing
+	}
+	c.Metadata = included
+	return client.NewContext(ctx, c)
+}
+
+// forwardMetadataUnaryInterceptor adds the values of the given keys in the client metadata to the outgoing metadata.
+func forwardMetadataUnaryInterceptor(keys []string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContextWithMetadata(ctx, keys), method, req, reply, cc, opts...)
+	}
+}
+
+// forwardMetadataStreamInterceptor adds the values of the given keys in the client metadata to the outgoing metadata.
+func forwardMetadataStreamInterceptor(keys []string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContextWithMetadata(ctx, keys), desc, cc, method, opts...)
+	}
+}
+
+func outgoingContextWithMetadata(ctx context.Context, keys []string) context.Context {
+	c, ok := client.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	var kv []string
+	for _, key := range keys {
+		for _, value := range c.Metadata[strings.ToLower(key)] {
+			kv = append(kv, key, value)
+		}
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
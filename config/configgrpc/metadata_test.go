@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+func TestIncludeMetadataUnaryInterceptor(t *testing.T) {
+	authenticated := &client.Client{IP: "127.0.0.1", Subject: "subject"}
+	ctx := client.NewContext(context.Background(), authenticated)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-tenant-id", "tenant", "authorization", "secret"))
+
+	var got *client.Client
+	_, err := includeMetadataUnaryInterceptor([]string{"X-Tenant-ID", "x-missing"})(ctx, nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			got, _ = client.FromContext(ctx)
+			return nil, nil
+		})
+	require.NoError(t, err)
+
+	// The metadata is added to a copy of the authenticated client.
+	assert.Equal(t, &client.Client{
+		IP:       "127.0.0.1",
+		Subject:  "subject",
+		Metadata: map[string][]string{"x-tenant-id": {"tenant"}},
+	}, got)
+	assert.Nil(t, authenticated.Metadata)
+}
+
+func TestForwardMetadataUnaryInterceptor(t *testing.T) {
+	ctx := client.NewContext(context.Background(), &client.Client{
+		Metadata: map[string][]string{"x-tenant-id": {"tenant"}, "authorization": {"secret"}},
+	})
+	ctx = metadata.AppendToOutgoingContext(ctx, "header", "value")
+
+	var md metadata.MD
+	err := forwardMetadataUnaryInterceptor([]string{"X-Tenant-ID"})(ctx, "method", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, metadata.Pairs("header", "value", "x-tenant-id", "tenant"), md)
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// startHealthServer starts a gRPC server serving the health service.
+func startHealthServer(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+	return ln.Addr().String()
+}
+
+// startProxy accepts connections and serves them with the handler until the test ends.
+func startProxy(t *testing.T, handler func(net.Conn)) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go handler(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// tunnel copies the data between the client and the target until one of them closes the connection.
+func tunnel(client net.Conn, target string) {
+	defer client.Close()
+	conn, err := net.Dial("tcp", target)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	go io.Copy(conn, client)
+	io.Copy(client, conn)
+}
+
+func checkHealth(t *testing.T, gcs *GRPCClientSettings) error {
+	opts, err := gcs.ToDialOptions()
+	require.NoError(t, err)
+	conn, err := grpc.Dial(gcs.Endpoint, opts...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+	return err
+}
+
+func TestHTTPConnectProxy(t *testing.T) {
+	target := startHealthServer(t)
+	requests := make(chan *http.Request, 1)
+	proxyAddr := startProxy(t, func(conn net.Conn) {
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			conn.Close()
+			return
+		}
+		requests <- req
+		if req.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNzd29yZA==" {
+			io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+			conn.Close()
+			return
+		}
+		io.WriteString(conn, "HTTP/1.1 200 OK\r\n\r\n")
+		tunnel(conn, req.Host)
+	})
+
+	gcs := &GRPCClientSettings{
+		Endpoint:   target,
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+		ProxyURL:   "http://user:password@" + proxyAddr,
+	}
+	require.NoError(t, checkHealth(t, gcs))
+	req := <-requests
+	assert.Equal(t, http.MethodConnect, req.Method)
+	assert.Equal(t, target, req.Host)
+}
+
+func TestHTTPConnectProxyRefused(t *testing.T) {
+	proxyAddr := startProxy(t, func(conn net.Conn) {
+		defer conn.Close()
+		if _, err := http.ReadRequest(bufio.NewReader(conn)); err == nil {
+			io.WriteString(conn, "HTTP/1.1 403 Forbidden\r\n\r\n")
+		}
+	})
+
+	dialer, err := newProxyDialer("http://" + proxyAddr)
+	require.NoError(t, err)
+	_, err = dialer(context.Background(), "127.0.0.1:4317")
+	assert.EqualError(t, err, "the proxy "+proxyAddr+" refused to connect to 127.0.0.1:4317: 403 Forbidden")
+}
+
+func TestSOCKS5Proxy(t *testing.T) {
+	target := startHealthServer(t)
+	targets := make(chan string, 1)
+	proxyAddr := startProxy(t, func(conn net.Conn) {
+		// Greeting: version, number of methods and methods, the "no authentication required" method is selected.
+		greeting := make([]byte, 2)
+		if _, err := io.ReadFull(conn, greeting); err != nil {
+			conn.Close()
+			return
+		}
+		io.ReadFull(conn, make([]byte, greeting[1]))
+		conn.Write([]byte{5, 0})
+
+		// Request: version, command, reserved, address type, IPv4 address and port.
+		req := make([]byte, 10)
+		if _, err := io.ReadFull(conn, req); err != nil || req[3] != 1 {
+			conn.Close()
+			return
+		}
+		addr := net.JoinHostPort(net.IP(req[4:8]).String(), strconv.Itoa(int(binary.BigEndian.Uint16(req[8:]))))
+		targets <- addr
+		conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+		tunnel(conn, addr)
+	})
+
+	gcs := &GRPCClientSettings{
+		Endpoint:   target,
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+		ProxyURL:   "socks5://" + proxyAddr,
+	}
+	require.NoError(t, checkHealth(t, gcs))
+	assert.Equal(t, target, <-targets)
+}
+
+func TestProxyURLError(t *testing.T) {
+	gcs := &GRPCClientSettings{ProxyURL: "ftp://proxy.local:21"}
+	_, err := gcs.ToDialOptions()
+	assert.EqualError(t, err, `unsupported proxy_url scheme "ftp", only http and socks5 are supported`)
+
+	gcs.ProxyURL = "http://[::1"
+	_, err = gcs.ToDialOptions()
+	assert.Error(t, err)
+}
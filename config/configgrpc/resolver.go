@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// periodicResolverBuilder builds the resolvers of the wrapped builder, e.g. dns, asking them to resolve the target
+// again every interval. The dns resolver of gRPC otherwise resolves it again only when a connection fails, so the
+// servers added behind the name are never used.
+type periodicResolverBuilder struct {
+	resolver.Builder
+	interval time.Duration
+}
+
+func (b *periodicResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	r, err := b.Builder.Build(target, cc, opts)
+	if err != nil {
+		return nil, err
+	}
+	pr := &periodicResolver{
+		Resolver: r,
+		done:     make(chan struct{}),
+	}
+	pr.wg.Add(1)
+	go pr.resolvePeriodically(b.interval)
+	return pr, nil
+}
+
+type periodicResolver struct {
+	resolver.Resolver
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func (r *periodicResolver) resolvePeriodically(interval time.Duration) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.Resolver.ResolveNow(resolver.ResolveNowOptions{})
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// Close stops resolving the target periodically and closes the wrapped resolver.
+func (r *periodicResolver) Close() {
+	close(r.done)
+	r.wg.Wait()
+	r.Resolver.Close()
+}
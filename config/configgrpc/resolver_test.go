@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+)
+
+type countingResolver struct {
+	resolveNow int64
+	closed     int64
+}
+
+func (r *countingResolver) ResolveNow(resolver.ResolveNowOptions) {
+	atomic.AddInt64(&r.resolveNow, 1)
+}
+
+func (r *countingResolver) Close() {
+	atomic.AddInt64(&r.closed, 1)
+}
+
+type countingResolverBuilder struct {
+	r *countingResolver
+}
+
+func (b *countingResolverBuilder) Build(resolver.Target, resolver.ClientConn, resolver.BuildOptions) (resolver.Resolver, error) {
+	return b.r, nil
+}
+
+func (*countingResolverBuilder) Scheme() string {
+	return "dns"
+}
+
+func TestPeriodicResolver(t *testing.T) {
+	cr := &countingResolver{}
+	b := &periodicResolverBuilder{
+		Builder:  &countingResolverBuilder{r: cr},
+		interval: 10 * time.Millisecond,
+	}
+	assert.Equal(t, "dns", b.Scheme())
+
+	r, err := b.Build(resolver.Target{}, nil, resolver.BuildOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt64(&cr.resolveNow) >= 3
+	}, time.Second, 5*time.Millisecond)
+
+	r.Close()
+	assert.EqualValues(t, 1, atomic.LoadInt64(&cr.closed))
+	resolved := atomic.LoadInt64(&cr.resolveNow)
+	<-time.After(50 * time.Millisecond)
+	assert.Equal(t, resolved, atomic.LoadInt64(&cr.resolveNow))
+}
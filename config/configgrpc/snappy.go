@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"google.golang.org/grpc/encoding"
+)
+
+// snappyName is the name the snappy compressor is registered under, also used
+// as the grpc-encoding of the messages.
+const snappyName = "snappy"
+
+func init() {
+	encoding.RegisterCompressor(&snappyCompressor{})
+}
+
+// snappyCompressor compresses the gRPC messages with the snappy framing format,
+// reusing the writers and readers.
+type snappyCompressor struct {
+	writersPool sync.Pool
+	readersPool sync.Pool
+}
+
+func (c *snappyCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	sw, ok := c.writersPool.Get().(*snappy.Writer)
+	if !ok {
+		sw = snappy.NewBufferedWriter(w)
+	} else {
+		sw.Reset(w)
+	}
+	return &snappyWriter{Writer: sw, pool: &c.writersPool}, nil
+}
+
+func (c *snappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	sr, ok := c.readersPool.Get().(*snappy.Reader)
+	if !ok {
+		sr = snappy.NewReader(r)
+	} else {
+		sr.Reset(r)
+	}
+	return &snappyReader{Reader: sr, pool: &c.readersPool}, nil
+}
+
+func (c *snappyCompressor) Name() string {
+	return snappyName
+}
+
+// snappyWriter returns the writer to the pool once closed.
+type snappyWriter struct {
+	*snappy.Writer
+	pool *sync.Pool
+}
+
+func (s *snappyWriter) Close() error {
+	defer s.pool.Put(s.Writer)
+	return s.Writer.Close()
+}
+
+// snappyReader returns the reader to the pool once the message is read.
+type snappyReader struct {
+	*snappy.Reader
+	pool *sync.Pool
+}
+
+func (s *snappyReader) Read(p []byte) (int, error) {
+	if s.Reader == nil {
+		return 0, io.EOF
+	}
+	n, err := s.Reader.Read(p)
+	if err == io.EOF {
+		s.pool.Put(s.Reader)
+		s.Reader = nil
+	}
+	return n, err
+}
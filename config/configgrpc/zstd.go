@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// zstdName is the name the zstd compressor is registered under, also used
+	// as the grpc-encoding of the messages.
+	zstdName = "zstd"
+
+	// zstdMaxDecodedSize is the maximum size of a decompressed message, the
+	// decompression fails once it is reached so that a small message with a
+	// high compression ratio cannot exhaust the memory. gRPC rejects the
+	// messages larger than the max_recv_msg_size_mib of the server after the
+	// decompression.
+	zstdMaxDecodedSize = 64 << 20
+)
+
+func init() {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		panic(err)
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(zstdMaxDecodedSize))
+	if err != nil {
+		panic(err)
+	}
+	encoding.RegisterCompressor(&zstdCompressor{encoder: encoder, decoder: decoder})
+}
+
+// zstdCompressor compresses the gRPC messages with zstd. The messages are
+// compressed and decompressed at once with the encoder and decoder shared by
+// all the streams, unlike the streaming mode they do not start goroutines.
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &zstdWriter{encoder: c.encoder, w: w}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	compressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	decompressed, err := c.decoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(decompressed), nil
+}
+
+func (c *zstdCompressor) Name() string {
+	return zstdName
+}
+
+// zstdWriter buffers the message and writes it compressed when closed.
+type zstdWriter struct {
+	encoder *zstd.Encoder
+	w       io.Writer
+	buf     bytes.Buffer
+}
+
+func (z *zstdWriter) Write(p []byte) (int, error) {
+	return z.buf.Write(p)
+}
+
+func (z *zstdWriter) Close() error {
+	_, err := z.w.Write(z.encoder.EncodeAll(z.buf.Bytes(), nil))
+	return err
+}
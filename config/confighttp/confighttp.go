@@ -16,16 +16,30 @@ package confighttp
 
 import (
 	"crypto/tls"
+	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/rs/cors"
 
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/internal/middleware"
 )
 
+// Compression types supported by the HTTP clients.
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// Middleware wraps the transport of an HTTP client to intercept its requests, for instance to sign them or to add
+// headers. The requests may be sent again, so a middleware reading the body must leave it readable.
+type Middleware func(next http.RoundTripper) (http.RoundTripper, error)
+
 type HTTPClientSettings struct {
 	// The target URL to send data to (e.g.: http://some.url:9411/v1/traces).
 	Endpoint string `mapstructure:"endpoint"`
@@ -46,8 +60,21 @@ type HTTPClientSettings struct {
 	// Existing header values are overwritten if collision happens.
 	Headers map[string]string `mapstructure:"headers,omitempty"`
 
+	// Auth configures the authenticator extension adding authentication data to every request.
+	Auth *configauth.ClientAuthentication `mapstructure:"auth,omitempty"`
+
+	// ForwardMetadata are the keys of the client metadata, included by the receiver, sent as request headers.
+	ForwardMetadata []string `mapstructure:"forward_metadata,omitempty"`
+
+	// The compression of the request bodies, either gzip or zstd. (optional)
+	Compression string `mapstructure:"compression"`
+
 	// Custom Round Tripper to allow for individual components to intercept HTTP requests
 	CustomRoundTripper func(next http.RoundTripper) (http.RoundTripper, error)
+
+	// Middlewares intercept the HTTP requests after the CustomRoundTripper, the first one seeing the requests first.
+	// The request bodies they receive are already compressed.
+	Middlewares []Middleware `mapstructure:"-"`
 }
 
 func (hcs *HTTPClientSettings) ToClient() (*http.Client, error) {
@@ -74,6 +101,24 @@ func (hcs *HTTPClientSettings) ToClient() (*http.Client, error) {
 		}
 	}
 
+	if len(hcs.ForwardMetadata) > 0 {
+		clientTransport = &forwardMetadataRoundTripper{
+			transport: clientTransport,
+			keys:      hcs.ForwardMetadata,
+		}
+	}
+
+	if hcs.Auth != nil {
+		clientTransport = hcs.Auth.ToRoundTripper(clientTransport)
+	}
+
+	for i := len(hcs.Middlewares) - 1; i >= 0; i-- {
+		clientTransport, err = hcs.Middlewares[i](clientTransport)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if hcs.CustomRoundTripper != nil {
 		clientTransport, err = hcs.CustomRoundTripper(clientTransport)
 		if err != nil {
@@ -81,6 +126,16 @@ func (hcs *HTTPClientSettings) ToClient() (*http.Client, error) {
 		}
 	}
 
+	switch strings.ToLower(hcs.Compression) {
+	case "":
+	case CompressionGzip:
+		clientTransport = middleware.NewCompressRoundTripper(clientTransport)
+	case CompressionZstd:
+		clientTransport = middleware.NewZstdCompressRoundTripper(clientTransport)
+	default:
+		return nil, fmt.Errorf("unsupported compression type %q", hcs.Compression)
+	}
+
 	return &http.Client{
 		Transport: clientTransport,
 		Timeout:   hcs.Timeout,
@@ -106,6 +161,17 @@ type HTTPServerSettings struct {
 	// Endpoint configures the listening address for the server.
 	Endpoint string `mapstructure:"endpoint"`
 
+	// Transport to listen on, "tcp" if omitted. The "unix" transport listens on the socket file at the Endpoint
+	// and the "npipe" transport on the Windows named pipe at the Endpoint.
+	Transport string `mapstructure:"transport"`
+
+	// SocketPermissions are the permissions, in octal notation such as "0660", of the socket file listened on
+	// with the "unix" transport. If omitted the permissions depend on the umask of the process.
+	SocketPermissions string `mapstructure:"socket_permissions"`
+
+	// SocketOptions configures the listening socket and the accepted connections.
+	SocketOptions confignet.SocketOptions `mapstructure:"socket_options"`
+
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls_settings, omitempty"`
 
@@ -120,10 +186,35 @@ type HTTPServerSettings struct {
 	// CORS needs to be enabled first by providing a non-empty list in CorsOrigins
 	// A wildcard (*) can be used to match any header.
 	CorsHeaders []string `mapstructure:"cors_allowed_headers"`
+
+	// CorsMaxAge is the duration, in seconds, the browsers are allowed to cache the
+	// results of the CORS preflight requests. Zero leaves the browsers' default.
+	CorsMaxAge int `mapstructure:"cors_max_age"`
+
+	// MaxRequestBodySize is the maximum size, in bytes, of the request bodies, enforced both on the body
+	// received and on the decompressed body. The larger requests are refused with 413 Request Entity Too Large.
+	// Zero means no limit.
+	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
+
+	// Auth for this receiver, only the authenticators provided by extensions are supported.
+	Auth *configauth.Authentication `mapstructure:"auth,omitempty"`
+
+	// IncludeMetadata are the request headers whose values are carried with the received data through the
+	// pipeline, so that the processors and exporters can use them, e.g. the header carrying the tenant.
+	IncludeMetadata []string `mapstructure:"include_metadata,omitempty"`
 }
 
 func (hss *HTTPServerSettings) ToListener() (net.Listener, error) {
-	listener, err := net.Listen("tcp", hss.Endpoint)
+	addr := confignet.NetAddr{
+		Endpoint:          hss.Endpoint,
+		Transport:         hss.Transport,
+		SocketPermissions: hss.SocketPermissions,
+		SocketOptions:     hss.SocketOptions,
+	}
+	if addr.Transport == "" {
+		addr.Transport = "tcp"
+	}
+	listener, err := addr.Listen()
 	if err != nil {
 		return nil, err
 	}
@@ -160,16 +251,30 @@ func (hss *HTTPServerSettings) ToServer(handler http.Handler, opts ...ToServerOp
 	for _, o := range opts {
 		o(serverOpts)
 	}
-	if len(hss.CorsOrigins) > 0 {
-		co := cors.Options{AllowedOrigins: hss.CorsOrigins, AllowedHeaders: hss.CorsHeaders}
-		handler = cors.New(co).Handler(handler)
+	// The metadata is included after the authentication, it is added to the authenticated client.
+	if len(hss.IncludeMetadata) > 0 {
+		handler = includeMetadataHandler(handler, hss.IncludeMetadata)
+	}
+	if hss.Auth != nil {
+		handler = hss.Auth.ToHTTPHandler(handler)
 	}
-	// TODO: emit a warning when non-empty CorsHeaders and empty CorsOrigins.
-
 	handler = middleware.HTTPContentDecompressor(
 		handler,
 		middleware.WithErrorHandler(serverOpts.errorHandler),
+		middleware.WithMaxRequestBodySize(hss.MaxRequestBodySize),
 	)
+
+	// CORS wraps the other handlers so that the browsers are able to read the errors
+	// returned by them, e.g. the ones of the decompression.
+	if len(hss.CorsOrigins) > 0 {
+		co := cors.Options{
+			AllowedOrigins: hss.CorsOrigins,
+			AllowedHeaders: hss.CorsHeaders,
+			MaxAge:         hss.CorsMaxAge,
+		}
+		handler = cors.New(co).Handler(handler)
+	}
+	// TODO: emit a warning when non-empty CorsHeaders and empty CorsOrigins.
 	return &http.Server{
 		Handler: handler,
 	}
@@ -15,19 +15,30 @@
 package confighttp
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
 
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -80,6 +91,89 @@ func TestAllHTTPClientSettings(t *testing.T) {
 	}
 }
 
+type mockClientAuthenticator struct{}
+
+func (mockClientAuthenticator) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	return &headerRoundTripper{transport: base, headers: map[string]string{"Authorization": "Bearer token"}}
+}
+
+func (mockClientAuthenticator) PerRPCCredentials() credentials.PerRPCCredentials {
+	return nil
+}
+
+func TestHTTPClientAuth(t *testing.T) {
+	require.NoError(t, configauth.RegisterClientAuthenticator("mock", mockClientAuthenticator{}))
+	defer configauth.UnregisterClientAuthenticator("mock")
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	hcs := HTTPClientSettings{
+		Endpoint: server.URL,
+		Headers:  map[string]string{"header1": "value1"},
+		Auth:     &configauth.ClientAuthentication{AuthenticatorName: "mock"},
+	}
+	client, err := hcs.ToClient()
+	require.NoError(t, err)
+
+	resp, err := client.Get(hcs.Endpoint)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "Bearer token", authorization)
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// recordingMiddleware appends its name to the "order" header and records the encoding of the requests it sees.
+func recordingMiddleware(name string, encodings *[]string) Middleware {
+	return func(next http.RoundTripper) (http.RoundTripper, error) {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Add("order", name)
+			*encodings = append(*encodings, req.Header.Get("Content-Encoding"))
+			return next.RoundTrip(req)
+		}), nil
+	}
+}
+
+func TestHTTPClientMiddlewares(t *testing.T) {
+	var order []string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = r.Header.Values("order")
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	var encodings []string
+	hcs := HTTPClientSettings{
+		Endpoint:    server.URL,
+		Compression: CompressionGzip,
+		Middlewares: []Middleware{recordingMiddleware("first", &encodings), recordingMiddleware("second", &encodings)},
+	}
+	client, err := hcs.ToClient()
+	require.NoError(t, err)
+
+	resp, err := client.Post(hcs.Endpoint, "text/plain", strings.NewReader("uncompressed"))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// The middlewares see the compressed requests in order.
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Equal(t, []string{"gzip", "gzip"}, encodings)
+	gr, err := gzip.NewReader(bytes.NewReader(body))
+	require.NoError(t, err)
+	uncompressed, err := ioutil.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, "uncompressed", string(uncompressed))
+}
+
 func TestHTTPClientSettingsError(t *testing.T) {
 	tests := []struct {
 		settings HTTPClientSettings
@@ -111,6 +205,20 @@ func TestHTTPClientSettingsError(t *testing.T) {
 				},
 			},
 		},
+		{
+			err: `^unsupported compression type "gzip2"`,
+			settings: HTTPClientSettings{
+				Compression: "gzip2",
+			},
+		},
+		{
+			err: "^middleware error",
+			settings: HTTPClientSettings{
+				Middlewares: []Middleware{func(next http.RoundTripper) (http.RoundTripper, error) {
+					return nil, errors.New("middleware error")
+				}},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -315,6 +423,45 @@ func TestHttpReception(t *testing.T) {
 	}
 }
 
+func TestHttpReceptionUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	hss := &HTTPServerSettings{
+		Endpoint:          filepath.Join(t.TempDir(), "http.sock"),
+		Transport:         "unix",
+		SocketPermissions: "0660",
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+	s := hss.ToServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, errWrite := fmt.Fprint(w, "test")
+		assert.NoError(t, errWrite)
+	}))
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer s.Close()
+
+	info, err := os.Stat(hss.Endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", hss.Endpoint)
+			},
+		},
+	}
+	resp, err := client.Get("http://localhost/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "test", string(body))
+}
+
 func TestHttpCors(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -383,6 +530,41 @@ func TestHttpCors(t *testing.T) {
 	}
 }
 
+func TestHttpCorsMaxAge(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint:    "localhost:0",
+		CorsOrigins: []string{"*"},
+		CorsMaxAge:  600,
+	}
+	s := hss.ToServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "allowed-origin.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	s.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestHttpCorsDecompressionError(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint:    "localhost:0",
+		CorsOrigins: []string{"allowed-*.com"},
+	}
+	s := hss.ToServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// The errors of the decompression must be readable by the browsers too.
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not gzip"))
+	req.Header.Set("Origin", "allowed-origin.com")
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	s.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "allowed-origin.com", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
 func TestHttpCorsInvalidSettings(t *testing.T) {
 	hss := &HTTPServerSettings{
 		Endpoint:    "localhost:0",
@@ -395,6 +577,43 @@ func TestHttpCorsInvalidSettings(t *testing.T) {
 	require.NoError(t, s.Close())
 }
 
+type mockAuthenticator struct{}
+
+func (mockAuthenticator) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	if len(headers["authorization"]) == 0 {
+		return ctx, errors.New("not authenticated")
+	}
+	return configauth.NewContext(ctx, headers["authorization"][0], nil), nil
+}
+
+func TestHttpAuth(t *testing.T) {
+	require.NoError(t, configauth.RegisterServerAuthenticator("mock", mockAuthenticator{}))
+	defer configauth.UnregisterServerAuthenticator("mock")
+
+	hss := &HTTPServerSettings{
+		Endpoint: "localhost:0",
+		Auth:     &configauth.Authentication{AuthenticatorName: "mock"},
+	}
+	var subject string
+	s := hss.ToServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := client.FromHTTP(r)
+		require.True(t, ok)
+		subject = c.Subject
+	}))
+
+	rec := httptest.NewRecorder()
+	s.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, subject)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Authorization", "my-subject")
+	rec = httptest.NewRecorder()
+	s.Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "my-subject", subject)
+}
+
 func verifyCorsResp(t *testing.T, url string, origin string, extraHeader bool, wantStatus int, wantAllowed bool) {
 	req, err := http.NewRequest("OPTIONS", url, nil)
 	require.NoError(t, err, "Error creating trace OPTIONS request: %v", err)
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// includeMetadataHandler stores the included request headers on the client.
+func includeMetadataHandler(next http.Handler, keys []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		included := make(map[string][]string, len(keys))
+		for _, key := range keys {
+			if values := r.Header.Values(key); len(values) > 0 {
+				included[strings.ToLower(key)] = values
+			}
+		}
+
+		c := &client.Client{}
+		if existing, ok := client.FromHTTP(r); ok {
+			*c = *existing
+		}
+		c.Metadata = included
+		next.ServeHTTP(w, r.WithContext(client.NewContext(r.Context(), c)))
+	})
+}
+
+// forwardMetadataRoundTripper adds the values of the given keys in the client metadata to the request headers.
+type forwardMetadataRoundTripper struct {
+	transport http.RoundTripper
+	keys      []string
+}
+
+func (rt *forwardMetadataRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	c, ok := client.FromContext(req.Context())
+	if !ok {
+		return rt.transport.RoundTrip(req)
+	}
+	// The request must not be modified by the RoundTripper, the headers are added to a copy.
+	req = req.Clone(req.Context())
+	for _, key := range rt.keys {
+		for _, value := range c.Metadata[strings.ToLower(key)] {
+			req.Header.Add(key, value)
+		}
+	}
+	return rt.transport.RoundTrip(req)
+}
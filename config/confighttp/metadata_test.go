@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+func TestHTTPIncludeAndForwardMetadata(t *testing.T) {
+	var forwarded http.Header
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header
+	}))
+	defer backend.Close()
+
+	hcs := HTTPClientSettings{Endpoint: backend.URL, ForwardMetadata: []string{"X-Tenant-ID"}}
+	httpClient, err := hcs.ToClient()
+	require.NoError(t, err)
+
+	var received *client.Client
+	hss := HTTPServerSettings{IncludeMetadata: []string{"x-tenant-id"}}
+	server := httptest.NewServer(hss.ToServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = client.FromContext(r.Context())
+		// The exporter sends the data with the context of the received data.
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, backend.URL, nil)
+		require.NoError(t, err)
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	})).Handler)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Tenant-ID", "tenant")
+	req.Header.Set("Authorization", "secret")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.NotNil(t, received)
+	assert.Equal(t, map[string][]string{"x-tenant-id": {"tenant"}}, received.Metadata)
+	assert.Equal(t, "127.0.0.1", received.IP)
+	assert.Equal(t, "tenant", forwarded.Get("X-Tenant-ID"))
+	assert.Empty(t, forwarded.Get("Authorization"))
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+var (
+	middlewaresMu sync.RWMutex
+	middlewares   = map[configmodels.Type][]Middleware{}
+)
+
+// RegisterMiddleware registers a middleware added to the HTTP clients of the exporters of the given type, after the
+// middlewares of the exporters. It lets distributions intercept the requests without forking the exporters, and must
+// be called before the exporters are created.
+func RegisterMiddleware(exporterType configmodels.Type, m Middleware) {
+	middlewaresMu.Lock()
+	defer middlewaresMu.Unlock()
+	middlewares[exporterType] = append(middlewares[exporterType], m)
+}
+
+// RegisteredMiddlewares returns the middlewares registered for the exporters of the given type.
+func RegisteredMiddlewares(exporterType configmodels.Type) []Middleware {
+	middlewaresMu.RLock()
+	defer middlewaresMu.RUnlock()
+	return append([]Middleware(nil), middlewares[exporterType]...)
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterMiddleware(t *testing.T) {
+	assert.Empty(t, RegisteredMiddlewares("test"))
+
+	m := func(next http.RoundTripper) (http.RoundTripper, error) {
+		return next, nil
+	}
+	RegisterMiddleware("test", m)
+	RegisterMiddleware("test", m)
+	assert.Len(t, RegisteredMiddlewares("test"), 2)
+	assert.Empty(t, RegisteredMiddlewares("other"))
+}
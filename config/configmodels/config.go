@@ -14,7 +14,7 @@
 
 // Package configmodels defines the data models for entities. This file defines the
 // models for configuration format. The defined entities are:
-// Config (the top-level structure), Receivers, Exporters, Processors, Pipelines.
+// Config (the top-level structure), Receivers, Exporters, Processors, Connectors, Pipelines.
 //
 // Receivers, Exporters and Processors typically have common configuration settings, however
 // sometimes specific implementations will have extra configuration settings.
@@ -32,12 +32,21 @@ package configmodels
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
 )
 
 var (
 	errMissingExporters        = errors.New("no enabled exporters specified in config")
 	errMissingReceivers        = errors.New("no enabled receivers specified in config")
 	errMissingServicePipelines = errors.New("service must have at least one pipeline")
+	errNegativeShutdownTimeout = errors.New("service shutdown_timeout must not be negative")
+	errMissingOTLPEndpoint     = errors.New("service telemetry metrics otlp endpoint must be specified")
+	errOTLPIntervalTooLow      = errors.New("service telemetry metrics otlp interval must be at least 1s")
+	errMissingTracesEndpoint   = errors.New("service telemetry traces otlp endpoint must be specified")
+	errInvalidSamplingRatio    = errors.New("service telemetry traces sampling_ratio must be between 0 and 1")
 )
 
 // Config defines the configuration for the various elements of collector or agent.
@@ -45,6 +54,7 @@ type Config struct {
 	Receivers
 	Exporters
 	Processors
+	Connectors
 	Extensions
 	Service
 }
@@ -67,14 +77,59 @@ func (cfg *Config) Validate() error {
 		return errMissingExporters
 	}
 
+	if cfg.Service.ShutdownTimeout < 0 {
+		return errNegativeShutdownTimeout
+	}
+
+	if err := cfg.validateServiceTelemetry(); err != nil {
+		return err
+	}
+
 	// Check that all enabled extensions in the service are configured
 	if err := cfg.validateServiceExtensions(); err != nil {
 		return err
 	}
 
+	// Check that the processor chains reference only configured processors.
+	if err := cfg.validateProcessorChains(); err != nil {
+		return err
+	}
+
 	// Check that all pipelines have at least one receiver and one exporter, and they reference
 	// only configured components.
-	return cfg.validateServicePipelines()
+	if err := cfg.validateServicePipelines(); err != nil {
+		return err
+	}
+
+	// Check that the connectors bridge pipelines.
+	return cfg.validateConnectors()
+}
+
+func (cfg *Config) validateServiceTelemetry() error {
+	metrics := cfg.Service.Telemetry.Metrics
+	if metrics.Level != "" {
+		if err := new(configtelemetry.Level).Set(metrics.Level); err != nil {
+			return fmt.Errorf("service telemetry metrics: %v", err)
+		}
+	}
+
+	if metrics.OTLP != nil {
+		if metrics.OTLP.Endpoint == "" {
+			return errMissingOTLPEndpoint
+		}
+		if metrics.OTLP.Interval != 0 && metrics.OTLP.Interval < time.Second {
+			return errOTLPIntervalTooLow
+		}
+	}
+
+	traces := cfg.Service.Telemetry.Traces
+	if traces.OTLP != nil && traces.OTLP.Endpoint == "" {
+		return errMissingTracesEndpoint
+	}
+	if traces.SamplingRatio < 0 || traces.SamplingRatio > 1 {
+		return errInvalidSamplingRatio
+	}
+	return nil
 }
 
 func (cfg *Config) validateServiceExtensions() error {
@@ -89,6 +144,70 @@ func (cfg *Config) validateServiceExtensions() error {
 	return nil
 }
 
+func (cfg *Config) validateProcessorChains() error {
+	for name, chain := range cfg.Service.ProcessorChains {
+		// The pipelines reference the processors and the processor chains by name.
+		if cfg.Processors[name] != nil {
+			return fmt.Errorf("processor chain %q has the same name as a processor", name)
+		}
+		if len(chain) == 0 {
+			return fmt.Errorf("processor chain %q must have at least one processor", name)
+		}
+		for _, ref := range chain {
+			// The chains are not nested, they reference only the top-level processors.
+			if cfg.Processors[ref] == nil {
+				return fmt.Errorf("processor chain %q references processor %q which does not exist", name, ref)
+			}
+		}
+	}
+
+	// The pipelines referencing a chain share the processors of the chain for their data type, so they must end with
+	// the processors of the chain and send the data to the same exporters.
+	names := make([]string, 0, len(cfg.Service.Pipelines))
+	for name := range cfg.Service.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sharing := make(map[string]*Pipeline)
+	for _, name := range names {
+		pipeline := cfg.Service.Pipelines[name]
+		if pipeline.ProcessorChain == "" {
+			continue
+		}
+		chain, ok := cfg.Service.ProcessorChains[pipeline.ProcessorChain]
+		if !ok {
+			return fmt.Errorf("pipeline %q references processor chain %q which does not exist", pipeline.Name, pipeline.ProcessorChain)
+		}
+		if len(pipeline.Processors) < len(chain) ||
+			!equalNames(pipeline.Processors[len(pipeline.Processors)-len(chain):], chain) {
+			return fmt.Errorf("pipeline %q does not end with the processors of processor chain %q", pipeline.Name, pipeline.ProcessorChain)
+		}
+		key := pipeline.ProcessorChain + "/" + string(pipeline.InputType)
+		first := sharing[key]
+		if first == nil {
+			sharing[key] = pipeline
+			continue
+		}
+		if !equalNames(first.Exporters, pipeline.Exporters) || first.FanoutErrorPolicy != pipeline.FanoutErrorPolicy {
+			return fmt.Errorf("pipelines %q and %q share processor chain %q but do not have the same exporters and fanout error policy",
+				first.Name, pipeline.Name, pipeline.ProcessorChain)
+		}
+	}
+	return nil
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (cfg *Config) validateServicePipelines() error {
 	// Must have at least one pipeline.
 	if len(cfg.Service.Pipelines) == 0 {
@@ -105,7 +224,8 @@ func (cfg *Config) validateServicePipelines() error {
 		// Validate pipeline receiver name references.
 		for _, ref := range pipeline.Receivers {
 			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers
-			if cfg.Receivers[ref] == nil {
+			// or connectors.
+			if cfg.Receivers[ref] == nil && cfg.Connectors[ref] == nil {
 				return fmt.Errorf("pipeline %q references receiver %q which does not exist", pipeline.Name, ref)
 			}
 		}
@@ -126,21 +246,124 @@ func (cfg *Config) validateServicePipelines() error {
 		// Validate pipeline exporter name references.
 		for _, ref := range pipeline.Exporters {
 			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters
-			if cfg.Exporters[ref] == nil {
+			// or Connectors.
+			if cfg.Exporters[ref] == nil && cfg.Connectors[ref] == nil {
 				return fmt.Errorf("pipeline %q references exporter %q which does not exist", pipeline.Name, ref)
 			}
 		}
+
+		switch pipeline.FanoutErrorPolicy {
+		case "", FanoutErrorPolicyPropagate, FanoutErrorPolicyFailFast, FanoutErrorPolicyIsolate:
+		default:
+			return fmt.Errorf("pipeline %q has unknown fanout_error_policy %q", pipeline.Name, pipeline.FanoutErrorPolicy)
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) validateConnectors() error {
+	for name := range cfg.Connectors {
+		// The pipelines reference the receivers, the exporters and the connectors by name.
+		if cfg.Receivers[name] != nil {
+			return fmt.Errorf("connector %q has the same name as a receiver", name)
+		}
+		if cfg.Exporters[name] != nil {
+			return fmt.Errorf("connector %q has the same name as an exporter", name)
+		}
+
+		// A connector used only on one side of the pipelines would never receive or never forward any data.
+		exported, received := false, false
+		for _, pipeline := range cfg.Service.Pipelines {
+			exported = exported || contains(pipeline.Exporters, name)
+			received = received || contains(pipeline.Receivers, name)
+		}
+		if exported && !received {
+			return fmt.Errorf("connector %q is used as an exporter but not as a receiver of any pipeline", name)
+		}
+		if received && !exported {
+			return fmt.Errorf("connector %q is used as a receiver but not as an exporter of any pipeline", name)
+		}
 	}
 	return nil
 }
 
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Service defines the configurable components of the service.
 type Service struct {
 	// Extensions is the ordered list of extensions configured for the service.
 	Extensions []string
 
+	// ProcessorChains are the named lists of processors the pipelines reference as the last of their processors
+	// instead of repeating the same processors. The processors of a chain are created once per data type and shared
+	// by the pipelines referencing the chain, which must therefore have the same exporters.
+	ProcessorChains map[string][]string
+
 	// Pipelines is the set of data pipelines configured for the service.
 	Pipelines Pipelines
+
+	// ShutdownTimeout is the time allowed to stop the receivers, flush the processors and drain the sending queues
+	// of the exporters when the service shuts down. The service default is used when it is 0.
+	ShutdownTimeout time.Duration
+
+	// Telemetry configures the telemetry of the collector itself.
+	Telemetry ServiceTelemetry
+
+	// FeatureGates are the identifiers of the feature gates to enable, or to disable when prefixed with '-'. The
+	// --feature-gates flag takes precedence over them.
+	FeatureGates []string
+}
+
+// ServiceTelemetry defines the configuration of the telemetry of the collector itself.
+type ServiceTelemetry struct {
+	// Metrics configures the metrics of the collector.
+	Metrics MetricsTelemetry `mapstructure:"metrics"`
+
+	// Traces configures the tracing of the collector operations.
+	Traces TracesTelemetry `mapstructure:"traces"`
+}
+
+// MetricsTelemetry defines how the metrics of the collector are collected and exported. The metrics have the
+// service.instance.id and service.version resource attributes, as labels when served in the Prometheus format.
+type MetricsTelemetry struct {
+	// Level is the level of the metrics: "none", "basic", "normal" or "detailed". The --metrics-level flag is used
+	// when it is empty.
+	Level string `mapstructure:"level"`
+
+	// Address is the [address]:port serving the metrics in the Prometheus format. The --metrics-addr flag is used
+	// when it is empty.
+	Address string `mapstructure:"address"`
+
+	// OTLP pushes the metrics to an OTLP/gRPC endpoint when it is set.
+	OTLP *OTLPMetricsTelemetry `mapstructure:"otlp"`
+}
+
+// OTLPTelemetryEndpoint defines the OTLP/gRPC endpoint the telemetry of the collector is exported to.
+type OTLPTelemetryEndpoint struct {
+	// Endpoint is the host:port of the OTLP/gRPC receiver.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Insecure disables the transport security.
+	Insecure bool `mapstructure:"insecure"`
+
+	// Headers are sent with every request.
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// OTLPMetricsTelemetry defines the OTLP/gRPC endpoint the metrics of the collector are pushed to.
+type OTLPMetricsTelemetry struct {
+	OTLPTelemetryEndpoint `mapstructure:",squash"`
+
+	// Interval is the interval at which the metrics are pushed, at least 1s. The service default is used when it
+	// is 0.
+	Interval time.Duration `mapstructure:"interval"`
 }
 
 // Type is the component type as it is used in the config.
@@ -153,6 +376,14 @@ type NamedEntity interface {
 	SetName(name string)
 }
 
+// CustomValidator is implemented by the receiver, processor, exporter, connector and extension configurations
+// validating their own settings. The configurations are validated when they are loaded, so that the invalid settings
+// are reported together before any component is created.
+type CustomValidator interface {
+	// Validate returns an error if the configuration is invalid.
+	Validate() error
+}
+
 // DataType is the data type that is supported for collection. We currently support
 // collecting metrics, traces and logs, this can expand in the future.
 type DataType string
@@ -169,14 +400,48 @@ const (
 	LogsDataType DataType = "logs"
 )
 
+// FanoutErrorPolicy defines how a pipeline handles the errors of its exporters when it fans out the data to several
+// of them.
+type FanoutErrorPolicy string
+
+const (
+	// FanoutErrorPolicyPropagate sends the data to all the exporters and returns the errors of the failed ones to the
+	// receivers. This is the policy of the pipelines not setting one.
+	FanoutErrorPolicyPropagate FanoutErrorPolicy = "propagate"
+
+	// FanoutErrorPolicyFailFast stops at the first exporter that fails and returns its error to the receivers, the
+	// following exporters do not receive the data.
+	FanoutErrorPolicyFailFast FanoutErrorPolicy = "fail_fast"
+
+	// FanoutErrorPolicyIsolate sends the data to all the exporters and returns an error to the receivers only if all
+	// of them failed, so that the receivers do not retry the data into the exporters that already consumed it. The
+	// items the exporters failed to consume are counted per exporter instead.
+	FanoutErrorPolicyIsolate FanoutErrorPolicy = "isolate"
+)
+
 // Pipeline defines a single pipeline.
 type Pipeline struct {
 	Name       string
 	InputType  DataType
 	Receivers  []string
 	Processors []string
-	Exporters  []string
+	// ProcessorChain is the name of the processor chain the pipeline references, its processors being the last of
+	// the Processors. It is empty when the pipeline does not reference a chain.
+	ProcessorChain    string
+	Exporters         []string
+	FanoutErrorPolicy FanoutErrorPolicy
 }
 
 // Pipelines is a map of names to Pipelines.
 type Pipelines map[string]*Pipeline
+
+// TracesTelemetry defines the tracing of the receive, process and export operations of the collector. The spans have
+// the same resource attributes as the metrics.
+type TracesTelemetry struct {
+	// OTLP exports the spans to an OTLP/gRPC endpoint, the operations are not traced when it is not set.
+	OTLP *OTLPTelemetryEndpoint `mapstructure:"otlp"`
+
+	// SamplingRatio is the ratio of the operations traced, between 0 and 1. All the operations are traced when it
+	// is 0.
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+}
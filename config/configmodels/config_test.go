@@ -17,6 +17,7 @@ package configmodels
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -109,6 +110,246 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`pipeline "traces" must have at least one exporter`),
 		},
+		{
+			name: "valid-processor-chain",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ProcessorChains = map[string][]string{"common": {"nop", "nop"}}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "processor-chain-same-name-as-processor",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ProcessorChains = map[string][]string{"nop": {"nop"}}
+				return cfg
+			},
+			expected: errors.New(`processor chain "nop" has the same name as a processor`),
+		},
+		{
+			name: "empty-processor-chain",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ProcessorChains = map[string][]string{"common": nil}
+				return cfg
+			},
+			expected: errors.New(`processor chain "common" must have at least one processor`),
+		},
+		{
+			name: "invalid-processor-chain-reference",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ProcessorChains = map[string][]string{"common": {"nop", "nop/2"}}
+				return cfg
+			},
+			expected: errors.New(`processor chain "common" references processor "nop/2" which does not exist`),
+		},
+		{
+			name: "valid-shared-processor-chain",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ProcessorChains = map[string][]string{"common": {"nop"}}
+				cfg.Service.Pipelines["traces"].ProcessorChain = "common"
+				cfg.Service.Pipelines["traces/2"] = &Pipeline{
+					Name:           "traces/2",
+					InputType:      TracesDataType,
+					Receivers:      []string{"nop"},
+					Processors:     []string{"nop"},
+					ProcessorChain: "common",
+					Exporters:      []string{"nop"},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "missing-pipeline-processor-chain",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines["traces"].ProcessorChain = "common"
+				return cfg
+			},
+			expected: errors.New(`pipeline "traces" references processor chain "common" which does not exist`),
+		},
+		{
+			name: "pipeline-not-ending-with-processor-chain",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ProcessorChains = map[string][]string{"common": {"nop", "nop"}}
+				cfg.Service.Pipelines["traces"].ProcessorChain = "common"
+				return cfg
+			},
+			expected: errors.New(`pipeline "traces" does not end with the processors of processor chain "common"`),
+		},
+		{
+			name: "shared-processor-chain-different-exporters",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Exporters["nop/2"] = &ExporterSettings{TypeVal: "nop"}
+				cfg.Service.ProcessorChains = map[string][]string{"common": {"nop"}}
+				cfg.Service.Pipelines["traces"].ProcessorChain = "common"
+				cfg.Service.Pipelines["traces/2"] = &Pipeline{
+					Name:           "traces/2",
+					InputType:      TracesDataType,
+					Receivers:      []string{"nop"},
+					Processors:     []string{"nop"},
+					ProcessorChain: "common",
+					Exporters:      []string{"nop/2"},
+				}
+				return cfg
+			},
+			expected: errors.New(`pipelines "traces" and "traces/2" share processor chain "common" but do not have the same exporters and fanout error policy`),
+		},
+		{
+			name: "valid-fanout-error-policy",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines["traces"].FanoutErrorPolicy = FanoutErrorPolicyIsolate
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-fanout-error-policy",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines["traces"].FanoutErrorPolicy = "retry"
+				return cfg
+			},
+			expected: errors.New(`pipeline "traces" has unknown fanout_error_policy "retry"`),
+		},
+		{
+			name: "valid-connector",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Connectors = map[string]Connector{
+					"forward": &ConnectorSettings{TypeVal: "forward"},
+				}
+				cfg.Service.Pipelines["traces"].Exporters = []string{"forward"}
+				cfg.Service.Pipelines["traces/2"] = &Pipeline{
+					Name:      "traces/2",
+					InputType: TracesDataType,
+					Receivers: []string{"forward"},
+					Exporters: []string{"nop"},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "connector-receiver-name-conflict",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Connectors = map[string]Connector{
+					"nop": &ConnectorSettings{TypeVal: "nop"},
+				}
+				cfg.Exporters = map[string]Exporter{
+					"nop/2": &ExporterSettings{TypeVal: "nop"},
+				}
+				cfg.Service.Pipelines["traces"].Exporters = []string{"nop/2"}
+				return cfg
+			},
+			expected: errors.New(`connector "nop" has the same name as a receiver`),
+		},
+		{
+			name: "connector-exporter-name-conflict",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Connectors = map[string]Connector{
+					"nop": &ConnectorSettings{TypeVal: "nop"},
+				}
+				cfg.Receivers = map[string]Receiver{
+					"nop/2": &ReceiverSettings{TypeVal: "nop"},
+				}
+				cfg.Service.Pipelines["traces"].Receivers = []string{"nop/2"}
+				return cfg
+			},
+			expected: errors.New(`connector "nop" has the same name as an exporter`),
+		},
+		{
+			name: "connector-not-received",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Connectors = map[string]Connector{
+					"forward": &ConnectorSettings{TypeVal: "forward"},
+				}
+				cfg.Service.Pipelines["traces"].Exporters = []string{"nop", "forward"}
+				return cfg
+			},
+			expected: errors.New(`connector "forward" is used as an exporter but not as a receiver of any pipeline`),
+		},
+		{
+			name: "connector-not-exported",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Connectors = map[string]Connector{
+					"forward": &ConnectorSettings{TypeVal: "forward"},
+				}
+				cfg.Service.Pipelines["traces"].Receivers = []string{"nop", "forward"}
+				return cfg
+			},
+			expected: errors.New(`connector "forward" is used as a receiver but not as an exporter of any pipeline`),
+		},
+		{
+			name: "negative-shutdown-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.ShutdownTimeout = -time.Second
+				return cfg
+			},
+			expected: errNegativeShutdownTimeout,
+		},
+		{
+			name: "invalid-telemetry-metrics-level",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Level = "verbose"
+				return cfg
+			},
+			expected: errors.New(`service telemetry metrics: unknown metrics level "verbose"`),
+		},
+		{
+			name: "missing-telemetry-otlp-endpoint",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.OTLP = &OTLPMetricsTelemetry{}
+				return cfg
+			},
+			expected: errMissingOTLPEndpoint,
+		},
+		{
+			name: "telemetry-otlp-interval-too-low",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.OTLP = &OTLPMetricsTelemetry{
+					OTLPTelemetryEndpoint: OTLPTelemetryEndpoint{Endpoint: "localhost:4317"},
+					Interval:              time.Millisecond,
+				}
+				return cfg
+			},
+			expected: errOTLPIntervalTooLow,
+		},
+		{
+			name: "missing-telemetry-traces-endpoint",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.OTLP = &OTLPTelemetryEndpoint{}
+				return cfg
+			},
+			expected: errMissingTracesEndpoint,
+		},
+		{
+			name: "invalid-telemetry-traces-sampling-ratio",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.OTLP = &OTLPTelemetryEndpoint{Endpoint: "localhost:4317"}
+				cfg.Service.Telemetry.Traces.SamplingRatio = 1.5
+				return cfg
+			},
+			expected: errInvalidSamplingRatio,
+		},
 		{
 			name: "missing-pipelines",
 			cfgFn: func() *Config {
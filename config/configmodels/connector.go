@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configmodels
+
+// Connector is the configuration of a connector.
+type Connector interface {
+	NamedEntity
+}
+
+// Connectors is a map of names to Connectors.
+type Connectors map[string]Connector
+
+// ConnectorSettings defines common settings for a connector configuration.
+// Specific connectors can embed this struct and extend it with more fields if needed.
+type ConnectorSettings struct {
+	TypeVal Type   `mapstructure:"-"`
+	NameVal string `mapstructure:"-"`
+}
+
+var _ Connector = (*ConnectorSettings)(nil)
+
+// Name gets the connector name.
+func (cs *ConnectorSettings) Name() string {
+	return cs.NameVal
+}
+
+// SetName sets the connector name.
+func (cs *ConnectorSettings) SetName(name string) {
+	cs.NameVal = name
+}
+
+// Type sets the connector type.
+func (cs *ConnectorSettings) Type() Type {
+	return cs.TypeVal
+}
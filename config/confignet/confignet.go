@@ -15,9 +15,19 @@
 package confignet
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
+	"os"
+	"strconv"
+	"time"
 )
 
+// TransportNamedPipe is the transport of the Windows named pipes, the endpoint
+// having the form `\\.\pipe\name`.
+const TransportNamedPipe = "npipe"
+
 // NetAddr represents a network endpoint address.
 type NetAddr struct {
 	// Endpoint configures the address for this network connection.
@@ -28,16 +38,119 @@ type NetAddr struct {
 	Endpoint string `mapstructure:"endpoint"`
 
 	// Transport to use. Known protocols are "tcp", "tcp4" (IPv4-only), "tcp6" (IPv6-only), "udp", "udp4" (IPv4-only),
-	// "udp6" (IPv6-only), "ip", "ip4" (IPv4-only), "ip6" (IPv6-only), "unix", "unixgram" and "unixpacket".
+	// "udp6" (IPv6-only), "ip", "ip4" (IPv4-only), "ip6" (IPv6-only), "unix", "unixgram", "unixpacket" and
+	// "npipe" (Windows-only).
 	Transport string `mapstructure:"transport"`
+
+	// SocketPermissions are the permissions, in octal notation such as "0660", of the socket file listened on
+	// with the "unix" and "unixpacket" transports. If omitted the permissions depend on the umask of the process.
+	SocketPermissions string `mapstructure:"socket_permissions"`
+
+	// SocketOptions configures the listening socket and the accepted connections.
+	SocketOptions SocketOptions `mapstructure:"socket_options"`
+}
+
+// SocketOptions are the advanced options of the listeners, to tune the network layer of high-throughput deployments.
+type SocketOptions struct {
+	// ReusePort sets SO_REUSEPORT on the listening socket, so that several processes can listen on the same address,
+	// the kernel balancing the connections between them. Only supported on Linux, macOS and the BSDs.
+	ReusePort bool `mapstructure:"reuse_port"`
+
+	// TCPKeepAlive is the keep-alive period of the accepted TCP connections. If omitted the Go default of 15s is
+	// used, a negative value disables the keep-alives.
+	TCPKeepAlive time.Duration `mapstructure:"tcp_keepalive"`
+
+	// ReadBufferSize is the size in bytes of the operating system receive buffer (SO_RCVBUF) of the accepted
+	// connections. If omitted the system default is used.
+	ReadBufferSize int `mapstructure:"read_buffer_size"`
+
+	// WriteBufferSize is the size in bytes of the operating system send buffer (SO_SNDBUF) of the accepted
+	// connections. If omitted the system default is used.
+	WriteBufferSize int `mapstructure:"write_buffer_size"`
+}
+
+// Listen announces on the local network address with the socket options applied.
+func (so *SocketOptions) Listen(network, address string) (net.Listener, error) {
+	if so.ReadBufferSize < 0 || so.WriteBufferSize < 0 {
+		return nil, errors.New("socket_options buffer sizes must not be negative")
+	}
+	lc := net.ListenConfig{KeepAlive: so.TCPKeepAlive}
+	if so.ReusePort {
+		lc.Control = reusePortControl
+	}
+	ln, err := lc.Listen(context.Background(), network, address)
+	if err != nil {
+		return nil, err
+	}
+	if so.ReadBufferSize > 0 || so.WriteBufferSize > 0 {
+		ln = &bufferSizeListener{Listener: ln, readBufferSize: so.ReadBufferSize, writeBufferSize: so.WriteBufferSize}
+	}
+	return ln, nil
+}
+
+// bufferSizeListener sets the operating system buffer sizes of the accepted connections.
+type bufferSizeListener struct {
+	net.Listener
+	readBufferSize  int
+	writeBufferSize int
+}
+
+func (l *bufferSizeListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	bufferConn, ok := conn.(interface {
+		SetReadBuffer(bytes int) error
+		SetWriteBuffer(bytes int) error
+	})
+	if !ok {
+		return conn, nil
+	}
+	// The buffer sizes are hints, the connection is usable even if the operating system refuses them.
+	if l.readBufferSize > 0 {
+		_ = bufferConn.SetReadBuffer(l.readBufferSize)
+	}
+	if l.writeBufferSize > 0 {
+		_ = bufferConn.SetWriteBuffer(l.writeBufferSize)
+	}
+	return conn, nil
 }
 
 func (na *NetAddr) Dial() (net.Conn, error) {
+	if na.Transport == TransportNamedPipe {
+		return dialNamedPipe(na.Endpoint)
+	}
 	return net.Dial(na.Transport, na.Endpoint)
 }
 
 func (na *NetAddr) Listen() (net.Listener, error) {
-	return net.Listen(na.Transport, na.Endpoint)
+	var mode os.FileMode
+	if na.SocketPermissions != "" {
+		if na.Transport != "unix" && na.Transport != "unixpacket" {
+			return nil, fmt.Errorf("socket_permissions are not supported with the %q transport", na.Transport)
+		}
+		perm, err := strconv.ParseUint(na.SocketPermissions, 8, 32)
+		if err != nil || perm > 0777 {
+			return nil, fmt.Errorf("invalid socket_permissions %q, expecting octal permissions such as \"0660\"", na.SocketPermissions)
+		}
+		mode = os.FileMode(perm)
+	}
+
+	if na.Transport == TransportNamedPipe {
+		return listenNamedPipe(na.Endpoint)
+	}
+	ln, err := na.SocketOptions.Listen(na.Transport, na.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if na.SocketPermissions != "" {
+		if err = os.Chmod(na.Endpoint, mode); err != nil {
+			ln.Close()
+			return nil, err
+		}
+	}
+	return ln, nil
 }
 
 // TCPAddr represents a tcp endpoint address.
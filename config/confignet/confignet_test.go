@@ -16,9 +16,14 @@ package confignet
 
 import (
 	"net"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNetAddr(t *testing.T) {
@@ -56,6 +61,92 @@ func TestNetAddr(t *testing.T) {
 	assert.NoError(t, ln.Close())
 }
 
+func TestNetAddrSocketPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	nas := &NetAddr{
+		Endpoint:          filepath.Join(t.TempDir(), "test.sock"),
+		Transport:         "unix",
+		SocketPermissions: "0600",
+	}
+	ln, err := nas.Listen()
+	require.NoError(t, err)
+	defer ln.Close()
+
+	info, err := os.Stat(nas.Endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	conn, err := nas.Dial()
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+}
+
+func TestNetAddrListenErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		addr NetAddr
+		err  string
+	}{
+		{
+			name: "permissions_tcp",
+			addr: NetAddr{Endpoint: "localhost:0", Transport: "tcp", SocketPermissions: "0600"},
+			err:  `socket_permissions are not supported with the "tcp" transport`,
+		},
+		{
+			name: "permissions_not_octal",
+			addr: NetAddr{Endpoint: "/tmp/test.sock", Transport: "unix", SocketPermissions: "rw"},
+			err:  `invalid socket_permissions "rw", expecting octal permissions such as "0660"`,
+		},
+		{
+			name: "permissions_too_large",
+			addr: NetAddr{Endpoint: "/tmp/test.sock", Transport: "unix", SocketPermissions: "01777"},
+			err:  `invalid socket_permissions "01777", expecting octal permissions such as "0660"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.addr.Listen()
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestSocketOptionsBufferSizes(t *testing.T) {
+	so := &SocketOptions{
+		TCPKeepAlive:    time.Minute,
+		ReadBufferSize:  64 * 1024,
+		WriteBufferSize: 64 * 1024,
+	}
+	ln, err := so.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	require.IsType(t, &bufferSizeListener{}, ln)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, errGo := ln.Accept()
+		assert.NoError(t, errGo)
+		assert.NoError(t, conn.Close())
+	}()
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	<-done
+	assert.NoError(t, conn.Close())
+
+	// Without buffer sizes the listener is not wrapped.
+	ln, err = (&SocketOptions{}).Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	_, wrapped := ln.(*bufferSizeListener)
+	assert.False(t, wrapped)
+	assert.NoError(t, ln.Close())
+
+	_, err = (&SocketOptions{ReadBufferSize: -1}).Listen("tcp", "localhost:0")
+	assert.EqualError(t, err, "socket_options buffer sizes must not be negative")
+}
+
 func TestTcpAddr(t *testing.T) {
 	nas := &TCPAddr{
 		Endpoint: "localhost:0",
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows
+
+package confignet
+
+import (
+	"errors"
+	"net"
+)
+
+var errNamedPipeUnsupported = errors.New("the npipe transport is only supported on Windows")
+
+func dialNamedPipe(string) (net.Conn, error) {
+	return nil, errNamedPipeUnsupported
+}
+
+func listenNamedPipe(string) (net.Listener, error) {
+	return nil, errNamedPipeUnsupported
+}
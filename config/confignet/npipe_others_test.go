@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+// +build !windows
+
+package confignet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetAddrNamedPipe(t *testing.T) {
+	nas := &NetAddr{
+		Endpoint:  `\\.\pipe\otelcol`,
+		Transport: TransportNamedPipe,
+	}
+	_, err := nas.Listen()
+	assert.Equal(t, errNamedPipeUnsupported, err)
+	_, err = nas.Dial()
+	assert.Equal(t, errNamedPipeUnsupported, err)
+}
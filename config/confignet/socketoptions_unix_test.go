@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux darwin freebsd openbsd netbsd dragonfly
+
+package confignet
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestSocketOptionsReusePort(t *testing.T) {
+	nas := &NetAddr{
+		Endpoint:      "localhost:0",
+		Transport:     "tcp",
+		SocketOptions: SocketOptions{ReusePort: true},
+	}
+	ln, err := nas.Listen()
+	require.NoError(t, err)
+	defer ln.Close()
+
+	// A second listener can bind the same address with reuse_port only.
+	nas.Endpoint = ln.Addr().String()
+	second, err := nas.Listen()
+	require.NoError(t, err)
+	assert.NoError(t, second.Close())
+
+	nas.SocketOptions.ReusePort = false
+	_, err = nas.Listen()
+	assert.Error(t, err)
+}
+
+func TestSocketOptionsReadBufferSize(t *testing.T) {
+	so := &SocketOptions{ReadBufferSize: 256 * 1024}
+	ln, err := so.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, errGo := ln.Accept()
+		assert.NoError(t, errGo)
+		accepted <- conn
+	}()
+	client, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	conn := <-accepted
+	defer conn.Close()
+
+	rawConn, err := conn.(*net.TCPConn).SyscallConn()
+	require.NoError(t, err)
+	var size int
+	var sockErr error
+	require.NoError(t, rawConn.Control(func(fd uintptr) {
+		size, sockErr = unix.GetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_RCVBUF)
+	}))
+	require.NoError(t, sockErr)
+	// Linux doubles the requested size to account for the bookkeeping overhead.
+	assert.GreaterOrEqual(t, size, 256*1024)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"time"
 )
 
 // TLSSetting exposes the common client and server TLS configurations.
@@ -34,6 +35,10 @@ type TLSSetting struct {
 	CertFile string `mapstructure:"cert_file"`
 	// Path to the TLS key to use for TLS required connections. (optional)
 	KeyFile string `mapstructure:"key_file"`
+	// ReloadInterval is the interval after which the certificates, the key and the CAs are reloaded from the files,
+	// so that the new connections use the files rotated on disk without restarting the collector. If zero the files
+	// are only loaded once. (optional)
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
 }
 
 // TLSClientSetting contains TLS configurations that are specific to client
@@ -120,11 +125,39 @@ func (c TLSSetting) loadCert(caPath string) (*x509.CertPool, error) {
 	return certPool, nil
 }
 
+// LoadTLSConfig loads the TLS configuration of a client. When ReloadInterval is set, the client certificate and the
+// CAs verifying the server certificate are reloaded from the files while the returned config is in use.
 func (c TLSClientSetting) LoadTLSConfig() (*tls.Config, error) {
 	if c.Insecure && c.CAFile == "" {
 		return nil, nil
 	}
+	if c.ReloadInterval <= 0 {
+		return c.loadClientTLSConfig()
+	}
+
+	r, err := newConfigReloader(c.ReloadInterval, c.loadClientTLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg := r.current().Clone()
+	if c.CertFile != "" {
+		tlsCfg.Certificates = nil
+		tlsCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return &r.current().Certificates[0], nil
+		}
+	}
+	if c.CAFile != "" && !c.InsecureSkipVerify {
+		// The CAs used by the standard verification cannot be swapped, the server certificate is verified against
+		// the reloaded CAs instead.
+		tlsCfg.InsecureSkipVerify = true
+		tlsCfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyServerCertificate(cs, r.current().RootCAs, c.ServerName)
+		}
+	}
+	return tlsCfg, nil
+}
 
+func (c TLSClientSetting) loadClientTLSConfig() (*tls.Config, error) {
 	tlsCfg, err := c.TLSSetting.loadTLSConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load TLS config: %w", err)
@@ -134,7 +167,28 @@ func (c TLSClientSetting) LoadTLSConfig() (*tls.Config, error) {
 	return tlsCfg, nil
 }
 
+// LoadTLSConfig loads the TLS configuration of a server. When ReloadInterval is set, the returned config selects
+// for every connection the last config reloaded from the files. The protocols negotiated by the server, if any, must
+// be set on the returned config.
 func (c TLSServerSetting) LoadTLSConfig() (*tls.Config, error) {
+	if c.ReloadInterval <= 0 {
+		return c.loadServerTLSConfig()
+	}
+
+	r, err := newConfigReloader(c.ReloadInterval, c.loadServerTLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg := &tls.Config{}
+	tlsCfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cfg := r.current().Clone()
+		cfg.NextProtos = tlsCfg.NextProtos
+		return cfg, nil
+	}
+	return tlsCfg, nil
+}
+
+func (c TLSServerSetting) loadServerTLSConfig() (*tls.Config, error) {
 	tlsCfg, err := c.loadTLSConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load TLS config: %w", err)
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"sync"
+	"time"
+)
+
+var errUnknownServerName = errors.New("the server name is required to verify the server certificate with the " +
+	"reloaded CAs, set server_name_override when the server is reached with an IP address")
+
+// configReloader holds the last TLS config loaded from the files and reloads it once the interval elapsed.
+type configReloader struct {
+	interval time.Duration
+	load     func() (*tls.Config, error)
+
+	mu       sync.Mutex
+	config   *tls.Config
+	loadedAt time.Time
+}
+
+func newConfigReloader(interval time.Duration, load func() (*tls.Config, error)) (*configReloader, error) {
+	cfg, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &configReloader{
+		interval: interval,
+		load:     load,
+		config:   cfg,
+		loadedAt: time.Now(),
+	}, nil
+}
+
+// current returns the last loaded config, reloading it first when the interval elapsed. When the files cannot be
+// loaded, for instance while they are being rotated, the previous config is kept until the next reload.
+func (r *configReloader) current() *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.loadedAt) >= r.interval {
+		if cfg, err := r.load(); err == nil {
+			r.config = cfg
+		}
+		r.loadedAt = time.Now()
+	}
+	return r.config
+}
+
+// verifyServerCertificate verifies the certificate chain sent by the server like the standard verification of a
+// client does, the server name is the one sent in the handshake or the configured override.
+func verifyServerCertificate(cs tls.ConnectionState, roots *x509.CertPool, serverName string) error {
+	if cs.ServerName != "" {
+		serverName = cs.ServerName
+	}
+	if serverName == "" {
+		return errUnknownServerName
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("the server did not send a certificate")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       serverName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReloadInterval = 10 * time.Millisecond
+
+// testCertificate is a certificate with its key, issued by the CA or self-signed when the CA is nil.
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCertificate(t *testing.T, ca *testCertificate, commonName string) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	parent, parentKey := template, key
+	if ca == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		template.DNSNames = []string{"localhost"}
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+		parent, parentKey = ca.cert, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCertificate{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(c.pem, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	require.NoError(t, err)
+	return cert
+}
+
+// writeFiles writes the certificate and its key to cert.pem and key.pem in dir.
+func (c *testCertificate) writeFiles(t *testing.T, dir string) {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cert.pem"), c.pem, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+}
+
+func newTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "configtls")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// startTLSServer accepts TLS connections and completes their handshake until the test ends.
+func startTLSServer(t *testing.T, tlsCfg *tls.Config) string {
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", tlsCfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestLoadTLSServerConfigReload(t *testing.T) {
+	dir := newTempDir(t)
+	ca := newTestCertificate(t, nil, "ca")
+	first := newTestCertificate(t, ca, "first")
+	first.writeFiles(t, dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ca.pem"), ca.pem, 0600))
+
+	tlsSetting := TLSServerSetting{
+		TLSSetting: TLSSetting{
+			CertFile:       filepath.Join(dir, "cert.pem"),
+			KeyFile:        filepath.Join(dir, "key.pem"),
+			ReloadInterval: testReloadInterval,
+		},
+		ClientCAFile: filepath.Join(dir, "ca.pem"),
+	}
+	tlsCfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, tlsCfg.GetConfigForClient)
+	tlsCfg.NextProtos = []string{"h2"}
+
+	cfg, err := tlsCfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, first.cert.Raw, cfg.Certificates[0].Certificate[0])
+	assert.Equal(t, tls.RequireAndVerifyClientCert, cfg.ClientAuth)
+	assert.Equal(t, []string{"h2"}, cfg.NextProtos)
+
+	// The certificate is only reloaded once the interval elapsed.
+	second := newTestCertificate(t, ca, "second")
+	second.writeFiles(t, dir)
+	cfg, err = tlsCfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, first.cert.Raw, cfg.Certificates[0].Certificate[0])
+
+	time.Sleep(2 * testReloadInterval)
+	cfg, err = tlsCfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, second.cert.Raw, cfg.Certificates[0].Certificate[0])
+
+	// The previous config is kept while the files are invalid.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key.pem"), []byte("invalid"), 0600))
+	time.Sleep(2 * testReloadInterval)
+	cfg, err = tlsCfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, second.cert.Raw, cfg.Certificates[0].Certificate[0])
+}
+
+func TestLoadTLSServerConfigReloadError(t *testing.T) {
+	tlsSetting := TLSServerSetting{
+		TLSSetting: TLSSetting{
+			CertFile:       "doesnt/exist",
+			KeyFile:        "doesnt/exist",
+			ReloadInterval: testReloadInterval,
+		},
+	}
+	_, err := tlsSetting.LoadTLSConfig()
+	assert.Error(t, err)
+}
+
+func TestLoadTLSClientConfigReload(t *testing.T) {
+	oldCA := newTestCertificate(t, nil, "old-ca")
+	newCA := newTestCertificate(t, nil, "new-ca")
+	oldServer := startTLSServer(t, &tls.Config{Certificates: []tls.Certificate{newTestCertificate(t, oldCA, "server").tlsCertificate(t)}})
+	newServer := startTLSServer(t, &tls.Config{Certificates: []tls.Certificate{newTestCertificate(t, newCA, "server").tlsCertificate(t)}})
+
+	dir := newTempDir(t)
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, ioutil.WriteFile(caFile, oldCA.pem, 0600))
+	tlsSetting := TLSClientSetting{
+		TLSSetting: TLSSetting{
+			CAFile:         caFile,
+			ReloadInterval: testReloadInterval,
+		},
+	}
+	tlsCfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+
+	dial := func(addr string) error {
+		_, port, err := net.SplitHostPort(addr)
+		require.NoError(t, err)
+		conn, err := tls.Dial("tcp", net.JoinHostPort("localhost", port), tlsCfg)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	assert.NoError(t, dial(oldServer))
+	assert.Error(t, dial(newServer))
+
+	require.NoError(t, ioutil.WriteFile(caFile, newCA.pem, 0600))
+	time.Sleep(2 * testReloadInterval)
+	assert.Error(t, dial(oldServer))
+	assert.NoError(t, dial(newServer))
+
+	// The server name is not sent for an IP address, it has to be configured.
+	_, err = tls.Dial("tcp", newServer, tlsCfg)
+	assert.Error(t, err)
+	tlsSetting.ServerName = "127.0.0.1"
+	tlsCfg, err = tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	conn, err := tls.Dial("tcp", newServer, tlsCfg)
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestLoadTLSClientConfigReloadCertificate(t *testing.T) {
+	dir := newTempDir(t)
+	ca := newTestCertificate(t, nil, "ca")
+	first := newTestCertificate(t, ca, "first")
+	first.writeFiles(t, dir)
+
+	tlsSetting := TLSClientSetting{
+		TLSSetting: TLSSetting{
+			CertFile:       filepath.Join(dir, "cert.pem"),
+			KeyFile:        filepath.Join(dir, "key.pem"),
+			ReloadInterval: testReloadInterval,
+		},
+	}
+	tlsCfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	assert.Empty(t, tlsCfg.Certificates)
+	assert.False(t, tlsCfg.InsecureSkipVerify)
+
+	cert, err := tlsCfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, first.cert.Raw, cert.Certificate[0])
+
+	second := newTestCertificate(t, ca, "second")
+	second.writeFiles(t, dir)
+	time.Sleep(2 * testReloadInterval)
+	cert, err = tlsCfg.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, second.cert.Raw, cert.Certificate[0])
+}
@@ -0,0 +1,304 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connectorhelper provides helpers to implement the connectors.
+package connectorhelper
+
+import (
+	"context"
+
+	"github.com/spf13/viper"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// FactoryOption apply changes to ConnectorOptions.
+type FactoryOption func(o *factory)
+
+// CreateDefaultConfig is the equivalent of component.ConnectorFactory.CreateDefaultConfig()
+type CreateDefaultConfig func() configmodels.Connector
+
+// CreateTracesToTracesConnector is the equivalent of component.ConnectorFactory.CreateTracesToTracesConnector()
+type CreateTracesToTracesConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Traces) (component.TracesConnector, error)
+
+// CreateTracesToMetricsConnector is the equivalent of component.ConnectorFactory.CreateTracesToMetricsConnector()
+type CreateTracesToMetricsConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Metrics) (component.TracesConnector, error)
+
+// CreateTracesToLogsConnector is the equivalent of component.ConnectorFactory.CreateTracesToLogsConnector()
+type CreateTracesToLogsConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Logs) (component.TracesConnector, error)
+
+// CreateMetricsToTracesConnector is the equivalent of component.ConnectorFactory.CreateMetricsToTracesConnector()
+type CreateMetricsToTracesConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Traces) (component.MetricsConnector, error)
+
+// CreateMetricsToMetricsConnector is the equivalent of component.ConnectorFactory.CreateMetricsToMetricsConnector()
+type CreateMetricsToMetricsConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Metrics) (component.MetricsConnector, error)
+
+// CreateMetricsToLogsConnector is the equivalent of component.ConnectorFactory.CreateMetricsToLogsConnector()
+type CreateMetricsToLogsConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Logs) (component.MetricsConnector, error)
+
+// CreateLogsToTracesConnector is the equivalent of component.ConnectorFactory.CreateLogsToTracesConnector()
+type CreateLogsToTracesConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Traces) (component.LogsConnector, error)
+
+// CreateLogsToMetricsConnector is the equivalent of component.ConnectorFactory.CreateLogsToMetricsConnector()
+type CreateLogsToMetricsConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Metrics) (component.LogsConnector, error)
+
+// CreateLogsToLogsConnector is the equivalent of component.ConnectorFactory.CreateLogsToLogsConnector()
+type CreateLogsToLogsConnector func(context.Context, component.ConnectorCreateParams, configmodels.Connector, consumer.Logs) (component.LogsConnector, error)
+
+type factory struct {
+	cfgType                         configmodels.Type
+	customUnmarshaler               component.CustomUnmarshaler
+	createDefaultConfig             CreateDefaultConfig
+	createTracesToTracesConnector   CreateTracesToTracesConnector
+	createTracesToMetricsConnector  CreateTracesToMetricsConnector
+	createTracesToLogsConnector     CreateTracesToLogsConnector
+	createMetricsToTracesConnector  CreateMetricsToTracesConnector
+	createMetricsToMetricsConnector CreateMetricsToMetricsConnector
+	createMetricsToLogsConnector    CreateMetricsToLogsConnector
+	createLogsToTracesConnector     CreateLogsToTracesConnector
+	createLogsToMetricsConnector    CreateLogsToMetricsConnector
+	createLogsToLogsConnector       CreateLogsToLogsConnector
+}
+
+// WithCustomUnmarshaler implements component.ConfigUnmarshaler.
+func WithCustomUnmarshaler(customUnmarshaler component.CustomUnmarshaler) FactoryOption {
+	return func(o *factory) {
+		o.customUnmarshaler = customUnmarshaler
+	}
+}
+
+// WithTracesToTraces overrides the default "error not supported" implementation for CreateTracesToTracesConnector.
+func WithTracesToTraces(create CreateTracesToTracesConnector) FactoryOption {
+	return func(o *factory) {
+		o.createTracesToTracesConnector = create
+	}
+}
+
+// WithTracesToMetrics overrides the default "error not supported" implementation for CreateTracesToMetricsConnector.
+func WithTracesToMetrics(create CreateTracesToMetricsConnector) FactoryOption {
+	return func(o *factory) {
+		o.createTracesToMetricsConnector = create
+	}
+}
+
+// WithTracesToLogs overrides the default "error not supported" implementation for CreateTracesToLogsConnector.
+func WithTracesToLogs(create CreateTracesToLogsConnector) FactoryOption {
+	return func(o *factory) {
+		o.createTracesToLogsConnector = create
+	}
+}
+
+// WithMetricsToTraces overrides the default "error not supported" implementation for CreateMetricsToTracesConnector.
+func WithMetricsToTraces(create CreateMetricsToTracesConnector) FactoryOption {
+	return func(o *factory) {
+		o.createMetricsToTracesConnector = create
+	}
+}
+
+// WithMetricsToMetrics overrides the default "error not supported" implementation for CreateMetricsToMetricsConnector.
+func WithMetricsToMetrics(create CreateMetricsToMetricsConnector) FactoryOption {
+	return func(o *factory) {
+		o.createMetricsToMetricsConnector = create
+	}
+}
+
+// WithMetricsToLogs overrides the default "error not supported" implementation for CreateMetricsToLogsConnector.
+func WithMetricsToLogs(create CreateMetricsToLogsConnector) FactoryOption {
+	return func(o *factory) {
+		o.createMetricsToLogsConnector = create
+	}
+}
+
+// WithLogsToTraces overrides the default "error not supported" implementation for CreateLogsToTracesConnector.
+func WithLogsToTraces(create CreateLogsToTracesConnector) FactoryOption {
+	return func(o *factory) {
+		o.createLogsToTracesConnector = create
+	}
+}
+
+// WithLogsToMetrics overrides the default "error not supported" implementation for CreateLogsToMetricsConnector.
+func WithLogsToMetrics(create CreateLogsToMetricsConnector) FactoryOption {
+	return func(o *factory) {
+		o.createLogsToMetricsConnector = create
+	}
+}
+
+// WithLogsToLogs overrides the default "error not supported" implementation for CreateLogsToLogsConnector.
+func WithLogsToLogs(create CreateLogsToLogsConnector) FactoryOption {
+	return func(o *factory) {
+		o.createLogsToLogsConnector = create
+	}
+}
+
+// NewFactory returns a component.ConnectorFactory.
+func NewFactory(
+	cfgType configmodels.Type,
+	createDefaultConfig CreateDefaultConfig,
+	options ...FactoryOption) component.ConnectorFactory {
+	f := &factory{
+		cfgType:             cfgType,
+		createDefaultConfig: createDefaultConfig,
+	}
+	for _, opt := range options {
+		opt(f)
+	}
+	var ret component.ConnectorFactory
+	if f.customUnmarshaler != nil {
+		ret = &factoryWithUnmarshaler{f}
+	} else {
+		ret = f
+	}
+	return ret
+}
+
+// Type gets the type of the Connector config created by this factory.
+func (f *factory) Type() configmodels.Type {
+	return f.cfgType
+}
+
+// CreateDefaultConfig creates the default configuration for connector.
+func (f *factory) CreateDefaultConfig() configmodels.Connector {
+	return f.createDefaultConfig()
+}
+
+// CreateTracesToTracesConnector creates a component.TracesConnector producing traces based on this config.
+func (f *factory) CreateTracesToTracesConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Traces,
+) (component.TracesConnector, error) {
+	if f.createTracesToTracesConnector != nil {
+		return f.createTracesToTracesConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateTracesToMetricsConnector creates a component.TracesConnector producing metrics based on this config.
+func (f *factory) CreateTracesToMetricsConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Metrics,
+) (component.TracesConnector, error) {
+	if f.createTracesToMetricsConnector != nil {
+		return f.createTracesToMetricsConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateTracesToLogsConnector creates a component.TracesConnector producing logs based on this config.
+func (f *factory) CreateTracesToLogsConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Logs,
+) (component.TracesConnector, error) {
+	if f.createTracesToLogsConnector != nil {
+		return f.createTracesToLogsConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateMetricsToTracesConnector creates a component.MetricsConnector producing traces based on this config.
+func (f *factory) CreateMetricsToTracesConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Traces,
+) (component.MetricsConnector, error) {
+	if f.createMetricsToTracesConnector != nil {
+		return f.createMetricsToTracesConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateMetricsToMetricsConnector creates a component.MetricsConnector producing metrics based on this config.
+func (f *factory) CreateMetricsToMetricsConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Metrics,
+) (component.MetricsConnector, error) {
+	if f.createMetricsToMetricsConnector != nil {
+		return f.createMetricsToMetricsConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateMetricsToLogsConnector creates a component.MetricsConnector producing logs based on this config.
+func (f *factory) CreateMetricsToLogsConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Logs,
+) (component.MetricsConnector, error) {
+	if f.createMetricsToLogsConnector != nil {
+		return f.createMetricsToLogsConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateLogsToTracesConnector creates a component.LogsConnector producing traces based on this config.
+func (f *factory) CreateLogsToTracesConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Traces,
+) (component.LogsConnector, error) {
+	if f.createLogsToTracesConnector != nil {
+		return f.createLogsToTracesConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateLogsToMetricsConnector creates a component.LogsConnector producing metrics based on this config.
+func (f *factory) CreateLogsToMetricsConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Metrics,
+) (component.LogsConnector, error) {
+	if f.createLogsToMetricsConnector != nil {
+		return f.createLogsToMetricsConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+// CreateLogsToLogsConnector creates a component.LogsConnector producing logs based on this config.
+func (f *factory) CreateLogsToLogsConnector(
+	ctx context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Logs,
+) (component.LogsConnector, error) {
+	if f.createLogsToLogsConnector != nil {
+		return f.createLogsToLogsConnector(ctx, params, cfg, nextConsumer)
+	}
+	return nil, configerror.ErrDataTypeIsNotSupported
+}
+
+var _ component.ConfigUnmarshaler = (*factoryWithUnmarshaler)(nil)
+
+type factoryWithUnmarshaler struct {
+	*factory
+}
+
+// Unmarshal un-marshals the config using the provided custom unmarshaler.
+func (f *factoryWithUnmarshaler) Unmarshal(componentViperSection *viper.Viper, intoCfg interface{}) error {
+	return f.customUnmarshaler(componentViperSection, intoCfg)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package forwardconnector implements a connector forwarding the data exported by its pipelines to the pipelines
+// receiving from it, for example to share the processing of several pipelines of the same data type.
+package forwardconnector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/connector/connectorhelper"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "forward"
+)
+
+// NewFactory returns a factory for the forward connector.
+func NewFactory() component.ConnectorFactory {
+	return connectorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		connectorhelper.WithTracesToTraces(createTracesToTraces),
+		connectorhelper.WithMetricsToMetrics(createMetricsToMetrics),
+		connectorhelper.WithLogsToLogs(createLogsToLogs))
+}
+
+func createDefaultConfig() configmodels.Connector {
+	return &Config{
+		ConnectorSettings: configmodels.ConnectorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+	}
+}
+
+func createTracesToTraces(
+	_ context.Context,
+	_ component.ConnectorCreateParams,
+	_ configmodels.Connector,
+	nextConsumer consumer.Traces,
+) (component.TracesConnector, error) {
+	return &forward{Component: componenthelper.New(), Traces: nextConsumer}, nil
+}
+
+func createMetricsToMetrics(
+	_ context.Context,
+	_ component.ConnectorCreateParams,
+	_ configmodels.Connector,
+	nextConsumer consumer.Metrics,
+) (component.MetricsConnector, error) {
+	return &forward{Component: componenthelper.New(), Metrics: nextConsumer}, nil
+}
+
+func createLogsToLogs(
+	_ context.Context,
+	_ component.ConnectorCreateParams,
+	_ configmodels.Connector,
+	nextConsumer consumer.Logs,
+) (component.LogsConnector, error) {
+	return &forward{Component: componenthelper.New(), Logs: nextConsumer}, nil
+}
+
+// forward passes the consumed data to the next consumer unchanged, only the consumer of the data type it was created
+// for is set.
+type forward struct {
+	component.Component
+	consumer.Traces
+	consumer.Metrics
+	consumer.Logs
+}
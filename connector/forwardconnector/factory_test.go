@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestForwardTraces(t *testing.T) {
+	factory := NewFactory()
+	sink := new(consumertest.TracesSink)
+	conn, err := factory.CreateTracesToTracesConnector(context.Background(), component.ConnectorCreateParams{Logger: zap.NewNop()}, factory.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	td := testdata.GenerateTraceDataOneSpan()
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, conn.Shutdown(context.Background()))
+	assert.Equal(t, []pdata.Traces{td}, sink.AllTraces())
+}
+
+func TestForwardMetrics(t *testing.T) {
+	factory := NewFactory()
+	sink := new(consumertest.MetricsSink)
+	conn, err := factory.CreateMetricsToMetricsConnector(context.Background(), component.ConnectorCreateParams{Logger: zap.NewNop()}, factory.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	md := testdata.GenerateMetricsOneMetric()
+	assert.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, 1, sink.MetricsCount())
+}
+
+func TestForwardLogs(t *testing.T) {
+	factory := NewFactory()
+	sink := new(consumertest.LogsSink)
+	conn, err := factory.CreateLogsToLogsConnector(context.Background(), component.ConnectorCreateParams{Logger: zap.NewNop()}, factory.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	ld := testdata.GenerateLogDataOneLog()
+	assert.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 1, sink.LogRecordsCount())
+}
+
+func TestUnsupportedDataTypes(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	params := component.ConnectorCreateParams{Logger: zap.NewNop()}
+
+	_, err := factory.CreateTracesToMetricsConnector(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	_, err = factory.CreateMetricsToLogsConnector(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	_, err = factory.CreateLogsToTracesConnector(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+)
+
+// Config defines configuration for the log metrics connector.
+type Config struct {
+	configmodels.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Metrics are the counters of the matching log records.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+
+	// MetricsFlushInterval is the interval at which the cumulative metrics are emitted.
+	MetricsFlushInterval time.Duration `mapstructure:"metrics_flush_interval"`
+}
+
+// MetricConfig defines a counter of the log records matching all its conditions.
+type MetricConfig struct {
+	// Name of the metric.
+	Name string `mapstructure:"name"`
+
+	// Description of the metric.
+	Description string `mapstructure:"description"`
+
+	// MinSeverity is the lowest severity of the counted log records, one of TRACE, DEBUG, INFO, WARN, ERROR and
+	// FATAL. All the log records are counted when empty.
+	MinSeverity string `mapstructure:"min_severity"`
+
+	// Match restricts the counted log records by name, attributes, resource or instrumentation library.
+	Match *filterconfig.MatchProperties `mapstructure:"match"`
+
+	// BodyRegexp restricts the counted log records to the ones whose body matches the regular expression, its named
+	// capture groups are added as labels to the metric.
+	BodyRegexp string `mapstructure:"body_regexp"`
+
+	// Dimensions are the attributes added as labels to the metric.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+}
+
+// Dimension is an attribute added as a label to the metric. The value is read in the log record attributes first,
+// then in the resource attributes.
+type Dimension struct {
+	// Name of the attribute, also used as the name of the label.
+	Name string `mapstructure:"name"`
+
+	// Default is the value of the label when the attribute is missing, the label is omitted when nil.
+	Default *string `mapstructure:"default"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the metrics have a unique name and valid conditions.
+func (cfg *Config) Validate() error {
+	if cfg.MetricsFlushInterval <= 0 {
+		return errors.New("metrics_flush_interval must be positive")
+	}
+	names := make(map[string]bool, len(cfg.Metrics))
+	for _, m := range cfg.Metrics {
+		if m.Name == "" {
+			return errors.New("metrics must have a name")
+		}
+		if names[m.Name] {
+			return fmt.Errorf("duplicate metric %q", m.Name)
+		}
+		names[m.Name] = true
+		if _, ok := severities[m.MinSeverity]; m.MinSeverity != "" && !ok {
+			return fmt.Errorf("metric %q: unknown min_severity %q", m.Name, m.MinSeverity)
+		}
+		if m.Match != nil {
+			if err := m.Match.ValidateForLogs(); err != nil {
+				return fmt.Errorf("metric %q: %w", m.Name, err)
+			}
+		}
+		if _, err := regexp.Compile(m.BodyRegexp); err != nil {
+			return fmt.Errorf("metric %q: invalid body_regexp: %w", m.Name, err)
+		}
+	}
+	return nil
+}
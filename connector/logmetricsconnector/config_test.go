@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Connectors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Connectors["logmetrics"])
+
+	unknown := "unknown"
+	assert.Equal(t,
+		&Config{
+			ConnectorSettings: configmodels.ConnectorSettings{
+				TypeVal: "logmetrics",
+				NameVal: "logmetrics/custom",
+			},
+			Metrics: []MetricConfig{
+				{
+					Name:        "error_logs_total",
+					Description: "Number of the error log records by service.",
+					MinSeverity: "ERROR",
+					Dimensions:  []Dimension{{Name: "service.name", Default: &unknown}},
+				},
+				{
+					Name: "http_requests_total",
+					Match: &filterconfig.MatchProperties{
+						Config:   filterset.Config{MatchType: filterset.Strict},
+						LogNames: []string{"access"},
+					},
+					BodyRegexp: `"(?P<method>[A-Z]+) [^ ]+" (?P<status>\d{3})`,
+				},
+			},
+			MetricsFlushInterval: 30 * time.Second,
+		},
+		cfg.Connectors["logmetrics/custom"])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Metrics = []MetricConfig{{}}
+	assert.EqualError(t, cfg.Validate(), "metrics must have a name")
+
+	cfg.Metrics = []MetricConfig{{Name: "logs_total"}, {Name: "logs_total"}}
+	assert.EqualError(t, cfg.Validate(), `duplicate metric "logs_total"`)
+
+	cfg.Metrics = []MetricConfig{{Name: "logs_total", MinSeverity: "CRITICAL"}}
+	assert.EqualError(t, cfg.Validate(), `metric "logs_total": unknown min_severity "CRITICAL"`)
+
+	cfg.Metrics = []MetricConfig{{Name: "logs_total", Match: &filterconfig.MatchProperties{}}}
+	assert.EqualError(t, cfg.Validate(),
+		`metric "logs_total": at least one of "log_names", "attributes", "libraries" or "resources" field must be specified`)
+
+	cfg.Metrics = []MetricConfig{{Name: "logs_total", BodyRegexp: "("}}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Metrics = nil
+	cfg.MetricsFlushInterval = 0
+	assert.EqualError(t, cfg.Validate(), "metrics_flush_interval must be positive")
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// severities are the lowest severity numbers of the severity names.
+var severities = map[string]pdata.SeverityNumber{
+	"TRACE": pdata.SeverityNumberTRACE,
+	"DEBUG": pdata.SeverityNumberDEBUG,
+	"INFO":  pdata.SeverityNumberINFO,
+	"WARN":  pdata.SeverityNumberWARN,
+	"ERROR": pdata.SeverityNumberERROR,
+	"FATAL": pdata.SeverityNumberFATAL,
+}
+
+// counter counts the matching log records by labels.
+type counter struct {
+	cfg         MetricConfig
+	minSeverity pdata.SeverityNumber
+	matcher     filterlog.Matcher
+	bodyRegexp  *regexp.Regexp
+	series      map[string]*series
+}
+
+// series is the count of the log records with the same labels.
+type series struct {
+	labels map[string]string
+	count  int64
+}
+
+// logMetrics counts the log records it consumes into cumulative metrics, emitted every MetricsFlushInterval and when
+// it shuts down.
+type logMetrics struct {
+	config       *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+	startTime    pdata.Timestamp
+
+	mu       sync.Mutex
+	counters []*counter
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ component.LogsConnector = (*logMetrics)(nil)
+
+func newLogMetrics(logger *zap.Logger, cfg *Config, nextConsumer consumer.Metrics) (*logMetrics, error) {
+	lm := &logMetrics{
+		config:       cfg,
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		done:         make(chan struct{}),
+	}
+	for _, mCfg := range cfg.Metrics {
+		c := &counter{cfg: mCfg, minSeverity: severities[mCfg.MinSeverity], series: make(map[string]*series)}
+		var err error
+		if c.matcher, err = filterlog.NewMatcher(mCfg.Match); err != nil {
+			return nil, err
+		}
+		if mCfg.BodyRegexp != "" {
+			if c.bodyRegexp, err = regexp.Compile(mCfg.BodyRegexp); err != nil {
+				return nil, err
+			}
+		}
+		lm.counters = append(lm.counters, c)
+	}
+	return lm, nil
+}
+
+// Start starts emitting the metrics every MetricsFlushInterval.
+func (lm *logMetrics) Start(context.Context, component.Host) error {
+	lm.startTime = pdata.TimestampFromTime(time.Now())
+	lm.wg.Add(1)
+	go func() {
+		defer lm.wg.Done()
+		ticker := time.NewTicker(lm.config.MetricsFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				lm.flush(context.Background())
+			case <-lm.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops emitting the metrics, they are emitted a last time.
+func (lm *logMetrics) Shutdown(ctx context.Context) error {
+	close(lm.done)
+	lm.wg.Wait()
+	lm.flush(ctx)
+	return nil
+}
+
+// ConsumeLogs counts the matching log records.
+func (lm *logMetrics) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			il := ills.At(j)
+			logs := il.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				for _, c := range lm.counters {
+					c.count(logs.At(k), rl.Resource(), il.InstrumentationLibrary())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// count adds the log record to its series if it matches, it is called with the lock held.
+func (c *counter) count(lr pdata.LogRecord, resource pdata.Resource, library pdata.InstrumentationLibrary) {
+	if lr.SeverityNumber() < c.minSeverity {
+		return
+	}
+	if c.matcher != nil && !c.matcher.MatchLogRecord(lr, resource, library) {
+		return
+	}
+
+	labels := make(map[string]string)
+	var key strings.Builder
+	if c.bodyRegexp != nil {
+		body := tracetranslator.AttributeValueToString(lr.Body(), false)
+		match := c.bodyRegexp.FindStringSubmatch(body)
+		if match == nil {
+			return
+		}
+		for i, name := range c.bodyRegexp.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			labels[name] = match[i]
+			key.WriteString(match[i])
+			key.WriteByte(0)
+		}
+	}
+	for _, d := range c.cfg.Dimensions {
+		value, ok := dimensionValue(d, lr.Attributes(), resource.Attributes())
+		if !ok {
+			// Tell a missing label from an empty one.
+			key.WriteByte(1)
+			continue
+		}
+		labels[d.Name] = value
+		key.WriteString(value)
+		key.WriteByte(0)
+	}
+
+	s, ok := c.series[key.String()]
+	if !ok {
+		s = &series{labels: labels}
+		c.series[key.String()] = s
+	}
+	s.count++
+}
+
+// dimensionValue returns the value of the dimension in the log record attributes, the resource attributes or its
+// default.
+func dimensionValue(d Dimension, recordAttrs, resourceAttrs pdata.AttributeMap) (string, bool) {
+	if v, ok := recordAttrs.Get(d.Name); ok {
+		return tracetranslator.AttributeValueToString(v, false), true
+	}
+	if v, ok := resourceAttrs.Get(d.Name); ok {
+		return tracetranslator.AttributeValueToString(v, false), true
+	}
+	if d.Default != nil {
+		return *d.Default, true
+	}
+	return "", false
+}
+
+// flush emits the cumulative metrics of all the counters.
+func (lm *logMetrics) flush(ctx context.Context) {
+	md, ok := lm.buildMetrics()
+	if !ok {
+		return
+	}
+	if err := lm.nextConsumer.ConsumeMetrics(ctx, md); err != nil {
+		lm.logger.Error("Failed to emit the log metrics.", zap.Error(err))
+	}
+}
+
+// buildMetrics returns the metrics of the counters that counted log records, or false when none did.
+func (lm *logMetrics) buildMetrics() (pdata.Metrics, bool) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	now := pdata.TimestampFromTime(time.Now())
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for _, c := range lm.counters {
+		if len(c.series) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(c.series))
+		for key := range c.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		m := metrics.AppendEmpty()
+		m.SetName(c.cfg.Name)
+		m.SetDescription(c.cfg.Description)
+		m.SetUnit("1")
+		m.SetDataType(pdata.MetricDataTypeIntSum)
+		m.IntSum().SetIsMonotonic(true)
+		m.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		for _, key := range keys {
+			s := c.series[key]
+			dp := m.IntSum().DataPoints().AppendEmpty()
+			dp.LabelsMap().InitFromMap(s.labels)
+			dp.SetStartTime(lm.startTime)
+			dp.SetTimestamp(now)
+			dp.SetValue(s.count)
+		}
+	}
+	return md, metrics.Len() > 0
+}
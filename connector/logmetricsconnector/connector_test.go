@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// addLog appends a log record of the service with the given name, severity and body.
+func addLog(ld pdata.Logs, service, name string, severity pdata.SeverityNumber, body string) {
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().UpsertString(conventions.AttributeServiceName, service)
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetName(name)
+	lr.SetSeverityNumber(severity)
+	lr.Body().SetStringVal(body)
+}
+
+// dataPoints returns the values of the data points of the metric by their labels.
+func dataPoints(m pdata.Metric) map[string]int64 {
+	values := map[string]int64{}
+	dps := m.IntSum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := ""
+		dp.LabelsMap().Sort().ForEach(func(k, v string) {
+			key += k + "=" + v + ";"
+		})
+		values[key] = dp.Value()
+	}
+	return values
+}
+
+func TestLogMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics = []MetricConfig{
+		{
+			Name:        "error_logs_total",
+			MinSeverity: "ERROR",
+			Dimensions:  []Dimension{{Name: conventions.AttributeServiceName}},
+		},
+		{
+			Name: "http_requests_total",
+			Match: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				LogNames: []string{"access"},
+			},
+			BodyRegexp: `"(?P<method>[A-Z]+) [^ ]+" (?P<status>\d{3})`,
+		},
+		{
+			Name: "unmatched_total",
+			Match: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				LogNames: []string{"audit"},
+			},
+		},
+	}
+	cfg.MetricsFlushInterval = time.Hour
+	sink := new(consumertest.MetricsSink)
+	lm, err := newLogMetrics(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, lm.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := pdata.NewLogs()
+	addLog(ld, "frontend", "app", pdata.SeverityNumberERROR, "connection refused")
+	addLog(ld, "frontend", "app", pdata.SeverityNumberFATAL, "out of memory")
+	addLog(ld, "frontend", "app", pdata.SeverityNumberWARN, "slow request")
+	addLog(ld, "backend", "app", pdata.SeverityNumberERROR2, "connection refused")
+	addLog(ld, "frontend", "access", pdata.SeverityNumberINFO, `"GET /cart" 200`)
+	addLog(ld, "frontend", "access", pdata.SeverityNumberINFO, `"GET /cart" 200`)
+	addLog(ld, "frontend", "access", pdata.SeverityNumberINFO, `"POST /checkout" 500`)
+	addLog(ld, "frontend", "access", pdata.SeverityNumberINFO, "malformed")
+	require.NoError(t, lm.ConsumeLogs(context.Background(), ld))
+
+	// The metrics are emitted when shutting down, the metrics without any matching log record are omitted.
+	require.NoError(t, lm.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	errors := metrics.At(0)
+	assert.Equal(t, "error_logs_total", errors.Name())
+	assert.True(t, errors.IntSum().IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, errors.IntSum().AggregationTemporality())
+	assert.Equal(t, map[string]int64{
+		"service.name=frontend;": 2,
+		"service.name=backend;":  1,
+	}, dataPoints(errors))
+
+	requests := metrics.At(1)
+	assert.Equal(t, "http_requests_total", requests.Name())
+	assert.Equal(t, map[string]int64{
+		"method=GET;status=200;":  2,
+		"method=POST;status=500;": 1,
+	}, dataPoints(requests))
+}
+
+func TestLogMetricsMissingDimension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	empty := ""
+	cfg.Metrics = []MetricConfig{
+		{Name: "logs_total", Dimensions: []Dimension{{Name: "missing"}}},
+		{Name: "logs_with_default_total", Dimensions: []Dimension{{Name: "missing", Default: &empty}}},
+	}
+	cfg.MetricsFlushInterval = time.Hour
+	sink := new(consumertest.MetricsSink)
+	lm, err := newLogMetrics(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, lm.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := pdata.NewLogs()
+	addLog(ld, "frontend", "app", pdata.SeverityNumberINFO, "started")
+	require.NoError(t, lm.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, lm.Shutdown(context.Background()))
+
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, map[string]int64{"": 1}, dataPoints(metrics.At(0)))
+	assert.Equal(t, map[string]int64{"missing=;": 1}, dataPoints(metrics.At(1)))
+}
+
+func TestLogMetricsFlushInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics = []MetricConfig{{Name: "logs_total"}}
+	cfg.MetricsFlushInterval = time.Millisecond
+	sink := new(consumertest.MetricsSink)
+	lm, err := newLogMetrics(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, lm.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, lm.Shutdown(context.Background()))
+	})
+
+	ld := pdata.NewLogs()
+	addLog(ld, "frontend", "app", pdata.SeverityNumberINFO, "started")
+	require.NoError(t, lm.ConsumeLogs(context.Background(), ld))
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logmetricsconnector implements a connector counting the log records exported by its logs pipelines, and
+// emitting the counters into its metrics pipelines.
+package logmetricsconnector
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/connector/connectorhelper"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "logmetrics"
+
+	defaultMetricsFlushInterval = 15 * time.Second
+)
+
+// NewFactory returns a factory for the log metrics connector.
+func NewFactory() component.ConnectorFactory {
+	return connectorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		connectorhelper.WithLogsToMetrics(createLogsToMetrics))
+}
+
+func createDefaultConfig() configmodels.Connector {
+	return &Config{
+		ConnectorSettings: configmodels.ConnectorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		MetricsFlushInterval: defaultMetricsFlushInterval,
+	}
+}
+
+func createLogsToMetrics(
+	_ context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Metrics,
+) (component.LogsConnector, error) {
+	return newLogMetrics(params.Logger, cfg.(*Config), nextConsumer)
+}
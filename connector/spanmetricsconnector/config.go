@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsconnector
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the span metrics connector.
+type Config struct {
+	configmodels.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// LatencyHistogramBuckets are the upper bounds of the buckets of the latency histogram, from a few milliseconds
+	// to 15 seconds when empty.
+	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
+
+	// Dimensions are the span or resource attributes added as labels to the metrics, in addition to the service
+	// name, span name, span kind and status code.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+
+	// MetricsFlushInterval is the interval at which the cumulative metrics are emitted.
+	MetricsFlushInterval time.Duration `mapstructure:"metrics_flush_interval"`
+}
+
+// Dimension is an attribute added as a label to the metrics. The value is read in the span attributes first, then
+// in the resource attributes.
+type Dimension struct {
+	// Name of the attribute, also used as the name of the label.
+	Name string `mapstructure:"name"`
+
+	// Default is the value of the label when the attribute is missing, the label is omitted when nil.
+	Default *string `mapstructure:"default"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the buckets are increasing, the dimensions unique and the flush interval positive.
+func (cfg *Config) Validate() error {
+	for i := 1; i < len(cfg.LatencyHistogramBuckets); i++ {
+		if cfg.LatencyHistogramBuckets[i] <= cfg.LatencyHistogramBuckets[i-1] {
+			return errors.New("latency_histogram_buckets must be strictly increasing")
+		}
+	}
+	seen := map[string]bool{
+		serviceNameLabel: true,
+		operationLabel:   true,
+		spanKindLabel:    true,
+		statusCodeLabel:  true,
+	}
+	for _, d := range cfg.Dimensions {
+		if d.Name == "" {
+			return errors.New("dimensions must have a name")
+		}
+		if seen[d.Name] {
+			return fmt.Errorf("duplicate dimension %q", d.Name)
+		}
+		seen[d.Name] = true
+	}
+	if cfg.MetricsFlushInterval <= 0 {
+		return errors.New("metrics_flush_interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsconnector
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Connectors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Connectors["spanmetrics"])
+
+	get := "GET"
+	assert.Equal(t,
+		&Config{
+			ConnectorSettings: configmodels.ConnectorSettings{
+				TypeVal: "spanmetrics",
+				NameVal: "spanmetrics/custom",
+			},
+			LatencyHistogramBuckets: []time.Duration{10 * time.Millisecond, 100 * time.Millisecond, time.Second},
+			Dimensions: []Dimension{
+				{Name: "http.method", Default: &get},
+				{Name: "deployment.environment"},
+			},
+			MetricsFlushInterval: 30 * time.Second,
+		},
+		cfg.Connectors["spanmetrics/custom"])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Dimensions = []Dimension{{Name: "http.method"}, {Name: "http.method"}}
+	assert.EqualError(t, cfg.Validate(), `duplicate dimension "http.method"`)
+
+	cfg.Dimensions = []Dimension{{Name: "operation"}}
+	assert.EqualError(t, cfg.Validate(), `duplicate dimension "operation"`)
+
+	cfg.Dimensions = nil
+	cfg.LatencyHistogramBuckets = []time.Duration{time.Second, time.Second}
+	assert.EqualError(t, cfg.Validate(), "latency_histogram_buckets must be strictly increasing")
+
+	cfg.LatencyHistogramBuckets = nil
+	cfg.MetricsFlushInterval = 0
+	assert.EqualError(t, cfg.Validate(), "metrics_flush_interval must be positive")
+}
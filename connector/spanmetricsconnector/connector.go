@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsconnector
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	callsMetricName   = "calls_total"
+	latencyMetricName = "latency"
+
+	serviceNameLabel = conventions.AttributeServiceName
+	operationLabel   = "operation"
+	spanKindLabel    = "span.kind"
+	statusCodeLabel  = "status.code"
+)
+
+// series aggregates the spans with the same labels.
+type series struct {
+	labels       map[string]string
+	calls        int64
+	latencySum   float64
+	bucketCounts []uint64
+}
+
+// spanMetrics aggregates the spans it consumes into cumulative metrics, emitted every MetricsFlushInterval and when
+// it shuts down.
+type spanMetrics struct {
+	config       *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+	// bounds are the latency histogram buckets in milliseconds.
+	bounds    []float64
+	startTime pdata.Timestamp
+
+	mu     sync.Mutex
+	series map[string]*series
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ component.TracesConnector = (*spanMetrics)(nil)
+
+func newSpanMetrics(logger *zap.Logger, cfg *Config, nextConsumer consumer.Metrics) *spanMetrics {
+	buckets := cfg.LatencyHistogramBuckets
+	if len(buckets) == 0 {
+		buckets = defaultLatencyHistogramBuckets
+	}
+	bounds := make([]float64, 0, len(buckets))
+	for _, b := range buckets {
+		bounds = append(bounds, durationToMillis(b))
+	}
+	return &spanMetrics{
+		config:       cfg,
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		bounds:       bounds,
+		series:       make(map[string]*series),
+		done:         make(chan struct{}),
+	}
+}
+
+// Start starts emitting the metrics every MetricsFlushInterval.
+func (sm *spanMetrics) Start(context.Context, component.Host) error {
+	sm.startTime = pdata.TimestampFromTime(time.Now())
+	sm.wg.Add(1)
+	go func() {
+		defer sm.wg.Done()
+		ticker := time.NewTicker(sm.config.MetricsFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sm.flush(context.Background())
+			case <-sm.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops emitting the metrics, they are emitted a last time.
+func (sm *spanMetrics) Shutdown(ctx context.Context) error {
+	close(sm.done)
+	sm.wg.Wait()
+	sm.flush(ctx)
+	return nil
+}
+
+// ConsumeTraces aggregates the spans.
+func (sm *spanMetrics) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceAttrs := rs.Resource().Attributes()
+		serviceName := ""
+		if v, ok := resourceAttrs.Get(conventions.AttributeServiceName); ok {
+			serviceName = v.StringVal()
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				sm.aggregate(serviceName, spans.At(k), resourceAttrs)
+			}
+		}
+	}
+	return nil
+}
+
+// aggregate adds the span to its series, it is called with the lock held.
+func (sm *spanMetrics) aggregate(serviceName string, span pdata.Span, resourceAttrs pdata.AttributeMap) {
+	labels := map[string]string{
+		serviceNameLabel: serviceName,
+		operationLabel:   span.Name(),
+		spanKindLabel:    span.Kind().String(),
+		statusCodeLabel:  span.Status().Code().String(),
+	}
+	var key strings.Builder
+	key.WriteString(serviceName)
+	key.WriteByte(0)
+	key.WriteString(span.Name())
+	key.WriteByte(0)
+	key.WriteString(labels[spanKindLabel])
+	key.WriteByte(0)
+	key.WriteString(labels[statusCodeLabel])
+	for _, d := range sm.config.Dimensions {
+		key.WriteByte(0)
+		value, ok := dimensionValue(d, span.Attributes(), resourceAttrs)
+		if !ok {
+			// Tell a missing label from an empty one.
+			key.WriteByte(1)
+			continue
+		}
+		labels[d.Name] = value
+		key.WriteString(value)
+	}
+
+	s, ok := sm.series[key.String()]
+	if !ok {
+		s = &series{labels: labels, bucketCounts: make([]uint64, len(sm.bounds)+1)}
+		sm.series[key.String()] = s
+	}
+	latency := durationToMillis(span.EndTime().AsTime().Sub(span.StartTime().AsTime()))
+	s.calls++
+	s.latencySum += latency
+	s.bucketCounts[sort.SearchFloat64s(sm.bounds, latency)]++
+}
+
+// dimensionValue returns the value of the dimension in the span attributes, the resource attributes or its default.
+func dimensionValue(d Dimension, spanAttrs, resourceAttrs pdata.AttributeMap) (string, bool) {
+	if v, ok := spanAttrs.Get(d.Name); ok {
+		return tracetranslator.AttributeValueToString(v, false), true
+	}
+	if v, ok := resourceAttrs.Get(d.Name); ok {
+		return tracetranslator.AttributeValueToString(v, false), true
+	}
+	if d.Default != nil {
+		return *d.Default, true
+	}
+	return "", false
+}
+
+// flush emits the cumulative metrics of all the series.
+func (sm *spanMetrics) flush(ctx context.Context) {
+	md, ok := sm.buildMetrics()
+	if !ok {
+		return
+	}
+	if err := sm.nextConsumer.ConsumeMetrics(ctx, md); err != nil {
+		sm.logger.Error("Failed to emit the span metrics.", zap.Error(err))
+	}
+}
+
+// buildMetrics returns the metrics of all the series, or false when no span was consumed yet.
+func (sm *spanMetrics) buildMetrics() (pdata.Metrics, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if len(sm.series) == 0 {
+		return pdata.Metrics{}, false
+	}
+	keys := make([]string, 0, len(sm.series))
+	for key := range sm.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	now := pdata.TimestampFromTime(time.Now())
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	calls := metrics.AppendEmpty()
+	calls.SetName(callsMetricName)
+	calls.SetDescription("Number of spans by service, span name, span kind and status code.")
+	calls.SetUnit("1")
+	calls.SetDataType(pdata.MetricDataTypeIntSum)
+	calls.IntSum().SetIsMonotonic(true)
+	calls.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	latency := metrics.AppendEmpty()
+	latency.SetName(latencyMetricName)
+	latency.SetDescription("Duration of the spans by service, span name, span kind and status code.")
+	latency.SetUnit("ms")
+	latency.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	latency.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	for _, key := range keys {
+		s := sm.series[key]
+
+		dp := calls.IntSum().DataPoints().AppendEmpty()
+		dp.LabelsMap().InitFromMap(s.labels)
+		dp.SetStartTime(sm.startTime)
+		dp.SetTimestamp(now)
+		dp.SetValue(s.calls)
+
+		hdp := latency.DoubleHistogram().DataPoints().AppendEmpty()
+		hdp.LabelsMap().InitFromMap(s.labels)
+		hdp.SetStartTime(sm.startTime)
+		hdp.SetTimestamp(now)
+		hdp.SetCount(uint64(s.calls))
+		hdp.SetSum(s.latencySum)
+		hdp.SetExplicitBounds(append([]float64(nil), sm.bounds...))
+		hdp.SetBucketCounts(append([]uint64(nil), s.bucketCounts...))
+	}
+	return md, true
+}
+
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
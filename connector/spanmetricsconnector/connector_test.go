@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// addSpan appends a span of the given duration to the traces of the service.
+func addSpan(td pdata.Traces, service, name string, code pdata.StatusCode, duration time.Duration, attrs map[string]string) {
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().UpsertString(conventions.AttributeServiceName, service)
+	rs.Resource().Attributes().UpsertString("deployment.environment", "prod")
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName(name)
+	span.SetKind(pdata.SpanKindSERVER)
+	span.Status().SetCode(code)
+	start := time.Now()
+	span.SetStartTime(pdata.TimestampFromTime(start))
+	span.SetEndTime(pdata.TimestampFromTime(start.Add(duration)))
+	for k, v := range attrs {
+		span.Attributes().UpsertString(k, v)
+	}
+}
+
+func TestSpanMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.LatencyHistogramBuckets = []time.Duration{10 * time.Millisecond, 100 * time.Millisecond}
+	get := "GET"
+	cfg.Dimensions = []Dimension{{Name: "http.method", Default: &get}, {Name: "deployment.environment"}, {Name: "missing"}}
+	cfg.MetricsFlushInterval = time.Hour
+	sink := new(consumertest.MetricsSink)
+	sm := newSpanMetrics(zap.NewNop(), cfg, sink)
+	require.NoError(t, sm.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := pdata.NewTraces()
+	addSpan(td, "frontend", "/checkout", pdata.StatusCodeOk, 5*time.Millisecond, map[string]string{"http.method": "POST"})
+	addSpan(td, "frontend", "/checkout", pdata.StatusCodeOk, 50*time.Millisecond, map[string]string{"http.method": "POST"})
+	addSpan(td, "frontend", "/checkout", pdata.StatusCodeError, time.Second, map[string]string{"http.method": "POST"})
+	addSpan(td, "frontend", "/cart", pdata.StatusCodeUnset, 20*time.Millisecond, nil)
+	require.NoError(t, sm.ConsumeTraces(context.Background(), td))
+
+	// The metrics are emitted when shutting down.
+	require.NoError(t, sm.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	calls := metrics.At(0)
+	assert.Equal(t, "calls_total", calls.Name())
+	assert.True(t, calls.IntSum().IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, calls.IntSum().AggregationTemporality())
+	callsByLabels := map[string]int64{}
+	for i := 0; i < calls.IntSum().DataPoints().Len(); i++ {
+		dp := calls.IntSum().DataPoints().At(i)
+		labels := dp.LabelsMap()
+		assert.Equal(t, 6, labels.Len())
+		service, _ := labels.Get("service.name")
+		kind, _ := labels.Get("span.kind")
+		env, _ := labels.Get("deployment.environment")
+		assert.Equal(t, "frontend", service)
+		assert.Equal(t, "SPAN_KIND_SERVER", kind)
+		assert.Equal(t, "prod", env)
+		operation, _ := labels.Get("operation")
+		code, _ := labels.Get("status.code")
+		method, _ := labels.Get("http.method")
+		callsByLabels[operation+" "+code+" "+method] = dp.Value()
+	}
+	assert.Equal(t, map[string]int64{
+		"/checkout STATUS_CODE_OK POST":    2,
+		"/checkout STATUS_CODE_ERROR POST": 1,
+		"/cart STATUS_CODE_UNSET GET":      1,
+	}, callsByLabels)
+
+	latency := metrics.At(1)
+	assert.Equal(t, "latency", latency.Name())
+	assert.Equal(t, "ms", latency.Unit())
+	found := false
+	for i := 0; i < latency.DoubleHistogram().DataPoints().Len(); i++ {
+		dp := latency.DoubleHistogram().DataPoints().At(i)
+		if code, _ := dp.LabelsMap().Get("status.code"); code != "STATUS_CODE_OK" {
+			continue
+		}
+		found = true
+		assert.Equal(t, []float64{10, 100}, dp.ExplicitBounds())
+		assert.Equal(t, []uint64{1, 1, 0}, dp.BucketCounts())
+		assert.EqualValues(t, 2, dp.Count())
+		assert.InDelta(t, 55, dp.Sum(), 0.001)
+	}
+	assert.True(t, found)
+}
+
+func TestSpanMetricsFlushInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsFlushInterval = 10 * time.Millisecond
+	sink := new(consumertest.MetricsSink)
+	sm := newSpanMetrics(zap.NewNop(), cfg, sink)
+	require.NoError(t, sm.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, sm.Shutdown(context.Background()))
+	}()
+
+	td := pdata.NewTraces()
+	addSpan(td, "frontend", "/cart", pdata.StatusCodeOk, time.Millisecond, nil)
+	require.NoError(t, sm.ConsumeTraces(context.Background(), td))
+
+	// The cumulative metrics are emitted periodically.
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSpanMetricsNothingToEmit(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	sm := newSpanMetrics(zap.NewNop(), createDefaultConfig().(*Config), sink)
+	require.NoError(t, sm.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, sm.Shutdown(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestSpanMetricsDefaultBuckets(t *testing.T) {
+	sm := newSpanMetrics(zap.NewNop(), createDefaultConfig().(*Config), consumertest.NewMetricsNop())
+	assert.Len(t, sm.bounds, len(defaultLatencyHistogramBuckets))
+	assert.Equal(t, 2.0, sm.bounds[0])
+	assert.Equal(t, 15000.0, sm.bounds[len(sm.bounds)-1])
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spanmetricsconnector implements a connector computing the request, error and duration (RED) metrics of
+// the spans exported by its traces pipelines, and emitting them into its metrics pipelines.
+package spanmetricsconnector
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/connector/connectorhelper"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "spanmetrics"
+
+	defaultMetricsFlushInterval = 15 * time.Second
+)
+
+// defaultLatencyHistogramBuckets are the bounds of the latency histogram, from a few milliseconds to 15 seconds.
+var defaultLatencyHistogramBuckets = []time.Duration{
+	2 * time.Millisecond, 4 * time.Millisecond, 6 * time.Millisecond, 8 * time.Millisecond, 10 * time.Millisecond,
+	50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond,
+	time.Second, 1400 * time.Millisecond, 2 * time.Second, 5 * time.Second, 10 * time.Second, 15 * time.Second,
+}
+
+// NewFactory returns a factory for the span metrics connector.
+func NewFactory() component.ConnectorFactory {
+	return connectorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		connectorhelper.WithTracesToMetrics(createTracesToMetrics))
+}
+
+func createDefaultConfig() configmodels.Connector {
+	return &Config{
+		ConnectorSettings: configmodels.ConnectorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		MetricsFlushInterval: defaultMetricsFlushInterval,
+	}
+}
+
+func createTracesToMetrics(
+	_ context.Context,
+	params component.ConnectorCreateParams,
+	cfg configmodels.Connector,
+	nextConsumer consumer.Metrics,
+) (component.TracesConnector, error) {
+	return newSpanMetrics(params.Logger, cfg.(*Config), nextConsumer), nil
+}
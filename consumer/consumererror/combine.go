@@ -22,7 +22,8 @@ import (
 // Combine converts a list of errors into one error.
 //
 // If any of the errors in errs are Permanent then the returned
-// error will also be Permanent.
+// error will also be Permanent. If all the errors in errs have the
+// same Reason then the returned error will also have it.
 //
 // Any signal data associated with an error from this package
 // will be discarded.
@@ -39,13 +40,20 @@ func Combine(errs []error) error {
 
 	errMsgs := make([]string, 0, numErrors)
 	permanent := false
+	reason := GetReason(errs[0])
 	for _, err := range errs {
 		if !permanent && IsPermanent(err) {
 			permanent = true
 		}
+		if GetReason(err) != reason {
+			reason = ReasonDownstreamError
+		}
 		errMsgs = append(errMsgs, err.Error())
 	}
 	err := fmt.Errorf("[%s]", strings.Join(errMsgs, "; "))
+	if reason != ReasonDownstreamError {
+		err = WithReason(err, reason)
+	}
 	if permanent {
 		err = Permanent(err)
 	}
@@ -25,6 +25,7 @@ func TestCombine(t *testing.T) {
 		expected          string
 		expectNil         bool
 		expectedPermanent bool
+		expectedReason    Reason
 	}{
 		{
 			errors:    []error{},
@@ -50,6 +51,20 @@ func TestCombine(t *testing.T) {
 				Permanent(fmt.Errorf("permanent"))},
 			expected: "Permanent error: [foo; bar; Permanent error: permanent]",
 		},
+		{
+			errors: []error{
+				WithReason(fmt.Errorf("foo"), ReasonMemoryLimit),
+				WithReason(fmt.Errorf("bar"), ReasonMemoryLimit)},
+			expected:       "[foo; bar]",
+			expectedReason: ReasonMemoryLimit,
+		},
+		{
+			errors: []error{
+				WithReason(fmt.Errorf("foo"), ReasonMemoryLimit),
+				fmt.Errorf("bar")},
+			expected:       "[foo; bar]",
+			expectedReason: ReasonDownstreamError,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -63,5 +78,8 @@ func TestCombine(t *testing.T) {
 		if tc.expectedPermanent && !IsPermanent(got) {
 			t.Errorf("Combine(%v) = %q. Want: consumererror.permanent", tc.errors, got)
 		}
+		if tc.expectedReason != "" && tc.expectedReason != GetReason(got) {
+			t.Errorf("GetReason(Combine(%v)) = %q. Want: %q", tc.errors, GetReason(got), tc.expectedReason)
+		}
 	}
 }
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+import "errors"
+
+// Reason classifies why data was refused by a receiver or failed to be sent
+// by an exporter.
+type Reason string
+
+const (
+	// ReasonQueueFull indicates that the data was dropped because a queue was full.
+	ReasonQueueFull Reason = "queue_full"
+	// ReasonMemoryLimit indicates that the data was refused because the memory
+	// limit was reached.
+	ReasonMemoryLimit Reason = "memory_limit"
+	// ReasonAuthFailed indicates that the data was refused because the client
+	// or the exporter failed to authenticate.
+	ReasonAuthFailed Reason = "auth_failed"
+	// ReasonDecodeError indicates that the data was refused because it could
+	// not be decoded.
+	ReasonDecodeError Reason = "decode_error"
+	// ReasonDownstreamError is the reason of the errors without any other
+	// reason, returned by the next consumer or by the destination.
+	ReasonDownstreamError Reason = "downstream_error"
+)
+
+// reasonError is an error classified with a Reason.
+type reasonError struct {
+	err    error
+	reason Reason
+}
+
+// WithReason wraps an error to classify it with the given reason.
+func WithReason(err error, reason Reason) error {
+	return reasonError{err: err, reason: reason}
+}
+
+func (r reasonError) Error() string {
+	return r.err.Error()
+}
+
+// Unwrap returns the wrapped error, so that the error stays permanent or
+// carrying signal data.
+func (r reasonError) Unwrap() error {
+	return r.err
+}
+
+// GetReason returns the reason of the first error in err's chain wrapped with
+// WithReason, ReasonDownstreamError if there is none, and an empty reason if
+// err is nil.
+func GetReason(err error) Reason {
+	if err == nil {
+		return ""
+	}
+	var re reasonError
+	if errors.As(err, &re) {
+		return re.reason
+	}
+	return ReasonDownstreamError
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReason(t *testing.T) {
+	assert.Equal(t, Reason(""), GetReason(nil))
+
+	err := errors.New("testError")
+	assert.Equal(t, ReasonDownstreamError, GetReason(err))
+
+	err = WithReason(err, ReasonDecodeError)
+	assert.Equal(t, "testError", err.Error())
+	assert.Equal(t, ReasonDecodeError, GetReason(err))
+	assert.Equal(t, ReasonDecodeError, GetReason(fmt.Errorf("wrapped: %w", err)))
+	assert.Equal(t, ReasonDecodeError, GetReason(Permanent(err)))
+}
+
+func TestWithReason_Permanent(t *testing.T) {
+	err := WithReason(Permanent(errors.New("testError")), ReasonAuthFailed)
+	assert.True(t, IsPermanent(err))
+	assert.Equal(t, ReasonAuthFailed, GetReason(err))
+}
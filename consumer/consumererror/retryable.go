@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+import (
+	"errors"
+	"time"
+)
+
+// Retryable is an error indicating that the data was refused temporarily, e.g.
+// to apply backpressure, and that the same data may be sent again after a delay.
+type Retryable struct {
+	error
+	delay time.Duration
+}
+
+// NewRetryable creates a Retryable suggesting to send the data again after the
+// given delay, zero if the sender is free to pick it.
+func NewRetryable(err error, delay time.Duration) error {
+	return Retryable{
+		error: err,
+		delay: delay,
+	}
+}
+
+// AsRetryable finds the first error in err's chain that can be assigned to target. If such an error is found
+// it is assigned to target and true is returned, otherwise false is returned.
+func AsRetryable(err error, target *Retryable) bool {
+	if err == nil {
+		return false
+	}
+	return errors.As(err, target)
+}
+
+// IsRetryable checks if an error was wrapped with the NewRetryable function.
+func IsRetryable(err error) bool {
+	var target Retryable
+	return AsRetryable(err, &target)
+}
+
+// Delay returns the delay after which the data may be sent again, zero if none
+// was suggested.
+func (err Retryable) Delay() time.Duration {
+	return err.delay
+}
+
+// Unwrap returns the wrapped error, so that the reason of the error stays
+// available.
+func (err Retryable) Unwrap() error {
+	return err.error
+}
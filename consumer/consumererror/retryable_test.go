@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryable(t *testing.T) {
+	err := WithReason(errors.New("testError"), ReasonMemoryLimit)
+	retryableErr := NewRetryable(err, time.Second)
+	assert.Equal(t, err.Error(), retryableErr.Error())
+	assert.False(t, IsPermanent(retryableErr))
+	assert.Equal(t, ReasonMemoryLimit, GetReason(retryableErr))
+
+	var target Retryable
+	assert.False(t, AsRetryable(nil, &target))
+	assert.False(t, AsRetryable(err, &target))
+	assert.True(t, AsRetryable(fmt.Errorf("wrapped: %w", retryableErr), &target))
+	assert.Equal(t, time.Second, target.Delay())
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.False(t, IsRetryable(nil))
+	assert.False(t, IsRetryable(errors.New("testError")))
+	assert.True(t, IsRetryable(NewRetryable(errors.New("testError"), 0)))
+}
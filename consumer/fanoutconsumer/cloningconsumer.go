@@ -59,6 +59,20 @@ func (mfc metricsCloningConsumer) ConsumeMetrics(ctx context.Context, md pdata.M
 	return consumererror.Combine(errs)
 }
 
+// NewMetricsCloningMutating wraps the read-only and the mutating metrics consumers in a single one, cloning the
+// data only for the consumers mutating it. The read-only consumers share a single clone of the data, and the
+// last mutating consumer receives the original data, e.g. no clone is made for a single mutating consumer.
+func NewMetricsCloningMutating(readOnly []consumer.Metrics, mutating []consumer.Metrics) consumer.Metrics {
+	if len(mutating) == 0 {
+		return NewMetrics(readOnly)
+	}
+	if len(readOnly) > 0 {
+		// The read-only consumers are fanned out first, with a clone that is not modified by the mutating ones.
+		mutating = append([]consumer.Metrics{NewMetrics(readOnly)}, mutating...)
+	}
+	return NewMetricsCloning(mutating)
+}
+
 // NewTracesCloning wraps multiple traces consumers in a single one and clones the data
 // before fanning out.
 func NewTracesCloning(tcs []consumer.Traces) consumer.Traces {
@@ -96,6 +110,20 @@ func (tfc tracesCloningConsumer) ConsumeTraces(ctx context.Context, td pdata.Tra
 	return consumererror.Combine(errs)
 }
 
+// NewTracesCloningMutating wraps the read-only and the mutating traces consumers in a single one, cloning the
+// data only for the consumers mutating it. The read-only consumers share a single clone of the data, and the
+// last mutating consumer receives the original data, e.g. no clone is made for a single mutating consumer.
+func NewTracesCloningMutating(readOnly []consumer.Traces, mutating []consumer.Traces) consumer.Traces {
+	if len(mutating) == 0 {
+		return NewTraces(readOnly)
+	}
+	if len(readOnly) > 0 {
+		// The read-only consumers are fanned out first, with a clone that is not modified by the mutating ones.
+		mutating = append([]consumer.Traces{NewTraces(readOnly)}, mutating...)
+	}
+	return NewTracesCloning(mutating)
+}
+
 // NewLogsCloning wraps multiple trace consumers in a single one and clones the data
 // before fanning out.
 func NewLogsCloning(lcs []consumer.Logs) consumer.Logs {
@@ -132,3 +160,17 @@ func (lfc logsCloningConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) e
 
 	return consumererror.Combine(errs)
 }
+
+// NewLogsCloningMutating wraps the read-only and the mutating logs consumers in a single one, cloning the
+// data only for the consumers mutating it. The read-only consumers share a single clone of the data, and the
+// last mutating consumer receives the original data, e.g. no clone is made for a single mutating consumer.
+func NewLogsCloningMutating(readOnly []consumer.Logs, mutating []consumer.Logs) consumer.Logs {
+	if len(mutating) == 0 {
+		return NewLogs(readOnly)
+	}
+	if len(readOnly) > 0 {
+		// The read-only consumers are fanned out first, with a clone that is not modified by the mutating ones.
+		mutating = append([]consumer.Logs{NewLogs(readOnly)}, mutating...)
+	}
+	return NewLogsCloning(mutating)
+}
@@ -153,3 +153,83 @@ func TestLogsProcessorCloningMultiplexing(t *testing.T) {
 		assert.EqualValues(t, metricOrig, metricClone)
 	}
 }
+
+func TestTracesCloningMutating(t *testing.T) {
+	readOnly := []*consumertest.TracesSink{new(consumertest.TracesSink), new(consumertest.TracesSink)}
+	mutating := []*consumertest.TracesSink{new(consumertest.TracesSink), new(consumertest.TracesSink)}
+	tfc := NewTracesCloningMutating(
+		[]consumer.Traces{readOnly[0], readOnly[1]},
+		[]consumer.Traces{mutating[0], mutating[1]})
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	assert.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The read-only consumers share a clone, the last mutating consumer receives the original.
+	shared := readOnly[0].AllTraces()[0]
+	assert.True(t, shared == readOnly[1].AllTraces()[0])
+	assert.True(t, shared != td)
+	assert.True(t, mutating[0].AllTraces()[0] != td)
+	assert.True(t, mutating[0].AllTraces()[0] != shared)
+	assert.True(t, mutating[1].AllTraces()[0] == td)
+	for _, sink := range append(readOnly, mutating...) {
+		assert.EqualValues(t, td, sink.AllTraces()[0])
+	}
+
+	// No clone is made without mutating consumers, and for a single mutating consumer.
+	sink := new(consumertest.TracesSink)
+	tfc = NewTracesCloningMutating([]consumer.Traces{sink, new(consumertest.TracesSink)}, nil)
+	assert.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	assert.True(t, sink.AllTraces()[0] == td)
+	sink = new(consumertest.TracesSink)
+	tfc = NewTracesCloningMutating(nil, []consumer.Traces{sink})
+	assert.Same(t, sink, tfc)
+}
+
+func TestMetricsCloningMutating(t *testing.T) {
+	readOnly := []*consumertest.MetricsSink{new(consumertest.MetricsSink), new(consumertest.MetricsSink)}
+	mutating := []*consumertest.MetricsSink{new(consumertest.MetricsSink), new(consumertest.MetricsSink)}
+	mfc := NewMetricsCloningMutating(
+		[]consumer.Metrics{readOnly[0], readOnly[1]},
+		[]consumer.Metrics{mutating[0], mutating[1]})
+	md := testdata.GenerateMetricsOneMetric()
+	assert.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+
+	shared := readOnly[0].AllMetrics()[0]
+	assert.True(t, shared == readOnly[1].AllMetrics()[0])
+	assert.True(t, shared != md)
+	assert.True(t, mutating[0].AllMetrics()[0] != md)
+	assert.True(t, mutating[0].AllMetrics()[0] != shared)
+	assert.True(t, mutating[1].AllMetrics()[0] == md)
+	for _, sink := range append(readOnly, mutating...) {
+		assert.EqualValues(t, md, sink.AllMetrics()[0])
+	}
+
+	sink := new(consumertest.MetricsSink)
+	mfc = NewMetricsCloningMutating([]consumer.Metrics{sink, new(consumertest.MetricsSink)}, nil)
+	assert.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+	assert.True(t, sink.AllMetrics()[0] == md)
+}
+
+func TestLogsCloningMutating(t *testing.T) {
+	readOnly := []*consumertest.LogsSink{new(consumertest.LogsSink), new(consumertest.LogsSink)}
+	mutating := []*consumertest.LogsSink{new(consumertest.LogsSink), new(consumertest.LogsSink)}
+	lfc := NewLogsCloningMutating(
+		[]consumer.Logs{readOnly[0], readOnly[1]},
+		[]consumer.Logs{mutating[0], mutating[1]})
+	ld := testdata.GenerateLogDataOneLog()
+	assert.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+
+	shared := readOnly[0].AllLogs()[0]
+	assert.True(t, shared == readOnly[1].AllLogs()[0])
+	assert.True(t, shared != ld)
+	assert.True(t, mutating[0].AllLogs()[0] != ld)
+	assert.True(t, mutating[0].AllLogs()[0] != shared)
+	assert.True(t, mutating[1].AllLogs()[0] == ld)
+	for _, sink := range append(readOnly, mutating...) {
+		assert.EqualValues(t, ld, sink.AllLogs()[0])
+	}
+
+	sink := new(consumertest.LogsSink)
+	lfc = NewLogsCloningMutating([]consumer.Logs{sink, new(consumertest.LogsSink)}, nil)
+	assert.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+	assert.True(t, sink.AllLogs()[0] == ld)
+}
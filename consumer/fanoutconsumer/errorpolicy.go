@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// NewMetricsWithErrorPolicy wraps multiple metrics consumers in a single one handling their errors according to the
+// policy. The names identify the consumers in the counts of the data they failed to consume with the isolate policy.
+func NewMetricsWithErrorPolicy(policy configmodels.FanoutErrorPolicy, names []string, mcs []consumer.Metrics) consumer.Metrics {
+	if len(mcs) == 1 {
+		// All the policies behave the same with a single consumer.
+		return mcs[0]
+	}
+	switch policy {
+	case configmodels.FanoutErrorPolicyFailFast:
+		return failFastMetricsConsumer(mcs)
+	case configmodels.FanoutErrorPolicyIsolate:
+		return &isolateMetricsConsumer{consumers: mcs, isolator: newIsolator(names)}
+	}
+	return NewMetrics(mcs)
+}
+
+type failFastMetricsConsumer []consumer.Metrics
+
+var _ consumer.Metrics = (*failFastMetricsConsumer)(nil)
+
+// ConsumeMetrics exports the pdata.Metrics to the consumers wrapped by the current one until one of them fails.
+func (mfc failFastMetricsConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	for _, mc := range mfc {
+		if err := mc.ConsumeMetrics(ctx, md); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type isolateMetricsConsumer struct {
+	consumers []consumer.Metrics
+	isolator  isolator
+}
+
+var _ consumer.Metrics = (*isolateMetricsConsumer)(nil)
+
+// ConsumeMetrics exports the pdata.Metrics to all consumers wrapped by the current one, and fails only if all of
+// them failed.
+func (mfc *isolateMetricsConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	errs := make([]error, len(mfc.consumers))
+	for i, mc := range mfc.consumers {
+		errs[i] = mc.ConsumeMetrics(ctx, md)
+	}
+	_, numPoints := md.MetricAndDataPointCount()
+	return mfc.isolator.isolate(ctx, errs, numPoints)
+}
+
+// NewTracesWithErrorPolicy wraps multiple trace consumers in a single one handling their errors according to the
+// policy. The names identify the consumers in the counts of the data they failed to consume with the isolate policy.
+func NewTracesWithErrorPolicy(policy configmodels.FanoutErrorPolicy, names []string, tcs []consumer.Traces) consumer.Traces {
+	if len(tcs) == 1 {
+		// All the policies behave the same with a single consumer.
+		return tcs[0]
+	}
+	switch policy {
+	case configmodels.FanoutErrorPolicyFailFast:
+		return failFastTracesConsumer(tcs)
+	case configmodels.FanoutErrorPolicyIsolate:
+		return &isolateTracesConsumer{consumers: tcs, isolator: newIsolator(names)}
+	}
+	return NewTraces(tcs)
+}
+
+type failFastTracesConsumer []consumer.Traces
+
+var _ consumer.Traces = (*failFastTracesConsumer)(nil)
+
+// ConsumeTraces exports the pdata.Traces to the consumers wrapped by the current one until one of them fails.
+func (tfc failFastTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	for _, tc := range tfc {
+		if err := tc.ConsumeTraces(ctx, td); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type isolateTracesConsumer struct {
+	consumers []consumer.Traces
+	isolator  isolator
+}
+
+var _ consumer.Traces = (*isolateTracesConsumer)(nil)
+
+// ConsumeTraces exports the pdata.Traces to all consumers wrapped by the current one, and fails only if all of
+// them failed.
+func (tfc *isolateTracesConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	errs := make([]error, len(tfc.consumers))
+	for i, tc := range tfc.consumers {
+		errs[i] = tc.ConsumeTraces(ctx, td)
+	}
+	return tfc.isolator.isolate(ctx, errs, td.SpanCount())
+}
+
+// NewLogsWithErrorPolicy wraps multiple log consumers in a single one handling their errors according to the
+// policy. The names identify the consumers in the counts of the data they failed to consume with the isolate policy.
+func NewLogsWithErrorPolicy(policy configmodels.FanoutErrorPolicy, names []string, lcs []consumer.Logs) consumer.Logs {
+	if len(lcs) == 1 {
+		// All the policies behave the same with a single consumer.
+		return lcs[0]
+	}
+	switch policy {
+	case configmodels.FanoutErrorPolicyFailFast:
+		return failFastLogsConsumer(lcs)
+	case configmodels.FanoutErrorPolicyIsolate:
+		return &isolateLogsConsumer{consumers: lcs, isolator: newIsolator(names)}
+	}
+	return NewLogs(lcs)
+}
+
+type failFastLogsConsumer []consumer.Logs
+
+var _ consumer.Logs = (*failFastLogsConsumer)(nil)
+
+// ConsumeLogs exports the pdata.Logs to the consumers wrapped by the current one until one of them fails.
+func (lfc failFastLogsConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	for _, lc := range lfc {
+		if err := lc.ConsumeLogs(ctx, ld); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type isolateLogsConsumer struct {
+	consumers []consumer.Logs
+	isolator  isolator
+}
+
+var _ consumer.Logs = (*isolateLogsConsumer)(nil)
+
+// ConsumeLogs exports the pdata.Logs to all consumers wrapped by the current one, and fails only if all of
+// them failed.
+func (lfc *isolateLogsConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	errs := make([]error, len(lfc.consumers))
+	for i, lc := range lfc.consumers {
+		errs[i] = lc.ConsumeLogs(ctx, ld)
+	}
+	return lfc.isolator.isolate(ctx, errs, ld.LogRecordCount())
+}
+
+// isolator counts, for each consumer, the items it failed to consume while the other consumers succeeded.
+type isolator []*obsreport.Exporter
+
+func newIsolator(names []string) isolator {
+	iso := make(isolator, len(names))
+	for i, name := range names {
+		iso[i] = obsreport.NewExporter(obsreport.ExporterSettings{
+			Level:        configtelemetry.GetMetricsLevelFlagValue(),
+			ExporterName: name,
+		})
+	}
+	return iso
+}
+
+// isolate returns the combined errors of the consumers if all of them failed. Otherwise it records the items of the
+// failed consumers and returns nil, so that the data is not retried into the consumers that succeeded.
+func (iso isolator) isolate(ctx context.Context, errs []error, numItems int) error {
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) == len(errs) {
+		return consumererror.Combine(failed)
+	}
+	for i, err := range errs {
+		if err != nil && i < len(iso) {
+			iso[i].RecordFanoutIsolatedItems(ctx, numItems)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+var fanoutNames = []string{"first", "failing", "last"}
+
+func TestTracesErrorPolicy(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+
+	// The propagate policy sends the data to all the consumers and returns the error.
+	first, last := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	tfc := NewTracesWithErrorPolicy(configmodels.FanoutErrorPolicyPropagate, fanoutNames,
+		[]consumer.Traces{first, consumertest.NewTracesErr(errors.New("my error")), last})
+	assert.EqualError(t, tfc.ConsumeTraces(context.Background(), td), "my error")
+	assert.Equal(t, 2, first.SpansCount())
+	assert.Equal(t, 2, last.SpansCount())
+
+	// The fail_fast policy stops at the failed consumer.
+	first, last = new(consumertest.TracesSink), new(consumertest.TracesSink)
+	tfc = NewTracesWithErrorPolicy(configmodels.FanoutErrorPolicyFailFast, fanoutNames,
+		[]consumer.Traces{first, consumertest.NewTracesErr(errors.New("my error")), last})
+	assert.EqualError(t, tfc.ConsumeTraces(context.Background(), td), "my error")
+	assert.Equal(t, 2, first.SpansCount())
+	assert.Equal(t, 0, last.SpansCount())
+
+	// The isolate policy counts the spans of the failed consumer and succeeds.
+	first, last = new(consumertest.TracesSink), new(consumertest.TracesSink)
+	tfc = NewTracesWithErrorPolicy(configmodels.FanoutErrorPolicyIsolate, fanoutNames,
+		[]consumer.Traces{first, consumertest.NewTracesErr(errors.New("my error")), last})
+	assert.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 2, first.SpansCount())
+	assert.Equal(t, 2, last.SpansCount())
+	obsreporttest.CheckExporterFanoutIsolatedItemsViews(t, "failing", 2)
+
+	// The isolate policy fails if all the consumers failed.
+	tfc = NewTracesWithErrorPolicy(configmodels.FanoutErrorPolicyIsolate, fanoutNames[:2],
+		[]consumer.Traces{consumertest.NewTracesErr(errors.New("my error")), consumertest.NewTracesErr(errors.New("my error"))})
+	assert.Error(t, tfc.ConsumeTraces(context.Background(), td))
+	obsreporttest.CheckExporterFanoutIsolatedItemsViews(t, "failing", 2)
+}
+
+func TestMetricsErrorPolicy(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	md := testdata.GenerateMetricsOneMetric()
+	_, numPoints := md.MetricAndDataPointCount()
+
+	first, last := new(consumertest.MetricsSink), new(consumertest.MetricsSink)
+	mfc := NewMetricsWithErrorPolicy(configmodels.FanoutErrorPolicyFailFast, fanoutNames,
+		[]consumer.Metrics{first, consumertest.NewMetricsErr(errors.New("my error")), last})
+	assert.EqualError(t, mfc.ConsumeMetrics(context.Background(), md), "my error")
+	assert.Len(t, first.AllMetrics(), 1)
+	assert.Len(t, last.AllMetrics(), 0)
+
+	first, last = new(consumertest.MetricsSink), new(consumertest.MetricsSink)
+	mfc = NewMetricsWithErrorPolicy(configmodels.FanoutErrorPolicyIsolate, fanoutNames,
+		[]consumer.Metrics{first, consumertest.NewMetricsErr(errors.New("my error")), last})
+	assert.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, first.AllMetrics(), 1)
+	assert.Len(t, last.AllMetrics(), 1)
+	obsreporttest.CheckExporterFanoutIsolatedItemsViews(t, "failing", int64(numPoints))
+}
+
+func TestLogsErrorPolicy(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	ld := testdata.GenerateLogDataTwoLogsSameResource()
+
+	first, last := new(consumertest.LogsSink), new(consumertest.LogsSink)
+	lfc := NewLogsWithErrorPolicy(configmodels.FanoutErrorPolicyFailFast, fanoutNames,
+		[]consumer.Logs{first, consumertest.NewLogsErr(errors.New("my error")), last})
+	assert.EqualError(t, lfc.ConsumeLogs(context.Background(), ld), "my error")
+	assert.Equal(t, 2, first.LogRecordsCount())
+	assert.Equal(t, 0, last.LogRecordsCount())
+
+	first, last = new(consumertest.LogsSink), new(consumertest.LogsSink)
+	lfc = NewLogsWithErrorPolicy(configmodels.FanoutErrorPolicyIsolate, fanoutNames,
+		[]consumer.Logs{first, consumertest.NewLogsErr(errors.New("my error")), last})
+	assert.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 2, first.LogRecordsCount())
+	assert.Equal(t, 2, last.LogRecordsCount())
+	obsreporttest.CheckExporterFanoutIsolatedItemsViews(t, "failing", 2)
+}
+
+func TestErrorPolicyNotMultiplexing(t *testing.T) {
+	nop := consumertest.NewTracesNop()
+	assert.Same(t, nop, NewTracesWithErrorPolicy(configmodels.FanoutErrorPolicyIsolate, []string{"nop"}, []consumer.Traces{nop}))
+}
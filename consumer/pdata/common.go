@@ -18,6 +18,7 @@ package pdata
 // such as timestamps, attributes, etc.
 
 import (
+	"fmt"
 	"sort"
 
 	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
@@ -279,6 +280,10 @@ func (a AttributeValue) Equal(av AttributeValue) bool {
 		return a.orig.Value == av.orig.Value
 	}
 
+	if a.Type() != av.Type() {
+		return false
+	}
+
 	switch v := a.orig.Value.(type) {
 	case *otlpcommon.AnyValue_StringValue:
 		return v.StringValue == av.orig.GetStringValue()
@@ -295,26 +300,67 @@ func (a AttributeValue) Equal(av AttributeValue) bool {
 			return false
 		}
 
-		for i, val := range avv {
-			val := val
-			av := newAttributeValue(&vv[i])
-
-			// According to the specification, array values must be scalar.
-			if avType := av.Type(); avType == AttributeValueARRAY || avType == AttributeValueMAP {
-				return false
-			}
-
-			if !av.Equal(newAttributeValue(&val)) {
+		// Nested arrays and maps are compared recursively.
+		for i := range avv {
+			if !newAttributeValue(&vv[i]).Equal(newAttributeValue(&avv[i])) {
 				return false
 			}
 		}
 		return true
+	case *otlpcommon.AnyValue_KvlistValue:
+		am := a.MapVal()
+		avm := av.MapVal()
+		if am.Len() != avm.Len() {
+			return false
+		}
+
+		// The maps are equal regardless of the order of their keys.
+		equal := true
+		am.ForEach(func(k string, val AttributeValue) {
+			if avVal, ok := avm.Get(k); !ok || !val.Equal(avVal) {
+				equal = false
+			}
+		})
+		return equal
 	}
 
-	// TODO: handle MAP data type
 	return false
 }
 
+// diff appends to diffs the differences between the value and the other, the
+// maps and the arrays are compared element by element.
+func (a AttributeValue) diff(path string, other AttributeValue, diffs []string) []string {
+	if a.Type() != other.Type() {
+		return append(diffs, fmt.Sprintf("%s.Type: %v != %v", path, a.Type(), other.Type()))
+	}
+	switch a.Type() {
+	case AttributeValueMAP:
+		return a.MapVal().diff(path, other.MapVal(), diffs)
+	case AttributeValueARRAY:
+		return a.ArrayVal().diff(path, other.ArrayVal(), diffs)
+	}
+	if !a.Equal(other) {
+		diffs = append(diffs, fmt.Sprintf("%s: %#v != %#v", path, a.primitiveVal(), other.primitiveVal()))
+	}
+	return diffs
+}
+
+// primitiveVal returns the value of a string, int, double or bool value, or
+// nil for the other types.
+func (a AttributeValue) primitiveVal() interface{} {
+	switch a.Type() {
+	case AttributeValueSTRING:
+		return a.StringVal()
+	case AttributeValueINT:
+		return a.IntVal()
+	case AttributeValueDOUBLE:
+		return a.DoubleVal()
+	case AttributeValueBOOL:
+		return a.BoolVal()
+	}
+	return nil
+}
+
 func newAttributeKeyValueString(k string, v string) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
 	akv := AttributeValue{&orig.Value}
@@ -647,6 +693,25 @@ func (am AttributeMap) CopyTo(dest AttributeMap) {
 	*dest.orig = origs
 }
 
+// diff appends to diffs the keys missing in the current map or in the other,
+// and the values that are different.
+func (am AttributeMap) diff(path string, other AttributeMap, diffs []string) []string {
+	am.ForEach(func(k string, v AttributeValue) {
+		otherV, ok := other.Get(k)
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s[%q]: missing in the other map", path, k))
+			return
+		}
+		diffs = v.diff(fmt.Sprintf("%s[%q]", path, k), otherV, diffs)
+	})
+	other.ForEach(func(k string, _ AttributeValue) {
+		if _, ok := am.Get(k); !ok {
+			diffs = append(diffs, fmt.Sprintf("%s[%q]: missing in the map", path, k))
+		}
+	})
+	return diffs
+}
+
 // StringMap stores a map of attribute keys to values.
 type StringMap struct {
 	orig *[]otlpcommon.StringKeyValue
@@ -781,6 +846,27 @@ func (sm StringMap) CopyTo(dest StringMap) {
 	}
 }
 
+// diff appends to diffs the keys missing in the current map or in the other,
+// and the values that are different.
+func (sm StringMap) diff(path string, other StringMap, diffs []string) []string {
+	sm.ForEach(func(k string, v string) {
+		otherV, ok := other.Get(k)
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s[%q]: missing in the other map", path, k))
+			return
+		}
+		if v != otherV {
+			diffs = append(diffs, fmt.Sprintf("%s[%q]: %q != %q", path, k, v, otherV))
+		}
+	})
+	other.ForEach(func(k string, _ string) {
+		if _, ok := sm.Get(k); !ok {
+			diffs = append(diffs, fmt.Sprintf("%s[%q]: missing in the map", path, k))
+		}
+	})
+	return diffs
+}
+
 func (sm StringMap) get(k string) (*otlpcommon.StringKeyValue, bool) {
 	for i := range *sm.orig {
 		skv := &(*sm.orig)[i]
@@ -277,11 +277,47 @@ func TestAttributeValueEqual(t *testing.T) {
 	NewAttributeValueInt(123).CopyTo(av2.ArrayVal().At(0))
 	assert.True(t, av1.Equal(av2))
 
-	av1.ArrayVal().Append(av1)
-	av2.ArrayVal().Append(av1)
+	av1.ArrayVal().Append(NewAttributeValueArray())
+	av1.ArrayVal().At(1).ArrayVal().Append(NewAttributeValueString("abc"))
+	av2.ArrayVal().Append(NewAttributeValueArray())
 	assert.False(t, av1.Equal(av2))
 
+	av2.ArrayVal().At(1).ArrayVal().Append(NewAttributeValueString("abc"))
+	assert.True(t, av1.Equal(av2))
+
 	assert.True(t, av1.Equal(av1))
+
+	assert.False(t, NewAttributeValueArray().Equal(NewAttributeValueMap()))
+	assert.False(t, NewAttributeValueString("").Equal(NewAttributeValueInt(0)))
+
+	av1 = NewAttributeValueMap()
+	av1.MapVal().InsertString("k1", "abc")
+	av1.MapVal().InsertInt("k2", 123)
+	av2 = NewAttributeValueMap()
+	av2.MapVal().InsertInt("k2", 123)
+	assert.False(t, av1.Equal(av2))
+	assert.False(t, av2.Equal(av1))
+
+	av2.MapVal().InsertString("k1", "abc")
+	assert.True(t, av1.Equal(av2))
+
+	av2.MapVal().UpdateString("k1", "edf")
+	assert.False(t, av1.Equal(av2))
+
+	av2.MapVal().Delete("k1")
+	av2.MapVal().InsertString("k3", "abc")
+	assert.False(t, av1.Equal(av2))
+
+	nested := NewAttributeValueMap()
+	nested.MapVal().Insert("k1", av1)
+	av1.MapVal().Insert("k3", nested)
+	av2 = NewAttributeValueMap()
+	av1.CopyTo(av2)
+	assert.True(t, av1.Equal(av2))
+
+	nested, _ = av2.MapVal().Get("k3")
+	nested.MapVal().Delete("k1")
+	assert.False(t, av1.Equal(av2))
 }
 
 func TestNilAttributeMap(t *testing.T) {
@@ -1147,3 +1183,20 @@ func TestAnyValueArrayWithNilValues(t *testing.T) {
 	assert.EqualValues(t, AttributeValueSTRING, val.Type())
 	assert.EqualValues(t, "other_value", val.StringVal())
 }
+
+func TestAttributeMapDiff(t *testing.T) {
+	am := NewAttributeMap().InitFromMap(map[string]AttributeValue{
+		"str":  NewAttributeValueString("value"),
+		"int":  NewAttributeValueInt(1),
+		"only": NewAttributeValueBool(true),
+	})
+	other := NewAttributeMap().InitFromMap(map[string]AttributeValue{
+		"str": NewAttributeValueString("value"),
+		"int": NewAttributeValueDouble(1),
+	})
+	assert.Empty(t, am.diff("Attributes", am, nil))
+	assert.ElementsMatch(t, []string{
+		`Attributes["int"].Type: INT != DOUBLE`,
+		`Attributes["only"]: missing in the other map`,
+	}, am.diff("Attributes", other, nil))
+}
@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/internal/otlpversion"
+)
+
+// ExponentialHistogram represents the type of a metric that is calculated by aggregating as a histogram with
+// exponentially sized buckets all reported double measurements over a time interval.
+//
+// The generated protos do not have exponential histograms, so an ExponentialHistogram cannot be held by a Metric:
+// the exponential histograms received from newer versions of OTLP are converted to DoubleHistogram when unmarshaled.
+// Use CopyToDoubleHistogram to do the same conversion.
+//
+// This is a reference type, if passed by value and callee modifies it the
+// caller will see the modification.
+//
+// Must use NewExponentialHistogram function to create new instances.
+// Important: zero-initialized instance is not valid for use.
+type ExponentialHistogram struct {
+	orig *otlpversion.ExponentialHistogram
+}
+
+// NewExponentialHistogram creates a new empty ExponentialHistogram.
+func NewExponentialHistogram() ExponentialHistogram {
+	return ExponentialHistogram{orig: &otlpversion.ExponentialHistogram{}}
+}
+
+// AggregationTemporality returns the aggregationtemporality associated with this ExponentialHistogram.
+func (ms ExponentialHistogram) AggregationTemporality() AggregationTemporality {
+	return AggregationTemporality((*ms.orig).AggregationTemporality)
+}
+
+// SetAggregationTemporality replaces the aggregationtemporality associated with this ExponentialHistogram.
+func (ms ExponentialHistogram) SetAggregationTemporality(v AggregationTemporality) {
+	(*ms.orig).AggregationTemporality = otlpmetrics.AggregationTemporality(v)
+}
+
+// DataPoints returns the DataPoints associated with this ExponentialHistogram.
+func (ms ExponentialHistogram) DataPoints() ExponentialHistogramDataPointSlice {
+	return ExponentialHistogramDataPointSlice{orig: &(*ms.orig).DataPoints}
+}
+
+// CopyTo copies all properties from the current struct to the dest.
+func (ms ExponentialHistogram) CopyTo(dest ExponentialHistogram) {
+	dest.SetAggregationTemporality(ms.AggregationTemporality())
+	ms.DataPoints().CopyTo(dest.DataPoints())
+}
+
+// CopyToDoubleHistogram converts the ExponentialHistogram to the dest, replacing its data points by the conversions
+// of the data points of the current struct.
+func (ms ExponentialHistogram) CopyToDoubleHistogram(dest DoubleHistogram) {
+	dest.SetAggregationTemporality(ms.AggregationTemporality())
+	dps := ms.DataPoints()
+	destDps := dest.DataPoints()
+	destDps.Resize(dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dps.At(i).CopyToDoubleHistogramDataPoint(destDps.At(i))
+	}
+}
+
+// ExponentialHistogramDataPointSlice logically represents a slice of ExponentialHistogramDataPoint.
+//
+// This is a reference type, if passed by value and callee modifies it the
+// caller will see the modification.
+//
+// Must use NewExponentialHistogramDataPointSlice function to create new instances.
+// Important: zero-initialized instance is not valid for use.
+type ExponentialHistogramDataPointSlice struct {
+	orig *[]*otlpversion.ExponentialHistogramDataPoint
+}
+
+// NewExponentialHistogramDataPointSlice creates an ExponentialHistogramDataPointSlice with 0 elements.
+func NewExponentialHistogramDataPointSlice() ExponentialHistogramDataPointSlice {
+	orig := []*otlpversion.ExponentialHistogramDataPoint(nil)
+	return ExponentialHistogramDataPointSlice{orig: &orig}
+}
+
+// Len returns the number of elements in the slice.
+func (es ExponentialHistogramDataPointSlice) Len() int {
+	return len(*es.orig)
+}
+
+// At returns the element at the given index.
+func (es ExponentialHistogramDataPointSlice) At(ix int) ExponentialHistogramDataPoint {
+	return ExponentialHistogramDataPoint{orig: (*es.orig)[ix]}
+}
+
+// AppendEmpty will append to the end of the slice an empty ExponentialHistogramDataPoint.
+// It returns the newly added ExponentialHistogramDataPoint.
+func (es ExponentialHistogramDataPointSlice) AppendEmpty() ExponentialHistogramDataPoint {
+	*es.orig = append(*es.orig, &otlpversion.ExponentialHistogramDataPoint{})
+	return es.At(es.Len() - 1)
+}
+
+// CopyTo copies all elements from the current slice to the dest.
+func (es ExponentialHistogramDataPointSlice) CopyTo(dest ExponentialHistogramDataPointSlice) {
+	origs := make([]otlpversion.ExponentialHistogramDataPoint, es.Len())
+	wrappers := make([]*otlpversion.ExponentialHistogramDataPoint, es.Len())
+	for i := range *es.orig {
+		wrappers[i] = &origs[i]
+		es.At(i).CopyTo(ExponentialHistogramDataPoint{orig: wrappers[i]})
+	}
+	*dest.orig = wrappers
+}
+
+// ExponentialHistogramDataPoint is a single data point in a timeseries that describes the time-varying values of an
+// exponential histogram of double values.
+//
+// This is a reference type, if passed by value and callee modifies it the
+// caller will see the modification.
+//
+// Must use NewExponentialHistogramDataPoint function to create new instances.
+// Important: zero-initialized instance is not valid for use.
+type ExponentialHistogramDataPoint struct {
+	orig *otlpversion.ExponentialHistogramDataPoint
+}
+
+// NewExponentialHistogramDataPoint creates a new empty ExponentialHistogramDataPoint.
+func NewExponentialHistogramDataPoint() ExponentialHistogramDataPoint {
+	return ExponentialHistogramDataPoint{orig: &otlpversion.ExponentialHistogramDataPoint{}}
+}
+
+// LabelsMap returns the Labels associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) LabelsMap() StringMap {
+	return newStringMap(&(*ms.orig).Labels)
+}
+
+// StartTime returns the starttime associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) StartTime() Timestamp {
+	return Timestamp((*ms.orig).StartTimeUnixNano)
+}
+
+// SetStartTime replaces the starttime associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) SetStartTime(v Timestamp) {
+	(*ms.orig).StartTimeUnixNano = uint64(v)
+}
+
+// Timestamp returns the timestamp associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) Timestamp() Timestamp {
+	return Timestamp((*ms.orig).TimeUnixNano)
+}
+
+// SetTimestamp replaces the timestamp associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) SetTimestamp(v Timestamp) {
+	(*ms.orig).TimeUnixNano = uint64(v)
+}
+
+// Count returns the count associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) Count() uint64 {
+	return (*ms.orig).Count
+}
+
+// SetCount replaces the count associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) SetCount(v uint64) {
+	(*ms.orig).Count = v
+}
+
+// Sum returns the sum associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) Sum() float64 {
+	return (*ms.orig).Sum
+}
+
+// SetSum replaces the sum associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) SetSum(v float64) {
+	(*ms.orig).Sum = v
+}
+
+// Scale returns the scale associated with this ExponentialHistogramDataPoint.
+// The base of the buckets is 2^(2^-scale).
+func (ms ExponentialHistogramDataPoint) Scale() int32 {
+	return (*ms.orig).Scale
+}
+
+// SetScale replaces the scale associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) SetScale(v int32) {
+	(*ms.orig).Scale = v
+}
+
+// ZeroCount returns the zerocount associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) ZeroCount() uint64 {
+	return (*ms.orig).ZeroCount
+}
+
+// SetZeroCount replaces the zerocount associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) SetZeroCount(v uint64) {
+	(*ms.orig).ZeroCount = v
+}
+
+// Positive returns the buckets of the positive values associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) Positive() ExponentialHistogramBuckets {
+	return ExponentialHistogramBuckets{orig: &(*ms.orig).Positive}
+}
+
+// Negative returns the buckets of the negative values associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) Negative() ExponentialHistogramBuckets {
+	return ExponentialHistogramBuckets{orig: &(*ms.orig).Negative}
+}
+
+// Exemplars returns the Exemplars associated with this ExponentialHistogramDataPoint.
+func (ms ExponentialHistogramDataPoint) Exemplars() DoubleExemplarSlice {
+	return newDoubleExemplarSlice(&(*ms.orig).Exemplars)
+}
+
+// CopyTo copies all properties from the current struct to the dest.
+func (ms ExponentialHistogramDataPoint) CopyTo(dest ExponentialHistogramDataPoint) {
+	ms.LabelsMap().CopyTo(dest.LabelsMap())
+	dest.SetStartTime(ms.StartTime())
+	dest.SetTimestamp(ms.Timestamp())
+	dest.SetCount(ms.Count())
+	dest.SetSum(ms.Sum())
+	dest.SetScale(ms.Scale())
+	dest.SetZeroCount(ms.ZeroCount())
+	ms.Positive().CopyTo(dest.Positive())
+	ms.Negative().CopyTo(dest.Negative())
+	ms.Exemplars().CopyTo(dest.Exemplars())
+}
+
+// CopyToDoubleHistogramDataPoint converts the ExponentialHistogramDataPoint to the dest. The explicit bounds of the
+// dest are the bounds of the negative buckets, of the zero bucket, and of the positive buckets, the bucket below the
+// lowest positive bucket and the bucket above the highest one being empty.
+func (ms ExponentialHistogramDataPoint) CopyToDoubleHistogramDataPoint(dest DoubleHistogramDataPoint) {
+	ms.LabelsMap().CopyTo(dest.LabelsMap())
+	dest.SetStartTime(ms.StartTime())
+	dest.SetTimestamp(ms.Timestamp())
+	dest.SetCount(ms.Count())
+	dest.SetSum(ms.Sum())
+	counts, bounds := otlpversion.ExplicitBuckets(ms.Scale(), ms.ZeroCount(), (*ms.orig).Positive, (*ms.orig).Negative)
+	dest.SetBucketCounts(counts)
+	dest.SetExplicitBounds(bounds)
+	ms.Exemplars().CopyTo(dest.Exemplars())
+}
+
+// ExponentialHistogramBuckets are the consecutive buckets of the positive or negative values of an
+// ExponentialHistogramDataPoint. The bucket of index i counts the values whose absolute value is in
+// (base^(offset+i), base^(offset+i+1)].
+//
+// This is a reference type, if passed by value and callee modifies it the
+// caller will see the modification.
+//
+// Must use NewExponentialHistogramBuckets function to create new instances.
+// Important: zero-initialized instance is not valid for use.
+type ExponentialHistogramBuckets struct {
+	orig *otlpversion.ExponentialHistogramBuckets
+}
+
+// NewExponentialHistogramBuckets creates a new empty ExponentialHistogramBuckets.
+func NewExponentialHistogramBuckets() ExponentialHistogramBuckets {
+	return ExponentialHistogramBuckets{orig: &otlpversion.ExponentialHistogramBuckets{}}
+}
+
+// Offset returns the offset associated with this ExponentialHistogramBuckets.
+func (ms ExponentialHistogramBuckets) Offset() int32 {
+	return (*ms.orig).Offset
+}
+
+// SetOffset replaces the offset associated with this ExponentialHistogramBuckets.
+func (ms ExponentialHistogramBuckets) SetOffset(v int32) {
+	(*ms.orig).Offset = v
+}
+
+// BucketCounts returns the bucketcounts associated with this ExponentialHistogramBuckets.
+func (ms ExponentialHistogramBuckets) BucketCounts() []uint64 {
+	return (*ms.orig).BucketCounts
+}
+
+// SetBucketCounts replaces the bucketcounts associated with this ExponentialHistogramBuckets.
+func (ms ExponentialHistogramBuckets) SetBucketCounts(v []uint64) {
+	(*ms.orig).BucketCounts = v
+}
+
+// CopyTo copies all properties from the current struct to the dest.
+func (ms ExponentialHistogramBuckets) CopyTo(dest ExponentialHistogramBuckets) {
+	dest.SetOffset(ms.Offset())
+	dest.SetBucketCounts(ms.BucketCounts())
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestExponentialHistogram() ExponentialHistogram {
+	eh := NewExponentialHistogram()
+	eh.SetAggregationTemporality(AggregationTemporalityDelta)
+	dp := eh.DataPoints().AppendEmpty()
+	dp.LabelsMap().Insert("k", "v")
+	dp.SetStartTime(Timestamp(1))
+	dp.SetTimestamp(Timestamp(2))
+	dp.SetCount(10)
+	dp.SetSum(12.5)
+	dp.SetScale(0)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(1)
+	dp.Positive().SetBucketCounts([]uint64{2, 3})
+	dp.Negative().SetBucketCounts([]uint64{4})
+	dp.Exemplars().AppendEmpty().SetValue(3)
+	return eh
+}
+
+func TestExponentialHistogram_CopyTo(t *testing.T) {
+	eh := generateTestExponentialHistogram()
+	dest := NewExponentialHistogram()
+	dest.DataPoints().AppendEmpty().SetCount(1)
+	eh.CopyTo(dest)
+
+	assert.EqualValues(t, eh, dest)
+	require.Equal(t, 1, dest.DataPoints().Len())
+	dp := dest.DataPoints().At(0)
+	assert.Equal(t, AggregationTemporalityDelta, dest.AggregationTemporality())
+	assert.Equal(t, NewStringMap().InitFromMap(map[string]string{"k": "v"}), dp.LabelsMap())
+	assert.Equal(t, int32(1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{2, 3}, dp.Positive().BucketCounts())
+	assert.Equal(t, []uint64{4}, dp.Negative().BucketCounts())
+	assert.Equal(t, 1, dp.Exemplars().Len())
+
+	// The copy does not share the data points.
+	dp.SetCount(20)
+	assert.Equal(t, uint64(10), eh.DataPoints().At(0).Count())
+}
+
+func TestExponentialHistogram_CopyToDoubleHistogram(t *testing.T) {
+	dh := NewDoubleHistogram()
+	dh.DataPoints().Resize(2)
+	generateTestExponentialHistogram().CopyToDoubleHistogram(dh)
+
+	assert.Equal(t, AggregationTemporalityDelta, dh.AggregationTemporality())
+	require.Equal(t, 1, dh.DataPoints().Len())
+	dp := dh.DataPoints().At(0)
+	assert.Equal(t, NewStringMap().InitFromMap(map[string]string{"k": "v"}), dp.LabelsMap())
+	assert.Equal(t, Timestamp(1), dp.StartTime())
+	assert.Equal(t, Timestamp(2), dp.Timestamp())
+	assert.Equal(t, uint64(10), dp.Count())
+	assert.Equal(t, 12.5, dp.Sum())
+	assert.Equal(t, []uint64{4, 1, 0, 2, 3, 0}, dp.BucketCounts())
+	assert.Equal(t, []float64{-1, 0, 2, 4, 8}, dp.ExplicitBounds())
+	require.Equal(t, 1, dp.Exemplars().Len())
+	assert.Equal(t, 3.0, dp.Exemplars().At(0).Value())
+}
+
+func TestExponentialHistogramDataPoint_CopyToDoubleHistogramDataPoint_Scale(t *testing.T) {
+	edp := NewExponentialHistogramDataPoint()
+	edp.SetScale(1)
+	edp.Positive().SetBucketCounts([]uint64{1, 2})
+	dp := NewDoubleHistogramDataPoint()
+	edp.CopyToDoubleHistogramDataPoint(dp)
+
+	assert.Equal(t, []uint64{0, 0, 1, 2, 0}, dp.BucketCounts())
+	require.Len(t, dp.ExplicitBounds(), 4)
+	assert.Equal(t, 0.0, dp.ExplicitBounds()[0])
+	assert.Equal(t, 1.0, dp.ExplicitBounds()[1])
+	assert.InDelta(t, 1.4142135, dp.ExplicitBounds()[2], 1e-6)
+	assert.InDelta(t, 2.0, dp.ExplicitBounds()[3], 1e-9)
+}
@@ -18,6 +18,10 @@
 package pdata
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
 	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
 )
 
@@ -69,6 +73,27 @@ func (ms InstrumentationLibrary) CopyTo(dest InstrumentationLibrary) {
 	dest.SetVersion(ms.Version())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms InstrumentationLibrary) Equal(other InstrumentationLibrary) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms InstrumentationLibrary) Diff(other InstrumentationLibrary) []string {
+	return ms.diff("InstrumentationLibrary", other, nil)
+}
+
+func (ms InstrumentationLibrary) diff(path string, other InstrumentationLibrary, diffs []string) []string {
+	if ms.Name() != other.Name() {
+		diffs = append(diffs, fmt.Sprintf("%s.Name: %v != %v", path, ms.Name(), other.Name()))
+	}
+	if ms.Version() != other.Version() {
+		diffs = append(diffs, fmt.Sprintf("%s.Version: %v != %v", path, ms.Version(), other.Version()))
+	}
+	return diffs
+}
+
 // AnyValueArray logically represents a slice of AttributeValue.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -170,6 +195,28 @@ func (es AnyValueArray) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new AnyValueArray can be initialized:
+// es := NewAnyValueArray()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es AnyValueArray) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]otlpcommon.AnyValue, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the AnyValueArray by one and set the
 // given AttributeValue at that new position.  The original AttributeValue
 // could still be referenced so do not reuse it after passing it to this
@@ -177,3 +224,70 @@ func (es AnyValueArray) Resize(newLen int) {
 func (es AnyValueArray) Append(e AttributeValue) {
 	*es.orig = append(*es.orig, *e.orig)
 }
+
+// AppendEmpty will append to the end of the slice an empty AttributeValue.
+// It returns the newly added AttributeValue.
+func (es AnyValueArray) AppendEmpty() AttributeValue {
+	*es.orig = append(*es.orig, otlpcommon.AnyValue{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es AnyValueArray) RemoveIf(f func(AttributeValue) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = otlpcommon.AnyValue{}
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es AnyValueArray) Equal(other AnyValueArray) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es AnyValueArray) Diff(other AnyValueArray) []string {
+	return es.diff("AnyValueArray", other, nil)
+}
+
+func (es AnyValueArray) diff(path string, other AnyValueArray, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the AttributeValue elements within AnyValueArray given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b AttributeValue) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es AnyValueArray) Sort(less func(a, b AttributeValue) bool) AnyValueArray {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
@@ -18,6 +18,7 @@
 package pdata
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,6 +32,14 @@ func TestInstrumentationLibrary_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestInstrumentationLibrary(), ms)
 }
 
+func TestInstrumentationLibrary_Equal(t *testing.T) {
+	ms := generateTestInstrumentationLibrary()
+	assert.True(t, ms.Equal(generateTestInstrumentationLibrary()))
+	assert.Empty(t, ms.Diff(generateTestInstrumentationLibrary()))
+	assert.False(t, ms.Equal(NewInstrumentationLibrary()))
+	assert.NotEmpty(t, ms.Diff(NewInstrumentationLibrary()))
+}
+
 func TestInstrumentationLibrary_Name(t *testing.T) {
 	ms := NewInstrumentationLibrary()
 	assert.EqualValues(t, "", ms.Name())
@@ -146,6 +155,23 @@ func TestAnyValueArray_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestAnyValueArray_EnsureCapacity(t *testing.T) {
+	es := generateTestAnyValueArray()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestAnyValueArray(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestAnyValueArray(), es)
+}
+
 func TestAnyValueArray_Append(t *testing.T) {
 	es := generateTestAnyValueArray()
 
@@ -161,6 +187,75 @@ func TestAnyValueArray_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestAnyValueArray_AppendEmpty(t *testing.T) {
+	es := generateTestAnyValueArray()
+	emptyVal := NewAttributeValue()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestAttributeValue(e)
+	assert.EqualValues(t, generateTestAttributeValue(), es.At(7))
+}
+
+func TestAnyValueArray_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewAnyValueArray()
+	emptySlice.RemoveIf(func(el AttributeValue) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestAnyValueArray()
+	expectedSlice := NewAnyValueArray()
+	pos := 0
+	filtered.RemoveIf(func(el AttributeValue) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestAnyValueArray_Equal(t *testing.T) {
+	es := generateTestAnyValueArray()
+	assert.True(t, es.Equal(generateTestAnyValueArray()))
+	assert.True(t, NewAnyValueArray().Equal(NewAnyValueArray()))
+	assert.Equal(t, []string{"AnyValueArray: length 7 != 0"}, es.Diff(NewAnyValueArray()))
+
+	// Test Equal with a different element
+	other := generateTestAnyValueArray()
+	other.Resize(6)
+	other.Append(NewAttributeValue())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "AnyValueArray[6]."), diff)
+	}
+}
+
+func TestAnyValueArray_Sort(t *testing.T) {
+	es := generateTestAnyValueArray()
+	emptyVal := NewAttributeValue()
+	es.Append(NewAttributeValue())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b AttributeValue) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestAttributeValue()))
+	}
+}
+
 func generateTestInstrumentationLibrary() InstrumentationLibrary {
 	tv := NewInstrumentationLibrary()
 	fillTestInstrumentationLibrary(tv)
@@ -18,6 +18,10 @@
 package pdata
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
 	"go.opentelemetry.io/collector/internal/data"
 	otlplogs "go.opentelemetry.io/collector/internal/data/protogen/logs/v1"
 )
@@ -128,6 +132,28 @@ func (es ResourceLogsSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new ResourceLogsSlice can be initialized:
+// es := NewResourceLogsSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es ResourceLogsSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlplogs.ResourceLogs, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the ResourceLogsSlice by one and set the
 // given ResourceLogs at that new position.  The original ResourceLogs
 // could still be referenced so do not reuse it after passing it to this
@@ -136,6 +162,73 @@ func (es ResourceLogsSlice) Append(e ResourceLogs) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty ResourceLogs.
+// It returns the newly added ResourceLogs.
+func (es ResourceLogsSlice) AppendEmpty() ResourceLogs {
+	*es.orig = append(*es.orig, &otlplogs.ResourceLogs{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es ResourceLogsSlice) Equal(other ResourceLogsSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es ResourceLogsSlice) Diff(other ResourceLogsSlice) []string {
+	return es.diff("ResourceLogsSlice", other, nil)
+}
+
+func (es ResourceLogsSlice) diff(path string, other ResourceLogsSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the ResourceLogs elements within ResourceLogsSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b ResourceLogs) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es ResourceLogsSlice) Sort(less func(a, b ResourceLogs) bool) ResourceLogsSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // ResourceLogs is a collection of logs from a Resource.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -163,6 +256,12 @@ func (ms ResourceLogs) Resource() Resource {
 	return newResource(&(*ms.orig).Resource)
 }
 
+// HasResource returns true if the resource associated with this ResourceLogs is set.
+// The resource is embedded in the OTLP struct, an empty resource is reported as not set.
+func (ms ResourceLogs) HasResource() bool {
+	return (*ms.orig).Resource.Size() != 0
+}
+
 // InstrumentationLibraryLogs returns the InstrumentationLibraryLogs associated with this ResourceLogs.
 func (ms ResourceLogs) InstrumentationLibraryLogs() InstrumentationLibraryLogsSlice {
 	return newInstrumentationLibraryLogsSlice(&(*ms.orig).InstrumentationLibraryLogs)
@@ -174,6 +273,23 @@ func (ms ResourceLogs) CopyTo(dest ResourceLogs) {
 	ms.InstrumentationLibraryLogs().CopyTo(dest.InstrumentationLibraryLogs())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms ResourceLogs) Equal(other ResourceLogs) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms ResourceLogs) Diff(other ResourceLogs) []string {
+	return ms.diff("ResourceLogs", other, nil)
+}
+
+func (ms ResourceLogs) diff(path string, other ResourceLogs, diffs []string) []string {
+	diffs = ms.Resource().diff(path+".Resource", other.Resource(), diffs)
+	diffs = ms.InstrumentationLibraryLogs().diff(path+".InstrumentationLibraryLogs", other.InstrumentationLibraryLogs(), diffs)
+	return diffs
+}
+
 // InstrumentationLibraryLogsSlice logically represents a slice of InstrumentationLibraryLogs.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -280,6 +396,28 @@ func (es InstrumentationLibraryLogsSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new InstrumentationLibraryLogsSlice can be initialized:
+// es := NewInstrumentationLibraryLogsSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es InstrumentationLibraryLogsSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlplogs.InstrumentationLibraryLogs, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the InstrumentationLibraryLogsSlice by one and set the
 // given InstrumentationLibraryLogs at that new position.  The original InstrumentationLibraryLogs
 // could still be referenced so do not reuse it after passing it to this
@@ -288,6 +426,73 @@ func (es InstrumentationLibraryLogsSlice) Append(e InstrumentationLibraryLogs) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty InstrumentationLibraryLogs.
+// It returns the newly added InstrumentationLibraryLogs.
+func (es InstrumentationLibraryLogsSlice) AppendEmpty() InstrumentationLibraryLogs {
+	*es.orig = append(*es.orig, &otlplogs.InstrumentationLibraryLogs{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es InstrumentationLibraryLogsSlice) RemoveIf(f func(InstrumentationLibraryLogs) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es InstrumentationLibraryLogsSlice) Equal(other InstrumentationLibraryLogsSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es InstrumentationLibraryLogsSlice) Diff(other InstrumentationLibraryLogsSlice) []string {
+	return es.diff("InstrumentationLibraryLogsSlice", other, nil)
+}
+
+func (es InstrumentationLibraryLogsSlice) diff(path string, other InstrumentationLibraryLogsSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the InstrumentationLibraryLogs elements within InstrumentationLibraryLogsSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b InstrumentationLibraryLogs) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es InstrumentationLibraryLogsSlice) Sort(less func(a, b InstrumentationLibraryLogs) bool) InstrumentationLibraryLogsSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // InstrumentationLibraryLogs is a collection of logs from a LibraryInstrumentation.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -315,6 +520,12 @@ func (ms InstrumentationLibraryLogs) InstrumentationLibrary() InstrumentationLib
 	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary)
 }
 
+// HasInstrumentationLibrary returns true if the instrumentationlibrary associated with this InstrumentationLibraryLogs is set.
+// The instrumentationlibrary is embedded in the OTLP struct, an empty instrumentationlibrary is reported as not set.
+func (ms InstrumentationLibraryLogs) HasInstrumentationLibrary() bool {
+	return (*ms.orig).InstrumentationLibrary.Size() != 0
+}
+
 // Logs returns the Logs associated with this InstrumentationLibraryLogs.
 func (ms InstrumentationLibraryLogs) Logs() LogSlice {
 	return newLogSlice(&(*ms.orig).Logs)
@@ -326,6 +537,23 @@ func (ms InstrumentationLibraryLogs) CopyTo(dest InstrumentationLibraryLogs) {
 	ms.Logs().CopyTo(dest.Logs())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms InstrumentationLibraryLogs) Equal(other InstrumentationLibraryLogs) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms InstrumentationLibraryLogs) Diff(other InstrumentationLibraryLogs) []string {
+	return ms.diff("InstrumentationLibraryLogs", other, nil)
+}
+
+func (ms InstrumentationLibraryLogs) diff(path string, other InstrumentationLibraryLogs, diffs []string) []string {
+	diffs = ms.InstrumentationLibrary().diff(path+".InstrumentationLibrary", other.InstrumentationLibrary(), diffs)
+	diffs = ms.Logs().diff(path+".Logs", other.Logs(), diffs)
+	return diffs
+}
+
 // LogSlice logically represents a slice of LogRecord.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -432,6 +660,28 @@ func (es LogSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new LogSlice can be initialized:
+// es := NewLogSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es LogSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlplogs.LogRecord, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the LogSlice by one and set the
 // given LogRecord at that new position.  The original LogRecord
 // could still be referenced so do not reuse it after passing it to this
@@ -440,6 +690,73 @@ func (es LogSlice) Append(e LogRecord) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty LogRecord.
+// It returns the newly added LogRecord.
+func (es LogSlice) AppendEmpty() LogRecord {
+	*es.orig = append(*es.orig, &otlplogs.LogRecord{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es LogSlice) RemoveIf(f func(LogRecord) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es LogSlice) Equal(other LogSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es LogSlice) Diff(other LogSlice) []string {
+	return es.diff("LogSlice", other, nil)
+}
+
+func (es LogSlice) diff(path string, other LogSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the LogRecord elements within LogSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b LogRecord) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es LogSlice) Sort(less func(a, b LogRecord) bool) LogSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // LogRecord are experimental implementation of OpenTelemetry Log Data Model.
 
 //
@@ -538,6 +855,12 @@ func (ms LogRecord) Body() AttributeValue {
 	return newAttributeValue(&(*ms.orig).Body)
 }
 
+// HasBody returns true if the body associated with this LogRecord is set.
+// The body is embedded in the OTLP struct, an empty body is reported as not set.
+func (ms LogRecord) HasBody() bool {
+	return (*ms.orig).Body.Size() != 0
+}
+
 // Attributes returns the Attributes associated with this LogRecord.
 func (ms LogRecord) Attributes() AttributeMap {
 	return newAttributeMap(&(*ms.orig).Attributes)
@@ -566,3 +889,44 @@ func (ms LogRecord) CopyTo(dest LogRecord) {
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
+
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms LogRecord) Equal(other LogRecord) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms LogRecord) Diff(other LogRecord) []string {
+	return ms.diff("LogRecord", other, nil)
+}
+
+func (ms LogRecord) diff(path string, other LogRecord, diffs []string) []string {
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.TraceID() != other.TraceID() {
+		diffs = append(diffs, fmt.Sprintf("%s.TraceID: %v != %v", path, ms.TraceID().HexString(), other.TraceID().HexString()))
+	}
+	if ms.SpanID() != other.SpanID() {
+		diffs = append(diffs, fmt.Sprintf("%s.SpanID: %v != %v", path, ms.SpanID().HexString(), other.SpanID().HexString()))
+	}
+	if ms.Flags() != other.Flags() {
+		diffs = append(diffs, fmt.Sprintf("%s.Flags: %v != %v", path, ms.Flags(), other.Flags()))
+	}
+	if ms.SeverityText() != other.SeverityText() {
+		diffs = append(diffs, fmt.Sprintf("%s.SeverityText: %v != %v", path, ms.SeverityText(), other.SeverityText()))
+	}
+	if ms.SeverityNumber() != other.SeverityNumber() {
+		diffs = append(diffs, fmt.Sprintf("%s.SeverityNumber: %v != %v", path, ms.SeverityNumber(), other.SeverityNumber()))
+	}
+	if ms.Name() != other.Name() {
+		diffs = append(diffs, fmt.Sprintf("%s.Name: %v != %v", path, ms.Name(), other.Name()))
+	}
+	diffs = ms.Body().diff(path+".Body", other.Body(), diffs)
+	diffs = ms.Attributes().diff(path+".Attributes", other.Attributes(), diffs)
+	if ms.DroppedAttributesCount() != other.DroppedAttributesCount() {
+		diffs = append(diffs, fmt.Sprintf("%s.DroppedAttributesCount: %v != %v", path, ms.DroppedAttributesCount(), other.DroppedAttributesCount()))
+	}
+	return diffs
+}
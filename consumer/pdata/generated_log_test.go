@@ -18,6 +18,7 @@
 package pdata
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -124,6 +125,23 @@ func TestResourceLogsSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestResourceLogsSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestResourceLogsSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestResourceLogsSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestResourceLogsSlice(), es)
+}
+
 func TestResourceLogsSlice_Append(t *testing.T) {
 	es := generateTestResourceLogsSlice()
 
@@ -139,15 +157,94 @@ func TestResourceLogsSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestResourceLogsSlice_AppendEmpty(t *testing.T) {
+	es := generateTestResourceLogsSlice()
+	emptyVal := NewResourceLogs()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestResourceLogs(e)
+	assert.EqualValues(t, generateTestResourceLogs(), es.At(7))
+}
+
+func TestResourceLogsSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewResourceLogsSlice()
+	emptySlice.RemoveIf(func(el ResourceLogs) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestResourceLogsSlice()
+	expectedSlice := NewResourceLogsSlice()
+	pos := 0
+	filtered.RemoveIf(func(el ResourceLogs) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestResourceLogsSlice_Equal(t *testing.T) {
+	es := generateTestResourceLogsSlice()
+	assert.True(t, es.Equal(generateTestResourceLogsSlice()))
+	assert.True(t, NewResourceLogsSlice().Equal(NewResourceLogsSlice()))
+	assert.Equal(t, []string{"ResourceLogsSlice: length 7 != 0"}, es.Diff(NewResourceLogsSlice()))
+
+	// Test Equal with a different element
+	other := generateTestResourceLogsSlice()
+	other.Resize(6)
+	other.Append(NewResourceLogs())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "ResourceLogsSlice[6]."), diff)
+	}
+}
+
+func TestResourceLogsSlice_Sort(t *testing.T) {
+	es := generateTestResourceLogsSlice()
+	emptyVal := NewResourceLogs()
+	es.Append(NewResourceLogs())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b ResourceLogs) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestResourceLogs()))
+	}
+}
+
 func TestResourceLogs_CopyTo(t *testing.T) {
 	ms := NewResourceLogs()
 	generateTestResourceLogs().CopyTo(ms)
 	assert.EqualValues(t, generateTestResourceLogs(), ms)
 }
 
+func TestResourceLogs_Equal(t *testing.T) {
+	ms := generateTestResourceLogs()
+	assert.True(t, ms.Equal(generateTestResourceLogs()))
+	assert.Empty(t, ms.Diff(generateTestResourceLogs()))
+	assert.False(t, ms.Equal(NewResourceLogs()))
+	assert.NotEmpty(t, ms.Diff(NewResourceLogs()))
+}
+
 func TestResourceLogs_Resource(t *testing.T) {
 	ms := NewResourceLogs()
+	assert.False(t, ms.HasResource())
 	fillTestResource(ms.Resource())
+	assert.True(t, ms.HasResource())
 	assert.EqualValues(t, generateTestResource(), ms.Resource())
 }
 
@@ -258,6 +355,23 @@ func TestInstrumentationLibraryLogsSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestInstrumentationLibraryLogsSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestInstrumentationLibraryLogsSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestInstrumentationLibraryLogsSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestInstrumentationLibraryLogsSlice(), es)
+}
+
 func TestInstrumentationLibraryLogsSlice_Append(t *testing.T) {
 	es := generateTestInstrumentationLibraryLogsSlice()
 
@@ -273,15 +387,94 @@ func TestInstrumentationLibraryLogsSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestInstrumentationLibraryLogsSlice_AppendEmpty(t *testing.T) {
+	es := generateTestInstrumentationLibraryLogsSlice()
+	emptyVal := NewInstrumentationLibraryLogs()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestInstrumentationLibraryLogs(e)
+	assert.EqualValues(t, generateTestInstrumentationLibraryLogs(), es.At(7))
+}
+
+func TestInstrumentationLibraryLogsSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewInstrumentationLibraryLogsSlice()
+	emptySlice.RemoveIf(func(el InstrumentationLibraryLogs) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestInstrumentationLibraryLogsSlice()
+	expectedSlice := NewInstrumentationLibraryLogsSlice()
+	pos := 0
+	filtered.RemoveIf(func(el InstrumentationLibraryLogs) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestInstrumentationLibraryLogsSlice_Equal(t *testing.T) {
+	es := generateTestInstrumentationLibraryLogsSlice()
+	assert.True(t, es.Equal(generateTestInstrumentationLibraryLogsSlice()))
+	assert.True(t, NewInstrumentationLibraryLogsSlice().Equal(NewInstrumentationLibraryLogsSlice()))
+	assert.Equal(t, []string{"InstrumentationLibraryLogsSlice: length 7 != 0"}, es.Diff(NewInstrumentationLibraryLogsSlice()))
+
+	// Test Equal with a different element
+	other := generateTestInstrumentationLibraryLogsSlice()
+	other.Resize(6)
+	other.Append(NewInstrumentationLibraryLogs())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "InstrumentationLibraryLogsSlice[6]."), diff)
+	}
+}
+
+func TestInstrumentationLibraryLogsSlice_Sort(t *testing.T) {
+	es := generateTestInstrumentationLibraryLogsSlice()
+	emptyVal := NewInstrumentationLibraryLogs()
+	es.Append(NewInstrumentationLibraryLogs())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b InstrumentationLibraryLogs) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestInstrumentationLibraryLogs()))
+	}
+}
+
 func TestInstrumentationLibraryLogs_CopyTo(t *testing.T) {
 	ms := NewInstrumentationLibraryLogs()
 	generateTestInstrumentationLibraryLogs().CopyTo(ms)
 	assert.EqualValues(t, generateTestInstrumentationLibraryLogs(), ms)
 }
 
+func TestInstrumentationLibraryLogs_Equal(t *testing.T) {
+	ms := generateTestInstrumentationLibraryLogs()
+	assert.True(t, ms.Equal(generateTestInstrumentationLibraryLogs()))
+	assert.Empty(t, ms.Diff(generateTestInstrumentationLibraryLogs()))
+	assert.False(t, ms.Equal(NewInstrumentationLibraryLogs()))
+	assert.NotEmpty(t, ms.Diff(NewInstrumentationLibraryLogs()))
+}
+
 func TestInstrumentationLibraryLogs_InstrumentationLibrary(t *testing.T) {
 	ms := NewInstrumentationLibraryLogs()
+	assert.False(t, ms.HasInstrumentationLibrary())
 	fillTestInstrumentationLibrary(ms.InstrumentationLibrary())
+	assert.True(t, ms.HasInstrumentationLibrary())
 	assert.EqualValues(t, generateTestInstrumentationLibrary(), ms.InstrumentationLibrary())
 }
 
@@ -392,6 +585,23 @@ func TestLogSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestLogSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestLogSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestLogSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestLogSlice(), es)
+}
+
 func TestLogSlice_Append(t *testing.T) {
 	es := generateTestLogSlice()
 
@@ -407,12 +617,89 @@ func TestLogSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestLogSlice_AppendEmpty(t *testing.T) {
+	es := generateTestLogSlice()
+	emptyVal := NewLogRecord()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestLogRecord(e)
+	assert.EqualValues(t, generateTestLogRecord(), es.At(7))
+}
+
+func TestLogSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewLogSlice()
+	emptySlice.RemoveIf(func(el LogRecord) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestLogSlice()
+	expectedSlice := NewLogSlice()
+	pos := 0
+	filtered.RemoveIf(func(el LogRecord) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestLogSlice_Equal(t *testing.T) {
+	es := generateTestLogSlice()
+	assert.True(t, es.Equal(generateTestLogSlice()))
+	assert.True(t, NewLogSlice().Equal(NewLogSlice()))
+	assert.Equal(t, []string{"LogSlice: length 7 != 0"}, es.Diff(NewLogSlice()))
+
+	// Test Equal with a different element
+	other := generateTestLogSlice()
+	other.Resize(6)
+	other.Append(NewLogRecord())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "LogSlice[6]."), diff)
+	}
+}
+
+func TestLogSlice_Sort(t *testing.T) {
+	es := generateTestLogSlice()
+	emptyVal := NewLogRecord()
+	es.Append(NewLogRecord())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b LogRecord) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestLogRecord()))
+	}
+}
+
 func TestLogRecord_CopyTo(t *testing.T) {
 	ms := NewLogRecord()
 	generateTestLogRecord().CopyTo(ms)
 	assert.EqualValues(t, generateTestLogRecord(), ms)
 }
 
+func TestLogRecord_Equal(t *testing.T) {
+	ms := generateTestLogRecord()
+	assert.True(t, ms.Equal(generateTestLogRecord()))
+	assert.Empty(t, ms.Diff(generateTestLogRecord()))
+	assert.False(t, ms.Equal(NewLogRecord()))
+	assert.NotEmpty(t, ms.Diff(NewLogRecord()))
+}
+
 func TestLogRecord_Timestamp(t *testing.T) {
 	ms := NewLogRecord()
 	assert.EqualValues(t, Timestamp(0), ms.Timestamp())
@@ -471,7 +758,9 @@ func TestLogRecord_Name(t *testing.T) {
 
 func TestLogRecord_Body(t *testing.T) {
 	ms := NewLogRecord()
+	assert.False(t, ms.HasBody())
 	fillTestAttributeValue(ms.Body())
+	assert.True(t, ms.HasBody())
 	assert.EqualValues(t, generateTestAttributeValue(), ms.Body())
 }
 
@@ -18,6 +18,11 @@
 package pdata
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"go.opentelemetry.io/collector/internal/data"
 	otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
 )
 
@@ -127,6 +132,28 @@ func (es ResourceMetricsSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new ResourceMetricsSlice can be initialized:
+// es := NewResourceMetricsSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es ResourceMetricsSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.ResourceMetrics, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the ResourceMetricsSlice by one and set the
 // given ResourceMetrics at that new position.  The original ResourceMetrics
 // could still be referenced so do not reuse it after passing it to this
@@ -135,6 +162,73 @@ func (es ResourceMetricsSlice) Append(e ResourceMetrics) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty ResourceMetrics.
+// It returns the newly added ResourceMetrics.
+func (es ResourceMetricsSlice) AppendEmpty() ResourceMetrics {
+	*es.orig = append(*es.orig, &otlpmetrics.ResourceMetrics{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es ResourceMetricsSlice) RemoveIf(f func(ResourceMetrics) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es ResourceMetricsSlice) Equal(other ResourceMetricsSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es ResourceMetricsSlice) Diff(other ResourceMetricsSlice) []string {
+	return es.diff("ResourceMetricsSlice", other, nil)
+}
+
+func (es ResourceMetricsSlice) diff(path string, other ResourceMetricsSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the ResourceMetrics elements within ResourceMetricsSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b ResourceMetrics) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es ResourceMetricsSlice) Sort(less func(a, b ResourceMetrics) bool) ResourceMetricsSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // InstrumentationLibraryMetrics is a collection of metrics from a LibraryInstrumentation.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -162,6 +256,12 @@ func (ms ResourceMetrics) Resource() Resource {
 	return newResource(&(*ms.orig).Resource)
 }
 
+// HasResource returns true if the resource associated with this ResourceMetrics is set.
+// The resource is embedded in the OTLP struct, an empty resource is reported as not set.
+func (ms ResourceMetrics) HasResource() bool {
+	return (*ms.orig).Resource.Size() != 0
+}
+
 // InstrumentationLibraryMetrics returns the InstrumentationLibraryMetrics associated with this ResourceMetrics.
 func (ms ResourceMetrics) InstrumentationLibraryMetrics() InstrumentationLibraryMetricsSlice {
 	return newInstrumentationLibraryMetricsSlice(&(*ms.orig).InstrumentationLibraryMetrics)
@@ -173,6 +273,23 @@ func (ms ResourceMetrics) CopyTo(dest ResourceMetrics) {
 	ms.InstrumentationLibraryMetrics().CopyTo(dest.InstrumentationLibraryMetrics())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms ResourceMetrics) Equal(other ResourceMetrics) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms ResourceMetrics) Diff(other ResourceMetrics) []string {
+	return ms.diff("ResourceMetrics", other, nil)
+}
+
+func (ms ResourceMetrics) diff(path string, other ResourceMetrics, diffs []string) []string {
+	diffs = ms.Resource().diff(path+".Resource", other.Resource(), diffs)
+	diffs = ms.InstrumentationLibraryMetrics().diff(path+".InstrumentationLibraryMetrics", other.InstrumentationLibraryMetrics(), diffs)
+	return diffs
+}
+
 // InstrumentationLibraryMetricsSlice logically represents a slice of InstrumentationLibraryMetrics.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -279,6 +396,28 @@ func (es InstrumentationLibraryMetricsSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new InstrumentationLibraryMetricsSlice can be initialized:
+// es := NewInstrumentationLibraryMetricsSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es InstrumentationLibraryMetricsSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.InstrumentationLibraryMetrics, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the InstrumentationLibraryMetricsSlice by one and set the
 // given InstrumentationLibraryMetrics at that new position.  The original InstrumentationLibraryMetrics
 // could still be referenced so do not reuse it after passing it to this
@@ -287,6 +426,73 @@ func (es InstrumentationLibraryMetricsSlice) Append(e InstrumentationLibraryMetr
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty InstrumentationLibraryMetrics.
+// It returns the newly added InstrumentationLibraryMetrics.
+func (es InstrumentationLibraryMetricsSlice) AppendEmpty() InstrumentationLibraryMetrics {
+	*es.orig = append(*es.orig, &otlpmetrics.InstrumentationLibraryMetrics{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es InstrumentationLibraryMetricsSlice) RemoveIf(f func(InstrumentationLibraryMetrics) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es InstrumentationLibraryMetricsSlice) Equal(other InstrumentationLibraryMetricsSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es InstrumentationLibraryMetricsSlice) Diff(other InstrumentationLibraryMetricsSlice) []string {
+	return es.diff("InstrumentationLibraryMetricsSlice", other, nil)
+}
+
+func (es InstrumentationLibraryMetricsSlice) diff(path string, other InstrumentationLibraryMetricsSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the InstrumentationLibraryMetrics elements within InstrumentationLibraryMetricsSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b InstrumentationLibraryMetrics) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es InstrumentationLibraryMetricsSlice) Sort(less func(a, b InstrumentationLibraryMetrics) bool) InstrumentationLibraryMetricsSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // InstrumentationLibraryMetrics is a collection of metrics from a LibraryInstrumentation.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -314,6 +520,12 @@ func (ms InstrumentationLibraryMetrics) InstrumentationLibrary() Instrumentation
 	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary)
 }
 
+// HasInstrumentationLibrary returns true if the instrumentationlibrary associated with this InstrumentationLibraryMetrics is set.
+// The instrumentationlibrary is embedded in the OTLP struct, an empty instrumentationlibrary is reported as not set.
+func (ms InstrumentationLibraryMetrics) HasInstrumentationLibrary() bool {
+	return (*ms.orig).InstrumentationLibrary.Size() != 0
+}
+
 // Metrics returns the Metrics associated with this InstrumentationLibraryMetrics.
 func (ms InstrumentationLibraryMetrics) Metrics() MetricSlice {
 	return newMetricSlice(&(*ms.orig).Metrics)
@@ -325,6 +537,23 @@ func (ms InstrumentationLibraryMetrics) CopyTo(dest InstrumentationLibraryMetric
 	ms.Metrics().CopyTo(dest.Metrics())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms InstrumentationLibraryMetrics) Equal(other InstrumentationLibraryMetrics) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms InstrumentationLibraryMetrics) Diff(other InstrumentationLibraryMetrics) []string {
+	return ms.diff("InstrumentationLibraryMetrics", other, nil)
+}
+
+func (ms InstrumentationLibraryMetrics) diff(path string, other InstrumentationLibraryMetrics, diffs []string) []string {
+	diffs = ms.InstrumentationLibrary().diff(path+".InstrumentationLibrary", other.InstrumentationLibrary(), diffs)
+	diffs = ms.Metrics().diff(path+".Metrics", other.Metrics(), diffs)
+	return diffs
+}
+
 // MetricSlice logically represents a slice of Metric.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -431,6 +660,28 @@ func (es MetricSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new MetricSlice can be initialized:
+// es := NewMetricSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es MetricSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.Metric, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the MetricSlice by one and set the
 // given Metric at that new position.  The original Metric
 // could still be referenced so do not reuse it after passing it to this
@@ -439,6 +690,73 @@ func (es MetricSlice) Append(e Metric) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty Metric.
+// It returns the newly added Metric.
+func (es MetricSlice) AppendEmpty() Metric {
+	*es.orig = append(*es.orig, &otlpmetrics.Metric{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es MetricSlice) RemoveIf(f func(Metric) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es MetricSlice) Equal(other MetricSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es MetricSlice) Diff(other MetricSlice) []string {
+	return es.diff("MetricSlice", other, nil)
+}
+
+func (es MetricSlice) diff(path string, other MetricSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the Metric elements within MetricSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b Metric) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es MetricSlice) Sort(less func(a, b Metric) bool) MetricSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // Metric represents one metric as a collection of datapoints.
 // See Metric definition in OTLP: https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/metrics/v1/metrics.proto
 //
@@ -492,6 +810,11 @@ func (ms Metric) SetUnit(v string) {
 	(*ms.orig).Unit = v
 }
 
+// HasData returns true if the data of this Metric is set.
+func (ms Metric) HasData() bool {
+	return (*ms.orig).Data != nil
+}
+
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Metric) CopyTo(dest Metric) {
 	dest.SetName(ms.Name())
@@ -500,6 +823,31 @@ func (ms Metric) CopyTo(dest Metric) {
 	copyData(ms.orig, dest.orig)
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms Metric) Equal(other Metric) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms Metric) Diff(other Metric) []string {
+	return ms.diff("Metric", other, nil)
+}
+
+func (ms Metric) diff(path string, other Metric, diffs []string) []string {
+	if ms.Name() != other.Name() {
+		diffs = append(diffs, fmt.Sprintf("%s.Name: %v != %v", path, ms.Name(), other.Name()))
+	}
+	if ms.Description() != other.Description() {
+		diffs = append(diffs, fmt.Sprintf("%s.Description: %v != %v", path, ms.Description(), other.Description()))
+	}
+	if ms.Unit() != other.Unit() {
+		diffs = append(diffs, fmt.Sprintf("%s.Unit: %v != %v", path, ms.Unit(), other.Unit()))
+	}
+	diffs = diffData(path, ms, other, diffs)
+	return diffs
+}
+
 // IntGauge represents the type of a int scalar metric that always exports the "current value" for every data point.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -532,6 +880,22 @@ func (ms IntGauge) CopyTo(dest IntGauge) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms IntGauge) Equal(other IntGauge) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms IntGauge) Diff(other IntGauge) []string {
+	return ms.diff("IntGauge", other, nil)
+}
+
+func (ms IntGauge) diff(path string, other IntGauge, diffs []string) []string {
+	diffs = ms.DataPoints().diff(path+".DataPoints", other.DataPoints(), diffs)
+	return diffs
+}
+
 // DoubleGauge represents the type of a double scalar metric that always exports the "current value" for every data point.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -564,6 +928,22 @@ func (ms DoubleGauge) CopyTo(dest DoubleGauge) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms DoubleGauge) Equal(other DoubleGauge) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms DoubleGauge) Diff(other DoubleGauge) []string {
+	return ms.diff("DoubleGauge", other, nil)
+}
+
+func (ms DoubleGauge) diff(path string, other DoubleGauge, diffs []string) []string {
+	diffs = ms.DataPoints().diff(path+".DataPoints", other.DataPoints(), diffs)
+	return diffs
+}
+
 // IntSum represents the type of a numeric int scalar metric that is calculated as a sum of all reported measurements over a time interval.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -618,6 +998,28 @@ func (ms IntSum) CopyTo(dest IntSum) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms IntSum) Equal(other IntSum) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms IntSum) Diff(other IntSum) []string {
+	return ms.diff("IntSum", other, nil)
+}
+
+func (ms IntSum) diff(path string, other IntSum, diffs []string) []string {
+	if ms.AggregationTemporality() != other.AggregationTemporality() {
+		diffs = append(diffs, fmt.Sprintf("%s.AggregationTemporality: %v != %v", path, ms.AggregationTemporality(), other.AggregationTemporality()))
+	}
+	if ms.IsMonotonic() != other.IsMonotonic() {
+		diffs = append(diffs, fmt.Sprintf("%s.IsMonotonic: %v != %v", path, ms.IsMonotonic(), other.IsMonotonic()))
+	}
+	diffs = ms.DataPoints().diff(path+".DataPoints", other.DataPoints(), diffs)
+	return diffs
+}
+
 // DoubleSum represents the type of a numeric double scalar metric that is calculated as a sum of all reported measurements over a time interval.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -672,6 +1074,28 @@ func (ms DoubleSum) CopyTo(dest DoubleSum) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms DoubleSum) Equal(other DoubleSum) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms DoubleSum) Diff(other DoubleSum) []string {
+	return ms.diff("DoubleSum", other, nil)
+}
+
+func (ms DoubleSum) diff(path string, other DoubleSum, diffs []string) []string {
+	if ms.AggregationTemporality() != other.AggregationTemporality() {
+		diffs = append(diffs, fmt.Sprintf("%s.AggregationTemporality: %v != %v", path, ms.AggregationTemporality(), other.AggregationTemporality()))
+	}
+	if ms.IsMonotonic() != other.IsMonotonic() {
+		diffs = append(diffs, fmt.Sprintf("%s.IsMonotonic: %v != %v", path, ms.IsMonotonic(), other.IsMonotonic()))
+	}
+	diffs = ms.DataPoints().diff(path+".DataPoints", other.DataPoints(), diffs)
+	return diffs
+}
+
 // IntHistogram represents the type of a metric that is calculated by aggregating as a Histogram of all reported double measurements over a time interval.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -715,6 +1139,25 @@ func (ms IntHistogram) CopyTo(dest IntHistogram) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms IntHistogram) Equal(other IntHistogram) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms IntHistogram) Diff(other IntHistogram) []string {
+	return ms.diff("IntHistogram", other, nil)
+}
+
+func (ms IntHistogram) diff(path string, other IntHistogram, diffs []string) []string {
+	if ms.AggregationTemporality() != other.AggregationTemporality() {
+		diffs = append(diffs, fmt.Sprintf("%s.AggregationTemporality: %v != %v", path, ms.AggregationTemporality(), other.AggregationTemporality()))
+	}
+	diffs = ms.DataPoints().diff(path+".DataPoints", other.DataPoints(), diffs)
+	return diffs
+}
+
 // DoubleHistogram represents the type of a metric that is calculated by aggregating as a Histogram of all reported double measurements over a time interval.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -758,6 +1201,25 @@ func (ms DoubleHistogram) CopyTo(dest DoubleHistogram) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms DoubleHistogram) Equal(other DoubleHistogram) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms DoubleHistogram) Diff(other DoubleHistogram) []string {
+	return ms.diff("DoubleHistogram", other, nil)
+}
+
+func (ms DoubleHistogram) diff(path string, other DoubleHistogram, diffs []string) []string {
+	if ms.AggregationTemporality() != other.AggregationTemporality() {
+		diffs = append(diffs, fmt.Sprintf("%s.AggregationTemporality: %v != %v", path, ms.AggregationTemporality(), other.AggregationTemporality()))
+	}
+	diffs = ms.DataPoints().diff(path+".DataPoints", other.DataPoints(), diffs)
+	return diffs
+}
+
 // Summary represents the type of a metric that is calculated by aggregating as a Summary of all reported double measurements over a time interval.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -790,6 +1252,22 @@ func (ms Summary) CopyTo(dest Summary) {
 	ms.DataPoints().CopyTo(dest.DataPoints())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms Summary) Equal(other Summary) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms Summary) Diff(other Summary) []string {
+	return ms.diff("Summary", other, nil)
+}
+
+func (ms Summary) diff(path string, other Summary, diffs []string) []string {
+	diffs = ms.DataPoints().diff(path+".DataPoints", other.DataPoints(), diffs)
+	return diffs
+}
+
 // IntDataPointSlice logically represents a slice of IntDataPoint.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -896,6 +1374,28 @@ func (es IntDataPointSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new IntDataPointSlice can be initialized:
+// es := NewIntDataPointSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es IntDataPointSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.IntDataPoint, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the IntDataPointSlice by one and set the
 // given IntDataPoint at that new position.  The original IntDataPoint
 // could still be referenced so do not reuse it after passing it to this
@@ -904,6 +1404,73 @@ func (es IntDataPointSlice) Append(e IntDataPoint) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty IntDataPoint.
+// It returns the newly added IntDataPoint.
+func (es IntDataPointSlice) AppendEmpty() IntDataPoint {
+	*es.orig = append(*es.orig, &otlpmetrics.IntDataPoint{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es IntDataPointSlice) RemoveIf(f func(IntDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es IntDataPointSlice) Equal(other IntDataPointSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es IntDataPointSlice) Diff(other IntDataPointSlice) []string {
+	return es.diff("IntDataPointSlice", other, nil)
+}
+
+func (es IntDataPointSlice) diff(path string, other IntDataPointSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the IntDataPoint elements within IntDataPointSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b IntDataPoint) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es IntDataPointSlice) Sort(less func(a, b IntDataPoint) bool) IntDataPointSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // IntDataPoint is a single data point in a timeseries that describes the time-varying values of a scalar int metric.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -975,6 +1542,32 @@ func (ms IntDataPoint) CopyTo(dest IntDataPoint) {
 	ms.Exemplars().CopyTo(dest.Exemplars())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms IntDataPoint) Equal(other IntDataPoint) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms IntDataPoint) Diff(other IntDataPoint) []string {
+	return ms.diff("IntDataPoint", other, nil)
+}
+
+func (ms IntDataPoint) diff(path string, other IntDataPoint, diffs []string) []string {
+	diffs = ms.LabelsMap().diff(path+".LabelsMap", other.LabelsMap(), diffs)
+	if ms.StartTime() != other.StartTime() {
+		diffs = append(diffs, fmt.Sprintf("%s.StartTime: %v != %v", path, ms.StartTime(), other.StartTime()))
+	}
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.Value() != other.Value() {
+		diffs = append(diffs, fmt.Sprintf("%s.Value: %v != %v", path, ms.Value(), other.Value()))
+	}
+	diffs = ms.Exemplars().diff(path+".Exemplars", other.Exemplars(), diffs)
+	return diffs
+}
+
 // DoubleDataPointSlice logically represents a slice of DoubleDataPoint.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1081,6 +1674,28 @@ func (es DoubleDataPointSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new DoubleDataPointSlice can be initialized:
+// es := NewDoubleDataPointSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es DoubleDataPointSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.DoubleDataPoint, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the DoubleDataPointSlice by one and set the
 // given DoubleDataPoint at that new position.  The original DoubleDataPoint
 // could still be referenced so do not reuse it after passing it to this
@@ -1089,6 +1704,73 @@ func (es DoubleDataPointSlice) Append(e DoubleDataPoint) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty DoubleDataPoint.
+// It returns the newly added DoubleDataPoint.
+func (es DoubleDataPointSlice) AppendEmpty() DoubleDataPoint {
+	*es.orig = append(*es.orig, &otlpmetrics.DoubleDataPoint{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es DoubleDataPointSlice) RemoveIf(f func(DoubleDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es DoubleDataPointSlice) Equal(other DoubleDataPointSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es DoubleDataPointSlice) Diff(other DoubleDataPointSlice) []string {
+	return es.diff("DoubleDataPointSlice", other, nil)
+}
+
+func (es DoubleDataPointSlice) diff(path string, other DoubleDataPointSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the DoubleDataPoint elements within DoubleDataPointSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b DoubleDataPoint) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es DoubleDataPointSlice) Sort(less func(a, b DoubleDataPoint) bool) DoubleDataPointSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // DoubleDataPoint is a single data point in a timeseries that describes the time-varying value of a double metric.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1160,6 +1842,32 @@ func (ms DoubleDataPoint) CopyTo(dest DoubleDataPoint) {
 	ms.Exemplars().CopyTo(dest.Exemplars())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms DoubleDataPoint) Equal(other DoubleDataPoint) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms DoubleDataPoint) Diff(other DoubleDataPoint) []string {
+	return ms.diff("DoubleDataPoint", other, nil)
+}
+
+func (ms DoubleDataPoint) diff(path string, other DoubleDataPoint, diffs []string) []string {
+	diffs = ms.LabelsMap().diff(path+".LabelsMap", other.LabelsMap(), diffs)
+	if ms.StartTime() != other.StartTime() {
+		diffs = append(diffs, fmt.Sprintf("%s.StartTime: %v != %v", path, ms.StartTime(), other.StartTime()))
+	}
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.Value() != other.Value() {
+		diffs = append(diffs, fmt.Sprintf("%s.Value: %v != %v", path, ms.Value(), other.Value()))
+	}
+	diffs = ms.Exemplars().diff(path+".Exemplars", other.Exemplars(), diffs)
+	return diffs
+}
+
 // IntHistogramDataPointSlice logically represents a slice of IntHistogramDataPoint.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1266,13 +1974,102 @@ func (es IntHistogramDataPointSlice) Resize(newLen int) {
 	}
 }
 
-// Append will increase the length of the IntHistogramDataPointSlice by one and set the
-// given IntHistogramDataPoint at that new position.  The original IntHistogramDataPoint
-// could still be referenced so do not reuse it after passing it to this
-// method.
-func (es IntHistogramDataPointSlice) Append(e IntHistogramDataPoint) {
-	*es.orig = append(*es.orig, e.orig)
-}
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new IntHistogramDataPointSlice can be initialized:
+// es := NewIntHistogramDataPointSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es IntHistogramDataPointSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.IntHistogramDataPoint, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
+// Append will increase the length of the IntHistogramDataPointSlice by one and set the
+// given IntHistogramDataPoint at that new position.  The original IntHistogramDataPoint
+// could still be referenced so do not reuse it after passing it to this
+// method.
+func (es IntHistogramDataPointSlice) Append(e IntHistogramDataPoint) {
+	*es.orig = append(*es.orig, e.orig)
+}
+
+// AppendEmpty will append to the end of the slice an empty IntHistogramDataPoint.
+// It returns the newly added IntHistogramDataPoint.
+func (es IntHistogramDataPointSlice) AppendEmpty() IntHistogramDataPoint {
+	*es.orig = append(*es.orig, &otlpmetrics.IntHistogramDataPoint{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es IntHistogramDataPointSlice) RemoveIf(f func(IntHistogramDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es IntHistogramDataPointSlice) Equal(other IntHistogramDataPointSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es IntHistogramDataPointSlice) Diff(other IntHistogramDataPointSlice) []string {
+	return es.diff("IntHistogramDataPointSlice", other, nil)
+}
+
+func (es IntHistogramDataPointSlice) diff(path string, other IntHistogramDataPointSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the IntHistogramDataPoint elements within IntHistogramDataPointSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b IntHistogramDataPoint) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es IntHistogramDataPointSlice) Sort(less func(a, b IntHistogramDataPoint) bool) IntHistogramDataPointSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
 
 // IntHistogramDataPoint is a single data point in a timeseries that describes the time-varying values of a Histogram of int values.
 //
@@ -1378,6 +2175,41 @@ func (ms IntHistogramDataPoint) CopyTo(dest IntHistogramDataPoint) {
 	ms.Exemplars().CopyTo(dest.Exemplars())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms IntHistogramDataPoint) Equal(other IntHistogramDataPoint) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms IntHistogramDataPoint) Diff(other IntHistogramDataPoint) []string {
+	return ms.diff("IntHistogramDataPoint", other, nil)
+}
+
+func (ms IntHistogramDataPoint) diff(path string, other IntHistogramDataPoint, diffs []string) []string {
+	diffs = ms.LabelsMap().diff(path+".LabelsMap", other.LabelsMap(), diffs)
+	if ms.StartTime() != other.StartTime() {
+		diffs = append(diffs, fmt.Sprintf("%s.StartTime: %v != %v", path, ms.StartTime(), other.StartTime()))
+	}
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.Count() != other.Count() {
+		diffs = append(diffs, fmt.Sprintf("%s.Count: %v != %v", path, ms.Count(), other.Count()))
+	}
+	if ms.Sum() != other.Sum() {
+		diffs = append(diffs, fmt.Sprintf("%s.Sum: %v != %v", path, ms.Sum(), other.Sum()))
+	}
+	if !uint64SlicesEqual(ms.BucketCounts(), other.BucketCounts()) {
+		diffs = append(diffs, fmt.Sprintf("%s.BucketCounts: %v != %v", path, ms.BucketCounts(), other.BucketCounts()))
+	}
+	if !float64SlicesEqual(ms.ExplicitBounds(), other.ExplicitBounds()) {
+		diffs = append(diffs, fmt.Sprintf("%s.ExplicitBounds: %v != %v", path, ms.ExplicitBounds(), other.ExplicitBounds()))
+	}
+	diffs = ms.Exemplars().diff(path+".Exemplars", other.Exemplars(), diffs)
+	return diffs
+}
+
 // DoubleHistogramDataPointSlice logically represents a slice of DoubleHistogramDataPoint.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1484,6 +2316,28 @@ func (es DoubleHistogramDataPointSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new DoubleHistogramDataPointSlice can be initialized:
+// es := NewDoubleHistogramDataPointSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es DoubleHistogramDataPointSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.DoubleHistogramDataPoint, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the DoubleHistogramDataPointSlice by one and set the
 // given DoubleHistogramDataPoint at that new position.  The original DoubleHistogramDataPoint
 // could still be referenced so do not reuse it after passing it to this
@@ -1492,6 +2346,73 @@ func (es DoubleHistogramDataPointSlice) Append(e DoubleHistogramDataPoint) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty DoubleHistogramDataPoint.
+// It returns the newly added DoubleHistogramDataPoint.
+func (es DoubleHistogramDataPointSlice) AppendEmpty() DoubleHistogramDataPoint {
+	*es.orig = append(*es.orig, &otlpmetrics.DoubleHistogramDataPoint{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es DoubleHistogramDataPointSlice) RemoveIf(f func(DoubleHistogramDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es DoubleHistogramDataPointSlice) Equal(other DoubleHistogramDataPointSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es DoubleHistogramDataPointSlice) Diff(other DoubleHistogramDataPointSlice) []string {
+	return es.diff("DoubleHistogramDataPointSlice", other, nil)
+}
+
+func (es DoubleHistogramDataPointSlice) diff(path string, other DoubleHistogramDataPointSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the DoubleHistogramDataPoint elements within DoubleHistogramDataPointSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b DoubleHistogramDataPoint) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es DoubleHistogramDataPointSlice) Sort(less func(a, b DoubleHistogramDataPoint) bool) DoubleHistogramDataPointSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // DoubleHistogramDataPoint is a single data point in a timeseries that describes the time-varying values of a Histogram of double values.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1596,6 +2517,41 @@ func (ms DoubleHistogramDataPoint) CopyTo(dest DoubleHistogramDataPoint) {
 	ms.Exemplars().CopyTo(dest.Exemplars())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms DoubleHistogramDataPoint) Equal(other DoubleHistogramDataPoint) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms DoubleHistogramDataPoint) Diff(other DoubleHistogramDataPoint) []string {
+	return ms.diff("DoubleHistogramDataPoint", other, nil)
+}
+
+func (ms DoubleHistogramDataPoint) diff(path string, other DoubleHistogramDataPoint, diffs []string) []string {
+	diffs = ms.LabelsMap().diff(path+".LabelsMap", other.LabelsMap(), diffs)
+	if ms.StartTime() != other.StartTime() {
+		diffs = append(diffs, fmt.Sprintf("%s.StartTime: %v != %v", path, ms.StartTime(), other.StartTime()))
+	}
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.Count() != other.Count() {
+		diffs = append(diffs, fmt.Sprintf("%s.Count: %v != %v", path, ms.Count(), other.Count()))
+	}
+	if ms.Sum() != other.Sum() {
+		diffs = append(diffs, fmt.Sprintf("%s.Sum: %v != %v", path, ms.Sum(), other.Sum()))
+	}
+	if !uint64SlicesEqual(ms.BucketCounts(), other.BucketCounts()) {
+		diffs = append(diffs, fmt.Sprintf("%s.BucketCounts: %v != %v", path, ms.BucketCounts(), other.BucketCounts()))
+	}
+	if !float64SlicesEqual(ms.ExplicitBounds(), other.ExplicitBounds()) {
+		diffs = append(diffs, fmt.Sprintf("%s.ExplicitBounds: %v != %v", path, ms.ExplicitBounds(), other.ExplicitBounds()))
+	}
+	diffs = ms.Exemplars().diff(path+".Exemplars", other.Exemplars(), diffs)
+	return diffs
+}
+
 // SummaryDataPointSlice logically represents a slice of SummaryDataPoint.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1702,6 +2658,28 @@ func (es SummaryDataPointSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new SummaryDataPointSlice can be initialized:
+// es := NewSummaryDataPointSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es SummaryDataPointSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.DoubleSummaryDataPoint, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the SummaryDataPointSlice by one and set the
 // given SummaryDataPoint at that new position.  The original SummaryDataPoint
 // could still be referenced so do not reuse it after passing it to this
@@ -1710,6 +2688,73 @@ func (es SummaryDataPointSlice) Append(e SummaryDataPoint) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty SummaryDataPoint.
+// It returns the newly added SummaryDataPoint.
+func (es SummaryDataPointSlice) AppendEmpty() SummaryDataPoint {
+	*es.orig = append(*es.orig, &otlpmetrics.DoubleSummaryDataPoint{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es SummaryDataPointSlice) RemoveIf(f func(SummaryDataPoint) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es SummaryDataPointSlice) Equal(other SummaryDataPointSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es SummaryDataPointSlice) Diff(other SummaryDataPointSlice) []string {
+	return es.diff("SummaryDataPointSlice", other, nil)
+}
+
+func (es SummaryDataPointSlice) diff(path string, other SummaryDataPointSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the SummaryDataPoint elements within SummaryDataPointSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b SummaryDataPoint) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es SummaryDataPointSlice) Sort(less func(a, b SummaryDataPoint) bool) SummaryDataPointSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // SummaryDataPoint is a single data point in a timeseries that describes the time-varying values of a Summary of double values.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1792,6 +2837,35 @@ func (ms SummaryDataPoint) CopyTo(dest SummaryDataPoint) {
 	ms.QuantileValues().CopyTo(dest.QuantileValues())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms SummaryDataPoint) Equal(other SummaryDataPoint) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms SummaryDataPoint) Diff(other SummaryDataPoint) []string {
+	return ms.diff("SummaryDataPoint", other, nil)
+}
+
+func (ms SummaryDataPoint) diff(path string, other SummaryDataPoint, diffs []string) []string {
+	diffs = ms.LabelsMap().diff(path+".LabelsMap", other.LabelsMap(), diffs)
+	if ms.StartTime() != other.StartTime() {
+		diffs = append(diffs, fmt.Sprintf("%s.StartTime: %v != %v", path, ms.StartTime(), other.StartTime()))
+	}
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.Count() != other.Count() {
+		diffs = append(diffs, fmt.Sprintf("%s.Count: %v != %v", path, ms.Count(), other.Count()))
+	}
+	if ms.Sum() != other.Sum() {
+		diffs = append(diffs, fmt.Sprintf("%s.Sum: %v != %v", path, ms.Sum(), other.Sum()))
+	}
+	diffs = ms.QuantileValues().diff(path+".QuantileValues", other.QuantileValues(), diffs)
+	return diffs
+}
+
 // ValueAtQuantileSlice logically represents a slice of ValueAtQuantile.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1898,6 +2972,28 @@ func (es ValueAtQuantileSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new ValueAtQuantileSlice can be initialized:
+// es := NewValueAtQuantileSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es ValueAtQuantileSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlpmetrics.DoubleSummaryDataPoint_ValueAtQuantile, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the ValueAtQuantileSlice by one and set the
 // given ValueAtQuantile at that new position.  The original ValueAtQuantile
 // could still be referenced so do not reuse it after passing it to this
@@ -1906,6 +3002,73 @@ func (es ValueAtQuantileSlice) Append(e ValueAtQuantile) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty ValueAtQuantile.
+// It returns the newly added ValueAtQuantile.
+func (es ValueAtQuantileSlice) AppendEmpty() ValueAtQuantile {
+	*es.orig = append(*es.orig, &otlpmetrics.DoubleSummaryDataPoint_ValueAtQuantile{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es ValueAtQuantileSlice) RemoveIf(f func(ValueAtQuantile) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es ValueAtQuantileSlice) Equal(other ValueAtQuantileSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es ValueAtQuantileSlice) Diff(other ValueAtQuantileSlice) []string {
+	return es.diff("ValueAtQuantileSlice", other, nil)
+}
+
+func (es ValueAtQuantileSlice) diff(path string, other ValueAtQuantileSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the ValueAtQuantile elements within ValueAtQuantileSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b ValueAtQuantile) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es ValueAtQuantileSlice) Sort(less func(a, b ValueAtQuantile) bool) ValueAtQuantileSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // ValueAtQuantile is a quantile value within a Summary data point
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -1954,6 +3117,27 @@ func (ms ValueAtQuantile) CopyTo(dest ValueAtQuantile) {
 	dest.SetValue(ms.Value())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms ValueAtQuantile) Equal(other ValueAtQuantile) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms ValueAtQuantile) Diff(other ValueAtQuantile) []string {
+	return ms.diff("ValueAtQuantile", other, nil)
+}
+
+func (ms ValueAtQuantile) diff(path string, other ValueAtQuantile, diffs []string) []string {
+	if ms.Quantile() != other.Quantile() {
+		diffs = append(diffs, fmt.Sprintf("%s.Quantile: %v != %v", path, ms.Quantile(), other.Quantile()))
+	}
+	if ms.Value() != other.Value() {
+		diffs = append(diffs, fmt.Sprintf("%s.Value: %v != %v", path, ms.Value(), other.Value()))
+	}
+	return diffs
+}
+
 // IntExemplarSlice logically represents a slice of IntExemplar.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -2055,6 +3239,28 @@ func (es IntExemplarSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new IntExemplarSlice can be initialized:
+// es := NewIntExemplarSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es IntExemplarSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]otlpmetrics.IntExemplar, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the IntExemplarSlice by one and set the
 // given IntExemplar at that new position.  The original IntExemplar
 // could still be referenced so do not reuse it after passing it to this
@@ -2063,6 +3269,73 @@ func (es IntExemplarSlice) Append(e IntExemplar) {
 	*es.orig = append(*es.orig, *e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty IntExemplar.
+// It returns the newly added IntExemplar.
+func (es IntExemplarSlice) AppendEmpty() IntExemplar {
+	*es.orig = append(*es.orig, otlpmetrics.IntExemplar{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es IntExemplarSlice) RemoveIf(f func(IntExemplar) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = otlpmetrics.IntExemplar{}
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es IntExemplarSlice) Equal(other IntExemplarSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es IntExemplarSlice) Diff(other IntExemplarSlice) []string {
+	return es.diff("IntExemplarSlice", other, nil)
+}
+
+func (es IntExemplarSlice) diff(path string, other IntExemplarSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the IntExemplar elements within IntExemplarSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b IntExemplar) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es IntExemplarSlice) Sort(less func(a, b IntExemplar) bool) IntExemplarSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // IntExemplar is a sample input int measurement.
 //
 // Exemplars also hold information about the environment when the measurement was recorded,
@@ -2113,11 +3386,61 @@ func (ms IntExemplar) FilteredLabels() StringMap {
 	return newStringMap(&(*ms.orig).FilteredLabels)
 }
 
+// TraceID returns the traceid associated with this IntExemplar.
+func (ms IntExemplar) TraceID() TraceID {
+	return TraceID((*ms.orig).TraceId)
+}
+
+// SetTraceID replaces the traceid associated with this IntExemplar.
+func (ms IntExemplar) SetTraceID(v TraceID) {
+	(*ms.orig).TraceId = data.TraceID(v)
+}
+
+// SpanID returns the spanid associated with this IntExemplar.
+func (ms IntExemplar) SpanID() SpanID {
+	return SpanID((*ms.orig).SpanId)
+}
+
+// SetSpanID replaces the spanid associated with this IntExemplar.
+func (ms IntExemplar) SetSpanID(v SpanID) {
+	(*ms.orig).SpanId = data.SpanID(v)
+}
+
 // CopyTo copies all properties from the current struct to the dest.
 func (ms IntExemplar) CopyTo(dest IntExemplar) {
 	dest.SetTimestamp(ms.Timestamp())
 	dest.SetValue(ms.Value())
 	ms.FilteredLabels().CopyTo(dest.FilteredLabels())
+	dest.SetTraceID(ms.TraceID())
+	dest.SetSpanID(ms.SpanID())
+}
+
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms IntExemplar) Equal(other IntExemplar) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms IntExemplar) Diff(other IntExemplar) []string {
+	return ms.diff("IntExemplar", other, nil)
+}
+
+func (ms IntExemplar) diff(path string, other IntExemplar, diffs []string) []string {
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.Value() != other.Value() {
+		diffs = append(diffs, fmt.Sprintf("%s.Value: %v != %v", path, ms.Value(), other.Value()))
+	}
+	diffs = ms.FilteredLabels().diff(path+".FilteredLabels", other.FilteredLabels(), diffs)
+	if ms.TraceID() != other.TraceID() {
+		diffs = append(diffs, fmt.Sprintf("%s.TraceID: %v != %v", path, ms.TraceID().HexString(), other.TraceID().HexString()))
+	}
+	if ms.SpanID() != other.SpanID() {
+		diffs = append(diffs, fmt.Sprintf("%s.SpanID: %v != %v", path, ms.SpanID().HexString(), other.SpanID().HexString()))
+	}
+	return diffs
 }
 
 // DoubleExemplarSlice logically represents a slice of DoubleExemplar.
@@ -2221,6 +3544,28 @@ func (es DoubleExemplarSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new DoubleExemplarSlice can be initialized:
+// es := NewDoubleExemplarSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es DoubleExemplarSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]otlpmetrics.DoubleExemplar, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the DoubleExemplarSlice by one and set the
 // given DoubleExemplar at that new position.  The original DoubleExemplar
 // could still be referenced so do not reuse it after passing it to this
@@ -2229,6 +3574,73 @@ func (es DoubleExemplarSlice) Append(e DoubleExemplar) {
 	*es.orig = append(*es.orig, *e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty DoubleExemplar.
+// It returns the newly added DoubleExemplar.
+func (es DoubleExemplarSlice) AppendEmpty() DoubleExemplar {
+	*es.orig = append(*es.orig, otlpmetrics.DoubleExemplar{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es DoubleExemplarSlice) RemoveIf(f func(DoubleExemplar) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = otlpmetrics.DoubleExemplar{}
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es DoubleExemplarSlice) Equal(other DoubleExemplarSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es DoubleExemplarSlice) Diff(other DoubleExemplarSlice) []string {
+	return es.diff("DoubleExemplarSlice", other, nil)
+}
+
+func (es DoubleExemplarSlice) diff(path string, other DoubleExemplarSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the DoubleExemplar elements within DoubleExemplarSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b DoubleExemplar) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es DoubleExemplarSlice) Sort(less func(a, b DoubleExemplar) bool) DoubleExemplarSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // DoubleExemplar is a sample input double measurement.
 //
 // Exemplars also hold information about the environment when the measurement was recorded,
@@ -2279,9 +3691,59 @@ func (ms DoubleExemplar) FilteredLabels() StringMap {
 	return newStringMap(&(*ms.orig).FilteredLabels)
 }
 
+// TraceID returns the traceid associated with this DoubleExemplar.
+func (ms DoubleExemplar) TraceID() TraceID {
+	return TraceID((*ms.orig).TraceId)
+}
+
+// SetTraceID replaces the traceid associated with this DoubleExemplar.
+func (ms DoubleExemplar) SetTraceID(v TraceID) {
+	(*ms.orig).TraceId = data.TraceID(v)
+}
+
+// SpanID returns the spanid associated with this DoubleExemplar.
+func (ms DoubleExemplar) SpanID() SpanID {
+	return SpanID((*ms.orig).SpanId)
+}
+
+// SetSpanID replaces the spanid associated with this DoubleExemplar.
+func (ms DoubleExemplar) SetSpanID(v SpanID) {
+	(*ms.orig).SpanId = data.SpanID(v)
+}
+
 // CopyTo copies all properties from the current struct to the dest.
 func (ms DoubleExemplar) CopyTo(dest DoubleExemplar) {
 	dest.SetTimestamp(ms.Timestamp())
 	dest.SetValue(ms.Value())
 	ms.FilteredLabels().CopyTo(dest.FilteredLabels())
+	dest.SetTraceID(ms.TraceID())
+	dest.SetSpanID(ms.SpanID())
+}
+
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms DoubleExemplar) Equal(other DoubleExemplar) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms DoubleExemplar) Diff(other DoubleExemplar) []string {
+	return ms.diff("DoubleExemplar", other, nil)
+}
+
+func (ms DoubleExemplar) diff(path string, other DoubleExemplar, diffs []string) []string {
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.Value() != other.Value() {
+		diffs = append(diffs, fmt.Sprintf("%s.Value: %v != %v", path, ms.Value(), other.Value()))
+	}
+	diffs = ms.FilteredLabels().diff(path+".FilteredLabels", other.FilteredLabels(), diffs)
+	if ms.TraceID() != other.TraceID() {
+		diffs = append(diffs, fmt.Sprintf("%s.TraceID: %v != %v", path, ms.TraceID().HexString(), other.TraceID().HexString()))
+	}
+	if ms.SpanID() != other.SpanID() {
+		diffs = append(diffs, fmt.Sprintf("%s.SpanID: %v != %v", path, ms.SpanID().HexString(), other.SpanID().HexString()))
+	}
+	return diffs
 }
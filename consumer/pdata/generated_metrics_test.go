@@ -18,6 +18,7 @@
 package pdata
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -124,6 +125,23 @@ func TestResourceMetricsSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestResourceMetricsSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestResourceMetricsSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestResourceMetricsSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestResourceMetricsSlice(), es)
+}
+
 func TestResourceMetricsSlice_Append(t *testing.T) {
 	es := generateTestResourceMetricsSlice()
 
@@ -139,15 +157,94 @@ func TestResourceMetricsSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestResourceMetricsSlice_AppendEmpty(t *testing.T) {
+	es := generateTestResourceMetricsSlice()
+	emptyVal := NewResourceMetrics()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestResourceMetrics(e)
+	assert.EqualValues(t, generateTestResourceMetrics(), es.At(7))
+}
+
+func TestResourceMetricsSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewResourceMetricsSlice()
+	emptySlice.RemoveIf(func(el ResourceMetrics) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestResourceMetricsSlice()
+	expectedSlice := NewResourceMetricsSlice()
+	pos := 0
+	filtered.RemoveIf(func(el ResourceMetrics) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestResourceMetricsSlice_Equal(t *testing.T) {
+	es := generateTestResourceMetricsSlice()
+	assert.True(t, es.Equal(generateTestResourceMetricsSlice()))
+	assert.True(t, NewResourceMetricsSlice().Equal(NewResourceMetricsSlice()))
+	assert.Equal(t, []string{"ResourceMetricsSlice: length 7 != 0"}, es.Diff(NewResourceMetricsSlice()))
+
+	// Test Equal with a different element
+	other := generateTestResourceMetricsSlice()
+	other.Resize(6)
+	other.Append(NewResourceMetrics())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "ResourceMetricsSlice[6]."), diff)
+	}
+}
+
+func TestResourceMetricsSlice_Sort(t *testing.T) {
+	es := generateTestResourceMetricsSlice()
+	emptyVal := NewResourceMetrics()
+	es.Append(NewResourceMetrics())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b ResourceMetrics) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestResourceMetrics()))
+	}
+}
+
 func TestResourceMetrics_CopyTo(t *testing.T) {
 	ms := NewResourceMetrics()
 	generateTestResourceMetrics().CopyTo(ms)
 	assert.EqualValues(t, generateTestResourceMetrics(), ms)
 }
 
+func TestResourceMetrics_Equal(t *testing.T) {
+	ms := generateTestResourceMetrics()
+	assert.True(t, ms.Equal(generateTestResourceMetrics()))
+	assert.Empty(t, ms.Diff(generateTestResourceMetrics()))
+	assert.False(t, ms.Equal(NewResourceMetrics()))
+	assert.NotEmpty(t, ms.Diff(NewResourceMetrics()))
+}
+
 func TestResourceMetrics_Resource(t *testing.T) {
 	ms := NewResourceMetrics()
+	assert.False(t, ms.HasResource())
 	fillTestResource(ms.Resource())
+	assert.True(t, ms.HasResource())
 	assert.EqualValues(t, generateTestResource(), ms.Resource())
 }
 
@@ -258,6 +355,23 @@ func TestInstrumentationLibraryMetricsSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestInstrumentationLibraryMetricsSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestInstrumentationLibraryMetricsSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestInstrumentationLibraryMetricsSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestInstrumentationLibraryMetricsSlice(), es)
+}
+
 func TestInstrumentationLibraryMetricsSlice_Append(t *testing.T) {
 	es := generateTestInstrumentationLibraryMetricsSlice()
 
@@ -273,15 +387,94 @@ func TestInstrumentationLibraryMetricsSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestInstrumentationLibraryMetricsSlice_AppendEmpty(t *testing.T) {
+	es := generateTestInstrumentationLibraryMetricsSlice()
+	emptyVal := NewInstrumentationLibraryMetrics()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestInstrumentationLibraryMetrics(e)
+	assert.EqualValues(t, generateTestInstrumentationLibraryMetrics(), es.At(7))
+}
+
+func TestInstrumentationLibraryMetricsSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewInstrumentationLibraryMetricsSlice()
+	emptySlice.RemoveIf(func(el InstrumentationLibraryMetrics) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestInstrumentationLibraryMetricsSlice()
+	expectedSlice := NewInstrumentationLibraryMetricsSlice()
+	pos := 0
+	filtered.RemoveIf(func(el InstrumentationLibraryMetrics) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestInstrumentationLibraryMetricsSlice_Equal(t *testing.T) {
+	es := generateTestInstrumentationLibraryMetricsSlice()
+	assert.True(t, es.Equal(generateTestInstrumentationLibraryMetricsSlice()))
+	assert.True(t, NewInstrumentationLibraryMetricsSlice().Equal(NewInstrumentationLibraryMetricsSlice()))
+	assert.Equal(t, []string{"InstrumentationLibraryMetricsSlice: length 7 != 0"}, es.Diff(NewInstrumentationLibraryMetricsSlice()))
+
+	// Test Equal with a different element
+	other := generateTestInstrumentationLibraryMetricsSlice()
+	other.Resize(6)
+	other.Append(NewInstrumentationLibraryMetrics())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "InstrumentationLibraryMetricsSlice[6]."), diff)
+	}
+}
+
+func TestInstrumentationLibraryMetricsSlice_Sort(t *testing.T) {
+	es := generateTestInstrumentationLibraryMetricsSlice()
+	emptyVal := NewInstrumentationLibraryMetrics()
+	es.Append(NewInstrumentationLibraryMetrics())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b InstrumentationLibraryMetrics) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestInstrumentationLibraryMetrics()))
+	}
+}
+
 func TestInstrumentationLibraryMetrics_CopyTo(t *testing.T) {
 	ms := NewInstrumentationLibraryMetrics()
 	generateTestInstrumentationLibraryMetrics().CopyTo(ms)
 	assert.EqualValues(t, generateTestInstrumentationLibraryMetrics(), ms)
 }
 
+func TestInstrumentationLibraryMetrics_Equal(t *testing.T) {
+	ms := generateTestInstrumentationLibraryMetrics()
+	assert.True(t, ms.Equal(generateTestInstrumentationLibraryMetrics()))
+	assert.Empty(t, ms.Diff(generateTestInstrumentationLibraryMetrics()))
+	assert.False(t, ms.Equal(NewInstrumentationLibraryMetrics()))
+	assert.NotEmpty(t, ms.Diff(NewInstrumentationLibraryMetrics()))
+}
+
 func TestInstrumentationLibraryMetrics_InstrumentationLibrary(t *testing.T) {
 	ms := NewInstrumentationLibraryMetrics()
+	assert.False(t, ms.HasInstrumentationLibrary())
 	fillTestInstrumentationLibrary(ms.InstrumentationLibrary())
+	assert.True(t, ms.HasInstrumentationLibrary())
 	assert.EqualValues(t, generateTestInstrumentationLibrary(), ms.InstrumentationLibrary())
 }
 
@@ -392,6 +585,23 @@ func TestMetricSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestMetricSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestMetricSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestMetricSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestMetricSlice(), es)
+}
+
 func TestMetricSlice_Append(t *testing.T) {
 	es := generateTestMetricSlice()
 
@@ -407,12 +617,89 @@ func TestMetricSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestMetricSlice_AppendEmpty(t *testing.T) {
+	es := generateTestMetricSlice()
+	emptyVal := NewMetric()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestMetric(e)
+	assert.EqualValues(t, generateTestMetric(), es.At(7))
+}
+
+func TestMetricSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewMetricSlice()
+	emptySlice.RemoveIf(func(el Metric) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestMetricSlice()
+	expectedSlice := NewMetricSlice()
+	pos := 0
+	filtered.RemoveIf(func(el Metric) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestMetricSlice_Equal(t *testing.T) {
+	es := generateTestMetricSlice()
+	assert.True(t, es.Equal(generateTestMetricSlice()))
+	assert.True(t, NewMetricSlice().Equal(NewMetricSlice()))
+	assert.Equal(t, []string{"MetricSlice: length 7 != 0"}, es.Diff(NewMetricSlice()))
+
+	// Test Equal with a different element
+	other := generateTestMetricSlice()
+	other.Resize(6)
+	other.Append(NewMetric())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "MetricSlice[6]."), diff)
+	}
+}
+
+func TestMetricSlice_Sort(t *testing.T) {
+	es := generateTestMetricSlice()
+	emptyVal := NewMetric()
+	es.Append(NewMetric())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b Metric) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestMetric()))
+	}
+}
+
 func TestMetric_CopyTo(t *testing.T) {
 	ms := NewMetric()
 	generateTestMetric().CopyTo(ms)
 	assert.EqualValues(t, generateTestMetric(), ms)
 }
 
+func TestMetric_Equal(t *testing.T) {
+	ms := generateTestMetric()
+	assert.True(t, ms.Equal(generateTestMetric()))
+	assert.Empty(t, ms.Diff(generateTestMetric()))
+	assert.False(t, ms.Equal(NewMetric()))
+	assert.NotEmpty(t, ms.Diff(NewMetric()))
+}
+
 func TestMetric_Name(t *testing.T) {
 	ms := NewMetric()
 	assert.EqualValues(t, "", ms.Name())
@@ -437,12 +724,27 @@ func TestMetric_Unit(t *testing.T) {
 	assert.EqualValues(t, testValUnit, ms.Unit())
 }
 
+func TestMetric_HasData(t *testing.T) {
+	ms := NewMetric()
+	assert.False(t, ms.HasData())
+	(*ms.orig).Data = &otlpmetrics.Metric_IntGauge{IntGauge: &otlpmetrics.IntGauge{}}
+	assert.True(t, ms.HasData())
+}
+
 func TestIntGauge_CopyTo(t *testing.T) {
 	ms := NewIntGauge()
 	generateTestIntGauge().CopyTo(ms)
 	assert.EqualValues(t, generateTestIntGauge(), ms)
 }
 
+func TestIntGauge_Equal(t *testing.T) {
+	ms := generateTestIntGauge()
+	assert.True(t, ms.Equal(generateTestIntGauge()))
+	assert.Empty(t, ms.Diff(generateTestIntGauge()))
+	assert.False(t, ms.Equal(NewIntGauge()))
+	assert.NotEmpty(t, ms.Diff(NewIntGauge()))
+}
+
 func TestIntGauge_DataPoints(t *testing.T) {
 	ms := NewIntGauge()
 	assert.EqualValues(t, NewIntDataPointSlice(), ms.DataPoints())
@@ -457,6 +759,14 @@ func TestDoubleGauge_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestDoubleGauge(), ms)
 }
 
+func TestDoubleGauge_Equal(t *testing.T) {
+	ms := generateTestDoubleGauge()
+	assert.True(t, ms.Equal(generateTestDoubleGauge()))
+	assert.Empty(t, ms.Diff(generateTestDoubleGauge()))
+	assert.False(t, ms.Equal(NewDoubleGauge()))
+	assert.NotEmpty(t, ms.Diff(NewDoubleGauge()))
+}
+
 func TestDoubleGauge_DataPoints(t *testing.T) {
 	ms := NewDoubleGauge()
 	assert.EqualValues(t, NewDoubleDataPointSlice(), ms.DataPoints())
@@ -471,6 +781,14 @@ func TestIntSum_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestIntSum(), ms)
 }
 
+func TestIntSum_Equal(t *testing.T) {
+	ms := generateTestIntSum()
+	assert.True(t, ms.Equal(generateTestIntSum()))
+	assert.Empty(t, ms.Diff(generateTestIntSum()))
+	assert.False(t, ms.Equal(NewIntSum()))
+	assert.NotEmpty(t, ms.Diff(NewIntSum()))
+}
+
 func TestIntSum_AggregationTemporality(t *testing.T) {
 	ms := NewIntSum()
 	assert.EqualValues(t, AggregationTemporalityUnspecified, ms.AggregationTemporality())
@@ -501,6 +819,14 @@ func TestDoubleSum_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestDoubleSum(), ms)
 }
 
+func TestDoubleSum_Equal(t *testing.T) {
+	ms := generateTestDoubleSum()
+	assert.True(t, ms.Equal(generateTestDoubleSum()))
+	assert.Empty(t, ms.Diff(generateTestDoubleSum()))
+	assert.False(t, ms.Equal(NewDoubleSum()))
+	assert.NotEmpty(t, ms.Diff(NewDoubleSum()))
+}
+
 func TestDoubleSum_AggregationTemporality(t *testing.T) {
 	ms := NewDoubleSum()
 	assert.EqualValues(t, AggregationTemporalityUnspecified, ms.AggregationTemporality())
@@ -531,6 +857,14 @@ func TestIntHistogram_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestIntHistogram(), ms)
 }
 
+func TestIntHistogram_Equal(t *testing.T) {
+	ms := generateTestIntHistogram()
+	assert.True(t, ms.Equal(generateTestIntHistogram()))
+	assert.Empty(t, ms.Diff(generateTestIntHistogram()))
+	assert.False(t, ms.Equal(NewIntHistogram()))
+	assert.NotEmpty(t, ms.Diff(NewIntHistogram()))
+}
+
 func TestIntHistogram_AggregationTemporality(t *testing.T) {
 	ms := NewIntHistogram()
 	assert.EqualValues(t, AggregationTemporalityUnspecified, ms.AggregationTemporality())
@@ -553,6 +887,14 @@ func TestDoubleHistogram_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestDoubleHistogram(), ms)
 }
 
+func TestDoubleHistogram_Equal(t *testing.T) {
+	ms := generateTestDoubleHistogram()
+	assert.True(t, ms.Equal(generateTestDoubleHistogram()))
+	assert.Empty(t, ms.Diff(generateTestDoubleHistogram()))
+	assert.False(t, ms.Equal(NewDoubleHistogram()))
+	assert.NotEmpty(t, ms.Diff(NewDoubleHistogram()))
+}
+
 func TestDoubleHistogram_AggregationTemporality(t *testing.T) {
 	ms := NewDoubleHistogram()
 	assert.EqualValues(t, AggregationTemporalityUnspecified, ms.AggregationTemporality())
@@ -575,6 +917,14 @@ func TestSummary_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestSummary(), ms)
 }
 
+func TestSummary_Equal(t *testing.T) {
+	ms := generateTestSummary()
+	assert.True(t, ms.Equal(generateTestSummary()))
+	assert.Empty(t, ms.Diff(generateTestSummary()))
+	assert.False(t, ms.Equal(NewSummary()))
+	assert.NotEmpty(t, ms.Diff(NewSummary()))
+}
+
 func TestSummary_DataPoints(t *testing.T) {
 	ms := NewSummary()
 	assert.EqualValues(t, NewSummaryDataPointSlice(), ms.DataPoints())
@@ -682,6 +1032,23 @@ func TestIntDataPointSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestIntDataPointSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestIntDataPointSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestIntDataPointSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestIntDataPointSlice(), es)
+}
+
 func TestIntDataPointSlice_Append(t *testing.T) {
 	es := generateTestIntDataPointSlice()
 
@@ -697,12 +1064,89 @@ func TestIntDataPointSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestIntDataPointSlice_AppendEmpty(t *testing.T) {
+	es := generateTestIntDataPointSlice()
+	emptyVal := NewIntDataPoint()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestIntDataPoint(e)
+	assert.EqualValues(t, generateTestIntDataPoint(), es.At(7))
+}
+
+func TestIntDataPointSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewIntDataPointSlice()
+	emptySlice.RemoveIf(func(el IntDataPoint) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestIntDataPointSlice()
+	expectedSlice := NewIntDataPointSlice()
+	pos := 0
+	filtered.RemoveIf(func(el IntDataPoint) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestIntDataPointSlice_Equal(t *testing.T) {
+	es := generateTestIntDataPointSlice()
+	assert.True(t, es.Equal(generateTestIntDataPointSlice()))
+	assert.True(t, NewIntDataPointSlice().Equal(NewIntDataPointSlice()))
+	assert.Equal(t, []string{"IntDataPointSlice: length 7 != 0"}, es.Diff(NewIntDataPointSlice()))
+
+	// Test Equal with a different element
+	other := generateTestIntDataPointSlice()
+	other.Resize(6)
+	other.Append(NewIntDataPoint())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "IntDataPointSlice[6]."), diff)
+	}
+}
+
+func TestIntDataPointSlice_Sort(t *testing.T) {
+	es := generateTestIntDataPointSlice()
+	emptyVal := NewIntDataPoint()
+	es.Append(NewIntDataPoint())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b IntDataPoint) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestIntDataPoint()))
+	}
+}
+
 func TestIntDataPoint_CopyTo(t *testing.T) {
 	ms := NewIntDataPoint()
 	generateTestIntDataPoint().CopyTo(ms)
 	assert.EqualValues(t, generateTestIntDataPoint(), ms)
 }
 
+func TestIntDataPoint_Equal(t *testing.T) {
+	ms := generateTestIntDataPoint()
+	assert.True(t, ms.Equal(generateTestIntDataPoint()))
+	assert.Empty(t, ms.Diff(generateTestIntDataPoint()))
+	assert.False(t, ms.Equal(NewIntDataPoint()))
+	assert.NotEmpty(t, ms.Diff(NewIntDataPoint()))
+}
+
 func TestIntDataPoint_LabelsMap(t *testing.T) {
 	ms := NewIntDataPoint()
 	assert.EqualValues(t, NewStringMap(), ms.LabelsMap())
@@ -842,6 +1286,23 @@ func TestDoubleDataPointSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestDoubleDataPointSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestDoubleDataPointSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestDoubleDataPointSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestDoubleDataPointSlice(), es)
+}
+
 func TestDoubleDataPointSlice_Append(t *testing.T) {
 	es := generateTestDoubleDataPointSlice()
 
@@ -857,12 +1318,89 @@ func TestDoubleDataPointSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestDoubleDataPointSlice_AppendEmpty(t *testing.T) {
+	es := generateTestDoubleDataPointSlice()
+	emptyVal := NewDoubleDataPoint()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestDoubleDataPoint(e)
+	assert.EqualValues(t, generateTestDoubleDataPoint(), es.At(7))
+}
+
+func TestDoubleDataPointSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewDoubleDataPointSlice()
+	emptySlice.RemoveIf(func(el DoubleDataPoint) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestDoubleDataPointSlice()
+	expectedSlice := NewDoubleDataPointSlice()
+	pos := 0
+	filtered.RemoveIf(func(el DoubleDataPoint) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestDoubleDataPointSlice_Equal(t *testing.T) {
+	es := generateTestDoubleDataPointSlice()
+	assert.True(t, es.Equal(generateTestDoubleDataPointSlice()))
+	assert.True(t, NewDoubleDataPointSlice().Equal(NewDoubleDataPointSlice()))
+	assert.Equal(t, []string{"DoubleDataPointSlice: length 7 != 0"}, es.Diff(NewDoubleDataPointSlice()))
+
+	// Test Equal with a different element
+	other := generateTestDoubleDataPointSlice()
+	other.Resize(6)
+	other.Append(NewDoubleDataPoint())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "DoubleDataPointSlice[6]."), diff)
+	}
+}
+
+func TestDoubleDataPointSlice_Sort(t *testing.T) {
+	es := generateTestDoubleDataPointSlice()
+	emptyVal := NewDoubleDataPoint()
+	es.Append(NewDoubleDataPoint())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b DoubleDataPoint) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestDoubleDataPoint()))
+	}
+}
+
 func TestDoubleDataPoint_CopyTo(t *testing.T) {
 	ms := NewDoubleDataPoint()
 	generateTestDoubleDataPoint().CopyTo(ms)
 	assert.EqualValues(t, generateTestDoubleDataPoint(), ms)
 }
 
+func TestDoubleDataPoint_Equal(t *testing.T) {
+	ms := generateTestDoubleDataPoint()
+	assert.True(t, ms.Equal(generateTestDoubleDataPoint()))
+	assert.Empty(t, ms.Diff(generateTestDoubleDataPoint()))
+	assert.False(t, ms.Equal(NewDoubleDataPoint()))
+	assert.NotEmpty(t, ms.Diff(NewDoubleDataPoint()))
+}
+
 func TestDoubleDataPoint_LabelsMap(t *testing.T) {
 	ms := NewDoubleDataPoint()
 	assert.EqualValues(t, NewStringMap(), ms.LabelsMap())
@@ -1002,6 +1540,23 @@ func TestIntHistogramDataPointSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestIntHistogramDataPointSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestIntHistogramDataPointSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestIntHistogramDataPointSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestIntHistogramDataPointSlice(), es)
+}
+
 func TestIntHistogramDataPointSlice_Append(t *testing.T) {
 	es := generateTestIntHistogramDataPointSlice()
 
@@ -1017,12 +1572,89 @@ func TestIntHistogramDataPointSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestIntHistogramDataPointSlice_AppendEmpty(t *testing.T) {
+	es := generateTestIntHistogramDataPointSlice()
+	emptyVal := NewIntHistogramDataPoint()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestIntHistogramDataPoint(e)
+	assert.EqualValues(t, generateTestIntHistogramDataPoint(), es.At(7))
+}
+
+func TestIntHistogramDataPointSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewIntHistogramDataPointSlice()
+	emptySlice.RemoveIf(func(el IntHistogramDataPoint) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestIntHistogramDataPointSlice()
+	expectedSlice := NewIntHistogramDataPointSlice()
+	pos := 0
+	filtered.RemoveIf(func(el IntHistogramDataPoint) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestIntHistogramDataPointSlice_Equal(t *testing.T) {
+	es := generateTestIntHistogramDataPointSlice()
+	assert.True(t, es.Equal(generateTestIntHistogramDataPointSlice()))
+	assert.True(t, NewIntHistogramDataPointSlice().Equal(NewIntHistogramDataPointSlice()))
+	assert.Equal(t, []string{"IntHistogramDataPointSlice: length 7 != 0"}, es.Diff(NewIntHistogramDataPointSlice()))
+
+	// Test Equal with a different element
+	other := generateTestIntHistogramDataPointSlice()
+	other.Resize(6)
+	other.Append(NewIntHistogramDataPoint())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "IntHistogramDataPointSlice[6]."), diff)
+	}
+}
+
+func TestIntHistogramDataPointSlice_Sort(t *testing.T) {
+	es := generateTestIntHistogramDataPointSlice()
+	emptyVal := NewIntHistogramDataPoint()
+	es.Append(NewIntHistogramDataPoint())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b IntHistogramDataPoint) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestIntHistogramDataPoint()))
+	}
+}
+
 func TestIntHistogramDataPoint_CopyTo(t *testing.T) {
 	ms := NewIntHistogramDataPoint()
 	generateTestIntHistogramDataPoint().CopyTo(ms)
 	assert.EqualValues(t, generateTestIntHistogramDataPoint(), ms)
 }
 
+func TestIntHistogramDataPoint_Equal(t *testing.T) {
+	ms := generateTestIntHistogramDataPoint()
+	assert.True(t, ms.Equal(generateTestIntHistogramDataPoint()))
+	assert.Empty(t, ms.Diff(generateTestIntHistogramDataPoint()))
+	assert.False(t, ms.Equal(NewIntHistogramDataPoint()))
+	assert.NotEmpty(t, ms.Diff(NewIntHistogramDataPoint()))
+}
+
 func TestIntHistogramDataPoint_LabelsMap(t *testing.T) {
 	ms := NewIntHistogramDataPoint()
 	assert.EqualValues(t, NewStringMap(), ms.LabelsMap())
@@ -1186,6 +1818,23 @@ func TestDoubleHistogramDataPointSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestDoubleHistogramDataPointSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestDoubleHistogramDataPointSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestDoubleHistogramDataPointSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestDoubleHistogramDataPointSlice(), es)
+}
+
 func TestDoubleHistogramDataPointSlice_Append(t *testing.T) {
 	es := generateTestDoubleHistogramDataPointSlice()
 
@@ -1201,12 +1850,89 @@ func TestDoubleHistogramDataPointSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestDoubleHistogramDataPointSlice_AppendEmpty(t *testing.T) {
+	es := generateTestDoubleHistogramDataPointSlice()
+	emptyVal := NewDoubleHistogramDataPoint()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestDoubleHistogramDataPoint(e)
+	assert.EqualValues(t, generateTestDoubleHistogramDataPoint(), es.At(7))
+}
+
+func TestDoubleHistogramDataPointSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewDoubleHistogramDataPointSlice()
+	emptySlice.RemoveIf(func(el DoubleHistogramDataPoint) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestDoubleHistogramDataPointSlice()
+	expectedSlice := NewDoubleHistogramDataPointSlice()
+	pos := 0
+	filtered.RemoveIf(func(el DoubleHistogramDataPoint) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestDoubleHistogramDataPointSlice_Equal(t *testing.T) {
+	es := generateTestDoubleHistogramDataPointSlice()
+	assert.True(t, es.Equal(generateTestDoubleHistogramDataPointSlice()))
+	assert.True(t, NewDoubleHistogramDataPointSlice().Equal(NewDoubleHistogramDataPointSlice()))
+	assert.Equal(t, []string{"DoubleHistogramDataPointSlice: length 7 != 0"}, es.Diff(NewDoubleHistogramDataPointSlice()))
+
+	// Test Equal with a different element
+	other := generateTestDoubleHistogramDataPointSlice()
+	other.Resize(6)
+	other.Append(NewDoubleHistogramDataPoint())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "DoubleHistogramDataPointSlice[6]."), diff)
+	}
+}
+
+func TestDoubleHistogramDataPointSlice_Sort(t *testing.T) {
+	es := generateTestDoubleHistogramDataPointSlice()
+	emptyVal := NewDoubleHistogramDataPoint()
+	es.Append(NewDoubleHistogramDataPoint())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b DoubleHistogramDataPoint) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestDoubleHistogramDataPoint()))
+	}
+}
+
 func TestDoubleHistogramDataPoint_CopyTo(t *testing.T) {
 	ms := NewDoubleHistogramDataPoint()
 	generateTestDoubleHistogramDataPoint().CopyTo(ms)
 	assert.EqualValues(t, generateTestDoubleHistogramDataPoint(), ms)
 }
 
+func TestDoubleHistogramDataPoint_Equal(t *testing.T) {
+	ms := generateTestDoubleHistogramDataPoint()
+	assert.True(t, ms.Equal(generateTestDoubleHistogramDataPoint()))
+	assert.Empty(t, ms.Diff(generateTestDoubleHistogramDataPoint()))
+	assert.False(t, ms.Equal(NewDoubleHistogramDataPoint()))
+	assert.NotEmpty(t, ms.Diff(NewDoubleHistogramDataPoint()))
+}
+
 func TestDoubleHistogramDataPoint_LabelsMap(t *testing.T) {
 	ms := NewDoubleHistogramDataPoint()
 	assert.EqualValues(t, NewStringMap(), ms.LabelsMap())
@@ -1370,6 +2096,23 @@ func TestSummaryDataPointSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestSummaryDataPointSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestSummaryDataPointSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestSummaryDataPointSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestSummaryDataPointSlice(), es)
+}
+
 func TestSummaryDataPointSlice_Append(t *testing.T) {
 	es := generateTestSummaryDataPointSlice()
 
@@ -1385,12 +2128,89 @@ func TestSummaryDataPointSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestSummaryDataPointSlice_AppendEmpty(t *testing.T) {
+	es := generateTestSummaryDataPointSlice()
+	emptyVal := NewSummaryDataPoint()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestSummaryDataPoint(e)
+	assert.EqualValues(t, generateTestSummaryDataPoint(), es.At(7))
+}
+
+func TestSummaryDataPointSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewSummaryDataPointSlice()
+	emptySlice.RemoveIf(func(el SummaryDataPoint) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestSummaryDataPointSlice()
+	expectedSlice := NewSummaryDataPointSlice()
+	pos := 0
+	filtered.RemoveIf(func(el SummaryDataPoint) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestSummaryDataPointSlice_Equal(t *testing.T) {
+	es := generateTestSummaryDataPointSlice()
+	assert.True(t, es.Equal(generateTestSummaryDataPointSlice()))
+	assert.True(t, NewSummaryDataPointSlice().Equal(NewSummaryDataPointSlice()))
+	assert.Equal(t, []string{"SummaryDataPointSlice: length 7 != 0"}, es.Diff(NewSummaryDataPointSlice()))
+
+	// Test Equal with a different element
+	other := generateTestSummaryDataPointSlice()
+	other.Resize(6)
+	other.Append(NewSummaryDataPoint())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "SummaryDataPointSlice[6]."), diff)
+	}
+}
+
+func TestSummaryDataPointSlice_Sort(t *testing.T) {
+	es := generateTestSummaryDataPointSlice()
+	emptyVal := NewSummaryDataPoint()
+	es.Append(NewSummaryDataPoint())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b SummaryDataPoint) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestSummaryDataPoint()))
+	}
+}
+
 func TestSummaryDataPoint_CopyTo(t *testing.T) {
 	ms := NewSummaryDataPoint()
 	generateTestSummaryDataPoint().CopyTo(ms)
 	assert.EqualValues(t, generateTestSummaryDataPoint(), ms)
 }
 
+func TestSummaryDataPoint_Equal(t *testing.T) {
+	ms := generateTestSummaryDataPoint()
+	assert.True(t, ms.Equal(generateTestSummaryDataPoint()))
+	assert.Empty(t, ms.Diff(generateTestSummaryDataPoint()))
+	assert.False(t, ms.Equal(NewSummaryDataPoint()))
+	assert.NotEmpty(t, ms.Diff(NewSummaryDataPoint()))
+}
+
 func TestSummaryDataPoint_LabelsMap(t *testing.T) {
 	ms := NewSummaryDataPoint()
 	assert.EqualValues(t, NewStringMap(), ms.LabelsMap())
@@ -1538,6 +2358,23 @@ func TestValueAtQuantileSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestValueAtQuantileSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestValueAtQuantileSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestValueAtQuantileSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestValueAtQuantileSlice(), es)
+}
+
 func TestValueAtQuantileSlice_Append(t *testing.T) {
 	es := generateTestValueAtQuantileSlice()
 
@@ -1553,12 +2390,89 @@ func TestValueAtQuantileSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestValueAtQuantileSlice_AppendEmpty(t *testing.T) {
+	es := generateTestValueAtQuantileSlice()
+	emptyVal := NewValueAtQuantile()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestValueAtQuantile(e)
+	assert.EqualValues(t, generateTestValueAtQuantile(), es.At(7))
+}
+
+func TestValueAtQuantileSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewValueAtQuantileSlice()
+	emptySlice.RemoveIf(func(el ValueAtQuantile) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestValueAtQuantileSlice()
+	expectedSlice := NewValueAtQuantileSlice()
+	pos := 0
+	filtered.RemoveIf(func(el ValueAtQuantile) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestValueAtQuantileSlice_Equal(t *testing.T) {
+	es := generateTestValueAtQuantileSlice()
+	assert.True(t, es.Equal(generateTestValueAtQuantileSlice()))
+	assert.True(t, NewValueAtQuantileSlice().Equal(NewValueAtQuantileSlice()))
+	assert.Equal(t, []string{"ValueAtQuantileSlice: length 7 != 0"}, es.Diff(NewValueAtQuantileSlice()))
+
+	// Test Equal with a different element
+	other := generateTestValueAtQuantileSlice()
+	other.Resize(6)
+	other.Append(NewValueAtQuantile())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "ValueAtQuantileSlice[6]."), diff)
+	}
+}
+
+func TestValueAtQuantileSlice_Sort(t *testing.T) {
+	es := generateTestValueAtQuantileSlice()
+	emptyVal := NewValueAtQuantile()
+	es.Append(NewValueAtQuantile())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b ValueAtQuantile) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestValueAtQuantile()))
+	}
+}
+
 func TestValueAtQuantile_CopyTo(t *testing.T) {
 	ms := NewValueAtQuantile()
 	generateTestValueAtQuantile().CopyTo(ms)
 	assert.EqualValues(t, generateTestValueAtQuantile(), ms)
 }
 
+func TestValueAtQuantile_Equal(t *testing.T) {
+	ms := generateTestValueAtQuantile()
+	assert.True(t, ms.Equal(generateTestValueAtQuantile()))
+	assert.Empty(t, ms.Diff(generateTestValueAtQuantile()))
+	assert.False(t, ms.Equal(NewValueAtQuantile()))
+	assert.NotEmpty(t, ms.Diff(NewValueAtQuantile()))
+}
+
 func TestValueAtQuantile_Quantile(t *testing.T) {
 	ms := NewValueAtQuantile()
 	assert.EqualValues(t, float64(0.0), ms.Quantile())
@@ -1674,6 +2588,23 @@ func TestIntExemplarSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestIntExemplarSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestIntExemplarSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestIntExemplarSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestIntExemplarSlice(), es)
+}
+
 func TestIntExemplarSlice_Append(t *testing.T) {
 	es := generateTestIntExemplarSlice()
 
@@ -1689,12 +2620,89 @@ func TestIntExemplarSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestIntExemplarSlice_AppendEmpty(t *testing.T) {
+	es := generateTestIntExemplarSlice()
+	emptyVal := NewIntExemplar()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestIntExemplar(e)
+	assert.EqualValues(t, generateTestIntExemplar(), es.At(7))
+}
+
+func TestIntExemplarSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewIntExemplarSlice()
+	emptySlice.RemoveIf(func(el IntExemplar) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestIntExemplarSlice()
+	expectedSlice := NewIntExemplarSlice()
+	pos := 0
+	filtered.RemoveIf(func(el IntExemplar) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestIntExemplarSlice_Equal(t *testing.T) {
+	es := generateTestIntExemplarSlice()
+	assert.True(t, es.Equal(generateTestIntExemplarSlice()))
+	assert.True(t, NewIntExemplarSlice().Equal(NewIntExemplarSlice()))
+	assert.Equal(t, []string{"IntExemplarSlice: length 7 != 0"}, es.Diff(NewIntExemplarSlice()))
+
+	// Test Equal with a different element
+	other := generateTestIntExemplarSlice()
+	other.Resize(6)
+	other.Append(NewIntExemplar())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "IntExemplarSlice[6]."), diff)
+	}
+}
+
+func TestIntExemplarSlice_Sort(t *testing.T) {
+	es := generateTestIntExemplarSlice()
+	emptyVal := NewIntExemplar()
+	es.Append(NewIntExemplar())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b IntExemplar) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestIntExemplar()))
+	}
+}
+
 func TestIntExemplar_CopyTo(t *testing.T) {
 	ms := NewIntExemplar()
 	generateTestIntExemplar().CopyTo(ms)
 	assert.EqualValues(t, generateTestIntExemplar(), ms)
 }
 
+func TestIntExemplar_Equal(t *testing.T) {
+	ms := generateTestIntExemplar()
+	assert.True(t, ms.Equal(generateTestIntExemplar()))
+	assert.Empty(t, ms.Diff(generateTestIntExemplar()))
+	assert.False(t, ms.Equal(NewIntExemplar()))
+	assert.NotEmpty(t, ms.Diff(NewIntExemplar()))
+}
+
 func TestIntExemplar_Timestamp(t *testing.T) {
 	ms := NewIntExemplar()
 	assert.EqualValues(t, Timestamp(0), ms.Timestamp())
@@ -1719,6 +2727,22 @@ func TestIntExemplar_FilteredLabels(t *testing.T) {
 	assert.EqualValues(t, testValFilteredLabels, ms.FilteredLabels())
 }
 
+func TestIntExemplar_TraceID(t *testing.T) {
+	ms := NewIntExemplar()
+	assert.EqualValues(t, NewTraceID([16]byte{}), ms.TraceID())
+	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	ms.SetTraceID(testValTraceID)
+	assert.EqualValues(t, testValTraceID, ms.TraceID())
+}
+
+func TestIntExemplar_SpanID(t *testing.T) {
+	ms := NewIntExemplar()
+	assert.EqualValues(t, NewSpanID([8]byte{}), ms.SpanID())
+	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	ms.SetSpanID(testValSpanID)
+	assert.EqualValues(t, testValSpanID, ms.SpanID())
+}
+
 func TestDoubleExemplarSlice(t *testing.T) {
 	es := NewDoubleExemplarSlice()
 	assert.EqualValues(t, 0, es.Len())
@@ -1818,6 +2842,23 @@ func TestDoubleExemplarSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestDoubleExemplarSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestDoubleExemplarSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestDoubleExemplarSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestDoubleExemplarSlice(), es)
+}
+
 func TestDoubleExemplarSlice_Append(t *testing.T) {
 	es := generateTestDoubleExemplarSlice()
 
@@ -1833,12 +2874,89 @@ func TestDoubleExemplarSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestDoubleExemplarSlice_AppendEmpty(t *testing.T) {
+	es := generateTestDoubleExemplarSlice()
+	emptyVal := NewDoubleExemplar()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestDoubleExemplar(e)
+	assert.EqualValues(t, generateTestDoubleExemplar(), es.At(7))
+}
+
+func TestDoubleExemplarSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewDoubleExemplarSlice()
+	emptySlice.RemoveIf(func(el DoubleExemplar) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestDoubleExemplarSlice()
+	expectedSlice := NewDoubleExemplarSlice()
+	pos := 0
+	filtered.RemoveIf(func(el DoubleExemplar) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestDoubleExemplarSlice_Equal(t *testing.T) {
+	es := generateTestDoubleExemplarSlice()
+	assert.True(t, es.Equal(generateTestDoubleExemplarSlice()))
+	assert.True(t, NewDoubleExemplarSlice().Equal(NewDoubleExemplarSlice()))
+	assert.Equal(t, []string{"DoubleExemplarSlice: length 7 != 0"}, es.Diff(NewDoubleExemplarSlice()))
+
+	// Test Equal with a different element
+	other := generateTestDoubleExemplarSlice()
+	other.Resize(6)
+	other.Append(NewDoubleExemplar())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "DoubleExemplarSlice[6]."), diff)
+	}
+}
+
+func TestDoubleExemplarSlice_Sort(t *testing.T) {
+	es := generateTestDoubleExemplarSlice()
+	emptyVal := NewDoubleExemplar()
+	es.Append(NewDoubleExemplar())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b DoubleExemplar) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestDoubleExemplar()))
+	}
+}
+
 func TestDoubleExemplar_CopyTo(t *testing.T) {
 	ms := NewDoubleExemplar()
 	generateTestDoubleExemplar().CopyTo(ms)
 	assert.EqualValues(t, generateTestDoubleExemplar(), ms)
 }
 
+func TestDoubleExemplar_Equal(t *testing.T) {
+	ms := generateTestDoubleExemplar()
+	assert.True(t, ms.Equal(generateTestDoubleExemplar()))
+	assert.Empty(t, ms.Diff(generateTestDoubleExemplar()))
+	assert.False(t, ms.Equal(NewDoubleExemplar()))
+	assert.NotEmpty(t, ms.Diff(NewDoubleExemplar()))
+}
+
 func TestDoubleExemplar_Timestamp(t *testing.T) {
 	ms := NewDoubleExemplar()
 	assert.EqualValues(t, Timestamp(0), ms.Timestamp())
@@ -1863,6 +2981,22 @@ func TestDoubleExemplar_FilteredLabels(t *testing.T) {
 	assert.EqualValues(t, testValFilteredLabels, ms.FilteredLabels())
 }
 
+func TestDoubleExemplar_TraceID(t *testing.T) {
+	ms := NewDoubleExemplar()
+	assert.EqualValues(t, NewTraceID([16]byte{}), ms.TraceID())
+	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
+	ms.SetTraceID(testValTraceID)
+	assert.EqualValues(t, testValTraceID, ms.TraceID())
+}
+
+func TestDoubleExemplar_SpanID(t *testing.T) {
+	ms := NewDoubleExemplar()
+	assert.EqualValues(t, NewSpanID([8]byte{}), ms.SpanID())
+	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	ms.SetSpanID(testValSpanID)
+	assert.EqualValues(t, testValSpanID, ms.SpanID())
+}
+
 func generateTestResourceMetricsSlice() ResourceMetricsSlice {
 	tv := NewResourceMetricsSlice()
 	fillTestResourceMetricsSlice(tv)
@@ -2203,6 +3337,8 @@ func fillTestIntExemplar(tv IntExemplar) {
 	tv.SetTimestamp(Timestamp(1234567890))
 	tv.SetValue(int64(-17))
 	fillTestStringMap(tv.FilteredLabels())
+	tv.SetTraceID(NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1}))
+	tv.SetSpanID(NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
 }
 
 func generateTestDoubleExemplarSlice() DoubleExemplarSlice {
@@ -2228,4 +3364,6 @@ func fillTestDoubleExemplar(tv DoubleExemplar) {
 	tv.SetTimestamp(Timestamp(1234567890))
 	tv.SetValue(float64(17.13))
 	fillTestStringMap(tv.FilteredLabels())
+	tv.SetTraceID(NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1}))
+	tv.SetSpanID(NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
 }
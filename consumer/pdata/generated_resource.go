@@ -52,3 +52,19 @@ func (ms Resource) Attributes() AttributeMap {
 func (ms Resource) CopyTo(dest Resource) {
 	ms.Attributes().CopyTo(dest.Attributes())
 }
+
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms Resource) Equal(other Resource) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms Resource) Diff(other Resource) []string {
+	return ms.diff("Resource", other, nil)
+}
+
+func (ms Resource) diff(path string, other Resource, diffs []string) []string {
+	diffs = ms.Attributes().diff(path+".Attributes", other.Attributes(), diffs)
+	return diffs
+}
@@ -29,6 +29,14 @@ func TestResource_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestResource(), ms)
 }
 
+func TestResource_Equal(t *testing.T) {
+	ms := generateTestResource()
+	assert.True(t, ms.Equal(generateTestResource()))
+	assert.Empty(t, ms.Diff(generateTestResource()))
+	assert.False(t, ms.Equal(NewResource()))
+	assert.NotEmpty(t, ms.Diff(NewResource()))
+}
+
 func TestResource_Attributes(t *testing.T) {
 	ms := NewResource()
 	assert.EqualValues(t, NewAttributeMap(), ms.Attributes())
@@ -18,6 +18,10 @@
 package pdata
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
 	"go.opentelemetry.io/collector/internal/data"
 	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"
 )
@@ -128,6 +132,28 @@ func (es ResourceSpansSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new ResourceSpansSlice can be initialized:
+// es := NewResourceSpansSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es ResourceSpansSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlptrace.ResourceSpans, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the ResourceSpansSlice by one and set the
 // given ResourceSpans at that new position.  The original ResourceSpans
 // could still be referenced so do not reuse it after passing it to this
@@ -136,6 +162,73 @@ func (es ResourceSpansSlice) Append(e ResourceSpans) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty ResourceSpans.
+// It returns the newly added ResourceSpans.
+func (es ResourceSpansSlice) AppendEmpty() ResourceSpans {
+	*es.orig = append(*es.orig, &otlptrace.ResourceSpans{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es ResourceSpansSlice) Equal(other ResourceSpansSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es ResourceSpansSlice) Diff(other ResourceSpansSlice) []string {
+	return es.diff("ResourceSpansSlice", other, nil)
+}
+
+func (es ResourceSpansSlice) diff(path string, other ResourceSpansSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the ResourceSpans elements within ResourceSpansSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b ResourceSpans) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es ResourceSpansSlice) Sort(less func(a, b ResourceSpans) bool) ResourceSpansSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // InstrumentationLibrarySpans is a collection of spans from a LibraryInstrumentation.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -163,6 +256,12 @@ func (ms ResourceSpans) Resource() Resource {
 	return newResource(&(*ms.orig).Resource)
 }
 
+// HasResource returns true if the resource associated with this ResourceSpans is set.
+// The resource is embedded in the OTLP struct, an empty resource is reported as not set.
+func (ms ResourceSpans) HasResource() bool {
+	return (*ms.orig).Resource.Size() != 0
+}
+
 // InstrumentationLibrarySpans returns the InstrumentationLibrarySpans associated with this ResourceSpans.
 func (ms ResourceSpans) InstrumentationLibrarySpans() InstrumentationLibrarySpansSlice {
 	return newInstrumentationLibrarySpansSlice(&(*ms.orig).InstrumentationLibrarySpans)
@@ -174,6 +273,23 @@ func (ms ResourceSpans) CopyTo(dest ResourceSpans) {
 	ms.InstrumentationLibrarySpans().CopyTo(dest.InstrumentationLibrarySpans())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms ResourceSpans) Equal(other ResourceSpans) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms ResourceSpans) Diff(other ResourceSpans) []string {
+	return ms.diff("ResourceSpans", other, nil)
+}
+
+func (ms ResourceSpans) diff(path string, other ResourceSpans, diffs []string) []string {
+	diffs = ms.Resource().diff(path+".Resource", other.Resource(), diffs)
+	diffs = ms.InstrumentationLibrarySpans().diff(path+".InstrumentationLibrarySpans", other.InstrumentationLibrarySpans(), diffs)
+	return diffs
+}
+
 // InstrumentationLibrarySpansSlice logically represents a slice of InstrumentationLibrarySpans.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -280,6 +396,28 @@ func (es InstrumentationLibrarySpansSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new InstrumentationLibrarySpansSlice can be initialized:
+// es := NewInstrumentationLibrarySpansSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es InstrumentationLibrarySpansSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlptrace.InstrumentationLibrarySpans, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the InstrumentationLibrarySpansSlice by one and set the
 // given InstrumentationLibrarySpans at that new position.  The original InstrumentationLibrarySpans
 // could still be referenced so do not reuse it after passing it to this
@@ -288,6 +426,73 @@ func (es InstrumentationLibrarySpansSlice) Append(e InstrumentationLibrarySpans)
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty InstrumentationLibrarySpans.
+// It returns the newly added InstrumentationLibrarySpans.
+func (es InstrumentationLibrarySpansSlice) AppendEmpty() InstrumentationLibrarySpans {
+	*es.orig = append(*es.orig, &otlptrace.InstrumentationLibrarySpans{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es InstrumentationLibrarySpansSlice) RemoveIf(f func(InstrumentationLibrarySpans) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es InstrumentationLibrarySpansSlice) Equal(other InstrumentationLibrarySpansSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es InstrumentationLibrarySpansSlice) Diff(other InstrumentationLibrarySpansSlice) []string {
+	return es.diff("InstrumentationLibrarySpansSlice", other, nil)
+}
+
+func (es InstrumentationLibrarySpansSlice) diff(path string, other InstrumentationLibrarySpansSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the InstrumentationLibrarySpans elements within InstrumentationLibrarySpansSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b InstrumentationLibrarySpans) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es InstrumentationLibrarySpansSlice) Sort(less func(a, b InstrumentationLibrarySpans) bool) InstrumentationLibrarySpansSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // InstrumentationLibrarySpans is a collection of spans from a LibraryInstrumentation.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -315,6 +520,12 @@ func (ms InstrumentationLibrarySpans) InstrumentationLibrary() InstrumentationLi
 	return newInstrumentationLibrary(&(*ms.orig).InstrumentationLibrary)
 }
 
+// HasInstrumentationLibrary returns true if the instrumentationlibrary associated with this InstrumentationLibrarySpans is set.
+// The instrumentationlibrary is embedded in the OTLP struct, an empty instrumentationlibrary is reported as not set.
+func (ms InstrumentationLibrarySpans) HasInstrumentationLibrary() bool {
+	return (*ms.orig).InstrumentationLibrary.Size() != 0
+}
+
 // Spans returns the Spans associated with this InstrumentationLibrarySpans.
 func (ms InstrumentationLibrarySpans) Spans() SpanSlice {
 	return newSpanSlice(&(*ms.orig).Spans)
@@ -326,6 +537,23 @@ func (ms InstrumentationLibrarySpans) CopyTo(dest InstrumentationLibrarySpans) {
 	ms.Spans().CopyTo(dest.Spans())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms InstrumentationLibrarySpans) Equal(other InstrumentationLibrarySpans) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms InstrumentationLibrarySpans) Diff(other InstrumentationLibrarySpans) []string {
+	return ms.diff("InstrumentationLibrarySpans", other, nil)
+}
+
+func (ms InstrumentationLibrarySpans) diff(path string, other InstrumentationLibrarySpans, diffs []string) []string {
+	diffs = ms.InstrumentationLibrary().diff(path+".InstrumentationLibrary", other.InstrumentationLibrary(), diffs)
+	diffs = ms.Spans().diff(path+".Spans", other.Spans(), diffs)
+	return diffs
+}
+
 // SpanSlice logically represents a slice of Span.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -432,6 +660,28 @@ func (es SpanSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new SpanSlice can be initialized:
+// es := NewSpanSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es SpanSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlptrace.Span, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the SpanSlice by one and set the
 // given Span at that new position.  The original Span
 // could still be referenced so do not reuse it after passing it to this
@@ -440,6 +690,73 @@ func (es SpanSlice) Append(e Span) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty Span.
+// It returns the newly added Span.
+func (es SpanSlice) AppendEmpty() Span {
+	*es.orig = append(*es.orig, &otlptrace.Span{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es SpanSlice) RemoveIf(f func(Span) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es SpanSlice) Equal(other SpanSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es SpanSlice) Diff(other SpanSlice) []string {
+	return es.diff("SpanSlice", other, nil)
+}
+
+func (es SpanSlice) diff(path string, other SpanSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the Span elements within SpanSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b Span) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es SpanSlice) Sort(less func(a, b Span) bool) SpanSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // Span represents a single operation within a trace.
 // See Span definition in OTLP: https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto#L37
 //
@@ -593,6 +910,12 @@ func (ms Span) Status() SpanStatus {
 	return newSpanStatus(&(*ms.orig).Status)
 }
 
+// HasStatus returns true if the status associated with this Span is set.
+// The status is embedded in the OTLP struct, an empty status is reported as not set.
+func (ms Span) HasStatus() bool {
+	return (*ms.orig).Status.Size() != 0
+}
+
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Span) CopyTo(dest Span) {
 	dest.SetTraceID(ms.TraceID())
@@ -612,6 +935,58 @@ func (ms Span) CopyTo(dest Span) {
 	ms.Status().CopyTo(dest.Status())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms Span) Equal(other Span) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms Span) Diff(other Span) []string {
+	return ms.diff("Span", other, nil)
+}
+
+func (ms Span) diff(path string, other Span, diffs []string) []string {
+	if ms.TraceID() != other.TraceID() {
+		diffs = append(diffs, fmt.Sprintf("%s.TraceID: %v != %v", path, ms.TraceID().HexString(), other.TraceID().HexString()))
+	}
+	if ms.SpanID() != other.SpanID() {
+		diffs = append(diffs, fmt.Sprintf("%s.SpanID: %v != %v", path, ms.SpanID().HexString(), other.SpanID().HexString()))
+	}
+	if ms.TraceState() != other.TraceState() {
+		diffs = append(diffs, fmt.Sprintf("%s.TraceState: %v != %v", path, ms.TraceState(), other.TraceState()))
+	}
+	if ms.ParentSpanID() != other.ParentSpanID() {
+		diffs = append(diffs, fmt.Sprintf("%s.ParentSpanID: %v != %v", path, ms.ParentSpanID().HexString(), other.ParentSpanID().HexString()))
+	}
+	if ms.Name() != other.Name() {
+		diffs = append(diffs, fmt.Sprintf("%s.Name: %v != %v", path, ms.Name(), other.Name()))
+	}
+	if ms.Kind() != other.Kind() {
+		diffs = append(diffs, fmt.Sprintf("%s.Kind: %v != %v", path, ms.Kind(), other.Kind()))
+	}
+	if ms.StartTime() != other.StartTime() {
+		diffs = append(diffs, fmt.Sprintf("%s.StartTime: %v != %v", path, ms.StartTime(), other.StartTime()))
+	}
+	if ms.EndTime() != other.EndTime() {
+		diffs = append(diffs, fmt.Sprintf("%s.EndTime: %v != %v", path, ms.EndTime(), other.EndTime()))
+	}
+	diffs = ms.Attributes().diff(path+".Attributes", other.Attributes(), diffs)
+	if ms.DroppedAttributesCount() != other.DroppedAttributesCount() {
+		diffs = append(diffs, fmt.Sprintf("%s.DroppedAttributesCount: %v != %v", path, ms.DroppedAttributesCount(), other.DroppedAttributesCount()))
+	}
+	diffs = ms.Events().diff(path+".Events", other.Events(), diffs)
+	if ms.DroppedEventsCount() != other.DroppedEventsCount() {
+		diffs = append(diffs, fmt.Sprintf("%s.DroppedEventsCount: %v != %v", path, ms.DroppedEventsCount(), other.DroppedEventsCount()))
+	}
+	diffs = ms.Links().diff(path+".Links", other.Links(), diffs)
+	if ms.DroppedLinksCount() != other.DroppedLinksCount() {
+		diffs = append(diffs, fmt.Sprintf("%s.DroppedLinksCount: %v != %v", path, ms.DroppedLinksCount(), other.DroppedLinksCount()))
+	}
+	diffs = ms.Status().diff(path+".Status", other.Status(), diffs)
+	return diffs
+}
+
 // SpanEventSlice logically represents a slice of SpanEvent.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -718,6 +1093,28 @@ func (es SpanEventSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new SpanEventSlice can be initialized:
+// es := NewSpanEventSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es SpanEventSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlptrace.Span_Event, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the SpanEventSlice by one and set the
 // given SpanEvent at that new position.  The original SpanEvent
 // could still be referenced so do not reuse it after passing it to this
@@ -726,6 +1123,73 @@ func (es SpanEventSlice) Append(e SpanEvent) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty SpanEvent.
+// It returns the newly added SpanEvent.
+func (es SpanEventSlice) AppendEmpty() SpanEvent {
+	*es.orig = append(*es.orig, &otlptrace.Span_Event{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es SpanEventSlice) RemoveIf(f func(SpanEvent) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es SpanEventSlice) Equal(other SpanEventSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es SpanEventSlice) Diff(other SpanEventSlice) []string {
+	return es.diff("SpanEventSlice", other, nil)
+}
+
+func (es SpanEventSlice) diff(path string, other SpanEventSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the SpanEvent elements within SpanEventSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b SpanEvent) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es SpanEventSlice) Sort(less func(a, b SpanEvent) bool) SpanEventSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // SpanEvent is a time-stamped annotation of the span, consisting of user-supplied
 // text description and key-value pairs. See OTLP for event definition.
 //
@@ -792,6 +1256,31 @@ func (ms SpanEvent) CopyTo(dest SpanEvent) {
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms SpanEvent) Equal(other SpanEvent) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms SpanEvent) Diff(other SpanEvent) []string {
+	return ms.diff("SpanEvent", other, nil)
+}
+
+func (ms SpanEvent) diff(path string, other SpanEvent, diffs []string) []string {
+	if ms.Timestamp() != other.Timestamp() {
+		diffs = append(diffs, fmt.Sprintf("%s.Timestamp: %v != %v", path, ms.Timestamp(), other.Timestamp()))
+	}
+	if ms.Name() != other.Name() {
+		diffs = append(diffs, fmt.Sprintf("%s.Name: %v != %v", path, ms.Name(), other.Name()))
+	}
+	diffs = ms.Attributes().diff(path+".Attributes", other.Attributes(), diffs)
+	if ms.DroppedAttributesCount() != other.DroppedAttributesCount() {
+		diffs = append(diffs, fmt.Sprintf("%s.DroppedAttributesCount: %v != %v", path, ms.DroppedAttributesCount(), other.DroppedAttributesCount()))
+	}
+	return diffs
+}
+
 // SpanLinkSlice logically represents a slice of SpanLink.
 //
 // This is a reference type, if passed by value and callee modifies it the
@@ -898,6 +1387,28 @@ func (es SpanLinkSlice) Resize(newLen int) {
 	}
 }
 
+// EnsureCapacity is an operation that ensures the slice has at least the specified capacity.
+// 1. If the newCap <= cap then no change in capacity.
+// 2. If the newCap > cap then the slice capacity will be expanded to equal newCap.
+//
+// Here is how a new SpanLinkSlice can be initialized:
+// es := NewSpanLinkSlice()
+// es.EnsureCapacity(4)
+// for i := 0; i < 4; i++ {
+//     e := es.AppendEmpty()
+//     // Here should set all the values for e.
+// }
+func (es SpanLinkSlice) EnsureCapacity(newCap int) {
+	oldCap := cap(*es.orig)
+	if newCap <= oldCap {
+		return
+	}
+
+	newOrig := make([]*otlptrace.Span_Link, len(*es.orig), newCap)
+	copy(newOrig, *es.orig)
+	*es.orig = newOrig
+}
+
 // Append will increase the length of the SpanLinkSlice by one and set the
 // given SpanLink at that new position.  The original SpanLink
 // could still be referenced so do not reuse it after passing it to this
@@ -906,6 +1417,73 @@ func (es SpanLinkSlice) Append(e SpanLink) {
 	*es.orig = append(*es.orig, e.orig)
 }
 
+// AppendEmpty will append to the end of the slice an empty SpanLink.
+// It returns the newly added SpanLink.
+func (es SpanLinkSlice) AppendEmpty() SpanLink {
+	*es.orig = append(*es.orig, &otlptrace.Span_Link{})
+	return es.At(es.Len() - 1)
+}
+
+// RemoveIf calls f sequentially for each element present in the slice.
+// If f returns true, the element is removed from the slice. The order of the
+// remaining elements is preserved.
+func (es SpanLinkSlice) RemoveIf(f func(SpanLink) bool) {
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.At(i)) {
+			continue
+		}
+		if newLen == i {
+			// Nothing to move, element is at the right place.
+			newLen++
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	// Erase the removed elements so they can be garbage collected.
+	for i := newLen; i < len(*es.orig); i++ {
+		(*es.orig)[i] = nil
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
+// Equal returns true if the current slice and the other have the same length
+// and all their elements are equal.
+func (es SpanLinkSlice) Equal(other SpanLinkSlice) bool {
+	return len(es.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the elements of the current slice that are
+// different in the other, as "<path>: <value> != <other value>". Returns an
+// empty slice if they are equal.
+func (es SpanLinkSlice) Diff(other SpanLinkSlice) []string {
+	return es.diff("SpanLinkSlice", other, nil)
+}
+
+func (es SpanLinkSlice) diff(path string, other SpanLinkSlice, diffs []string) []string {
+	if es.Len() != other.Len() {
+		diffs = append(diffs, fmt.Sprintf("%s: length %d != %d", path, es.Len(), other.Len()))
+	}
+	for i := 0; i < es.Len() && i < other.Len(); i++ {
+		diffs = es.At(i).diff(path+"["+strconv.Itoa(i)+"]", other.At(i), diffs)
+	}
+	return diffs
+}
+
+// Sort sorts the SpanLink elements within SpanLinkSlice given the
+// provided less function, the order of the equal elements is preserved.
+//
+// Returns the same instance to allow nicer code like:
+// lessFunc := func(a, b SpanLink) bool {
+//     return a.Name() < b.Name() // choose any comparison here
+// }
+// assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
+func (es SpanLinkSlice) Sort(less func(a, b SpanLink) bool) SpanLinkSlice {
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	return es
+}
+
 // SpanLink is a pointer from the current span to another span in the same trace or in a
 // different trace. See OTLP for link definition.
 //
@@ -983,6 +1561,34 @@ func (ms SpanLink) CopyTo(dest SpanLink) {
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
 
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms SpanLink) Equal(other SpanLink) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms SpanLink) Diff(other SpanLink) []string {
+	return ms.diff("SpanLink", other, nil)
+}
+
+func (ms SpanLink) diff(path string, other SpanLink, diffs []string) []string {
+	if ms.TraceID() != other.TraceID() {
+		diffs = append(diffs, fmt.Sprintf("%s.TraceID: %v != %v", path, ms.TraceID().HexString(), other.TraceID().HexString()))
+	}
+	if ms.SpanID() != other.SpanID() {
+		diffs = append(diffs, fmt.Sprintf("%s.SpanID: %v != %v", path, ms.SpanID().HexString(), other.SpanID().HexString()))
+	}
+	if ms.TraceState() != other.TraceState() {
+		diffs = append(diffs, fmt.Sprintf("%s.TraceState: %v != %v", path, ms.TraceState(), other.TraceState()))
+	}
+	diffs = ms.Attributes().diff(path+".Attributes", other.Attributes(), diffs)
+	if ms.DroppedAttributesCount() != other.DroppedAttributesCount() {
+		diffs = append(diffs, fmt.Sprintf("%s.DroppedAttributesCount: %v != %v", path, ms.DroppedAttributesCount(), other.DroppedAttributesCount()))
+	}
+	return diffs
+}
+
 // SpanStatus is an optional final status for this span. Semantically when Status wasn't set
 // it is means span ended without errors and assume Status.Ok (code = 0).
 //
@@ -1026,3 +1632,24 @@ func (ms SpanStatus) CopyTo(dest SpanStatus) {
 	dest.SetCode(ms.Code())
 	dest.SetMessage(ms.Message())
 }
+
+// Equal returns true if all the fields of the current struct and the other are equal.
+func (ms SpanStatus) Equal(other SpanStatus) bool {
+	return len(ms.diff("", other, nil)) == 0
+}
+
+// Diff returns the fields of the current struct that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ms SpanStatus) Diff(other SpanStatus) []string {
+	return ms.diff("SpanStatus", other, nil)
+}
+
+func (ms SpanStatus) diff(path string, other SpanStatus, diffs []string) []string {
+	if ms.Code() != other.Code() {
+		diffs = append(diffs, fmt.Sprintf("%s.Code: %v != %v", path, ms.Code(), other.Code()))
+	}
+	if ms.Message() != other.Message() {
+		diffs = append(diffs, fmt.Sprintf("%s.Message: %v != %v", path, ms.Message(), other.Message()))
+	}
+	return diffs
+}
@@ -18,6 +18,7 @@
 package pdata
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -124,6 +125,23 @@ func TestResourceSpansSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestResourceSpansSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestResourceSpansSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestResourceSpansSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestResourceSpansSlice(), es)
+}
+
 func TestResourceSpansSlice_Append(t *testing.T) {
 	es := generateTestResourceSpansSlice()
 
@@ -139,15 +157,94 @@ func TestResourceSpansSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestResourceSpansSlice_AppendEmpty(t *testing.T) {
+	es := generateTestResourceSpansSlice()
+	emptyVal := NewResourceSpans()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestResourceSpans(e)
+	assert.EqualValues(t, generateTestResourceSpans(), es.At(7))
+}
+
+func TestResourceSpansSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewResourceSpansSlice()
+	emptySlice.RemoveIf(func(el ResourceSpans) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestResourceSpansSlice()
+	expectedSlice := NewResourceSpansSlice()
+	pos := 0
+	filtered.RemoveIf(func(el ResourceSpans) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestResourceSpansSlice_Equal(t *testing.T) {
+	es := generateTestResourceSpansSlice()
+	assert.True(t, es.Equal(generateTestResourceSpansSlice()))
+	assert.True(t, NewResourceSpansSlice().Equal(NewResourceSpansSlice()))
+	assert.Equal(t, []string{"ResourceSpansSlice: length 7 != 0"}, es.Diff(NewResourceSpansSlice()))
+
+	// Test Equal with a different element
+	other := generateTestResourceSpansSlice()
+	other.Resize(6)
+	other.Append(NewResourceSpans())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "ResourceSpansSlice[6]."), diff)
+	}
+}
+
+func TestResourceSpansSlice_Sort(t *testing.T) {
+	es := generateTestResourceSpansSlice()
+	emptyVal := NewResourceSpans()
+	es.Append(NewResourceSpans())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b ResourceSpans) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestResourceSpans()))
+	}
+}
+
 func TestResourceSpans_CopyTo(t *testing.T) {
 	ms := NewResourceSpans()
 	generateTestResourceSpans().CopyTo(ms)
 	assert.EqualValues(t, generateTestResourceSpans(), ms)
 }
 
+func TestResourceSpans_Equal(t *testing.T) {
+	ms := generateTestResourceSpans()
+	assert.True(t, ms.Equal(generateTestResourceSpans()))
+	assert.Empty(t, ms.Diff(generateTestResourceSpans()))
+	assert.False(t, ms.Equal(NewResourceSpans()))
+	assert.NotEmpty(t, ms.Diff(NewResourceSpans()))
+}
+
 func TestResourceSpans_Resource(t *testing.T) {
 	ms := NewResourceSpans()
+	assert.False(t, ms.HasResource())
 	fillTestResource(ms.Resource())
+	assert.True(t, ms.HasResource())
 	assert.EqualValues(t, generateTestResource(), ms.Resource())
 }
 
@@ -258,6 +355,23 @@ func TestInstrumentationLibrarySpansSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestInstrumentationLibrarySpansSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestInstrumentationLibrarySpansSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestInstrumentationLibrarySpansSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestInstrumentationLibrarySpansSlice(), es)
+}
+
 func TestInstrumentationLibrarySpansSlice_Append(t *testing.T) {
 	es := generateTestInstrumentationLibrarySpansSlice()
 
@@ -273,15 +387,94 @@ func TestInstrumentationLibrarySpansSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestInstrumentationLibrarySpansSlice_AppendEmpty(t *testing.T) {
+	es := generateTestInstrumentationLibrarySpansSlice()
+	emptyVal := NewInstrumentationLibrarySpans()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestInstrumentationLibrarySpans(e)
+	assert.EqualValues(t, generateTestInstrumentationLibrarySpans(), es.At(7))
+}
+
+func TestInstrumentationLibrarySpansSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewInstrumentationLibrarySpansSlice()
+	emptySlice.RemoveIf(func(el InstrumentationLibrarySpans) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestInstrumentationLibrarySpansSlice()
+	expectedSlice := NewInstrumentationLibrarySpansSlice()
+	pos := 0
+	filtered.RemoveIf(func(el InstrumentationLibrarySpans) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestInstrumentationLibrarySpansSlice_Equal(t *testing.T) {
+	es := generateTestInstrumentationLibrarySpansSlice()
+	assert.True(t, es.Equal(generateTestInstrumentationLibrarySpansSlice()))
+	assert.True(t, NewInstrumentationLibrarySpansSlice().Equal(NewInstrumentationLibrarySpansSlice()))
+	assert.Equal(t, []string{"InstrumentationLibrarySpansSlice: length 7 != 0"}, es.Diff(NewInstrumentationLibrarySpansSlice()))
+
+	// Test Equal with a different element
+	other := generateTestInstrumentationLibrarySpansSlice()
+	other.Resize(6)
+	other.Append(NewInstrumentationLibrarySpans())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "InstrumentationLibrarySpansSlice[6]."), diff)
+	}
+}
+
+func TestInstrumentationLibrarySpansSlice_Sort(t *testing.T) {
+	es := generateTestInstrumentationLibrarySpansSlice()
+	emptyVal := NewInstrumentationLibrarySpans()
+	es.Append(NewInstrumentationLibrarySpans())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b InstrumentationLibrarySpans) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestInstrumentationLibrarySpans()))
+	}
+}
+
 func TestInstrumentationLibrarySpans_CopyTo(t *testing.T) {
 	ms := NewInstrumentationLibrarySpans()
 	generateTestInstrumentationLibrarySpans().CopyTo(ms)
 	assert.EqualValues(t, generateTestInstrumentationLibrarySpans(), ms)
 }
 
+func TestInstrumentationLibrarySpans_Equal(t *testing.T) {
+	ms := generateTestInstrumentationLibrarySpans()
+	assert.True(t, ms.Equal(generateTestInstrumentationLibrarySpans()))
+	assert.Empty(t, ms.Diff(generateTestInstrumentationLibrarySpans()))
+	assert.False(t, ms.Equal(NewInstrumentationLibrarySpans()))
+	assert.NotEmpty(t, ms.Diff(NewInstrumentationLibrarySpans()))
+}
+
 func TestInstrumentationLibrarySpans_InstrumentationLibrary(t *testing.T) {
 	ms := NewInstrumentationLibrarySpans()
+	assert.False(t, ms.HasInstrumentationLibrary())
 	fillTestInstrumentationLibrary(ms.InstrumentationLibrary())
+	assert.True(t, ms.HasInstrumentationLibrary())
 	assert.EqualValues(t, generateTestInstrumentationLibrary(), ms.InstrumentationLibrary())
 }
 
@@ -392,6 +585,23 @@ func TestSpanSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestSpanSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestSpanSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestSpanSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestSpanSlice(), es)
+}
+
 func TestSpanSlice_Append(t *testing.T) {
 	es := generateTestSpanSlice()
 
@@ -407,12 +617,89 @@ func TestSpanSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestSpanSlice_AppendEmpty(t *testing.T) {
+	es := generateTestSpanSlice()
+	emptyVal := NewSpan()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestSpan(e)
+	assert.EqualValues(t, generateTestSpan(), es.At(7))
+}
+
+func TestSpanSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewSpanSlice()
+	emptySlice.RemoveIf(func(el Span) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestSpanSlice()
+	expectedSlice := NewSpanSlice()
+	pos := 0
+	filtered.RemoveIf(func(el Span) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestSpanSlice_Equal(t *testing.T) {
+	es := generateTestSpanSlice()
+	assert.True(t, es.Equal(generateTestSpanSlice()))
+	assert.True(t, NewSpanSlice().Equal(NewSpanSlice()))
+	assert.Equal(t, []string{"SpanSlice: length 7 != 0"}, es.Diff(NewSpanSlice()))
+
+	// Test Equal with a different element
+	other := generateTestSpanSlice()
+	other.Resize(6)
+	other.Append(NewSpan())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "SpanSlice[6]."), diff)
+	}
+}
+
+func TestSpanSlice_Sort(t *testing.T) {
+	es := generateTestSpanSlice()
+	emptyVal := NewSpan()
+	es.Append(NewSpan())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b Span) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestSpan()))
+	}
+}
+
 func TestSpan_CopyTo(t *testing.T) {
 	ms := NewSpan()
 	generateTestSpan().CopyTo(ms)
 	assert.EqualValues(t, generateTestSpan(), ms)
 }
 
+func TestSpan_Equal(t *testing.T) {
+	ms := generateTestSpan()
+	assert.True(t, ms.Equal(generateTestSpan()))
+	assert.Empty(t, ms.Diff(generateTestSpan()))
+	assert.False(t, ms.Equal(NewSpan()))
+	assert.NotEmpty(t, ms.Diff(NewSpan()))
+}
+
 func TestSpan_TraceID(t *testing.T) {
 	ms := NewSpan()
 	assert.EqualValues(t, NewTraceID([16]byte{}), ms.TraceID())
@@ -527,7 +814,9 @@ func TestSpan_DroppedLinksCount(t *testing.T) {
 
 func TestSpan_Status(t *testing.T) {
 	ms := NewSpan()
+	assert.False(t, ms.HasStatus())
 	fillTestSpanStatus(ms.Status())
+	assert.True(t, ms.HasStatus())
 	assert.EqualValues(t, generateTestSpanStatus(), ms.Status())
 }
 
@@ -630,6 +919,23 @@ func TestSpanEventSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestSpanEventSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestSpanEventSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestSpanEventSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestSpanEventSlice(), es)
+}
+
 func TestSpanEventSlice_Append(t *testing.T) {
 	es := generateTestSpanEventSlice()
 
@@ -645,12 +951,89 @@ func TestSpanEventSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestSpanEventSlice_AppendEmpty(t *testing.T) {
+	es := generateTestSpanEventSlice()
+	emptyVal := NewSpanEvent()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestSpanEvent(e)
+	assert.EqualValues(t, generateTestSpanEvent(), es.At(7))
+}
+
+func TestSpanEventSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewSpanEventSlice()
+	emptySlice.RemoveIf(func(el SpanEvent) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestSpanEventSlice()
+	expectedSlice := NewSpanEventSlice()
+	pos := 0
+	filtered.RemoveIf(func(el SpanEvent) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestSpanEventSlice_Equal(t *testing.T) {
+	es := generateTestSpanEventSlice()
+	assert.True(t, es.Equal(generateTestSpanEventSlice()))
+	assert.True(t, NewSpanEventSlice().Equal(NewSpanEventSlice()))
+	assert.Equal(t, []string{"SpanEventSlice: length 7 != 0"}, es.Diff(NewSpanEventSlice()))
+
+	// Test Equal with a different element
+	other := generateTestSpanEventSlice()
+	other.Resize(6)
+	other.Append(NewSpanEvent())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "SpanEventSlice[6]."), diff)
+	}
+}
+
+func TestSpanEventSlice_Sort(t *testing.T) {
+	es := generateTestSpanEventSlice()
+	emptyVal := NewSpanEvent()
+	es.Append(NewSpanEvent())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b SpanEvent) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestSpanEvent()))
+	}
+}
+
 func TestSpanEvent_CopyTo(t *testing.T) {
 	ms := NewSpanEvent()
 	generateTestSpanEvent().CopyTo(ms)
 	assert.EqualValues(t, generateTestSpanEvent(), ms)
 }
 
+func TestSpanEvent_Equal(t *testing.T) {
+	ms := generateTestSpanEvent()
+	assert.True(t, ms.Equal(generateTestSpanEvent()))
+	assert.Empty(t, ms.Diff(generateTestSpanEvent()))
+	assert.False(t, ms.Equal(NewSpanEvent()))
+	assert.NotEmpty(t, ms.Diff(NewSpanEvent()))
+}
+
 func TestSpanEvent_Timestamp(t *testing.T) {
 	ms := NewSpanEvent()
 	assert.EqualValues(t, Timestamp(0), ms.Timestamp())
@@ -782,6 +1165,23 @@ func TestSpanLinkSlice_Resize(t *testing.T) {
 	assert.Equal(t, 0, es.Len())
 }
 
+func TestSpanLinkSlice_EnsureCapacity(t *testing.T) {
+	es := generateTestSpanLinkSlice()
+	// Test ensure smaller capacity.
+	const ensureSmallLen = 4
+	es.EnsureCapacity(ensureSmallLen)
+	assert.Less(t, ensureSmallLen, es.Len())
+	assert.EqualValues(t, generateTestSpanLinkSlice(), es)
+
+	// Test ensure larger capacity
+	const ensureLargeLen = 9
+	oldLen := es.Len()
+	es.EnsureCapacity(ensureLargeLen)
+	assert.Equal(t, ensureLargeLen, cap(*es.orig))
+	assert.Equal(t, oldLen, es.Len())
+	assert.EqualValues(t, generateTestSpanLinkSlice(), es)
+}
+
 func TestSpanLinkSlice_Append(t *testing.T) {
 	es := generateTestSpanLinkSlice()
 
@@ -797,12 +1197,89 @@ func TestSpanLinkSlice_Append(t *testing.T) {
 	assert.Equal(t, 9, es.Len())
 }
 
+func TestSpanLinkSlice_AppendEmpty(t *testing.T) {
+	es := generateTestSpanLinkSlice()
+	emptyVal := NewSpanLink()
+	e := es.AppendEmpty()
+	assert.EqualValues(t, emptyVal, e)
+	assert.Equal(t, 8, es.Len())
+
+	fillTestSpanLink(e)
+	assert.EqualValues(t, generateTestSpanLink(), es.At(7))
+}
+
+func TestSpanLinkSlice_RemoveIf(t *testing.T) {
+	// Test RemoveIf on empty slice
+	emptySlice := NewSpanLinkSlice()
+	emptySlice.RemoveIf(func(el SpanLink) bool {
+		t.Fail()
+		return false
+	})
+
+	// Test RemoveIf
+	filtered := generateTestSpanLinkSlice()
+	expectedSlice := NewSpanLinkSlice()
+	pos := 0
+	filtered.RemoveIf(func(el SpanLink) bool {
+		pos++
+		if pos%3 == 0 {
+			return true
+		}
+		expectedSlice.Append(el)
+		return false
+	})
+	assert.Equal(t, 7, pos)
+	assert.Equal(t, 5, filtered.Len())
+	assert.EqualValues(t, expectedSlice, filtered)
+}
+
+func TestSpanLinkSlice_Equal(t *testing.T) {
+	es := generateTestSpanLinkSlice()
+	assert.True(t, es.Equal(generateTestSpanLinkSlice()))
+	assert.True(t, NewSpanLinkSlice().Equal(NewSpanLinkSlice()))
+	assert.Equal(t, []string{"SpanLinkSlice: length 7 != 0"}, es.Diff(NewSpanLinkSlice()))
+
+	// Test Equal with a different element
+	other := generateTestSpanLinkSlice()
+	other.Resize(6)
+	other.Append(NewSpanLink())
+	assert.False(t, es.Equal(other))
+	diffs := es.Diff(other)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.True(t, strings.HasPrefix(diff, "SpanLinkSlice[6]."), diff)
+	}
+}
+
+func TestSpanLinkSlice_Sort(t *testing.T) {
+	es := generateTestSpanLinkSlice()
+	emptyVal := NewSpanLink()
+	es.Append(NewSpanLink())
+	// Test Sort moving the empty element first.
+	sorted := es.Sort(func(a, b SpanLink) bool {
+		return a.Equal(emptyVal) && !b.Equal(emptyVal)
+	})
+	assert.EqualValues(t, es, sorted)
+	assert.True(t, es.At(0).Equal(emptyVal))
+	for i := 1; i < es.Len(); i++ {
+		assert.True(t, es.At(i).Equal(generateTestSpanLink()))
+	}
+}
+
 func TestSpanLink_CopyTo(t *testing.T) {
 	ms := NewSpanLink()
 	generateTestSpanLink().CopyTo(ms)
 	assert.EqualValues(t, generateTestSpanLink(), ms)
 }
 
+func TestSpanLink_Equal(t *testing.T) {
+	ms := generateTestSpanLink()
+	assert.True(t, ms.Equal(generateTestSpanLink()))
+	assert.Empty(t, ms.Diff(generateTestSpanLink()))
+	assert.False(t, ms.Equal(NewSpanLink()))
+	assert.NotEmpty(t, ms.Diff(NewSpanLink()))
+}
+
 func TestSpanLink_TraceID(t *testing.T) {
 	ms := NewSpanLink()
 	assert.EqualValues(t, NewTraceID([16]byte{}), ms.TraceID())
@@ -849,6 +1326,14 @@ func TestSpanStatus_CopyTo(t *testing.T) {
 	assert.EqualValues(t, generateTestSpanStatus(), ms)
 }
 
+func TestSpanStatus_Equal(t *testing.T) {
+	ms := generateTestSpanStatus()
+	assert.True(t, ms.Equal(generateTestSpanStatus()))
+	assert.Empty(t, ms.Diff(generateTestSpanStatus()))
+	assert.False(t, ms.Equal(NewSpanStatus()))
+	assert.NotEmpty(t, ms.Diff(NewSpanStatus()))
+}
+
 func TestSpanStatus_Code(t *testing.T) {
 	ms := NewSpanStatus()
 	assert.EqualValues(t, StatusCode(0), ms.Code())
@@ -17,6 +17,7 @@ package pdata
 import (
 	"go.opentelemetry.io/collector/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
+	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
 	otlplogs "go.opentelemetry.io/collector/internal/data/protogen/logs/v1"
 )
 
@@ -78,6 +79,17 @@ func (ld Logs) Clone() Logs {
 	return cloneLd
 }
 
+// Equal returns true if all the resource logs of the current Logs and the other are equal.
+func (ld Logs) Equal(other Logs) bool {
+	return ld.ResourceLogs().Equal(other.ResourceLogs())
+}
+
+// Diff returns the fields of the current Logs that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (ld Logs) Diff(other Logs) []string {
+	return ld.ResourceLogs().diff("ResourceLogs", other.ResourceLogs(), nil)
+}
+
 // LogRecordCount calculates the total number of log records.
 func (ld Logs) LogRecordCount() int {
 	logCount := 0
@@ -135,3 +147,45 @@ const (
 )
 
 func (sn SeverityNumber) String() string { return otlplogs.SeverityNumber(sn).String() }
+
+// FlattenCollisionPolicy specifies how LogRecord.FlattenBody handles the keys of the body
+// that already exist in the attributes.
+type FlattenCollisionPolicy int32
+
+const (
+	// FlattenCollisionOverwrite replaces the existing attributes with the values of the body.
+	FlattenCollisionOverwrite FlattenCollisionPolicy = iota
+	// FlattenCollisionKeep keeps the existing attributes, the values of the body are dropped.
+	FlattenCollisionKeep
+)
+
+// FlattenBody moves the entries of a Body of map type to the attributes of the LogRecord
+// and clears the Body. The keys of the nested maps are joined with the separator, e.g. the
+// body {"http": {"method": "GET"}} is flattened to the "http.method" attribute with the "."
+// separator. The arrays and the empty maps are moved as they are.
+//
+// Returns false, and the LogRecord is left unchanged, if the Body is not a map.
+func (ms LogRecord) FlattenBody(separator string, policy FlattenCollisionPolicy) bool {
+	body := ms.Body()
+	if body.Type() != AttributeValueMAP {
+		return false
+	}
+	flattenAttributeMap("", separator, body.MapVal(), ms.Attributes(), policy)
+	ms.orig.Body = otlpcommon.AnyValue{}
+	return true
+}
+
+func flattenAttributeMap(prefix string, separator string, src AttributeMap, dest AttributeMap, policy FlattenCollisionPolicy) {
+	src.ForEach(func(k string, v AttributeValue) {
+		key := prefix + k
+		if v.Type() == AttributeValueMAP && v.MapVal().Len() > 0 {
+			flattenAttributeMap(key+separator, separator, v.MapVal(), dest, policy)
+			return
+		}
+		if policy == FlattenCollisionKeep {
+			dest.Insert(key, v)
+		} else {
+			dest.Upsert(key, v)
+		}
+	})
+}
@@ -139,3 +139,64 @@ func BenchmarkLogsFromOtlp(b *testing.B) {
 		assert.Equal(b, baseLogs.ResourceLogs().Len(), logs.ResourceLogs().Len())
 	}
 }
+
+func TestLogRecordFlattenBody(t *testing.T) {
+	newLogRecord := func() LogRecord {
+		lr := NewLogRecord()
+		lr.Attributes().InsertString("http.method", "POST")
+		lr.Attributes().InsertInt("attr", 1)
+
+		http := NewAttributeValueMap()
+		http.MapVal().InsertString("method", "GET")
+		http.MapVal().InsertInt("status_code", 200)
+		tags := NewAttributeValueArray()
+		tags.ArrayVal().Append(NewAttributeValueString("tag1"))
+		request := NewAttributeValueMap()
+		request.MapVal().Insert("headers", NewAttributeValueMap())
+		request.MapVal().Insert("tags", tags)
+		http.MapVal().Insert("request", request)
+
+		body := NewAttributeValueMap()
+		body.MapVal().Insert("http", http)
+		body.MapVal().InsertString("message", "request received")
+		body.CopyTo(lr.Body())
+		return lr
+	}
+
+	tags := NewAttributeValueArray()
+	tags.ArrayVal().Append(NewAttributeValueString("tag1"))
+	expected := NewAttributeMap().InitFromMap(map[string]AttributeValue{
+		"http.method":          NewAttributeValueString("GET"),
+		"attr":                 NewAttributeValueInt(1),
+		"http.status_code":     NewAttributeValueInt(200),
+		"http.request.headers": NewAttributeValueMap(),
+		"http.request.tags":    tags,
+		"message":              NewAttributeValueString("request received"),
+	})
+
+	lr := newLogRecord()
+	assert.True(t, lr.FlattenBody(".", FlattenCollisionOverwrite))
+	assert.Equal(t, AttributeValueNULL, lr.Body().Type())
+	assert.Equal(t, expected.Sort(), lr.Attributes().Sort())
+
+	lr = newLogRecord()
+	assert.True(t, lr.FlattenBody(".", FlattenCollisionKeep))
+	expected.UpsertString("http.method", "POST")
+	assert.Equal(t, expected.Sort(), lr.Attributes().Sort())
+
+	lr = newLogRecord()
+	assert.True(t, lr.FlattenBody("_", FlattenCollisionKeep))
+	v, ok := lr.Attributes().Get("http_request_tags")
+	assert.True(t, ok)
+	assert.True(t, tags.Equal(v))
+	v, ok = lr.Attributes().Get("http_method")
+	assert.True(t, ok)
+	assert.Equal(t, "GET", v.StringVal())
+
+	// The bodies of other types are left unchanged.
+	lr = NewLogRecord()
+	lr.Body().SetStringVal("message")
+	assert.False(t, lr.FlattenBody(".", FlattenCollisionOverwrite))
+	assert.Equal(t, "message", lr.Body().StringVal())
+	assert.Equal(t, 0, lr.Attributes().Len())
+}
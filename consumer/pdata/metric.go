@@ -15,9 +15,12 @@
 package pdata
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/internal"
 	otlpcollectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
 	otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/internal/otlpversion"
 )
 
 type AggregationTemporality int32
@@ -53,12 +56,12 @@ func MetricsFromInternalRep(wrapper internal.MetricsWrapper) Metrics {
 }
 
 // MetricsFromOtlpProtoBytes converts the OTLP Collector ExportMetricsServiceRequest
-// ProtoBuf bytes to Metrics.
+// ProtoBuf bytes to Metrics. The bytes of newer versions of OTLP are down-converted.
 //
 // Returns an invalid Metrics instance if error is not nil.
 func MetricsFromOtlpProtoBytes(data []byte) (Metrics, error) {
 	req := otlpcollectormetrics.ExportMetricsServiceRequest{}
-	if err := req.Unmarshal(data); err != nil {
+	if err := otlpversion.UnmarshalMetrics(data, &req); err != nil {
 		return Metrics{}, err
 	}
 	return Metrics{orig: &req}, nil
@@ -89,6 +92,17 @@ func (md Metrics) ResourceMetrics() ResourceMetricsSlice {
 	return newResourceMetricsSlice(&md.orig.ResourceMetrics)
 }
 
+// Equal returns true if all the resource metrics of the current Metrics and the other are equal.
+func (md Metrics) Equal(other Metrics) bool {
+	return md.ResourceMetrics().Equal(other.ResourceMetrics())
+}
+
+// Diff returns the fields of the current Metrics that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (md Metrics) Diff(other Metrics) []string {
+	return md.ResourceMetrics().diff("ResourceMetrics", other.ResourceMetrics(), nil)
+}
+
 // MetricCount calculates the total number of metrics.
 func (md Metrics) MetricCount() int {
 	metricCount := 0
@@ -145,6 +159,46 @@ func (md Metrics) MetricAndDataPointCount() (metricCount int, dataPointCount int
 	return
 }
 
+// RemoveDataPointsOutside removes the data points with a Timestamp before start or after end,
+// and returns the number of removed data points. The metrics left without data points are kept.
+func (md Metrics) RemoveDataPointsOutside(start, end Timestamp) int {
+	removed := 0
+	outside := func(ts Timestamp) bool {
+		if ts < start || ts > end {
+			removed++
+			return true
+		}
+		return false
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ms := ilms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				switch m.DataType() {
+				case MetricDataTypeIntGauge:
+					m.IntGauge().DataPoints().RemoveIf(func(dp IntDataPoint) bool { return outside(dp.Timestamp()) })
+				case MetricDataTypeDoubleGauge:
+					m.DoubleGauge().DataPoints().RemoveIf(func(dp DoubleDataPoint) bool { return outside(dp.Timestamp()) })
+				case MetricDataTypeIntSum:
+					m.IntSum().DataPoints().RemoveIf(func(dp IntDataPoint) bool { return outside(dp.Timestamp()) })
+				case MetricDataTypeDoubleSum:
+					m.DoubleSum().DataPoints().RemoveIf(func(dp DoubleDataPoint) bool { return outside(dp.Timestamp()) })
+				case MetricDataTypeIntHistogram:
+					m.IntHistogram().DataPoints().RemoveIf(func(dp IntHistogramDataPoint) bool { return outside(dp.Timestamp()) })
+				case MetricDataTypeDoubleHistogram:
+					m.DoubleHistogram().DataPoints().RemoveIf(func(dp DoubleHistogramDataPoint) bool { return outside(dp.Timestamp()) })
+				case MetricDataTypeSummary:
+					m.Summary().DataPoints().RemoveIf(func(dp SummaryDataPoint) bool { return outside(dp.Timestamp()) })
+				}
+			}
+		}
+	}
+	return removed
+}
+
 // MetricDataType specifies the type of data in a Metric.
 type MetricDataType int32
 
@@ -305,3 +359,82 @@ func copyData(src, dest *otlpmetrics.Metric) {
 		dest.Data = data
 	}
 }
+
+// diffData appends to diffs the differences between the data of the metrics,
+// only the data of the same type are compared.
+func diffData(path string, ms, other Metric, diffs []string) []string {
+	if ms.DataType() != other.DataType() {
+		return append(diffs, fmt.Sprintf("%s.DataType: %v != %v", path, ms.DataType(), other.DataType()))
+	}
+	switch ms.DataType() {
+	case MetricDataTypeIntGauge:
+		return ms.IntGauge().diff(path+".IntGauge", other.IntGauge(), diffs)
+	case MetricDataTypeDoubleGauge:
+		return ms.DoubleGauge().diff(path+".DoubleGauge", other.DoubleGauge(), diffs)
+	case MetricDataTypeIntSum:
+		return ms.IntSum().diff(path+".IntSum", other.IntSum(), diffs)
+	case MetricDataTypeDoubleSum:
+		return ms.DoubleSum().diff(path+".DoubleSum", other.DoubleSum(), diffs)
+	case MetricDataTypeIntHistogram:
+		return ms.IntHistogram().diff(path+".IntHistogram", other.IntHistogram(), diffs)
+	case MetricDataTypeDoubleHistogram:
+		return ms.DoubleHistogram().diff(path+".DoubleHistogram", other.DoubleHistogram(), diffs)
+	case MetricDataTypeSummary:
+		return ms.Summary().diff(path+".Summary", other.Summary(), diffs)
+	}
+	return diffs
+}
+
+func float64SlicesEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func uint64SlicesEqual(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// timestampsValid returns true if the start time is unset or not after the timestamp.
+func timestampsValid(start, ts Timestamp) bool {
+	return start == 0 || start <= ts
+}
+
+// HasValidTimestamps returns true if the StartTime is not set or is not after the Timestamp.
+func (ms IntDataPoint) HasValidTimestamps() bool {
+	return timestampsValid(ms.StartTime(), ms.Timestamp())
+}
+
+// HasValidTimestamps returns true if the StartTime is not set or is not after the Timestamp.
+func (ms DoubleDataPoint) HasValidTimestamps() bool {
+	return timestampsValid(ms.StartTime(), ms.Timestamp())
+}
+
+// HasValidTimestamps returns true if the StartTime is not set or is not after the Timestamp.
+func (ms IntHistogramDataPoint) HasValidTimestamps() bool {
+	return timestampsValid(ms.StartTime(), ms.Timestamp())
+}
+
+// HasValidTimestamps returns true if the StartTime is not set or is not after the Timestamp.
+func (ms DoubleHistogramDataPoint) HasValidTimestamps() bool {
+	return timestampsValid(ms.StartTime(), ms.Timestamp())
+}
+
+// HasValidTimestamps returns true if the StartTime is not set or is not after the Timestamp.
+func (ms SummaryDataPoint) HasValidTimestamps() bool {
+	return timestampsValid(ms.StartTime(), ms.Timestamp())
+}
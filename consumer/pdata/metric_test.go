@@ -1037,3 +1037,122 @@ func generateMetricsEmptyDataPoints() Metrics {
 		},
 	}))
 }
+
+func TestMetricsDiff(t *testing.T) {
+	md := NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(2)
+	metrics.At(0).SetDataType(MetricDataTypeIntGauge)
+	metrics.At(1).SetDataType(MetricDataTypeDoubleHistogram)
+	metrics.At(1).DoubleHistogram().DataPoints().Resize(1)
+	metrics.At(1).DoubleHistogram().DataPoints().At(0).SetExplicitBounds([]float64{1, 2})
+
+	other := md.Clone()
+	assert.True(t, md.Equal(other))
+	assert.Empty(t, md.Diff(other))
+
+	otherMetrics := other.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	otherMetrics.At(0).SetDataType(MetricDataTypeIntSum)
+	otherMetrics.At(1).DoubleHistogram().DataPoints().At(0).SetExplicitBounds([]float64{1, 3})
+	otherMetrics.At(1).DoubleHistogram().DataPoints().At(0).LabelsMap().Insert("k", "v")
+
+	assert.False(t, md.Equal(other))
+	assert.Equal(t, []string{
+		`ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[0].DataType: IntGauge != IntSum`,
+		`ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[1].DoubleHistogram.DataPoints[0].LabelsMap["k"]: missing in the map`,
+		`ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[1].DoubleHistogram.DataPoints[0].ExplicitBounds: [1 2] != [1 3]`,
+	}, md.Diff(other))
+}
+
+func TestMetricsRemoveDataPointsOutside(t *testing.T) {
+	md := NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for _, dataType := range []MetricDataType{
+		MetricDataTypeIntGauge,
+		MetricDataTypeDoubleGauge,
+		MetricDataTypeIntSum,
+		MetricDataTypeDoubleSum,
+		MetricDataTypeIntHistogram,
+		MetricDataTypeDoubleHistogram,
+		MetricDataTypeSummary,
+	} {
+		m := ms.AppendEmpty()
+		m.SetDataType(dataType)
+		for _, ts := range []Timestamp{100, 200, 300, 400} {
+			switch dataType {
+			case MetricDataTypeIntGauge:
+				m.IntGauge().DataPoints().AppendEmpty().SetTimestamp(ts)
+			case MetricDataTypeDoubleGauge:
+				m.DoubleGauge().DataPoints().AppendEmpty().SetTimestamp(ts)
+			case MetricDataTypeIntSum:
+				m.IntSum().DataPoints().AppendEmpty().SetTimestamp(ts)
+			case MetricDataTypeDoubleSum:
+				m.DoubleSum().DataPoints().AppendEmpty().SetTimestamp(ts)
+			case MetricDataTypeIntHistogram:
+				m.IntHistogram().DataPoints().AppendEmpty().SetTimestamp(ts)
+			case MetricDataTypeDoubleHistogram:
+				m.DoubleHistogram().DataPoints().AppendEmpty().SetTimestamp(ts)
+			case MetricDataTypeSummary:
+				m.Summary().DataPoints().AppendEmpty().SetTimestamp(ts)
+			}
+		}
+	}
+
+	assert.Equal(t, 14, md.RemoveDataPointsOutside(200, 300))
+	metricCount, dataPointCount := md.MetricAndDataPointCount()
+	assert.Equal(t, 7, metricCount)
+	assert.Equal(t, 14, dataPointCount)
+	dps := ms.At(0).IntGauge().DataPoints()
+	assert.Equal(t, Timestamp(200), dps.At(0).Timestamp())
+	assert.Equal(t, Timestamp(300), dps.At(1).Timestamp())
+
+	// The metrics without data points in the window are kept.
+	assert.Equal(t, 14, md.RemoveDataPointsOutside(500, 600))
+	metricCount, dataPointCount = md.MetricAndDataPointCount()
+	assert.Equal(t, 7, metricCount)
+	assert.Equal(t, 0, dataPointCount)
+}
+
+func TestDataPointHasValidTimestamps(t *testing.T) {
+	tests := []struct {
+		name      string
+		startTime Timestamp
+		timestamp Timestamp
+		valid     bool
+	}{
+		{name: "NoStartTime", startTime: 0, timestamp: 100, valid: true},
+		{name: "StartTimeBefore", startTime: 50, timestamp: 100, valid: true},
+		{name: "SameTime", startTime: 100, timestamp: 100, valid: true},
+		{name: "StartTimeAfter", startTime: 150, timestamp: 100, valid: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			intDP := NewIntDataPoint()
+			intDP.SetStartTime(test.startTime)
+			intDP.SetTimestamp(test.timestamp)
+			assert.Equal(t, test.valid, intDP.HasValidTimestamps())
+
+			doubleDP := NewDoubleDataPoint()
+			doubleDP.SetStartTime(test.startTime)
+			doubleDP.SetTimestamp(test.timestamp)
+			assert.Equal(t, test.valid, doubleDP.HasValidTimestamps())
+
+			intHistogramDP := NewIntHistogramDataPoint()
+			intHistogramDP.SetStartTime(test.startTime)
+			intHistogramDP.SetTimestamp(test.timestamp)
+			assert.Equal(t, test.valid, intHistogramDP.HasValidTimestamps())
+
+			doubleHistogramDP := NewDoubleHistogramDataPoint()
+			doubleHistogramDP.SetStartTime(test.startTime)
+			doubleHistogramDP.SetTimestamp(test.timestamp)
+			assert.Equal(t, test.valid, doubleHistogramDP.HasValidTimestamps())
+
+			summaryDP := NewSummaryDataPoint()
+			summaryDP.SetStartTime(test.startTime)
+			summaryDP.SetTimestamp(test.timestamp)
+			assert.Equal(t, test.valid, summaryDP.HasValidTimestamps())
+		})
+	}
+}
@@ -71,6 +71,17 @@ func (td Traces) Clone() Traces {
 	return cloneTd
 }
 
+// Equal returns true if all the resource spans of the current Traces and the other are equal.
+func (td Traces) Equal(other Traces) bool {
+	return td.ResourceSpans().Equal(other.ResourceSpans())
+}
+
+// Diff returns the fields of the current Traces that are different in the other,
+// as "<path>: <value> != <other value>". Returns an empty slice if they are equal.
+func (td Traces) Diff(other Traces) []string {
+	return td.ResourceSpans().diff("ResourceSpans", other.ResourceSpans(), nil)
+}
+
 // SpanCount calculates the total number of spans.
 func (td Traces) SpanCount() int {
 	spanCount := 0
@@ -229,3 +229,34 @@ func BenchmarkTracesFromOtlp(b *testing.B) {
 		assert.Equal(b, baseTraces.ResourceSpans().Len(), traces.ResourceSpans().Len())
 	}
 }
+
+func TestTracesDiff(t *testing.T) {
+	td := NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().Attributes().InsertString("service.name", "svc")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(2)
+	spans.At(0).SetName("span0")
+	spans.At(1).SetName("span1")
+	spans.At(1).SetTraceID(NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+
+	other := td.Clone()
+	assert.True(t, td.Equal(other))
+	assert.Empty(t, td.Diff(other))
+
+	other.ResourceSpans().At(0).Resource().Attributes().UpsertString("service.name", "other")
+	otherSpans := other.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	otherSpans.At(1).SetName("other")
+	otherSpans.At(1).SetTraceID(NewTraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}))
+	otherSpans.At(1).Attributes().InsertInt("count", 1)
+
+	assert.False(t, td.Equal(other))
+	assert.Equal(t, []string{
+		`ResourceSpans[0].Resource.Attributes["service.name"]: "svc" != "other"`,
+		`ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[1].TraceID: 0102030405060708090a0b0c0d0e0f10 != 100f0e0d0c0b0a090807060504030201`,
+		`ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[1].Name: span1 != other`,
+		`ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[1].Attributes["count"]: missing in the map`,
+	}, td.Diff(other))
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The headers carrying the trace context, see https://www.w3.org/TR/trace-context/.
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+// TraceFlagsSampled is the flag of the traceparent header recording that the caller may have sampled the trace.
+const TraceFlagsSampled byte = 0x01
+
+const (
+	traceParentVersion   = "00"
+	traceParentLength    = 55
+	traceStateMaxMembers = 32
+)
+
+var (
+	errInvalidTraceParent = errors.New("invalid traceparent")
+	errInvalidTraceState  = errors.New("invalid tracestate")
+
+	traceStateKeyRegexp   = regexp.MustCompile(`^(?:[a-z][a-z0-9_\-*/]{0,255}|[a-z0-9][a-z0-9_\-*/]{0,240}@[a-z][a-z0-9_\-*/]{0,13})$`)
+	traceStateValueRegexp = regexp.MustCompile(`^[\x20-\x2b\x2d-\x3c\x3e-\x7e]{0,255}[\x21-\x2b\x2d-\x3c\x3e-\x7e]$`)
+)
+
+// ParseTraceParent parses the value of a traceparent header into its TraceID, SpanID and trace flags.
+// Headers of a later version than 00 are parsed as version 00, ignoring the fields they add.
+func ParseTraceParent(header string) (TraceID, SpanID, byte, error) {
+	if len(header) < traceParentLength || !isLowerHex(header[:2]) || header[:2] == "ff" ||
+		header[2] != '-' || header[35] != '-' || header[52] != '-' {
+		return InvalidTraceID(), InvalidSpanID(), 0, fmt.Errorf("%w %q", errInvalidTraceParent, header)
+	}
+	if len(header) > traceParentLength && (header[:2] == traceParentVersion || header[traceParentLength] != '-') {
+		return InvalidTraceID(), InvalidSpanID(), 0, fmt.Errorf("%w %q", errInvalidTraceParent, header)
+	}
+
+	var tid [16]byte
+	var sid [8]byte
+	var flags [1]byte
+	if !decodeLowerHex(tid[:], header[3:35]) || !decodeLowerHex(sid[:], header[36:52]) ||
+		!decodeLowerHex(flags[:], header[53:55]) {
+		return InvalidTraceID(), InvalidSpanID(), 0, fmt.Errorf("%w %q", errInvalidTraceParent, header)
+	}
+	traceID, spanID := NewTraceID(tid), NewSpanID(sid)
+	if traceID.IsEmpty() || spanID.IsEmpty() {
+		return InvalidTraceID(), InvalidSpanID(), 0, fmt.Errorf("%w %q: the trace and span IDs must not be zeros", errInvalidTraceParent, header)
+	}
+	return traceID, spanID, flags[0], nil
+}
+
+// TraceParent renders the TraceID, SpanID and trace flags as the value of a version 00 traceparent header.
+func TraceParent(traceID TraceID, spanID SpanID, flags byte) string {
+	return traceParentVersion + "-" + traceID.HexString() + "-" + spanID.HexString() + "-" + hex.EncodeToString([]byte{flags})
+}
+
+// TraceStateMember is a key/value list member of a TraceState.
+type TraceStateMember struct {
+	Key   string
+	Value string
+}
+
+// NewTraceState renders the members, in order, as a TraceState.
+// Returns an error if a key or a value is invalid, if a key is duplicated or if there are more than 32 members.
+func NewTraceState(members ...TraceStateMember) (TraceState, error) {
+	if len(members) > traceStateMaxMembers {
+		return TraceStateEmpty, fmt.Errorf("%w: %d members, at most %d are allowed", errInvalidTraceState, len(members), traceStateMaxMembers)
+	}
+	keys := make(map[string]struct{}, len(members))
+	var sb strings.Builder
+	for i, member := range members {
+		if err := validateTraceStateMember(member); err != nil {
+			return TraceStateEmpty, err
+		}
+		if _, ok := keys[member.Key]; ok {
+			return TraceStateEmpty, fmt.Errorf("%w: duplicated key %q", errInvalidTraceState, member.Key)
+		}
+		keys[member.Key] = struct{}{}
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(member.Key)
+		sb.WriteString("=")
+		sb.WriteString(member.Value)
+	}
+	return TraceState(sb.String()), nil
+}
+
+// Members parses the TraceState into its list members, in order. Empty list members are skipped.
+// Returns an error if a member is invalid, if a key is duplicated or if there are more than 32 members.
+func (ts TraceState) Members() ([]TraceStateMember, error) {
+	if strings.TrimSpace(string(ts)) == "" {
+		return nil, nil
+	}
+	var members []TraceStateMember
+	keys := make(map[string]struct{})
+	for _, entry := range strings.Split(string(ts), ",") {
+		entry = strings.Trim(entry, " \t")
+		if entry == "" {
+			continue
+		}
+		eq := strings.IndexByte(entry, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("%w: list member %q is not a key=value pair", errInvalidTraceState, entry)
+		}
+		member := TraceStateMember{Key: entry[:eq], Value: entry[eq+1:]}
+		if err := validateTraceStateMember(member); err != nil {
+			return nil, err
+		}
+		if _, ok := keys[member.Key]; ok {
+			return nil, fmt.Errorf("%w: duplicated key %q", errInvalidTraceState, member.Key)
+		}
+		keys[member.Key] = struct{}{}
+		members = append(members, member)
+	}
+	if len(members) > traceStateMaxMembers {
+		return nil, fmt.Errorf("%w: %d members, at most %d are allowed", errInvalidTraceState, len(members), traceStateMaxMembers)
+	}
+	return members, nil
+}
+
+// Get returns the value of the key in the TraceState, and whether the TraceState is valid and has the key.
+func (ts TraceState) Get(key string) (string, bool) {
+	members, err := ts.Members()
+	if err != nil {
+		return "", false
+	}
+	for _, member := range members {
+		if member.Key == key {
+			return member.Value, true
+		}
+	}
+	return "", false
+}
+
+// Upsert returns the TraceState with the key set to the value and moved to the beginning of the list, as required
+// when a vendor modifies its member. The last member is dropped if the list would exceed 32 members.
+func (ts TraceState) Upsert(key, value string) (TraceState, error) {
+	members, err := ts.Members()
+	if err != nil {
+		return ts, err
+	}
+	updated := make([]TraceStateMember, 0, len(members)+1)
+	updated = append(updated, TraceStateMember{Key: key, Value: value})
+	for _, member := range members {
+		if member.Key != key {
+			updated = append(updated, member)
+		}
+	}
+	if len(updated) > traceStateMaxMembers {
+		updated = updated[:traceStateMaxMembers]
+	}
+	return NewTraceState(updated...)
+}
+
+// Delete returns the TraceState without the key.
+func (ts TraceState) Delete(key string) (TraceState, error) {
+	members, err := ts.Members()
+	if err != nil {
+		return ts, err
+	}
+	kept := members[:0]
+	for _, member := range members {
+		if member.Key != key {
+			kept = append(kept, member)
+		}
+	}
+	return NewTraceState(kept...)
+}
+
+func validateTraceStateMember(member TraceStateMember) error {
+	if !traceStateKeyRegexp.MatchString(member.Key) {
+		return fmt.Errorf("%w: invalid key %q", errInvalidTraceState, member.Key)
+	}
+	if !traceStateValueRegexp.MatchString(member.Value) {
+		return fmt.Errorf("%w: invalid value %q of the key %q", errInvalidTraceState, member.Value, member.Key)
+	}
+	return nil
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < '0' || s[i] > '9') && (s[i] < 'a' || s[i] > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeLowerHex(dst []byte, s string) bool {
+	if !isLowerHex(s) {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdata
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	traceID, spanID, flags, err := ParseTraceParent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	require.NoError(t, err)
+	assert.Equal(t, NewTraceID([16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c}), traceID)
+	assert.Equal(t, NewSpanID([8]byte{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31}), spanID)
+	assert.Equal(t, TraceFlagsSampled, flags)
+
+	// A later version may add fields.
+	_, _, flags, err = ParseTraceParent("01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00-what-comes-next")
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), flags)
+}
+
+func TestParseTraceParent_Invalid(t *testing.T) {
+	headers := []string{
+		"",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-00",
+		"ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01x",
+		"00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b716920333g-01",
+		"00_0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+	}
+	for _, header := range headers {
+		t.Run(header, func(t *testing.T) {
+			traceID, spanID, _, err := ParseTraceParent(header)
+			assert.Error(t, err)
+			assert.True(t, traceID.IsEmpty())
+			assert.True(t, spanID.IsEmpty())
+		})
+	}
+}
+
+func TestTraceParent(t *testing.T) {
+	header := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	traceID, spanID, flags, err := ParseTraceParent(header)
+	require.NoError(t, err)
+	assert.Equal(t, header, TraceParent(traceID, spanID, flags))
+}
+
+func TestTraceStateMembers(t *testing.T) {
+	members, err := TraceState(" rojo=00f067aa0ba902b7 ,, congo=t61rcWkgMzE,tenant@vendor=a b\t").Members()
+	require.NoError(t, err)
+	assert.Equal(t, []TraceStateMember{
+		{Key: "rojo", Value: "00f067aa0ba902b7"},
+		{Key: "congo", Value: "t61rcWkgMzE"},
+		{Key: "tenant@vendor", Value: "a b"},
+	}, members)
+
+	members, err = TraceStateEmpty.Members()
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func TestTraceStateMembers_Invalid(t *testing.T) {
+	var tooMany []string
+	for i := 0; i < 33; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("k%d=v", i))
+	}
+	traceStates := []TraceState{
+		"rojo",
+		"Rojo=1",
+		"1rojo=1",
+		"rojo=",
+		"rojo=a=b",
+		"rojo=a\x01",
+		"rojo=1,rojo=2",
+		"tenant@Vendor=1",
+		TraceState(strings.Join(tooMany, ",")),
+	}
+	for _, ts := range traceStates {
+		t.Run(string(ts), func(t *testing.T) {
+			_, err := ts.Members()
+			assert.Error(t, err)
+			_, ok := ts.Get("rojo")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestNewTraceState(t *testing.T) {
+	ts, err := NewTraceState(TraceStateMember{Key: "rojo", Value: "1"}, TraceStateMember{Key: "congo", Value: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, TraceState("rojo=1,congo=2"), ts)
+
+	_, err = NewTraceState(TraceStateMember{Key: "rojo", Value: "1"}, TraceStateMember{Key: "rojo", Value: "2"})
+	assert.Error(t, err)
+	_, err = NewTraceState(TraceStateMember{Key: "rojo", Value: "a,b"})
+	assert.Error(t, err)
+}
+
+func TestTraceStateGetUpsertDelete(t *testing.T) {
+	ts := TraceState("rojo=1,congo=2")
+	value, ok := ts.Get("congo")
+	assert.True(t, ok)
+	assert.Equal(t, "2", value)
+	_, ok = ts.Get("verde")
+	assert.False(t, ok)
+
+	ts, err := ts.Upsert("congo", "3")
+	require.NoError(t, err)
+	assert.Equal(t, TraceState("congo=3,rojo=1"), ts)
+
+	ts, err = ts.Upsert("verde", "4")
+	require.NoError(t, err)
+	assert.Equal(t, TraceState("verde=4,congo=3,rojo=1"), ts)
+
+	ts, err = ts.Delete("congo")
+	require.NoError(t, err)
+	assert.Equal(t, TraceState("verde=4,rojo=1"), ts)
+
+	_, err = ts.Upsert("Verde", "5")
+	assert.Error(t, err)
+	_, err = TraceState("rojo").Upsert("verde", "5")
+	assert.Error(t, err)
+}
+
+func TestTraceStateUpsert_DropsLastMember(t *testing.T) {
+	var members []TraceStateMember
+	for i := 0; i < 32; i++ {
+		members = append(members, TraceStateMember{Key: fmt.Sprintf("k%d", i), Value: "v"})
+	}
+	ts, err := NewTraceState(members...)
+	require.NoError(t, err)
+
+	ts, err = ts.Upsert("verde", "1")
+	require.NoError(t, err)
+	updated, err := ts.Members()
+	require.NoError(t, err)
+	assert.Len(t, updated, 32)
+	assert.Equal(t, TraceStateMember{Key: "verde", Value: "1"}, updated[0])
+	assert.Equal(t, TraceStateMember{Key: "k30", Value: "v"}, updated[31])
+}
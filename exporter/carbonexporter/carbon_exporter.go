@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package carbonexporter implements an exporter that sends metrics to Carbon, the Graphite back-end, using the
+// plaintext protocol.
+package carbonexporter
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// carbonExporter sends the metrics over a single connection, opened on the first push and opened again after a
+// failed write.
+type carbonExporter struct {
+	endpoint  string
+	tlsConfig *tls.Config
+	formatter *plaintextFormatter
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newCarbonExporter(cfg *Config) (*carbonExporter, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLS != nil {
+		var err error
+		if tlsConfig, err = cfg.TLS.LoadTLSConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return &carbonExporter{
+		endpoint:  cfg.Endpoint,
+		tlsConfig: tlsConfig,
+		formatter: &plaintextFormatter{
+			format:       cfg.Format,
+			sanitization: cfg.LabelSanitization,
+		},
+	}, nil
+}
+
+func (ce *carbonExporter) pushMetricsData(ctx context.Context, md pdata.Metrics) error {
+	lines := ce.formatter.formatMetrics(md)
+	if lines == "" {
+		return nil
+	}
+
+	ce.mu.Lock()
+	defer ce.mu.Unlock()
+
+	if ce.conn == nil {
+		conn, err := ce.dial(ctx)
+		if err != nil {
+			return err
+		}
+		ce.conn = conn
+	}
+
+	// The zero deadline of a context without timeout clears the deadline of the previous push.
+	deadline, _ := ctx.Deadline()
+	if err := ce.conn.SetWriteDeadline(deadline); err != nil {
+		return ce.closeOnError(err)
+	}
+	if _, err := io.WriteString(ce.conn, lines); err != nil {
+		return ce.closeOnError(err)
+	}
+	return nil
+}
+
+func (ce *carbonExporter) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+	if ce.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, "tcp", ce.endpoint, ce.tlsConfig)
+	}
+	return dialer.DialContext(ctx, "tcp", ce.endpoint)
+}
+
+// closeOnError closes the connection after a failed write, a partial line may have been written, and returns the
+// error so that the metrics are sent again on a new connection.
+func (ce *carbonExporter) closeOnError(err error) error {
+	_ = ce.conn.Close()
+	ce.conn = nil
+	return err
+}
+
+// Shutdown closes the connection.
+func (ce *carbonExporter) Shutdown(context.Context) error {
+	ce.mu.Lock()
+	defer ce.mu.Unlock()
+	if ce.conn == nil {
+		return nil
+	}
+	err := ce.conn.Close()
+	ce.conn = nil
+	return err
+}
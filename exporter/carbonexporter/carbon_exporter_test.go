@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// receiveLines accepts connections on the listener and sends the received lines to the returned channel.
+func receiveLines(ln net.Listener) <-chan string {
+	lines := make(chan string, 100)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}()
+		}
+	}()
+	return lines
+}
+
+func selfSignedCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestPushMetricsData(t *testing.T) {
+	plaintextLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer plaintextLn.Close()
+
+	tlsLn, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{selfSignedCertificate(t)}})
+	require.NoError(t, err)
+	defer tlsLn.Close()
+
+	tests := []struct {
+		name string
+		ln   net.Listener
+		tls  *configtls.TLSClientSetting
+	}{
+		{
+			name: "plaintext",
+			ln:   plaintextLn,
+		},
+		{
+			name: "tls",
+			ln:   tlsLn,
+			tls:  &configtls.TLSClientSetting{InsecureSkipVerify: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := receiveLines(tt.ln)
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = tt.ln.Addr().String()
+			cfg.TLS = tt.tls
+			exp, err := newCarbonExporter(cfg)
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, exp.Shutdown(context.Background()))
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			require.NoError(t, exp.pushMetricsData(ctx, testMetrics()))
+
+			select {
+			case line := <-lines:
+				assert.Equal(t, "cpu_usage;cpu_=0;host.name=web_1 0.5 1600000000", line)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no line received")
+			}
+		})
+	}
+}
+
+func TestPushMetricsDataConnectionFailure(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	exp, err := newCarbonExporter(cfg)
+	require.NoError(t, err)
+	assert.Error(t, exp.pushMetricsData(context.Background(), testMetrics()))
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
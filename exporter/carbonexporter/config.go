@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// formatTags sends the labels as graphite tags: metric;tag=value.
+	formatTags = "tags"
+	// formatPath appends the labels, sorted by name, to the metric path: metric.tag.value.
+	formatPath = "path"
+
+	// sanitizationReplace replaces the characters not allowed by graphite in label names with underscores.
+	sanitizationReplace = "replace"
+	// sanitizationStrict replaces the characters other than letters, digits and underscores in label names with
+	// underscores.
+	sanitizationStrict = "strict"
+	// sanitizationDrop drops the labels whose names contain characters not allowed by graphite.
+	sanitizationDrop = "drop"
+)
+
+// Config defines configuration for the Carbon exporter.
+type Config struct {
+	configmodels.ExporterSettings  `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the host:port of the Carbon plaintext protocol receiver.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// TLS enables TLS on the connection to the endpoint, the plaintext protocol is sent over plain TCP when not set.
+	TLS *configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Format defines how the labels are sent: "tags" (default) or "path".
+	Format string `mapstructure:"format"`
+
+	// LabelSanitization defines how the label names are sanitized: "replace" (default), "strict" or "drop".
+	LabelSanitization string `mapstructure:"label_sanitization"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the endpoint is set, that the format and the sanitization policy are known, and that the
+// queue settings are valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("exporter config requires a non-empty 'endpoint'")
+	}
+	switch cfg.Format {
+	case formatTags, formatPath:
+	default:
+		return fmt.Errorf("unknown format %q, must be %q or %q", cfg.Format, formatTags, formatPath)
+	}
+	switch cfg.LabelSanitization {
+	case sanitizationReplace, sanitizationStrict, sanitizationDrop:
+	default:
+		return fmt.Errorf("unknown label_sanitization %q, must be %q, %q or %q",
+			cfg.LabelSanitization, sanitizationReplace, sanitizationStrict, sanitizationDrop)
+	}
+	return cfg.QueueSettings.Validate()
+}
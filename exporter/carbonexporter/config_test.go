@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters["carbon"]
+	assert.Equal(t, e0, factory.CreateDefaultConfig())
+
+	e1 := cfg.Exporters["carbon/allsettings"]
+	assert.Equal(t, e1,
+		&Config{
+			ExporterSettings: configmodels.ExporterSettings{
+				NameVal: "carbon/allsettings",
+				TypeVal: "carbon",
+			},
+			TimeoutSettings: exporterhelper.TimeoutSettings{
+				Timeout: 10 * time.Second,
+			},
+			QueueSettings: exporterhelper.QueueSettings{
+				Enabled:       true,
+				NumConsumers:  2,
+				QueueSize:     10,
+				MinConsumers:  1,
+				MaxConsumers:  50,
+				HighWatermark: 0.8,
+				LowWatermark:  0.5,
+			},
+			RetrySettings: exporterhelper.RetrySettings{
+				Enabled:         true,
+				InitialInterval: 10 * time.Second,
+				MaxInterval:     1 * time.Minute,
+				MaxElapsedTime:  10 * time.Minute,
+			},
+			Endpoint: "carbon.example.com:2004",
+			TLS: &configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile: "/var/lib/mycert.pem",
+				},
+				ServerName: "carbon.example.com",
+			},
+			Format:            formatPath,
+			LabelSanitization: sanitizationStrict,
+		})
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(cfg *Config)
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			update: func(*Config) {},
+		},
+		{
+			name:    "empty_endpoint",
+			update:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: true,
+		},
+		{
+			name:    "unknown_format",
+			update:  func(cfg *Config) { cfg.Format = "json" },
+			wantErr: true,
+		},
+		{
+			name:    "unknown_sanitization",
+			update:  func(cfg *Config) { cfg.LabelSanitization = "none" },
+			wantErr: true,
+		},
+		{
+			name:    "invalid_watermarks",
+			update:  func(cfg *Config) { cfg.LowWatermark = 0.9 },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.update(cfg)
+			if tt.wantErr {
+				assert.Error(t, cfg.Validate())
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "carbon"
+
+	defaultEndpoint = "localhost:2003"
+)
+
+// NewFactory creates a factory for Carbon exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithMetrics(createMetricsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		TimeoutSettings:   exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:     exporterhelper.DefaultRetrySettings(),
+		QueueSettings:     exporterhelper.DefaultQueueSettings(),
+		Endpoint:          defaultEndpoint,
+		Format:            formatTags,
+		LabelSanitization: sanitizationReplace,
+	}
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	cCfg := cfg.(*Config)
+	if err := cCfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	exp, err := newCarbonExporter(cCfg)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		params.Logger,
+		exp.pushMetricsData,
+		exporterhelper.WithTimeout(cCfg.TimeoutSettings),
+		exporterhelper.WithQueue(cCfg.QueueSettings),
+		exporterhelper.WithRetry(cCfg.RetrySettings),
+		exporterhelper.WithShutdown(exp.Shutdown))
+}
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	// Suffixes of the paths of the count and sum of the histograms and summaries.
+	countSuffix = ".count"
+	sumSuffix   = ".sum"
+	// bucketSuffix is the suffix of the path of the bucket counts of histograms, upperBoundLabel holding the upper
+	// bound of the bucket.
+	bucketSuffix    = ".bucket"
+	upperBoundLabel = "upper_bound"
+	// quantileSuffix is the suffix of the path of the quantiles of summaries, quantileLabel holding the quantile.
+	quantileSuffix = ".quantile"
+	quantileLabel  = "quantile"
+)
+
+type label struct {
+	name  string
+	value string
+}
+
+// plaintextFormatter converts metrics to the lines of the Carbon plaintext protocol: "<path> <value> <timestamp>".
+type plaintextFormatter struct {
+	format       string
+	sanitization string
+}
+
+// formatMetrics returns the lines of the metrics, each terminated by a new line.
+func (f *plaintextFormatter) formatMetrics(md pdata.Metrics) string {
+	var sb strings.Builder
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				f.formatMetric(&sb, metrics.At(k))
+			}
+		}
+	}
+	return sb.String()
+}
+
+func (f *plaintextFormatter) formatMetric(sb *strings.Builder, metric pdata.Metric) {
+	name := sanitizeMetricName(metric.Name())
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		f.formatIntDataPoints(sb, name, metric.IntGauge().DataPoints())
+	case pdata.MetricDataTypeIntSum:
+		f.formatIntDataPoints(sb, name, metric.IntSum().DataPoints())
+	case pdata.MetricDataTypeDoubleGauge:
+		f.formatDoubleDataPoints(sb, name, metric.DoubleGauge().DataPoints())
+	case pdata.MetricDataTypeDoubleSum:
+		f.formatDoubleDataPoints(sb, name, metric.DoubleSum().DataPoints())
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			labels, ts := f.labels(dp.LabelsMap()), formatTimestamp(dp.Timestamp())
+			f.writeLine(sb, name+countSuffix, labels, strconv.FormatUint(dp.Count(), 10), ts)
+			f.writeLine(sb, name+sumSuffix, labels, strconv.FormatInt(dp.Sum(), 10), ts)
+			f.formatBuckets(sb, name, labels, dp.ExplicitBounds(), dp.BucketCounts(), ts)
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		dps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			labels, ts := f.labels(dp.LabelsMap()), formatTimestamp(dp.Timestamp())
+			f.writeLine(sb, name+countSuffix, labels, strconv.FormatUint(dp.Count(), 10), ts)
+			f.writeLine(sb, name+sumSuffix, labels, formatFloat(dp.Sum()), ts)
+			f.formatBuckets(sb, name, labels, dp.ExplicitBounds(), dp.BucketCounts(), ts)
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			labels, ts := f.labels(dp.LabelsMap()), formatTimestamp(dp.Timestamp())
+			f.writeLine(sb, name+countSuffix, labels, strconv.FormatUint(dp.Count(), 10), ts)
+			f.writeLine(sb, name+sumSuffix, labels, formatFloat(dp.Sum()), ts)
+			quantiles := dp.QuantileValues()
+			for j := 0; j < quantiles.Len(); j++ {
+				q := quantiles.At(j)
+				qLabels := append(labels[:len(labels):len(labels)], label{name: quantileLabel, value: formatFloat(q.Quantile())})
+				f.writeLine(sb, name+quantileSuffix, qLabels, formatFloat(q.Value()), ts)
+			}
+		}
+	}
+}
+
+func (f *plaintextFormatter) formatIntDataPoints(sb *strings.Builder, name string, dps pdata.IntDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		f.writeLine(sb, name, f.labels(dp.LabelsMap()), strconv.FormatInt(dp.Value(), 10), formatTimestamp(dp.Timestamp()))
+	}
+}
+
+func (f *plaintextFormatter) formatDoubleDataPoints(sb *strings.Builder, name string, dps pdata.DoubleDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		f.writeLine(sb, name, f.labels(dp.LabelsMap()), formatFloat(dp.Value()), formatTimestamp(dp.Timestamp()))
+	}
+}
+
+// formatBuckets writes the count of each bucket, the upper bound of the last bucket being +Inf.
+func (f *plaintextFormatter) formatBuckets(sb *strings.Builder, name string, labels []label, bounds []float64, counts []uint64, ts string) {
+	for i, count := range counts {
+		upperBound := math.Inf(1)
+		if i < len(bounds) {
+			upperBound = bounds[i]
+		}
+		bLabels := append(labels[:len(labels):len(labels)], label{name: upperBoundLabel, value: formatFloat(upperBound)})
+		f.writeLine(sb, name+bucketSuffix, bLabels, strconv.FormatUint(count, 10), ts)
+	}
+}
+
+// labels returns the labels of the data point sorted by name, with their names sanitized according to the policy.
+func (f *plaintextFormatter) labels(lm pdata.StringMap) []label {
+	labels := make([]label, 0, lm.Len())
+	lm.ForEach(func(k string, v string) {
+		if name, ok := f.sanitizeLabelName(k); ok && v != "" {
+			labels = append(labels, label{name: name, value: v})
+		}
+	})
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].name < labels[j].name
+	})
+	return labels
+}
+
+func (f *plaintextFormatter) writeLine(sb *strings.Builder, name string, labels []label, value string, ts string) {
+	sb.WriteString(name)
+	for _, l := range labels {
+		if f.format == formatPath {
+			sb.WriteString("." + l.name + "." + sanitizePathValue(l.value))
+		} else {
+			sb.WriteString(";" + l.name + "=" + sanitizeTagValue(l.value))
+		}
+	}
+	sb.WriteString(" " + value + " " + ts + "\n")
+}
+
+// sanitizeLabelName returns the label name sanitized according to the policy, the second return value is false when
+// the label must be dropped.
+func (f *plaintextFormatter) sanitizeLabelName(name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	switch f.sanitization {
+	case sanitizationStrict:
+		return strings.Map(func(r rune) rune {
+			if r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))) {
+				return r
+			}
+			return '_'
+		}, name), true
+	case sanitizationDrop:
+		return name, strings.IndexFunc(name, f.isReservedInName) == -1
+	default:
+		return strings.Map(func(r rune) rune {
+			if f.isReservedInName(r) {
+				return '_'
+			}
+			return r
+		}, name), true
+	}
+}
+
+// isReservedInName returns whether the character is not allowed in a label name: the separators of the tags and
+// white spaces, and the separator of the path components in the path format.
+func (f *plaintextFormatter) isReservedInName(r rune) bool {
+	return isReservedInTag(r) || (f.format == formatPath && r == '.')
+}
+
+func isReservedInTag(r rune) bool {
+	return r == ';' || r == '!' || r == '^' || r == '=' || unicode.IsSpace(r)
+}
+
+// sanitizeMetricName replaces the characters not allowed in a metric path with underscores.
+func sanitizeMetricName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if isReservedInTag(r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// sanitizeTagValue replaces the separator of the tags and white spaces with underscores, tag values cannot start
+// with a tilde either.
+func sanitizeTagValue(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r == ';' || unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, value)
+	if strings.HasPrefix(value, "~") {
+		value = "_" + value[1:]
+	}
+	return value
+}
+
+// sanitizePathValue replaces the characters not allowed in a path component with underscores.
+func sanitizePathValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || isReservedInTag(r) {
+			return '_'
+		}
+		return r
+	}, value)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// formatTimestamp returns the timestamp in seconds, the current time is used when it is not set.
+func formatTimestamp(ts pdata.Timestamp) string {
+	if ts == 0 {
+		return strconv.FormatInt(time.Now().Unix(), 10)
+	}
+	return strconv.FormatInt(int64(ts)/int64(time.Second), 10)
+}
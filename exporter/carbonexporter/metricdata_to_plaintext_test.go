@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carbonexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var testTimestamp = pdata.TimestampFromTime(time.Unix(1600000000, 0))
+
+func testMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := pdata.NewResourceMetrics()
+	md.ResourceMetrics().Append(rm)
+	ilm := pdata.NewInstrumentationLibraryMetrics()
+	rm.InstrumentationLibraryMetrics().Append(ilm)
+
+	gauge := pdata.NewMetric()
+	gauge.SetName("cpu usage")
+	gauge.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	gdp := pdata.NewDoubleDataPoint()
+	gdp.SetValue(0.5)
+	gdp.SetTimestamp(testTimestamp)
+	gdp.LabelsMap().InitFromMap(map[string]string{"host.name": "web 1", "cpu=": "0", "empty": ""})
+	gauge.DoubleGauge().DataPoints().Append(gdp)
+	ilm.Metrics().Append(gauge)
+
+	sum := pdata.NewMetric()
+	sum.SetName("requests")
+	sum.SetDataType(pdata.MetricDataTypeIntSum)
+	sdp := pdata.NewIntDataPoint()
+	sdp.SetValue(42)
+	sdp.SetTimestamp(testTimestamp)
+	sum.IntSum().DataPoints().Append(sdp)
+	ilm.Metrics().Append(sum)
+
+	histogram := pdata.NewMetric()
+	histogram.SetName("latency")
+	histogram.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	hdp := pdata.NewDoubleHistogramDataPoint()
+	hdp.SetCount(3)
+	hdp.SetSum(7.5)
+	hdp.SetExplicitBounds([]float64{1})
+	hdp.SetBucketCounts([]uint64{1, 2})
+	hdp.SetTimestamp(testTimestamp)
+	hdp.LabelsMap().Insert("path", "/")
+	histogram.DoubleHistogram().DataPoints().Append(hdp)
+	ilm.Metrics().Append(histogram)
+
+	summary := pdata.NewMetric()
+	summary.SetName("duration")
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	smdp := pdata.NewSummaryDataPoint()
+	smdp.SetCount(2)
+	smdp.SetSum(3)
+	smdp.SetTimestamp(testTimestamp)
+	q := pdata.NewValueAtQuantile()
+	q.SetQuantile(0.99)
+	q.SetValue(2.5)
+	smdp.QuantileValues().Append(q)
+	summary.Summary().DataPoints().Append(smdp)
+	ilm.Metrics().Append(summary)
+
+	return md
+}
+
+func TestFormatMetrics(t *testing.T) {
+	tests := []struct {
+		name         string
+		format       string
+		sanitization string
+		want         string
+	}{
+		{
+			name:         "tags_replace",
+			format:       formatTags,
+			sanitization: sanitizationReplace,
+			want: "cpu_usage;cpu_=0;host.name=web_1 0.5 1600000000\n" +
+				"requests 42 1600000000\n" +
+				"latency.count;path=/ 3 1600000000\n" +
+				"latency.sum;path=/ 7.5 1600000000\n" +
+				"latency.bucket;path=/;upper_bound=1 1 1600000000\n" +
+				"latency.bucket;path=/;upper_bound=+Inf 2 1600000000\n" +
+				"duration.count 2 1600000000\n" +
+				"duration.sum 3 1600000000\n" +
+				"duration.quantile;quantile=0.99 2.5 1600000000\n",
+		},
+		{
+			name:         "tags_strict",
+			format:       formatTags,
+			sanitization: sanitizationStrict,
+			want: "cpu_usage;cpu_=0;host_name=web_1 0.5 1600000000\n" +
+				"requests 42 1600000000\n" +
+				"latency.count;path=/ 3 1600000000\n" +
+				"latency.sum;path=/ 7.5 1600000000\n" +
+				"latency.bucket;path=/;upper_bound=1 1 1600000000\n" +
+				"latency.bucket;path=/;upper_bound=+Inf 2 1600000000\n" +
+				"duration.count 2 1600000000\n" +
+				"duration.sum 3 1600000000\n" +
+				"duration.quantile;quantile=0.99 2.5 1600000000\n",
+		},
+		{
+			name:         "path_drop",
+			format:       formatPath,
+			sanitization: sanitizationDrop,
+			want: "cpu_usage 0.5 1600000000\n" +
+				"requests 42 1600000000\n" +
+				"latency.count.path./ 3 1600000000\n" +
+				"latency.sum.path./ 7.5 1600000000\n" +
+				"latency.bucket.path./.upper_bound.1 1 1600000000\n" +
+				"latency.bucket.path./.upper_bound.+Inf 2 1600000000\n" +
+				"duration.count 2 1600000000\n" +
+				"duration.sum 3 1600000000\n" +
+				"duration.quantile.quantile.0_99 2.5 1600000000\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &plaintextFormatter{format: tt.format, sanitization: tt.sanitization}
+			assert.Equal(t, tt.want, f.formatMetrics(testMetrics()))
+		})
+	}
+}
+
+func TestSanitizeTagValue(t *testing.T) {
+	assert.Equal(t, "a_b_c", sanitizeTagValue("a;b c"))
+	assert.Equal(t, "_home", sanitizeTagValue("~home"))
+}
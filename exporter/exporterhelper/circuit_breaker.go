@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// CircuitBreakerSettings defines configuration for stopping to send to a destination failing repeatedly.
+type CircuitBreakerSettings struct {
+	// Enabled indicates whether to stop sending batches after consecutive failed attempts.
+	Enabled bool `mapstructure:"enabled"`
+	// FailureThreshold is the number of consecutive failed attempts after which the circuit breaker opens.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// OpenTimeout is the time the circuit breaker stays open, rejecting all the attempts, before letting probes through.
+	OpenTimeout time.Duration `mapstructure:"open_timeout"`
+	// HalfOpenMaxRequests is the number of probe attempts let through once the OpenTimeout expires, the circuit breaker
+	// closes when they all succeed and opens again as soon as one of them fails.
+	HalfOpenMaxRequests int `mapstructure:"half_open_max_requests"`
+}
+
+// DefaultCircuitBreakerSettings returns the default settings for CircuitBreakerSettings.
+func DefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		Enabled:             false,
+		FailureThreshold:    5,
+		OpenTimeout:         30 * time.Second,
+		HalfOpenMaxRequests: 1,
+	}
+}
+
+// errCircuitOpen is returned for the attempts rejected by the circuit breaker.
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half_open"
+	}
+	return "closed"
+}
+
+// circuitBreaker tracks the outcome of the send attempts. It opens after FailureThreshold consecutive failed attempts,
+// rejects the attempts while open, then lets HalfOpenMaxRequests probes through to decide whether to close or open
+// again. The permanent errors are caused by the data, not by the destination, they are not counted as failures but
+// do not reset the consecutive failures either.
+type circuitBreaker struct {
+	cfg    CircuitBreakerSettings
+	obsrep *obsreport.Exporter
+	logger *zap.Logger
+	now    func() time.Time
+	// host receives the status of the exporter when the circuit breaker opens and closes, it is set when the
+	// exporter starts.
+	host component.Host
+
+	mu       sync.Mutex
+	state    circuitState
+	openedAt time.Time
+	// generation changes at each transition, so that the outcome of an attempt allowed in a previous state is ignored.
+	generation uint64
+	failures   int
+	probes     int
+	successes  int
+}
+
+func newCircuitBreaker(cfg CircuitBreakerSettings, obsrep *obsreport.Exporter, logger *zap.Logger) *circuitBreaker {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 1
+	}
+	if cfg.HalfOpenMaxRequests < 1 {
+		cfg.HalfOpenMaxRequests = 1
+	}
+	return &circuitBreaker{
+		cfg:    cfg,
+		obsrep: obsrep,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// allow returns the generation of the attempt if it can be sent, or an error if it is rejected. While open the error
+// is a throttle error delaying the retry until the probes are let through.
+func (cb *circuitBreaker) allow() (uint64, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case circuitOpen:
+		if remaining := cb.cfg.OpenTimeout - cb.now().Sub(cb.openedAt); remaining > 0 {
+			return 0, NewThrottleRetry(errCircuitOpen, remaining)
+		}
+		cb.setState(circuitHalfOpen)
+		fallthrough
+	case circuitHalfOpen:
+		if cb.probes >= cb.cfg.HalfOpenMaxRequests {
+			return 0, errCircuitOpen
+		}
+		cb.probes++
+	}
+	return cb.generation, nil
+}
+
+// done records the outcome of an attempt allowed with the given generation.
+func (cb *circuitBreaker) done(generation uint64, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if generation != cb.generation {
+		return
+	}
+	failed := err != nil && !consumererror.IsPermanent(err)
+	switch cb.state {
+	case circuitClosed:
+		if err == nil {
+			cb.failures = 0
+			return
+		}
+		if !failed {
+			return
+		}
+		cb.failures++
+		if cb.failures >= cb.cfg.FailureThreshold {
+			cb.setState(circuitOpen)
+		}
+	case circuitHalfOpen:
+		if failed {
+			cb.setState(circuitOpen)
+			return
+		}
+		cb.successes++
+		if cb.successes >= cb.cfg.HalfOpenMaxRequests {
+			cb.setState(circuitClosed)
+		}
+	}
+}
+
+// currentState returns the state of the circuit breaker.
+func (cb *circuitBreaker) currentState() circuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// setState transitions to the given state, it is called with the lock held.
+func (cb *circuitBreaker) setState(state circuitState) {
+	cb.state = state
+	cb.generation++
+	cb.failures = 0
+	cb.probes = 0
+	cb.successes = 0
+	if state == circuitOpen {
+		cb.openedAt = cb.now()
+		cb.logger.Warn("Circuit breaker opened, the sends are rejected until the probes succeed.",
+			zap.Duration("open_timeout", cb.cfg.OpenTimeout))
+	} else {
+		cb.logger.Info("Circuit breaker state changed.", zap.Stringer("state", state))
+	}
+	cb.obsrep.RecordCircuitBreakerTransition(context.Background(), state.String())
+	cb.reportStatus(state)
+}
+
+// setHost sets the host receiving the status of the exporter.
+func (cb *circuitBreaker) setHost(host component.Host) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.host = host
+}
+
+// reportStatus reports the exporter as failing when the circuit breaker opens, and as recovered when it closes. It is
+// called with the lock held.
+func (cb *circuitBreaker) reportStatus(state circuitState) {
+	if cb.host == nil {
+		return
+	}
+	switch state {
+	case circuitOpen:
+		cb.host.ReportComponentStatus(&component.StatusEvent{Status: component.StatusRecoverableError, Err: errCircuitOpen})
+	case circuitClosed:
+		cb.host.ReportComponentStatus(&component.StatusEvent{Status: component.StatusOK})
+	}
+}
+
+// circuitBreakerSender rejects the requests while the circuit breaker is open.
+type circuitBreakerSender struct {
+	breaker    *circuitBreaker
+	nextSender requestSender
+}
+
+// send implements the requestSender interface
+func (cbs *circuitBreakerSender) send(req request) error {
+	generation, err := cbs.breaker.allow()
+	if err != nil {
+		return err
+	}
+	err = cbs.nextSender.send(req)
+	cbs.breaker.done(generation, err)
+	return err
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+func newTestCircuitBreaker(cfg CircuitBreakerSettings, now *time.Time) *circuitBreaker {
+	cb := newCircuitBreaker(cfg, obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.LevelNormal,
+		ExporterName: defaultExporterCfg.Name(),
+	}), zap.NewNop())
+	cb.now = func() time.Time { return *now }
+	return cb
+}
+
+func TestCircuitBreaker_OpenAfterConsecutiveFailures(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	now := time.Now()
+	cfg := DefaultCircuitBreakerSettings()
+	cfg.FailureThreshold = 3
+	cb := newTestCircuitBreaker(cfg, &now)
+
+	fail := func() {
+		gen, err := cb.allow()
+		require.NoError(t, err)
+		cb.done(gen, errors.New("transient error"))
+	}
+	fail()
+	fail()
+	// A success resets the consecutive failures, the permanent errors are not counted.
+	gen, err := cb.allow()
+	require.NoError(t, err)
+	cb.done(gen, nil)
+	fail()
+	fail()
+	gen, err = cb.allow()
+	require.NoError(t, err)
+	cb.done(gen, consumererror.Permanent(errors.New("bad data")))
+	assert.Equal(t, circuitClosed, cb.currentState())
+
+	fail()
+	assert.Equal(t, circuitOpen, cb.currentState())
+	obsreporttest.CheckExporterCircuitBreakerTransitionsViews(t, defaultExporterCfg.Name(), "open", 1)
+
+	// The attempts are rejected and retried once the open timeout expires.
+	now = now.Add(10 * time.Second)
+	_, err = cb.allow()
+	require.Error(t, err)
+	throttleErr, ok := err.(*throttleRetry)
+	require.True(t, ok)
+	assert.Equal(t, 20*time.Second, throttleErr.delay)
+	assert.Equal(t, errCircuitOpen, throttleErr.error)
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	now := time.Now()
+	cfg := DefaultCircuitBreakerSettings()
+	cfg.FailureThreshold = 1
+	cfg.HalfOpenMaxRequests = 2
+	cb := newTestCircuitBreaker(cfg, &now)
+
+	gen, err := cb.allow()
+	require.NoError(t, err)
+	// An attempt allowed before the circuit breaker opened does not count as a probe.
+	staleGen, err := cb.allow()
+	require.NoError(t, err)
+	cb.done(gen, errors.New("transient error"))
+	assert.Equal(t, circuitOpen, cb.currentState())
+
+	// A failed probe opens the circuit breaker again.
+	now = now.Add(cfg.OpenTimeout)
+	gen, err = cb.allow()
+	require.NoError(t, err)
+	assert.Equal(t, circuitHalfOpen, cb.currentState())
+	cb.done(staleGen, nil)
+	cb.done(gen, errors.New("transient error"))
+	assert.Equal(t, circuitOpen, cb.currentState())
+
+	// Only HalfOpenMaxRequests probes are let through, the circuit breaker closes once they all succeed.
+	now = now.Add(cfg.OpenTimeout)
+	firstGen, err := cb.allow()
+	require.NoError(t, err)
+	secondGen, err := cb.allow()
+	require.NoError(t, err)
+	_, err = cb.allow()
+	assert.Equal(t, errCircuitOpen, err)
+	cb.done(firstGen, nil)
+	assert.Equal(t, circuitHalfOpen, cb.currentState())
+	cb.done(secondGen, nil)
+	assert.Equal(t, circuitClosed, cb.currentState())
+}
+
+func TestQueuedRetry_CircuitBreaker(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.Enabled = false
+	cbCfg := DefaultCircuitBreakerSettings()
+	cbCfg.Enabled = true
+	cbCfg.FailureThreshold = 2
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg), WithCircuitBreaker(cbCfg))
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+	assert.Equal(t, "closed", be.CircuitBreakerState())
+
+	reqs := make([]*mockRequest, 0, 3)
+	for i := 0; i < 3; i++ {
+		ocs.run(func() {
+			req := newMockRequest(context.Background(), 2, errors.New("transient error"))
+			reqs = append(reqs, req)
+			// This is asynchronous so it should just enqueue, no errors expected.
+			require.NoError(t, be.sender.send(req))
+		})
+	}
+	ocs.awaitAsyncProcessing()
+
+	// The third request is rejected without being exported.
+	reqs[0].checkNumRequests(t, 1)
+	reqs[1].checkNumRequests(t, 1)
+	reqs[2].checkNumRequests(t, 0)
+	ocs.checkDroppedItemsCount(t, 6)
+	assert.Equal(t, "open", be.CircuitBreakerState())
+}
+
+type statusRecordingHost struct {
+	component.Host
+	statuses []component.Status
+}
+
+func (h *statusRecordingHost) ReportComponentStatus(event *component.StatusEvent) {
+	h.statuses = append(h.statuses, event.Status)
+}
+
+func TestCircuitBreaker_ReportStatus(t *testing.T) {
+	now := time.Now()
+	cfg := DefaultCircuitBreakerSettings()
+	cfg.FailureThreshold = 1
+	cb := newTestCircuitBreaker(cfg, &now)
+	host := &statusRecordingHost{Host: componenttest.NewNopHost()}
+	cb.setHost(host)
+
+	gen, err := cb.allow()
+	require.NoError(t, err)
+	cb.done(gen, errors.New("transient error"))
+
+	// The half open state is not reported, the exporter recovers once the circuit breaker closes.
+	now = now.Add(cfg.OpenTimeout)
+	gen, err = cb.allow()
+	require.NoError(t, err)
+	cb.done(gen, nil)
+	assert.Equal(t, circuitClosed, cb.currentState())
+	assert.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, host.statuses)
+}
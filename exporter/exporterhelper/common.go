@@ -50,6 +50,8 @@ type request interface {
 	onError(error) request
 	// Returns the count of spans/metric points or log records.
 	count() int
+	// marshal returns the bytes representing the data of the request, so that it can be written to a persistent queue.
+	marshal() ([]byte, error)
 }
 
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
@@ -76,7 +78,9 @@ type baseSettings struct {
 	TimeoutSettings
 	QueueSettings
 	RetrySettings
+	CircuitBreakerSettings
 	ResourceToTelemetrySettings
+	marshalerSettings
 }
 
 // fromOptions returns the internal options starting from the default and applying all configured options.
@@ -88,7 +92,9 @@ func fromOptions(options []Option) *baseSettings {
 		QueueSettings: QueueSettings{Enabled: false},
 		// TODO: Enable retry by default (call DefaultRetrySettings)
 		RetrySettings:               RetrySettings{Enabled: false},
+		CircuitBreakerSettings:      DefaultCircuitBreakerSettings(),
 		ResourceToTelemetrySettings: defaultResourceToTelemetrySettings(),
+		marshalerSettings:           defaultMarshalerSettings(),
 	}
 
 	for _, op := range options {
@@ -141,6 +147,14 @@ func WithQueue(queueSettings QueueSettings) Option {
 	}
 }
 
+// WithCircuitBreaker overrides the default CircuitBreakerSettings for an exporter.
+// The default CircuitBreakerSettings is to disable the circuit breaker.
+func WithCircuitBreaker(circuitBreakerSettings CircuitBreakerSettings) Option {
+	return func(o *baseSettings) {
+		o.CircuitBreakerSettings = circuitBreakerSettings
+	}
+}
+
 // WithResourceToTelemetryConversion overrides the default ResourceToTelemetrySettings for an exporter.
 // The default ResourceToTelemetrySettings is to disable resource attributes to metric labels conversion.
 func WithResourceToTelemetryConversion(resourceToTelemetrySettings ResourceToTelemetrySettings) Option {
@@ -156,6 +170,7 @@ type baseExporter struct {
 	sender                     requestSender
 	qrSender                   *queuedRetrySender
 	convertResourceToTelemetry bool
+	marshalers                 marshalerSettings
 }
 
 func newBaseExporter(cfg configmodels.Exporter, logger *zap.Logger, options ...Option) *baseExporter {
@@ -164,9 +179,10 @@ func newBaseExporter(cfg configmodels.Exporter, logger *zap.Logger, options ...O
 		Component:                  componenthelper.New(bs.componentOptions...),
 		cfg:                        cfg,
 		convertResourceToTelemetry: bs.ResourceToTelemetrySettings.Enabled,
+		marshalers:                 bs.marshalerSettings,
 	}
 
-	be.qrSender = newQueuedRetrySender(cfg.Name(), bs.QueueSettings, bs.RetrySettings, &timeoutSender{cfg: bs.TimeoutSettings}, logger)
+	be.qrSender = newQueuedRetrySender(cfg.Name(), bs.QueueSettings, bs.RetrySettings, bs.CircuitBreakerSettings, &timeoutSender{cfg: bs.TimeoutSettings}, logger)
 	be.sender = be.qrSender
 
 	return be
@@ -178,6 +194,12 @@ func (be *baseExporter) wrapConsumerSender(f func(consumer requestSender) reques
 	be.qrSender.consumerSender = f(be.qrSender.consumerSender)
 }
 
+// setRequestUnmarshaler sets the function used to read back the requests of the exporter written to a persistent
+// queue, it must match the marshal function of the requests sent by the exporter.
+func (be *baseExporter) setRequestUnmarshaler(unmarshaler requestUnmarshaler) {
+	be.qrSender.unmarshaler = unmarshaler
+}
+
 // Start all senders and exporter and is invoked during service start.
 func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 	// First start the wrapped exporter.
@@ -186,18 +208,33 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 	}
 
 	// If no error then start the queuedRetrySender.
+	if be.qrSender.breaker != nil {
+		be.qrSender.breaker.setHost(host)
+	}
 	be.qrSender.start()
 	return nil
 }
 
 // Shutdown all senders and exporter and is invoked during service shutdown.
 func (be *baseExporter) Shutdown(ctx context.Context) error {
-	// First shutdown the queued retry sender
-	be.qrSender.shutdown()
+	// First shutdown the queued retry sender, draining the queue until the context is done.
+	be.qrSender.shutdown(ctx)
 	// Last shutdown the wrapped exporter itself.
 	return be.Component.Shutdown(ctx)
 }
 
+// QueueSize returns the number of batches waiting in the sending queue and the capacity of the queue, the capacity is
+// 0 when the sending queue is disabled.
+func (be *baseExporter) QueueSize() (size int, capacity int) {
+	return be.qrSender.queueSize()
+}
+
+// CircuitBreakerState returns the state of the circuit breaker, "closed", "open" or "half_open", it is "closed" when
+// the circuit breaker is disabled.
+func (be *baseExporter) CircuitBreakerState() string {
+	return be.qrSender.circuitBreakerState().String()
+}
+
 // timeoutSender is a request sender that adds a `timeout` to every request that passes this sender.
 type timeoutSender struct {
 	cfg TimeoutSettings
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// concurrencyAdjustInterval is the interval at which the adaptive concurrency is adjusted.
+	concurrencyAdjustInterval = time.Second
+	// latencyIncreaseFactor is the factor of the baseline latency above which the backend is considered overloaded,
+	// and the concurrency decreased.
+	latencyIncreaseFactor = 2
+)
+
+// concurrencyLimiter limits the number of queue consumers sending concurrently, and measures the latency of the
+// send attempts. The limit can be changed while the consumers are running.
+type concurrencyLimiter struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	limit   int
+	active  int
+	stopped bool
+
+	// The statistics of the current adjust interval.
+	peakActive   int
+	attempts     int
+	totalLatency time.Duration
+}
+
+func newConcurrencyLimiter(limit int) *concurrencyLimiter {
+	l := &concurrencyLimiter{limit: limit}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// acquire blocks until the consumer is allowed to send, it returns false if the limiter was stopped.
+func (l *concurrencyLimiter) acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for !l.stopped && l.active >= l.limit {
+		l.cond.Wait()
+	}
+	if l.stopped {
+		return false
+	}
+	l.active++
+	if l.active > l.peakActive {
+		l.peakActive = l.active
+	}
+	return true
+}
+
+// release is called when the consumer is done sending.
+func (l *concurrencyLimiter) release() {
+	l.mu.Lock()
+	l.active--
+	l.mu.Unlock()
+	l.cond.Signal()
+}
+
+// recordLatency records the latency of a send attempt, the retries being separate attempts.
+func (l *concurrencyLimiter) recordLatency(latency time.Duration) {
+	l.mu.Lock()
+	l.attempts++
+	l.totalLatency += latency
+	l.mu.Unlock()
+}
+
+// setLimit changes the number of consumers allowed to send concurrently.
+func (l *concurrencyLimiter) setLimit(limit int) {
+	l.mu.Lock()
+	l.limit = limit
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+// stop unblocks the consumers waiting to send.
+func (l *concurrencyLimiter) stop() {
+	l.mu.Lock()
+	l.stopped = true
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+// concurrencyStats are the statistics of an adjust interval.
+type concurrencyStats struct {
+	limit      int
+	active     int
+	peakActive int
+	attempts   int
+	avgLatency time.Duration
+}
+
+// collectStats returns the statistics of the interval and starts a new one.
+func (l *concurrencyLimiter) collectStats() concurrencyStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := concurrencyStats{
+		limit:      l.limit,
+		active:     l.active,
+		peakActive: l.peakActive,
+		attempts:   l.attempts,
+	}
+	if l.attempts > 0 {
+		stats.avgLatency = l.totalLatency / time.Duration(l.attempts)
+	}
+	l.peakActive = l.active
+	l.attempts = 0
+	l.totalLatency = 0
+	return stats
+}
+
+// adaptiveConcurrency computes the concurrency limit from the latency of the send attempts and the backlog of the
+// queue. The limit grows while requests are waiting to be sent and the latency stays close to the lowest observed
+// latency, it shrinks quickly when the latency increases, which means the backend is overloaded, and slowly when the
+// consumers are idle.
+type adaptiveConcurrency struct {
+	minConsumers int
+	maxConsumers int
+	// baselineLatency follows the lowest average latency of the intervals, and slowly the higher ones so that it
+	// adapts to the backends becoming durably slower.
+	baselineLatency time.Duration
+}
+
+// nextLimit returns the limit of the next interval, backlog being the number of requests waiting to be sent.
+func (ac *adaptiveConcurrency) nextLimit(stats concurrencyStats, backlog int) int {
+	limit := stats.limit
+	if stats.attempts > 0 {
+		if ac.baselineLatency == 0 || stats.avgLatency < ac.baselineLatency {
+			ac.baselineLatency = stats.avgLatency
+		} else {
+			ac.baselineLatency += (stats.avgLatency - ac.baselineLatency) / 10
+		}
+		if stats.avgLatency > latencyIncreaseFactor*ac.baselineLatency {
+			return maxInt(ac.minConsumers, minInt(limit-1, limit*3/4))
+		}
+	}
+
+	switch {
+	case backlog > 0 && stats.peakActive >= limit:
+		// All the consumers were busy and requests are waiting.
+		return minInt(ac.maxConsumers, limit+maxInt(1, limit/4))
+	case backlog == 0 && stats.peakActive < limit:
+		return maxInt(ac.minConsumers, limit-1)
+	}
+	return limit
+}
+
+func minInt(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+func maxInt(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+// latencySender records the latency of the send attempts in the concurrency limiter.
+type latencySender struct {
+	limiter    *concurrencyLimiter
+	nextSender requestSender
+}
+
+// send implements the requestSender interface
+func (ls *latencySender) send(req request) error {
+	start := time.Now()
+	err := ls.nextSender.send(req)
+	ls.limiter.recordLatency(time.Since(start))
+	return err
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimiter_Acquire(t *testing.T) {
+	l := newConcurrencyLimiter(1)
+	assert.True(t, l.acquire())
+
+	acquired := make(chan bool)
+	go func() {
+		acquired <- l.acquire()
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired over the limit")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Raising the limit unblocks the waiting consumer.
+	l.setLimit(2)
+	assert.True(t, <-acquired)
+
+	stats := l.collectStats()
+	assert.Equal(t, 2, stats.limit)
+	assert.Equal(t, 2, stats.active)
+	assert.Equal(t, 2, stats.peakActive)
+
+	l.release()
+	l.release()
+	assert.Equal(t, 2, l.collectStats().peakActive)
+	assert.Equal(t, 0, l.collectStats().peakActive)
+}
+
+func TestConcurrencyLimiter_Stop(t *testing.T) {
+	l := newConcurrencyLimiter(1)
+	assert.True(t, l.acquire())
+
+	acquired := make(chan bool)
+	go func() {
+		acquired <- l.acquire()
+	}()
+	l.stop()
+	assert.False(t, <-acquired)
+	assert.False(t, l.acquire())
+}
+
+func TestConcurrencyLimiter_Latency(t *testing.T) {
+	l := newConcurrencyLimiter(1)
+	l.recordLatency(10 * time.Millisecond)
+	l.recordLatency(30 * time.Millisecond)
+
+	stats := l.collectStats()
+	assert.Equal(t, 2, stats.attempts)
+	assert.Equal(t, 20*time.Millisecond, stats.avgLatency)
+
+	stats = l.collectStats()
+	assert.Equal(t, 0, stats.attempts)
+	assert.Equal(t, time.Duration(0), stats.avgLatency)
+}
+
+func TestAdaptiveConcurrency_NextLimit(t *testing.T) {
+	ac := &adaptiveConcurrency{minConsumers: 2, maxConsumers: 10}
+
+	// All the consumers are busy and requests are waiting, the limit grows up to the maximum.
+	stats := concurrencyStats{limit: 4, peakActive: 4, attempts: 8, avgLatency: 10 * time.Millisecond}
+	assert.Equal(t, 5, ac.nextLimit(stats, 3))
+	stats.limit, stats.peakActive = 10, 10
+	assert.Equal(t, 10, ac.nextLimit(stats, 3))
+
+	// The latency doubled, the backend is overloaded.
+	stats.avgLatency = 30 * time.Millisecond
+	assert.Equal(t, 7, ac.nextLimit(stats, 3))
+
+	// The consumers are idle, the limit shrinks down to the minimum.
+	stats = concurrencyStats{limit: 3, peakActive: 1}
+	assert.Equal(t, 2, ac.nextLimit(stats, 0))
+	stats.limit = 2
+	assert.Equal(t, 2, ac.nextLimit(stats, 0))
+
+	// The consumers are busy without backlog, the limit is kept.
+	stats = concurrencyStats{limit: 5, peakActive: 5}
+	assert.Equal(t, 5, ac.nextLimit(stats, 0))
+}
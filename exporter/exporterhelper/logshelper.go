@@ -33,22 +33,24 @@ type PushLogs func(ctx context.Context, md pdata.Logs) error
 
 type logsRequest struct {
 	baseRequest
-	ld     pdata.Logs
-	pusher PushLogs
+	ld        pdata.Logs
+	pusher    PushLogs
+	marshaler LogsMarshaler
 }
 
-func newLogsRequest(ctx context.Context, ld pdata.Logs, pusher PushLogs) request {
+func newLogsRequest(ctx context.Context, ld pdata.Logs, pusher PushLogs, marshaler LogsMarshaler) request {
 	return &logsRequest{
 		baseRequest: baseRequest{ctx: ctx},
 		ld:          ld,
 		pusher:      pusher,
+		marshaler:   marshaler,
 	}
 }
 
 func (req *logsRequest) onError(err error) request {
 	var logError consumererror.Logs
 	if consumererror.AsLogs(err, &logError) {
-		return newLogsRequest(req.ctx, logError.GetLogs(), req.pusher)
+		return newLogsRequest(req.ctx, logError.GetLogs(), req.pusher, req.marshaler)
 	}
 	return req
 }
@@ -57,6 +59,10 @@ func (req *logsRequest) export(ctx context.Context) error {
 	return req.pusher(ctx, req.ld)
 }
 
+func (req *logsRequest) marshal() ([]byte, error) {
+	return req.marshaler(req.ld)
+}
+
 func (req *logsRequest) count() int {
 	return req.ld.LogRecordCount()
 }
@@ -67,7 +73,7 @@ type logsExporter struct {
 }
 
 func (lexp *logsExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
-	return lexp.sender.send(newLogsRequest(ctx, ld, lexp.pusher))
+	return lexp.sender.send(newLogsRequest(ctx, ld, lexp.pusher, lexp.marshalers.logsMarshaler))
 }
 
 // NewLogsExporter creates an LogsExporter that records observability metrics and wraps every request with a Span.
@@ -90,6 +96,13 @@ func NewLogsExporter(
 	}
 
 	be := newBaseExporter(cfg, logger, options...)
+	be.setRequestUnmarshaler(func(buf []byte) (request, error) {
+		ld, err := be.marshalers.logsUnmarshaler(buf)
+		if err != nil {
+			return nil, err
+		}
+		return newLogsRequest(context.Background(), ld, pusher, be.marshalers.logsMarshaler), nil
+	})
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &logsExporterWithObservability{
 			obsrep: obsreport.NewExporter(obsreport.ExporterSettings{
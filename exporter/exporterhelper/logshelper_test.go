@@ -46,12 +46,12 @@ var (
 )
 
 func TestLogsRequest(t *testing.T) {
-	lr := newLogsRequest(context.Background(), testdata.GenerateLogDataOneLog(), nil)
+	lr := newLogsRequest(context.Background(), testdata.GenerateLogDataOneLog(), nil, nil)
 
 	logErr := consumererror.NewLogs(errors.New("some error"), testdata.GenerateLogDataEmpty())
 	assert.EqualValues(
 		t,
-		newLogsRequest(context.Background(), testdata.GenerateLogDataEmpty(), nil),
+		newLogsRequest(context.Background(), testdata.GenerateLogDataEmpty(), nil, nil),
 		lr.onError(logErr),
 	)
 }
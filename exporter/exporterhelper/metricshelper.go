@@ -33,22 +33,24 @@ type PushMetrics func(ctx context.Context, md pdata.Metrics) error
 
 type metricsRequest struct {
 	baseRequest
-	md     pdata.Metrics
-	pusher PushMetrics
+	md        pdata.Metrics
+	pusher    PushMetrics
+	marshaler MetricsMarshaler
 }
 
-func newMetricsRequest(ctx context.Context, md pdata.Metrics, pusher PushMetrics) request {
+func newMetricsRequest(ctx context.Context, md pdata.Metrics, pusher PushMetrics, marshaler MetricsMarshaler) request {
 	return &metricsRequest{
 		baseRequest: baseRequest{ctx: ctx},
 		md:          md,
 		pusher:      pusher,
+		marshaler:   marshaler,
 	}
 }
 
 func (req *metricsRequest) onError(err error) request {
 	var metricsError consumererror.Metrics
 	if consumererror.AsMetrics(err, &metricsError) {
-		return newMetricsRequest(req.ctx, metricsError.GetMetrics(), req.pusher)
+		return newMetricsRequest(req.ctx, metricsError.GetMetrics(), req.pusher, req.marshaler)
 	}
 	return req
 }
@@ -57,6 +59,10 @@ func (req *metricsRequest) export(ctx context.Context) error {
 	return req.pusher(ctx, req.md)
 }
 
+func (req *metricsRequest) marshal() ([]byte, error) {
+	return req.marshaler(req.md)
+}
+
 func (req *metricsRequest) count() int {
 	_, numPoints := req.md.MetricAndDataPointCount()
 	return numPoints
@@ -71,7 +77,7 @@ func (mexp *metricsExporter) ConsumeMetrics(ctx context.Context, md pdata.Metric
 	if mexp.baseExporter.convertResourceToTelemetry {
 		md = convertResourceToLabels(md)
 	}
-	return mexp.sender.send(newMetricsRequest(ctx, md, mexp.pusher))
+	return mexp.sender.send(newMetricsRequest(ctx, md, mexp.pusher, mexp.marshalers.metricsMarshaler))
 }
 
 // NewMetricsExporter creates an MetricsExporter that records observability metrics and wraps every request with a Span.
@@ -94,6 +100,13 @@ func NewMetricsExporter(
 	}
 
 	be := newBaseExporter(cfg, logger, options...)
+	be.setRequestUnmarshaler(func(buf []byte) (request, error) {
+		md, err := be.marshalers.metricsUnmarshaler(buf)
+		if err != nil {
+			return nil, err
+		}
+		return newMetricsRequest(context.Background(), md, pusher, be.marshalers.metricsMarshaler), nil
+	})
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &metricsSenderWithObservability{
 			obsrep: obsreport.NewExporter(obsreport.ExporterSettings{
@@ -46,12 +46,12 @@ var (
 )
 
 func TestMetricsRequest(t *testing.T) {
-	mr := newMetricsRequest(context.Background(), testdata.GenerateMetricsOneMetric(), nil)
+	mr := newMetricsRequest(context.Background(), testdata.GenerateMetricsOneMetric(), nil, nil)
 
 	metricsErr := consumererror.NewMetrics(errors.New("some error"), testdata.GenerateMetricsEmpty())
 	assert.EqualValues(
 		t,
-		newMetricsRequest(context.Background(), testdata.GenerateMetricsEmpty(), nil),
+		newMetricsRequest(context.Background(), testdata.GenerateMetricsEmpty(), nil, nil),
 		mr.onError(metricsErr),
 	)
 }
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var errQueueWatermarkCallbackAlreadyRegistered = errors.New("queue watermark callback already registered")
+
+// QueueWatermarkEvent describes the crossing of a watermark by the sending queue of an exporter.
+type QueueWatermarkEvent struct {
+	// ExporterName is the full name of the exporter.
+	ExporterName string
+	// Size is the number of batches in the sending queue when the watermark was crossed.
+	Size int
+	// Capacity is the capacity of the sending queue.
+	Capacity int
+	// High is true when the queue filled up to the high watermark, false when it drained down to the low watermark.
+	High bool
+}
+
+// QueueWatermarkCallback is called when the sending queue of an exporter crosses one of its watermarks. It is called
+// by the goroutine enqueuing or consuming the batch that crossed the watermark, so it must not block.
+type QueueWatermarkCallback func(event QueueWatermarkEvent)
+
+var (
+	queueWatermarkCallbacksMu sync.RWMutex
+	queueWatermarkCallbacks   = map[string]QueueWatermarkCallback{}
+)
+
+// RegisterQueueWatermarkCallback subscribes the callback to the watermark events of the sending queues of all the
+// exporters, under the given name. It lets components like the memory limiter or the health check apply
+// backpressure when the exporters cannot keep up.
+func RegisterQueueWatermarkCallback(name string, callback QueueWatermarkCallback) error {
+	queueWatermarkCallbacksMu.Lock()
+	defer queueWatermarkCallbacksMu.Unlock()
+	if _, ok := queueWatermarkCallbacks[name]; ok {
+		return fmt.Errorf("%w: %q", errQueueWatermarkCallbackAlreadyRegistered, name)
+	}
+	queueWatermarkCallbacks[name] = callback
+	return nil
+}
+
+// UnregisterQueueWatermarkCallback removes the callback registered under the given name.
+func UnregisterQueueWatermarkCallback(name string) {
+	queueWatermarkCallbacksMu.Lock()
+	defer queueWatermarkCallbacksMu.Unlock()
+	delete(queueWatermarkCallbacks, name)
+}
+
+func notifyQueueWatermark(event QueueWatermarkEvent) {
+	queueWatermarkCallbacksMu.RLock()
+	callbacks := make([]QueueWatermarkCallback, 0, len(queueWatermarkCallbacks))
+	for _, callback := range queueWatermarkCallbacks {
+		callbacks = append(callbacks, callback)
+	}
+	queueWatermarkCallbacksMu.RUnlock()
+
+	for _, callback := range callbacks {
+		callback(event)
+	}
+}
+
+// queueWatermarks tracks whether the sending queue is above its high watermark, the state only changes back once
+// the queue drained down to the low watermark so that the subscribers are not notified on every batch.
+type queueWatermarks struct {
+	exporterName string
+	high         int
+	low          int
+	// above is accessed atomically, it is 1 while the queue is above the high watermark.
+	above int32
+}
+
+// newQueueWatermarks returns the watermarks of a queue of the given capacity, nil when the high watermark is not set.
+func newQueueWatermarks(exporterName string, capacity int, highWatermark, lowWatermark float64) *queueWatermarks {
+	if highWatermark <= 0 || capacity <= 0 {
+		return nil
+	}
+	high := maxInt(int(highWatermark*float64(capacity)), 1)
+	return &queueWatermarks{
+		exporterName: exporterName,
+		high:         high,
+		// The low watermark is kept below the high one, otherwise the state would change on every batch.
+		low: minInt(int(lowWatermark*float64(capacity)), high-1),
+	}
+}
+
+// update notifies the subscribers when the size of the queue crosses one of the watermarks.
+func (w *queueWatermarks) update(size, capacity int) {
+	switch {
+	case size >= w.high:
+		if atomic.CompareAndSwapInt32(&w.above, 0, 1) {
+			notifyQueueWatermark(QueueWatermarkEvent{ExporterName: w.exporterName, Size: size, Capacity: capacity, High: true})
+		}
+	case size <= w.low:
+		if atomic.CompareAndSwapInt32(&w.above, 1, 0) {
+			notifyQueueWatermark(QueueWatermarkEvent{ExporterName: w.exporterName, Size: size, Capacity: capacity, High: false})
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueWatermarks(t *testing.T) {
+	var events []QueueWatermarkEvent
+	require.NoError(t, RegisterQueueWatermarkCallback("test", func(event QueueWatermarkEvent) {
+		events = append(events, event)
+	}))
+	defer UnregisterQueueWatermarkCallback("test")
+	assert.True(t, errors.Is(RegisterQueueWatermarkCallback("test", func(QueueWatermarkEvent) {}), errQueueWatermarkCallbackAlreadyRegistered))
+
+	w := newQueueWatermarks("exporter", 10, 0.8, 0.5)
+	for _, size := range []int{1, 7, 8, 9, 8, 6, 5, 4, 8} {
+		w.update(size, 10)
+	}
+	assert.Equal(t, []QueueWatermarkEvent{
+		{ExporterName: "exporter", Size: 8, Capacity: 10, High: true},
+		{ExporterName: "exporter", Size: 5, Capacity: 10, High: false},
+		{ExporterName: "exporter", Size: 8, Capacity: 10, High: true},
+	}, events)
+}
+
+func TestNewQueueWatermarks(t *testing.T) {
+	assert.Nil(t, newQueueWatermarks("exporter", 10, 0, 0))
+	assert.Nil(t, newQueueWatermarks("exporter", 0, 0.8, 0.5))
+
+	// The low watermark is kept below the high one.
+	w := newQueueWatermarks("exporter", 10, 0.5, 0.9)
+	assert.Equal(t, 5, w.high)
+	assert.Equal(t, 4, w.low)
+
+	w = newQueueWatermarks("exporter", 1, 0.1, 0)
+	assert.Equal(t, 1, w.high)
+	assert.Equal(t, 0, w.low)
+}
@@ -15,9 +15,12 @@
 package exporterhelper
 
 import (
+	"container/list"
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -26,18 +29,39 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
+const (
+	// drainCheckInterval is the interval at which the queue is checked while waiting for it to be drained at shutdown.
+	drainCheckInterval = 10 * time.Millisecond
+	// queueStatsInterval is the interval at which the size of the queue and the age of its oldest batch are recorded.
+	queueStatsInterval = 10 * time.Second
+)
+
 // QueueSettings defines configuration for queueing batches before sending to the consumerSender.
 type QueueSettings struct {
 	// Enabled indicates whether to not enqueue batches before sending to the consumerSender.
 	Enabled bool `mapstructure:"enabled"`
-	// NumConsumers is the number of consumers from the queue.
+	// NumConsumers is the number of consumers from the queue, the initial one when AdaptiveConcurrency is enabled.
 	NumConsumers int `mapstructure:"num_consumers"`
 	// QueueSize is the maximum number of batches allowed in queue at a given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// AdaptiveConcurrency enables adjusting the number of consumers sending concurrently, between MinConsumers and
+	// MaxConsumers, depending on the latency of the sends and the number of batches waiting in the queue.
+	AdaptiveConcurrency bool `mapstructure:"adaptive_concurrency"`
+	// MinConsumers is the minimum number of consumers sending concurrently when AdaptiveConcurrency is enabled.
+	MinConsumers int `mapstructure:"min_consumers"`
+	// MaxConsumers is the maximum number of consumers sending concurrently when AdaptiveConcurrency is enabled.
+	MaxConsumers int `mapstructure:"max_consumers"`
+	// HighWatermark is the fill ratio of the queue at which the callbacks registered with
+	// RegisterQueueWatermarkCallback are notified that the queue is filling up, 0 disables the notifications.
+	HighWatermark float64 `mapstructure:"high_watermark"`
+	// LowWatermark is the fill ratio of the queue at which the callbacks are notified that the queue drained, after
+	// it reached the high watermark.
+	LowWatermark float64 `mapstructure:"low_watermark"`
 }
 
 // DefaultQueueSettings returns the default settings for QueueSettings.
@@ -49,10 +73,29 @@ func DefaultQueueSettings() QueueSettings {
 		// This is a pretty decent value for production.
 		// User should calculate this from the perspective of how many seconds to buffer in case of a backend outage,
 		// multiply that by the number of requests per seconds.
-		QueueSize: 5000,
+		QueueSize:     5000,
+		MinConsumers:  1,
+		MaxConsumers:  50,
+		HighWatermark: 0.8,
+		LowWatermark:  0.5,
 	}
 }
 
+// Validate checks that the watermarks are fill ratios of the queue, the low watermark being lower than the high
+// watermark unless the notifications are disabled. It is promoted to the configurations embedding QueueSettings.
+func (qs *QueueSettings) Validate() error {
+	if qs.HighWatermark < 0 || qs.HighWatermark > 1 {
+		return fmt.Errorf("high_watermark %v must be between 0 and 1", qs.HighWatermark)
+	}
+	if qs.LowWatermark < 0 || qs.LowWatermark > 1 {
+		return fmt.Errorf("low_watermark %v must be between 0 and 1", qs.LowWatermark)
+	}
+	if qs.HighWatermark != 0 && qs.LowWatermark >= qs.HighWatermark {
+		return fmt.Errorf("low_watermark %v must be lower than high_watermark %v", qs.LowWatermark, qs.HighWatermark)
+	}
+	return nil
+}
+
 // RetrySettings defines configuration for retrying batches in case of export failure.
 // The current supported strategy is exponential backoff.
 type RetrySettings struct {
@@ -79,12 +122,75 @@ func DefaultRetrySettings() RetrySettings {
 }
 
 type queuedRetrySender struct {
+	// pendingRequests and pendingItems count the requests, and their items, that were enqueued and not yet consumed.
+	// They are accessed atomically and kept first in the struct so that they are 64-bit aligned.
+	pendingRequests int64
+	pendingItems    int64
+	// shutdownDroppedItems counts the items of the requests that failed after the retries were stopped at shutdown.
+	shutdownDroppedItems int64
+
 	cfg             QueueSettings
 	consumerSender  requestSender
 	queue           *queue.BoundedQueue
 	retryStopCh     chan struct{}
 	traceAttributes []trace.Attribute
+	obsrep          *obsreport.Exporter
 	logger          *zap.Logger
+
+	// limiter and adaptive are set when the adaptive concurrency is enabled.
+	limiter  *concurrencyLimiter
+	adaptive *adaptiveConcurrency
+	adjustWG sync.WaitGroup
+
+	// breaker is set when the circuit breaker is enabled.
+	breaker *circuitBreaker
+
+	// enqueueTimes and watermarks track the age of the queued requests and the watermarks of the queue, watermarks
+	// is nil when they are disabled.
+	enqueueTimes *enqueueTimes
+	watermarks   *queueWatermarks
+	statsWG      sync.WaitGroup
+
+	// unmarshaler reads back the requests written to a persistent queue with their marshal function.
+	unmarshaler requestUnmarshaler
+}
+
+// queuedRequest is a request waiting in the queue, along with its enqueue time.
+type queuedRequest struct {
+	request
+	enqueued *list.Element
+}
+
+// enqueueTimes keeps the enqueue times of the requests in the queue, the oldest first.
+type enqueueTimes struct {
+	mu    sync.Mutex
+	times *list.List
+}
+
+func newEnqueueTimes() *enqueueTimes {
+	return &enqueueTimes{times: list.New()}
+}
+
+func (e *enqueueTimes) push(t time.Time) *list.Element {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.times.PushBack(t)
+}
+
+func (e *enqueueTimes) remove(elem *list.Element) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.times.Remove(elem)
+}
+
+// oldestAge returns how long the oldest request has been waiting in the queue, 0 when the queue is empty.
+func (e *enqueueTimes) oldestAge(now time.Time) time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if front := e.times.Front(); front != nil {
+		return now.Sub(front.Value.(time.Time))
+	}
+	return 0
 }
 
 func createSampledLogger(logger *zap.Logger) *zap.Logger {
@@ -106,10 +212,39 @@ func createSampledLogger(logger *zap.Logger) *zap.Logger {
 	return logger.WithOptions(opts)
 }
 
-func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySettings, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySettings, cbCfg CircuitBreakerSettings, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := trace.StringAttribute(obsreport.ExporterKey, fullName)
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.GetMetricsLevelFlagValue(),
+		ExporterName: fullName,
+	})
+
+	var limiter *concurrencyLimiter
+	var adaptive *adaptiveConcurrency
+	if qCfg.Enabled && qCfg.AdaptiveConcurrency {
+		// Fix the bounds so that 1 <= MinConsumers <= NumConsumers <= MaxConsumers.
+		qCfg.MinConsumers = maxInt(qCfg.MinConsumers, 1)
+		qCfg.MaxConsumers = maxInt(qCfg.MaxConsumers, qCfg.MinConsumers)
+		qCfg.NumConsumers = minInt(maxInt(qCfg.NumConsumers, qCfg.MinConsumers), qCfg.MaxConsumers)
+		limiter = newConcurrencyLimiter(qCfg.NumConsumers)
+		adaptive = &adaptiveConcurrency{minConsumers: qCfg.MinConsumers, maxConsumers: qCfg.MaxConsumers}
+		nextSender = &latencySender{limiter: limiter, nextSender: nextSender}
+	}
+
+	// The circuit breaker wraps the latency measurement, so that the rejected attempts are not measured.
+	var breaker *circuitBreaker
+	if cbCfg.Enabled {
+		breaker = newCircuitBreaker(cbCfg, obsrep, logger)
+		nextSender = &circuitBreakerSender{breaker: breaker, nextSender: nextSender}
+	}
+
+	var watermarks *queueWatermarks
+	if qCfg.Enabled {
+		watermarks = newQueueWatermarks(fullName, qCfg.QueueSize, qCfg.HighWatermark, qCfg.LowWatermark)
+	}
+
 	return &queuedRetrySender{
 		cfg: qCfg,
 		consumerSender: &retrySender{
@@ -122,16 +257,97 @@ func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySetting
 		queue:           queue.NewBoundedQueue(qCfg.QueueSize, func(item interface{}) {}),
 		retryStopCh:     retryStopCh,
 		traceAttributes: []trace.Attribute{traceAttr},
+		obsrep:          obsrep,
 		logger:          sampledLogger,
+		limiter:         limiter,
+		adaptive:        adaptive,
+		breaker:         breaker,
+		enqueueTimes:    newEnqueueTimes(),
+		watermarks:      watermarks,
 	}
 }
 
 // start is invoked during service startup.
 func (qrs *queuedRetrySender) start() {
-	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
-		req := item.(request)
-		_ = qrs.consumerSender.send(req)
+	if qrs.cfg.Enabled {
+		qrs.statsWG.Add(1)
+		go qrs.reportQueueStats(queueStatsInterval)
+	}
+
+	if qrs.limiter == nil {
+		if qrs.cfg.Enabled {
+			qrs.obsrep.RecordSenderConcurrency(context.Background(), qrs.cfg.NumConsumers)
+		}
+		qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
+			qrs.consume(qrs.dequeue(item))
+		})
+		return
+	}
+
+	// With the adaptive concurrency, MaxConsumers consume the queue and the limiter lets only some of them send.
+	qrs.obsrep.RecordSenderConcurrency(context.Background(), qrs.cfg.NumConsumers)
+	qrs.queue.StartConsumers(qrs.cfg.MaxConsumers, func(item interface{}) {
+		req := qrs.dequeue(item)
+		if !qrs.limiter.acquire() {
+			// The exporter is shutting down, the request is dropped.
+			atomic.AddInt64(&qrs.shutdownDroppedItems, int64(req.count()))
+			atomic.AddInt64(&qrs.pendingItems, -int64(req.count()))
+			atomic.AddInt64(&qrs.pendingRequests, -1)
+			return
+		}
+		defer qrs.limiter.release()
+		qrs.consume(req)
 	})
+	qrs.adjustWG.Add(1)
+	go qrs.adjustConcurrency(concurrencyAdjustInterval)
+}
+
+// dequeue returns the request taken from the queue, and stops tracking its age.
+func (qrs *queuedRetrySender) dequeue(item interface{}) request {
+	qr := item.(*queuedRequest)
+	qrs.enqueueTimes.remove(qr.enqueued)
+	if qrs.watermarks != nil {
+		qrs.watermarks.update(qrs.queue.Size(), qrs.queue.Capacity())
+	}
+	return qr.request
+}
+
+func (qrs *queuedRetrySender) consume(req request) {
+	count := req.count()
+	if err := qrs.consumerSender.send(req); err != nil && qrs.retriesStopped() {
+		atomic.AddInt64(&qrs.shutdownDroppedItems, int64(count))
+	}
+	atomic.AddInt64(&qrs.pendingItems, -int64(count))
+	atomic.AddInt64(&qrs.pendingRequests, -1)
+}
+
+// adjustConcurrency adjusts the concurrency limit at each interval until the retries are stopped at shutdown.
+func (qrs *queuedRetrySender) adjustConcurrency(interval time.Duration) {
+	defer qrs.adjustWG.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-qrs.retryStopCh:
+			return
+		case <-ticker.C:
+		}
+
+		stats := qrs.limiter.collectStats()
+		// The pending requests are either in the queue, waiting for the limiter, or being sent.
+		backlog := int(atomic.LoadInt64(&qrs.pendingRequests)) - stats.active
+		limit := qrs.adaptive.nextLimit(stats, backlog)
+		if limit == stats.limit {
+			continue
+		}
+		qrs.logger.Debug("Adjusting the sending concurrency.",
+			zap.Int("concurrency", limit),
+			zap.Int("previous_concurrency", stats.limit),
+			zap.Duration("average_latency", stats.avgLatency),
+			zap.Int("backlog", backlog))
+		qrs.limiter.setLimit(limit)
+		qrs.obsrep.RecordSenderConcurrency(context.Background(), limit)
+	}
 }
 
 // send implements the requestSender interface
@@ -152,27 +368,118 @@ func (qrs *queuedRetrySender) send(req request) error {
 	req.setContext(noCancellationContext{Context: req.context()})
 
 	span := trace.FromContext(req.context())
-	if !qrs.queue.Produce(req) {
+	// Count the request before enqueuing it, it may be consumed before Produce returns.
+	count := req.count()
+	atomic.AddInt64(&qrs.pendingRequests, 1)
+	atomic.AddInt64(&qrs.pendingItems, int64(count))
+	qr := &queuedRequest{request: req, enqueued: qrs.enqueueTimes.push(time.Now())}
+	if !qrs.queue.Produce(qr) {
+		qrs.enqueueTimes.remove(qr.enqueued)
+		atomic.AddInt64(&qrs.pendingItems, -int64(count))
+		atomic.AddInt64(&qrs.pendingRequests, -1)
+		qrs.obsrep.RecordEnqueueFailedItems(req.context(), count)
 		qrs.logger.Error(
 			"Dropping data because sending_queue is full. Try increasing queue_size.",
 			zap.Int("dropped_items", req.count()),
 		)
 		span.Annotate(qrs.traceAttributes, "Dropped item, sending_queue is full.")
-		return errors.New("sending_queue is full")
+		return consumererror.WithReason(errors.New("sending_queue is full"), consumererror.ReasonQueueFull)
 	}
 
+	if qrs.watermarks != nil {
+		qrs.watermarks.update(qrs.queue.Size(), qrs.queue.Capacity())
+	}
 	span.Annotate(qrs.traceAttributes, "Enqueued item.")
 	return nil
 }
 
-// shutdown is invoked during service shutdown.
-func (qrs *queuedRetrySender) shutdown() {
-	// First stop the retry goroutines, so that unblocks the queue workers.
+// reportQueueStats records the statistics of the queue at each interval until the retries are stopped at shutdown.
+func (qrs *queuedRetrySender) reportQueueStats(interval time.Duration) {
+	defer qrs.statsWG.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		qrs.recordQueueStats()
+		select {
+		case <-qrs.retryStopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (qrs *queuedRetrySender) recordQueueStats() {
+	qrs.obsrep.RecordQueueStats(context.Background(), qrs.queue.Size(), qrs.queue.Capacity(), qrs.enqueueTimes.oldestAge(time.Now()))
+}
+
+// queueSize returns the number of requests in the queue and its capacity, the capacity is 0 when the queue is disabled.
+func (qrs *queuedRetrySender) queueSize() (int, int) {
+	if !qrs.cfg.Enabled {
+		return 0, 0
+	}
+	return qrs.queue.Size(), qrs.queue.Capacity()
+}
+
+// shutdown is invoked during service shutdown. When the context has a deadline the queued requests are sent, and
+// retried, until the queue is drained or the deadline expires. The requests still queued or being retried are then
+// dropped, and the number of their items is recorded.
+func (qrs *queuedRetrySender) shutdown(ctx context.Context) {
+	if _, ok := ctx.Deadline(); ok && qrs.cfg.Enabled {
+		qrs.waitForDrain(ctx)
+	}
+
+	// Stop the retry goroutines, so that unblocks the queue workers.
 	close(qrs.retryStopCh)
+	qrs.adjustWG.Wait()
+	qrs.statsWG.Wait()
 
-	// Stop the queued sender, this will drain the queue and will call the retry (which is stopped) that will only
-	// try once every request.
+	// Unblock the queue workers waiting for the concurrency limiter, their requests are dropped.
+	if qrs.limiter != nil {
+		qrs.limiter.stop()
+	}
+
+	// Stop the queue workers, the requests remaining in the queue are not consumed.
 	qrs.queue.Stop()
+
+	dropped := atomic.LoadInt64(&qrs.shutdownDroppedItems) + atomic.LoadInt64(&qrs.pendingItems)
+	if dropped > 0 {
+		qrs.logger.Warn(
+			"Dropping data because the exporter was shut down before it could be sent.",
+			zap.Int64("dropped_items", dropped),
+		)
+		qrs.obsrep.RecordShutdownDroppedItems(context.Background(), int(dropped))
+	}
+}
+
+// circuitBreakerState returns the state of the circuit breaker, closed when it is disabled.
+func (qrs *queuedRetrySender) circuitBreakerState() circuitState {
+	if qrs.breaker == nil {
+		return circuitClosed
+	}
+	return qrs.breaker.currentState()
+}
+
+// waitForDrain waits until all the queued requests are consumed or the context is done.
+func (qrs *queuedRetrySender) waitForDrain(ctx context.Context) {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	for atomic.LoadInt64(&qrs.pendingRequests) > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// retriesStopped returns true once the retries are stopped at shutdown.
+func (qrs *queuedRetrySender) retriesStopped() bool {
+	select {
+	case <-qrs.retryStopCh:
+		return true
+	default:
+		return false
+	}
 }
 
 // TODO: Clean this by forcing all exporters to return an internal error type that always include the information about retries.
@@ -188,6 +495,11 @@ func NewThrottleRetry(err error, delay time.Duration) error {
 	}
 }
 
+// Unwrap returns the throttled error.
+func (t *throttleRetry) Unwrap() error {
+	return t.error
+}
+
 type retrySender struct {
 	traceAttribute trace.Attribute
 	cfg            RetrySettings
@@ -139,6 +139,63 @@ func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
 	// require.Zero(t, be.qrSender.queue.OtlpProtoSize())
 }
 
+func TestQueuedRetry_DrainOnShutdown(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg))
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	firstMockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(firstMockR))
+	})
+	secondMockR := newMockRequest(context.Background(), 3, nil)
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(secondMockR))
+	})
+
+	// The shutdown waits for the first request to be retried and for the second one to be sent.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, be.Shutdown(ctx))
+
+	firstMockR.checkNumRequests(t, 2)
+	secondMockR.checkNumRequests(t, 1)
+	ocs.checkSendItemsCount(t, 5)
+	ocs.checkDroppedItemsCount(t, 0)
+	assert.Zero(t, atomic.LoadInt64(&be.qrSender.pendingItems))
+}
+
+func TestQueuedRetry_DropOnShutdownTimeout(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The first request is retried until the shutdown timeout, the second one is still queued or fails once the
+	// retries are stopped.
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+	require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, be.Shutdown(ctx))
+
+	obsreporttest.CheckExporterShutdownDroppedItemsViews(t, defaultExporterCfg.Name(), 14)
+}
+
 func TestQueuedRetry_DoNotPreserveCancellation(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -264,6 +321,10 @@ func TestQueuedRetry_RetryOnError(t *testing.T) {
 }
 
 func TestQueuedRetry_DropOnFull(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
 	qCfg := DefaultQueueSettings()
 	qCfg.QueueSize = 0
 	rCfg := DefaultRetrySettings()
@@ -274,8 +335,132 @@ func TestQueuedRetry_DropOnFull(t *testing.T) {
 	t.Cleanup(func() {
 		assert.NoError(t, be.Shutdown(context.Background()))
 	})
-	err := be.sender.send(newMockRequest(context.Background(), 2, errors.New("transient error")))
+	err = be.sender.send(newMockRequest(context.Background(), 2, errors.New("transient error")))
 	require.Error(t, err)
+	assert.Equal(t, consumererror.ReasonQueueFull, consumererror.GetReason(err))
+	obsreporttest.CheckExporterEnqueueFailedItemsViews(t, defaultExporterCfg.Name(), 2)
+}
+
+func TestQueuedRetry_QueueSize(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 0
+	qCfg.QueueSize = 10
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(DefaultRetrySettings()), WithQueue(qCfg))
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	size, capacity := be.QueueSize()
+	assert.Equal(t, 2, size)
+	assert.Equal(t, 10, capacity)
+
+	qCfg.Enabled = false
+	disabled := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithQueue(qCfg))
+	size, capacity = disabled.QueueSize()
+	assert.Equal(t, 0, size)
+	assert.Equal(t, 0, capacity)
+}
+
+func TestQueuedRetry_QueueStats(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 0
+	qCfg.QueueSize = 10
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(DefaultRetrySettings()), WithQueue(qCfg))
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	be.qrSender.recordQueueStats()
+	obsreporttest.CheckExporterQueueViews(t, defaultExporterCfg.Name(), 0, 10, 0)
+
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	age := be.qrSender.enqueueTimes.oldestAge(time.Now().Add(time.Second))
+	assert.GreaterOrEqual(t, int64(age), int64(time.Second))
+	assert.Equal(t, 2, be.qrSender.enqueueTimes.times.Len())
+}
+
+func TestQueuedRetry_QueueWatermarks(t *testing.T) {
+	var events []QueueWatermarkEvent
+	var mu sync.Mutex
+	require.NoError(t, RegisterQueueWatermarkCallback("test", func(event QueueWatermarkEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}))
+	defer UnregisterQueueWatermarkCallback("test")
+
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 4
+	qCfg.HighWatermark = 0.5
+	qCfg.LowWatermark = 0
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(DefaultRetrySettings()), WithQueue(qCfg))
+	block := make(chan struct{})
+	be.qrSender.consumerSender = requestSenderFunc(func(req request) error {
+		<-block
+		return nil
+	})
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The consumer blocks on the first request, the next ones stay in the queue.
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 1, nil)))
+	assert.Eventually(t, func() bool { return be.qrSender.queue.Size() == 0 }, time.Second, time.Millisecond)
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 1, nil)))
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 1, nil)))
+	close(block)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []QueueWatermarkEvent{
+		{ExporterName: defaultExporterCfg.Name(), Size: 2, Capacity: 4, High: true},
+		{ExporterName: defaultExporterCfg.Name(), Size: 0, Capacity: 4, High: false},
+	}, events)
+}
+
+func TestQueueSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		high    float64
+		low     float64
+		wantErr string
+	}{
+		{name: "default", high: 0.8, low: 0.5},
+		{name: "disabled", high: 0, low: 0.5},
+		{name: "full", high: 1, low: 0},
+		{name: "negative_high", high: -0.1, low: 0, wantErr: "high_watermark -0.1 must be between 0 and 1"},
+		{name: "high_above_one", high: 1.5, low: 0.5, wantErr: "high_watermark 1.5 must be between 0 and 1"},
+		{name: "negative_low", high: 0.8, low: -0.5, wantErr: "low_watermark -0.5 must be between 0 and 1"},
+		{name: "low_equal_high", high: 0.8, low: 0.8, wantErr: "low_watermark 0.8 must be lower than high_watermark 0.8"},
+		{name: "low_above_high", high: 0.5, low: 0.8, wantErr: "low_watermark 0.8 must be lower than high_watermark 0.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qCfg := DefaultQueueSettings()
+			qCfg.HighWatermark = tt.high
+			qCfg.LowWatermark = tt.low
+			err := qCfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
 }
 
 func TestQueuedRetryHappyPath(t *testing.T) {
@@ -315,6 +500,42 @@ func TestQueuedRetryHappyPath(t *testing.T) {
 	ocs.checkDroppedItemsCount(t, 0)
 }
 
+func TestQueuedRetry_AdaptiveConcurrency(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	qCfg := DefaultQueueSettings()
+	qCfg.AdaptiveConcurrency = true
+	qCfg.NumConsumers = 20
+	qCfg.MinConsumers = 2
+	qCfg.MaxConsumers = 5
+	rCfg := DefaultRetrySettings()
+	be := newBaseExporter(defaultExporterCfg, zap.NewNop(), WithRetry(rCfg), WithQueue(qCfg))
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The initial concurrency is bounded by the maximum.
+	assert.Equal(t, 5, be.qrSender.limiter.collectStats().limit)
+	obsreporttest.CheckExporterSenderConcurrencyViews(t, defaultExporterCfg.Name(), 5)
+
+	wantRequests := 10
+	for i := 0; i < wantRequests; i++ {
+		ocs.run(func() {
+			require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+		})
+	}
+	ocs.awaitAsyncProcessing()
+
+	ocs.checkSendItemsCount(t, 2*wantRequests)
+	ocs.checkDroppedItemsCount(t, 0)
+	assert.Equal(t, wantRequests, be.qrSender.limiter.collectStats().attempts)
+}
+
 func TestNoCancellationContext(t *testing.T) {
 	deadline := time.Now().Add(1 * time.Second)
 	ctx, cancelFunc := context.WithDeadline(context.Background(), deadline)
@@ -347,6 +568,10 @@ func (mer *mockErrorRequest) count() int {
 	return 7
 }
 
+func (mer *mockErrorRequest) marshal() ([]byte, error) {
+	return nil, nil
+}
+
 func newErrorRequest(ctx context.Context) request {
 	return &mockErrorRequest{
 		baseRequest: baseRequest{ctx: ctx},
@@ -393,6 +618,10 @@ func (m *mockRequest) count() int {
 	return m.cnt
 }
 
+func (m *mockRequest) marshal() ([]byte, error) {
+	return nil, nil
+}
+
 func newMockRequest(ctx context.Context, cnt int, consumeError error) *mockRequest {
 	return &mockRequest{
 		baseRequest:  baseRequest{ctx: ctx},
@@ -402,6 +631,12 @@ func newMockRequest(ctx context.Context, cnt int, consumeError error) *mockReque
 	}
 }
 
+type requestSenderFunc func(req request) error
+
+func (f requestSenderFunc) send(req request) error {
+	return f(req)
+}
+
 type observabilityConsumerSender struct {
 	waitGroup         *sync.WaitGroup
 	sentItemsCount    int64
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// TracesMarshaler returns the bytes representing the traces of a request, so that the request can be written to a
+// persistent queue. The exporters can use it to store the traces in the format they send to their backend.
+type TracesMarshaler func(td pdata.Traces) ([]byte, error)
+
+// TracesUnmarshaler returns the traces represented by the bytes returned by the matching TracesMarshaler.
+type TracesUnmarshaler func(buf []byte) (pdata.Traces, error)
+
+// MetricsMarshaler returns the bytes representing the metrics of a request, so that the request can be written to a
+// persistent queue. The exporters can use it to store the metrics in the format they send to their backend.
+type MetricsMarshaler func(md pdata.Metrics) ([]byte, error)
+
+// MetricsUnmarshaler returns the metrics represented by the bytes returned by the matching MetricsMarshaler.
+type MetricsUnmarshaler func(buf []byte) (pdata.Metrics, error)
+
+// LogsMarshaler returns the bytes representing the logs of a request, so that the request can be written to a
+// persistent queue. The exporters can use it to store the logs in the format they send to their backend.
+type LogsMarshaler func(ld pdata.Logs) ([]byte, error)
+
+// LogsUnmarshaler returns the logs represented by the bytes returned by the matching LogsMarshaler.
+type LogsUnmarshaler func(buf []byte) (pdata.Logs, error)
+
+// requestUnmarshaler returns the request represented by the bytes returned by the marshal function of a request.
+type requestUnmarshaler func(buf []byte) (request, error)
+
+// marshalerSettings holds the marshalers and unmarshalers of the requests of the exporters, by default the data is
+// marshaled to the OTLP protobuf format.
+type marshalerSettings struct {
+	tracesMarshaler    TracesMarshaler
+	tracesUnmarshaler  TracesUnmarshaler
+	metricsMarshaler   MetricsMarshaler
+	metricsUnmarshaler MetricsUnmarshaler
+	logsMarshaler      LogsMarshaler
+	logsUnmarshaler    LogsUnmarshaler
+}
+
+func defaultMarshalerSettings() marshalerSettings {
+	return marshalerSettings{
+		tracesMarshaler:    pdata.Traces.ToOtlpProtoBytes,
+		tracesUnmarshaler:  pdata.TracesFromOtlpProtoBytes,
+		metricsMarshaler:   pdata.Metrics.ToOtlpProtoBytes,
+		metricsUnmarshaler: pdata.MetricsFromOtlpProtoBytes,
+		logsMarshaler:      pdata.Logs.ToOtlpProtoBytes,
+		logsUnmarshaler:    pdata.LogsFromOtlpProtoBytes,
+	}
+}
+
+// WithTracesMarshaler overrides the default marshaling of the requests of a traces exporter.
+// The default marshaling uses the OTLP protobuf format. The option is ignored if any of the functions is nil.
+func WithTracesMarshaler(marshaler TracesMarshaler, unmarshaler TracesUnmarshaler) Option {
+	return func(o *baseSettings) {
+		if marshaler != nil && unmarshaler != nil {
+			o.tracesMarshaler = marshaler
+			o.tracesUnmarshaler = unmarshaler
+		}
+	}
+}
+
+// WithMetricsMarshaler overrides the default marshaling of the requests of a metrics exporter.
+// The default marshaling uses the OTLP protobuf format. The option is ignored if any of the functions is nil.
+func WithMetricsMarshaler(marshaler MetricsMarshaler, unmarshaler MetricsUnmarshaler) Option {
+	return func(o *baseSettings) {
+		if marshaler != nil && unmarshaler != nil {
+			o.metricsMarshaler = marshaler
+			o.metricsUnmarshaler = unmarshaler
+		}
+	}
+}
+
+// WithLogsMarshaler overrides the default marshaling of the requests of a logs exporter.
+// The default marshaling uses the OTLP protobuf format. The option is ignored if any of the functions is nil.
+func WithLogsMarshaler(marshaler LogsMarshaler, unmarshaler LogsUnmarshaler) Option {
+	return func(o *baseSettings) {
+		if marshaler != nil && unmarshaler != nil {
+			o.logsMarshaler = marshaler
+			o.logsUnmarshaler = unmarshaler
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestTracesRequestMarshaler(t *testing.T) {
+	var pushed pdata.Traces
+	pusher := func(_ context.Context, td pdata.Traces) error {
+		pushed = td
+		return nil
+	}
+	te, err := NewTraceExporter(fakeTraceExporterConfig, zap.NewNop(), pusher)
+	require.NoError(t, err)
+	be := te.(*traceExporter).baseExporter
+
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	buf, err := newTracesRequest(context.Background(), td, pusher, be.marshalers.tracesMarshaler).marshal()
+	require.NoError(t, err)
+	want, err := td.ToOtlpProtoBytes()
+	require.NoError(t, err)
+	assert.Equal(t, want, buf)
+
+	req, err := be.qrSender.unmarshaler(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, req.count())
+	require.NoError(t, req.export(context.Background()))
+	assert.EqualValues(t, td, pushed)
+
+	_, err = be.qrSender.unmarshaler([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestMetricsRequestMarshaler(t *testing.T) {
+	var pushed pdata.Metrics
+	pusher := func(_ context.Context, md pdata.Metrics) error {
+		pushed = md
+		return nil
+	}
+	me, err := NewMetricsExporter(fakeMetricsExporterConfig, zap.NewNop(), pusher)
+	require.NoError(t, err)
+	be := me.(*metricsExporter).baseExporter
+
+	md := testdata.GenerateMetricsTwoMetrics()
+	buf, err := newMetricsRequest(context.Background(), md, pusher, be.marshalers.metricsMarshaler).marshal()
+	require.NoError(t, err)
+
+	req, err := be.qrSender.unmarshaler(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 4, req.count())
+	require.NoError(t, req.export(context.Background()))
+	assert.EqualValues(t, md, pushed)
+}
+
+func TestLogsRequestMarshaler(t *testing.T) {
+	var pushed pdata.Logs
+	pusher := func(_ context.Context, ld pdata.Logs) error {
+		pushed = ld
+		return nil
+	}
+	le, err := NewLogsExporter(fakeLogsExporterConfig, zap.NewNop(), pusher)
+	require.NoError(t, err)
+	be := le.(*logsExporter).baseExporter
+
+	ld := testdata.GenerateLogDataTwoLogsSameResource()
+	buf, err := newLogsRequest(context.Background(), ld, pusher, be.marshalers.logsMarshaler).marshal()
+	require.NoError(t, err)
+
+	req, err := be.qrSender.unmarshaler(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, req.count())
+	require.NoError(t, req.export(context.Background()))
+	assert.EqualValues(t, ld, pushed)
+}
+
+func TestWithTracesMarshaler(t *testing.T) {
+	errUnmarshal := errors.New("unmarshal error")
+	marshaler := func(td pdata.Traces) ([]byte, error) {
+		return []byte{byte(td.SpanCount())}, nil
+	}
+	unmarshaler := func(buf []byte) (pdata.Traces, error) {
+		if len(buf) != 1 {
+			return pdata.Traces{}, errUnmarshal
+		}
+		return testdata.GenerateTraceDataOneSpan(), nil
+	}
+	te, err := NewTraceExporter(fakeTraceExporterConfig, zap.NewNop(), newTraceDataPusher(nil), WithTracesMarshaler(marshaler, unmarshaler))
+	require.NoError(t, err)
+	be := te.(*traceExporter).baseExporter
+
+	buf, err := newTracesRequest(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource(), nil, be.marshalers.tracesMarshaler).marshal()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, buf)
+
+	req, err := be.qrSender.unmarshaler(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, req.count())
+
+	_, err = be.qrSender.unmarshaler(nil)
+	assert.Equal(t, errUnmarshal, err)
+}
+
+func TestWithMarshalerIgnoresNil(t *testing.T) {
+	bs := fromOptions([]Option{
+		WithTracesMarshaler(nil, pdata.TracesFromOtlpProtoBytes),
+		WithMetricsMarshaler(pdata.Metrics.ToOtlpProtoBytes, nil),
+		WithLogsMarshaler(nil, nil),
+	})
+	assert.NotNil(t, bs.tracesMarshaler)
+	assert.NotNil(t, bs.tracesUnmarshaler)
+	assert.NotNil(t, bs.metricsMarshaler)
+	assert.NotNil(t, bs.metricsUnmarshaler)
+	assert.NotNil(t, bs.logsMarshaler)
+	assert.NotNil(t, bs.logsUnmarshaler)
+}
@@ -33,22 +33,24 @@ type PushTraces func(ctx context.Context, td pdata.Traces) error
 
 type tracesRequest struct {
 	baseRequest
-	td     pdata.Traces
-	pusher PushTraces
+	td        pdata.Traces
+	pusher    PushTraces
+	marshaler TracesMarshaler
 }
 
-func newTracesRequest(ctx context.Context, td pdata.Traces, pusher PushTraces) request {
+func newTracesRequest(ctx context.Context, td pdata.Traces, pusher PushTraces, marshaler TracesMarshaler) request {
 	return &tracesRequest{
 		baseRequest: baseRequest{ctx: ctx},
 		td:          td,
 		pusher:      pusher,
+		marshaler:   marshaler,
 	}
 }
 
 func (req *tracesRequest) onError(err error) request {
 	var traceError consumererror.Traces
 	if consumererror.AsTraces(err, &traceError) {
-		return newTracesRequest(req.ctx, traceError.GetTraces(), req.pusher)
+		return newTracesRequest(req.ctx, traceError.GetTraces(), req.pusher, req.marshaler)
 	}
 	return req
 }
@@ -57,6 +59,10 @@ func (req *tracesRequest) export(ctx context.Context) error {
 	return req.pusher(ctx, req.td)
 }
 
+func (req *tracesRequest) marshal() ([]byte, error) {
+	return req.marshaler(req.td)
+}
+
 func (req *tracesRequest) count() int {
 	return req.td.SpanCount()
 }
@@ -67,7 +73,7 @@ type traceExporter struct {
 }
 
 func (texp *traceExporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
-	return texp.sender.send(newTracesRequest(ctx, td, texp.pusher))
+	return texp.sender.send(newTracesRequest(ctx, td, texp.pusher, texp.marshalers.tracesMarshaler))
 }
 
 // NewTraceExporter creates a TracesExporter that records observability metrics and wraps every request with a Span.
@@ -91,6 +97,13 @@ func NewTraceExporter(
 	}
 
 	be := newBaseExporter(cfg, logger, options...)
+	be.setRequestUnmarshaler(func(buf []byte) (request, error) {
+		td, err := be.marshalers.tracesUnmarshaler(buf)
+		if err != nil {
+			return nil, err
+		}
+		return newTracesRequest(context.Background(), td, pusher, be.marshalers.tracesMarshaler), nil
+	})
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &tracesExporterWithObservability{
 			obsrep: obsreport.NewExporter(
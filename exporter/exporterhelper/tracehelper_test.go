@@ -47,10 +47,10 @@ var (
 )
 
 func TestTracesRequest(t *testing.T) {
-	mr := newTracesRequest(context.Background(), testdata.GenerateTraceDataOneSpan(), nil)
+	mr := newTracesRequest(context.Background(), testdata.GenerateTraceDataOneSpan(), nil, nil)
 
 	traceErr := consumererror.NewTraces(errors.New("some error"), testdata.GenerateTraceDataEmpty())
-	assert.EqualValues(t, newTracesRequest(context.Background(), testdata.GenerateTraceDataEmpty(), nil), mr.onError(traceErr))
+	assert.EqualValues(t, newTracesRequest(context.Background(), testdata.GenerateTraceDataEmpty(), nil, nil), mr.onError(traceErr))
 }
 
 type testOCTraceExporter struct {
@@ -15,13 +15,74 @@
 package fileexporter
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
+const (
+	// FormatJSON writes one Protobuf JSON encoded request per line.
+	FormatJSON = "json"
+	// FormatProto writes binary Protobuf encoded requests, each prefixed by its
+	// length as a 4 byte big endian unsigned integer.
+	FormatProto = "proto"
+
+	// CompressionGzip compresses the written file using gzip.
+	CompressionGzip = "gzip"
+)
+
 // Config defines configuration for file exporter.
 type Config struct {
 	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 
 	// Path of the file to write to. Path is relative to current directory.
 	Path string `mapstructure:"path"`
+
+	// Format of the written data, either "json" (default) or "proto".
+	Format string `mapstructure:"format"`
+
+	// Compression used for the written file. The only supported value is "gzip",
+	// by default no compression is used.
+	Compression string `mapstructure:"compression"`
+
+	// Rotation defines an optional policy for rotating the written file.
+	// If not set the file grows without bounds.
+	Rotation *Rotation `mapstructure:"rotation"`
+}
+
+// Rotation defines when the written file is rotated and how many of the
+// rotated files are kept. Rotated files are renamed by adding the rotation
+// timestamp before the file extension, e.g. "data-2021-03-01T10-00-00.000000000.json".
+type Rotation struct {
+	// MaxMegabytes is the maximum size in megabytes of the file before it is rotated.
+	// Zero means the file is not rotated based on its size.
+	MaxMegabytes int `mapstructure:"max_size_mb"`
+
+	// MaxAge is the maximum amount of time a file is written to before it is rotated.
+	// Zero means the file is not rotated based on its age.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// MaxBackups is the maximum number of rotated files to retain.
+	// Zero means all rotated files are retained.
+	MaxBackups int `mapstructure:"max_backups"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks the format, the compression and the rotation settings.
+func (cfg *Config) Validate() error {
+	switch cfg.Format {
+	case FormatJSON, FormatProto:
+	default:
+		return fmt.Errorf("unsupported format %q, must be %q or %q", cfg.Format, FormatJSON, FormatProto)
+	}
+	if cfg.Compression != "" && cfg.Compression != CompressionGzip {
+		return fmt.Errorf("unsupported compression %q, must be empty or %q", cfg.Compression, CompressionGzip)
+	}
+	if r := cfg.Rotation; r != nil && (r.MaxMegabytes < 0 || r.MaxAge < 0 || r.MaxBackups < 0) {
+		return errors.New("rotation settings must not be negative")
+	}
+	return nil
 }
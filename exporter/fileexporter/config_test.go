@@ -17,6 +17,7 @@ package fileexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,6 +48,24 @@ func TestLoadConfig(t *testing.T) {
 				NameVal: "file/2",
 				TypeVal: "file",
 			},
-			Path: "./filename.json",
+			Path:   "./filename.json",
+			Format: FormatJSON,
+		})
+
+	e2 := cfg.Exporters["file/3"]
+	assert.Equal(t, e2,
+		&Config{
+			ExporterSettings: configmodels.ExporterSettings{
+				NameVal: "file/3",
+				TypeVal: "file",
+			},
+			Path:        "./filename.pb.gz",
+			Format:      FormatProto,
+			Compression: CompressionGzip,
+			Rotation: &Rotation{
+				MaxMegabytes: 10,
+				MaxAge:       time.Hour,
+				MaxBackups:   3,
+			},
 		})
 }
@@ -16,7 +16,6 @@ package fileexporter
 
 import (
 	"context"
-	"os"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -44,6 +43,7 @@ func createDefaultConfig() configmodels.Exporter {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
+		Format: FormatJSON,
 	}
 }
 
@@ -81,11 +81,14 @@ func createExporter(config configmodels.Exporter) (*fileExporter, error) {
 	exporter, ok := exporters[cfg]
 
 	if !ok {
-		file, err := os.OpenFile(cfg.Path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+		file, err := newFileWriter(cfg)
 		if err != nil {
 			return nil, err
 		}
-		exporter = &fileExporter{file: file}
+		exporter = &fileExporter{file: file, format: cfg.Format}
 
 		// Remember the receiver in the map
 		exporters[cfg] = exporter
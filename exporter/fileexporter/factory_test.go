@@ -62,3 +62,24 @@ func TestCreateLogsExporter(t *testing.T) {
 	assert.Error(t, err)
 	require.Nil(t, exp)
 }
+
+func TestCreateExporterInvalidConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = "./filename.json"
+	cfg.Format = "xml"
+	exp, err := createTraceExporter(
+		context.Background(),
+		component.ExporterCreateParams{Logger: zap.NewNop()},
+		cfg)
+	assert.Error(t, err)
+	require.Nil(t, exp)
+
+	cfg.Format = FormatJSON
+	cfg.Compression = "zstd"
+	exp, err = createTraceExporter(
+		context.Background(),
+		component.ExporterCreateParams{Logger: zap.NewNop()},
+		cfg)
+	assert.Error(t, err)
+	require.Nil(t, exp)
+}
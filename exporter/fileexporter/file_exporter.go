@@ -15,7 +15,9 @@
 package fileexporter
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"io"
 	"sync"
 
@@ -31,36 +33,58 @@ import (
 var marshaler = &jsonpb.Marshaler{}
 
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
-// in Protobuf-JSON format.
+// in Protobuf-JSON or length-prefixed binary Protobuf format.
 type fileExporter struct {
-	file  io.WriteCloser
-	mutex sync.Mutex
+	file   io.WriteCloser
+	format string
+	mutex  sync.Mutex
 }
 
 func (e *fileExporter) ConsumeTraces(_ context.Context, td pdata.Traces) error {
-	return exportMessageAsLine(e, internal.TracesToOtlp(td.InternalRep()))
+	return exportMessage(e, internal.TracesToOtlp(td.InternalRep()))
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pdata.Metrics) error {
-	return exportMessageAsLine(e, internal.MetricsToOtlp(md.InternalRep()))
+	return exportMessage(e, internal.MetricsToOtlp(md.InternalRep()))
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
 	request := internal.LogsToOtlp(ld.InternalRep())
-	return exportMessageAsLine(e, request)
+	return exportMessage(e, request)
 }
 
-func exportMessageAsLine(e *fileExporter, message proto.Message) error {
+func exportMessage(e *fileExporter, message proto.Message) error {
+	buf, err := marshalMessage(e.format, message)
+	if err != nil {
+		return err
+	}
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if err := marshaler.Marshal(e.file, message); err != nil {
-		return err
+	// Write every message with a single call so that the file is never rotated
+	// in the middle of a message.
+	_, err = e.file.Write(buf)
+	return err
+}
+
+func marshalMessage(format string, message proto.Message) ([]byte, error) {
+	if format == FormatProto {
+		data, err := proto.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, 4+len(data))
+		binary.BigEndian.PutUint32(buf, uint32(len(data)))
+		copy(buf[4:], data)
+		return buf, nil
 	}
-	if _, err := io.WriteString(e.file, "\n"); err != nil {
-		return err
+
+	buf := &bytes.Buffer{}
+	if err := marshaler.Marshal(buf, message); err != nil {
+		return nil, err
 	}
-	return nil
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
 }
 
 func (e *fileExporter) Start(context.Context, component.Host) error {
@@ -15,10 +15,12 @@ package fileexporter
 
 import (
 	"context"
+	"encoding/binary"
 	"testing"
 	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -219,3 +221,24 @@ func TestFileLogsExporterErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestFileTraceExporterProtoFormat(t *testing.T) {
+	mf := &testutil.LimitedWriter{}
+	fe := &fileExporter{file: mf, format: FormatProto}
+
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	buf := mf.Bytes()
+	for i := 0; i < 2; i++ {
+		require.True(t, len(buf) > 4)
+		size := binary.BigEndian.Uint32(buf)
+		got := &collectortrace.ExportTraceServiceRequest{}
+		require.NoError(t, proto.Unmarshal(buf[4:4+size], got))
+		assert.EqualValues(t, internal.TracesToOtlp(td.InternalRep()), got)
+		buf = buf[4+size:]
+	}
+	assert.Len(t, buf, 0)
+}
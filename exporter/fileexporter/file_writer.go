@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is the layout of the timestamp added to the name of rotated files.
+// It sorts lexicographically in the same order as time.
+const backupTimeFormat = "2006-01-02T15-04-05.000000000"
+
+// fileWriter is an io.WriteCloser that writes to a file, optionally compressing
+// the data and rotating the file based on its size and age.
+// Every call to Write is expected to contain one complete message, rotation only
+// happens between calls to Write so messages are never split across files.
+type fileWriter struct {
+	path        string
+	compression string
+	maxSize     int64
+	maxAge      time.Duration
+	maxBackups  int
+
+	file     *os.File
+	gz       *gzip.Writer
+	size     int64
+	openedAt time.Time
+
+	// now is used to allow tests to control time.
+	now func() time.Time
+}
+
+func newFileWriter(cfg *Config) (*fileWriter, error) {
+	fw := &fileWriter{
+		path:        cfg.Path,
+		compression: cfg.Compression,
+		now:         time.Now,
+	}
+	if cfg.Rotation != nil {
+		fw.maxSize = int64(cfg.Rotation.MaxMegabytes) * 1024 * 1024
+		fw.maxAge = cfg.Rotation.MaxAge
+		fw.maxBackups = cfg.Rotation.MaxBackups
+	}
+	if err := fw.open(); err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
+
+func (fw *fileWriter) open() error {
+	file, err := os.OpenFile(fw.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	fw.file = file
+	fw.size = 0
+	fw.openedAt = fw.now()
+	if fw.compression == CompressionGzip {
+		fw.gz = gzip.NewWriter(countingWriter{w: file, n: &fw.size})
+	}
+	return nil
+}
+
+// Write writes p to the current file, rotating the file first if required.
+func (fw *fileWriter) Write(p []byte) (int, error) {
+	if fw.shouldRotate(len(p)) {
+		if err := fw.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	if fw.gz == nil {
+		n, err := fw.file.Write(p)
+		fw.size += int64(n)
+		return n, err
+	}
+
+	n, err := fw.gz.Write(p)
+	if err != nil {
+		return n, err
+	}
+	// Flush after every message so that the file is always readable up to the
+	// last complete message, even if the collector is not cleanly shutdown.
+	return n, fw.gz.Flush()
+}
+
+func (fw *fileWriter) shouldRotate(next int) bool {
+	if fw.size == 0 {
+		return false
+	}
+	if fw.maxSize > 0 && fw.size+int64(next) > fw.maxSize {
+		return true
+	}
+	return fw.maxAge > 0 && fw.now().Sub(fw.openedAt) >= fw.maxAge
+}
+
+func (fw *fileWriter) rotate() error {
+	if err := fw.closeFile(); err != nil {
+		return err
+	}
+	if err := os.Rename(fw.path, backupName(fw.path, fw.now())); err != nil {
+		return err
+	}
+	if err := fw.removeOldBackups(); err != nil {
+		return err
+	}
+	return fw.open()
+}
+
+func (fw *fileWriter) removeOldBackups() error {
+	if fw.maxBackups <= 0 {
+		return nil
+	}
+	files, err := backups(fw.path)
+	if err != nil {
+		return err
+	}
+	for len(files) > fw.maxBackups {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+func (fw *fileWriter) closeFile() error {
+	if fw.gz != nil {
+		if err := fw.gz.Close(); err != nil {
+			return err
+		}
+		fw.gz = nil
+	}
+	return fw.file.Close()
+}
+
+// Close closes the current file.
+func (fw *fileWriter) Close() error {
+	return fw.closeFile()
+}
+
+// backupName returns the name of the rotated file for path at time t.
+func backupName(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(path, ext)
+	return prefix + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+// backups returns the rotated files of the given path, ordered from the oldest
+// to the newest. The active file itself is not included.
+func backups(path string) ([]string, error) {
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(path, ext) + "-"
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, m := range matches {
+		ts := strings.TrimSuffix(strings.TrimPrefix(m, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, ts); err == nil {
+			backups = append(backups, m)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// countingWriter counts the bytes written to the wrapped io.Writer.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (cw countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	*cw.n += int64(n)
+	return n, err
+}
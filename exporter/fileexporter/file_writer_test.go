@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWriterRotateBySize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileexporter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "data.json")
+	fw, err := newFileWriter(&Config{Path: path, Rotation: &Rotation{MaxBackups: 2}})
+	require.NoError(t, err)
+	// Use a size limit smaller than a megabyte to keep the test fast.
+	fw.maxSize = 10
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	fw.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	for _, msg := range []string{"message1\n", "message2\n", "message3\n", "message4\n"} {
+		_, err = fw.Write([]byte(msg))
+		require.NoError(t, err)
+	}
+	require.NoError(t, fw.Close())
+
+	files, err := backups(path)
+	require.NoError(t, err)
+	// The oldest backup is removed because of the max_backups setting.
+	require.Len(t, files, 2)
+	assert.Equal(t, filepath.Join(dir, "data-2021-03-01T10-00-03.000000000.json"), files[0])
+	assert.Equal(t, filepath.Join(dir, "data-2021-03-01T10-00-05.000000000.json"), files[1])
+
+	content, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, "message2\n", string(content))
+	content, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "message4\n", string(content))
+}
+
+func TestFileWriterRotateByAge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileexporter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "data.json")
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	fw, err := newFileWriter(&Config{Path: path, Rotation: &Rotation{MaxAge: time.Minute}})
+	require.NoError(t, err)
+	fw.now = func() time.Time { return now }
+	fw.openedAt = now
+
+	_, err = fw.Write([]byte("message1\n"))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = fw.Write([]byte("message2\n"))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = fw.Write([]byte("message3\n"))
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	files, err := backups(path)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	content, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, "message1\nmessage2\n", string(content))
+	content, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "message3\n", string(content))
+}
+
+func TestFileWriterGzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileexporter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "data.json.gz")
+	fw, err := newFileWriter(&Config{Path: path, Compression: CompressionGzip})
+	require.NoError(t, err)
+	_, err = fw.Write([]byte("message1\n"))
+	require.NoError(t, err)
+	assert.Greater(t, fw.size, int64(0))
+	_, err = fw.Write([]byte("message2\n"))
+	require.NoError(t, err)
+	require.NoError(t, fw.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, "message1\nmessage2\n", string(content))
+}
+
+func TestBackupsIgnoresUnrelatedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileexporter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "data.json")
+	for _, name := range []string{"data.json", "data-other.json", "data-2021-03-01T10-00-00.000000000.json"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), nil, 0600))
+	}
+	files, err := backups(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "data-2021-03-01T10-00-00.000000000.json")}, files)
+}
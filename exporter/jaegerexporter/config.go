@@ -15,6 +15,8 @@
 package jaegerexporter
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -29,3 +31,14 @@ type Config struct {
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 }
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the endpoint is set and that the queue settings are valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		// TODO: Improve error message, see #215
+		return fmt.Errorf("%q config requires a non-empty \"endpoint\"", cfg.Name())
+	}
+	return cfg.QueueSettings.Validate()
+}
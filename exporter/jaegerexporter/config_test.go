@@ -44,7 +44,11 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 
 	e0 := cfg.Exporters["jaeger"]
-	assert.Equal(t, e0, factory.CreateDefaultConfig())
+
+	// Endpoint doesn't have a default value so set it directly.
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "localhost:14250"
+	assert.Equal(t, defaultCfg, e0)
 
 	e1 := cfg.Exporters["jaeger/2"]
 	assert.Equal(t, e1,
@@ -63,9 +67,13 @@ func TestLoadConfig(t *testing.T) {
 				MaxElapsedTime:  10 * time.Minute,
 			},
 			QueueSettings: exporterhelper.QueueSettings{
-				Enabled:      true,
-				NumConsumers: 2,
-				QueueSize:    10,
+				Enabled:       true,
+				NumConsumers:  2,
+				QueueSize:     10,
+				MinConsumers:  1,
+				MaxConsumers:  50,
+				HighWatermark: 0.8,
+				LowWatermark:  0.5,
 			},
 			GRPCClientSettings: configgrpc.GRPCClientSettings{
 				Endpoint:        "a.new.target:1234",
@@ -79,3 +87,11 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, te)
 }
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "\"jaeger\" config requires a non-empty \"endpoint\"")
+
+	cfg.Endpoint = "some.target.org:12345"
+	assert.NoError(t, cfg.Validate())
+}
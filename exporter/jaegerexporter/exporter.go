@@ -29,6 +29,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -44,6 +45,7 @@ func newTraceExporter(cfg *Config, logger *zap.Logger) (component.TracesExporter
 	if err != nil {
 		return nil, err
 	}
+	opts = append(opts, configgrpc.RegisteredDialOptions(typeStr)...)
 
 	conn, err := grpc.Dial(cfg.GRPCClientSettings.Endpoint, opts...)
 	if err != nil {
@@ -16,7 +16,6 @@ package jaegerexporter
 
 import (
 	"context"
-	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -60,14 +59,6 @@ func createTraceExporter(
 ) (component.TracesExporter, error) {
 
 	expCfg := config.(*Config)
-	if expCfg.Endpoint == "" {
-		// TODO: Improve error message, see #215
-		err := fmt.Errorf(
-			"%q config requires a non-empty \"endpoint\"",
-			expCfg.Name())
-		return nil, err
-	}
-
 	exp, err := newTraceExporter(expCfg, params.Logger)
 	if err != nil {
 		return nil, err
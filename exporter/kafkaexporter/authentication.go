@@ -44,8 +44,19 @@ type SASLConfig struct {
 	Username string `mapstructure:"username"`
 	// Password to be used on authentication
 	Password string `mapstructure:"password"`
-	// SASL Mechanism to be used, possible values are: (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512).
+	// SASL Mechanism to be used, possible values are: (PLAIN, SCRAM-SHA-256, SCRAM-SHA-512, OAUTHBEARER or AWS_MSK_IAM).
 	Mechanism string `mapstructure:"mechanism"`
+	// TokenProvider is the full name of the extension providing the access tokens of the OAUTHBEARER mechanism, for
+	// instance "oauth2client".
+	TokenProvider string `mapstructure:"token_provider"`
+	// AWSMSK holds the settings of the AWS_MSK_IAM mechanism.
+	AWSMSK AWSMSKConfig `mapstructure:"aws_msk"`
+}
+
+// AWSMSKConfig defines the configuration of the AWS MSK IAM authentication.
+type AWSMSKConfig struct {
+	// Region is the AWS region of the MSK cluster.
+	Region string `mapstructure:"region"`
 }
 
 // KerberosConfig defines kereros configuration.
@@ -88,6 +99,28 @@ func configurePlaintext(config PlainTextConfig, saramaConfig *sarama.Config) {
 }
 
 func configureSASL(config SASLConfig, saramaConfig *sarama.Config) error {
+	switch config.Mechanism {
+	case "OAUTHBEARER":
+		if config.TokenProvider == "" {
+			return fmt.Errorf("token_provider have to be provided")
+		}
+		saramaConfig.Net.SASL.Enable = true
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		saramaConfig.Net.SASL.TokenProvider = &extensionTokenProvider{name: config.TokenProvider}
+		return nil
+	case "AWS_MSK_IAM":
+		if config.AWSMSK.Region == "" {
+			return fmt.Errorf("aws_msk region have to be provided")
+		}
+		tokenProvider, err := newMSKTokenProvider(config.AWSMSK.Region)
+		if err != nil {
+			return err
+		}
+		saramaConfig.Net.SASL.Enable = true
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		saramaConfig.Net.SASL.TokenProvider = tokenProvider
+		return nil
+	}
 
 	if config.Username == "" {
 		return fmt.Errorf("username have to be provided")
@@ -111,7 +144,7 @@ func configureSASL(config SASLConfig, saramaConfig *sarama.Config) error {
 	case "PLAIN":
 		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
 	default:
-		return fmt.Errorf("invalid SASL Mechanism %q: can be either \"PLAIN\" , \"SCRAM-SHA-256\" or \"SCRAM-SHA-512\"", config.Mechanism)
+		return fmt.Errorf("invalid SASL Mechanism %q: can be either \"PLAIN\", \"SCRAM-SHA-256\", \"SCRAM-SHA-512\", \"OAUTHBEARER\" or \"AWS_MSK_IAM\"", config.Mechanism)
 	}
 
 	return nil
@@ -49,6 +49,15 @@ func TestAuthentication(t *testing.T) {
 
 	saramaSASLPLAINConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
 
+	saramaSASLOAuthConfig := &sarama.Config{}
+	saramaSASLOAuthConfig.Net.SASL.Enable = true
+	saramaSASLOAuthConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+	saramaSASLOAuthConfig.Net.SASL.TokenProvider = &extensionTokenProvider{name: "oauth2client"}
+
+	saramaSASLAWSMSKConfig := &sarama.Config{}
+	saramaSASLAWSMSKConfig.Net.SASL.Enable = true
+	saramaSASLAWSMSKConfig.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+
 	saramaTLSCfg := &sarama.Config{}
 	saramaTLSCfg.Net.TLS.Enable = true
 	tlsClient := configtls.TLSClientSetting{}
@@ -109,6 +118,24 @@ func TestAuthentication(t *testing.T) {
 			auth:         Authentication{SASL: &SASLConfig{Username: "jdoe", Password: "pass", Mechanism: "PLAIN"}},
 			saramaConfig: saramaSASLPLAINConfig,
 		},
+		{
+			auth:         Authentication{SASL: &SASLConfig{Mechanism: "OAUTHBEARER", TokenProvider: "oauth2client"}},
+			saramaConfig: saramaSASLOAuthConfig,
+		},
+		{
+			auth:         Authentication{SASL: &SASLConfig{Mechanism: "OAUTHBEARER"}},
+			saramaConfig: saramaSASLOAuthConfig,
+			err:          "token_provider have to be provided",
+		},
+		{
+			auth:         Authentication{SASL: &SASLConfig{Mechanism: "AWS_MSK_IAM", AWSMSK: AWSMSKConfig{Region: "us-east-1"}}},
+			saramaConfig: saramaSASLAWSMSKConfig,
+		},
+		{
+			auth:         Authentication{SASL: &SASLConfig{Mechanism: "AWS_MSK_IAM"}},
+			saramaConfig: saramaSASLAWSMSKConfig,
+			err:          "aws_msk region have to be provided",
+		},
 		{
 			auth:         Authentication{SASL: &SASLConfig{Username: "jdoe", Password: "pass", Mechanism: "SCRAM-SHA-222"}},
 			saramaConfig: saramaSASLSCRAM512Config,
@@ -135,6 +162,9 @@ func TestAuthentication(t *testing.T) {
 			} else {
 				// equalizes SCRAMClientGeneratorFunc to do assertion with the same reference.
 				config.Net.SASL.SCRAMClientGeneratorFunc = test.saramaConfig.Net.SASL.SCRAMClientGeneratorFunc
+				if _, ok := config.Net.SASL.TokenProvider.(*mskTokenProvider); ok {
+					config.Net.SASL.TokenProvider = nil
+				}
 				assert.Equal(t, test.saramaConfig, config)
 			}
 		})
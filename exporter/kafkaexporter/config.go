@@ -32,9 +32,14 @@ type Config struct {
 	Brokers []string `mapstructure:"brokers"`
 	// Kafka protocol version
 	ProtocolVersion string `mapstructure:"protocol_version"`
-	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
+	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics).
+	// The name can contain {attribute} placeholders replaced by the value of the resource attributes,
+	// e.g. otlp_spans_{service.name}.
 	Topic string `mapstructure:"topic"`
 
+	// MessageKey defines the key of the produced messages, used by Kafka to choose the partition.
+	MessageKey MessageKey `mapstructure:"message_key"`
+
 	// Encoding of messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 
@@ -44,6 +49,42 @@ type Config struct {
 
 	// Authentication defines used authentication mechanism.
 	Authentication Authentication `mapstructure:"auth"`
+
+	// Producer defines the tuning of the Kafka producer.
+	Producer Producer `mapstructure:"producer"`
+}
+
+// Producer defines the batching, compression and delivery settings of the Kafka producer.
+type Producer struct {
+	// RequiredAcks is the number of acknowledgements required from the brokers: 0 for no acknowledgement,
+	// 1 to wait for the leader (default) or -1 to wait for all the in-sync replicas.
+	RequiredAcks int `mapstructure:"required_acks"`
+
+	// Compression is the compression codec of the messages: none (default), gzip, snappy, lz4 or zstd.
+	Compression string `mapstructure:"compression"`
+
+	// Idempotent enables the idempotent producer, writing each message exactly once per partition. It requires
+	// required_acks -1 and a protocol_version of at least 0.11.0.
+	Idempotent bool `mapstructure:"idempotent"`
+
+	// FlushBytes is the best-effort number of bytes triggering the send of a batch of messages (default 0: not set).
+	FlushBytes int `mapstructure:"flush_bytes"`
+
+	// FlushMessages is the best-effort number of messages triggering the send of a batch (default 0: not set).
+	FlushMessages int `mapstructure:"flush_messages"`
+
+	// FlushFrequency is the best-effort frequency at which the batches are sent (default 0: not set).
+	FlushFrequency time.Duration `mapstructure:"flush_frequency"`
+}
+
+// MessageKey defines how the key of the messages is set.
+type MessageKey struct {
+	// Source of the key: empty for no key (default), "trace_id" to key traces by trace ID,
+	// or "resource_attribute" to key the messages by the value of Attribute.
+	Source string `mapstructure:"source"`
+
+	// Attribute is the resource attribute used as key when Source is "resource_attribute".
+	Attribute string `mapstructure:"attribute"`
 }
 
 // Metadata defines configuration for retrieving metadata from the broker.
@@ -54,11 +54,18 @@ func TestLoadConfig(t *testing.T) {
 			MaxElapsedTime:  10 * time.Minute,
 		},
 		QueueSettings: exporterhelper.QueueSettings{
-			Enabled:      true,
-			NumConsumers: 2,
-			QueueSize:    10,
+			Enabled:       true,
+			NumConsumers:  2,
+			QueueSize:     10,
+			MinConsumers:  1,
+			MaxConsumers:  50,
+			HighWatermark: 0.8,
+			LowWatermark:  0.5,
+		},
+		Topic: "spans_{service.name}",
+		MessageKey: MessageKey{
+			Source: MessageKeyTraceID,
 		},
-		Topic:    "spans",
 		Encoding: "otlp_proto",
 		Brokers:  []string{"foo:123", "bar:456"},
 		Authentication: Authentication{
@@ -74,5 +81,13 @@ func TestLoadConfig(t *testing.T) {
 				Backoff: defaultMetadataRetryBackoff,
 			},
 		},
+		Producer: Producer{
+			RequiredAcks:   -1,
+			Compression:    "zstd",
+			Idempotent:     true,
+			FlushBytes:     1048576,
+			FlushMessages:  1000,
+			FlushFrequency: 100 * time.Millisecond,
+		},
 	}, c)
 }
@@ -35,6 +35,9 @@ const (
 	defaultMetadataRetryBackoff = time.Millisecond * 250
 	// default from sarama.NewConfig()
 	defaultMetadataFull = true
+	// wait only for the leader to commit the messages, default from sarama.NewConfig()
+	defaultProducerRequiredAcks = 1
+	defaultProducerCompression  = "none"
 )
 
 // FactoryOption applies changes to kafkaExporterFactory.
@@ -85,6 +88,10 @@ func createDefaultConfig() configmodels.Exporter {
 				Backoff: defaultMetadataRetryBackoff,
 			},
 		},
+		Producer: Producer{
+			RequiredAcks: defaultProducerRequiredAcks,
+			Compression:  defaultProducerCompression,
+		},
 	}
 }
 
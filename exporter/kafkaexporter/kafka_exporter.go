@@ -17,8 +17,11 @@ package kafkaexporter
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -26,26 +29,40 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
+var (
+	errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
+	errTraceIDKeyMetrics    = fmt.Errorf("message_key source %q is only valid for traces", MessageKeyTraceID)
+	errUnknownCompression   = fmt.Errorf("unknown compression, must be none, gzip, snappy, lz4 or zstd")
+
+	compressionCodecs = map[string]sarama.CompressionCodec{
+		"":       sarama.CompressionNone,
+		"none":   sarama.CompressionNone,
+		"gzip":   sarama.CompressionGZIP,
+		"snappy": sarama.CompressionSnappy,
+		"lz4":    sarama.CompressionLZ4,
+		"zstd":   sarama.CompressionZSTD,
+	}
+)
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer   sarama.SyncProducer
-	topic      string
-	marshaller TracesMarshaller
-	logger     *zap.Logger
+	name        string
+	producer    sarama.SyncProducer
+	partitioner *partitioner
+	marshaller  TracesMarshaller
+	logger      *zap.Logger
 }
 
-func (e *kafkaTracesProducer) traceDataPusher(_ context.Context, td pdata.Traces) error {
-	messages, err := e.marshaller.Marshal(td)
-	if err != nil {
-		return consumererror.Permanent(err)
-	}
-	err = e.producer.SendMessages(producerMessages(messages, e.topic))
-	if err != nil {
-		return err
+func (e *kafkaTracesProducer) traceDataPusher(ctx context.Context, td pdata.Traces) error {
+	var allMessages []*sarama.ProducerMessage
+	for _, part := range e.partitioner.partitionTraces(td) {
+		messages, err := e.marshaller.Marshal(part.traces)
+		if err != nil {
+			return consumererror.Permanent(err)
+		}
+		allMessages = append(allMessages, producerMessages(messages, part.partitionKey)...)
 	}
-	return nil
+	return sendMessages(ctx, e.producer, e.name, allMessages)
 }
 
 func (e *kafkaTracesProducer) Close(context.Context) error {
@@ -54,35 +71,46 @@ func (e *kafkaTracesProducer) Close(context.Context) error {
 
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
-	producer   sarama.SyncProducer
-	topic      string
-	marshaller MetricsMarshaller
-	logger     *zap.Logger
+	name        string
+	producer    sarama.SyncProducer
+	partitioner *partitioner
+	marshaller  MetricsMarshaller
+	logger      *zap.Logger
 }
 
-func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pdata.Metrics) error {
-	messages, err := e.marshaller.Marshal(md)
-	if err != nil {
-		return consumererror.Permanent(err)
-	}
-	err = e.producer.SendMessages(producerMessages(messages, e.topic))
-	if err != nil {
-		return err
+func (e *kafkaMetricsProducer) metricsDataPusher(ctx context.Context, md pdata.Metrics) error {
+	var allMessages []*sarama.ProducerMessage
+	for _, part := range e.partitioner.partitionMetrics(md) {
+		messages, err := e.marshaller.Marshal(part.metrics)
+		if err != nil {
+			return consumererror.Permanent(err)
+		}
+		allMessages = append(allMessages, producerMessages(messages, part.partitionKey)...)
 	}
-	return nil
+	return sendMessages(ctx, e.producer, e.name, allMessages)
 }
 
 func (e *kafkaMetricsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
+// sendMessages sends the messages and records the latency of the produce request.
+func sendMessages(ctx context.Context, producer sarama.SyncProducer, name string, messages []*sarama.ProducerMessage) error {
+	start := time.Now()
+	err := producer.SendMessages(messages)
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Insert(tagInstanceName, name)},
+		statProduceLatency.M(float64(time.Since(start))/float64(time.Millisecond)))
+	return err
+}
+
 func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	c := sarama.NewConfig()
 	// These setting are required by the sarama.SyncProducer implementation.
 	c.Producer.Return.Successes = true
 	c.Producer.Return.Errors = true
-	// Wait only the local commit to succeed before responding.
-	c.Producer.RequiredAcks = sarama.WaitForLocal
+	if err := configureProducer(config, c); err != nil {
+		return nil, err
+	}
 	// Because sarama does not accept a Context for every message, set the Timeout here.
 	c.Producer.Timeout = config.Timeout
 	c.Metadata.Full = config.Metadata.Full
@@ -105,21 +133,59 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	return producer, nil
 }
 
+// configureProducer applies the producer settings to the sarama config, the combinations of settings not supported
+// by Kafka, such as an idempotent producer not waiting for all the replicas, are reported when the producer is
+// created.
+func configureProducer(config Config, c *sarama.Config) error {
+	producer := config.Producer
+	codec, ok := compressionCodecs[producer.Compression]
+	if !ok {
+		return errUnknownCompression
+	}
+	c.Producer.Compression = codec
+	c.Producer.RequiredAcks = sarama.RequiredAcks(producer.RequiredAcks)
+	if producer.Idempotent {
+		c.Producer.Idempotent = true
+		// The idempotent producer requires the requests to a broker to be sent one at a time.
+		c.Net.MaxOpenRequests = 1
+	}
+	c.Producer.Flush.Bytes = producer.FlushBytes
+	c.Producer.Flush.Messages = producer.FlushMessages
+	c.Producer.Flush.Frequency = producer.FlushFrequency
+
+	// The retries of the produce requests are counted when sarama computes their backoff.
+	backoff := c.Producer.Retry.Backoff
+	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, config.Name())}
+	c.Producer.Retry.BackoffFunc = func(int, int) time.Duration {
+		_ = stats.RecordWithTags(context.Background(), statsTags, statProduceRetries.M(1))
+		return backoff
+	}
+	return nil
+}
+
 func newMetricsExporter(config Config, params component.ExporterCreateParams, marshallers map[string]MetricsMarshaller) (*kafkaMetricsProducer, error) {
 	marshaller := marshallers[config.Encoding]
 	if marshaller == nil {
 		return nil, errUnrecognizedEncoding
 	}
+	if config.MessageKey.Source == MessageKeyTraceID {
+		return nil, errTraceIDKeyMetrics
+	}
+	part, err := newPartitioner(config)
+	if err != nil {
+		return nil, err
+	}
 	producer, err := newSaramaProducer(config)
 	if err != nil {
 		return nil, err
 	}
 
 	return &kafkaMetricsProducer{
-		producer:   producer,
-		topic:      config.Topic,
-		marshaller: marshaller,
-		logger:     params.Logger,
+		name:        config.Name(),
+		producer:    producer,
+		partitioner: part,
+		marshaller:  marshaller,
+		logger:      params.Logger,
 	}, nil
 
 }
@@ -130,25 +196,33 @@ func newTracesExporter(config Config, params component.ExporterCreateParams, mar
 	if marshaller == nil {
 		return nil, errUnrecognizedEncoding
 	}
+	part, err := newPartitioner(config)
+	if err != nil {
+		return nil, err
+	}
 	producer, err := newSaramaProducer(config)
 	if err != nil {
 		return nil, err
 	}
 	return &kafkaTracesProducer{
-		producer:   producer,
-		topic:      config.Topic,
-		marshaller: marshaller,
-		logger:     params.Logger,
+		name:        config.Name(),
+		producer:    producer,
+		partitioner: part,
+		marshaller:  marshaller,
+		logger:      params.Logger,
 	}, nil
 }
 
-func producerMessages(messages []Message, topic string) []*sarama.ProducerMessage {
+func producerMessages(messages []Message, pk partitionKey) []*sarama.ProducerMessage {
 	producerMessages := make([]*sarama.ProducerMessage, len(messages))
 	for i := range messages {
 		producerMessages[i] = &sarama.ProducerMessage{
-			Topic: topic,
+			Topic: pk.topic,
 			Value: sarama.ByteEncoder(messages[i].Value),
 		}
+		if pk.key != "" {
+			producerMessages[i].Key = sarama.StringEncoder(pk.key)
+		}
 	}
 	return producerMessages
 }
@@ -18,11 +18,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/Shopify/sarama/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -97,8 +100,9 @@ func TestTraceDataPusher(t *testing.T) {
 	producer.ExpectSendMessageAndSucceed()
 
 	p := kafkaTracesProducer{
-		producer:   producer,
-		marshaller: &otlpTracesPbMarshaller{},
+		partitioner: newTestPartitioner(t, Config{}),
+		producer:    producer,
+		marshaller:  &otlpTracesPbMarshaller{},
 	}
 	t.Cleanup(func() {
 		require.NoError(t, p.Close(context.Background()))
@@ -114,9 +118,10 @@ func TestTraceDataPusher_err(t *testing.T) {
 	producer.ExpectSendMessageAndFail(expErr)
 
 	p := kafkaTracesProducer{
-		producer:   producer,
-		marshaller: &otlpTracesPbMarshaller{},
-		logger:     zap.NewNop(),
+		partitioner: newTestPartitioner(t, Config{}),
+		producer:    producer,
+		marshaller:  &otlpTracesPbMarshaller{},
+		logger:      zap.NewNop(),
 	}
 	t.Cleanup(func() {
 		require.NoError(t, p.Close(context.Background()))
@@ -129,8 +134,9 @@ func TestTraceDataPusher_err(t *testing.T) {
 func TestTraceDataPusher_marshall_error(t *testing.T) {
 	expErr := fmt.Errorf("failed to marshall")
 	p := kafkaTracesProducer{
-		marshaller: &tracesErrorMarshaller{err: expErr},
-		logger:     zap.NewNop(),
+		partitioner: newTestPartitioner(t, Config{}),
+		marshaller:  &tracesErrorMarshaller{err: expErr},
+		logger:      zap.NewNop(),
 	}
 	td := testdata.GenerateTraceDataTwoSpansSameResource()
 	err := p.traceDataPusher(context.Background(), td)
@@ -144,8 +150,9 @@ func TestMetricsDataPusher(t *testing.T) {
 	producer.ExpectSendMessageAndSucceed()
 
 	p := kafkaMetricsProducer{
-		producer:   producer,
-		marshaller: &otlpMetricsPbMarshaller{},
+		partitioner: newTestPartitioner(t, Config{}),
+		producer:    producer,
+		marshaller:  &otlpMetricsPbMarshaller{},
 	}
 	t.Cleanup(func() {
 		require.NoError(t, p.Close(context.Background()))
@@ -161,9 +168,10 @@ func TestMetricsDataPusher_err(t *testing.T) {
 	producer.ExpectSendMessageAndFail(expErr)
 
 	p := kafkaMetricsProducer{
-		producer:   producer,
-		marshaller: &otlpMetricsPbMarshaller{},
-		logger:     zap.NewNop(),
+		partitioner: newTestPartitioner(t, Config{}),
+		producer:    producer,
+		marshaller:  &otlpMetricsPbMarshaller{},
+		logger:      zap.NewNop(),
 	}
 	t.Cleanup(func() {
 		require.NoError(t, p.Close(context.Background()))
@@ -176,8 +184,9 @@ func TestMetricsDataPusher_err(t *testing.T) {
 func TestMetricsDataPusher_marshal_error(t *testing.T) {
 	expErr := fmt.Errorf("failed to marshall")
 	p := kafkaMetricsProducer{
-		marshaller: &metricsErrorMarshaller{err: expErr},
-		logger:     zap.NewNop(),
+		partitioner: newTestPartitioner(t, Config{}),
+		marshaller:  &metricsErrorMarshaller{err: expErr},
+		logger:      zap.NewNop(),
 	}
 	md := testdata.GenerateMetricsTwoMetrics()
 	err := p.metricsDataPusher(context.Background(), md)
@@ -210,3 +219,165 @@ func (e tracesErrorMarshaller) Marshal(_ pdata.Traces) ([]Message, error) {
 func (e tracesErrorMarshaller) Encoding() string {
 	panic("implement me")
 }
+
+func newTestPartitioner(t *testing.T, config Config) *partitioner {
+	p, err := newPartitioner(config)
+	require.NoError(t, err)
+	return p
+}
+
+func TestTraceDataPusher_topicTemplateAndTraceIDKey(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	td := testdata.GenerateTraceDataTwoSpansSameResourceOneDifferent()
+	rss := td.ResourceSpans()
+	rss.At(0).Resource().Attributes().UpsertString("service.name", "svc1")
+	rss.At(1).Resource().Attributes().UpsertString("service.name", "svc2")
+	spans := rss.At(0).InstrumentationLibrarySpans().At(0).Spans()
+	spans.At(0).SetTraceID(pdata.NewTraceID([16]byte{1}))
+	spans.At(1).SetTraceID(pdata.NewTraceID([16]byte{2}))
+	rss.At(1).InstrumentationLibrarySpans().At(0).Spans().At(0).SetTraceID(pdata.NewTraceID([16]byte{1}))
+
+	var got []*sarama.ProducerMessage
+	for i := 0; i < 3; i++ {
+		producer.ExpectSendMessageAndSucceed()
+	}
+	p := kafkaTracesProducer{
+		producer: &recordingProducer{SyncProducer: producer, messages: &got},
+		partitioner: newTestPartitioner(t, Config{
+			Topic:      "otlp_spans_{service.name}",
+			MessageKey: MessageKey{Source: MessageKeyTraceID},
+		}),
+		marshaller: &otlpTracesPbMarshaller{},
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.traceDataPusher(context.Background(), td))
+
+	require.Len(t, got, 3)
+	assertMessage := func(msg *sarama.ProducerMessage, topic string, traceID pdata.TraceID, spanCount int) {
+		assert.Equal(t, topic, msg.Topic)
+		assert.Equal(t, sarama.StringEncoder(traceID.HexString()), msg.Key)
+		value, err := msg.Value.Encode()
+		require.NoError(t, err)
+		traces, err := pdata.TracesFromOtlpProtoBytes(value)
+		require.NoError(t, err)
+		assert.Equal(t, spanCount, traces.SpanCount())
+		assert.Equal(t, traceID, traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID())
+	}
+	assertMessage(got[0], "otlp_spans_svc1", pdata.NewTraceID([16]byte{1}), 1)
+	assertMessage(got[1], "otlp_spans_svc1", pdata.NewTraceID([16]byte{2}), 1)
+	assertMessage(got[2], "otlp_spans_svc2", pdata.NewTraceID([16]byte{1}), 1)
+}
+
+func TestMetricsDataPusher_resourceAttributeKey(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	producer.ExpectSendMessageAndSucceed()
+
+	md := testdata.GenerateMetricsOneMetric()
+	md.ResourceMetrics().At(0).Resource().Attributes().UpsertString("host.name", "host1")
+	var got []*sarama.ProducerMessage
+	p := kafkaMetricsProducer{
+		producer: &recordingProducer{SyncProducer: producer, messages: &got},
+		partitioner: newTestPartitioner(t, Config{
+			Topic:      "metrics",
+			MessageKey: MessageKey{Source: MessageKeyResourceAttribute, Attribute: "host.name"},
+		}),
+		marshaller: &otlpMetricsPbMarshaller{},
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.metricsDataPusher(context.Background(), md))
+	require.Len(t, got, 1)
+	assert.Equal(t, "metrics", got[0].Topic)
+	assert.Equal(t, sarama.StringEncoder("host1"), got[0].Key)
+}
+
+func TestNewMetricsExporter_err_trace_id_key(t *testing.T) {
+	c := Config{Encoding: defaultEncoding, MessageKey: MessageKey{Source: MessageKeyTraceID}}
+	mexp, err := newMetricsExporter(c, component.ExporterCreateParams{Logger: zap.NewNop()}, metricsMarshallers())
+	assert.EqualError(t, err, errTraceIDKeyMetrics.Error())
+	assert.Nil(t, mexp)
+}
+
+func TestConfigureProducer(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Producer = Producer{
+		RequiredAcks:   -1,
+		Compression:    "zstd",
+		Idempotent:     true,
+		FlushBytes:     1024,
+		FlushMessages:  10,
+		FlushFrequency: time.Second,
+	}
+	c := sarama.NewConfig()
+	c.Version = sarama.V2_1_0_0
+	require.NoError(t, configureProducer(*cfg, c))
+	assert.Equal(t, sarama.WaitForAll, c.Producer.RequiredAcks)
+	assert.Equal(t, sarama.CompressionZSTD, c.Producer.Compression)
+	assert.True(t, c.Producer.Idempotent)
+	assert.Equal(t, 1, c.Net.MaxOpenRequests)
+	assert.Equal(t, 1024, c.Producer.Flush.Bytes)
+	assert.Equal(t, 10, c.Producer.Flush.Messages)
+	assert.Equal(t, time.Second, c.Producer.Flush.Frequency)
+	assert.NoError(t, c.Validate())
+
+	// The idempotent producer must wait for all the replicas.
+	cfg.Producer.RequiredAcks = 1
+	c = sarama.NewConfig()
+	c.Version = sarama.V2_1_0_0
+	require.NoError(t, configureProducer(*cfg, c))
+	assert.Error(t, c.Validate())
+
+	cfg.Producer.Compression = "brotli"
+	assert.Equal(t, errUnknownCompression, configureProducer(*cfg, sarama.NewConfig()))
+}
+
+func TestProducerMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := createDefaultConfig().(*Config)
+	c := sarama.NewConfig()
+	require.NoError(t, configureProducer(*cfg, c))
+	assert.Equal(t, c.Producer.Retry.Backoff, c.Producer.Retry.BackoffFunc(1, 3))
+
+	rows, err := view.RetrieveData(statProduceRetries.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []tag.Tag{{Key: tagInstanceName, Value: typeStr}}, rows[0].Tags)
+	assert.Equal(t, float64(1), rows[0].Data.(*view.SumData).Value)
+
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	producer.ExpectSendMessageAndSucceed()
+	p := kafkaTracesProducer{
+		name:        typeStr,
+		partitioner: newTestPartitioner(t, Config{}),
+		producer:    producer,
+		marshaller:  &otlpTracesPbMarshaller{},
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.traceDataPusher(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource()))
+
+	rows, err = view.RetrieveData(statProduceLatency.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(1), rows[0].Data.(*view.DistributionData).Count)
+}
+
+// recordingProducer records the messages sent to the wrapped producer.
+type recordingProducer struct {
+	sarama.SyncProducer
+	messages *[]*sarama.ProducerMessage
+}
+
+func (r *recordingProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	*r.messages = append(*r.messages, msgs...)
+	return r.SyncProducer.SendMessages(msgs)
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagInstanceName, _ = tag.NewKey("name")
+
+	statProduceLatency = stats.Float64("kafka_exporter_produce_latency", "Latency of the produce requests", stats.UnitMilliseconds)
+	statProduceRetries = stats.Int64("kafka_exporter_produce_retries", "Number of retries of the produce requests", stats.UnitDimensionless)
+)
+
+// MetricViews return metric views for Kafka exporter.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagInstanceName}
+
+	distributionProduceLatency := &view.View{
+		Name:        statProduceLatency.Name(),
+		Measure:     statProduceLatency,
+		Description: statProduceLatency.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000),
+	}
+
+	countProduceRetries := &view.View{
+		Name:        statProduceRetries.Name(),
+		Measure:     statProduceRetries,
+		Description: statProduceRetries.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{
+		distributionProduceLatency,
+		countProduceRetries,
+	}
+}
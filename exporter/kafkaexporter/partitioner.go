@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	// MessageKeyTraceID keys the messages by trace ID, only valid for traces.
+	MessageKeyTraceID = "trace_id"
+	// MessageKeyResourceAttribute keys the messages by the value of a resource attribute.
+	MessageKeyResourceAttribute = "resource_attribute"
+)
+
+// topicTemplate resolves topic names containing {attribute} placeholders
+// from the resource attributes.
+type topicTemplate struct {
+	// literals and attributes alternate, literals has always one more element.
+	literals   []string
+	attributes []string
+}
+
+func newTopicTemplate(topic string) (*topicTemplate, error) {
+	t := &topicTemplate{}
+	rest := topic
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			if strings.IndexByte(rest, '}') >= 0 {
+				return nil, fmt.Errorf("invalid topic template %q: unexpected '}'", topic)
+			}
+			t.literals = append(t.literals, rest)
+			return t, nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("invalid topic template %q: missing '}'", topic)
+		}
+		attribute := rest[start+1 : start+end]
+		if attribute == "" || strings.ContainsAny(attribute, "{") {
+			return nil, fmt.Errorf("invalid topic template %q: invalid attribute name %q", topic, attribute)
+		}
+		t.literals = append(t.literals, rest[:start])
+		t.attributes = append(t.attributes, attribute)
+		rest = rest[start+end+1:]
+	}
+}
+
+// static returns true if the topic doesn't depend on the resource.
+func (t *topicTemplate) static() bool {
+	return len(t.attributes) == 0
+}
+
+// resolve returns the topic for the given resource. Missing attributes are
+// replaced by an empty string.
+func (t *topicTemplate) resolve(resource pdata.Resource) string {
+	if t.static() {
+		return t.literals[0]
+	}
+	var b strings.Builder
+	for i, attribute := range t.attributes {
+		b.WriteString(t.literals[i])
+		b.WriteString(resourceAttribute(resource, attribute))
+	}
+	b.WriteString(t.literals[len(t.literals)-1])
+	return b.String()
+}
+
+func resourceAttribute(resource pdata.Resource, key string) string {
+	if v, ok := resource.Attributes().Get(key); ok {
+		return tracetranslator.AttributeValueToString(v, false)
+	}
+	return ""
+}
+
+// partitionKey identifies the messages produced with the same topic and key.
+type partitionKey struct {
+	topic string
+	key   string
+}
+
+// partitioner splits the data in groups sent to the same topic with the same message key.
+type partitioner struct {
+	topic        *topicTemplate
+	keySource    string
+	keyAttribute string
+}
+
+func newPartitioner(config Config) (*partitioner, error) {
+	topic, err := newTopicTemplate(config.Topic)
+	if err != nil {
+		return nil, err
+	}
+	switch config.MessageKey.Source {
+	case "", MessageKeyTraceID:
+	case MessageKeyResourceAttribute:
+		if config.MessageKey.Attribute == "" {
+			return nil, fmt.Errorf("message_key attribute must be set when source is %q", MessageKeyResourceAttribute)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported message_key source %q", config.MessageKey.Source)
+	}
+	return &partitioner{
+		topic:        topic,
+		keySource:    config.MessageKey.Source,
+		keyAttribute: config.MessageKey.Attribute,
+	}, nil
+}
+
+func (p *partitioner) resourceKey(resource pdata.Resource) partitionKey {
+	pk := partitionKey{topic: p.topic.resolve(resource)}
+	if p.keySource == MessageKeyResourceAttribute {
+		pk.key = resourceAttribute(resource, p.keyAttribute)
+	}
+	return pk
+}
+
+// tracesPartition is the part of the traces sent to the same topic with the same key.
+type tracesPartition struct {
+	partitionKey
+	traces pdata.Traces
+	// rss maps the index of the resource in the original traces to the
+	// resource in this partition.
+	rss map[int]pdata.ResourceSpans
+	// ilss maps the indexes of the resource and the instrumentation library in
+	// the original traces to the instrumentation library in this partition.
+	ilss map[[2]int]pdata.InstrumentationLibrarySpans
+}
+
+// partitionTraces splits the traces by topic and message key. The returned
+// traces share the spans of td, so they must not be modified.
+func (p *partitioner) partitionTraces(td pdata.Traces) []*tracesPartition {
+	if p.topic.static() && p.keySource == "" {
+		return []*tracesPartition{{partitionKey: partitionKey{topic: p.topic.literals[0]}, traces: td}}
+	}
+
+	var partitions []*tracesPartition
+	byKey := map[partitionKey]*tracesPartition{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceKey := p.resourceKey(rs.Resource())
+		if p.keySource != MessageKeyTraceID {
+			part := byKey[resourceKey]
+			if part == nil {
+				part = &tracesPartition{partitionKey: resourceKey, traces: pdata.NewTraces()}
+				byKey[resourceKey] = part
+				partitions = append(partitions, part)
+			}
+			part.traces.ResourceSpans().Append(rs)
+			continue
+		}
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				pk := partitionKey{topic: resourceKey.topic, key: span.TraceID().HexString()}
+				part := byKey[pk]
+				if part == nil {
+					part = &tracesPartition{
+						partitionKey: pk,
+						traces:       pdata.NewTraces(),
+						rss:          map[int]pdata.ResourceSpans{},
+						ilss:         map[[2]int]pdata.InstrumentationLibrarySpans{},
+					}
+					byKey[pk] = part
+					partitions = append(partitions, part)
+				}
+				dest, ok := part.ilss[[2]int{i, j}]
+				if !ok {
+					dest = part.instrumentationLibrarySpans(rs, i, ils)
+					part.ilss[[2]int{i, j}] = dest
+				}
+				dest.Spans().Append(span)
+			}
+		}
+	}
+	return partitions
+}
+
+// instrumentationLibrarySpans creates the instrumentation library in the partition,
+// reusing the resource if it was already created for another instrumentation library.
+func (tp *tracesPartition) instrumentationLibrarySpans(rs pdata.ResourceSpans, rsIndex int, ils pdata.InstrumentationLibrarySpans) pdata.InstrumentationLibrarySpans {
+	destRs, ok := tp.rss[rsIndex]
+	if !ok {
+		rss := tp.traces.ResourceSpans()
+		rss.Resize(rss.Len() + 1)
+		destRs = rss.At(rss.Len() - 1)
+		rs.Resource().CopyTo(destRs.Resource())
+		tp.rss[rsIndex] = destRs
+	}
+	destIlss := destRs.InstrumentationLibrarySpans()
+	destIlss.Resize(destIlss.Len() + 1)
+	destIls := destIlss.At(destIlss.Len() - 1)
+	ils.InstrumentationLibrary().CopyTo(destIls.InstrumentationLibrary())
+	return destIls
+}
+
+// metricsPartition is the part of the metrics sent to the same topic with the same key.
+type metricsPartition struct {
+	partitionKey
+	metrics pdata.Metrics
+}
+
+// partitionMetrics splits the metrics by topic and message key. The returned
+// metrics share the resource metrics of md, so they must not be modified.
+func (p *partitioner) partitionMetrics(md pdata.Metrics) []*metricsPartition {
+	if p.topic.static() && p.keySource == "" {
+		return []*metricsPartition{{partitionKey: partitionKey{topic: p.topic.literals[0]}, metrics: md}}
+	}
+
+	var partitions []*metricsPartition
+	byKey := map[partitionKey]*metricsPartition{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		pk := p.resourceKey(rm.Resource())
+		part := byKey[pk]
+		if part == nil {
+			part = &metricsPartition{partitionKey: pk, metrics: pdata.NewMetrics()}
+			byKey[pk] = part
+			partitions = append(partitions, part)
+		}
+		part.metrics.ResourceMetrics().Append(rm)
+	}
+	return partitions
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestTopicTemplate(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "svc")
+	resource.Attributes().InsertInt("shard", 3)
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{template: "otlp_spans", expected: "otlp_spans"},
+		{template: "otlp_spans_{service.name}", expected: "otlp_spans_svc"},
+		{template: "{service.name}-{shard}-spans", expected: "svc-3-spans"},
+		{template: "spans_{missing}", expected: "spans_"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			tmpl, err := newTopicTemplate(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tmpl.resolve(resource))
+		})
+	}
+}
+
+func TestTopicTemplateInvalid(t *testing.T) {
+	for _, template := range []string{"spans_{", "spans_}", "spans_{}", "spans_{{a}}"} {
+		_, err := newTopicTemplate(template)
+		assert.Error(t, err, template)
+	}
+}
+
+func TestNewPartitionerInvalidMessageKey(t *testing.T) {
+	_, err := newPartitioner(Config{MessageKey: MessageKey{Source: "span_id"}})
+	assert.Error(t, err)
+	_, err = newPartitioner(Config{MessageKey: MessageKey{Source: MessageKeyResourceAttribute}})
+	assert.Error(t, err)
+}
+
+func TestPartitionTracesByResourceAttribute(t *testing.T) {
+	p, err := newPartitioner(Config{
+		Topic:      "spans",
+		MessageKey: MessageKey{Source: MessageKeyResourceAttribute, Attribute: "tenant"},
+	})
+	require.NoError(t, err)
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(3)
+	td.ResourceSpans().At(0).Resource().Attributes().InsertString("tenant", "a")
+	td.ResourceSpans().At(1).Resource().Attributes().InsertString("tenant", "b")
+	td.ResourceSpans().At(2).Resource().Attributes().InsertString("tenant", "a")
+
+	parts := p.partitionTraces(td)
+	require.Len(t, parts, 2)
+	assert.Equal(t, partitionKey{topic: "spans", key: "a"}, parts[0].partitionKey)
+	assert.Equal(t, 2, parts[0].traces.ResourceSpans().Len())
+	assert.Equal(t, partitionKey{topic: "spans", key: "b"}, parts[1].partitionKey)
+	assert.Equal(t, 1, parts[1].traces.ResourceSpans().Len())
+}
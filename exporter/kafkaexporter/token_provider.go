@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+const (
+	mskService     = "kafka-cluster"
+	mskAction      = "kafka-cluster:Connect"
+	mskTokenExpiry = 15 * time.Minute
+	mskUserAgent   = "otelcol-kafka"
+)
+
+// extensionTokenProvider provides the OAUTHBEARER access tokens of the extension registered under name, the extension
+// is looked up for every token as it starts after the producers and consumers are created.
+type extensionTokenProvider struct {
+	name string
+}
+
+var _ sarama.AccessTokenProvider = (*extensionTokenProvider)(nil)
+
+func (p *extensionTokenProvider) Token() (*sarama.AccessToken, error) {
+	provider, err := configauth.GetTokenProvider(p.name)
+	if err != nil {
+		return nil, err
+	}
+	token, err := provider.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &sarama.AccessToken{Token: token}, nil
+}
+
+// mskTokenProvider provides the OAUTHBEARER access tokens of the AWS MSK IAM authentication, the token is a URL
+// presigned with the AWS credentials of the default credential chain.
+type mskTokenProvider struct {
+	region string
+	signer *v4.Signer
+	now    func() time.Time
+}
+
+var _ sarama.AccessTokenProvider = (*mskTokenProvider)(nil)
+
+func newMSKTokenProvider(region string) (*mskTokenProvider, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the AWS session: %w", err)
+	}
+	return &mskTokenProvider{
+		region: region,
+		signer: v4.NewSigner(sess.Config.Credentials),
+		now:    time.Now,
+	}, nil
+}
+
+func (p *mskTokenProvider) Token() (*sarama.AccessToken, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://kafka.%s.amazonaws.com/", p.region), nil)
+	if err != nil {
+		return nil, err
+	}
+	query := req.URL.Query()
+	query.Set("Action", mskAction)
+	req.URL.RawQuery = query.Encode()
+
+	if _, err = p.signer.Presign(req, nil, mskService, p.region, mskTokenExpiry, p.now()); err != nil {
+		return nil, fmt.Errorf("failed to sign the AWS MSK IAM token: %w", err)
+	}
+	// The user agent is not part of the signature.
+	query = req.URL.Query()
+	query.Set("User-Agent", mskUserAgent)
+	req.URL.RawQuery = query.Encode()
+	return &sarama.AccessToken{Token: base64.RawURLEncoding.EncodeToString([]byte(req.URL.String()))}, nil
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpccredentials "google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/oauth"
+
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+type mockTokenProvider struct{}
+
+func (mockTokenProvider) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	return base
+}
+
+func (mockTokenProvider) PerRPCCredentials() grpccredentials.PerRPCCredentials {
+	return oauth.NewOauthAccess(nil)
+}
+
+func (mockTokenProvider) Token() (string, error) {
+	return "token", nil
+}
+
+func TestExtensionTokenProvider(t *testing.T) {
+	provider := &extensionTokenProvider{name: "mock/kafka"}
+	_, err := provider.Token()
+	assert.EqualError(t, err, `authenticator "mock/kafka" not found`)
+
+	require.NoError(t, configauth.RegisterClientAuthenticator("mock/kafka", mockTokenProvider{}))
+	defer configauth.UnregisterClientAuthenticator("mock/kafka")
+	token, err := provider.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.Token)
+}
+
+func TestMSKTokenProvider(t *testing.T) {
+	signTime := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	provider := &mskTokenProvider{
+		region: "eu-west-1",
+		signer: v4.NewSigner(credentials.NewStaticCredentials("AKID", "SECRET", "")),
+		now:    func() time.Time { return signTime },
+	}
+
+	token, err := provider.Token()
+	require.NoError(t, err)
+	rawURL, err := base64.RawURLEncoding.DecodeString(token.Token)
+	require.NoError(t, err)
+	signedURL, err := url.Parse(string(rawURL))
+	require.NoError(t, err)
+
+	assert.Equal(t, "kafka.eu-west-1.amazonaws.com", signedURL.Host)
+	query := signedURL.Query()
+	assert.Equal(t, "kafka-cluster:Connect", query.Get("Action"))
+	assert.Equal(t, "AKID/20210301/eu-west-1/kafka-cluster/aws4_request", query.Get("X-Amz-Credential"))
+	assert.Equal(t, "20210301T120000Z", query.Get("X-Amz-Date"))
+	assert.Equal(t, "900", query.Get("X-Amz-Expires"))
+	assert.Equal(t, "otelcol-kafka", query.Get("User-Agent"))
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+// Config defines configuration for the load-balancing exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"`
+
+	// Protocol configures the exporters sending the data to the backends.
+	Protocol Protocol `mapstructure:"protocol"`
+
+	// Resolver configures how the backends are discovered, exactly one resolver must be set.
+	Resolver ResolverSettings `mapstructure:"resolver"`
+}
+
+// Protocol holds the configuration of the exporters of the backends, their endpoint is replaced by the one of
+// the backend.
+type Protocol struct {
+	OTLP otlpexporter.Config `mapstructure:"otlp"`
+}
+
+// ResolverSettings configures how the backends are discovered.
+type ResolverSettings struct {
+	// Static lists the backends.
+	Static *StaticResolver `mapstructure:"static"`
+
+	// DNS resolves the backends from the addresses of a DNS name, e.g. a headless Kubernetes service.
+	DNS *DNSResolver `mapstructure:"dns"`
+}
+
+// StaticResolver defines the backends as a fixed list.
+type StaticResolver struct {
+	// Hostnames are the endpoints of the backends, as host:port.
+	Hostnames []string `mapstructure:"hostnames"`
+}
+
+// DNSResolver defines the backends as the addresses a DNS name resolves to.
+type DNSResolver struct {
+	// Hostname is the DNS name to resolve.
+	Hostname string `mapstructure:"hostname"`
+
+	// Port is the port of the backends. Default value is 4317.
+	Port string `mapstructure:"port"`
+
+	// Interval is the interval at which the name is resolved again. Default value is 5s.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Timeout is the timeout of the resolution. Default value is 1s.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that exactly one resolver is set with the backends to discover.
+func (cfg *Config) Validate() error {
+	static, dns := cfg.Resolver.Static, cfg.Resolver.DNS
+	switch {
+	case static == nil && dns == nil:
+		return errors.New("a resolver must be set, either static or dns")
+	case static != nil && dns != nil:
+		return errors.New("only one resolver can be set, either static or dns")
+	case static != nil:
+		if len(static.Hostnames) == 0 {
+			return errors.New("the static resolver must list at least one hostname")
+		}
+	default:
+		if dns.Hostname == "" {
+			return errors.New("the dns resolver requires a hostname")
+		}
+		if dns.Interval < 0 || dns.Timeout < 0 {
+			return errors.New("the interval and timeout of the dns resolver must not be negative")
+		}
+	}
+	return nil
+}
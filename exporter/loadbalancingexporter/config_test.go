@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	otlpCfg := factory.CreateDefaultConfig().(*Config).Protocol.OTLP
+	otlpCfg.TLSSetting.Insecure = true
+	otlpCfg.Timeout = time.Second
+	assert.Equal(t, cfg.Exporters["loadbalancing"],
+		&Config{
+			ExporterSettings: configmodels.ExporterSettings{
+				TypeVal: typeStr,
+				NameVal: "loadbalancing",
+			},
+			Protocol: Protocol{OTLP: otlpCfg},
+			Resolver: ResolverSettings{
+				Static: &StaticResolver{Hostnames: []string{"backend-1:4317", "backend-2:4317"}},
+			},
+		})
+
+	otlpCfg = factory.CreateDefaultConfig().(*Config).Protocol.OTLP
+	otlpCfg.TLSSetting.Insecure = true
+	assert.Equal(t, cfg.Exporters["loadbalancing/dns"],
+		&Config{
+			ExporterSettings: configmodels.ExporterSettings{
+				TypeVal: typeStr,
+				NameVal: "loadbalancing/dns",
+			},
+			Protocol: Protocol{OTLP: otlpCfg},
+			Resolver: ResolverSettings{
+				DNS: &DNSResolver{
+					Hostname: "otelcol-sampling.observability.svc.cluster.local",
+					Port:     "55690",
+					Interval: 30 * time.Second,
+				},
+			},
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "a resolver must be set, either static or dns")
+
+	cfg.Resolver.Static = &StaticResolver{}
+	assert.EqualError(t, cfg.Validate(), "the static resolver must list at least one hostname")
+
+	cfg.Resolver.Static.Hostnames = []string{"backend-1:4317"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Resolver.DNS = &DNSResolver{}
+	assert.EqualError(t, cfg.Validate(), "only one resolver can be set, either static or dns")
+
+	cfg.Resolver.Static = nil
+	assert.EqualError(t, cfg.Validate(), "the dns resolver requires a hostname")
+
+	cfg.Resolver.DNS.Hostname = "backends"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Resolver.DNS.Interval = -time.Second
+	assert.EqualError(t, cfg.Validate(), "the interval and timeout of the dns resolver must not be negative")
+}
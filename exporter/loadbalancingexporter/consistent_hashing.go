@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// virtualNodesPerEndpoint is the number of positions of every endpoint on the ring, the more positions the more
+// evenly the trace IDs are spread across the endpoints.
+const virtualNodesPerEndpoint = 100
+
+type ringPosition struct {
+	hash     uint32
+	endpoint string
+}
+
+// hashRing maps the trace IDs to the endpoints by consistent hashing. The positions of an endpoint do not depend
+// on the other endpoints, so adding or removing an endpoint moves only the trace IDs from or to this endpoint.
+type hashRing struct {
+	// positions are sorted by hash.
+	positions []ringPosition
+}
+
+func newHashRing(endpoints []string) *hashRing {
+	positions := make([]ringPosition, 0, len(endpoints)*virtualNodesPerEndpoint)
+	for _, endpoint := range endpoints {
+		for i := 0; i < virtualNodesPerEndpoint; i++ {
+			positions = append(positions, ringPosition{
+				hash:     crc32.ChecksumIEEE([]byte(endpoint + "-" + strconv.Itoa(i))),
+				endpoint: endpoint,
+			})
+		}
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].hash == positions[j].hash {
+			// Make the ring the same whatever the order of the endpoints.
+			return positions[i].endpoint < positions[j].endpoint
+		}
+		return positions[i].hash < positions[j].hash
+	})
+	return &hashRing{positions: positions}
+}
+
+// endpointFor returns the endpoint of the trace ID, the one of the first position following its hash on the ring,
+// or an empty string when the ring has no endpoints.
+func (r *hashRing) endpointFor(traceID pdata.TraceID) string {
+	if len(r.positions) == 0 {
+		return ""
+	}
+	b := traceID.Bytes()
+	hash := crc32.ChecksumIEEE(b[:])
+	i := sort.Search(len(r.positions), func(i int) bool {
+		return r.positions[i].hash >= hash
+	})
+	if i == len(r.positions) {
+		i = 0
+	}
+	return r.positions[i].endpoint
+}
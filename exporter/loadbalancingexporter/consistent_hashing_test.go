@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func traceIDFromInt(i int) pdata.TraceID {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[8:], uint64(i))
+	return pdata.NewTraceID(b)
+}
+
+func TestHashRingEmpty(t *testing.T) {
+	assert.Equal(t, "", newHashRing(nil).endpointFor(traceIDFromInt(1)))
+}
+
+func TestHashRingSpreadsTraces(t *testing.T) {
+	endpoints := []string{"backend-1:4317", "backend-2:4317", "backend-3:4317"}
+	ring := newHashRing(endpoints)
+
+	counts := map[string]int{}
+	for i := 0; i < 30000; i++ {
+		endpoint := ring.endpointFor(traceIDFromInt(i))
+		assert.Equal(t, endpoint, ring.endpointFor(traceIDFromInt(i)), "the endpoint of a trace must not change")
+		counts[endpoint]++
+	}
+	assert.Len(t, counts, len(endpoints))
+	for _, endpoint := range endpoints {
+		// Every endpoint receives roughly a third of the traces.
+		assert.InDelta(t, 10000, counts[endpoint], 3000, endpoint)
+	}
+}
+
+func TestHashRingSameWhateverTheOrder(t *testing.T) {
+	ring := newHashRing([]string{"backend-1:4317", "backend-2:4317"})
+	reversed := newHashRing([]string{"backend-2:4317", "backend-1:4317"})
+	assert.Equal(t, ring, reversed)
+}
+
+func TestHashRingMinimalChurn(t *testing.T) {
+	ring := newHashRing([]string{"backend-1:4317", "backend-2:4317", "backend-3:4317"})
+	grown := newHashRing([]string{"backend-1:4317", "backend-2:4317", "backend-3:4317", "backend-4:4317"})
+
+	for i := 0; i < 10000; i++ {
+		traceID := traceIDFromInt(i)
+		// Only the traces moving to the added endpoint change, the others stay on their endpoint.
+		if endpoint := grown.endpointFor(traceID); endpoint != "backend-4:4317" {
+			assert.Equal(t, ring.endpointFor(traceID), endpoint)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "loadbalancing"
+)
+
+// NewFactory creates a factory for the load-balancing exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTraceExporter))
+}
+
+// Note: This isn't a valid configuration because the exporter has no resolver.
+func createDefaultConfig() configmodels.Exporter {
+	otlpDefaultCfg := otlpexporter.NewFactory().CreateDefaultConfig().(*otlpexporter.Config)
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Protocol: Protocol{
+			OTLP: *otlpDefaultCfg,
+		},
+	}
+}
+
+func createTraceExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.TracesExporter, error) {
+	lbCfg := cfg.(*Config)
+	if err := lbCfg.Validate(); err != nil {
+		return nil, err
+	}
+	var res resolver
+	if lbCfg.Resolver.Static != nil {
+		res = newStaticResolver(lbCfg.Resolver.Static.Hostnames)
+	} else {
+		res = newDNSResolver(params.Logger, lbCfg.Resolver.DNS)
+	}
+
+	otlpFactory := otlpexporter.NewFactory()
+	createExporter := func(ctx context.Context, endpoint string) (component.TracesExporter, error) {
+		// Every backend has its own exporter, with its own queue and retries, named after the backend.
+		otlpCfg := lbCfg.Protocol.OTLP
+		otlpCfg.TypeVal = otlpFactory.Type()
+		otlpCfg.NameVal = lbCfg.Name() + "/" + endpoint
+		otlpCfg.Endpoint = endpoint
+		otlpCfg.TracesEndpoint = ""
+		return otlpFactory.CreateTracesExporter(ctx, params, &otlpCfg)
+	}
+	return newTracesExporter(newLoadBalancer(params.Logger, res, createExporter)), nil
+}
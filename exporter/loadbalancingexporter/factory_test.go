@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.NotNil(t, cfg)
+}
+
+func TestCreateTracesExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	_, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	assert.EqualError(t, err, "a resolver must be set, either static or dns")
+
+	cfg.Protocol.OTLP.TLSSetting.Insecure = true
+	cfg.Resolver.Static = &StaticResolver{Hostnames: []string{
+		testutil.GetAvailableLocalAddress(t),
+		testutil.GetAvailableLocalAddress(t),
+	}}
+	exp, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	lb := exp.(*traceExporterImp).loadBalancer
+	assert.Len(t, lb.exporters, 2)
+	// The spans are queued by the exporters of the backends.
+	assert.NoError(t, exp.ConsumeTraces(context.Background(), generateTraces(10)))
+	assert.NoError(t, exp.Shutdown(context.Background()))
+	assert.Len(t, lb.exporters, 0)
+
+	_, err = factory.CreateMetricsExporter(context.Background(), params, cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// createExporterFunc creates the exporter sending the data to the backend at the endpoint.
+type createExporterFunc func(ctx context.Context, endpoint string) (component.TracesExporter, error)
+
+// loadBalancer keeps an exporter per backend, and the ring selecting the backend of every trace, up to date with
+// the endpoints discovered by the resolver.
+type loadBalancer struct {
+	logger         *zap.Logger
+	res            resolver
+	createExporter createExporterFunc
+	host           component.Host
+
+	// mu guards ring and exporters, it is held for reading while exporting so that an exporter is not shut down
+	// while it is being used.
+	mu        sync.RWMutex
+	ring      *hashRing
+	exporters map[string]component.TracesExporter
+}
+
+func newLoadBalancer(logger *zap.Logger, res resolver, createExporter createExporterFunc) *loadBalancer {
+	lb := &loadBalancer{
+		logger:         logger,
+		res:            res,
+		createExporter: createExporter,
+		ring:           newHashRing(nil),
+		exporters:      map[string]component.TracesExporter{},
+	}
+	res.onChange(lb.onBackendChanges)
+	return lb
+}
+
+func (lb *loadBalancer) start(ctx context.Context, host component.Host) error {
+	lb.host = host
+	return lb.res.start(ctx)
+}
+
+func (lb *loadBalancer) shutdown(ctx context.Context) error {
+	err := lb.res.shutdown(ctx)
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	for endpoint, exp := range lb.exporters {
+		if shutdownErr := exp.Shutdown(ctx); shutdownErr != nil && err == nil {
+			err = shutdownErr
+		}
+		delete(lb.exporters, endpoint)
+	}
+	lb.ring = newHashRing(nil)
+	return err
+}
+
+// onBackendChanges creates and starts the exporters of the new backends before using them, and shuts the exporters
+// of the removed backends down once they are not used anymore.
+func (lb *loadBalancer) onBackendChanges(endpoints []string) {
+	lb.mu.RLock()
+	current := lb.exporters
+	lb.mu.RUnlock()
+
+	exporters := make(map[string]component.TracesExporter, len(endpoints))
+	available := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if exp, ok := current[endpoint]; ok {
+			exporters[endpoint] = exp
+			available = append(available, endpoint)
+			continue
+		}
+		exp, err := lb.createExporter(context.Background(), endpoint)
+		if err != nil {
+			lb.logger.Error("Failed to create the exporter of the backend", zap.String("endpoint", endpoint), zap.Error(err))
+			continue
+		}
+		if err = exp.Start(context.Background(), lb.host); err != nil {
+			lb.logger.Error("Failed to start the exporter of the backend", zap.String("endpoint", endpoint), zap.Error(err))
+			continue
+		}
+		exporters[endpoint] = exp
+		available = append(available, endpoint)
+	}
+
+	lb.mu.Lock()
+	lb.ring = newHashRing(available)
+	lb.exporters = exporters
+	lb.mu.Unlock()
+	lb.logger.Info("Backends updated", zap.Strings("endpoints", available))
+
+	for endpoint, exp := range current {
+		if _, ok := exporters[endpoint]; !ok {
+			if err := exp.Shutdown(context.Background()); err != nil {
+				lb.logger.Warn("Failed to shut the exporter of the removed backend down", zap.String("endpoint", endpoint), zap.Error(err))
+			}
+		}
+	}
+}
+
+// exporterFor returns the endpoint and the exporter of the backend of the trace ID, the lock must be held.
+func (lb *loadBalancer) exporterFor(traceID pdata.TraceID) (string, component.TracesExporter) {
+	endpoint := lb.ring.endpointFor(traceID)
+	return endpoint, lb.exporters[endpoint]
+}
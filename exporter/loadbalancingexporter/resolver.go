@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPort        = "4317"
+	defaultDNSInterval = 5 * time.Second
+	defaultDNSTimeout  = time.Second
+)
+
+// resolver discovers the endpoints of the backends and notifies the changes.
+type resolver interface {
+	// start resolves the endpoints a first time, calling the callback, and keeps watching them.
+	start(ctx context.Context) error
+	shutdown(ctx context.Context) error
+	// onChange registers the callback called with the sorted endpoints every time they change.
+	onChange(func(endpoints []string))
+}
+
+// staticResolver returns the endpoints listed in the configuration.
+type staticResolver struct {
+	endpoints []string
+	callbacks []func([]string)
+}
+
+func newStaticResolver(hostnames []string) *staticResolver {
+	endpoints := append([]string(nil), hostnames...)
+	sort.Strings(endpoints)
+	return &staticResolver{endpoints: endpoints}
+}
+
+func (r *staticResolver) start(context.Context) error {
+	for _, callback := range r.callbacks {
+		callback(r.endpoints)
+	}
+	return nil
+}
+
+func (r *staticResolver) shutdown(context.Context) error {
+	return nil
+}
+
+func (r *staticResolver) onChange(callback func([]string)) {
+	r.callbacks = append(r.callbacks, callback)
+}
+
+// netResolver is implemented by net.Resolver, it is replaced in the tests.
+type netResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// dnsResolver returns the addresses a DNS name resolves to, with the port of the backends, resolving it again
+// periodically.
+type dnsResolver struct {
+	logger   *zap.Logger
+	resolver netResolver
+	hostname string
+	port     string
+	interval time.Duration
+	timeout  time.Duration
+
+	callbacks []func([]string)
+	endpoints []string
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newDNSResolver(logger *zap.Logger, cfg *DNSResolver) *dnsResolver {
+	r := &dnsResolver{
+		logger:   logger,
+		resolver: net.DefaultResolver,
+		hostname: cfg.Hostname,
+		port:     cfg.Port,
+		interval: cfg.Interval,
+		timeout:  cfg.Timeout,
+		stopCh:   make(chan struct{}),
+	}
+	if r.port == "" {
+		r.port = defaultPort
+	}
+	if r.interval == 0 {
+		r.interval = defaultDNSInterval
+	}
+	if r.timeout == 0 {
+		r.timeout = defaultDNSTimeout
+	}
+	return r
+}
+
+// start resolves the name a first time. A failure is logged and not returned, the name may be resolvable later,
+// e.g. when the backends are started after the collector.
+func (r *dnsResolver) start(ctx context.Context) error {
+	if err := r.resolve(ctx); err != nil {
+		r.logger.Warn("Failed to resolve the backends, the spans are refused until they are resolved", zap.String("hostname", r.hostname), zap.Error(err))
+	}
+	r.wg.Add(1)
+	go r.periodicallyResolve()
+	return nil
+}
+
+func (r *dnsResolver) shutdown(context.Context) error {
+	close(r.stopCh)
+	r.wg.Wait()
+	return nil
+}
+
+func (r *dnsResolver) onChange(callback func([]string)) {
+	r.callbacks = append(r.callbacks, callback)
+}
+
+func (r *dnsResolver) periodicallyResolve() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.resolve(context.Background()); err != nil {
+				// Keep the last endpoints resolved, a transient failure must not move the traces.
+				r.logger.Warn("Failed to resolve the backends", zap.String("hostname", r.hostname), zap.Error(err))
+			}
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// resolve looks the name up and calls the callbacks if the endpoints changed.
+func (r *dnsResolver) resolve(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	addrs, err := r.resolver.LookupIPAddr(ctx, r.hostname)
+	if err != nil {
+		return err
+	}
+
+	endpoints := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		endpoints = append(endpoints, net.JoinHostPort(addr.IP.String(), r.port))
+	}
+	sort.Strings(endpoints)
+	if equalEndpoints(endpoints, r.endpoints) {
+		return nil
+	}
+	r.endpoints = endpoints
+	for _, callback := range r.callbacks {
+		callback(endpoints)
+	}
+	return nil
+}
+
+func equalEndpoints(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStaticResolver(t *testing.T) {
+	res := newStaticResolver([]string{"backend-2:4317", "backend-1:4317"})
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	assert.Equal(t, []string{"backend-1:4317", "backend-2:4317"}, resolved)
+	assert.NoError(t, res.shutdown(context.Background()))
+}
+
+type fakeNetResolver struct {
+	mu    sync.Mutex
+	addrs []net.IPAddr
+	err   error
+}
+
+func (r *fakeNetResolver) LookupIPAddr(context.Context, string) ([]net.IPAddr, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.addrs, r.err
+}
+
+func (r *fakeNetResolver) set(addrs []net.IPAddr, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addrs, r.err = addrs, err
+}
+
+func TestDNSResolver(t *testing.T) {
+	fake := &fakeNetResolver{}
+	fake.set([]net.IPAddr{{IP: net.IPv4(10, 0, 0, 2)}, {IP: net.IPv4(10, 0, 0, 1)}}, nil)
+	res := newDNSResolver(zap.NewNop(), &DNSResolver{Hostname: "backends", Interval: 10 * time.Millisecond})
+	res.resolver = fake
+	assert.Equal(t, defaultPort, res.port)
+	assert.Equal(t, defaultDNSTimeout, res.timeout)
+
+	changes := make(chan []string, 10)
+	res.onChange(func(endpoints []string) {
+		changes <- endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		assert.NoError(t, res.shutdown(context.Background()))
+	}()
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.2:4317"}, <-changes)
+
+	// A failure keeps the last endpoints.
+	fake.set(nil, errors.New("no such host"))
+	<-time.After(50 * time.Millisecond)
+	assert.Len(t, changes, 0)
+
+	fake.set([]net.IPAddr{{IP: net.ParseIP("fd00::1")}, {IP: net.IPv4(10, 0, 0, 1)}}, nil)
+	assert.Equal(t, []string{"10.0.0.1:4317", "[fd00::1]:4317"}, <-changes)
+	<-time.After(50 * time.Millisecond)
+	assert.Len(t, changes, 0, "the callbacks must be called only when the endpoints change")
+}
+
+func TestDNSResolverStartFailure(t *testing.T) {
+	res := newDNSResolver(zap.NewNop(), &DNSResolver{Hostname: "backends", Port: "55690", Interval: time.Hour})
+	res.resolver = &fakeNetResolver{err: errors.New("no such host")}
+	called := false
+	res.onChange(func([]string) {
+		called = true
+	})
+	// The backends may be resolvable later, the exporter starts anyway.
+	require.NoError(t, res.start(context.Background()))
+	assert.False(t, called)
+	assert.NoError(t, res.shutdown(context.Background()))
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var errNoBackends = errors.New("no backends available")
+
+type traceExporterImp struct {
+	loadBalancer *loadBalancer
+}
+
+var _ component.TracesExporter = (*traceExporterImp)(nil)
+
+func newTracesExporter(lb *loadBalancer) *traceExporterImp {
+	return &traceExporterImp{loadBalancer: lb}
+}
+
+// Start starts resolving the backends.
+func (e *traceExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.start(ctx, host)
+}
+
+// Shutdown stops resolving the backends and shuts their exporters down.
+func (e *traceExporterImp) Shutdown(ctx context.Context) error {
+	return e.loadBalancer.shutdown(ctx)
+}
+
+// ConsumeTraces splits the spans by backend, the backend of every span being selected by its trace ID, and sends
+// them to the exporters of the backends.
+func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	lb := e.loadBalancer
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	batches := make(map[string]*traceBatch)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				endpoint, exp := lb.exporterFor(span.TraceID())
+				if exp == nil {
+					return errNoBackends
+				}
+				b, ok := batches[endpoint]
+				if !ok {
+					b = &traceBatch{exporter: exp, traces: pdata.NewTraces(), rsIndex: -1, ilsIndex: -1}
+					batches[endpoint] = b
+				}
+				b.add(i, rs, j, ils, span)
+			}
+		}
+	}
+
+	var errs []error
+	for endpoint, b := range batches {
+		if err := b.exporter.ConsumeTraces(ctx, b.traces); err != nil {
+			errs = append(errs, fmt.Errorf("failed to export the spans to the backend %s: %w", endpoint, err))
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+// traceBatch accumulates the spans sent to a backend, keeping their resource and instrumentation library.
+type traceBatch struct {
+	exporter component.TracesExporter
+	traces   pdata.Traces
+	// rsIndex and ilsIndex are the indexes in the consumed traces of the last resource spans and instrumentation
+	// library spans copied, the following spans sharing them are appended to the same ones.
+	rsIndex, ilsIndex int
+	rs                pdata.ResourceSpans
+	ils               pdata.InstrumentationLibrarySpans
+}
+
+func (b *traceBatch) add(rsIndex int, rs pdata.ResourceSpans, ilsIndex int, ils pdata.InstrumentationLibrarySpans, span pdata.Span) {
+	if rsIndex != b.rsIndex {
+		b.rs = b.traces.ResourceSpans().AppendEmpty()
+		rs.Resource().CopyTo(b.rs.Resource())
+		b.rsIndex, b.ilsIndex = rsIndex, -1
+	}
+	if ilsIndex != b.ilsIndex {
+		b.ils = b.rs.InstrumentationLibrarySpans().AppendEmpty()
+		ils.InstrumentationLibrary().CopyTo(b.ils.InstrumentationLibrary())
+		b.ilsIndex = ilsIndex
+	}
+	span.CopyTo(b.ils.Spans().AppendEmpty())
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type sinkExporter struct {
+	consumertest.TracesSink
+	started, shutdown bool
+	consumeErr        error
+}
+
+func (e *sinkExporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if e.consumeErr != nil {
+		return e.consumeErr
+	}
+	return e.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func (e *sinkExporter) Start(context.Context, component.Host) error {
+	e.started = true
+	return nil
+}
+
+func (e *sinkExporter) Shutdown(context.Context) error {
+	e.shutdown = true
+	return nil
+}
+
+// fakeExporters creates sinkExporters, keeping the ones created for every endpoint.
+type fakeExporters struct {
+	mu        sync.Mutex
+	exporters map[string]*sinkExporter
+	err       error
+}
+
+func (f *fakeExporters) create(_ context.Context, endpoint string) (component.TracesExporter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	exp := &sinkExporter{}
+	f.exporters[endpoint] = exp
+	return exp, nil
+}
+
+func newTestExporter(t *testing.T, endpoints ...string) (*traceExporterImp, *fakeExporters) {
+	fake := &fakeExporters{exporters: map[string]*sinkExporter{}}
+	exp := newTracesExporter(newLoadBalancer(zap.NewNop(), newStaticResolver(endpoints), fake.create))
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	return exp, fake
+}
+
+// generateTraces returns traces with a resource and an instrumentation library holding a span for each trace ID,
+// twice.
+func generateTraces(numTraces int) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+	ils.InstrumentationLibrary().SetName("library")
+	for i := 0; i < 2*numTraces; i++ {
+		span := ils.Spans().AppendEmpty()
+		span.SetName("span")
+		span.SetTraceID(traceIDFromInt(i % numTraces))
+	}
+	return td
+}
+
+func TestConsumeTracesSplitsByTraceID(t *testing.T) {
+	exp, fake := newTestExporter(t, "backend-1:4317", "backend-2:4317", "backend-3:4317")
+	defer exp.Shutdown(context.Background())
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), generateTraces(100)))
+
+	backendOf := map[pdata.TraceID]string{}
+	total := 0
+	for endpoint, sink := range fake.exporters {
+		assert.True(t, sink.started)
+		require.Len(t, sink.AllTraces(), 1, endpoint)
+		td := sink.AllTraces()[0]
+		total += td.SpanCount()
+
+		// The resource and the instrumentation library are kept, once per batch.
+		require.Equal(t, 1, td.ResourceSpans().Len())
+		rs := td.ResourceSpans().At(0)
+		v, ok := rs.Resource().Attributes().Get("service.name")
+		require.True(t, ok)
+		assert.Equal(t, "checkout", v.StringVal())
+		require.Equal(t, 1, rs.InstrumentationLibrarySpans().Len())
+		ils := rs.InstrumentationLibrarySpans().At(0)
+		assert.Equal(t, "library", ils.InstrumentationLibrary().Name())
+
+		for i := 0; i < ils.Spans().Len(); i++ {
+			traceID := ils.Spans().At(i).TraceID()
+			if other, ok := backendOf[traceID]; ok {
+				assert.Equal(t, other, endpoint, "the spans of a trace must reach the same backend")
+			}
+			backendOf[traceID] = endpoint
+		}
+	}
+	assert.Len(t, fake.exporters, 3)
+	assert.Equal(t, 200, total)
+	assert.Len(t, backendOf, 100)
+}
+
+func TestConsumeTracesNoBackends(t *testing.T) {
+	exp, _ := newTestExporter(t)
+	defer exp.Shutdown(context.Background())
+	assert.Equal(t, errNoBackends, exp.ConsumeTraces(context.Background(), generateTraces(1)))
+}
+
+func TestConsumeTracesExportError(t *testing.T) {
+	fake := &fakeExporters{exporters: map[string]*sinkExporter{}}
+	lb := newLoadBalancer(zap.NewNop(), newStaticResolver(nil), fake.create)
+	exp := newTracesExporter(lb)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+
+	lb.onBackendChanges([]string{"backend-1:4317"})
+	fake.exporters["backend-1:4317"].consumeErr = errors.New("unavailable")
+	assert.EqualError(t, exp.ConsumeTraces(context.Background(), generateTraces(1)), "failed to export the spans to the backend backend-1:4317: unavailable")
+}
+
+func TestBackendChanges(t *testing.T) {
+	fake := &fakeExporters{exporters: map[string]*sinkExporter{}}
+	lb := newLoadBalancer(zap.NewNop(), newStaticResolver(nil), fake.create)
+	exp := newTracesExporter(lb)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	lb.onBackendChanges([]string{"backend-1:4317", "backend-2:4317"})
+	backend1, backend2 := fake.exporters["backend-1:4317"], fake.exporters["backend-2:4317"]
+	require.NotNil(t, backend1)
+	require.NotNil(t, backend2)
+
+	// The exporter of the backend kept is reused, the one of the removed backend is shut down.
+	lb.onBackendChanges([]string{"backend-1:4317", "backend-3:4317"})
+	assert.Same(t, backend1, fake.exporters["backend-1:4317"])
+	assert.False(t, backend1.shutdown)
+	assert.True(t, backend2.shutdown)
+	assert.True(t, fake.exporters["backend-3:4317"].started)
+
+	// The backends whose exporter cannot be created are skipped.
+	fake.err = errors.New("invalid endpoint")
+	lb.onBackendChanges([]string{"backend-1:4317", "backend-4:4317"})
+	require.NoError(t, exp.ConsumeTraces(context.Background(), generateTraces(10)))
+	assert.Equal(t, 20, backend1.SpansCount())
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.True(t, backend1.shutdown)
+	assert.True(t, fake.exporters["backend-3:4317"].shutdown)
+}
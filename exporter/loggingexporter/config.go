@@ -30,4 +30,27 @@ type Config struct {
 
 	// SamplingThereafter defines the sampling rate after the initial samples are logged.
 	SamplingThereafter int `mapstructure:"sampling_thereafter"`
+
+	// Format defines how the pipeline data is logged when the log level is debug;
+	// options are detailed, compact and json.
+	Format string `mapstructure:"format"`
+
+	// Fields selects the fields logged for every item by the compact and json formats.
+	Fields FieldsSettings `mapstructure:"fields"`
+
+	// BatchSamplingRate defines that only 1 out of every BatchSamplingRate batches is logged.
+	BatchSamplingRate int `mapstructure:"batch_sampling_rate"`
+}
+
+// FieldsSettings defines the fields logged for every item of each signal.
+// If the list for a signal is empty, all the fields are logged.
+type FieldsSettings struct {
+	// Traces is the list of fields logged for every span.
+	Traces []string `mapstructure:"traces"`
+
+	// Metrics is the list of fields logged for every metric data point.
+	Metrics []string `mapstructure:"metrics"`
+
+	// Logs is the list of fields logged for every log record.
+	Logs []string `mapstructure:"logs"`
 }
@@ -50,5 +50,11 @@ func TestLoadConfig(t *testing.T) {
 			LogLevel:           "debug",
 			SamplingInitial:    10,
 			SamplingThereafter: 50,
+			Format:             formatJSON,
+			Fields: FieldsSettings{
+				Traces: []string{"trace_id", "name"},
+				Logs:   []string{"body"},
+			},
+			BatchSamplingRate: 10,
 		})
 }
@@ -51,6 +51,8 @@ func createDefaultConfig() configmodels.Exporter {
 		LogLevel:           "info",
 		SamplingInitial:    defaultSamplingInitial,
 		SamplingThereafter: defaultSamplingThereafter,
+		Format:             formatDetailed,
+		BatchSamplingRate:  1,
 	}
 }
 
@@ -62,7 +64,7 @@ func createTraceExporter(_ context.Context, _ component.ExporterCreateParams, co
 		return nil, err
 	}
 
-	return newTraceExporter(config, cfg.LogLevel, exporterLogger)
+	return newTraceExporter(cfg, exporterLogger)
 }
 
 func createMetricsExporter(_ context.Context, _ component.ExporterCreateParams, config configmodels.Exporter) (component.MetricsExporter, error) {
@@ -73,7 +75,7 @@ func createMetricsExporter(_ context.Context, _ component.ExporterCreateParams,
 		return nil, err
 	}
 
-	return newMetricsExporter(config, cfg.LogLevel, exporterLogger)
+	return newMetricsExporter(cfg, exporterLogger)
 }
 
 func createLogsExporter(_ context.Context, _ component.ExporterCreateParams, config configmodels.Exporter) (component.LogsExporter, error) {
@@ -84,7 +86,7 @@ func createLogsExporter(_ context.Context, _ component.ExporterCreateParams, con
 		return nil, err
 	}
 
-	return newLogsExporter(config, cfg.LogLevel, exporterLogger)
+	return newLogsExporter(cfg, exporterLogger)
 }
 
 func createLogger(cfg *Config) (*zap.Logger, error) {
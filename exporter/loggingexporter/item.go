@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	formatDetailed = "detailed"
+	formatCompact  = "compact"
+	formatJSON     = "json"
+)
+
+// Fields available for every span.
+var spanFields = []string{
+	"resource", "trace_id", "span_id", "parent_span_id", "name", "kind",
+	"start_time", "end_time", "status_code", "status_message", "attributes",
+}
+
+// Fields available for every metric data point.
+var dataPointFields = []string{
+	"resource", "name", "description", "unit", "type", "labels",
+	"start_time", "timestamp", "value", "count", "sum",
+}
+
+// Fields available for every log record.
+var logRecordFields = []string{
+	"resource", "timestamp", "trace_id", "span_id", "severity_number",
+	"severity_text", "name", "body", "attributes",
+}
+
+// field is a named value of an item.
+type field struct {
+	key   string
+	value interface{}
+}
+
+// item is the ordered list of fields logged for a span, a data point or a log record.
+type item []field
+
+// fieldSelector filters the fields of every item.
+type fieldSelector map[string]bool
+
+// newFieldSelector returns a fieldSelector that selects the given fields,
+// or all the fields if selected is empty.
+func newFieldSelector(selected []string, available []string) (fieldSelector, error) {
+	if len(selected) == 0 {
+		return nil, nil
+	}
+	fs := fieldSelector{}
+	for _, f := range selected {
+		if !contains(available, f) {
+			return nil, fmt.Errorf("unknown field %q, available fields are %v", f, available)
+		}
+		fs[f] = true
+	}
+	return fs, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// add appends the field to the item if it is selected.
+func (fs fieldSelector) add(it item, key string, value func() interface{}) item {
+	if fs != nil && !fs[key] {
+		return it
+	}
+	return append(it, field{key: key, value: value()})
+}
+
+// format returns the item as a single line in the given format.
+func (it item) format(format string) string {
+	var b strings.Builder
+	if format == formatJSON {
+		b.WriteByte('{')
+		for i, f := range it {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(f.key)
+			b.Write(key)
+			b.WriteByte(':')
+			value, err := json.Marshal(f.value)
+			if err != nil {
+				value, _ = json.Marshal(fmt.Sprint(f.value))
+			}
+			b.Write(value)
+		}
+		b.WriteByte('}')
+		return b.String()
+	}
+
+	for i, f := range it {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(f.key)
+		b.WriteByte('=')
+		b.WriteString(compactValue(f.value))
+	}
+	return b.String()
+}
+
+func compactValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		if val == "" || strings.ContainsAny(val, " \t\n\"=") {
+			return strconv.Quote(val)
+		}
+		return val
+	case map[string]interface{}, map[string]string, []interface{}:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func stringMapToMap(sm pdata.StringMap) map[string]string {
+	m := make(map[string]string, sm.Len())
+	sm.ForEach(func(k string, v string) {
+		m[k] = v
+	})
+	return m
+}
+
+func attributeValueToInterface(av pdata.AttributeValue) interface{} {
+	switch av.Type() {
+	case pdata.AttributeValueSTRING:
+		return av.StringVal()
+	case pdata.AttributeValueBOOL:
+		return av.BoolVal()
+	case pdata.AttributeValueDOUBLE:
+		return av.DoubleVal()
+	case pdata.AttributeValueINT:
+		return av.IntVal()
+	case pdata.AttributeValueARRAY:
+		return tracetranslator.AttributeArrayToSlice(av.ArrayVal())
+	case pdata.AttributeValueMAP:
+		return tracetranslator.AttributeMapToMap(av.MapVal())
+	default:
+		return nil
+	}
+}
+
+func spanItem(fs fieldSelector, resource pdata.Resource, span pdata.Span) item {
+	var it item
+	it = fs.add(it, "resource", func() interface{} { return tracetranslator.AttributeMapToMap(resource.Attributes()) })
+	it = fs.add(it, "trace_id", func() interface{} { return span.TraceID().HexString() })
+	it = fs.add(it, "span_id", func() interface{} { return span.SpanID().HexString() })
+	it = fs.add(it, "parent_span_id", func() interface{} { return span.ParentSpanID().HexString() })
+	it = fs.add(it, "name", func() interface{} { return span.Name() })
+	it = fs.add(it, "kind", func() interface{} { return span.Kind().String() })
+	it = fs.add(it, "start_time", func() interface{} { return uint64(span.StartTime()) })
+	it = fs.add(it, "end_time", func() interface{} { return uint64(span.EndTime()) })
+	it = fs.add(it, "status_code", func() interface{} { return span.Status().Code().String() })
+	it = fs.add(it, "status_message", func() interface{} { return span.Status().Message() })
+	it = fs.add(it, "attributes", func() interface{} { return tracetranslator.AttributeMapToMap(span.Attributes()) })
+	return it
+}
+
+// dataPoint holds the fields shared by all the data point types.
+type dataPoint struct {
+	labels    pdata.StringMap
+	startTime pdata.Timestamp
+	timestamp pdata.Timestamp
+	value     interface{}
+	count     interface{}
+	sum       interface{}
+}
+
+func dataPointItems(fs fieldSelector, resource pdata.Resource, metric pdata.Metric) []item {
+	var dps []dataPoint
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		ps := metric.IntGauge().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			dps = append(dps, dataPoint{labels: p.LabelsMap(), startTime: p.StartTime(), timestamp: p.Timestamp(), value: p.Value()})
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		ps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			dps = append(dps, dataPoint{labels: p.LabelsMap(), startTime: p.StartTime(), timestamp: p.Timestamp(), value: p.Value()})
+		}
+	case pdata.MetricDataTypeIntSum:
+		ps := metric.IntSum().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			dps = append(dps, dataPoint{labels: p.LabelsMap(), startTime: p.StartTime(), timestamp: p.Timestamp(), value: p.Value()})
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		ps := metric.DoubleSum().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			dps = append(dps, dataPoint{labels: p.LabelsMap(), startTime: p.StartTime(), timestamp: p.Timestamp(), value: p.Value()})
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		ps := metric.IntHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			dps = append(dps, dataPoint{labels: p.LabelsMap(), startTime: p.StartTime(), timestamp: p.Timestamp(), count: p.Count(), sum: p.Sum()})
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		ps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			dps = append(dps, dataPoint{labels: p.LabelsMap(), startTime: p.StartTime(), timestamp: p.Timestamp(), count: p.Count(), sum: p.Sum()})
+		}
+	case pdata.MetricDataTypeSummary:
+		ps := metric.Summary().DataPoints()
+		for i := 0; i < ps.Len(); i++ {
+			p := ps.At(i)
+			dps = append(dps, dataPoint{labels: p.LabelsMap(), startTime: p.StartTime(), timestamp: p.Timestamp(), count: p.Count(), sum: p.Sum()})
+		}
+	}
+
+	items := make([]item, 0, len(dps))
+	for _, dp := range dps {
+		dp := dp
+		var it item
+		it = fs.add(it, "resource", func() interface{} { return tracetranslator.AttributeMapToMap(resource.Attributes()) })
+		it = fs.add(it, "name", func() interface{} { return metric.Name() })
+		it = fs.add(it, "description", func() interface{} { return metric.Description() })
+		it = fs.add(it, "unit", func() interface{} { return metric.Unit() })
+		it = fs.add(it, "type", func() interface{} { return metric.DataType().String() })
+		it = fs.add(it, "labels", func() interface{} { return stringMapToMap(dp.labels) })
+		it = fs.add(it, "start_time", func() interface{} { return uint64(dp.startTime) })
+		it = fs.add(it, "timestamp", func() interface{} { return uint64(dp.timestamp) })
+		if dp.value != nil {
+			it = fs.add(it, "value", func() interface{} { return dp.value })
+		}
+		if dp.count != nil {
+			it = fs.add(it, "count", func() interface{} { return dp.count })
+			it = fs.add(it, "sum", func() interface{} { return dp.sum })
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+func logRecordItem(fs fieldSelector, resource pdata.Resource, lr pdata.LogRecord) item {
+	var it item
+	it = fs.add(it, "resource", func() interface{} { return tracetranslator.AttributeMapToMap(resource.Attributes()) })
+	it = fs.add(it, "timestamp", func() interface{} { return uint64(lr.Timestamp()) })
+	it = fs.add(it, "trace_id", func() interface{} { return lr.TraceID().HexString() })
+	it = fs.add(it, "span_id", func() interface{} { return lr.SpanID().HexString() })
+	it = fs.add(it, "severity_number", func() interface{} { return int32(lr.SeverityNumber()) })
+	it = fs.add(it, "severity_text", func() interface{} { return lr.SeverityText() })
+	it = fs.add(it, "name", func() interface{} { return lr.Name() })
+	it = fs.add(it, "body", func() interface{} { return attributeValueToInterface(lr.Body()) })
+	it = fs.add(it, "attributes", func() interface{} { return tracetranslator.AttributeMapToMap(lr.Attributes()) })
+	return it
+}
@@ -20,12 +20,12 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"syscall"
 
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	tracetranslator "go.opentelemetry.io/collector/translator/trace"
@@ -317,6 +317,58 @@ func attributeMapToString(av pdata.AttributeMap) string {
 type loggingExporter struct {
 	logger *zap.Logger
 	debug  bool
+	format string
+	fields fieldSelector
+
+	samplingRate uint64
+	batches      uint64
+}
+
+func newLoggingExporter(cfg *Config, logger *zap.Logger, available []string, selected []string) (*loggingExporter, error) {
+	format := cfg.Format
+	if format == "" {
+		format = formatDetailed
+	}
+	switch format {
+	case formatDetailed, formatCompact, formatJSON:
+	default:
+		return nil, fmt.Errorf("unsupported format %q, must be %q, %q or %q", cfg.Format, formatDetailed, formatCompact, formatJSON)
+	}
+	if cfg.BatchSamplingRate < 0 {
+		return nil, fmt.Errorf("batch_sampling_rate must not be negative")
+	}
+	fields, err := newFieldSelector(selected, available)
+	if err != nil {
+		return nil, err
+	}
+	return &loggingExporter{
+		logger:       logger,
+		debug:        strings.ToLower(cfg.LogLevel) == "debug",
+		format:       format,
+		fields:       fields,
+		samplingRate: uint64(cfg.BatchSamplingRate),
+	}, nil
+}
+
+// sampled returns true if the current batch must be logged.
+func (s *loggingExporter) sampled() bool {
+	if s.samplingRate <= 1 {
+		return true
+	}
+	return (atomic.AddUint64(&s.batches, 1)-1)%s.samplingRate == 0
+}
+
+// logItems logs every item on its own line.
+func (s *loggingExporter) logItems(items []item) {
+	if len(items) == 0 {
+		return
+	}
+	var b strings.Builder
+	for _, it := range items {
+		b.WriteString(it.format(s.format))
+		b.WriteByte('\n')
+	}
+	s.logger.Debug(b.String())
 }
 
 func (s *loggingExporter) pushTraceData(
@@ -324,12 +376,33 @@ func (s *loggingExporter) pushTraceData(
 	td pdata.Traces,
 ) error {
 
+	if !s.sampled() {
+		return nil
+	}
+
 	s.logger.Info("TracesExporter", zap.Int("#spans", td.SpanCount()))
 
 	if !s.debug {
 		return nil
 	}
 
+	if s.format != formatDetailed {
+		var items []item
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			ilss := rs.InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					items = append(items, spanItem(s.fields, rs.Resource(), spans.At(k)))
+				}
+			}
+		}
+		s.logItems(items)
+		return nil
+	}
+
 	buf := logDataBuffer{}
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
@@ -372,12 +445,33 @@ func (s *loggingExporter) pushMetricsData(
 	_ context.Context,
 	md pdata.Metrics,
 ) error {
+	if !s.sampled() {
+		return nil
+	}
+
 	s.logger.Info("MetricsExporter", zap.Int("#metrics", md.MetricCount()))
 
 	if !s.debug {
 		return nil
 	}
 
+	if s.format != formatDetailed {
+		var items []item
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			ilms := rm.InstrumentationLibraryMetrics()
+			for j := 0; j < ilms.Len(); j++ {
+				metrics := ilms.At(j).Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					items = append(items, dataPointItems(s.fields, rm.Resource(), metrics.At(k))...)
+				}
+			}
+		}
+		s.logItems(items)
+		return nil
+	}
+
 	buf := logDataBuffer{}
 	rms := md.ResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
@@ -406,14 +500,14 @@ func (s *loggingExporter) pushMetricsData(
 
 // newTraceExporter creates an exporter.TracesExporter that just drops the
 // received data and logs debugging messages.
-func newTraceExporter(config configmodels.Exporter, level string, logger *zap.Logger) (component.TracesExporter, error) {
-	s := &loggingExporter{
-		debug:  strings.ToLower(level) == "debug",
-		logger: logger,
+func newTraceExporter(cfg *Config, logger *zap.Logger) (component.TracesExporter, error) {
+	s, err := newLoggingExporter(cfg, logger, spanFields, cfg.Fields.Traces)
+	if err != nil {
+		return nil, err
 	}
 
 	return exporterhelper.NewTraceExporter(
-		config,
+		cfg,
 		logger,
 		s.pushTraceData,
 		// Disable Timeout/RetryOnFailure and SendingQueue
@@ -426,14 +520,14 @@ func newTraceExporter(config configmodels.Exporter, level string, logger *zap.Lo
 
 // newMetricsExporter creates an exporter.MetricsExporter that just drops the
 // received data and logs debugging messages.
-func newMetricsExporter(config configmodels.Exporter, level string, logger *zap.Logger) (component.MetricsExporter, error) {
-	s := &loggingExporter{
-		debug:  strings.ToLower(level) == "debug",
-		logger: logger,
+func newMetricsExporter(cfg *Config, logger *zap.Logger) (component.MetricsExporter, error) {
+	s, err := newLoggingExporter(cfg, logger, dataPointFields, cfg.Fields.Metrics)
+	if err != nil {
+		return nil, err
 	}
 
 	return exporterhelper.NewMetricsExporter(
-		config,
+		cfg,
 		logger,
 		s.pushMetricsData,
 		// Disable Timeout/RetryOnFailure and SendingQueue
@@ -446,14 +540,14 @@ func newMetricsExporter(config configmodels.Exporter, level string, logger *zap.
 
 // newLogsExporter creates an exporter.LogsExporter that just drops the
 // received data and logs debugging messages.
-func newLogsExporter(config configmodels.Exporter, level string, logger *zap.Logger) (component.LogsExporter, error) {
-	s := &loggingExporter{
-		debug:  strings.ToLower(level) == "debug",
-		logger: logger,
+func newLogsExporter(cfg *Config, logger *zap.Logger) (component.LogsExporter, error) {
+	s, err := newLoggingExporter(cfg, logger, logRecordFields, cfg.Fields.Logs)
+	if err != nil {
+		return nil, err
 	}
 
 	return exporterhelper.NewLogsExporter(
-		config,
+		cfg,
 		logger,
 		s.pushLogData,
 		// Disable Timeout/RetryOnFailure and SendingQueue
@@ -468,12 +562,33 @@ func (s *loggingExporter) pushLogData(
 	_ context.Context,
 	ld pdata.Logs,
 ) error {
+	if !s.sampled() {
+		return nil
+	}
+
 	s.logger.Info("LogsExporter", zap.Int("#logs", ld.LogRecordCount()))
 
 	if !s.debug {
 		return nil
 	}
 
+	if s.format != formatDetailed {
+		var items []item
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			rl := rls.At(i)
+			ills := rl.InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				logs := ills.At(j).Logs()
+				for k := 0; k < logs.Len(); k++ {
+					items = append(items, logRecordItem(s.fields, rl.Resource(), logs.At(k)))
+				}
+			}
+		}
+		s.logItems(items)
+		return nil
+	}
+
 	buf := logDataBuffer{}
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
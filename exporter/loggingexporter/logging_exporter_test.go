@@ -4,7 +4,7 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//       http://www.apache.org/licenses/LICENSE-2.0
+//	http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -20,14 +20,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
-	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/testdata"
 )
 
 func TestLoggingTraceExporterNoErrors(t *testing.T) {
-	lte, err := newTraceExporter(&configmodels.ExporterSettings{}, "Debug", zap.NewNop())
+	lte, err := newTraceExporter(&Config{LogLevel: "Debug"}, zap.NewNop())
 	require.NotNil(t, lte)
 	assert.NoError(t, err)
 
@@ -38,7 +39,7 @@ func TestLoggingTraceExporterNoErrors(t *testing.T) {
 }
 
 func TestLoggingMetricsExporterNoErrors(t *testing.T) {
-	lme, err := newMetricsExporter(&configmodels.ExporterSettings{}, "DEBUG", zap.NewNop())
+	lme, err := newMetricsExporter(&Config{LogLevel: "DEBUG"}, zap.NewNop())
 	require.NotNil(t, lme)
 	assert.NoError(t, err)
 
@@ -51,7 +52,7 @@ func TestLoggingMetricsExporterNoErrors(t *testing.T) {
 }
 
 func TestLoggingLogsExporterNoErrors(t *testing.T) {
-	lle, err := newLogsExporter(&configmodels.ExporterSettings{}, "debug", zap.NewNop())
+	lle, err := newLogsExporter(&Config{LogLevel: "debug"}, zap.NewNop())
 	require.NotNil(t, lle)
 	assert.NoError(t, err)
 
@@ -97,3 +98,53 @@ func TestNestedMapSerializesCorrectly(t *testing.T) {
 	assert.Equal(t, 2, ava.MapVal().Len())
 	assert.Equal(t, expected, attributeValueToString(ava))
 }
+
+func TestLoggingExporterCompactFormat(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	cfg := &Config{LogLevel: "debug", Format: formatCompact, Fields: FieldsSettings{Traces: []string{"name", "kind"}}}
+	lte, err := newTraceExporter(cfg, zap.New(core))
+	require.NoError(t, err)
+
+	assert.NoError(t, lte.ConsumeTraces(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource()))
+	debugLogs := logs.FilterMessageSnippet("name=").All()
+	require.Len(t, debugLogs, 1)
+	assert.Equal(t, "name=operationA kind=SPAN_KIND_UNSPECIFIED\nname=operationB kind=SPAN_KIND_UNSPECIFIED\n", debugLogs[0].Message)
+}
+
+func TestLoggingExporterJSONFormat(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	cfg := &Config{LogLevel: "debug", Format: formatJSON, Fields: FieldsSettings{Logs: []string{"name", "severity_number"}}}
+	lle, err := newLogsExporter(cfg, zap.New(core))
+	require.NoError(t, err)
+
+	assert.NoError(t, lle.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+	debugLogs := logs.FilterMessageSnippet("{").All()
+	require.Len(t, debugLogs, 1)
+	assert.Equal(t, `{"severity_number":9,"name":"logA"}`+"\n", debugLogs[0].Message)
+
+	cfg = &Config{LogLevel: "debug", Format: formatJSON}
+	lme, err := newMetricsExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, lme.ConsumeMetrics(context.Background(), testdata.GeneratMetricsAllTypesWithSampleDatapoints()))
+}
+
+func TestLoggingExporterBatchSampling(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	lte, err := newTraceExporter(&Config{LogLevel: "info", BatchSamplingRate: 3}, zap.New(core))
+	require.NoError(t, err)
+
+	for i := 0; i < 7; i++ {
+		assert.NoError(t, lte.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	}
+	// Batches 1, 4 and 7 are logged.
+	assert.Equal(t, 3, logs.FilterMessage("TracesExporter").Len())
+}
+
+func TestLoggingExporterInvalidSettings(t *testing.T) {
+	_, err := newTraceExporter(&Config{Format: "xml"}, zap.NewNop())
+	assert.Error(t, err)
+	_, err = newTraceExporter(&Config{Fields: FieldsSettings{Traces: []string{"unknown"}}}, zap.NewNop())
+	assert.Error(t, err)
+	_, err = newTraceExporter(&Config{BatchSamplingRate: -1}, zap.NewNop())
+	assert.Error(t, err)
+}
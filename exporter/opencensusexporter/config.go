@@ -15,6 +15,8 @@
 package opencensusexporter
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -30,3 +32,16 @@ type Config struct {
 	// The number of workers that send the gRPC requests.
 	NumWorkers int `mapstructure:"num_workers"`
 }
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the endpoint is set, that there is at least one worker and that the queue settings are valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("OpenCensus exporter cfg requires an Endpoint")
+	}
+	if cfg.NumWorkers <= 0 {
+		return errors.New("OpenCensus exporter cfg requires at least one worker")
+	}
+	return cfg.QueueSettings.Validate()
+}
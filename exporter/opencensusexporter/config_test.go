@@ -42,7 +42,11 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 
 	e0 := cfg.Exporters["opencensus"]
-	assert.Equal(t, e0, factory.CreateDefaultConfig())
+
+	// Endpoint doesn't have a default value so set it directly.
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "localhost:55678"
+	assert.Equal(t, defaultCfg, e0)
 
 	e1 := cfg.Exporters["opencensus/2"]
 	assert.Equal(t, e1,
@@ -87,3 +91,14 @@ func TestLoadConfig(t *testing.T) {
 			NumWorkers: 123,
 		})
 }
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "OpenCensus exporter cfg requires an Endpoint")
+
+	cfg.Endpoint = "localhost:55678"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.NumWorkers = 0
+	assert.EqualError(t, cfg.Validate(), "OpenCensus exporter cfg requires at least one worker")
+}
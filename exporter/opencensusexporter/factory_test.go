@@ -43,29 +43,6 @@ func TestCreateTraceExporter(t *testing.T) {
 		config   Config
 		mustFail bool
 	}{
-		{
-			name: "NoEndpoint",
-			config: Config{
-				GRPCClientSettings: configgrpc.GRPCClientSettings{
-					Endpoint: "",
-				},
-				NumWorkers: 3,
-			},
-			mustFail: true,
-		},
-		{
-			name: "ZeroNumWorkers",
-			config: Config{
-				GRPCClientSettings: configgrpc.GRPCClientSettings{
-					Endpoint: endpoint,
-					TLSSetting: configtls.TLSClientSetting{
-						Insecure: false,
-					},
-				},
-				NumWorkers: 0,
-			},
-			mustFail: true,
-		},
 		{
 			name: "UseSecure",
 			config: Config{
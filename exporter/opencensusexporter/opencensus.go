@@ -26,6 +26,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/internaldata"
 )
@@ -58,18 +59,11 @@ type ocExporter struct {
 }
 
 func newOcExporter(ctx context.Context, cfg *Config) (*ocExporter, error) {
-	if cfg.Endpoint == "" {
-		return nil, errors.New("OpenCensus exporter cfg requires an Endpoint")
-	}
-
-	if cfg.NumWorkers <= 0 {
-		return nil, errors.New("OpenCensus exporter cfg requires at least one worker")
-	}
-
 	dialOpts, err := cfg.GRPCClientSettings.ToDialOptions()
 	if err != nil {
 		return nil, err
 	}
+	dialOpts = append(dialOpts, configgrpc.RegisteredDialOptions(typeStr)...)
 
 	var clientConn *grpc.ClientConn
 	if clientConn, err = grpc.DialContext(ctx, cfg.GRPCClientSettings.Endpoint, dialOpts...); err != nil {
@@ -15,6 +15,8 @@
 package otlpexporter
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -22,10 +24,72 @@ import (
 
 // Config defines configuration for OpenCensus exporter.
 type Config struct {
-	configmodels.ExporterSettings  `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
-	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+	configmodels.ExporterSettings         `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.TimeoutSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings          `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings          `mapstructure:"retry_on_failure"`
+	exporterhelper.CircuitBreakerSettings `mapstructure:"circuit_breaker"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// The target to send traces to. If omitted the Endpoint will be used.
+	TracesEndpoint string `mapstructure:"traces_endpoint"`
+	// The headers added to the Headers for the traces, replacing the headers with the same names.
+	TracesHeaders map[string]string `mapstructure:"traces_headers"`
+	// The compression for the traces. If omitted the Compression will be used.
+	TracesCompression string `mapstructure:"traces_compression"`
+
+	// The target to send metrics to. If omitted the Endpoint will be used.
+	MetricsEndpoint string `mapstructure:"metrics_endpoint"`
+	// The headers added to the Headers for the metrics, replacing the headers with the same names.
+	MetricsHeaders map[string]string `mapstructure:"metrics_headers"`
+	// The compression for the metrics. If omitted the Compression will be used.
+	MetricsCompression string `mapstructure:"metrics_compression"`
+
+	// The target to send logs to. If omitted the Endpoint will be used.
+	LogsEndpoint string `mapstructure:"logs_endpoint"`
+	// The headers added to the Headers for the logs, replacing the headers with the same names.
+	LogsHeaders map[string]string `mapstructure:"logs_headers"`
+	// The compression for the logs. If omitted the Compression will be used.
+	LogsCompression string `mapstructure:"logs_compression"`
+}
+
+// clientSettings returns the gRPC client settings of a signal, the common
+// settings with the given endpoint, headers and compression overrides applied.
+func (cfg *Config) clientSettings(endpoint string, headers map[string]string, compression string) configgrpc.GRPCClientSettings {
+	settings := cfg.GRPCClientSettings
+	if endpoint != "" {
+		settings.Endpoint = endpoint
+	}
+	if compression != "" {
+		settings.Compression = compression
+	}
+	if len(headers) > 0 {
+		settings.Headers = make(map[string]string, len(cfg.Headers)+len(headers))
+		for k, v := range cfg.Headers {
+			settings.Headers[k] = v
+		}
+		for k, v := range headers {
+			settings.Headers[k] = v
+		}
+	}
+	return settings
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that each signal has an endpoint and that the queue settings are valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		for _, signal := range []struct{ name, endpoint string }{
+			{"traces", cfg.TracesEndpoint},
+			{"metrics", cfg.MetricsEndpoint},
+			{"logs", cfg.LogsEndpoint},
+		} {
+			if signal.endpoint == "" {
+				return fmt.Errorf("OTLP exporter config requires an endpoint or a %s_endpoint", signal.name)
+			}
+		}
+	}
+	return cfg.QueueSettings.Validate()
 }
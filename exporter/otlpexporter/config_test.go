@@ -42,7 +42,11 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 
 	e0 := cfg.Exporters["otlp"]
-	assert.Equal(t, e0, factory.CreateDefaultConfig())
+
+	// The endpoint doesn't have a default value so set it directly.
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "localhost:4317"
+	assert.Equal(t, defaultCfg, e0)
 
 	e1 := cfg.Exporters["otlp/2"]
 	assert.Equal(t, e1,
@@ -61,9 +65,19 @@ func TestLoadConfig(t *testing.T) {
 				MaxElapsedTime:  10 * time.Minute,
 			},
 			QueueSettings: exporterhelper.QueueSettings{
-				Enabled:      true,
-				NumConsumers: 2,
-				QueueSize:    10,
+				Enabled:       true,
+				NumConsumers:  2,
+				QueueSize:     10,
+				MinConsumers:  1,
+				MaxConsumers:  50,
+				HighWatermark: 0.8,
+				LowWatermark:  0.5,
+			},
+			CircuitBreakerSettings: exporterhelper.CircuitBreakerSettings{
+				Enabled:             true,
+				FailureThreshold:    10,
+				OpenTimeout:         time.Minute,
+				HalfOpenMaxRequests: 2,
 			},
 			GRPCClientSettings: configgrpc.GRPCClientSettings{
 				Headers: map[string]string{
@@ -92,4 +106,45 @@ func TestLoadConfig(t *testing.T) {
 				BalancerName: "round_robin",
 			},
 		})
+
+	e2 := cfg.Exporters["otlp/signals"].(*Config)
+	assert.Equal(t, "traces.example.com:443", e2.TracesEndpoint)
+	assert.Equal(t, map[string]string{"tenant": "traces"}, e2.TracesHeaders)
+	assert.Equal(t, "metrics.example.com:443", e2.MetricsEndpoint)
+	assert.Equal(t, "none", e2.MetricsCompression)
+	assert.Equal(t, map[string]string{"logs": "true"}, e2.LogsHeaders)
+}
+
+func TestClientSettings(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "1.2.3.4:1234"
+	cfg.Compression = "gzip"
+	cfg.Headers = map[string]string{"common": "value", "tenant": "default"}
+
+	// The common settings are used when there are no overrides.
+	assert.Equal(t, cfg.GRPCClientSettings, cfg.clientSettings("", nil, ""))
+
+	settings := cfg.clientSettings("traces.example.com:443", map[string]string{"tenant": "traces"}, "none")
+	assert.Equal(t, "traces.example.com:443", settings.Endpoint)
+	assert.Equal(t, "none", settings.Compression)
+	assert.Equal(t, map[string]string{"common": "value", "tenant": "traces"}, settings.Headers)
+	assert.Equal(t, cfg.WriteBufferSize, settings.WriteBufferSize)
+	// The common headers are left unchanged.
+	assert.Equal(t, map[string]string{"common": "value", "tenant": "default"}, cfg.Headers)
+}
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "OTLP exporter config requires an endpoint or a traces_endpoint")
+
+	cfg.TracesEndpoint = "traces.example.com:443"
+	cfg.MetricsEndpoint = "metrics.example.com:443"
+	assert.EqualError(t, cfg.Validate(), "OTLP exporter config requires an endpoint or a logs_endpoint")
+
+	cfg.LogsEndpoint = "logs.example.com:443"
+	assert.NoError(t, cfg.Validate())
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Endpoint = "1.2.3.4:1234"
+	assert.NoError(t, cfg.Validate())
 }
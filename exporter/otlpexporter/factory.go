@@ -44,9 +44,10 @@ func createDefaultConfig() configmodels.Exporter {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
-		TimeoutSettings: exporterhelper.DefaultTimeoutSettings(),
-		RetrySettings:   exporterhelper.DefaultRetrySettings(),
-		QueueSettings:   exporterhelper.DefaultQueueSettings(),
+		TimeoutSettings:        exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:          exporterhelper.DefaultRetrySettings(),
+		QueueSettings:          exporterhelper.DefaultQueueSettings(),
+		CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
 		GRPCClientSettings: configgrpc.GRPCClientSettings{
 			Headers: map[string]string{},
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
@@ -60,11 +61,11 @@ func createTraceExporter(
 	params component.ExporterCreateParams,
 	cfg configmodels.Exporter,
 ) (component.TracesExporter, error) {
-	oce, err := newExporter(cfg)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(cfg, oCfg.clientSettings(oCfg.TracesEndpoint, oCfg.TracesHeaders, oCfg.TracesCompression))
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 	oexp, err := exporterhelper.NewTraceExporter(
 		cfg,
 		params.Logger,
@@ -72,6 +73,7 @@ func createTraceExporter(
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
 		exporterhelper.WithShutdown(oce.shutdown))
 	if err != nil {
 		return nil, err
@@ -85,11 +87,11 @@ func createMetricsExporter(
 	params component.ExporterCreateParams,
 	cfg configmodels.Exporter,
 ) (component.MetricsExporter, error) {
-	oce, err := newExporter(cfg)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(cfg, oCfg.clientSettings(oCfg.MetricsEndpoint, oCfg.MetricsHeaders, oCfg.MetricsCompression))
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 	oexp, err := exporterhelper.NewMetricsExporter(
 		cfg,
 		params.Logger,
@@ -97,6 +99,7 @@ func createMetricsExporter(
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
 		exporterhelper.WithShutdown(oce.shutdown),
 	)
 	if err != nil {
@@ -111,11 +114,11 @@ func createLogsExporter(
 	params component.ExporterCreateParams,
 	cfg configmodels.Exporter,
 ) (component.LogsExporter, error) {
-	oce, err := newExporter(cfg)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(cfg, oCfg.clientSettings(oCfg.LogsEndpoint, oCfg.LogsHeaders, oCfg.LogsCompression))
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 	oexp, err := exporterhelper.NewLogsExporter(
 		cfg,
 		params.Logger,
@@ -123,6 +126,7 @@ func createLogsExporter(
 		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
 		exporterhelper.WithShutdown(oce.shutdown),
 	)
 	if err != nil {
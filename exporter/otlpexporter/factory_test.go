@@ -62,15 +62,6 @@ func TestCreateTraceExporter(t *testing.T) {
 		config   Config
 		mustFail bool
 	}{
-		{
-			name: "NoEndpoint",
-			config: Config{
-				GRPCClientSettings: configgrpc.GRPCClientSettings{
-					Endpoint: "",
-				},
-			},
-			mustFail: true,
-		},
 		{
 			name: "UseSecure",
 			config: Config{
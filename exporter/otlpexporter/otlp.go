@@ -16,7 +16,6 @@ package otlpexporter
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -26,6 +25,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -42,18 +42,15 @@ type exporterImp struct {
 	w      *grpcSender
 }
 
-// Crete new exporter and start it. The exporter will begin connecting but
-// this function may return before the connection is established.
-func newExporter(cfg configmodels.Exporter) (*exporterImp, error) {
+// Crete new exporter and start it, sending with the given client settings of the
+// signal. The exporter will begin connecting but this function may return before
+// the connection is established.
+func newExporter(cfg configmodels.Exporter, settings configgrpc.GRPCClientSettings) (*exporterImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Endpoint == "" {
-		return nil, errors.New("OTLP exporter config requires an Endpoint")
-	}
-
 	e := &exporterImp{}
 	e.config = oCfg
-	w, err := newGrpcSender(oCfg)
+	w, err := newGrpcSender(settings)
 	if err != nil {
 		return nil, err
 	}
@@ -98,14 +95,15 @@ type grpcSender struct {
 	waitForReady   bool
 }
 
-func newGrpcSender(config *Config) (*grpcSender, error) {
-	dialOpts, err := config.GRPCClientSettings.ToDialOptions()
+func newGrpcSender(settings configgrpc.GRPCClientSettings) (*grpcSender, error) {
+	dialOpts, err := settings.ToDialOptions()
 	if err != nil {
 		return nil, err
 	}
+	dialOpts = append(dialOpts, configgrpc.RegisteredDialOptions(typeStr)...)
 
 	var clientConn *grpc.ClientConn
-	if clientConn, err = grpc.Dial(config.GRPCClientSettings.Endpoint, dialOpts...); err != nil {
+	if clientConn, err = grpc.Dial(settings.Endpoint, dialOpts...); err != nil {
 		return nil, err
 	}
 
@@ -114,8 +112,8 @@ func newGrpcSender(config *Config) (*grpcSender, error) {
 		metricExporter: otlpmetrics.NewMetricsServiceClient(clientConn),
 		logExporter:    otlplogs.NewLogsServiceClient(clientConn),
 		grpcClientConn: clientConn,
-		metadata:       metadata.New(config.GRPCClientSettings.Headers),
-		waitForReady:   config.GRPCClientSettings.WaitForReady,
+		metadata:       metadata.New(settings.Headers),
+		waitForReady:   settings.WaitForReady,
 	}
 	return gs, nil
 }
@@ -165,6 +163,10 @@ func processError(err error) error {
 
 	// Now, this is this a real error.
 
+	if st.Code() == codes.Unauthenticated || st.Code() == codes.PermissionDenied {
+		err = consumererror.WithReason(err, consumererror.ReasonAuthFailed)
+	}
+
 	if !shouldRetry(st.Code()) {
 		// It is not a retryable error, we should not retry.
 		return consumererror.Permanent(err)
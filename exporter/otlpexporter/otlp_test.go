@@ -259,6 +259,49 @@ func TestSendTraces(t *testing.T) {
 	require.EqualValues(t, rcv.GetMetadata().Get("header"), expectedHeader)
 }
 
+func TestSendMetricsSignalEndpoint(t *testing.T) {
+	// Start an OTLP-compatible receiver for the metrics only.
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv := otlpMetricsReceiverOnGRPCServer(ln)
+	// Also closes the connection.
+	defer rcv.srv.GracefulStop()
+
+	// The common endpoint is not listening, the metrics are sent to the metrics endpoint.
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: "localhost:1",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		Headers: map[string]string{
+			"header": "header-value",
+		},
+	}
+	cfg.MetricsEndpoint = ln.Addr().String()
+	cfg.MetricsHeaders = map[string]string{"signal": "metrics"}
+	creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exp, err := factory.CreateMetricsExporter(context.Background(), creationParams, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+	assert.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.NoError(t, exp.ConsumeMetrics(context.Background(), testdata.GenerateMetricsTwoMetrics()))
+
+	// Wait until it is received.
+	testutil.WaitFor(t, func() bool {
+		return atomic.LoadInt32(&rcv.requestCount) > 0
+	}, "receive a request")
+
+	md := rcv.GetMetadata()
+	assert.Equal(t, []string{"header-value"}, md.Get("header"))
+	assert.Equal(t, []string{"metrics"}, md.Get("signal"))
+}
+
 func TestSendMetrics(t *testing.T) {
 	// Start an OTLP-compatible receiver.
 	ln, err := net.Listen("tcp", "localhost:")
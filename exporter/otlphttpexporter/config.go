@@ -15,6 +15,10 @@
 package otlphttpexporter
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -35,8 +39,32 @@ type Config struct {
 
 	// The URL to send logs to. If omitted the Endpoint + "/v1/logs" will be used.
 	LogsEndpoint string `mapstructure:"logs_endpoint"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
 
-	// The compression key for supported compression types within
-	// collector. Currently the only supported mode is `gzip`.
-	Compression string `mapstructure:"compression"`
+// Validate checks that each signal has an endpoint, that the endpoints are valid URLs and that the queue settings are
+// valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint != "" {
+		if _, err := url.Parse(cfg.Endpoint); err != nil {
+			return errors.New("endpoint must be a valid URL")
+		}
+	}
+	for _, signal := range []struct{ name, endpoint string }{
+		{"traces", cfg.TracesEndpoint},
+		{"metrics", cfg.MetricsEndpoint},
+		{"logs", cfg.LogsEndpoint},
+	} {
+		if signal.endpoint == "" {
+			if cfg.Endpoint == "" {
+				return fmt.Errorf("either endpoint or %s_endpoint must be specified", signal.name)
+			}
+			continue
+		}
+		if _, err := url.Parse(signal.endpoint); err != nil {
+			return fmt.Errorf("%s_endpoint must be a valid URL", signal.name)
+		}
+	}
+	return cfg.QueueSettings.Validate()
 }
@@ -42,7 +42,11 @@ func TestLoadConfig(t *testing.T) {
 	require.NotNil(t, cfg)
 
 	e0 := cfg.Exporters["otlphttp"]
-	assert.Equal(t, e0, factory.CreateDefaultConfig())
+
+	// The endpoint doesn't have a default value so set it directly.
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "https://localhost:4318"
+	assert.Equal(t, defaultCfg, e0)
 
 	e1 := cfg.Exporters["otlphttp/2"]
 	assert.Equal(t, e1,
@@ -58,9 +62,13 @@ func TestLoadConfig(t *testing.T) {
 				MaxElapsedTime:  10 * time.Minute,
 			},
 			QueueSettings: exporterhelper.QueueSettings{
-				Enabled:      true,
-				NumConsumers: 2,
-				QueueSize:    10,
+				Enabled:       true,
+				NumConsumers:  2,
+				QueueSize:     10,
+				MinConsumers:  1,
+				MaxConsumers:  50,
+				HighWatermark: 0.8,
+				LowWatermark:  0.5,
 			},
 			HTTPClientSettings: confighttp.HTTPClientSettings{
 				Headers: map[string]string{
@@ -80,7 +88,7 @@ func TestLoadConfig(t *testing.T) {
 				ReadBufferSize:  123,
 				WriteBufferSize: 345,
 				Timeout:         time.Second * 10,
+				Compression:     "gzip",
 			},
-			Compression: "gzip",
 		})
 }
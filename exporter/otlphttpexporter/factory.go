@@ -16,8 +16,6 @@ package otlphttpexporter
 
 import (
 	"context"
-	"fmt"
-	"net/url"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -59,19 +57,12 @@ func createDefaultConfig() configmodels.Exporter {
 	}
 }
 
-func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string) (string, error) {
-	switch {
-	case signalOverrideURL != "":
-		_, err := url.Parse(signalOverrideURL)
-		if err != nil {
-			return "", fmt.Errorf("%s_endpoint must be a valid URL", signalName)
-		}
-		return signalOverrideURL, nil
-	case oCfg.Endpoint == "":
-		return "", fmt.Errorf("either endpoint or %s_endpoint must be specified", signalName)
-	default:
-		return oCfg.Endpoint + "/v1/" + signalName, nil
+// composeSignalURL returns the URL of a signal, its override or the endpoint followed by the path of the signal.
+func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string) string {
+	if signalOverrideURL != "" {
+		return signalOverrideURL
 	}
+	return oCfg.Endpoint + "/v1/" + signalName
 }
 
 func createTraceExporter(
@@ -85,10 +76,7 @@ func createTraceExporter(
 	}
 	oCfg := cfg.(*Config)
 
-	oce.tracesURL, err = composeSignalURL(oCfg, oCfg.TracesEndpoint, "traces")
-	if err != nil {
-		return nil, err
-	}
+	oce.tracesURL = composeSignalURL(oCfg, oCfg.TracesEndpoint, "traces")
 
 	return exporterhelper.NewTraceExporter(
 		cfg,
@@ -111,10 +99,7 @@ func createMetricsExporter(
 	}
 	oCfg := cfg.(*Config)
 
-	oce.metricsURL, err = composeSignalURL(oCfg, oCfg.MetricsEndpoint, "metrics")
-	if err != nil {
-		return nil, err
-	}
+	oce.metricsURL = composeSignalURL(oCfg, oCfg.MetricsEndpoint, "metrics")
 
 	return exporterhelper.NewMetricsExporter(
 		cfg,
@@ -137,10 +122,7 @@ func createLogsExporter(
 	}
 	oCfg := cfg.(*Config)
 
-	oce.logsURL, err = composeSignalURL(oCfg, oCfg.LogsEndpoint, "logs")
-	if err != nil {
-		return nil, err
-	}
+	oce.logsURL = composeSignalURL(oCfg, oCfg.LogsEndpoint, "logs")
 
 	return exporterhelper.NewLogsExporter(
 		cfg,
@@ -65,15 +65,6 @@ func TestCreateTraceExporter(t *testing.T) {
 		config   Config
 		mustFail bool
 	}{
-		{
-			name: "NoEndpoint",
-			config: Config{
-				HTTPClientSettings: confighttp.HTTPClientSettings{
-					Endpoint: "",
-				},
-			},
-			mustFail: true,
-		},
 		{
 			name: "UseSecure",
 			config: Config{
@@ -17,26 +17,22 @@ package otlphttpexporter
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"strconv"
-	"strings"
 	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/protobuf/proto"
 
-	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
-	"go.opentelemetry.io/collector/internal/middleware"
 )
 
 type exporterImp struct {
@@ -58,26 +54,13 @@ const (
 func newExporter(cfg configmodels.Exporter, logger *zap.Logger) (*exporterImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Endpoint != "" {
-		_, err := url.Parse(oCfg.Endpoint)
-		if err != nil {
-			return nil, errors.New("endpoint must be a valid URL")
-		}
-	}
-
-	client, err := oCfg.HTTPClientSettings.ToClient()
+	clientSettings := oCfg.HTTPClientSettings
+	clientSettings.Middlewares = append(clientSettings.Middlewares, confighttp.RegisteredMiddlewares(typeStr)...)
+	client, err := clientSettings.ToClient()
 	if err != nil {
 		return nil, err
 	}
 
-	if oCfg.Compression != "" {
-		if strings.ToLower(oCfg.Compression) == configgrpc.CompressionGzip {
-			client.Transport = middleware.NewCompressRoundTripper(client.Transport)
-		} else {
-			return nil, fmt.Errorf("unsupported compression type %q", oCfg.Compression)
-		}
-	}
-
 	return &exporterImp{
 		config: oCfg,
 		client: client,
@@ -165,6 +148,10 @@ func (e *exporterImp) export(ctx context.Context, url string, request []byte) er
 			url, resp.StatusCode)
 	}
 
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		formattedErr = consumererror.WithReason(formattedErr, consumererror.ReasonAuthFailed)
+	}
+
 	// Check if the server is overwhelmed.
 	// See spec https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#throttling-1
 	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
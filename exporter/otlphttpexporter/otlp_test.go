@@ -50,14 +50,18 @@ func TestInvalidConfig(t *testing.T) {
 			Endpoint: "",
 		},
 	}
-	f := NewFactory()
-	params := component.ExporterCreateParams{Logger: zap.NewNop()}
-	_, err := f.CreateTracesExporter(context.Background(), params, config)
-	require.Error(t, err)
-	_, err = f.CreateMetricsExporter(context.Background(), params, config)
-	require.Error(t, err)
-	_, err = f.CreateLogsExporter(context.Background(), params, config)
-	require.Error(t, err)
+	assert.EqualError(t, config.Validate(), "either endpoint or traces_endpoint must be specified")
+
+	config.TracesEndpoint = "http://localhost/v1/traces"
+	assert.EqualError(t, config.Validate(), "either endpoint or metrics_endpoint must be specified")
+
+	config.MetricsEndpoint = "http://localhost/v1/metrics"
+	config.LogsEndpoint = "://missing-scheme"
+	assert.EqualError(t, config.Validate(), "logs_endpoint must be a valid URL")
+
+	config.LogsEndpoint = ""
+	config.Endpoint = "http://localhost"
+	assert.NoError(t, config.Validate())
 }
 
 func TestTraceNoBackend(t *testing.T) {
@@ -149,6 +153,11 @@ func TestCompressionOptions(t *testing.T) {
 			baseURL:     fmt.Sprintf("http://%s", addr),
 			compression: "gzip",
 		},
+		{
+			name:        "zstd",
+			baseURL:     fmt.Sprintf("http://%s", addr),
+			compression: "zstd",
+		},
 		{
 			name:        "incorrect compression",
 			baseURL:     fmt.Sprintf("http://%s", addr),
@@ -61,8 +61,13 @@ func newAccumulator(logger *zap.Logger, metricExpiration time.Duration) accumula
 	}
 }
 
-// Accumulate stores one datapoint per metric
+// Accumulate stores one datapoint per metric, and the target_info metric of the resource
 func (a *lastValueAccumulator) Accumulate(rm pdata.ResourceMetrics) (n int) {
+	if targetInfo, ok := createTargetInfo(rm.Resource()); ok {
+		// target_info is generated by the exporter, it is not counted as a processed metric.
+		a.addMetric(targetInfo, pdata.NewInstrumentationLibrary())
+	}
+
 	ilms := rm.InstrumentationLibraryMetrics()
 
 	for i := 0; i < ilms.Len(); i++ {
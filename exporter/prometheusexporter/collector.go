@@ -28,18 +28,20 @@ type collector struct {
 	accumulator accumulator
 	logger      *zap.Logger
 
-	sendTimestamps bool
-	namespace      string
-	constLabels    prometheus.Labels
+	sendTimestamps    bool
+	enableOpenMetrics bool
+	namespace         string
+	constLabels       prometheus.Labels
 }
 
 func newCollector(config *Config, logger *zap.Logger) *collector {
 	return &collector{
-		accumulator:    newAccumulator(logger, config.MetricExpiration),
-		logger:         logger,
-		namespace:      sanitize(config.Namespace),
-		sendTimestamps: config.SendTimestamps,
-		constLabels:    config.ConstLabels,
+		accumulator:       newAccumulator(logger, config.MetricExpiration),
+		logger:            logger,
+		namespace:         sanitize(config.Namespace),
+		sendTimestamps:    config.SendTimestamps,
+		enableOpenMetrics: config.EnableOpenMetrics,
+		constLabels:       config.ConstLabels,
 	}
 }
 
@@ -76,7 +78,7 @@ func (c *collector) convertMetric(metric pdata.Metric) (prometheus.Metric, error
 }
 
 func metricName(namespace string, metric pdata.Metric) string {
-	if namespace != "" {
+	if namespace != "" && metric.Name() != targetInfoMetricName {
 		return namespace + "_" + sanitize(metric.Name())
 	}
 	return sanitize(metric.Name())
@@ -162,6 +164,9 @@ func (c *collector) convertDoubleSum(metric pdata.Metric) (prometheus.Metric, er
 	if err != nil {
 		return nil, err
 	}
+	if c.enableOpenMetrics && metricType == prometheus.CounterValue {
+		m = newMetricWithExemplars(m, ip.Exemplars())
+	}
 
 	if c.sendTimestamps {
 		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
@@ -238,6 +243,9 @@ func (c *collector) convertDoubleHistogram(metric pdata.Metric) (prometheus.Metr
 	if err != nil {
 		return nil, err
 	}
+	if c.enableOpenMetrics {
+		m = newMetricWithExemplars(m, ip.Exemplars())
+	}
 
 	if c.sendTimestamps {
 		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
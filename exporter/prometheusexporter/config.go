@@ -40,4 +40,7 @@ type Config struct {
 
 	// MetricExpiration defines how long metrics are kept without updates
 	MetricExpiration time.Duration `mapstructure:"metric_expiration"`
+
+	// EnableOpenMetrics serves the OpenMetrics format, including exemplars, to the scrapers requesting it.
+	EnableOpenMetrics bool `mapstructure:"enable_open_metrics"`
 }
@@ -54,7 +54,8 @@ func TestLoadConfig(t *testing.T) {
 				"label1":        "value1",
 				"another label": "spaced value",
 			},
-			SendTimestamps:   true,
-			MetricExpiration: 60 * time.Minute,
+			SendTimestamps:    true,
+			MetricExpiration:  60 * time.Minute,
+			EnableOpenMetrics: true,
 		})
 }
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	exemplarTraceIDLabel = "trace_id"
+	exemplarSpanIDLabel  = "span_id"
+)
+
+// metricWithExemplars decorates a counter or a histogram with the exemplars of its data point. Exemplars are part of
+// the written metric but only rendered by the OpenMetrics format.
+type metricWithExemplars struct {
+	prometheus.Metric
+	exemplars []*dto.Exemplar
+}
+
+func newMetricWithExemplars(m prometheus.Metric, exemplars pdata.DoubleExemplarSlice) prometheus.Metric {
+	if exemplars.Len() == 0 {
+		return m
+	}
+	dtoExemplars := make([]*dto.Exemplar, 0, exemplars.Len())
+	for i := 0; i < exemplars.Len(); i++ {
+		dtoExemplars = append(dtoExemplars, exemplarToDto(exemplars.At(i)))
+	}
+	return &metricWithExemplars{Metric: m, exemplars: dtoExemplars}
+}
+
+func (m *metricWithExemplars) Write(pb *dto.Metric) error {
+	if err := m.Metric.Write(pb); err != nil {
+		return err
+	}
+	switch {
+	case pb.Counter != nil:
+		// a counter has a single exemplar, keep the latest one
+		pb.Counter.Exemplar = m.exemplars[len(m.exemplars)-1]
+	case pb.Histogram != nil:
+		// buckets are sorted by upper bound, an exemplar goes to the first bucket it fits in
+		for _, e := range m.exemplars {
+			for _, b := range pb.Histogram.Bucket {
+				if e.GetValue() <= b.GetUpperBound() {
+					b.Exemplar = e
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func exemplarToDto(exemplar pdata.DoubleExemplar) *dto.Exemplar {
+	labels := make([]*dto.LabelPair, 0, exemplar.FilteredLabels().Len()+2)
+	if traceID := exemplar.TraceID(); !traceID.IsEmpty() {
+		labels = append(labels, &dto.LabelPair{Name: proto.String(exemplarTraceIDLabel), Value: proto.String(traceID.HexString())})
+	}
+	if spanID := exemplar.SpanID(); !spanID.IsEmpty() {
+		labels = append(labels, &dto.LabelPair{Name: proto.String(exemplarSpanIDLabel), Value: proto.String(spanID.HexString())})
+	}
+	exemplar.FilteredLabels().ForEach(func(k string, v string) {
+		labels = append(labels, &dto.LabelPair{Name: proto.String(sanitize(k)), Value: proto.String(v)})
+	})
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].GetName() < labels[j].GetName()
+	})
+
+	dtoExemplar := &dto.Exemplar{
+		Label: labels,
+		Value: proto.Float64(exemplar.Value()),
+	}
+	if exemplar.Timestamp() != 0 {
+		dtoExemplar.Timestamp = timestamppb.New(exemplar.Timestamp().AsTime())
+	}
+	return dtoExemplar
+}
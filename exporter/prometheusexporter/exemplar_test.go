@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func testExemplars(values ...float64) pdata.DoubleExemplarSlice {
+	exemplars := pdata.NewDoubleExemplarSlice()
+	exemplars.Resize(len(values))
+	for i, v := range values {
+		e := exemplars.At(i)
+		e.SetValue(v)
+		e.SetTimestamp(pdata.TimestampFromTime(time.Unix(1600000000, 0)))
+		e.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		e.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+		e.FilteredLabels().Insert("user.id", "42")
+	}
+	return exemplars
+}
+
+func TestMetricWithExemplars_histogram(t *testing.T) {
+	desc := prometheus.NewDesc("test_histogram", "", nil, nil)
+	h, err := prometheus.NewConstHistogram(desc, 3, 12, map[float64]uint64{1: 1, 5: 2, 10: 3})
+	require.NoError(t, err)
+
+	m := newMetricWithExemplars(h, testExemplars(3, 50))
+	pb := &dto.Metric{}
+	require.NoError(t, m.Write(pb))
+
+	buckets := pb.GetHistogram().GetBucket()
+	require.Len(t, buckets, 3)
+	assert.Nil(t, buckets[0].GetExemplar())
+	assert.Nil(t, buckets[2].GetExemplar())
+	e := buckets[1].GetExemplar()
+	require.NotNil(t, e)
+	assert.EqualValues(t, 3, e.GetValue())
+	assert.EqualValues(t, 1600000000, e.GetTimestamp().GetSeconds())
+	require.Len(t, e.GetLabel(), 3)
+	assert.Equal(t, "span_id", e.GetLabel()[0].GetName())
+	assert.Equal(t, "0102030405060708", e.GetLabel()[0].GetValue())
+	assert.Equal(t, "trace_id", e.GetLabel()[1].GetName())
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", e.GetLabel()[1].GetValue())
+	assert.Equal(t, "user_id", e.GetLabel()[2].GetName())
+}
+
+func TestMetricWithExemplars_counter(t *testing.T) {
+	desc := prometheus.NewDesc("test_counter", "", nil, nil)
+	c, err := prometheus.NewConstMetric(desc, prometheus.CounterValue, 10)
+	require.NoError(t, err)
+
+	m := newMetricWithExemplars(c, testExemplars(1, 2))
+	pb := &dto.Metric{}
+	require.NoError(t, m.Write(pb))
+	assert.EqualValues(t, 2, pb.GetCounter().GetExemplar().GetValue())
+
+	// no exemplars leaves the metric untouched
+	assert.Equal(t, c, newMetricWithExemplars(c, pdata.NewDoubleExemplarSlice()))
+}
+
+func TestPrometheusExporter_endToEndOpenMetrics(t *testing.T) {
+	config := &Config{
+		Namespace:         "test",
+		Endpoint:          ":7778",
+		MetricExpiration:  120 * time.Minute,
+		EnableOpenMetrics: true,
+	}
+
+	factory := NewFactory()
+	creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
+	exp, err := factory.CreateMetricsExporter(context.Background(), creationParams, config)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+		// trigger a get so that the server cleans up our keepalive socket
+		http.Get("http://localhost:7778/metrics")
+	})
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.Resize(1)
+	metric := metrics.At(0)
+	metric.SetName("latency")
+	metric.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	metric.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	metric.DoubleHistogram().DataPoints().Resize(1)
+	dp := metric.DoubleHistogram().DataPoints().At(0)
+	dp.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+	dp.SetCount(3)
+	dp.SetSum(12)
+	dp.SetExplicitBounds([]float64{1, 5})
+	dp.SetBucketCounts([]uint64{1, 1, 1})
+	testExemplars(3).CopyTo(dp.Exemplars())
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:7778/metrics", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/openmetrics-text; version=0.0.1")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Failed to perform a scrape")
+	blob, _ := ioutil.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	assert.Contains(t, res.Header.Get("Content-Type"), "application/openmetrics-text")
+	assert.Contains(t, string(blob),
+		`test_latency_bucket{le="5.0"} 2 # {span_id="0102030405060708",trace_id="0102030405060708090a0b0c0d0e0f10",user_id="42"} 3.0 1.6e+09`)
+	assert.Contains(t, string(blob), "# EOF")
+}
@@ -68,7 +68,8 @@ func newPrometheusExporter(config *Config, logger *zap.Logger) (*prometheusExpor
 		handler: promhttp.HandlerFor(
 			registry,
 			promhttp.HandlerOpts{
-				ErrorHandling: promhttp.ContinueOnError,
+				ErrorHandling:     promhttp.ContinueOnError,
+				EnableOpenMetrics: config.EnableOpenMetrics,
 			},
 		),
 	}, nil
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	// targetInfoMetricName is the name of the metric carrying the resource attributes, it is exposed without the
+	// namespace so that the Prometheus receivers can map it back onto the resource.
+	targetInfoMetricName = "target_info"
+
+	jobLabel      = "job"
+	instanceLabel = "instance"
+)
+
+// createTargetInfo returns the target_info gauge of the resource, following the Prometheus compatibility
+// specification: the service.namespace and service.name attributes become the job label, service.instance.id
+// becomes the instance label and the other attributes are kept as labels. The second return value is false when
+// the resource has no attributes.
+func createTargetInfo(resource pdata.Resource) (pdata.Metric, bool) {
+	attrs := resource.Attributes()
+	if attrs.Len() == 0 {
+		return pdata.Metric{}, false
+	}
+
+	metric := pdata.NewMetric()
+	metric.SetName(targetInfoMetricName)
+	metric.SetDescription("Target metadata")
+	metric.SetDataType(pdata.MetricDataTypeIntGauge)
+
+	dp := pdata.NewIntDataPoint()
+	dp.SetValue(1)
+	dp.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+	labels := dp.LabelsMap()
+
+	job := ""
+	if name, ok := attrs.Get(conventions.AttributeServiceName); ok {
+		job = name.StringVal()
+		if namespace, ok := attrs.Get(conventions.AttributeServiceNamespace); ok && namespace.StringVal() != "" {
+			job = namespace.StringVal() + "/" + job
+		}
+	}
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		switch k {
+		case conventions.AttributeServiceName, conventions.AttributeServiceNamespace:
+		case conventions.AttributeServiceInstance:
+			labels.Upsert(instanceLabel, tracetranslator.AttributeValueToString(v, false))
+		default:
+			labels.Upsert(k, tracetranslator.AttributeValueToString(v, false))
+		}
+	})
+	if job != "" {
+		labels.Upsert(jobLabel, job)
+	}
+	labels.Sort()
+
+	metric.IntGauge().DataPoints().Append(dp)
+	return metric, true
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestCreateTargetInfo(t *testing.T) {
+	_, ok := createTargetInfo(pdata.NewResource())
+	assert.False(t, ok)
+
+	resource := pdata.NewResource()
+	resource.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		conventions.AttributeServiceNamespace: pdata.NewAttributeValueString("shop"),
+		conventions.AttributeServiceName:      pdata.NewAttributeValueString("cart"),
+		conventions.AttributeServiceInstance:  pdata.NewAttributeValueString("10.0.0.1:8080"),
+		"region":                              pdata.NewAttributeValueString("eu-west-1"),
+		"replicas":                            pdata.NewAttributeValueInt(3),
+	})
+
+	metric, ok := createTargetInfo(resource)
+	require.True(t, ok)
+	assert.Equal(t, targetInfoMetricName, metric.Name())
+	assert.Equal(t, pdata.MetricDataTypeIntGauge, metric.DataType())
+	require.Equal(t, 1, metric.IntGauge().DataPoints().Len())
+
+	dp := metric.IntGauge().DataPoints().At(0)
+	assert.EqualValues(t, 1, dp.Value())
+	labels := map[string]string{}
+	dp.LabelsMap().ForEach(func(k, v string) {
+		labels[k] = v
+	})
+	assert.Equal(t, map[string]string{
+		"job":      "shop/cart",
+		"instance": "10.0.0.1:8080",
+		"region":   "eu-west-1",
+		"replicas": "3",
+	}, labels)
+}
+
+func TestAccumulateTargetInfo(t *testing.T) {
+	resourceMetrics := pdata.NewResourceMetrics()
+	resourceMetrics.Resource().Attributes().InsertString(conventions.AttributeServiceName, "cart")
+	ilm := pdata.NewInstrumentationLibraryMetrics()
+	resourceMetrics.InstrumentationLibraryMetrics().Append(ilm)
+	metric := pdata.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetDataType(pdata.MetricDataTypeIntGauge)
+	dp := pdata.NewIntDataPoint()
+	dp.SetValue(42)
+	dp.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+	metric.IntGauge().DataPoints().Append(dp)
+	ilm.Metrics().Append(metric)
+
+	a := newAccumulator(zap.NewNop(), 1*time.Hour)
+	// target_info is not counted as a processed metric.
+	require.Equal(t, 1, a.Accumulate(resourceMetrics))
+
+	names := map[string]bool{}
+	for _, m := range a.Collect() {
+		names[m.Name()] = true
+	}
+	assert.Equal(t, map[string]bool{"test_metric": true, targetInfoMetricName: true}, names)
+}
+
+func TestTargetInfoMetricNameIgnoresNamespace(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName(targetInfoMetricName)
+	assert.Equal(t, targetInfoMetricName, metricName("test_space", metric))
+}
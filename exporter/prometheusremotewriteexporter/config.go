@@ -15,6 +15,8 @@
 package prometheusremotewriteexporter
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -36,4 +38,47 @@ type Config struct {
 	ExternalLabels map[string]string `mapstructure:"external_labels"`
 
 	HTTPClientSettings confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// WAL enables persisting the converted requests to an on-disk write-ahead log before sending them.
+	WAL *WALConfig `mapstructure:"wal"`
+
+	// Tenant defines how the tenant of the requests, sent in the X-Scope-OrgID header, is determined.
+	Tenant TenantSettings `mapstructure:"tenant"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks the write-ahead log and the queue settings.
+func (cfg *Config) Validate() error {
+	if cfg.WAL != nil {
+		if cfg.WAL.Directory == "" {
+			return errors.New("wal directory cannot be empty")
+		}
+		if cfg.WAL.MaxSizeMiB <= 0 {
+			return errors.New("wal max_size_mib must be positive")
+		}
+	}
+	return cfg.QueueSettings.Validate()
+}
+
+// TenantSettings defines the sources of the tenant of the requests, the first one found is used: the resource
+// attribute, then the client metadata, then the fixed value. The header is not sent when no tenant is found.
+type TenantSettings struct {
+	// Value is the tenant used when none is found in the resource attributes or in the client metadata.
+	Value string `mapstructure:"value"`
+
+	// ResourceAttribute is the resource attribute holding the tenant of the metrics of the resource.
+	ResourceAttribute string `mapstructure:"resource_attribute"`
+
+	// MetadataKey is the key of the client metadata, included by the receiver, holding the tenant.
+	MetadataKey string `mapstructure:"metadata_key"`
+}
+
+// WALConfig defines the settings of the write-ahead log.
+type WALConfig struct {
+	// Directory is the directory in which the log segments are stored.
+	Directory string `mapstructure:"directory"`
+
+	// MaxSizeMiB is the maximum size of the log in MiB, the oldest segments are dropped when it is reached.
+	MaxSizeMiB int `mapstructure:"max_size_mib"`
 }
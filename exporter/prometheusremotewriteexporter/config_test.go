@@ -56,9 +56,13 @@ func Test_loadConfig(t *testing.T) {
 			},
 			TimeoutSettings: exporterhelper.DefaultTimeoutSettings(),
 			QueueSettings: exporterhelper.QueueSettings{
-				Enabled:      true,
-				NumConsumers: 2,
-				QueueSize:    10,
+				Enabled:       true,
+				NumConsumers:  2,
+				QueueSize:     10,
+				MinConsumers:  1,
+				MaxConsumers:  50,
+				HighWatermark: 0.8,
+				LowWatermark:  0.5,
 			},
 			RetrySettings: exporterhelper.RetrySettings{
 				Enabled:         true,
@@ -83,5 +87,30 @@ func Test_loadConfig(t *testing.T) {
 					"prometheus-remote-write-version": "0.1.0",
 					"x-scope-orgid":                   "234"},
 			},
+			WAL: &WALConfig{
+				Directory:  "/var/lib/otelcol/prw-wal",
+				MaxSizeMiB: 256,
+			},
 		})
+
+	e2 := cfg.Exporters["prometheusremotewrite/tenant"].(*Config)
+	assert.Equal(t, TenantSettings{
+		Value:             "default",
+		ResourceAttribute: "tenant",
+		MetadataKey:       "X-Scope-OrgID",
+	}, e2.Tenant)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	invalidWALDirConfig := createDefaultConfig().(*Config)
+	invalidWALDirConfig.WAL = &WALConfig{MaxSizeMiB: 10}
+	assert.EqualError(t, invalidWALDirConfig.Validate(), "wal directory cannot be empty")
+
+	invalidWALSizeConfig := createDefaultConfig().(*Config)
+	invalidWALSizeConfig.WAL = &WALConfig{Directory: "wal"}
+	assert.EqualError(t, invalidWALSizeConfig.Validate(), "wal max_size_mib must be positive")
+
+	validWALConfig := createDefaultConfig().(*Config)
+	validWALConfig.WAL = &WALConfig{Directory: "wal", MaxSizeMiB: 10}
+	assert.NoError(t, validWALConfig.Validate())
 }
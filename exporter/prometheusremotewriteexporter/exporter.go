@@ -25,22 +25,29 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/prometheus/prometheus/prompb"
 
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal"
 	otlp "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
+	otlpresource "go.opentelemetry.io/collector/internal/data/protogen/resource/v1"
 	"go.opentelemetry.io/collector/internal/version"
 )
 
 const (
 	maxConcurrentRequests = 5
 	maxBatchByteSize      = 3000000
+
+	// tenantHeader is the header carrying the tenant of the requests, as expected by Cortex and Mimir.
+	tenantHeader = "X-Scope-OrgID"
 )
 
 // PrwExporter converts OTLP metrics to Prometheus remote write TimeSeries and sends them to a remote endpoint.
@@ -51,6 +58,9 @@ type PrwExporter struct {
 	client         *http.Client
 	wg             *sync.WaitGroup
 	closeChan      chan struct{}
+	walConfig      *WALConfig
+	wal            *wal
+	tenant         TenantSettings
 }
 
 // NewPrwExporter initializes a new PrwExporter instance and sets fields accordingly.
@@ -80,11 +90,37 @@ func NewPrwExporter(namespace string, endpoint string, client *http.Client, exte
 	}, nil
 }
 
+// Start opens the write-ahead log when it is enabled and sends the requests which were not acknowledged before the
+// last shutdown or crash.
+func (prwe *PrwExporter) Start(context.Context, component.Host) error {
+	if prwe.walConfig == nil {
+		return nil
+	}
+	w, records, err := openWAL(prwe.walConfig.Directory, int64(prwe.walConfig.MaxSizeMiB)*1024*1024)
+	if err != nil {
+		return err
+	}
+	prwe.wal = w
+	if len(records) == 0 {
+		return nil
+	}
+
+	prwe.wg.Add(1)
+	go func() {
+		defer prwe.wg.Done()
+		prwe.replay(records)
+	}()
+	return nil
+}
+
 // Shutdown stops the exporter from accepting incoming calls(and return error), and wait for current export operations
 // to finish before returning
 func (prwe *PrwExporter) Shutdown(context.Context) error {
 	close(prwe.closeChan)
 	prwe.wg.Wait()
+	if prwe.wal != nil {
+		return prwe.wal.close()
+	}
 	return nil
 }
 
@@ -99,7 +135,8 @@ func (prwe *PrwExporter) PushMetrics(ctx context.Context, md pdata.Metrics) erro
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
-		tsMap := map[string]*prompb.TimeSeries{}
+		// The time series are grouped by tenant, each tenant being sent in its own requests.
+		tenantTsMaps := map[string]map[string]*prompb.TimeSeries{}
 		dropped := 0
 		var errs []error
 		resourceMetrics := internal.MetricsToOtlp(md.InternalRep()).ResourceMetrics
@@ -107,6 +144,12 @@ func (prwe *PrwExporter) PushMetrics(ctx context.Context, md pdata.Metrics) erro
 			if resourceMetric == nil {
 				continue
 			}
+			tenant := prwe.getTenant(ctx, resourceMetric.Resource)
+			tsMap, ok := tenantTsMaps[tenant]
+			if !ok {
+				tsMap = map[string]*prompb.TimeSeries{}
+				tenantTsMaps[tenant] = tsMap
+			}
 			// TODO: add resource attributes as labels, probably in next PR
 			for _, instrumentationMetrics := range resourceMetric.InstrumentationLibraryMetrics {
 				if instrumentationMetrics == nil {
@@ -149,9 +192,15 @@ func (prwe *PrwExporter) PushMetrics(ctx context.Context, md pdata.Metrics) erro
 			}
 		}
 
-		if exportErrors := prwe.export(ctx, tsMap); len(exportErrors) != 0 {
+		exportFailed := false
+		for tenant, tsMap := range tenantTsMaps {
+			if exportErrors := prwe.export(ctx, tenant, tsMap); len(exportErrors) != 0 {
+				exportFailed = true
+				errs = append(errs, exportErrors...)
+			}
+		}
+		if exportFailed {
 			dropped = md.MetricCount()
-			errs = append(errs, exportErrors...)
 		}
 
 		if dropped != 0 {
@@ -162,6 +211,25 @@ func (prwe *PrwExporter) PushMetrics(ctx context.Context, md pdata.Metrics) erro
 	}
 }
 
+// getTenant returns the tenant of the metrics of the resource, it is empty when the tenant is not found.
+func (prwe *PrwExporter) getTenant(ctx context.Context, resource otlpresource.Resource) string {
+	if prwe.tenant.ResourceAttribute != "" {
+		for _, attr := range resource.Attributes {
+			if attr.Key == prwe.tenant.ResourceAttribute && attr.Value.GetStringValue() != "" {
+				return attr.Value.GetStringValue()
+			}
+		}
+	}
+	if prwe.tenant.MetadataKey != "" {
+		if c, ok := client.FromContext(ctx); ok {
+			if values := c.Metadata[strings.ToLower(prwe.tenant.MetadataKey)]; len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+	}
+	return prwe.tenant.Value
+}
+
 func validateAndSanitizeExternalLabels(externalLabels map[string]string) (map[string]string, error) {
 	sanitizedLabels := make(map[string]string)
 	for key, value := range externalLabels {
@@ -255,8 +323,9 @@ func (prwe *PrwExporter) handleSummaryMetric(tsMap map[string]*prompb.TimeSeries
 	return nil
 }
 
-// export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
-func (prwe *PrwExporter) export(ctx context.Context, tsMap map[string]*prompb.TimeSeries) []error {
+// export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order, the tenant
+// header is set when the tenant is not empty.
+func (prwe *PrwExporter) export(ctx context.Context, tenant string, tsMap map[string]*prompb.TimeSeries) []error {
 	var errs []error
 	// Calls the helper function to convert and batch the TsMap to the desired format
 	requests, err := batchTimeSeries(tsMap, maxBatchByteSize)
@@ -284,7 +353,7 @@ func (prwe *PrwExporter) export(ctx context.Context, tsMap map[string]*prompb.Ti
 			defer wg.Done()
 
 			for request := range input {
-				err := prwe.execute(ctx, request)
+				err := prwe.persistAndExecute(ctx, tenant, request)
 				if err != nil {
 					mu.Lock()
 					errs = append(errs, err)
@@ -298,7 +367,58 @@ func (prwe *PrwExporter) export(ctx context.Context, tsMap map[string]*prompb.Ti
 	return errs
 }
 
-func (prwe *PrwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequest) error {
+// persistAndExecute appends the request to the write-ahead log, when enabled, before sending it. The record is
+// acknowledged once the request is accepted or permanently rejected, otherwise it is kept to be sent again at the
+// next start.
+func (prwe *PrwExporter) persistAndExecute(ctx context.Context, tenant string, writeReq *prompb.WriteRequest) error {
+	if prwe.wal == nil {
+		return prwe.execute(ctx, tenant, writeReq)
+	}
+	data, err := proto.Marshal(writeReq)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	index, err := prwe.wal.append(marshalWALRequest(tenant, data))
+	if err != nil {
+		return fmt.Errorf("failed to persist request to the write-ahead log: %w", err)
+	}
+	return prwe.executeAndAck(ctx, tenant, writeReq, index)
+}
+
+func (prwe *PrwExporter) executeAndAck(ctx context.Context, tenant string, writeReq *prompb.WriteRequest, index uint64) error {
+	err := prwe.execute(ctx, tenant, writeReq)
+	if err != nil && !consumererror.IsPermanent(err) {
+		return err
+	}
+	if ackErr := prwe.wal.ack(index); ackErr != nil && err == nil {
+		return ackErr
+	}
+	return err
+}
+
+// replay sends the requests read from the write-ahead log until shutdown, the ones failing with a retryable error
+// are kept in the log.
+func (prwe *PrwExporter) replay(records []walRecord) {
+	for _, record := range records {
+		select {
+		case <-prwe.closeChan:
+			return
+		default:
+		}
+		tenant, data, err := unmarshalWALRequest(record.data)
+		writeReq := &prompb.WriteRequest{}
+		if err == nil {
+			err = proto.Unmarshal(data, writeReq)
+		}
+		if err != nil {
+			_ = prwe.wal.ack(record.index)
+			continue
+		}
+		_ = prwe.executeAndAck(context.Background(), tenant, writeReq, record.index)
+	}
+}
+
+func (prwe *PrwExporter) execute(ctx context.Context, tenant string, writeReq *prompb.WriteRequest) error {
 	// Uses proto.Marshal to convert the WriteRequest into bytes array
 	data, err := proto.Marshal(writeReq)
 	if err != nil {
@@ -319,6 +439,9 @@ func (prwe *PrwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	req.Header.Set("Content-Type", "application/x-protobuf")
 	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
 	req.Header.Set("User-Agent", "OpenTelemetry-Collector/"+version.Version)
+	if tenant != "" {
+		req.Header.Set(tenantHeader, tenant)
+	}
 
 	resp, err := prwe.client.Do(req)
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -251,7 +252,7 @@ func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) []error {
 		errs = append(errs, err)
 		return errs
 	}
-	errs = append(errs, prwe.export(context.Background(), testmap)...)
+	errs = append(errs, prwe.export(context.Background(), "", testmap)...)
 	return errs
 }
 
@@ -713,6 +714,67 @@ func Test_PushMetrics(t *testing.T) {
 	}
 }
 
+// Test_PushMetrics_Tenant checks that the tenant header is taken from the resource attribute, then from the client
+// metadata, then from the fixed value.
+func Test_PushMetrics_Tenant(t *testing.T) {
+	var mu sync.Mutex
+	var tenants []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tenants = append(tenants, r.Header.Get(tenantHeader))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	md := testdata.GenerateMetricsOneMetric()
+	testdata.GenerateMetricsOneMetric().ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	md.ResourceMetrics().At(0).Resource().Attributes().UpsertString("tenant", "resource-tenant")
+
+	tests := []struct {
+		name   string
+		tenant TenantSettings
+		ctx    context.Context
+		want   []string
+	}{
+		{
+			name:   "no_tenant",
+			tenant: TenantSettings{},
+			ctx:    context.Background(),
+			want:   []string{""},
+		},
+		{
+			name:   "fixed_value",
+			tenant: TenantSettings{Value: "default"},
+			ctx:    context.Background(),
+			want:   []string{"default"},
+		},
+		{
+			name:   "resource_attribute",
+			tenant: TenantSettings{Value: "default", ResourceAttribute: "tenant"},
+			ctx:    context.Background(),
+			want:   []string{"default", "resource-tenant"},
+		},
+		{
+			name:   "client_metadata",
+			tenant: TenantSettings{Value: "default", ResourceAttribute: "tenant", MetadataKey: "X-Tenant"},
+			ctx: client.NewContext(context.Background(), &client.Client{
+				Metadata: map[string][]string{"x-tenant": {"client-tenant"}},
+			}),
+			want: []string{"client-tenant", "resource-tenant"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenants = nil
+			prwe, err := NewPrwExporter("", server.URL, http.DefaultClient, map[string]string{})
+			require.NoError(t, err)
+			prwe.tenant = tt.tenant
+			require.NoError(t, prwe.PushMetrics(tt.ctx, md))
+			assert.ElementsMatch(t, tt.want, tenants)
+		})
+	}
+}
+
 func Test_validateAndSanitizeExternalLabels(t *testing.T) {
 	tests := []struct {
 		name           string
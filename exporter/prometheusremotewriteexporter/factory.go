@@ -44,7 +44,9 @@ func createMetricsExporter(_ context.Context, params component.ExporterCreatePar
 		return nil, errors.New("invalid configuration")
 	}
 
-	client, err := prwCfg.HTTPClientSettings.ToClient()
+	clientSettings := prwCfg.HTTPClientSettings
+	clientSettings.Middlewares = append(clientSettings.Middlewares, confighttp.RegisteredMiddlewares(typeStr)...)
+	client, err := clientSettings.ToClient()
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +55,8 @@ func createMetricsExporter(_ context.Context, params component.ExporterCreatePar
 	if err != nil {
 		return nil, err
 	}
+	prwe.walConfig = prwCfg.WAL
+	prwe.tenant = prwCfg.Tenant
 
 	prwexp, err := exporterhelper.NewMetricsExporter(
 		cfg,
@@ -61,6 +65,7 @@ func createMetricsExporter(_ context.Context, params component.ExporterCreatePar
 		exporterhelper.WithTimeout(prwCfg.TimeoutSettings),
 		exporterhelper.WithQueue(prwCfg.QueueSettings),
 		exporterhelper.WithRetry(prwCfg.RetrySettings),
+		exporterhelper.WithStart(prwe.Start),
 		exporterhelper.WithShutdown(prwe.Shutdown),
 	)
 
@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	walSegmentSuffix = ".wal"
+	// walSegmentSize is the size after which a new segment is started, segments are only removed once all their
+	// records were acknowledged.
+	walSegmentSize = 8 * 1024 * 1024
+	// walHeaderSize is the size of the record header: the length of the data followed by its CRC32 checksum.
+	walHeaderSize = 8
+)
+
+var (
+	errWALRecordTooLarge = errors.New("write-ahead log record is larger than the maximum size of the log")
+	crcTable             = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// walRecord is a record read back from the write-ahead log when it is opened.
+type walRecord struct {
+	index uint64
+	data  []byte
+}
+
+type walSegment struct {
+	path  string
+	first uint64
+	// count is the number of records in the segment, pending the number of records not yet acknowledged.
+	count   int
+	pending int
+	size    int64
+}
+
+// wal is an on-disk write-ahead log made of segment files named after the index of their first record. Records are
+// appended to the last segment and acknowledged once processed, the leading segments whose records are all
+// acknowledged are removed from disk.
+type wal struct {
+	mu        sync.Mutex
+	dir       string
+	maxSize   int64
+	segments  []*walSegment
+	file      *os.File
+	size      int64
+	nextIndex uint64
+}
+
+// openWAL opens the write-ahead log stored in dir and returns the records which were not acknowledged before the
+// log was last closed. A record partially written by a crash is discarded.
+func openWAL(dir string, maxSize int64) (*wal, []walRecord, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, err
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w := &wal{dir: dir, maxSize: maxSize}
+	var records []walRecord
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, walSegmentSuffix) {
+			continue
+		}
+		first, err := strconv.ParseUint(strings.TrimSuffix(name, walSegmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		w.segments = append(w.segments, &walSegment{path: filepath.Join(dir, name), first: first})
+	}
+	sort.Slice(w.segments, func(i, j int) bool {
+		return w.segments[i].first < w.segments[j].first
+	})
+
+	segments := w.segments[:0]
+	for _, s := range w.segments {
+		data, err := readSegment(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		if s.count == 0 {
+			if err := os.Remove(s.path); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		for i, d := range data {
+			records = append(records, walRecord{index: s.first + uint64(i), data: d})
+		}
+		s.pending = s.count
+		w.size += s.size
+		w.nextIndex = s.first + uint64(s.count)
+		segments = append(segments, s)
+	}
+	w.segments = segments
+	return w, records, nil
+}
+
+// readSegment reads all the records of the segment and truncates the file after the last valid record.
+func readSegment(s *walSegment) ([][]byte, error) {
+	f, err := os.OpenFile(s.path, os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records [][]byte
+	r := bufio.NewReader(f)
+	header := make([]byte, walHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			break
+		}
+		data := make([]byte, binary.BigEndian.Uint32(header[:4]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			break
+		}
+		if crc32.Checksum(data, crcTable) != binary.BigEndian.Uint32(header[4:]) {
+			break
+		}
+		records = append(records, data)
+		s.size += int64(walHeaderSize + len(data))
+	}
+	s.count = len(records)
+	return records, f.Truncate(s.size)
+}
+
+// append persists the data and returns the index of the record. When the log would grow over its maximum size, the
+// oldest segments are dropped even if they still hold unacknowledged records.
+func (w *wal) append(data []byte) (uint64, error) {
+	recordSize := int64(walHeaderSize + len(data))
+	if recordSize > w.maxSize {
+		return 0, errWALRecordTooLarge
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.size+recordSize > w.maxSize {
+		if err := w.closeSegment(); err != nil {
+			return 0, err
+		}
+		for w.size+recordSize > w.maxSize && len(w.segments) > 0 {
+			if err := w.removeOldestSegment(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if w.file == nil || w.segments[len(w.segments)-1].size >= walSegmentSize {
+		if err := w.openSegment(); err != nil {
+			return 0, err
+		}
+	}
+
+	buf := make([]byte, recordSize)
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(buf[4:walHeaderSize], crc32.Checksum(data, crcTable))
+	copy(buf[walHeaderSize:], data)
+	if _, err := w.file.Write(buf); err != nil {
+		return 0, err
+	}
+	if err := w.file.Sync(); err != nil {
+		return 0, err
+	}
+
+	s := w.segments[len(w.segments)-1]
+	s.count++
+	s.pending++
+	s.size += recordSize
+	w.size += recordSize
+	index := w.nextIndex
+	w.nextIndex++
+	return index, nil
+}
+
+// ack marks the record as processed and removes the leading segments which have no pending record left.
+func (w *wal) ack(index uint64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, s := range w.segments {
+		if index >= s.first && index < s.first+uint64(s.count) {
+			s.pending--
+			break
+		}
+	}
+	for len(w.segments) > 0 && w.segments[0].pending == 0 {
+		if len(w.segments) == 1 {
+			if err := w.closeSegment(); err != nil {
+				return err
+			}
+		}
+		if err := w.removeOldestSegment(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *wal) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeSegment()
+}
+
+func (w *wal) openSegment() error {
+	if err := w.closeSegment(); err != nil {
+		return err
+	}
+	path := filepath.Join(w.dir, fmt.Sprintf("%020d%s", w.nextIndex, walSegmentSuffix))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.segments = append(w.segments, &walSegment{path: path, first: w.nextIndex})
+	return nil
+}
+
+func (w *wal) closeSegment() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *wal) removeOldestSegment() error {
+	s := w.segments[0]
+	if err := os.Remove(s.path); err != nil {
+		return err
+	}
+	w.size -= s.size
+	w.segments = w.segments[1:]
+	return nil
+}
+
+// marshalWALRequest returns the data of the record of a request: the length of the tenant as an uvarint, the tenant,
+// then the marshaled write request.
+func marshalWALRequest(tenant string, request []byte) []byte {
+	data := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(tenant)+len(request))
+	n := binary.PutUvarint(data, uint64(len(tenant)))
+	data = append(data[:n], tenant...)
+	return append(data, request...)
+}
+
+// unmarshalWALRequest returns the tenant and the marshaled write request of the data of a record.
+func unmarshalWALRequest(data []byte) (string, []byte, error) {
+	size, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < size {
+		return "", nil, errors.New("invalid write-ahead log record")
+	}
+	return string(data[n : n+int(size)]), data[n+int(size):], nil
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func walFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+walSegmentSuffix))
+	require.NoError(t, err)
+	return files
+}
+
+func TestWAL_appendAck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prw-wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, records, err := openWAL(dir, 1024*1024)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	i0, err := w.append([]byte("first"))
+	require.NoError(t, err)
+	i1, err := w.append([]byte("second"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), i0)
+	assert.Equal(t, uint64(1), i1)
+	assert.Len(t, walFiles(t, dir), 1)
+
+	// the segment is kept until all of its records are acknowledged
+	require.NoError(t, w.ack(i1))
+	assert.Len(t, walFiles(t, dir), 1)
+	require.NoError(t, w.ack(i0))
+	assert.Empty(t, walFiles(t, dir))
+	assert.EqualValues(t, 0, w.size)
+
+	i2, err := w.append([]byte("third"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), i2)
+	require.NoError(t, w.close())
+}
+
+func TestWAL_reopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prw-wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, _, err := openWAL(dir, 1024*1024)
+	require.NoError(t, err)
+	i0, err := w.append([]byte("first"))
+	require.NoError(t, err)
+	_, err = w.append([]byte("second"))
+	require.NoError(t, err)
+	require.NoError(t, w.ack(i0))
+	require.NoError(t, w.close())
+
+	// simulate a record partially written by a crash
+	files := walFiles(t, dir)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 10, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// acknowledged records sharing a segment with pending ones are read again
+	w, records, err := openWAL(dir, 1024*1024)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, walRecord{index: 0, data: []byte("first")}, records[0])
+	assert.Equal(t, walRecord{index: 1, data: []byte("second")}, records[1])
+
+	i2, err := w.append([]byte("third"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), i2)
+	assert.Len(t, walFiles(t, dir), 2)
+
+	for _, r := range records {
+		require.NoError(t, w.ack(r.index))
+	}
+	assert.Len(t, walFiles(t, dir), 1)
+	require.NoError(t, w.ack(i2))
+	assert.Empty(t, walFiles(t, dir))
+	require.NoError(t, w.close())
+}
+
+func TestWAL_maxSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prw-wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, _, err := openWAL(dir, 2*(walHeaderSize+10))
+	require.NoError(t, err)
+	defer w.close()
+
+	_, err = w.append(make([]byte, 2*walHeaderSize+20))
+	assert.Equal(t, errWALRecordTooLarge, err)
+
+	_, err = w.append(make([]byte, 10))
+	require.NoError(t, err)
+	_, err = w.append(make([]byte, 10))
+	require.NoError(t, err)
+	// the oldest segment is dropped to make room for the new record
+	i2, err := w.append(make([]byte, 10))
+	require.NoError(t, err)
+	assert.EqualValues(t, walHeaderSize+10, w.size)
+	require.Len(t, w.segments, 1)
+	assert.Equal(t, i2, w.segments[0].first)
+	// acknowledging a dropped record is a no-op
+	require.NoError(t, w.ack(0))
+	assert.Len(t, walFiles(t, dir), 1)
+}
+
+func TestWALRequest(t *testing.T) {
+	tenant, request, err := unmarshalWALRequest(marshalWALRequest("tenant", []byte("request")))
+	require.NoError(t, err)
+	assert.Equal(t, "tenant", tenant)
+	assert.Equal(t, []byte("request"), request)
+
+	tenant, request, err = unmarshalWALRequest(marshalWALRequest("", []byte("request")))
+	require.NoError(t, err)
+	assert.Equal(t, "", tenant)
+	assert.Equal(t, []byte("request"), request)
+
+	_, _, err = unmarshalWALRequest([]byte{10, 'a'})
+	assert.Error(t, err)
+}
+
+func TestPrwExporter_WAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prw-wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var status int32 = http.StatusServiceUnavailable
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+		// the tenant is kept in the log with the request
+		assert.Equal(t, "tenant", r.Header.Get(tenantHeader))
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+
+	newExporter := func() *PrwExporter {
+		prwe, nErr := NewPrwExporter("test", server.URL, http.DefaultClient, map[string]string{})
+		require.NoError(t, nErr)
+		prwe.walConfig = &WALConfig{Directory: dir, MaxSizeMiB: 1}
+		require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+		return prwe
+	}
+
+	// a retryable failure keeps the request in the log
+	prwe := newExporter()
+	ts := getTimeSeries(getPromLabels(label11, value11), getSample(floatVal1, msTime1))
+	errs := prwe.export(context.Background(), "tenant", map[string]*prompb.TimeSeries{"test": ts})
+	require.Len(t, errs, 1)
+	require.NoError(t, prwe.Shutdown(context.Background()))
+	assert.Len(t, walFiles(t, dir), 1)
+
+	// the request is replayed on start and removed once accepted
+	atomic.StoreInt32(&status, http.StatusOK)
+	prwe = newExporter()
+	assert.Eventually(t, func() bool {
+		return len(walFiles(t, dir)) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&received))
+
+	errs = prwe.export(context.Background(), "tenant", map[string]*prompb.TimeSeries{"test": ts})
+	assert.Empty(t, errs)
+	assert.Empty(t, walFiles(t, dir))
+	require.NoError(t, prwe.Shutdown(context.Background()))
+}
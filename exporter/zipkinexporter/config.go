@@ -15,6 +15,8 @@
 package zipkinexporter
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -34,3 +36,14 @@ type Config struct {
 
 	DefaultServiceName string `mapstructure:"default_service_name"`
 }
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the endpoint is set and that the queue settings are valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		// TODO https://github.com/open-telemetry/opentelemetry-collector/issues/215
+		return errors.New("exporter config requires a non-empty 'endpoint'")
+	}
+	return cfg.QueueSettings.Validate()
+}
@@ -64,9 +64,13 @@ func TestLoadConfig(t *testing.T) {
 			MaxElapsedTime:  10 * time.Minute,
 		},
 		QueueSettings: exporterhelper.QueueSettings{
-			Enabled:      true,
-			NumConsumers: 2,
-			QueueSize:    10,
+			Enabled:       true,
+			NumConsumers:  2,
+			QueueSize:     10,
+			MinConsumers:  1,
+			MaxConsumers:  50,
+			HighWatermark: 0.8,
+			LowWatermark:  0.5,
 		},
 		HTTPClientSettings: confighttp.HTTPClientSettings{
 			Endpoint:        "https://somedest:1234/api/v2/spans",
@@ -16,7 +16,6 @@ package zipkinexporter
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -69,9 +68,8 @@ func createTraceExporter(
 ) (component.TracesExporter, error) {
 	zc := cfg.(*Config)
 
-	if zc.Endpoint == "" {
-		// TODO https://github.com/open-telemetry/opentelemetry-collector/issues/215
-		return nil, errors.New("exporter config requires a non-empty 'endpoint'")
+	if err := zc.Validate(); err != nil {
+		return nil, err
 	}
 
 	ze, err := createZipkinExporter(zc)
@@ -23,6 +23,7 @@ import (
 	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 	zipkinreporter "github.com/openzipkin/zipkin-go/reporter"
 
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/trace/zipkin"
@@ -42,7 +43,9 @@ type zipkinExporter struct {
 }
 
 func createZipkinExporter(cfg *Config) (*zipkinExporter, error) {
-	client, err := cfg.HTTPClientSettings.ToClient()
+	clientSettings := cfg.HTTPClientSettings
+	clientSettings.Middlewares = append(clientSettings.Middlewares, confighttp.RegisteredMiddlewares(typeStr)...)
+	client, err := clientSettings.ToClient()
 	if err != nil {
 		return nil, err
 	}
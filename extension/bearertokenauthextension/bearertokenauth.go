@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+const bearerPrefix = "bearer "
+
+var (
+	errMissingToken = errors.New("no bearer token found in the authorization header")
+	errInvalidToken = errors.New("invalid bearer token")
+)
+
+type bearerTokenAuth struct {
+	config Config
+	logger *zap.Logger
+}
+
+var (
+	_ component.Extension            = (*bearerTokenAuth)(nil)
+	_ configauth.ServerAuthenticator = (*bearerTokenAuth)(nil)
+)
+
+func newBearerTokenAuth(config Config, logger *zap.Logger) *bearerTokenAuth {
+	return &bearerTokenAuth{
+		config: config,
+		logger: logger,
+	}
+}
+
+func (b *bearerTokenAuth) Start(context.Context, component.Host) error {
+	b.logger.Info("Starting bearertokenauth extension", zap.Int("tokens", len(b.config.Tokens)))
+	return configauth.RegisterServerAuthenticator(b.config.Name(), b)
+}
+
+func (b *bearerTokenAuth) Shutdown(context.Context) error {
+	configauth.UnregisterServerAuthenticator(b.config.Name())
+	return nil
+}
+
+// Authenticate looks up the token of the authorization header, all the tokens are compared in constant time.
+func (b *bearerTokenAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	authHeaders := headers["authorization"]
+	if len(authHeaders) == 0 || !strings.HasPrefix(strings.ToLower(authHeaders[0]), bearerPrefix) {
+		return ctx, errMissingToken
+	}
+	received := []byte(strings.TrimSpace(authHeaders[0][len(bearerPrefix):]))
+
+	var found *TokenConfig
+	for i := range b.config.Tokens {
+		if subtle.ConstantTimeCompare(received, []byte(b.config.Tokens[i].Token)) == 1 {
+			found = &b.config.Tokens[i]
+		}
+	}
+	if found == nil {
+		return ctx, errInvalidToken
+	}
+	return configauth.NewContext(ctx, found.Subject, found.Groups), nil
+}
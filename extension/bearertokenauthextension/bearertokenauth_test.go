@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+func newTestConfig() Config {
+	return Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: "bearertokenauth/test",
+		},
+		Tokens: []TokenConfig{
+			{Token: "token-1", Subject: "agent-1"},
+			{Token: "token-2", Subject: "agent-2", Groups: []string{"agents"}},
+		},
+	}
+}
+
+func TestBearerTokenAuthenticate(t *testing.T) {
+	auth := newBearerTokenAuth(newTestConfig(), zap.NewNop())
+
+	tests := []struct {
+		name        string
+		headers     map[string][]string
+		wantErr     error
+		wantSubject string
+		wantGroups  []string
+	}{
+		{
+			name:    "missing header",
+			headers: map[string][]string{},
+			wantErr: errMissingToken,
+		},
+		{
+			name:    "not a bearer token",
+			headers: map[string][]string{"authorization": {"Basic dXNlcjpwYXNz"}},
+			wantErr: errMissingToken,
+		},
+		{
+			name:    "unknown token",
+			headers: map[string][]string{"authorization": {"Bearer token-3"}},
+			wantErr: errInvalidToken,
+		},
+		{
+			name:        "first token",
+			headers:     map[string][]string{"authorization": {"Bearer token-1"}},
+			wantSubject: "agent-1",
+		},
+		{
+			name:        "case insensitive scheme",
+			headers:     map[string][]string{"authorization": {"bearer token-2"}},
+			wantSubject: "agent-2",
+			wantGroups:  []string{"agents"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := auth.Authenticate(context.Background(), tt.headers)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			subject, _ := configauth.SubjectFromContext(ctx)
+			assert.Equal(t, tt.wantSubject, subject)
+			groups, _ := configauth.GroupsFromContext(ctx)
+			assert.Equal(t, tt.wantGroups, groups)
+		})
+	}
+}
+
+func TestBearerTokenAuthRegistration(t *testing.T) {
+	auth := newBearerTokenAuth(newTestConfig(), zap.NewNop())
+
+	var handled *client.Client
+	handler := (&configauth.Authentication{AuthenticatorName: "bearertokenauth/test"}).ToHTTPHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handled, _ = client.FromHTTP(r)
+		}))
+	serve := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+		req.RemoteAddr = "1.2.3.4:5678"
+		req.Header.Set("Authorization", "Bearer token-2")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.NoError(t, auth.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Error(t, auth.Start(context.Background(), componenttest.NewNopHost()), "the name is already registered")
+
+	assert.Equal(t, http.StatusOK, serve())
+	assert.Equal(t, &client.Client{IP: "1.2.3.4", Subject: "agent-2", Groups: []string{"agents"}}, handled)
+
+	require.NoError(t, auth.Shutdown(context.Background()))
+	assert.Equal(t, http.StatusUnauthorized, serve())
+}
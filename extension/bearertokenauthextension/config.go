@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config has the configuration for the bearer token authenticator extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// Tokens are the bearer tokens accepted by the authenticator.
+	Tokens []TokenConfig `mapstructure:"tokens"`
+}
+
+// TokenConfig is a bearer token and the identity authenticated with it.
+type TokenConfig struct {
+	// Token is the value expected after "Bearer " in the authorization header.
+	Token string `mapstructure:"token"`
+
+	// Subject is the identity authenticated with the token.
+	Subject string `mapstructure:"subject"`
+
+	// Groups are the groups the subject belongs to.
+	Groups []string `mapstructure:"groups"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that at least one token is set, with its subject.
+func (cfg *Config) Validate() error {
+	if len(cfg.Tokens) == 0 {
+		return errors.New("at least one token is required when using the \"bearertokenauth\" extension")
+	}
+	for i, token := range cfg.Tokens {
+		if token.Token == "" || token.Subject == "" {
+			return fmt.Errorf("the token and the subject of tokens[%d] are required", i)
+		}
+	}
+	return nil
+}
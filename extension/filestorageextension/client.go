@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorageextension
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errEmptyKey     = errors.New("storage key must not be empty")
+	errClientClosed = errors.New("storage client is closed")
+)
+
+// fileClient stores every key in its own file of the store directory. The file names are the keys encoded with
+// the URL safe base64 alphabet, values are replaced by renaming a temporary file so a crash never leaves a partially
+// written value behind.
+type fileClient struct {
+	dir    string
+	mu     sync.RWMutex
+	closed bool
+}
+
+var _ component.StorageClient = (*fileClient)(nil)
+
+func newFileClient(dir string) *fileClient {
+	return &fileClient{dir: dir}
+}
+
+func (c *fileClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	path, err := c.path(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return value, err
+}
+
+func (c *fileClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+
+	// The temporary file has a suffix out of the base64 alphabet so it never clashes with a key.
+	f, err := ioutil.TempFile(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(value); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+func (c *fileClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *fileClient) Close(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+// path returns the path of the file storing the key, it must be called holding the lock.
+func (c *fileClient) path(key string) (string, error) {
+	if c.closed {
+		return "", errClientClosed
+	}
+	if key == "" {
+		return "", errEmptyKey
+	}
+	return filepath.Join(c.dir, base64.RawURLEncoding.EncodeToString([]byte(key))), nil
+}
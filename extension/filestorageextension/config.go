@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorageextension
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config has the configuration for the file storage extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// Directory is the directory in which the stores are kept, it must exist and be
+	// writable by the collector. Every store is a sub-directory holding a file per key.
+	Directory string `mapstructure:"directory"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the directory is set.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("\"directory\" is required when using the \"file_storage\" extension")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorageextension
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type fileStorage struct {
+	config Config
+	logger *zap.Logger
+}
+
+var _ component.StorageExtension = (*fileStorage)(nil)
+
+func newFileStorage(config Config, logger *zap.Logger) *fileStorage {
+	return &fileStorage{
+		config: config,
+		logger: logger,
+	}
+}
+
+func (fs *fileStorage) Start(context.Context, component.Host) error {
+	fs.logger.Info("Starting file_storage extension", zap.Any("config", fs.config))
+
+	info, err := os.Stat(fs.config.Directory)
+	if err != nil {
+		return fmt.Errorf("directory %q is not usable: %w", fs.config.Directory, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%q is not a directory", fs.config.Directory)
+	}
+	return nil
+}
+
+func (fs *fileStorage) Shutdown(context.Context) error {
+	return nil
+}
+
+// GetClient returns a client to the store kept in a sub-directory named after the kind and the name of the
+// component, and the name of the store.
+func (fs *fileStorage) GetClient(_ context.Context, kind component.Kind, componentName string, name string) (component.StorageClient, error) {
+	kindStr, ok := kindNames[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown component kind %d", kind)
+	}
+	storeName := kindStr + "_" + url.PathEscape(componentName)
+	if name != "" {
+		storeName += "_" + url.PathEscape(name)
+	}
+	dir := filepath.Join(fs.config.Directory, storeName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return newFileClient(dir), nil
+}
+
+var kindNames = map[component.Kind]string{
+	component.KindReceiver:  "receiver",
+	component.KindProcessor: "processor",
+	component.KindExporter:  "exporter",
+	component.KindExtension: "extension",
+	component.KindConnector: "connector",
+}
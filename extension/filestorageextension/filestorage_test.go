@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorageextension
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func newTestFileStorage(t *testing.T) (*fileStorage, func()) {
+	dir, err := ioutil.TempDir("", "file_storage")
+	require.NoError(t, err)
+
+	fs := newFileStorage(Config{Directory: dir}, zap.NewNop())
+	require.NoError(t, fs.Start(context.Background(), componenttest.NewNopHost()))
+	return fs, func() {
+		assert.NoError(t, fs.Shutdown(context.Background()))
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFileStorageClient(t *testing.T) {
+	fs, cleanup := newTestFileStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	client, err := fs.GetClient(ctx, component.KindExporter, "otlp/2", "queue")
+	require.NoError(t, err)
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("new value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new value"), value)
+
+	// Keys are encoded so they can hold any character without escaping the store directory.
+	require.NoError(t, client.Set(ctx, "../../other/key", []byte("other")))
+	value, err = client.Get(ctx, "../../other/key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other"), value)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NoError(t, client.Delete(ctx, "key"))
+
+	assert.Equal(t, errEmptyKey, client.Set(ctx, "", []byte("value")))
+
+	require.NoError(t, client.Close(ctx))
+	_, err = client.Get(ctx, "../../other/key")
+	assert.Equal(t, errClientClosed, err)
+}
+
+func TestFileStorageClientPersistence(t *testing.T) {
+	fs, cleanup := newTestFileStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	client, err := fs.GetClient(ctx, component.KindReceiver, "filelog", "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "checkpoint", []byte("42")))
+	require.NoError(t, client.Close(ctx))
+
+	// A new client to the same store, for instance after a restart, reads the values previously set.
+	client, err = fs.GetClient(ctx, component.KindReceiver, "filelog", "")
+	require.NoError(t, err)
+	value, err := client.Get(ctx, "checkpoint")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("42"), value)
+
+	// The stores of other components, or with another name, are independent.
+	for _, other := range []struct {
+		kind          component.Kind
+		componentName string
+		name          string
+	}{
+		{component.KindExporter, "filelog", ""},
+		{component.KindReceiver, "filelog/2", ""},
+		{component.KindReceiver, "filelog", "other"},
+	} {
+		otherClient, err := fs.GetClient(ctx, other.kind, other.componentName, other.name)
+		require.NoError(t, err)
+		value, err := otherClient.Get(ctx, "checkpoint")
+		require.NoError(t, err)
+		assert.Nil(t, value)
+		require.NoError(t, otherClient.Close(ctx))
+	}
+
+	_, err = fs.GetClient(ctx, component.Kind(0), "filelog", "")
+	assert.EqualError(t, err, "unknown component kind 0")
+}
+
+func TestFileStorageClientConcurrency(t *testing.T) {
+	fs, cleanup := newTestFileStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	client, err := fs.GetClient(ctx, component.KindExporter, "otlp", "")
+	require.NoError(t, err)
+	defer client.Close(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.NoError(t, client.Set(ctx, "key", []byte("value")))
+				value, err := client.Get(ctx, "key")
+				assert.NoError(t, err)
+				assert.Equal(t, []byte("value"), value)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// No temporary file is left behind.
+	files, err := filepath.Glob(filepath.Join(fs.config.Directory, "exporter_otlp", "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestFileStorageStartInvalidDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fs := newFileStorage(Config{Directory: filepath.Join(dir, "missing")}, zap.NewNop())
+	assert.Error(t, fs.Start(context.Background(), componenttest.NewNopHost()))
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, ioutil.WriteFile(file, nil, 0600))
+	fs = newFileStorage(Config{Directory: file}, zap.NewNop())
+	assert.EqualError(t, fs.Start(context.Background(), componenttest.NewNopHost()), "\""+file+"\" is not a directory")
+}
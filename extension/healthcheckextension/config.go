@@ -15,6 +15,9 @@
 package healthcheckextension
 
 import (
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
@@ -26,4 +29,62 @@ type Config struct {
 	// Port is the port used to publish the health check status.
 	// The default value is 13133.
 	Port uint16 `mapstructure:"port"`
+
+	// Detail configures the per-pipeline readiness report published on /health/detail.
+	Detail DetailConfig `mapstructure:"detail"`
+}
+
+// DetailConfig defines the thresholds used to mark the components, and the pipelines using them, as not ready.
+type DetailConfig struct {
+	// Enabled publishes the per-pipeline readiness report on /health/detail.
+	Enabled bool `mapstructure:"enabled"`
+
+	// CheckInterval is the interval at which the component status is evaluated, the ratios below are computed over
+	// the data processed during the interval. The default value is 30s.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// MaxQueueUtilization is the ratio of the sending queue capacity over which an exporter is not ready.
+	// The default value is 0.9.
+	MaxQueueUtilization float64 `mapstructure:"max_queue_utilization"`
+
+	// MaxExportFailureRatio is the ratio of the data failed to be sent over which an exporter is not ready.
+	// The default value is 0.5.
+	MaxExportFailureRatio float64 `mapstructure:"max_export_failure_ratio"`
+
+	// MaxRefusedRatio is the ratio of the data refused by a processor, for instance by the memory limiter, over which
+	// the processor is not ready. The default value is 0, any refused data marks the processor as not ready.
+	MaxRefusedRatio float64 `mapstructure:"max_refused_ratio"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks the thresholds of the per-pipeline readiness report.
+func (cfg *Config) Validate() error {
+	if err := validateDetailConfig(cfg.Detail); err != nil {
+		return fmt.Errorf("invalid detail configuration: %w", err)
+	}
+	return nil
+}
+
+func validateDetailConfig(cfg DetailConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.CheckInterval <= 0 {
+		return fmt.Errorf("check_interval must be positive, got %v", cfg.CheckInterval)
+	}
+	ratios := []struct {
+		name  string
+		value float64
+	}{
+		{"max_queue_utilization", cfg.MaxQueueUtilization},
+		{"max_export_failure_ratio", cfg.MaxExportFailureRatio},
+		{"max_refused_ratio", cfg.MaxRefusedRatio},
+	}
+	for _, ratio := range ratios {
+		if ratio.value < 0 || ratio.value > 1 {
+			return fmt.Errorf("%s must be between 0 and 1, got %v", ratio.name, ratio.value)
+		}
+	}
+	return nil
 }
@@ -17,6 +17,7 @@ package healthcheckextension
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,9 +49,32 @@ func TestLoadConfig(t *testing.T) {
 				NameVal: "health_check/1",
 			},
 			Port: 13,
+			Detail: DetailConfig{
+				CheckInterval:         30 * time.Second,
+				MaxQueueUtilization:   0.9,
+				MaxExportFailureRatio: 0.5,
+			},
 		},
 		ext1)
 
+	ext2 := cfg.Extensions["health_check/detail"]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				TypeVal: "health_check",
+				NameVal: "health_check/detail",
+			},
+			Port: 13133,
+			Detail: DetailConfig{
+				Enabled:               true,
+				CheckInterval:         10 * time.Second,
+				MaxQueueUtilization:   0.8,
+				MaxExportFailureRatio: 0.25,
+				MaxRefusedRatio:       0.1,
+			},
+		},
+		ext2)
+
 	assert.Equal(t, 1, len(cfg.Service.Extensions))
 	assert.Equal(t, "health_check/1", cfg.Service.Extensions[0])
 }
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.opencensus.io/stats/view"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	detailPath = "/health/detail"
+
+	statusReady    = "ready"
+	statusNotReady = "not_ready"
+
+	circuitBreakerClosed = "closed"
+)
+
+// pipelinesHost is implemented by the service host, it gives access to the configured pipelines.
+type pipelinesHost interface {
+	GetPipelines() configmodels.Pipelines
+}
+
+// queueSizer is implemented by the exporters built with the exporterhelper.
+type queueSizer interface {
+	QueueSize() (size int, capacity int)
+}
+
+// circuitBreakerStater is implemented by the exporters built with the exporterhelper.
+type circuitBreakerStater interface {
+	CircuitBreakerState() string
+}
+
+// ratioKeys are the obsreport keys of the counters used to compute the failure ratio of a component, the first one
+// counts the data successfully handled, the second one the data which failed.
+type ratioKeys [2]string
+
+var (
+	exporterKeysByDataType = map[configmodels.DataType]ratioKeys{
+		configmodels.TracesDataType:  {obsreport.SentSpansKey, obsreport.FailedToSendSpansKey},
+		configmodels.MetricsDataType: {obsreport.SentMetricPointsKey, obsreport.FailedToSendMetricPointsKey},
+		configmodels.LogsDataType:    {obsreport.SentLogRecordsKey, obsreport.FailedToSendLogRecordsKey},
+	}
+	processorKeysByDataType = map[configmodels.DataType]ratioKeys{
+		configmodels.TracesDataType:  {obsreport.AcceptedSpansKey, obsreport.RefusedSpansKey},
+		configmodels.MetricsDataType: {obsreport.AcceptedMetricPointsKey, obsreport.RefusedMetricPointsKey},
+		configmodels.LogsDataType:    {obsreport.AcceptedLogRecordsKey, obsreport.RefusedLogRecordsKey},
+	}
+)
+
+// detailReport is the JSON document published on /health/detail.
+type detailReport struct {
+	Status    string                    `json:"status"`
+	Pipelines map[string]pipelineReport `json:"pipelines"`
+}
+
+type pipelineReport struct {
+	Ready      bool              `json:"ready"`
+	Components []componentReport `json:"components"`
+}
+
+type componentReport struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Ready  bool   `json:"ready"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// detailChecker periodically evaluates the status of the components of every pipeline and keeps the last report.
+type detailChecker struct {
+	config DetailConfig
+	// collectorReady reports whether the collector itself is ready, the report is not ready until it is.
+	collectorReady func() bool
+
+	pipelines configmodels.Pipelines
+	exporters map[configmodels.DataType]map[string]component.Exporter
+
+	// previous holds the counters read by the previous check, by view name then component name.
+	previous map[string]map[string]int64
+
+	mu     sync.Mutex
+	report detailReport
+
+	// statusMu guards the last status event reported by each component.
+	statusMu sync.Mutex
+	statuses map[component.StatusSource]*component.StatusEvent
+}
+
+func newDetailChecker(config DetailConfig, host component.Host, collectorReady func() bool) *detailChecker {
+	dc := &detailChecker{
+		config:         config,
+		collectorReady: collectorReady,
+		exporters:      map[configmodels.DataType]map[string]component.Exporter{},
+		previous:       map[string]map[string]int64{},
+		statuses:       map[component.StatusSource]*component.StatusEvent{},
+	}
+	if ph, ok := host.(pipelinesHost); ok {
+		dc.pipelines = ph.GetPipelines()
+	}
+	for dataType, exporters := range host.GetExporters() {
+		dc.exporters[dataType] = map[string]component.Exporter{}
+		for cfg, exp := range exporters {
+			dc.exporters[dataType][cfg.Name()] = exp
+		}
+	}
+	return dc
+}
+
+// check evaluates the components using the counters recorded since the previous check.
+func (dc *detailChecker) check() {
+	current := readCounters()
+	report := detailReport{
+		Status:    statusReady,
+		Pipelines: make(map[string]pipelineReport, len(dc.pipelines)),
+	}
+
+	for name, pipeline := range dc.pipelines {
+		pr := pipelineReport{Ready: true}
+		for _, receiver := range pipeline.Receivers {
+			cr := componentReport{Kind: "receiver", Name: receiver, Ready: true}
+			dc.applyStatus(&cr, component.StatusSource{Kind: component.KindReceiver, Name: receiver})
+			pr.Components = append(pr.Components, cr)
+		}
+		for _, processor := range pipeline.Processors {
+			cr := componentReport{Kind: "processor", Name: processor, Ready: true}
+			ratio := dc.ratio(current, "processor", processor, processorKeysByDataType[pipeline.InputType])
+			if ratio > dc.config.MaxRefusedRatio {
+				cr.Ready = false
+				cr.Reason = fmt.Sprintf("refused ratio %.2f is over the threshold %.2f", ratio, dc.config.MaxRefusedRatio)
+			}
+			dc.applyStatus(&cr, component.StatusSource{Kind: component.KindProcessor, Name: processor, Pipeline: name})
+			pr.Components = append(pr.Components, cr)
+		}
+		for _, exporter := range pipeline.Exporters {
+			cr := componentReport{Kind: "exporter", Name: exporter, Ready: true}
+			ratio := dc.ratio(current, "exporter", exporter, exporterKeysByDataType[pipeline.InputType])
+			if ratio > dc.config.MaxExportFailureRatio {
+				cr.Ready = false
+				cr.Reason = fmt.Sprintf("export failure ratio %.2f is over the threshold %.2f", ratio, dc.config.MaxExportFailureRatio)
+			}
+			dc.applyStatus(&cr, component.StatusSource{Kind: component.KindExporter, Name: exporter})
+			if cbs, ok := dc.exporters[pipeline.InputType][exporter].(circuitBreakerStater); ok && cr.Ready {
+				if state := cbs.CircuitBreakerState(); state != circuitBreakerClosed {
+					cr.Ready = false
+					cr.Reason = fmt.Sprintf("circuit breaker is %s", state)
+				}
+			}
+			if qs, ok := dc.exporters[pipeline.InputType][exporter].(queueSizer); ok && cr.Ready {
+				if size, capacity := qs.QueueSize(); capacity > 0 {
+					if utilization := float64(size) / float64(capacity); utilization >= dc.config.MaxQueueUtilization {
+						cr.Ready = false
+						cr.Reason = fmt.Sprintf("queue utilization %.2f is over the threshold %.2f", utilization, dc.config.MaxQueueUtilization)
+					}
+				}
+			}
+			pr.Components = append(pr.Components, cr)
+		}
+		for _, cr := range pr.Components {
+			pr.Ready = pr.Ready && cr.Ready
+		}
+		if !pr.Ready {
+			report.Status = statusNotReady
+		}
+		report.Pipelines[name] = pr
+	}
+	dc.previous = current
+
+	dc.mu.Lock()
+	dc.report = report
+	dc.mu.Unlock()
+}
+
+// setStatus records the last status event reported by a component.
+func (dc *detailChecker) setStatus(source component.StatusSource, event *component.StatusEvent) {
+	dc.statusMu.Lock()
+	defer dc.statusMu.Unlock()
+	dc.statuses[source] = event
+}
+
+// applyStatus marks the component as not ready when the last status it reported is an error, unless it is already
+// not ready.
+func (dc *detailChecker) applyStatus(cr *componentReport, source component.StatusSource) {
+	dc.statusMu.Lock()
+	event := dc.statuses[source]
+	dc.statusMu.Unlock()
+	if event == nil || event.Status == component.StatusOK || !cr.Ready {
+		return
+	}
+	cr.Ready = false
+	cr.Reason = "reported " + event.Status.String()
+	if event.Err != nil {
+		cr.Reason += ": " + event.Err.Error()
+	}
+}
+
+// ratio returns the ratio of the failed data over all the data handled by the component since the previous check.
+func (dc *detailChecker) ratio(current map[string]map[string]int64, kind, name string, keys ratioKeys) float64 {
+	delta := func(key string) int64 {
+		viewName := kind + "/" + key
+		return current[viewName][name] - dc.previous[viewName][name]
+	}
+	ok, failed := delta(keys[0]), delta(keys[1])
+	if ok+failed <= 0 {
+		return 0
+	}
+	return float64(failed) / float64(ok+failed)
+}
+
+func (dc *detailChecker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	dc.mu.Lock()
+	report := dc.report
+	dc.mu.Unlock()
+	if !dc.collectorReady() {
+		report.Status = statusNotReady
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status == statusReady {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// readCounters reads the obsreport counters aggregated by component name, the views which are not registered, for
+// instance when the telemetry level is none, are skipped.
+func readCounters() map[string]map[string]int64 {
+	counters := map[string]map[string]int64{}
+	for _, v := range obsreport.AllViews() {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			continue
+		}
+		sums := map[string]int64{}
+		for _, row := range rows {
+			sumData, ok := row.Data.(*view.SumData)
+			if !ok {
+				continue
+			}
+			for _, t := range row.Tags {
+				if t.Key.Name() == obsreport.ProcessorKey || t.Key.Name() == obsreport.ExporterKey {
+					sums[t.Value] += int64(sumData.Value)
+				}
+			}
+		}
+		counters[v.Name] = sums
+	}
+	return counters
+}
@@ -0,0 +1,301 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+type detailHost struct {
+	component.Host
+	pipelines configmodels.Pipelines
+	exporters map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter
+}
+
+func (h *detailHost) GetPipelines() configmodels.Pipelines {
+	return h.pipelines
+}
+
+func (h *detailHost) GetExporters() map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter {
+	return h.exporters
+}
+
+type queuedExporter struct {
+	component.Exporter
+	size, capacity int
+}
+
+func (e *queuedExporter) QueueSize() (int, int) {
+	return e.size, e.capacity
+}
+
+type breakerExporter struct {
+	component.Exporter
+	state string
+}
+
+func (e *breakerExporter) CircuitBreakerState() string {
+	return e.state
+}
+
+func newDetailHost(exp component.Exporter) *detailHost {
+	return &detailHost{
+		Host: componenttest.NewNopHost(),
+		pipelines: configmodels.Pipelines{
+			"traces": &configmodels.Pipeline{
+				Name:       "traces",
+				InputType:  configmodels.TracesDataType,
+				Receivers:  []string{"otlp"},
+				Processors: []string{"memory_limiter"},
+				Exporters:  []string{"otlp"},
+			},
+		},
+		exporters: map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter{
+			configmodels.TracesDataType: {
+				&configmodels.ExporterSettings{NameVal: "otlp"}: exp,
+			},
+		},
+	}
+}
+
+func defaultDetailConfig() DetailConfig {
+	cfg := createDefaultConfig().(*Config).Detail
+	cfg.Enabled = true
+	return cfg
+}
+
+func getDetailReport(t *testing.T, dc *detailChecker) (int, detailReport) {
+	rec := httptest.NewRecorder()
+	dc.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, detailPath, nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var report detailReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	return rec.Code, report
+}
+
+func TestDetailChecker(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	exp := &queuedExporter{capacity: 10}
+	ready := true
+	dc := newDetailChecker(defaultDetailConfig(), newDetailHost(exp), func() bool { return ready })
+	dc.check()
+
+	code, report := getDetailReport(t, dc)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, detailReport{
+		Status: statusReady,
+		Pipelines: map[string]pipelineReport{
+			"traces": {
+				Ready: true,
+				Components: []componentReport{
+					{Kind: "receiver", Name: "otlp", Ready: true},
+					{Kind: "processor", Name: "memory_limiter", Ready: true},
+					{Kind: "exporter", Name: "otlp", Ready: true},
+				},
+			},
+		},
+	}, report)
+
+	ready = false
+	code, report = getDetailReport(t, dc)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, statusNotReady, report.Status)
+	assert.True(t, report.Pipelines["traces"].Ready)
+	ready = true
+
+	// The memory limiter refusing data and most of the data failing to be sent marks the pipeline as not ready.
+	ctx := context.Background()
+	proc := obsreport.NewProcessor(obsreport.ProcessorSettings{Level: configtelemetry.LevelNormal, ProcessorName: "memory_limiter"})
+	proc.TracesAccepted(ctx, 10)
+	proc.TracesRefused(ctx, 1)
+	obsExp := obsreport.NewExporter(obsreport.ExporterSettings{Level: configtelemetry.LevelNormal, ExporterName: "otlp"})
+	obsExp.EndTracesExportOp(obsExp.StartTracesExportOp(ctx), 2, nil)
+	obsExp.EndTracesExportOp(obsExp.StartTracesExportOp(ctx), 3, errors.New("failed"))
+	dc.check()
+
+	code, report = getDetailReport(t, dc)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, detailReport{
+		Status: statusNotReady,
+		Pipelines: map[string]pipelineReport{
+			"traces": {
+				Ready: false,
+				Components: []componentReport{
+					{Kind: "receiver", Name: "otlp", Ready: true},
+					{Kind: "processor", Name: "memory_limiter", Ready: false, Reason: "refused ratio 0.09 is over the threshold 0.00"},
+					{Kind: "exporter", Name: "otlp", Ready: false, Reason: "export failure ratio 0.60 is over the threshold 0.50"},
+				},
+			},
+		},
+	}, report)
+
+	// The ratios are computed over the data handled since the previous check, only the queue is saturated now.
+	exp.size = 9
+	dc.check()
+
+	code, report = getDetailReport(t, dc)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, []componentReport{
+		{Kind: "receiver", Name: "otlp", Ready: true},
+		{Kind: "processor", Name: "memory_limiter", Ready: true},
+		{Kind: "exporter", Name: "otlp", Ready: false, Reason: "queue utilization 0.90 is over the threshold 0.90"},
+	}, report.Pipelines["traces"].Components)
+
+	exp.size = 1
+	dc.check()
+	code, _ = getDetailReport(t, dc)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestDetailCheckerCircuitBreaker(t *testing.T) {
+	exp := &breakerExporter{state: "closed"}
+	dc := newDetailChecker(defaultDetailConfig(), newDetailHost(exp), func() bool { return true })
+	dc.check()
+	code, _ := getDetailReport(t, dc)
+	assert.Equal(t, http.StatusOK, code)
+
+	exp.state = "open"
+	dc.check()
+	code, report := getDetailReport(t, dc)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, []componentReport{
+		{Kind: "receiver", Name: "otlp", Ready: true},
+		{Kind: "processor", Name: "memory_limiter", Ready: true},
+		{Kind: "exporter", Name: "otlp", Ready: false, Reason: "circuit breaker is open"},
+	}, report.Pipelines["traces"].Components)
+}
+
+func TestDetailCheckerComponentStatus(t *testing.T) {
+	hcExt := newServer(Config{Detail: defaultDetailConfig()}, zap.NewNop())
+	hcExt.detail = newDetailChecker(hcExt.config.Detail, newDetailHost(&queuedExporter{}), func() bool { return true })
+
+	hcExt.ComponentStatusChanged(
+		component.StatusSource{Kind: component.KindReceiver, Name: "otlp"},
+		&component.StatusEvent{Status: component.StatusPermanentError, Err: errors.New("port in use")})
+	hcExt.ComponentStatusChanged(
+		component.StatusSource{Kind: component.KindExporter, Name: "otlp"},
+		&component.StatusEvent{Status: component.StatusRecoverableError})
+	// The processors are identified by their pipeline.
+	hcExt.ComponentStatusChanged(
+		component.StatusSource{Kind: component.KindProcessor, Name: "memory_limiter", Pipeline: "other"},
+		&component.StatusEvent{Status: component.StatusPermanentError})
+	hcExt.detail.check()
+
+	code, report := getDetailReport(t, hcExt.detail)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, []componentReport{
+		{Kind: "receiver", Name: "otlp", Ready: false, Reason: "reported PermanentError: port in use"},
+		{Kind: "processor", Name: "memory_limiter", Ready: true},
+		{Kind: "exporter", Name: "otlp", Ready: false, Reason: "reported RecoverableError"},
+	}, report.Pipelines["traces"].Components)
+
+	// The components are ready again once they reported they recovered.
+	hcExt.ComponentStatusChanged(
+		component.StatusSource{Kind: component.KindReceiver, Name: "otlp"},
+		&component.StatusEvent{Status: component.StatusOK})
+	hcExt.ComponentStatusChanged(
+		component.StatusSource{Kind: component.KindExporter, Name: "otlp"},
+		&component.StatusEvent{Status: component.StatusOK})
+	hcExt.detail.check()
+	code, _ = getDetailReport(t, hcExt.detail)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestDetailCheckerWithoutPipelines(t *testing.T) {
+	dc := newDetailChecker(defaultDetailConfig(), componenttest.NewNopHost(), func() bool { return true })
+	dc.check()
+
+	code, report := getDetailReport(t, dc)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, detailReport{Status: statusReady, Pipelines: map[string]pipelineReport{}}, report)
+}
+
+func TestHealthCheckExtensionDetail(t *testing.T) {
+	config := Config{
+		Port:   testutil.GetAvailablePort(t),
+		Detail: defaultDetailConfig(),
+	}
+
+	hcExt := newServer(config, zap.NewNop())
+	require.NotNil(t, hcExt)
+
+	require.NoError(t, hcExt.Start(context.Background(), newDetailHost(&queuedExporter{capacity: 10})))
+	defer hcExt.Shutdown(context.Background())
+
+	url := "http://localhost:" + strconv.Itoa(int(config.Port))
+	client := &http.Client{Timeout: 5 * time.Second}
+	hcExt.Ready()
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respDetail, err := client.Get(url + detailPath)
+	require.NoError(t, err)
+	defer respDetail.Body.Close()
+	assert.Equal(t, http.StatusOK, respDetail.StatusCode)
+
+	var report detailReport
+	require.NoError(t, json.NewDecoder(respDetail.Body).Decode(&report))
+	assert.Equal(t, statusReady, report.Status)
+	assert.Contains(t, report.Pipelines, "traces")
+}
+
+func TestHealthCheckExtensionDetailDisabled(t *testing.T) {
+	config := Config{
+		Port: testutil.GetAvailablePort(t),
+	}
+
+	hcExt := newServer(config, zap.NewNop())
+	require.NotNil(t, hcExt)
+
+	require.NoError(t, hcExt.Start(context.Background(), newDetailHost(&queuedExporter{})))
+	defer hcExt.Shutdown(context.Background())
+	hcExt.Ready()
+
+	// Without the detail report every path serves the health check status.
+	resp, err := http.Get("http://localhost:" + strconv.Itoa(int(config.Port)) + detailPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.NotContains(t, body, "pipelines")
+}
@@ -16,6 +16,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -42,11 +43,19 @@ func createDefaultConfig() configmodels.Extension {
 			NameVal: typeStr,
 		},
 		Port: 13133,
+		Detail: DetailConfig{
+			CheckInterval:         30 * time.Second,
+			MaxQueueUtilization:   0.9,
+			MaxExportFailureRatio: 0.5,
+		},
 	}
 }
 
 func createExtension(_ context.Context, params component.ExtensionCreateParams, cfg configmodels.Extension) (component.Extension, error) {
 	config := cfg.(*Config)
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 
 	return newServer(*config, params.Logger), nil
 }
@@ -17,6 +17,7 @@ package healthcheckextension
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +37,11 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 			TypeVal: typeStr,
 		},
 		Port: 13133,
+		Detail: DetailConfig{
+			CheckInterval:         30 * time.Second,
+			MaxQueueUtilization:   0.9,
+			MaxExportFailureRatio: 0.5,
+		},
 	},
 		cfg)
 
@@ -53,3 +59,38 @@ func TestFactory_CreateExtension(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, ext)
 }
+
+func TestFactory_CreateExtensionInvalidDetail(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *DetailConfig)
+		wantErr string
+	}{
+		{
+			name:    "check_interval",
+			modify:  func(cfg *DetailConfig) { cfg.CheckInterval = 0 },
+			wantErr: `invalid detail configuration: check_interval must be positive, got 0s`,
+		},
+		{
+			name:    "max_queue_utilization",
+			modify:  func(cfg *DetailConfig) { cfg.MaxQueueUtilization = 1.5 },
+			wantErr: `invalid detail configuration: max_queue_utilization must be between 0 and 1, got 1.5`,
+		},
+		{
+			name:    "max_refused_ratio",
+			modify:  func(cfg *DetailConfig) { cfg.MaxRefusedRatio = -0.1 },
+			wantErr: `invalid detail configuration: max_refused_ratio must be between 0 and 1, got -0.1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Detail.Enabled = true
+			tt.modify(&cfg.Detail)
+
+			ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, ext)
+		})
+	}
+}
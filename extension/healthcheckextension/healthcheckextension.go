@@ -19,6 +19,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.uber.org/zap"
@@ -32,9 +33,14 @@ type healthCheckExtension struct {
 	state  *healthcheck.HealthCheck
 	server http.Server
 	stopCh chan struct{}
+
+	// doneCh stops the periodic checks of the detail report.
+	doneCh chan struct{}
+	detail *detailChecker
 }
 
 var _ component.PipelineWatcher = (*healthCheckExtension)(nil)
+var _ component.StatusWatcher = (*healthCheckExtension)(nil)
 
 func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
 
@@ -49,6 +55,19 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 
 	// Mount HC handler
 	hc.server.Handler = hc.state.Handler()
+	if hc.config.Detail.Enabled {
+		hc.detail = newDetailChecker(hc.config.Detail, host, func() bool {
+			return hc.state.Get() == healthcheck.Ready
+		})
+		hc.detail.check()
+		mux := http.NewServeMux()
+		mux.Handle("/", hc.state.Handler())
+		mux.Handle(detailPath, hc.detail)
+		hc.server.Handler = mux
+
+		hc.doneCh = make(chan struct{})
+		go hc.checkDetail(hc.doneCh)
+	}
 	hc.stopCh = make(chan struct{})
 	go func() {
 		defer close(hc.stopCh)
@@ -62,7 +81,25 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 	return nil
 }
 
+// checkDetail refreshes the detail report every check interval until the extension is shut down.
+func (hc *healthCheckExtension) checkDetail(doneCh chan struct{}) {
+	ticker := time.NewTicker(hc.config.Detail.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			hc.detail.check()
+		case <-doneCh:
+			return
+		}
+	}
+}
+
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
+	if hc.doneCh != nil {
+		close(hc.doneCh)
+		hc.doneCh = nil
+	}
 	err := hc.server.Close()
 	if hc.stopCh != nil {
 		<-hc.stopCh
@@ -80,6 +117,14 @@ func (hc *healthCheckExtension) NotReady() error {
 	return nil
 }
 
+// ComponentStatusChanged records the status events of the components for the detail report, the components that
+// last reported an error are not ready from the next check.
+func (hc *healthCheckExtension) ComponentStatusChanged(source component.StatusSource, event *component.StatusEvent) {
+	if hc.detail != nil {
+		hc.detail.setStatus(source, event)
+	}
+}
+
 func newServer(config Config, logger *zap.Logger) *healthCheckExtension {
 	hc := &healthCheckExtension{
 		config: config,
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+var errNoVerifiedCertificate = errors.New("no client certificate verified by the TLS handshake")
+
+type mtlsAuth struct {
+	config          Config
+	logger          *zap.Logger
+	allowedSubjects map[string]bool
+}
+
+var (
+	_ component.Extension            = (*mtlsAuth)(nil)
+	_ configauth.ServerAuthenticator = (*mtlsAuth)(nil)
+)
+
+func newMTLSAuth(config Config, logger *zap.Logger) *mtlsAuth {
+	m := &mtlsAuth{
+		config: config,
+		logger: logger,
+	}
+	if len(config.AllowedSubjects) > 0 {
+		m.allowedSubjects = make(map[string]bool, len(config.AllowedSubjects))
+		for _, subject := range config.AllowedSubjects {
+			m.allowedSubjects[subject] = true
+		}
+	}
+	return m
+}
+
+func (m *mtlsAuth) Start(context.Context, component.Host) error {
+	m.logger.Info("Starting mtlsauth extension", zap.Any("config", m.config))
+	return configauth.RegisterServerAuthenticator(m.config.Name(), m)
+}
+
+func (m *mtlsAuth) Shutdown(context.Context) error {
+	configauth.UnregisterServerAuthenticator(m.config.Name())
+	return nil
+}
+
+// Authenticate uses the common name of the verified client certificate as the subject, and its organizational units
+// as the groups. The receiver must be configured with a client CA so the client certificates are verified.
+func (m *mtlsAuth) Authenticate(ctx context.Context, _ map[string][]string) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx, errNoVerifiedCertificate
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ctx, errNoVerifiedCertificate
+	}
+
+	subject := tlsInfo.State.VerifiedChains[0][0].Subject
+	if m.allowedSubjects != nil && !m.allowedSubjects[subject.CommonName] {
+		return ctx, fmt.Errorf("subject %q is not allowed", subject.CommonName)
+	}
+	return configauth.NewContext(ctx, subject.CommonName, subject.OrganizationalUnit), nil
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+func peerContext(state tls.ConnectionState) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.ParseIP("1.2.3.4")},
+		AuthInfo: credentials.TLSInfo{State: state},
+	})
+}
+
+func verifiedState(commonName string, units ...string) tls.ConnectionState {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName, OrganizationalUnit: units}}
+	return tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+}
+
+func TestMTLSAuthenticate(t *testing.T) {
+	tests := []struct {
+		name            string
+		allowedSubjects []string
+		ctx             context.Context
+		wantErr         string
+		wantSubject     string
+		wantGroups      []string
+	}{
+		{
+			name:    "no peer",
+			ctx:     context.Background(),
+			wantErr: errNoVerifiedCertificate.Error(),
+		},
+		{
+			name:    "no verified certificate",
+			ctx:     peerContext(tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}}),
+			wantErr: errNoVerifiedCertificate.Error(),
+		},
+		{
+			name:        "verified certificate",
+			ctx:         peerContext(verifiedState("agent-1", "agents", "eu-west")),
+			wantSubject: "agent-1",
+			wantGroups:  []string{"agents", "eu-west"},
+		},
+		{
+			name:            "allowed subject",
+			allowedSubjects: []string{"agent-1"},
+			ctx:             peerContext(verifiedState("agent-1")),
+			wantSubject:     "agent-1",
+		},
+		{
+			name:            "subject not allowed",
+			allowedSubjects: []string{"agent-1"},
+			ctx:             peerContext(verifiedState("agent-2")),
+			wantErr:         `subject "agent-2" is not allowed`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := newMTLSAuth(Config{AllowedSubjects: tt.allowedSubjects}, zap.NewNop())
+			ctx, err := auth.Authenticate(tt.ctx, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			subject, _ := configauth.SubjectFromContext(ctx)
+			assert.Equal(t, tt.wantSubject, subject)
+			groups, _ := configauth.GroupsFromContext(ctx)
+			assert.Equal(t, tt.wantGroups, groups)
+		})
+	}
+}
+
+func TestMTLSAuthRegistration(t *testing.T) {
+	auth := newMTLSAuth(Config{ExtensionSettings: configmodels.ExtensionSettings{TypeVal: typeStr, NameVal: "mtlsauth/test"}}, zap.NewNop())
+
+	require.NoError(t, auth.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Error(t, configauth.RegisterServerAuthenticator("mtlsauth/test", auth))
+	require.NoError(t, auth.Shutdown(context.Background()))
+	require.NoError(t, configauth.RegisterServerAuthenticator("mtlsauth/test", auth))
+	configauth.UnregisterServerAuthenticator("mtlsauth/test")
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config has the configuration for the OAuth2 client credentials authenticator extension.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// ClientID is the application's ID.
+	ClientID string `mapstructure:"client_id"`
+
+	// ClientSecret is the application's secret.
+	ClientSecret string `mapstructure:"client_secret"`
+
+	// TokenURL is the resource server's token endpoint URL.
+	TokenURL string `mapstructure:"token_url"`
+
+	// Scopes specifies the optional requested permissions.
+	Scopes []string `mapstructure:"scopes"`
+
+	// EndpointParams specifies the additional parameters sent to the token endpoint, for instance an audience.
+	EndpointParams map[string]string `mapstructure:"endpoint_params"`
+
+	// Timeout is the timeout of the requests to the token endpoint. The default value is 10s.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the client credentials and the token URL are set.
+func (cfg *Config) Validate() error {
+	switch {
+	case cfg.ClientID == "":
+		return errNoClientIDProvided
+	case cfg.ClientSecret == "":
+		return errNoClientSecretProvided
+	case cfg.TokenURL == "":
+		return errNoTokenURLProvided
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			NameVal: typeStr,
+			TypeVal: typeStr,
+		},
+		Timeout: 10 * time.Second,
+	},
+		cfg)
+
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestFactory_CreateExtension(t *testing.T) {
+	params := component.ExtensionCreateParams{Logger: zap.NewNop()}
+	cfg := createDefaultConfig().(*Config)
+	ext, err := createExtension(context.Background(), params, cfg)
+	assert.Equal(t, errNoClientIDProvided, err)
+	assert.Nil(t, ext)
+
+	cfg.ClientID = "agent"
+	ext, err = createExtension(context.Background(), params, cfg)
+	assert.Equal(t, errNoClientSecretProvided, err)
+	assert.Nil(t, ext)
+
+	cfg.ClientSecret = "secret"
+	ext, err = createExtension(context.Background(), params, cfg)
+	assert.Equal(t, errNoTokenURLProvided, err)
+	assert.Nil(t, ext)
+
+	cfg.TokenURL = "https://auth.example.com/oauth2/token"
+	ext, err = createExtension(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc/credentials"
+	grpcoauth "google.golang.org/grpc/credentials/oauth"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+// clientCredentialsAuth obtains the access tokens with the OAuth2 client credentials flow, the token source caches
+// the token and fetches a new one when it expires.
+type clientCredentialsAuth struct {
+	config      Config
+	logger      *zap.Logger
+	tokenSource oauth2.TokenSource
+}
+
+var (
+	_ component.Extension            = (*clientCredentialsAuth)(nil)
+	_ configauth.ClientAuthenticator = (*clientCredentialsAuth)(nil)
+	_ configauth.TokenProvider       = (*clientCredentialsAuth)(nil)
+)
+
+func newClientCredentialsAuth(config Config, logger *zap.Logger) *clientCredentialsAuth {
+	ccConfig := &clientcredentials.Config{
+		ClientID:       config.ClientID,
+		ClientSecret:   config.ClientSecret,
+		TokenURL:       config.TokenURL,
+		Scopes:         config.Scopes,
+		EndpointParams: url.Values{},
+	}
+	for k, v := range config.EndpointParams {
+		ccConfig.EndpointParams.Set(k, v)
+	}
+
+	// The token source uses this client for the requests to the token endpoint.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: config.Timeout})
+	return &clientCredentialsAuth{
+		config:      config,
+		logger:      logger,
+		tokenSource: ccConfig.TokenSource(ctx),
+	}
+}
+
+func (c *clientCredentialsAuth) Start(context.Context, component.Host) error {
+	c.logger.Info("Starting oauth2client extension", zap.String("token_url", c.config.TokenURL))
+	return configauth.RegisterClientAuthenticator(c.config.Name(), c)
+}
+
+func (c *clientCredentialsAuth) Shutdown(context.Context) error {
+	configauth.UnregisterClientAuthenticator(c.config.Name())
+	return nil
+}
+
+// RoundTripper adds the access token to the authorization header of the requests.
+func (c *clientCredentialsAuth) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	return &oauth2.Transport{
+		Source: c.tokenSource,
+		Base:   base,
+	}
+}
+
+// PerRPCCredentials adds the access token to the authorization metadata of the RPCs.
+func (c *clientCredentialsAuth) PerRPCCredentials() credentials.PerRPCCredentials {
+	return grpcoauth.TokenSource{TokenSource: c.tokenSource}
+}
+
+// Token returns the access token, for the components authenticating with it outside of HTTP and gRPC.
+func (c *clientCredentialsAuth) Token() (string, error) {
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth2clientauthextension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	grpcoauth "google.golang.org/grpc/credentials/oauth"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// newTokenServer returns a token endpoint issuing a new token for every request with the expected credentials.
+func newTokenServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "agent" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "api.traces", r.PostForm.Get("scope"))
+		assert.Equal(t, "otlp-gateway", r.PostForm.Get("audience"))
+
+		n := atomic.AddInt32(requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+}
+
+func newTestConfig(tokenURL string) Config {
+	return Config{
+		ExtensionSettings: configmodels.ExtensionSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		ClientID:       "agent",
+		ClientSecret:   "secret",
+		TokenURL:       tokenURL,
+		Scopes:         []string{"api.traces"},
+		EndpointParams: map[string]string{"audience": "otlp-gateway"},
+		Timeout:        5 * time.Second,
+	}
+}
+
+func TestClientCredentialsRoundTripper(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+
+	var authorization []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	auth := newClientCredentialsAuth(newTestConfig(tokenServer.URL), zap.NewNop())
+	client := &http.Client{Transport: auth.RoundTripper(http.DefaultTransport)}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// The token is cached until it expires.
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-1"}, authorization)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&tokenRequests))
+}
+
+func TestClientCredentialsPerRPCCredentials(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+
+	creds := newClientCredentialsAuth(newTestConfig(tokenServer.URL), zap.NewNop()).PerRPCCredentials()
+	assert.True(t, creds.RequireTransportSecurity())
+
+	// The metadata is only requested on secure connections, the token source is checked directly.
+	ts, ok := creds.(grpcoauth.TokenSource)
+	require.True(t, ok)
+	token, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+}
+
+func TestClientCredentialsToken(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+
+	auth := newClientCredentialsAuth(newTestConfig(tokenServer.URL), zap.NewNop())
+	for i := 0; i < 2; i++ {
+		token, err := auth.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(&tokenRequests))
+}
+
+func TestClientCredentialsInvalidCredentials(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+
+	cfg := newTestConfig(tokenServer.URL)
+	cfg.ClientSecret = "wrong"
+	client := &http.Client{Transport: newClientCredentialsAuth(cfg, zap.NewNop()).RoundTripper(http.DefaultTransport)}
+	_, err := client.Get(tokenServer.URL)
+	assert.Error(t, err)
+}
+
+func TestClientCredentialsStartRegistersAuthenticator(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	auth := newClientCredentialsAuth(newTestConfig(tokenServer.URL), zap.NewNop())
+	require.NoError(t, auth.Start(context.Background(), componenttest.NewNopHost()))
+
+	clientAuth := &configauth.ClientAuthentication{AuthenticatorName: typeStr}
+	client := &http.Client{Transport: clientAuth.ToRoundTripper(http.DefaultTransport)}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "Bearer token-1", authorization)
+
+	require.NoError(t, auth.Shutdown(context.Background()))
+	_, err = client.Get(server.URL)
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+// oidcAuth makes the OIDC authenticator of configauth available to the receivers as an extension.
+type oidcAuth struct {
+	configauth.Authenticator
+	config Config
+	logger *zap.Logger
+}
+
+var (
+	_ component.Extension            = (*oidcAuth)(nil)
+	_ configauth.ServerAuthenticator = (*oidcAuth)(nil)
+)
+
+func newOIDCAuth(config Config, authenticator configauth.Authenticator, logger *zap.Logger) *oidcAuth {
+	return &oidcAuth{
+		Authenticator: authenticator,
+		config:        config,
+		logger:        logger,
+	}
+}
+
+// Start fetches the configuration of the provider, the extension fails to start when the provider is not reachable.
+func (o *oidcAuth) Start(ctx context.Context, _ component.Host) error {
+	o.logger.Info("Starting oidc extension", zap.String("issuer_url", o.config.IssuerURL))
+	if err := o.Authenticator.Start(ctx); err != nil {
+		return err
+	}
+	return configauth.RegisterServerAuthenticator(o.config.Name(), o)
+}
+
+func (o *oidcAuth) Shutdown(context.Context) error {
+	configauth.UnregisterServerAuthenticator(o.config.Name())
+	return o.Authenticator.Close()
+}
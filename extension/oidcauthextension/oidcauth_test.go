@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidcauthextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// newDiscoveryServer returns a server publishing the configuration of an OIDC provider, the tokens themselves are
+// verified by the tests of configauth.
+func newDiscoveryServer() *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 server.URL,
+			"jwks_uri":               server.URL + "/.well-known/jwks.json",
+			"authorization_endpoint": server.URL + "/auth",
+			"token_endpoint":         server.URL + "/token",
+		})
+	}))
+	return server
+}
+
+func newTestExtension(t *testing.T, issuerURL string) component.Extension {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NameVal = "oidc/test"
+	cfg.IssuerURL = issuerURL
+	cfg.Audience = "unit-test"
+	ext, err := createExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	return ext
+}
+
+func TestOIDCAuthStart(t *testing.T) {
+	server := newDiscoveryServer()
+	defer server.Close()
+
+	ext := newTestExtension(t, server.URL)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The extension is registered under its name, the requests without token are rejected.
+	auth := ext.(configauth.ServerAuthenticator)
+	assert.Error(t, configauth.RegisterServerAuthenticator("oidc/test", auth))
+	_, err := auth.Authenticate(context.Background(), map[string][]string{})
+	assert.Error(t, err)
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+	require.NoError(t, configauth.RegisterServerAuthenticator("oidc/test", auth))
+	configauth.UnregisterServerAuthenticator("oidc/test")
+}
+
+func TestOIDCAuthStartProviderNotReachable(t *testing.T) {
+	server := newDiscoveryServer()
+	server.Close()
+
+	ext := newTestExtension(t, server.URL)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The extension is not registered when it fails to start.
+	auth := &oidcAuth{config: Config{ExtensionSettings: configmodels.ExtensionSettings{NameVal: "oidc/test"}}}
+	require.NoError(t, configauth.RegisterServerAuthenticator("oidc/test", auth))
+	configauth.UnregisterServerAuthenticator("oidc/test")
+}
@@ -15,6 +15,8 @@
 package pprofextension
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
@@ -41,3 +43,13 @@ type Config struct {
 	// Collector starts and is saved to the file when the Collector is terminated.
 	SaveToFile string `mapstructure:"save_to_file"`
 }
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the endpoint is set.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"pprof\" extension")
+	}
+	return nil
+}
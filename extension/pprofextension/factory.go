@@ -16,7 +16,6 @@ package pprofextension
 
 import (
 	"context"
-	"errors"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -48,8 +47,8 @@ func createDefaultConfig() configmodels.Extension {
 
 func createExtension(_ context.Context, params component.ExtensionCreateParams, cfg configmodels.Extension) (component.Extension, error) {
 	config := cfg.(*Config)
-	if config.Endpoint == "" {
-		return nil, errors.New("\"endpoint\" is required when using the \"pprof\" extension")
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
 
 	return newServer(*config, params.Logger), nil
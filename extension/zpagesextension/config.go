@@ -15,6 +15,8 @@
 package zpagesextension
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 )
 
@@ -26,4 +28,32 @@ type Config struct {
 	// Use localhost:<port> to make it available only locally, or ":<port>" to
 	// make it available on all network interfaces.
 	Endpoint string `mapstructure:"endpoint"`
+
+	// TraceZ configures the spans of the collector operations shown by the
+	// tracez page.
+	TraceZ TraceZSettings `mapstructure:"tracez"`
+}
+
+// TraceZSettings configures the sampling of the receive, scrape, process and
+// export operations of the components. The sampled spans are shown by the
+// tracez page, grouped by component and operation, and bucketed by latency and
+// errors.
+type TraceZSettings struct {
+	// SamplingRatio is the ratio of the operations that are sampled, between 0
+	// and 1. The sampled spans are also exported if the collector exports its
+	// own spans. If not set the operations are sampled with the default sampler.
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the endpoint is set.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"zpages\" extension")
+	}
+	if cfg.TraceZ.SamplingRatio < 0 || cfg.TraceZ.SamplingRatio > 1 {
+		return errors.New("\"tracez::sampling_ratio\" must be between 0 and 1")
+	}
+	return nil
 }
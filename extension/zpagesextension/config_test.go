@@ -48,9 +48,24 @@ func TestLoadConfig(t *testing.T) {
 				NameVal: "zpages/1",
 			},
 			Endpoint: "localhost:56888",
+			TraceZ: TraceZSettings{
+				SamplingRatio: 0.01,
+			},
 		},
 		ext1)
 
 	assert.Equal(t, 1, len(cfg.Service.Extensions))
 	assert.Equal(t, "zpages/1", cfg.Service.Extensions[0])
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.TraceZ.SamplingRatio = 1.5
+	assert.EqualError(t, cfg.Validate(), "\"tracez::sampling_ratio\" must be between 0 and 1")
+
+	cfg.TraceZ.SamplingRatio = 0.5
+	cfg.Endpoint = ""
+	assert.EqualError(t, cfg.Validate(), "\"endpoint\" is required when using the \"zpages\" extension")
+}
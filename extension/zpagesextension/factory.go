@@ -16,7 +16,6 @@ package zpagesextension
 
 import (
 	"context"
-	"errors"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -49,8 +48,8 @@ func createDefaultConfig() configmodels.Extension {
 // createExtension creates the extension based on this config.
 func createExtension(_ context.Context, params component.ExtensionCreateParams, cfg configmodels.Extension) (component.Extension, error) {
 	config := cfg.(*Config)
-	if config.Endpoint == "" {
-		return nil, errors.New("\"endpoint\" is required when using the \"zpages\" extension")
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
 
 	return newServer(*config, params.Logger), nil
@@ -19,10 +19,12 @@ import (
 	"net"
 	"net/http"
 
+	"go.opencensus.io/trace"
 	"go.opencensus.io/zpages"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/obsreport"
 )
 
 type zpagesExtension struct {
@@ -53,6 +55,12 @@ func (zpe *zpagesExtension) Start(_ context.Context, host component.Host) error
 		return err
 	}
 
+	// Sample the operations of the components so the tracez page shows the
+	// slow or failed ones.
+	if zpe.config.TraceZ.SamplingRatio > 0 {
+		obsreport.SetOperationsSampler(trace.ProbabilitySampler(zpe.config.TraceZ.SamplingRatio))
+	}
+
 	zpe.logger.Info("Starting zPages extension", zap.Any("config", zpe.config))
 	zpe.server = http.Server{Handler: zPagesMux}
 	zpe.stopCh = make(chan struct{})
@@ -68,6 +76,9 @@ func (zpe *zpagesExtension) Start(_ context.Context, host component.Host) error
 }
 
 func (zpe *zpagesExtension) Shutdown(context.Context) error {
+	if zpe.config.TraceZ.SamplingRatio > 0 {
+		obsreport.SetOperationsSampler(nil)
+	}
 	err := zpe.server.Close()
 	if zpe.stopCh != nil {
 		<-zpe.stopCh
@@ -16,15 +16,20 @@ package zpagesextension
 
 import (
 	"context"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"runtime"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/testutil"
 )
 
@@ -53,6 +58,41 @@ func TestZPagesExtensionUsage(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestZPagesExtensionTraceZSampling(t *testing.T) {
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
+	defer trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(1e-4)})
+
+	config := Config{
+		Endpoint: testutil.GetAvailableLocalAddress(t),
+		TraceZ: TraceZSettings{
+			SamplingRatio: 1,
+		},
+	}
+
+	zpagesExt := newServer(config, zap.NewNop())
+	require.NotNil(t, zpagesExt)
+
+	require.NoError(t, zpagesExt.Start(context.Background(), componenttest.NewNopHost()))
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{Level: configtelemetry.LevelNone, ExporterName: "slow"})
+	ctx := obsrep.StartTracesExportOp(context.Background())
+	assert.True(t, trace.FromContext(ctx).SpanContext().IsSampled())
+	obsrep.EndTracesExportOp(ctx, 1, nil)
+
+	resp, err := http.Get("http://" + config.Endpoint + "/debug/tracez")
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Contains(t, string(body), "exporter/slow/traces")
+
+	// The operations are sampled with the default sampler after the shutdown.
+	require.NoError(t, zpagesExt.Shutdown(context.Background()))
+	ctx = obsrep.StartTracesExportOp(context.Background())
+	assert.False(t, trace.FromContext(ctx).SpanContext().IsSampled())
+	obsrep.EndTracesExportOp(ctx, 1, nil)
+}
+
 func TestZPagesExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	ln, err := net.Listen("tcp", endpoint)
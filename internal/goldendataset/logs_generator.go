@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldendataset
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// GenerateLogs takes the filename of a PICT-generated file, walks through all of the rows in the PICT
+// file and for each row, generates a Logs object with a single LogRecord, collecting them and returning
+// them to the caller. The LogRecord of the row i is named "pict_<i>".
+func GenerateLogs(logPairsFile string) ([]pdata.Logs, error) {
+	random := io.Reader(rand.New(rand.NewSource(42)))
+	pictData, err := loadPictOutputFile(logPairsFile)
+	if err != nil {
+		return nil, err
+	}
+	var out []pdata.Logs
+	for i, values := range pictData {
+		if i == 0 {
+			continue
+		}
+		logInputs := &PICTLogInputs{
+			Severity:         PICTLogSeverity(values[0]),
+			Body:             PICTLogBody(values[1]),
+			Attributes:       PICTLogAttributes(values[2]),
+			TraceContext:     PICTLogTraceContext(values[3]),
+			NumResourceAttrs: PICTNumResourceAttrs(values[4]),
+		}
+		out = append(out, generateLogs(logInputs, fmt.Sprintf("pict_%d", i), random))
+	}
+	return out, nil
+}
+
+func generateLogs(logInputs *PICTLogInputs, name string, random io.Reader) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+	switch logInputs.NumResourceAttrs {
+	case AttrsOne:
+		rl.Resource().Attributes().InsertString("resource-attr-name-0", "resource-attr-val-0")
+	case AttrsTwo:
+		rl.Resource().Attributes().InsertString("resource-attr-name-0", "resource-attr-val-0")
+		rl.Resource().Attributes().InsertString("resource-attr-name-1", "resource-attr-val-1")
+	}
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	ills.At(0).InstrumentationLibrary().SetName("go.opentelemetry.io/collector/goldendataset")
+	logs := ills.At(0).Logs()
+	logs.Resize(1)
+
+	lr := logs.At(0)
+	lr.SetName(name)
+	lr.SetTimestamp(pdata.Timestamp(1581452772000000321))
+	populateLogSeverity(logInputs.Severity, lr)
+	populateLogBody(logInputs.Body, lr.Body())
+	populateLogAttributes(logInputs.Attributes, lr.Attributes())
+	populateLogTraceContext(logInputs.TraceContext, lr, random)
+	return ld
+}
+
+func populateLogSeverity(severity PICTLogSeverity, lr pdata.LogRecord) {
+	switch severity {
+	case LogSeverityTrace:
+		lr.SetSeverityNumber(pdata.SeverityNumberTRACE)
+		lr.SetSeverityText("TRACE")
+	case LogSeverityDebug:
+		lr.SetSeverityNumber(pdata.SeverityNumberDEBUG)
+		lr.SetSeverityText("DEBUG")
+	case LogSeverityInfo:
+		lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+		lr.SetSeverityText("INFO")
+	case LogSeverityWarn:
+		lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+		lr.SetSeverityText("WARN")
+	case LogSeverityError:
+		lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+		lr.SetSeverityText("ERROR")
+	case LogSeverityFatal:
+		lr.SetSeverityNumber(pdata.SeverityNumberFATAL)
+		lr.SetSeverityText("FATAL")
+	}
+}
+
+func populateLogBody(body PICTLogBody, dest pdata.AttributeValue) {
+	switch body {
+	case LogBodyString:
+		dest.SetStringVal("Connection to the database established after 3 retries")
+	case LogBodyInt:
+		dest.SetIntVal(42)
+	case LogBodyDouble:
+		dest.SetDoubleVal(3.14159)
+	case LogBodyBool:
+		dest.SetBoolVal(true)
+	case LogBodyMap:
+		kvs := pdata.NewAttributeValueMap()
+		kvs.MapVal().InsertString("event", "login")
+		kvs.MapVal().InsertString("user", "alice")
+		kvs.MapVal().InsertInt("attempt", 2)
+		kvs.CopyTo(dest)
+	case LogBodyArray:
+		arr := pdata.NewAttributeValueArray()
+		arr.ArrayVal().Append(pdata.NewAttributeValueString("first line"))
+		arr.ArrayVal().Append(pdata.NewAttributeValueString("second line"))
+		arr.CopyTo(dest)
+	}
+}
+
+func populateLogAttributes(attrs PICTLogAttributes, dest pdata.AttributeMap) {
+	switch attrs {
+	case LogAttrsOne:
+		dest.InsertString("component", "auth")
+	case LogAttrsMany:
+		dest.InsertString("component", "auth")
+		dest.InsertString("thread.name", "worker-7")
+		dest.InsertInt("thread.id", 7)
+		dest.InsertDouble("duration", 0.25)
+		dest.InsertBool("retry", false)
+	}
+}
+
+func populateLogTraceContext(traceContext PICTLogTraceContext, lr pdata.LogRecord, random io.Reader) {
+	switch traceContext {
+	case LogTraceContextSampled:
+		lr.SetTraceID(pdata.TraceID(generateTraceID(random)))
+		lr.SetSpanID(pdata.SpanID(generateSpanID(random)))
+		lr.SetFlags(1)
+	case LogTraceContextNotSampled:
+		lr.SetTraceID(pdata.TraceID(generateTraceID(random)))
+		lr.SetSpanID(pdata.SpanID(generateSpanID(random)))
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldendataset
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestGenerateLogs(t *testing.T) {
+	lds, err := GenerateLogs("testdata/generated_pict_pairs_logs.txt")
+	require.NoError(t, err)
+	require.Equal(t, 49, len(lds))
+	for i, ld := range lds {
+		require.Equal(t, 1, ld.LogRecordCount())
+		lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+		assert.Equal(t, "pict_"+strconv.Itoa(i+1), lr.Name())
+	}
+}
+
+func TestGenerateLogsInputs(t *testing.T) {
+	ld := generateLogs(&PICTLogInputs{
+		Severity:         LogSeverityError,
+		Body:             LogBodyMap,
+		Attributes:       LogAttrsMany,
+		TraceContext:     LogTraceContextSampled,
+		NumResourceAttrs: AttrsTwo,
+	}, "many", rand.New(rand.NewSource(42)))
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, 2, rl.Resource().Attributes().Len())
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "many", lr.Name())
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+	assert.Equal(t, "ERROR", lr.SeverityText())
+	assert.Equal(t, pdata.AttributeValueMAP, lr.Body().Type())
+	assert.Equal(t, 3, lr.Body().MapVal().Len())
+	assert.Equal(t, 5, lr.Attributes().Len())
+	assert.False(t, lr.TraceID().IsEmpty())
+	assert.False(t, lr.SpanID().IsEmpty())
+	assert.EqualValues(t, 1, lr.Flags())
+
+	ld = generateLogs(&PICTLogInputs{
+		Severity:         LogSeverityUnset,
+		Body:             LogBodyEmpty,
+		Attributes:       LogAttrsNone,
+		TraceContext:     LogTraceContextNone,
+		NumResourceAttrs: AttrsNone,
+	}, "none", rand.New(rand.NewSource(42)))
+	rl = ld.ResourceLogs().At(0)
+	assert.Equal(t, 0, rl.Resource().Attributes().Len())
+	lr = rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.SeverityNumberUNDEFINED, lr.SeverityNumber())
+	assert.Equal(t, pdata.AttributeValueNULL, lr.Body().Type())
+	assert.Equal(t, 0, lr.Attributes().Len())
+	assert.True(t, lr.TraceID().IsEmpty())
+}
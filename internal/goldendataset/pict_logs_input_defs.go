@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goldendataset
+
+// Start of PICT inputs for generating golden dataset logs (pict_input_logs.txt)
+
+// PICTLogInputs defines one pairwise combination of LogRecord variations
+type PICTLogInputs struct {
+	// Specifies the severity of the log record.
+	Severity PICTLogSeverity
+	// Specifies the type of the body of the log record.
+	Body PICTLogBody
+	// Specifies the number of attributes on the log record.
+	Attributes PICTLogAttributes
+	// Specifies the trace context the log record is correlated with.
+	TraceContext PICTLogTraceContext
+	// Specifies the number of attributes on each resource.
+	NumResourceAttrs PICTNumResourceAttrs
+}
+
+// Enumerates the severities of the log record.
+type PICTLogSeverity string
+
+const (
+	LogSeverityUnset PICTLogSeverity = "Unset"
+	LogSeverityTrace PICTLogSeverity = "Trace"
+	LogSeverityDebug PICTLogSeverity = "Debug"
+	LogSeverityInfo  PICTLogSeverity = "Info"
+	LogSeverityWarn  PICTLogSeverity = "Warn"
+	LogSeverityError PICTLogSeverity = "Error"
+	LogSeverityFatal PICTLogSeverity = "Fatal"
+)
+
+// Enumerates the types of the body of the log record.
+type PICTLogBody string
+
+const (
+	LogBodyEmpty  PICTLogBody = "Empty"
+	LogBodyString PICTLogBody = "String"
+	LogBodyInt    PICTLogBody = "Int"
+	LogBodyDouble PICTLogBody = "Double"
+	LogBodyBool   PICTLogBody = "Bool"
+	LogBodyMap    PICTLogBody = "Map"
+	LogBodyArray  PICTLogBody = "Array"
+)
+
+// Enumerates the number of attributes on the log record.
+type PICTLogAttributes string
+
+const (
+	LogAttrsNone PICTLogAttributes = "NoAttrs"
+	LogAttrsOne  PICTLogAttributes = "OneAttr"
+	LogAttrsMany PICTLogAttributes = "ManyAttrs"
+)
+
+// Enumerates the trace contexts the log record is correlated with.
+type PICTLogTraceContext string
+
+const (
+	LogTraceContextNone       PICTLogTraceContext = "NoTrace"
+	LogTraceContextSampled    PICTLogTraceContext = "Sampled"
+	LogTraceContextNotSampled PICTLogTraceContext = "NotSampled"
+)
@@ -70,13 +70,15 @@ type PICTTracingInputs struct {
 
 // Input columns in pict_input_spans.txt
 const (
-	SpansColumnParent     = 0
-	SpansColumnTracestate = 1
-	SpansColumnKind       = 2
-	SpansColumnAttributes = 3
-	SpansColumnEvents     = 4
-	SpansColumnLinks      = 5
-	SpansColumnStatus     = 6
+	SpansColumnParent          = 0
+	SpansColumnTracestate      = 1
+	SpansColumnKind            = 2
+	SpansColumnAttributes      = 3
+	SpansColumnEvents          = 4
+	SpansColumnLinks           = 5
+	SpansColumnStatus          = 6
+	SpansColumnEventAttributes = 7
+	SpansColumnLinkTracestate  = 8
 )
 
 // Enumerates the parent/child types of spans that can be generated.
@@ -142,6 +144,19 @@ const (
 	SpanChildCountEight PICTInputSpanChild = "Eight"
 )
 
+// Enumerates the types of attributes the events of a generated span can be populated with.
+type PICTInputEventAttributes string
+
+const (
+	EventAttrNil    PICTInputEventAttributes = "Nil"
+	EventAttrEmpty  PICTInputEventAttributes = "Empty"
+	EventAttrString PICTInputEventAttributes = "String"
+	EventAttrInt    PICTInputEventAttributes = "Int"
+	EventAttrDouble PICTInputEventAttributes = "Double"
+	EventAttrBool   PICTInputEventAttributes = "Bool"
+	EventAttrMixed  PICTInputEventAttributes = "Mixed"
+)
+
 // Enumerates the status values a generated span can be populated with.
 type PICTInputStatus string
 
@@ -167,4 +182,8 @@ type PICTSpanInputs struct {
 	Links PICTInputSpanChild
 	// Specifies the value to populate the Status field with
 	Status PICTInputStatus
+	// Specifies the types of values to populate the Attributes field of the Events with
+	EventAttributes PICTInputEventAttributes
+	// Specifies the category of contents to populate the TraceState field of the Links with
+	LinkTracestate PICTInputTracestate
 }
@@ -72,6 +72,9 @@ func GenerateSpans(count int, startPos int, pictFile string, random io.Reader) (
 			Events:     PICTInputSpanChild(inputs[SpansColumnEvents]),
 			Links:      PICTInputSpanChild(inputs[SpansColumnLinks]),
 			Status:     PICTInputStatus(inputs[SpansColumnStatus]),
+
+			EventAttributes: PICTInputEventAttributes(inputs[SpansColumnEventAttributes]),
+			LinkTracestate:  PICTInputTracestate(inputs[SpansColumnLinkTracestate]),
 		}
 		switch spanInputs.Parent {
 		case SpanParentRoot:
@@ -95,8 +98,9 @@ func GenerateSpans(count int, startPos int, pictFile string, random io.Reader) (
 }
 
 func generateSpanName(spanInputs *PICTSpanInputs) string {
-	return fmt.Sprintf("/%s/%s/%s/%s/%s/%s/%s", spanInputs.Parent, spanInputs.Tracestate, spanInputs.Kind,
-		spanInputs.Attributes, spanInputs.Events, spanInputs.Links, spanInputs.Status)
+	return fmt.Sprintf("/%s/%s/%s/%s/%s/%s/%s/%s/%s", spanInputs.Parent, spanInputs.Tracestate, spanInputs.Kind,
+		spanInputs.Attributes, spanInputs.Events, spanInputs.Links, spanInputs.Status, spanInputs.EventAttributes,
+		spanInputs.LinkTracestate)
 }
 
 // GenerateSpan generates a single OTLP Span based on the input values provided. They are:
@@ -121,9 +125,9 @@ func GenerateSpan(traceID data.TraceID, parentID data.SpanID, spanName string, s
 		EndTimeUnixNano:        uint64(endTime.UnixNano()),
 		Attributes:             generateSpanAttributes(spanInputs.Attributes, spanInputs.Status),
 		DroppedAttributesCount: 0,
-		Events:                 generateSpanEvents(spanInputs.Events),
+		Events:                 generateSpanEvents(spanInputs.Events, spanInputs.EventAttributes),
 		DroppedEventsCount:     0,
-		Links:                  generateSpanLinks(spanInputs.Links, random),
+		Links:                  generateSpanLinks(spanInputs.Links, spanInputs.LinkTracestate, random),
 		DroppedLinksCount:      0,
 		Status:                 generateStatus(spanInputs.Status),
 	}
@@ -435,26 +439,27 @@ func generateMaxCountAttributes(includeStatus bool) map[string]interface{} {
 	return attrMap
 }
 
-func generateSpanEvents(eventCnt PICTInputSpanChild) []*otlptrace.Span_Event {
+func generateSpanEvents(eventCnt PICTInputSpanChild, eventAttributes PICTInputEventAttributes) []*otlptrace.Span_Event {
 	if SpanChildCountNil == eventCnt {
 		return nil
 	}
 	listSize := calculateListSize(eventCnt)
 	eventList := make([]*otlptrace.Span_Event, listSize)
 	for i := 0; i < listSize; i++ {
-		eventList[i] = generateSpanEvent(i)
+		eventList[i] = generateSpanEvent(i, eventAttributes)
 	}
 	return eventList
 }
 
-func generateSpanLinks(linkCnt PICTInputSpanChild, random io.Reader) []*otlptrace.Span_Link {
+func generateSpanLinks(linkCnt PICTInputSpanChild, tracestate PICTInputTracestate,
+	random io.Reader) []*otlptrace.Span_Link {
 	if SpanChildCountNil == linkCnt {
 		return nil
 	}
 	listSize := calculateListSize(linkCnt)
 	linkList := make([]*otlptrace.Span_Link, listSize)
 	for i := 0; i < listSize; i++ {
-		linkList[i] = generateSpanLink(random, i)
+		linkList[i] = generateSpanLink(random, tracestate, i)
 	}
 	return linkList
 }
@@ -474,9 +479,9 @@ func calculateListSize(listCnt PICTInputSpanChild) int {
 	}
 }
 
-func generateSpanEvent(index int) *otlptrace.Span_Event {
+func generateSpanEvent(index int, eventAttributes PICTInputEventAttributes) *otlptrace.Span_Event {
 	t := time.Now().Add(-75 * time.Microsecond)
-	name, attributes := generateEventNameAndAttributes(index)
+	name, attributes := generateEventNameAndAttributes(index, eventAttributes)
 	return &otlptrace.Span_Event{
 		TimeUnixNano:           uint64(t.UnixNano()),
 		Name:                   name,
@@ -485,7 +490,35 @@ func generateSpanEvent(index int) *otlptrace.Span_Event {
 	}
 }
 
-func generateEventNameAndAttributes(index int) (string, []otlpcommon.KeyValue) {
+func generateEventNameAndAttributes(index int, eventAttributes PICTInputEventAttributes) (string, []otlpcommon.KeyValue) {
+	switch eventAttributes {
+	case EventAttrNil:
+		return "annotation", nil
+	case EventAttrEmpty:
+		return "annotation", []otlpcommon.KeyValue{}
+	case EventAttrString:
+		attrMap := make(map[string]interface{})
+		if index%2 == 0 {
+			attrMap[conventions.AttributeMessageType] = "SENT"
+		} else {
+			attrMap[conventions.AttributeMessageType] = "RECEIVED"
+		}
+		attrMap["app.statemap"] = "14|5|202"
+		return "message", convertMapToAttributeKeyValues(attrMap)
+	case EventAttrInt:
+		return "message", convertMapToAttributeKeyValues(map[string]interface{}{
+			conventions.AttributeMessageID:               int64(index),
+			conventions.AttributeMessageCompressedSize:   int64(17 * index),
+			conventions.AttributeMessageUncompressedSize: int64(24 * index)})
+	case EventAttrDouble:
+		return "custom", convertMapToAttributeKeyValues(map[string]interface{}{
+			"app.progress":  0.6,
+			"app.threshold": 0.95})
+	case EventAttrBool:
+		return "custom", convertMapToAttributeKeyValues(map[string]interface{}{
+			"app.inretry": true,
+			"app.cached":  index%2 == 0})
+	}
 	switch index % 4 {
 	case 0, 3:
 		attrMap := make(map[string]interface{})
@@ -508,11 +541,11 @@ func generateEventNameAndAttributes(index int) (string, []otlpcommon.KeyValue) {
 	}
 }
 
-func generateSpanLink(random io.Reader, index int) *otlptrace.Span_Link {
+func generateSpanLink(random io.Reader, tracestate PICTInputTracestate, index int) *otlptrace.Span_Link {
 	return &otlptrace.Span_Link{
 		TraceId:                generateTraceID(random),
 		SpanId:                 generateSpanID(random),
-		TraceState:             "",
+		TraceState:             generateTraceState(tracestate),
 		Attributes:             generateLinkAttributes(index),
 		DroppedAttributesCount: 0,
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/collector/internal/data"
+	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
 	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"
 )
 
@@ -63,6 +64,44 @@ func TestGenerateChildSpan(t *testing.T) {
 	assert.Equal(t, otlptrace.Status_STATUS_CODE_OK, span.Status.Code)
 }
 
+func TestGenerateSpanEventsAndLinks(t *testing.T) {
+	random := rand.Reader
+	spanInputs := &PICTSpanInputs{
+		Parent:          SpanParentChild,
+		Tracestate:      TraceStateEmpty,
+		Kind:            SpanKindConsumer,
+		Attributes:      SpanAttrMessagingConsumer,
+		Events:          SpanChildCountTwo,
+		Links:           SpanChildCountEight,
+		Status:          SpanStatusOk,
+		EventAttributes: EventAttrInt,
+		LinkTracestate:  TraceStateFour,
+	}
+	span := GenerateSpan(generateTraceID(random), generateSpanID(random), "process_test_event", spanInputs, random)
+	assert.Equal(t, 2, len(span.Events))
+	for _, event := range span.Events {
+		assert.Equal(t, 3, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			assert.IsType(t, &otlpcommon.AnyValue_IntValue{}, attr.Value.Value)
+		}
+	}
+	assert.Equal(t, 8, len(span.Links))
+	for _, link := range span.Links {
+		assert.Equal(t, generateTraceState(TraceStateFour), link.TraceState)
+	}
+
+	spanInputs.EventAttributes = EventAttrEmpty
+	spanInputs.LinkTracestate = TraceStateEmpty
+	span = GenerateSpan(generateTraceID(random), generateSpanID(random), "process_test_event", spanInputs, random)
+	for _, event := range span.Events {
+		assert.NotNil(t, event.Attributes)
+		assert.Empty(t, event.Attributes)
+	}
+	for _, link := range span.Links {
+		assert.Empty(t, link.TraceState)
+	}
+}
+
 func TestGenerateSpans(t *testing.T) {
 	random := rand.Reader
 	count1 := 16
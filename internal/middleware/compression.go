@@ -18,22 +18,45 @@ import (
 	"bytes"
 	"compress/gzip"
 	"compress/zlib"
+	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 const (
 	headerContentEncoding = "Content-Encoding"
 	headerValueGZIP       = "gzip"
+	headerValueZstd       = "zstd"
 )
 
 type CompressRoundTripper struct {
 	http.RoundTripper
+	encoding  string
+	newWriter func(w io.Writer) (io.WriteCloser, error)
 }
 
+// NewCompressRoundTripper returns a round tripper compressing the request bodies with gzip.
 func NewCompressRoundTripper(rt http.RoundTripper) *CompressRoundTripper {
 	return &CompressRoundTripper{
 		RoundTripper: rt,
+		encoding:     headerValueGZIP,
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+	}
+}
+
+// NewZstdCompressRoundTripper returns a round tripper compressing the request bodies with zstd.
+func NewZstdCompressRoundTripper(rt http.RoundTripper) *CompressRoundTripper {
+	return &CompressRoundTripper{
+		RoundTripper: rt,
+		encoding:     headerValueZstd,
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		},
 	}
 }
 
@@ -47,13 +70,16 @@ func (r *CompressRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 		return r.RoundTripper.RoundTrip(req)
 	}
 
-	// Gzip the body.
+	// Compress the body.
 	buf := bytes.NewBuffer([]byte{})
-	gzipWriter := gzip.NewWriter(buf)
-	_, copyErr := io.Copy(gzipWriter, req.Body)
+	compressWriter, err := r.newWriter(buf)
+	if err != nil {
+		return nil, err
+	}
+	_, copyErr := io.Copy(compressWriter, req.Body)
 	closeErr := req.Body.Close()
 
-	if err := gzipWriter.Close(); err != nil {
+	if err := compressWriter.Close(); err != nil {
 		return nil, err
 	}
 
@@ -65,23 +91,28 @@ func (r *CompressRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 	}
 
 	// Create a new request since the docs say that we cannot modify the "req"
-	// (see https://golang.org/pkg/net/http/#RoundTripper).
+	// (see https://golang.org/pkg/net/http/#RoundTripper). The body of the new
+	// request can be read again, for instance when the request is retried.
 	cReq, err := http.NewRequestWithContext(req.Context(), req.Method, req.URL.String(), buf)
 	if err != nil {
 		return nil, err
 	}
 
-	// Clone the headers and add gzip encoding header.
+	// Clone the headers and add the encoding header.
 	cReq.Header = req.Header.Clone()
-	cReq.Header.Add(headerContentEncoding, headerValueGZIP)
+	cReq.Header.Add(headerContentEncoding, r.encoding)
 
 	return r.RoundTripper.RoundTrip(cReq)
 }
 
 type ErrorHandler func(w http.ResponseWriter, r *http.Request, errorMsg string, statusCode int)
 
+// errRequestBodyTooLarge is returned when reading more than the maximum size of the request bodies.
+var errRequestBodyTooLarge = errors.New("request body too large")
+
 type decompressor struct {
-	errorHandler ErrorHandler
+	errorHandler       ErrorHandler
+	maxRequestBodySize int64
 }
 
 type DecompressorOption func(d *decompressor)
@@ -92,10 +123,18 @@ func WithErrorHandler(e ErrorHandler) DecompressorOption {
 	}
 }
 
+// WithMaxRequestBodySize limits the size, in bytes, of the request bodies both as received and once decompressed,
+// the requests exceeding it are refused with 413 Request Entity Too Large. Zero means no limit.
+func WithMaxRequestBodySize(size int64) DecompressorOption {
+	return func(d *decompressor) {
+		d.maxRequestBodySize = size
+	}
+}
+
 // HTTPContentDecompressor is a middleware that offloads the task of handling compressed
 // HTTP requests by identifying the compression format in the "Content-Encoding" header and re-writing
 // request body so that the handlers further in the chain can work on decompressed data.
-// It supports gzip and deflate/zlib compression.
+// It supports gzip, deflate/zlib and zstd compression.
 func HTTPContentDecompressor(h http.Handler, opts ...DecompressorOption) http.Handler {
 	d := &decompressor{}
 	for _, o := range opts {
@@ -109,9 +148,18 @@ func HTTPContentDecompressor(h http.Handler, opts ...DecompressorOption) http.Ha
 
 func (d *decompressor) wrap(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if d.maxRequestBodySize > 0 {
+			if r.ContentLength > d.maxRequestBodySize {
+				d.errorHandler(w, r, errRequestBodyTooLarge.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			// The body received is limited too so that the decompressors cannot read an unbounded amount of
+			// data, e.g. a gzip stream made of empty blocks.
+			r.Body = &limitedReadCloser{ReadCloser: r.Body, remaining: d.maxRequestBodySize}
+		}
 		newBody, err := newBodyReader(r)
 		if err != nil {
-			d.errorHandler(w, r, err.Error(), http.StatusBadRequest)
+			d.errorHandler(w, r, err.Error(), errorStatusCode(err))
 			return
 		}
 		if newBody != nil {
@@ -124,10 +172,54 @@ func (d *decompressor) wrap(h http.Handler) http.Handler {
 			r.ContentLength = -1
 			r.Body = newBody
 		}
+		if d.maxRequestBodySize > 0 {
+			// The body is read before calling the next handler to refuse the requests whose decompressed
+			// body is too large with the right status, the handlers reading it would report a bad request.
+			body, err := ioutil.ReadAll(&limitedReadCloser{ReadCloser: r.Body, remaining: d.maxRequestBodySize})
+			if err != nil {
+				d.errorHandler(w, r, err.Error(), errorStatusCode(err))
+				return
+			}
+			r.ContentLength = int64(len(body))
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
 		h.ServeHTTP(w, r)
 	})
 }
 
+// errorStatusCode returns the status code of the response to a request whose body could not be read.
+func errorStatusCode(err error) int {
+	if errors.Is(err, errRequestBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// limitedReadCloser returns errRequestBodyTooLarge when reading more than remaining bytes, unlike io.LimitReader
+// which returns io.EOF and would silently truncate the body.
+type limitedReadCloser struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Read one more byte to tell apart the bodies of exactly the maximum size from the larger ones.
+		var b [1]byte
+		n, err := l.ReadCloser.Read(b[:])
+		if n > 0 {
+			return 0, errRequestBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
 func newBodyReader(r *http.Request) (io.ReadCloser, error) {
 	switch r.Header.Get("Content-Encoding") {
 	case "gzip":
@@ -142,6 +234,12 @@ func newBodyReader(r *http.Request) (io.ReadCloser, error) {
 			return nil, err
 		}
 		return zr, nil
+	case "zstd":
+		zr, err := zstd.NewReader(r.Body, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
 	}
 	return nil, nil
 }
@@ -22,9 +22,11 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -34,6 +36,7 @@ import (
 func TestHTTPClientCompression(t *testing.T) {
 	testBody := []byte("uncompressed_text")
 	compressedBody, _ := compressGzip(testBody)
+	compressedZstdBody, _ := compressZstd(testBody)
 
 	tests := []struct {
 		name     string
@@ -50,6 +53,11 @@ func TestHTTPClientCompression(t *testing.T) {
 			encoding: "gzip",
 			reqBody:  compressedBody.Bytes(),
 		},
+		{
+			name:     "ValidZstd",
+			encoding: "zstd",
+			reqBody:  compressedZstdBody.Bytes(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -79,8 +87,11 @@ func TestHTTPClientCompression(t *testing.T) {
 			require.NoError(t, err, "failed to create request to test handler")
 
 			client := http.Client{}
-			if tt.encoding == "gzip" {
+			switch tt.encoding {
+			case "gzip":
 				client.Transport = NewCompressRoundTripper(http.DefaultTransport)
+			case "zstd":
+				client.Transport = NewZstdCompressRoundTripper(http.DefaultTransport)
 			}
 			res, err := client.Do(req)
 			require.NoError(t, err)
@@ -92,6 +103,93 @@ func TestHTTPClientCompression(t *testing.T) {
 	}
 }
 
+func TestHTTPContentDecompressionMaxRequestBodySize(t *testing.T) {
+	testBody := []byte("uncompressed_text")
+	largeBody := bytes.Repeat([]byte("a"), 1024*1024)
+	tests := []struct {
+		name        string
+		encoding    string
+		chunked     bool
+		reqBodyFunc func() (*bytes.Buffer, error)
+		respCode    int
+	}{
+		{
+			name: "NoCompression",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return bytes.NewBuffer(testBody), nil
+			},
+			respCode: 200,
+		},
+		{
+			name: "NoCompressionTooLarge",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return bytes.NewBuffer(largeBody), nil
+			},
+			respCode: 413,
+		},
+		{
+			name:    "ChunkedTooLarge",
+			chunked: true,
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return bytes.NewBuffer(largeBody), nil
+			},
+			respCode: 413,
+		},
+		{
+			name:     "ValidGzip",
+			encoding: "gzip",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressGzip(testBody)
+			},
+			respCode: 200,
+		},
+		{
+			name:     "GzipDecompressedTooLarge",
+			encoding: "gzip",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressGzip(largeBody)
+			},
+			respCode: 413,
+		},
+		{
+			name:     "ZstdDecompressedTooLarge",
+			encoding: "zstd",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressZstd(largeBody)
+			},
+			respCode: 413,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err, "failed to read request body: %v", err)
+				assert.EqualValues(t, testBody, body)
+				w.WriteHeader(200)
+			})
+			reqBody, err := tt.reqBodyFunc()
+			require.NoError(t, err, "failed to generate request body: %v", err)
+			if tt.encoding != "" {
+				// The compressed bodies are smaller than the limit, only their decompressed size may exceed it.
+				require.Less(t, reqBody.Len(), 64*1024)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", reqBody)
+			req.Header.Set("Content-Encoding", tt.encoding)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			HTTPContentDecompressor(handler, WithMaxRequestBodySize(64*1024)).ServeHTTP(rec, req)
+			assert.Equal(t, tt.respCode, rec.Code)
+			if tt.respCode == 413 {
+				assert.Equal(t, "request body too large\n", rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestHTTPContentDecompressionHandler(t *testing.T) {
 	testBody := []byte("uncompressed_text")
 	tests := []struct {
@@ -125,6 +223,14 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			},
 			respCode: 200,
 		},
+		{
+			name:     "ValidZstd",
+			encoding: "zstd",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressZstd(testBody)
+			},
+			respCode: 200,
+		},
 		{
 			name:     "InvalidGzip",
 			encoding: "gzip",
@@ -216,3 +322,20 @@ func compressZlib(body []byte) (*bytes.Buffer, error) {
 
 	return &buf, nil
 }
+
+func compressZstd(body []byte) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+
+	zw, err := zstd.NewWriter(&buf, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	defer zw.Close()
+
+	_, err = zw.Write(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
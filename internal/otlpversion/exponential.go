@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpversion
+
+import (
+	"math"
+
+	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
+)
+
+// ExponentialHistogram is the exponential histogram of the newer versions of OTLP, which the generated protos do not
+// have. It backs the pdata.ExponentialHistogram.
+type ExponentialHistogram struct {
+	DataPoints             []*ExponentialHistogramDataPoint
+	AggregationTemporality otlpmetrics.AggregationTemporality
+}
+
+// ExponentialHistogramDataPoint is a data point of the ExponentialHistogram, its attributes being held as labels.
+type ExponentialHistogramDataPoint struct {
+	Labels            []otlpcommon.StringKeyValue
+	StartTimeUnixNano uint64
+	TimeUnixNano      uint64
+	Count             uint64
+	Sum               float64
+	Scale             int32
+	ZeroCount         uint64
+	Positive          ExponentialHistogramBuckets
+	Negative          ExponentialHistogramBuckets
+	Exemplars         []otlpmetrics.DoubleExemplar
+}
+
+// ExponentialHistogramBuckets are the consecutive buckets of an ExponentialHistogramDataPoint, the bucket of index i
+// counting the values in (base^(Offset+i), base^(Offset+i+1)], base being 2^(2^-scale).
+type ExponentialHistogramBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+// ExplicitBuckets returns the bucket counts and the explicit bounds of the double histogram data point equivalent to
+// the exponential one. The explicit bounds are the bounds of the negative buckets, of the zero bucket, and of the
+// positive buckets, the bucket below the lowest positive bucket and the bucket above the highest one being empty.
+func ExplicitBuckets(scale int32, zeroCount uint64, positive, negative ExponentialHistogramBuckets) ([]uint64, []float64) {
+	base := math.Exp2(math.Exp2(-float64(scale)))
+	counts := make([]uint64, 0, len(negative.BucketCounts)+len(positive.BucketCounts)+3)
+	bounds := make([]float64, 0, len(negative.BucketCounts)+len(positive.BucketCounts)+2)
+	for i := len(negative.BucketCounts) - 1; i >= 0; i-- {
+		bounds = append(bounds, -math.Pow(base, float64(negative.Offset+int32(i))))
+		counts = append(counts, negative.BucketCounts[i])
+	}
+	bounds = append(bounds, 0)
+	counts = append(counts, zeroCount)
+	if len(positive.BucketCounts) > 0 {
+		bounds = append(bounds, math.Pow(base, float64(positive.Offset)))
+		counts = append(counts, 0)
+		for i, count := range positive.BucketCounts {
+			bounds = append(bounds, math.Pow(base, float64(positive.Offset+int32(i)+1)))
+			counts = append(counts, count)
+		}
+	}
+	counts = append(counts, 0)
+	return counts, bounds
+}
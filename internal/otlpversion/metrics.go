@@ -0,0 +1,469 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpversion down-converts the payloads of newer versions of OTLP, from v0.9.0 on, to the version of the
+// generated protos, so that they can be unmarshaled into the internal data types. The payloads of the version of the
+// generated protos are left unchanged.
+package otlpversion
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	otlpcollectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
+)
+
+var errMalformed = errors.New("malformed OTLP payload")
+
+// The field numbers of the messages of the generated protos, and of the messages of the newer versions that differ.
+const (
+	requestResourceMetrics = 1
+	resourceMetricsILM     = 2
+	ilmMetrics             = 2
+
+	metricIntGauge             = 4
+	metricGauge                = 5
+	metricIntSum               = 6
+	metricSum                  = 7
+	metricHistogram            = 9
+	metricExponentialHistogram = 10
+	metricSummary              = 11
+
+	dataPoints             = 1
+	aggregationTemporality = 2
+
+	numberLabels     = 1
+	numberAsDouble   = 4
+	numberExemplars  = 5
+	numberAsInt      = 6
+	numberAttributes = 7
+
+	histogramLabels         = 1
+	histogramStartTime      = 2
+	histogramTime           = 3
+	histogramCount          = 4
+	histogramSum            = 5
+	histogramBucketCounts   = 6
+	histogramExplicitBounds = 7
+	histogramExemplars      = 8
+	histogramAttributes     = 9
+
+	summaryLabels     = 1
+	summaryAttributes = 7
+
+	exemplarLabels     = 1
+	exemplarAsDouble   = 3
+	exemplarAsInt      = 6
+	exemplarAttributes = 7
+
+	exponentialAttributes = 1
+	exponentialStartTime  = 2
+	exponentialTime       = 3
+	exponentialCount      = 4
+	exponentialSum        = 5
+	exponentialScale      = 6
+	exponentialZeroCount  = 7
+	exponentialPositive   = 8
+	exponentialNegative   = 9
+	exponentialExemplars  = 11
+
+	bucketsOffset = 1
+	bucketsCounts = 2
+
+	stringKeyValueKey   = 1
+	stringKeyValueValue = 2
+)
+
+// UnmarshalMetrics down-converts the ExportMetricsServiceRequest payload and unmarshals it into the request.
+func UnmarshalMetrics(buf []byte, req *otlpcollectormetrics.ExportMetricsServiceRequest) error {
+	downgraded, err := DowngradeMetrics(buf)
+	if err != nil {
+		// Return the error of the generated protos if they cannot unmarshal the payload either.
+		if uerr := req.Unmarshal(buf); uerr != nil {
+			return uerr
+		}
+		return err
+	}
+	return req.Unmarshal(downgraded)
+}
+
+// DowngradeMetrics down-converts the ExportMetricsServiceRequest payload to the version of the generated protos:
+//   - the gauges and sums of NumberDataPoint are converted to the int gauges and sums if all their data points have
+//     int values, to the double gauges and sums otherwise, the int values being converted to doubles;
+//   - the exponential histograms are converted to the double histograms with the explicit bounds of their buckets;
+//   - the attributes of the data points and exemplars are converted to labels, the labels having precedence.
+func DowngradeMetrics(buf []byte) ([]byte, error) {
+	return rewriteFields(buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		if num != requestResourceMetrics || typ != protowire.BytesType {
+			return dst, false, nil
+		}
+		return appendMessage(dst, num, value, downgradeResourceMetrics)
+	})
+}
+
+func downgradeResourceMetrics(dst, buf []byte) ([]byte, error) {
+	return appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		if num != resourceMetricsILM || typ != protowire.BytesType {
+			return dst, false, nil
+		}
+		return appendMessage(dst, num, value, downgradeILM)
+	})
+}
+
+func downgradeILM(dst, buf []byte) ([]byte, error) {
+	return appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		if num != ilmMetrics || typ != protowire.BytesType {
+			return dst, false, nil
+		}
+		return appendMessage(dst, num, value, downgradeMetric)
+	})
+}
+
+func downgradeMetric(dst, buf []byte) ([]byte, error) {
+	return appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		if typ != protowire.BytesType {
+			return dst, false, nil
+		}
+		switch num {
+		case metricGauge, metricSum:
+			allInt, err := hasOnlyIntDataPoints(value)
+			if err != nil {
+				return nil, false, err
+			}
+			if allInt {
+				num--
+			}
+			return appendMessage(dst, num, value, func(dst, buf []byte) ([]byte, error) {
+				return downgradeDataPoints(dst, buf, func(dst, buf []byte) ([]byte, error) {
+					return downgradeNumberDataPoint(dst, buf, allInt)
+				})
+			})
+		case metricHistogram:
+			return appendMessage(dst, num, value, func(dst, buf []byte) ([]byte, error) {
+				return downgradeDataPoints(dst, buf, downgradeHistogramDataPoint)
+			})
+		case metricSummary:
+			return appendMessage(dst, num, value, func(dst, buf []byte) ([]byte, error) {
+				return downgradeDataPoints(dst, buf, downgradeSummaryDataPoint)
+			})
+		case metricExponentialHistogram:
+			return appendMessage(dst, metricHistogram, value, func(dst, buf []byte) ([]byte, error) {
+				return downgradeDataPoints(dst, buf, downgradeExponentialHistogramDataPoint)
+			})
+		}
+		return dst, false, nil
+	})
+}
+
+// hasOnlyIntDataPoints returns whether the gauge or sum has data points, all of them having an int value.
+func hasOnlyIntDataPoints(buf []byte) (bool, error) {
+	hasInt, hasDouble := false, false
+	err := forEachField(buf, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != dataPoints || typ != protowire.BytesType {
+			return nil
+		}
+		return forEachField(value, func(num protowire.Number, _ protowire.Type, _ []byte) error {
+			switch num {
+			case numberAsInt:
+				hasInt = true
+			case numberAsDouble:
+				hasDouble = true
+			}
+			return nil
+		})
+	})
+	return hasInt && !hasDouble, err
+}
+
+func downgradeDataPoints(dst, buf []byte, downgradeDataPoint func(dst, buf []byte) ([]byte, error)) ([]byte, error) {
+	return appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		if num != dataPoints || typ != protowire.BytesType {
+			return dst, false, nil
+		}
+		return appendMessage(dst, num, value, downgradeDataPoint)
+	})
+}
+
+func downgradeNumberDataPoint(dst, buf []byte, allInt bool) ([]byte, error) {
+	labels := newLabels()
+	dst, err := appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		switch {
+		case num == numberLabels && typ == protowire.BytesType:
+			return dst, false, labels.addLabel(value)
+		case num == numberAttributes && typ == protowire.BytesType:
+			return dst, true, labels.addAttribute(value)
+		case num == numberAsInt && typ == protowire.Fixed64Type:
+			if !allInt {
+				v, _ := protowire.ConsumeFixed64(value)
+				dst = protowire.AppendTag(dst, numberAsDouble, protowire.Fixed64Type)
+				dst = protowire.AppendFixed64(dst, math.Float64bits(float64(int64(v))))
+				return dst, true, nil
+			}
+			dst = protowire.AppendTag(dst, numberAsDouble, protowire.Fixed64Type)
+			return append(dst, value...), true, nil
+		case num == numberExemplars && typ == protowire.BytesType:
+			return appendMessage(dst, num, value, func(dst, buf []byte) ([]byte, error) {
+				return downgradeExemplar(dst, buf, allInt)
+			})
+		}
+		return dst, false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels.appendAttributes(dst, numberLabels), nil
+}
+
+func downgradeHistogramDataPoint(dst, buf []byte) ([]byte, error) {
+	labels := newLabels()
+	dst, err := appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		if typ != protowire.BytesType {
+			return dst, false, nil
+		}
+		switch num {
+		case histogramLabels:
+			return dst, false, labels.addLabel(value)
+		case histogramAttributes:
+			return dst, true, labels.addAttribute(value)
+		case histogramExemplars:
+			return appendMessage(dst, num, value, func(dst, buf []byte) ([]byte, error) {
+				return downgradeExemplar(dst, buf, false)
+			})
+		}
+		return dst, false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels.appendAttributes(dst, histogramLabels), nil
+}
+
+func downgradeSummaryDataPoint(dst, buf []byte) ([]byte, error) {
+	labels := newLabels()
+	dst, err := appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		if typ != protowire.BytesType {
+			return dst, false, nil
+		}
+		switch num {
+		case summaryLabels:
+			return dst, false, labels.addLabel(value)
+		case summaryAttributes:
+			return dst, true, labels.addAttribute(value)
+		}
+		return dst, false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels.appendAttributes(dst, summaryLabels), nil
+}
+
+func downgradeExemplar(dst, buf []byte, allInt bool) ([]byte, error) {
+	labels := newLabels()
+	dst, err := appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		switch {
+		case num == exemplarLabels && typ == protowire.BytesType:
+			return dst, false, labels.addLabel(value)
+		case num == exemplarAttributes && typ == protowire.BytesType:
+			return dst, true, labels.addAttribute(value)
+		case num == exemplarAsDouble && typ == protowire.Fixed64Type && allInt:
+			v, _ := protowire.ConsumeFixed64(value)
+			dst = protowire.AppendTag(dst, exemplarAsDouble, protowire.Fixed64Type)
+			return protowire.AppendFixed64(dst, uint64(int64(math.Float64frombits(v)))), true, nil
+		case num == exemplarAsInt && typ == protowire.Fixed64Type:
+			v, _ := protowire.ConsumeFixed64(value)
+			if !allInt {
+				v = math.Float64bits(float64(int64(v)))
+			}
+			dst = protowire.AppendTag(dst, exemplarAsDouble, protowire.Fixed64Type)
+			return protowire.AppendFixed64(dst, v), true, nil
+		}
+		return dst, false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labels.appendAttributes(dst, exemplarLabels), nil
+}
+
+// downgradeExponentialHistogramDataPoint converts the ExponentialHistogramDataPoint to a DoubleHistogramDataPoint.
+// The bucket counts and explicit bounds are the ones of ExplicitBuckets.
+func downgradeExponentialHistogramDataPoint(dst, buf []byte) ([]byte, error) {
+	labels := newLabels()
+	var scale int32
+	var zeroCount uint64
+	var positive, negative ExponentialHistogramBuckets
+	dst, err := appendRewrittenFields(dst, buf, func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error) {
+		var err error
+		switch num {
+		case exponentialAttributes:
+			err = labels.addAttribute(value)
+		case exponentialStartTime, exponentialTime, exponentialCount, exponentialSum:
+			// Same field numbers and types in the DoubleHistogramDataPoint.
+			return dst, false, nil
+		case exponentialScale:
+			v, _ := protowire.ConsumeVarint(value)
+			scale = int32(protowire.DecodeZigZag(v))
+		case exponentialZeroCount:
+			zeroCount, _ = protowire.ConsumeFixed64(value)
+		case exponentialPositive:
+			positive, err = parseBuckets(value)
+		case exponentialNegative:
+			negative, err = parseBuckets(value)
+		case exponentialExemplars:
+			return appendMessage(dst, histogramExemplars, value, func(dst, buf []byte) ([]byte, error) {
+				return downgradeExemplar(dst, buf, false)
+			})
+		}
+		return dst, true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts, bounds := ExplicitBuckets(scale, zeroCount, positive, negative)
+
+	var packed []byte
+	for _, count := range counts {
+		packed = protowire.AppendFixed64(packed, count)
+	}
+	dst = protowire.AppendTag(dst, histogramBucketCounts, protowire.BytesType)
+	dst = protowire.AppendBytes(dst, packed)
+	packed = packed[:0]
+	for _, bound := range bounds {
+		packed = protowire.AppendFixed64(packed, math.Float64bits(bound))
+	}
+	dst = protowire.AppendTag(dst, histogramExplicitBounds, protowire.BytesType)
+	dst = protowire.AppendBytes(dst, packed)
+	return labels.appendAttributes(dst, histogramLabels), nil
+}
+
+func parseBuckets(buf []byte) (ExponentialHistogramBuckets, error) {
+	var b ExponentialHistogramBuckets
+	err := forEachField(buf, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch {
+		case num == bucketsOffset && typ == protowire.VarintType:
+			v, _ := protowire.ConsumeVarint(value)
+			b.Offset = int32(protowire.DecodeZigZag(v))
+		case num == bucketsCounts && typ == protowire.VarintType:
+			v, _ := protowire.ConsumeVarint(value)
+			b.BucketCounts = append(b.BucketCounts, v)
+		case num == bucketsCounts && typ == protowire.BytesType:
+			for len(value) > 0 {
+				v, n := protowire.ConsumeVarint(value)
+				if n < 0 {
+					return errMalformed
+				}
+				b.BucketCounts = append(b.BucketCounts, v)
+				value = value[n:]
+			}
+		}
+		return nil
+	})
+	return b, err
+}
+
+// labels collects the labels of a data point or exemplar, and its attributes to be converted to labels.
+type labels struct {
+	keys       map[string]struct{}
+	attributes []otlpcommon.StringKeyValue
+}
+
+func newLabels() *labels {
+	return &labels{keys: map[string]struct{}{}}
+}
+
+func (l *labels) addLabel(buf []byte) error {
+	var label otlpcommon.StringKeyValue
+	if err := label.Unmarshal(buf); err != nil {
+		return err
+	}
+	l.keys[label.Key] = struct{}{}
+	return nil
+}
+
+func (l *labels) addAttribute(buf []byte) error {
+	var attribute otlpcommon.KeyValue
+	if err := attribute.Unmarshal(buf); err != nil {
+		return err
+	}
+	l.attributes = append(l.attributes, otlpcommon.StringKeyValue{Key: attribute.Key, Value: anyValueToString(attribute.Value)})
+	return nil
+}
+
+// appendAttributes appends the attributes whose keys are not the keys of labels as labels.
+func (l *labels) appendAttributes(dst []byte, num protowire.Number) []byte {
+	for _, attribute := range l.attributes {
+		if _, ok := l.keys[attribute.Key]; ok {
+			continue
+		}
+		l.keys[attribute.Key] = struct{}{}
+		var label []byte
+		label = protowire.AppendTag(label, stringKeyValueKey, protowire.BytesType)
+		label = protowire.AppendString(label, attribute.Key)
+		label = protowire.AppendTag(label, stringKeyValueValue, protowire.BytesType)
+		label = protowire.AppendString(label, attribute.Value)
+		dst = protowire.AppendTag(dst, num, protowire.BytesType)
+		dst = protowire.AppendBytes(dst, label)
+	}
+	return dst
+}
+
+func anyValueToString(v otlpcommon.AnyValue) string {
+	switch v.Value.(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		return v.GetStringValue()
+	case *otlpcommon.AnyValue_BoolValue:
+		return strconv.FormatBool(v.GetBoolValue())
+	case *otlpcommon.AnyValue_IntValue:
+		return strconv.FormatInt(v.GetIntValue(), 10)
+	case *otlpcommon.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.GetDoubleValue(), 'f', -1, 64)
+	case nil:
+		return ""
+	}
+	jsonStr, _ := json.Marshal(anyValueToRaw(v))
+	return string(jsonStr)
+}
+
+func anyValueToRaw(v otlpcommon.AnyValue) interface{} {
+	switch v.Value.(type) {
+	case *otlpcommon.AnyValue_StringValue:
+		return v.GetStringValue()
+	case *otlpcommon.AnyValue_BoolValue:
+		return v.GetBoolValue()
+	case *otlpcommon.AnyValue_IntValue:
+		return v.GetIntValue()
+	case *otlpcommon.AnyValue_DoubleValue:
+		return v.GetDoubleValue()
+	case *otlpcommon.AnyValue_ArrayValue:
+		values := make([]interface{}, 0, len(v.GetArrayValue().GetValues()))
+		for _, value := range v.GetArrayValue().GetValues() {
+			values = append(values, anyValueToRaw(value))
+		}
+		return values
+	case *otlpcommon.AnyValue_KvlistValue:
+		values := make(map[string]interface{}, len(v.GetKvlistValue().GetValues()))
+		for _, kv := range v.GetKvlistValue().GetValues() {
+			values[kv.Key] = anyValueToRaw(kv.Value)
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpversion
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	otlpcollectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/protogen/metrics/v1"
+)
+
+func message(num protowire.Number, fields ...[]byte) []byte {
+	buf := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, bytes.Join(fields, nil))
+}
+
+func fixed64(num protowire.Number, v uint64) []byte {
+	buf := protowire.AppendTag(nil, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(buf, v)
+}
+
+func varint(num protowire.Number, v uint64) []byte {
+	buf := protowire.AppendTag(nil, num, protowire.VarintType)
+	return protowire.AppendVarint(buf, v)
+}
+
+func str(num protowire.Number, v string) []byte {
+	buf := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendString(buf, v)
+}
+
+func stringAttribute(num protowire.Number, key, value string) []byte {
+	return message(num, str(1, key), message(2, str(1, value)))
+}
+
+func intAttribute(num protowire.Number, key string, value int64) []byte {
+	return message(num, str(1, key), message(2, varint(3, uint64(value))))
+}
+
+func label(num protowire.Number, key, value string) []byte {
+	return message(num, str(1, key), str(2, value))
+}
+
+func request(metrics ...[]byte) []byte {
+	var ilm [][]byte
+	for _, metric := range metrics {
+		ilm = append(ilm, message(ilmMetrics, metric))
+	}
+	return message(requestResourceMetrics, message(resourceMetricsILM, ilm...))
+}
+
+func unmarshalMetrics(t *testing.T, buf []byte) []*otlpmetrics.Metric {
+	req := &otlpcollectormetrics.ExportMetricsServiceRequest{}
+	require.NoError(t, UnmarshalMetrics(buf, req))
+	require.Len(t, req.ResourceMetrics, 1)
+	require.Len(t, req.ResourceMetrics[0].InstrumentationLibraryMetrics, 1)
+	return req.ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics
+}
+
+func TestDowngradeMetrics_IntGauge(t *testing.T) {
+	minusThree := int64(-3)
+	metrics := unmarshalMetrics(t, request(
+		append(str(1, "gauge"), message(metricGauge, message(dataPoints,
+			stringAttribute(numberAttributes, "k", "v"),
+			fixed64(2, 1),
+			fixed64(3, 2),
+			fixed64(numberAsInt, uint64(minusThree)),
+			message(numberExemplars, fixed64(exemplarAsInt, 4), stringAttribute(exemplarAttributes, "e", "f")),
+		))...),
+	))
+
+	require.Len(t, metrics, 1)
+	assert.Equal(t, "gauge", metrics[0].Name)
+	assert.Equal(t, &otlpmetrics.IntGauge{
+		DataPoints: []*otlpmetrics.IntDataPoint{{
+			Labels:            []otlpcommon.StringKeyValue{{Key: "k", Value: "v"}},
+			StartTimeUnixNano: 1,
+			TimeUnixNano:      2,
+			Value:             -3,
+			Exemplars: []otlpmetrics.IntExemplar{{
+				FilteredLabels: []otlpcommon.StringKeyValue{{Key: "e", Value: "f"}},
+				Value:          4,
+			}},
+		}},
+	}, metrics[0].GetIntGauge())
+}
+
+func TestDowngradeMetrics_DoubleSum(t *testing.T) {
+	metrics := unmarshalMetrics(t, request(
+		append(str(1, "sum"), message(metricSum,
+			message(dataPoints,
+				label(numberLabels, "a", "b"),
+				stringAttribute(numberAttributes, "a", "x"),
+				intAttribute(numberAttributes, "c", 1),
+				fixed64(numberAsInt, 1),
+			),
+			message(dataPoints, fixed64(numberAsDouble, math.Float64bits(2.5))),
+			varint(aggregationTemporality, uint64(otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE)),
+			varint(3, 1),
+		)...),
+	))
+
+	require.Len(t, metrics, 1)
+	assert.Equal(t, &otlpmetrics.DoubleSum{
+		DataPoints: []*otlpmetrics.DoubleDataPoint{
+			{
+				Labels: []otlpcommon.StringKeyValue{{Key: "a", Value: "b"}, {Key: "c", Value: "1"}},
+				Value:  1,
+			},
+			{
+				Value: 2.5,
+			},
+		},
+		AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		IsMonotonic:            true,
+	}, metrics[0].GetDoubleSum())
+}
+
+func TestDowngradeMetrics_HistogramAndSummary(t *testing.T) {
+	metrics := unmarshalMetrics(t, request(
+		message(metricHistogram, message(dataPoints,
+			stringAttribute(histogramAttributes, "k", "v"),
+			fixed64(histogramCount, 3),
+			message(histogramExemplars, fixed64(exemplarAsInt, 7)),
+		)),
+		message(metricSummary, message(dataPoints,
+			stringAttribute(summaryAttributes, "k", "v"),
+			fixed64(4, 3),
+		)),
+	))
+
+	require.Len(t, metrics, 2)
+	assert.Equal(t, &otlpmetrics.DoubleHistogram{
+		DataPoints: []*otlpmetrics.DoubleHistogramDataPoint{{
+			Labels:    []otlpcommon.StringKeyValue{{Key: "k", Value: "v"}},
+			Count:     3,
+			Exemplars: []otlpmetrics.DoubleExemplar{{Value: 7}},
+		}},
+	}, metrics[0].GetDoubleHistogram())
+	assert.Equal(t, &otlpmetrics.DoubleSummary{
+		DataPoints: []*otlpmetrics.DoubleSummaryDataPoint{{
+			Labels: []otlpcommon.StringKeyValue{{Key: "k", Value: "v"}},
+			Count:  3,
+		}},
+	}, metrics[1].GetDoubleSummary())
+}
+
+func TestDowngradeMetrics_ExponentialHistogram(t *testing.T) {
+	metrics := unmarshalMetrics(t, request(
+		message(metricExponentialHistogram,
+			message(dataPoints,
+				stringAttribute(exponentialAttributes, "k", "v"),
+				fixed64(exponentialCount, 10),
+				fixed64(exponentialSum, math.Float64bits(12.5)),
+				varint(exponentialScale, protowire.EncodeZigZag(0)),
+				fixed64(exponentialZeroCount, 1),
+				message(exponentialPositive, varint(bucketsOffset, protowire.EncodeZigZag(1)), message(bucketsCounts, protowire.AppendVarint([]byte{2}, 3))),
+				message(exponentialNegative, varint(bucketsCounts, 4)),
+				varint(10, 1),
+			),
+			varint(aggregationTemporality, uint64(otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA)),
+		),
+	))
+
+	require.Len(t, metrics, 1)
+	assert.Equal(t, &otlpmetrics.DoubleHistogram{
+		DataPoints: []*otlpmetrics.DoubleHistogramDataPoint{{
+			Labels:         []otlpcommon.StringKeyValue{{Key: "k", Value: "v"}},
+			Count:          10,
+			Sum:            12.5,
+			BucketCounts:   []uint64{4, 1, 0, 2, 3, 0},
+			ExplicitBounds: []float64{-1, 0, 2, 4, 8},
+		}},
+		AggregationTemporality: otlpmetrics.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+	}, metrics[0].GetDoubleHistogram())
+}
+
+func TestDowngradeMetrics_Unchanged(t *testing.T) {
+	labels := []otlpcommon.StringKeyValue{{Key: "k", Value: "v"}}
+	req := &otlpcollectormetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlpmetrics.ResourceMetrics{{
+			InstrumentationLibraryMetrics: []*otlpmetrics.InstrumentationLibraryMetrics{{
+				Metrics: []*otlpmetrics.Metric{
+					{
+						Name: "int_gauge",
+						Data: &otlpmetrics.Metric_IntGauge{IntGauge: &otlpmetrics.IntGauge{
+							DataPoints: []*otlpmetrics.IntDataPoint{{Labels: labels, Value: 1, Exemplars: []otlpmetrics.IntExemplar{{Value: 2}}}},
+						}},
+					},
+					{
+						Name: "double_gauge",
+						Data: &otlpmetrics.Metric_DoubleGauge{DoubleGauge: &otlpmetrics.DoubleGauge{
+							DataPoints: []*otlpmetrics.DoubleDataPoint{{Labels: labels, Value: 1.5, Exemplars: []otlpmetrics.DoubleExemplar{{Value: 2.5}}}},
+						}},
+					},
+					{
+						Name: "int_sum",
+						Data: &otlpmetrics.Metric_IntSum{IntSum: &otlpmetrics.IntSum{
+							DataPoints:  []*otlpmetrics.IntDataPoint{{Labels: labels, Value: 1}},
+							IsMonotonic: true,
+						}},
+					},
+					{
+						Name: "double_sum",
+						Data: &otlpmetrics.Metric_DoubleSum{DoubleSum: &otlpmetrics.DoubleSum{
+							DataPoints: []*otlpmetrics.DoubleDataPoint{{Labels: labels, Value: 1.5}},
+						}},
+					},
+					{
+						Name: "int_histogram",
+						Data: &otlpmetrics.Metric_IntHistogram{IntHistogram: &otlpmetrics.IntHistogram{
+							DataPoints: []*otlpmetrics.IntHistogramDataPoint{{Labels: labels, Count: 1, BucketCounts: []uint64{1}}},
+						}},
+					},
+					{
+						Name: "double_histogram",
+						Data: &otlpmetrics.Metric_DoubleHistogram{DoubleHistogram: &otlpmetrics.DoubleHistogram{
+							DataPoints: []*otlpmetrics.DoubleHistogramDataPoint{{Labels: labels, Count: 1, BucketCounts: []uint64{0, 1}, ExplicitBounds: []float64{1}}},
+						}},
+					},
+					{
+						Name: "double_summary",
+						Data: &otlpmetrics.Metric_DoubleSummary{DoubleSummary: &otlpmetrics.DoubleSummary{
+							DataPoints: []*otlpmetrics.DoubleSummaryDataPoint{{Labels: labels, Count: 1}},
+						}},
+					},
+				},
+			}},
+		}},
+	}
+	buf, err := req.Marshal()
+	require.NoError(t, err)
+
+	downgraded, err := DowngradeMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, buf, downgraded)
+}
+
+func TestDowngradeMetrics_Malformed(t *testing.T) {
+	_, err := DowngradeMetrics([]byte{0x0a, 0x05, 0x12})
+	assert.Error(t, err)
+
+	err = UnmarshalMetrics(request(message(metricGauge, message(dataPoints, []byte{0x3a, 0x02, 0xff, 0xff}))), &otlpcollectormetrics.ExportMetricsServiceRequest{})
+	assert.Error(t, err)
+}
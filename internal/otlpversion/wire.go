@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpversion
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// rewriteFunc appends the rewritten field to dst and returns true, or returns false to keep the field unchanged.
+// The value is the content of the length-delimited fields, and the encoded value of the other fields.
+type rewriteFunc func(dst []byte, num protowire.Number, typ protowire.Type, value []byte) ([]byte, bool, error)
+
+// forEachField calls fn with each field of the message.
+func forEachField(buf []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return errMalformed
+		}
+		buf = buf[n:]
+		m := protowire.ConsumeFieldValue(num, typ, buf)
+		if m < 0 {
+			return errMalformed
+		}
+		value := buf[:m]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		if err := fn(num, typ, value); err != nil {
+			return err
+		}
+		buf = buf[m:]
+	}
+	return nil
+}
+
+// rewriteFields returns the message with its fields rewritten by fn.
+func rewriteFields(buf []byte, fn rewriteFunc) ([]byte, error) {
+	return appendRewrittenFields(make([]byte, 0, len(buf)), buf, fn)
+}
+
+// appendRewrittenFields appends the fields of the message rewritten by fn to dst.
+func appendRewrittenFields(dst, buf []byte, fn rewriteFunc) ([]byte, error) {
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return nil, errMalformed
+		}
+		m := protowire.ConsumeFieldValue(num, typ, buf[n:])
+		if m < 0 {
+			return nil, errMalformed
+		}
+		field := buf[:n+m]
+		value := buf[n : n+m]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		var rewritten bool
+		var err error
+		if dst, rewritten, err = fn(dst, num, typ, value); err != nil {
+			return nil, err
+		}
+		if !rewritten {
+			dst = append(dst, field...)
+		}
+		buf = buf[n+m:]
+	}
+	return dst, nil
+}
+
+// appendMessage appends the length-delimited field of the message rewritten by fn to dst.
+func appendMessage(dst []byte, num protowire.Number, buf []byte, fn func(dst, buf []byte) ([]byte, error)) ([]byte, bool, error) {
+	msg, err := fn(nil, buf)
+	if err != nil {
+		return nil, false, err
+	}
+	dst = protowire.AppendTag(dst, num, protowire.BytesType)
+	return protowire.AppendBytes(dst, msg), true, nil
+}
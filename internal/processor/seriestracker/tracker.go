@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package seriestracker tracks the state of the metric series across the batches of metrics, e.g. to convert their
+// aggregation temporality.
+package seriestracker
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// Series is the tracked state of a series. The fields used depend on the data type of the metric: IntValue for the
+// IntSum, DoubleValue for the DoubleSum, Count, BucketCounts, ExplicitBounds and IntSum or DoubleSum for the
+// histograms.
+type Series struct {
+	StartTime      pdata.Timestamp
+	Timestamp      pdata.Timestamp
+	IntValue       int64
+	DoubleValue    float64
+	Count          uint64
+	IntSum         int64
+	DoubleSum      float64
+	BucketCounts   []uint64
+	ExplicitBounds []float64
+
+	lastSeen time.Time
+}
+
+// Tracker keeps the state of at most maxSeries series, the series not seen for maxStaleness are evicted.
+// It is not safe for concurrent use.
+type Tracker struct {
+	maxSeries    int
+	maxStaleness time.Duration
+	series       map[string]*Series
+	lastSweep    time.Time
+}
+
+// New returns a Tracker of at most maxSeries series, evicting the series not seen for maxStaleness.
+func New(maxSeries int, maxStaleness time.Duration) *Tracker {
+	return &Tracker{
+		maxSeries:    maxSeries,
+		maxStaleness: maxStaleness,
+		series:       make(map[string]*Series),
+	}
+}
+
+// Get returns the series with the given key and whether it was already tracked, the series is marked as seen at now.
+// A new series is tracked unless the tracker is full, in which case nil is returned.
+func (t *Tracker) Get(key string, now time.Time) (*Series, bool) {
+	t.sweep(now)
+	if s, ok := t.series[key]; ok {
+		s.lastSeen = now
+		return s, true
+	}
+	if len(t.series) >= t.maxSeries {
+		return nil, false
+	}
+	s := &Series{lastSeen: now}
+	t.series[key] = s
+	return s, false
+}
+
+// Remove stops tracking the series with the given key.
+func (t *Tracker) Remove(key string) {
+	delete(t.series, key)
+}
+
+// Len returns the number of tracked series.
+func (t *Tracker) Len() int {
+	return len(t.series)
+}
+
+// sweep evicts the stale series, at most once every maxStaleness.
+func (t *Tracker) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < t.maxStaleness {
+		return
+	}
+	t.lastSweep = now
+	for key, s := range t.series {
+		if now.Sub(s.lastSeen) >= t.maxStaleness {
+			delete(t.series, key)
+		}
+	}
+}
+
+// Key returns the identity of the series of a data point: the attributes of its resource, its instrumentation
+// library, the name and data type of its metric and its labels. The resource attributes and the labels are sorted in
+// place.
+func Key(resource pdata.Resource, library pdata.InstrumentationLibrary, metric pdata.Metric, labels pdata.StringMap) string {
+	var b strings.Builder
+	resource.Attributes().Sort().ForEach(func(k string, v pdata.AttributeValue) {
+		writeField(&b, k)
+		writeField(&b, tracetranslator.AttributeValueToString(v, true))
+	})
+	b.WriteByte(1)
+	writeField(&b, library.Name())
+	writeField(&b, library.Version())
+	writeField(&b, metric.Name())
+	writeField(&b, metric.DataType().String())
+	labels.Sort().ForEach(func(k, v string) {
+		writeField(&b, k)
+		writeField(&b, v)
+	})
+	return b.String()
+}
+
+func writeField(b *strings.Builder, s string) {
+	b.WriteString(s)
+	b.WriteByte(0)
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seriestracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestTracker(t *testing.T) {
+	now := time.Now()
+	tracker := New(2, time.Minute)
+
+	s, found := tracker.Get("a", now)
+	assert.NotNil(t, s)
+	assert.False(t, found)
+	s.IntValue = 1
+	s, found = tracker.Get("a", now)
+	assert.True(t, found)
+	assert.EqualValues(t, 1, s.IntValue)
+
+	// A new series is not tracked once the tracker is full.
+	_, found = tracker.Get("b", now)
+	assert.False(t, found)
+	s, found = tracker.Get("c", now)
+	assert.Nil(t, s)
+	assert.False(t, found)
+	assert.Equal(t, 2, tracker.Len())
+
+	// The series not seen for the max staleness are evicted.
+	_, found = tracker.Get("a", now.Add(30*time.Second))
+	assert.True(t, found)
+	s, found = tracker.Get("c", now.Add(time.Minute))
+	assert.NotNil(t, s)
+	assert.False(t, found)
+	_, found = tracker.Get("a", now.Add(time.Minute))
+	assert.True(t, found)
+	assert.Equal(t, 2, tracker.Len())
+
+	tracker.Remove("a")
+	assert.Equal(t, 1, tracker.Len())
+}
+
+func TestKey(t *testing.T) {
+	newPoint := func(resource map[string]string, name string, labels map[string]string) string {
+		rm := pdata.NewResourceMetrics()
+		for k, v := range resource {
+			rm.Resource().Attributes().UpsertString(k, v)
+		}
+		ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+		ilm.InstrumentationLibrary().SetName("library")
+		metric := ilm.Metrics().AppendEmpty()
+		metric.SetName(name)
+		metric.SetDataType(pdata.MetricDataTypeIntSum)
+		dp := metric.IntSum().DataPoints().AppendEmpty()
+		dp.LabelsMap().InitFromMap(labels)
+		return Key(rm.Resource(), ilm.InstrumentationLibrary(), metric, dp.LabelsMap())
+	}
+
+	key := newPoint(map[string]string{"host": "a", "service": "b"}, "requests", map[string]string{"code": "200", "method": "GET"})
+	assert.Equal(t, key, newPoint(map[string]string{"service": "b", "host": "a"}, "requests", map[string]string{"method": "GET", "code": "200"}))
+	assert.NotEqual(t, key, newPoint(map[string]string{"host": "b", "service": "b"}, "requests", map[string]string{"code": "200", "method": "GET"}))
+	assert.NotEqual(t, key, newPoint(map[string]string{"host": "a", "service": "b"}, "errors", map[string]string{"code": "200", "method": "GET"}))
+	assert.NotEqual(t, key, newPoint(map[string]string{"host": "a", "service": "b"}, "requests", map[string]string{"code": "500", "method": "GET"}))
+	// The resource attributes are not mistaken for labels.
+	assert.NotEqual(t, newPoint(map[string]string{"code": "200"}, "requests", nil), newPoint(nil, "requests", map[string]string{"code": "200"}))
+}
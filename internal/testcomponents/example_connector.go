@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testcomponents
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/connector/connectorhelper"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// ExampleConnectorCfg is for testing purposes. We are defining an example config and factory
+// for "exampleconnector" connector type.
+type ExampleConnectorCfg struct {
+	configmodels.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	ExtraSetting                   string                   `mapstructure:"extra"`
+}
+
+const connType = "exampleconnector"
+
+// ExampleConnectorFactory is factory for ExampleConnector. The connector forwards the traces, metrics and logs
+// unchanged, and produces a metric for each consumed trace.
+var ExampleConnectorFactory = connectorhelper.NewFactory(
+	connType,
+	createConnectorDefaultConfig,
+	connectorhelper.WithTracesToTraces(createTracesToTracesConnector),
+	connectorhelper.WithTracesToMetrics(createTracesToMetricsConnector),
+	connectorhelper.WithMetricsToMetrics(createMetricsToMetricsConnector),
+	connectorhelper.WithLogsToLogs(createLogsToLogsConnector))
+
+func createConnectorDefaultConfig() configmodels.Connector {
+	return &ExampleConnectorCfg{
+		ConnectorSettings: configmodels.ConnectorSettings{
+			TypeVal: connType,
+			NameVal: connType,
+		},
+		ExtraSetting: "some connector string",
+	}
+}
+
+func createTracesToTracesConnector(_ context.Context, _ component.ConnectorCreateParams, _ configmodels.Connector, nextConsumer consumer.Traces) (component.TracesConnector, error) {
+	return &ExampleConnector{Traces: nextConsumer}, nil
+}
+
+func createTracesToMetricsConnector(_ context.Context, _ component.ConnectorCreateParams, _ configmodels.Connector, nextConsumer consumer.Metrics) (component.TracesConnector, error) {
+	return &ExampleConnector{Traces: &tracesToMetrics{next: nextConsumer}}, nil
+}
+
+func createMetricsToMetricsConnector(_ context.Context, _ component.ConnectorCreateParams, _ configmodels.Connector, nextConsumer consumer.Metrics) (component.MetricsConnector, error) {
+	return &ExampleConnector{Metrics: nextConsumer}, nil
+}
+
+func createLogsToLogsConnector(_ context.Context, _ component.ConnectorCreateParams, _ configmodels.Connector, nextConsumer consumer.Logs) (component.LogsConnector, error) {
+	return &ExampleConnector{Logs: nextConsumer}, nil
+}
+
+// ExampleConnector is for testing purposes, it records whether it was started and shut down.
+type ExampleConnector struct {
+	consumer.Traces
+	consumer.Metrics
+	consumer.Logs
+	ConnectorStarted  bool
+	ConnectorShutdown bool
+}
+
+// Start tells the connector to start.
+func (ec *ExampleConnector) Start(_ context.Context, _ component.Host) error {
+	ec.ConnectorStarted = true
+	return nil
+}
+
+// Shutdown is invoked during shutdown.
+func (ec *ExampleConnector) Shutdown(context.Context) error {
+	ec.ConnectorShutdown = true
+	return nil
+}
+
+// tracesToMetrics produces a metrics batch for each consumed traces batch.
+type tracesToMetrics struct {
+	next consumer.Metrics
+}
+
+func (tm *tracesToMetrics) ConsumeTraces(ctx context.Context, _ pdata.Traces) error {
+	return tm.next.ConsumeMetrics(ctx, pdata.NewMetrics())
+}
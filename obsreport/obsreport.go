@@ -17,6 +17,7 @@ package obsreport
 import (
 	"context"
 	"strings"
+	"sync/atomic"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
@@ -24,18 +25,54 @@ import (
 	"go.opencensus.io/trace"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 const (
 	nameSep = "/"
+
+	// Key used to identify the reason why data was refused by receivers or failed to be sent by exporters.
+	ReasonKey = "reason"
 )
 
 var (
 	gLevel = configtelemetry.LevelBasic
 
+	tagKeyReason, _ = tag.NewKey(ReasonKey)
+
 	okStatus = trace.Status{Code: trace.StatusCodeOK}
+
+	// gOperationsSampler holds the operationsSampler set with SetOperationsSampler.
+	gOperationsSampler atomic.Value
+
+	// aggLastValue is shared by the views since view.LastValue returns a new
+	// aggregation at each call, unlike view.Sum.
+	aggLastValue = view.LastValue()
 )
 
+// operationsSampler wraps the sampler of the operations since atomic.Value
+// can't store a nil value.
+type operationsSampler struct {
+	sampler trace.Sampler
+}
+
+// SetOperationsSampler sets the sampler of the spans of the receive, scrape,
+// process and export operations of the components, instead of the default
+// sampler. The sampled spans are kept in the span store of the zPages and
+// exported as any other span. A nil sampler restores the default sampler.
+func SetOperationsSampler(sampler trace.Sampler) {
+	gOperationsSampler.Store(operationsSampler{sampler: sampler})
+}
+
+// startOperationSpan starts the span of an operation of a component, sampled
+// with the sampler set with SetOperationsSampler if any.
+func startOperationSpan(ctx context.Context, spanName string) (context.Context, *trace.Span) {
+	if holder, ok := gOperationsSampler.Load().(operationsSampler); ok && holder.sampler != nil {
+		return trace.StartSpan(ctx, spanName, trace.WithSampler(holder.sampler))
+	}
+	return trace.StartSpan(ctx, spanName)
+}
+
 // setParentLink tries to retrieve a span from parentCtx and if one exists
 // sets its SpanID, TraceID as a link to the given child Span.
 // It returns true only if it retrieved a parent span from the context.
@@ -88,36 +125,66 @@ func AllViews() (views []*view.View) {
 	// Receiver views.
 	measures := []*stats.Int64Measure{
 		mReceiverAcceptedSpans,
-		mReceiverRefusedSpans,
 		mReceiverAcceptedMetricPoints,
-		mReceiverRefusedMetricPoints,
 		mReceiverAcceptedLogRecords,
-		mReceiverRefusedLogRecords,
 	}
 	tagKeys := []tag.Key{
 		tagKeyReceiver, tagKeyTransport,
 	}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	measures = []*stats.Int64Measure{
+		mReceiverRefusedSpans,
+		mReceiverRefusedMetricPoints,
+		mReceiverRefusedLogRecords,
+	}
+	tagKeys = []tag.Key{
+		tagKeyReceiver, tagKeyTransport, tagKeyReason,
+	}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+
 	// Scraper views.
 	measures = []*stats.Int64Measure{
 		mScraperScrapedMetricPoints,
 		mScraperErroredMetricPoints,
+		mScraperSkippedScrapes,
 	}
 	tagKeys = []tag.Key{tagKeyReceiver, tagKeyScraper}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+	views = append(views, &view.View{
+		Name:        mScraperScrapeDuration.Name(),
+		Description: mScraperScrapeDuration.Description(),
+		TagKeys:     tagKeys,
+		Measure:     mScraperScrapeDuration,
+		Aggregation: aggScrapeDuration,
+	})
 
 	// Exporter views.
 	measures = []*stats.Int64Measure{
 		mExporterSentSpans,
-		mExporterFailedToSendSpans,
 		mExporterSentMetricPoints,
-		mExporterFailedToSendMetricPoints,
 		mExporterSentLogRecords,
-		mExporterFailedToSendLogRecords,
+		mExporterShutdownDroppedItems,
+		mExporterEnqueueFailedItems,
+		mExporterFanoutIsolatedItems,
 	}
 	tagKeys = []tag.Key{tagKeyExporter}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+	measures = []*stats.Int64Measure{
+		mExporterFailedToSendSpans,
+		mExporterFailedToSendMetricPoints,
+		mExporterFailedToSendLogRecords,
+	}
+	views = append(views, genViews(measures, []tag.Key{tagKeyExporter, tagKeyReason}, view.Sum())...)
+	measures = []*stats.Int64Measure{
+		mExporterSenderConcurrency,
+		mExporterQueueSize,
+		mExporterQueueCapacity,
+		mExporterQueueOldestItemAge,
+	}
+	views = append(views, genViews(measures, tagKeys, aggLastValue)...)
+	tagKeys = []tag.Key{tagKeyExporter, tagKeyCircuitBreakerState}
+	views = append(views, genViews([]*stats.Int64Measure{mExporterCircuitBreakerTransitions}, tagKeys, view.Sum())...)
 
 	// Processor views.
 	measures = []*stats.Int64Measure{
@@ -130,6 +197,9 @@ func AllViews() (views []*view.View) {
 		mProcessorAcceptedLogRecords,
 		mProcessorRefusedLogRecords,
 		mProcessorDroppedLogRecords,
+		mProcessorThrottledSpans,
+		mProcessorThrottledMetricPoints,
+		mProcessorThrottledLogRecords,
 	}
 	tagKeys = []tag.Key{tagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
@@ -155,6 +225,16 @@ func genViews(
 	return views
 }
 
+// reasonMutators returns the mutators adding the reason of the error to the tags, none if err is nil.
+func reasonMutators(err error) []tag.Mutator {
+	if err == nil {
+		return nil
+	}
+	return []tag.Mutator{
+		tag.Upsert(tagKeyReason, string(consumererror.GetReason(err)), tag.WithTTL(tag.TTLNoPropagation)),
+	}
+}
+
 func errToStatus(err error) trace.Status {
 	if err != nil {
 		return trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()}
@@ -16,12 +16,14 @@ package obsreport
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 const (
@@ -42,10 +44,35 @@ const (
 	SentLogRecordsKey = "sent_log_records"
 	// Key used to track logs that failed to be sent by exporters.
 	FailedToSendLogRecordsKey = "send_failed_log_records"
+
+	// Key used to track spans, metric points and logs dropped by exporters when shutting down.
+	ShutdownDroppedItemsKey = "shutdown_dropped_items"
+
+	// Key used to track the number of consumers of the exporter queue sending concurrently.
+	SenderConcurrencyKey = "sender_concurrency"
+
+	// Key used to track the state transitions of the circuit breaker of exporters.
+	CircuitBreakerTransitionsKey = "circuit_breaker_transitions"
+	// Key used to identify the state the circuit breaker transitioned to.
+	CircuitBreakerStateKey = "state"
+
+	// Key used to track the number of batches in the sending queue of exporters.
+	QueueSizeKey = "queue_size"
+	// Key used to track the capacity of the sending queue of exporters.
+	QueueCapacityKey = "queue_capacity"
+	// Key used to track the age of the oldest batch in the sending queue of exporters.
+	QueueOldestItemAgeKey = "queue_oldest_item_age"
+	// Key used to track spans, metric points and logs that failed to be added to the sending queue of exporters.
+	EnqueueFailedItemsKey = "enqueue_failed_items"
+
+	// Key used to track spans, metric points and logs that exporters failed to consume, and whose error was isolated
+	// from the other exporters of the pipeline instead of being returned to the receivers.
+	FanoutIsolatedItemsKey = "fanout_isolated_items"
 )
 
 var (
-	tagKeyExporter, _ = tag.NewKey(ExporterKey)
+	tagKeyExporter, _            = tag.NewKey(ExporterKey)
+	tagKeyCircuitBreakerState, _ = tag.NewKey(CircuitBreakerStateKey)
 
 	exporterPrefix                 = ExporterKey + nameSep
 	exportTraceDataOperationSuffix = nameSep + "traces"
@@ -81,6 +108,38 @@ var (
 		exporterPrefix+FailedToSendLogRecordsKey,
 		"Number of log records in failed attempts to send to destination.",
 		stats.UnitDimensionless)
+	mExporterShutdownDroppedItems = stats.Int64(
+		exporterPrefix+ShutdownDroppedItemsKey,
+		"Number of spans, metric points or log records dropped because they could not be sent before the shutdown timeout.",
+		stats.UnitDimensionless)
+	mExporterSenderConcurrency = stats.Int64(
+		exporterPrefix+SenderConcurrencyKey,
+		"Current number of consumers of the sending queue allowed to send concurrently.",
+		stats.UnitDimensionless)
+	mExporterCircuitBreakerTransitions = stats.Int64(
+		exporterPrefix+CircuitBreakerTransitionsKey,
+		"Number of transitions of the circuit breaker to the state.",
+		stats.UnitDimensionless)
+	mExporterQueueSize = stats.Int64(
+		exporterPrefix+QueueSizeKey,
+		"Current number of batches in the sending queue.",
+		stats.UnitDimensionless)
+	mExporterQueueCapacity = stats.Int64(
+		exporterPrefix+QueueCapacityKey,
+		"Maximum number of batches in the sending queue.",
+		stats.UnitDimensionless)
+	mExporterQueueOldestItemAge = stats.Int64(
+		exporterPrefix+QueueOldestItemAgeKey,
+		"Time the oldest batch of the sending queue has been waiting, 0 when the queue is empty.",
+		stats.UnitMilliseconds)
+	mExporterEnqueueFailedItems = stats.Int64(
+		exporterPrefix+EnqueueFailedItemsKey,
+		"Number of spans, metric points or log records dropped because the sending queue was full.",
+		stats.UnitDimensionless)
+	mExporterFanoutIsolatedItems = stats.Int64(
+		exporterPrefix+FanoutIsolatedItemsKey,
+		"Number of spans, metric points or log records the exporter failed to consume without failing the other exporters of the pipeline.",
+		stats.UnitDimensionless)
 )
 
 type Exporter struct {
@@ -113,7 +172,7 @@ func (eor *Exporter) StartTracesExportOp(ctx context.Context) context.Context {
 // EndTracesExportOp completes the export operation that was started with StartTracesExportOp.
 func (eor *Exporter) EndTracesExportOp(ctx context.Context, numSpans int, err error) {
 	numSent, numFailedToSend := toNumItems(numSpans, err)
-	eor.recordMetrics(ctx, numSent, numFailedToSend, mExporterSentSpans, mExporterFailedToSendSpans)
+	eor.recordMetrics(ctx, numSent, numFailedToSend, err, mExporterSentSpans, mExporterFailedToSendSpans)
 	endSpan(ctx, err, numSent, numFailedToSend, SentSpansKey, FailedToSendSpansKey)
 }
 
@@ -128,7 +187,7 @@ func (eor *Exporter) StartMetricsExportOp(ctx context.Context) context.Context {
 // StartMetricsExportOp.
 func (eor *Exporter) EndMetricsExportOp(ctx context.Context, numMetricPoints int, err error) {
 	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	eor.recordMetrics(ctx, numSent, numFailedToSend, mExporterSentMetricPoints, mExporterFailedToSendMetricPoints)
+	eor.recordMetrics(ctx, numSent, numFailedToSend, err, mExporterSentMetricPoints, mExporterFailedToSendMetricPoints)
 	endSpan(ctx, err, numSent, numFailedToSend, SentMetricPointsKey, FailedToSendMetricPointsKey)
 }
 
@@ -142,26 +201,105 @@ func (eor *Exporter) StartLogsExportOp(ctx context.Context) context.Context {
 // EndLogsExportOp completes the export operation that was started with StartLogsExportOp.
 func (eor *Exporter) EndLogsExportOp(ctx context.Context, numLogRecords int, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	eor.recordMetrics(ctx, numSent, numFailedToSend, mExporterSentLogRecords, mExporterFailedToSendLogRecords)
+	eor.recordMetrics(ctx, numSent, numFailedToSend, err, mExporterSentLogRecords, mExporterFailedToSendLogRecords)
 	endSpan(ctx, err, numSent, numFailedToSend, SentLogRecordsKey, FailedToSendLogRecordsKey)
 }
 
+// RecordShutdownDroppedItems records the number of spans, metric points or log records that were queued or being
+// retried, and that were dropped because the exporter was shut down before they could be sent.
+func (eor *Exporter) RecordShutdownDroppedItems(ctx context.Context, numItems int) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	// Ignore the error for now. This should not happen.
+	_ = stats.RecordWithTags(
+		ctx,
+		eor.mutators,
+		mExporterShutdownDroppedItems.M(int64(numItems)))
+}
+
+// RecordSenderConcurrency records the number of consumers of the sending queue allowed to send concurrently, it is
+// recorded when the exporter starts and each time the adaptive concurrency changes it.
+func (eor *Exporter) RecordSenderConcurrency(ctx context.Context, concurrency int) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	// Ignore the error for now. This should not happen.
+	_ = stats.RecordWithTags(
+		ctx,
+		eor.mutators,
+		mExporterSenderConcurrency.M(int64(concurrency)))
+}
+
+// RecordCircuitBreakerTransition records a transition of the circuit breaker of the exporter to the given state.
+func (eor *Exporter) RecordCircuitBreakerTransition(ctx context.Context, state string) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	// Ignore the error for now. This should not happen.
+	_ = stats.RecordWithTags(
+		ctx,
+		append(eor.mutators, tag.Upsert(tagKeyCircuitBreakerState, state, tag.WithTTL(tag.TTLNoPropagation))),
+		mExporterCircuitBreakerTransitions.M(1))
+}
+
+// RecordQueueStats records the number of batches in the sending queue, its capacity and the time the oldest batch has
+// been waiting in it.
+func (eor *Exporter) RecordQueueStats(ctx context.Context, size, capacity int, oldestItemAge time.Duration) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	// Ignore the error for now. This should not happen.
+	_ = stats.RecordWithTags(
+		ctx,
+		eor.mutators,
+		mExporterQueueSize.M(int64(size)),
+		mExporterQueueCapacity.M(int64(capacity)),
+		mExporterQueueOldestItemAge.M(oldestItemAge.Milliseconds()))
+}
+
+// RecordEnqueueFailedItems records the number of spans, metric points or log records that were dropped because the
+// sending queue was full.
+func (eor *Exporter) RecordEnqueueFailedItems(ctx context.Context, numItems int) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	// Ignore the error for now. This should not happen.
+	_ = stats.RecordWithTags(
+		ctx,
+		eor.mutators,
+		mExporterEnqueueFailedItems.M(int64(numItems)))
+}
+
+// RecordFanoutIsolatedItems records the number of spans, metric points or log records the exporter failed to consume,
+// and whose error was not returned to the receivers because the other exporters of the pipeline consumed them.
+func (eor *Exporter) RecordFanoutIsolatedItems(ctx context.Context, numItems int) {
+	if gLevel == configtelemetry.LevelNone {
+		return
+	}
+	// Ignore the error for now. This should not happen.
+	_ = stats.RecordWithTags(
+		ctx,
+		eor.mutators,
+		mExporterFanoutIsolatedItems.M(int64(numItems)))
+}
+
 // startSpan creates the span used to trace the operation. Returning
 // the updated context and the created span.
 func (eor *Exporter) startSpan(ctx context.Context, operationSuffix string) context.Context {
 	spanName := exporterPrefix + eor.exporterName + operationSuffix
-	ctx, _ = trace.StartSpan(ctx, spanName)
+	ctx, _ = startOperationSpan(ctx, spanName)
 	return ctx
 }
 
-func (eor *Exporter) recordMetrics(ctx context.Context, numSent, numFailedToSend int64, sentMeasure, failedToSendMeasure *stats.Int64Measure) {
+func (eor *Exporter) recordMetrics(ctx context.Context, numSent, numFailedToSend int64, err error, sentMeasure, failedToSendMeasure *stats.Int64Measure) {
 	if gLevel == configtelemetry.LevelNone {
 		return
 	}
 	// Ignore the error for now. This should not happen.
 	_ = stats.RecordWithTags(
 		ctx,
-		eor.mutators,
+		append(reasonMutators(err), eor.mutators...),
 		sentMeasure.M(numSent),
 		failedToSendMeasure.M(numFailedToSend))
 }
@@ -176,6 +314,9 @@ func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sen
 			trace.Int64Attribute(
 				failedToSendItemsKey, numFailedToSend),
 		)
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute(ReasonKey, string(consumererror.GetReason(err))))
+		}
 		span.SetStatus(errToStatus(err))
 	}
 	span.End()
@@ -20,8 +20,10 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 const (
@@ -36,6 +38,21 @@ const (
 
 	// Key used to identify log records dropped by the Collector.
 	DroppedLogRecordsKey = "dropped_log_records"
+
+	// Key used to identify spans refused by processors to apply backpressure.
+	ThrottledSpansKey = "throttled_spans"
+
+	// Key used to identify metric points refused by processors to apply backpressure.
+	ThrottledMetricPointsKey = "throttled_metric_points"
+
+	// Key used to identify log records refused by processors to apply backpressure.
+	ThrottledLogRecordsKey = "throttled_log_records"
+)
+
+const (
+	processTracesOperationSuffix  = nameSep + "traces"
+	processMetricsOperationSuffix = nameSep + "metrics"
+	processLogsOperationSuffix    = nameSep + "logs"
 )
 
 var (
@@ -81,6 +98,18 @@ var (
 		processorPrefix+DroppedLogRecordsKey,
 		"Number of log records that were dropped.",
 		stats.UnitDimensionless)
+	mProcessorThrottledSpans = stats.Int64(
+		processorPrefix+ThrottledSpansKey,
+		"Number of spans that were refused to apply backpressure, the senders being expected to retry.",
+		stats.UnitDimensionless)
+	mProcessorThrottledMetricPoints = stats.Int64(
+		processorPrefix+ThrottledMetricPointsKey,
+		"Number of metric points that were refused to apply backpressure, the senders being expected to retry.",
+		stats.UnitDimensionless)
+	mProcessorThrottledLogRecords = stats.Int64(
+		processorPrefix+ThrottledLogRecordsKey,
+		"Number of log records that were refused to apply backpressure, the senders being expected to retry.",
+		stats.UnitDimensionless)
 )
 
 // BuildProcessorCustomMetricName is used to be build a metric name following
@@ -117,8 +146,9 @@ func ProcessorMetricViews(configType string, legacyViews []*view.View) []*view.V
 var gProcessor = &Processor{level: configtelemetry.LevelNone}
 
 type Processor struct {
-	level    configtelemetry.Level
-	mutators []tag.Mutator
+	level         configtelemetry.Level
+	processorName string
+	mutators      []tag.Mutator
 }
 
 type ProcessorSettings struct {
@@ -128,8 +158,9 @@ type ProcessorSettings struct {
 
 func NewProcessor(cfg ProcessorSettings) *Processor {
 	return &Processor{
-		level:    cfg.Level,
-		mutators: []tag.Mutator{tag.Upsert(tagKeyProcessor, cfg.ProcessorName, tag.WithTTL(tag.TTLNoPropagation))},
+		level:         cfg.Level,
+		processorName: cfg.ProcessorName,
+		mutators:      []tag.Mutator{tag.Upsert(tagKeyProcessor, cfg.ProcessorName, tag.WithTTL(tag.TTLNoPropagation))},
 	}
 }
 
@@ -159,6 +190,21 @@ func (por *Processor) TracesRefused(ctx context.Context, numSpans int) {
 	}
 }
 
+// TracesThrottled reports that the trace data was refused to apply backpressure,
+// it is counted both as refused and as throttled.
+func (por *Processor) TracesThrottled(ctx context.Context, numSpans int) {
+	if por.level != configtelemetry.LevelNone {
+		stats.RecordWithTags(
+			ctx,
+			por.mutators,
+			mProcessorAcceptedSpans.M(0),
+			mProcessorRefusedSpans.M(int64(numSpans)),
+			mProcessorDroppedSpans.M(0),
+			mProcessorThrottledSpans.M(int64(numSpans)),
+		)
+	}
+}
+
 // TracesDropped reports that the trace data was dropped.
 func (por *Processor) TracesDropped(ctx context.Context, numSpans int) {
 	if por.level != configtelemetry.LevelNone {
@@ -198,6 +244,21 @@ func (por *Processor) MetricsRefused(ctx context.Context, numPoints int) {
 	}
 }
 
+// MetricsThrottled reports that the metrics were refused to apply backpressure,
+// they are counted both as refused and as throttled.
+func (por *Processor) MetricsThrottled(ctx context.Context, numPoints int) {
+	if por.level != configtelemetry.LevelNone {
+		stats.RecordWithTags(
+			ctx,
+			por.mutators,
+			mProcessorAcceptedMetricPoints.M(0),
+			mProcessorRefusedMetricPoints.M(int64(numPoints)),
+			mProcessorDroppedMetricPoints.M(0),
+			mProcessorThrottledMetricPoints.M(int64(numPoints)),
+		)
+	}
+}
+
 // MetricsDropped reports that the metrics were dropped.
 func (por *Processor) MetricsDropped(ctx context.Context, numPoints int) {
 	if por.level != configtelemetry.LevelNone {
@@ -237,6 +298,21 @@ func (por *Processor) LogsRefused(ctx context.Context, numRecords int) {
 	}
 }
 
+// LogsThrottled reports that the logs were refused to apply backpressure, they
+// are counted both as refused and as throttled.
+func (por *Processor) LogsThrottled(ctx context.Context, numRecords int) {
+	if por.level != configtelemetry.LevelNone {
+		stats.RecordWithTags(
+			ctx,
+			por.mutators,
+			mProcessorAcceptedLogRecords.M(0),
+			mProcessorRefusedLogRecords.M(int64(numRecords)),
+			mProcessorDroppedLogRecords.M(0),
+			mProcessorThrottledLogRecords.M(int64(numRecords)),
+		)
+	}
+}
+
 // LogsDropped reports that the logs were dropped.
 func (por *Processor) LogsDropped(ctx context.Context, numRecords int) {
 	if por.level != configtelemetry.LevelNone {
@@ -249,3 +325,95 @@ func (por *Processor) LogsDropped(ctx context.Context, numRecords int) {
 		)
 	}
 }
+
+// StartTracesProcessOp is called when a processor starts processing traces.
+// The returned context has the span of the operation, it must be ended with
+// EndProcessOp.
+func (por *Processor) StartTracesProcessOp(ctx context.Context) context.Context {
+	return por.startSpan(ctx, processTracesOperationSuffix)
+}
+
+// StartMetricsProcessOp is called when a processor starts processing metrics.
+// The returned context has the span of the operation, it must be ended with
+// EndProcessOp.
+func (por *Processor) StartMetricsProcessOp(ctx context.Context) context.Context {
+	return por.startSpan(ctx, processMetricsOperationSuffix)
+}
+
+// StartLogsProcessOp is called when a processor starts processing logs.
+// The returned context has the span of the operation, it must be ended with
+// EndProcessOp.
+func (por *Processor) StartLogsProcessOp(ctx context.Context) context.Context {
+	return por.startSpan(ctx, processLogsOperationSuffix)
+}
+
+// EndProcessOp completes the process operation that was started with one of
+// the Start*ProcessOp functions.
+func (por *Processor) EndProcessOp(ctx context.Context, err error) {
+	span := trace.FromContext(ctx)
+	if span.IsRecordingEvents() {
+		span.SetStatus(errToStatus(err))
+	}
+	span.End()
+}
+
+// EndTracesProcessOp completes the process operation that was started with
+// StartTracesProcessOp and reports the spans. When err is not nil the received
+// spans are reported as refused, and as throttled when err is retryable since
+// the processors refuse data with retryable errors to apply backpressure.
+// Otherwise the processed spans are reported as accepted and the spans removed
+// by the processor as dropped.
+func (por *Processor) EndTracesProcessOp(ctx context.Context, numReceivedSpans, numProcessedSpans int, err error) {
+	por.EndProcessOp(ctx, err)
+	switch {
+	case err == nil:
+		por.TracesAccepted(ctx, numProcessedSpans)
+		if dropped := numReceivedSpans - numProcessedSpans; dropped > 0 {
+			por.TracesDropped(ctx, dropped)
+		}
+	case consumererror.IsRetryable(err):
+		por.TracesThrottled(ctx, numReceivedSpans)
+	default:
+		por.TracesRefused(ctx, numReceivedSpans)
+	}
+}
+
+// EndMetricsProcessOp completes the process operation that was started with
+// StartMetricsProcessOp and reports the metric points like EndTracesProcessOp.
+func (por *Processor) EndMetricsProcessOp(ctx context.Context, numReceivedPoints, numProcessedPoints int, err error) {
+	por.EndProcessOp(ctx, err)
+	switch {
+	case err == nil:
+		por.MetricsAccepted(ctx, numProcessedPoints)
+		if dropped := numReceivedPoints - numProcessedPoints; dropped > 0 {
+			por.MetricsDropped(ctx, dropped)
+		}
+	case consumererror.IsRetryable(err):
+		por.MetricsThrottled(ctx, numReceivedPoints)
+	default:
+		por.MetricsRefused(ctx, numReceivedPoints)
+	}
+}
+
+// EndLogsProcessOp completes the process operation that was started with
+// StartLogsProcessOp and reports the log records like EndTracesProcessOp.
+func (por *Processor) EndLogsProcessOp(ctx context.Context, numReceivedRecords, numProcessedRecords int, err error) {
+	por.EndProcessOp(ctx, err)
+	switch {
+	case err == nil:
+		por.LogsAccepted(ctx, numProcessedRecords)
+		if dropped := numReceivedRecords - numProcessedRecords; dropped > 0 {
+			por.LogsDropped(ctx, dropped)
+		}
+	case consumererror.IsRetryable(err):
+		por.LogsThrottled(ctx, numReceivedRecords)
+	default:
+		por.LogsRefused(ctx, numReceivedRecords)
+	}
+}
+
+func (por *Processor) startSpan(ctx context.Context, operationSuffix string) context.Context {
+	spanName := processorPrefix + por.processorName + operationSuffix
+	ctx, _ = startOperationSpan(ctx, spanName)
+	return ctx
+}
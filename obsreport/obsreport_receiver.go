@@ -23,6 +23,7 @@ import (
 
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
 const (
@@ -279,12 +280,12 @@ func traceReceiveOp(
 	var span *trace.Span
 	spanName := receiverPrefix + receiverName + operationSuffix
 	if !opts.LongLivedCtx {
-		ctx, span = trace.StartSpan(receiverCtx, spanName)
+		ctx, span = startOperationSpan(receiverCtx, spanName)
 	} else {
 		// Since the receiverCtx is long lived do not use it to start the span.
 		// This way this trace ends when the EndTraceDataReceiveOp is called.
 		// Here is safe to ignore the returned context since it is not used below.
-		_, span = trace.StartSpan(context.Background(), spanName)
+		_, span = startOperationSpan(context.Background(), spanName)
 
 		// If the long lived context has a parent span, then add it as a parent link.
 		setParentLink(receiverCtx, span)
@@ -329,8 +330,10 @@ func endReceiveOp(
 			refusedMeasure = mReceiverRefusedLogRecords
 		}
 
-		stats.Record(
+		// Ignore the error for now. This should not happen.
+		_ = stats.RecordWithTags(
 			receiverCtx,
+			reasonMutators(err),
 			acceptedMeasure.M(int64(numAccepted)),
 			refusedMeasure.M(int64(numRefused)))
 	}
@@ -358,6 +361,9 @@ func endReceiveOp(
 			trace.Int64Attribute(
 				refusedItemsKey, int64(numRefused)),
 		)
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute(ReasonKey, string(consumererror.GetReason(err))))
+		}
 		span.SetStatus(errToStatus(err))
 	}
 	span.End()
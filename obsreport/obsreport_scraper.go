@@ -16,8 +16,10 @@ package obsreport
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 
@@ -36,6 +38,12 @@ const (
 	// ErroredMetricPointsKey used to identify metric points errored (i.e.
 	// unable to be scraped) by the Collector.
 	ErroredMetricPointsKey = "errored_metric_points"
+	// ScrapeDurationKey used to track the duration of the scrapes in
+	// milliseconds.
+	ScrapeDurationKey = "scrape_duration"
+	// SkippedScrapesKey used to identify the scrapes skipped because the
+	// previous scrape of the scraper was still running.
+	SkippedScrapesKey = "skipped_scrapes"
 )
 
 const (
@@ -54,8 +62,24 @@ var (
 		scraperPrefix+ErroredMetricPointsKey,
 		"Number of metric points that were unable to be scraped.",
 		stats.UnitDimensionless)
+	mScraperSkippedScrapes = stats.Int64(
+		scraperPrefix+SkippedScrapesKey,
+		"Number of scrapes skipped because the previous scrape was still running.",
+		stats.UnitDimensionless)
+	mScraperScrapeDuration = stats.Float64(
+		scraperPrefix+ScrapeDurationKey,
+		"Duration of the scrapes.",
+		stats.UnitMilliseconds)
+
+	// aggScrapeDuration has the bounds in milliseconds of the buckets of
+	// the scrape duration distribution, shared by the views like aggLastValue.
+	aggScrapeDuration = view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000)
 )
 
+// scrapeStartTimeKey is the key of the start time of the scrape operation in
+// the context returned by StartMetricsScrapeOp.
+type scrapeStartTimeKey struct{}
+
 // ScraperContext adds the keys used when recording observability metrics to
 // the given context returning the newly created context. This context should
 // be used in related calls to the obsreport functions so metrics are properly
@@ -80,6 +104,18 @@ func StartMetricsScrapeOp(
 	scraperCtx context.Context,
 	receiver string,
 	scraper string,
+) context.Context {
+	return StartMetricsScrapeOpAt(scraperCtx, receiver, scraper, time.Now())
+}
+
+// StartMetricsScrapeOpAt is like StartMetricsScrapeOp for the scrapes which
+// started before the operation, the duration of the scrape being measured
+// from startTime instead of from the call.
+func StartMetricsScrapeOpAt(
+	scraperCtx context.Context,
+	receiver string,
+	scraper string,
+	startTime time.Time,
 ) context.Context {
 	scraperName := receiver
 	if scraper != "" {
@@ -87,8 +123,8 @@ func StartMetricsScrapeOp(
 	}
 
 	spanName := scraperPrefix + scraperName + scraperMetricsOperationSuffix
-	ctx, _ := trace.StartSpan(scraperCtx, spanName)
-	return ctx
+	ctx, _ := startOperationSpan(scraperCtx, spanName)
+	return context.WithValue(ctx, scrapeStartTimeKey{}, startTime)
 }
 
 // EndMetricsScrapeOp completes the scrape operation that was started with
@@ -111,10 +147,14 @@ func EndMetricsScrapeOp(
 	span := trace.FromContext(scraperCtx)
 
 	if gLevel != configtelemetry.LevelNone {
-		stats.Record(
-			scraperCtx,
+		measurements := []stats.Measurement{
 			mScraperScrapedMetricPoints.M(int64(numScrapedMetrics)),
-			mScraperErroredMetricPoints.M(int64(numErroredMetrics)))
+			mScraperErroredMetricPoints.M(int64(numErroredMetrics)),
+		}
+		if startTime, ok := scraperCtx.Value(scrapeStartTimeKey{}).(time.Time); ok {
+			measurements = append(measurements, mScraperScrapeDuration.M(float64(time.Since(startTime))/float64(time.Millisecond)))
+		}
+		stats.Record(scraperCtx, measurements...)
 	}
 
 	// end span according to errors
@@ -130,3 +170,12 @@ func EndMetricsScrapeOp(
 
 	span.End()
 }
+
+// RecordSkippedScrape records that a scrape was skipped because the previous
+// scrape of the scraper was still running. The context must be the one
+// returned by ScraperContext.
+func RecordSkippedScrape(scraperCtx context.Context) {
+	if gLevel != configtelemetry.LevelNone {
+		stats.Record(scraperCtx, mScraperSkippedScrapes.M(1))
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,7 @@ import (
 	"go.opencensus.io/trace"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
@@ -323,6 +325,26 @@ func TestScrapeMetricsDataOp(t *testing.T) {
 	}
 
 	obsreporttest.CheckScraperMetricsViews(t, receiver, scraper, int64(scrapedMetricPoints), int64(erroredMetricPoints))
+	obsreporttest.CheckScraperDurationViews(t, receiver, scraper, int64(len(errParams)))
+}
+
+func TestScrapeMetricsDataOpAt(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	scraperCtx := obsreport.ScraperContext(context.Background(), receiver, scraper)
+	ctx := obsreport.StartMetricsScrapeOpAt(scraperCtx, receiver, scraper, time.Now().Add(-time.Second))
+	obsreport.EndMetricsScrapeOp(ctx, 7, nil)
+
+	obsreporttest.CheckScraperMetricsViews(t, receiver, scraper, 7, 0)
+	obsreporttest.CheckScraperDurationViews(t, receiver, scraper, 1)
+
+	rows, err := view.RetrieveData("scraper/" + obsreport.ScrapeDurationKey)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	// The duration is measured from the given start time.
+	assert.GreaterOrEqual(t, rows[0].Data.(*view.DistributionData).Min, float64(1000))
 }
 
 func TestExportTraceDataOp(t *testing.T) {
@@ -643,6 +665,114 @@ func TestProcessorLogRecords(t *testing.T) {
 	obsreporttest.CheckProcessorLogsViews(t, processor, acceptedRecords, refusedRecords, droppedRecords)
 }
 
+func TestProcessorThrottled(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	const throttledSpans = 7
+	const throttledPoints = 5
+	const throttledRecords = 3
+
+	obsrep := obsreport.NewProcessor(obsreport.ProcessorSettings{configtelemetry.LevelNormal, processor})
+	obsrep.TracesThrottled(context.Background(), throttledSpans)
+	obsrep.MetricsThrottled(context.Background(), throttledPoints)
+	obsrep.LogsThrottled(context.Background(), throttledRecords)
+
+	obsreporttest.CheckProcessorTracesViews(t, processor, 0, throttledSpans, 0)
+	obsreporttest.CheckProcessorMetricsViews(t, processor, 0, throttledPoints, 0)
+	obsreporttest.CheckProcessorLogsViews(t, processor, 0, throttledRecords, 0)
+	obsreporttest.CheckProcessorThrottledViews(t, processor, throttledSpans, throttledPoints, throttledRecords)
+}
+
+func TestProcessOp(t *testing.T) {
+	ss := &spanStore{}
+	trace.RegisterExporter(ss)
+	defer trace.UnregisterExporter(ss)
+
+	parentCtx, parentSpan := trace.StartSpan(context.Background(),
+		t.Name(), trace.WithSampler(trace.AlwaysSample()))
+	defer parentSpan.End()
+
+	obsrep := obsreport.NewProcessor(obsreport.ProcessorSettings{configtelemetry.LevelNormal, processor})
+	startOps := []func(context.Context) context.Context{
+		obsrep.StartTracesProcessOp,
+		obsrep.StartMetricsProcessOp,
+		obsrep.StartLogsProcessOp,
+	}
+	errs := []error{nil, errFake, nil}
+	for i, startOp := range startOps {
+		ctx := startOp(parentCtx)
+		assert.NotNil(t, ctx)
+		obsrep.EndProcessOp(ctx, errs[i])
+	}
+
+	spans := ss.PullAllSpans()
+	require.Equal(t, len(startOps), len(spans))
+	for i, name := range []string{"traces", "metrics", "logs"} {
+		assert.Equal(t, "processor/"+processor+"/"+name, spans[i].Name)
+		assert.Equal(t, parentSpan.SpanContext().SpanID, spans[i].ParentSpanID)
+		if errs[i] == nil {
+			assert.Equal(t, trace.Status{Code: trace.StatusCodeOK}, spans[i].Status)
+		} else {
+			assert.Equal(t, errs[i].Error(), spans[i].Status.Message)
+		}
+	}
+}
+
+func TestEndProcessOp(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewProcessor(obsreport.ProcessorSettings{configtelemetry.LevelNormal, processor})
+	retryableErr := consumererror.NewRetryable(errFake, time.Second)
+
+	obsrep.EndTracesProcessOp(obsrep.StartTracesProcessOp(context.Background()), 10, 7, nil)
+	obsrep.EndTracesProcessOp(obsrep.StartTracesProcessOp(context.Background()), 5, 0, errFake)
+	obsrep.EndTracesProcessOp(obsrep.StartTracesProcessOp(context.Background()), 2, 0, retryableErr)
+
+	obsrep.EndMetricsProcessOp(obsrep.StartMetricsProcessOp(context.Background()), 8, 8, nil)
+	obsrep.EndMetricsProcessOp(obsrep.StartMetricsProcessOp(context.Background()), 4, 0, nil)
+	obsrep.EndMetricsProcessOp(obsrep.StartMetricsProcessOp(context.Background()), 3, 0, retryableErr)
+
+	obsrep.EndLogsProcessOp(obsrep.StartLogsProcessOp(context.Background()), 6, 9, nil)
+	obsrep.EndLogsProcessOp(obsrep.StartLogsProcessOp(context.Background()), 1, 0, errFake)
+	obsrep.EndLogsProcessOp(obsrep.StartLogsProcessOp(context.Background()), 2, 0, retryableErr)
+
+	obsreporttest.CheckProcessorTracesViews(t, processor, 7, 7, 3)
+	obsreporttest.CheckProcessorMetricsViews(t, processor, 8, 3, 4)
+	obsreporttest.CheckProcessorLogsViews(t, processor, 9, 3, 0)
+	obsreporttest.CheckProcessorThrottledViews(t, processor, 2, 3, 2)
+}
+
+func TestSetOperationsSampler(t *testing.T) {
+	ss := &spanStore{}
+	trace.RegisterExporter(ss)
+	defer trace.UnregisterExporter(ss)
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{configtelemetry.LevelNormal, exporter})
+	export := func() {
+		ctx := obsrep.StartTracesExportOp(context.Background())
+		obsrep.EndTracesExportOp(ctx, 1, nil)
+	}
+
+	obsreport.SetOperationsSampler(trace.AlwaysSample())
+	export()
+	assert.Len(t, ss.PullAllSpans(), 1)
+
+	obsreport.SetOperationsSampler(trace.NeverSample())
+	export()
+	assert.Len(t, ss.PullAllSpans(), 0)
+
+	// A nil sampler restores the default sampler.
+	obsreport.SetOperationsSampler(nil)
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+	defer trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(1e-4)})
+	export()
+	assert.Len(t, ss.PullAllSpans(), 1)
+}
+
 type spanStore struct {
 	sync.Mutex
 	spans []*trace.SpanData
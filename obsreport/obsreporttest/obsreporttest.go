@@ -39,6 +39,8 @@ var (
 	transportTag, _ = tag.NewKey("transport")
 	exporterTag, _  = tag.NewKey("exporter")
 	processorTag, _ = tag.NewKey("processor")
+	stateTag, _     = tag.NewKey("state")
+	reasonTag, _    = tag.NewKey("reason")
 )
 
 // SetupRecordedMetricsTest does setup the testing environment to check the metrics recorded by receivers, producers or exporters.
@@ -79,6 +81,60 @@ func CheckExporterLogsViews(t *testing.T, exporter string, acceptedLogRecords, d
 	checkValueForView(t, exporterTags, droppedLogRecords, "exporter/send_failed_log_records")
 }
 
+// CheckExporterShutdownDroppedItemsViews checks that for the current exported value for the items dropped by the
+// exporter at shutdown matches the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterShutdownDroppedItemsViews(t *testing.T, exporter string, droppedItems int64) {
+	checkValueForView(t, tagsForExporterView(exporter), droppedItems, "exporter/shutdown_dropped_items")
+}
+
+// CheckExporterSenderConcurrencyViews checks that for the current exported value for the sender concurrency of the
+// exporter matches the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterSenderConcurrencyViews(t *testing.T, exporter string, concurrency int64) {
+	checkValueForView(t, tagsForExporterView(exporter), concurrency, "exporter/sender_concurrency")
+}
+
+// CheckExporterQueueViews checks that for the current exported values for the size, capacity and age in milliseconds
+// of the oldest batch of the sending queue of the exporter match the given values.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterQueueViews(t *testing.T, exporter string, size, capacity, oldestItemAge int64) {
+	checkValueForView(t, tagsForExporterView(exporter), size, "exporter/queue_size")
+	checkValueForView(t, tagsForExporterView(exporter), capacity, "exporter/queue_capacity")
+	checkValueForView(t, tagsForExporterView(exporter), oldestItemAge, "exporter/queue_oldest_item_age")
+}
+
+// CheckExporterEnqueueFailedItemsViews checks that for the current exported value for the items dropped because the
+// sending queue of the exporter was full matches the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterEnqueueFailedItemsViews(t *testing.T, exporter string, failedItems int64) {
+	checkValueForView(t, tagsForExporterView(exporter), failedItems, "exporter/enqueue_failed_items")
+}
+
+// CheckExporterCircuitBreakerTransitionsViews checks that for the current exported value for the transitions of the
+// circuit breaker of the exporter to the given state matches the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterCircuitBreakerTransitionsViews(t *testing.T, exporter, state string, transitions int64) {
+	tags := append(tagsForExporterView(exporter), tag.Tag{Key: stateTag, Value: state})
+	checkValueForView(t, tags, transitions, "exporter/circuit_breaker_transitions")
+}
+
+// CheckExporterFanoutIsolatedItemsViews checks that for the current exported value for the items the exporter failed
+// to consume without failing the other exporters of the pipeline matches the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterFanoutIsolatedItemsViews(t *testing.T, exporter string, isolatedItems int64) {
+	checkValueForView(t, tagsForExporterView(exporter), isolatedItems, "exporter/fanout_isolated_items")
+}
+
+// CheckExporterFailedReasonViews checks that for the current exported value for the data failed to be sent by the
+// exporter for the given reason matches the given value, failedKey being one of obsreport.FailedToSendSpansKey,
+// obsreport.FailedToSendMetricPointsKey or obsreport.FailedToSendLogRecordsKey.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckExporterFailedReasonViews(t *testing.T, exporter, failedKey, reason string, failed int64) {
+	tags := append(tagsForExporterView(exporter), tag.Tag{Key: reasonTag, Value: reason})
+	checkValueForView(t, tags, failed, "exporter/"+failedKey)
+}
+
 // CheckProcessorTracesViews checks that for the current exported values for trace exporter views match given values.
 // When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
 func CheckProcessorTracesViews(t *testing.T, processor string, acceptedSpans, refusedSpans, droppedSpans int64) {
@@ -106,6 +162,25 @@ func CheckProcessorLogsViews(t *testing.T, processor string, acceptedLogRecords,
 	checkValueForView(t, processorTags, droppedLogRecords, "processor/dropped_log_records")
 }
 
+// CheckProcessorThrottledViews checks that for the current exported values for the data refused by the processor to
+// apply backpressure match given values.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckProcessorThrottledViews(t *testing.T, processor string, throttledSpans, throttledMetricPoints, throttledLogRecords int64) {
+	processorTags := tagsForProcessorView(processor)
+	checkValueForView(t, processorTags, throttledSpans, "processor/throttled_spans")
+	checkValueForView(t, processorTags, throttledMetricPoints, "processor/throttled_metric_points")
+	checkValueForView(t, processorTags, throttledLogRecords, "processor/throttled_log_records")
+}
+
+// CheckReceiverRefusedReasonViews checks that for the current exported value for the data refused by the receiver
+// for the given reason matches the given value, refusedKey being one of obsreport.RefusedSpansKey,
+// obsreport.RefusedMetricPointsKey or obsreport.RefusedLogRecordsKey.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckReceiverRefusedReasonViews(t *testing.T, receiver, protocol, refusedKey, reason string, refused int64) {
+	tags := append(tagsForReceiverView(receiver, protocol), tag.Tag{Key: reasonTag, Value: reason})
+	checkValueForView(t, tags, refused, "receiver/"+refusedKey)
+}
+
 // CheckReceiverTracesViews checks that for the current exported values for trace receiver views match given values.
 // When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
 func CheckReceiverTracesViews(t *testing.T, receiver, protocol string, acceptedSpans, droppedSpans int64) {
@@ -138,8 +213,22 @@ func CheckScraperMetricsViews(t *testing.T, receiver, scraper string, scrapedMet
 	checkValueForView(t, scraperTags, erroredMetricPoints, "scraper/errored_metric_points")
 }
 
+// CheckScraperDurationViews checks that the current exported number of scrapes in the scrape duration view matches
+// the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckScraperDurationViews(t *testing.T, receiver, scraper string, scrapes int64) {
+	checkValueForView(t, tagsForScraperView(receiver, scraper), scrapes, "scraper/scrape_duration")
+}
+
+// CheckScraperSkippedScrapesViews checks that the current exported number of skipped scrapes matches the given value.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckScraperSkippedScrapesViews(t *testing.T, receiver, scraper string, skippedScrapes int64) {
+	checkValueForView(t, tagsForScraperView(receiver, scraper), skippedScrapes, "scraper/skipped_scrapes")
+}
+
 // checkValueForView checks that for the current exported value in the view with the given name
-// for {LegacyTagKeyReceiver: receiverName} is equal to "value".
+// for {LegacyTagKeyReceiver: receiverName} is equal to "value". Unless the reason is part of the
+// given tags, the rows of all the reasons are summed. The count of the distributions is compared.
 func checkValueForView(t *testing.T, wantTags []tag.Tag, value int64, vName string) {
 	// Make sure the tags slice is sorted by tag keys.
 	sortTags(wantTags)
@@ -147,17 +236,51 @@ func checkValueForView(t *testing.T, wantTags []tag.Tag, value int64, vName stri
 	rows, err := view.RetrieveData(vName)
 	require.NoError(t, err)
 
+	found := false
+	sum := float64(0)
 	for _, row := range rows {
+		rowTags := row.Tags
+		if !hasTag(wantTags, reasonTag) {
+			rowTags = withoutTag(rowTags, reasonTag)
+		}
 		// Make sure the tags slice is sorted by tag keys.
-		sortTags(row.Tags)
-		if reflect.DeepEqual(wantTags, row.Tags) {
-			sum := row.Data.(*view.SumData)
-			require.Equal(t, float64(value), sum.Value)
-			return
+		sortTags(rowTags)
+		if reflect.DeepEqual(wantTags, rowTags) {
+			switch data := row.Data.(type) {
+			case *view.SumData:
+				sum += data.Value
+			case *view.LastValueData:
+				sum += data.Value
+			case *view.DistributionData:
+				sum += float64(data.Count)
+			default:
+				require.Failf(t, "unexpected aggregation", "view: %s, data: %v", vName, row.Data)
+			}
+			found = true
+		}
+	}
+
+	require.Truef(t, found, "could not find tags, wantTags: %s in rows %v", wantTags, rows)
+	require.Equal(t, float64(value), sum)
+}
+
+func hasTag(tags []tag.Tag, key tag.Key) bool {
+	for _, t := range tags {
+		if t.Key == key {
+			return true
 		}
 	}
+	return false
+}
 
-	require.Failf(t, "could not find tags", "wantTags: %s in rows %v", wantTags, rows)
+func withoutTag(tags []tag.Tag, key tag.Key) []tag.Tag {
+	filtered := make([]tag.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t.Key != key {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
 }
 
 // tagsForReceiverView returns the tags that are needed for the receiver views.
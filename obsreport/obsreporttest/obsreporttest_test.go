@@ -16,12 +16,15 @@ package obsreporttest_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -50,6 +53,22 @@ func TestCheckReceiverTracesViews(t *testing.T) {
 	obsreporttest.CheckReceiverTracesViews(t, receiver, transport, 7, 0)
 }
 
+func TestCheckReceiverRefusedReasonViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	receiverCtx := obsreport.ReceiverContext(context.Background(), receiver, transport)
+	ctx := obsreport.StartTraceDataReceiveOp(receiverCtx, receiver, transport)
+	obsreport.EndTraceDataReceiveOp(ctx, format, 7, consumererror.WithReason(errors.New("full"), consumererror.ReasonQueueFull))
+	ctx = obsreport.StartTraceDataReceiveOp(receiverCtx, receiver, transport)
+	obsreport.EndTraceDataReceiveOp(ctx, format, 3, errors.New("failed"))
+
+	obsreporttest.CheckReceiverTracesViews(t, receiver, transport, 0, 10)
+	obsreporttest.CheckReceiverRefusedReasonViews(t, receiver, transport, obsreport.RefusedSpansKey, "queue_full", 7)
+	obsreporttest.CheckReceiverRefusedReasonViews(t, receiver, transport, obsreport.RefusedSpansKey, "downstream_error", 3)
+}
+
 func TestCheckReceiverMetricsViews(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
@@ -93,6 +112,114 @@ func TestCheckExporterTracesViews(t *testing.T) {
 	obsreporttest.CheckExporterTracesViews(t, exporter, 7, 0)
 }
 
+func TestCheckExporterFailedReasonViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.LevelNormal,
+		ExporterName: exporter,
+	})
+	obsrep.EndLogsExportOp(obsrep.StartLogsExportOp(context.Background()), 5,
+		consumererror.WithReason(errors.New("unauthenticated"), consumererror.ReasonAuthFailed))
+	obsrep.EndLogsExportOp(obsrep.StartLogsExportOp(context.Background()), 2, nil)
+
+	obsreporttest.CheckExporterLogsViews(t, exporter, 2, 5)
+	obsreporttest.CheckExporterFailedReasonViews(t, exporter, obsreport.FailedToSendLogRecordsKey, "auth_failed", 5)
+}
+
+func TestCheckExporterShutdownDroppedItemsViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.LevelNormal,
+		ExporterName: exporter,
+	})
+	obsrep.RecordShutdownDroppedItems(context.Background(), 5)
+
+	obsreporttest.CheckExporterShutdownDroppedItemsViews(t, exporter, 5)
+}
+
+func TestCheckExporterFanoutIsolatedItemsViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.LevelNormal,
+		ExporterName: exporter,
+	})
+	obsrep.RecordFanoutIsolatedItems(context.Background(), 3)
+	obsrep.RecordFanoutIsolatedItems(context.Background(), 2)
+
+	obsreporttest.CheckExporterFanoutIsolatedItemsViews(t, exporter, 5)
+}
+
+func TestCheckExporterSenderConcurrencyViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.LevelNormal,
+		ExporterName: exporter,
+	})
+	obsrep.RecordSenderConcurrency(context.Background(), 4)
+	obsrep.RecordSenderConcurrency(context.Background(), 6)
+
+	obsreporttest.CheckExporterSenderConcurrencyViews(t, exporter, 6)
+}
+
+func TestCheckExporterQueueViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.LevelNormal,
+		ExporterName: exporter,
+	})
+	obsrep.RecordQueueStats(context.Background(), 7, 100, 2*time.Second)
+	obsrep.RecordQueueStats(context.Background(), 3, 100, 1500*time.Millisecond)
+
+	obsreporttest.CheckExporterQueueViews(t, exporter, 3, 100, 1500)
+}
+
+func TestCheckExporterEnqueueFailedItemsViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.LevelNormal,
+		ExporterName: exporter,
+	})
+	obsrep.RecordEnqueueFailedItems(context.Background(), 4)
+	obsrep.RecordEnqueueFailedItems(context.Background(), 6)
+
+	obsreporttest.CheckExporterEnqueueFailedItemsViews(t, exporter, 10)
+}
+
+func TestCheckExporterCircuitBreakerTransitionsViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	obsrep := obsreport.NewExporter(obsreport.ExporterSettings{
+		Level:        configtelemetry.LevelNormal,
+		ExporterName: exporter,
+	})
+	obsrep.RecordCircuitBreakerTransition(context.Background(), "open")
+	obsrep.RecordCircuitBreakerTransition(context.Background(), "half_open")
+	obsrep.RecordCircuitBreakerTransition(context.Background(), "open")
+
+	obsreporttest.CheckExporterCircuitBreakerTransitionsViews(t, exporter, "open", 2)
+	obsreporttest.CheckExporterCircuitBreakerTransitionsViews(t, exporter, "half_open", 1)
+}
+
 func TestCheckExporterMetricsViews(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
@@ -234,4 +234,18 @@ func TestLoadingConfig(t *testing.T) {
 		},
 	})
 
+	p11 := cfg.Processors["attributes/convert"]
+	assert.Equal(t, p11, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "attributes/convert",
+			TypeVal: typeStr,
+		},
+		Settings: processorhelper.Settings{
+			Actions: []processorhelper.ActionKeyValue{
+				{Key: "http.status_code", Action: processorhelper.CONVERT, ConvertedType: "int"},
+				{Key: "retry", Action: processorhelper.CONVERT, ConvertedType: "bool", OnError: "delete"},
+			},
+		},
+	})
+
 }
@@ -16,16 +16,22 @@ package batchprocessor
 
 import (
 	"context"
+	"errors"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/processor"
 )
@@ -38,6 +44,10 @@ import (
 // Batches are sent out with any of the following conditions:
 // - batch size reaches cfg.SendBatchSize
 // - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
+//
+// When cfg.MetadataKeys is set the data is batched in a separate shard for each
+// combination of values of these keys in the client metadata. The shards that
+// receive no data for a whole cfg.Timeout are removed.
 type batchProcessor struct {
 	name           string
 	logger         *zap.Logger
@@ -47,15 +57,41 @@ type batchProcessor struct {
 	timeout          time.Duration
 	sendBatchMaxSize uint32
 
-	timer   *time.Timer
-	done    chan struct{}
-	newItem chan interface{}
-	batch   batch
+	metadataKeys  []string
+	metadataLimit uint32
+
+	newBatch func() batch
+
+	// defaultShard batches all the data when there are no metadata keys.
+	defaultShard *shard
+	shardsMu     sync.Mutex
+	shards       map[string]*shard
+	shardsWG     sync.WaitGroup
 
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
+// shard batches the data independently of the other shards, the batches are exported with the shard context.
+type shard struct {
+	bp *batchProcessor
+	// key is the key of the shard in bp.shards, empty for the default shard.
+	key string
+	// exportCtx carries the client metadata of the shard to the next consumer.
+	exportCtx context.Context
+	// pending counts the items being sent to newItem, guarded by bp.shardsMu. The shard is not removed while items
+	// are pending.
+	pending int
+	// active is set when an item is processed since the last timeout.
+	active bool
+
+	timer   *time.Timer
+	newItem chan interface{}
+	batch   batch
+	// batchStart is when the first item was added to the current batch.
+	batchStart time.Time
+}
+
 type batch interface {
 	// export the current batch
 	export(ctx context.Context) error
@@ -77,9 +113,13 @@ var _ consumer.Traces = (*batchProcessor)(nil)
 var _ consumer.Metrics = (*batchProcessor)(nil)
 var _ consumer.Logs = (*batchProcessor)(nil)
 
-func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, batch batch, telemetryLevel configtelemetry.Level) *batchProcessor {
+// errTooManyShards is returned when the data has a new combination of metadata values while
+// the number of shards is already cfg.MetadataCardinalityLimit.
+var errTooManyShards = consumererror.Permanent(errors.New("too many batcher metadata-value combinations"))
+
+func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, newBatch func() batch, telemetryLevel configtelemetry.Level) *batchProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &batchProcessor{
+	bp := &batchProcessor{
 		name:           cfg.Name(),
 		logger:         params.Logger,
 		telemetryLevel: telemetryLevel,
@@ -87,12 +127,30 @@ func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, batc
 		sendBatchSize:    cfg.SendBatchSize,
 		sendBatchMaxSize: cfg.SendBatchMaxSize,
 		timeout:          cfg.Timeout,
-		done:             make(chan struct{}, 1),
-		newItem:          make(chan interface{}, runtime.NumCPU()),
-		batch:            batch,
+		metadataLimit:    cfg.MetadataCardinalityLimit,
+		newBatch:         newBatch,
 		ctx:              ctx,
 		cancel:           cancel,
 	}
+	for _, key := range cfg.MetadataKeys {
+		bp.metadataKeys = append(bp.metadataKeys, strings.ToLower(key))
+	}
+	if len(bp.metadataKeys) == 0 {
+		bp.defaultShard = bp.newShard("", context.Background())
+	} else {
+		bp.shards = make(map[string]*shard)
+	}
+	return bp
+}
+
+func (bp *batchProcessor) newShard(key string, exportCtx context.Context) *shard {
+	return &shard{
+		bp:        bp,
+		key:       key,
+		exportCtx: exportCtx,
+		newItem:   make(chan interface{}, runtime.NumCPU()),
+		batch:     bp.newBatch(),
+	}
 }
 
 func (bp *batchProcessor) GetCapabilities() component.ProcessorCapabilities {
@@ -101,7 +159,9 @@ func (bp *batchProcessor) GetCapabilities() component.ProcessorCapabilities {
 
 // Start is invoked during service startup.
 func (bp *batchProcessor) Start(context.Context, component.Host) error {
-	go bp.startProcessingCycle()
+	if bp.defaultShard != nil {
+		bp.startShard(bp.defaultShard)
+	}
 	return nil
 }
 
@@ -109,141 +169,249 @@ func (bp *batchProcessor) Start(context.Context, component.Host) error {
 func (bp *batchProcessor) Shutdown(context.Context) error {
 	bp.cancel()
 
-	// Wait until current batch is drained.
-	<-bp.done
+	// Wait until the current batches are drained.
+	bp.shardsWG.Wait()
 	return nil
 }
 
-func (bp *batchProcessor) startProcessingCycle() {
-	bp.timer = time.NewTimer(bp.timeout)
+func (bp *batchProcessor) startShard(s *shard) {
+	bp.shardsWG.Add(1)
+	go func() {
+		defer bp.shardsWG.Done()
+		s.startProcessingCycle()
+	}()
+}
+
+// shardFor returns the shard batching the data received with the given context. The returned shard of metadata
+// values has a pending item, which must be released with releaseItem once sent to the shard.
+func (bp *batchProcessor) shardFor(ctx context.Context) (*shard, error) {
+	if bp.defaultShard != nil {
+		return bp.defaultShard, nil
+	}
+
+	var metadata map[string][]string
+	if c, ok := client.FromContext(ctx); ok {
+		metadata = c.Metadata
+	}
+	shardMetadata := make(map[string][]string, len(bp.metadataKeys))
+	var key strings.Builder
+	for _, k := range bp.metadataKeys {
+		values := metadata[k]
+		if len(values) > 0 {
+			shardMetadata[k] = values
+		}
+		key.WriteString(k)
+		for _, v := range values {
+			key.WriteByte(0)
+			key.WriteString(v)
+		}
+		key.WriteByte(1)
+	}
+
+	bp.shardsMu.Lock()
+	defer bp.shardsMu.Unlock()
+	if s, ok := bp.shards[key.String()]; ok {
+		s.pending++
+		return s, nil
+	}
+	if bp.metadataLimit > 0 && uint32(len(bp.shards)) >= bp.metadataLimit {
+		return nil, errTooManyShards
+	}
+	s := bp.newShard(key.String(), client.NewContext(context.Background(), &client.Client{Metadata: shardMetadata}))
+	s.pending++
+	bp.shards[s.key] = s
+	bp.startShard(s)
+	return s, nil
+}
+
+// releaseItem releases a pending item of the shard once it is sent to the shard.
+func (s *shard) releaseItem() {
+	if s == s.bp.defaultShard {
+		return
+	}
+	s.bp.shardsMu.Lock()
+	s.pending--
+	s.bp.shardsMu.Unlock()
+}
+
+// requeue sends an item to the shard asynchronously, the shard is not removed until the item is sent.
+func (s *shard) requeue(item interface{}) {
+	if s != s.bp.defaultShard {
+		s.bp.shardsMu.Lock()
+		s.pending++
+		s.bp.shardsMu.Unlock()
+	}
+	go func() {
+		s.newItem <- item
+		s.releaseItem()
+	}()
+}
+
+// removeIfIdle removes the shard of metadata values from the processor, unless items are pending or waiting to be
+// processed. It returns true if the shard is removed.
+func (s *shard) removeIfIdle() bool {
+	bp := s.bp
+	if s == bp.defaultShard {
+		return false
+	}
+	bp.shardsMu.Lock()
+	defer bp.shardsMu.Unlock()
+	if s.pending > 0 || len(s.newItem) > 0 {
+		return false
+	}
+	delete(bp.shards, s.key)
+	return true
+}
+
+func (s *shard) startProcessingCycle() {
+	s.timer = time.NewTimer(s.bp.timeout)
 	for {
 		select {
-		case <-bp.ctx.Done():
+		case <-s.bp.ctx.Done():
 		DONE:
 			for {
 				select {
-				case item := <-bp.newItem:
-					bp.processItem(item)
+				case item := <-s.newItem:
+					s.processItem(item)
 				default:
 					break DONE
 				}
 			}
 			// This is the close of the channel
-			if bp.batch.itemCount() > 0 {
+			if s.batch.itemCount() > 0 {
 				// TODO: Set a timeout on sendTraces or
 				// make it cancellable using the context that Shutdown gets as a parameter
-				bp.sendItems(statTimeoutTriggerSend)
+				s.sendItems(statTimeoutTriggerSend)
 			}
-			// Indicate that we finished draining.
-			close(bp.done)
 			return
-		case item := <-bp.newItem:
+		case item := <-s.newItem:
 			if item == nil {
 				continue
 			}
-			bp.processItem(item)
-		case <-bp.timer.C:
-			if bp.batch.itemCount() > 0 {
-				bp.sendItems(statTimeoutTriggerSend)
+			s.processItem(item)
+		case <-s.timer.C:
+			if s.batch.itemCount() > 0 {
+				s.sendItems(statTimeoutTriggerSend)
+			} else if !s.active && s.removeIfIdle() {
+				// The shard received no data for a whole timeout, a new one is created for its next data.
+				return
 			}
-			bp.resetTimer()
+			s.active = false
+			s.resetTimer()
 		}
 	}
 }
 
-func (bp *batchProcessor) processItem(item interface{}) {
+func (s *shard) processItem(item interface{}) {
+	bp := s.bp
 	if bp.sendBatchMaxSize > 0 {
 		if td, ok := item.(pdata.Traces); ok {
-			itemCount := bp.batch.itemCount()
+			itemCount := s.batch.itemCount()
 			if itemCount+uint32(td.SpanCount()) > bp.sendBatchMaxSize {
 				tdRemainSize := splitTrace(int(bp.sendBatchSize-itemCount), td)
 				item = tdRemainSize
-				go func() {
-					bp.newItem <- td
-				}()
+				s.requeue(td)
 			}
 		}
 		if td, ok := item.(pdata.Metrics); ok {
-			itemCount := bp.batch.itemCount()
+			itemCount := s.batch.itemCount()
 			if itemCount+uint32(td.MetricCount()) > bp.sendBatchMaxSize {
 				tdRemainSize := splitMetrics(int(bp.sendBatchSize-itemCount), td)
 				item = tdRemainSize
-				go func() {
-					bp.newItem <- td
-				}()
+				s.requeue(td)
 			}
 		}
 		if td, ok := item.(pdata.Logs); ok {
-			itemCount := bp.batch.itemCount()
+			itemCount := s.batch.itemCount()
 			if itemCount+uint32(td.LogRecordCount()) > bp.sendBatchMaxSize {
 				tdRemainSize := splitLogs(int(bp.sendBatchSize-itemCount), td)
 				item = tdRemainSize
-				go func() {
-					bp.newItem <- td
-				}()
+				s.requeue(td)
 			}
 		}
 	}
 
-	bp.batch.add(item)
-	if bp.batch.itemCount() >= bp.sendBatchSize {
-		bp.timer.Stop()
-		bp.sendItems(statBatchSizeTriggerSend)
-		bp.resetTimer()
+	s.active = true
+	if s.batch.itemCount() == 0 {
+		s.batchStart = time.Now()
+	}
+	s.batch.add(item)
+	if s.batch.itemCount() >= bp.sendBatchSize {
+		s.timer.Stop()
+		s.sendItems(statBatchSizeTriggerSend)
+		s.resetTimer()
 	}
 }
 
-func (bp *batchProcessor) resetTimer() {
-	bp.timer.Reset(bp.timeout)
+func (s *shard) resetTimer() {
+	s.timer.Reset(s.bp.timeout)
 }
 
-func (bp *batchProcessor) sendItems(measure *stats.Int64Measure) {
+func (s *shard) sendItems(measure *stats.Int64Measure) {
+	bp := s.bp
 	// Add that it came form the trace pipeline?
 	statsTags := []tag.Mutator{tag.Insert(processor.TagProcessorNameKey, bp.name)}
-	_ = stats.RecordWithTags(context.Background(), statsTags, measure.M(1), statBatchSendSize.M(int64(bp.batch.itemCount())))
+	_ = stats.RecordWithTags(context.Background(), statsTags, measure.M(1), statBatchSendSize.M(int64(s.batch.itemCount())))
 
 	if bp.telemetryLevel == configtelemetry.LevelDetailed {
-		_ = stats.RecordWithTags(context.Background(), statsTags, statBatchSendSizeBytes.M(int64(bp.batch.size())))
+		_ = stats.RecordWithTags(context.Background(), statsTags, statBatchSendSizeBytes.M(int64(s.batch.size())))
 	}
 
-	if err := bp.batch.export(context.Background()); err != nil {
+	// The span of the batch export shows how long the items waited in the batch, the spans of the next components are
+	// its children.
+	ctx, span := trace.StartSpan(s.exportCtx, "processor/"+bp.name+"/send_batch")
+	defer span.End()
+	span.AddAttributes(
+		trace.Int64Attribute("batch_size", int64(s.batch.itemCount())),
+		trace.StringAttribute("batch_wait", time.Since(s.batchStart).String()),
+	)
+
+	if err := s.batch.export(ctx); err != nil {
 		bp.logger.Warn("Sender failed", zap.Error(err))
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
 	}
-	bp.batch.reset()
+	s.batch.reset()
 }
 
-// ConsumeTraces implements TracesProcessor
-func (bp *batchProcessor) ConsumeTraces(_ context.Context, td pdata.Traces) error {
-	bp.newItem <- td
+// consume adds the item to the batch of the shard matching the context.
+func (bp *batchProcessor) consume(ctx context.Context, item interface{}) error {
+	s, err := bp.shardFor(ctx)
+	if err != nil {
+		return err
+	}
+	s.newItem <- item
+	s.releaseItem()
 	return nil
 }
 
+// ConsumeTraces implements TracesProcessor
+func (bp *batchProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return bp.consume(ctx, td)
+}
+
 // ConsumeTraces implements MetricsProcessor
-func (bp *batchProcessor) ConsumeMetrics(_ context.Context, md pdata.Metrics) error {
-	// First thing is convert into a different internal format
-	bp.newItem <- md
-	return nil
+func (bp *batchProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return bp.consume(ctx, md)
 }
 
 // ConsumeLogs implements LogsProcessor
-func (bp *batchProcessor) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
-	bp.newItem <- ld
-	return nil
+func (bp *batchProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return bp.consume(ctx, ld)
 }
 
 // newBatchTracesProcessor creates a new batch processor that batches traces by size or with timeout
 func newBatchTracesProcessor(params component.ProcessorCreateParams, trace consumer.Traces, cfg *Config, telemetryLevel configtelemetry.Level) *batchProcessor {
-	return newBatchProcessor(params, cfg, newBatchTraces(trace), telemetryLevel)
+	return newBatchProcessor(params, cfg, func() batch { return newBatchTraces(trace) }, telemetryLevel)
 }
 
 // newBatchMetricsProcessor creates a new batch processor that batches metrics by size or with timeout
 func newBatchMetricsProcessor(params component.ProcessorCreateParams, metrics consumer.Metrics, cfg *Config, telemetryLevel configtelemetry.Level) *batchProcessor {
-	return newBatchProcessor(params, cfg, newBatchMetrics(metrics), telemetryLevel)
+	return newBatchProcessor(params, cfg, func() batch { return newBatchMetrics(metrics) }, telemetryLevel)
 }
 
 // newBatchLogsProcessor creates a new batch processor that batches logs by size or with timeout
 func newBatchLogsProcessor(params component.ProcessorCreateParams, logs consumer.Logs, cfg *Config, telemetryLevel configtelemetry.Level) *batchProcessor {
-	return newBatchProcessor(params, cfg, newBatchLogs(logs), telemetryLevel)
+	return newBatchProcessor(params, cfg, func() batch { return newBatchLogs(logs) }, telemetryLevel)
 }
 
 type batchTraces struct {
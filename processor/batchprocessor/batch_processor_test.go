@@ -17,17 +17,21 @@ package batchprocessor
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/testdata"
@@ -73,6 +77,128 @@ func TestBatchProcessorSpansDelivered(t *testing.T) {
 	}
 }
 
+type spanRecorder struct {
+	spans chan *trace.SpanData
+}
+
+func (sr *spanRecorder) ExportSpan(sd *trace.SpanData) {
+	sr.spans <- sd
+}
+
+func TestBatchProcessorSendBatchSpan(t *testing.T) {
+	recorder := &spanRecorder{spans: make(chan *trace.SpanData, 10)}
+	trace.RegisterExporter(recorder)
+	defer trace.UnregisterExporter(recorder)
+
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	// Sample all the operations, as when the collector traces them.
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+	defer trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(1e-4)})
+
+	assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(10)))
+
+	var sd *trace.SpanData
+	select {
+	case sd = <-recorder.spans:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the batch export was not traced")
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, "processor/batch/send_batch", sd.Name)
+	assert.EqualValues(t, 10, sd.Attributes["batch_size"])
+	assert.Contains(t, sd.Attributes, "batch_wait")
+	assert.Equal(t, 10, sink.SpansCount())
+}
+
+// tenantSink records the number of spans of each batch by the tenant of its client metadata.
+type tenantSink struct {
+	mu      sync.Mutex
+	batches map[string][]int
+}
+
+func (ts *tenantSink) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	tenant := "<none>"
+	if c, ok := client.FromContext(ctx); ok && len(c.Metadata["x-tenant-id"]) > 0 {
+		tenant = c.Metadata["x-tenant-id"][0]
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.batches[tenant] = append(ts.batches[tenant], td.SpanCount())
+	return nil
+}
+
+func TestBatchProcessorMetadataKeys(t *testing.T) {
+	sink := &tenantSink{batches: map[string][]int{}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.Timeout = time.Hour
+	cfg.MetadataKeys = []string{"X-Tenant-ID"}
+	cfg.MetadataCardinalityLimit = 2
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), &client.Client{
+			IP:       "127.0.0.1",
+			Metadata: map[string][]string{"x-tenant-id": {tenant}, "x-other": {"ignored"}},
+		})
+	}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, batcher.ConsumeTraces(tenantCtx("a"), testdata.GenerateTraceDataManySpansSameResource(4)))
+		assert.NoError(t, batcher.ConsumeTraces(tenantCtx("b"), testdata.GenerateTraceDataManySpansSameResource(3)))
+	}
+	// The number of partitions is limited.
+	err := batcher.ConsumeTraces(context.Background(), testdata.GenerateTraceDataManySpansSameResource(1))
+	assert.True(t, consumererror.IsPermanent(err))
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	// The tenants are never mixed in a batch.
+	assert.Equal(t, map[string][]int{"a": {12}, "b": {9}}, sink.batches)
+}
+
+func TestBatchProcessorRemovesIdleShards(t *testing.T) {
+	sink := &tenantSink{batches: map[string][]int{}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.Timeout = 10 * time.Millisecond
+	cfg.MetadataKeys = []string{"X-Tenant-ID"}
+	cfg.MetadataCardinalityLimit = 1
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	shardCount := func() int {
+		batcher.shardsMu.Lock()
+		defer batcher.shardsMu.Unlock()
+		return len(batcher.shards)
+	}
+	tenantCtx := func(tenant string) context.Context {
+		return client.NewContext(context.Background(), &client.Client{
+			Metadata: map[string][]string{"x-tenant-id": {tenant}},
+		})
+	}
+
+	require.NoError(t, batcher.ConsumeTraces(tenantCtx("a"), testdata.GenerateTraceDataManySpansSameResource(2)))
+	assert.Equal(t, 1, shardCount())
+
+	// The shard of the tenant is removed once idle for a timeout, freeing its place for another tenant.
+	assert.Eventually(t, func() bool { return shardCount() == 0 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, batcher.ConsumeTraces(tenantCtx("b"), testdata.GenerateTraceDataManySpansSameResource(3)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	assert.Equal(t, map[string][]int{"a": {2}, "b": {3}}, sink.batches)
+}
+
 func TestBatchProcessorSpansDeliveredEnforceBatchSize(t *testing.T) {
 	sink := new(consumertest.TracesSink)
 	cfg := createDefaultConfig().(*Config)
@@ -15,6 +15,7 @@
 package batchprocessor
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -33,4 +34,22 @@ type Config struct {
 	// SendBatchMaxSize is the maximum size of a batch. Larger batches are split into smaller units.
 	// Default value is 0, that means no maximum size.
 	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size,omitempty"`
+
+	// MetadataKeys are the client metadata keys whose values partition the data in independent batches,
+	// e.g. the header carrying the tenant. The data is batched in a single partition when empty.
+	MetadataKeys []string `mapstructure:"metadata_keys,omitempty"`
+
+	// MetadataCardinalityLimit is the maximum number of partitions, the data with a new combination of
+	// metadata values is refused once it is reached. Default value is 1000, 0 means no limit.
+	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit,omitempty"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the maximum size of a batch is not smaller than the size triggering it to be sent.
+func (cfg *Config) Validate() error {
+	if cfg.SendBatchMaxSize > 0 && cfg.SendBatchMaxSize < cfg.SendBatchSize {
+		return errors.New("send_batch_max_size must be greater or equal to send_batch_size")
+	}
+	return nil
 }
@@ -53,8 +53,21 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "batch",
 				NameVal: "batch/2",
 			},
-			SendBatchSize:    sendBatchSize,
-			SendBatchMaxSize: sendBatchMaxSize,
-			Timeout:          timeout,
+			SendBatchSize:            sendBatchSize,
+			SendBatchMaxSize:         sendBatchMaxSize,
+			Timeout:                  timeout,
+			MetadataKeys:             []string{"X-Tenant-ID"},
+			MetadataCardinalityLimit: 100,
 		})
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.SendBatchMaxSize = cfg.SendBatchSize
+	assert.NoError(t, cfg.Validate())
+
+	cfg.SendBatchMaxSize = cfg.SendBatchSize - 1
+	assert.EqualError(t, cfg.Validate(), "send_batch_max_size must be greater or equal to send_batch_size")
+}
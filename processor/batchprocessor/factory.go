@@ -31,6 +31,8 @@ const (
 
 	defaultSendBatchSize = uint32(8192)
 	defaultTimeout       = 200 * time.Millisecond
+
+	defaultMetadataCardinalityLimit = uint32(1000)
 )
 
 // NewFactory returns a new factory for the Batch processor.
@@ -49,8 +51,9 @@ func createDefaultConfig() configmodels.Processor {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
-		SendBatchSize: defaultSendBatchSize,
-		Timeout:       defaultTimeout,
+		SendBatchSize:            defaultSendBatchSize,
+		Timeout:                  defaultTimeout,
+		MetadataCardinalityLimit: defaultMetadataCardinalityLimit,
 	}
 }
 
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/seriestracker"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// cumulativeToDeltaProcessor converts each cumulative data point to the difference with the previous data point of
+// its series. The first data point of a series only initializes its state and is dropped, as is a data point whose
+// value decreased without a new start time. A data point with a new start time is kept as is, its value is the delta
+// since the restart of the series.
+type cumulativeToDeltaProcessor struct {
+	logger  *zap.Logger
+	metrics map[string]bool
+	now     func() time.Time
+
+	mu      sync.Mutex
+	tracker *seriestracker.Tracker
+}
+
+func newCumulativeToDeltaProcessor(logger *zap.Logger, cfg *Config) *cumulativeToDeltaProcessor {
+	p := &cumulativeToDeltaProcessor{
+		logger:  logger,
+		now:     time.Now,
+		tracker: seriestracker.New(cfg.MaxSeries, cfg.MaxStaleness),
+	}
+	if len(cfg.Metrics) > 0 {
+		p.metrics = make(map[string]bool, len(cfg.Metrics))
+		for _, name := range cfg.Metrics {
+			p.metrics[name] = true
+		}
+	}
+	return p
+}
+
+// ProcessMetrics converts the cumulative sums and histograms to delta temporality.
+func (p *cumulativeToDeltaProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			ilm.Metrics().RemoveIf(func(metric pdata.Metric) bool {
+				if p.metrics != nil && !p.metrics[metric.Name()] {
+					return false
+				}
+				key := func(labels pdata.StringMap) string {
+					return seriestracker.Key(rm.Resource(), ilm.InstrumentationLibrary(), metric, labels)
+				}
+				return p.convertMetric(metric, key, now)
+			})
+		}
+	}
+	if md.MetricCount() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// convertMetric converts the data points of a cumulative metric, it returns whether the metric has no data point
+// left and must be removed.
+func (p *cumulativeToDeltaProcessor) convertMetric(metric pdata.Metric, key func(pdata.StringMap) string, now time.Time) bool {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		sum := metric.IntSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return false
+		}
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		sum.DataPoints().RemoveIf(func(dp pdata.IntDataPoint) bool {
+			return !p.convertIntPoint(dp, key(dp.LabelsMap()), sum.IsMonotonic(), now)
+		})
+		return sum.DataPoints().Len() == 0
+	case pdata.MetricDataTypeDoubleSum:
+		sum := metric.DoubleSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return false
+		}
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		sum.DataPoints().RemoveIf(func(dp pdata.DoubleDataPoint) bool {
+			return !p.convertDoublePoint(dp, key(dp.LabelsMap()), sum.IsMonotonic(), now)
+		})
+		return sum.DataPoints().Len() == 0
+	case pdata.MetricDataTypeIntHistogram:
+		histogram := metric.IntHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return false
+		}
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		histogram.DataPoints().RemoveIf(func(dp pdata.IntHistogramDataPoint) bool {
+			return !p.convertIntHistogramPoint(dp, key(dp.LabelsMap()), now)
+		})
+		return histogram.DataPoints().Len() == 0
+	case pdata.MetricDataTypeDoubleHistogram:
+		histogram := metric.DoubleHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return false
+		}
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		histogram.DataPoints().RemoveIf(func(dp pdata.DoubleHistogramDataPoint) bool {
+			return !p.convertDoubleHistogramPoint(dp, key(dp.LabelsMap()), now)
+		})
+		return histogram.DataPoints().Len() == 0
+	}
+	return false
+}
+
+// track returns the state of the series and whether the data point can be converted from it. The data point is
+// dropped when its series cannot be tracked or when it is not newer than the last data point of the series.
+func (p *cumulativeToDeltaProcessor) track(key string, timestamp pdata.Timestamp, now time.Time) (s *seriestracker.Series, found bool, keep bool) {
+	s, found = p.tracker.Get(key, now)
+	if s == nil {
+		p.logger.Debug("Dropping a data point of a new series, the maximum number of tracked series is reached.")
+		return nil, false, false
+	}
+	if found && timestamp <= s.Timestamp {
+		return s, found, false
+	}
+	return s, found, true
+}
+
+// restarted returns whether the series restarted with the data point, which is then kept as is.
+func restarted(s *seriestracker.Series, startTime pdata.Timestamp) bool {
+	return startTime != 0 && startTime != s.StartTime && startTime >= s.Timestamp
+}
+
+func (p *cumulativeToDeltaProcessor) convertIntPoint(dp pdata.IntDataPoint, key string, monotonic bool, now time.Time) bool {
+	s, found, keep := p.track(key, dp.Timestamp(), now)
+	if !keep {
+		return false
+	}
+	prev := *s
+	s.StartTime, s.Timestamp, s.IntValue = dp.StartTime(), dp.Timestamp(), dp.Value()
+	if !found || restarted(&prev, dp.StartTime()) {
+		return found
+	}
+	if dp.StartTime() != prev.StartTime || (monotonic && dp.Value() < prev.IntValue) {
+		return false
+	}
+	dp.SetStartTime(prev.Timestamp)
+	dp.SetValue(dp.Value() - prev.IntValue)
+	return true
+}
+
+func (p *cumulativeToDeltaProcessor) convertDoublePoint(dp pdata.DoubleDataPoint, key string, monotonic bool, now time.Time) bool {
+	s, found, keep := p.track(key, dp.Timestamp(), now)
+	if !keep {
+		return false
+	}
+	prev := *s
+	s.StartTime, s.Timestamp, s.DoubleValue = dp.StartTime(), dp.Timestamp(), dp.Value()
+	if !found || restarted(&prev, dp.StartTime()) {
+		return found
+	}
+	if dp.StartTime() != prev.StartTime || (monotonic && dp.Value() < prev.DoubleValue) {
+		return false
+	}
+	dp.SetStartTime(prev.Timestamp)
+	dp.SetValue(dp.Value() - prev.DoubleValue)
+	return true
+}
+
+func (p *cumulativeToDeltaProcessor) convertIntHistogramPoint(dp pdata.IntHistogramDataPoint, key string, now time.Time) bool {
+	s, found, keep := p.track(key, dp.Timestamp(), now)
+	if !keep {
+		return false
+	}
+	prev := *s
+	s.StartTime, s.Timestamp, s.Count, s.IntSum = dp.StartTime(), dp.Timestamp(), dp.Count(), dp.Sum()
+	s.BucketCounts = append([]uint64(nil), dp.BucketCounts()...)
+	s.ExplicitBounds = append([]float64(nil), dp.ExplicitBounds()...)
+	if !found || restarted(&prev, dp.StartTime()) {
+		return found
+	}
+	if dp.StartTime() != prev.StartTime || !histogramContinues(prev, dp.Count(), dp.BucketCounts(), dp.ExplicitBounds()) {
+		return false
+	}
+	dp.SetStartTime(prev.Timestamp)
+	dp.SetCount(dp.Count() - prev.Count)
+	dp.SetSum(dp.Sum() - prev.IntSum)
+	dp.SetBucketCounts(deltaBucketCounts(dp.BucketCounts(), prev.BucketCounts))
+	return true
+}
+
+func (p *cumulativeToDeltaProcessor) convertDoubleHistogramPoint(dp pdata.DoubleHistogramDataPoint, key string, now time.Time) bool {
+	s, found, keep := p.track(key, dp.Timestamp(), now)
+	if !keep {
+		return false
+	}
+	prev := *s
+	s.StartTime, s.Timestamp, s.Count, s.DoubleSum = dp.StartTime(), dp.Timestamp(), dp.Count(), dp.Sum()
+	s.BucketCounts = append([]uint64(nil), dp.BucketCounts()...)
+	s.ExplicitBounds = append([]float64(nil), dp.ExplicitBounds()...)
+	if !found || restarted(&prev, dp.StartTime()) {
+		return found
+	}
+	if dp.StartTime() != prev.StartTime || !histogramContinues(prev, dp.Count(), dp.BucketCounts(), dp.ExplicitBounds()) {
+		return false
+	}
+	dp.SetStartTime(prev.Timestamp)
+	dp.SetCount(dp.Count() - prev.Count)
+	dp.SetSum(dp.Sum() - prev.DoubleSum)
+	dp.SetBucketCounts(deltaBucketCounts(dp.BucketCounts(), prev.BucketCounts))
+	return true
+}
+
+// histogramContinues returns whether a cumulative histogram data point continues the previous data point of its
+// series: it has the same buckets and none of its counts decreased.
+func histogramContinues(prev seriestracker.Series, count uint64, bucketCounts []uint64, explicitBounds []float64) bool {
+	if count < prev.Count || len(bucketCounts) != len(prev.BucketCounts) || len(explicitBounds) != len(prev.ExplicitBounds) {
+		return false
+	}
+	for i, bound := range explicitBounds {
+		if bound != prev.ExplicitBounds[i] {
+			return false
+		}
+	}
+	for i, c := range bucketCounts {
+		if c < prev.BucketCounts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// deltaBucketCounts returns the differences between the bucket counts and the previous ones.
+func deltaBucketCounts(bucketCounts, prev []uint64) []uint64 {
+	delta := make([]uint64, len(bucketCounts))
+	for i, c := range bucketCounts {
+		delta[i] = c - prev[i]
+	}
+	return delta
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// newMetrics returns a metric with a data point of each given value, the data points differ by the "series" label.
+func newMetrics(name string, dataType pdata.MetricDataType, temporality pdata.AggregationTemporality, start, ts pdata.Timestamp, values ...int64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(name)
+	metric.SetDataType(dataType)
+	for i, v := range values {
+		labels := map[string]string{"series": string(rune('a' + i))}
+		switch dataType {
+		case pdata.MetricDataTypeIntSum:
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(temporality)
+			dp := metric.IntSum().DataPoints().AppendEmpty()
+			dp.LabelsMap().InitFromMap(labels)
+			dp.SetStartTime(start)
+			dp.SetTimestamp(ts)
+			dp.SetValue(v)
+		case pdata.MetricDataTypeDoubleSum:
+			metric.DoubleSum().SetIsMonotonic(true)
+			metric.DoubleSum().SetAggregationTemporality(temporality)
+			dp := metric.DoubleSum().DataPoints().AppendEmpty()
+			dp.LabelsMap().InitFromMap(labels)
+			dp.SetStartTime(start)
+			dp.SetTimestamp(ts)
+			dp.SetValue(float64(v))
+		case pdata.MetricDataTypeDoubleHistogram:
+			metric.DoubleHistogram().SetAggregationTemporality(temporality)
+			dp := metric.DoubleHistogram().DataPoints().AppendEmpty()
+			dp.LabelsMap().InitFromMap(labels)
+			dp.SetStartTime(start)
+			dp.SetTimestamp(ts)
+			dp.SetCount(uint64(v))
+			dp.SetSum(float64(10 * v))
+			dp.SetBucketCounts([]uint64{uint64(v), 0})
+			dp.SetExplicitBounds([]float64{100})
+		}
+	}
+	return md
+}
+
+func process(t *testing.T, p *cumulativeToDeltaProcessor, md pdata.Metrics) pdata.Metrics {
+	out, err := p.ProcessMetrics(context.Background(), md)
+	if err == processorhelper.ErrSkipProcessingData {
+		return pdata.NewMetrics()
+	}
+	require.NoError(t, err)
+	return out
+}
+
+func TestCumulativeToDeltaSum(t *testing.T) {
+	p := newCumulativeToDeltaProcessor(zap.NewNop(), createDefaultConfig().(*Config))
+
+	// The first data points only initialize the series.
+	out := process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 10, 5, 7))
+	assert.Equal(t, 0, out.MetricCount())
+
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 20, 8, 10))
+	require.Equal(t, 1, out.MetricCount())
+	sum := out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum()
+	assert.Equal(t, pdata.AggregationTemporalityDelta, sum.AggregationTemporality())
+	require.Equal(t, 2, sum.DataPoints().Len())
+	assert.EqualValues(t, 10, sum.DataPoints().At(0).StartTime())
+	assert.EqualValues(t, 20, sum.DataPoints().At(0).Timestamp())
+	assert.EqualValues(t, 3, sum.DataPoints().At(0).Value())
+	assert.EqualValues(t, 3, sum.DataPoints().At(1).Value())
+
+	// A data point not newer than the last one is dropped.
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 20, 9))
+	assert.Equal(t, 0, out.MetricCount())
+
+	// A restarted series keeps the value since its new start time, a decreased value without a new start time is
+	// dropped.
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 25, 30, 2))
+	require.Equal(t, 1, out.MetricCount())
+	dp := out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().At(0)
+	assert.EqualValues(t, 25, dp.StartTime())
+	assert.EqualValues(t, 2, dp.Value())
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 25, 40, 1))
+	assert.Equal(t, 0, out.MetricCount())
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 25, 50, 4))
+	require.Equal(t, 1, out.MetricCount())
+	dp = out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().At(0)
+	assert.EqualValues(t, 40, dp.StartTime())
+	assert.EqualValues(t, 3, dp.Value())
+
+	out = process(t, p, newMetrics("latency", pdata.MetricDataTypeDoubleSum, pdata.AggregationTemporalityCumulative, 1, 10, 5))
+	assert.Equal(t, 0, out.MetricCount())
+	out = process(t, p, newMetrics("latency", pdata.MetricDataTypeDoubleSum, pdata.AggregationTemporalityCumulative, 1, 20, 8))
+	require.Equal(t, 1, out.MetricCount())
+	assert.EqualValues(t, 3, out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleSum().DataPoints().At(0).Value())
+}
+
+func TestCumulativeToDeltaHistogram(t *testing.T) {
+	p := newCumulativeToDeltaProcessor(zap.NewNop(), createDefaultConfig().(*Config))
+
+	out := process(t, p, newMetrics("duration", pdata.MetricDataTypeDoubleHistogram, pdata.AggregationTemporalityCumulative, 1, 10, 5))
+	assert.Equal(t, 0, out.MetricCount())
+	out = process(t, p, newMetrics("duration", pdata.MetricDataTypeDoubleHistogram, pdata.AggregationTemporalityCumulative, 1, 20, 8))
+	require.Equal(t, 1, out.MetricCount())
+	histogram := out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleHistogram()
+	assert.Equal(t, pdata.AggregationTemporalityDelta, histogram.AggregationTemporality())
+	dp := histogram.DataPoints().At(0)
+	assert.EqualValues(t, 10, dp.StartTime())
+	assert.EqualValues(t, 3, dp.Count())
+	assert.EqualValues(t, 30, dp.Sum())
+	assert.Equal(t, []uint64{3, 0}, dp.BucketCounts())
+}
+
+func TestCumulativeToDeltaPassThrough(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics = []string{"requests"}
+	p := newCumulativeToDeltaProcessor(zap.NewNop(), cfg)
+
+	// The metrics not configured and the delta metrics are not converted.
+	out := process(t, p, newMetrics("errors", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 10, 5))
+	assert.Equal(t, newMetrics("errors", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 10, 5), out)
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 1, 10, 5))
+	assert.Equal(t, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 1, 10, 5), out)
+}
+
+func TestCumulativeToDeltaMaxSeries(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxSeries = 1
+	cfg.MaxStaleness = time.Minute
+	p := newCumulativeToDeltaProcessor(zap.NewNop(), cfg)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 10, 5, 7))
+	out := process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 20, 8, 10))
+	require.Equal(t, 1, out.MetricCount())
+	assert.Equal(t, 1, out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().Len())
+
+	// The stale series are evicted, the next data point initializes the series again.
+	now = now.Add(time.Minute)
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 30, 12))
+	assert.Equal(t, 0, out.MetricCount())
+}
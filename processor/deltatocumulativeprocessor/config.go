@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the Delta to Cumulative processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Metrics are the names of the converted metrics, all the delta sums and histograms are converted when empty.
+	Metrics []string `mapstructure:"metrics"`
+
+	// MaxSeries is the maximum number of tracked series, the data points of the new series are dropped once it is
+	// reached.
+	MaxSeries int `mapstructure:"max_series"`
+
+	// MaxStaleness is the time after which a series without new data points is no longer tracked.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the tracking limits are positive.
+func (cfg *Config) Validate() error {
+	if cfg.MaxSeries <= 0 {
+		return errors.New("max_series must be positive")
+	}
+	if cfg.MaxStaleness <= 0 {
+		return errors.New("max_staleness must be positive")
+	}
+	return nil
+}
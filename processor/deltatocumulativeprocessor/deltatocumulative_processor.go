@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/seriestracker"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// deltaToCumulativeProcessor converts each delta data point to the sum of the data points of its series since the
+// series is tracked, which is the start time of the cumulative data points. A data point not newer than the last
+// data point of its series is dropped, as is a data point of a new series once the maximum number of tracked series
+// is reached. A histogram whose buckets changed starts over from the data point.
+type deltaToCumulativeProcessor struct {
+	logger  *zap.Logger
+	metrics map[string]bool
+	now     func() time.Time
+
+	mu      sync.Mutex
+	tracker *seriestracker.Tracker
+}
+
+func newDeltaToCumulativeProcessor(logger *zap.Logger, cfg *Config) *deltaToCumulativeProcessor {
+	p := &deltaToCumulativeProcessor{
+		logger:  logger,
+		now:     time.Now,
+		tracker: seriestracker.New(cfg.MaxSeries, cfg.MaxStaleness),
+	}
+	if len(cfg.Metrics) > 0 {
+		p.metrics = make(map[string]bool, len(cfg.Metrics))
+		for _, name := range cfg.Metrics {
+			p.metrics[name] = true
+		}
+	}
+	return p
+}
+
+// ProcessMetrics converts the delta sums and histograms to cumulative temporality.
+func (p *deltaToCumulativeProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			ilm.Metrics().RemoveIf(func(metric pdata.Metric) bool {
+				if p.metrics != nil && !p.metrics[metric.Name()] {
+					return false
+				}
+				key := func(labels pdata.StringMap) string {
+					return seriestracker.Key(rm.Resource(), ilm.InstrumentationLibrary(), metric, labels)
+				}
+				return p.convertMetric(metric, key, now)
+			})
+		}
+	}
+	if md.MetricCount() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// convertMetric converts the data points of a delta metric, it returns whether the metric has no data point left
+// and must be removed.
+func (p *deltaToCumulativeProcessor) convertMetric(metric pdata.Metric, key func(pdata.StringMap) string, now time.Time) bool {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		sum := metric.IntSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return false
+		}
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		sum.DataPoints().RemoveIf(func(dp pdata.IntDataPoint) bool {
+			return !p.convertIntPoint(dp, key(dp.LabelsMap()), now)
+		})
+		return sum.DataPoints().Len() == 0
+	case pdata.MetricDataTypeDoubleSum:
+		sum := metric.DoubleSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return false
+		}
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		sum.DataPoints().RemoveIf(func(dp pdata.DoubleDataPoint) bool {
+			return !p.convertDoublePoint(dp, key(dp.LabelsMap()), now)
+		})
+		return sum.DataPoints().Len() == 0
+	case pdata.MetricDataTypeIntHistogram:
+		histogram := metric.IntHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return false
+		}
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		histogram.DataPoints().RemoveIf(func(dp pdata.IntHistogramDataPoint) bool {
+			return !p.convertIntHistogramPoint(dp, key(dp.LabelsMap()), now)
+		})
+		return histogram.DataPoints().Len() == 0
+	case pdata.MetricDataTypeDoubleHistogram:
+		histogram := metric.DoubleHistogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return false
+		}
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		histogram.DataPoints().RemoveIf(func(dp pdata.DoubleHistogramDataPoint) bool {
+			return !p.convertDoubleHistogramPoint(dp, key(dp.LabelsMap()), now)
+		})
+		return histogram.DataPoints().Len() == 0
+	}
+	return false
+}
+
+// track returns the state of the series of the data point, started at the data point if the series is new, or nil
+// if the data point must be dropped.
+func (p *deltaToCumulativeProcessor) track(key string, startTime, timestamp pdata.Timestamp, now time.Time) *seriestracker.Series {
+	s, found := p.tracker.Get(key, now)
+	if s == nil {
+		p.logger.Debug("Dropping a data point of a new series, the maximum number of tracked series is reached.")
+		return nil
+	}
+	if found && timestamp <= s.Timestamp {
+		return nil
+	}
+	if !found {
+		s.StartTime = startTime
+	}
+	s.Timestamp = timestamp
+	return s
+}
+
+func (p *deltaToCumulativeProcessor) convertIntPoint(dp pdata.IntDataPoint, key string, now time.Time) bool {
+	s := p.track(key, dp.StartTime(), dp.Timestamp(), now)
+	if s == nil {
+		return false
+	}
+	s.IntValue += dp.Value()
+	dp.SetStartTime(s.StartTime)
+	dp.SetValue(s.IntValue)
+	return true
+}
+
+func (p *deltaToCumulativeProcessor) convertDoublePoint(dp pdata.DoubleDataPoint, key string, now time.Time) bool {
+	s := p.track(key, dp.StartTime(), dp.Timestamp(), now)
+	if s == nil {
+		return false
+	}
+	s.DoubleValue += dp.Value()
+	dp.SetStartTime(s.StartTime)
+	dp.SetValue(s.DoubleValue)
+	return true
+}
+
+func (p *deltaToCumulativeProcessor) convertIntHistogramPoint(dp pdata.IntHistogramDataPoint, key string, now time.Time) bool {
+	s := p.track(key, dp.StartTime(), dp.Timestamp(), now)
+	if s == nil {
+		return false
+	}
+	if !sameBuckets(s, dp.BucketCounts(), dp.ExplicitBounds()) {
+		*s = seriestracker.Series{StartTime: dp.StartTime(), Timestamp: dp.Timestamp()}
+		s.ExplicitBounds = append([]float64(nil), dp.ExplicitBounds()...)
+		s.BucketCounts = make([]uint64, len(dp.BucketCounts()))
+	}
+	s.Count += dp.Count()
+	s.IntSum += dp.Sum()
+	addBucketCounts(s.BucketCounts, dp.BucketCounts())
+	dp.SetStartTime(s.StartTime)
+	dp.SetCount(s.Count)
+	dp.SetSum(s.IntSum)
+	dp.SetBucketCounts(append([]uint64(nil), s.BucketCounts...))
+	return true
+}
+
+func (p *deltaToCumulativeProcessor) convertDoubleHistogramPoint(dp pdata.DoubleHistogramDataPoint, key string, now time.Time) bool {
+	s := p.track(key, dp.StartTime(), dp.Timestamp(), now)
+	if s == nil {
+		return false
+	}
+	if !sameBuckets(s, dp.BucketCounts(), dp.ExplicitBounds()) {
+		*s = seriestracker.Series{StartTime: dp.StartTime(), Timestamp: dp.Timestamp()}
+		s.ExplicitBounds = append([]float64(nil), dp.ExplicitBounds()...)
+		s.BucketCounts = make([]uint64, len(dp.BucketCounts()))
+	}
+	s.Count += dp.Count()
+	s.DoubleSum += dp.Sum()
+	addBucketCounts(s.BucketCounts, dp.BucketCounts())
+	dp.SetStartTime(s.StartTime)
+	dp.SetCount(s.Count)
+	dp.SetSum(s.DoubleSum)
+	dp.SetBucketCounts(append([]uint64(nil), s.BucketCounts...))
+	return true
+}
+
+// sameBuckets returns whether the histogram data point has the same buckets as the series.
+func sameBuckets(s *seriestracker.Series, bucketCounts []uint64, explicitBounds []float64) bool {
+	if s.BucketCounts == nil || len(bucketCounts) != len(s.BucketCounts) || len(explicitBounds) != len(s.ExplicitBounds) {
+		return false
+	}
+	for i, bound := range explicitBounds {
+		if bound != s.ExplicitBounds[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// addBucketCounts adds the bucket counts of a data point to the ones of its series.
+func addBucketCounts(sum, bucketCounts []uint64) {
+	for i, c := range bucketCounts {
+		sum[i] += c
+	}
+}
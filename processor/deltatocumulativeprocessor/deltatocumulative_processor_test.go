@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// newMetrics returns a metric with a data point of each given value, the data points differ by the "series" label.
+func newMetrics(name string, dataType pdata.MetricDataType, temporality pdata.AggregationTemporality, start, ts pdata.Timestamp, values ...int64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(name)
+	metric.SetDataType(dataType)
+	for i, v := range values {
+		labels := map[string]string{"series": string(rune('a' + i))}
+		switch dataType {
+		case pdata.MetricDataTypeIntSum:
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(temporality)
+			dp := metric.IntSum().DataPoints().AppendEmpty()
+			dp.LabelsMap().InitFromMap(labels)
+			dp.SetStartTime(start)
+			dp.SetTimestamp(ts)
+			dp.SetValue(v)
+		case pdata.MetricDataTypeDoubleSum:
+			metric.DoubleSum().SetIsMonotonic(true)
+			metric.DoubleSum().SetAggregationTemporality(temporality)
+			dp := metric.DoubleSum().DataPoints().AppendEmpty()
+			dp.LabelsMap().InitFromMap(labels)
+			dp.SetStartTime(start)
+			dp.SetTimestamp(ts)
+			dp.SetValue(float64(v))
+		case pdata.MetricDataTypeDoubleHistogram:
+			metric.DoubleHistogram().SetAggregationTemporality(temporality)
+			dp := metric.DoubleHistogram().DataPoints().AppendEmpty()
+			dp.LabelsMap().InitFromMap(labels)
+			dp.SetStartTime(start)
+			dp.SetTimestamp(ts)
+			dp.SetCount(uint64(v))
+			dp.SetSum(float64(10 * v))
+			dp.SetBucketCounts([]uint64{uint64(v), 0})
+			dp.SetExplicitBounds([]float64{100})
+		}
+	}
+	return md
+}
+
+func process(t *testing.T, p *deltaToCumulativeProcessor, md pdata.Metrics) pdata.Metrics {
+	out, err := p.ProcessMetrics(context.Background(), md)
+	if err == processorhelper.ErrSkipProcessingData {
+		return pdata.NewMetrics()
+	}
+	require.NoError(t, err)
+	return out
+}
+
+func TestDeltaToCumulativeSum(t *testing.T) {
+	p := newDeltaToCumulativeProcessor(zap.NewNop(), createDefaultConfig().(*Config))
+
+	out := process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 1, 10, 5, 7))
+	require.Equal(t, 1, out.MetricCount())
+	sum := out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum()
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	require.Equal(t, 2, sum.DataPoints().Len())
+	assert.EqualValues(t, 1, sum.DataPoints().At(0).StartTime())
+	assert.EqualValues(t, 5, sum.DataPoints().At(0).Value())
+	assert.EqualValues(t, 7, sum.DataPoints().At(1).Value())
+
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 10, 20, 3, 1))
+	require.Equal(t, 1, out.MetricCount())
+	sum = out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum()
+	assert.EqualValues(t, 1, sum.DataPoints().At(0).StartTime())
+	assert.EqualValues(t, 20, sum.DataPoints().At(0).Timestamp())
+	assert.EqualValues(t, 8, sum.DataPoints().At(0).Value())
+	assert.EqualValues(t, 8, sum.DataPoints().At(1).Value())
+
+	// A data point not newer than the last one is dropped.
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 10, 20, 3))
+	assert.Equal(t, 0, out.MetricCount())
+
+	out = process(t, p, newMetrics("latency", pdata.MetricDataTypeDoubleSum, pdata.AggregationTemporalityDelta, 1, 10, 5))
+	require.Equal(t, 1, out.MetricCount())
+	out = process(t, p, newMetrics("latency", pdata.MetricDataTypeDoubleSum, pdata.AggregationTemporalityDelta, 10, 20, 3))
+	require.Equal(t, 1, out.MetricCount())
+	assert.EqualValues(t, 8, out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleSum().DataPoints().At(0).Value())
+}
+
+func TestDeltaToCumulativeHistogram(t *testing.T) {
+	p := newDeltaToCumulativeProcessor(zap.NewNop(), createDefaultConfig().(*Config))
+
+	process(t, p, newMetrics("duration", pdata.MetricDataTypeDoubleHistogram, pdata.AggregationTemporalityDelta, 1, 10, 5))
+	out := process(t, p, newMetrics("duration", pdata.MetricDataTypeDoubleHistogram, pdata.AggregationTemporalityDelta, 10, 20, 3))
+	require.Equal(t, 1, out.MetricCount())
+	histogram := out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleHistogram()
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, histogram.AggregationTemporality())
+	dp := histogram.DataPoints().At(0)
+	assert.EqualValues(t, 1, dp.StartTime())
+	assert.EqualValues(t, 8, dp.Count())
+	assert.EqualValues(t, 80, dp.Sum())
+	assert.Equal(t, []uint64{8, 0}, dp.BucketCounts())
+
+	// The series starts over when the buckets change.
+	md := newMetrics("duration", pdata.MetricDataTypeDoubleHistogram, pdata.AggregationTemporalityDelta, 20, 30, 2)
+	dp = md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleHistogram().DataPoints().At(0)
+	dp.SetExplicitBounds([]float64{50})
+	out = process(t, p, md)
+	require.Equal(t, 1, out.MetricCount())
+	dp = out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleHistogram().DataPoints().At(0)
+	assert.EqualValues(t, 20, dp.StartTime())
+	assert.EqualValues(t, 2, dp.Count())
+	assert.Equal(t, []uint64{2, 0}, dp.BucketCounts())
+}
+
+func TestDeltaToCumulativePassThrough(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics = []string{"requests"}
+	p := newDeltaToCumulativeProcessor(zap.NewNop(), cfg)
+
+	// The metrics not configured and the cumulative metrics are not converted.
+	out := process(t, p, newMetrics("errors", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 1, 10, 5))
+	assert.Equal(t, newMetrics("errors", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 1, 10, 5), out)
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 10, 5))
+	assert.Equal(t, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityCumulative, 1, 10, 5), out)
+}
+
+func TestDeltaToCumulativeMaxSeries(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxSeries = 1
+	cfg.MaxStaleness = time.Minute
+	p := newDeltaToCumulativeProcessor(zap.NewNop(), cfg)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	out := process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 1, 10, 5, 7))
+	require.Equal(t, 1, out.MetricCount())
+	assert.Equal(t, 1, out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().Len())
+
+	// The stale series are evicted, the next data point starts the series again.
+	now = now.Add(time.Minute)
+	out = process(t, p, newMetrics("requests", pdata.MetricDataTypeIntSum, pdata.AggregationTemporalityDelta, 20, 30, 3))
+	require.Equal(t, 1, out.MetricCount())
+	dp := out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().At(0)
+	assert.EqualValues(t, 20, dp.StartTime())
+	assert.EqualValues(t, 3, dp.Value())
+}
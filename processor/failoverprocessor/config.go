@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the Failover processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Exporters are the names of the candidate exporters by decreasing priority, they must be in the exporters
+	// of the pipeline. The data is sent to one of them only.
+	Exporters []string `mapstructure:"exporters"`
+
+	// MaxConsecutiveFailures is the number of consecutive errors of the active exporter after which the data
+	// is sent to the next exporter of the list. Default value is 3.
+	MaxConsecutiveFailures int `mapstructure:"max_consecutive_failures"`
+
+	// RetryInterval is the interval between the probes of the exporters having a higher priority than the
+	// active one, the data is sent to them first and the first one succeeding becomes active again.
+	// Default value is 30s.
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that there are exporters to fail over to and that the thresholds are positive.
+func (cfg *Config) Validate() error {
+	if len(cfg.Exporters) < 2 {
+		return errors.New("exporters must have at least two exporters")
+	}
+	seen := make(map[string]bool, len(cfg.Exporters))
+	for _, name := range cfg.Exporters {
+		if seen[name] {
+			return errors.New("exporters must not have duplicates")
+		}
+		seen[name] = true
+	}
+	if cfg.MaxConsecutiveFailures < 1 {
+		return errors.New("max_consecutive_failures must be at least 1")
+	}
+	if cfg.RetryInterval <= 0 {
+		return errors.New("retry_interval must be positive")
+	}
+	return nil
+}
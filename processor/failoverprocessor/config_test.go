@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, cfg.Processors["failover"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "failover",
+				NameVal: "failover",
+			},
+			Exporters:              []string{"nop/primary", "nop/secondary"},
+			MaxConsecutiveFailures: 3,
+			RetryInterval:          30 * time.Second,
+		})
+
+	assert.Equal(t, cfg.Processors["failover/dr"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "failover",
+				NameVal: "failover/dr",
+			},
+			Exporters:              []string{"nop/primary", "nop/secondary", "nop/dr"},
+			MaxConsecutiveFailures: 1,
+			RetryInterval:          time.Minute,
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "exporters must have at least two exporters")
+
+	cfg.Exporters = []string{"otlp", "otlp"}
+	assert.EqualError(t, cfg.Validate(), "exporters must not have duplicates")
+
+	cfg.Exporters = []string{"otlp", "otlp/dr"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxConsecutiveFailures = 0
+	assert.EqualError(t, cfg.Validate(), "max_consecutive_failures must be at least 1")
+
+	cfg.MaxConsecutiveFailures = 1
+	cfg.RetryInterval = 0
+	assert.EqualError(t, cfg.Validate(), "retry_interval must be positive")
+}
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverprocessor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// failover tracks the active exporter, the exporters are resolved when the processor starts.
+type failover struct {
+	logger *zap.Logger
+	config *Config
+	now    func() time.Time
+
+	mu sync.Mutex
+	// active is the index of the exporter receiving the data.
+	active int
+	// failures is the number of consecutive errors of the active exporter.
+	failures int
+	// lastProbe is the last time the exporters before the active one were probed.
+	lastProbe time.Time
+}
+
+func newFailover(logger *zap.Logger, cfg *Config) failover {
+	return failover{logger: logger, config: cfg, now: time.Now}
+}
+
+// exporters returns the exporters of the data type by decreasing priority.
+func (f *failover) exporters(host component.Host, dataType configmodels.DataType) ([]component.Exporter, error) {
+	available := make(map[string]component.Exporter)
+	for entity, exp := range host.GetExporters()[dataType] {
+		available[entity.Name()] = exp
+	}
+	exps := make([]component.Exporter, 0, len(f.config.Exporters))
+	for _, name := range f.config.Exporters {
+		exp, ok := available[name]
+		if !ok {
+			return nil, fmt.Errorf("the %s exporter %q of the failover processor %q is not in the exporters of the pipeline", dataType, name, f.config.Name())
+		}
+		exps = append(exps, exp)
+	}
+	return exps, nil
+}
+
+// consume sends the data with send to the active exporter. When it is time to probe, the exporters having a
+// higher priority are tried first and the first one succeeding becomes active. An error of the active exporter
+// that makes it reach the maximum of consecutive failures fails over to the next exporter, which is sent the
+// same data.
+func (f *failover) consume(send func(index int) error) error {
+	f.mu.Lock()
+	active := f.active
+	probe := active > 0 && f.now().Sub(f.lastProbe) >= f.config.RetryInterval
+	if probe {
+		f.lastProbe = f.now()
+	}
+	f.mu.Unlock()
+
+	if probe {
+		for i := 0; i < active; i++ {
+			if send(i) == nil {
+				f.recovered(i)
+				return nil
+			}
+		}
+	}
+
+	for {
+		err := send(active)
+		next, retry := f.report(active, err)
+		if !retry {
+			return err
+		}
+		active = next
+	}
+}
+
+// report records the result of sending the data to the exporter at index, it returns the index of the exporter
+// to send the data to when it failed over.
+func (f *failover) report(index int, err error) (int, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err != nil && consumererror.IsPermanent(err) {
+		// The data is rejected, the exporter is healthy.
+		err = nil
+	}
+	if index == f.active {
+		if err == nil {
+			f.failures = 0
+			return 0, false
+		}
+		f.failures++
+		if f.failures >= f.config.MaxConsecutiveFailures && f.active < len(f.config.Exporters)-1 {
+			f.active++
+			f.failures = 0
+			f.lastProbe = f.now()
+			f.logger.Warn("Failing over to the next exporter",
+				zap.String("from", f.config.Exporters[index]),
+				zap.String("to", f.config.Exporters[f.active]),
+				zap.Error(err))
+		}
+	}
+	// Another call may have failed over meanwhile.
+	if err != nil && f.active > index {
+		return f.active, true
+	}
+	return 0, false
+}
+
+// recovered makes the exporter at index active after it succeeded during a probe.
+func (f *failover) recovered(index int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if index < f.active {
+		f.logger.Info("Exporter recovered",
+			zap.String("from", f.config.Exporters[f.active]),
+			zap.String("to", f.config.Exporters[index]))
+		f.active = index
+		f.failures = 0
+	}
+}
+
+func (f *failover) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// Shutdown is invoked during service shutdown.
+func (f *failover) Shutdown(context.Context) error {
+	return nil
+}
+
+type tracesProcessor struct {
+	failover
+	exps []consumer.Traces
+}
+
+var _ component.TracesProcessor = (*tracesProcessor)(nil)
+
+func newTracesProcessor(logger *zap.Logger, cfg *Config) *tracesProcessor {
+	return &tracesProcessor{failover: newFailover(logger, cfg)}
+}
+
+// Start resolves the exporters.
+func (tp *tracesProcessor) Start(_ context.Context, host component.Host) error {
+	exps, err := tp.exporters(host, configmodels.TracesDataType)
+	if err != nil {
+		return err
+	}
+	tp.exps = make([]consumer.Traces, 0, len(exps))
+	for _, exp := range exps {
+		tp.exps = append(tp.exps, exp.(consumer.Traces))
+	}
+	return nil
+}
+
+// ConsumeTraces sends the traces to the active exporter.
+func (tp *tracesProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return tp.consume(func(index int) error {
+		return tp.exps[index].ConsumeTraces(ctx, td)
+	})
+}
+
+type metricsProcessor struct {
+	failover
+	exps []consumer.Metrics
+}
+
+var _ component.MetricsProcessor = (*metricsProcessor)(nil)
+
+func newMetricsProcessor(logger *zap.Logger, cfg *Config) *metricsProcessor {
+	return &metricsProcessor{failover: newFailover(logger, cfg)}
+}
+
+// Start resolves the exporters.
+func (mp *metricsProcessor) Start(_ context.Context, host component.Host) error {
+	exps, err := mp.exporters(host, configmodels.MetricsDataType)
+	if err != nil {
+		return err
+	}
+	mp.exps = make([]consumer.Metrics, 0, len(exps))
+	for _, exp := range exps {
+		mp.exps = append(mp.exps, exp.(consumer.Metrics))
+	}
+	return nil
+}
+
+// ConsumeMetrics sends the metrics to the active exporter.
+func (mp *metricsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return mp.consume(func(index int) error {
+		return mp.exps[index].ConsumeMetrics(ctx, md)
+	})
+}
+
+type logsProcessor struct {
+	failover
+	exps []consumer.Logs
+}
+
+var _ component.LogsProcessor = (*logsProcessor)(nil)
+
+func newLogsProcessor(logger *zap.Logger, cfg *Config) *logsProcessor {
+	return &logsProcessor{failover: newFailover(logger, cfg)}
+}
+
+// Start resolves the exporters.
+func (lp *logsProcessor) Start(_ context.Context, host component.Host) error {
+	exps, err := lp.exporters(host, configmodels.LogsDataType)
+	if err != nil {
+		return err
+	}
+	lp.exps = make([]consumer.Logs, 0, len(exps))
+	for _, exp := range exps {
+		lp.exps = append(lp.exps, exp.(consumer.Logs))
+	}
+	return nil
+}
+
+// ConsumeLogs sends the logs to the active exporter.
+func (lp *logsProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return lp.consume(func(index int) error {
+		return lp.exps[index].ConsumeLogs(ctx, ld)
+	})
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+// sinkExporter records the data of all the signals, or fails with err when set.
+type sinkExporter struct {
+	consumertest.TracesSink
+	consumertest.MetricsSink
+	consumertest.LogsSink
+	err error
+}
+
+func (e *sinkExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *sinkExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *sinkExporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func (e *sinkExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.MetricsSink.ConsumeMetrics(ctx, md)
+}
+
+func (e *sinkExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.LogsSink.ConsumeLogs(ctx, ld)
+}
+
+// exportersHost returns the exporters by name for all the data types.
+type exportersHost struct {
+	component.Host
+	exporters map[string]*sinkExporter
+}
+
+func (h *exportersHost) GetExporters() map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter {
+	exps := make(map[configmodels.NamedEntity]component.Exporter)
+	for name, exp := range h.exporters {
+		exps[&configmodels.ExporterSettings{TypeVal: "sink", NameVal: name}] = exp
+	}
+	return map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter{
+		configmodels.TracesDataType:  exps,
+		configmodels.MetricsDataType: exps,
+		configmodels.LogsDataType:    exps,
+	}
+}
+
+func newExportersHost(names ...string) *exportersHost {
+	h := &exportersHost{Host: componenttest.NewNopHost(), exporters: make(map[string]*sinkExporter)}
+	for _, name := range names {
+		h.exporters[name] = &sinkExporter{}
+	}
+	return h
+}
+
+func newTestConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Exporters = []string{"sink/primary", "sink/secondary", "sink/dr"}
+	cfg.MaxConsecutiveFailures = 2
+	cfg.RetryInterval = time.Minute
+	return cfg
+}
+
+// fakeClock is a clock advanced by the tests.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func TestTracesFailover(t *testing.T) {
+	host := newExportersHost("sink/primary", "sink/secondary", "sink/dr")
+	tp := newTracesProcessor(zap.NewNop(), newTestConfig())
+	require.NoError(t, tp.Start(context.Background(), host))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Equal(t, 1, host.exporters["sink/primary"].SpansCount())
+
+	host.exporters["sink/primary"].err = errUnavailable
+	// The first error is returned, the primary exporter stays active.
+	assert.Equal(t, errUnavailable, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Equal(t, 0, host.exporters["sink/secondary"].SpansCount())
+
+	// The second error fails over, the same data is sent to the secondary exporter.
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Equal(t, 1, host.exporters["sink/secondary"].SpansCount())
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Equal(t, 2, host.exporters["sink/secondary"].SpansCount())
+	assert.Equal(t, 1, host.exporters["sink/primary"].SpansCount())
+	assert.Equal(t, 0, host.exporters["sink/dr"].SpansCount())
+}
+
+func TestMetricsFailoverToLastExporter(t *testing.T) {
+	host := newExportersHost("sink/primary", "sink/secondary", "sink/dr")
+	cfg := newTestConfig()
+	cfg.MaxConsecutiveFailures = 1
+	mp := newMetricsProcessor(zap.NewNop(), cfg)
+	require.NoError(t, mp.Start(context.Background(), host))
+
+	host.exporters["sink/primary"].err = errUnavailable
+	host.exporters["sink/secondary"].err = errUnavailable
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	assert.Equal(t, 1, host.exporters["sink/dr"].MetricsCount())
+
+	// The last exporter stays active even when it fails.
+	host.exporters["sink/dr"].err = errUnavailable
+	assert.Equal(t, errUnavailable, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	assert.Equal(t, errUnavailable, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	host.exporters["sink/dr"].err = nil
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	assert.Equal(t, 2, host.exporters["sink/dr"].MetricsCount())
+}
+
+func TestLogsRecovery(t *testing.T) {
+	host := newExportersHost("sink/primary", "sink/secondary", "sink/dr")
+	cfg := newTestConfig()
+	cfg.MaxConsecutiveFailures = 1
+	lp := newLogsProcessor(zap.NewNop(), cfg)
+	clock := &fakeClock{t: time.Now()}
+	lp.now = clock.now
+	require.NoError(t, lp.Start(context.Background(), host))
+
+	host.exporters["sink/primary"].err = errUnavailable
+	require.NoError(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+	assert.Equal(t, 1, host.exporters["sink/secondary"].LogRecordsCount())
+
+	// The primary exporter is not probed before the retry interval.
+	host.exporters["sink/primary"].err = nil
+	clock.t = clock.t.Add(30 * time.Second)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+	assert.Equal(t, 2, host.exporters["sink/secondary"].LogRecordsCount())
+	assert.Equal(t, 0, host.exporters["sink/primary"].LogRecordsCount())
+
+	// A failed probe sends the data to the active exporter.
+	host.exporters["sink/primary"].err = errUnavailable
+	clock.t = clock.t.Add(30 * time.Second)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+	assert.Equal(t, 3, host.exporters["sink/secondary"].LogRecordsCount())
+
+	// A successful probe makes the primary exporter active again.
+	host.exporters["sink/primary"].err = nil
+	clock.t = clock.t.Add(time.Minute)
+	require.NoError(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+	assert.Equal(t, 2, host.exporters["sink/primary"].LogRecordsCount())
+	assert.Equal(t, 3, host.exporters["sink/secondary"].LogRecordsCount())
+}
+
+func TestPermanentErrorDoesNotFailOver(t *testing.T) {
+	host := newExportersHost("sink/primary", "sink/secondary", "sink/dr")
+	cfg := newTestConfig()
+	cfg.MaxConsecutiveFailures = 1
+	tp := newTracesProcessor(zap.NewNop(), cfg)
+	require.NoError(t, tp.Start(context.Background(), host))
+
+	permanent := consumererror.Permanent(errors.New("invalid data"))
+	host.exporters["sink/primary"].err = permanent
+	assert.Equal(t, permanent, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+
+	host.exporters["sink/primary"].err = nil
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Equal(t, 1, host.exporters["sink/primary"].SpansCount())
+	assert.Equal(t, 0, host.exporters["sink/secondary"].SpansCount())
+}
+
+func TestFailoverUnknownExporter(t *testing.T) {
+	tp := newTracesProcessor(zap.NewNop(), newTestConfig())
+	err := tp.Start(context.Background(), newExportersHost("sink/primary", "sink/secondary"))
+	assert.EqualError(t, err, `the traces exporter "sink/dr" of the failover processor "failover" is not in the exporters of the pipeline`)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the Log Dedup processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Interval is the time window within which the identical log records are deduplicated, the deduplicated log
+	// records are emitted at the end of each window. Default value is 10s.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Attributes are the log record attributes whose values must be the same for the log records to be identical,
+	// in addition to the resource, the instrumentation library, the severity and the body. The other attributes are
+	// the ones of the first log record.
+	Attributes []string `mapstructure:"attributes"`
+
+	// MaxRecords is the maximum number of distinct log records kept within a window, the window ends early when it
+	// is reached. Default value is 10000.
+	MaxRecords int `mapstructure:"max_records"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the interval and the maximum number of records are positive.
+func (cfg *Config) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if cfg.MaxRecords <= 0 {
+		return errors.New("max_records must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// countAttribute is the attribute of the emitted log records holding the number of identical log records.
+const countAttribute = "log.dedup_count"
+
+// group is the deduplicated log records of a resource and an instrumentation library.
+type group struct {
+	resource pdata.Resource
+	library  pdata.InstrumentationLibrary
+	records  []*record
+	index    map[string]*record
+}
+
+// record is the first of the identical log records and their count.
+type record struct {
+	lr    pdata.LogRecord
+	count int64
+}
+
+// logDedup keeps the first of the identical log records it consumes with their count, the log records are emitted
+// every Interval, when MaxRecords distinct log records are kept and when it shuts down.
+type logDedup struct {
+	config       *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Logs
+
+	mu      sync.Mutex
+	groups  []*group
+	index   map[string]*group
+	records int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ component.LogsProcessor = (*logDedup)(nil)
+
+func newLogDedupProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Logs) *logDedup {
+	return &logDedup{
+		config:       cfg,
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		index:        make(map[string]*group),
+		done:         make(chan struct{}),
+	}
+}
+
+func (p *logDedup) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// Start starts emitting the log records every Interval.
+func (p *logDedup) Start(context.Context, component.Host) error {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.flush(context.Background()); err != nil {
+					p.logger.Error("Failed to emit the deduplicated log records.", zap.Error(err))
+				}
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops emitting the log records, the log records kept are emitted a last time.
+func (p *logDedup) Shutdown(ctx context.Context) error {
+	close(p.done)
+	p.wg.Wait()
+	return p.flush(ctx)
+}
+
+// ConsumeLogs keeps the log records, the window ends early when the maximum number of records is reached.
+func (p *logDedup) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if full := p.add(ld); full {
+		return p.flush(ctx)
+	}
+	return nil
+}
+
+// add keeps the first of the identical log records and counts them, it returns whether the maximum number of
+// records is reached.
+func (p *logDedup) add(ld pdata.Logs) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceKey := attributesKey(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			il := ills.At(j)
+			library := il.InstrumentationLibrary()
+			groupKey := resourceKey + library.Name() + "\x00" + library.Version()
+			g, ok := p.index[groupKey]
+			if !ok {
+				g = &group{
+					resource: pdata.NewResource(),
+					library:  pdata.NewInstrumentationLibrary(),
+					index:    make(map[string]*record),
+				}
+				rl.Resource().CopyTo(g.resource)
+				library.CopyTo(g.library)
+				p.groups = append(p.groups, g)
+				p.index[groupKey] = g
+			}
+			logs := il.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				key := p.recordKey(lr)
+				if r, ok := g.index[key]; ok {
+					r.count++
+					continue
+				}
+				r := &record{lr: pdata.NewLogRecord(), count: 1}
+				lr.CopyTo(r.lr)
+				g.records = append(g.records, r)
+				g.index[key] = r
+				p.records++
+			}
+		}
+	}
+	return p.records >= p.config.MaxRecords
+}
+
+// recordKey returns a key identifying the identical log records of a group.
+func (p *logDedup) recordKey(lr pdata.LogRecord) string {
+	var b strings.Builder
+	b.WriteString(lr.SeverityNumber().String())
+	b.WriteByte(0)
+	b.WriteString(lr.SeverityText())
+	b.WriteByte(0)
+	b.WriteString(lr.Body().Type().String())
+	b.WriteByte(0)
+	b.WriteString(tracetranslator.AttributeValueToString(lr.Body(), true))
+	b.WriteByte(0)
+	for _, name := range p.config.Attributes {
+		v, ok := lr.Attributes().Get(name)
+		if !ok {
+			// Tell a missing attribute from an empty one.
+			b.WriteByte(1)
+			continue
+		}
+		b.WriteString(tracetranslator.AttributeValueToString(v, true))
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// attributesKey returns a key identifying the attributes whatever their order.
+func attributesKey(attrs pdata.AttributeMap) string {
+	// Sort a copy of the keys, the attributes are consumed data that must not be modified.
+	keys := make([]string, 0, attrs.Len())
+	attrs.ForEach(func(k string, _ pdata.AttributeValue) {
+		keys = append(keys, k)
+	})
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(tracetranslator.AttributeValueToString(v, true))
+		b.WriteByte(0)
+	}
+	b.WriteByte(1)
+	return b.String()
+}
+
+// flush emits the log records kept with their count.
+func (p *logDedup) flush(ctx context.Context) error {
+	ld, ok := p.take()
+	if !ok {
+		return nil
+	}
+	return p.nextConsumer.ConsumeLogs(ctx, ld)
+}
+
+// take returns the log records kept and starts a new window, or false when no log record is kept.
+func (p *logDedup) take() (pdata.Logs, bool) {
+	p.mu.Lock()
+	groups := p.groups
+	p.groups = nil
+	p.index = make(map[string]*group)
+	p.records = 0
+	p.mu.Unlock()
+
+	ld := pdata.NewLogs()
+	for _, g := range groups {
+		if len(g.records) == 0 {
+			continue
+		}
+		rl := ld.ResourceLogs().AppendEmpty()
+		g.resource.CopyTo(rl.Resource())
+		il := rl.InstrumentationLibraryLogs().AppendEmpty()
+		g.library.CopyTo(il.InstrumentationLibrary())
+		for _, r := range g.records {
+			r.lr.Attributes().UpsertInt(countAttribute, r.count)
+			il.Logs().Append(r.lr)
+		}
+	}
+	return ld, ld.LogRecordCount() > 0
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// addLog appends a log record of the service with the given severity, body and user.
+func addLog(ld pdata.Logs, service string, severity pdata.SeverityNumber, body, user string) {
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().UpsertString(conventions.AttributeServiceName, service)
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetSeverityNumber(severity)
+	lr.Body().SetStringVal(body)
+	lr.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+	if user != "" {
+		lr.Attributes().UpsertString("user.id", user)
+	}
+}
+
+// counts returns the count of the emitted log records by service, body and user.
+func counts(t *testing.T, sink *consumertest.LogsSink) map[string]int64 {
+	res := make(map[string]int64)
+	for _, ld := range sink.AllLogs() {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			service, _ := rls.At(i).Resource().Attributes().Get(conventions.AttributeServiceName)
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				logs := ills.At(j).Logs()
+				for k := 0; k < logs.Len(); k++ {
+					lr := logs.At(k)
+					count, ok := lr.Attributes().Get(countAttribute)
+					require.True(t, ok)
+					key := service.StringVal() + "/" + lr.SeverityNumber().String() + "/" + lr.Body().StringVal()
+					if user, ok := lr.Attributes().Get("user.id"); ok {
+						key += "/" + user.StringVal()
+					}
+					res[key] = count.IntVal()
+				}
+			}
+		}
+	}
+	return res
+}
+
+func TestLogDedup(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Attributes = []string{"user.id"}
+	sink := new(consumertest.LogsSink)
+	p := newLogDedupProcessor(zap.NewNop(), cfg, sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := pdata.NewLogs()
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "connection refused", "")
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "connection refused", "")
+	addLog(ld, "frontend", pdata.SeverityNumberWARN, "connection refused", "")
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "timeout", "")
+	addLog(ld, "backend", pdata.SeverityNumberERROR, "connection refused", "")
+	addLog(ld, "frontend", pdata.SeverityNumberINFO, "login", "alice")
+	addLog(ld, "frontend", pdata.SeverityNumberINFO, "login", "bob")
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	ld = pdata.NewLogs()
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "connection refused", "")
+	addLog(ld, "frontend", pdata.SeverityNumberINFO, "login", "alice")
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	// The log records are emitted at the end of the window.
+	assert.Equal(t, 0, sink.LogRecordsCount())
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	assert.Equal(t, map[string]int64{
+		"frontend/SEVERITY_NUMBER_ERROR/connection refused": 3,
+		"frontend/SEVERITY_NUMBER_WARN/connection refused":  1,
+		"frontend/SEVERITY_NUMBER_ERROR/timeout":            1,
+		"backend/SEVERITY_NUMBER_ERROR/connection refused":  1,
+		"frontend/SEVERITY_NUMBER_INFO/login/alice":         2,
+		"frontend/SEVERITY_NUMBER_INFO/login/bob":           1,
+	}, counts(t, sink))
+	// The log records of a resource are emitted together.
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, 2, sink.AllLogs()[0].ResourceLogs().Len())
+}
+
+func TestLogDedupInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+	sink := new(consumertest.LogsSink)
+	p := newLogDedupProcessor(zap.NewNop(), cfg, sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := pdata.NewLogs()
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "connection refused", "")
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "connection refused", "")
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordsCount() == 1
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Equal(t, map[string]int64{"frontend/SEVERITY_NUMBER_ERROR/connection refused": 2}, counts(t, sink))
+}
+
+func TestLogDedupMaxRecords(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxRecords = 2
+	sink := new(consumertest.LogsSink)
+	p := newLogDedupProcessor(zap.NewNop(), cfg, sink)
+
+	ld := pdata.NewLogs()
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "connection refused", "")
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "connection refused", "")
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 0, sink.LogRecordsCount())
+
+	// The window ends early when the maximum number of distinct log records is reached.
+	ld = pdata.NewLogs()
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "timeout", "")
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, map[string]int64{
+		"frontend/SEVERITY_NUMBER_ERROR/connection refused": 2,
+		"frontend/SEVERITY_NUMBER_ERROR/timeout":            1,
+	}, counts(t, sink))
+}
+
+func TestLogDedupNextConsumerError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxRecords = 1
+	errTest := errors.New("test error")
+	p := newLogDedupProcessor(zap.NewNop(), cfg, consumertest.NewLogsErr(errTest))
+
+	ld := pdata.NewLogs()
+	addLog(ld, "frontend", pdata.SeverityNumberERROR, "connection refused", "")
+	assert.Equal(t, errTest, p.ConsumeLogs(context.Background(), ld))
+}
+
+func TestAttributesKey(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	attrs.UpsertString("b", "2")
+	attrs.UpsertString("a", "1")
+	sorted := pdata.NewAttributeMap()
+	sorted.UpsertString("a", "1")
+	sorted.UpsertString("b", "2")
+	assert.Equal(t, attributesKey(sorted), attributesKey(attrs))
+
+	// The consumed attributes are not reordered.
+	var keys []string
+	attrs.ForEach(func(k string, _ pdata.AttributeValue) {
+		keys = append(keys, k)
+	})
+	assert.Equal(t, []string{"b", "a"}, keys)
+}
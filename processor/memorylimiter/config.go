@@ -52,5 +52,18 @@ type Config struct {
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
 }
 
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the check interval and one of the memory limits are set.
+func (cfg *Config) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		return errCheckIntervalOutOfRange
+	}
+	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
+		return errLimitOutOfRange
+	}
+	return nil
+}
+
 // Name of BallastSizeMiB config option.
 const ballastSizeMibKey = "ballast_size_mib"
@@ -42,15 +42,6 @@ func TestLoadConfig(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, cfg)
 
-	p0 := cfg.Processors["memory_limiter"]
-	assert.Equal(t, p0,
-		&Config{
-			ProcessorSettings: configmodels.ProcessorSettings{
-				TypeVal: "memory_limiter",
-				NameVal: "memory_limiter",
-			},
-		})
-
 	p1 := cfg.Processors["memory_limiter/with-settings"]
 	assert.Equal(t, p1,
 		&Config{
@@ -64,3 +55,12 @@ func TestLoadConfig(t *testing.T) {
 			BallastSizeMiB:      2000,
 		})
 }
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Processors[typeStr] = NewFactory()
+
+	_, err = configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_invalid.yaml"), factories)
+	assert.EqualError(t, err, `invalid configuration of processor "memory_limiter": checkInterval must be greater than zero`)
+}
@@ -25,9 +25,8 @@ import (
 	"go.opencensus.io/stats"
 	"go.uber.org/zap"
 
-	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
-	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/memorylimiter/internal/iruntime"
 )
@@ -37,9 +36,9 @@ const (
 )
 
 var (
-	// errForcedDrop will be returned to callers of ConsumeTraceData to indicate
-	// that data is being dropped due to high memory usage.
-	errForcedDrop = errors.New("data dropped due to high memory usage")
+	// errForcedDrop will be returned, wrapped as retryable, to callers of
+	// ConsumeTraceData to indicate that data is being dropped due to high memory usage.
+	errForcedDrop = consumererror.WithReason(errors.New("data dropped due to high memory usage"), consumererror.ReasonMemoryLimit)
 
 	// Construction errors
 
@@ -81,8 +80,6 @@ type memoryLimiter struct {
 	procName               string
 	logger                 *zap.Logger
 	configMismatchedLogged bool
-
-	obsrep *obsreport.Processor
 }
 
 // Minimum interval between forced GC when in soft limited mode. We don't want to
@@ -93,11 +90,8 @@ const minGCIntervalWhenSoftLimited = 10 * time.Second
 func newMemoryLimiter(logger *zap.Logger, cfg *Config) (*memoryLimiter, error) {
 	ballastSize := uint64(cfg.BallastSizeMiB) * mibBytes
 
-	if cfg.CheckInterval <= 0 {
-		return nil, errCheckIntervalOutOfRange
-	}
-	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
-		return nil, errLimitOutOfRange
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
 
 	usageChecker, err := getMemUsageChecker(cfg, logger)
@@ -118,10 +112,6 @@ func newMemoryLimiter(logger *zap.Logger, cfg *Config) (*memoryLimiter, error) {
 		readMemStatsFn: runtime.ReadMemStats,
 		procName:       cfg.Name(),
 		logger:         logger,
-		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
-			Level:         configtelemetry.GetMetricsLevelFlagValue(),
-			ProcessorName: cfg.Name(),
-		}),
 	}
 
 	ml.startMonitoring()
@@ -160,62 +150,54 @@ func (ml *memoryLimiter) ProcessTraces(ctx context.Context, td pdata.Traces) (pd
 			processor.StatDroppedSpanCount.M(int64(numSpans)),
 			processor.StatTraceBatchesDroppedCount.M(1))
 
-		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
-		// 	it is necessary to check the pipeline to see if this is directly connected
-		// 	to a receiver (ie.: a receiver is on the call stack). For now it
-		// 	assumes that the pipeline is properly configured and a receiver is on the
-		// 	callstack.
-		ml.obsrep.TracesRefused(ctx, numSpans)
-
-		return td, errForcedDrop
+		// The error is retryable, processorhelper reports the data as refused and
+		// throttled. TODO: actually to be 100% sure that this is "refused" and not
+		// "dropped" it is necessary to check the pipeline to see if this is directly
+		// connected to a receiver (ie.: a receiver is on the call stack). For now it
+		// assumes that the pipeline is properly configured and a receiver is on the
+		// callstack.
+		return td, ml.refusedError()
 	}
 
-	// Even if the next consumer returns error record the data as accepted by
-	// this processor.
-	ml.obsrep.TracesAccepted(ctx, numSpans)
 	return td, nil
 }
 
 // ProcessMetrics implements the MProcessor interface
 func (ml *memoryLimiter) ProcessMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
-	_, numDataPoints := md.MetricAndDataPointCount()
 	if ml.forcingDrop() {
-		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
-		// 	it is necessary to check the pipeline to see if this is directly connected
-		// 	to a receiver (ie.: a receiver is on the call stack). For now it
-		// 	assumes that the pipeline is properly configured and a receiver is on the
-		// 	callstack.
-		ml.obsrep.MetricsRefused(ctx, numDataPoints)
-
-		return md, errForcedDrop
+		// The error is retryable, processorhelper reports the data as refused and
+		// throttled. TODO: actually to be 100% sure that this is "refused" and not
+		// "dropped" it is necessary to check the pipeline to see if this is directly
+		// connected to a receiver (ie.: a receiver is on the call stack). For now it
+		// assumes that the pipeline is properly configured and a receiver is on the
+		// callstack.
+		return md, ml.refusedError()
 	}
 
-	// Even if the next consumer returns error record the data as accepted by
-	// this processor.
-	ml.obsrep.MetricsAccepted(ctx, numDataPoints)
 	return md, nil
 }
 
 // ProcessLogs implements the LProcessor interface
 func (ml *memoryLimiter) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
-	numRecords := ld.LogRecordCount()
 	if ml.forcingDrop() {
-		// TODO: actually to be 100% sure that this is "refused" and not "dropped"
-		// 	it is necessary to check the pipeline to see if this is directly connected
-		// 	to a receiver (ie.: a receiver is on the call stack). For now it
-		// 	assumes that the pipeline is properly configured and a receiver is on the
-		// 	callstack.
-		ml.obsrep.LogsRefused(ctx, numRecords)
-
-		return ld, errForcedDrop
+		// The error is retryable, processorhelper reports the data as refused and
+		// throttled. TODO: actually to be 100% sure that this is "refused" and not
+		// "dropped" it is necessary to check the pipeline to see if this is directly
+		// connected to a receiver (ie.: a receiver is on the call stack). For now it
+		// assumes that the pipeline is properly configured and a receiver is on the
+		// callstack.
+		return ld, ml.refusedError()
 	}
 
-	// Even if the next consumer returns error record the data as accepted by
-	// this processor.
-	ml.obsrep.LogsAccepted(ctx, numRecords)
 	return ld, nil
 }
 
+// refusedError returns the error applying backpressure while the data is being
+// dropped, the senders should not retry before the memory usage is checked again.
+func (ml *memoryLimiter) refusedError() error {
+	return consumererror.NewRetryable(errForcedDrop, ml.memCheckWait)
+}
+
 func (ml *memoryLimiter) readMemStats() *runtime.MemStats {
 	ms := &runtime.MemStats{}
 	ml.readMemStatsFn(ms)
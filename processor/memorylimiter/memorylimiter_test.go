@@ -25,11 +25,12 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/config/configmodels"
-	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
-	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/processor/memorylimiter/internal/iruntime"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
@@ -110,11 +111,6 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 		readMemStatsFn: func(ms *runtime.MemStats) {
 			ms.Alloc = currentMemAlloc
 		},
-		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
-			Level:         configtelemetry.LevelNone,
-			ProcessorName: "",
-		}),
-
 		logger: zap.NewNop(),
 	}
 	mp, err := processorhelper.NewMetricsProcessor(
@@ -141,7 +137,7 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit.
 	currentMemAlloc = 1800
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
+	assert.ErrorIs(t, mp.ConsumeMetrics(ctx, md), errForcedDrop)
 
 	// Check ballast effect
 	ml.ballastSize = 1000
@@ -154,7 +150,7 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit even accountiing for ballast.
 	currentMemAlloc = 1800 + ml.ballastSize
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
+	assert.ErrorIs(t, mp.ConsumeMetrics(ctx, md), errForcedDrop)
 
 	// Restore ballast to default.
 	ml.ballastSize = 0
@@ -170,7 +166,7 @@ func TestMetricsMemoryPressureResponse(t *testing.T) {
 	// Above memSpikeLimit.
 	currentMemAlloc = 550
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
+	assert.ErrorIs(t, mp.ConsumeMetrics(ctx, md), errForcedDrop)
 
 }
 
@@ -185,10 +181,6 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 		readMemStatsFn: func(ms *runtime.MemStats) {
 			ms.Alloc = currentMemAlloc
 		},
-		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
-			Level:         configtelemetry.LevelNone,
-			ProcessorName: "",
-		}),
 		logger: zap.NewNop(),
 	}
 	tp, err := processorhelper.NewTraceProcessor(
@@ -215,7 +207,7 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit.
 	currentMemAlloc = 1800
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
+	assert.ErrorIs(t, tp.ConsumeTraces(ctx, td), errForcedDrop)
 
 	// Check ballast effect
 	ml.ballastSize = 1000
@@ -228,7 +220,7 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit even accountiing for ballast.
 	currentMemAlloc = 1800 + ml.ballastSize
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
+	assert.ErrorIs(t, tp.ConsumeTraces(ctx, td), errForcedDrop)
 
 	// Restore ballast to default.
 	ml.ballastSize = 0
@@ -244,7 +236,7 @@ func TestTraceMemoryPressureResponse(t *testing.T) {
 	// Above memSpikeLimit.
 	currentMemAlloc = 550
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
+	assert.ErrorIs(t, tp.ConsumeTraces(ctx, td), errForcedDrop)
 
 }
 
@@ -259,10 +251,6 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 		readMemStatsFn: func(ms *runtime.MemStats) {
 			ms.Alloc = currentMemAlloc
 		},
-		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
-			Level:         configtelemetry.LevelNone,
-			ProcessorName: "",
-		}),
 		logger: zap.NewNop(),
 	}
 	lp, err := processorhelper.NewLogsProcessor(
@@ -289,7 +277,7 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit.
 	currentMemAlloc = 1800
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
+	assert.ErrorIs(t, lp.ConsumeLogs(ctx, ld), errForcedDrop)
 
 	// Check ballast effect
 	ml.ballastSize = 1000
@@ -302,7 +290,7 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	// Above memAllocLimit even accountiing for ballast.
 	currentMemAlloc = 1800 + ml.ballastSize
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
+	assert.ErrorIs(t, lp.ConsumeLogs(ctx, ld), errForcedDrop)
 
 	// Restore ballast to default.
 	ml.ballastSize = 0
@@ -318,7 +306,51 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	// Above memSpikeLimit.
 	currentMemAlloc = 550
 	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
+	assert.ErrorIs(t, lp.ConsumeLogs(ctx, ld), errForcedDrop)
+}
+
+func TestRefusedErrorIsRetryable(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	ml := &memoryLimiter{
+		usageChecker: memUsageChecker{
+			memAllocLimit: 1024,
+		},
+		memCheckWait: time.Second,
+		readMemStatsFn: func(ms *runtime.MemStats) {
+			ms.Alloc = 1800
+		},
+		logger: zap.NewNop(),
+	}
+	ml.checkMemLimits()
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+	}
+
+	lp, err := processorhelper.NewLogsProcessor(cfg, consumertest.NewLogsNop(), ml)
+	require.NoError(t, err)
+	err = lp.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog())
+	var retryable consumererror.Retryable
+	require.True(t, consumererror.AsRetryable(err, &retryable))
+	assert.Equal(t, time.Second, retryable.Delay())
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Equal(t, consumererror.ReasonMemoryLimit, consumererror.GetReason(err))
+
+	tp, err := processorhelper.NewTraceProcessor(cfg, consumertest.NewTracesNop(), ml)
+	require.NoError(t, err)
+	assert.True(t, consumererror.IsRetryable(tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan())))
+	mp, err := processorhelper.NewMetricsProcessor(cfg, consumertest.NewMetricsNop(), ml)
+	require.NoError(t, err)
+	assert.True(t, consumererror.IsRetryable(mp.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric())))
+
+	// The processor helper reports the data refused with the retryable error as throttled.
+	obsreporttest.CheckProcessorLogsViews(t, typeStr, 0, 1, 0)
+	obsreporttest.CheckProcessorThrottledViews(t, typeStr, 1, 2, 1)
 }
 
 func TestGetDecision(t *testing.T) {
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+const (
+	// actionUpdate transforms the matched metrics in place.
+	actionUpdate = "update"
+	// actionInsert transforms a copy of the matched metrics, the original metrics are kept.
+	actionInsert = "insert"
+	// actionCombine replaces the matched metrics with a single metric having all their data points.
+	actionCombine = "combine"
+
+	// opAddLabel adds a label with a fixed value to all the data points.
+	opAddLabel = "add_label"
+	// opUpdateLabel renames a label and its values.
+	opUpdateLabel = "update_label"
+	// opDeleteLabelValue drops the data points having a label value.
+	opDeleteLabelValue = "delete_label_value"
+	// opAggregateLabels keeps only a set of labels and aggregates the data points having the same labels left.
+	opAggregateLabels = "aggregate_labels"
+	// opAggregateLabelValues replaces a set of values of a label with a new value and aggregates the data points
+	// having the same labels left.
+	opAggregateLabelValues = "aggregate_label_values"
+
+	aggregationSum  = "sum"
+	aggregationMean = "mean"
+	aggregationMin  = "min"
+	aggregationMax  = "max"
+)
+
+// Config defines configuration for the Metrics Transform processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Transforms are applied in order to the metrics of each instrumentation library.
+	Transforms []Transform `mapstructure:"transforms"`
+}
+
+// Transform specifies the metrics to transform and how.
+type Transform struct {
+	// Include is the name of the metrics to transform, or a regexp matching the whole name of the metrics when
+	// MatchType is "regexp".
+	Include string `mapstructure:"include"`
+
+	// MatchType is either "strict" or "regexp". Default value is "strict".
+	MatchType filterset.MatchType `mapstructure:"match_type"`
+
+	// Action is either "update", "insert" or "combine". Default value is "update".
+	Action string `mapstructure:"action"`
+
+	// NewName is the new name of the metrics, it is required by "insert" and "combine". With a regexp, it may
+	// reference the submatches of the name as $1 or ${name}.
+	NewName string `mapstructure:"new_name"`
+
+	// Operations are applied in order to the data points of the transformed metrics.
+	Operations []Operation `mapstructure:"operations"`
+}
+
+// Operation specifies a change of the labels of the data points.
+type Operation struct {
+	// Action is either "add_label", "update_label", "delete_label_value", "aggregate_labels" or
+	// "aggregate_label_values".
+	Action string `mapstructure:"action"`
+
+	// Label is the label updated, deleted or aggregated.
+	Label string `mapstructure:"label"`
+
+	// NewLabel is the label added by "add_label", or the new name of the label for "update_label".
+	NewLabel string `mapstructure:"new_label"`
+
+	// NewValue is the value of the label added by "add_label", or the value replacing the aggregated values for
+	// "aggregate_label_values".
+	NewValue string `mapstructure:"new_value"`
+
+	// LabelValue is the value whose data points are dropped by "delete_label_value".
+	LabelValue string `mapstructure:"label_value"`
+
+	// ValueActions rename the values of the label for "update_label".
+	ValueActions []ValueAction `mapstructure:"value_actions"`
+
+	// LabelSet are the labels kept by "aggregate_labels".
+	LabelSet []string `mapstructure:"label_set"`
+
+	// AggregatedValues are the values replaced by "aggregate_label_values".
+	AggregatedValues []string `mapstructure:"aggregated_values"`
+
+	// AggregationType is either "sum", "mean", "min" or "max", it computes the values of the data points merged by
+	// "aggregate_labels" and "aggregate_label_values".
+	AggregationType string `mapstructure:"aggregation_type"`
+}
+
+// ValueAction renames a label value.
+type ValueAction struct {
+	// Value is the current value.
+	Value string `mapstructure:"value"`
+
+	// NewValue is the value replacing it.
+	NewValue string `mapstructure:"new_value"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that there is at least one transform and that the transforms and their operations are complete.
+func (cfg *Config) Validate() error {
+	if len(cfg.Transforms) == 0 {
+		return errors.New("transforms must have at least one transform")
+	}
+	for _, t := range cfg.Transforms {
+		if err := t.validate(); err != nil {
+			return fmt.Errorf("transform of %q: %w", t.Include, err)
+		}
+	}
+	return nil
+}
+
+func (t *Transform) validate() error {
+	if t.Include == "" {
+		return errors.New("include must be set")
+	}
+	switch t.MatchType {
+	case "", filterset.Strict:
+	case filterset.Regexp:
+		if _, err := regexp.Compile(t.Include); err != nil {
+			return fmt.Errorf("include is not a valid regexp: %w", err)
+		}
+	default:
+		return fmt.Errorf("match_type must be either %q or %q", filterset.Strict, filterset.Regexp)
+	}
+	switch t.Action {
+	case "", actionUpdate:
+	case actionInsert, actionCombine:
+		if t.NewName == "" {
+			return fmt.Errorf("new_name must be set with action %q", t.Action)
+		}
+	default:
+		return fmt.Errorf("action must be either %q, %q or %q", actionUpdate, actionInsert, actionCombine)
+	}
+	for _, op := range t.Operations {
+		if err := op.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (op *Operation) validate() error {
+	switch op.Action {
+	case opAddLabel:
+		if op.NewLabel == "" {
+			return fmt.Errorf("operation %q: new_label must be set", op.Action)
+		}
+	case opUpdateLabel:
+		if op.Label == "" {
+			return fmt.Errorf("operation %q: label must be set", op.Action)
+		}
+		if op.NewLabel == "" && len(op.ValueActions) == 0 {
+			return fmt.Errorf("operation %q: at least one of new_label or value_actions must be set", op.Action)
+		}
+	case opDeleteLabelValue:
+		if op.Label == "" {
+			return fmt.Errorf("operation %q: label must be set", op.Action)
+		}
+	case opAggregateLabels:
+		if err := validateAggregationType(op.AggregationType); err != nil {
+			return fmt.Errorf("operation %q: %w", op.Action, err)
+		}
+	case opAggregateLabelValues:
+		if op.Label == "" {
+			return fmt.Errorf("operation %q: label must be set", op.Action)
+		}
+		if len(op.AggregatedValues) == 0 {
+			return fmt.Errorf("operation %q: aggregated_values must be set", op.Action)
+		}
+		if err := validateAggregationType(op.AggregationType); err != nil {
+			return fmt.Errorf("operation %q: %w", op.Action, err)
+		}
+	default:
+		return fmt.Errorf("operation action must be either %q, %q, %q, %q or %q",
+			opAddLabel, opUpdateLabel, opDeleteLabelValue, opAggregateLabels, opAggregateLabelValues)
+	}
+	return nil
+}
+
+func validateAggregationType(aggregationType string) error {
+	switch aggregationType {
+	case aggregationSum, aggregationMean, aggregationMin, aggregationMax:
+		return nil
+	}
+	return fmt.Errorf("aggregation_type must be either %q, %q, %q or %q",
+		aggregationSum, aggregationMean, aggregationMin, aggregationMax)
+}
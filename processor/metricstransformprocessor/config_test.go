@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, cfg.Processors["metricstransform"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "metricstransform",
+				NameVal: "metricstransform",
+			},
+			Transforms: []Transform{{
+				Include: "system.cpu.usage",
+				NewName: "cpu_usage",
+				Operations: []Operation{{
+					Action:       opUpdateLabel,
+					Label:        "state",
+					NewLabel:     "cpu_state",
+					ValueActions: []ValueAction{{Value: "idle", NewValue: "unused"}},
+				}},
+			}},
+		})
+
+	assert.Equal(t, cfg.Processors["metricstransform/combine"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "metricstransform",
+				NameVal: "metricstransform/combine",
+			},
+			Transforms: []Transform{
+				{
+					Include:    `^system\.memory\.(?P<state>.*)$`,
+					MatchType:  filterset.Regexp,
+					Action:     actionCombine,
+					NewName:    "system.memory.usage",
+					Operations: []Operation{{Action: opAddLabel, NewLabel: "host", NewValue: "localhost"}},
+				},
+				{
+					Include: "system.cpu.time",
+					Action:  actionInsert,
+					NewName: "system.cpu.time.total",
+					Operations: []Operation{
+						{Action: opAggregateLabels, LabelSet: []string{"state"}, AggregationType: aggregationSum},
+						{
+							Action:           opAggregateLabelValues,
+							Label:            "state",
+							AggregatedValues: []string{"user", "system"},
+							NewValue:         "used",
+							AggregationType:  aggregationSum,
+						},
+						{Action: opDeleteLabelValue, Label: "state", LabelValue: "idle"},
+					},
+				},
+			},
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "transforms must have at least one transform")
+
+	tests := []struct {
+		name      string
+		transform Transform
+		err       string
+	}{
+		{
+			name:      "no include",
+			transform: Transform{},
+			err:       `transform of "": include must be set`,
+		},
+		{
+			name:      "invalid match type",
+			transform: Transform{Include: "cpu", MatchType: "glob"},
+			err:       `transform of "cpu": match_type must be either "strict" or "regexp"`,
+		},
+		{
+			name:      "invalid regexp",
+			transform: Transform{Include: "cpu(", MatchType: filterset.Regexp},
+			err:       "transform of \"cpu(\": include is not a valid regexp: error parsing regexp: missing closing ): `cpu(`",
+		},
+		{
+			name:      "invalid action",
+			transform: Transform{Include: "cpu", Action: "delete"},
+			err:       `transform of "cpu": action must be either "update", "insert" or "combine"`,
+		},
+		{
+			name:      "insert without new name",
+			transform: Transform{Include: "cpu", Action: actionInsert},
+			err:       `transform of "cpu": new_name must be set with action "insert"`,
+		},
+		{
+			name:      "invalid operation",
+			transform: Transform{Include: "cpu", Operations: []Operation{{Action: "rename"}}},
+			err: `transform of "cpu": operation action must be either "add_label", "update_label", "delete_label_value", ` +
+				`"aggregate_labels" or "aggregate_label_values"`,
+		},
+		{
+			name:      "add label without new label",
+			transform: Transform{Include: "cpu", Operations: []Operation{{Action: opAddLabel}}},
+			err:       `transform of "cpu": operation "add_label": new_label must be set`,
+		},
+		{
+			name:      "update label without change",
+			transform: Transform{Include: "cpu", Operations: []Operation{{Action: opUpdateLabel, Label: "state"}}},
+			err:       `transform of "cpu": operation "update_label": at least one of new_label or value_actions must be set`,
+		},
+		{
+			name:      "delete label value without label",
+			transform: Transform{Include: "cpu", Operations: []Operation{{Action: opDeleteLabelValue}}},
+			err:       `transform of "cpu": operation "delete_label_value": label must be set`,
+		},
+		{
+			name:      "aggregate labels without aggregation type",
+			transform: Transform{Include: "cpu", Operations: []Operation{{Action: opAggregateLabels}}},
+			err:       `transform of "cpu": operation "aggregate_labels": aggregation_type must be either "sum", "mean", "min" or "max"`,
+		},
+		{
+			name:      "aggregate label values without values",
+			transform: Transform{Include: "cpu", Operations: []Operation{{Action: opAggregateLabelValues, Label: "state"}}},
+			err:       `transform of "cpu": operation "aggregate_label_values": aggregated_values must be set`,
+		},
+		{
+			name: "valid",
+			transform: Transform{Include: "cpu.*", MatchType: filterset.Regexp, Action: actionCombine, NewName: "cpu",
+				Operations: []Operation{{Action: opAggregateLabels, LabelSet: []string{"state"}, AggregationType: aggregationMean}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.Transforms = []Transform{tt.transform}
+			if tt.err == "" {
+				assert.NoError(t, cfg.Validate())
+				return
+			}
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"regexp"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+type metricsTransformProcessor struct {
+	transforms []transform
+}
+
+// transform is a Transform with its defaults and its compiled regexp.
+type transform struct {
+	Transform
+	// re matches the whole metric name, it is nil with the strict match type.
+	re *regexp.Regexp
+}
+
+func newMetricsTransformProcessor(cfg *Config) (*metricsTransformProcessor, error) {
+	p := &metricsTransformProcessor{transforms: make([]transform, 0, len(cfg.Transforms))}
+	for _, t := range cfg.Transforms {
+		tr := transform{Transform: t}
+		if tr.Action == "" {
+			tr.Action = actionUpdate
+		}
+		if tr.MatchType == filterset.Regexp {
+			re, err := regexp.Compile("^(?:" + t.Include + ")$")
+			if err != nil {
+				return nil, err
+			}
+			tr.re = re
+		}
+		p.transforms = append(p.transforms, tr)
+	}
+	return p, nil
+}
+
+// ProcessMetrics applies the transforms in order to the metrics of each instrumentation library.
+func (p *metricsTransformProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for _, t := range p.transforms {
+				switch t.Action {
+				case actionUpdate:
+					t.update(metrics)
+				case actionInsert:
+					t.insert(metrics)
+				case actionCombine:
+					t.combine(metrics)
+				}
+			}
+		}
+	}
+	return md, nil
+}
+
+// match returns the submatch indexes of the metric name, or nil when the metric does not match.
+func (t *transform) match(name string) []int {
+	if t.re == nil {
+		if name != t.Include {
+			return nil
+		}
+		return []int{0, len(name)}
+	}
+	return t.re.FindStringSubmatchIndex(name)
+}
+
+// newName returns the new name of the metric, expanding the references to the submatches of its name.
+func (t *transform) newName(name string, submatches []int) string {
+	if t.NewName == "" {
+		return name
+	}
+	if t.re == nil {
+		return t.NewName
+	}
+	return string(t.re.ExpandString(nil, t.NewName, name, submatches))
+}
+
+// apply renames the metric and applies the operations, it returns whether the metric has data points left.
+func (t *transform) apply(metric pdata.Metric, name string) bool {
+	metric.SetName(name)
+	for _, op := range t.Operations {
+		applyOperation(metric, op)
+	}
+	return dataPointCount(metric) > 0
+}
+
+// update transforms the matched metrics in place, the metrics left without data points are removed.
+func (t *transform) update(metrics pdata.MetricSlice) {
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		submatches := t.match(metric.Name())
+		if submatches == nil {
+			return false
+		}
+		return !t.apply(metric, t.newName(metric.Name(), submatches))
+	})
+}
+
+// insert appends a transformed copy of each matched metric.
+func (t *transform) insert(metrics pdata.MetricSlice) {
+	n := metrics.Len()
+	for i := 0; i < n; i++ {
+		metric := metrics.At(i)
+		submatches := t.match(metric.Name())
+		if submatches == nil {
+			continue
+		}
+		copied := pdata.NewMetric()
+		metric.CopyTo(copied)
+		if t.apply(copied, t.newName(metric.Name(), submatches)) {
+			metrics.Append(copied)
+		}
+	}
+}
+
+// combine replaces the matched metrics having the shape of the first one with a single metric. The named
+// submatches of the name of each metric are added as labels of its data points.
+func (t *transform) combine(metrics pdata.MetricSlice) {
+	var combined pdata.Metric
+	found := false
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		submatches := t.match(metric.Name())
+		if submatches == nil || (found && !sameShape(combined, metric)) {
+			return false
+		}
+		t.addSubmatchLabels(metric, submatches)
+		if !found {
+			combined = pdata.NewMetric()
+			metric.CopyTo(combined)
+			found = true
+			return true
+		}
+		moveDataPoints(metric, combined)
+		return true
+	})
+	if found && t.apply(combined, t.NewName) {
+		metrics.Append(combined)
+	}
+}
+
+// addSubmatchLabels adds the named submatches of the name of the metric as labels of its data points, the existing
+// labels are overwritten.
+func (t *transform) addSubmatchLabels(metric pdata.Metric, submatches []int) {
+	if t.re == nil {
+		return
+	}
+	name := metric.Name()
+	for i, label := range t.re.SubexpNames() {
+		if label == "" || submatches[2*i] < 0 {
+			continue
+		}
+		value := name[submatches[2*i]:submatches[2*i+1]]
+		forEachLabels(metric, func(labels pdata.StringMap) {
+			labels.Upsert(label, value)
+		})
+	}
+}
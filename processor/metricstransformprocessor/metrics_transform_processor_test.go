@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+// point is a data point with its labels, the value is converted for the int metrics.
+type point struct {
+	labels    map[string]string
+	value     float64
+	timestamp pdata.Timestamp
+}
+
+// addMetric appends a metric of the data type with the data points, the int and double gauges and sums only.
+func addMetric(metrics pdata.MetricSlice, name string, dataType pdata.MetricDataType, points ...point) {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDataType(dataType)
+	for _, p := range points {
+		switch dataType {
+		case pdata.MetricDataTypeIntGauge, pdata.MetricDataTypeIntSum:
+			dp := intDataPoints(metric).AppendEmpty()
+			dp.LabelsMap().InitFromMap(p.labels)
+			dp.SetValue(int64(p.value))
+			dp.SetStartTime(1)
+			dp.SetTimestamp(p.timestamp)
+		case pdata.MetricDataTypeDoubleGauge, pdata.MetricDataTypeDoubleSum:
+			dp := doubleDataPoints(metric).AppendEmpty()
+			dp.LabelsMap().InitFromMap(p.labels)
+			dp.SetValue(p.value)
+			dp.SetStartTime(1)
+			dp.SetTimestamp(p.timestamp)
+		}
+	}
+}
+
+func intDataPoints(metric pdata.Metric) pdata.IntDataPointSlice {
+	if metric.DataType() == pdata.MetricDataTypeIntSum {
+		return metric.IntSum().DataPoints()
+	}
+	return metric.IntGauge().DataPoints()
+}
+
+func doubleDataPoints(metric pdata.Metric) pdata.DoubleDataPointSlice {
+	if metric.DataType() == pdata.MetricDataTypeDoubleSum {
+		return metric.DoubleSum().DataPoints()
+	}
+	return metric.DoubleGauge().DataPoints()
+}
+
+// points returns the data points of a metric of the int and double gauges and sums, by labels key.
+func points(metric pdata.Metric) map[string]point {
+	res := make(map[string]point)
+	add := func(labels pdata.StringMap, value float64, timestamp pdata.Timestamp) {
+		p := point{labels: make(map[string]string), value: value, timestamp: timestamp}
+		labels.ForEach(func(k, v string) {
+			p.labels[k] = v
+		})
+		res[labelsKey(labels)] = p
+	}
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge, pdata.MetricDataTypeIntSum:
+		dps := intDataPoints(metric)
+		for i := 0; i < dps.Len(); i++ {
+			add(dps.At(i).LabelsMap(), float64(dps.At(i).Value()), dps.At(i).Timestamp())
+		}
+	case pdata.MetricDataTypeDoubleGauge, pdata.MetricDataTypeDoubleSum:
+		dps := doubleDataPoints(metric)
+		for i := 0; i < dps.Len(); i++ {
+			add(dps.At(i).LabelsMap(), dps.At(i).Value(), dps.At(i).Timestamp())
+		}
+	}
+	return res
+}
+
+// labels returns the labels key of the labels of a test data point.
+func labels(l map[string]string) string {
+	m := pdata.NewStringMap()
+	m.InitFromMap(l)
+	return labelsKey(m)
+}
+
+func process(t *testing.T, md pdata.Metrics, transforms ...Transform) pdata.MetricSlice {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Transforms = transforms
+	require.NoError(t, cfg.Validate())
+	p, err := newMetricsTransformProcessor(cfg)
+	require.NoError(t, err)
+	out, err := p.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+	return out.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+}
+
+func newMetricSlice() (pdata.Metrics, pdata.MetricSlice) {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	return md, metrics
+}
+
+func names(metrics pdata.MetricSlice) []string {
+	var res []string
+	for i := 0; i < metrics.Len(); i++ {
+		res = append(res, metrics.At(i).Name())
+	}
+	return res
+}
+
+func TestRenameAndUpdateLabel(t *testing.T) {
+	md, metrics := newMetricSlice()
+	addMetric(metrics, "system.cpu.usage", pdata.MetricDataTypeIntGauge,
+		point{labels: map[string]string{"state": "idle"}, value: 3},
+		point{labels: map[string]string{"state": "user"}, value: 1})
+	addMetric(metrics, "system.disk.usage", pdata.MetricDataTypeDoubleSum,
+		point{labels: map[string]string{"device": "sda"}, value: 2})
+
+	out := process(t, md,
+		Transform{
+			Include: "system.cpu.usage",
+			NewName: "cpu_usage",
+			Operations: []Operation{{
+				Action:       opUpdateLabel,
+				Label:        "state",
+				NewLabel:     "cpu_state",
+				ValueActions: []ValueAction{{Value: "idle", NewValue: "unused"}},
+			}},
+		},
+		Transform{
+			Include:   `system\.(.*)\.usage`,
+			MatchType: filterset.Regexp,
+			NewName:   "${1}_usage",
+			Operations: []Operation{{
+				Action:       opUpdateLabel,
+				Label:        "device",
+				ValueActions: []ValueAction{{Value: "sda", NewValue: "disk0"}},
+			}},
+		})
+
+	assert.Equal(t, []string{"cpu_usage", "disk_usage"}, names(out))
+	assert.Equal(t, map[string]point{
+		labels(map[string]string{"cpu_state": "unused"}): {labels: map[string]string{"cpu_state": "unused"}, value: 3},
+		labels(map[string]string{"cpu_state": "user"}):   {labels: map[string]string{"cpu_state": "user"}, value: 1},
+	}, points(out.At(0)))
+	assert.Equal(t, map[string]point{
+		labels(map[string]string{"device": "disk0"}): {labels: map[string]string{"device": "disk0"}, value: 2},
+	}, points(out.At(1)))
+}
+
+func TestInsertAggregateLabels(t *testing.T) {
+	md, metrics := newMetricSlice()
+	addMetric(metrics, "system.cpu.time", pdata.MetricDataTypeIntSum,
+		point{labels: map[string]string{"cpu": "0", "state": "user"}, value: 1, timestamp: 10},
+		point{labels: map[string]string{"cpu": "1", "state": "user"}, value: 2, timestamp: 20},
+		point{labels: map[string]string{"cpu": "0", "state": "idle"}, value: 4, timestamp: 10})
+
+	out := process(t, md, Transform{
+		Include: "system.cpu.time",
+		Action:  actionInsert,
+		NewName: "system.cpu.time.total",
+		Operations: []Operation{
+			{Action: opAddLabel, NewLabel: "host", NewValue: "localhost"},
+			{Action: opAggregateLabels, LabelSet: []string{"state", "host"}, AggregationType: aggregationSum},
+		},
+	})
+
+	// The original metric is kept.
+	assert.Equal(t, []string{"system.cpu.time", "system.cpu.time.total"}, names(out))
+	assert.Len(t, points(out.At(0)), 3)
+	assert.Equal(t, map[string]point{
+		labels(map[string]string{"state": "user", "host": "localhost"}): {labels: map[string]string{"state": "user", "host": "localhost"}, value: 3, timestamp: 20},
+		labels(map[string]string{"state": "idle", "host": "localhost"}): {labels: map[string]string{"state": "idle", "host": "localhost"}, value: 4, timestamp: 10},
+	}, points(out.At(1)))
+}
+
+func TestAggregateLabelValues(t *testing.T) {
+	tests := []struct {
+		aggregationType string
+		want            float64
+	}{
+		{aggregationType: aggregationSum, want: 6},
+		{aggregationType: aggregationMean, want: 2},
+		{aggregationType: aggregationMin, want: 1},
+		{aggregationType: aggregationMax, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.aggregationType, func(t *testing.T) {
+			md, metrics := newMetricSlice()
+			addMetric(metrics, "system.memory.usage", pdata.MetricDataTypeDoubleGauge,
+				point{labels: map[string]string{"state": "used"}, value: 1},
+				point{labels: map[string]string{"state": "buffered"}, value: 3},
+				point{labels: map[string]string{"state": "cached"}, value: 2},
+				point{labels: map[string]string{"state": "free"}, value: 10})
+
+			out := process(t, md, Transform{
+				Include: "system.memory.usage",
+				Operations: []Operation{{
+					Action:           opAggregateLabelValues,
+					Label:            "state",
+					AggregatedValues: []string{"used", "buffered", "cached"},
+					NewValue:         "used",
+					AggregationType:  tt.aggregationType,
+				}},
+			})
+
+			assert.Equal(t, map[string]point{
+				labels(map[string]string{"state": "used"}): {labels: map[string]string{"state": "used"}, value: tt.want},
+				labels(map[string]string{"state": "free"}): {labels: map[string]string{"state": "free"}, value: 10},
+			}, points(out.At(0)))
+		})
+	}
+}
+
+func TestDeleteLabelValue(t *testing.T) {
+	md, metrics := newMetricSlice()
+	addMetric(metrics, "system.cpu.usage", pdata.MetricDataTypeDoubleGauge,
+		point{labels: map[string]string{"state": "idle"}, value: 3},
+		point{labels: map[string]string{"state": "user"}, value: 1})
+	addMetric(metrics, "system.cpu.idle", pdata.MetricDataTypeIntSum,
+		point{labels: map[string]string{"state": "idle"}, value: 3})
+
+	out := process(t, md, Transform{
+		Include:    "system.cpu.*",
+		MatchType:  filterset.Regexp,
+		Operations: []Operation{{Action: opDeleteLabelValue, Label: "state", LabelValue: "idle"}},
+	})
+
+	// The metrics left without data points are removed.
+	assert.Equal(t, []string{"system.cpu.usage"}, names(out))
+	assert.Equal(t, map[string]point{
+		labels(map[string]string{"state": "user"}): {labels: map[string]string{"state": "user"}, value: 1},
+	}, points(out.At(0)))
+}
+
+func TestCombine(t *testing.T) {
+	md, metrics := newMetricSlice()
+	addMetric(metrics, "system.memory.used", pdata.MetricDataTypeIntGauge, point{labels: map[string]string{"host": "a"}, value: 1})
+	addMetric(metrics, "system.cpu.usage", pdata.MetricDataTypeIntGauge, point{value: 5})
+	addMetric(metrics, "system.memory.free", pdata.MetricDataTypeIntGauge, point{labels: map[string]string{"host": "a"}, value: 2})
+	// The metrics with another shape than the first matched one are not combined.
+	addMetric(metrics, "system.memory.utilization", pdata.MetricDataTypeDoubleGauge, point{value: 0.5})
+
+	out := process(t, md, Transform{
+		Include:   `system\.memory\.(?P<state>used|free|utilization)`,
+		MatchType: filterset.Regexp,
+		Action:    actionCombine,
+		NewName:   "system.memory.usage",
+	})
+
+	assert.Equal(t, []string{"system.cpu.usage", "system.memory.utilization", "system.memory.usage"}, names(out))
+	assert.Equal(t, pdata.MetricDataTypeIntGauge, out.At(2).DataType())
+	assert.Equal(t, map[string]point{
+		labels(map[string]string{"host": "a", "state": "used"}): {labels: map[string]string{"host": "a", "state": "used"}, value: 1},
+		labels(map[string]string{"host": "a", "state": "free"}): {labels: map[string]string{"host": "a", "state": "free"}, value: 2},
+	}, points(out.At(2)))
+}
+
+func TestAggregateSkipsHistograms(t *testing.T) {
+	md, metrics := newMetricSlice()
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	histogram.DoubleHistogram().DataPoints().AppendEmpty().LabelsMap().InitFromMap(map[string]string{"route": "/a"})
+	histogram.DoubleHistogram().DataPoints().AppendEmpty().LabelsMap().InitFromMap(map[string]string{"route": "/b"})
+
+	out := process(t, md, Transform{
+		Include:    "latency",
+		NewName:    "http.latency",
+		Operations: []Operation{{Action: opAggregateLabels, AggregationType: aggregationSum}},
+	})
+
+	// The histogram is renamed but its data points are left as is.
+	assert.Equal(t, []string{"http.latency"}, names(out))
+	assert.Equal(t, 2, out.At(0).DoubleHistogram().DataPoints().Len())
+	_, ok := out.At(0).DoubleHistogram().DataPoints().At(0).LabelsMap().Get("route")
+	assert.True(t, ok)
+}
@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// applyOperation changes the labels of the data points of the metric.
+func applyOperation(metric pdata.Metric, op Operation) {
+	switch op.Action {
+	case opAddLabel:
+		forEachLabels(metric, func(labels pdata.StringMap) {
+			labels.Insert(op.NewLabel, op.NewValue)
+		})
+	case opUpdateLabel:
+		forEachLabels(metric, func(labels pdata.StringMap) {
+			updateLabel(labels, op)
+		})
+	case opDeleteLabelValue:
+		removeDataPoints(metric, func(labels pdata.StringMap) bool {
+			v, ok := labels.Get(op.Label)
+			return ok && v == op.LabelValue
+		})
+	case opAggregateLabels:
+		if !aggregatable(metric) {
+			return
+		}
+		keep := make(map[string]bool, len(op.LabelSet))
+		for _, label := range op.LabelSet {
+			keep[label] = true
+		}
+		forEachLabels(metric, func(labels pdata.StringMap) {
+			var removed []string
+			labels.ForEach(func(k, _ string) {
+				if !keep[k] {
+					removed = append(removed, k)
+				}
+			})
+			for _, k := range removed {
+				labels.Delete(k)
+			}
+		})
+		aggregateDataPoints(metric, op.AggregationType)
+	case opAggregateLabelValues:
+		if !aggregatable(metric) {
+			return
+		}
+		aggregated := make(map[string]bool, len(op.AggregatedValues))
+		for _, value := range op.AggregatedValues {
+			aggregated[value] = true
+		}
+		forEachLabels(metric, func(labels pdata.StringMap) {
+			if v, ok := labels.Get(op.Label); ok && aggregated[v] {
+				labels.Update(op.Label, op.NewValue)
+			}
+		})
+		aggregateDataPoints(metric, op.AggregationType)
+	}
+}
+
+// updateLabel renames the values of the label, then the label itself, the existing label with the new name is
+// overwritten.
+func updateLabel(labels pdata.StringMap, op Operation) {
+	v, ok := labels.Get(op.Label)
+	if !ok {
+		return
+	}
+	for _, va := range op.ValueActions {
+		if va.Value == v {
+			v = va.NewValue
+			break
+		}
+	}
+	if op.NewLabel != "" && op.NewLabel != op.Label {
+		labels.Delete(op.Label)
+		labels.Upsert(op.NewLabel, v)
+		return
+	}
+	labels.Update(op.Label, v)
+}
+
+// aggregatable returns whether the data points of the metric can be aggregated, only the int and double gauges and
+// sums are.
+func aggregatable(metric pdata.Metric) bool {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge, pdata.MetricDataTypeDoubleGauge,
+		pdata.MetricDataTypeIntSum, pdata.MetricDataTypeDoubleSum:
+		return true
+	}
+	return false
+}
+
+// aggregateDataPoints merges the data points having the same labels, the merged data point has the earliest start
+// time, the latest timestamp and the value computed with the aggregation type.
+func aggregateDataPoints(metric pdata.Metric, aggregationType string) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		aggregateIntDataPoints(metric.IntGauge().DataPoints(), aggregationType)
+	case pdata.MetricDataTypeDoubleGauge:
+		aggregateDoubleDataPoints(metric.DoubleGauge().DataPoints(), aggregationType)
+	case pdata.MetricDataTypeIntSum:
+		aggregateIntDataPoints(metric.IntSum().DataPoints(), aggregationType)
+	case pdata.MetricDataTypeDoubleSum:
+		aggregateDoubleDataPoints(metric.DoubleSum().DataPoints(), aggregationType)
+	}
+}
+
+func aggregateIntDataPoints(dps pdata.IntDataPointSlice, aggregationType string) {
+	merged := make(map[string]pdata.IntDataPoint)
+	counts := make(map[string]int64)
+	dps.RemoveIf(func(dp pdata.IntDataPoint) bool {
+		key := labelsKey(dp.LabelsMap())
+		first, ok := merged[key]
+		if !ok {
+			merged[key] = dp
+			counts[key] = 1
+			return false
+		}
+		counts[key]++
+		mergeTimestamps(first.StartTime(), first.Timestamp(), dp.StartTime(), dp.Timestamp(), first.SetStartTime, first.SetTimestamp)
+		switch aggregationType {
+		case aggregationSum, aggregationMean:
+			first.SetValue(first.Value() + dp.Value())
+		case aggregationMin:
+			if dp.Value() < first.Value() {
+				first.SetValue(dp.Value())
+			}
+		case aggregationMax:
+			if dp.Value() > first.Value() {
+				first.SetValue(dp.Value())
+			}
+		}
+		return true
+	})
+	if aggregationType == aggregationMean {
+		for key, dp := range merged {
+			dp.SetValue(dp.Value() / counts[key])
+		}
+	}
+}
+
+func aggregateDoubleDataPoints(dps pdata.DoubleDataPointSlice, aggregationType string) {
+	merged := make(map[string]pdata.DoubleDataPoint)
+	counts := make(map[string]float64)
+	dps.RemoveIf(func(dp pdata.DoubleDataPoint) bool {
+		key := labelsKey(dp.LabelsMap())
+		first, ok := merged[key]
+		if !ok {
+			merged[key] = dp
+			counts[key] = 1
+			return false
+		}
+		counts[key]++
+		mergeTimestamps(first.StartTime(), first.Timestamp(), dp.StartTime(), dp.Timestamp(), first.SetStartTime, first.SetTimestamp)
+		switch aggregationType {
+		case aggregationSum, aggregationMean:
+			first.SetValue(first.Value() + dp.Value())
+		case aggregationMin:
+			if dp.Value() < first.Value() {
+				first.SetValue(dp.Value())
+			}
+		case aggregationMax:
+			if dp.Value() > first.Value() {
+				first.SetValue(dp.Value())
+			}
+		}
+		return true
+	})
+	if aggregationType == aggregationMean {
+		for key, dp := range merged {
+			dp.SetValue(dp.Value() / counts[key])
+		}
+	}
+}
+
+// mergeTimestamps sets the earliest start time and the latest timestamp of two data points.
+func mergeTimestamps(startTime, timestamp, otherStartTime, otherTimestamp pdata.Timestamp, setStartTime, setTimestamp func(pdata.Timestamp)) {
+	if otherStartTime != 0 && (startTime == 0 || otherStartTime < startTime) {
+		setStartTime(otherStartTime)
+	}
+	if otherTimestamp > timestamp {
+		setTimestamp(otherTimestamp)
+	}
+}
+
+// labelsKey returns a key identifying the labels whatever their order.
+func labelsKey(labels pdata.StringMap) string {
+	var b strings.Builder
+	labels.Sort().ForEach(func(k, v string) {
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(v)
+		b.WriteByte(0)
+	})
+	return b.String()
+}
+
+// sameShape returns whether the data points of the metrics can be combined in a single metric.
+func sameShape(a, b pdata.Metric) bool {
+	if a.DataType() != b.DataType() {
+		return false
+	}
+	switch a.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		return a.IntSum().AggregationTemporality() == b.IntSum().AggregationTemporality() &&
+			a.IntSum().IsMonotonic() == b.IntSum().IsMonotonic()
+	case pdata.MetricDataTypeDoubleSum:
+		return a.DoubleSum().AggregationTemporality() == b.DoubleSum().AggregationTemporality() &&
+			a.DoubleSum().IsMonotonic() == b.DoubleSum().IsMonotonic()
+	case pdata.MetricDataTypeIntHistogram:
+		return a.IntHistogram().AggregationTemporality() == b.IntHistogram().AggregationTemporality()
+	case pdata.MetricDataTypeDoubleHistogram:
+		return a.DoubleHistogram().AggregationTemporality() == b.DoubleHistogram().AggregationTemporality()
+	}
+	return true
+}
+
+// moveDataPoints moves the data points of src to dest, both metrics must have the same shape.
+func moveDataPoints(src, dest pdata.Metric) {
+	switch src.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		src.IntGauge().DataPoints().MoveAndAppendTo(dest.IntGauge().DataPoints())
+	case pdata.MetricDataTypeDoubleGauge:
+		src.DoubleGauge().DataPoints().MoveAndAppendTo(dest.DoubleGauge().DataPoints())
+	case pdata.MetricDataTypeIntSum:
+		src.IntSum().DataPoints().MoveAndAppendTo(dest.IntSum().DataPoints())
+	case pdata.MetricDataTypeDoubleSum:
+		src.DoubleSum().DataPoints().MoveAndAppendTo(dest.DoubleSum().DataPoints())
+	case pdata.MetricDataTypeIntHistogram:
+		src.IntHistogram().DataPoints().MoveAndAppendTo(dest.IntHistogram().DataPoints())
+	case pdata.MetricDataTypeDoubleHistogram:
+		src.DoubleHistogram().DataPoints().MoveAndAppendTo(dest.DoubleHistogram().DataPoints())
+	case pdata.MetricDataTypeSummary:
+		src.Summary().DataPoints().MoveAndAppendTo(dest.Summary().DataPoints())
+	}
+}
+
+// dataPointCount returns the number of data points of the metric.
+func dataPointCount(metric pdata.Metric) int {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		return metric.IntGauge().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleGauge:
+		return metric.DoubleGauge().DataPoints().Len()
+	case pdata.MetricDataTypeIntSum:
+		return metric.IntSum().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleSum:
+		return metric.DoubleSum().DataPoints().Len()
+	case pdata.MetricDataTypeIntHistogram:
+		return metric.IntHistogram().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleHistogram:
+		return metric.DoubleHistogram().DataPoints().Len()
+	case pdata.MetricDataTypeSummary:
+		return metric.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+// removeDataPoints removes the data points of the metric whose labels f returns true for.
+func removeDataPoints(metric pdata.Metric, f func(pdata.StringMap) bool) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		metric.IntGauge().DataPoints().RemoveIf(func(dp pdata.IntDataPoint) bool { return f(dp.LabelsMap()) })
+	case pdata.MetricDataTypeDoubleGauge:
+		metric.DoubleGauge().DataPoints().RemoveIf(func(dp pdata.DoubleDataPoint) bool { return f(dp.LabelsMap()) })
+	case pdata.MetricDataTypeIntSum:
+		metric.IntSum().DataPoints().RemoveIf(func(dp pdata.IntDataPoint) bool { return f(dp.LabelsMap()) })
+	case pdata.MetricDataTypeDoubleSum:
+		metric.DoubleSum().DataPoints().RemoveIf(func(dp pdata.DoubleDataPoint) bool { return f(dp.LabelsMap()) })
+	case pdata.MetricDataTypeIntHistogram:
+		metric.IntHistogram().DataPoints().RemoveIf(func(dp pdata.IntHistogramDataPoint) bool { return f(dp.LabelsMap()) })
+	case pdata.MetricDataTypeDoubleHistogram:
+		metric.DoubleHistogram().DataPoints().RemoveIf(func(dp pdata.DoubleHistogramDataPoint) bool { return f(dp.LabelsMap()) })
+	case pdata.MetricDataTypeSummary:
+		metric.Summary().DataPoints().RemoveIf(func(dp pdata.SummaryDataPoint) bool { return f(dp.LabelsMap()) })
+	}
+}
+
+// forEachLabels calls f with the labels of all the data points of the metric.
+func forEachLabels(metric pdata.Metric, f func(pdata.StringMap)) {
+	removeDataPoints(metric, func(labels pdata.StringMap) bool {
+		f(labels)
+		return false
+	})
+}
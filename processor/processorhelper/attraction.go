@@ -26,7 +26,7 @@ import (
 // Settings
 type Settings struct {
 	// Actions specifies the list of attributes to act on.
-	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.
+	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT, CONVERT}.
 	// This is a required field.
 	Actions []ActionKeyValue `mapstructure:"actions"`
 }
@@ -55,6 +55,14 @@ type ActionKeyValue struct {
 	// the value. If the attribute doesn't exist, no action is performed.
 	FromAttribute string `mapstructure:"from_attribute"`
 
+	// ConvertedType specifies the type the value is converted to by the action CONVERT.
+	// The set of values are {int, double, bool, string}.
+	ConvertedType string `mapstructure:"converted_type"`
+
+	// OnError specifies what the action CONVERT does with a value that cannot be converted.
+	// The set of values are {ignore, delete}, ignore keeps the value as is and is the default.
+	OnError string `mapstructure:"on_error"`
+
 	// Action specifies the type of action to perform.
 	// The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH}.
 	// Both lower case and upper case are supported.
@@ -76,6 +84,8 @@ type ActionKeyValue struct {
 	// EXTRACT - Extracts values using a regular expression rule from the input
 	//           'key' to target keys specified in the 'rule'. If a target key
 	//           already exists, it will be overridden.
+	// CONVERT - Converts the value of an existing key to the type specified in
+	//           'converted_type'.
 	// This is a required field.
 	Action Action `mapstructure:"action"`
 }
@@ -109,6 +119,10 @@ const (
 	// 'key' to target keys specified in the 'rule'. If a target key already
 	// exists, it will be overridden.
 	EXTRACT Action = "extract"
+
+	// CONVERT converts the value of an existing key to the type specified in
+	// 'converted_type'. If the key doesn't exist, no action is performed.
+	CONVERT Action = "convert"
 )
 
 type attributeAction struct {
@@ -126,6 +140,9 @@ type attributeAction struct {
 	// and could impact performance.
 	Action         Action
 	AttributeValue *pdata.AttributeValue
+	// Type converted to and behavior on conversion errors of the action CONVERT.
+	ConvertedType string
+	OnError       string
 }
 
 type AttrProc struct {
@@ -201,6 +218,24 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 			}
 			action.Regex = re
 			action.AttrNames = attrNames
+		case CONVERT:
+			if a.Value != nil || a.FromAttribute != "" || a.RegexPattern != "" {
+				return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use \"value\", \"pattern\" or \"from_attribute\" field. These must not be specified for %d-th action", a.Action, i)
+			}
+			switch a.ConvertedType {
+			case convertedTypeInt, convertedTypeDouble, convertedTypeBool, convertedTypeString:
+			default:
+				return nil, fmt.Errorf("error creating AttrProc due to unsupported \"converted_type\" %q at the %d-th actions", a.ConvertedType, i)
+			}
+			switch a.OnError {
+			case "":
+				a.OnError = onErrorIgnore
+			case onErrorIgnore, onErrorDelete:
+			default:
+				return nil, fmt.Errorf("error creating AttrProc due to unsupported \"on_error\" %q at the %d-th actions", a.OnError, i)
+			}
+			action.ConvertedType = a.ConvertedType
+			action.OnError = a.OnError
 		default:
 			return nil, fmt.Errorf("error creating AttrProc due to unsupported action %q at the %d-th actions", a.Action, i)
 		}
@@ -241,6 +276,8 @@ func (ap *AttrProc) Process(attrs pdata.AttributeMap) {
 			hashAttribute(action, attrs)
 		case EXTRACT:
 			extractAttributes(action, attrs)
+		case CONVERT:
+			convertAttribute(action, attrs)
 		}
 	}
 }
@@ -260,6 +297,16 @@ func hashAttribute(action attributeAction, attrs pdata.AttributeMap) {
 	}
 }
 
+func convertAttribute(action attributeAction, attrs pdata.AttributeMap) {
+	value, exists := attrs.Get(action.Key)
+	if !exists {
+		return
+	}
+	if !convertValue(value, action.ConvertedType) && action.OnError == onErrorDelete {
+		attrs.Delete(action.Key)
+	}
+}
+
 func extractAttributes(action attributeAction, attrs pdata.AttributeMap) {
 	value, found := attrs.Get(action.Key)
 
@@ -629,6 +629,76 @@ func TestAttributes_HashValue(t *testing.T) {
 	}
 }
 
+func TestAttributes_Convert(t *testing.T) {
+	testCases := []struct {
+		name          string
+		convertedType string
+		onError       string
+		input         pdata.AttributeValue
+		expected      map[string]pdata.AttributeValue
+	}{
+		{name: "StringToInt", convertedType: "int", input: pdata.NewAttributeValueString(" 42 "),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueInt(42)}},
+		{name: "DoubleToInt", convertedType: "int", input: pdata.NewAttributeValueDouble(4.2),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueInt(4)}},
+		{name: "BoolToInt", convertedType: "int", input: pdata.NewAttributeValueBool(true),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueInt(1)}},
+		{name: "StringToDouble", convertedType: "double", input: pdata.NewAttributeValueString("4.2"),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueDouble(4.2)}},
+		{name: "IntToDouble", convertedType: "double", input: pdata.NewAttributeValueInt(42),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueDouble(42)}},
+		{name: "StringToBool", convertedType: "bool", input: pdata.NewAttributeValueString("true"),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueBool(true)}},
+		{name: "IntToBool", convertedType: "bool", input: pdata.NewAttributeValueInt(0),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueBool(false)}},
+		{name: "IntToString", convertedType: "string", input: pdata.NewAttributeValueInt(42),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueString("42")}},
+		{name: "DoubleToString", convertedType: "string", input: pdata.NewAttributeValueDouble(4.2),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueString("4.2")}},
+		{name: "BoolToString", convertedType: "string", input: pdata.NewAttributeValueBool(false),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueString("false")}},
+		// Ensure the value is kept as is when it cannot be converted.
+		{name: "InvalidStringToInt", convertedType: "int", input: pdata.NewAttributeValueString("4.2"),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueString("4.2")}},
+		{name: "MapToString", convertedType: "string", input: pdata.NewAttributeValueMap(),
+			expected: map[string]pdata.AttributeValue{"convertme": pdata.NewAttributeValueMap()}},
+		// Ensure the attribute is deleted when it cannot be converted with on_error delete.
+		{name: "InvalidStringToBoolDelete", convertedType: "bool", onError: "delete", input: pdata.NewAttributeValueString("maybe"),
+			expected: map[string]pdata.AttributeValue{}},
+	}
+
+	for _, tc := range testCases {
+		cfg := &Settings{
+			Actions: []ActionKeyValue{
+				{Key: "convertme", Action: CONVERT, ConvertedType: tc.convertedType, OnError: tc.onError},
+			},
+		}
+		ap, err := NewAttrProc(cfg)
+		require.Nil(t, err)
+		require.NotNil(t, ap)
+
+		// The other attributes are left as is.
+		tc.expected["boo"] = pdata.NewAttributeValueString("foo")
+		runIndividualTestCase(t, testCase{
+			name: tc.name,
+			inputAttributes: map[string]pdata.AttributeValue{
+				"convertme": tc.input,
+				"boo":       pdata.NewAttributeValueString("foo"),
+			},
+			expectedAttributes: tc.expected,
+		}, ap)
+	}
+
+	// Ensure no changes to the span as the key does not exist.
+	ap, err := NewAttrProc(&Settings{Actions: []ActionKeyValue{{Key: "convertme", Action: CONVERT, ConvertedType: "int", OnError: "delete"}}})
+	require.Nil(t, err)
+	runIndividualTestCase(t, testCase{
+		name:               "ConvertKeyNoExist",
+		inputAttributes:    map[string]pdata.AttributeValue{"boo": pdata.NewAttributeValueString("foo")},
+		expectedAttributes: map[string]pdata.AttributeValue{"boo": pdata.NewAttributeValueString("foo")},
+	}, ap)
+}
+
 func TestAttributes_FromAttributeNoChange(t *testing.T) {
 	tc := testCase{
 		name: "FromAttributeNoChange",
@@ -835,6 +905,27 @@ func TestInvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating AttrProc. Field \"pattern\" contains at least one unnamed matcher group at the 0-th actions",
 		},
+		{
+			name: "convert with value",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Value: 123, ConvertedType: "int", Action: CONVERT},
+			},
+			errorString: "error creating AttrProc. Action \"convert\" does not use \"value\", \"pattern\" or \"from_attribute\" field. These must not be specified for 0-th action",
+		},
+		{
+			name: "convert with unsupported type",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", ConvertedType: "float", Action: CONVERT},
+			},
+			errorString: "error creating AttrProc due to unsupported \"converted_type\" \"float\" at the 0-th actions",
+		},
+		{
+			name: "convert with unsupported on error",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", ConvertedType: "int", OnError: "drop", Action: CONVERT},
+			},
+			errorString: "error creating AttrProc due to unsupported \"on_error\" \"drop\" at the 0-th actions",
+		},
 	}
 
 	for _, tc := range testcase {
@@ -854,6 +945,7 @@ func TestValidConfiguration(t *testing.T) {
 			{Key: "three", FromAttribute: "two", Action: "upDaTE"},
 			{Key: "five", FromAttribute: "two", Action: "upsert"},
 			{Key: "two", RegexPattern: "^\\/api\\/v1\\/document\\/(?P<documentId>.*)\\/update$", Action: "EXTRact"},
+			{Key: "six", ConvertedType: "int", Action: "CONVERT"},
 		},
 	}
 	ap, err := NewAttrProc(cfg)
@@ -869,6 +961,7 @@ func TestValidConfiguration(t *testing.T) {
 		{Key: "three", FromAttribute: "two", Action: UPDATE},
 		{Key: "five", FromAttribute: "two", Action: UPSERT},
 		{Key: "two", Regex: compiledRegex, AttrNames: []string{"", "documentId"}, Action: EXTRACT},
+		{Key: "six", Action: CONVERT, ConvertedType: "int", OnError: "ignore"},
 	}, ap.actions)
 
 }
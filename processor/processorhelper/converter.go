@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processorhelper
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	convertedTypeInt    = "int"
+	convertedTypeDouble = "double"
+	convertedTypeBool   = "bool"
+	convertedTypeString = "string"
+
+	onErrorIgnore = "ignore"
+	onErrorDelete = "delete"
+)
+
+// convertValue converts the AttributeValue in place to the type, it returns
+// false and leaves the value as is if it cannot be converted. The strings are
+// parsed after trimming the spaces, the booleans convert to and from 1 and 0.
+// The maps and arrays cannot be converted.
+func convertValue(attr pdata.AttributeValue, convertedType string) bool {
+	switch convertedType {
+	case convertedTypeInt:
+		switch attr.Type() {
+		case pdata.AttributeValueINT:
+		case pdata.AttributeValueSTRING:
+			i, err := strconv.ParseInt(strings.TrimSpace(attr.StringVal()), 10, 64)
+			if err != nil {
+				return false
+			}
+			attr.SetIntVal(i)
+		case pdata.AttributeValueDOUBLE:
+			attr.SetIntVal(int64(attr.DoubleVal()))
+		case pdata.AttributeValueBOOL:
+			attr.SetIntVal(boolToInt(attr.BoolVal()))
+		default:
+			return false
+		}
+	case convertedTypeDouble:
+		switch attr.Type() {
+		case pdata.AttributeValueDOUBLE:
+		case pdata.AttributeValueSTRING:
+			d, err := strconv.ParseFloat(strings.TrimSpace(attr.StringVal()), 64)
+			if err != nil {
+				return false
+			}
+			attr.SetDoubleVal(d)
+		case pdata.AttributeValueINT:
+			attr.SetDoubleVal(float64(attr.IntVal()))
+		case pdata.AttributeValueBOOL:
+			attr.SetDoubleVal(float64(boolToInt(attr.BoolVal())))
+		default:
+			return false
+		}
+	case convertedTypeBool:
+		switch attr.Type() {
+		case pdata.AttributeValueBOOL:
+		case pdata.AttributeValueSTRING:
+			b, err := strconv.ParseBool(strings.TrimSpace(attr.StringVal()))
+			if err != nil {
+				return false
+			}
+			attr.SetBoolVal(b)
+		case pdata.AttributeValueINT:
+			attr.SetBoolVal(attr.IntVal() != 0)
+		case pdata.AttributeValueDOUBLE:
+			attr.SetBoolVal(attr.DoubleVal() != 0)
+		default:
+			return false
+		}
+	case convertedTypeString:
+		switch attr.Type() {
+		case pdata.AttributeValueSTRING:
+		case pdata.AttributeValueINT:
+			attr.SetStringVal(strconv.FormatInt(attr.IntVal(), 10))
+		case pdata.AttributeValueDOUBLE:
+			attr.SetStringVal(strconv.FormatFloat(attr.DoubleVal(), 'f', -1, 64))
+		case pdata.AttributeValueBOOL:
+			attr.SetStringVal(strconv.FormatBool(attr.BoolVal()))
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processorhelper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// Tick specifies the function invoked at every interval of the ticker of a processor.
+type Tick func(context.Context) error
+
+// Flush specifies the function invoked when the processor is being shutdown to send the data it holds.
+type Flush func(context.Context) error
+
+// Start starts the processor, then its ticker when it has one.
+func (bp *baseProcessor) Start(ctx context.Context, host component.Host) error {
+	if err := bp.Component.Start(ctx, host); err != nil {
+		return err
+	}
+	if bp.tick != nil {
+		bp.stopCh = make(chan struct{})
+		bp.tickWG.Add(1)
+		go bp.runTicker(host)
+	}
+	return nil
+}
+
+// Shutdown stops the ticker of the processor, flushes it and then shuts it down.
+func (bp *baseProcessor) Shutdown(ctx context.Context) error {
+	if bp.stopCh != nil {
+		close(bp.stopCh)
+		bp.tickWG.Wait()
+	}
+
+	var errs []error
+	if bp.flush != nil {
+		if err := bp.callRecovering(func() error { return bp.flush(ctx) }); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := bp.Component.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return consumererror.Combine(errs)
+}
+
+// runTicker calls the tick function at every interval until the processor is shut down. The status of the processor
+// is reported as a recoverable error when a tick fails, and as OK again after the next successful tick.
+func (bp *baseProcessor) runTicker(host component.Host) {
+	defer bp.tickWG.Done()
+	ticker := time.NewTicker(bp.tickInterval)
+	defer ticker.Stop()
+	failing := false
+	for {
+		select {
+		case <-bp.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		err := bp.callRecovering(func() error { return bp.tick(context.Background()) })
+		switch {
+		case err != nil && !failing:
+			host.ReportComponentStatus(&component.StatusEvent{Status: component.StatusRecoverableError, Err: err})
+			failing = true
+		case err == nil && failing:
+			host.ReportComponentStatus(&component.StatusEvent{Status: component.StatusOK})
+			failing = false
+		}
+	}
+}
+
+// callRecovering calls f and converts its panic to a permanent error wrapping componenterror.ErrPanic, the data
+// making a processor panic would make it panic again if it was retried.
+func (bp *baseProcessor) callRecovering(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = consumererror.Permanent(fmt.Errorf("%w in processor %q: %v", componenterror.ErrPanic, bp.fullName, r))
+		}
+	}()
+	return f()
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processorhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []*component.StatusEvent
+}
+
+func (sh *statusHost) ReportComponentStatus(event *component.StatusEvent) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.events = append(sh.events, event)
+}
+
+func (sh *statusHost) statuses() []component.Status {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	var statuses []component.Status
+	for _, event := range sh.events {
+		statuses = append(statuses, event.Status)
+	}
+	return statuses
+}
+
+type panicTProcessor struct{}
+
+func (panicTProcessor) ProcessTraces(context.Context, pdata.Traces) (pdata.Traces, error) {
+	panic("boom")
+}
+
+func TestTickerAndFlush(t *testing.T) {
+	var ticks int32
+	var calls []string
+	tp, err := NewTraceProcessor(testCfg, consumertest.NewTracesNop(), newTestTProcessor(nil),
+		WithTicker(time.Millisecond, func(context.Context) error {
+			atomic.AddInt32(&ticks, 1)
+			return nil
+		}),
+		WithFlush(func(context.Context) error {
+			calls = append(calls, "flush")
+			return nil
+		}),
+		WithShutdown(func(context.Context) error {
+			calls = append(calls, "shutdown")
+			return nil
+		}))
+	require.NoError(t, err)
+
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&ticks) >= 2 }, time.Second, time.Millisecond)
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	// The ticker is stopped before the flush.
+	stopped := atomic.LoadInt32(&ticks)
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&ticks))
+	assert.Equal(t, []string{"flush", "shutdown"}, calls)
+}
+
+func TestTickerReportsStatus(t *testing.T) {
+	var ticks int32
+	tp, err := NewTraceProcessor(testCfg, consumertest.NewTracesNop(), newTestTProcessor(nil),
+		WithTicker(time.Millisecond, func(context.Context) error {
+			switch atomic.AddInt32(&ticks, 1) {
+			case 1:
+				return errors.New("my_error")
+			case 2:
+				panic("boom")
+			}
+			return nil
+		}))
+	require.NoError(t, err)
+
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, tp.Start(context.Background(), host))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&ticks) >= 4 }, time.Second, time.Millisecond)
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	assert.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, host.statuses())
+}
+
+func TestFlushErrors(t *testing.T) {
+	want := errors.New("my_error")
+	lp, err := NewLogsProcessor(testCfg, consumertest.NewLogsNop(), newTestLProcessor(nil),
+		WithFlush(func(context.Context) error { panic("boom") }),
+		WithShutdown(func(context.Context) error { return want }))
+	require.NoError(t, err)
+
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	err = lp.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `panic in processor "testFullName": boom`)
+	assert.Contains(t, err.Error(), want.Error())
+}
+
+func TestProcessPanic(t *testing.T) {
+	tp, err := NewTraceProcessor(testCfg, consumertest.NewTracesNop(), panicTProcessor{})
+	require.NoError(t, err)
+
+	err = tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, componenterror.ErrPanic))
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.EqualError(t, err, `Permanent error: panic in processor "testFullName": boom`)
+}
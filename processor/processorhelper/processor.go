@@ -17,6 +17,8 @@ package processorhelper
 import (
 	"context"
 	"errors"
+	"sync"
+	"time"
 
 	"go.opencensus.io/trace"
 
@@ -24,14 +26,15 @@ import (
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/component/componenthelper"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
-// ErrSkipProcessingData is a sentinel value to indicate when traces or metrics should intentionally be dropped
+// ErrSkipProcessingData is a sentinel value to indicate when traces, metrics or logs should intentionally be dropped
 // from further processing in the pipeline because the data is determined to be irrelevant. A processor can return this error
-// to stop further processing without propagating an error back up the pipeline to logs.
+// to stop further processing without propagating an error back up the pipeline to logs, the data is reported as dropped.
 var ErrSkipProcessingData = errors.New("sentinel error to skip processing data from the remainder of the pipeline")
 
 // TProcessor is a helper interface that allows avoiding implementing all functions in TracesProcessor by using NewTraceProcessor.
@@ -82,9 +85,30 @@ func WithCapabilities(capabilities component.ProcessorCapabilities) Option {
 	}
 }
 
+// WithTicker makes the processor call tick at every interval, from its start until its shutdown, for the processors
+// working in the background like the ones batching the data. The errors returned by tick, and its panics, are reported
+// to the host as a recoverable error status of the processor, the status goes back to OK after the next successful tick.
+func WithTicker(interval time.Duration, tick Tick) Option {
+	return func(o *baseSettings) {
+		o.tickInterval = interval
+		o.tick = tick
+	}
+}
+
+// WithFlush sets the function invoked when the processor is being shutdown, after its ticker is stopped and before the
+// Shutdown function, to send the data held by the processor to the next consumer.
+func WithFlush(flush Flush) Option {
+	return func(o *baseSettings) {
+		o.flush = flush
+	}
+}
+
 type baseSettings struct {
 	componentOptions []componenthelper.Option
 	capabilities     component.ProcessorCapabilities
+	tickInterval     time.Duration
+	tick             Tick
+	flush            Flush
 }
 
 // fromOptions returns the internal settings starting from the default and applying all options.
@@ -107,18 +131,33 @@ type baseProcessor struct {
 	fullName        string
 	capabilities    component.ProcessorCapabilities
 	traceAttributes []trace.Attribute
+	obsrep          *obsreport.Processor
+
+	// tickInterval, tick and flush are set with WithTicker and WithFlush, stopCh stops the ticker at shutdown.
+	tickInterval time.Duration
+	tick         Tick
+	flush        Flush
+	stopCh       chan struct{}
+	tickWG       sync.WaitGroup
 }
 
 // Construct the internalOptions from multiple Option.
-func newBaseProcessor(fullName string, options ...Option) baseProcessor {
+func newBaseProcessor(fullName string, options ...Option) *baseProcessor {
 	bs := fromOptions(options)
-	be := baseProcessor{
+	be := &baseProcessor{
 		Component:    componenthelper.New(bs.componentOptions...),
 		fullName:     fullName,
 		capabilities: bs.capabilities,
 		traceAttributes: []trace.Attribute{
 			trace.StringAttribute(obsreport.ProcessorKey, fullName),
 		},
+		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
+			Level:         configtelemetry.GetMetricsLevelFlagValue(),
+			ProcessorName: fullName,
+		}),
+		tickInterval: bs.tickInterval,
+		tick:         bs.tick,
+		flush:        bs.flush,
 	}
 
 	return be
@@ -129,7 +168,7 @@ func (bp *baseProcessor) GetCapabilities() component.ProcessorCapabilities {
 }
 
 type tracesProcessor struct {
-	baseProcessor
+	*baseProcessor
 	processor    TProcessor
 	nextConsumer consumer.Traces
 }
@@ -137,17 +176,33 @@ type tracesProcessor struct {
 func (tp *tracesProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
 	span := trace.FromContext(ctx)
 	span.Annotate(tp.traceAttributes, "Start processing.")
+	processCtx := tp.obsrep.StartTracesProcessOp(ctx)
+	numReceived := td.SpanCount()
 	var err error
-	td, err = tp.processor.ProcessTraces(ctx, td)
+	err = tp.callRecovering(func() (err error) {
+		td, err = tp.processor.ProcessTraces(processCtx, td)
+		return err
+	})
+	switch err {
+	case nil:
+		tp.obsrep.EndTracesProcessOp(processCtx, numReceived, td.SpanCount(), nil)
+	case ErrSkipProcessingData:
+		tp.obsrep.EndTracesProcessOp(processCtx, numReceived, 0, nil)
+	default:
+		tp.obsrep.EndTracesProcessOp(processCtx, numReceived, 0, err)
+	}
 	span.Annotate(tp.traceAttributes, "End processing.")
 	if err != nil {
+		if err == ErrSkipProcessingData {
+			return nil
+		}
 		return err
 	}
 	return tp.nextConsumer.ConsumeTraces(ctx, td)
 }
 
-// NewTraceProcessor creates a TracesProcessor that ensure context propagation and the right tags are set.
-// TODO: Add observability metrics support
+// NewTraceProcessor creates a TracesProcessor that ensure context propagation and the right tags are set. The spans
+// are reported as accepted, refused or dropped by the processor with the obsreport.Processor helpers.
 func NewTraceProcessor(
 	config configmodels.Processor,
 	nextConsumer consumer.Traces,
@@ -170,7 +225,7 @@ func NewTraceProcessor(
 }
 
 type metricsProcessor struct {
-	baseProcessor
+	*baseProcessor
 	processor    MProcessor
 	nextConsumer consumer.Metrics
 }
@@ -178,8 +233,22 @@ type metricsProcessor struct {
 func (mp *metricsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
 	span := trace.FromContext(ctx)
 	span.Annotate(mp.traceAttributes, "Start processing.")
+	processCtx := mp.obsrep.StartMetricsProcessOp(ctx)
+	_, numReceived := md.MetricAndDataPointCount()
 	var err error
-	md, err = mp.processor.ProcessMetrics(ctx, md)
+	err = mp.callRecovering(func() (err error) {
+		md, err = mp.processor.ProcessMetrics(processCtx, md)
+		return err
+	})
+	switch err {
+	case nil:
+		_, numProcessed := md.MetricAndDataPointCount()
+		mp.obsrep.EndMetricsProcessOp(processCtx, numReceived, numProcessed, nil)
+	case ErrSkipProcessingData:
+		mp.obsrep.EndMetricsProcessOp(processCtx, numReceived, 0, nil)
+	default:
+		mp.obsrep.EndMetricsProcessOp(processCtx, numReceived, 0, err)
+	}
 	span.Annotate(mp.traceAttributes, "End processing.")
 	if err != nil {
 		if err == ErrSkipProcessingData {
@@ -190,8 +259,8 @@ func (mp *metricsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics
 	return mp.nextConsumer.ConsumeMetrics(ctx, md)
 }
 
-// NewMetricsProcessor creates a MetricsProcessor that ensure context propagation and the right tags are set.
-// TODO: Add observability metrics support
+// NewMetricsProcessor creates a MetricsProcessor that ensure context propagation and the right tags are set. The
+// metric points are reported as accepted, refused or dropped by the processor with the obsreport.Processor helpers.
 func NewMetricsProcessor(
 	config configmodels.Processor,
 	nextConsumer consumer.Metrics,
@@ -214,7 +283,7 @@ func NewMetricsProcessor(
 }
 
 type logProcessor struct {
-	baseProcessor
+	*baseProcessor
 	processor    LProcessor
 	nextConsumer consumer.Logs
 }
@@ -222,17 +291,33 @@ type logProcessor struct {
 func (lp *logProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
 	span := trace.FromContext(ctx)
 	span.Annotate(lp.traceAttributes, "Start processing.")
+	processCtx := lp.obsrep.StartLogsProcessOp(ctx)
+	numReceived := ld.LogRecordCount()
 	var err error
-	ld, err = lp.processor.ProcessLogs(ctx, ld)
+	err = lp.callRecovering(func() (err error) {
+		ld, err = lp.processor.ProcessLogs(processCtx, ld)
+		return err
+	})
+	switch err {
+	case nil:
+		lp.obsrep.EndLogsProcessOp(processCtx, numReceived, ld.LogRecordCount(), nil)
+	case ErrSkipProcessingData:
+		lp.obsrep.EndLogsProcessOp(processCtx, numReceived, 0, nil)
+	default:
+		lp.obsrep.EndLogsProcessOp(processCtx, numReceived, 0, err)
+	}
 	span.Annotate(lp.traceAttributes, "End processing.")
 	if err != nil {
+		if err == ErrSkipProcessingData {
+			return nil
+		}
 		return err
 	}
 	return lp.nextConsumer.ConsumeLogs(ctx, ld)
 }
 
-// NewLogsProcessor creates a LogsProcessor that ensure context propagation and the right tags are set.
-// TODO: Add observability metrics support
+// NewLogsProcessor creates a LogsProcessor that ensure context propagation and the right tags are set. The log
+// records are reported as accepted, refused or dropped by the processor with the obsreport.Processor helpers.
 func NewLogsProcessor(
 	config configmodels.Processor,
 	nextConsumer consumer.Logs,
@@ -17,18 +17,23 @@ package processorhelper
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
 
 const testFullName = "testFullName"
@@ -80,6 +85,26 @@ func TestNewTraceExporter_ProcessTraceError(t *testing.T) {
 	assert.Equal(t, want, me.ConsumeTraces(context.Background(), testdata.GenerateTraceDataEmpty()))
 }
 
+func TestNewTraceExporter_ProcessSpan(t *testing.T) {
+	ss := &spanStore{}
+	trace.RegisterExporter(ss)
+	defer trace.UnregisterExporter(ss)
+
+	want := errors.New("my_error")
+	me, err := NewTraceProcessor(testCfg, consumertest.NewTracesNop(), newTestTProcessor(want))
+	require.NoError(t, err)
+
+	ctx, parentSpan := trace.StartSpan(context.Background(), t.Name(), trace.WithSampler(trace.AlwaysSample()))
+	assert.Equal(t, want, me.ConsumeTraces(ctx, testdata.GenerateTraceDataEmpty()))
+	parentSpan.End()
+
+	spans := ss.pullAllSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "processor/"+testFullName+"/traces", spans[0].Name)
+	assert.Equal(t, parentSpan.SpanContext().SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, want.Error(), spans[0].Status.Message)
+}
+
 func TestNewMetricsExporter(t *testing.T) {
 	me, err := NewMetricsProcessor(testCfg, consumertest.NewMetricsNop(), newTestMProcessor(nil))
 	require.NoError(t, err)
@@ -104,6 +129,12 @@ func TestNewMetricsExporter_ProcessMetricsError(t *testing.T) {
 	assert.Equal(t, want, me.ConsumeMetrics(context.Background(), testdata.GenerateMetricsEmpty()))
 }
 
+func TestNewTraceExporter_ProcessTraceErrSkipProcessingData(t *testing.T) {
+	me, err := NewTraceProcessor(testCfg, consumertest.NewTracesNop(), newTestTProcessor(ErrSkipProcessingData))
+	require.NoError(t, err)
+	assert.Equal(t, nil, me.ConsumeTraces(context.Background(), testdata.GenerateTraceDataEmpty()))
+}
+
 func TestNewMetricsExporter_ProcessMetricsErrSkipProcessingData(t *testing.T) {
 	me, err := NewMetricsProcessor(testCfg, consumertest.NewMetricsNop(), newTestMProcessor(ErrSkipProcessingData))
 	require.NoError(t, err)
@@ -134,6 +165,31 @@ func TestNewLogsExporter_ProcessLogError(t *testing.T) {
 	assert.Equal(t, want, me.ConsumeLogs(context.Background(), testdata.GenerateLogDataEmpty()))
 }
 
+func TestProcessorObsreport(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	tp, err := NewTraceProcessor(testCfg, consumertest.NewTracesNop(), newTestTProcessor(nil))
+	require.NoError(t, err)
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource()))
+	tp, err = NewTraceProcessor(testCfg, consumertest.NewTracesNop(), newTestTProcessor(ErrSkipProcessingData))
+	require.NoError(t, err)
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+
+	mp, err := NewMetricsProcessor(testCfg, consumertest.NewMetricsNop(), newTestMProcessor(errors.New("my_error")))
+	require.NoError(t, err)
+	assert.Error(t, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+
+	lp, err := NewLogsProcessor(testCfg, consumertest.NewLogsNop(), newTestLProcessor(consumererror.NewRetryable(errors.New("my_error"), time.Second)))
+	require.NoError(t, err)
+	assert.Error(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+
+	obsreporttest.CheckProcessorTracesViews(t, testFullName, 2, 0, 1)
+	obsreporttest.CheckProcessorMetricsViews(t, testFullName, 0, 2, 0)
+	obsreporttest.CheckProcessorLogsViews(t, testFullName, 0, 1, 0)
+}
+
 type testTProcessor struct {
 	retError error
 }
@@ -169,3 +225,22 @@ func newTestLProcessor(retError error) LProcessor {
 func (tlp *testLProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
 	return ld, tlp.retError
 }
+
+type spanStore struct {
+	sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (ss *spanStore) ExportSpan(sd *trace.SpanData) {
+	ss.Lock()
+	ss.spans = append(ss.spans, sd)
+	ss.Unlock()
+}
+
+func (ss *spanStore) pullAllSpans() []*trace.SpanData {
+	ss.Lock()
+	defer ss.Unlock()
+	spans := ss.spans
+	ss.spans = nil
+	return spans
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the Redaction processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// AllowedKeys are the only attributes kept when set, the other attributes are deleted.
+	AllowedKeys []string `mapstructure:"allowed_keys"`
+
+	// HashedKeys are the attributes whose values are replaced with their SHA-256 hash.
+	HashedKeys []string `mapstructure:"hashed_keys"`
+
+	// BlockedValues are regular expressions, their matches in the string values of the other attributes and in the
+	// log bodies are masked.
+	BlockedValues []string `mapstructure:"blocked_values"`
+
+	// Summary adds the number of deleted, hashed and masked values as attributes of the redacted span, log record or
+	// resource. Default value is true.
+	Summary bool `mapstructure:"summary"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the blocked values are valid regular expressions.
+func (cfg *Config) Validate() error {
+	for _, value := range cfg.BlockedValues {
+		if _, err := regexp.Compile(value); err != nil {
+			return fmt.Errorf("invalid blocked value %q: %w", value, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	// mask replaces the matches of the blocked values.
+	mask = "****"
+
+	// The summary attributes, they are never redacted.
+	deletedCountAttribute = "redaction.deleted.count"
+	hashedCountAttribute  = "redaction.hashed.count"
+	maskedCountAttribute  = "redaction.masked.count"
+)
+
+// counts are the numbers of deleted, hashed and masked values of a span, log record or resource.
+type counts struct {
+	deleted int64
+	hashed  int64
+	masked  int64
+}
+
+func (c *counts) add(other counts) {
+	c.deleted += other.deleted
+	c.hashed += other.hashed
+	c.masked += other.masked
+}
+
+type redaction struct {
+	// allowed is nil when all the attributes are allowed.
+	allowed map[string]bool
+	hashed  map[string]bool
+	blocked []*regexp.Regexp
+	summary bool
+}
+
+func newRedaction(cfg *Config) (*redaction, error) {
+	r := &redaction{hashed: make(map[string]bool, len(cfg.HashedKeys)), summary: cfg.Summary}
+	if len(cfg.AllowedKeys) > 0 {
+		r.allowed = map[string]bool{
+			deletedCountAttribute: true,
+			hashedCountAttribute:  true,
+			maskedCountAttribute:  true,
+		}
+		for _, key := range cfg.AllowedKeys {
+			r.allowed[key] = true
+		}
+	}
+	for _, key := range cfg.HashedKeys {
+		r.hashed[key] = true
+	}
+	for _, value := range cfg.BlockedValues {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, err
+		}
+		r.blocked = append(r.blocked, re)
+	}
+	return r, nil
+}
+
+// ProcessTraces redacts the attributes of the resources and the spans.
+func (r *redaction) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		r.redactResource(rs.Resource())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				r.redactSpan(spans.At(k))
+			}
+		}
+	}
+	return td, nil
+}
+
+// ProcessLogs redacts the attributes of the resources and the log records, and the bodies of the log records.
+func (r *redaction) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		r.redactResource(rl.Resource())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				c := r.redactAttributes(lr.Attributes())
+				c.masked += r.maskValue(lr.Body())
+				r.addSummary(lr.Attributes(), c)
+			}
+		}
+	}
+	return ld, nil
+}
+
+// redactSpan redacts the attributes of the span, of its events and of its links, the summary of them all being added
+// to the span attributes.
+func (r *redaction) redactSpan(span pdata.Span) {
+	c := r.redactAttributes(span.Attributes())
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		c.add(r.redactAttributes(events.At(i).Attributes()))
+	}
+	links := span.Links()
+	for i := 0; i < links.Len(); i++ {
+		c.add(r.redactAttributes(links.At(i).Attributes()))
+	}
+	r.addSummary(span.Attributes(), c)
+}
+
+func (r *redaction) redactResource(resource pdata.Resource) {
+	attrs := resource.Attributes()
+	r.addSummary(attrs, r.redactAttributes(attrs))
+}
+
+// redactAttributes deletes the attributes that are not allowed, hashes the values of the hashed attributes, then
+// masks the blocked values of the strings of the other attributes, including the ones nested in maps and arrays.
+func (r *redaction) redactAttributes(attrs pdata.AttributeMap) counts {
+	var c counts
+	if r.allowed != nil {
+		var deleted []string
+		attrs.ForEach(func(k string, _ pdata.AttributeValue) {
+			if !r.allowed[k] {
+				deleted = append(deleted, k)
+			}
+		})
+		for _, k := range deleted {
+			attrs.Delete(k)
+		}
+		c.deleted = int64(len(deleted))
+	}
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		if r.hashed[k] {
+			v.SetStringVal(hash(v))
+			c.hashed++
+			return
+		}
+		c.masked += r.maskValue(v)
+	})
+	return c
+}
+
+// maskValue masks the blocked values of the string value, or of the strings nested in the map or array value, it
+// returns the number of matches.
+func (r *redaction) maskValue(v pdata.AttributeValue) int64 {
+	var n int64
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		if masked, matches := r.mask(v.StringVal()); matches > 0 {
+			v.SetStringVal(masked)
+			n = matches
+		}
+	case pdata.AttributeValueMAP:
+		v.MapVal().ForEach(func(_ string, nested pdata.AttributeValue) {
+			n += r.maskValue(nested)
+		})
+	case pdata.AttributeValueARRAY:
+		values := v.ArrayVal()
+		for i := 0; i < values.Len(); i++ {
+			n += r.maskValue(values.At(i))
+		}
+	}
+	return n
+}
+
+// mask replaces the matches of the blocked values, it returns the masked value and the number of matches.
+func (r *redaction) mask(value string) (string, int64) {
+	var n int64
+	for _, re := range r.blocked {
+		value = re.ReplaceAllStringFunc(value, func(string) string {
+			n++
+			return mask
+		})
+	}
+	return value, n
+}
+
+// addSummary adds the non-zero counts as attributes.
+func (r *redaction) addSummary(attrs pdata.AttributeMap, c counts) {
+	if !r.summary {
+		return
+	}
+	if c.deleted > 0 {
+		attrs.UpsertInt(deletedCountAttribute, c.deleted)
+	}
+	if c.hashed > 0 {
+		attrs.UpsertInt(hashedCountAttribute, c.hashed)
+	}
+	if c.masked > 0 {
+		attrs.UpsertInt(maskedCountAttribute, c.masked)
+	}
+}
+
+// hash returns the hex encoded SHA-256 hash of the value converted to a string.
+func hash(v pdata.AttributeValue) string {
+	sum := sha256.Sum256([]byte(tracetranslator.AttributeValueToString(v, false)))
+	return hex.EncodeToString(sum[:])
+}
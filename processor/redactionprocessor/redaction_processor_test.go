@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	creditCardRegexp = `\b(?:\d[ -]*?){13,16}\b`
+	emailRegexp      = `[\w.+-]+@[\w-]+\.[\w.-]+`
+)
+
+func newTestRedaction(t *testing.T, cfg *Config) *redaction {
+	require.NoError(t, cfg.Validate())
+	r, err := newRedaction(cfg)
+	require.NoError(t, err)
+	return r
+}
+
+func TestRedactSpans(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AllowedKeys = []string{"http.method", "http.url", "user.id", "service.name"}
+	cfg.HashedKeys = []string{"user.id"}
+	cfg.BlockedValues = []string{creditCardRegexp, emailRegexp}
+	r := newTestRedaction(t, cfg)
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"service.name": pdata.NewAttributeValueString("checkout"),
+		"host.name":    pdata.NewAttributeValueString("node-1"),
+	})
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"http.method":   pdata.NewAttributeValueString("GET"),
+		"http.url":      pdata.NewAttributeValueString("/pay?card=4111 1111 1111 1111&to=jane.doe@example.com"),
+		"user.id":       pdata.NewAttributeValueInt(42),
+		"user.password": pdata.NewAttributeValueString("secret"),
+	})
+
+	_, err := r.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.name":            pdata.NewAttributeValueString("checkout"),
+		"redaction.deleted.count": pdata.NewAttributeValueInt(1),
+	}).Sort(), rs.Resource().Attributes().Sort())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"http.method":             pdata.NewAttributeValueString("GET"),
+		"http.url":                pdata.NewAttributeValueString("/pay?card=****&to=****"),
+		"user.id":                 pdata.NewAttributeValueString("73475cb40a568e8da8a045ced110137e159f890ac4da883b6b17dc651b3a8049"),
+		"redaction.deleted.count": pdata.NewAttributeValueInt(1),
+		"redaction.hashed.count":  pdata.NewAttributeValueInt(1),
+		"redaction.masked.count":  pdata.NewAttributeValueInt(2),
+	}).Sort(), span.Attributes().Sort())
+}
+
+func TestRedactLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.BlockedValues = []string{creditCardRegexp, emailRegexp}
+	r := newTestRedaction(t, cfg)
+
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("service.name", "checkout")
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	lr := logs.AppendEmpty()
+	lr.Body().SetStringVal("payment of jane.doe@example.com with 4111-1111-1111-1111 failed")
+	lr.Attributes().InsertString("customer", "john@example.com")
+	lr.Attributes().InsertInt("amount", 42)
+	clean := logs.AppendEmpty()
+	clean.Body().SetStringVal("payment succeeded")
+
+	_, err := r.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	// All the attributes are allowed without allowed keys.
+	assert.Equal(t, 1, rl.Resource().Attributes().Len())
+	assert.Equal(t, "payment of **** with **** failed", lr.Body().StringVal())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"customer":               pdata.NewAttributeValueString("****"),
+		"amount":                 pdata.NewAttributeValueInt(42),
+		"redaction.masked.count": pdata.NewAttributeValueInt(3),
+	}).Sort(), lr.Attributes().Sort())
+	assert.Equal(t, "payment succeeded", clean.Body().StringVal())
+	assert.Equal(t, 0, clean.Attributes().Len())
+}
+
+func TestRedactWithoutSummary(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AllowedKeys = []string{"http.method"}
+	cfg.Summary = false
+	r := newTestRedaction(t, cfg)
+
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Attributes().InsertString("http.method", "GET")
+	lr.Attributes().InsertString("user.email", "jane.doe@example.com")
+
+	_, err := r.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"http.method": pdata.NewAttributeValueString("GET"),
+	}), lr.Attributes())
+}
+
+// nestedValue returns a map value holding the string value in a nested map and in an array.
+func nestedValue(value string) pdata.AttributeValue {
+	nested := pdata.NewAttributeValueMap()
+	nested.MapVal().InsertString("email", value)
+	arr := pdata.NewAttributeValueArray()
+	arr.ArrayVal().AppendEmpty().SetStringVal(value)
+	arr.ArrayVal().AppendEmpty().SetIntVal(42)
+	v := pdata.NewAttributeValueMap()
+	v.MapVal().Insert("user", nested)
+	v.MapVal().Insert("recipients", arr)
+	return v
+}
+
+func TestRedactNestedAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.BlockedValues = []string{emailRegexp}
+	r := newTestRedaction(t, cfg)
+
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().Insert("payload", nestedValue("jane.doe@example.com"))
+
+	_, err := r.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	payload, ok := span.Attributes().Get("payload")
+	require.True(t, ok)
+	assert.Equal(t, nestedValue("****"), payload)
+	masked, ok := span.Attributes().Get(maskedCountAttribute)
+	require.True(t, ok)
+	assert.EqualValues(t, 2, masked.IntVal())
+}
+
+func TestRedactLogBodies(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.BlockedValues = []string{emailRegexp}
+	r := newTestRedaction(t, cfg)
+
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	mapBody := logs.AppendEmpty()
+	nestedValue("jane.doe@example.com").CopyTo(mapBody.Body())
+	arrayBody := logs.AppendEmpty()
+	pdata.NewAttributeValueArray().CopyTo(arrayBody.Body())
+	arrayBody.Body().ArrayVal().AppendEmpty().SetStringVal("to jane.doe@example.com")
+	nestedValue("jane.doe@example.com").CopyTo(arrayBody.Body().ArrayVal().AppendEmpty())
+
+	_, err := r.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	assert.Equal(t, nestedValue("****"), mapBody.Body())
+	wantArray := pdata.NewAttributeValueArray()
+	wantArray.ArrayVal().AppendEmpty().SetStringVal("to ****")
+	nestedValue("****").CopyTo(wantArray.ArrayVal().AppendEmpty())
+	assert.Equal(t, wantArray, arrayBody.Body())
+	masked, ok := arrayBody.Attributes().Get(maskedCountAttribute)
+	require.True(t, ok)
+	assert.EqualValues(t, 3, masked.IntVal())
+}
+
+func TestRedactSpanEventsAndLinks(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AllowedKeys = []string{"exception.message", "peer.email"}
+	cfg.BlockedValues = []string{emailRegexp}
+	r := newTestRedaction(t, cfg)
+
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	event := span.Events().AppendEmpty()
+	event.Attributes().InsertString("exception.message", "no account for jane.doe@example.com")
+	event.Attributes().InsertString("exception.stacktrace", "secret")
+	link := span.Links().AppendEmpty()
+	link.Attributes().Insert("peer.email", nestedValue("john@example.com"))
+
+	_, err := r.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"exception.message": pdata.NewAttributeValueString("no account for ****"),
+	}), event.Attributes())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"peer.email": nestedValue("****"),
+	}), link.Attributes())
+	// The summary of the events and links is added to the span.
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"redaction.deleted.count": pdata.NewAttributeValueInt(1),
+		"redaction.masked.count":  pdata.NewAttributeValueInt(3),
+	}).Sort(), span.Attributes().Sort())
+}
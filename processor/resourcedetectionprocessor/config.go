@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for the Resource Detection processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// Detectors are the names of the detectors to run, the attributes detected by the first detectors take
+	// precedence over the ones of the following detectors.
+	Detectors []string `mapstructure:"detectors"`
+
+	// Timeout is the maximum time each detector can take to detect the resource.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Override indicates whether the detected attributes overwrite the existing attributes of the resources.
+	Override bool `mapstructure:"override"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the detectors exist and that the timeout is positive.
+func (cfg *Config) Validate() error {
+	if len(cfg.Detectors) == 0 {
+		return errors.New("detectors must have at least one detector")
+	}
+	seen := make(map[string]bool, len(cfg.Detectors))
+	for _, name := range cfg.Detectors {
+		if _, ok := detectorFactories[name]; !ok {
+			return fmt.Errorf("unknown detector %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate detector %q", name)
+		}
+		seen[name] = true
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "resourcedetection",
+				NameVal: "resourcedetection",
+			},
+			Detectors: []string{"env", "ec2", "system"},
+			Timeout:   5 * time.Second,
+			Override:  true,
+		},
+		cfg.Processors["resourcedetection"])
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "resourcedetection",
+				NameVal: "resourcedetection/gce",
+			},
+			Detectors: []string{"gce"},
+			Timeout:   2 * time.Second,
+			Override:  false,
+		},
+		cfg.Processors["resourcedetection/gce"])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "detectors must have at least one detector")
+
+	cfg.Detectors = []string{"env", "openstack"}
+	assert.EqualError(t, cfg.Validate(), `unknown detector "openstack"`)
+
+	cfg.Detectors = []string{"env", "env"}
+	assert.EqualError(t, cfg.Validate(), `duplicate detector "env"`)
+
+	cfg.Detectors = []string{"env", "system", "ec2", "gce", "azure"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Timeout = 0
+	assert.EqualError(t, cfg.Validate(), "timeout must be positive")
+}
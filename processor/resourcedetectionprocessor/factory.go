@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal/azure"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal/ec2"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal/env"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal/gce"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal/system"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "resourcedetection"
+
+	defaultTimeout = 5 * time.Second
+)
+
+var processorCapabilities = component.ProcessorCapabilities{MutatesConsumedData: true}
+
+// detectorFactories are the detectors available by name.
+var detectorFactories = map[string]func() internal.Detector{
+	env.TypeStr:    env.NewDetector,
+	system.TypeStr: system.NewDetector,
+	ec2.TypeStr:    ec2.NewDetector,
+	gce.TypeStr:    gce.NewDetector,
+	azure.TypeStr:  azure.NewDetector,
+}
+
+// NewFactory returns a new factory for the Resource Detection processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+// Note: This isn't a valid configuration because the processor would do no work.
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Timeout:  defaultTimeout,
+		Override: true,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.Traces) (component.TracesProcessor, error) {
+	rdp := newResourceDetectionProcessor(params.Logger, cfg.(*Config))
+	return processorhelper.NewTraceProcessor(
+		cfg,
+		nextConsumer,
+		rdp,
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.Metrics) (component.MetricsProcessor, error) {
+	rdp := newResourceDetectionProcessor(params.Logger, cfg.(*Config))
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		rdp,
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.Logs) (component.LogsProcessor, error) {
+	rdp := newResourceDetectionProcessor(params.Logger, cfg.(*Config))
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		rdp,
+		processorhelper.WithStart(rdp.Start),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
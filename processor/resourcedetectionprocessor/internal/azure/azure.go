@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package azure implements a detector of the Azure virtual machine the collector runs on, using the instance
+// metadata service.
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// TypeStr is the name of the detector in the configuration.
+const TypeStr = "azure"
+
+const (
+	defaultEndpoint = "http://169.254.169.254"
+
+	// attributeResourceGroupName is the resource group of the virtual machine.
+	attributeResourceGroupName = "azure.resourcegroup.name"
+)
+
+// computeMetadata is the compute metadata of the instance metadata service.
+type computeMetadata struct {
+	Location          string `json:"location"`
+	Name              string `json:"name"`
+	VMID              string `json:"vmId"`
+	VMSize            string `json:"vmSize"`
+	SubscriptionID    string `json:"subscriptionId"`
+	ResourceGroupName string `json:"resourceGroupName"`
+}
+
+type detector struct {
+	client   *http.Client
+	endpoint string
+}
+
+var _ internal.Detector = (*detector)(nil)
+
+// NewDetector returns a detector of the Azure virtual machine the collector runs on.
+func NewDetector() internal.Detector {
+	return &detector{client: &http.Client{}, endpoint: defaultEndpoint}
+}
+
+// Detect returns the cloud and host attributes of the virtual machine, or an empty resource when the instance
+// metadata service cannot be reached.
+func (d *detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	body, err := internal.GetMetadata(ctx, d.client, http.MethodGet,
+		d.endpoint+"/metadata/instance/compute?api-version=2020-09-01&format=json", http.Header{"Metadata": {"true"}})
+	if err != nil {
+		// Not running on Azure.
+		return res, nil
+	}
+	var compute computeMetadata
+	if err = json.Unmarshal(body, &compute); err != nil {
+		return res, err
+	}
+
+	attrs := res.Attributes()
+	attrs.UpsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
+	attrs.UpsertString(conventions.AttributeCloudInfrastructureService, conventions.AttributeCloudProviderAzureVM)
+	attrs.UpsertString(conventions.AttributeCloudAccount, compute.SubscriptionID)
+	attrs.UpsertString(conventions.AttributeCloudRegion, compute.Location)
+	attrs.UpsertString(conventions.AttributeHostID, compute.VMID)
+	attrs.UpsertString(conventions.AttributeHostType, compute.VMSize)
+	attrs.UpsertString(conventions.AttributeHostName, compute.Name)
+	attrs.UpsertString(attributeResourceGroupName, compute.ResourceGroupName)
+	return res, nil
+}
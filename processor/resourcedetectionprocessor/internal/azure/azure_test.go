@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestDetect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/instance/compute" || r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"location": "westeurope", "name": "vm-1", "vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+			"vmSize": "Standard_A3", "subscriptionId": "8d10da13-8125-4ba9-a717-bf7490507b3d", "resourceGroupName": "rg-1"}`))
+	}))
+	defer server.Close()
+
+	d := &detector{client: server.Client(), endpoint: server.URL}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	want := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"cloud.provider":               pdata.NewAttributeValueString("azure"),
+		"cloud.infrastructure_service": pdata.NewAttributeValueString("azure_vm"),
+		"cloud.account.id":             pdata.NewAttributeValueString("8d10da13-8125-4ba9-a717-bf7490507b3d"),
+		"cloud.region":                 pdata.NewAttributeValueString("westeurope"),
+		"host.id":                      pdata.NewAttributeValueString("02aab8a4-74ef-476e-8182-f6d2ba4166a6"),
+		"host.type":                    pdata.NewAttributeValueString("Standard_A3"),
+		"host.name":                    pdata.NewAttributeValueString("vm-1"),
+		"azure.resourcegroup.name":     pdata.NewAttributeValueString("rg-1"),
+	})
+	assert.Equal(t, want.Sort(), res.Attributes().Sort())
+}
+
+func TestDetectNotOnAzure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	d := &detector{client: server.Client(), endpoint: server.URL}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package internal contains the detectors of the resource the collector runs on, and the provider merging their
+// resources.
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Detector detects the attributes of the resource the collector runs on.
+type Detector interface {
+	// Detect returns the detected resource, an empty resource when the collector does not run on the platform of
+	// the detector.
+	Detect(ctx context.Context) (pdata.Resource, error)
+}
+
+// Provider runs the detectors and merges their resources.
+type Provider struct {
+	logger    *zap.Logger
+	timeout   time.Duration
+	detectors map[string]Detector
+	order     []string
+}
+
+// NewProvider returns a Provider running the named detectors in the given order, each one within the timeout.
+func NewProvider(logger *zap.Logger, timeout time.Duration, order []string, detectors map[string]Detector) *Provider {
+	return &Provider{
+		logger:    logger,
+		timeout:   timeout,
+		detectors: detectors,
+		order:     order,
+	}
+}
+
+// Detect runs the detectors in order and returns the merged resource, the attributes of the first detectors take
+// precedence over the ones of the following detectors.
+func (p *Provider) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	for _, name := range p.order {
+		detected, err := p.detect(ctx, name)
+		if err != nil {
+			return res, fmt.Errorf("detector %q: %w", name, err)
+		}
+		p.logger.Debug("Detected the resource.", zap.String("detector", name), zap.Int("attributes", detected.Attributes().Len()))
+		detected.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+			res.Attributes().Insert(k, v)
+		})
+	}
+	return res, nil
+}
+
+func (p *Provider) detect(ctx context.Context, name string) (pdata.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	return p.detectors[name].Detect(ctx)
+}
+
+// MergeResource sets the attributes of the detected resource in the resource, the existing attributes are
+// overwritten only when override is true.
+func MergeResource(to, from pdata.Resource, override bool) {
+	attrs := to.Attributes()
+	from.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		if override {
+			attrs.Upsert(k, v)
+		} else {
+			attrs.Insert(k, v)
+		}
+	})
+}
+
+// GetMetadata sends a request to a metadata endpoint and returns the body of the response.
+func GetMetadata(ctx context.Context, client *http.Client, method, url string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: unexpected status %s", method, url, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
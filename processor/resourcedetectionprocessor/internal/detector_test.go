@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type mockDetector struct {
+	attrs map[string]string
+	err   error
+}
+
+func (d *mockDetector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	if d.attrs == nil && d.err == nil {
+		// Wait for the timeout.
+		<-ctx.Done()
+		return res, ctx.Err()
+	}
+	for k, v := range d.attrs {
+		res.Attributes().UpsertString(k, v)
+	}
+	return res, d.err
+}
+
+func newResource(attrs map[string]string) pdata.Resource {
+	res := pdata.NewResource()
+	for k, v := range attrs {
+		res.Attributes().UpsertString(k, v)
+	}
+	res.Attributes().Sort()
+	return res
+}
+
+func TestProviderDetect(t *testing.T) {
+	detectors := map[string]Detector{
+		"a": &mockDetector{attrs: map[string]string{"host.name": "a", "cloud.provider": "aws"}},
+		"b": &mockDetector{attrs: map[string]string{"host.name": "b", "os.type": "linux"}},
+	}
+	p := NewProvider(zap.NewNop(), time.Second, []string{"a", "b"}, detectors)
+	res, err := p.Detect(context.Background())
+	require.NoError(t, err)
+	res.Attributes().Sort()
+	// The attributes of the first detectors take precedence.
+	assert.Equal(t, newResource(map[string]string{"host.name": "a", "cloud.provider": "aws", "os.type": "linux"}), res)
+}
+
+func TestProviderDetectError(t *testing.T) {
+	detectors := map[string]Detector{
+		"a": &mockDetector{attrs: map[string]string{"host.name": "a"}},
+		"b": &mockDetector{err: errors.New("invalid metadata")},
+		"c": &mockDetector{},
+	}
+	p := NewProvider(zap.NewNop(), time.Millisecond, []string{"a", "b"}, detectors)
+	_, err := p.Detect(context.Background())
+	assert.EqualError(t, err, `detector "b": invalid metadata`)
+
+	p = NewProvider(zap.NewNop(), time.Millisecond, []string{"c"}, detectors)
+	_, err = p.Detect(context.Background())
+	assert.EqualError(t, err, `detector "c": context deadline exceeded`)
+}
+
+func TestMergeResource(t *testing.T) {
+	detected := newResource(map[string]string{"host.name": "detected", "os.type": "linux"})
+
+	res := newResource(map[string]string{"host.name": "existing"})
+	MergeResource(res, detected, false)
+	res.Attributes().Sort()
+	assert.Equal(t, newResource(map[string]string{"host.name": "existing", "os.type": "linux"}), res)
+
+	res = newResource(map[string]string{"host.name": "existing"})
+	MergeResource(res, detected, true)
+	res.Attributes().Sort()
+	assert.Equal(t, newResource(map[string]string{"host.name": "detected", "os.type": "linux"}), res)
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ec2 implements a detector of the AWS EC2 instance the collector runs on, using the instance metadata
+// service (IMDSv2).
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// TypeStr is the name of the detector in the configuration.
+const TypeStr = "ec2"
+
+const defaultEndpoint = "http://169.254.169.254"
+
+// identityDocument is the instance identity document of the instance metadata service.
+type identityDocument struct {
+	AccountID        string `json:"accountId"`
+	Region           string `json:"region"`
+	AvailabilityZone string `json:"availabilityZone"`
+	InstanceID       string `json:"instanceId"`
+	InstanceType     string `json:"instanceType"`
+	ImageID          string `json:"imageId"`
+}
+
+type detector struct {
+	client   *http.Client
+	endpoint string
+}
+
+var _ internal.Detector = (*detector)(nil)
+
+// NewDetector returns a detector of the AWS EC2 instance the collector runs on.
+func NewDetector() internal.Detector {
+	return &detector{client: &http.Client{}, endpoint: defaultEndpoint}
+}
+
+// Detect returns the cloud and host attributes of the instance, or an empty resource when the instance metadata
+// service cannot be reached.
+func (d *detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	token, err := internal.GetMetadata(ctx, d.client, http.MethodPut, d.endpoint+"/latest/api/token",
+		http.Header{"X-Aws-Ec2-Metadata-Token-Ttl-Seconds": {"60"}})
+	if err != nil {
+		// Not running on EC2.
+		return res, nil
+	}
+	header := http.Header{"X-Aws-Ec2-Metadata-Token": {string(token)}}
+	body, err := internal.GetMetadata(ctx, d.client, http.MethodGet, d.endpoint+"/latest/dynamic/instance-identity/document", header)
+	if err != nil {
+		return res, err
+	}
+	var doc identityDocument
+	if err = json.Unmarshal(body, &doc); err != nil {
+		return res, err
+	}
+	hostname, err := internal.GetMetadata(ctx, d.client, http.MethodGet, d.endpoint+"/latest/meta-data/hostname", header)
+	if err != nil {
+		return res, err
+	}
+
+	attrs := res.Attributes()
+	attrs.UpsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attrs.UpsertString(conventions.AttributeCloudInfrastructureService, conventions.AttributeCloudProviderAWSEC2)
+	attrs.UpsertString(conventions.AttributeCloudAccount, doc.AccountID)
+	attrs.UpsertString(conventions.AttributeCloudRegion, doc.Region)
+	attrs.UpsertString(conventions.AttributeCloudZone, doc.AvailabilityZone)
+	attrs.UpsertString(conventions.AttributeHostID, doc.InstanceID)
+	attrs.UpsertString(conventions.AttributeHostType, doc.InstanceType)
+	attrs.UpsertString(conventions.AttributeHostImageID, doc.ImageID)
+	attrs.UpsertString(conventions.AttributeHostName, string(hostname))
+	return res, nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ec2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestDetect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/latest/api/token" {
+			assert.Equal(t, http.MethodPut, r.Method)
+			_, _ = w.Write([]byte("token"))
+			return
+		}
+		if r.Header.Get("X-Aws-Ec2-Metadata-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/latest/dynamic/instance-identity/document":
+			_, _ = w.Write([]byte(`{"accountId": "123456789012", "region": "us-west-2", "availabilityZone": "us-west-2b",
+				"instanceId": "i-1234567890abcdef0", "instanceType": "t2.micro", "imageId": "ami-5fb8c835"}`))
+		case "/latest/meta-data/hostname":
+			_, _ = w.Write([]byte("ip-10-0-0-1.us-west-2.compute.internal"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	d := &detector{client: server.Client(), endpoint: server.URL}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	want := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"cloud.provider":               pdata.NewAttributeValueString("aws"),
+		"cloud.infrastructure_service": pdata.NewAttributeValueString("aws_ec2"),
+		"cloud.account.id":             pdata.NewAttributeValueString("123456789012"),
+		"cloud.region":                 pdata.NewAttributeValueString("us-west-2"),
+		"cloud.zone":                   pdata.NewAttributeValueString("us-west-2b"),
+		"host.id":                      pdata.NewAttributeValueString("i-1234567890abcdef0"),
+		"host.type":                    pdata.NewAttributeValueString("t2.micro"),
+		"host.image.id":                pdata.NewAttributeValueString("ami-5fb8c835"),
+		"host.name":                    pdata.NewAttributeValueString("ip-10-0-0-1.us-west-2.compute.internal"),
+	})
+	assert.Equal(t, want.Sort(), res.Attributes().Sort())
+}
+
+func TestDetectNotOnEC2(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	d := &detector{client: server.Client(), endpoint: server.URL}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
+
+func TestDetectInvalidDocument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{"))
+	}))
+	defer server.Close()
+
+	d := &detector{client: server.Client(), endpoint: server.URL}
+	_, err := d.Detect(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package env implements a detector reading the resource attributes in the OTEL_RESOURCE_ATTRIBUTES environment
+// variable.
+package env
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal"
+)
+
+// TypeStr is the name of the detector in the configuration.
+const TypeStr = "env"
+
+// envVar is the environment variable holding the comma separated key=value attributes, the values are URL encoded.
+const envVar = "OTEL_RESOURCE_ATTRIBUTES"
+
+type detector struct{}
+
+var _ internal.Detector = (*detector)(nil)
+
+// NewDetector returns a detector of the resource attributes in the OTEL_RESOURCE_ATTRIBUTES environment variable.
+func NewDetector() internal.Detector {
+	return &detector{}
+}
+
+// Detect parses the attributes of the environment variable.
+func (d *detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	labels := strings.TrimSpace(os.Getenv(envVar))
+	if labels == "" {
+		return res, nil
+	}
+	for _, pair := range strings.Split(labels, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return res, fmt.Errorf("invalid %s: %q is not a key=value pair", envVar, pair)
+		}
+		value, err := url.QueryUnescape(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return res, fmt.Errorf("invalid %s: %w", envVar, err)
+		}
+		res.Attributes().UpsertString(strings.TrimSpace(kv[0]), value)
+	}
+	return res, nil
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "empty",
+			want: map[string]string{},
+		},
+		{
+			name:  "attributes",
+			value: "service.name=frontend, deployment.environment = prod,team=a%2Cb",
+			want:  map[string]string{"service.name": "frontend", "deployment.environment": "prod", "team": "a,b"},
+		},
+		{
+			name:    "missing value",
+			value:   "service.name",
+			wantErr: `invalid OTEL_RESOURCE_ATTRIBUTES: "service.name" is not a key=value pair`,
+		},
+		{
+			name:    "invalid encoding",
+			value:   "team=a%2",
+			wantErr: `invalid OTEL_RESOURCE_ATTRIBUTES: invalid URL escape "%2"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.Setenv(envVar, tt.value))
+			defer os.Unsetenv(envVar)
+
+			res, err := NewDetector().Detect(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			want := pdata.NewAttributeMap()
+			for k, v := range tt.want {
+				want.UpsertString(k, v)
+			}
+			assert.Equal(t, want.Sort(), res.Attributes().Sort())
+		})
+	}
+}
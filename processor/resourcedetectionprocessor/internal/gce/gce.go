@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gce implements a detector of the Google Compute Engine instance the collector runs on, using the
+// metadata server.
+package gce
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// TypeStr is the name of the detector in the configuration.
+const TypeStr = "gce"
+
+const defaultEndpoint = "http://metadata.google.internal"
+
+var metadataHeader = http.Header{"Metadata-Flavor": {"Google"}}
+
+type detector struct {
+	client   *http.Client
+	endpoint string
+}
+
+var _ internal.Detector = (*detector)(nil)
+
+// NewDetector returns a detector of the Google Compute Engine instance the collector runs on.
+func NewDetector() internal.Detector {
+	return &detector{client: &http.Client{}, endpoint: defaultEndpoint}
+}
+
+// Detect returns the cloud and host attributes of the instance, or an empty resource when the metadata server
+// cannot be reached.
+func (d *detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	projectID, err := d.get(ctx, "project/project-id")
+	if err != nil {
+		// Not running on GCE.
+		return res, nil
+	}
+	values := map[string]string{}
+	for _, p := range []string{"instance/id", "instance/zone", "instance/machine-type", "instance/hostname"} {
+		if values[p], err = d.get(ctx, p); err != nil {
+			return res, err
+		}
+	}
+	// The zone and machine type are returned as projects/<number>/zones/<zone> and
+	// projects/<number>/machineTypes/<type>, the region is the zone without its suffix.
+	zone := path.Base(values["instance/zone"])
+	region := zone
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		region = zone[:i]
+	}
+
+	attrs := res.Attributes()
+	attrs.UpsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderGCP)
+	attrs.UpsertString(conventions.AttributeCloudInfrastructureService, conventions.AttributeCloudProviderGCPComputeEngine)
+	attrs.UpsertString(conventions.AttributeCloudAccount, projectID)
+	attrs.UpsertString(conventions.AttributeCloudRegion, region)
+	attrs.UpsertString(conventions.AttributeCloudZone, zone)
+	attrs.UpsertString(conventions.AttributeHostID, values["instance/id"])
+	attrs.UpsertString(conventions.AttributeHostType, path.Base(values["instance/machine-type"]))
+	attrs.UpsertString(conventions.AttributeHostName, values["instance/hostname"])
+	return res, nil
+}
+
+func (d *detector) get(ctx context.Context, p string) (string, error) {
+	body, err := internal.GetMetadata(ctx, d.client, http.MethodGet, d.endpoint+"/computeMetadata/v1/"+p, metadataHeader)
+	return string(body), err
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gce
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestDetect(t *testing.T) {
+	metadata := map[string]string{
+		"/computeMetadata/v1/project/project-id":    "my-project",
+		"/computeMetadata/v1/instance/id":           "4520031799277581759",
+		"/computeMetadata/v1/instance/zone":         "projects/123456789/zones/us-central1-a",
+		"/computeMetadata/v1/instance/machine-type": "projects/123456789/machineTypes/n1-standard-1",
+		"/computeMetadata/v1/instance/hostname":     "instance-1.c.my-project.internal",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := metadata[r.URL.Path]
+		if !ok || r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(value))
+	}))
+	defer server.Close()
+
+	d := &detector{client: server.Client(), endpoint: server.URL}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	want := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"cloud.provider":               pdata.NewAttributeValueString("gcp"),
+		"cloud.infrastructure_service": pdata.NewAttributeValueString("gcp_compute_engine"),
+		"cloud.account.id":             pdata.NewAttributeValueString("my-project"),
+		"cloud.region":                 pdata.NewAttributeValueString("us-central1"),
+		"cloud.zone":                   pdata.NewAttributeValueString("us-central1-a"),
+		"host.id":                      pdata.NewAttributeValueString("4520031799277581759"),
+		"host.type":                    pdata.NewAttributeValueString("n1-standard-1"),
+		"host.name":                    pdata.NewAttributeValueString("instance-1.c.my-project.internal"),
+	})
+	assert.Equal(t, want.Sort(), res.Attributes().Sort())
+}
+
+func TestDetectNotOnGCE(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	d := &detector{client: server.Client(), endpoint: server.URL}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
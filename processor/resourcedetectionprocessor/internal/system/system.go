@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package system implements a detector of the host name and operating system of the host.
+package system
+
+import (
+	"context"
+	"os"
+	"runtime"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// TypeStr is the name of the detector in the configuration.
+const TypeStr = "system"
+
+type detector struct {
+	hostname func() (string, error)
+}
+
+var _ internal.Detector = (*detector)(nil)
+
+// NewDetector returns a detector of the host name and operating system of the host.
+func NewDetector() internal.Detector {
+	return &detector{hostname: os.Hostname}
+}
+
+// Detect returns the host.name and os.type attributes.
+func (d *detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	hostname, err := d.hostname()
+	if err != nil {
+		return res, err
+	}
+	res.Attributes().UpsertString(conventions.AttributeHostName, hostname)
+	res.Attributes().UpsertString(conventions.AttributeOSType, runtime.GOOS)
+	return res, nil
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestDetect(t *testing.T) {
+	d := &detector{hostname: func() (string, error) { return "host-1", nil }}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	want := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"host.name": pdata.NewAttributeValueString("host-1"),
+		"os.type":   pdata.NewAttributeValueString(runtime.GOOS),
+	})
+	assert.Equal(t, want.Sort(), res.Attributes().Sort())
+}
+
+func TestDetectError(t *testing.T) {
+	d := &detector{hostname: func() (string, error) { return "", errors.New("no hostname") }}
+	_, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "no hostname")
+}
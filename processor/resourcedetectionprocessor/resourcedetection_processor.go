@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor/internal"
+)
+
+// resourceDetectionProcessor detects the resource once when it starts, then sets its attributes to the resources of
+// the data.
+type resourceDetectionProcessor struct {
+	logger   *zap.Logger
+	provider *internal.Provider
+	override bool
+	resource pdata.Resource
+}
+
+func newResourceDetectionProcessor(logger *zap.Logger, cfg *Config) *resourceDetectionProcessor {
+	detectors := make(map[string]internal.Detector, len(cfg.Detectors))
+	for _, name := range cfg.Detectors {
+		detectors[name] = detectorFactories[name]()
+	}
+	return &resourceDetectionProcessor{
+		logger:   logger,
+		provider: internal.NewProvider(logger, cfg.Timeout, cfg.Detectors, detectors),
+		override: cfg.Override,
+		resource: pdata.NewResource(),
+	}
+}
+
+// Start detects the resource.
+func (rdp *resourceDetectionProcessor) Start(ctx context.Context, _ component.Host) error {
+	res, err := rdp.provider.Detect(ctx)
+	if err != nil {
+		return err
+	}
+	rdp.logger.Info("Detected the resource.", zap.Int("attributes", res.Attributes().Len()))
+	rdp.resource = res
+	return nil
+}
+
+// ProcessTraces implements the TProcessor interface
+func (rdp *resourceDetectionProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		internal.MergeResource(rss.At(i).Resource(), rdp.resource, rdp.override)
+	}
+	return td, nil
+}
+
+// ProcessMetrics implements the MProcessor interface
+func (rdp *resourceDetectionProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		internal.MergeResource(rms.At(i).Resource(), rdp.resource, rdp.override)
+	}
+	return md, nil
+}
+
+// ProcessLogs implements the LProcessor interface
+func (rdp *resourceDetectionProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		internal.MergeResource(rls.At(i).Resource(), rdp.resource, rdp.override)
+	}
+	return ld, nil
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestResourceDetection(t *testing.T) {
+	require.NoError(t, os.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.name=frontend,deployment.environment=prod"))
+	defer os.Unsetenv("OTEL_RESOURCE_ATTRIBUTES")
+
+	tests := []struct {
+		name     string
+		override bool
+		want     map[string]string
+	}{
+		{
+			name:     "override",
+			override: true,
+			want:     map[string]string{"service.name": "frontend", "deployment.environment": "prod", "team": "a"},
+		},
+		{
+			name:     "keep existing",
+			override: false,
+			want:     map[string]string{"service.name": "backend", "deployment.environment": "prod", "team": "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Detectors = []string{"env"}
+			cfg.Override = tt.override
+			sink := new(consumertest.TracesSink)
+			tp, err := NewFactory().CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, tp.Shutdown(context.Background())) }()
+
+			td := pdata.NewTraces()
+			attrs := td.ResourceSpans().AppendEmpty().Resource().Attributes()
+			attrs.UpsertString("service.name", "backend")
+			attrs.UpsertString("team", "a")
+			require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+			require.Len(t, sink.AllTraces(), 1)
+			want := pdata.NewAttributeMap()
+			for k, v := range tt.want {
+				want.UpsertString(k, v)
+			}
+			assert.Equal(t, want.Sort(), sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Sort())
+		})
+	}
+}
+
+func TestResourceDetectionStartError(t *testing.T) {
+	require.NoError(t, os.Setenv("OTEL_RESOURCE_ATTRIBUTES", "invalid"))
+	defer os.Unsetenv("OTEL_RESOURCE_ATTRIBUTES")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Detectors = []string{"env"}
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewMetricsNop())
+	require.NoError(t, err)
+	assert.EqualError(t, mp.Start(context.Background(), componenttest.NewNopHost()),
+		`detector "env": invalid OTEL_RESOURCE_ATTRIBUTES: "invalid" is not a key=value pair`)
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcelabelsprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// actionCopy keeps the source attribute or label.
+	actionCopy = "copy"
+	// actionMove removes the source attribute or label.
+	actionMove = "move"
+)
+
+// Config defines configuration for the Resource Labels processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// ResourceToLabels are the resource attributes set as labels of all the data points of the resource.
+	ResourceToLabels []KeyAction `mapstructure:"resource_to_labels"`
+
+	// LabelsToResource are the labels set as resource attributes when all the data points of the resource have the
+	// same value.
+	LabelsToResource []KeyAction `mapstructure:"labels_to_resource"`
+}
+
+// KeyAction specifies a resource attribute or label to copy or move.
+type KeyAction struct {
+	// Key is the name of the source resource attribute or label.
+	Key string `mapstructure:"key"`
+
+	// NewKey is the name of the destination label or resource attribute. Default value is Key.
+	NewKey string `mapstructure:"new_key"`
+
+	// Action is either "copy" to keep the source or "move" to remove it. Default value is "copy".
+	Action string `mapstructure:"action"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that there is at least one key and that the keys and actions are valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.ResourceToLabels) == 0 && len(cfg.LabelsToResource) == 0 {
+		return errors.New("at least one of resource_to_labels or labels_to_resource must be set")
+	}
+	for _, ka := range append(append([]KeyAction(nil), cfg.ResourceToLabels...), cfg.LabelsToResource...) {
+		if ka.Key == "" {
+			return errors.New("key must be set")
+		}
+		if ka.Action != "" && ka.Action != actionCopy && ka.Action != actionMove {
+			return fmt.Errorf("key %q: action must be either %q or %q", ka.Key, actionCopy, actionMove)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcelabelsprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "resourcelabels",
+				NameVal: "resourcelabels",
+			},
+			ResourceToLabels: []KeyAction{{Key: "service.name", NewKey: "service"}},
+			LabelsToResource: []KeyAction{{Key: "region", Action: "move"}},
+		},
+		cfg.Processors["resourcelabels"])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "at least one of resource_to_labels or labels_to_resource must be set")
+
+	cfg.ResourceToLabels = []KeyAction{{}}
+	assert.EqualError(t, cfg.Validate(), "key must be set")
+
+	cfg.ResourceToLabels = []KeyAction{{Key: "service.name"}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.LabelsToResource = []KeyAction{{Key: "region", Action: "delete"}}
+	assert.EqualError(t, cfg.Validate(), `key "region": action must be either "copy" or "move"`)
+}
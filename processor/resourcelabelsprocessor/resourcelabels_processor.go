@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcelabelsprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+type resourceLabelsProcessor struct {
+	resourceToLabels []KeyAction
+	labelsToResource []KeyAction
+}
+
+func newResourceLabelsProcessor(cfg *Config) *resourceLabelsProcessor {
+	return &resourceLabelsProcessor{
+		resourceToLabels: withDefaults(cfg.ResourceToLabels),
+		labelsToResource: withDefaults(cfg.LabelsToResource),
+	}
+}
+
+// withDefaults returns the key actions with the default new key and action.
+func withDefaults(kas []KeyAction) []KeyAction {
+	res := make([]KeyAction, len(kas))
+	for i, ka := range kas {
+		if ka.NewKey == "" {
+			ka.NewKey = ka.Key
+		}
+		if ka.Action == "" {
+			ka.Action = actionCopy
+		}
+		res[i] = ka
+	}
+	return res
+}
+
+// ProcessMetrics sets the resource attributes as labels, then the common labels as resource attributes.
+func (p *resourceLabelsProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		for _, ka := range p.resourceToLabels {
+			resourceToLabels(rm, ka)
+		}
+		for _, ka := range p.labelsToResource {
+			labelsToResource(rm, ka)
+		}
+	}
+	return md, nil
+}
+
+// resourceToLabels sets the resource attribute as a label of all the data points of the resource, the existing
+// labels are kept.
+func resourceToLabels(rm pdata.ResourceMetrics, ka KeyAction) {
+	attrs := rm.Resource().Attributes()
+	v, ok := attrs.Get(ka.Key)
+	if !ok {
+		return
+	}
+	value := tracetranslator.AttributeValueToString(v, false)
+	forEachLabels(rm, func(labels pdata.StringMap) {
+		labels.Insert(ka.NewKey, value)
+	})
+	if ka.Action == actionMove {
+		attrs.Delete(ka.Key)
+	}
+}
+
+// labelsToResource sets the label as a resource attribute if all the data points of the resource have the same
+// value, the existing attribute is overwritten.
+func labelsToResource(rm pdata.ResourceMetrics, ka KeyAction) {
+	var value string
+	common, points := true, 0
+	forEachLabels(rm, func(labels pdata.StringMap) {
+		v, ok := labels.Get(ka.Key)
+		if !ok || (points > 0 && v != value) {
+			common = false
+		}
+		value = v
+		points++
+	})
+	if !common || points == 0 {
+		return
+	}
+	rm.Resource().Attributes().UpsertString(ka.NewKey, value)
+	if ka.Action == actionMove {
+		forEachLabels(rm, func(labels pdata.StringMap) {
+			labels.Delete(ka.Key)
+		})
+	}
+}
+
+// forEachLabels calls f with the labels of all the data points of the resource.
+func forEachLabels(rm pdata.ResourceMetrics, f func(pdata.StringMap)) {
+	ilms := rm.InstrumentationLibraryMetrics()
+	for i := 0; i < ilms.Len(); i++ {
+		metrics := ilms.At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			metric := metrics.At(j)
+			switch metric.DataType() {
+			case pdata.MetricDataTypeIntGauge:
+				dps := metric.IntGauge().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					f(dps.At(k).LabelsMap())
+				}
+			case pdata.MetricDataTypeDoubleGauge:
+				dps := metric.DoubleGauge().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					f(dps.At(k).LabelsMap())
+				}
+			case pdata.MetricDataTypeIntSum:
+				dps := metric.IntSum().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					f(dps.At(k).LabelsMap())
+				}
+			case pdata.MetricDataTypeDoubleSum:
+				dps := metric.DoubleSum().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					f(dps.At(k).LabelsMap())
+				}
+			case pdata.MetricDataTypeIntHistogram:
+				dps := metric.IntHistogram().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					f(dps.At(k).LabelsMap())
+				}
+			case pdata.MetricDataTypeDoubleHistogram:
+				dps := metric.DoubleHistogram().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					f(dps.At(k).LabelsMap())
+				}
+			case pdata.MetricDataTypeSummary:
+				dps := metric.Summary().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					f(dps.At(k).LabelsMap())
+				}
+			}
+		}
+	}
+}
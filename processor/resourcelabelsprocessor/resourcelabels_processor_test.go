@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcelabelsprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// newMetrics returns the metrics of a resource with a gauge and a sum, each with a data point of each given labels.
+func newMetrics(resource map[string]pdata.AttributeValue, labels ...map[string]string) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InitFromMap(resource)
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("memory")
+	gauge.SetDataType(pdata.MetricDataTypeIntGauge)
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetDataType(pdata.MetricDataTypeDoubleSum)
+	for _, l := range labels {
+		gauge.IntGauge().DataPoints().AppendEmpty().LabelsMap().InitFromMap(l)
+		sum.DoubleSum().DataPoints().AppendEmpty().LabelsMap().InitFromMap(l)
+	}
+	return md
+}
+
+func process(t *testing.T, cfg *Config, md pdata.Metrics) pdata.Metrics {
+	require.NoError(t, cfg.Validate())
+	out, err := newResourceLabelsProcessor(cfg).ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+	return sorted(out)
+}
+
+// sorted sorts the resource attributes and the labels, to compare the metrics built from maps.
+func sorted(md pdata.Metrics) pdata.Metrics {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rms.At(i).Resource().Attributes().Sort()
+		forEachLabels(rms.At(i), func(labels pdata.StringMap) {
+			labels.Sort()
+		})
+	}
+	return md
+}
+
+func TestResourceToLabels(t *testing.T) {
+	resource := map[string]pdata.AttributeValue{
+		"service.name": pdata.NewAttributeValueString("frontend"),
+		"pid":          pdata.NewAttributeValueInt(42),
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResourceToLabels = []KeyAction{
+		{Key: "service.name", NewKey: "service"},
+		{Key: "pid", Action: "move"},
+		{Key: "missing"},
+	}
+	out := process(t, cfg, newMetrics(resource, map[string]string{"code": "200"}, map[string]string{"code": "500", "service": "backend"}))
+
+	// The existing labels are kept.
+	assert.Equal(t, sorted(newMetrics(
+		map[string]pdata.AttributeValue{"service.name": pdata.NewAttributeValueString("frontend")},
+		map[string]string{"code": "200", "service": "frontend", "pid": "42"},
+		map[string]string{"code": "500", "service": "backend", "pid": "42"})),
+		out)
+}
+
+func TestLabelsToResource(t *testing.T) {
+	resource := map[string]pdata.AttributeValue{"service.name": pdata.NewAttributeValueString("frontend")}
+	cfg := createDefaultConfig().(*Config)
+	cfg.LabelsToResource = []KeyAction{
+		{Key: "region", Action: "move"},
+		{Key: "host", NewKey: "host.name"},
+		{Key: "code"},
+	}
+	out := process(t, cfg, newMetrics(resource,
+		map[string]string{"code": "200", "region": "eu", "host": "a"},
+		map[string]string{"code": "500", "region": "eu", "host": "a"}))
+
+	// The labels without a common value are kept as is.
+	assert.Equal(t, sorted(newMetrics(
+		map[string]pdata.AttributeValue{
+			"service.name": pdata.NewAttributeValueString("frontend"),
+			"region":       pdata.NewAttributeValueString("eu"),
+			"host.name":    pdata.NewAttributeValueString("a"),
+		},
+		map[string]string{"code": "200", "host": "a"},
+		map[string]string{"code": "500", "host": "a"})),
+		out)
+
+	// A label missing on some data points is not common.
+	out = process(t, cfg, newMetrics(resource, map[string]string{"region": "eu"}, map[string]string{}))
+	assert.Equal(t, sorted(newMetrics(resource, map[string]string{"region": "eu"}, map[string]string{})), out)
+}
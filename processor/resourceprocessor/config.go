@@ -15,6 +15,8 @@
 package resourceprocessor
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
@@ -27,3 +29,13 @@ type Config struct {
 	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.
 	AttributesActions []processorhelper.ActionKeyValue `mapstructure:"attributes"`
 }
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that at least one action is set in the attributes field.
+func (cfg *Config) Validate() error {
+	if len(cfg.AttributesActions) == 0 {
+		return errors.New(`missing required field "attributes"`)
+	}
+	return nil
+}
@@ -47,11 +47,14 @@ func TestLoadConfig(t *testing.T) {
 			{Key: "redundant-attribute", Action: processorhelper.DELETE},
 		},
 	})
+}
 
-	assert.Equal(t, cfg.Processors["resource/invalid"], &Config{
-		ProcessorSettings: configmodels.ProcessorSettings{
-			TypeVal: "resource",
-			NameVal: "resource/invalid",
-		},
-	})
+func TestLoadConfig_Invalid(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	_, err = configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_invalid.yaml"), factories)
+	assert.EqualError(t, err, `invalid configuration of processor "resource/invalid": missing required field "attributes"`)
 }
@@ -100,9 +100,6 @@ func createLogsProcessor(
 }
 
 func createAttrProcessor(cfg *Config) (*processorhelper.AttrProc, error) {
-	if len(cfg.AttributesActions) == 0 {
-		return nil, fmt.Errorf("error creating \"%q\" processor due to missing required field \"attributes\"", cfg.Name())
-	}
 	attrProc, err := processorhelper.NewAttrProc(&processorhelper.Settings{Actions: cfg.AttributesActions})
 	if err != nil {
 		return nil, fmt.Errorf("error creating \"%q\" processor: %w", cfg.Name(), err)
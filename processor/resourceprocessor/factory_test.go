@@ -59,11 +59,7 @@ func TestInvalidEmptyActions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
 
-	_, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewTracesNop())
-	assert.Error(t, err)
-
-	_, err = factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewMetricsNop())
-	assert.Error(t, err)
+	assert.EqualError(t, cfg.(*Config).Validate(), `missing required field "attributes"`)
 }
 
 func TestInvalidAttributeActions(t *testing.T) {
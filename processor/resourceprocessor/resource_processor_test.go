@@ -147,7 +147,9 @@ func TestResourceProcessorError(t *testing.T) {
 
 	badCfg := &Config{
 		ProcessorSettings: processorSettings,
-		AttributesActions: nil,
+		AttributesActions: []processorhelper.ActionKeyValue{
+			{Key: "k", Value: "v", Action: "invalid-action"},
+		},
 	}
 
 	factory := NewFactory()
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// attributeSourceContext reads the value in the client metadata carried by the context.
+	attributeSourceContext = "context"
+	// attributeSourceResource reads the value in the resource attributes.
+	attributeSourceResource = "resource"
+)
+
+// Config defines configuration for the Routing processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// AttributeSource is where the value selecting the route is read, either "context" for the client
+	// metadata kept by the receiver or "resource" for the resource attributes. Default value is "context".
+	AttributeSource string `mapstructure:"attribute_source"`
+
+	// FromAttribute is the name of the metadata key or of the resource attribute whose value selects the route.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// DefaultExporters are the exporters receiving the data whose value matches no route.
+	DefaultExporters []string `mapstructure:"default_exporters"`
+
+	// Table maps the values to the exporters receiving the data.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+// RoutingTableItem specifies the exporters receiving the data with a given value.
+type RoutingTableItem struct {
+	// Value is the value of the attribute selecting this route.
+	Value string `mapstructure:"value"`
+
+	// Exporters are the names of the exporters receiving the data, they must be in the exporters of the pipeline.
+	Exporters []string `mapstructure:"exporters"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the attribute is set and that every route has exporters.
+func (cfg *Config) Validate() error {
+	if cfg.AttributeSource != attributeSourceContext && cfg.AttributeSource != attributeSourceResource {
+		return fmt.Errorf("attribute_source must be either %q or %q", attributeSourceContext, attributeSourceResource)
+	}
+	if cfg.FromAttribute == "" {
+		return errors.New("from_attribute must be set")
+	}
+	if len(cfg.Table) == 0 {
+		return errors.New("table must have at least one route")
+	}
+	for _, item := range cfg.Table {
+		if len(item.Exporters) == 0 {
+			return fmt.Errorf("the route of the value %q has no exporters", item.Value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, cfg.Processors["routing"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "routing",
+				NameVal: "routing",
+			},
+			AttributeSource:  "context",
+			FromAttribute:    "X-Tenant",
+			DefaultExporters: []string{"nop"},
+			Table:            []RoutingTableItem{{Value: "acme", Exporters: []string{"nop/acme"}}},
+		})
+
+	assert.Equal(t, cfg.Processors["routing/env"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "routing",
+				NameVal: "routing/env",
+			},
+			AttributeSource: "resource",
+			FromAttribute:   "deployment.environment",
+			Table:           []RoutingTableItem{{Value: "prod", Exporters: []string{"nop", "nop/acme"}}},
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "from_attribute must be set")
+
+	cfg.FromAttribute = "X-Tenant"
+	assert.EqualError(t, cfg.Validate(), "table must have at least one route")
+
+	cfg.Table = []RoutingTableItem{{Value: "acme"}}
+	assert.EqualError(t, cfg.Validate(), `the route of the value "acme" has no exporters`)
+
+	cfg.Table[0].Exporters = []string{"otlp"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.AttributeSource = "span"
+	assert.EqualError(t, cfg.Validate(), `attribute_source must be either "context" or "resource"`)
+}
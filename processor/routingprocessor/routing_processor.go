@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/fanoutconsumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// router selects the route of the data, the exporters of the routes are resolved when the processor starts.
+type router struct {
+	config *Config
+	// index is the index of the route of each value, the default route is the last one.
+	index map[string]int
+}
+
+func newRouter(cfg *Config) router {
+	r := router{config: cfg, index: make(map[string]int, len(cfg.Table))}
+	for i, item := range cfg.Table {
+		r.index[item.Value] = i
+	}
+	return r
+}
+
+// exporters returns the exporters of the data type of each route, the default exporters are the last ones.
+func (r *router) exporters(host component.Host, dataType configmodels.DataType) ([][]component.Exporter, error) {
+	available := make(map[string]component.Exporter)
+	for entity, exp := range host.GetExporters()[dataType] {
+		available[entity.Name()] = exp
+	}
+	resolve := func(names []string) ([]component.Exporter, error) {
+		exps := make([]component.Exporter, 0, len(names))
+		for _, name := range names {
+			exp, ok := available[name]
+			if !ok {
+				return nil, fmt.Errorf("the %s exporter %q of the routing processor %q is not in the exporters of the pipeline", dataType, name, r.config.Name())
+			}
+			exps = append(exps, exp)
+		}
+		return exps, nil
+	}
+
+	routes := make([][]component.Exporter, 0, len(r.config.Table)+1)
+	for _, item := range r.config.Table {
+		exps, err := resolve(item.Exporters)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, exps)
+	}
+	defaultExps, err := resolve(r.config.DefaultExporters)
+	if err != nil {
+		return nil, err
+	}
+	return append(routes, defaultExps), nil
+}
+
+// routeIndex returns the index of the route of the value, the index of the default route when it matches no route.
+func (r *router) routeIndex(value string) int {
+	if i, ok := r.index[value]; ok {
+		return i
+	}
+	return len(r.config.Table)
+}
+
+// contextValue returns the first value of the attribute in the client metadata, or an empty string.
+func (r *router) contextValue(ctx context.Context) string {
+	c, ok := client.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := c.Metadata[strings.ToLower(r.config.FromAttribute)]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// resourceValue returns the value of the attribute in the resource attributes, or an empty string.
+func (r *router) resourceValue(resource pdata.Resource) string {
+	if v, ok := resource.Attributes().Get(r.config.FromAttribute); ok && v.Type() == pdata.AttributeValueSTRING {
+		return v.StringVal()
+	}
+	return ""
+}
+
+func (r *router) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// Shutdown is invoked during service shutdown.
+func (r *router) Shutdown(context.Context) error {
+	return nil
+}
+
+type tracesProcessor struct {
+	router
+	routes []consumer.Traces
+}
+
+var _ component.TracesProcessor = (*tracesProcessor)(nil)
+
+func newTracesProcessor(cfg *Config) *tracesProcessor {
+	return &tracesProcessor{router: newRouter(cfg)}
+}
+
+// Start resolves the exporters of the routes.
+func (tp *tracesProcessor) Start(_ context.Context, host component.Host) error {
+	routes, err := tp.exporters(host, configmodels.TracesDataType)
+	if err != nil {
+		return err
+	}
+	tp.routes = make([]consumer.Traces, 0, len(routes))
+	for _, exps := range routes {
+		cs := make([]consumer.Traces, 0, len(exps))
+		for _, exp := range exps {
+			cs = append(cs, exp.(consumer.Traces))
+		}
+		tp.routes = append(tp.routes, fanoutconsumer.NewTraces(cs))
+	}
+	return nil
+}
+
+// ConsumeTraces sends the traces to the exporters of their route.
+func (tp *tracesProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if tp.config.AttributeSource == attributeSourceContext {
+		return tp.routes[tp.routeIndex(tp.contextValue(ctx))].ConsumeTraces(ctx, td)
+	}
+
+	groups := make(map[int]pdata.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		index := tp.routeIndex(tp.resourceValue(rs.Resource()))
+		group, ok := groups[index]
+		if !ok {
+			group = pdata.NewTraces()
+			groups[index] = group
+		}
+		rs.CopyTo(group.ResourceSpans().AppendEmpty())
+	}
+
+	var errs []error
+	for index, group := range groups {
+		if err := tp.routes[index].ConsumeTraces(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+type metricsProcessor struct {
+	router
+	routes []consumer.Metrics
+}
+
+var _ component.MetricsProcessor = (*metricsProcessor)(nil)
+
+func newMetricsProcessor(cfg *Config) *metricsProcessor {
+	return &metricsProcessor{router: newRouter(cfg)}
+}
+
+// Start resolves the exporters of the routes.
+func (mp *metricsProcessor) Start(_ context.Context, host component.Host) error {
+	routes, err := mp.exporters(host, configmodels.MetricsDataType)
+	if err != nil {
+		return err
+	}
+	mp.routes = make([]consumer.Metrics, 0, len(routes))
+	for _, exps := range routes {
+		cs := make([]consumer.Metrics, 0, len(exps))
+		for _, exp := range exps {
+			cs = append(cs, exp.(consumer.Metrics))
+		}
+		mp.routes = append(mp.routes, fanoutconsumer.NewMetrics(cs))
+	}
+	return nil
+}
+
+// ConsumeMetrics sends the metrics to the exporters of their route.
+func (mp *metricsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if mp.config.AttributeSource == attributeSourceContext {
+		return mp.routes[mp.routeIndex(mp.contextValue(ctx))].ConsumeMetrics(ctx, md)
+	}
+
+	groups := make(map[int]pdata.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		index := mp.routeIndex(mp.resourceValue(rm.Resource()))
+		group, ok := groups[index]
+		if !ok {
+			group = pdata.NewMetrics()
+			groups[index] = group
+		}
+		rm.CopyTo(group.ResourceMetrics().AppendEmpty())
+	}
+
+	var errs []error
+	for index, group := range groups {
+		if err := mp.routes[index].ConsumeMetrics(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+type logsProcessor struct {
+	router
+	routes []consumer.Logs
+}
+
+var _ component.LogsProcessor = (*logsProcessor)(nil)
+
+func newLogsProcessor(cfg *Config) *logsProcessor {
+	return &logsProcessor{router: newRouter(cfg)}
+}
+
+// Start resolves the exporters of the routes.
+func (lp *logsProcessor) Start(_ context.Context, host component.Host) error {
+	routes, err := lp.exporters(host, configmodels.LogsDataType)
+	if err != nil {
+		return err
+	}
+	lp.routes = make([]consumer.Logs, 0, len(routes))
+	for _, exps := range routes {
+		cs := make([]consumer.Logs, 0, len(exps))
+		for _, exp := range exps {
+			cs = append(cs, exp.(consumer.Logs))
+		}
+		lp.routes = append(lp.routes, fanoutconsumer.NewLogs(cs))
+	}
+	return nil
+}
+
+// ConsumeLogs sends the logs to the exporters of their route.
+func (lp *logsProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if lp.config.AttributeSource == attributeSourceContext {
+		return lp.routes[lp.routeIndex(lp.contextValue(ctx))].ConsumeLogs(ctx, ld)
+	}
+
+	groups := make(map[int]pdata.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		index := lp.routeIndex(lp.resourceValue(rl.Resource()))
+		group, ok := groups[index]
+		if !ok {
+			group = pdata.NewLogs()
+			groups[index] = group
+		}
+		rl.CopyTo(group.ResourceLogs().AppendEmpty())
+	}
+
+	var errs []error
+	for index, group := range groups {
+		if err := lp.routes[index].ConsumeLogs(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
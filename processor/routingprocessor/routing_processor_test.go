@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+// sinkExporter records the data of all the signals.
+type sinkExporter struct {
+	consumertest.TracesSink
+	consumertest.MetricsSink
+	consumertest.LogsSink
+}
+
+func (e *sinkExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *sinkExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// exportersHost returns the exporters by name for all the data types.
+type exportersHost struct {
+	component.Host
+	exporters map[string]*sinkExporter
+}
+
+func (h *exportersHost) GetExporters() map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter {
+	exps := make(map[configmodels.NamedEntity]component.Exporter)
+	for name, exp := range h.exporters {
+		exps[&configmodels.ExporterSettings{TypeVal: "sink", NameVal: name}] = exp
+	}
+	return map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter{
+		configmodels.TracesDataType:  exps,
+		configmodels.MetricsDataType: exps,
+		configmodels.LogsDataType:    exps,
+	}
+}
+
+func newExportersHost(names ...string) *exportersHost {
+	h := &exportersHost{Host: componenttest.NewNopHost(), exporters: make(map[string]*sinkExporter)}
+	for _, name := range names {
+		h.exporters[name] = &sinkExporter{}
+	}
+	return h
+}
+
+func newTestConfig(source string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AttributeSource = source
+	cfg.FromAttribute = "X-Tenant"
+	cfg.DefaultExporters = []string{"sink/default"}
+	cfg.Table = []RoutingTableItem{
+		{Value: "acme", Exporters: []string{"sink/acme", "sink/all"}},
+		{Value: "globex", Exporters: []string{"sink/globex", "sink/all"}},
+	}
+	return cfg
+}
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), &client.Client{
+		Metadata: map[string][]string{"x-tenant": {tenant}},
+	})
+}
+
+func TestTracesRoutingFromContext(t *testing.T) {
+	host := newExportersHost("sink/default", "sink/acme", "sink/globex", "sink/all")
+	tp := newTracesProcessor(newTestConfig(attributeSourceContext))
+	require.NoError(t, tp.Start(context.Background(), host))
+
+	require.NoError(t, tp.ConsumeTraces(tenantContext("acme"), testdata.GenerateTraceDataTwoSpansSameResource()))
+	require.NoError(t, tp.ConsumeTraces(tenantContext("globex"), testdata.GenerateTraceDataOneSpan()))
+	require.NoError(t, tp.ConsumeTraces(tenantContext("initech"), testdata.GenerateTraceDataOneSpan()))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	assert.Equal(t, 2, host.exporters["sink/acme"].SpansCount())
+	assert.Equal(t, 1, host.exporters["sink/globex"].SpansCount())
+	assert.Equal(t, 3, host.exporters["sink/all"].SpansCount())
+	assert.Equal(t, 2, host.exporters["sink/default"].SpansCount())
+}
+
+func TestMetricsRoutingFromResource(t *testing.T) {
+	host := newExportersHost("sink/default", "sink/acme", "sink/globex", "sink/all")
+	mp := newMetricsProcessor(newTestConfig(attributeSourceResource))
+	require.NoError(t, mp.Start(context.Background(), host))
+
+	md := pdata.NewMetrics()
+	for _, tenant := range []string{"acme", "globex", "acme", ""} {
+		rm := testdata.GenerateMetricsOneMetric().ResourceMetrics().At(0)
+		if tenant != "" {
+			rm.Resource().Attributes().UpsertString("X-Tenant", tenant)
+		}
+		rm.CopyTo(md.ResourceMetrics().AppendEmpty())
+	}
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+
+	// The data is split by resource.
+	assert.Equal(t, 2, host.exporters["sink/acme"].MetricsCount())
+	require.Len(t, host.exporters["sink/acme"].AllMetrics(), 1)
+	assert.Equal(t, 2, host.exporters["sink/acme"].AllMetrics()[0].ResourceMetrics().Len())
+	assert.Equal(t, 1, host.exporters["sink/globex"].MetricsCount())
+	assert.Equal(t, 3, host.exporters["sink/all"].MetricsCount())
+	assert.Equal(t, 1, host.exporters["sink/default"].MetricsCount())
+}
+
+func TestLogsRoutingWithoutDefaultExporters(t *testing.T) {
+	host := newExportersHost("sink/acme", "sink/globex", "sink/all")
+	cfg := newTestConfig(attributeSourceContext)
+	cfg.DefaultExporters = nil
+	lp := newLogsProcessor(cfg)
+	require.NoError(t, lp.Start(context.Background(), host))
+
+	require.NoError(t, lp.ConsumeLogs(tenantContext("acme"), testdata.GenerateLogDataOneLog()))
+	// The data matching no route is dropped.
+	require.NoError(t, lp.ConsumeLogs(tenantContext("initech"), testdata.GenerateLogDataOneLog()))
+
+	assert.Equal(t, 1, host.exporters["sink/acme"].LogRecordsCount())
+	assert.Equal(t, 1, host.exporters["sink/all"].LogRecordsCount())
+	assert.Equal(t, 0, host.exporters["sink/globex"].LogRecordsCount())
+}
+
+func TestRoutingUnknownExporter(t *testing.T) {
+	tp := newTracesProcessor(newTestConfig(attributeSourceContext))
+	err := tp.Start(context.Background(), newExportersHost("sink/default", "sink/acme", "sink/all"))
+	assert.EqualError(t, err, `the traces exporter "sink/globex" of the routing processor "routing" is not in the exporters of the pipeline`)
+}
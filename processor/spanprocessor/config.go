@@ -15,6 +15,9 @@
 package spanprocessor
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/internal/processor/filterconfig"
 )
@@ -34,6 +37,52 @@ type Config struct {
 	// Note: The field name is `Rename` to avoid collision with the Name() method
 	// from configmodels.ProcessorSettings.NamedEntity
 	Rename Name `mapstructure:"name"`
+
+	// SetStatus specifies the rules setting the status of a span.
+	SetStatus *Status `mapstructure:"status"`
+
+	// Events specifies the events added to or removed from a span.
+	Events *Events `mapstructure:"events"`
+
+	// RemoveLinks indicates whether the links of a span are removed.
+	RemoveLinks bool `mapstructure:"remove_links"`
+
+	// TruncateAttributes specifies the maximum length of the string attribute values of a span.
+	TruncateAttributes *TruncateAttributes `mapstructure:"truncate_attributes"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that 'from_attributes' or 'to_attributes' under 'name' is set,
+// or that one of the other actions is set.
+// If not set and not enforced, the processor would do no work.
+func (cfg *Config) Validate() error {
+	if len(cfg.Rename.FromAttributes) == 0 &&
+		(cfg.Rename.ToAttributes == nil || len(cfg.Rename.ToAttributes.Rules) == 0) &&
+		cfg.SetStatus == nil && cfg.Events == nil && !cfg.RemoveLinks && cfg.TruncateAttributes == nil {
+		return errMissingRequiredField
+	}
+	if cfg.SetStatus != nil {
+		for _, rule := range cfg.SetStatus.Rules {
+			if rule.Attribute == "" {
+				return errors.New("error creating \"span\" processor: \"attribute\" must be specified in the status rules")
+			}
+			if _, ok := statusCodes[rule.Code]; !ok {
+				return fmt.Errorf("error creating \"span\" processor: invalid status code %q, must be one of \"Unset\", \"Ok\" or \"Error\"", rule.Code)
+			}
+		}
+	}
+	if cfg.Events != nil {
+		for _, event := range cfg.Events.Add {
+			if event.Name == "" {
+				return errors.New("error creating \"span\" processor: \"name\" must be specified in the added events")
+			}
+		}
+	}
+	if cfg.TruncateAttributes != nil && cfg.TruncateAttributes.Limit <= 0 {
+		return errors.New("error creating \"span\" processor: \"limit\" must be positive in \"truncate_attributes:\"")
+	}
+	return nil
 }
 
 // Name specifies the attributes to use to re-name a span.
@@ -77,3 +126,63 @@ type ToAttributes struct {
 	// modified span name.
 	BreakAfterMatch bool `mapstructure:"break_after_match"`
 }
+
+// Status specifies the rules setting the status of a span.
+type Status struct {
+	// FromHTTPStatusCode sets the Error status code on the spans whose http.status_code
+	// attribute is a server error (5xx), or a client error (4xx) for the client spans,
+	// as per the semantic conventions. The spans whose status is already set are not
+	// modified.
+	FromHTTPStatusCode bool `mapstructure:"from_http_status_code"`
+
+	// Rules set the status of the spans with a given attribute value, they are applied
+	// after FromHTTPStatusCode. The first matching rule is applied.
+	Rules []StatusRule `mapstructure:"rules"`
+}
+
+// StatusRule sets the status of the spans with a given attribute value.
+type StatusRule struct {
+	// Attribute is the key of the attribute matched by the rule.
+	Attribute string `mapstructure:"attribute"`
+
+	// Values are the attribute values matched by the rule, compared to the string
+	// representation of the attribute value. The rule matches all the spans with the
+	// attribute when empty.
+	Values []string `mapstructure:"values"`
+
+	// Code is the status code set by the rule, one of "Unset", "Ok" or "Error".
+	Code string `mapstructure:"code"`
+
+	// Description is the status message set by the rule.
+	Description string `mapstructure:"description"`
+}
+
+// Events specifies the events added to or removed from a span.
+type Events struct {
+	// Add are the events added to the span, at the start time of the span.
+	Add []Event `mapstructure:"add"`
+
+	// Remove are the names of the events removed from the span, they are removed
+	// before the events are added.
+	Remove []string `mapstructure:"remove"`
+}
+
+// Event is an event added to a span.
+type Event struct {
+	// Name is the name of the event.
+	Name string `mapstructure:"name"`
+
+	// Attributes are the string attributes of the event.
+	Attributes map[string]string `mapstructure:"attributes"`
+}
+
+// TruncateAttributes specifies the maximum length of the string attribute values.
+type TruncateAttributes struct {
+	// Limit is the maximum number of characters of the string attribute values, the
+	// longer values are truncated.
+	Limit int `mapstructure:"limit"`
+
+	// Keys are the keys of the truncated attributes, all the string attributes are
+	// truncated when empty.
+	Keys []string `mapstructure:"keys"`
+}
@@ -99,6 +99,48 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 	})
+
+	p4 := cfg.Processors["span/actions"]
+	assert.Equal(t, p4, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: "span/actions",
+		},
+		SetStatus: &Status{
+			FromHTTPStatusCode: true,
+			Rules: []StatusRule{
+				{Attribute: "db.error", Values: []string{"timeout"}, Code: "Error", Description: "database timeout"},
+			},
+		},
+		Events: &Events{
+			Add:    []Event{{Name: "processed", Attributes: map[string]string{"by": "collector"}}},
+			Remove: []string{"debug"},
+		},
+		RemoveLinks:        true,
+		TruncateAttributes: &TruncateAttributes{Limit: 256, Keys: []string{"db.statement"}},
+	})
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, errMissingRequiredField, cfg.Validate())
+
+	cfg.RemoveLinks = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.SetStatus = &Status{Rules: []StatusRule{{Code: "Error"}}}
+	assert.EqualError(t, cfg.Validate(), `error creating "span" processor: "attribute" must be specified in the status rules`)
+
+	cfg.SetStatus = &Status{Rules: []StatusRule{{Attribute: "db.error", Code: "Failed"}}}
+	assert.EqualError(t, cfg.Validate(), `error creating "span" processor: invalid status code "Failed", must be one of "Unset", "Ok" or "Error"`)
+
+	cfg.SetStatus = nil
+	cfg.Events = &Events{Add: []Event{{}}}
+	assert.EqualError(t, cfg.Validate(), `error creating "span" processor: "name" must be specified in the added events`)
+
+	cfg.Events = nil
+	cfg.TruncateAttributes = &TruncateAttributes{}
+	assert.EqualError(t, cfg.Validate(), `error creating "span" processor: "limit" must be positive in "truncate_attributes:"`)
 }
 
 func createMatchConfig(matchType filterset.MatchType) *filterset.Config {
@@ -35,7 +35,8 @@ var processorCapabilities = component.ProcessorCapabilities{MutatesConsumedData:
 // is not specified.
 // TODO https://github.com/open-telemetry/opentelemetry-collector/issues/215
 //	Move this to the error package that allows for span name and field to be specified.
-var errMissingRequiredField = errors.New("error creating \"span\" processor: either \"from_attributes\" or \"to_attributes\" must be specified in \"name:\"")
+var errMissingRequiredField = errors.New("error creating \"span\" processor: either \"from_attributes\" or \"to_attributes\" must be specified in \"name:\", " +
+	"or one of \"status\", \"events\", \"remove_links\" or \"truncate_attributes\" must be specified")
 
 // NewFactory returns a new factory for the Span processor.
 func NewFactory() component.ProcessorFactory {
@@ -61,12 +62,9 @@ func createTraceProcessor(
 	nextConsumer consumer.Traces,
 ) (component.TracesProcessor, error) {
 
-	// 'from_attributes' or 'to_attributes' under 'name' has to be set for the span
-	// processor to be valid. If not set and not enforced, the processor would do no work.
 	oCfg := cfg.(*Config)
-	if len(oCfg.Rename.FromAttributes) == 0 &&
-		(oCfg.Rename.ToAttributes == nil || len(oCfg.Rename.ToAttributes.Rules) == 0) {
-		return nil, errMissingRequiredField
+	if err := oCfg.Validate(); err != nil {
+		return nil, err
 	}
 
 	sp, err := newSpanProcessor(*oCfg)
@@ -20,14 +20,27 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterspan"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
 )
 
+// statusCodes maps the status codes of the configuration to the span status codes.
+var statusCodes = map[string]pdata.StatusCode{
+	"Unset": pdata.StatusCodeUnset,
+	"Ok":    pdata.StatusCodeOk,
+	"Error": pdata.StatusCodeError,
+}
+
 type spanProcessor struct {
 	config           Config
 	toAttributeRules []toAttributeRule
+	statusRules      []statusRule
+	removedEvents    map[string]bool
+	truncatedKeys    map[string]bool
 	include          filterspan.Matcher
 	exclude          filterspan.Matcher
 }
@@ -41,6 +54,14 @@ type toAttributeRule struct {
 	attrNames []string
 }
 
+// statusRule is the compiled equivalent of config.SetStatus.Rules field.
+type statusRule struct {
+	attribute   string
+	values      map[string]bool
+	code        pdata.StatusCode
+	description string
+}
+
 // newSpanProcessor returns the span processor.
 func newSpanProcessor(config Config) (*spanProcessor, error) {
 	include, err := filterspan.NewMatcher(config.Include)
@@ -76,6 +97,37 @@ func newSpanProcessor(config Config) (*spanProcessor, error) {
 		}
 	}
 
+	if config.SetStatus != nil {
+		for _, r := range config.SetStatus.Rules {
+			rule := statusRule{
+				attribute:   r.Attribute,
+				code:        statusCodes[r.Code],
+				description: r.Description,
+			}
+			if len(r.Values) > 0 {
+				rule.values = make(map[string]bool, len(r.Values))
+				for _, v := range r.Values {
+					rule.values[v] = true
+				}
+			}
+			sp.statusRules = append(sp.statusRules, rule)
+		}
+	}
+
+	if config.Events != nil && len(config.Events.Remove) > 0 {
+		sp.removedEvents = make(map[string]bool, len(config.Events.Remove))
+		for _, name := range config.Events.Remove {
+			sp.removedEvents[name] = true
+		}
+	}
+
+	if config.TruncateAttributes != nil && len(config.TruncateAttributes.Keys) > 0 {
+		sp.truncatedKeys = make(map[string]bool, len(config.TruncateAttributes.Keys))
+		for _, key := range config.TruncateAttributes.Keys {
+			sp.truncatedKeys[key] = true
+		}
+	}
+
 	return sp, nil
 }
 
@@ -96,6 +148,12 @@ func (sp *spanProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdat
 				}
 				sp.processFromAttributes(s)
 				sp.processToAttributes(s)
+				sp.processStatus(s)
+				sp.processEvents(s)
+				if sp.config.RemoveLinks {
+					s.Links().Resize(0)
+				}
+				sp.processTruncateAttributes(s)
 			}
 		}
 	}
@@ -219,3 +277,97 @@ func (sp *spanProcessor) processToAttributes(span pdata.Span) {
 		}
 	}
 }
+
+func (sp *spanProcessor) processStatus(span pdata.Span) {
+	if sp.config.SetStatus == nil {
+		return
+	}
+
+	if sp.config.SetStatus.FromHTTPStatusCode && span.Status().Code() == pdata.StatusCodeUnset {
+		if code, ok := httpStatusCode(span.Attributes()); ok {
+			// The client errors are only errors of the client spans, the server handled
+			// the request correctly.
+			if code >= 500 || (code >= 400 && span.Kind() == pdata.SpanKindCLIENT) {
+				span.Status().SetCode(pdata.StatusCodeError)
+			}
+		}
+	}
+
+	attrs := span.Attributes()
+	for _, rule := range sp.statusRules {
+		attr, found := attrs.Get(rule.attribute)
+		if !found {
+			continue
+		}
+		if rule.values != nil && !rule.values[tracetranslator.AttributeValueToString(attr, false)] {
+			continue
+		}
+		span.Status().SetCode(rule.code)
+		span.Status().SetMessage(rule.description)
+		return
+	}
+}
+
+// httpStatusCode returns the value of the http.status_code attribute, either an int or a string.
+func httpStatusCode(attrs pdata.AttributeMap) (int64, bool) {
+	attr, found := attrs.Get(conventions.AttributeHTTPStatusCode)
+	if !found {
+		return 0, false
+	}
+	switch attr.Type() {
+	case pdata.AttributeValueINT:
+		return attr.IntVal(), true
+	case pdata.AttributeValueSTRING:
+		code, err := strconv.ParseInt(attr.StringVal(), 10, 64)
+		return code, err == nil
+	}
+	return 0, false
+}
+
+func (sp *spanProcessor) processEvents(span pdata.Span) {
+	if sp.config.Events == nil {
+		return
+	}
+
+	events := span.Events()
+	if sp.removedEvents != nil {
+		events.RemoveIf(func(event pdata.SpanEvent) bool {
+			return sp.removedEvents[event.Name()]
+		})
+	}
+	for _, e := range sp.config.Events.Add {
+		event := events.AppendEmpty()
+		event.SetName(e.Name)
+		event.SetTimestamp(span.StartTime())
+		for k, v := range e.Attributes {
+			event.Attributes().UpsertString(k, v)
+		}
+	}
+}
+
+func (sp *spanProcessor) processTruncateAttributes(span pdata.Span) {
+	if sp.config.TruncateAttributes == nil {
+		return
+	}
+
+	limit := sp.config.TruncateAttributes.Limit
+	span.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		if v.Type() != pdata.AttributeValueSTRING || (sp.truncatedKeys != nil && !sp.truncatedKeys[k]) {
+			return
+		}
+		if value := v.StringVal(); utf8.RuneCountInString(value) > limit {
+			v.SetStringVal(truncate(value, limit))
+		}
+	})
+}
+
+// truncate returns the first limit characters of the string.
+func truncate(s string, limit int) string {
+	for i := range s {
+		if limit == 0 {
+			return s[:i]
+		}
+		limit--
+	}
+	return s
+}
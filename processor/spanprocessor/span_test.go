@@ -597,3 +597,155 @@ func TestSpanProcessor_skipSpan(t *testing.T) {
 		runIndividualTestCase(t, tc, tp)
 	}
 }
+
+func TestSpanProcessor_Status(t *testing.T) {
+	testCases := []struct {
+		name        string
+		kind        pdata.SpanKind
+		code        pdata.StatusCode
+		attrs       map[string]pdata.AttributeValue
+		wantCode    pdata.StatusCode
+		wantMessage string
+	}{
+		{
+			name:     "server error",
+			kind:     pdata.SpanKindSERVER,
+			attrs:    map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(503)},
+			wantCode: pdata.StatusCodeError,
+		},
+		{
+			name:     "client error of a server span",
+			kind:     pdata.SpanKindSERVER,
+			attrs:    map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(404)},
+			wantCode: pdata.StatusCodeUnset,
+		},
+		{
+			name:     "client error of a client span",
+			kind:     pdata.SpanKindCLIENT,
+			attrs:    map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueString("404")},
+			wantCode: pdata.StatusCodeError,
+		},
+		{
+			name:     "status already set",
+			kind:     pdata.SpanKindSERVER,
+			code:     pdata.StatusCodeOk,
+			attrs:    map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(500)},
+			wantCode: pdata.StatusCodeOk,
+		},
+		{
+			name:        "rule matching a value",
+			kind:        pdata.SpanKindINTERNAL,
+			attrs:       map[string]pdata.AttributeValue{"db.error": pdata.NewAttributeValueString("timeout")},
+			wantCode:    pdata.StatusCodeError,
+			wantMessage: "database error",
+		},
+		{
+			name:     "rule not matching a value",
+			kind:     pdata.SpanKindINTERNAL,
+			attrs:    map[string]pdata.AttributeValue{"db.error": pdata.NewAttributeValueString("none")},
+			wantCode: pdata.StatusCodeUnset,
+		},
+		{
+			name:     "rule matching any value",
+			kind:     pdata.SpanKindINTERNAL,
+			code:     pdata.StatusCodeError,
+			attrs:    map[string]pdata.AttributeValue{"retried": pdata.NewAttributeValueBool(true)},
+			wantCode: pdata.StatusCodeOk,
+		},
+	}
+
+	factory := NewFactory()
+	oCfg := factory.CreateDefaultConfig().(*Config)
+	oCfg.SetStatus = &Status{
+		FromHTTPStatusCode: true,
+		Rules: []StatusRule{
+			{Attribute: "db.error", Values: []string{"timeout", "refused"}, Code: "Error", Description: "database error"},
+			{Attribute: "retried", Code: "Ok"},
+		},
+	}
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewTracesNop())
+	require.Nil(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			td := generateTraceData("", "span", tc.attrs)
+			span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+			span.SetKind(tc.kind)
+			span.Status().SetCode(tc.code)
+
+			assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+			assert.Equal(t, tc.wantCode, span.Status().Code())
+			assert.Equal(t, tc.wantMessage, span.Status().Message())
+		})
+	}
+}
+
+func TestSpanProcessor_EventsAndLinks(t *testing.T) {
+	factory := NewFactory()
+	oCfg := factory.CreateDefaultConfig().(*Config)
+	oCfg.Events = &Events{
+		Add:    []Event{{Name: "processed", Attributes: map[string]string{"by": "collector"}}},
+		Remove: []string{"debug"},
+	}
+	oCfg.RemoveLinks = true
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewTracesNop())
+	require.Nil(t, err)
+
+	td := generateTraceData("", "span", nil)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.SetStartTime(pdata.Timestamp(42))
+	span.Events().AppendEmpty().SetName("debug")
+	span.Events().AppendEmpty().SetName("exception")
+	span.Links().AppendEmpty()
+
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 0, span.Links().Len())
+	require.Equal(t, 2, span.Events().Len())
+	assert.Equal(t, "exception", span.Events().At(0).Name())
+	added := span.Events().At(1)
+	assert.Equal(t, "processed", added.Name())
+	assert.Equal(t, pdata.Timestamp(42), added.Timestamp())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{"by": pdata.NewAttributeValueString("collector")}), added.Attributes())
+}
+
+func TestSpanProcessor_TruncateAttributes(t *testing.T) {
+	factory := NewFactory()
+	oCfg := factory.CreateDefaultConfig().(*Config)
+	oCfg.TruncateAttributes = &TruncateAttributes{Limit: 4}
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewTracesNop())
+	require.Nil(t, err)
+
+	runIndividualTestCase(t, testCase{
+		inputName: "truncate all",
+		inputAttributes: map[string]pdata.AttributeValue{
+			"db.statement": pdata.NewAttributeValueString("SELECT * FROM users"),
+			"short":        pdata.NewAttributeValueString("abc"),
+			"unicode":      pdata.NewAttributeValueString("héllo wörld"),
+			"count":        pdata.NewAttributeValueInt(123456),
+		},
+		outputName: "truncate all",
+		outputAttributes: map[string]pdata.AttributeValue{
+			"db.statement": pdata.NewAttributeValueString("SELE"),
+			"short":        pdata.NewAttributeValueString("abc"),
+			"unicode":      pdata.NewAttributeValueString("héll"),
+			"count":        pdata.NewAttributeValueInt(123456),
+		},
+	}, tp)
+
+	oCfg.TruncateAttributes.Keys = []string{"db.statement"}
+	tp, err = factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewTracesNop())
+	require.Nil(t, err)
+
+	runIndividualTestCase(t, testCase{
+		inputName: "truncate keys",
+		inputAttributes: map[string]pdata.AttributeValue{
+			"db.statement": pdata.NewAttributeValueString("SELECT * FROM users"),
+			"http.url":     pdata.NewAttributeValueString("http://localhost"),
+		},
+		outputName: "truncate keys",
+		outputAttributes: map[string]pdata.AttributeValue{
+			"db.statement": pdata.NewAttributeValueString("SELE"),
+			"http.url":     pdata.NewAttributeValueString("http://localhost"),
+		},
+	}, tp)
+}
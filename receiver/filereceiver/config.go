@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+const (
+	// FormatJSON reads one Protobuf JSON encoded request per line.
+	FormatJSON = "json"
+	// FormatProto reads binary Protobuf encoded requests, each prefixed by its
+	// length as a 4 byte big endian unsigned integer.
+	FormatProto = "proto"
+
+	// CompressionGzip reads files compressed using gzip.
+	CompressionGzip = "gzip"
+
+	// TimingFast replays the data as fast as possible.
+	TimingFast = "fast"
+	// TimingOriginal replays the data respecting the time between the original
+	// timestamps of the replayed data.
+	TimingOriginal = "original"
+
+	// StartAtBeginning reads the files from the beginning.
+	StartAtBeginning = "beginning"
+	// StartAtEnd tails the file, only reading data written after the receiver started.
+	StartAtEnd = "end"
+)
+
+// Config defines configuration for file receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Path of the file to read from, usually the path configured in a file exporter.
+	Path string `mapstructure:"path"`
+
+	// Format of the data in the file, either "json" (default) or "proto".
+	Format string `mapstructure:"format"`
+
+	// Compression of the file. The only supported value is "gzip", by default
+	// the file is not compressed.
+	Compression string `mapstructure:"compression"`
+
+	// IncludeRotated also replays the files rotated by the file exporter, from
+	// the oldest to the newest, before reading the file configured in Path.
+	IncludeRotated bool `mapstructure:"include_rotated"`
+
+	// Timing controls the speed of the replay, either "fast" (default) to replay
+	// as fast as possible or "original" to respect the original timestamps.
+	Timing string `mapstructure:"timing"`
+
+	// Loop restarts the replay from the beginning once all the data is read.
+	Loop bool `mapstructure:"loop"`
+
+	// StartAt is either "beginning" (default) to replay existing data or "end"
+	// to tail the file and only read data written after the receiver started.
+	StartAt string `mapstructure:"start_at"`
+
+	// PollInterval is how often the file is checked for new data when tailing.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks the path and the supported values of the settings.
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be specified")
+	}
+	switch cfg.Format {
+	case FormatJSON, FormatProto:
+	default:
+		return fmt.Errorf("unsupported format %q, must be %q or %q", cfg.Format, FormatJSON, FormatProto)
+	}
+	if cfg.Compression != "" && cfg.Compression != CompressionGzip {
+		return fmt.Errorf("unsupported compression %q, must be empty or %q", cfg.Compression, CompressionGzip)
+	}
+	switch cfg.Timing {
+	case TimingFast, TimingOriginal:
+	default:
+		return fmt.Errorf("unsupported timing %q, must be %q or %q", cfg.Timing, TimingFast, TimingOriginal)
+	}
+	switch cfg.StartAt {
+	case StartAtBeginning:
+	case StartAtEnd:
+		if cfg.Loop || cfg.IncludeRotated || cfg.Compression != "" {
+			return fmt.Errorf("loop, include_rotated and compression cannot be used with start_at %q", StartAtEnd)
+		}
+		if cfg.PollInterval <= 0 {
+			return errors.New("poll_interval must be positive")
+		}
+	default:
+		return fmt.Errorf("unsupported start_at %q, must be %q or %q", cfg.StartAt, StartAtBeginning, StartAtEnd)
+	}
+	return nil
+}
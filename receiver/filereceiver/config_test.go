@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	require.Len(t, cfg.Receivers, 3)
+
+	r0 := cfg.Receivers["file"]
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Path = "./filename.json"
+	assert.Equal(t, defaultCfg, r0)
+
+	r1 := cfg.Receivers["file/2"]
+	assert.Equal(t, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			NameVal: "file/2",
+			TypeVal: typeStr,
+		},
+		Path:           "./filename.json.gz",
+		Format:         FormatJSON,
+		Compression:    CompressionGzip,
+		IncludeRotated: true,
+		Timing:         TimingOriginal,
+		Loop:           true,
+		StartAt:        StartAtBeginning,
+		PollInterval:   defaultPollInterval,
+	}, r1)
+
+	r2 := cfg.Receivers["file/3"]
+	assert.Equal(t, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			NameVal: "file/3",
+			TypeVal: typeStr,
+		},
+		Path:         "./filename.json",
+		Format:       FormatJSON,
+		Timing:       TimingFast,
+		StartAt:      StartAtEnd,
+		PollInterval: time.Second,
+	}, r2)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "no_path",
+			modify: func(cfg *Config) { cfg.Path = "" },
+			err:    "path must be specified",
+		},
+		{
+			name:   "format",
+			modify: func(cfg *Config) { cfg.Format = "xml" },
+			err:    `unsupported format "xml", must be "json" or "proto"`,
+		},
+		{
+			name:   "compression",
+			modify: func(cfg *Config) { cfg.Compression = "zstd" },
+			err:    `unsupported compression "zstd", must be empty or "gzip"`,
+		},
+		{
+			name:   "timing",
+			modify: func(cfg *Config) { cfg.Timing = "slow" },
+			err:    `unsupported timing "slow", must be "fast" or "original"`,
+		},
+		{
+			name:   "start_at",
+			modify: func(cfg *Config) { cfg.StartAt = "middle" },
+			err:    `unsupported start_at "middle", must be "beginning" or "end"`,
+		},
+		{
+			name: "tail_loop",
+			modify: func(cfg *Config) {
+				cfg.StartAt = StartAtEnd
+				cfg.Loop = true
+			},
+			err: `loop, include_rotated and compression cannot be used with start_at "end"`,
+		},
+		{
+			name: "tail_poll_interval",
+			modify: func(cfg *Config) {
+				cfg.StartAt = StartAtEnd
+				cfg.PollInterval = 0
+			},
+			err: "poll_interval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = "./filename.json"
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "file"
+
+	defaultPollInterval = 200 * time.Millisecond
+)
+
+// NewFactory creates a factory for file receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithTraces(createTracesReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
+}
+
+func createDefaultConfig() configmodels.Receiver {
+	return &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Format:       FormatJSON,
+		Timing:       TimingFast,
+		StartAt:      StartAtBeginning,
+		PollInterval: defaultPollInterval,
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	r := createReceiver(cfg, params)
+	r.traces = nextConsumer
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	r := createReceiver(cfg, params)
+	r.metrics = nextConsumer
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	nextConsumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	r := createReceiver(cfg, params)
+	r.logs = nextConsumer
+	return r, nil
+}
+
+func createReceiver(config configmodels.Receiver, params component.ReceiverCreateParams) *fileReceiver {
+	cfg := config.(*Config)
+
+	// There must be one receiver for metrics, traces, and logs since the file
+	// exporter writes all signals to the same file. We maintain a map of
+	// receivers per config.
+
+	// Check to see if there is already a receiver for this config.
+	receiver, ok := receivers[cfg]
+	if !ok {
+		receiver = newFileReceiver(cfg, params.Logger)
+
+		// Remember the receiver in the map
+		receivers[cfg] = receiver
+	}
+	return receiver
+}
+
+// This is the map of already created file receivers for particular configurations.
+// We maintain this map because the Factory is asked trace, metric and log receivers
+// separately but they must not create separate objects, they must use one
+// fileReceiver object per configuration.
+var receivers = map[*Config]*fileReceiver{}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = "./filename.json"
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	tr, err := createTracesReceiver(context.Background(), params, cfg, consumertest.NewTracesNop())
+	assert.NoError(t, err)
+	mr, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewMetricsNop())
+	assert.NoError(t, err)
+	lr, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewLogsNop())
+	assert.NoError(t, err)
+
+	// All signals share the same receiver.
+	assert.Same(t, tr, mr)
+	assert.Same(t, tr, lr)
+	assert.NoError(t, tr.Shutdown(context.Background()))
+}
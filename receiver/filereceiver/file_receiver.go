@@ -0,0 +1,347 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const transport = "file"
+
+var errProtoMultipleSignals = errors.New("the proto format can only be used in pipelines of a single data type")
+
+// fileReceiver replays the requests written by the file exporter.
+type fileReceiver struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	traces  consumer.Traces
+	metrics consumer.Metrics
+	logs    consumer.Logs
+
+	startOnce sync.Once
+	startErr  error
+	stopOnce  sync.Once
+	cancel    context.CancelFunc
+	done      chan struct{}
+
+	// firstTimestamp and replayStart are used to replay the data respecting
+	// the original timestamps.
+	firstTimestamp pdata.Timestamp
+	replayStart    time.Time
+}
+
+var _ component.Receiver = (*fileReceiver)(nil)
+
+func newFileReceiver(cfg *Config, logger *zap.Logger) *fileReceiver {
+	return &fileReceiver{
+		cfg:    cfg,
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+}
+
+// Start starts replaying the file. It is safe to call Start once per signal,
+// the file is only replayed once.
+func (r *fileReceiver) Start(context.Context, component.Host) error {
+	r.startOnce.Do(func() {
+		var dec func(io.Reader) decoder
+		if dec, r.startErr = r.decoderFactory(); r.startErr != nil {
+			return
+		}
+		var ctx context.Context
+		ctx, r.cancel = context.WithCancel(context.Background())
+		go r.run(ctx, dec)
+	})
+	return r.startErr
+}
+
+// Shutdown stops replaying the file.
+func (r *fileReceiver) Shutdown(context.Context) error {
+	r.stopOnce.Do(func() {
+		if r.cancel != nil {
+			r.cancel()
+			<-r.done
+		}
+		delete(receivers, r.cfg)
+	})
+	return nil
+}
+
+func (r *fileReceiver) decoderFactory() (func(io.Reader) decoder, error) {
+	if r.cfg.Format == FormatJSON {
+		return func(rd io.Reader) decoder { return newJSONDecoder(rd) }, nil
+	}
+
+	var dataTypes []configmodels.DataType
+	if r.traces != nil {
+		dataTypes = append(dataTypes, configmodels.TracesDataType)
+	}
+	if r.metrics != nil {
+		dataTypes = append(dataTypes, configmodels.MetricsDataType)
+	}
+	if r.logs != nil {
+		dataTypes = append(dataTypes, configmodels.LogsDataType)
+	}
+	if len(dataTypes) != 1 {
+		return nil, errProtoMultipleSignals
+	}
+	return func(rd io.Reader) decoder { return &protoDecoder{reader: rd, dataType: dataTypes[0]} }, nil
+}
+
+func (r *fileReceiver) run(ctx context.Context, dec func(io.Reader) decoder) {
+	defer close(r.done)
+
+	if r.cfg.StartAt == StartAtEnd {
+		if err := r.tail(ctx, dec); err != nil {
+			r.logger.Error("Failed to tail file", zap.String("path", r.cfg.Path), zap.Error(err))
+		}
+		return
+	}
+
+	for {
+		r.firstTimestamp = 0
+		paths := []string{r.cfg.Path}
+		if r.cfg.IncludeRotated {
+			rotated, err := rotatedFiles(r.cfg.Path)
+			if err != nil {
+				r.logger.Error("Failed to list rotated files", zap.String("path", r.cfg.Path), zap.Error(err))
+			}
+			paths = append(rotated, paths...)
+		}
+		for _, path := range paths {
+			if err := r.replay(ctx, path, dec); err != nil {
+				r.logger.Error("Failed to replay file", zap.String("path", path), zap.Error(err))
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+		if !r.cfg.Loop {
+			r.logger.Info("Finished replaying file", zap.String("path", r.cfg.Path))
+			return
+		}
+	}
+}
+
+func (r *fileReceiver) replay(ctx context.Context, path string, dec func(io.Reader) decoder) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var rd io.Reader = file
+	if r.cfg.Compression == CompressionGzip {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		rd = gz
+	}
+	return r.consumeAll(ctx, dec(rd))
+}
+
+func (r *fileReceiver) tail(ctx context.Context, dec func(io.Reader) decoder) error {
+	file, err := os.Open(r.cfg.Path)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return err
+	}
+	tr := &tailReader{
+		ctx:          ctx,
+		path:         r.cfg.Path,
+		file:         file,
+		pollInterval: r.cfg.PollInterval,
+	}
+	defer tr.Close()
+	return r.consumeAll(ctx, dec(tr))
+}
+
+func (r *fileReceiver) consumeAll(ctx context.Context, d decoder) error {
+	for ctx.Err() == nil {
+		req, err := d.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		r.waitForTimestamp(ctx, requestTimestamp(req))
+		r.consume(ctx, req)
+	}
+	return nil
+}
+
+func (r *fileReceiver) consume(ctx context.Context, req request) {
+	ctx = obsreport.ReceiverContext(ctx, r.cfg.Name(), transport)
+	var err error
+	switch req.dataType {
+	case configmodels.TracesDataType:
+		if r.traces == nil {
+			return
+		}
+		ctx = obsreport.StartTraceDataReceiveOp(ctx, r.cfg.Name(), transport)
+		err = r.traces.ConsumeTraces(ctx, req.traces)
+		obsreport.EndTraceDataReceiveOp(ctx, r.cfg.Format, req.traces.SpanCount(), err)
+	case configmodels.MetricsDataType:
+		if r.metrics == nil {
+			return
+		}
+		ctx = obsreport.StartMetricsReceiveOp(ctx, r.cfg.Name(), transport)
+		_, numPoints := req.metrics.MetricAndDataPointCount()
+		err = r.metrics.ConsumeMetrics(ctx, req.metrics)
+		obsreport.EndMetricsReceiveOp(ctx, r.cfg.Format, numPoints, err)
+	case configmodels.LogsDataType:
+		if r.logs == nil {
+			return
+		}
+		ctx = obsreport.StartLogsReceiveOp(ctx, r.cfg.Name(), transport)
+		err = r.logs.ConsumeLogs(ctx, req.logs)
+		obsreport.EndLogsReceiveOp(ctx, r.cfg.Format, req.logs.LogRecordCount(), err)
+	}
+	if err != nil {
+		r.logger.Error("Failed to consume replayed data", zap.String("data_type", string(req.dataType)), zap.Error(err))
+	}
+}
+
+// waitForTimestamp waits until the time elapsed since the replay started
+// matches the time elapsed between the first replayed timestamp and ts.
+func (r *fileReceiver) waitForTimestamp(ctx context.Context, ts pdata.Timestamp) {
+	if r.cfg.Timing != TimingOriginal || ts == 0 {
+		return
+	}
+	if r.firstTimestamp == 0 {
+		r.firstTimestamp = ts
+		r.replayStart = time.Now()
+		return
+	}
+	delay := ts.AsTime().Sub(r.firstTimestamp.AsTime()) - time.Since(r.replayStart)
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// requestTimestamp returns the earliest timestamp of the request, or 0 if the
+// request doesn't contain any timestamp.
+func requestTimestamp(req request) pdata.Timestamp {
+	var min pdata.Timestamp
+	update := func(ts pdata.Timestamp) {
+		if ts != 0 && (min == 0 || ts < min) {
+			min = ts
+		}
+	}
+
+	switch req.dataType {
+	case configmodels.TracesDataType:
+		rss := req.traces.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			ilss := rss.At(i).InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					update(spans.At(k).StartTime())
+				}
+			}
+		}
+	case configmodels.MetricsDataType:
+		rms := req.metrics.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			ilms := rms.At(i).InstrumentationLibraryMetrics()
+			for j := 0; j < ilms.Len(); j++ {
+				metrics := ilms.At(j).Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					metricTimestamps(metrics.At(k), update)
+				}
+			}
+		}
+	case configmodels.LogsDataType:
+		rls := req.logs.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				logs := ills.At(j).Logs()
+				for k := 0; k < logs.Len(); k++ {
+					update(logs.At(k).Timestamp())
+				}
+			}
+		}
+	}
+	return min
+}
+
+func metricTimestamps(metric pdata.Metric, fn func(pdata.Timestamp)) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Timestamp())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Timestamp())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Timestamp())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Timestamp())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Timestamp())
+		}
+	case pdata.MetricDataTypeDoubleHistogram:
+		dps := metric.DoubleHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Timestamp())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Timestamp())
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/fileexporter"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func newTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "filereceiver")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// writeFile writes the given data using the file exporter.
+func writeFile(t *testing.T, cfg *fileexporter.Config, data ...interface{}) {
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	factory := fileexporter.NewFactory()
+	te, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	me, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	le, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+
+	for _, d := range data {
+		switch v := d.(type) {
+		case pdata.Traces:
+			require.NoError(t, te.ConsumeTraces(context.Background(), v))
+		case pdata.Metrics:
+			require.NoError(t, me.ConsumeMetrics(context.Background(), v))
+		case pdata.Logs:
+			require.NoError(t, le.ConsumeLogs(context.Background(), v))
+		}
+	}
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func newExporterConfig(path string) *fileexporter.Config {
+	return &fileexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{TypeVal: "file", NameVal: "file"},
+		Path:             path,
+		Format:           fileexporter.FormatJSON,
+	}
+}
+
+func TestReplayJSON(t *testing.T) {
+	path := filepath.Join(newTempDir(t), "data.json")
+	writeFile(t, newExporterConfig(path),
+		testdata.GenerateTraceDataTwoSpansSameResource(),
+		testdata.GenerateMetricsTwoMetrics(),
+		testdata.GenerateLogDataOneLog(),
+		testdata.GenerateTraceDataOneSpan())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = path
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	tracesSink := new(consumertest.TracesSink)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	tr, err := createTracesReceiver(context.Background(), params, cfg, tracesSink)
+	require.NoError(t, err)
+	mr, err := createMetricsReceiver(context.Background(), params, cfg, metricsSink)
+	require.NoError(t, err)
+	lr, err := createLogsReceiver(context.Background(), params, cfg, logsSink)
+	require.NoError(t, err)
+
+	require.NoError(t, tr.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, mr.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, lr.Start(context.Background(), componenttest.NewNopHost()))
+	<-tr.(*fileReceiver).done
+	require.NoError(t, tr.Shutdown(context.Background()))
+
+	require.Len(t, tracesSink.AllTraces(), 2)
+	assert.Equal(t, testdata.GenerateTraceDataTwoSpansSameResource(), tracesSink.AllTraces()[0])
+	assert.Equal(t, testdata.GenerateTraceDataOneSpan(), tracesSink.AllTraces()[1])
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Equal(t, testdata.GenerateMetricsTwoMetrics(), metricsSink.AllMetrics()[0])
+	require.Len(t, logsSink.AllLogs(), 1)
+	assert.Equal(t, testdata.GenerateLogDataOneLog(), logsSink.AllLogs()[0])
+}
+
+func TestReplayProtoGzipRotated(t *testing.T) {
+	dir := newTempDir(t)
+	path := filepath.Join(dir, "data.pb.gz")
+	expCfg := newExporterConfig(path)
+	expCfg.Format = fileexporter.FormatProto
+	expCfg.Compression = fileexporter.CompressionGzip
+	writeFile(t, expCfg, testdata.GenerateTraceDataOneSpan())
+	// Simulate a rotation done by the file exporter.
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "data.pb-2021-03-01T10-00-00.000000000.gz")))
+	writeFile(t, newExporterConfigLike(expCfg), testdata.GenerateTraceDataTwoSpansSameResource())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = path
+	cfg.Format = FormatProto
+	cfg.Compression = CompressionGzip
+	cfg.IncludeRotated = true
+	sink := new(consumertest.TracesSink)
+	tr, err := createTracesReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tr.Start(context.Background(), componenttest.NewNopHost()))
+	<-tr.(*fileReceiver).done
+	require.NoError(t, tr.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, testdata.GenerateTraceDataOneSpan(), sink.AllTraces()[0])
+	assert.Equal(t, testdata.GenerateTraceDataTwoSpansSameResource(), sink.AllTraces()[1])
+}
+
+func TestReplayProtoMultipleSignals(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(newTempDir(t), "data.pb")
+	cfg.Format = FormatProto
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	tr, err := createTracesReceiver(context.Background(), params, cfg, consumertest.NewTracesNop())
+	require.NoError(t, err)
+	_, err = createLogsReceiver(context.Background(), params, cfg, consumertest.NewLogsNop())
+	require.NoError(t, err)
+
+	assert.Equal(t, errProtoMultipleSignals, tr.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, tr.Shutdown(context.Background()))
+}
+
+func TestTail(t *testing.T) {
+	path := filepath.Join(newTempDir(t), "data.json")
+	writeFile(t, newExporterConfig(path), testdata.GenerateTraceDataOneSpan())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = path
+	cfg.StartAt = StartAtEnd
+	cfg.PollInterval = 10 * time.Millisecond
+	sink := new(consumertest.TracesSink)
+	tr, err := createTracesReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, tr.Shutdown(context.Background()))
+	}()
+
+	// Give the receiver time to seek to the end of the existing file, then
+	// rotate the file and write new data.
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, os.Rename(path, path+".old"))
+	writeFile(t, newExporterConfig(path), testdata.GenerateTraceDataTwoSpansSameResource())
+
+	require.Eventually(t, func() bool {
+		return sink.SpansCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, testdata.GenerateTraceDataTwoSpansSameResource(), sink.AllTraces()[0])
+}
+
+func TestRequestTimestamp(t *testing.T) {
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	spans := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	spans.At(0).SetStartTime(200)
+	spans.At(1).SetStartTime(100)
+	assert.Equal(t, pdata.Timestamp(100), requestTimestamp(request{dataType: configmodels.TracesDataType, traces: td}))
+
+	md := testdata.GenerateMetricsOneMetric()
+	assert.NotEqual(t, pdata.Timestamp(0), requestTimestamp(request{dataType: configmodels.MetricsDataType, metrics: md}))
+
+	ld := testdata.GenerateLogDataOneLog()
+	assert.Equal(t, ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Timestamp(),
+		requestTimestamp(request{dataType: configmodels.LogsDataType, logs: ld}))
+
+	assert.Equal(t, pdata.Timestamp(0), requestTimestamp(request{dataType: configmodels.TracesDataType, traces: testdata.GenerateTraceDataEmpty()}))
+}
+
+func newExporterConfigLike(cfg *fileexporter.Config) *fileexporter.Config {
+	c := *cfg
+	return &c
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal"
+	otlpcollectorlog "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
+)
+
+// backupTimeFormat is the layout of the timestamp the file exporter adds to
+// the name of rotated files.
+const backupTimeFormat = "2006-01-02T15-04-05.000000000"
+
+var errTruncatedMessage = errors.New("truncated message")
+
+// request is one decoded request read from the file. Only the field matching
+// dataType is set.
+type request struct {
+	dataType configmodels.DataType
+	traces   pdata.Traces
+	metrics  pdata.Metrics
+	logs     pdata.Logs
+}
+
+// decoder reads requests from a file.
+type decoder interface {
+	// next returns the next request, or io.EOF if there are no more requests.
+	next() (request, error)
+}
+
+// jsonDecoder decodes requests written as one Protobuf JSON message per line.
+// The type of every request is detected from its top level field.
+type jsonDecoder struct {
+	reader      *bufio.Reader
+	unmarshaler *jsonpb.Unmarshaler
+}
+
+func newJSONDecoder(r io.Reader) *jsonDecoder {
+	return &jsonDecoder{
+		reader:      bufio.NewReader(r),
+		unmarshaler: &jsonpb.Unmarshaler{AllowUnknownFields: true},
+	}
+}
+
+func (d *jsonDecoder) next() (request, error) {
+	for {
+		line, err := d.reader.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			return request{}, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		return d.decode(line)
+	}
+}
+
+func (d *jsonDecoder) decode(line []byte) (request, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return request{}, err
+	}
+	switch {
+	case fields["resourceSpans"] != nil:
+		req := &otlpcollectortrace.ExportTraceServiceRequest{}
+		if err := d.unmarshaler.Unmarshal(bytes.NewReader(line), req); err != nil {
+			return request{}, err
+		}
+		return request{dataType: configmodels.TracesDataType, traces: pdata.TracesFromInternalRep(internal.TracesFromOtlp(req))}, nil
+	case fields["resourceMetrics"] != nil:
+		req := &otlpcollectormetrics.ExportMetricsServiceRequest{}
+		if err := d.unmarshaler.Unmarshal(bytes.NewReader(line), req); err != nil {
+			return request{}, err
+		}
+		return request{dataType: configmodels.MetricsDataType, metrics: pdata.MetricsFromInternalRep(internal.MetricsFromOtlp(req))}, nil
+	case fields["resourceLogs"] != nil:
+		req := &otlpcollectorlog.ExportLogsServiceRequest{}
+		if err := d.unmarshaler.Unmarshal(bytes.NewReader(line), req); err != nil {
+			return request{}, err
+		}
+		return request{dataType: configmodels.LogsDataType, logs: pdata.LogsFromInternalRep(internal.LogsFromOtlp(req))}, nil
+	}
+	return request{}, errors.New("unknown request type")
+}
+
+// protoDecoder decodes length-prefixed binary Protobuf requests. The binary
+// encoding of the different request types cannot be distinguished, so all the
+// requests are decoded as dataType.
+type protoDecoder struct {
+	reader   io.Reader
+	dataType configmodels.DataType
+}
+
+func (d *protoDecoder) next() (request, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(d.reader, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return request{}, errTruncatedMessage
+		}
+		return request{}, err
+	}
+	buf := make([]byte, binary.BigEndian.Uint32(header[:]))
+	if _, err := io.ReadFull(d.reader, buf); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return request{}, errTruncatedMessage
+		}
+		return request{}, err
+	}
+
+	switch d.dataType {
+	case configmodels.TracesDataType:
+		req := &otlpcollectortrace.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(buf, req); err != nil {
+			return request{}, err
+		}
+		return request{dataType: d.dataType, traces: pdata.TracesFromInternalRep(internal.TracesFromOtlp(req))}, nil
+	case configmodels.MetricsDataType:
+		req := &otlpcollectormetrics.ExportMetricsServiceRequest{}
+		if err := proto.Unmarshal(buf, req); err != nil {
+			return request{}, err
+		}
+		return request{dataType: d.dataType, metrics: pdata.MetricsFromInternalRep(internal.MetricsFromOtlp(req))}, nil
+	case configmodels.LogsDataType:
+		req := &otlpcollectorlog.ExportLogsServiceRequest{}
+		if err := proto.Unmarshal(buf, req); err != nil {
+			return request{}, err
+		}
+		return request{dataType: d.dataType, logs: pdata.LogsFromInternalRep(internal.LogsFromOtlp(req))}, nil
+	}
+	return request{}, fmt.Errorf("unsupported data type %q", d.dataType)
+}
+
+// tailReader is an io.Reader that waits for new data to be written to the file
+// instead of returning io.EOF. If the file is rotated, the reader switches to
+// the new file once the rotated one is fully read. io.EOF is only returned
+// once the context is done.
+type tailReader struct {
+	ctx          context.Context
+	path         string
+	file         *os.File
+	pollInterval time.Duration
+}
+
+func (t *tailReader) Read(p []byte) (int, error) {
+	for {
+		n, err := t.file.Read(p)
+		if n > 0 || (err != nil && err != io.EOF) {
+			return n, err
+		}
+		rotated, err := t.rotated()
+		if err != nil {
+			return 0, err
+		}
+		if rotated {
+			// Data may have been written to the rotated file after the last
+			// read but before it was rotated.
+			if n, err = t.file.Read(p); n > 0 || (err != nil && err != io.EOF) {
+				return n, err
+			}
+			file, err := os.Open(t.path)
+			if err != nil {
+				return 0, err
+			}
+			t.file.Close()
+			t.file = file
+			continue
+		}
+		select {
+		case <-t.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(t.pollInterval):
+		}
+	}
+}
+
+// rotated returns true if the file at path is not the file being read anymore.
+func (t *tailReader) rotated() (bool, error) {
+	current, err := t.file.Stat()
+	if err != nil {
+		return false, err
+	}
+	latest, err := os.Stat(t.path)
+	if err != nil {
+		// The file is being rotated, wait for the new one to be created.
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !os.SameFile(current, latest), nil
+}
+
+func (t *tailReader) Close() error {
+	return t.file.Close()
+}
+
+// rotatedFiles returns the files rotated by the file exporter for the given
+// path, ordered from the oldest to the newest.
+func rotatedFiles(path string) ([]string, error) {
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(path, ext) + "-"
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, m := range matches {
+		ts := strings.TrimSuffix(strings.TrimPrefix(m, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, ts); err == nil {
+			files = append(files, m)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
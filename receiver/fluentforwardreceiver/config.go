@@ -15,7 +15,10 @@
 package fluentforwardreceiver
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 // Config defines configuration for the SignalFx receiver.
@@ -26,4 +29,41 @@ type Config struct {
 	// of the form `<ip addr>:<port>` (TCP) or `unix://<socket_path>` (Unix
 	// domain socket).
 	ListenAddress string `mapstructure:"endpoint"`
+
+	// TLSSetting enables TLS on the TCP or Unix domain socket listener. The
+	// heartbeats are still received over UDP without TLS.
+	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls_settings,omitempty"`
+
+	// Security enables the handshake of the forward protocol, the clients are
+	// required to authenticate with the shared key before sending events.
+	Security *SecuritySettings `mapstructure:"security,omitempty"`
+}
+
+// SecuritySettings configures the shared key authentication of the handshake
+// of the forward protocol.
+type SecuritySettings struct {
+	// SelfHostname is the hostname of the receiver sent to the clients in the
+	// PONG message of the handshake.
+	SelfHostname string `mapstructure:"self_hostname"`
+
+	// SharedKey is the key shared by the receiver and the clients, used to
+	// authenticate each other.
+	SharedKey string `mapstructure:"shared_key"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks that the shared key and the hostname are set when the
+// handshake is enabled.
+func (cfg *Config) Validate() error {
+	if cfg.Security == nil {
+		return nil
+	}
+	if cfg.Security.SharedKey == "" {
+		return errors.New("\"security::shared_key\" is required when the security is enabled")
+	}
+	if cfg.Security.SelfHostname == "" {
+		return errors.New("\"security::self_hostname\" is required when the security is enabled")
+	}
+	return nil
 }
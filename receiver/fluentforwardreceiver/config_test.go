@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -39,9 +40,65 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 1)
+	assert.Equal(t, len(cfg.Receivers), 2)
 
 	r0 := cfg.Receivers["fluentforward"]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
 
+	r1 := cfg.Receivers["fluentforward/secure"]
+	assert.Equal(t, r1, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: "fluentforward/secure",
+		},
+		ListenAddress: "0.0.0.0:24224",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "/etc/collector/server.crt",
+				KeyFile:  "/etc/collector/server.key",
+			},
+		},
+		Security: &SecuritySettings{
+			SelfHostname: "collector",
+			SharedKey:    "secret",
+		},
+	})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		security *SecuritySettings
+		err      string
+	}{
+		{
+			name: "no security",
+		},
+		{
+			name:     "security",
+			security: &SecuritySettings{SelfHostname: "collector", SharedKey: "secret"},
+		},
+		{
+			name:     "no shared key",
+			security: &SecuritySettings{SelfHostname: "collector"},
+			err:      "\"security::shared_key\" is required when the security is enabled",
+		},
+		{
+			name:     "no hostname",
+			security: &SecuritySettings{SharedKey: "secret"},
+			err:      "\"security::self_hostname\" is required when the security is enabled",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Security = test.security
+			err := cfg.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
 }
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// The time given to the clients to complete the handshake once connected.
+const handshakeTimeout = 10 * time.Second
+
+var errSharedKeyMismatch = errors.New("shared key mismatch")
+
+// PingMessage is the message sent by the clients in response to the HELO
+// message of the handshake.
+type PingMessage struct {
+	ClientHostname     string
+	SharedKeySalt      []byte
+	SharedKeyHexDigest string
+}
+
+func (pm *PingMessage) DecodeMsg(dc *msgp.Reader) error {
+	arrLen, err := dc.ReadArrayHeader()
+	if err != nil {
+		return msgp.WrapError(err)
+	}
+	// The username and the password digest of the user authentication are
+	// not supported and ignored if sent.
+	if arrLen < 4 {
+		return msgp.ArrayError{Wanted: 4, Got: arrLen}
+	}
+
+	msgType, err := dc.ReadString()
+	if err != nil {
+		return msgp.WrapError(err, "Type")
+	}
+	if msgType != "PING" {
+		return msgp.WrapError(fmt.Errorf("unexpected message %q", msgType), "Type")
+	}
+
+	pm.ClientHostname, err = dc.ReadString()
+	if err != nil {
+		return msgp.WrapError(err, "ClientHostname")
+	}
+
+	pm.SharedKeySalt, err = readStrOrBin(dc)
+	if err != nil {
+		return msgp.WrapError(err, "SharedKeySalt")
+	}
+
+	pm.SharedKeyHexDigest, err = dc.ReadString()
+	if err != nil {
+		return msgp.WrapError(err, "SharedKeyHexDigest")
+	}
+
+	for i := uint32(4); i < arrLen; i++ {
+		if err = dc.Skip(); err != nil {
+			return msgp.WrapError(err)
+		}
+	}
+	return nil
+}
+
+// handshake authenticates a newly connected client with the shared key, as
+// described in
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1#handshake-messages.
+// The connection must be closed if an error is returned.
+func (s *server) handshake(conn net.Conn, reader *msgp.Reader) error {
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	writer := msgp.NewWriter(conn)
+	if err := writeHelo(writer, nonce); err != nil {
+		return fmt.Errorf("failed to send HELO: %v", err)
+	}
+
+	var ping PingMessage
+	if err := ping.DecodeMsg(reader); err != nil {
+		return fmt.Errorf("failed to parse PING: %v", err)
+	}
+
+	sharedKey := s.security.SharedKey
+	expected := sharedKeyHexDigest(ping.SharedKeySalt, ping.ClientHostname, nonce, sharedKey)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(ping.SharedKeyHexDigest)) != 1 {
+		if err := writePong(writer, false, errSharedKeyMismatch.Error(), "", ""); err != nil {
+			return fmt.Errorf("failed to send PONG: %v", err)
+		}
+		return errSharedKeyMismatch
+	}
+
+	selfHostname := s.security.SelfHostname
+	digest := sharedKeyHexDigest(ping.SharedKeySalt, selfHostname, nonce, sharedKey)
+	if err := writePong(writer, true, "", selfHostname, digest); err != nil {
+		return fmt.Errorf("failed to send PONG: %v", err)
+	}
+
+	return conn.SetDeadline(time.Time{})
+}
+
+// writeHelo sends ["HELO", {"nonce": nonce, "auth": "", "keepalive": true}],
+// the empty auth salt meaning that the user authentication is not required.
+func writeHelo(writer *msgp.Writer, nonce []byte) error {
+	if err := writer.WriteArrayHeader(2); err != nil {
+		return err
+	}
+	if err := writer.WriteString("HELO"); err != nil {
+		return err
+	}
+	if err := writer.WriteMapHeader(3); err != nil {
+		return err
+	}
+	if err := writer.WriteString("nonce"); err != nil {
+		return err
+	}
+	if err := writer.WriteBytes(nonce); err != nil {
+		return err
+	}
+	if err := writer.WriteString("auth"); err != nil {
+		return err
+	}
+	if err := writer.WriteString(""); err != nil {
+		return err
+	}
+	if err := writer.WriteString("keepalive"); err != nil {
+		return err
+	}
+	if err := writer.WriteBool(true); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// writePong sends ["PONG", authResult, reason, selfHostname, sharedKeyHexDigest].
+func writePong(writer *msgp.Writer, authResult bool, reason string, selfHostname string, digest string) error {
+	if err := writer.WriteArrayHeader(5); err != nil {
+		return err
+	}
+	if err := writer.WriteString("PONG"); err != nil {
+		return err
+	}
+	if err := writer.WriteBool(authResult); err != nil {
+		return err
+	}
+	if err := writer.WriteString(reason); err != nil {
+		return err
+	}
+	if err := writer.WriteString(selfHostname); err != nil {
+		return err
+	}
+	if err := writer.WriteString(digest); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// sharedKeyHexDigest returns the hex encoded SHA-512 of the salt, the hostname,
+// the nonce and the shared key.
+func sharedKeyHexDigest(salt []byte, hostname string, nonce []byte, sharedKey string) string {
+	h := sha512.New()
+	h.Write(salt)
+	h.Write([]byte(hostname))
+	h.Write(nonce)
+	h.Write([]byte(sharedKey))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// readStrOrBin reads a string or a binary, the clients encoding the random
+// bytes either way.
+func readStrOrBin(dc *msgp.Reader) ([]byte, error) {
+	nextType, err := dc.NextType()
+	if err != nil {
+		return nil, err
+	}
+	switch nextType {
+	case msgp.StrType:
+		s, err := dc.ReadString()
+		return []byte(s), err
+	case msgp.BinType:
+		return dc.ReadBytes(nil)
+	default:
+		return nil, fmt.Errorf("invalid type %d", nextType)
+	}
+}
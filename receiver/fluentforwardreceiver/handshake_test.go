@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func dialTCP(addr string) (net.Conn, error) {
+	return net.Dial("tcp", addr)
+}
+
+// clientHandshake performs the client side of the handshake and returns the
+// PONG message.
+func clientHandshake(t *testing.T, conn net.Conn, hostname string, sharedKey string) []interface{} {
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	reader := msgp.NewReader(conn)
+
+	helo, err := reader.ReadIntf()
+	require.NoError(t, err)
+	heloArr, ok := helo.([]interface{})
+	require.True(t, ok)
+	require.Len(t, heloArr, 2)
+	assert.Equal(t, "HELO", heloArr[0])
+	options, ok := heloArr[1].(map[string]interface{})
+	require.True(t, ok)
+	nonce, ok := options["nonce"].([]byte)
+	require.True(t, ok)
+	assert.Len(t, nonce, 16)
+	assert.Equal(t, "", options["auth"])
+	assert.Equal(t, true, options["keepalive"])
+
+	salt := []byte("0123456789abcdef")
+	var ping []byte
+	ping = msgp.AppendArrayHeader(ping, 6)
+	ping = msgp.AppendString(ping, "PING")
+	ping = msgp.AppendString(ping, hostname)
+	ping = msgp.AppendBytes(ping, salt)
+	ping = msgp.AppendString(ping, sharedKeyHexDigest(salt, hostname, nonce, sharedKey))
+	ping = msgp.AppendString(ping, "")
+	ping = msgp.AppendString(ping, "")
+	_, err = conn.Write(ping)
+	require.NoError(t, err)
+
+	pong, err := reader.ReadIntf()
+	require.NoError(t, err)
+	pongArr, ok := pong.([]interface{})
+	require.True(t, ok)
+	require.Len(t, pongArr, 5)
+	assert.Equal(t, "PONG", pongArr[0])
+	if pongArr[1] == true {
+		assert.Equal(t, sharedKeyHexDigest(salt, "collector", nonce, sharedKey), pongArr[4])
+	}
+	require.NoError(t, conn.SetDeadline(time.Time{}))
+	return pongArr
+}
+
+func securedConfig() *Config {
+	return &Config{
+		ListenAddress: "127.0.0.1:0",
+		Security: &SecuritySettings{
+			SelfHostname: "collector",
+			SharedKey:    "secret",
+		},
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	connect, next, _, cancel := setupServerWithConfig(t, securedConfig(), dialTCP)
+	defer cancel()
+
+	conn := connect()
+	pong := clientHandshake(t, conn, "client", "secret")
+	assert.Equal(t, []interface{}{"PONG", true, "", "collector", pong[4]}, pong)
+
+	eventBytes := makeSampleEvent("tag")
+	_, err := conn.Write(eventBytes)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool {
+		return next.LogRecordsCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHandshakeSharedKeyMismatch(t *testing.T) {
+	connect, next, observedLogs, cancel := setupServerWithConfig(t, securedConfig(), dialTCP)
+	defer cancel()
+
+	conn := connect()
+	pong := clientHandshake(t, conn, "client", "wrong")
+	assert.Equal(t, []interface{}{"PONG", false, errSharedKeyMismatch.Error(), "", ""}, pong)
+
+	waitForConnectionClose(t, conn)
+	assert.Len(t, observedLogs.FilterMessageSnippet("Unexpected").All(), 1)
+	assert.Equal(t, 0, next.LogRecordsCount())
+}
+
+func TestHandshakeRequired(t *testing.T) {
+	connect, next, _, cancel := setupServerWithConfig(t, securedConfig(), dialTCP)
+	defer cancel()
+
+	// The events sent without completing the handshake are rejected.
+	conn := connect()
+	_, err := conn.Write(makeSampleEvent("tag"))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	reader := msgp.NewReader(conn)
+	helo, err := reader.ReadIntf()
+	require.NoError(t, err)
+	assert.Equal(t, "HELO", helo.([]interface{})[0])
+	waitForConnectionClose(t, conn)
+	assert.Equal(t, 0, next.LogRecordsCount())
+}
+
+func TestTLS(t *testing.T) {
+	conf := securedConfig()
+	conf.TLSSetting = &configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: "../../config/configtls/testdata/test-cert.pem",
+			KeyFile:  "../../config/configtls/testdata/test-key.pem",
+		},
+	}
+	connect, next, _, cancel := setupServerWithConfig(t, conf, func(addr string) (net.Conn, error) {
+		return tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+	})
+	defer cancel()
+
+	conn := connect()
+	clientHandshake(t, conn, "client", "secret")
+	_, err := conn.Write(makeSampleEvent("tag"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool {
+		return next.LogRecordsCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTLSInvalidSettings(t *testing.T) {
+	conf := &Config{
+		ListenAddress: "127.0.0.1:0",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "/non/existent",
+				KeyFile:  "/non/existent",
+			},
+		},
+	}
+	next := new(consumertest.LogsSink)
+	receiver, err := newFluentReceiver(zap.NewNop(), conf, next)
+	require.NoError(t, err)
+	assert.Error(t, receiver.Start(context.Background(), nil))
+}
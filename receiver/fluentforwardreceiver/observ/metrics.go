@@ -66,6 +66,17 @@ var (
 		Aggregation: view.Sum(),
 	}
 
+	FailedHandshakes = stats.Int64(
+		"fluent_handshake_failures",
+		"Number of times Fluent clients failed to complete the handshake",
+		stats.UnitDimensionless)
+	failedHandshakesView = &view.View{
+		Name:        FailedHandshakes.Name(),
+		Measure:     FailedHandshakes,
+		Description: FailedHandshakes.Description(),
+		Aggregation: view.Sum(),
+	}
+
 	RecordsGenerated = stats.Int64(
 		"fluent_records_generated",
 		"Number of log records generated from Fluent forward input",
@@ -84,6 +95,7 @@ func MetricViews() []*view.View {
 		connectionsClosedView,
 		eventsParsedView,
 		failedToParseView,
+		failedHandshakesView,
 		recordsGeneratedView,
 	}
 }
@@ -21,5 +21,5 @@ import (
 )
 
 func TestViews(t *testing.T) {
-	require.Equal(t, len(MetricViews()), 5)
+	require.Equal(t, len(MetricViews()), 6)
 }
@@ -16,6 +16,7 @@ package fluentforwardreceiver
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"strings"
 
@@ -43,7 +44,7 @@ func newFluentReceiver(logger *zap.Logger, conf *Config, next consumer.Logs) (co
 
 	collector := newCollector(eventCh, next, logger)
 
-	server := newServer(eventCh, logger)
+	server := newServer(eventCh, logger, conf.Security)
 
 	return &fluentReceiver{
 		collector: collector,
@@ -77,6 +78,18 @@ func (r *fluentReceiver) Start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
+	if r.conf.TLSSetting != nil {
+		tlsCfg, err := r.conf.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			listener.Close()
+			if udpListener != nil {
+				udpListener.Close()
+			}
+			return err
+		}
+		listener = tls.NewListener(listener, tlsCfg)
+	}
+
 	r.listener = listener
 
 	r.server.Start(receiverCtx, listener)
@@ -37,22 +37,27 @@ import (
 )
 
 func setupServer(t *testing.T) (func() net.Conn, *consumertest.LogsSink, *observer.ObservedLogs, context.CancelFunc) {
+	conf := &Config{
+		ListenAddress: "127.0.0.1:0",
+	}
+	return setupServerWithConfig(t, conf, func(addr string) (net.Conn, error) {
+		return net.Dial("tcp", addr)
+	})
+}
+
+func setupServerWithConfig(t *testing.T, conf *Config, dial func(addr string) (net.Conn, error)) (func() net.Conn, *consumertest.LogsSink, *observer.ObservedLogs, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	next := new(consumertest.LogsSink)
 	logCore, logObserver := observer.New(zap.DebugLevel)
 	logger := zap.New(logCore)
 
-	conf := &Config{
-		ListenAddress: "127.0.0.1:0",
-	}
-
 	receiver, err := newFluentReceiver(logger, conf, next)
 	require.NoError(t, err)
 	require.NoError(t, receiver.Start(ctx, nil))
 
 	connect := func() net.Conn {
-		conn, err := net.Dial("tcp", receiver.(*fluentReceiver).listener.Addr().String())
+		conn, err := dial(receiver.(*fluentReceiver).listener.Addr().String())
 		require.Nil(t, err)
 		return conn
 	}
@@ -35,14 +35,16 @@ import (
 const readBufferSize = 10 * 1024
 
 type server struct {
-	outCh  chan<- Event
-	logger *zap.Logger
+	outCh    chan<- Event
+	logger   *zap.Logger
+	security *SecuritySettings
 }
 
-func newServer(outCh chan<- Event, logger *zap.Logger) *server {
+func newServer(outCh chan<- Event, logger *zap.Logger, security *SecuritySettings) *server {
 	return &server{
-		outCh:  outCh,
-		logger: logger,
+		outCh:    outCh,
+		logger:   logger,
+		security: security,
 	}
 }
 
@@ -91,6 +93,13 @@ func (s *server) handleConnections(ctx context.Context, listener net.Listener) {
 func (s *server) handleConn(ctx context.Context, conn net.Conn) error {
 	reader := msgp.NewReaderSize(conn, readBufferSize)
 
+	if s.security != nil {
+		if err := s.handshake(conn, reader); err != nil {
+			stats.Record(ctx, observ.FailedHandshakes.M(1))
+			return fmt.Errorf("handshake failed: %v", err)
+		}
+	}
+
 	for {
 		mode, err := DetermineNextEventMode(reader.R)
 		if err != nil {
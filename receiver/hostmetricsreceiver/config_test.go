@@ -30,12 +30,15 @@ import (
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/loadscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/powerscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/temperaturescraper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
@@ -61,6 +64,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, defaultConfigCPUScraper, r0)
 
 	r1 := cfg.Receivers["hostmetrics/customname"].(*Config)
+	disabled := false
 	expectedConfig := &Config{
 		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
 			ReceiverSettings: configmodels.ReceiverSettings{
@@ -70,19 +74,37 @@ func TestLoadConfig(t *testing.T) {
 			CollectionInterval: 30 * time.Second,
 		},
 		Scrapers: map[string]internal.Config{
-			cpuscraper.TypeStr:        &cpuscraper.Config{},
-			diskscraper.TypeStr:       &diskscraper.Config{},
-			loadscraper.TypeStr:       &loadscraper.Config{},
+			cpuscraper.TypeStr:  &cpuscraper.Config{},
+			diskscraper.TypeStr: &diskscraper.Config{},
+			loadscraper.TypeStr: &loadscraper.Config{
+				ConfigSettings: internal.ConfigSettings{
+					Metrics: map[string]internal.MetricSettings{
+						"system.cpu.load_average.15m": {Enabled: &disabled},
+					},
+				},
+			},
 			filesystemscraper.TypeStr: &filesystemscraper.Config{},
-			memoryscraper.TypeStr:     &memoryscraper.Config{},
+			memoryscraper.TypeStr: &memoryscraper.Config{
+				ConfigSettings: internal.ConfigSettings{
+					Metrics: map[string]internal.MetricSettings{
+						"system.memory.usage": {
+							Name:   "system.memory.used",
+							Labels: map[string]string{"state": "memory_state"},
+						},
+					},
+				},
+			},
 			networkscraper.TypeStr: &networkscraper.Config{
 				Include: networkscraper.MatchConfig{
 					Interfaces: []string{"test1"},
 					Config:     filterset.Config{MatchType: "strict"},
 				},
 			},
-			processesscraper.TypeStr: &processesscraper.Config{},
-			pagingscraper.TypeStr:    &pagingscraper.Config{},
+			processesscraper.TypeStr:   &processesscraper.Config{},
+			pagingscraper.TypeStr:      &pagingscraper.Config{},
+			temperaturescraper.TypeStr: &temperaturescraper.Config{},
+			powerscraper.TypeStr:       &powerscraper.Config{},
+			gpuscraper.TypeStr:         &gpuscraper.Config{},
 			processscraper.TypeStr: &processscraper.Config{
 				Include: processscraper.MatchConfig{
 					Names:  []string{"test2", "test3"},
@@ -30,12 +30,15 @@ import (
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/gpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/loadscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/powerscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/temperaturescraper"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
@@ -50,14 +53,17 @@ const (
 
 var (
 	scraperFactories = map[string]internal.ScraperFactory{
-		cpuscraper.TypeStr:        &cpuscraper.Factory{},
-		diskscraper.TypeStr:       &diskscraper.Factory{},
-		loadscraper.TypeStr:       &loadscraper.Factory{},
-		filesystemscraper.TypeStr: &filesystemscraper.Factory{},
-		memoryscraper.TypeStr:     &memoryscraper.Factory{},
-		networkscraper.TypeStr:    &networkscraper.Factory{},
-		pagingscraper.TypeStr:     &pagingscraper.Factory{},
-		processesscraper.TypeStr:  &processesscraper.Factory{},
+		cpuscraper.TypeStr:         &cpuscraper.Factory{},
+		diskscraper.TypeStr:        &diskscraper.Factory{},
+		loadscraper.TypeStr:        &loadscraper.Factory{},
+		filesystemscraper.TypeStr:  &filesystemscraper.Factory{},
+		memoryscraper.TypeStr:      &memoryscraper.Factory{},
+		networkscraper.TypeStr:     &networkscraper.Factory{},
+		pagingscraper.TypeStr:      &pagingscraper.Factory{},
+		processesscraper.TypeStr:   &processesscraper.Factory{},
+		temperaturescraper.TypeStr: &temperaturescraper.Factory{},
+		powerscraper.TypeStr:       &powerscraper.Factory{},
+		gpuscraper.TypeStr:         &gpuscraper.Factory{},
 	}
 
 	resourceScraperFactories = map[string]internal.ResourceScraperFactory{
@@ -172,12 +178,18 @@ func createAddScraperOptions(
 	scraperControllerOptions := make([]scraperhelper.ScraperControllerOption, 0, len(config.Scrapers))
 
 	for key, cfg := range config.Scrapers {
+		metricsSettings := cfg.MetricsSettings()
+		if err := internal.ValidateMetricsSettings(metricsSettings); err != nil {
+			return nil, fmt.Errorf("invalid metrics settings for key %q: %w", key, err)
+		}
+
 		hostMetricsScraper, ok, err := createHostMetricsScraper(ctx, logger, key, cfg, factories)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create scraper for key %q: %w", key, err)
 		}
 
 		if ok {
+			hostMetricsScraper = internal.WithMetricsSettings(hostMetricsScraper, metricsSettings)
 			scraperControllerOptions = append(scraperControllerOptions, scraperhelper.AddMetricsScraper(hostMetricsScraper))
 			continue
 		}
@@ -188,6 +200,7 @@ func createAddScraperOptions(
 		}
 
 		if ok {
+			resourceMetricsScraper = internal.WithResourceMetricsSettings(resourceMetricsScraper, metricsSettings)
 			scraperControllerOptions = append(scraperControllerOptions, scraperhelper.AddResourceMetricsScraper(resourceMetricsScraper))
 			continue
 		}
@@ -202,7 +202,9 @@ func appendMapInto(m1 map[string]struct{}, m2 map[string]struct{}) {
 const mockTypeStr = "mock"
 const mockResourceTypeStr = "mockresource"
 
-type mockConfig struct{}
+type mockConfig struct {
+	internal.ConfigSettings `mapstructure:",squash"`
+}
 
 type mockFactory struct{ mock.Mock }
 type mockScraper struct{ mock.Mock }
@@ -246,6 +248,19 @@ func TestGatherMetrics_ScraperKeyConfigError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestGatherMetrics_MetricsSettingsError(t *testing.T) {
+	scraperFactories = map[string]internal.ScraperFactory{mockTypeStr: &mockFactory{}}
+	resourceScraperFactories = map[string]internal.ResourceScraperFactory{}
+
+	sink := new(consumertest.MetricsSink)
+	mockCfg := &mockConfig{ConfigSettings: internal.ConfigSettings{
+		Metrics: map[string]internal.MetricSettings{"system.unknown": {}},
+	}}
+	config := &Config{Scrapers: map[string]internal.Config{mockTypeStr: mockCfg}}
+	_, err := NewFactory().CreateMetricsReceiver(context.Background(), creationParams, config, sink)
+	require.EqualError(t, err, `invalid metrics settings for key "mock": unknown metric "system.unknown"`)
+}
+
 func TestGatherMetrics_CreateMetricsScraperError(t *testing.T) {
 	mFactory := &mockFactory{}
 	mFactory.On("CreateMetricsScraper").Return(&mockScraper{}, errors.New("err1"))
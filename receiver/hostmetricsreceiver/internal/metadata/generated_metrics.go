@@ -59,6 +59,8 @@ type metricStruct struct {
 	ProcessDiskIo               MetricIntf
 	ProcessMemoryPhysicalUsage  MetricIntf
 	ProcessMemoryVirtualUsage   MetricIntf
+	SystemBatteryCharge         MetricIntf
+	SystemBatteryPower          MetricIntf
 	SystemCPULoadAverage15m     MetricIntf
 	SystemCPULoadAverage1m      MetricIntf
 	SystemCPULoadAverage5m      MetricIntf
@@ -72,6 +74,8 @@ type metricStruct struct {
 	SystemDiskWeightedIoTime    MetricIntf
 	SystemFilesystemInodesUsage MetricIntf
 	SystemFilesystemUsage       MetricIntf
+	SystemGpuMemoryUsage        MetricIntf
+	SystemGpuUtilization        MetricIntf
 	SystemMemoryUsage           MetricIntf
 	SystemNetworkConnections    MetricIntf
 	SystemNetworkDropped        MetricIntf
@@ -83,6 +87,7 @@ type metricStruct struct {
 	SystemPagingUsage           MetricIntf
 	SystemProcessesCount        MetricIntf
 	SystemProcessesCreated      MetricIntf
+	SystemTemperature           MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
@@ -92,6 +97,8 @@ func (m *metricStruct) Names() []string {
 		"process.disk.io",
 		"process.memory.physical_usage",
 		"process.memory.virtual_usage",
+		"system.battery.charge",
+		"system.battery.power",
 		"system.cpu.load_average.15m",
 		"system.cpu.load_average.1m",
 		"system.cpu.load_average.5m",
@@ -105,6 +112,8 @@ func (m *metricStruct) Names() []string {
 		"system.disk.weighted_io_time",
 		"system.filesystem.inodes.usage",
 		"system.filesystem.usage",
+		"system.gpu.memory.usage",
+		"system.gpu.utilization",
 		"system.memory.usage",
 		"system.network.connections",
 		"system.network.dropped",
@@ -116,6 +125,7 @@ func (m *metricStruct) Names() []string {
 		"system.paging.usage",
 		"system.processes.count",
 		"system.processes.created",
+		"system.temperature",
 	}
 }
 
@@ -124,6 +134,8 @@ var metricsByName = map[string]MetricIntf{
 	"process.disk.io":                Metrics.ProcessDiskIo,
 	"process.memory.physical_usage":  Metrics.ProcessMemoryPhysicalUsage,
 	"process.memory.virtual_usage":   Metrics.ProcessMemoryVirtualUsage,
+	"system.battery.charge":          Metrics.SystemBatteryCharge,
+	"system.battery.power":           Metrics.SystemBatteryPower,
 	"system.cpu.load_average.15m":    Metrics.SystemCPULoadAverage15m,
 	"system.cpu.load_average.1m":     Metrics.SystemCPULoadAverage1m,
 	"system.cpu.load_average.5m":     Metrics.SystemCPULoadAverage5m,
@@ -137,6 +149,8 @@ var metricsByName = map[string]MetricIntf{
 	"system.disk.weighted_io_time":   Metrics.SystemDiskWeightedIoTime,
 	"system.filesystem.inodes.usage": Metrics.SystemFilesystemInodesUsage,
 	"system.filesystem.usage":        Metrics.SystemFilesystemUsage,
+	"system.gpu.memory.usage":        Metrics.SystemGpuMemoryUsage,
+	"system.gpu.utilization":         Metrics.SystemGpuUtilization,
 	"system.memory.usage":            Metrics.SystemMemoryUsage,
 	"system.network.connections":     Metrics.SystemNetworkConnections,
 	"system.network.dropped":         Metrics.SystemNetworkDropped,
@@ -148,6 +162,7 @@ var metricsByName = map[string]MetricIntf{
 	"system.paging.usage":            Metrics.SystemPagingUsage,
 	"system.processes.count":         Metrics.SystemProcessesCount,
 	"system.processes.created":       Metrics.SystemProcessesCreated,
+	"system.temperature":             Metrics.SystemTemperature,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -160,6 +175,8 @@ func (m *metricStruct) FactoriesByName() map[string]func() pdata.Metric {
 		Metrics.ProcessDiskIo.Name():               Metrics.ProcessDiskIo.New,
 		Metrics.ProcessMemoryPhysicalUsage.Name():  Metrics.ProcessMemoryPhysicalUsage.New,
 		Metrics.ProcessMemoryVirtualUsage.Name():   Metrics.ProcessMemoryVirtualUsage.New,
+		Metrics.SystemBatteryCharge.Name():         Metrics.SystemBatteryCharge.New,
+		Metrics.SystemBatteryPower.Name():          Metrics.SystemBatteryPower.New,
 		Metrics.SystemCPULoadAverage15m.Name():     Metrics.SystemCPULoadAverage15m.New,
 		Metrics.SystemCPULoadAverage1m.Name():      Metrics.SystemCPULoadAverage1m.New,
 		Metrics.SystemCPULoadAverage5m.Name():      Metrics.SystemCPULoadAverage5m.New,
@@ -173,6 +190,8 @@ func (m *metricStruct) FactoriesByName() map[string]func() pdata.Metric {
 		Metrics.SystemDiskWeightedIoTime.Name():    Metrics.SystemDiskWeightedIoTime.New,
 		Metrics.SystemFilesystemInodesUsage.Name(): Metrics.SystemFilesystemInodesUsage.New,
 		Metrics.SystemFilesystemUsage.Name():       Metrics.SystemFilesystemUsage.New,
+		Metrics.SystemGpuMemoryUsage.Name():        Metrics.SystemGpuMemoryUsage.New,
+		Metrics.SystemGpuUtilization.Name():        Metrics.SystemGpuUtilization.New,
 		Metrics.SystemMemoryUsage.Name():           Metrics.SystemMemoryUsage.New,
 		Metrics.SystemNetworkConnections.Name():    Metrics.SystemNetworkConnections.New,
 		Metrics.SystemNetworkDropped.Name():        Metrics.SystemNetworkDropped.New,
@@ -184,6 +203,7 @@ func (m *metricStruct) FactoriesByName() map[string]func() pdata.Metric {
 		Metrics.SystemPagingUsage.Name():           Metrics.SystemPagingUsage.New,
 		Metrics.SystemProcessesCount.Name():        Metrics.SystemProcessesCount.New,
 		Metrics.SystemProcessesCreated.Name():      Metrics.SystemProcessesCreated.New,
+		Metrics.SystemTemperature.Name():           Metrics.SystemTemperature.New,
 	}
 }
 
@@ -234,6 +254,24 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.battery.charge",
+		func(metric pdata.Metric) {
+			metric.SetName("system.battery.charge")
+			metric.SetDescription("Remaining battery charge as a percentage of its full capacity.")
+			metric.SetUnit("%")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
+	&metricImpl{
+		"system.battery.power",
+		func(metric pdata.Metric) {
+			metric.SetName("system.battery.power")
+			metric.SetDescription("Power being drawn from or supplied to the battery.")
+			metric.SetUnit("W")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"system.cpu.load_average.15m",
 		func(metric pdata.Metric) {
@@ -371,6 +409,26 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.gpu.memory.usage",
+		func(metric pdata.Metric) {
+			metric.SetName("system.gpu.memory.usage")
+			metric.SetDescription("Bytes of GPU memory in use.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(false)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"system.gpu.utilization",
+		func(metric pdata.Metric) {
+			metric.SetName("system.gpu.utilization")
+			metric.SetDescription("Fraction of time the GPU was busy over the last sample period.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"system.memory.usage",
 		func(metric pdata.Metric) {
@@ -492,6 +550,15 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"system.temperature",
+		func(metric pdata.Metric) {
+			metric.SetName("system.temperature")
+			metric.SetDescription("Temperature reported by the hardware sensors.")
+			metric.SetUnit("Cel")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 }
 
 // M contains a set of methods for each metric that help with
@@ -500,6 +567,8 @@ var M = Metrics
 
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
+	// BatteryDevice (Name of the battery.)
+	BatteryDevice string
 	// Cpu (CPU number starting at 0.)
 	Cpu string
 	// CPUState (Breakdown of CPU usage by type.)
@@ -518,6 +587,10 @@ var Labels = struct {
 	FilesystemState string
 	// FilesystemType (Filesystem type, such as, "ext4", "tmpfs", etc.)
 	FilesystemType string
+	// GpuDevice (Index of the GPU starting at 0.)
+	GpuDevice string
+	// GpuMemoryState (Breakdown of GPU memory usage by type.)
+	GpuMemoryState string
 	// MemState (Breakdown of memory usage by type.)
 	MemState string
 	// NetworkDevice (Name of the network interface.)
@@ -542,7 +615,10 @@ var Labels = struct {
 	ProcessState string
 	// ProcessesStatus (Breakdown status of the processes.)
 	ProcessesStatus string
+	// TemperatureSensor (Name of the temperature sensor.)
+	TemperatureSensor string
 }{
+	"device",
 	"cpu",
 	"state",
 	"device",
@@ -552,6 +628,8 @@ var Labels = struct {
 	"mountpoint",
 	"state",
 	"type",
+	"device",
+	"state",
 	"state",
 	"device",
 	"direction",
@@ -564,6 +642,7 @@ var Labels = struct {
 	"direction",
 	"state",
 	"status",
+	"sensor",
 }
 
 // L contains the possible metric labels that can be used. L is an alias for
@@ -611,6 +690,15 @@ var LabelFilesystemState = struct {
 	"used",
 }
 
+// LabelGpuMemoryState are the possible values that the label "gpu.memory.state" can have.
+var LabelGpuMemoryState = struct {
+	Free string
+	Used string
+}{
+	"free",
+	"used",
+}
+
 // LabelMemState are the possible values that the label "mem.state" can have.
 var LabelMemState = struct {
 	Buffered          string
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// MetricSettings configures how a metric emitted by a scraper is reported.
+type MetricSettings struct {
+	// Enabled controls whether the metric is reported, metrics are enabled when not set.
+	Enabled *bool `mapstructure:"enabled"`
+
+	// Name replaces the name of the metric when set.
+	Name string `mapstructure:"name"`
+
+	// Labels renames the labels of the metric data points, keyed by their original name.
+	Labels map[string]string `mapstructure:"labels"`
+}
+
+// ValidateMetricsSettings returns an error when a setting refers to a metric which is not emitted by any scraper.
+func ValidateMetricsSettings(settings map[string]MetricSettings) error {
+	for name := range settings {
+		if metadata.Metrics.ByName(name) == nil {
+			return fmt.Errorf("unknown metric %q", name)
+		}
+	}
+	return nil
+}
+
+type metricsSettingsScraper struct {
+	scraperhelper.MetricsScraper
+	settings map[string]MetricSettings
+}
+
+// WithMetricsSettings wraps the scraper to apply the metrics settings to the scraped metrics.
+func WithMetricsSettings(scraper scraperhelper.MetricsScraper, settings map[string]MetricSettings) scraperhelper.MetricsScraper {
+	if len(settings) == 0 {
+		return scraper
+	}
+	return &metricsSettingsScraper{MetricsScraper: scraper, settings: settings}
+}
+
+func (s *metricsSettingsScraper) Scrape(ctx context.Context, receiverName string) (pdata.MetricSlice, error) {
+	metrics, err := s.MetricsScraper.Scrape(ctx, receiverName)
+	ApplyMetricsSettings(metrics, s.settings)
+	return metrics, err
+}
+
+type resourceMetricsSettingsScraper struct {
+	scraperhelper.ResourceMetricsScraper
+	settings map[string]MetricSettings
+}
+
+// WithResourceMetricsSettings wraps the resource scraper to apply the metrics settings to the scraped metrics.
+func WithResourceMetricsSettings(scraper scraperhelper.ResourceMetricsScraper, settings map[string]MetricSettings) scraperhelper.ResourceMetricsScraper {
+	if len(settings) == 0 {
+		return scraper
+	}
+	return &resourceMetricsSettingsScraper{ResourceMetricsScraper: scraper, settings: settings}
+}
+
+func (s *resourceMetricsSettingsScraper) Scrape(ctx context.Context, receiverName string) (pdata.ResourceMetricsSlice, error) {
+	resourceMetrics, err := s.ResourceMetricsScraper.Scrape(ctx, receiverName)
+	ApplyResourceMetricsSettings(resourceMetrics, s.settings)
+	return resourceMetrics, err
+}
+
+// ApplyMetricsSettings removes the disabled metrics from the slice then renames the metrics and their labels.
+func ApplyMetricsSettings(metrics pdata.MetricSlice, settings map[string]MetricSettings) {
+	if len(settings) == 0 {
+		return
+	}
+
+	kept := pdata.NewMetricSlice()
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		ms, ok := settings[metric.Name()]
+		if !ok {
+			kept.Append(metric)
+			continue
+		}
+		if ms.Enabled != nil && !*ms.Enabled {
+			continue
+		}
+		if len(ms.Labels) > 0 {
+			forEachLabelsMap(metric, func(labels pdata.StringMap) {
+				renameLabels(labels, ms.Labels)
+			})
+		}
+		if ms.Name != "" {
+			metric.SetName(ms.Name)
+		}
+		kept.Append(metric)
+	}
+
+	metrics.Resize(0)
+	kept.MoveAndAppendTo(metrics)
+}
+
+// ApplyResourceMetricsSettings applies the metrics settings to every metric of the resource metrics.
+func ApplyResourceMetricsSettings(resourceMetrics pdata.ResourceMetricsSlice, settings map[string]MetricSettings) {
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		ilms := resourceMetrics.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ApplyMetricsSettings(ilms.At(j).Metrics(), settings)
+		}
+	}
+}
+
+func renameLabels(labels pdata.StringMap, renames map[string]string) {
+	type label struct{ key, value string }
+	var renamed []label
+	labels.ForEach(func(k string, v string) {
+		if newKey, ok := renames[k]; ok {
+			renamed = append(renamed, label{newKey, v})
+		}
+	})
+	if len(renamed) == 0 {
+		return
+	}
+	for k := range renames {
+		labels.Delete(k)
+	}
+	for _, l := range renamed {
+		labels.Upsert(l.key, l.value)
+	}
+}
+
+func forEachLabelsMap(metric pdata.Metric, f func(pdata.StringMap)) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	}
+}
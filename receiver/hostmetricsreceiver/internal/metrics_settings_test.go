@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+func newTestMetrics() pdata.MetricSlice {
+	metrics := pdata.NewMetricSlice()
+	metrics.Resize(3)
+
+	metadata.Metrics.SystemCPULoadAverage1m.Init(metrics.At(0))
+	metadata.Metrics.SystemCPULoadAverage15m.Init(metrics.At(1))
+	metadata.Metrics.SystemMemoryUsage.Init(metrics.At(2))
+
+	idps := metrics.At(2).IntSum().DataPoints()
+	idps.Resize(2)
+	idps.At(0).LabelsMap().Insert(metadata.Labels.MemState, metadata.LabelMemState.Used)
+	idps.At(1).LabelsMap().Insert(metadata.Labels.MemState, metadata.LabelMemState.Free)
+	return metrics
+}
+
+func TestApplyMetricsSettings(t *testing.T) {
+	disabled := false
+	metrics := newTestMetrics()
+	ApplyMetricsSettings(metrics, map[string]MetricSettings{
+		"system.cpu.load_average.15m": {Enabled: &disabled},
+		"system.memory.usage":         {Name: "system.memory.used", Labels: map[string]string{"state": "memory_state"}},
+	})
+
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "system.cpu.load_average.1m", metrics.At(0).Name())
+	assert.Equal(t, "system.memory.used", metrics.At(1).Name())
+
+	idps := metrics.At(1).IntSum().DataPoints()
+	for i := 0; i < idps.Len(); i++ {
+		_, ok := idps.At(i).LabelsMap().Get(metadata.Labels.MemState)
+		assert.False(t, ok)
+	}
+	AssertIntSumMetricLabelHasValue(t, metrics.At(1), 0, "memory_state", metadata.LabelMemState.Used)
+	AssertIntSumMetricLabelHasValue(t, metrics.At(1), 1, "memory_state", metadata.LabelMemState.Free)
+}
+
+func TestApplyMetricsSettings_NoSettings(t *testing.T) {
+	metrics := newTestMetrics()
+	ApplyMetricsSettings(metrics, nil)
+	assert.Equal(t, newTestMetrics(), metrics)
+}
+
+func TestApplyResourceMetricsSettings(t *testing.T) {
+	disabled := false
+	resourceMetrics := pdata.NewResourceMetricsSlice()
+	resourceMetrics.Resize(2)
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		ilms := resourceMetrics.At(i).InstrumentationLibraryMetrics()
+		ilms.Resize(1)
+		newTestMetrics().MoveAndAppendTo(ilms.At(0).Metrics())
+	}
+
+	ApplyResourceMetricsSettings(resourceMetrics, map[string]MetricSettings{
+		"system.cpu.load_average.1m": {Enabled: &disabled},
+	})
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		metrics := resourceMetrics.At(i).InstrumentationLibraryMetrics().At(0).Metrics()
+		require.Equal(t, 2, metrics.Len())
+		assert.Equal(t, "system.cpu.load_average.15m", metrics.At(0).Name())
+	}
+}
+
+func TestValidateMetricsSettings(t *testing.T) {
+	assert.NoError(t, ValidateMetricsSettings(nil))
+	assert.NoError(t, ValidateMetricsSettings(map[string]MetricSettings{"system.memory.usage": {}}))
+	assert.EqualError(t, ValidateMetricsSettings(map[string]MetricSettings{"system.unknown": {}}), `unknown metric "system.unknown"`)
+}
+
+func TestWithMetricsSettings(t *testing.T) {
+	scraper := scraperhelper.NewMetricsScraper("test", func(context.Context) (pdata.MetricSlice, error) {
+		return newTestMetrics(), errors.New("err1")
+	})
+	assert.Equal(t, scraper, WithMetricsSettings(scraper, nil))
+
+	disabled := false
+	wrapped := WithMetricsSettings(scraper, map[string]MetricSettings{"system.memory.usage": {Enabled: &disabled}})
+	assert.Equal(t, "test", wrapped.Name())
+	metrics, err := wrapped.Scrape(context.Background(), "receiver")
+	assert.EqualError(t, err, "err1")
+	assert.Equal(t, 2, metrics.Len())
+}
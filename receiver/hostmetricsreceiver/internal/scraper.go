@@ -48,8 +48,16 @@ type ResourceScraperFactory interface {
 
 // Config is the configuration of a scraper.
 type Config interface {
+	// MetricsSettings returns the settings of the individual metrics emitted by the scraper, keyed by metric name.
+	MetricsSettings() map[string]MetricSettings
 }
 
 // ConfigSettings provides common settings for scraper configuration.
 type ConfigSettings struct {
+	Metrics map[string]MetricSettings `mapstructure:"metrics"`
+}
+
+// MetricsSettings returns the settings of the individual metrics emitted by the scraper.
+func (cs ConfigSettings) MetricsSettings() map[string]MetricSettings {
+	return cs.Metrics
 }
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpuscraper
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const (
+	metricsLen         = 2
+	gpuMemoryStatesLen = 2
+)
+
+// gpuStat holds the readings of a single GPU.
+type gpuStat struct {
+	index string
+	// utilization is the fraction of time the GPU was busy over the last sample period.
+	utilization float64
+	memoryUsed  int64
+	memoryFree  int64
+}
+
+// scraper for GPU Metrics
+type scraper struct {
+	config *Config
+
+	// for mocking
+	gpus func(context.Context) ([]gpuStat, error)
+}
+
+// newGPUScraper creates a GPU Scraper
+func newGPUScraper(_ context.Context, cfg *Config) *scraper {
+	return &scraper{config: cfg, gpus: getNvidiaGPUs}
+}
+
+// Scrape
+func (s *scraper) Scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+
+	now := pdata.TimestampFromTime(time.Now())
+	gpus, err := s.gpus(ctx)
+	if err != nil {
+		return metrics, scrapererror.NewPartialScrapeError(err, metricsLen)
+	}
+	if len(gpus) == 0 {
+		return metrics, nil
+	}
+
+	metrics.Resize(metricsLen)
+	initializeGPUUtilizationMetric(metrics.At(0), now, gpus)
+	initializeGPUMemoryUsageMetric(metrics.At(1), now, gpus)
+	return metrics, nil
+}
+
+func initializeGPUUtilizationMetric(metric pdata.Metric, now pdata.Timestamp, gpus []gpuStat) {
+	metadata.Metrics.SystemGpuUtilization.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(len(gpus))
+	for i, gpu := range gpus {
+		dataPoint := ddps.At(i)
+		dataPoint.LabelsMap().Insert(metadata.Labels.GpuDevice, gpu.index)
+		dataPoint.SetTimestamp(now)
+		dataPoint.SetValue(gpu.utilization)
+	}
+}
+
+func initializeGPUMemoryUsageMetric(metric pdata.Metric, now pdata.Timestamp, gpus []gpuStat) {
+	metadata.Metrics.SystemGpuMemoryUsage.Init(metric)
+
+	idps := metric.IntSum().DataPoints()
+	idps.Resize(gpuMemoryStatesLen * len(gpus))
+	for i, gpu := range gpus {
+		initializeGPUMemoryUsageDataPoint(idps.At(gpuMemoryStatesLen*i+0), now, gpu.index, metadata.LabelGpuMemoryState.Used, gpu.memoryUsed)
+		initializeGPUMemoryUsageDataPoint(idps.At(gpuMemoryStatesLen*i+1), now, gpu.index, metadata.LabelGpuMemoryState.Free, gpu.memoryFree)
+	}
+}
+
+func initializeGPUMemoryUsageDataPoint(dataPoint pdata.IntDataPoint, now pdata.Timestamp, deviceLabel, stateLabel string, value int64) {
+	labelsMap := dataPoint.LabelsMap()
+	labelsMap.Insert(metadata.Labels.GpuDevice, deviceLabel)
+	labelsMap.Insert(metadata.Labels.GpuMemoryState, stateLabel)
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetValue(value)
+}
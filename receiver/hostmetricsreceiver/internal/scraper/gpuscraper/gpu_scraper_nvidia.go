@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpuscraper
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const (
+	// nvidiaSMI is the NVIDIA System Management Interface, the command line front end of NVML installed along with
+	// the NVIDIA driver.
+	nvidiaSMI = "nvidia-smi"
+
+	bytesPerMiB = 1024 * 1024
+)
+
+var nvidiaSMIArgs = []string{"--query-gpu=index,utilization.gpu,memory.used,memory.free", "--format=csv,noheader,nounits"}
+
+// getNvidiaGPUs queries NVML through nvidia-smi, no GPU is reported when the NVIDIA driver is not installed.
+func getNvidiaGPUs(ctx context.Context) ([]gpuStat, error) {
+	path, err := exec.LookPath(nvidiaSMI)
+	if err != nil {
+		return nil, nil
+	}
+	out, err := exec.CommandContext(ctx, path, nvidiaSMIArgs...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", nvidiaSMI, err)
+	}
+	return parseNvidiaSMIOutput(string(out))
+}
+
+// parseNvidiaSMIOutput parses the csv output of nvidia-smi, one line per GPU.
+func parseNvidiaSMIOutput(out string) ([]gpuStat, error) {
+	var gpus []gpuStat
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected %s output: %q", nvidiaSMI, line)
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		utilization, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			// fields are reported as "[N/A]" or "[Not Supported]" when unavailable for the GPU
+			return nil, fmt.Errorf("invalid utilization for GPU %s: %w", fields[0], err)
+		}
+		used, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid used memory for GPU %s: %w", fields[0], err)
+		}
+		free, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid free memory for GPU %s: %w", fields[0], err)
+		}
+
+		gpus = append(gpus, gpuStat{
+			index:       fields[0],
+			utilization: utilization / 100,
+			memoryUsed:  used * bytesPerMiB,
+			memoryFree:  free * bytesPerMiB,
+		})
+	}
+	return gpus, nil
+}
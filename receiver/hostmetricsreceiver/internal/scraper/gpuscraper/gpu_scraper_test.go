@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpuscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func TestScrape(t *testing.T) {
+	type testCase struct {
+		name          string
+		gpusFunc      func(context.Context) ([]gpuStat, error)
+		expectMetrics bool
+		expectedErr   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Standard",
+			gpusFunc: func(context.Context) ([]gpuStat, error) {
+				return []gpuStat{{index: "0", utilization: 0.5, memoryUsed: 1024, memoryFree: 3072}, {index: "1"}}, nil
+			},
+			expectMetrics: true,
+		},
+		{
+			name:     "No GPU",
+			gpusFunc: func(context.Context) ([]gpuStat, error) { return nil, nil },
+		},
+		{
+			name:        "Error",
+			gpusFunc:    func(context.Context) ([]gpuStat, error) { return nil, errors.New("err1") },
+			expectedErr: "err1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper := newGPUScraper(context.Background(), &Config{})
+			scraper.gpus = test.gpusFunc
+
+			metrics, err := scraper.Scrape(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+
+				isPartial := scrapererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, metricsLen, err.(scrapererror.PartialScrapeError).Failed)
+				}
+
+				return
+			}
+			require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+			if !test.expectMetrics {
+				assert.Equal(t, 0, metrics.Len())
+				return
+			}
+			assert.Equal(t, metricsLen, metrics.Len())
+
+			utilization := metrics.At(0)
+			internal.AssertDescriptorEqual(t, metadata.Metrics.SystemGpuUtilization.New(), utilization)
+			assert.Equal(t, 2, utilization.DoubleGauge().DataPoints().Len())
+			internal.AssertDoubleGaugeMetricLabelHasValue(t, utilization, 0, metadata.Labels.GpuDevice, "0")
+			assert.Equal(t, 0.5, utilization.DoubleGauge().DataPoints().At(0).Value())
+
+			memory := metrics.At(1)
+			internal.AssertDescriptorEqual(t, metadata.Metrics.SystemGpuMemoryUsage.New(), memory)
+			assert.Equal(t, 2*gpuMemoryStatesLen, memory.IntSum().DataPoints().Len())
+			internal.AssertIntSumMetricLabelHasValue(t, memory, 0, metadata.Labels.GpuDevice, "0")
+			internal.AssertIntSumMetricLabelHasValue(t, memory, 0, metadata.Labels.GpuMemoryState, metadata.LabelGpuMemoryState.Used)
+			internal.AssertIntSumMetricLabelHasValue(t, memory, 1, metadata.Labels.GpuMemoryState, metadata.LabelGpuMemoryState.Free)
+			internal.AssertIntSumMetricLabelHasValue(t, memory, 2, metadata.Labels.GpuDevice, "1")
+			assert.Equal(t, int64(3072), memory.IntSum().DataPoints().At(1).Value())
+
+			internal.AssertSameTimeStampForAllMetrics(t, metrics)
+		})
+	}
+}
+
+func TestParseNvidiaSMIOutput(t *testing.T) {
+	gpus, err := parseNvidiaSMIOutput("0, 35, 1024, 15136\n1, 0, 0, 16160\n")
+	require.NoError(t, err)
+	assert.Equal(t, []gpuStat{
+		{index: "0", utilization: 0.35, memoryUsed: 1024 * bytesPerMiB, memoryFree: 15136 * bytesPerMiB},
+		{index: "1", utilization: 0, memoryUsed: 0, memoryFree: 16160 * bytesPerMiB},
+	}, gpus)
+
+	gpus, err = parseNvidiaSMIOutput("")
+	require.NoError(t, err)
+	assert.Empty(t, gpus)
+
+	_, err = parseNvidiaSMIOutput("0, 35, 1024\n")
+	assert.EqualError(t, err, `unexpected nvidia-smi output: "0, 35, 1024"`)
+
+	_, err = parseNvidiaSMIOutput("0, [Not Supported], 1024, 15136\n")
+	assert.Error(t, err)
+}
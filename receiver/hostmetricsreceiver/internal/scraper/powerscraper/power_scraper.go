@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package powerscraper
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const metricsLen = 2
+
+// batteryStat holds the readings of a single battery.
+type batteryStat struct {
+	name string
+	// charge is the remaining charge as a percentage of the full capacity.
+	charge float64
+	// power is the power drawn from or supplied to the battery in Watts, only set when hasPower is true.
+	power    float64
+	hasPower bool
+}
+
+// scraper for Power Metrics
+type scraper struct {
+	config *Config
+
+	// for mocking
+	batteries func() ([]batteryStat, error)
+}
+
+// newPowerScraper creates a Power Scraper
+func newPowerScraper(_ context.Context, cfg *Config) *scraper {
+	return &scraper{config: cfg, batteries: getBatteries}
+}
+
+// Scrape
+func (s *scraper) Scrape(_ context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+
+	now := pdata.TimestampFromTime(time.Now())
+	batteries, err := s.batteries()
+	if err != nil {
+		return metrics, scrapererror.NewPartialScrapeError(err, metricsLen)
+	}
+	if len(batteries) == 0 {
+		return metrics, nil
+	}
+
+	metrics.Resize(metricsLen)
+	initializeBatteryChargeMetric(metrics.At(0), now, batteries)
+	initializeBatteryPowerMetric(metrics.At(1), now, batteries)
+	return metrics, nil
+}
+
+func initializeBatteryChargeMetric(metric pdata.Metric, now pdata.Timestamp, batteries []batteryStat) {
+	metadata.Metrics.SystemBatteryCharge.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(len(batteries))
+	for i, battery := range batteries {
+		initializeBatteryDataPoint(ddps.At(i), now, battery.name, battery.charge)
+	}
+}
+
+func initializeBatteryPowerMetric(metric pdata.Metric, now pdata.Timestamp, batteries []batteryStat) {
+	metadata.Metrics.SystemBatteryPower.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(len(batteries))
+	idx := 0
+	for _, battery := range batteries {
+		if battery.hasPower {
+			initializeBatteryDataPoint(ddps.At(idx), now, battery.name, battery.power)
+			idx++
+		}
+	}
+	ddps.Resize(idx)
+}
+
+func initializeBatteryDataPoint(dataPoint pdata.DoubleDataPoint, now pdata.Timestamp, deviceLabel string, value float64) {
+	labelsMap := dataPoint.LabelsMap()
+	labelsMap.Insert(metadata.Labels.BatteryDevice, deviceLabel)
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetValue(value)
+}
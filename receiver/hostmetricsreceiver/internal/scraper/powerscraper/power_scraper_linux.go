@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package powerscraper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// powerSupplyPath is the sysfs directory listing the power supplies, overridden in tests.
+var powerSupplyPath = "/sys/class/power_supply"
+
+// getBatteries reads the batteries exposed by the kernel power supply class.
+func getBatteries() ([]batteryStat, error) {
+	infos, err := ioutil.ReadDir(powerSupplyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var batteries []batteryStat
+	for _, info := range infos {
+		dir := filepath.Join(powerSupplyPath, info.Name())
+		if supplyType, err := readString(dir, "type"); err != nil || supplyType != "Battery" {
+			continue
+		}
+
+		battery := batteryStat{name: info.Name()}
+		if capacity, err := readFloat(dir, "capacity"); err == nil {
+			battery.charge = capacity
+		} else if now, full, err := readPair(dir, "energy_now", "energy_full"); err == nil && full > 0 {
+			battery.charge = now / full * 100
+		} else if now, full, err := readPair(dir, "charge_now", "charge_full"); err == nil && full > 0 {
+			battery.charge = now / full * 100
+		} else {
+			continue
+		}
+
+		// the kernel reports power in µW, current in µA and voltage in µV
+		if power, err := readFloat(dir, "power_now"); err == nil {
+			battery.power, battery.hasPower = power/1e6, true
+		} else if current, voltage, err := readPair(dir, "current_now", "voltage_now"); err == nil {
+			battery.power, battery.hasPower = current*voltage/1e12, true
+		}
+
+		batteries = append(batteries, battery)
+	}
+	return batteries, nil
+}
+
+func readString(dir, name string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readFloat(dir, name string) (float64, error) {
+	value, err := readString(dir, name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func readPair(dir, name1, name2 string) (float64, float64, error) {
+	value1, err := readFloat(dir, name1)
+	if err != nil {
+		return 0, 0, err
+	}
+	value2, err := readFloat(dir, name2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return value1, value2, nil
+}
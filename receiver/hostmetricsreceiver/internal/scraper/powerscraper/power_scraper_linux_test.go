@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package powerscraper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBatteries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "power_supply")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeSupply := func(name string, files map[string]string) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0700))
+		for file, content := range files {
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name, file), []byte(content+"\n"), 0600))
+		}
+	}
+	writeSupply("AC", map[string]string{"type": "Mains", "online": "1"})
+	writeSupply("BAT0", map[string]string{"type": "Battery", "capacity": "75", "power_now": "9500000"})
+	writeSupply("BAT1", map[string]string{"type": "Battery", "charge_now": "2000000", "charge_full": "4000000", "current_now": "1000000", "voltage_now": "12000000"})
+	writeSupply("BAT2", map[string]string{"type": "Battery", "status": "Unknown"})
+
+	powerSupplyPath = dir
+	defer func() { powerSupplyPath = "/sys/class/power_supply" }()
+
+	batteries, err := getBatteries()
+	require.NoError(t, err)
+	assert.Equal(t, []batteryStat{
+		{name: "BAT0", charge: 75, power: 9.5, hasPower: true},
+		{name: "BAT1", charge: 50, power: 12, hasPower: true},
+	}, batteries)
+}
+
+func TestGetBatteries_NoPowerSupplies(t *testing.T) {
+	powerSupplyPath = filepath.Join(os.TempDir(), "does-not-exist")
+	defer func() { powerSupplyPath = "/sys/class/power_supply" }()
+
+	batteries, err := getBatteries()
+	require.NoError(t, err)
+	assert.Empty(t, batteries)
+}
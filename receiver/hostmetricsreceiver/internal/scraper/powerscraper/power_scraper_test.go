@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package powerscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func TestScrape(t *testing.T) {
+	type testCase struct {
+		name          string
+		batteriesFunc func() ([]batteryStat, error)
+		expectMetrics bool
+		expectedErr   string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Standard",
+			batteriesFunc: func() ([]batteryStat, error) {
+				return []batteryStat{{name: "BAT0", charge: 80, power: 12.5, hasPower: true}, {name: "BAT1", charge: 40}}, nil
+			},
+			expectMetrics: true,
+		},
+		{
+			name:          "No Batteries",
+			batteriesFunc: func() ([]batteryStat, error) { return nil, nil },
+		},
+		{
+			name:          "Error",
+			batteriesFunc: func() ([]batteryStat, error) { return nil, errors.New("err1") },
+			expectedErr:   "err1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper := newPowerScraper(context.Background(), &Config{})
+			scraper.batteries = test.batteriesFunc
+
+			metrics, err := scraper.Scrape(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+
+				isPartial := scrapererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, metricsLen, err.(scrapererror.PartialScrapeError).Failed)
+				}
+
+				return
+			}
+			require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+			if !test.expectMetrics {
+				assert.Equal(t, 0, metrics.Len())
+				return
+			}
+			assert.Equal(t, metricsLen, metrics.Len())
+
+			charge := metrics.At(0)
+			internal.AssertDescriptorEqual(t, metadata.Metrics.SystemBatteryCharge.New(), charge)
+			assert.Equal(t, 2, charge.DoubleGauge().DataPoints().Len())
+			internal.AssertDoubleGaugeMetricLabelHasValue(t, charge, 0, metadata.Labels.BatteryDevice, "BAT0")
+			internal.AssertDoubleGaugeMetricLabelHasValue(t, charge, 1, metadata.Labels.BatteryDevice, "BAT1")
+			assert.Equal(t, 80.0, charge.DoubleGauge().DataPoints().At(0).Value())
+
+			// only the batteries reporting their power draw have a data point
+			power := metrics.At(1)
+			internal.AssertDescriptorEqual(t, metadata.Metrics.SystemBatteryPower.New(), power)
+			assert.Equal(t, 1, power.DoubleGauge().DataPoints().Len())
+			internal.AssertDoubleGaugeMetricLabelHasValue(t, power, 0, metadata.Labels.BatteryDevice, "BAT0")
+			assert.Equal(t, 12.5, power.DoubleGauge().DataPoints().At(0).Value())
+
+			internal.AssertSameTimeStampForAllMetrics(t, metrics)
+		})
+	}
+}
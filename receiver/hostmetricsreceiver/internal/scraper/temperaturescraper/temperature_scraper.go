@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temperaturescraper
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirou/gopsutil/host"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const metricsLen = 1
+
+// scraper for Temperature Metrics
+type scraper struct {
+	config *Config
+
+	// for mocking gopsutil host.SensorsTemperatures
+	sensorsTemperatures func() ([]host.TemperatureStat, error)
+}
+
+// newTemperatureScraper creates a Temperature Scraper
+func newTemperatureScraper(_ context.Context, cfg *Config) *scraper {
+	return &scraper{config: cfg, sensorsTemperatures: host.SensorsTemperatures}
+}
+
+// Scrape
+func (s *scraper) Scrape(_ context.Context) (pdata.MetricSlice, error) {
+	metrics := pdata.NewMetricSlice()
+
+	now := pdata.TimestampFromTime(time.Now())
+	// on Linux, gopsutil returns the readings it could gather along with warnings for the sensors it failed to read
+	temperatures, err := s.sensorsTemperatures()
+	if err != nil && len(temperatures) == 0 {
+		return metrics, scrapererror.NewPartialScrapeError(err, metricsLen)
+	}
+
+	metrics.Resize(metricsLen)
+	initializeTemperatureMetric(metrics.At(0), now, temperatures)
+	return metrics, nil
+}
+
+func initializeTemperatureMetric(metric pdata.Metric, now pdata.Timestamp, temperatures []host.TemperatureStat) {
+	metadata.Metrics.SystemTemperature.Init(metric)
+
+	ddps := metric.DoubleGauge().DataPoints()
+	ddps.Resize(len(temperatures))
+	for i, temperature := range temperatures {
+		dataPoint := ddps.At(i)
+		dataPoint.LabelsMap().Insert(metadata.Labels.TemperatureSensor, temperature.SensorKey)
+		dataPoint.SetTimestamp(now)
+		dataPoint.SetValue(temperature.Temperature)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temperaturescraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func TestScrape(t *testing.T) {
+	type testCase struct {
+		name               string
+		temperaturesFunc   func() ([]host.TemperatureStat, error)
+		expectedDataPoints int
+		expectedErr        string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Standard",
+			temperaturesFunc: func() ([]host.TemperatureStat, error) {
+				return []host.TemperatureStat{{SensorKey: "coretemp_core0_input", Temperature: 45}, {SensorKey: "acpitz_input", Temperature: 30.5}}, nil
+			},
+			expectedDataPoints: 2,
+		},
+		{
+			name: "Sensors Warnings",
+			temperaturesFunc: func() ([]host.TemperatureStat, error) {
+				return []host.TemperatureStat{{SensorKey: "coretemp_core0_input", Temperature: 45}}, errors.New("warn1")
+			},
+			expectedDataPoints: 1,
+		},
+		{
+			name:             "Error",
+			temperaturesFunc: func() ([]host.TemperatureStat, error) { return nil, errors.New("err1") },
+			expectedErr:      "err1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper := newTemperatureScraper(context.Background(), &Config{})
+			scraper.sensorsTemperatures = test.temperaturesFunc
+
+			metrics, err := scraper.Scrape(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+
+				isPartial := scrapererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, metricsLen, err.(scrapererror.PartialScrapeError).Failed)
+				}
+
+				return
+			}
+			require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+			assert.Equal(t, metricsLen, metrics.Len())
+
+			metric := metrics.At(0)
+			internal.AssertDescriptorEqual(t, metadata.Metrics.SystemTemperature.New(), metric)
+			ddps := metric.DoubleGauge().DataPoints()
+			assert.Equal(t, test.expectedDataPoints, ddps.Len())
+			internal.AssertDoubleGaugeMetricLabelHasValue(t, metric, 0, metadata.Labels.TemperatureSensor, "coretemp_core0_input")
+			assert.Equal(t, 45.0, ddps.At(0).Value())
+			internal.AssertSameTimeStampForAllMetrics(t, metrics)
+		})
+	}
+}
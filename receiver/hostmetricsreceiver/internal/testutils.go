@@ -47,6 +47,12 @@ func AssertIntGaugeMetricLabelHasValue(t *testing.T, metric pdata.Metric, index
 	assert.Equal(t, expectedVal, val)
 }
 
+func AssertDoubleGaugeMetricLabelHasValue(t *testing.T, metric pdata.Metric, index int, labelName string, expectedVal string) {
+	val, ok := metric.DoubleGauge().DataPoints().At(index).LabelsMap().Get(labelName)
+	assert.Truef(t, ok, "Missing label %q in metric %q", labelName, metric.Name())
+	assert.Equal(t, expectedVal, val)
+}
+
 func AssertDoubleSumMetricLabelHasValue(t *testing.T, metric pdata.Metric, index int, labelName string, expectedVal string) {
 	val, ok := metric.DoubleSum().DataPoints().At(index).LabelsMap().Get(labelName)
 	assert.Truef(t, ok, "Missing label %q in metric %q", labelName, metric.Name())
@@ -106,5 +112,15 @@ func AssertSameTimeStampForMetrics(t *testing.T, metrics pdata.MetricSlice, star
 				require.Equalf(t, ts, ddps.At(j).Timestamp(), "metrics contained different end timestamp values")
 			}
 		}
+
+		if dt == pdata.MetricDataTypeDoubleGauge {
+			ddps := metric.DoubleGauge().DataPoints()
+			for j := 0; j < ddps.Len(); j++ {
+				if ts == 0 {
+					ts = ddps.At(j).Timestamp()
+				}
+				require.Equalf(t, ts, ddps.At(j).Timestamp(), "metrics contained different end timestamp values")
+			}
+		}
 	}
 }
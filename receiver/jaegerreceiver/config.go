@@ -15,6 +15,8 @@
 package jaegerreceiver
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -33,11 +35,33 @@ const (
 
 // RemoteSamplingConfig defines config key for remote sampling fetch endpoint
 type RemoteSamplingConfig struct {
-	HostEndpoint                  string `mapstructure:"host_endpoint"`
-	StrategyFile                  string `mapstructure:"strategy_file"`
+	HostEndpoint string `mapstructure:"host_endpoint"`
+	StrategyFile string `mapstructure:"strategy_file"`
+	// StrategyFileReloadInterval is the interval at which the strategy file is reloaded, it is not reloaded when 0.
+	StrategyFileReloadInterval time.Duration `mapstructure:"strategy_file_reload_interval"`
+	// HTTPEndpoint is the URL of the sampling endpoint of an upstream Jaeger agent or collector serving the
+	// strategies over HTTP, e.g. http://jaeger-agent:5778/sampling. The strategies are fetched over gRPC from
+	// the endpoint when it is empty.
+	HTTPEndpoint string `mapstructure:"http_endpoint"`
+	// CacheTTL is the duration the strategies fetched from the upstream endpoint are cached, they are fetched
+	// for each request when it is 0.
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+	// ServiceStrategies are the strategies served for the services instead of the strategies of the strategy
+	// file or of the upstream endpoint.
+	ServiceStrategies             []ServiceStrategy `mapstructure:"service_strategies"`
 	configgrpc.GRPCClientSettings `mapstructure:",squash"`
 }
 
+// ServiceStrategy defines the sampling strategy of a service.
+type ServiceStrategy struct {
+	Service string `mapstructure:"service"`
+	// Type is either probabilistic or ratelimiting.
+	Type string `mapstructure:"type"`
+	// Param is the sampling rate of the probabilistic strategy, between 0 and 1, or the maximum number of
+	// traces per second of the ratelimiting strategy.
+	Param float64 `mapstructure:"param"`
+}
+
 type Protocols struct {
 	GRPC          *configgrpc.GRPCServerSettings `mapstructure:"grpc"`
 	ThriftHTTP    *confighttp.HTTPServerSettings `mapstructure:"thrift_http"`
@@ -17,6 +17,7 @@ package jaegerreceiver
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -84,7 +85,13 @@ func TestLoadConfig(t *testing.T) {
 				GRPCClientSettings: configgrpc.GRPCClientSettings{
 					Endpoint: "jaeger-collector:1234",
 				},
-				StrategyFile: "/etc/strategies.json",
+				StrategyFile:               "/etc/strategies.json",
+				StrategyFileReloadInterval: time.Minute,
+				HTTPEndpoint:               "http://jaeger-agent:5778/sampling",
+				CacheTTL:                   30 * time.Second,
+				ServiceStrategies: []ServiceStrategy{
+					{Service: "FooService", Type: "probabilistic", Param: 0.5},
+				},
 			},
 		})
 
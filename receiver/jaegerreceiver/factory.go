@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/url"
 	"strconv"
 
 	"github.com/spf13/viper"
@@ -218,6 +219,27 @@ func createTraceReceiver(
 			}
 
 			config.RemoteSamplingStrategyFile = remoteSamplingConfig.StrategyFile
+			config.RemoteSamplingStrategyFileReloadInterval = remoteSamplingConfig.StrategyFileReloadInterval
+		} else if remoteSamplingConfig.StrategyFileReloadInterval != 0 {
+			return nil, fmt.Errorf("strategy_file_reload_interval requires strategy_file")
+		}
+
+		if len(remoteSamplingConfig.HTTPEndpoint) != 0 {
+			u, err := url.Parse(remoteSamplingConfig.HTTPEndpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				return nil, fmt.Errorf("http_endpoint must be an http or https URL: %q", remoteSamplingConfig.HTTPEndpoint)
+			}
+			config.RemoteSamplingHTTPEndpoint = remoteSamplingConfig.HTTPEndpoint
+		}
+		if remoteSamplingConfig.CacheTTL < 0 {
+			return nil, fmt.Errorf("cache_ttl must not be negative")
+		}
+		config.RemoteSamplingCacheTTL = remoteSamplingConfig.CacheTTL
+
+		var err error
+		config.RemoteSamplingServiceStrategies, err = newServiceStrategies(remoteSamplingConfig.ServiceStrategies)
+		if err != nil {
+			return nil, err
 		}
 	}
 
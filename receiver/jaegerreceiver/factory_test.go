@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -338,6 +339,70 @@ func TestRemoteSamplingFileRequiresGRPC(t *testing.T) {
 	assert.Error(t, err, "create trace receiver should error")
 }
 
+func TestRemoteSamplingUpstreamConfigPropagation(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	rCfg := cfg.(*Config)
+
+	rCfg.RemoteSampling = &RemoteSamplingConfig{
+		StrategyFile:               "strategies.json",
+		StrategyFileReloadInterval: time.Minute,
+		HTTPEndpoint:               "http://jaeger-agent:5778/sampling",
+		CacheTTL:                   time.Hour,
+		ServiceStrategies: []ServiceStrategy{
+			{Service: "foo", Type: "probabilistic", Param: 0.5},
+		},
+	}
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	r, err := factory.CreateTracesReceiver(context.Background(), params, cfg, nil)
+	require.NoError(t, err)
+
+	config := r.(*jReceiver).config
+	assert.Equal(t, time.Minute, config.RemoteSamplingStrategyFileReloadInterval)
+	assert.Equal(t, "http://jaeger-agent:5778/sampling", config.RemoteSamplingHTTPEndpoint)
+	assert.Equal(t, time.Hour, config.RemoteSamplingCacheTTL)
+	assert.Len(t, config.RemoteSamplingServiceStrategies, 1)
+}
+
+func TestRemoteSamplingConfigErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		remoteSampling *RemoteSamplingConfig
+		err            string
+	}{
+		{
+			name:           "reload_without_file",
+			remoteSampling: &RemoteSamplingConfig{StrategyFileReloadInterval: time.Minute},
+			err:            "strategy_file_reload_interval requires strategy_file",
+		},
+		{
+			name:           "invalid_http_endpoint",
+			remoteSampling: &RemoteSamplingConfig{HTTPEndpoint: "jaeger-agent:5778"},
+			err:            `http_endpoint must be an http or https URL: "jaeger-agent:5778"`,
+		},
+		{
+			name:           "negative_cache_ttl",
+			remoteSampling: &RemoteSamplingConfig{CacheTTL: -time.Second},
+			err:            "cache_ttl must not be negative",
+		},
+		{
+			name:           "invalid_service_strategy",
+			remoteSampling: &RemoteSamplingConfig{ServiceStrategies: []ServiceStrategy{{Service: "foo", Type: "adaptive"}}},
+			err:            `the strategy of service "foo" has unknown type "adaptive", must be "probabilistic" or "ratelimiting"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			cfg.(*Config).RemoteSampling = tt.remoteSampling
+			params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+			_, err := factory.CreateTracesReceiver(context.Background(), params, cfg, nil)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
 func TestCustomUnmarshalErrors(t *testing.T) {
 	factory := NewFactory()
 
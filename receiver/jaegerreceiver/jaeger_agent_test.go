@@ -17,9 +17,12 @@ package jaegerreceiver
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/jaegertracing/jaeger/cmd/agent/app/servers/thriftudp"
@@ -28,6 +31,7 @@ import (
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/jaegertracing/jaeger/thrift-gen/agent"
 	jaegerthrift "github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -177,6 +181,52 @@ func TestJaegerHTTP(t *testing.T) {
 	}
 }
 
+func TestJaegerHTTPUpstream(t *testing.T) {
+	upstreamCalls := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamCalls++
+		_, _ = w.Write([]byte(`{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.3}}`))
+	}))
+	defer upstream.Close()
+
+	port := testutil.GetAvailablePort(t)
+	config := &configuration{
+		AgentHTTPPort:              int(port),
+		RemoteSamplingHTTPEndpoint: upstream.URL + "/sampling",
+		RemoteSamplingCacheTTL:     time.Hour,
+		RemoteSamplingServiceStrategies: map[string]*sampling.SamplingStrategyResponse{
+			"overridden": {
+				StrategyType:         sampling.SamplingStrategyType_RATE_LIMITING,
+				RateLimitingSampling: &sampling.RateLimitingSamplingStrategy{MaxTracesPerSecond: 5},
+			},
+		},
+	}
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	jr := newJaegerReceiver(jaegerAgent, config, nil, params)
+	defer jr.Shutdown(context.Background())
+
+	assert.NoError(t, jr.Start(context.Background(), componenttest.NewNopHost()), "Start failed")
+
+	// allow http server to start
+	assert.NoError(t, testutil.WaitForPort(t, port), "WaitForPort failed")
+
+	getStrategy := func(service string) string {
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d/sampling?service=%s", port, service))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.JSONEq(t, `{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.3}}`, getStrategy("test"))
+	assert.JSONEq(t, `{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.3}}`, getStrategy("test"))
+	assert.Equal(t, 1, upstreamCalls, "the strategy should be cached")
+	assert.JSONEq(t, `{"strategyType":"RATE_LIMITING","rateLimitingSampling":{"maxTracesPerSecond":5}}`, getStrategy("overridden"))
+	assert.Equal(t, 1, upstreamCalls, "the overridden strategy should not be fetched")
+}
+
 func testJaegerAgent(t *testing.T, agentEndpoint string, receiverConfig *configuration) {
 	// 1. Create the Jaeger receiver aka "server"
 	sink := new(consumertest.TracesSink)
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"go.uber.org/zap"
+)
+
+var errNoSamplingSource = errors.New("no sampling strategy source is configured")
+
+const (
+	samplingStrategyProbabilistic = "probabilistic"
+	samplingStrategyRateLimiting  = "ratelimiting"
+)
+
+// newServiceStrategies converts the configured strategies of the services to
+// the responses served to the clients.
+func newServiceStrategies(cfgs []ServiceStrategy) (map[string]*sampling.SamplingStrategyResponse, error) {
+	strategies := make(map[string]*sampling.SamplingStrategyResponse, len(cfgs))
+	for _, cfg := range cfgs {
+		if cfg.Service == "" {
+			return nil, fmt.Errorf("service_strategies require the service name")
+		}
+		if _, ok := strategies[cfg.Service]; ok {
+			return nil, fmt.Errorf("service_strategies has duplicate service %q", cfg.Service)
+		}
+
+		switch cfg.Type {
+		case samplingStrategyProbabilistic:
+			if cfg.Param < 0 || cfg.Param > 1 {
+				return nil, fmt.Errorf("the probabilistic strategy of service %q requires a param between 0 and 1, got %v", cfg.Service, cfg.Param)
+			}
+			strategies[cfg.Service] = &sampling.SamplingStrategyResponse{
+				StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+				ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: cfg.Param},
+			}
+		case samplingStrategyRateLimiting:
+			if cfg.Param < 0 || cfg.Param > math.MaxInt16 {
+				return nil, fmt.Errorf("the ratelimiting strategy of service %q requires a param between 0 and %d, got %v", cfg.Service, math.MaxInt16, cfg.Param)
+			}
+			strategies[cfg.Service] = &sampling.SamplingStrategyResponse{
+				StrategyType:         sampling.SamplingStrategyType_RATE_LIMITING,
+				RateLimitingSampling: &sampling.RateLimitingSamplingStrategy{MaxTracesPerSecond: int16(cfg.Param)},
+			}
+		default:
+			return nil, fmt.Errorf("the strategy of service %q has unknown type %q, must be %q or %q",
+				cfg.Service, cfg.Type, samplingStrategyProbabilistic, samplingStrategyRateLimiting)
+		}
+	}
+	return strategies, nil
+}
+
+// serviceStrategyStore serves the strategies configured for the services, and
+// the strategies of the next store for the other services.
+type serviceStrategyStore struct {
+	strategies map[string]*sampling.SamplingStrategyResponse
+	next       strategystore.StrategyStore
+}
+
+var _ strategystore.StrategyStore = (*serviceStrategyStore)(nil)
+
+func (s *serviceStrategyStore) GetSamplingStrategy(ctx context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	if strategy, ok := s.strategies[serviceName]; ok {
+		return strategy, nil
+	}
+	return s.next.GetSamplingStrategy(ctx, serviceName)
+}
+
+// httpStrategyStore fetches the strategies from the sampling endpoint of a
+// Jaeger agent or collector, e.g. http://jaeger-agent:5778/sampling.
+type httpStrategyStore struct {
+	client   *http.Client
+	endpoint string
+}
+
+var _ strategystore.StrategyStore = (*httpStrategyStore)(nil)
+
+func (s *httpStrategyStore) GetSamplingStrategy(ctx context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+"?service="+url.QueryEscape(serviceName), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the sampling strategy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the sampling strategy: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the sampling strategy, received %s: %s", resp.Status, body)
+	}
+
+	strategy := &sampling.SamplingStrategyResponse{}
+	if err := json.Unmarshal(body, strategy); err != nil {
+		return nil, fmt.Errorf("failed to decode the sampling strategy: %w", err)
+	}
+	return strategy, nil
+}
+
+// cachedStrategy is a strategy fetched by the cachingStrategyStore.
+type cachedStrategy struct {
+	strategy  *sampling.SamplingStrategyResponse
+	fetchedAt time.Time
+}
+
+// cachingStrategyStore caches the strategies of the next store for the ttl.
+// The expired strategy of a service keeps being served when the next store
+// fails, so that the clients do not fall back to their default strategy while
+// the upstream endpoint is unavailable.
+type cachingStrategyStore struct {
+	next   strategystore.StrategyStore
+	ttl    time.Duration
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu         sync.Mutex
+	strategies map[string]cachedStrategy
+}
+
+var _ strategystore.StrategyStore = (*cachingStrategyStore)(nil)
+
+func newCachingStrategyStore(next strategystore.StrategyStore, ttl time.Duration, logger *zap.Logger) *cachingStrategyStore {
+	return &cachingStrategyStore{
+		next:       next,
+		ttl:        ttl,
+		logger:     logger,
+		now:        time.Now,
+		strategies: make(map[string]cachedStrategy),
+	}
+}
+
+func (s *cachingStrategyStore) GetSamplingStrategy(ctx context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	s.mu.Lock()
+	cached, ok := s.strategies[serviceName]
+	s.mu.Unlock()
+	if ok && s.now().Sub(cached.fetchedAt) < s.ttl {
+		return cached.strategy, nil
+	}
+
+	strategy, err := s.next.GetSamplingStrategy(ctx, serviceName)
+	if err != nil {
+		if ok {
+			s.logger.Warn("Failed to fetch the sampling strategy, serving the expired one",
+				zap.String("service", serviceName), zap.Error(err))
+			return cached.strategy, nil
+		}
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.strategies[serviceName] = cachedStrategy{strategy: strategy, fetchedAt: s.now()}
+	s.mu.Unlock()
+	return strategy, nil
+}
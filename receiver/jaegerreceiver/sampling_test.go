@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewServiceStrategies(t *testing.T) {
+	strategies, err := newServiceStrategies([]ServiceStrategy{
+		{Service: "foo", Type: "probabilistic", Param: 0.5},
+		{Service: "bar", Type: "ratelimiting", Param: 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*sampling.SamplingStrategyResponse{
+		"foo": {
+			StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+			ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.5},
+		},
+		"bar": {
+			StrategyType:         sampling.SamplingStrategyType_RATE_LIMITING,
+			RateLimitingSampling: &sampling.RateLimitingSamplingStrategy{MaxTracesPerSecond: 10},
+		},
+	}, strategies)
+}
+
+func TestNewServiceStrategiesErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategies []ServiceStrategy
+		err        string
+	}{
+		{
+			name:       "no_service",
+			strategies: []ServiceStrategy{{Type: "probabilistic", Param: 0.5}},
+			err:        "service_strategies require the service name",
+		},
+		{
+			name:       "duplicate_service",
+			strategies: []ServiceStrategy{{Service: "foo", Type: "probabilistic"}, {Service: "foo", Type: "ratelimiting"}},
+			err:        `service_strategies has duplicate service "foo"`,
+		},
+		{
+			name:       "probabilistic_above_one",
+			strategies: []ServiceStrategy{{Service: "foo", Type: "probabilistic", Param: 2}},
+			err:        `the probabilistic strategy of service "foo" requires a param between 0 and 1, got 2`,
+		},
+		{
+			name:       "ratelimiting_negative",
+			strategies: []ServiceStrategy{{Service: "foo", Type: "ratelimiting", Param: -1}},
+			err:        `the ratelimiting strategy of service "foo" requires a param between 0 and 32767, got -1`,
+		},
+		{
+			name:       "unknown_type",
+			strategies: []ServiceStrategy{{Service: "foo", Type: "adaptive"}},
+			err:        `the strategy of service "foo" has unknown type "adaptive", must be "probabilistic" or "ratelimiting"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newServiceStrategies(tt.strategies)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+type fakeStrategyStore struct {
+	calls    int
+	strategy *sampling.SamplingStrategyResponse
+	err      error
+}
+
+func (s *fakeStrategyStore) GetSamplingStrategy(context.Context, string) (*sampling.SamplingStrategyResponse, error) {
+	s.calls++
+	return s.strategy, s.err
+}
+
+func probabilisticStrategy(rate float64) *sampling.SamplingStrategyResponse {
+	return &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: rate},
+	}
+}
+
+func TestServiceStrategyStore(t *testing.T) {
+	next := &fakeStrategyStore{strategy: probabilisticStrategy(0.1)}
+	ss := &serviceStrategyStore{
+		strategies: map[string]*sampling.SamplingStrategyResponse{"foo": probabilisticStrategy(0.5)},
+		next:       next,
+	}
+
+	strategy, err := ss.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, probabilisticStrategy(0.5), strategy)
+	assert.Equal(t, 0, next.calls)
+
+	strategy, err = ss.GetSamplingStrategy(context.Background(), "bar")
+	require.NoError(t, err)
+	assert.Equal(t, probabilisticStrategy(0.1), strategy)
+	assert.Equal(t, 1, next.calls)
+}
+
+func TestHTTPStrategyStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("service") {
+		case "foo bar":
+			_, _ = w.Write([]byte(`{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.3}}`))
+		case "invalid":
+			_, _ = w.Write([]byte(`{"strategyType":`))
+		default:
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	ss := &httpStrategyStore{client: server.Client(), endpoint: server.URL + "/sampling"}
+
+	strategy, err := ss.GetSamplingStrategy(context.Background(), "foo bar")
+	require.NoError(t, err)
+	assert.Equal(t, probabilisticStrategy(0.3), strategy)
+
+	_, err = ss.GetSamplingStrategy(context.Background(), "invalid")
+	assert.Error(t, err)
+
+	_, err = ss.GetSamplingStrategy(context.Background(), "other")
+	assert.EqualError(t, err, "failed to fetch the sampling strategy, received 500 Internal Server Error: unavailable\n")
+}
+
+func TestCachingStrategyStore(t *testing.T) {
+	next := &fakeStrategyStore{strategy: probabilisticStrategy(0.1)}
+	ss := newCachingStrategyStore(next, time.Minute, zap.NewNop())
+	now := time.Now()
+	ss.now = func() time.Time { return now }
+
+	_, err := ss.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	strategy, err := ss.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, probabilisticStrategy(0.1), strategy)
+	assert.Equal(t, 1, next.calls, "the cached strategy should be served")
+
+	// The expired strategy is fetched again.
+	now = now.Add(time.Minute)
+	next.strategy = probabilisticStrategy(0.2)
+	strategy, err = ss.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, probabilisticStrategy(0.2), strategy)
+	assert.Equal(t, 2, next.calls)
+
+	// The expired strategy is served when the upstream endpoint fails.
+	now = now.Add(time.Minute)
+	next.err = errors.New("unavailable")
+	strategy, err = ss.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, probabilisticStrategy(0.2), strategy)
+
+	_, err = ss.GetSamplingStrategy(context.Background(), "bar")
+	assert.EqualError(t, err, "unavailable")
+}
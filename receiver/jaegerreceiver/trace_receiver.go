@@ -22,6 +22,7 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	apacheThrift "github.com/apache/thrift/lib/go/thrift"
 	"github.com/gorilla/mux"
@@ -33,6 +34,7 @@ import (
 	"github.com/jaegertracing/jaeger/cmd/agent/app/servers/thriftudp"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/handler"
 	collectorSampling "github.com/jaegertracing/jaeger/cmd/collector/app/sampling"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	staticStrategyStore "github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/jaegertracing/jaeger/thrift-gen/agent"
@@ -69,6 +71,14 @@ type configuration struct {
 	AgentHTTPPort                int
 	RemoteSamplingClientSettings configgrpc.GRPCClientSettings
 	RemoteSamplingStrategyFile   string
+	// RemoteSamplingStrategyFileReloadInterval is the interval at which the strategy file is reloaded.
+	RemoteSamplingStrategyFileReloadInterval time.Duration
+	// RemoteSamplingHTTPEndpoint is the URL the agent fetches the strategies from instead of the gRPC endpoint.
+	RemoteSamplingHTTPEndpoint string
+	// RemoteSamplingCacheTTL is the duration the agent caches the fetched strategies.
+	RemoteSamplingCacheTTL time.Duration
+	// RemoteSamplingServiceStrategies are served by both the agent and the collector for their services.
+	RemoteSamplingServiceStrategies map[string]*sampling.SamplingStrategyResponse
 }
 
 // Receiver type is used to receive spans that were originally intended to be sent to Jaeger.
@@ -88,9 +98,11 @@ type jReceiver struct {
 	grpc            *grpc.Server
 	collectorServer *http.Server
 
-	agentSamplingManager *jSamplingConfig.SamplingManager
-	agentProcessors      []processors.Processor
-	agentServer          *http.Server
+	agentSamplingConn      *grpc.ClientConn
+	agentStrategyStore     strategystore.StrategyStore
+	agentProcessors        []processors.Processor
+	agentServer            *http.Server
+	collectorStrategyStore strategystore.StrategyStore
 
 	logger *zap.Logger
 }
@@ -234,6 +246,16 @@ func (jr *jReceiver) Shutdown(context.Context) error {
 			jr.grpc.Stop()
 			jr.grpc = nil
 		}
+		if jr.agentSamplingConn != nil {
+			if cerr := jr.agentSamplingConn.Close(); cerr != nil {
+				errs = append(errs, cerr)
+			}
+			jr.agentSamplingConn = nil
+		}
+		// The strategy store stops reloading the strategy file when closed.
+		if closer, ok := jr.collectorStrategyStore.(interface{ Close() }); ok {
+			closer.Close()
+		}
 		err = consumererror.Combine(errs)
 	})
 
@@ -275,18 +297,16 @@ func (h *agentHandler) EmitBatch(ctx context.Context, batch *jaeger.Batch) error
 }
 
 func (jr *jReceiver) GetSamplingStrategy(ctx context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
-	return jr.agentSamplingManager.GetSamplingStrategy(ctx, serviceName)
+	if jr.agentStrategyStore == nil {
+		return nil, errNoSamplingSource
+	}
+	return jr.agentStrategyStore.GetSamplingStrategy(ctx, serviceName)
 }
 
-func (jr *jReceiver) GetBaggageRestrictions(ctx context.Context, serviceName string) ([]*baggage.BaggageRestriction, error) {
-	br, err := jr.agentSamplingManager.GetBaggageRestrictions(ctx, serviceName)
-	if err != nil {
-		// Baggage restrictions are not yet implemented - refer to - https://github.com/jaegertracing/jaeger/issues/373
-		// As of today, GetBaggageRestrictions() always returns an error.
-		// However, we `return nil, nil` here in order to serve a valid `200 OK` response.
-		return nil, nil
-	}
-	return br, nil
+func (jr *jReceiver) GetBaggageRestrictions(context.Context, string) ([]*baggage.BaggageRestriction, error) {
+	// Baggage restrictions are not yet implemented - refer to - https://github.com/jaegertracing/jaeger/issues/373
+	// We `return nil, nil` here in order to serve a valid `200 OK` response.
+	return nil, nil
 }
 
 func (jr *jReceiver) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
@@ -343,8 +363,12 @@ func (jr *jReceiver) startAgent(_ component.Host) error {
 		go processor.Serve()
 	}
 
-	// Start upstream grpc client before serving sampling endpoints over HTTP
-	if jr.config.RemoteSamplingClientSettings.Endpoint != "" {
+	// Start upstream client before serving sampling endpoints over HTTP
+	var upstream strategystore.StrategyStore
+	switch {
+	case jr.config.RemoteSamplingHTTPEndpoint != "":
+		upstream = &httpStrategyStore{client: &http.Client{}, endpoint: jr.config.RemoteSamplingHTTPEndpoint}
+	case jr.config.RemoteSamplingClientSettings.Endpoint != "":
 		grpcOpts, err := jr.config.RemoteSamplingClientSettings.ToDialOptions()
 		if err != nil {
 			jr.logger.Error("Error creating grpc dial options for remote sampling endpoint", zap.Error(err))
@@ -356,7 +380,14 @@ func (jr *jReceiver) startAgent(_ component.Host) error {
 			return err
 		}
 
-		jr.agentSamplingManager = jSamplingConfig.NewConfigManager(conn)
+		jr.agentSamplingConn = conn
+		upstream = jSamplingConfig.NewConfigManager(conn)
+	}
+	if upstream != nil {
+		if jr.config.RemoteSamplingCacheTTL > 0 {
+			upstream = newCachingStrategyStore(upstream, jr.config.RemoteSamplingCacheTTL, jr.logger)
+		}
+		jr.agentStrategyStore = jr.withServiceStrategies(upstream)
 	}
 
 	if jr.agentHTTPEnabled() {
@@ -372,6 +403,15 @@ func (jr *jReceiver) startAgent(_ component.Host) error {
 	return nil
 }
 
+// withServiceStrategies returns the store serving the strategies configured
+// for the services, and the strategies of the given store for the others.
+func (jr *jReceiver) withServiceStrategies(ss strategystore.StrategyStore) strategystore.StrategyStore {
+	if len(jr.config.RemoteSamplingServiceStrategies) == 0 {
+		return ss
+	}
+	return &serviceStrategyStore{strategies: jr.config.RemoteSamplingServiceStrategies, next: ss}
+}
+
 func (jr *jReceiver) buildProcessor(address string, cfg ServerConfigUDP, factory apacheThrift.TProtocolFactory, a agent.Agent) (processors.Processor, error) {
 	handler := agent.NewAgentProcessor(a)
 	transport, err := thriftudp.NewTUDPServerTransport(address)
@@ -442,7 +482,7 @@ func (jr *jReceiver) HandleThriftHTTPBatch(w http.ResponseWriter, r *http.Reques
 	batch, hErr := jr.decodeThriftHTTPBody(r)
 	if hErr != nil {
 		http.Error(w, hErr.msg, hErr.statusCode)
-		obsreport.EndTraceDataReceiveOp(ctx, thriftFormat, 0, hErr)
+		obsreport.EndTraceDataReceiveOp(ctx, thriftFormat, 0, consumererror.WithReason(hErr, consumererror.ReasonDecodeError))
 		return
 	}
 
@@ -489,11 +529,13 @@ func (jr *jReceiver) startCollector(host component.Host) error {
 		// init and register sampling strategy store
 		ss, gerr := staticStrategyStore.NewStrategyStore(staticStrategyStore.Options{
 			StrategiesFile: jr.config.RemoteSamplingStrategyFile,
+			ReloadInterval: jr.config.RemoteSamplingStrategyFileReloadInterval,
 		}, jr.logger)
 		if gerr != nil {
 			return fmt.Errorf("failed to create collector strategy store: %v", gerr)
 		}
-		api_v2.RegisterSamplingManagerServer(jr.grpc, collectorSampling.NewGRPCHandler(ss))
+		jr.collectorStrategyStore = ss
+		api_v2.RegisterSamplingManagerServer(jr.grpc, collectorSampling.NewGRPCHandler(jr.withServiceStrategies(ss)))
 
 		go func() {
 			if err := jr.grpc.Serve(gln); err != nil {
@@ -34,6 +34,7 @@ import (
 	staticStrategyStore "github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	jaegerthrift "github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -431,6 +432,50 @@ func TestSampling(t *testing.T) {
 	assert.Equal(t, expected, resp)
 }
 
+func TestSamplingServiceStrategies(t *testing.T) {
+	port := testutil.GetAvailablePort(t)
+	config := &configuration{
+		CollectorGRPCPort:          int(port),
+		RemoteSamplingStrategyFile: "testdata/strategies.json",
+		RemoteSamplingServiceStrategies: map[string]*sampling.SamplingStrategyResponse{
+			"foo": {
+				StrategyType:         sampling.SamplingStrategyType_RATE_LIMITING,
+				RateLimitingSampling: &sampling.RateLimitingSamplingStrategy{MaxTracesPerSecond: 5},
+			},
+		},
+	}
+	sink := new(consumertest.TracesSink)
+
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	jr := newJaegerReceiver(jaegerReceiver, config, sink, params)
+	defer jr.Shutdown(context.Background())
+
+	require.NoError(t, jr.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", config.CollectorGRPCPort), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cl := api_v2.NewSamplingManagerClient(conn)
+
+	// The configured strategy of the service takes precedence over the strategy file.
+	resp, err := cl.GetSamplingStrategy(context.Background(), &api_v2.SamplingStrategyParameters{
+		ServiceName: "foo",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &api_v2.SamplingStrategyResponse{
+		StrategyType:         api_v2.SamplingStrategyType_RATE_LIMITING,
+		RateLimitingSampling: &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: 5},
+	}, resp)
+
+	resp, err = cl.GetSamplingStrategy(context.Background(), &api_v2.SamplingStrategyParameters{
+		ServiceName: "bar",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api_v2.SamplingStrategyType_RATE_LIMITING, resp.StrategyType)
+	assert.Equal(t, int32(5), resp.GetRateLimitingSampling().GetMaxTracesPerSecond())
+}
+
 func TestSamplingFailsOnNotConfigured(t *testing.T) {
 	port := testutil.GetAvailablePort(t)
 	// prepare
@@ -48,14 +48,92 @@ type kafkaConsumer struct {
 	logger *zap.Logger
 }
 
+// kafkaMetricsConsumer uses sarama to consume and handle metrics messages from kafka.
+type kafkaMetricsConsumer struct {
+	name              string
+	consumerGroup     sarama.ConsumerGroup
+	nextConsumer      consumer.Metrics
+	topics            []string
+	cancelConsumeLoop context.CancelFunc
+	unmarshaller      MetricsUnmarshaller
+
+	logger *zap.Logger
+}
+
+// kafkaLogsConsumer uses sarama to consume and handle logs messages from kafka.
+type kafkaLogsConsumer struct {
+	name              string
+	consumerGroup     sarama.ConsumerGroup
+	nextConsumer      consumer.Logs
+	topics            []string
+	cancelConsumeLoop context.CancelFunc
+	unmarshaller      LogsUnmarshaller
+
+	logger *zap.Logger
+}
+
 var _ component.Receiver = (*kafkaConsumer)(nil)
+var _ component.Receiver = (*kafkaMetricsConsumer)(nil)
+var _ component.Receiver = (*kafkaLogsConsumer)(nil)
 
 func newReceiver(config Config, params component.ReceiverCreateParams, unmarshalers map[string]Unmarshaller, nextConsumer consumer.Traces) (*kafkaConsumer, error) {
 	unmarshaller := unmarshalers[config.Encoding]
 	if unmarshaller == nil {
 		return nil, errUnrecognizedEncoding
 	}
+	client, err := newConsumerGroup(config)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaConsumer{
+		name:          config.Name(),
+		consumerGroup: client,
+		topics:        []string{config.Topic},
+		nextConsumer:  nextConsumer,
+		unmarshaller:  unmarshaller,
+		logger:        params.Logger,
+	}, nil
+}
+
+func newMetricsReceiver(config Config, params component.ReceiverCreateParams, unmarshalers map[string]MetricsUnmarshaller, nextConsumer consumer.Metrics) (*kafkaMetricsConsumer, error) {
+	unmarshaller := unmarshalers[config.Encoding]
+	if unmarshaller == nil {
+		return nil, errUnrecognizedEncoding
+	}
+	client, err := newConsumerGroup(config)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaMetricsConsumer{
+		name:          config.Name(),
+		consumerGroup: client,
+		topics:        []string{config.Topic},
+		nextConsumer:  nextConsumer,
+		unmarshaller:  unmarshaller,
+		logger:        params.Logger,
+	}, nil
+}
+
+func newLogsReceiver(config Config, params component.ReceiverCreateParams, unmarshalers map[string]LogsUnmarshaller, nextConsumer consumer.Logs) (*kafkaLogsConsumer, error) {
+	unmarshaller := unmarshalers[config.Encoding]
+	if unmarshaller == nil {
+		return nil, errUnrecognizedEncoding
+	}
+	client, err := newConsumerGroup(config)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaLogsConsumer{
+		name:          config.Name(),
+		consumerGroup: client,
+		topics:        []string{config.Topic},
+		nextConsumer:  nextConsumer,
+		unmarshaller:  unmarshaller,
+		logger:        params.Logger,
+	}, nil
+}
 
+func newConsumerGroup(config Config) (sarama.ConsumerGroup, error) {
 	c := sarama.NewConfig()
 	c.ClientID = config.ClientID
 	c.Metadata.Full = config.Metadata.Full
@@ -71,18 +149,7 @@ func newReceiver(config Config, params component.ReceiverCreateParams, unmarshal
 	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
-	if err != nil {
-		return nil, err
-	}
-	return &kafkaConsumer{
-		name:          config.Name(),
-		consumerGroup: client,
-		topics:        []string{config.Topic},
-		nextConsumer:  nextConsumer,
-		unmarshaller:  unmarshaller,
-		logger:        params.Logger,
-	}, nil
+	return sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
 }
 
 func (c *kafkaConsumer) Start(context.Context, component.Host) error {
@@ -101,26 +168,70 @@ func (c *kafkaConsumer) Start(context.Context, component.Host) error {
 }
 
 func (c *kafkaConsumer) consumeLoop(ctx context.Context, handler sarama.ConsumerGroupHandler) error {
+	return consumeLoop(ctx, c.consumerGroup, c.topics, handler, c.logger)
+}
+
+func (c *kafkaConsumer) Shutdown(context.Context) error {
+	c.cancelConsumeLoop()
+	return c.consumerGroup.Close()
+}
+
+func (c *kafkaMetricsConsumer) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancelConsumeLoop = cancel
+	consumerGroup := &metricsConsumerGroupHandler{
+		name:         c.name,
+		logger:       c.logger,
+		unmarshaller: c.unmarshaller,
+		nextConsumer: c.nextConsumer,
+		ready:        make(chan bool),
+	}
+	go consumeLoop(ctx, c.consumerGroup, c.topics, consumerGroup, c.logger)
+	<-consumerGroup.ready
+	return nil
+}
+
+func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
+	c.cancelConsumeLoop()
+	return c.consumerGroup.Close()
+}
+
+func (c *kafkaLogsConsumer) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancelConsumeLoop = cancel
+	consumerGroup := &logsConsumerGroupHandler{
+		name:         c.name,
+		logger:       c.logger,
+		unmarshaller: c.unmarshaller,
+		nextConsumer: c.nextConsumer,
+		ready:        make(chan bool),
+	}
+	go consumeLoop(ctx, c.consumerGroup, c.topics, consumerGroup, c.logger)
+	<-consumerGroup.ready
+	return nil
+}
+
+func (c *kafkaLogsConsumer) Shutdown(context.Context) error {
+	c.cancelConsumeLoop()
+	return c.consumerGroup.Close()
+}
+
+func consumeLoop(ctx context.Context, consumerGroup sarama.ConsumerGroup, topics []string, handler sarama.ConsumerGroupHandler, logger *zap.Logger) error {
 	for {
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
-			c.logger.Error("Error from consumer", zap.Error(err))
+		if err := consumerGroup.Consume(ctx, topics, handler); err != nil {
+			logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
 		if ctx.Err() != nil {
-			c.logger.Info("Consumer stopped", zap.Error(ctx.Err()))
+			logger.Info("Consumer stopped", zap.Error(ctx.Err()))
 			return ctx.Err()
 		}
 	}
 }
 
-func (c *kafkaConsumer) Shutdown(context.Context) error {
-	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
-}
-
 type consumerGroupHandler struct {
 	name         string
 	unmarshaller Unmarshaller
@@ -179,3 +290,122 @@ func (c *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 	}
 	return nil
 }
+
+type metricsConsumerGroupHandler struct {
+	name         string
+	unmarshaller MetricsUnmarshaller
+	nextConsumer consumer.Metrics
+	ready        chan bool
+	readyCloser  sync.Once
+
+	logger *zap.Logger
+}
+
+var _ sarama.ConsumerGroupHandler = (*metricsConsumerGroupHandler)(nil)
+
+func (c *metricsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.readyCloser.Do(func() {
+		close(c.ready)
+	})
+	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.name)}
+	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
+	return nil
+}
+
+func (c *metricsConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.name)}
+	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionClose.M(1))
+	return nil
+}
+
+func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	c.logger.Info("Starting consumer group", zap.Int32("partition", claim.Partition()))
+	for message := range claim.Messages() {
+		c.logger.Debug("Kafka message claimed",
+			zap.String("value", string(message.Value)),
+			zap.Time("timestamp", message.Timestamp),
+			zap.String("topic", message.Topic))
+		session.MarkMessage(message, "")
+
+		ctx := obsreport.ReceiverContext(session.Context(), c.name, transport)
+		ctx = obsreport.StartMetricsReceiveOp(ctx, c.name, transport)
+		statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.name)}
+		_ = stats.RecordWithTags(ctx, statsTags,
+			statMessageCount.M(1),
+			statMessageOffset.M(message.Offset),
+			statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
+
+		metrics, err := c.unmarshaller.Unmarshal(message.Value)
+		if err != nil {
+			c.logger.Error("failed to unmarshall message", zap.Error(err))
+			return err
+		}
+
+		_, numPoints := metrics.MetricAndDataPointCount()
+		err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+		obsreport.EndMetricsReceiveOp(ctx, c.unmarshaller.Encoding(), numPoints, err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type logsConsumerGroupHandler struct {
+	name         string
+	unmarshaller LogsUnmarshaller
+	nextConsumer consumer.Logs
+	ready        chan bool
+	readyCloser  sync.Once
+
+	logger *zap.Logger
+}
+
+var _ sarama.ConsumerGroupHandler = (*logsConsumerGroupHandler)(nil)
+
+func (c *logsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	c.readyCloser.Do(func() {
+		close(c.ready)
+	})
+	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.name)}
+	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
+	return nil
+}
+
+func (c *logsConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.name)}
+	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionClose.M(1))
+	return nil
+}
+
+func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	c.logger.Info("Starting consumer group", zap.Int32("partition", claim.Partition()))
+	for message := range claim.Messages() {
+		c.logger.Debug("Kafka message claimed",
+			zap.String("value", string(message.Value)),
+			zap.Time("timestamp", message.Timestamp),
+			zap.String("topic", message.Topic))
+		session.MarkMessage(message, "")
+
+		ctx := obsreport.ReceiverContext(session.Context(), c.name, transport)
+		ctx = obsreport.StartLogsReceiveOp(ctx, c.name, transport)
+		statsTags := []tag.Mutator{tag.Insert(tagInstanceName, c.name)}
+		_ = stats.RecordWithTags(ctx, statsTags,
+			statMessageCount.M(1),
+			statMessageOffset.M(message.Offset),
+			statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
+
+		logs, err := c.unmarshaller.Unmarshal(message.Value)
+		if err != nil {
+			c.logger.Error("failed to unmarshall message", zap.Error(err))
+			return err
+		}
+
+		err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
+		obsreport.EndLogsReceiveOp(ctx, c.unmarshaller.Encoding(), logs.LogRecordCount(), err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
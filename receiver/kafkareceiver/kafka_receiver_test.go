@@ -228,6 +228,146 @@ func TestConsumerGroupHandler_error_nextConsumer(t *testing.T) {
 	wg.Wait()
 }
 
+func TestMetricsConsumerGroupHandler(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := metricsConsumerGroupHandler{
+		unmarshaller: &otlpMetricsPbUnmarshaller{},
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+	}
+
+	testSession := testConsumerGroupSession{}
+	require.NoError(t, c.Setup(testSession))
+	_, ok := <-c.ready
+	assert.False(t, ok)
+
+	groupClaim := testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testSession, groupClaim))
+		wg.Done()
+	}()
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Resize(1)
+	bts, err := md.ToOtlpProtoBytes()
+	require.NoError(t, err)
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Value: bts}
+	close(groupClaim.messageChan)
+	wg.Wait()
+	require.NoError(t, c.Cleanup(testSession))
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestMetricsConsumerGroupHandler_error_unmarshall(t *testing.T) {
+	c := metricsConsumerGroupHandler{
+		unmarshaller: &otlpMetricsPbUnmarshaller{},
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: consumertest.NewMetricsNop(),
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	go func() {
+		assert.Error(t, c.ConsumeClaim(testConsumerGroupSession{}, groupClaim))
+		wg.Done()
+	}()
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Value: []byte("!@#")}
+	close(groupClaim.messageChan)
+	wg.Wait()
+}
+
+func TestLogsConsumerGroupHandler(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	c := logsConsumerGroupHandler{
+		unmarshaller: &otlpLogsPbUnmarshaller{},
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: sink,
+	}
+
+	testSession := testConsumerGroupSession{}
+	require.NoError(t, c.Setup(testSession))
+	_, ok := <-c.ready
+	assert.False(t, ok)
+
+	groupClaim := testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testSession, groupClaim))
+		wg.Done()
+	}()
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	bts, err := ld.ToOtlpProtoBytes()
+	require.NoError(t, err)
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Value: bts}
+	close(groupClaim.messageChan)
+	wg.Wait()
+	require.NoError(t, c.Cleanup(testSession))
+	assert.Len(t, sink.AllLogs(), 1)
+}
+
+func TestLogsConsumerGroupHandler_error_nextConsumer(t *testing.T) {
+	consumerError := errors.New("failed to consumer")
+	c := logsConsumerGroupHandler{
+		unmarshaller: &otlpLogsPbUnmarshaller{},
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: consumertest.NewLogsErr(consumerError),
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	go func() {
+		assert.EqualError(t, c.ConsumeClaim(testConsumerGroupSession{}, groupClaim), consumerError.Error())
+		wg.Done()
+	}()
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	bts, err := ld.ToOtlpProtoBytes()
+	require.NoError(t, err)
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Value: bts}
+	close(groupClaim.messageChan)
+	wg.Wait()
+}
+
+func TestNewMetricsReceiver_encoding_err(t *testing.T) {
+	c := Config{
+		Encoding: "foo",
+	}
+	r, err := newMetricsReceiver(c, component.ReceiverCreateParams{}, defaultMetricsUnmarshallers(), consumertest.NewMetricsNop())
+	require.Error(t, err)
+	assert.Nil(t, r)
+	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
+}
+
+func TestNewLogsReceiver_encoding_err(t *testing.T) {
+	c := Config{
+		Encoding: "foo",
+	}
+	r, err := newLogsReceiver(c, component.ReceiverCreateParams{}, defaultLogsUnmarshallers(), consumertest.NewLogsNop())
+	require.Error(t, err)
+	assert.Nil(t, r)
+	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
+}
+
 type testConsumerGroupClaim struct {
 	messageChan chan *sarama.ConsumerMessage
 }
@@ -15,9 +15,19 @@
 package kafkareceiver
 
 import (
+	"bytes"
+
+	"github.com/gogo/protobuf/jsonpb"
+
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal"
+	otlpcollectorlog "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
 )
 
+const otlpJSONEncoding = "otlp_json"
+
 type otlpTracesPbUnmarshaller struct {
 }
 
@@ -30,3 +40,80 @@ func (p *otlpTracesPbUnmarshaller) Unmarshal(bytes []byte) (pdata.Traces, error)
 func (*otlpTracesPbUnmarshaller) Encoding() string {
 	return defaultEncoding
 }
+
+type otlpMetricsPbUnmarshaller struct {
+}
+
+var _ MetricsUnmarshaller = (*otlpMetricsPbUnmarshaller)(nil)
+
+func (p *otlpMetricsPbUnmarshaller) Unmarshal(bytes []byte) (pdata.Metrics, error) {
+	return pdata.MetricsFromOtlpProtoBytes(bytes)
+}
+
+func (*otlpMetricsPbUnmarshaller) Encoding() string {
+	return defaultEncoding
+}
+
+type otlpLogsPbUnmarshaller struct {
+}
+
+var _ LogsUnmarshaller = (*otlpLogsPbUnmarshaller)(nil)
+
+func (p *otlpLogsPbUnmarshaller) Unmarshal(bytes []byte) (pdata.Logs, error) {
+	return pdata.LogsFromOtlpProtoBytes(bytes)
+}
+
+func (*otlpLogsPbUnmarshaller) Encoding() string {
+	return defaultEncoding
+}
+
+type otlpTracesJSONUnmarshaller struct {
+}
+
+var _ Unmarshaller = (*otlpTracesJSONUnmarshaller)(nil)
+
+func (p *otlpTracesJSONUnmarshaller) Unmarshal(buf []byte) (pdata.Traces, error) {
+	req := &otlpcollectortrace.ExportTraceServiceRequest{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(buf), req); err != nil {
+		return pdata.NewTraces(), err
+	}
+	return pdata.TracesFromInternalRep(internal.TracesFromOtlp(req)), nil
+}
+
+func (*otlpTracesJSONUnmarshaller) Encoding() string {
+	return otlpJSONEncoding
+}
+
+type otlpMetricsJSONUnmarshaller struct {
+}
+
+var _ MetricsUnmarshaller = (*otlpMetricsJSONUnmarshaller)(nil)
+
+func (p *otlpMetricsJSONUnmarshaller) Unmarshal(buf []byte) (pdata.Metrics, error) {
+	req := &otlpcollectormetrics.ExportMetricsServiceRequest{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(buf), req); err != nil {
+		return pdata.NewMetrics(), err
+	}
+	return pdata.MetricsFromInternalRep(internal.MetricsFromOtlp(req)), nil
+}
+
+func (*otlpMetricsJSONUnmarshaller) Encoding() string {
+	return otlpJSONEncoding
+}
+
+type otlpLogsJSONUnmarshaller struct {
+}
+
+var _ LogsUnmarshaller = (*otlpLogsJSONUnmarshaller)(nil)
+
+func (p *otlpLogsJSONUnmarshaller) Unmarshal(buf []byte) (pdata.Logs, error) {
+	req := &otlpcollectorlog.ExportLogsServiceRequest{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(buf), req); err != nil {
+		return pdata.NewLogs(), err
+	}
+	return pdata.LogsFromInternalRep(internal.LogsFromOtlp(req)), nil
+}
+
+func (*otlpLogsJSONUnmarshaller) Encoding() string {
+	return otlpJSONEncoding
+}
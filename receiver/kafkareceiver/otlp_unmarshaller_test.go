@@ -17,10 +17,13 @@ package kafkareceiver
 import (
 	"testing"
 
+	"github.com/gogo/protobuf/jsonpb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal"
+	"go.opentelemetry.io/collector/internal/testdata"
 )
 
 func TestUnmarshallOTLP(t *testing.T) {
@@ -44,3 +47,77 @@ func TestUnmarshallOTLP_error(t *testing.T) {
 	_, err := p.Unmarshal([]byte("+$%"))
 	assert.Error(t, err)
 }
+
+func TestUnmarshallOTLPMetrics(t *testing.T) {
+	md := testdata.GenerateMetricsOneMetric()
+	expected, err := md.ToOtlpProtoBytes()
+	require.NoError(t, err)
+
+	p := otlpMetricsPbUnmarshaller{}
+	got, err := p.Unmarshal(expected)
+	require.NoError(t, err)
+
+	assert.Equal(t, md, got)
+	assert.Equal(t, "otlp_proto", p.Encoding())
+}
+
+func TestUnmarshallOTLPLogs(t *testing.T) {
+	ld := testdata.GenerateLogDataOneLog()
+	expected, err := ld.ToOtlpProtoBytes()
+	require.NoError(t, err)
+
+	p := otlpLogsPbUnmarshaller{}
+	got, err := p.Unmarshal(expected)
+	require.NoError(t, err)
+
+	assert.Equal(t, ld, got)
+	assert.Equal(t, "otlp_proto", p.Encoding())
+}
+
+func TestUnmarshallOTLPJSON(t *testing.T) {
+	td := testdata.GenerateTraceDataOneSpan()
+	json, err := (&jsonpb.Marshaler{}).MarshalToString(internal.TracesToOtlp(td.InternalRep()))
+	require.NoError(t, err)
+
+	p := otlpTracesJSONUnmarshaller{}
+	got, err := p.Unmarshal([]byte(json))
+	require.NoError(t, err)
+
+	assert.Equal(t, td, got)
+	assert.Equal(t, "otlp_json", p.Encoding())
+}
+
+func TestUnmarshallOTLPMetricsJSON(t *testing.T) {
+	md := testdata.GenerateMetricsOneMetric()
+	json, err := (&jsonpb.Marshaler{}).MarshalToString(internal.MetricsToOtlp(md.InternalRep()))
+	require.NoError(t, err)
+
+	p := otlpMetricsJSONUnmarshaller{}
+	got, err := p.Unmarshal([]byte(json))
+	require.NoError(t, err)
+
+	assert.Equal(t, md, got)
+	assert.Equal(t, "otlp_json", p.Encoding())
+}
+
+func TestUnmarshallOTLPLogsJSON(t *testing.T) {
+	ld := testdata.GenerateLogDataOneLog()
+	json, err := (&jsonpb.Marshaler{}).MarshalToString(internal.LogsToOtlp(ld.InternalRep()))
+	require.NoError(t, err)
+
+	p := otlpLogsJSONUnmarshaller{}
+	got, err := p.Unmarshal([]byte(json))
+	require.NoError(t, err)
+
+	assert.Equal(t, ld, got)
+	assert.Equal(t, "otlp_json", p.Encoding())
+}
+
+func TestUnmarshallOTLPJSON_error(t *testing.T) {
+	_, err := (&otlpTracesJSONUnmarshaller{}).Unmarshal([]byte("+$%"))
+	assert.Error(t, err)
+	_, err = (&otlpMetricsJSONUnmarshaller{}).Unmarshal([]byte("+$%"))
+	assert.Error(t, err)
+	_, err = (&otlpLogsJSONUnmarshaller{}).Unmarshal([]byte("+$%"))
+	assert.Error(t, err)
+}
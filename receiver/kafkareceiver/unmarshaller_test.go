@@ -24,6 +24,7 @@ import (
 func TestDefaultUnMarshaller(t *testing.T) {
 	expectedEncodings := []string{
 		"otlp_proto",
+		"otlp_json",
 		"jaeger_proto",
 		"jaeger_json",
 		"zipkin_proto",
@@ -40,3 +41,35 @@ func TestDefaultUnMarshaller(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultMetricsUnMarshaller(t *testing.T) {
+	expectedEncodings := []string{
+		"otlp_proto",
+		"otlp_json",
+	}
+	marshallers := defaultMetricsUnmarshallers()
+	assert.Equal(t, len(expectedEncodings), len(marshallers))
+	for _, e := range expectedEncodings {
+		t.Run(e, func(t *testing.T) {
+			m, ok := marshallers[e]
+			require.True(t, ok)
+			assert.NotNil(t, m)
+		})
+	}
+}
+
+func TestDefaultLogsUnMarshaller(t *testing.T) {
+	expectedEncodings := []string{
+		"otlp_proto",
+		"otlp_json",
+	}
+	marshallers := defaultLogsUnmarshallers()
+	assert.Equal(t, len(expectedEncodings), len(marshallers))
+	for _, e := range expectedEncodings {
+		t.Run(e, func(t *testing.T) {
+			m, ok := marshallers[e]
+			require.True(t, ok)
+			assert.NotNil(t, m)
+		})
+	}
+}
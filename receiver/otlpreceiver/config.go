@@ -31,4 +31,10 @@ type Config struct {
 
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// WaitForResult indicates whether the requests are answered once the data is accepted by the next consumer,
+	// returning its errors, such as a full sending queue, to the clients. If false the requests are acknowledged
+	// immediately and the errors of the next consumer are only reported by the receiver metrics, the requests are
+	// refused with RESOURCE_EXHAUSTED when too many acknowledged requests are being passed to the next consumer.
+	WaitForResult bool `mapstructure:"wait_for_result"`
 }
@@ -42,7 +42,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 10)
+	assert.Equal(t, len(cfg.Receivers), 12)
 
 	assert.Equal(t, cfg.Receivers["otlp"], factory.CreateDefaultConfig())
 
@@ -56,6 +56,12 @@ func TestLoadConfig(t *testing.T) {
 	defaultOnlyHTTP.GRPC = nil
 	assert.Equal(t, cfg.Receivers["otlp/only_http"], defaultOnlyHTTP)
 
+	defaultAsync := factory.CreateDefaultConfig().(*Config)
+	defaultAsync.SetName("otlp/async")
+	defaultAsync.HTTP = nil
+	defaultAsync.WaitForResult = false
+	assert.Equal(t, cfg.Receivers["otlp/async"], defaultAsync)
+
 	assert.Equal(t, cfg.Receivers["otlp/customname"],
 		&Config{
 			ReceiverSettings: configmodels.ReceiverSettings{
@@ -71,6 +77,7 @@ func TestLoadConfig(t *testing.T) {
 					ReadBufferSize: 512 * 1024,
 				},
 			},
+			WaitForResult: true,
 		})
 
 	assert.Equal(t, cfg.Receivers["otlp/keepalive"],
@@ -101,6 +108,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 			},
+			WaitForResult: true,
 		})
 
 	assert.Equal(t, cfg.Receivers["otlp/msg-size-conc-connect-max-idle"],
@@ -126,6 +134,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 			},
+			WaitForResult: true,
 		})
 
 	// NOTE: Once the config loader checks for the files existence, this test may fail and require
@@ -151,7 +160,8 @@ func TestLoadConfig(t *testing.T) {
 					ReadBufferSize: 512 * 1024,
 				},
 				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint: "0.0.0.0:55681",
+					MaxRequestBodySize: defaultMaxRequestBodySize,
+					Endpoint:           "0.0.0.0:55681",
 					TLSSetting: &configtls.TLSServerSetting{
 						TLSSetting: configtls.TLSSetting{
 							CertFile: "test.crt",
@@ -160,6 +170,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 			},
+			WaitForResult: true,
 		})
 
 	assert.Equal(t, cfg.Receivers["otlp/cors"],
@@ -170,10 +181,12 @@ func TestLoadConfig(t *testing.T) {
 			},
 			Protocols: Protocols{
 				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint:    "0.0.0.0:55681",
-					CorsOrigins: []string{"https://*.test.com", "https://test.com"},
+					MaxRequestBodySize: defaultMaxRequestBodySize,
+					Endpoint:           "0.0.0.0:55681",
+					CorsOrigins:        []string{"https://*.test.com", "https://test.com"},
 				},
 			},
+			WaitForResult: true,
 		})
 
 	assert.Equal(t, cfg.Receivers["otlp/corsheader"],
@@ -184,11 +197,46 @@ func TestLoadConfig(t *testing.T) {
 			},
 			Protocols: Protocols{
 				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint:    "0.0.0.0:55681",
-					CorsOrigins: []string{"https://*.test.com", "https://test.com"},
-					CorsHeaders: []string{"ExampleHeader"},
+					MaxRequestBodySize: defaultMaxRequestBodySize,
+					Endpoint:           "0.0.0.0:55681",
+					CorsOrigins:        []string{"https://*.test.com", "https://test.com"},
+					CorsHeaders:        []string{"ExampleHeader"},
+					CorsMaxAge:         7200,
+				},
+			},
+			WaitForResult: true,
+		})
+
+	assert.Equal(t, cfg.Receivers["otlp/socket_options"],
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "otlp/socket_options",
+			},
+			Protocols: Protocols{
+				GRPC: &configgrpc.GRPCServerSettings{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "0.0.0.0:4317",
+						Transport: "tcp",
+						SocketOptions: confignet.SocketOptions{
+							ReusePort:       true,
+							TCPKeepAlive:    30 * time.Second,
+							ReadBufferSize:  1048576,
+							WriteBufferSize: 524288,
+						},
+					},
+					ReadBufferSize: 512 * 1024,
+				},
+				HTTP: &confighttp.HTTPServerSettings{
+					MaxRequestBodySize: defaultMaxRequestBodySize,
+					Endpoint:           "0.0.0.0:55681",
+					SocketOptions: confignet.SocketOptions{
+						ReusePort:    true,
+						TCPKeepAlive: -time.Second,
+					},
 				},
 			},
+			WaitForResult: true,
 		})
 
 	assert.Equal(t, cfg.Receivers["otlp/uds"],
@@ -200,16 +248,20 @@ func TestLoadConfig(t *testing.T) {
 			Protocols: Protocols{
 				GRPC: &configgrpc.GRPCServerSettings{
 					NetAddr: confignet.NetAddr{
-						Endpoint:  "/tmp/grpc_otlp.sock",
-						Transport: "unix",
+						Endpoint:          "/tmp/grpc_otlp.sock",
+						Transport:         "unix",
+						SocketPermissions: "0660",
 					},
 					ReadBufferSize: 512 * 1024,
 				},
 				HTTP: &confighttp.HTTPServerSettings{
-					Endpoint: "/tmp/http_otlp.sock",
-					// Transport: "unix",
+					MaxRequestBodySize: defaultMaxRequestBodySize,
+					Endpoint:           "/tmp/http_otlp.sock",
+					Transport:          "unix",
+					SocketPermissions:  "0600",
 				},
 			},
+			WaitForResult: true,
 		})
 }
 
@@ -42,6 +42,9 @@ const (
 	defaultGRPCEndpoint = "0.0.0.0:4317"
 	defaultHTTPEndpoint = "0.0.0.0:55681"
 	legacyGRPCEndpoint  = "0.0.0.0:55680"
+
+	// defaultMaxRequestBodySize bounds the memory used by a single HTTP request, compressed or not.
+	defaultMaxRequestBodySize = 20 * 1024 * 1024
 )
 
 func NewFactory() component.ReceiverFactory {
@@ -71,9 +74,11 @@ func createDefaultConfig() configmodels.Receiver {
 				ReadBufferSize: 512 * 1024,
 			},
 			HTTP: &confighttp.HTTPServerSettings{
-				Endpoint: defaultHTTPEndpoint,
+				Endpoint:           defaultHTTPEndpoint,
+				MaxRequestBodySize: defaultMaxRequestBodySize,
 			},
 		},
+		WaitForResult: true,
 	}
 }
 
@@ -16,30 +16,46 @@ package logs
 
 import (
 	"context"
+	"errors"
+	"sync"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal"
 	collectorlog "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const (
 	dataFormatProtobuf = "protobuf"
+
+	// maxAsyncExports is the maximum number of acknowledged requests whose data is being passed to the next
+	// consumer, the requests are refused with RESOURCE_EXHAUSTED once it is reached.
+	maxAsyncExports = 128
 )
 
+var errTooManyAsyncExports = errors.New("too many acknowledged requests are being processed")
+
 // Receiver is the type used to handle spans from OpenTelemetry exporters.
 type Receiver struct {
-	instanceName string
-	nextConsumer consumer.Logs
+	instanceName  string
+	nextConsumer  consumer.Logs
+	waitForResult bool
+	asyncSem      chan struct{}
+	asyncWG       sync.WaitGroup
 }
 
-// New creates a new Receiver reference.
-func New(instanceName string, nextConsumer consumer.Logs) *Receiver {
+// New creates a new Receiver reference. If waitForResult is false the requests are acknowledged before the data
+// is passed to the next consumer, and its errors are not returned to the clients.
+func New(instanceName string, nextConsumer consumer.Logs, waitForResult bool) *Receiver {
 	r := &Receiver{
-		instanceName: instanceName,
-		nextConsumer: nextConsumer,
+		instanceName:  instanceName,
+		nextConsumer:  nextConsumer,
+		waitForResult: waitForResult,
+		asyncSem:      make(chan struct{}, maxAsyncExports),
 	}
 
 	return r
@@ -55,14 +71,50 @@ func (r *Receiver) Export(ctx context.Context, req *collectorlog.ExportLogsServi
 	ctxWithReceiverName := obsreport.ReceiverContext(ctx, r.instanceName, receiverTransport)
 
 	ld := pdata.LogsFromInternalRep(internal.LogsFromOtlp(req))
+	if !r.waitForResult {
+		select {
+		case r.asyncSem <- struct{}{}:
+		default:
+			err := consumererror.NewRetryable(errTooManyAsyncExports, 0)
+			r.refuse(ctxWithReceiverName, ld, err)
+			return nil, receiverhelper.GRPCError(err)
+		}
+		r.asyncWG.Add(1)
+		go func() {
+			defer func() {
+				<-r.asyncSem
+				r.asyncWG.Done()
+			}()
+			// The errors of the next consumer are recorded by obsreport as refused log records.
+			_ = r.sendToNextConsumer(receiverhelper.DetachContext(ctxWithReceiverName), ld)
+		}()
+		return &collectorlog.ExportLogsServiceResponse{}, nil
+	}
+
 	err := r.sendToNextConsumer(ctxWithReceiverName, ld)
 	if err != nil {
-		return nil, err
+		return nil, receiverhelper.GRPCError(err)
 	}
 
 	return &collectorlog.ExportLogsServiceResponse{}, nil
 }
 
+// Wait waits for the data of the acknowledged requests to be passed to the next consumer, it must be called once
+// the server is stopped. It returns the error of the context when it is done first.
+func (r *Receiver) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.asyncWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (r *Receiver) sendToNextConsumer(ctx context.Context, ld pdata.Logs) error {
 	numSpans := ld.LogRecordCount()
 	if numSpans == 0 {
@@ -79,3 +131,9 @@ func (r *Receiver) sendToNextConsumer(ctx context.Context, ld pdata.Logs) error
 
 	return err
 }
+
+// refuse records the data of a request refused before being passed to the next consumer.
+func (r *Receiver) refuse(ctx context.Context, ld pdata.Logs, err error) {
+	ctx = obsreport.StartLogsReceiveOp(ctx, r.instanceName, receiverTransport)
+	obsreport.EndLogsReceiveOp(ctx, dataFormatProtobuf, ld.LogRecordCount(), err)
+}
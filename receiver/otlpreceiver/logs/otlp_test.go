@@ -163,7 +163,7 @@ func otlpReceiverOnGRPCServer(t *testing.T, tc consumer.Logs) (int, func()) {
 		t.Fatalf("Failed to parse host:port from listener address: %s error: %v", ln.Addr(), err)
 	}
 
-	r := New(receiverTagValue, tc)
+	r := New(receiverTagValue, tc, true)
 	require.NoError(t, err)
 
 	// Now run it as a gRPC server
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	collectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	"go.opentelemetry.io/collector/internal/otlpversion"
+)
+
+// exportRequest is decoded by the gRPC codec in place of the ExportMetricsServiceRequest, so that the metrics of
+// newer versions of OTLP are down-converted before being unmarshaled.
+type exportRequest struct {
+	collectormetrics.ExportMetricsServiceRequest
+}
+
+// Unmarshal implements the legacy unmarshaler used by the protobuf codec.
+func (r *exportRequest) Unmarshal(buf []byte) error {
+	return otlpversion.UnmarshalMetrics(buf, &r.ExportMetricsServiceRequest)
+}
+
+// RegisterServer registers the Receiver as the MetricsService of the gRPC server, accepting the metrics of the
+// versions of OTLP newer than the one of the generated protos.
+func RegisterServer(s *grpc.Server, r *Receiver) {
+	s.RegisterService(&serviceDesc, r)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.proto.collector.metrics.v1.MetricsService",
+	HandlerType: (*collectormetrics.MetricsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    exportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/metrics/v1/metrics_service.proto",
+}
+
+func exportHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(exportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(collectormetrics.MetricsServiceServer).Export(ctx, &in.ExportMetricsServiceRequest)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(collectormetrics.MetricsServiceServer).Export(ctx, req.(*collectormetrics.ExportMetricsServiceRequest))
+	}
+	return interceptor(ctx, &in.ExportMetricsServiceRequest, info, handler)
+}
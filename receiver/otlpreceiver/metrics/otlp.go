@@ -16,30 +16,46 @@ package metrics
 
 import (
 	"context"
+	"errors"
+	"sync"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal"
 	collectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 const (
 	dataFormatProtobuf = "protobuf"
+
+	// maxAsyncExports is the maximum number of acknowledged requests whose data is being passed to the next
+	// consumer, the requests are refused with RESOURCE_EXHAUSTED once it is reached.
+	maxAsyncExports = 128
 )
 
+var errTooManyAsyncExports = errors.New("too many acknowledged requests are being processed")
+
 // Receiver is the type used to handle metrics from OpenTelemetry exporters.
 type Receiver struct {
-	instanceName string
-	nextConsumer consumer.Metrics
+	instanceName  string
+	nextConsumer  consumer.Metrics
+	waitForResult bool
+	asyncSem      chan struct{}
+	asyncWG       sync.WaitGroup
 }
 
-// New creates a new Receiver reference.
-func New(instanceName string, nextConsumer consumer.Metrics) *Receiver {
+// New creates a new Receiver reference. If waitForResult is false the requests are acknowledged before the data
+// is passed to the next consumer, and its errors are not returned to the clients.
+func New(instanceName string, nextConsumer consumer.Metrics, waitForResult bool) *Receiver {
 	r := &Receiver{
-		instanceName: instanceName,
-		nextConsumer: nextConsumer,
+		instanceName:  instanceName,
+		nextConsumer:  nextConsumer,
+		waitForResult: waitForResult,
+		asyncSem:      make(chan struct{}, maxAsyncExports),
 	}
 	return r
 }
@@ -54,14 +70,50 @@ func (r *Receiver) Export(ctx context.Context, req *collectormetrics.ExportMetri
 
 	md := pdata.MetricsFromInternalRep(internal.MetricsFromOtlp(req))
 
+	if !r.waitForResult {
+		select {
+		case r.asyncSem <- struct{}{}:
+		default:
+			err := consumererror.NewRetryable(errTooManyAsyncExports, 0)
+			r.refuse(receiverCtx, md, err)
+			return nil, receiverhelper.GRPCError(err)
+		}
+		r.asyncWG.Add(1)
+		go func() {
+			defer func() {
+				<-r.asyncSem
+				r.asyncWG.Done()
+			}()
+			// The errors of the next consumer are recorded by obsreport as refused metric data points.
+			_ = r.sendToNextConsumer(receiverhelper.DetachContext(receiverCtx), md)
+		}()
+		return &collectormetrics.ExportMetricsServiceResponse{}, nil
+	}
+
 	err := r.sendToNextConsumer(receiverCtx, md)
 	if err != nil {
-		return nil, err
+		return nil, receiverhelper.GRPCError(err)
 	}
 
 	return &collectormetrics.ExportMetricsServiceResponse{}, nil
 }
 
+// Wait waits for the data of the acknowledged requests to be passed to the next consumer, it must be called once
+// the server is stopped. It returns the error of the context when it is done first.
+func (r *Receiver) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.asyncWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (r *Receiver) sendToNextConsumer(ctx context.Context, md pdata.Metrics) error {
 	metricCount, dataPointCount := md.MetricAndDataPointCount()
 	if metricCount == 0 {
@@ -78,3 +130,10 @@ func (r *Receiver) sendToNextConsumer(ctx context.Context, md pdata.Metrics) err
 
 	return err
 }
+
+// refuse records the data of a request refused before being passed to the next consumer.
+func (r *Receiver) refuse(ctx context.Context, md pdata.Metrics, err error) {
+	_, dataPointCount := md.MetricAndDataPointCount()
+	ctx = obsreport.StartMetricsReceiveOp(ctx, r.instanceName, receiverTransport)
+	obsreport.EndMetricsReceiveOp(ctx, dataFormatProtobuf, dataPointCount, err)
+}
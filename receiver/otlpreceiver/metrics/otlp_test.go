@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -119,6 +120,52 @@ func TestExport(t *testing.T) {
 	assert.EqualValues(t, metricData, metricSink.AllMetrics()[0])
 }
 
+// rawCodec sends the request bytes as they are and ignores the response.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) { return v.([]byte), nil }
+
+func (rawCodec) Unmarshal([]byte, interface{}) error { return nil }
+
+func (rawCodec) Name() string { return "proto" }
+
+func TestExport_NewerOTLPVersion(t *testing.T) {
+	metricSink := new(consumertest.MetricsSink)
+
+	port, doneFn := otlpReceiverOnGRPCServer(t, metricSink)
+	defer doneFn()
+
+	cc, err := grpc.Dial(fmt.Sprintf(":%d", port), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+
+	// A gauge of NumberDataPoint with an int value and an attribute, as sent by OTLP v0.9.0 and later.
+	message := func(num protowire.Number, fields ...[]byte) []byte {
+		var buf []byte
+		for _, field := range fields {
+			buf = append(buf, field...)
+		}
+		return protowire.AppendBytes(protowire.AppendTag(nil, num, protowire.BytesType), buf)
+	}
+	name := protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "mymetric")
+	value := protowire.AppendFixed64(protowire.AppendTag(nil, 6, protowire.Fixed64Type), 123)
+	attribute := message(7,
+		protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "key1"),
+		message(2, protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "value1")))
+	req := message(1, message(2, message(2, name, message(5, message(1, value, attribute)))))
+
+	err = cc.Invoke(context.Background(), "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export", req, nil, grpc.ForceCodec(rawCodec{}))
+	require.NoError(t, err)
+
+	require.Len(t, metricSink.AllMetrics(), 1)
+	metric := metricSink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "mymetric", metric.Name())
+	require.Equal(t, pdata.MetricDataTypeIntGauge, metric.DataType())
+	dp := metric.IntGauge().DataPoints().At(0)
+	assert.EqualValues(t, 123, dp.Value())
+	assert.Equal(t, pdata.NewStringMap().InitFromMap(map[string]string{"key1": "value1"}), dp.LabelsMap())
+}
+
 func TestExport_EmptyRequest(t *testing.T) {
 	// given
 
@@ -207,10 +254,10 @@ func otlpReceiverOnGRPCServer(t *testing.T, mc consumer.Metrics) (int, func()) {
 	_, port, err := testutil.HostPortFromAddr(ln.Addr())
 	require.NoError(t, err)
 
-	r := New(receiverTagValue, mc)
+	r := New(receiverTagValue, mc, true)
 	// Now run it as a gRPC server
 	srv := obsreport.GRPCServerWithObservabilityEnabled()
-	collectormetrics.RegisterMetricsServiceServer(srv, r)
+	RegisterServer(srv, r)
 	go func() {
 		_ = srv.Serve(ln)
 	}()
@@ -81,7 +81,7 @@ func newOtlpReceiver(cfg *Config, logger *zap.Logger) (*otlpReceiver, error) {
 			OrigName:     true,
 		}
 		r.gatewayMux = gatewayruntime.NewServeMux(
-			gatewayruntime.WithProtoErrorHandler(gatewayruntime.DefaultHTTPProtoErrorHandler),
+			gatewayruntime.WithProtoErrorHandler(protoErrorHandler),
 			gatewayruntime.WithMarshalerOption("application/x-protobuf", &xProtobufMarshaler{}),
 			gatewayruntime.WithMarshalerOption(gatewayruntime.MIMEWildcard, jsonpb),
 		)
@@ -190,6 +190,22 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 
 		r.shutdownWG.Wait()
 
+		// The servers are stopped, wait for the data of the acknowledged requests to reach the next consumers.
+		// They are not waited for once the context is done.
+		var waitErr error
+		if r.traceReceiver != nil {
+			waitErr = r.traceReceiver.Wait(ctx)
+		}
+		if r.metricsReceiver != nil && waitErr == nil {
+			waitErr = r.metricsReceiver.Wait(ctx)
+		}
+		if r.logReceiver != nil && waitErr == nil {
+			waitErr = r.logReceiver.Wait(ctx)
+		}
+		if err == nil {
+			err = waitErr
+		}
+
 		// delete the receiver from the map so it doesn't leak and it becomes possible to create
 		// another instance with the same configuration that functions properly. Notice that an
 		// OTLP object can only be started and shutdown once.
@@ -202,7 +218,7 @@ func (r *otlpReceiver) registerTraceConsumer(ctx context.Context, tc consumer.Tr
 	if tc == nil {
 		return componenterror.ErrNilNextConsumer
 	}
-	r.traceReceiver = trace.New(r.cfg.Name(), tc)
+	r.traceReceiver = trace.New(r.cfg.Name(), tc, r.cfg.WaitForResult)
 	if r.serverGRPC != nil {
 		collectortrace.RegisterTraceServiceServer(r.serverGRPC, r.traceReceiver)
 	}
@@ -221,9 +237,9 @@ func (r *otlpReceiver) registerMetricsConsumer(ctx context.Context, mc consumer.
 	if mc == nil {
 		return componenterror.ErrNilNextConsumer
 	}
-	r.metricsReceiver = metrics.New(r.cfg.Name(), mc)
+	r.metricsReceiver = metrics.New(r.cfg.Name(), mc, r.cfg.WaitForResult)
 	if r.serverGRPC != nil {
-		collectormetrics.RegisterMetricsServiceServer(r.serverGRPC, r.metricsReceiver)
+		metrics.RegisterServer(r.serverGRPC, r.metricsReceiver)
 	}
 	if r.gatewayMux != nil {
 		return collectormetrics.RegisterMetricsServiceHandlerServer(ctx, r.gatewayMux, r.metricsReceiver)
@@ -235,7 +251,7 @@ func (r *otlpReceiver) registerLogsConsumer(ctx context.Context, tc consumer.Log
 	if tc == nil {
 		return componenterror.ErrNilNextConsumer
 	}
-	r.logReceiver = logs.New(r.cfg.Name(), tc)
+	r.logReceiver = logs.New(r.cfg.Name(), tc, r.cfg.WaitForResult)
 	if r.serverGRPC != nil {
 		collectorlog.RegisterLogsServiceServer(r.serverGRPC, r.logReceiver)
 	}
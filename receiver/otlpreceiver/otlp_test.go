@@ -24,6 +24,9 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -35,6 +38,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/collector/component"
@@ -45,10 +49,12 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal"
 	"go.opentelemetry.io/collector/internal/data"
+	collectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
 	collectortrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
 	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
 	otlpresource "go.opentelemetry.io/collector/internal/data/protogen/resource/v1"
@@ -56,6 +62,7 @@ import (
 	"go.opentelemetry.io/collector/internal/internalconsumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/testutil"
 	"go.opentelemetry.io/collector/translator/conventions"
 )
@@ -442,10 +449,27 @@ func testHTTPProtobufRequest(
 	}
 }
 
+func TestXProtobufMarshalerNewerOTLPVersion(t *testing.T) {
+	// A gauge of NumberDataPoint with an int value, as sent by OTLP v0.9.0 and later.
+	dp := protowire.AppendFixed64(protowire.AppendTag(nil, 6, protowire.Fixed64Type), 123)
+	gauge := protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), dp)
+	metric := protowire.AppendBytes(protowire.AppendTag(nil, 5, protowire.BytesType), gauge)
+	ilm := protowire.AppendBytes(protowire.AppendTag(nil, 2, protowire.BytesType), metric)
+	rm := protowire.AppendBytes(protowire.AppendTag(nil, 2, protowire.BytesType), ilm)
+	buf := protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), rm)
+
+	req := &collectormetrics.ExportMetricsServiceRequest{}
+	require.NoError(t, (&xProtobufMarshaler{}).NewDecoder(bytes.NewReader(buf)).Decode(req))
+	dps := req.ResourceMetrics[0].InstrumentationLibraryMetrics[0].Metrics[0].GetIntGauge().GetDataPoints()
+	require.Len(t, dps, 1)
+	assert.EqualValues(t, 123, dps[0].Value)
+}
+
 func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 	tests := []struct {
 		name        string
 		content     string
+		resContent  string
 		encoding    string
 		reqBodyFunc func() (*bytes.Buffer, error)
 		resBodyFunc func() ([]byte, error)
@@ -475,6 +499,44 @@ func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 			},
 			status: 400,
 		},
+		{
+			name:     "JsonGzipTooLarge",
+			content:  "application/json",
+			encoding: "gzip",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressGzip(make([]byte, defaultMaxRequestBodySize+1))
+			},
+			resBodyFunc: func() ([]byte, error) {
+				return json.Marshal(status.New(codes.ResourceExhausted, "request body too large").Proto())
+			},
+			status: 413,
+		},
+		{
+			name:       "JsonCharsetGzipUncompressed",
+			content:    "application/json; charset=utf-8",
+			resContent: "application/json",
+			encoding:   "gzip",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return bytes.NewBuffer([]byte(`{"key": "value"}`)), nil
+			},
+			resBodyFunc: func() ([]byte, error) {
+				return json.Marshal(status.New(codes.InvalidArgument, "gzip: invalid header").Proto())
+			},
+			status: 400,
+		},
+		{
+			name:       "UnknownContentGzipUncompressed",
+			content:    "text/plain",
+			resContent: "application/x-protobuf",
+			encoding:   "gzip",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return bytes.NewBuffer([]byte(`{"key": "value"}`)), nil
+			},
+			resBodyFunc: func() ([]byte, error) {
+				return proto.Marshal(status.New(codes.InvalidArgument, "gzip: invalid header").Proto())
+			},
+			status: 400,
+		},
 	}
 	addr := testutil.GetAvailableLocalAddress(t)
 
@@ -512,12 +574,67 @@ func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 			require.NoError(t, resp.Body.Close(), "Error closing response body")
 
 			require.Equal(t, test.status, resp.StatusCode, "Unexpected return status")
-			require.Equal(t, test.content, resp.Header.Get("Content-Type"), "Unexpected response Content-Type")
+			resContent := test.resContent
+			if resContent == "" {
+				resContent = test.content
+			}
+			require.Equal(t, resContent, resp.Header.Get("Content-Type"), "Unexpected response Content-Type")
 			require.Equal(t, exRespBytes, respBytes, "Unexpected response content")
 		})
 	}
 }
 
+func TestOTLPReceiverCors(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiverName)
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.CorsOrigins = []string{"https://*.example.com"}
+	cfg.HTTP.CorsHeaders = []string{"*"}
+	cfg.HTTP.CorsMaxAge = 7200
+	cfg.GRPC = nil
+	ocr := newReceiver(t, factory, cfg, new(consumertest.TracesSink), nil)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	defer ocr.Shutdown(context.Background())
+
+	url := fmt.Sprintf("http://%s/v1/traces", addr)
+
+	// Wait for the servers to start
+	<-time.After(10 * time.Millisecond)
+
+	req, err := http.NewRequest(http.MethodOptions, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "content-type,x-custom")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Content-Type, X-Custom", resp.Header.Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "7200", resp.Header.Get("Access-Control-Max-Age"))
+
+	// The errors are readable by the browsers and encoded as a Status message.
+	req, err = http.NewRequest(http.MethodPost, url, bytes.NewBufferString(`{"key": "value"}`))
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	errStatus := &spb.Status{}
+	require.NoError(t, json.Unmarshal(respBytes, errStatus))
+	assert.EqualValues(t, codes.InvalidArgument, errStatus.Code)
+}
+
 func TestGRPCNewPortAlreadyUsed(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	ln, err := net.Listen("tcp", addr)
@@ -664,6 +781,84 @@ func TestOTLPReceiverTrace_HandleNextConsumerResponse(t *testing.T) {
 	}
 }
 
+func TestOTLPReceiverBackpressure(t *testing.T) {
+	refusedErr := consumererror.NewRetryable(errors.New("data dropped due to high memory usage"), 1500*time.Millisecond)
+	sink := &internalconsumertest.ErrOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	sink.SetConsumeError(refusedErr)
+
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	grpcReceiver := newGRPCReceiver(t, otlpReceiverName, grpcAddr, sink, nil)
+	require.NoError(t, grpcReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer grpcReceiver.Shutdown(context.Background())
+
+	cc, err := grpc.Dial(grpcAddr, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+
+	_, err = collectortrace.NewTraceServiceClient(cc).Export(context.Background(), createSingleSpanTrace())
+	s, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, s.Code())
+	assert.Equal(t, 1500*time.Millisecond, receiverhelper.RetryDelay(s))
+
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	httpReceiver := newHTTPReceiver(t, httpAddr, sink, nil)
+	require.NoError(t, httpReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer httpReceiver.Shutdown(context.Background())
+
+	resp, err := http.Post(fmt.Sprintf("http://%s/v1/traces", httpAddr), "application/json", bytes.NewBuffer(traceJSON))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Len(t, sink.AllTraces(), 0)
+}
+
+func TestOTLPReceiverUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	dir := t.TempDir()
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName("otlp/uds")
+	cfg.GRPC.NetAddr = confignet.NetAddr{
+		Endpoint:          filepath.Join(dir, "grpc.sock"),
+		Transport:         "unix",
+		SocketPermissions: "0600",
+	}
+	cfg.HTTP.Endpoint = filepath.Join(dir, "http.sock")
+	cfg.HTTP.Transport = "unix"
+	sink := new(consumertest.TracesSink)
+	r := newReceiver(t, factory, cfg, sink, nil)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer r.Shutdown(context.Background())
+
+	info, err := os.Stat(cfg.GRPC.NetAddr.Endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	cc, err := grpc.Dial("unix://"+cfg.GRPC.NetAddr.Endpoint, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+	_, err = collectortrace.NewTraceServiceClient(cc).Export(context.Background(), createSingleSpanTrace())
+	require.NoError(t, err)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", cfg.HTTP.Endpoint)
+			},
+		},
+	}
+	resp, err := client.Post("http://localhost/v1/traces", "application/json", bytes.NewBuffer(traceJSON))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, 2, sink.SpansCount())
+}
+
 func TestGRPCInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		ReceiverSettings: configmodels.ReceiverSettings{
@@ -16,13 +16,23 @@ package otlpreceiver
 
 import (
 	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+
+	collectormetrics "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
+	"go.opentelemetry.io/collector/internal/otlpversion"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 // xProtobufMarshaler is a Marshaler which wraps runtime.ProtoMarshaller
@@ -36,8 +46,45 @@ func (*xProtobufMarshaler) ContentType() string {
 	return "application/x-protobuf"
 }
 
+// Unmarshal unmarshals the protobuf data into the value, down-converting the
+// metrics of newer versions of OTLP.
+func (m *xProtobufMarshaler) Unmarshal(data []byte, value interface{}) error {
+	if req, ok := value.(*collectormetrics.ExportMetricsServiceRequest); ok {
+		return otlpversion.UnmarshalMetrics(data, req)
+	}
+	return m.ProtoMarshaller.Unmarshal(data, value)
+}
+
+// NewDecoder returns a Decoder which reads the protobuf stream from the reader.
+func (m *xProtobufMarshaler) NewDecoder(reader io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(value interface{}) error {
+		buffer, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		return m.Unmarshal(buffer, value)
+	})
+}
+
 var jsonMarshaller = &jsonpb.Marshaler{}
 
+// protoErrorHandler replies to the requests refused to apply backpressure, the
+// RESOURCE_EXHAUSTED statuses, with 503 Service Unavailable and a Retry-After
+// header as required by the OTLP protocol. The other errors are handled by the
+// default grpc-gateway handler.
+func protoErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if s, ok := status.FromError(err); ok && s.Code() == codes.ResourceExhausted {
+		if delay := receiverhelper.RetryDelay(s); delay > 0 {
+			// Retry-After is in seconds, round up so that the clients do not retry too early.
+			w.Header().Set("Retry-After", strconv.FormatInt(int64((delay+time.Second-1)/time.Second), 10))
+		}
+		p := s.Proto()
+		p.Code = int32(codes.Unavailable)
+		err = status.ErrorProto(p)
+	}
+	runtime.DefaultHTTPProtoErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
 // errorHandler encodes the HTTP error message inside a rpc.Status message as required
 // by the OTLP protocol.
 func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusCode int) {
@@ -50,14 +97,21 @@ func errorHandler(w http.ResponseWriter, r *http.Request, errMsg string, statusC
 	fallbackMsg := []byte(`{"code": 13, "message": "failed to marshal error message"}`)
 	fallbackContentType := "application/json"
 
-	if statusCode == http.StatusBadRequest {
+	switch statusCode {
+	case http.StatusBadRequest:
 		s = status.New(codes.InvalidArgument, errMsg)
-	} else {
+	case http.StatusRequestEntityTooLarge:
+		// Same code as the gRPC servers refusing the messages larger than their maximum size.
+		s = status.New(codes.ResourceExhausted, errMsg)
+	default:
 		s = status.New(codes.Internal, errMsg)
 	}
 
-	contentType := r.Header.Get("Content-Type")
-	if contentType == "application/json" {
+	// The browsers send parameters along with the media type, e.g. "application/json; charset=utf-8",
+	// reply with the JSON encoding to all of them and with the protobuf encoding to the others.
+	contentType := "application/x-protobuf"
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+		contentType = mediaType
 		buf := new(bytes.Buffer)
 		err = jsonMarshaller.Marshal(buf, s.Proto())
 		msg = buf.Bytes()
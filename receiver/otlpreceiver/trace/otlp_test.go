@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -33,6 +36,7 @@ import (
 	collectortrace "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
 	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/testutil"
 )
 
@@ -137,6 +141,95 @@ func TestExport_ErrorConsumer(t *testing.T) {
 	assert.Nil(t, resp)
 }
 
+func TestExport_DoNotWaitForResult(t *testing.T) {
+	req := &collectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{
+			{
+				InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+					{
+						Spans: []*otlptrace.Span{
+							{
+								Name: "operationB",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The data is consumed after the context of the request is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	traceSink := new(consumertest.TracesSink)
+	r := New(receiverTagValue, traceSink, false)
+	resp, err := r.Export(ctx, req)
+	cancel()
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	require.NoError(t, r.Wait(context.Background()))
+	assert.Equal(t, 1, traceSink.SpansCount())
+
+	// The errors of the next consumer are not returned.
+	r = New(receiverTagValue, consumertest.NewTracesErr(errors.New("my error")), false)
+	resp, err = r.Export(context.Background(), req)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	require.NoError(t, r.Wait(context.Background()))
+}
+
+// blockingConsumer blocks until it is released.
+type blockingConsumer struct {
+	release chan struct{}
+}
+
+func (bc *blockingConsumer) ConsumeTraces(context.Context, pdata.Traces) error {
+	<-bc.release
+	return nil
+}
+
+func TestExport_DoNotWaitForResultLimit(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	req := &collectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{
+			{
+				InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{
+					{
+						Spans: []*otlptrace.Span{
+							{
+								Name: "operationB",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	bc := &blockingConsumer{release: make(chan struct{})}
+	r := New(receiverTagValue, bc, false)
+	for i := 0; i < maxAsyncExports; i++ {
+		_, err = r.Export(context.Background(), req)
+		require.NoError(t, err)
+	}
+
+	// The next request is refused until the data of an acknowledged request is consumed.
+	_, err = r.Export(context.Background(), req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	obsreporttest.CheckReceiverTracesViews(t, receiverTagValue, receiverTransport, 0, 1)
+
+	// The receiver is not drained before the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, r.Wait(ctx))
+
+	close(bc.release)
+	require.NoError(t, r.Wait(context.Background()))
+	obsreporttest.CheckReceiverTracesViews(t, receiverTagValue, receiverTransport, maxAsyncExports, 1)
+}
+
 func makeTraceServiceClient(port int) (collectortrace.TraceServiceClient, func(), error) {
 	addr := fmt.Sprintf(":%d", port)
 	cc, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
@@ -167,7 +260,7 @@ func otlpReceiverOnGRPCServer(t *testing.T, tc consumer.Traces) (int, func()) {
 		t.Fatalf("Failed to parse host:port from listener address: %s error: %v", ln.Addr(), err)
 	}
 
-	r := New(receiverTagValue, tc)
+	r := New(receiverTagValue, tc, true)
 	require.NoError(t, err)
 
 	// Now run it as a gRPC server
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"sort"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/prometheus/prometheus/pkg/exemplar"
+	"github.com/prometheus/prometheus/pkg/labels"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/internaldata"
+)
+
+var (
+	errExemplarWithoutSample = errors.New("exemplar does not follow a sample of the same metric family")
+	errExemplarNotSupported  = errors.New("exemplars are only supported for counters and histogram buckets")
+	errExemplarNoBucket      = errors.New("exemplar does not match any bucket of the histogram")
+)
+
+// exemplarToOC converts an OpenMetrics exemplar into an OC exemplar, all the exemplar labels including the
+// trace_id and span_id are kept as attachments and turned into the pdata trace context by the OC translator.
+func exemplarToOC(e exemplar.Exemplar) *metricspb.DistributionValue_Exemplar {
+	ocExemplar := &metricspb.DistributionValue_Exemplar{Value: e.Value}
+	if e.HasTs {
+		ocExemplar.Timestamp = timestampFromMs(e.Ts)
+	}
+	if len(e.Labels) != 0 {
+		ocExemplar.Attachments = e.Labels.Map()
+	}
+	return ocExemplar
+}
+
+// seriesKey identifies a single series of a metric family, the same key is computed from the scraped labels and
+// from the labels of the data point once converted to pdata.
+func seriesKey(metricName string, ls labels.Labels) string {
+	keys := make([]string, 0, len(ls))
+	for _, l := range ls {
+		keys = append(keys, l.Name)
+	}
+	sort.Strings(keys)
+	return metricName + dpgSignature(keys, ls)
+}
+
+// usefulLabels returns the labels that are kept on the data points of the given metric type.
+func usefulLabels(mType metricspb.MetricDescriptor_Type, ls labels.Labels) labels.Labels {
+	useful := make(labels.Labels, 0, len(ls))
+	for _, l := range ls {
+		if isUsefulLabel(mType, l.Name) {
+			useful = append(useful, l)
+		}
+	}
+	return useful
+}
+
+// addSumExemplars attaches the exemplars collected for counters to the matching DoubleSum data points. Counters
+// exemplars cannot be carried by the OC data model, so they are added after the translation to pdata.
+func addSumExemplars(md pdata.Metrics, exemplars map[string][]exemplar.Exemplar) {
+	if len(exemplars) == 0 {
+		return
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if metric.DataType() != pdata.MetricDataTypeDoubleSum {
+					continue
+				}
+				dps := metric.DoubleSum().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dp := dps.At(l)
+					lm := make(map[string]string, dp.LabelsMap().Len())
+					dp.LabelsMap().ForEach(func(k string, v string) {
+						lm[k] = v
+					})
+					key := seriesKey(metric.Name(), labels.FromMap(lm))
+					for _, e := range exemplars[key] {
+						pe := pdata.NewDoubleExemplar()
+						internaldata.OCExemplarToMetrics(exemplarToOC(e), pe)
+						dp.Exemplars().Append(pe)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/exemplar"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/internaldata"
+)
+
+var testExemplar = exemplar.Exemplar{
+	Labels: labels.FromStrings("trace_id", "0102030405060708090a0b0c0d0e0f10", "span_id", "0102030405060708", "foo", "bar"),
+	Value:  1.5,
+	HasTs:  true,
+	Ts:     startTs,
+}
+
+func assertTestExemplar(t *testing.T, got pdata.DoubleExemplar) {
+	assert.EqualValues(t, 1.5, got.Value())
+	assert.Equal(t, pdata.TimestampFromTime(timestampFromMs(startTs).AsTime()), got.Timestamp())
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", got.TraceID().HexString())
+	assert.Equal(t, "0102030405060708", got.SpanID().HexString())
+	assert.Equal(t, pdata.NewStringMap().InitFromMap(map[string]string{"foo": "bar"}), got.FilteredLabels())
+}
+
+func Test_metricBuilder_histogramExemplar(t *testing.T) {
+	b := newMetricBuilder(newMockMetadataCache(testMetadata), true, "", testLogger)
+	b.startTime = defaultBuilderStartTime
+	require.NoError(t, b.AddDataPoint(createLabels("hist_test", "foo", "bar", "le", "10"), startTs, 1))
+	require.NoError(t, b.AddDataPoint(createLabels("hist_test", "foo", "bar", "le", "20"), startTs, 2))
+	require.NoError(t, b.AddExemplar(createLabels("hist_test_bucket", "foo", "bar", "le", "20"), testExemplar))
+	require.NoError(t, b.AddDataPoint(createLabels("hist_test", "foo", "bar", "le", "+inf"), startTs, 3))
+	require.NoError(t, b.AddDataPoint(createLabels("hist_test_sum", "foo", "bar"), startTs, 50))
+	require.NoError(t, b.AddDataPoint(createLabels("hist_test_count", "foo", "bar"), startTs, 3))
+	assert.Equal(t, errExemplarNotSupported, b.AddExemplar(createLabels("hist_test_sum", "foo", "bar"), testExemplar))
+
+	metrics, _, _, err := b.Build()
+	require.NoError(t, err)
+	md := internaldata.OCToMetrics(internaldata.MetricsData{Metrics: metrics})
+	dp := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleHistogram().DataPoints().At(0)
+	require.Equal(t, 1, dp.Exemplars().Len())
+	assertTestExemplar(t, dp.Exemplars().At(0))
+}
+
+func Test_metricBuilder_counterExemplar(t *testing.T) {
+	b := newMetricBuilder(newMockMetadataCache(testMetadata), true, "", testLogger)
+	b.startTime = defaultBuilderStartTime
+	require.NoError(t, b.AddDataPoint(createLabels("counter_test", "foo", "bar"), startTs, 100))
+	require.NoError(t, b.AddExemplar(createLabels("counter_test", "foo", "bar"), testExemplar))
+	require.NoError(t, b.AddDataPoint(createLabels("counter_test", "foo", "other"), startTs, 50))
+	require.NoError(t, b.AddDataPoint(createLabels("gauge_test", "foo", "bar"), startTs, 10))
+	assert.Equal(t, errExemplarNotSupported, b.AddExemplar(createLabels("gauge_test", "foo", "bar"), testExemplar))
+
+	metrics, _, _, err := b.Build()
+	require.NoError(t, err)
+	md := internaldata.OCToMetrics(internaldata.MetricsData{Metrics: metrics})
+	addSumExemplars(md, b.sumExemplars)
+
+	dps := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).DoubleSum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	require.Equal(t, 1, dps.At(0).Exemplars().Len())
+	assertTestExemplar(t, dps.At(0).Exemplars().At(0))
+	assert.Equal(t, 0, dps.At(1).Exemplars().Len())
+}
+
+func Test_metricBuilder_exemplarWithoutSample(t *testing.T) {
+	b := newMetricBuilder(newMockMetadataCache(testMetadata), true, "", testLogger)
+	assert.Equal(t, errExemplarWithoutSample, b.AddExemplar(createLabels("counter_test", "foo", "bar"), testExemplar))
+	assert.Equal(t, errMetricNameNotFound, b.AddExemplar(labels.FromStrings("a", "b"), testExemplar))
+
+	require.NoError(t, b.AddDataPoint(createLabels("counter_test", "foo", "bar"), startTs, 100))
+	assert.Equal(t, errExemplarWithoutSample, b.AddExemplar(createLabels("counter_test", "foo", "other"), testExemplar))
+	assert.Equal(t, errExemplarWithoutSample, b.AddExemplar(createLabels("counter_test2", "foo", "bar"), testExemplar))
+}
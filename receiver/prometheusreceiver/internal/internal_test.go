@@ -46,6 +46,10 @@ func (m *mockMetadataCache) SharedLabels() labels.Labels {
 	return labels.FromStrings("__scheme__", "http")
 }
 
+func (m *mockMetadataCache) TargetLabels() labels.Labels {
+	return labels.FromStrings("job", "test", "instance", "localhost:8080")
+}
+
 type mockScrapeManager struct {
 	targets map[string][]*scrape.Target
 }
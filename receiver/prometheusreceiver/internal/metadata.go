@@ -26,6 +26,7 @@ import (
 type MetadataCache interface {
 	Metadata(metricName string) (scrape.MetricMetadata, bool)
 	SharedLabels() labels.Labels
+	TargetLabels() labels.Labels
 }
 
 type ScrapeManager interface {
@@ -64,3 +65,8 @@ func (m *mCache) Metadata(metricName string) (scrape.MetricMetadata, bool) {
 func (m *mCache) SharedLabels() labels.Labels {
 	return m.t.DiscoveredLabels()
 }
+
+// TargetLabels returns the labels of the target after relabeling, the labels prefixed by __ are not included.
+func (m *mCache) TargetLabels() labels.Labels {
+	return m.t.Labels()
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/prometheus/prometheus/pkg/exemplar"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/pkg/textparse"
 	"github.com/prometheus/prometheus/scrape"
@@ -30,8 +31,11 @@ import (
 // a single scrape.
 type MetricFamily interface {
 	Add(metricName string, ls labels.Labels, t int64, v float64) error
+	AddExemplar(metricName string, ls labels.Labels, e exemplar.Exemplar) error
 	IsSameFamily(metricName string) bool
 	ToMetric() (*metricspb.Metric, int, int)
+	// SumExemplars returns the exemplars of the counters series keyed by seriesKey.
+	SumExemplars() map[string][]exemplar.Exemplar
 }
 
 type metricFamily struct {
@@ -44,6 +48,7 @@ type metricFamily struct {
 	metadata          *scrape.MetricMetadata
 	groupOrders       map[string]int
 	groups            map[string]*metricGroup
+	sumExemplars      map[string][]exemplar.Exemplar
 }
 
 func newMetricFamily(metricName string, mc MetadataCache) MetricFamily {
@@ -180,6 +185,48 @@ func (mf *metricFamily) Add(metricName string, ls labels.Labels, t int64, v floa
 	return nil
 }
 
+// AddExemplar attaches an exemplar to the series of the last added sample. Exemplars of histograms are attached to the
+// bucket matching the le label, exemplars of counters are kept aside until the family is converted to pdata.
+func (mf *metricFamily) AddExemplar(metricName string, ls labels.Labels, e exemplar.Exemplar) error {
+	switch mf.mtype {
+	case metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION:
+		if !strings.HasSuffix(metricName, metricsSuffixBucket) {
+			return errExemplarNotSupported
+		}
+		mg, ok := mf.groups[mf.getGroupKey(ls)]
+		if !ok {
+			return errExemplarWithoutSample
+		}
+		boundary, err := getBoundary(mf.mtype, ls)
+		if err != nil {
+			return err
+		}
+		for _, dp := range mg.complexValue {
+			if dp.boundary == boundary {
+				dp.exemplar = &e
+				return nil
+			}
+		}
+		return errExemplarNoBucket
+	case metricspb.MetricDescriptor_CUMULATIVE_DOUBLE:
+		if _, ok := mf.groups[mf.getGroupKey(ls)]; !ok {
+			return errExemplarWithoutSample
+		}
+		if mf.sumExemplars == nil {
+			mf.sumExemplars = make(map[string][]exemplar.Exemplar)
+		}
+		key := seriesKey(mf.name, usefulLabels(mf.mtype, ls))
+		mf.sumExemplars[key] = append(mf.sumExemplars[key], e)
+		return nil
+	default:
+		return errExemplarNotSupported
+	}
+}
+
+func (mf *metricFamily) SumExemplars() map[string][]exemplar.Exemplar {
+	return mf.sumExemplars
+}
+
 func (mf *metricFamily) ToMetric() (*metricspb.Metric, int, int) {
 	timeseries := make([]*metricspb.TimeSeries, 0, len(mf.groups))
 	switch mf.mtype {
@@ -237,6 +284,7 @@ func (mf *metricFamily) ToMetric() (*metricspb.Metric, int, int) {
 type dataPoint struct {
 	value    float64
 	boundary float64
+	exemplar *exemplar.Exemplar
 }
 
 // metricGroup, represents a single metric of a metric family. for example a histogram metric is usually represent by
@@ -279,6 +327,9 @@ func (mg *metricGroup) toDistributionTimeSeries(orderedLabelKeys []string) *metr
 			adjustedCount -= mg.complexValue[i-1].value
 		}
 		buckets[i] = &metricspb.DistributionValue_Bucket{Count: int64(adjustedCount)}
+		if mg.complexValue[i].exemplar != nil {
+			buckets[i].Exemplar = exemplarToOC(*mg.complexValue[i].exemplar)
+		}
 	}
 
 	dv := &metricspb.DistributionValue{
@@ -90,6 +90,15 @@ func (tsm *timeseriesMap) get(
 	return tsi
 }
 
+// Remove the timeseries reported stale, so that they restart from a new initial point if they reappear.
+func (tsm *timeseriesMap) removeStale(signatures map[string]struct{}) {
+	tsm.Lock()
+	defer tsm.Unlock()
+	for sig := range signatures {
+		delete(tsm.tsiMap, sig)
+	}
+}
+
 // Remove timeseries that have aged out.
 func (tsm *timeseriesMap) gc() {
 	tsm.Lock()
@@ -231,6 +231,32 @@ func Test_emptyLabels(t *testing.T) {
 	runScript(t, NewJobsMap(time.Minute).get("job", "0"), script)
 }
 
+func Test_staleTimeseries(t *testing.T) {
+	script1 := []*metricsAdjusterTest{{
+		"Stale: round 1 - initial instance, adjusted should be empty",
+		[]*metricspb.Metric{mtu.Cumulative(c1, k1k2, mtu.Timeseries(t1Ms, v1v2, mtu.Double(t1Ms, 44)))},
+		[]*metricspb.Metric{},
+	}, {
+		"Stale: round 2 - instance adjusted based on round 1",
+		[]*metricspb.Metric{mtu.Cumulative(c1, k1k2, mtu.Timeseries(t2Ms, v1v2, mtu.Double(t2Ms, 66)))},
+		[]*metricspb.Metric{mtu.Cumulative(c1, k1k2, mtu.Timeseries(t1Ms, v1v2, mtu.Double(t2Ms, 22)))},
+	}}
+	script2 := []*metricsAdjusterTest{{
+		"Stale: round 3 - instance reappearing after being stale, adjusted should be empty",
+		[]*metricspb.Metric{mtu.Cumulative(c1, k1k2, mtu.Timeseries(t3Ms, v1v2, mtu.Double(t3Ms, 88)))},
+		[]*metricspb.Metric{},
+	}, {
+		"Stale: round 4 - instance adjusted based on round 3",
+		[]*metricspb.Metric{mtu.Cumulative(c1, k1k2, mtu.Timeseries(t4Ms, v1v2, mtu.Double(t4Ms, 99)))},
+		[]*metricspb.Metric{mtu.Cumulative(c1, k1k2, mtu.Timeseries(t3Ms, v1v2, mtu.Double(t4Ms, 11)))},
+	}}
+
+	tsm := NewJobsMap(time.Minute).get("job", "0")
+	runScript(t, tsm, script1)
+	tsm.removeStale(map[string]struct{}{getTimeseriesSignature(c1, mtu.Timeseries(t1Ms, v1v2, nil).GetLabelValues()): {}})
+	runScript(t, tsm, script2)
+}
+
 func Test_tsGC(t *testing.T) {
 	script1 := []*metricsAdjusterTest{{
 		"TsGC: round 1 - initial instances, adjusted should be empty",
@@ -23,6 +23,7 @@ import (
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/exemplar"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/pkg/textparse"
 	"go.uber.org/zap"
@@ -56,6 +57,11 @@ type metricBuilder struct {
 	startTime            float64
 	logger               *zap.Logger
 	currentMf            MetricFamily
+	sumExemplars         map[string][]exemplar.Exemplar
+	// scrapeFailed is true when the up metric reports that the target could not be scraped.
+	scrapeFailed bool
+	// staleSignatures are the signatures of the timeseries reported stale by the scrape loop.
+	staleSignatures map[string]struct{}
 }
 
 // newMetricBuilder creates a MetricBuilder which is allowed to feed all the datapoints from a single prometheus
@@ -101,6 +107,7 @@ func (b *metricBuilder) AddDataPoint(ls labels.Labels, t int64, v float64) error
 		// up: 1 if the instance is healthy, i.e. reachable, or 0 if the scrape failed.
 		if metricName == scrapeUpMetricName && v != 1.0 {
 			if v == 0.0 {
+				b.scrapeFailed = true
 				b.logger.Warn("Failed to scrape Prometheus endpoint",
 					zap.Int64("scrape_timestamp", t),
 					zap.String("target_labels", fmt.Sprintf("%v", lm)))
@@ -119,12 +126,7 @@ func (b *metricBuilder) AddDataPoint(ls labels.Labels, t int64, v float64) error
 	b.hasData = true
 
 	if b.currentMf != nil && !b.currentMf.IsSameFamily(metricName) {
-		m, ts, dts := b.currentMf.ToMetric()
-		b.numTimeseries += ts
-		b.droppedTimeseries += dts
-		if m != nil {
-			b.metrics = append(b.metrics, m)
-		}
+		b.flushCurrentFamily()
 		b.currentMf = newMetricFamily(metricName, b.mc)
 	} else if b.currentMf == nil {
 		b.currentMf = newMetricFamily(metricName, b.mc)
@@ -137,25 +139,69 @@ func (b *metricBuilder) AddDataPoint(ls labels.Labels, t int64, v float64) error
 // The only error returned by this function is errNoDataToBuild.
 func (b *metricBuilder) Build() ([]*metricspb.Metric, int, int, error) {
 	if !b.hasData {
-		if b.hasInternalMetric {
+		if b.hasInternalMetric || len(b.staleSignatures) > 0 {
 			return make([]*metricspb.Metric, 0), 0, 0, nil
 		}
 		return nil, 0, 0, errNoDataToBuild
 	}
 
 	if b.currentMf != nil {
-		m, ts, dts := b.currentMf.ToMetric()
-		b.numTimeseries += ts
-		b.droppedTimeseries += dts
-		if m != nil {
-			b.metrics = append(b.metrics, m)
-		}
+		b.flushCurrentFamily()
 		b.currentMf = nil
 	}
 
 	return b.metrics, b.numTimeseries, b.droppedTimeseries, nil
 }
 
+// AddStaleMarker is for feeding the staleness markers, reported by the scrape loop for the timeseries that the target
+// no longer exposes or that could not be scraped. The marker is not a data point, the timeseries signature is recorded
+// so that the timeseries restarts from a new initial point if it reappears.
+func (b *metricBuilder) AddStaleMarker(ls labels.Labels) {
+	metricName := ls.Get(model.MetricNameLabel)
+	if metricName == "" || isInternalMetric(metricName) {
+		return
+	}
+	mf := newMetricFamily(metricName, b.mc).(*metricFamily)
+	// The labels are sorted by name, as the label keys of the metric family.
+	values := make([]*metricspb.LabelValue, 0, len(ls))
+	for _, l := range ls {
+		if isUsefulLabel(mf.mtype, l.Name) {
+			values = append(values, &metricspb.LabelValue{Value: l.Value, HasValue: true})
+		}
+	}
+	if b.staleSignatures == nil {
+		b.staleSignatures = make(map[string]struct{})
+	}
+	b.staleSignatures[getTimeseriesSignature(mf.name, values)] = struct{}{}
+}
+
+// AddExemplar is for feeding the exemplar of the data point which was added last.
+func (b *metricBuilder) AddExemplar(ls labels.Labels, e exemplar.Exemplar) error {
+	metricName := ls.Get(model.MetricNameLabel)
+	if metricName == "" {
+		return errMetricNameNotFound
+	}
+	if b.currentMf == nil || !b.currentMf.IsSameFamily(metricName) {
+		return errExemplarWithoutSample
+	}
+	return b.currentMf.AddExemplar(metricName, ls, e)
+}
+
+func (b *metricBuilder) flushCurrentFamily() {
+	m, ts, dts := b.currentMf.ToMetric()
+	b.numTimeseries += ts
+	b.droppedTimeseries += dts
+	if m != nil {
+		b.metrics = append(b.metrics, m)
+	}
+	for k, es := range b.currentMf.SumExemplars() {
+		if b.sumExemplars == nil {
+			b.sumExemplars = make(map[string][]exemplar.Exemplar)
+		}
+		b.sumExemplars[k] = append(b.sumExemplars[k], es...)
+	}
+}
+
 // TODO: move the following helper functions to a proper place, as they are not called directly in this go file
 
 func isUsefulLabel(mType metricspb.MetricDescriptor_Type, labelKey string) bool {
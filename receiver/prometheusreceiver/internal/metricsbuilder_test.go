@@ -1138,6 +1138,41 @@ func Test_metricBuilder_skipped(t *testing.T) {
 	runBuilderTests(t, tests)
 }
 
+func Test_metricBuilder_staleMarkers(t *testing.T) {
+	mc := newMockMetadataCache(testMetadata)
+	b := newMetricBuilder(mc, true, "", testLogger)
+	b.startTime = defaultBuilderStartTime
+	pts := []*testDataPoint{
+		createDataPoint("counter_test", 100, "foo", "bar", model.JobLabel, "job", model.InstanceLabel, "instance"),
+		createDataPoint("hist_test_bucket", 1, "foo", "bar", "le", "10"),
+		createDataPoint("hist_test_bucket", 2, "foo", "bar", "le", "+Inf"),
+		createDataPoint("hist_test_sum", 99, "foo", "bar"),
+		createDataPoint("hist_test_count", 2, "foo", "bar"),
+	}
+	for _, pt := range pts {
+		assert.NoError(t, b.AddDataPoint(pt.lb, startTs, pt.v))
+	}
+	metrics, _, _, err := b.Build()
+	assert.NoError(t, err)
+	wantSignatures := map[string]struct{}{}
+	for _, metric := range metrics {
+		for _, ts := range metric.GetTimeseries() {
+			wantSignatures[getTimeseriesSignature(metric.GetMetricDescriptor().GetName(), ts.GetLabelValues())] = struct{}{}
+		}
+	}
+
+	b = newMetricBuilder(mc, true, "", testLogger)
+	for _, pt := range pts {
+		b.AddStaleMarker(pt.lb)
+	}
+	b.AddStaleMarker(createLabels("up"))
+	assert.Equal(t, wantSignatures, b.staleSignatures)
+
+	metrics, _, _, err = b.Build()
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
 func Test_metricBuilder_baddata(t *testing.T) {
 	t.Run("empty-metric-name", func(t *testing.T) {
 		mc := newMockMetadataCache(testMetadata)
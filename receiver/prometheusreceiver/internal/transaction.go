@@ -20,18 +20,22 @@ import (
 	"math"
 	"net"
 	"sync/atomic"
+	"time"
 
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/exemplar"
 	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/value"
 	"github.com/prometheus/prometheus/storage"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"go.opentelemetry.io/collector/translator/internaldata"
 )
 
@@ -39,6 +43,10 @@ const (
 	portAttr   = "port"
 	schemeAttr = "scheme"
 
+	// targetInfoMetricName is the name of the metric whose labels are the attributes of the resource, following the
+	// Prometheus compatibility specification. It is not reported as a metric.
+	targetInfoMetricName = "target_info"
+
 	transport  = "http"
 	dataformat = "prometheus"
 )
@@ -47,6 +55,7 @@ var errMetricNameNotFound = errors.New("metricName not found from labels")
 var errTransactionAborted = errors.New("transaction aborted")
 var errNoJobInstance = errors.New("job or instance cannot be found from labels")
 var errNoStartTimeMetrics = errors.New("process_start_time_seconds metric is missing")
+var errScrapeFailed = errors.New("failed to scrape the target")
 
 // A transaction is corresponding to an individual scrape operation or stale report.
 // That said, whenever prometheus receiver scrapped a target metric endpoint a page of raw metrics is returned,
@@ -70,6 +79,8 @@ type transaction struct {
 	resource             *resourcepb.Resource
 	metricBuilder        *metricBuilder
 	logger               *zap.Logger
+	// startTime is the time the scrape started, the transaction being created before the target is scraped.
+	startTime time.Time
 }
 
 func newTransaction(ctx context.Context, jobsMap *JobsMap, useStartTimeMetric bool, startTimeMetricRegex string, receiverName string, ms *metadataService, sink consumer.Metrics, logger *zap.Logger) *transaction {
@@ -84,6 +95,7 @@ func newTransaction(ctx context.Context, jobsMap *JobsMap, useStartTimeMetric bo
 		receiverName:         receiverName,
 		ms:                   ms,
 		logger:               logger,
+		startTime:            time.Now(),
 	}
 }
 
@@ -96,7 +108,7 @@ func (tr *transaction) Add(ls labels.Labels, t int64, v float64) (uint64, error)
 	// scrape the remote target,  if the previous scrape was success and some data were cached internally
 	// in our case, we don't need these data, simply drop them shall be good enough. more details:
 	// https://github.com/prometheus/prometheus/blob/851131b0740be7291b98f295567a97f32fffc655/scrape/scrape.go#L933-L935
-	if math.IsNaN(v) {
+	if math.IsNaN(v) && !value.IsStaleNaN(v) {
 		return 0, nil
 	}
 
@@ -111,6 +123,14 @@ func (tr *transaction) Add(ls labels.Labels, t int64, v float64) (uint64, error)
 			return 0, err
 		}
 	}
+	if value.IsStaleNaN(v) {
+		tr.metricBuilder.AddStaleMarker(ls)
+		return 0, nil
+	}
+	if !tr.isNew && ls.Get(model.MetricNameLabel) == targetInfoMetricName {
+		tr.addTargetInfo(ls)
+		return 0, nil
+	}
 	return 0, tr.metricBuilder.AddDataPoint(ls, t, v)
 }
 
@@ -119,6 +139,21 @@ func (tr *transaction) AddFast(_ uint64, _ int64, _ float64) error {
 	return storage.ErrNotFound
 }
 
+// AppendExemplar adds the exemplar of the series which was added last, it follows the prometheus ExemplarAppender
+// contract: the exemplar is only kept when it belongs to a counter or to a histogram bucket.
+func (tr *transaction) AppendExemplar(ref uint64, ls labels.Labels, e exemplar.Exemplar) (uint64, error) {
+	select {
+	case <-tr.ctx.Done():
+		return 0, errTransactionAborted
+	default:
+	}
+
+	if tr.isNew {
+		return 0, errExemplarWithoutSample
+	}
+	return ref, tr.metricBuilder.AddExemplar(ls, e)
+}
+
 func (tr *transaction) initTransaction(ls labels.Labels) error {
 	job, instance := ls.Get(model.JobLabel), ls.Get(model.InstanceLabel)
 	if job == "" || instance == "" {
@@ -129,17 +164,27 @@ func (tr *transaction) initTransaction(ls labels.Labels) error {
 	if err != nil {
 		return err
 	}
-	if tr.jobsMap != nil {
-		tr.job = job
-		tr.instance = instance
-	}
-	tr.node, tr.resource = createNodeAndResource(job, instance, mc.SharedLabels().Get(model.SchemeLabel))
+	tr.job = job
+	tr.instance = instance
+	tr.node, tr.resource = createNodeAndResource(job, instance, mc.SharedLabels().Get(model.SchemeLabel), mc.TargetLabels())
 	tr.metricBuilder = newMetricBuilder(mc, tr.useStartTimeMetric, tr.startTimeMetricRegex, tr.logger)
 	tr.isNew = false
 	return nil
 }
 
 // submit metrics data to consumers
+// addTargetInfo copies the labels of the target_info metric onto the resource, job and instance already being
+// mapped to the node.
+func (tr *transaction) addTargetInfo(ls labels.Labels) {
+	for _, l := range ls {
+		switch l.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel:
+		default:
+			tr.resource.Labels[l.Name] = l.Value
+		}
+	}
+}
+
 func (tr *transaction) Commit() error {
 	if tr.isNew {
 		// In a situation like not able to connect to the remote server, scrapeloop will still commit even if it had
@@ -147,10 +192,25 @@ func (tr *transaction) Commit() error {
 		return nil
 	}
 
+	// A failed scrape reports all the timeseries of the previous scrape as stale, they are only restarted when the
+	// target stops reporting them.
+	if !tr.useStartTimeMetric && !tr.metricBuilder.scrapeFailed && len(tr.metricBuilder.staleSignatures) > 0 {
+		tr.jobsMap.get(tr.job, tr.instance).removeStale(tr.metricBuilder.staleSignatures)
+	}
+
+	// The scrape of each job is reported as a scraper of the receiver.
+	scrapeCtx := obsreport.ScraperContext(tr.ctx, tr.receiverName, tr.job)
+	scrapeCtx = obsreport.StartMetricsScrapeOpAt(scrapeCtx, tr.receiverName, tr.job, tr.startTime)
+	var scrapeErr error
+	if tr.metricBuilder.scrapeFailed {
+		scrapeErr = errScrapeFailed
+	}
+
 	ctx := obsreport.StartMetricsReceiveOp(tr.ctx, tr.receiverName, transport)
 	metrics, _, _, err := tr.metricBuilder.Build()
 	if err != nil {
 		// Only error by Build() is errNoDataToBuild, with numReceivedPoints set to zero.
+		obsreport.EndMetricsScrapeOp(scrapeCtx, 0, scrapeErr)
 		obsreport.EndMetricsReceiveOp(ctx, dataformat, 0, err)
 		return err
 	}
@@ -162,6 +222,7 @@ func (tr *transaction) Commit() error {
 			// Since we are unable to adjust metrics properly, we will drop them
 			// and return an error.
 			err = errNoStartTimeMetrics
+			obsreport.EndMetricsScrapeOp(scrapeCtx, 0, err)
 			obsreport.EndMetricsReceiveOp(ctx, dataformat, 0, err)
 			return err
 		}
@@ -180,8 +241,12 @@ func (tr *transaction) Commit() error {
 			Resource: tr.resource,
 			Metrics:  metrics,
 		})
+		addSumExemplars(md, tr.metricBuilder.sumExemplars)
 		_, numPoints = md.MetricAndDataPointCount()
+		obsreport.EndMetricsScrapeOp(scrapeCtx, numPoints, scrapeErr)
 		err = tr.sink.ConsumeMetrics(ctx, md)
+	} else {
+		obsreport.EndMetricsScrapeOp(scrapeCtx, 0, scrapeErr)
 	}
 	obsreport.EndMetricsReceiveOp(ctx, dataformat, numPoints, err)
 	return err
@@ -214,7 +279,9 @@ func timestampFromFloat64(ts float64) *timestamppb.Timestamp {
 	}
 }
 
-func createNodeAndResource(job, instance, scheme string) (*commonpb.Node, *resourcepb.Resource) {
+// createNodeAndResource maps the target onto the node and the resource: job becomes the service name, instance the
+// service instance id and the host name, and the other labels of the target become resource labels.
+func createNodeAndResource(job, instance, scheme string, targetLabels labels.Labels) (*commonpb.Node, *resourcepb.Resource) {
 	host, port, err := net.SplitHostPort(instance)
 	if err != nil {
 		host = instance
@@ -226,10 +293,15 @@ func createNodeAndResource(job, instance, scheme string) (*commonpb.Node, *resou
 		},
 	}
 	resource := &resourcepb.Resource{
-		Labels: map[string]string{
-			portAttr:   port,
-			schemeAttr: scheme,
-		},
+		Labels: make(map[string]string, len(targetLabels)+2),
+	}
+	for _, l := range targetLabels {
+		if l.Name != model.JobLabel && l.Name != model.InstanceLabel {
+			resource.Labels[l.Name] = l.Value
+		}
 	}
+	resource.Labels[conventions.AttributeServiceInstance] = instance
+	resource.Labels[portAttr] = port
+	resource.Labels[schemeAttr] = scheme
 	return node, resource
 }
@@ -21,11 +21,16 @@ import (
 	"time"
 
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/exemplar"
 	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/value"
 	"github.com/prometheus/prometheus/scrape"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/translator/internaldata"
 )
 
@@ -111,7 +116,7 @@ func Test_transaction(t *testing.T) {
 		if got := tr.Commit(); got != nil {
 			t.Errorf("expecting nil from Commit() but got err %v", got)
 		}
-		expectedNode, expectedResource := createNodeAndResource("test", "localhost:8080", "http")
+		expectedNode, expectedResource := createNodeAndResource("test", "localhost:8080", "http", labels.FromStrings("job", "test", "instance", "localhost:8080"))
 		mds := sink.AllMetrics()
 		if len(mds) != 1 {
 			t.Fatalf("wanted one batch, got %v\n", sink.AllMetrics())
@@ -131,6 +136,39 @@ func Test_transaction(t *testing.T) {
 		// assert.Len(t, ocmds[0].Metrics, 1)
 	})
 
+	t.Run("Scrape reported", func(t *testing.T) {
+		doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+		require.NoError(t, err)
+		defer doneFn()
+
+		tr := newTransaction(context.Background(), nil, true, "", rn, ms, new(consumertest.MetricsSink), testLogger)
+		_, err = tr.Add(goodLabels, time.Now().Unix()*1000, 1.0)
+		require.NoError(t, err)
+		tr.metricBuilder.startTime = 1.0 // set to a non-zero value
+		require.NoError(t, tr.Commit())
+
+		upLabels := labels.Labels([]labels.Label{{Name: "instance", Value: "localhost:8080"},
+			{Name: "job", Value: "test"},
+			{Name: "__name__", Value: "up"}})
+		tr = newTransaction(context.Background(), NewJobsMap(time.Minute), false, "", rn, ms, new(consumertest.MetricsSink), testLogger)
+		_, err = tr.Add(upLabels, time.Now().Unix()*1000, 0.0)
+		require.NoError(t, err)
+		require.NoError(t, tr.Commit())
+		assert.True(t, tr.metricBuilder.scrapeFailed)
+
+		// Both scrapes of the job are reported.
+		obsreporttest.CheckScraperMetricsViews(t, rn, "test", 0, 0)
+		obsreporttest.CheckScraperDurationViews(t, rn, "test", 2)
+	})
+
+	t.Run("Exemplar before any sample", func(t *testing.T) {
+		nomc := consumertest.NewMetricsNop()
+		tr := newTransaction(context.Background(), nil, true, "", rn, ms, nomc, testLogger)
+		if _, got := tr.AppendExemplar(0, goodLabels, exemplar.Exemplar{Value: 1}); got != errExemplarWithoutSample {
+			t.Errorf("expecting errExemplarWithoutSample from AppendExemplar() but got %v", got)
+		}
+	})
+
 	t.Run("Error when start time is zero", func(t *testing.T) {
 		sink := new(consumertest.MetricsSink)
 		tr := newTransaction(context.Background(), nil, true, "", rn, ms, sink, testLogger)
@@ -160,4 +198,67 @@ func Test_transaction(t *testing.T) {
 		}
 	})
 
+	t.Run("Stale marker", func(t *testing.T) {
+		sink := new(consumertest.MetricsSink)
+		jobsMap := NewJobsMap(time.Minute)
+		tr := newTransaction(context.Background(), jobsMap, false, "", rn, ms, sink, testLogger)
+		if _, got := tr.Add(goodLabels, time.Now().Unix()*1000, math.Float64frombits(value.StaleNaN)); got != nil {
+			t.Errorf("expecting error == nil from Add() but got: %v\n", got)
+		}
+		if got := tr.Commit(); got != nil {
+			t.Errorf("expecting nil from Commit() but got err %v", got)
+		}
+		if len(sink.AllMetrics()) != 0 {
+			t.Errorf("wanted nil, got %v\n", sink.AllMetrics())
+		}
+		if len(tr.metricBuilder.staleSignatures) != 1 {
+			t.Errorf("wanted 1 stale timeseries, got %v\n", tr.metricBuilder.staleSignatures)
+		}
+	})
+
+	t.Run("Target info", func(t *testing.T) {
+		sink := new(consumertest.MetricsSink)
+		tr := newTransaction(context.Background(), nil, true, "", rn, ms, sink, testLogger)
+		_, err := tr.Add(goodLabels, time.Now().Unix()*1000, 1.0)
+		require.NoError(t, err)
+		targetInfoLabels := labels.Labels([]labels.Label{{Name: "instance", Value: "localhost:8080"},
+			{Name: "job", Value: "test"},
+			{Name: "__name__", Value: "target_info"},
+			{Name: "region", Value: "eu-west-1"}})
+		_, err = tr.Add(targetInfoLabels, time.Now().Unix()*1000, 1.0)
+		require.NoError(t, err)
+		tr.metricBuilder.startTime = 1.0 // set to a non-zero value
+		require.NoError(t, tr.Commit())
+
+		mds := sink.AllMetrics()
+		require.Len(t, mds, 1)
+		ocmds := internaldata.MetricsToOC(mds[0])
+		require.Len(t, ocmds, 1)
+		assert.Equal(t, "test", ocmds[0].Node.ServiceInfo.Name)
+		assert.Equal(t, "eu-west-1", ocmds[0].Resource.Labels["region"])
+		assert.Equal(t, "localhost:8080", ocmds[0].Resource.Labels["service.instance.id"])
+		for _, m := range ocmds[0].Metrics {
+			assert.NotEqual(t, "target_info", m.MetricDescriptor.Name)
+		}
+	})
+
+	t.Run("Stale marker of a failed scrape", func(t *testing.T) {
+		jobsMap := NewJobsMap(time.Minute)
+		tr := newTransaction(context.Background(), jobsMap, false, "", rn, ms, new(consumertest.MetricsSink), testLogger)
+		_, err := tr.Add(goodLabels, time.Now().Unix()*1000, math.Float64frombits(value.StaleNaN))
+		require.NoError(t, err)
+		upLabels := labels.Labels([]labels.Label{{Name: "instance", Value: "localhost:8080"},
+			{Name: "job", Value: "test"},
+			{Name: "__name__", Value: "up"}})
+		_, err = tr.Add(upLabels, time.Now().Unix()*1000, 0.0)
+		require.NoError(t, err)
+
+		tsm := jobsMap.get("test", "localhost:8080")
+		for sig := range tr.metricBuilder.staleSignatures {
+			tsm.tsiMap[sig] = &timeseriesinfo{}
+		}
+		require.NoError(t, tr.Commit())
+		// The timeseries reported stale by a failed scrape are not restarted.
+		assert.Len(t, tsm.tsiMap, 1)
+	})
 }
@@ -155,8 +155,9 @@ func setupMockPrometheus(tds ...*testData) (*mockPrometheus, *promcfg.Config, er
 		}
 		t.resource = &resourcepb.Resource{
 			Labels: map[string]string{
-				"scheme": "http",
-				"port":   port,
+				"scheme":              "http",
+				"port":                port,
+				"service.instance.id": u.Host,
 			},
 		}
 	}
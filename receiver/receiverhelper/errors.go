@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receiverhelper
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// GRPCError converts an error returned by the next consumer to the error returned
+// to the gRPC clients. A consumererror.Retryable becomes a RESOURCE_EXHAUSTED
+// status, carrying the suggested delay as RetryInfo, so that the clients back off
+// and send the data again, the other errors are returned unchanged.
+func GRPCError(err error) error {
+	var retryable consumererror.Retryable
+	if !consumererror.AsRetryable(err, &retryable) {
+		return err
+	}
+	s := status.New(codes.ResourceExhausted, err.Error())
+	if retryable.Delay() <= 0 {
+		return s.Err()
+	}
+	if withDetails, detailsErr := s.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryable.Delay())}); detailsErr == nil {
+		s = withDetails
+	}
+	return s.Err()
+}
+
+// RetryDelay returns the delay suggested by the RetryInfo details of the status,
+// zero if there is none.
+func RetryDelay(s *status.Status) time.Duration {
+	for _, detail := range s.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			return retryInfo.RetryDelay.AsDuration()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receiverhelper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestGRPCError(t *testing.T) {
+	assert.NoError(t, GRPCError(nil))
+
+	err := errors.New("downstream failure")
+	assert.Equal(t, err, GRPCError(err))
+
+	s, ok := status.FromError(GRPCError(consumererror.NewRetryable(errors.New("memory limit"), 2*time.Second)))
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, s.Code())
+	assert.Equal(t, "memory limit", s.Message())
+	assert.Equal(t, 2*time.Second, RetryDelay(s))
+
+	s, ok = status.FromError(GRPCError(consumererror.NewRetryable(errors.New("memory limit"), 0)))
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, s.Code())
+	assert.Zero(t, RetryDelay(s))
+}
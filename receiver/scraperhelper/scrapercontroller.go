@@ -17,6 +17,10 @@ package scraperhelper
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -37,6 +41,18 @@ import (
 type ScraperControllerSettings struct {
 	configmodels.ReceiverSettings `mapstructure:"squash"`
 	CollectionInterval            time.Duration `mapstructure:"collection_interval"`
+	// Timeout is the maximum duration of a scrape of each scraper, the metrics of a scraper which does not
+	// complete in time are dropped for that collection, and its next scrapes are skipped until it completes.
+	// No timeout is applied when it is 0.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// InitialDelay is the duration waited after the start before the collection interval starts ticking.
+	InitialDelay time.Duration `mapstructure:"initial_delay"`
+	// Jitter is the upper bound of a random duration added to InitialDelay, so that the receivers started
+	// at the same time do not scrape at the same time.
+	Jitter time.Duration `mapstructure:"jitter"`
+	// MaxConcurrency is the maximum number of scrapers of the receiver scraping at the same time. The scrapers
+	// scrape one after the other when it is 0 or 1.
+	MaxConcurrency int `mapstructure:"max_concurrency"`
 }
 
 // DefaultScraperControllerSettings returns default scraper controller
@@ -61,7 +77,7 @@ type ScraperControllerOption func(*controller)
 // will be passed to the next consumer.
 func AddMetricsScraper(scraper MetricsScraper) ScraperControllerOption {
 	return func(o *controller) {
-		o.metricsScrapers.scrapers = append(o.metricsScrapers.scrapers, scraper)
+		o.metricsScrapers = append(o.metricsScrapers, scraper)
 	}
 }
 
@@ -90,13 +106,24 @@ type controller struct {
 	name               string
 	logger             *zap.Logger
 	collectionInterval time.Duration
+	timeout            time.Duration
+	initialDelay       time.Duration
+	jitter             time.Duration
+	maxConcurrency     int
 	nextConsumer       consumer.Metrics
 
-	metricsScrapers        *multiMetricScraper
+	metricsScrapers        []MetricsScraper
 	resourceMetricScrapers []ResourceMetricsScraper
+	// inFlight has a flag per scraper, resource metrics scrapers first, set while the scraper is scraping. It is
+	// accessed atomically since a scrape that timed out keeps running.
+	inFlight []int32
 
 	tickerCh <-chan time.Time
 
+	host component.Host
+	// failing is true when the last collection had errors, it is only accessed by the scraping goroutine.
+	failing bool
+
 	initialized bool
 	done        chan struct{}
 	terminated  chan struct{}
@@ -116,24 +143,39 @@ func NewScraperControllerReceiver(
 	if cfg.CollectionInterval <= 0 {
 		return nil, errors.New("collection_interval must be a positive duration")
 	}
+	if cfg.Timeout < 0 {
+		return nil, errors.New("timeout must not be negative")
+	}
+	if cfg.InitialDelay < 0 {
+		return nil, errors.New("initial_delay must not be negative")
+	}
+	if cfg.Jitter < 0 {
+		return nil, errors.New("jitter must not be negative")
+	}
+	if cfg.MaxConcurrency < 0 {
+		return nil, errors.New("max_concurrency must not be negative")
+	}
 
 	sc := &controller{
 		name:               cfg.Name(),
 		logger:             logger,
 		collectionInterval: cfg.CollectionInterval,
+		timeout:            cfg.Timeout,
+		initialDelay:       cfg.InitialDelay,
+		jitter:             cfg.Jitter,
+		maxConcurrency:     cfg.MaxConcurrency,
 		nextConsumer:       nextConsumer,
-		metricsScrapers:    &multiMetricScraper{},
 		done:               make(chan struct{}),
 		terminated:         make(chan struct{}),
 	}
+	if sc.maxConcurrency == 0 {
+		sc.maxConcurrency = 1
+	}
 
 	for _, op := range options {
 		op(sc)
 	}
-
-	if len(sc.metricsScrapers.scrapers) > 0 {
-		sc.resourceMetricScrapers = append(sc.resourceMetricScrapers, sc.metricsScrapers)
-	}
+	sc.inFlight = make([]int32, len(sc.resourceMetricScrapers)+len(sc.metricsScrapers))
 
 	return sc, nil
 }
@@ -145,7 +187,13 @@ func (sc *controller) Start(ctx context.Context, host component.Host) error {
 			return err
 		}
 	}
+	for _, scraper := range sc.metricsScrapers {
+		if err := scraper.Start(ctx, host); err != nil {
+			return err
+		}
+	}
 
+	sc.host = host
 	sc.initialized = true
 	sc.startScraping()
 	return nil
@@ -166,13 +214,29 @@ func (sc *controller) Shutdown(ctx context.Context) error {
 			errs = append(errs, err)
 		}
 	}
+	for _, scraper := range sc.metricsScrapers {
+		if err := scraper.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return consumererror.Combine(errs)
 }
 
-// startScraping initiates a ticker that calls Scrape based on the configured
-// collection interval.
+// startScraping waits for the initial delay, then initiates a ticker that
+// calls Scrape based on the configured collection interval.
 func (sc *controller) startScraping() {
 	go func() {
+		if delay := sc.startDelay(); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-sc.done:
+				timer.Stop()
+				sc.terminated <- struct{}{}
+				return
+			}
+		}
+
 		if sc.tickerCh == nil {
 			ticker := time.NewTicker(sc.collectionInterval)
 			defer ticker.Stop()
@@ -192,25 +256,73 @@ func (sc *controller) startScraping() {
 	}()
 }
 
+// startDelay returns the initial delay with a random jitter added.
+func (sc *controller) startDelay() time.Duration {
+	delay := sc.initialDelay
+	if sc.jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(sc.jitter)))
+	}
+	return delay
+}
+
+// scrapeResult holds the outcome of the scrape of a single scraper, either
+// metrics or resource metrics depending on the type of the scraper.
+type scrapeResult struct {
+	scraper         string
+	resource        bool
+	metrics         pdata.MetricSlice
+	resourceMetrics pdata.ResourceMetricsSlice
+	err             error
+}
+
+// scrapeFunc calls the Scrape function of the named scraper.
+type scrapeFunc struct {
+	name   string
+	scrape func(context.Context) scrapeResult
+	// inFlight is the flag of the scraper set while it is scraping.
+	inFlight *int32
+}
+
 // scrapeMetricsAndReport calls the Scrape function for each of the configured
 // Scrapers, records observability information, and passes the scraped metrics
-// to the next component.
+// to the next component. The metrics of the scrapers which failed partially are
+// passed along with the metrics of the scrapers which succeeded, while the
+// scrapers which failed or timed out are reported to the host.
 func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
 	ctx = obsreport.ReceiverContext(ctx, sc.name, "")
 
 	metrics := pdata.NewMetrics()
 
-	for _, rms := range sc.resourceMetricScrapers {
-		resourceMetrics, err := rms.Scrape(ctx, sc.name)
-		if err != nil {
-			sc.logger.Error("Error scraping metrics", zap.Error(err))
+	var rms pdata.ResourceMetricsSlice
+	var ilm pdata.InstrumentationLibraryMetrics
+	if len(sc.metricsScrapers) > 0 {
+		rms = pdata.NewResourceMetricsSlice()
+		rms.Resize(1)
+		ilms := rms.At(0).InstrumentationLibraryMetrics()
+		ilms.Resize(1)
+		ilm = ilms.At(0)
+	}
+
+	var errs []error
+	for _, result := range sc.scrapeAll(ctx) {
+		if result.err != nil {
+			sc.logger.Error("Error scraping metrics", zap.String("scraper", result.scraper), zap.Error(result.err))
+			errs = append(errs, result.err)
 
-			if !scrapererror.IsPartialScrapeError(err) {
+			if !scrapererror.IsPartialScrapeError(result.err) {
 				continue
 			}
 		}
-		resourceMetrics.MoveAndAppendTo(metrics.ResourceMetrics())
+		if result.resource {
+			result.resourceMetrics.MoveAndAppendTo(metrics.ResourceMetrics())
+		} else {
+			result.metrics.MoveAndAppendTo(ilm.Metrics())
+		}
+	}
+	if len(sc.metricsScrapers) > 0 {
+		rms.MoveAndAppendTo(metrics.ResourceMetrics())
 	}
+	sc.reportStatus(consumererror.Combine(errs))
 
 	_, dataPointCount := metrics.MetricAndDataPointCount()
 
@@ -219,61 +331,93 @@ func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
 	obsreport.EndMetricsReceiveOp(ctx, "", dataPointCount, err)
 }
 
-// stopScraping stops the ticker
-func (sc *controller) stopScraping() {
-	close(sc.done)
-}
-
-var _ ResourceMetricsScraper = (*multiMetricScraper)(nil)
+// scrapeAll calls the Scrape function of each of the configured Scrapers,
+// running at most maxConcurrency of them at the same time, and returns the
+// results in the order of the scrapers, resource metrics scrapers first.
+func (sc *controller) scrapeAll(ctx context.Context) []scrapeResult {
+	scrapes := make([]scrapeFunc, 0, len(sc.resourceMetricScrapers)+len(sc.metricsScrapers))
+	for _, scraper := range sc.resourceMetricScrapers {
+		scraper := scraper
+		scrapes = append(scrapes, scrapeFunc{name: scraper.Name(), scrape: func(ctx context.Context) scrapeResult {
+			resourceMetrics, err := scraper.Scrape(ctx, sc.name)
+			return scrapeResult{scraper: scraper.Name(), resource: true, resourceMetrics: resourceMetrics, err: err}
+		}, inFlight: &sc.inFlight[len(scrapes)]})
+	}
+	for _, scraper := range sc.metricsScrapers {
+		scraper := scraper
+		scrapes = append(scrapes, scrapeFunc{name: scraper.Name(), scrape: func(ctx context.Context) scrapeResult {
+			metrics, err := scraper.Scrape(ctx, sc.name)
+			return scrapeResult{scraper: scraper.Name(), metrics: metrics, err: err}
+		}, inFlight: &sc.inFlight[len(scrapes)]})
+	}
 
-type multiMetricScraper struct {
-	scrapers []MetricsScraper
+	results := make([]scrapeResult, len(scrapes))
+	slots := make(chan struct{}, sc.maxConcurrency)
+	var wg sync.WaitGroup
+	for i, scrape := range scrapes {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int, scrape scrapeFunc) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			results[i] = sc.scrapeWithTimeout(ctx, scrape)
+		}(i, scrape)
+	}
+	wg.Wait()
+	return results
 }
 
-func (mms *multiMetricScraper) Name() string {
-	return ""
-}
+// scrapeWithTimeout calls the scrape function with a context done once the
+// timeout expires, and gives up waiting for it then. The scrape function is
+// expected to return soon after its context is done, it is not waited for
+// nonetheless, and the next scrapes of the scraper are skipped until it
+// returns so that the stuck scrapes do not pile up.
+func (sc *controller) scrapeWithTimeout(ctx context.Context, scrape scrapeFunc) scrapeResult {
+	if !atomic.CompareAndSwapInt32(scrape.inFlight, 0, 1) {
+		obsreport.RecordSkippedScrape(obsreport.ScraperContext(ctx, sc.name, scrape.name))
+		return scrapeResult{scraper: scrape.name, err: errors.New("scrape skipped, the previous scrape is still running")}
+	}
 
-func (mms *multiMetricScraper) Start(ctx context.Context, host component.Host) error {
-	for _, scraper := range mms.scrapers {
-		if err := scraper.Start(ctx, host); err != nil {
-			return err
-		}
+	if sc.timeout == 0 {
+		defer atomic.StoreInt32(scrape.inFlight, 0)
+		return scrape.scrape(ctx)
 	}
-	return nil
-}
 
-func (mms *multiMetricScraper) Shutdown(ctx context.Context) error {
-	var errs []error
-	for _, scraper := range mms.scrapers {
-		if err := scraper.Shutdown(ctx); err != nil {
-			errs = append(errs, err)
-		}
+	ctx, cancel := context.WithTimeout(ctx, sc.timeout)
+	defer cancel()
+
+	resultCh := make(chan scrapeResult, 1)
+	go func() {
+		defer atomic.StoreInt32(scrape.inFlight, 0)
+		resultCh <- scrape.scrape(ctx)
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result
+	case <-ctx.Done():
+		return scrapeResult{scraper: scrape.name, err: fmt.Errorf("scrape timed out after %v", sc.timeout)}
 	}
-	return consumererror.Combine(errs)
 }
 
-func (mms *multiMetricScraper) Scrape(ctx context.Context, receiverName string) (pdata.ResourceMetricsSlice, error) {
-	rms := pdata.NewResourceMetricsSlice()
-	rms.Resize(1)
-	rm := rms.At(0)
-	ilms := rm.InstrumentationLibraryMetrics()
-	ilms.Resize(1)
-	ilm := ilms.At(0)
-
-	var errs scrapererror.ScrapeErrors
-	for _, scraper := range mms.scrapers {
-		metrics, err := scraper.Scrape(ctx, receiverName)
-		if err != nil {
-			partialErr, isPartial := err.(scrapererror.PartialScrapeError)
-			if isPartial {
-				errs.AddPartial(partialErr.Failed, partialErr)
-			}
-
-			continue
-		}
+// reportStatus reports to the host when the collections start failing and
+// when they recover.
+func (sc *controller) reportStatus(err error) {
+	if (err != nil) == sc.failing {
+		return
+	}
+	sc.failing = err != nil
 
-		metrics.MoveAndAppendTo(ilm.Metrics())
+	event := &component.StatusEvent{Status: component.StatusOK}
+	if err != nil {
+		event = &component.StatusEvent{Status: component.StatusRecoverableError, Err: err}
 	}
-	return rms, errs.Combine()
+	sc.host.ReportComponentStatus(event)
+}
+
+// stopScraping stops the ticker
+func (sc *controller) stopScraping() {
+	close(sc.done)
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -129,6 +130,24 @@ func TestScrapeController(t *testing.T) {
 			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: -time.Millisecond},
 			expectedNewErr:            "collection_interval must be a positive duration",
 		},
+		{
+			name:                      "AddMetricsScrapers_InvalidTimeoutError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Second, Timeout: -time.Millisecond},
+			expectedNewErr:            "timeout must not be negative",
+		},
+		{
+			name:                      "AddMetricsScrapers_InvalidJitterError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Second, Jitter: -time.Millisecond},
+			expectedNewErr:            "jitter must not be negative",
+		},
+		{
+			name:                      "AddMetricsScrapers_InvalidMaxConcurrencyError",
+			scrapers:                  2,
+			scraperControllerSettings: &ScraperControllerSettings{CollectionInterval: time.Second, MaxConcurrency: -1},
+			expectedNewErr:            "max_concurrency must not be negative",
+		},
 		{
 			name:      "AddMetricsScrapers_ScrapeError",
 			scrapers:  2,
@@ -457,6 +476,211 @@ func TestSingleScrapePerTick(t *testing.T) {
 	}
 }
 
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []*component.StatusEvent
+}
+
+func (sh *statusHost) ReportComponentStatus(event *component.StatusEvent) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.events = append(sh.events, event)
+}
+
+func (sh *statusHost) statuses() []component.Status {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	var statuses []component.Status
+	for _, event := range sh.events {
+		statuses = append(statuses, event.Status)
+	}
+	return statuses
+}
+
+func TestScrapeController_PartialScrapeError(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tickerCh := make(chan time.Time)
+	defaultCfg := DefaultScraperControllerSettings("")
+
+	receiver, err := NewScraperControllerReceiver(
+		&defaultCfg,
+		zap.NewNop(),
+		sink,
+		AddMetricsScraper(NewMetricsScraper("partial", func(context.Context) (pdata.MetricSlice, error) {
+			return singleMetric(), scrapererror.NewPartialScrapeError(errors.New("err1"), 1)
+		})),
+		AddMetricsScraper(NewMetricsScraper("ok", func(context.Context) (pdata.MetricSlice, error) {
+			return singleMetric(), nil
+		})),
+		AddResourceMetricsScraper(NewResourceMetricsScraper("failed", func(context.Context) (pdata.ResourceMetricsSlice, error) {
+			return singleResourceMetric(), errors.New("err2")
+		})),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, receiver.Start(context.Background(), host))
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, time.Second, time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	// The metrics of the partially failed scraper are passed along, the ones of the failed scraper are dropped.
+	assert.Equal(t, 2, sink.MetricsCount())
+	assert.Equal(t, []component.Status{component.StatusRecoverableError}, host.statuses())
+}
+
+func TestScrapeController_Timeout(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tickerCh := make(chan time.Time)
+	cfg := DefaultScraperControllerSettings("")
+	cfg.Timeout = 10 * time.Millisecond
+
+	var slow int32
+	receiver, err := NewScraperControllerReceiver(
+		&cfg,
+		zap.NewNop(),
+		sink,
+		AddMetricsScraper(NewMetricsScraper("slow", func(ctx context.Context) (pdata.MetricSlice, error) {
+			if atomic.AddInt32(&slow, 1) == 1 {
+				<-ctx.Done()
+			}
+			return singleMetric(), nil
+		})),
+		AddMetricsScraper(NewMetricsScraper("fast", func(context.Context) (pdata.MetricSlice, error) {
+			return singleMetric(), nil
+		})),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, receiver.Start(context.Background(), host))
+
+	// The first scrape of the slow scraper times out, the second one completes in time.
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, sink.MetricsCount())
+	// Wait for the timed out scrape to return, otherwise the next scrape of the scraper is skipped.
+	inFlight := &receiver.(*controller).inFlight[0]
+	require.Eventually(t, func() bool { return atomic.LoadInt32(inFlight) == 0 }, time.Second, time.Millisecond)
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, 3, sink.MetricsCount())
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	assert.Equal(t, []component.Status{component.StatusRecoverableError, component.StatusOK}, host.statuses())
+}
+
+func TestScrapeController_SkipWhileScraping(t *testing.T) {
+	done, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer done()
+
+	sink := new(consumertest.MetricsSink)
+	tickerCh := make(chan time.Time)
+	cfg := DefaultScraperControllerSettings("receiver")
+	cfg.Timeout = 10 * time.Millisecond
+
+	// The first scrape ignores its context and stays stuck until released.
+	var scrapes int32
+	release := make(chan struct{})
+	receiver, err := NewScraperControllerReceiver(
+		&cfg,
+		zap.NewNop(),
+		sink,
+		AddMetricsScraper(NewMetricsScraper("stuck", func(context.Context) (pdata.MetricSlice, error) {
+			if atomic.AddInt32(&scrapes, 1) == 1 {
+				<-release
+			}
+			return singleMetric(), nil
+		})),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The first scrape times out and the second one is skipped while the first one is still running.
+	tickerCh <- time.Now()
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, 0, sink.MetricsCount())
+	assert.EqualValues(t, 1, atomic.LoadInt32(&scrapes))
+	obsreporttest.CheckScraperSkippedScrapesViews(t, "receiver", "stuck", 1)
+
+	// The scraper scrapes again once the stuck scrape returned.
+	close(release)
+	inFlight := &receiver.(*controller).inFlight[0]
+	require.Eventually(t, func() bool { return atomic.LoadInt32(inFlight) == 0 }, time.Second, time.Millisecond)
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, sink.MetricsCount())
+	assert.EqualValues(t, 2, atomic.LoadInt32(&scrapes))
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
+
+func TestScrapeController_MaxConcurrency(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tickerCh := make(chan time.Time)
+	cfg := DefaultScraperControllerSettings("")
+	cfg.MaxConcurrency = 2
+
+	// Each scraper waits for the other one to be scraping, which only completes if they scrape concurrently.
+	var started sync.WaitGroup
+	started.Add(2)
+	scrape := func(context.Context) (pdata.MetricSlice, error) {
+		started.Done()
+		started.Wait()
+		return singleMetric(), nil
+	}
+
+	receiver, err := NewScraperControllerReceiver(
+		&cfg,
+		zap.NewNop(),
+		sink,
+		AddMetricsScraper(NewMetricsScraper("scraper1", scrape)),
+		AddMetricsScraper(NewMetricsScraper("scraper2", scrape)),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	assert.Equal(t, 2, sink.MetricsCount())
+}
+
+func TestScrapeController_InitialDelay(t *testing.T) {
+	tickerCh := make(chan time.Time)
+	cfg := DefaultScraperControllerSettings("")
+	cfg.InitialDelay = time.Hour
+	cfg.Jitter = time.Hour
+
+	receiver, err := NewScraperControllerReceiver(
+		&cfg,
+		zap.NewNop(),
+		new(consumertest.MetricsSink),
+		AddMetricsScraper(NewMetricsScraper("", func(context.Context) (pdata.MetricSlice, error) {
+			return singleMetric(), nil
+		})),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	select {
+	case tickerCh <- time.Now():
+		assert.Fail(t, "Ticker was listened to before the initial delay")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
+
 type spanStore struct {
 	sync.Mutex
 	spans []*trace.SpanData
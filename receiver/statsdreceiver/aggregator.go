@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// aggregator aggregates the StatsD metrics received during an interval. The
+// counters are summed into delta monotonic sums, the gauges keep their last
+// value and the timers, histograms and distributions are bucketed into delta
+// histograms. The sample rates scale the counters and weight the histogram
+// values.
+type aggregator struct {
+	boundaries []float64
+	start      time.Time
+	series     map[string]*series
+}
+
+// series is the aggregated value of a metric name, type and label set.
+type series struct {
+	name   string
+	typ    metricType
+	labels []label
+
+	// value is the sum of the counters and the last value of the gauges.
+	value float64
+
+	count   float64
+	sum     float64
+	buckets []float64
+}
+
+func newAggregator(boundaries []float64, start time.Time) *aggregator {
+	return &aggregator{
+		boundaries: boundaries,
+		start:      start,
+		series:     make(map[string]*series),
+	}
+}
+
+func seriesKey(m statsDMetric) string {
+	var b strings.Builder
+	b.WriteString(m.name)
+	b.WriteByte('|')
+	b.WriteString(string(m.typ))
+	for _, l := range m.labels {
+		b.WriteByte('|')
+		b.WriteString(l.key)
+		b.WriteByte(':')
+		b.WriteString(l.value)
+	}
+	return b.String()
+}
+
+func (a *aggregator) add(m statsDMetric) {
+	key := seriesKey(m)
+	s, ok := a.series[key]
+	if !ok {
+		s = &series{name: m.name, typ: m.typ, labels: m.labels}
+		if s.isHistogram() {
+			s.buckets = make([]float64, len(a.boundaries)+1)
+		}
+		a.series[key] = s
+	}
+
+	weight := 1 / m.sampleRate
+	for _, v := range m.values {
+		switch {
+		case m.typ == counterType:
+			s.value += v * weight
+		case m.typ == gaugeType:
+			s.value = v
+		default:
+			s.count += weight
+			s.sum += v * weight
+			// The buckets include their upper bound.
+			s.buckets[sort.SearchFloat64s(a.boundaries, v)] += weight
+		}
+	}
+}
+
+func (s *series) isHistogram() bool {
+	return s.typ != counterType && s.typ != gaugeType
+}
+
+// flush returns the metrics aggregated since the previous flush, and their
+// number of data points, then starts a new interval.
+func (a *aggregator) flush(now time.Time) (pdata.Metrics, int) {
+	md := pdata.NewMetrics()
+	if len(a.series) == 0 {
+		a.start = now
+		return md, 0
+	}
+
+	keys := make([]string, 0, len(a.series))
+	for key := range a.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	metrics.EnsureCapacity(len(keys))
+	startTime := pdata.TimestampFromTime(a.start)
+	timestamp := pdata.TimestampFromTime(now)
+	var prev *series
+	var metric pdata.Metric
+	for _, key := range keys {
+		s := a.series[key]
+		// The keys are sorted so the series of the same metric are contiguous.
+		if prev == nil || prev.name != s.name || prev.typ != s.typ {
+			metric = newMetric(metrics, s.name, s.typ)
+		}
+		prev = s
+
+		switch s.typ {
+		case counterType:
+			dp := metric.DoubleSum().DataPoints().AppendEmpty()
+			dp.SetStartTime(startTime)
+			dp.SetTimestamp(timestamp)
+			dp.SetValue(s.value)
+			setLabels(dp.LabelsMap(), s.labels)
+		case gaugeType:
+			dp := metric.DoubleGauge().DataPoints().AppendEmpty()
+			dp.SetTimestamp(timestamp)
+			dp.SetValue(s.value)
+			setLabels(dp.LabelsMap(), s.labels)
+		default:
+			dp := metric.DoubleHistogram().DataPoints().AppendEmpty()
+			dp.SetStartTime(startTime)
+			dp.SetTimestamp(timestamp)
+			dp.SetSum(s.sum)
+			dp.SetExplicitBounds(a.boundaries)
+			bucketCounts := make([]uint64, len(s.buckets))
+			var count uint64
+			for i, c := range s.buckets {
+				bucketCounts[i] = uint64(math.Round(c))
+				count += bucketCounts[i]
+			}
+			dp.SetBucketCounts(bucketCounts)
+			dp.SetCount(count)
+			setLabels(dp.LabelsMap(), s.labels)
+		}
+	}
+
+	_, dataPoints := md.MetricAndDataPointCount()
+	a.series = make(map[string]*series)
+	a.start = now
+	return md, dataPoints
+}
+
+func newMetric(metrics pdata.MetricSlice, name string, typ metricType) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	switch typ {
+	case counterType:
+		metric.SetDataType(pdata.MetricDataTypeDoubleSum)
+		metric.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		metric.DoubleSum().SetIsMonotonic(true)
+	case gaugeType:
+		metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	default:
+		if typ == timerType {
+			metric.SetUnit("ms")
+		}
+		metric.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+		metric.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	}
+	return metric
+}
+
+func setLabels(labels pdata.StringMap, from []label) {
+	for _, l := range from {
+		labels.Insert(l.key, l.value)
+	}
+}
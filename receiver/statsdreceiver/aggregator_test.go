@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestAggregatorFlush(t *testing.T) {
+	start := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+	now := start.Add(10 * time.Second)
+	agg := newAggregator([]float64{10, 100}, start)
+
+	for _, line := range []string{
+		"requests:1|c|#code:200",
+		"requests:2|c|@0.5|#code:200",
+		"requests:1|c|#code:500",
+		"temperature:20|g",
+		"temperature:21.5|g",
+		"latency:10:50|ms",
+		"latency:500|ms|@0.5",
+		"size:5|h|#env:prod",
+	} {
+		m, err := parseLine(line)
+		require.NoError(t, err, line)
+		agg.add(m)
+	}
+
+	md, numPoints := agg.flush(now)
+	assert.Equal(t, 5, numPoints)
+
+	expected := pdata.NewMetrics()
+	metrics := expected.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	latency := newMetric(metrics, "latency", timerType)
+	dp := latency.DoubleHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTime(pdata.TimestampFromTime(start))
+	dp.SetTimestamp(pdata.TimestampFromTime(now))
+	dp.SetCount(4)
+	dp.SetSum(1060)
+	dp.SetExplicitBounds([]float64{10, 100})
+	dp.SetBucketCounts([]uint64{1, 1, 2})
+
+	requests := newMetric(metrics, "requests", counterType)
+	dp200 := requests.DoubleSum().DataPoints().AppendEmpty()
+	dp200.SetStartTime(pdata.TimestampFromTime(start))
+	dp200.SetTimestamp(pdata.TimestampFromTime(now))
+	dp200.SetValue(5)
+	dp200.LabelsMap().Insert("code", "200")
+	dp500 := requests.DoubleSum().DataPoints().AppendEmpty()
+	dp500.SetStartTime(pdata.TimestampFromTime(start))
+	dp500.SetTimestamp(pdata.TimestampFromTime(now))
+	dp500.SetValue(1)
+	dp500.LabelsMap().Insert("code", "500")
+
+	size := newMetric(metrics, "size", histogramType)
+	dp = size.DoubleHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTime(pdata.TimestampFromTime(start))
+	dp.SetTimestamp(pdata.TimestampFromTime(now))
+	dp.SetCount(1)
+	dp.SetSum(5)
+	dp.SetExplicitBounds([]float64{10, 100})
+	dp.SetBucketCounts([]uint64{1, 0, 0})
+	dp.LabelsMap().Insert("env", "prod")
+
+	temperature := newMetric(metrics, "temperature", gaugeType)
+	gdp := temperature.DoubleGauge().DataPoints().AppendEmpty()
+	gdp.SetTimestamp(pdata.TimestampFromTime(now))
+	gdp.SetValue(21.5)
+
+	assert.Empty(t, expected.Diff(md))
+	assert.Equal(t, "ms", md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Unit())
+
+	// The next interval starts empty.
+	md, numPoints = agg.flush(now.Add(10 * time.Second))
+	assert.Equal(t, 0, numPoints)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+	assert.Equal(t, now.Add(10*time.Second), agg.start)
+}
+
+func TestAggregatorSameNameDifferentTypes(t *testing.T) {
+	agg := newAggregator(nil, time.Now())
+	for _, line := range []string{"x:1|c", "x:2|g", "x:3|c|#a:b"} {
+		m, err := parseLine(line)
+		require.NoError(t, err, line)
+		agg.add(m)
+	}
+
+	md, numPoints := agg.flush(time.Now())
+	assert.Equal(t, 3, numPoints)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, pdata.MetricDataTypeDoubleSum, metrics.At(0).DataType())
+	assert.Equal(t, 2, metrics.At(0).DoubleSum().DataPoints().Len())
+	assert.Equal(t, pdata.MetricDataTypeDoubleGauge, metrics.At(1).DataType())
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// Config defines configuration for StatsD receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// NetAddr is the address to listen on, the transport being either "udp"
+	// (default) or "unixgram" with the path of the socket as endpoint.
+	confignet.NetAddr `mapstructure:",squash"`
+
+	// AggregationInterval is the interval at which the received metrics are
+	// aggregated and pushed to the next consumer.
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+
+	// HistogramBoundaries are the explicit bounds of the buckets of the
+	// histograms of the timers, histograms and distributions. By default the
+	// boundaries are suited for the timers in milliseconds.
+	HistogramBoundaries []float64 `mapstructure:"histogram_boundaries"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks the address, the interval and the histogram boundaries.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required")
+	}
+	switch cfg.Transport {
+	case "udp", "udp4", "udp6", "unixgram":
+	default:
+		return fmt.Errorf("unsupported transport %q", cfg.Transport)
+	}
+	if cfg.AggregationInterval <= 0 {
+		return errors.New("\"aggregation_interval\" must be positive")
+	}
+	if !sort.Float64sAreSorted(cfg.HistogramBoundaries) {
+		return errors.New("\"histogram_boundaries\" must be sorted in increasing order")
+	}
+	for i := 1; i < len(cfg.HistogramBoundaries); i++ {
+		if cfg.HistogramBoundaries[i] == cfg.HistogramBoundaries[i-1] {
+			return fmt.Errorf("duplicate histogram boundary %v", cfg.HistogramBoundaries[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers["statsd"]
+	assert.Equal(t, r0, factory.CreateDefaultConfig())
+
+	r1 := cfg.Receivers["statsd/uds"]
+	assert.Equal(t, r1, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: "statsd/uds",
+		},
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "/var/run/statsd.sock",
+			Transport: "unixgram",
+		},
+		AggregationInterval: 30 * time.Second,
+		HistogramBoundaries: []float64{0.1, 0.5, 1, 5},
+	})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "no boundaries",
+			modify: func(cfg *Config) { cfg.HistogramBoundaries = nil },
+		},
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "\"endpoint\" is required",
+		},
+		{
+			name:   "unsupported transport",
+			modify: func(cfg *Config) { cfg.Transport = "tcp" },
+			err:    "unsupported transport \"tcp\"",
+		},
+		{
+			name:   "zero interval",
+			modify: func(cfg *Config) { cfg.AggregationInterval = 0 },
+			err:    "\"aggregation_interval\" must be positive",
+		},
+		{
+			name:   "unsorted boundaries",
+			modify: func(cfg *Config) { cfg.HistogramBoundaries = []float64{1, 0.5} },
+			err:    "\"histogram_boundaries\" must be sorted in increasing order",
+		},
+		{
+			name:   "duplicate boundaries",
+			modify: func(cfg *Config) { cfg.HistogramBoundaries = []float64{1, 1} },
+			err:    "duplicate histogram boundary 1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			test.modify(cfg)
+			err := cfg.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// metricType is the type of a StatsD metric.
+type metricType string
+
+const (
+	counterType      metricType = "c"
+	gaugeType        metricType = "g"
+	timerType        metricType = "ms"
+	histogramType    metricType = "h"
+	distributionType metricType = "d"
+)
+
+// label is a DogStatsD tag, the value being empty for the tags without colon.
+type label struct {
+	key   string
+	value string
+}
+
+// statsDMetric is a line of the StatsD protocol:
+//
+//	<name>:<value>[:<value>...]|<type>[|@<sample rate>][|#<tag>[:<value>],...]
+//
+// The multiple values and the tags are DogStatsD extensions.
+type statsDMetric struct {
+	name       string
+	typ        metricType
+	values     []float64
+	sampleRate float64
+	// labels are sorted by key and deduplicated, the last value winning.
+	labels []label
+}
+
+var errEventsNotSupported = errors.New("events and service checks are not supported")
+
+// parseLine parses a line of the StatsD protocol. The unknown sections of the
+// line, e.g. the DogStatsD container id, are ignored.
+func parseLine(line string) (statsDMetric, error) {
+	m := statsDMetric{sampleRate: 1}
+	if strings.HasPrefix(line, "_e{") || strings.HasPrefix(line, "_sc|") {
+		return m, errEventsNotSupported
+	}
+
+	sections := strings.Split(line, "|")
+	if len(sections) < 2 {
+		return m, fmt.Errorf("invalid line %q: missing type", line)
+	}
+
+	m.typ = metricType(sections[1])
+	switch m.typ {
+	case counterType, gaugeType, timerType, histogramType, distributionType:
+	default:
+		return m, fmt.Errorf("unsupported metric type %q", sections[1])
+	}
+
+	nameAndValues := strings.Split(sections[0], ":")
+	if len(nameAndValues) < 2 || nameAndValues[0] == "" {
+		return m, fmt.Errorf("invalid line %q: missing name or value", line)
+	}
+	m.name = nameAndValues[0]
+	for _, s := range nameAndValues[1:] {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return m, fmt.Errorf("invalid value %q", s)
+		}
+		m.values = append(m.values, v)
+	}
+
+	for _, section := range sections[2:] {
+		switch {
+		case strings.HasPrefix(section, "@"):
+			rate, err := strconv.ParseFloat(section[1:], 64)
+			if err != nil || rate <= 0 || rate > 1 {
+				return m, fmt.Errorf("invalid sample rate %q", section[1:])
+			}
+			m.sampleRate = rate
+		case strings.HasPrefix(section, "#"):
+			m.labels = parseTags(section[1:])
+		}
+	}
+	return m, nil
+}
+
+func parseTags(s string) []label {
+	byKey := make(map[string]string)
+	for _, tag := range strings.Split(s, ",") {
+		if tag == "" {
+			continue
+		}
+		kv := strings.SplitN(tag, ":", 2)
+		if len(kv) == 2 {
+			byKey[kv[0]] = kv[1]
+		} else {
+			byKey[kv[0]] = ""
+		}
+	}
+	labels := make([]label, 0, len(byKey))
+	for k, v := range byKey {
+		labels = append(labels, label{key: k, value: v})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].key < labels[j].key
+	})
+	return labels
+}
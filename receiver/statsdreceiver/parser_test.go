@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected statsDMetric
+	}{
+		{
+			line:     "page.views:1|c",
+			expected: statsDMetric{name: "page.views", typ: counterType, values: []float64{1}, sampleRate: 1},
+		},
+		{
+			line:     "fuel.level:0.5|g",
+			expected: statsDMetric{name: "fuel.level", typ: gaugeType, values: []float64{0.5}, sampleRate: 1},
+		},
+		{
+			line:     "song.length:240|h|@0.5",
+			expected: statsDMetric{name: "song.length", typ: histogramType, values: []float64{240}, sampleRate: 0.5},
+		},
+		{
+			line: "request.latency:12:30.5|ms|#env:prod,region:us-east:1,canary,env:dev",
+			expected: statsDMetric{
+				name:       "request.latency",
+				typ:        timerType,
+				values:     []float64{12, 30.5},
+				sampleRate: 1,
+				labels:     []label{{key: "canary"}, {key: "env", value: "dev"}, {key: "region", value: "us-east:1"}},
+			},
+		},
+		{
+			line: "image.size:-3e2|d|#|c:container|@0.25",
+			expected: statsDMetric{
+				name:       "image.size",
+				typ:        distributionType,
+				values:     []float64{-300},
+				sampleRate: 0.25,
+				labels:     []label{},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			m, err := parseLine(test.line)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, m)
+		})
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		err  string
+	}{
+		{line: "page.views:1", err: "invalid line \"page.views:1\": missing type"},
+		{line: "page.views|c", err: "invalid line \"page.views|c\": missing name or value"},
+		{line: ":1|c", err: "invalid line \":1|c\": missing name or value"},
+		{line: "page.views:one|c", err: "invalid value \"one\""},
+		{line: "page.views:1:|c", err: "invalid value \"\""},
+		{line: "users:alice|s", err: "unsupported metric type \"s\""},
+		{line: "page.views:1|c|@2", err: "invalid sample rate \"2\""},
+		{line: "page.views:1|c|@0", err: "invalid sample rate \"0\""},
+		{line: "_e{5,4}:title|text", err: "events and service checks are not supported"},
+		{line: "_sc|check|0", err: "events and service checks are not supported"},
+	}
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			_, err := parseLine(test.line)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	format = "statsd"
+
+	// The maximum size of the datagrams, larger datagrams are truncated.
+	maxPacketSize = 64 * 1024
+)
+
+// statsDReceiver receives the StatsD metrics and pushes them aggregated to the
+// next consumer at each interval.
+type statsDReceiver struct {
+	cfg          *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+
+	mu         sync.Mutex
+	aggregator *aggregator
+
+	conn      net.PacketConn
+	done      chan struct{}
+	readWG    sync.WaitGroup
+	flushWG   sync.WaitGroup
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+var _ component.MetricsReceiver = (*statsDReceiver)(nil)
+
+func newStatsDReceiver(cfg *Config, logger *zap.Logger, nextConsumer consumer.Metrics) (*statsDReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	return &statsDReceiver{
+		cfg:          cfg,
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		done:         make(chan struct{}),
+	}, nil
+}
+
+// Start starts listening for the metrics and pushing them at each interval.
+func (r *statsDReceiver) Start(_ context.Context, host component.Host) error {
+	err := componenterror.ErrAlreadyStarted
+	r.startOnce.Do(func() {
+		r.conn, err = net.ListenPacket(r.cfg.Transport, r.cfg.Endpoint)
+		if err != nil {
+			return
+		}
+		boundaries := r.cfg.HistogramBoundaries
+		if len(boundaries) == 0 {
+			boundaries = defaultHistogramBoundaries
+		}
+		r.aggregator = newAggregator(boundaries, time.Now())
+
+		r.readWG.Add(1)
+		go r.read(host)
+		r.flushWG.Add(1)
+		go r.flushLoop()
+	})
+	return err
+}
+
+func (r *statsDReceiver) read(host component.Host) {
+	defer r.readWG.Done()
+	receiverCtx := obsreport.ReceiverContext(context.Background(), r.cfg.Name(), r.cfg.Transport)
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := r.conn.ReadFrom(buf)
+		if n > 0 {
+			r.handlePacket(receiverCtx, buf[:n])
+		}
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				continue
+			}
+			if !errors.Is(err, net.ErrClosed) {
+				host.ReportFatalError(err)
+			}
+			return
+		}
+	}
+}
+
+// handlePacket aggregates the lines of the packet. The malformed lines are
+// reported as refused metric points.
+func (r *statsDReceiver) handlePacket(receiverCtx context.Context, packet []byte) {
+	var parseErr error
+	numErrors := 0
+	r.mu.Lock()
+	for _, line := range bytes.Split(packet, []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		m, err := parseLine(string(line))
+		if err != nil {
+			parseErr = err
+			numErrors++
+			r.logger.Debug("Failed to parse line", zap.ByteString("line", line), zap.Error(err))
+			continue
+		}
+		r.aggregator.add(m)
+	}
+	r.mu.Unlock()
+
+	if numErrors > 0 {
+		ctx := obsreport.StartMetricsReceiveOp(receiverCtx, r.cfg.Name(), r.cfg.Transport, obsreport.WithLongLivedCtx())
+		obsreport.EndMetricsReceiveOp(ctx, format, numErrors, consumererror.WithReason(parseErr, consumererror.ReasonDecodeError))
+	}
+}
+
+func (r *statsDReceiver) flushLoop() {
+	defer r.flushWG.Done()
+	receiverCtx := obsreport.ReceiverContext(context.Background(), r.cfg.Name(), r.cfg.Transport)
+	ticker := time.NewTicker(r.cfg.AggregationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.flush(receiverCtx)
+		case <-r.done:
+			// Push the metrics of the last interval.
+			r.flush(receiverCtx)
+			return
+		}
+	}
+}
+
+func (r *statsDReceiver) flush(receiverCtx context.Context) {
+	r.mu.Lock()
+	md, numPoints := r.aggregator.flush(time.Now())
+	r.mu.Unlock()
+	if numPoints == 0 {
+		return
+	}
+
+	ctx := obsreport.StartMetricsReceiveOp(receiverCtx, r.cfg.Name(), r.cfg.Transport, obsreport.WithLongLivedCtx())
+	err := r.nextConsumer.ConsumeMetrics(ctx, md)
+	if err != nil {
+		r.logger.Error("Failed to push the aggregated metrics", zap.Error(err))
+	}
+	obsreport.EndMetricsReceiveOp(ctx, format, numPoints, err)
+}
+
+// Shutdown stops listening and pushes the metrics of the last interval.
+func (r *statsDReceiver) Shutdown(context.Context) error {
+	err := componenterror.ErrAlreadyStopped
+	r.stopOnce.Do(func() {
+		err = nil
+		if r.conn == nil {
+			return
+		}
+		err = r.conn.Close()
+		r.readWG.Wait()
+		close(r.done)
+		r.flushWG.Wait()
+		if r.cfg.Transport == "unixgram" {
+			// The socket file is not removed when the connection is closed.
+			if rmErr := os.Remove(r.cfg.Endpoint); rmErr != nil && err == nil && !os.IsNotExist(rmErr) {
+				err = rmErr
+			}
+		}
+	})
+	return err
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver
+
+import (
+	"context"
+	"net"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func TestUDPReceiver(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.AggregationInterval = 50 * time.Millisecond
+
+	sink := new(consumertest.MetricsSink)
+	r, err := newStatsDReceiver(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := net.Dial("udp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("requests:1|c|#code:200\nrequests:2|c|#code:200\r\ntemperature:21|g\nmalformed\n"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.MetricsCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	md := sink.AllMetrics()[0]
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t, "requests", metrics.At(0).Name())
+	assert.Equal(t, 3.0, metrics.At(0).DoubleSum().DataPoints().At(0).Value())
+	assert.Equal(t, "temperature", metrics.At(1).Name())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	obsreporttest.CheckReceiverMetricsViews(t, "statsd", "udp", 2, 1)
+}
+
+func TestShutdownFlushes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.AggregationInterval = time.Hour
+
+	sink := new(consumertest.MetricsSink)
+	r, err := newStatsDReceiver(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Handle the packet directly since the datagrams are not guaranteed to be
+	// read before the shutdown.
+	r.handlePacket(context.Background(), []byte("requests:1|c"))
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.MetricsCount())
+}
+
+func TestUnixgramReceiver(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Transport = "unixgram"
+	cfg.Endpoint = testutil.TempSocketName(t)
+	cfg.AggregationInterval = 50 * time.Millisecond
+
+	sink := new(consumertest.MetricsSink)
+	r, err := newStatsDReceiver(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := net.Dial("unixgram", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("latency:12|ms|#env:prod"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.MetricsCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	_, err = os.Stat(cfg.Endpoint)
+	assert.True(t, os.IsNotExist(err), "socket file not removed")
+}
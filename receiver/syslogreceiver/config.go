@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	// TransportUDP receives one message per datagram.
+	TransportUDP = "udp"
+	// TransportTCP receives the messages framed by octet counting or delimited
+	// by newlines, as described by RFC 6587.
+	TransportTCP = "tcp"
+
+	// ProtocolRFC5424 parses the messages as described by RFC 5424.
+	ProtocolRFC5424 = "rfc5424"
+	// ProtocolRFC3164 parses the messages as described by RFC 3164, the BSD
+	// syslog protocol.
+	ProtocolRFC3164 = "rfc3164"
+)
+
+// Config defines configuration for syslog receiver.
+type Config struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Endpoint is the host:port to listen on.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Transport is either "udp" (default) or "tcp".
+	Transport string `mapstructure:"transport"`
+
+	// Protocol of the messages, either "rfc5424" (default) or "rfc3164".
+	Protocol string `mapstructure:"protocol"`
+
+	// Location is the IANA time zone name of the RFC 3164 timestamps, which
+	// have no time zone. By default the timestamps are in UTC.
+	Location string `mapstructure:"location"`
+
+	// TLSSetting enables TLS on the TCP listener.
+	TLSSetting *configtls.TLSServerSetting `mapstructure:"tls_settings,omitempty"`
+}
+
+var _ configmodels.CustomValidator = (*Config)(nil)
+
+// Validate checks the transport, the protocol and the location.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required")
+	}
+	switch cfg.Transport {
+	case TransportUDP:
+		if cfg.TLSSetting != nil {
+			return errors.New("\"tls_settings\" can only be used with the \"tcp\" transport")
+		}
+	case TransportTCP:
+	default:
+		return fmt.Errorf("unsupported transport %q", cfg.Transport)
+	}
+	if cfg.Protocol != ProtocolRFC5424 && cfg.Protocol != ProtocolRFC3164 {
+		return fmt.Errorf("unsupported protocol %q", cfg.Protocol)
+	}
+	if _, err := time.LoadLocation(cfg.Location); err != nil {
+		return fmt.Errorf("invalid location %q: %w", cfg.Location, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[configmodels.Type(typeStr)] = factory
+	cfg, err := configtest.LoadConfigFile(
+		t, path.Join(".", "testdata", "config.yaml"), factories,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, len(cfg.Receivers), 2)
+
+	r0 := cfg.Receivers["syslog"]
+	assert.Equal(t, r0, factory.CreateDefaultConfig())
+
+	r1 := cfg.Receivers["syslog/tcp"]
+	assert.Equal(t, r1, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: typeStr,
+			NameVal: "syslog/tcp",
+		},
+		Endpoint:  "0.0.0.0:6514",
+		Transport: TransportTCP,
+		Protocol:  ProtocolRFC3164,
+		Location:  "America/New_York",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "/etc/collector/server.crt",
+				KeyFile:  "/etc/collector/server.key",
+			},
+		},
+	})
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "tcp with tls",
+			modify: func(cfg *Config) {
+				cfg.Transport = TransportTCP
+				cfg.TLSSetting = &configtls.TLSServerSetting{}
+			},
+		},
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			err:    "\"endpoint\" is required",
+		},
+		{
+			name:   "unsupported transport",
+			modify: func(cfg *Config) { cfg.Transport = "unix" },
+			err:    "unsupported transport \"unix\"",
+		},
+		{
+			name:   "udp with tls",
+			modify: func(cfg *Config) { cfg.TLSSetting = &configtls.TLSServerSetting{} },
+			err:    "\"tls_settings\" can only be used with the \"tcp\" transport",
+		},
+		{
+			name:   "unsupported protocol",
+			modify: func(cfg *Config) { cfg.Protocol = "rfc6587" },
+			err:    "unsupported protocol \"rfc6587\"",
+		},
+		{
+			name:   "invalid location",
+			modify: func(cfg *Config) { cfg.Location = "Nowhere/Nothing" },
+			err:    "invalid location \"Nowhere/Nothing\": unknown time zone Nowhere/Nothing",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			test.modify(cfg)
+			err := cfg.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+// The maximum number of digits of the length of the octet counted messages.
+const maxLengthDigits = 10
+
+// splitFrames is a bufio.SplitFunc returning the messages received over TCP,
+// each message being either framed by octet counting (prefixed by its length
+// and a space) or delimited by a newline, as described by RFC 6587. The
+// framing is detected per message, the messages always starting with '<'.
+func splitFrames(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if data[0] >= '1' && data[0] <= '9' {
+		sp := bytes.IndexByte(data, ' ')
+		if sp < 0 {
+			if len(data) > maxLengthDigits {
+				return 0, nil, errors.New("invalid octet counting frame length")
+			}
+			if atEOF {
+				return 0, nil, errors.New("truncated octet counting frame")
+			}
+			return 0, nil, nil
+		}
+		length, err := strconv.Atoi(string(data[:sp]))
+		if err != nil || sp > maxLengthDigits {
+			return 0, nil, errors.New("invalid octet counting frame length")
+		}
+		end := sp + 1 + length
+		if len(data) < end {
+			if atEOF {
+				return 0, nil, errors.New("truncated octet counting frame")
+			}
+			return 0, nil, nil
+		}
+		return end, data[sp+1 : end], nil
+	}
+
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, bytes.TrimSuffix(data[:i], []byte{'\r'}), nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFrames(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "octet counting",
+			data:     "5 <1>1a11 <2>1 - - -\n",
+			expected: []string{"<1>1a", "<2>1 - - -\n"},
+		},
+		{
+			name:     "newline delimited",
+			data:     "<1>first\r\n<2>second\n\n<3>last",
+			expected: []string{"<1>first", "<2>second", "", "<3>last"},
+		},
+		{
+			name:     "mixed",
+			data:     "<1>first\n6 <2>two",
+			expected: []string{"<1>first", "<2>two"},
+		},
+		{
+			name: "truncated frame",
+			data: "10 <1>",
+			err:  "truncated octet counting frame",
+		},
+		{
+			name: "too many digits",
+			data: "12345678901 <1>",
+			err:  "invalid octet counting frame length",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Reading one byte at a time makes the frames span multiple reads.
+			scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(test.data)))
+			scanner.Split(splitFrames)
+			var frames []string
+			for scanner.Scan() {
+				frames = append(frames, scanner.Text())
+			}
+			assert.Equal(t, test.expected, frames)
+			if test.err == "" {
+				assert.NoError(t, scanner.Err())
+			} else {
+				assert.EqualError(t, scanner.Err(), test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// The attributes of the log records set from the fields of the messages.
+const (
+	// AttributeFacility is the facility code of the message, between 0 and 23.
+	AttributeFacility = "syslog.facility"
+	// AttributeVersion is the version of the RFC 5424 messages.
+	AttributeVersion = "syslog.version"
+	// AttributeHostname is the hostname of the machine that sent the message.
+	AttributeHostname = "syslog.hostname"
+	// AttributeAppName is the application that sent the message, the TAG of
+	// the RFC 3164 messages.
+	AttributeAppName = "syslog.appname"
+	// AttributeProcID is the process id of the application.
+	AttributeProcID = "syslog.procid"
+	// AttributeMsgID is the type of the RFC 5424 messages.
+	AttributeMsgID = "syslog.msgid"
+	// AttributeStructuredData is the map of the structured data elements of
+	// the RFC 5424 messages, each element being a map of its parameters.
+	AttributeStructuredData = "syslog.structured_data"
+)
+
+// The log data model severities of the syslog severities, see
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/logs/data-model.md#appendix-b-severitynumber-example-mappings.
+var severityNumbers = [8]pdata.SeverityNumber{
+	pdata.SeverityNumberFATAL,  // Emergency
+	pdata.SeverityNumberERROR3, // Alert
+	pdata.SeverityNumberERROR2, // Critical
+	pdata.SeverityNumberERROR,  // Error
+	pdata.SeverityNumberWARN,   // Warning
+	pdata.SeverityNumberINFO2,  // Notice
+	pdata.SeverityNumberINFO,   // Informational
+	pdata.SeverityNumberDEBUG,  // Debug
+}
+
+var severityTexts = [8]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+var errUnexpectedEnd = errors.New("unexpected end of message")
+
+// scanner reads the fields of a message.
+type scanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *scanner) done() bool {
+	return s.pos >= len(s.data)
+}
+
+func (s *scanner) peek() byte {
+	return s.data[s.pos]
+}
+
+// expect consumes the next byte, returning an error if it is not b.
+func (s *scanner) expect(b byte) error {
+	if s.done() {
+		return errUnexpectedEnd
+	}
+	if s.data[s.pos] != b {
+		return fmt.Errorf("expected %q at position %d, got %q", b, s.pos, s.data[s.pos])
+	}
+	s.pos++
+	return nil
+}
+
+// token consumes the bytes up to the next space or the end of the message.
+func (s *scanner) token() string {
+	start := s.pos
+	for !s.done() && s.data[s.pos] != ' ' {
+		s.pos++
+	}
+	return string(s.data[start:s.pos])
+}
+
+// rest consumes the remaining bytes.
+func (s *scanner) rest() string {
+	start := s.pos
+	s.pos = len(s.data)
+	return string(s.data[start:])
+}
+
+// priority parses the PRI part of a message and sets the severity and the
+// facility of the log record.
+func (s *scanner) priority(lr pdata.LogRecord) error {
+	if err := s.expect('<'); err != nil {
+		return fmt.Errorf("invalid priority: %w", err)
+	}
+	pri := 0
+	digits := 0
+	for !s.done() && s.peek() >= '0' && s.peek() <= '9' {
+		pri = pri*10 + int(s.peek()-'0')
+		digits++
+		s.pos++
+	}
+	if digits == 0 || digits > 3 || pri > 191 {
+		return errors.New("invalid priority")
+	}
+	if err := s.expect('>'); err != nil {
+		return fmt.Errorf("invalid priority: %w", err)
+	}
+
+	severity := pri % 8
+	lr.SetSeverityNumber(severityNumbers[severity])
+	lr.SetSeverityText(severityTexts[severity])
+	lr.Attributes().InsertInt(AttributeFacility, int64(pri/8))
+	return nil
+}
+
+// insertNonNil inserts the string attribute unless the value is the NILVALUE.
+func insertNonNil(attrs pdata.AttributeMap, key string, value string) {
+	if value != "-" && value != "" {
+		attrs.InsertString(key, value)
+	}
+}
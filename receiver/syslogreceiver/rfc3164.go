@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// The timestamp of the RFC 3164 messages, with a space padded day.
+const rfc3164Timestamp = "Jan _2 15:04:05"
+
+// The maximum length of the TAG of the RFC 3164 messages.
+const maxTagLen = 32
+
+// parseRFC3164 parses a RFC 3164 message into the log record:
+//
+//   <PRI>Mmm dd hh:mm:ss HOSTNAME TAG[PID]: MSG
+//
+// The timestamps have no year and no time zone, they are parsed in loc and the
+// year is the one of the receive time, or the previous one for the timestamps
+// more than a day ahead of the receive time (e.g. a message of December 31st
+// received January 1st). The RFC 3339 timestamps sent by some implementations
+// are also accepted. The content is kept whole as the body if it does not start
+// with a TAG.
+func parseRFC3164(msg []byte, now time.Time, loc *time.Location, lr pdata.LogRecord) error {
+	s := &scanner{data: msg}
+	if err := s.priority(lr); err != nil {
+		return err
+	}
+
+	timestamp, err := parseRFC3164Timestamp(s, now, loc)
+	if err != nil {
+		return err
+	}
+	lr.SetTimestamp(pdata.TimestampFromTime(timestamp))
+
+	if err = s.expect(' '); err != nil {
+		return err
+	}
+	attrs := lr.Attributes()
+	insertNonNil(attrs, AttributeHostname, s.token())
+
+	if s.done() {
+		return nil
+	}
+	if err = s.expect(' '); err != nil {
+		return err
+	}
+	parseTag(s, attrs)
+	if content := s.rest(); content != "" {
+		lr.Body().SetStringVal(content)
+	}
+	return nil
+}
+
+func parseRFC3164Timestamp(s *scanner, now time.Time, loc *time.Location) (time.Time, error) {
+	if end := s.pos + len(rfc3164Timestamp); end <= len(s.data) {
+		if t, err := time.ParseInLocation(rfc3164Timestamp, string(s.data[s.pos:end]), loc); err == nil {
+			s.pos = end
+			now = now.In(loc)
+			t = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+			if t.After(now.Add(24 * time.Hour)) {
+				t = t.AddDate(-1, 0, 0)
+			}
+			return t, nil
+		}
+	}
+
+	start := s.pos
+	t, err := time.Parse(time.RFC3339Nano, s.token())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp at position %d", start)
+	}
+	return t, nil
+}
+
+// parseTag consumes the TAG, the optional PID between brackets and the colon
+// ending them, if the content starts with them.
+func parseTag(s *scanner, attrs pdata.AttributeMap) {
+	start := s.pos
+	end := start
+	for end < len(s.data) && end-start <= maxTagLen && isTagChar(s.data[end]) {
+		end++
+	}
+	if end == start || end-start > maxTagLen || end >= len(s.data) {
+		return
+	}
+
+	pidStart, pidEnd := 0, 0
+	pos := end
+	if s.data[pos] == '[' {
+		pidStart = pos + 1
+		for pos < len(s.data) && s.data[pos] != ']' {
+			pos++
+		}
+		if pos >= len(s.data) {
+			return
+		}
+		pidEnd = pos
+		pos++
+	}
+	if pos >= len(s.data) || s.data[pos] != ':' {
+		return
+	}
+	pos++
+	if pos < len(s.data) && s.data[pos] == ' ' {
+		pos++
+	}
+
+	attrs.InsertString(AttributeAppName, string(s.data[start:end]))
+	if pidEnd > pidStart {
+		attrs.InsertString(AttributeProcID, string(s.data[pidStart:pidEnd]))
+	}
+	s.pos = pos
+}
+
+func isTagChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == '/'
+}
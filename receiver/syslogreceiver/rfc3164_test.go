@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestParseRFC3164(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		msg      string
+		loc      *time.Location
+		now      time.Time
+		expected func() pdata.LogRecord
+	}{
+		{
+			name: "tag with pid",
+			msg:  "<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8",
+			loc:  time.UTC,
+			now:  receiveTime,
+			expected: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2020, 10, 11, 22, 14, 15, 0, time.UTC)))
+				lr.SetSeverityNumber(pdata.SeverityNumberERROR2)
+				lr.SetSeverityText("crit")
+				lr.Body().SetStringVal("'su root' failed for lonvick on /dev/pts/8")
+				attrs := lr.Attributes()
+				attrs.InsertInt(AttributeFacility, 4)
+				attrs.InsertString(AttributeHostname, "mymachine")
+				attrs.InsertString(AttributeAppName, "su")
+				attrs.InsertString(AttributeProcID, "123")
+				return lr
+			},
+		},
+		{
+			name: "location and single digit day",
+			msg:  "<13>Mar  4 08:30:00 host sshd: Accepted publickey",
+			loc:  newYork,
+			now:  receiveTime,
+			expected: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2021, 3, 4, 13, 30, 0, 0, time.UTC)))
+				lr.SetSeverityNumber(pdata.SeverityNumberINFO2)
+				lr.SetSeverityText("notice")
+				lr.Body().SetStringVal("Accepted publickey")
+				attrs := lr.Attributes()
+				attrs.InsertInt(AttributeFacility, 1)
+				attrs.InsertString(AttributeHostname, "host")
+				attrs.InsertString(AttributeAppName, "sshd")
+				return lr
+			},
+		},
+		{
+			name: "no tag",
+			msg:  "<14>2021-03-04T09:59:00.5+01:00 host just a message: with a colon",
+			loc:  time.UTC,
+			now:  receiveTime,
+			expected: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2021, 3, 4, 8, 59, 0, 500000000, time.UTC)))
+				lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+				lr.SetSeverityText("info")
+				lr.Body().SetStringVal("just a message: with a colon")
+				attrs := lr.Attributes()
+				attrs.InsertInt(AttributeFacility, 1)
+				attrs.InsertString(AttributeHostname, "host")
+				return lr
+			},
+		},
+		{
+			name: "previous year",
+			msg:  "<15>Dec 31 23:59:59 host",
+			loc:  time.UTC,
+			now:  time.Date(2021, 1, 1, 0, 0, 1, 0, time.UTC),
+			expected: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC)))
+				lr.SetSeverityNumber(pdata.SeverityNumberDEBUG)
+				lr.SetSeverityText("debug")
+				attrs := lr.Attributes()
+				attrs.InsertInt(AttributeFacility, 1)
+				attrs.InsertString(AttributeHostname, "host")
+				return lr
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lr := pdata.NewLogRecord()
+			require.NoError(t, parseRFC3164([]byte(test.msg), test.now, test.loc, lr))
+			expected := test.expected()
+			expected.Attributes().Sort()
+			lr.Attributes().Sort()
+			assert.Equal(t, expected, lr)
+		})
+	}
+}
+
+func TestParseRFC3164Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		err  string
+	}{
+		{
+			name: "no priority",
+			msg:  "Oct 11 22:14:15 mymachine su: message",
+			err:  "invalid priority: expected '<' at position 0, got 'O'",
+		},
+		{
+			name: "invalid timestamp",
+			msg:  "<34>Octember 11 22:14:15 mymachine su: message",
+			err:  "invalid timestamp at position 4",
+		},
+		{
+			name: "missing hostname",
+			msg:  "<34>Oct 11 22:14:15",
+			err:  "unexpected end of message",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, parseRFC3164([]byte(test.msg), receiveTime, time.UTC, pdata.NewLogRecord()), test.err)
+		})
+	}
+}
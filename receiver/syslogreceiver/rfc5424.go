@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// parseRFC5424 parses a RFC 5424 message into the log record:
+//
+//   <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+//
+// The receive time is used as timestamp if the message has no timestamp.
+func parseRFC5424(msg []byte, now time.Time, lr pdata.LogRecord) error {
+	s := &scanner{data: msg}
+	if err := s.priority(lr); err != nil {
+		return err
+	}
+	attrs := lr.Attributes()
+
+	version, err := strconv.Atoi(s.token())
+	if err != nil || version < 1 || version > 999 {
+		return errors.New("invalid version")
+	}
+	attrs.InsertInt(AttributeVersion, int64(version))
+
+	fields := make([]string, 5)
+	for i := range fields {
+		if err = s.expect(' '); err != nil {
+			return err
+		}
+		fields[i] = s.token()
+	}
+
+	timestamp := now
+	if fields[0] != "-" {
+		if timestamp, err = time.Parse(time.RFC3339Nano, fields[0]); err != nil {
+			return fmt.Errorf("invalid timestamp: %w", err)
+		}
+	}
+	lr.SetTimestamp(pdata.TimestampFromTime(timestamp))
+	insertNonNil(attrs, AttributeHostname, fields[1])
+	insertNonNil(attrs, AttributeAppName, fields[2])
+	insertNonNil(attrs, AttributeProcID, fields[3])
+	insertNonNil(attrs, AttributeMsgID, fields[4])
+
+	if err = s.expect(' '); err != nil {
+		return err
+	}
+	if err = parseStructuredData(s, attrs); err != nil {
+		return fmt.Errorf("invalid structured data: %w", err)
+	}
+
+	if s.done() {
+		return nil
+	}
+	if err = s.expect(' '); err != nil {
+		return err
+	}
+	if body := bytes.TrimPrefix(msg[s.pos:], utf8BOM); len(body) > 0 {
+		lr.Body().SetStringVal(string(body))
+	}
+	return nil
+}
+
+// parseStructuredData parses either the NILVALUE or the SD-ELEMENTs:
+//
+//   [SD-ID PARAM-NAME="PARAM-VALUE" ...][SD-ID ...]
+func parseStructuredData(s *scanner, attrs pdata.AttributeMap) error {
+	if s.done() {
+		return errUnexpectedEnd
+	}
+	if s.peek() == '-' {
+		s.pos++
+		return nil
+	}
+
+	sd := pdata.NewAttributeValueMap()
+	for !s.done() && s.peek() == '[' {
+		s.pos++
+		id := s.sdName()
+		if id == "" {
+			return errors.New("missing SD-ID")
+		}
+		params := pdata.NewAttributeValueMap()
+		for {
+			if s.done() {
+				return errUnexpectedEnd
+			}
+			if s.peek() == ']' {
+				s.pos++
+				break
+			}
+			if err := s.expect(' '); err != nil {
+				return err
+			}
+			name := s.sdName()
+			if name == "" {
+				return errors.New("missing PARAM-NAME")
+			}
+			if err := s.expect('='); err != nil {
+				return err
+			}
+			value, err := s.sdParamValue()
+			if err != nil {
+				return err
+			}
+			params.MapVal().Upsert(name, pdata.NewAttributeValueString(value))
+		}
+		sd.MapVal().Upsert(id, params)
+	}
+	if sd.MapVal().Len() == 0 {
+		return errors.New("expected '-' or '['")
+	}
+	attrs.Insert(AttributeStructuredData, sd)
+	return nil
+}
+
+// sdName consumes a SD-ID or a PARAM-NAME, up to the next space, '=' or ']'.
+func (s *scanner) sdName() string {
+	start := s.pos
+	for !s.done() {
+		switch s.peek() {
+		case ' ', '=', ']', '"':
+			return string(s.data[start:s.pos])
+		}
+		s.pos++
+	}
+	return string(s.data[start:s.pos])
+}
+
+// sdParamValue consumes a quoted PARAM-VALUE, unescaping '"', '\' and ']'.
+func (s *scanner) sdParamValue() (string, error) {
+	if err := s.expect('"'); err != nil {
+		return "", err
+	}
+	var value strings.Builder
+	for !s.done() {
+		c := s.peek()
+		s.pos++
+		switch c {
+		case '"':
+			return value.String(), nil
+		case '\\':
+			if !s.done() {
+				if next := s.peek(); next == '"' || next == '\\' || next == ']' {
+					c = next
+					s.pos++
+				}
+			}
+		}
+		value.WriteByte(c)
+	}
+	return "", errUnexpectedEnd
+}
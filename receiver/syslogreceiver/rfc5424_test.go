@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var receiveTime = time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+
+func TestParseRFC5424(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected func() pdata.LogRecord
+	}{
+		{
+			name: "all fields",
+			msg:  `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut="3" eventSource="Application"][examplePriority@32473 class="high"] An application event log entry...`,
+			expected: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC)))
+				lr.SetSeverityNumber(pdata.SeverityNumberINFO2)
+				lr.SetSeverityText("notice")
+				lr.Body().SetStringVal("An application event log entry...")
+				attrs := lr.Attributes()
+				attrs.InsertInt(AttributeFacility, 20)
+				attrs.InsertInt(AttributeVersion, 1)
+				attrs.InsertString(AttributeHostname, "mymachine.example.com")
+				attrs.InsertString(AttributeAppName, "evntslog")
+				attrs.InsertString(AttributeProcID, "1234")
+				attrs.InsertString(AttributeMsgID, "ID47")
+				sd := pdata.NewAttributeValueMap()
+				event := pdata.NewAttributeValueMap()
+				event.MapVal().InsertString("iut", "3")
+				event.MapVal().InsertString("eventSource", "Application")
+				sd.MapVal().Insert("exampleSDID@32473", event)
+				priority := pdata.NewAttributeValueMap()
+				priority.MapVal().InsertString("class", "high")
+				sd.MapVal().Insert("examplePriority@32473", priority)
+				attrs.Insert(AttributeStructuredData, sd)
+				return lr
+			},
+		},
+		{
+			name: "nil values",
+			msg:  "<34>1 - - - - - -",
+			expected: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.TimestampFromTime(receiveTime))
+				lr.SetSeverityNumber(pdata.SeverityNumberERROR2)
+				lr.SetSeverityText("crit")
+				lr.Attributes().InsertInt(AttributeFacility, 4)
+				lr.Attributes().InsertInt(AttributeVersion, 1)
+				return lr
+			},
+		},
+		{
+			name: "escaped values and BOM",
+			msg:  "<0>1 2003-08-24T05:14:15.000003-07:00 192.0.2.1 myproc - - [id p=\"a\\\"b\\]c\\\\d\\e\"] \xEF\xBB\xBFmessage",
+			expected: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2003, 8, 24, 12, 14, 15, 3000, time.UTC)))
+				lr.SetSeverityNumber(pdata.SeverityNumberFATAL)
+				lr.SetSeverityText("emerg")
+				lr.Body().SetStringVal("message")
+				attrs := lr.Attributes()
+				attrs.InsertInt(AttributeFacility, 0)
+				attrs.InsertInt(AttributeVersion, 1)
+				attrs.InsertString(AttributeHostname, "192.0.2.1")
+				attrs.InsertString(AttributeAppName, "myproc")
+				sd := pdata.NewAttributeValueMap()
+				params := pdata.NewAttributeValueMap()
+				params.MapVal().InsertString("p", `a"b]c\d\e`)
+				sd.MapVal().Insert("id", params)
+				attrs.Insert(AttributeStructuredData, sd)
+				return lr
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lr := pdata.NewLogRecord()
+			require.NoError(t, parseRFC5424([]byte(test.msg), receiveTime, lr))
+			expected := test.expected()
+			expected.Attributes().Sort()
+			lr.Attributes().Sort()
+			assert.Equal(t, expected, lr)
+		})
+	}
+}
+
+func TestParseRFC5424Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		err  string
+	}{
+		{
+			name: "no priority",
+			msg:  "1 - - - - - -",
+			err:  "invalid priority: expected '<' at position 0, got '1'",
+		},
+		{
+			name: "priority out of range",
+			msg:  "<192>1 - - - - - -",
+			err:  "invalid priority",
+		},
+		{
+			name: "invalid version",
+			msg:  "<34>X - - - - - -",
+			err:  "invalid version",
+		},
+		{
+			name: "missing fields",
+			msg:  "<34>1 - - -",
+			err:  "unexpected end of message",
+		},
+		{
+			name: "invalid timestamp",
+			msg:  "<34>1 yesterday - - - - -",
+			err:  "invalid timestamp: parsing time \"yesterday\" as \"2006-01-02T15:04:05.999999999Z07:00\": cannot parse \"yesterday\" as \"2006\"",
+		},
+		{
+			name: "invalid structured data",
+			msg:  "<34>1 - - - - - x",
+			err:  "invalid structured data: expected '-' or '['",
+		},
+		{
+			name: "unterminated structured data",
+			msg:  "<34>1 - - - - - [id p=\"v",
+			err:  "invalid structured data: unexpected end of message",
+		},
+		{
+			name: "no space before message",
+			msg:  "<34>1 - - - - - -message",
+			err:  "expected ' ' at position 17, got 'm'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, parseRFC5424([]byte(test.msg), receiveTime, pdata.NewLogRecord()), test.err)
+		})
+	}
+}
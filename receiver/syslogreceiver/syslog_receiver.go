@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	// The maximum size of the messages, larger TCP messages close the
+	// connection and larger UDP messages are truncated.
+	maxMessageSize = 64 * 1024
+
+	transportTCPTLS = "tcp_tls"
+)
+
+// syslogReceiver receives the syslog messages over UDP or TCP, each message
+// being converted to a log record.
+type syslogReceiver struct {
+	cfg          *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Logs
+	location     *time.Location
+	transport    string
+
+	listener   net.Listener
+	packetConn net.PacketConn
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+}
+
+var _ component.LogsReceiver = (*syslogReceiver)(nil)
+
+func newSyslogReceiver(cfg *Config, logger *zap.Logger, nextConsumer consumer.Logs) (*syslogReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	location, err := time.LoadLocation(cfg.Location)
+	if err != nil {
+		return nil, err
+	}
+	transport := cfg.Transport
+	if cfg.TLSSetting != nil {
+		transport = transportTCPTLS
+	}
+	return &syslogReceiver{
+		cfg:          cfg,
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		location:     location,
+		transport:    transport,
+		conns:        make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// Start starts listening for the messages.
+func (r *syslogReceiver) Start(_ context.Context, host component.Host) error {
+	err := componenterror.ErrAlreadyStarted
+	r.startOnce.Do(func() {
+		switch r.cfg.Transport {
+		case TransportTCP:
+			err = r.startTCP(host)
+		case TransportUDP:
+			err = r.startUDP(host)
+		default:
+			err = errors.New("unsupported transport " + r.cfg.Transport)
+		}
+	})
+	return err
+}
+
+func (r *syslogReceiver) startTCP(host component.Host) error {
+	listener, err := net.Listen("tcp", r.cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+	if r.cfg.TLSSetting != nil {
+		tlsCfg, err := r.cfg.TLSSetting.LoadTLSConfig()
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		listener = tls.NewListener(listener, tlsCfg)
+	}
+	r.listener = listener
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Temporary() {
+					continue
+				}
+				if !errors.Is(err, net.ErrClosed) {
+					host.ReportFatalError(err)
+				}
+				return
+			}
+			if !r.trackConn(conn) {
+				conn.Close()
+				return
+			}
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				defer r.untrackConn(conn)
+				r.handleConn(conn)
+			}()
+		}
+	}()
+	return nil
+}
+
+func (r *syslogReceiver) trackConn(conn net.Conn) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conns == nil {
+		return false
+	}
+	r.conns[conn] = struct{}{}
+	return true
+}
+
+func (r *syslogReceiver) untrackConn(conn net.Conn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conn.Close()
+	delete(r.conns, conn)
+}
+
+func (r *syslogReceiver) handleConn(conn net.Conn) {
+	ctx := obsreport.ReceiverContext(context.Background(), r.cfg.Name(), r.transport)
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), maxMessageSize+maxLengthDigits+1)
+	scanner.Split(splitFrames)
+	for scanner.Scan() {
+		if msg := scanner.Bytes(); len(msg) > 0 {
+			r.handleMessage(ctx, msg)
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		r.logger.Debug("Closing connection", zap.String("remoteAddr", conn.RemoteAddr().String()), zap.Error(err))
+	}
+}
+
+func (r *syslogReceiver) startUDP(host component.Host) error {
+	packetConn, err := net.ListenPacket("udp", r.cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+	r.packetConn = packetConn
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ctx := obsreport.ReceiverContext(context.Background(), r.cfg.Name(), r.transport)
+		buf := make([]byte, maxMessageSize)
+		for {
+			n, _, err := packetConn.ReadFrom(buf)
+			if n > 0 {
+				if msg := bytes.TrimRight(buf[:n], "\r\n\x00"); len(msg) > 0 {
+					r.handleMessage(ctx, msg)
+				}
+			}
+			if err != nil {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Temporary() {
+					continue
+				}
+				if !errors.Is(err, net.ErrClosed) {
+					host.ReportFatalError(err)
+				}
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// handleMessage converts the message and pushes it to the next consumer. The
+// malformed messages are reported as refused log records.
+func (r *syslogReceiver) handleMessage(receiverCtx context.Context, msg []byte) {
+	ctx := obsreport.StartLogsReceiveOp(receiverCtx, r.cfg.Name(), r.transport, obsreport.WithLongLivedCtx())
+
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	var err error
+	if r.cfg.Protocol == ProtocolRFC3164 {
+		err = parseRFC3164(msg, time.Now(), r.location, lr)
+	} else {
+		err = parseRFC5424(msg, time.Now(), lr)
+	}
+	if err != nil {
+		r.logger.Debug("Failed to parse message", zap.ByteString("message", msg), zap.Error(err))
+	} else {
+		err = r.nextConsumer.ConsumeLogs(ctx, ld)
+	}
+	obsreport.EndLogsReceiveOp(ctx, r.cfg.Protocol, 1, err)
+}
+
+// Shutdown stops listening and closes the open connections.
+func (r *syslogReceiver) Shutdown(context.Context) error {
+	err := componenterror.ErrAlreadyStopped
+	r.stopOnce.Do(func() {
+		err = nil
+		if r.listener != nil {
+			err = r.listener.Close()
+		}
+		if r.packetConn != nil {
+			err = r.packetConn.Close()
+		}
+		r.mu.Lock()
+		for conn := range r.conns {
+			conn.Close()
+		}
+		r.conns = nil
+		r.mu.Unlock()
+		r.wg.Wait()
+	})
+	return err
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func setupReceiver(t *testing.T, modify func(cfg *Config)) (*syslogReceiver, *consumertest.LogsSink, string) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	modify(cfg)
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.LogsSink)
+	r, err := newSyslogReceiver(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	return r, sink, cfg.Endpoint
+}
+
+func waitForRecords(t *testing.T, sink *consumertest.LogsSink, n int) []pdata.LogRecord {
+	require.Eventually(t, func() bool {
+		return sink.LogRecordsCount() == n
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var records []pdata.LogRecord
+	for _, ld := range sink.AllLogs() {
+		records = append(records, ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0))
+	}
+	return records
+}
+
+func bodies(records []pdata.LogRecord) []string {
+	var out []string
+	for _, lr := range records {
+		out = append(out, lr.Body().StringVal())
+	}
+	return out
+}
+
+func TestUDPReceiver(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	r, sink, endpoint := setupReceiver(t, func(cfg *Config) {})
+
+	conn, err := net.Dial("udp", endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The malformed messages are refused.
+	_, err = conn.Write([]byte("not syslog"))
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - 'su root' failed\n"))
+	require.NoError(t, err)
+	records := waitForRecords(t, sink, 1)
+
+	lr := records[0]
+	assert.Equal(t, "'su root' failed", lr.Body().StringVal())
+	assert.Equal(t, pdata.SeverityNumberERROR2, lr.SeverityNumber())
+	assert.Equal(t, pdata.TimestampFromTime(time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC)), lr.Timestamp())
+	hostname, ok := lr.Attributes().Get(AttributeHostname)
+	require.True(t, ok)
+	assert.Equal(t, "mymachine.example.com", hostname.StringVal())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	obsreporttest.CheckReceiverLogsViews(t, "syslog", TransportUDP, 1, 1)
+}
+
+func TestTCPReceiver(t *testing.T) {
+	r, sink, endpoint := setupReceiver(t, func(cfg *Config) {
+		cfg.Transport = TransportTCP
+		cfg.Protocol = ProtocolRFC3164
+	})
+	defer r.Shutdown(context.Background())
+
+	conn, err := net.Dial("tcp", endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	msg := "<13>Mar  4 08:30:00 host app[42]: octet\ncounted"
+	_, err = fmt.Fprintf(conn, "%d %s<13>Mar  4 08:30:01 host app: newline\r\nnot syslog\n<13>Mar  4 08:30:02 host app: last\n", len(msg), msg)
+	require.NoError(t, err)
+
+	records := waitForRecords(t, sink, 3)
+	assert.Equal(t, []string{"octet\ncounted", "newline", "last"}, bodies(records))
+	procID, ok := records[0].Attributes().Get(AttributeProcID)
+	require.True(t, ok)
+	assert.Equal(t, "42", procID.StringVal())
+}
+
+func TestTCPReceiverTLS(t *testing.T) {
+	r, sink, endpoint := setupReceiver(t, func(cfg *Config) {
+		cfg.Transport = TransportTCP
+		cfg.TLSSetting = &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CertFile: "../../config/configtls/testdata/test-cert.pem",
+				KeyFile:  "../../config/configtls/testdata/test-key.pem",
+			},
+		}
+	})
+	defer r.Shutdown(context.Background())
+
+	conn, err := tls.Dial("tcp", endpoint, &tls.Config{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("<14>1 - - - - - - secure\n"))
+	require.NoError(t, err)
+
+	records := waitForRecords(t, sink, 1)
+	assert.Equal(t, []string{"secure"}, bodies(records))
+}
+
+func TestShutdownClosesConnections(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Transport = TransportTCP
+
+	r, err := newSyslogReceiver(cfg, zap.NewNop(), consumertest.NewLogsNop())
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	conn, err := net.Dial("tcp", cfg.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("<14>1 - - - - - - open\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+	assert.False(t, isTimeout(err), "connection not closed by shutdown")
+}
+
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
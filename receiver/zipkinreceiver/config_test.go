@@ -51,7 +51,8 @@ func TestLoadConfig(t *testing.T) {
 				NameVal: "zipkin/customname",
 			},
 			HTTPServerSettings: confighttp.HTTPServerSettings{
-				Endpoint: "localhost:8765",
+				Endpoint:           "localhost:8765",
+				MaxRequestBodySize: 1024 * 1024,
 			},
 		})
 
@@ -63,7 +64,8 @@ func TestLoadConfig(t *testing.T) {
 				NameVal: "zipkin/parse_strings",
 			},
 			HTTPServerSettings: confighttp.HTTPServerSettings{
-				Endpoint: "0.0.0.0:9411",
+				Endpoint:           "0.0.0.0:9411",
+				MaxRequestBodySize: defaultMaxRequestBodySize,
 			},
 			ParseStringTags: true,
 		})
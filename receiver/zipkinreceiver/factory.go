@@ -31,6 +31,9 @@ const (
 	typeStr = "zipkin"
 
 	defaultBindEndpoint = "0.0.0.0:9411"
+
+	// defaultMaxRequestBodySize bounds the memory used by a single request, compressed or not.
+	defaultMaxRequestBodySize = 20 * 1024 * 1024
 )
 
 // NewFactory creates a new Zipkin receiver factory
@@ -50,7 +53,8 @@ func createDefaultConfig() configmodels.Receiver {
 			NameVal: typeStr,
 		},
 		HTTPServerSettings: confighttp.HTTPServerSettings{
-			Endpoint: defaultBindEndpoint,
+			Endpoint:           defaultBindEndpoint,
+			MaxRequestBodySize: defaultMaxRequestBodySize,
 		},
 		ParseStringTags: false,
 	}
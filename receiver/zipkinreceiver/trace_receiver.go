@@ -18,23 +18,24 @@ import (
 	"compress/gzip"
 	"compress/zlib"
 	"context"
-	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net"
 	"net/http"
 	"strings"
 	"sync"
 
 	jaegerzipkin "github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
-	zipkinmodel "github.com/openzipkin/zipkin-go/model"
 	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/translator/trace/zipkin"
@@ -45,10 +46,16 @@ const (
 	receiverTransportV1JSON   = "http_v1_json"
 	receiverTransportV2JSON   = "http_v2_json"
 	receiverTransportV2PROTO  = "http_v2_proto"
+
+	// The transports of the requests refused for their unsupported "Content-Type".
+	receiverTransportV1Unsupported = "http_v1_unsupported"
+	receiverTransportV2Unsupported = "http_v2_unsupported"
 )
 
 var errNextConsumerRespBody = []byte(`"Internal Server Error"`)
 
+var errUnsupportedContentEncoding = errors.New("unsupported Content-Encoding, must be gzip, deflate or zlib")
+
 // ZipkinReceiver type is used to handle spans received in the Zipkin format.
 type ZipkinReceiver struct {
 	// mu protects the fields of this struct
@@ -115,9 +122,9 @@ func (zr *ZipkinReceiver) Start(_ context.Context, host component.Host) error {
 	return err
 }
 
-// v1ToTraceSpans parses Zipkin v1 JSON traces and converts them to OpenCensus Proto spans.
+// v1ToTraceSpans parses Zipkin v1 JSON or Thrift traces and converts them to OpenCensus Proto spans.
 func (zr *ZipkinReceiver) v1ToTraceSpans(blob []byte, hdr http.Header) (reqs pdata.Traces, err error) {
-	if hdr.Get("Content-Type") == "application/x-thrift" {
+	if mediaType(hdr) == "application/x-thrift" {
 		zSpans, err := jaegerzipkin.DeserializeThrift(blob)
 		if err != nil {
 			return pdata.NewTraces(), err
@@ -134,30 +141,15 @@ func (zr *ZipkinReceiver) v2ToTraceSpans(blob []byte, hdr http.Header) (reqs pda
 	//      https://github.com/openzipkin/zipkin-go/blob/3793c981d4f621c0e3eb1457acffa2c1cc591384/proto/v2/zipkin.proto#L154
 	debugWasSet := hdr.Get("X-B3-Flags") == "1"
 
-	var zipkinSpans []*zipkinmodel.SpanModel
-
 	// Zipkin can send protobuf via http
-	switch hdr.Get("Content-Type") {
-	// TODO: (@odeke-em) record the unique types of Content-Type uploads
-	case "application/x-protobuf":
-		zipkinSpans, err = zipkin_proto3.ParseSpans(blob, debugWasSet)
-
-	default: // By default, we'll assume using JSON
-		zipkinSpans, err = zr.deserializeFromJSON(blob)
-	}
-
-	if err != nil {
-		return pdata.Traces{}, err
-	}
-
-	return zipkin.V2SpansToInternalTraces(zipkinSpans, zr.config.ParseStringTags)
-}
-
-func (zr *ZipkinReceiver) deserializeFromJSON(jsonBlob []byte) (zs []*zipkinmodel.SpanModel, err error) {
-	if err = json.Unmarshal(jsonBlob, &zs); err != nil {
-		return nil, err
+	if isProtobuf(mediaType(hdr)) {
+		zipkinSpans, err := zipkin_proto3.ParseSpans(blob, debugWasSet)
+		if err != nil {
+			return pdata.Traces{}, err
+		}
+		return zipkin.V2SpansToInternalTraces(zipkinSpans, zr.config.ParseStringTags)
 	}
-	return zs, nil
+	return zipkin.V2JSONBatchToInternalTraces(blob, zr.config.ParseStringTags)
 }
 
 // Shutdown tells the receiver that should stop reception,
@@ -176,36 +168,30 @@ func (zr *ZipkinReceiver) Shutdown(context.Context) error {
 // a compression such as "gzip", "deflate", "zlib", is found, the body will
 // be uncompressed accordingly or return the body untouched if otherwise.
 // Clients such as Zipkin-Java do this behavior e.g.
-//    send "Content-Encoding":"gzip" of the JSON content.
-func processBodyIfNecessary(req *http.Request) io.Reader {
-	switch req.Header.Get("Content-Encoding") {
-	default:
-		return req.Body
+//
+//	send "Content-Encoding":"gzip" of the JSON content.
+func processBodyIfNecessary(req *http.Request) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+		return req.Body, nil
 
 	case "gzip":
-		return gunzippedBodyIfPossible(req.Body)
+		gzr, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress the gzip body: %w", err)
+		}
+		return gzr, nil
 
 	case "deflate", "zlib":
-		return zlibUncompressedbody(req.Body)
-	}
-}
-
-func gunzippedBodyIfPossible(r io.Reader) io.Reader {
-	gzr, err := gzip.NewReader(r)
-	if err != nil {
-		// Just return the old body as was
-		return r
-	}
-	return gzr
-}
+		zr, err := zlib.NewReader(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress the deflate body: %w", err)
+		}
+		return zr, nil
 
-func zlibUncompressedbody(r io.Reader) io.Reader {
-	zr, err := zlib.NewReader(r)
-	if err != nil {
-		// Just return the old body as was
-		return r
+	default:
+		return nil, errUnsupportedContentEncoding
 	}
-	return zr
 }
 
 const (
@@ -224,19 +210,29 @@ func (zr *ZipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Now deserialize and process the spans.
 	asZipkinv1 := r.URL != nil && strings.Contains(r.URL.Path, "api/v1/spans")
 
-	transportTag := transportType(r)
+	receiverTagValue := zipkinV2TagValue
+	if asZipkinv1 {
+		receiverTagValue = zipkinV1TagValue
+	}
+
+	transportTag, err := transportType(r)
 	ctx = obsreport.ReceiverContext(ctx, zr.instanceName, transportTag)
 	ctx = obsreport.StartTraceDataReceiveOp(ctx, zr.instanceName, transportTag)
+	if err != nil {
+		_ = r.Body.Close()
+		obsreport.EndTraceDataReceiveOp(ctx, receiverTagValue, 0, consumererror.WithReason(err, consumererror.ReasonDecodeError))
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
 
-	pr := processBodyIfNecessary(r)
-	slurp, _ := ioutil.ReadAll(pr)
-	if c, ok := pr.(io.Closer); ok {
-		_ = c.Close()
+	slurp, statusCode, err := readBody(r)
+	if err != nil {
+		obsreport.EndTraceDataReceiveOp(ctx, receiverTagValue, 0, consumererror.WithReason(err, consumererror.ReasonDecodeError))
+		http.Error(w, err.Error(), statusCode)
+		return
 	}
-	_ = r.Body.Close()
 
 	var td pdata.Traces
-	var err error
 	if asZipkinv1 {
 		td, err = zr.v1ToTraceSpans(slurp, r.Header)
 	} else {
@@ -244,16 +240,13 @@ func (zr *ZipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
+		obsreport.EndTraceDataReceiveOp(ctx, receiverTagValue, 0, consumererror.WithReason(err, consumererror.ReasonDecodeError))
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	consumerErr := zr.nextConsumer.ConsumeTraces(ctx, td)
 
-	receiverTagValue := zipkinV2TagValue
-	if asZipkinv1 {
-		receiverTagValue = zipkinV1TagValue
-	}
 	obsreport.EndTraceDataReceiveOp(ctx, receiverTagValue, td.SpanCount(), consumerErr)
 
 	if consumerErr != nil {
@@ -268,16 +261,62 @@ func (zr *ZipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusAccepted)
 }
 
-func transportType(r *http.Request) string {
+// readBody reads the uncompressed body of the request, and returns the HTTP
+// status code to respond with when it cannot be read.
+func readBody(r *http.Request) ([]byte, int, error) {
+	defer r.Body.Close()
+
+	pr, err := processBodyIfNecessary(r)
+	if err == errUnsupportedContentEncoding {
+		return nil, http.StatusUnsupportedMediaType, err
+	}
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	defer pr.Close()
+
+	slurp, err := ioutil.ReadAll(pr)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to read the body: %w", err)
+	}
+	return slurp, 0, nil
+}
+
+// transportType returns the transport of the request from its endpoint and
+// its "Content-Type", the body being assumed to be JSON when it is missing. The
+// unsupported transport of the endpoint is returned along with the error.
+func transportType(r *http.Request) (string, error) {
+	mt := mediaType(r.Header)
 	v1 := r.URL != nil && strings.Contains(r.URL.Path, "api/v1/spans")
-	if v1 {
-		if r.Header.Get("Content-Type") == "application/x-thrift" {
-			return receiverTransportV1Thrift
+	switch {
+	case mt == "" || mt == "application/json":
+		if v1 {
+			return receiverTransportV1JSON, nil
 		}
-		return receiverTransportV1JSON
+		return receiverTransportV2JSON, nil
+	case v1 && mt == "application/x-thrift":
+		return receiverTransportV1Thrift, nil
+	case !v1 && isProtobuf(mt):
+		return receiverTransportV2PROTO, nil
 	}
-	if r.Header.Get("Content-Type") == "application/x-protobuf" {
-		return receiverTransportV2PROTO
+	transport := receiverTransportV2Unsupported
+	if v1 {
+		transport = receiverTransportV1Unsupported
 	}
-	return receiverTransportV2JSON
+	return transport, fmt.Errorf("unsupported Content-Type %q", r.Header.Get("Content-Type"))
+}
+
+// mediaType returns the media type of the "Content-Type" header without its
+// parameters, or the header as is when it cannot be parsed.
+func mediaType(hdr http.Header) string {
+	contentType := hdr.Get("Content-Type")
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mt
+}
+
+func isProtobuf(mediaType string) bool {
+	return mediaType == "application/x-protobuf" || mediaType == "application/protobuf"
 }
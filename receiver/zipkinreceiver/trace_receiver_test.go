@@ -32,9 +32,11 @@ import (
 
 	zipkin2 "github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
 	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
+	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
@@ -42,9 +44,11 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/zipkinexporter"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/testutil"
 	"go.opentelemetry.io/collector/translator/conventions"
 )
@@ -359,6 +363,29 @@ func TestReceiverContentTypes(t *testing.T) {
 				return ioutil.ReadFile("../../translator/trace/zipkin/testdata/zipkin_v2_single.json")
 			},
 		},
+
+		{
+			endpoint: "/api/v2/spans",
+			content:  "application/json; charset=utf-8",
+			encoding: "deflate",
+			bodyFn: func() ([]byte, error) {
+				return ioutil.ReadFile("../../translator/trace/zipkin/testdata/zipkin_v2_single.json")
+			},
+		},
+
+		{
+			endpoint: "/api/v2/spans",
+			content:  "application/x-protobuf",
+			encoding: "gzip",
+			bodyFn:   protoExample,
+		},
+
+		{
+			endpoint: "/api/v2/spans",
+			content:  "application/protobuf",
+			encoding: "",
+			bodyFn:   protoExample,
+		},
 	}
 
 	for _, test := range tests {
@@ -371,7 +398,7 @@ func TestReceiverContentTypes(t *testing.T) {
 			switch test.encoding {
 			case "":
 				requestBody = bytes.NewBuffer(body)
-			case "zlib":
+			case "zlib", "deflate":
 				requestBody, err = compressZlib(body)
 			case "gzip":
 				requestBody, err = compressGzip(body)
@@ -440,6 +467,128 @@ func TestReceiverInvalidContentType(t *testing.T) {
 	require.Equal(t, "invalid character 'i' looking for beginning of object key string\n", req.Body.String())
 }
 
+func TestReceiverUnsupportedRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		endpoint   string
+		content    string
+		encoding   string
+		body       string
+		statusCode int
+		respBody   string
+		transport  string
+	}{
+		{
+			name:       "unsupported_content_type",
+			endpoint:   "/api/v2/spans",
+			content:    "text/xml",
+			statusCode: http.StatusUnsupportedMediaType,
+			respBody:   "unsupported Content-Type \"text/xml\"\n",
+			transport:  receiverTransportV2Unsupported,
+		},
+		{
+			name:       "thrift_on_v2",
+			endpoint:   "/api/v2/spans",
+			content:    "application/x-thrift",
+			statusCode: http.StatusUnsupportedMediaType,
+			respBody:   "unsupported Content-Type \"application/x-thrift\"\n",
+			transport:  receiverTransportV2Unsupported,
+		},
+		{
+			name:       "protobuf_on_v1",
+			endpoint:   "/api/v1/spans",
+			content:    "application/x-protobuf",
+			statusCode: http.StatusUnsupportedMediaType,
+			respBody:   "unsupported Content-Type \"application/x-protobuf\"\n",
+			transport:  receiverTransportV1Unsupported,
+		},
+		{
+			name:       "unsupported_content_encoding",
+			endpoint:   "/api/v2/spans",
+			content:    "application/json",
+			encoding:   "br",
+			statusCode: http.StatusUnsupportedMediaType,
+			respBody:   "unsupported Content-Encoding, must be gzip, deflate or zlib\n",
+			transport:  receiverTransportV2JSON,
+		},
+		{
+			name:       "invalid_gzip",
+			endpoint:   "/api/v2/spans",
+			content:    "application/json",
+			encoding:   "gzip",
+			body:       "[]",
+			statusCode: http.StatusBadRequest,
+			respBody:   "failed to decompress the gzip body: unexpected EOF\n",
+			transport:  receiverTransportV2JSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+			require.NoError(t, err)
+			defer doneFn()
+
+			r := httptest.NewRequest("POST", tt.endpoint, bytes.NewBufferString(tt.body))
+			r.Header.Add("content-type", tt.content)
+			r.Header.Add("content-encoding", tt.encoding)
+
+			sink := new(consumertest.TracesSink)
+			cfg := &Config{
+				ReceiverSettings: configmodels.ReceiverSettings{
+					NameVal: zipkinReceiverName,
+				},
+			}
+			zr, err := New(cfg, sink)
+			require.NoError(t, err)
+
+			req := httptest.NewRecorder()
+			zr.ServeHTTP(req, r)
+
+			assert.Equal(t, tt.statusCode, req.Code)
+			assert.Equal(t, tt.respBody, req.Body.String())
+			assert.Equal(t, 0, sink.SpansCount())
+
+			// The refused requests are reported even when their body is not decoded.
+			obsreporttest.CheckReceiverRefusedReasonViews(t, zipkinReceiverName, tt.transport, "refused_spans", string(consumererror.ReasonDecodeError), 0)
+		})
+	}
+}
+
+func TestReceiverProtobufViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	body, err := protoExample()
+	require.NoError(t, err)
+
+	sink := new(consumertest.TracesSink)
+	cfg := &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			NameVal: zipkinReceiverName,
+		},
+	}
+	zr, err := New(cfg, sink)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest("POST", "/api/v2/spans", bytes.NewBuffer(body))
+	r.Header.Add("content-type", "application/x-protobuf")
+	req := httptest.NewRecorder()
+	zr.ServeHTTP(req, r)
+	require.Equal(t, http.StatusAccepted, req.Code)
+
+	r = httptest.NewRequest("POST", "/api/v2/spans", bytes.NewBufferString("invalid"))
+	r.Header.Add("content-type", "application/x-protobuf")
+	req = httptest.NewRecorder()
+	zr.ServeHTTP(req, r)
+	require.Equal(t, http.StatusBadRequest, req.Code)
+
+	// The spans are counted per encoding, and the invalid request is refused as a decode error.
+	obsreporttest.CheckReceiverTracesViews(t, zipkinReceiverName, receiverTransportV2PROTO, 1, 0)
+	obsreporttest.CheckReceiverRefusedReasonViews(t, zipkinReceiverName, receiverTransportV2PROTO, "refused_spans", string(consumererror.ReasonDecodeError), 0)
+}
+
 func TestReceiverConsumerError(t *testing.T) {
 	body, err := ioutil.ReadFile("../../translator/trace/zipkin/testdata/zipkin_v2_single.json")
 	require.NoError(t, err)
@@ -489,6 +638,24 @@ func thriftExample() []byte {
 	return zipkin2.SerializeThrift(zSpans)
 }
 
+func protoExample() ([]byte, error) {
+	return proto.Marshal(&zipkin_proto3.ListOfSpans{
+		Spans: []*zipkin_proto3.Span{
+			{
+				TraceId:   []byte{0x7F, 0x6F, 0x5F, 0x4F, 0x3F, 0x2F, 0x1F, 0x0F, 0xF7, 0xF6, 0xF5, 0xF4, 0xF3, 0xF2, 0xF1, 0xF0},
+				Id:        []byte{0xF7, 0xF6, 0xF5, 0xF4, 0xF3, 0xF2, 0xF1, 0xF0},
+				Name:      "ProtoSpan",
+				Kind:      zipkin_proto3.Span_SERVER,
+				Timestamp: uint64(time.Now().UnixNano() / 1e3),
+				Duration:  1e6,
+				LocalEndpoint: &zipkin_proto3.Endpoint{
+					ServiceName: "svc",
+				},
+			},
+		},
+	})
+}
+
 func compressGzip(body []byte) (*bytes.Buffer, error) {
 	var buf bytes.Buffer
 	zw := gzip.NewWriter(&buf)
@@ -545,6 +712,26 @@ func TestConvertSpansToTraceSpans_JSONWithoutSerivceName(t *testing.T) {
 	require.Equal(t, 1, reqs.SpanCount(), "Incorrect non-nil spans count")
 }
 
+func TestConvertSpansToTraceSpans_JSONWithFractionalTimestamps(t *testing.T) {
+	blob := []byte(`[{
+		"traceId": "4d1e00c0db9010db86154a4ba6e91385",
+		"id": "86154a4ba6e91385",
+		"name": "get",
+		"timestamp": 1472470996199000.5,
+		"duration": 207000.25,
+		"localEndpoint": {"serviceName": "frontend"}
+	}]`)
+	zi := new(ZipkinReceiver)
+	zi.config = createDefaultConfig().(*Config)
+	reqs, err := zi.v2ToTraceSpans(blob, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, reqs.SpanCount())
+	span := reqs.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.Timestamp(1472470996199000500), span.StartTime())
+	assert.Equal(t, pdata.Timestamp(1472470996406000750), span.EndTime())
+}
+
 func TestReceiverConvertsStringsToTypes(t *testing.T) {
 	body, err := ioutil.ReadFile("../../translator/trace/zipkin/testdata/zipkin_v2_single.json")
 	require.NoError(t, err, "Failed to read sample JSON file: %v", err)
@@ -25,6 +25,7 @@ import (
 	"os/signal"
 	"runtime"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -37,6 +38,8 @@ import (
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
+	"go.opentelemetry.io/collector/service/configprovider"
+	"go.opentelemetry.io/collector/service/featuregate"
 	"go.opentelemetry.io/collector/service/internal/builder"
 )
 
@@ -44,8 +47,12 @@ const (
 	servicezPath   = "servicez"
 	pipelinezPath  = "pipelinez"
 	extensionzPath = "extensionz"
+	statuszPath    = "statusz"
 )
 
+// metricsLevelFlag is the flag of the level of the collector metrics, defined by configtelemetry.Flags.
+const metricsLevelFlag = "metrics-level"
+
 // State defines Application's state.
 type State int
 
@@ -70,9 +77,18 @@ type Application struct {
 	// stopTestChan is used to terminate the application in end to end tests.
 	stopTestChan chan struct{}
 
-	// signalsChannel is used to receive termination signals from the OS.
+	// signalsChannel is used to receive termination and reload signals from the OS.
 	signalsChannel chan os.Signal
 
+	// reloadChannel is used to request a reload of the configuration.
+	reloadChannel chan struct{}
+
+	// remoteConfig is the configuration retrieved by the ConfigProvider, nil when it is not set.
+	remoteConfig *remoteConfig
+
+	// configPollInterval is the interval at which the ConfigProvider is polled.
+	configPollInterval time.Duration
+
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
 }
@@ -88,6 +104,12 @@ type Parameters struct {
 	// The default factory loads the configuration file and overrides component's configuration
 	// properties supplied via --set command line flag.
 	ConfigFactory ConfigFactory
+	// ConfigProvider retrieves the configuration from a remote source, the config file, if any, and the --set
+	// command line flag override the retrieved configuration. It cannot be used with ConfigFactory.
+	ConfigProvider configprovider.Provider
+	// ConfigPollInterval is the interval at which the ConfigProvider is polled, the configuration is reloaded when it
+	// changed. The ConfigProvider is not polled when this is not specified.
+	ConfigPollInterval time.Duration
 	// LoggingOptions provides a way to change behavior of zap logging.
 	LoggingOptions []zap.Option
 }
@@ -98,18 +120,16 @@ type Parameters struct {
 // The factories also belong to the Application and are equal to the factories passed via Parameters.
 type ConfigFactory func(v *viper.Viper, cmd *cobra.Command, factories component.Factories) (*configmodels.Config, error)
 
-// FileLoaderConfigFactory implements ConfigFactory and it creates configuration from file
+// FileLoaderConfigFactory implements ConfigFactory and it creates configuration from the files merged in order
 // and from --set command line flag (if the flag is present).
 func FileLoaderConfigFactory(v *viper.Viper, cmd *cobra.Command, factories component.Factories) (*configmodels.Config, error) {
-	file := builder.GetConfigFile()
-	if file == "" {
+	files := builder.GetConfigFiles()
+	if len(files) == 0 {
 		return nil, errors.New("config file not specified")
 	}
-	// first load the config file
-	v.SetConfigFile(file)
-	err := v.ReadInConfig()
-	if err != nil {
-		return nil, fmt.Errorf("error loading config file %q: %v", file, err)
+	// first load the config files
+	if err := mergeConfigFiles(v, files); err != nil {
+		return nil, err
 	}
 
 	// next overlay the config file with --set flags
@@ -125,9 +145,18 @@ func New(params Parameters) (*Application, error) {
 		info:         params.ApplicationStartInfo,
 		factories:    params.Factories,
 		stateChannel: make(chan State, Closed+1),
+
+		configPollInterval: params.ConfigPollInterval,
 	}
 
 	factory := params.ConfigFactory
+	if params.ConfigProvider != nil {
+		if factory != nil {
+			return nil, errors.New("ConfigFactory and ConfigProvider cannot be both set")
+		}
+		app.remoteConfig = &remoteConfig{provider: params.ConfigProvider}
+		factory = app.remoteConfig.configFactory
+	}
 	if factory == nil {
 		// use default factory that loads the configuration file
 		factory = FileLoaderConfigFactory
@@ -156,13 +185,17 @@ func New(params Parameters) (*Application, error) {
 		configtelemetry.Flags,
 		telemetry.Flags,
 		builder.Flags,
+		featuregate.Flags,
 		loggerFlags,
 	}
 	for _, addFlags := range addFlagsFns {
 		addFlags(flagSet)
 	}
-	rootCmd.Flags().AddGoFlagSet(flagSet)
-	addSetFlag(rootCmd.Flags())
+	// The flags are persistent so that the subcommands accept them.
+	rootCmd.PersistentFlags().AddGoFlagSet(flagSet)
+	addSetFlag(rootCmd.PersistentFlags())
+
+	rootCmd.AddCommand(app.newValidateCommand(factory))
 
 	app.rootCmd = rootCmd
 
@@ -185,9 +218,9 @@ func (app *Application) GetLogger() *zap.Logger {
 	return app.logger
 }
 
+// Shutdown stops the Application, the service is shut down as on SIGTERM, draining the pipelines until
+// service::shutdown_timeout expires.
 func (app *Application) Shutdown() {
-	// TODO: Implement a proper shutdown with graceful draining of the pipeline.
-	// See https://github.com/open-telemetry/opentelemetry-collector/issues/483.
 	defer func() {
 		if r := recover(); r != nil {
 			app.logger.Info("stopTestChan already closed")
@@ -196,10 +229,19 @@ func (app *Application) Shutdown() {
 	close(app.stopTestChan)
 }
 
-func (app *Application) setupTelemetry(ballastSizeBytes uint64) error {
+// setupTelemetry sets up the own telemetry of the collector from service::telemetry, it is not changed when
+// the configuration is reloaded.
+func (app *Application) setupTelemetry(ballastSizeBytes uint64, cfg configmodels.ServiceTelemetry) error {
 	app.logger.Info("Setting up own telemetry...")
 
-	err := applicationTelemetry.init(app.asyncErrorChannel, ballastSizeBytes, app.logger)
+	// The level of the configuration overrides the --metrics-level flag, the components read the level from the flag.
+	if cfg.Metrics.Level != "" {
+		if err := app.rootCmd.PersistentFlags().Set(metricsLevelFlag, cfg.Metrics.Level); err != nil {
+			return fmt.Errorf("failed to set the metrics level: %w", err)
+		}
+	}
+
+	err := applicationTelemetry.init(app.asyncErrorChannel, ballastSizeBytes, app.logger, app.info, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize telemetry: %w", err)
 	}
@@ -207,42 +249,87 @@ func (app *Application) setupTelemetry(ballastSizeBytes uint64) error {
 	return nil
 }
 
-// runAndWaitForShutdownEvent waits for one of the shutdown events that can happen.
-func (app *Application) runAndWaitForShutdownEvent() {
+// setupFeatureGates enables and disables the feature gates from service::feature_gates and the --feature-gates flag
+// before the components are created, they are not changed when the configuration is reloaded.
+func (app *Application) setupFeatureGates(configured []string) error {
+	if err := featuregate.Apply(configured); err != nil {
+		return fmt.Errorf("failed to set the feature gates: %w", err)
+	}
+	for _, g := range featuregate.List() {
+		app.logger.Info("Feature gate", zap.String("id", g.ID), zap.Bool("enabled", g.Enabled))
+	}
+	return nil
+}
+
+// runAndWaitForShutdownEvent waits for one of the shutdown events that can happen, the configuration is reloaded
+// on SIGHUP and when the config files or the configuration retrieved by the ConfigProvider changed.
+func (app *Application) runAndWaitForShutdownEvent(ctx context.Context, factory ConfigFactory) {
 	app.logger.Info("Everything is ready. Begin running and processing data.")
 
-	// plug SIGTERM signal into a channel.
+	// plug SIGTERM and SIGHUP signals into a channel.
 	app.signalsChannel = make(chan os.Signal, 1)
-	signal.Notify(app.signalsChannel, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(app.signalsChannel, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	app.reloadChannel = make(chan struct{}, 1)
+	stopWatching := app.watchConfigFiles(builder.GetConfigFiles(), builder.ConfigWatchInterval())
+	defer stopWatching()
+	stopPolling := app.pollConfigProvider(app.configPollInterval)
+	defer stopPolling()
 
 	// set the channel to stop testing.
 	app.stopTestChan = make(chan struct{})
 	app.stateChannel <- Running
+	for !app.waitForEvent(ctx, factory) {
+	}
+	app.stateChannel <- Closing
+}
+
+// waitForEvent waits for the next event and returns true when it requires a shutdown.
+func (app *Application) waitForEvent(ctx context.Context, factory ConfigFactory) bool {
 	select {
 	case err := <-app.asyncErrorChannel:
 		app.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
 	case s := <-app.signalsChannel:
 		app.logger.Info("Received signal from OS", zap.String("signal", s.String()))
+		if s != syscall.SIGHUP {
+			return true
+		}
+		return app.reloadOrTerminate(ctx, factory)
+	case <-app.reloadChannel:
+		app.logger.Info("Configuration changed")
+		return app.reloadOrTerminate(ctx, factory)
 	case <-app.stopTestChan:
 		app.logger.Info("Received stop test request")
 	}
-	app.stateChannel <- Closing
+	return true
 }
 
-func (app *Application) setupConfigurationComponents(ctx context.Context, factory ConfigFactory) error {
+func (app *Application) reloadOrTerminate(ctx context.Context, factory ConfigFactory) bool {
+	if err := app.reloadConfiguration(ctx, factory); err != nil {
+		app.logger.Error("Failed to reload configuration, terminating process", zap.Error(err))
+		return true
+	}
+	return false
+}
+
+func (app *Application) loadConfiguration(factory ConfigFactory) (*configmodels.Config, error) {
 	if err := configcheck.ValidateConfigFromFactories(app.factories); err != nil {
-		return err
+		return nil, err
 	}
 
 	app.logger.Info("Loading configuration...")
 
 	cfg, err := factory(config.NewViper(), app.rootCmd, app.factories)
 	if err != nil {
-		return fmt.Errorf("cannot load configuration: %w", err)
+		return nil, fmt.Errorf("cannot load configuration: %w", err)
 	}
+	return cfg, nil
+}
 
+func (app *Application) setupConfigurationComponents(ctx context.Context, cfg *configmodels.Config) error {
 	app.logger.Info("Applying configuration...")
 
+	var err error
 	app.service, err = newService(&settings{
 		Factories:         app.factories,
 		StartInfo:         app.info,
@@ -270,19 +357,30 @@ func (app *Application) execute(ctx context.Context, factory ConfigFactory) erro
 
 	app.asyncErrorChannel = make(chan error)
 
+	// Load the configuration first, it configures the own telemetry.
+	cfg, err := app.loadConfiguration(factory)
+	if err != nil {
+		return err
+	}
+
 	// Setup everything.
-	err := app.setupTelemetry(ballastSizeBytes)
+	err = app.setupFeatureGates(cfg.Service.FeatureGates)
+	if err != nil {
+		return err
+	}
+
+	err = app.setupTelemetry(ballastSizeBytes, cfg.Service.Telemetry)
 	if err != nil {
 		return err
 	}
 
-	err = app.setupConfigurationComponents(ctx, factory)
+	err = app.setupConfigurationComponents(ctx, cfg)
 	if err != nil {
 		return err
 	}
 
 	// Everything is ready, now run until an event requiring shutdown happens.
-	app.runAndWaitForShutdownEvent()
+	app.runAndWaitForShutdownEvent(ctx, factory)
 
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs []error
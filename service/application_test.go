@@ -91,6 +91,7 @@ func TestApplication_Start(t *testing.T) {
 	// monitor the Collector in production deployments.
 	mandatoryLabels := []string{
 		"service_instance_id",
+		"service_version",
 	}
 	assertMetrics(t, testPrefix, metricsPort, mandatoryLabels)
 
@@ -102,7 +103,7 @@ func TestApplication_Start(t *testing.T) {
 
 type mockAppTelemetry struct{}
 
-func (tel *mockAppTelemetry) init(chan<- error, uint64, *zap.Logger) error {
+func (tel *mockAppTelemetry) init(chan<- error, uint64, *zap.Logger, component.ApplicationStartInfo, configmodels.ServiceTelemetry) error {
 	return nil
 }
 
@@ -138,6 +139,17 @@ func TestApplication_ReportError(t *testing.T) {
 	assert.Equal(t, Closed, <-app.GetStateChannel())
 }
 
+func TestApplication_UnknownFeatureGate(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	app, err := New(Parameters{Factories: factories, ApplicationStartInfo: component.DefaultApplicationStartInfo()})
+	require.NoError(t, err)
+
+	app.rootCmd.SetArgs([]string{"--config=testdata/otelcol-config-minimal.yaml", "--feature-gates=-no.such.gate"})
+	assert.EqualError(t, app.Run(), `failed to set the feature gates: unknown feature gate "no.such.gate"`)
+}
+
 func TestApplication_StartAsGoRoutine(t *testing.T) {
 	factories, err := defaultcomponents.Components()
 	require.NoError(t, err)
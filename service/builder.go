@@ -17,15 +17,21 @@ package service
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/service/internal/builder"
 )
 
+// defaultShutdownTimeout is the time allowed to shut the service down when service::shutdown_timeout is not set.
+const defaultShutdownTimeout = 10 * time.Second
+
 // settings holds configuration for building a new service.
 type settings struct {
 	// Factories component factories.
@@ -52,10 +58,16 @@ type service struct {
 	logger            *zap.Logger
 	asyncErrorChannel chan error
 
+	// mu guards the configuration and the built pipelines replaced when the configuration is reloaded.
+	mu              sync.RWMutex
 	builtExporters  builder.Exporters
 	builtReceivers  builder.Receivers
 	builtPipelines  builder.BuiltPipelines
 	builtExtensions builder.Extensions
+
+	// statusMu guards the last status event reported by each component.
+	statusMu sync.Mutex
+	statuses map[component.StatusSource]*component.StatusEvent
 }
 
 func newService(settings *settings) (*service, error) {
@@ -65,12 +77,17 @@ func newService(settings *settings) (*service, error) {
 		config:            settings.Config,
 		logger:            settings.Logger,
 		asyncErrorChannel: settings.AsyncErrorChannel,
+		statuses:          make(map[component.StatusSource]*component.StatusEvent),
 	}
 
 	if err := srv.config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	if err := config.ValidateComponentConfigs(srv.config); err != nil {
+		return nil, err
+	}
+
 	if err := srv.buildExtensions(); err != nil {
 		return nil, fmt.Errorf("cannot build extensions: %w", err)
 	}
@@ -95,6 +112,11 @@ func (srv *service) Start(ctx context.Context) error {
 }
 
 func (srv *service) Shutdown(ctx context.Context) error {
+	// The receivers, processors and exporters share the shutdown timeout, the exporters drain their sending queues
+	// until it expires.
+	ctx, cancel := context.WithTimeout(ctx, srv.shutdownTimeout())
+	defer cancel()
+
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs []error
 
@@ -120,6 +142,35 @@ func (srv *service) ReportFatalError(err error) {
 	srv.asyncErrorChannel <- err
 }
 
+// ReportComponentStatus records a status event reported by a component, the components started by the service report
+// their status events through ReportComponentStatusFrom.
+func (srv *service) ReportComponentStatus(event *component.StatusEvent) {
+	srv.ReportComponentStatusFrom(component.StatusSource{}, event)
+}
+
+// ReportComponentStatusFrom records the last status event reported by the component, and notifies the extensions
+// watching the status events.
+func (srv *service) ReportComponentStatusFrom(source component.StatusSource, event *component.StatusEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	srv.statusMu.Lock()
+	srv.statuses[source] = event
+	srv.statusMu.Unlock()
+	srv.builtExtensions.NotifyComponentStatusChanged(source, event)
+}
+
+// getComponentStatuses returns the last status event reported by each component.
+func (srv *service) getComponentStatuses() map[component.StatusSource]*component.StatusEvent {
+	srv.statusMu.Lock()
+	defer srv.statusMu.Unlock()
+	statuses := make(map[component.StatusSource]*component.StatusEvent, len(srv.statuses))
+	for source, event := range srv.statuses {
+		statuses[source] = event
+	}
+	return statuses
+}
+
 func (srv *service) GetFactory(kind component.Kind, componentType configmodels.Type) component.Factory {
 	switch kind {
 	case component.KindReceiver:
@@ -130,6 +181,8 @@ func (srv *service) GetFactory(kind component.Kind, componentType configmodels.T
 		return srv.factories.Exporters[componentType]
 	case component.KindExtension:
 		return srv.factories.Extensions[componentType]
+	case component.KindConnector:
+		return srv.factories.Connectors[componentType]
 	}
 	return nil
 }
@@ -139,9 +192,36 @@ func (srv *service) GetExtensions() map[configmodels.NamedEntity]component.Exten
 }
 
 func (srv *service) GetExporters() map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
 	return srv.builtExporters.ToMapByDataType()
 }
 
+// GetPipelines returns the configured pipelines, components can use it to relate the exporters to their pipelines.
+func (srv *service) GetPipelines() configmodels.Pipelines {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+	return srv.config.Service.Pipelines
+}
+
+// shutdownTimeout returns the time allowed to shut the service down.
+func (srv *service) shutdownTimeout() time.Duration {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+	if srv.config.Service.ShutdownTimeout == 0 {
+		return defaultShutdownTimeout
+	}
+	return srv.config.Service.ShutdownTimeout
+}
+
+// getBuiltPipelines returns the running pipelines, the returned map is not modified when the configuration is
+// reloaded.
+func (srv *service) getBuiltPipelines() builder.BuiltPipelines {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+	return srv.builtPipelines
+}
+
 func (srv *service) buildExtensions() error {
 	var err error
 	srv.builtExtensions, err = builder.BuildExtensions(srv.logger, srv.startInfo, srv.config, srv.factories.Extensions)
@@ -182,7 +262,7 @@ func (srv *service) buildPipelines() error {
 
 	// Create pipelines and their processors and plug exporters to the
 	// end of the pipelines.
-	srv.builtPipelines, err = builder.BuildPipelines(srv.logger, srv.startInfo, srv.config, srv.builtExporters, srv.factories.Processors)
+	srv.builtPipelines, err = builder.BuildPipelines(srv.logger, srv.startInfo, srv.config, srv.builtExporters, srv.factories.Processors, srv.factories.Connectors)
 	if err != nil {
 		return fmt.Errorf("cannot build pipelines: %w", err)
 	}
@@ -217,8 +297,8 @@ func (srv *service) startPipelines(ctx context.Context) error {
 
 func (srv *service) shutdownPipelines(ctx context.Context) error {
 	// Shutdown order is the reverse of building: first receivers, then flushing pipelines
-	// giving senders a chance to send all their data. This may take time, the allowed
-	// time should be part of configuration.
+	// giving senders a chance to send all their data. This may take time, up to the
+	// service::shutdown_timeout given by the context deadline.
 
 	var errs []error
 
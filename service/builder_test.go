@@ -18,6 +18,7 @@ import (
 	"context"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,11 +61,27 @@ func TestService_GetFactory(t *testing.T) {
 	factory = srv.GetFactory(component.KindExtension, "wrongtype")
 	assert.EqualValues(t, nil, factory)
 
+	factory = srv.GetFactory(component.KindConnector, "nop")
+	assert.EqualValues(t, factories.Connectors["nop"], factory)
+	factory = srv.GetFactory(component.KindConnector, "wrongtype")
+	assert.EqualValues(t, nil, factory)
+
 	// Try retrieve non existing component.Kind.
 	factory = srv.GetFactory(42, "nop")
 	assert.EqualValues(t, nil, factory)
 }
 
+func TestService_ShutdownTimeout(t *testing.T) {
+	srv := createExampleService(t)
+	assert.Equal(t, defaultShutdownTimeout, srv.shutdownTimeout())
+
+	srv.config.Service.ShutdownTimeout = 30 * time.Second
+	assert.Equal(t, 30*time.Second, srv.shutdownTimeout())
+
+	assert.NoError(t, srv.Start(context.Background()))
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
 func TestService_GetExtensions(t *testing.T) {
 	srv := createExampleService(t)
 
@@ -97,6 +114,15 @@ func TestService_GetExporters(t *testing.T) {
 	assert.Contains(t, expMap[configmodels.LogsDataType], &configmodels.ExporterSettings{TypeVal: "nop", NameVal: "nop"})
 }
 
+func TestService_GetPipelines(t *testing.T) {
+	srv := createExampleService(t)
+
+	pipelines := srv.GetPipelines()
+	assert.Len(t, pipelines, 3)
+	assert.Equal(t, configmodels.TracesDataType, pipelines["traces"].InputType)
+	assert.Equal(t, []string{"nop"}, pipelines["traces"].Exporters)
+}
+
 func createExampleService(t *testing.T) *service {
 	// Create some factories.
 	factories, err := componenttest.NopFactories()
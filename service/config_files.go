@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// configFileExtensions are the extensions of the config files loaded from a directory.
+var configFileExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+}
+
+// expandConfigPaths returns the config files of the given paths, in order. A directory is replaced by its config
+// files sorted by name, its sub-directories are ignored.
+func expandConfigPaths(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("error loading config file %q: %v", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("error loading config directory %q: %v", path, err)
+		}
+		var dirFiles []string
+		for _, entry := range entries {
+			if !entry.IsDir() && configFileExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+				dirFiles = append(dirFiles, filepath.Join(path, entry.Name()))
+			}
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+	return files, nil
+}
+
+// mergeConfigFiles merges the config files of the given paths into v, in order. The maps are merged, the other
+// values, including the lists, are overridden by the later files.
+func mergeConfigFiles(v *viper.Viper, paths []string) error {
+	files, err := expandConfigPaths(paths)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		v.SetConfigFile(file)
+		if err = v.MergeInConfig(); err != nil {
+			return fmt.Errorf("error loading config file %q: %v", file, err)
+		}
+	}
+	return nil
+}
+
+// configFilesState returns a description of the config files of the given paths that changes when a file is added,
+// removed or modified.
+func configFilesState(paths []string) (string, error) {
+	files, err := expandConfigPaths(paths)
+	if err != nil {
+		return "", err
+	}
+	var state strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&state, "%s %d %d\n", file, info.ModTime().UnixNano(), info.Size())
+	}
+	return state.String(), nil
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/defaultcomponents"
+)
+
+func TestExpandConfigPaths(t *testing.T) {
+	files, err := expandConfigPaths([]string{
+		filepath.Join("testdata", "merge", "base.yaml"),
+		filepath.Join("testdata", "merge", "overlays"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join("testdata", "merge", "base.yaml"),
+		filepath.Join("testdata", "merge", "overlays", "10-exporters.yaml"),
+		filepath.Join("testdata", "merge", "overlays", "20-pipelines.yml"),
+	}, files)
+
+	_, err = expandConfigPaths([]string{filepath.Join("testdata", "merge", "missing.yaml")})
+	assert.Error(t, err)
+}
+
+func TestMergeConfigFiles(t *testing.T) {
+	v := config.NewViper()
+	require.NoError(t, mergeConfigFiles(v, []string{
+		filepath.Join("testdata", "merge", "base.yaml"),
+		filepath.Join("testdata", "merge", "overlays"),
+	}))
+
+	// The maps are merged.
+	assert.Equal(t, "localhost:4317", v.Get("receivers::otlp::protocols::grpc::endpoint"))
+	assert.Equal(t, "localhost:55681", v.Get("receivers::otlp::protocols::http::endpoint"))
+	assert.Equal(t, "debug", v.Get("exporters::logging::loglevel"))
+	assert.Contains(t, v.GetStringMap("exporters"), "logging/2")
+	// The lists are overridden.
+	assert.Equal(t, []interface{}{"logging/2"}, v.Get("service::pipelines::traces::exporters"))
+	assert.Equal(t, []interface{}{"otlp"}, v.Get("service::pipelines::traces::receivers"))
+}
+
+func TestMergeConfigFilesInvalid(t *testing.T) {
+	v := config.NewViper()
+	assert.Error(t, mergeConfigFiles(v, []string{filepath.Join("testdata", "merge", "missing.yaml")}))
+	assert.Error(t, mergeConfigFiles(v, []string{filepath.Join("testdata", "merge", "invalid.yaml")}))
+}
+
+func TestApplication_MultipleConfigFiles(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	app, err := New(Parameters{ApplicationStartInfo: component.DefaultApplicationStartInfo(), Factories: factories})
+	require.NoError(t, err)
+	app.Command().SetArgs([]string{
+		"--config=" + filepath.Join("testdata", "merge", "base.yaml"),
+		"--config=" + filepath.Join("testdata", "merge", "overlays"),
+		"--metrics-level=NONE",
+	})
+
+	appDone := make(chan struct{})
+	go func() {
+		defer close(appDone)
+		assert.NoError(t, app.Run())
+	}()
+
+	assert.Equal(t, Starting, <-app.GetStateChannel())
+	assert.Equal(t, Running, <-app.GetStateChannel())
+	assert.Equal(t, []string{"logging/2"}, app.service.GetPipelines()["traces"].Exporters)
+
+	app.Shutdown()
+	<-appDone
+	assert.Equal(t, Closing, <-app.GetStateChannel())
+	assert.Equal(t, Closed, <-app.GetStateChannel())
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// httpProvider retrieves the configuration with GET requests, the ETag of the previous response is sent in the
+// If-None-Match header so that the server can answer that the configuration is not modified.
+type httpProvider struct {
+	endpoint string
+	client   *http.Client
+	etag     string
+}
+
+// NewHTTPProvider creates a provider retrieving the configuration from the HTTP(S) endpoint of the settings.
+func NewHTTPProvider(settings confighttp.HTTPClientSettings) (Provider, error) {
+	client, err := settings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	return &httpProvider{
+		endpoint: settings.Endpoint,
+		client:   client,
+	}, nil
+}
+
+func (hp *httpProvider) Retrieve(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hp.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hp.etag != "" {
+		req.Header.Set("If-None-Match", hp.etag)
+	}
+
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve configuration: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, ErrNotModified
+	default:
+		return nil, fmt.Errorf("cannot retrieve configuration, unexpected status: %s", resp.Status)
+	}
+
+	body, err := readResponse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read configuration: %w", err)
+	}
+	hp.etag = resp.Header.Get("ETag")
+	return body, nil
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configprovider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	config, etag := "receivers:\n", `"v1"`
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		authorization = r.Header.Get("Authorization")
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(config))
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPProvider(confighttp.HTTPClientSettings{
+		Endpoint: server.URL,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	})
+	require.NoError(t, err)
+
+	retrieved, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "receivers:\n", string(retrieved))
+	assert.Equal(t, "Bearer token", authorization)
+
+	_, err = provider.Retrieve(context.Background())
+	assert.Equal(t, ErrNotModified, err)
+
+	config, etag = "exporters:\n", `"v2"`
+	retrieved, err = provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "exporters:\n", string(retrieved))
+}
+
+func TestHTTPProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	provider, err := NewHTTPProvider(confighttp.HTTPClientSettings{Endpoint: server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Retrieve(context.Background())
+	assert.EqualError(t, err, "cannot retrieve configuration, unexpected status: 403 Forbidden")
+
+	server.Close()
+	_, err = provider.Retrieve(context.Background())
+	assert.Error(t, err)
+}
+
+func TestHTTPProvider_ResponseTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(make([]byte, maxResponseSize+1))
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPProvider(confighttp.HTTPClientSettings{Endpoint: server.URL})
+	require.NoError(t, err)
+
+	_, err = provider.Retrieve(context.Background())
+	assert.EqualError(t, err, "cannot read configuration: response larger than 16777216 bytes")
+}
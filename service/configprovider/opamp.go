@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configprovider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// agentToServer is the status the collector reports to the management server.
+type agentToServer struct {
+	InstanceUID        string             `json:"instance_uid"`
+	RemoteConfigStatus remoteConfigStatus `json:"remote_config_status"`
+}
+
+type remoteConfigStatus struct {
+	LastRemoteConfigHash string `json:"last_remote_config_hash,omitempty"`
+}
+
+// serverToAgent is the answer of the management server, the remote configuration is not set when the configuration
+// of the collector is up to date.
+type serverToAgent struct {
+	RemoteConfig *remoteConfig `json:"remote_config,omitempty"`
+}
+
+type remoteConfig struct {
+	Config     string `json:"config"`
+	ConfigHash string `json:"config_hash"`
+}
+
+// opampProvider retrieves the configuration from an OpAMP-style management server: the collector POSTs its instance
+// ID and the hash of its configuration, the server answers with a new configuration or with no content.
+type opampProvider struct {
+	endpoint   string
+	client     *http.Client
+	instanceID string
+	configHash string
+}
+
+// NewOpAMPProvider creates a provider retrieving the configuration from the OpAMP-style management server at the
+// endpoint of the settings. A random instance ID is generated when it is empty.
+func NewOpAMPProvider(settings confighttp.HTTPClientSettings, instanceID string) (Provider, error) {
+	client, err := settings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	if instanceID == "" {
+		id := make([]byte, 16)
+		if _, err = rand.Read(id); err != nil {
+			return nil, fmt.Errorf("cannot generate instance ID: %w", err)
+		}
+		instanceID = hex.EncodeToString(id)
+	}
+	return &opampProvider{
+		endpoint:   settings.Endpoint,
+		client:     client,
+		instanceID: instanceID,
+	}, nil
+}
+
+func (op *opampProvider) Retrieve(ctx context.Context) ([]byte, error) {
+	body, err := json.Marshal(agentToServer{
+		InstanceUID:        op.instanceID,
+		RemoteConfigStatus: remoteConfigStatus{LastRemoteConfigHash: op.configHash},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, op.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := op.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve configuration: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent, http.StatusNotModified:
+		return nil, ErrNotModified
+	default:
+		return nil, fmt.Errorf("cannot retrieve configuration, unexpected status: %s", resp.Status)
+	}
+
+	body, err = readResponse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read management server response: %w", err)
+	}
+	var msg serverToAgent
+	if err = json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("cannot decode management server response: %w", err)
+	}
+	if msg.RemoteConfig == nil || (op.configHash != "" && msg.RemoteConfig.ConfigHash == op.configHash) {
+		return nil, ErrNotModified
+	}
+	op.configHash = msg.RemoteConfig.ConfigHash
+	return []byte(msg.RemoteConfig.Config), nil
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+func TestOpAMPProvider(t *testing.T) {
+	config, hash := "receivers:\n", "v1"
+	var status agentToServer
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+		if status.RemoteConfigStatus.LastRemoteConfigHash == hash {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(serverToAgent{RemoteConfig: &remoteConfig{Config: config, ConfigHash: hash}})
+	}))
+	defer server.Close()
+
+	provider, err := NewOpAMPProvider(confighttp.HTTPClientSettings{Endpoint: server.URL}, "instance")
+	require.NoError(t, err)
+
+	retrieved, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "receivers:\n", string(retrieved))
+	assert.Equal(t, agentToServer{InstanceUID: "instance"}, status)
+
+	_, err = provider.Retrieve(context.Background())
+	assert.Equal(t, ErrNotModified, err)
+	assert.Equal(t, "v1", status.RemoteConfigStatus.LastRemoteConfigHash)
+
+	config, hash = "exporters:\n", "v2"
+	retrieved, err = provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "exporters:\n", string(retrieved))
+}
+
+func TestOpAMPProvider_UnchangedHash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(serverToAgent{RemoteConfig: &remoteConfig{Config: "receivers:\n", ConfigHash: "v1"}})
+	}))
+	defer server.Close()
+
+	provider, err := NewOpAMPProvider(confighttp.HTTPClientSettings{Endpoint: server.URL}, "")
+	require.NoError(t, err)
+	assert.Len(t, provider.(*opampProvider).instanceID, 32)
+
+	_, err = provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	_, err = provider.Retrieve(context.Background())
+	assert.Equal(t, ErrNotModified, err)
+}
+
+func TestOpAMPProvider_InvalidResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("receivers:\n"))
+	}))
+	defer server.Close()
+
+	provider, err := NewOpAMPProvider(confighttp.HTTPClientSettings{Endpoint: server.URL}, "instance")
+	require.NoError(t, err)
+
+	_, err = provider.Retrieve(context.Background())
+	assert.Error(t, err)
+}
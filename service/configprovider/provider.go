@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configprovider retrieves the configuration of the collector from remote sources, so that fleets of
+// collectors can be managed centrally.
+package configprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// ErrNotModified is returned by Retrieve when the configuration did not change since the previous successful call.
+var ErrNotModified = errors.New("configuration not modified")
+
+// maxResponseSize is the maximum size of the responses of the configuration endpoints.
+const maxResponseSize = 16 << 20
+
+// Provider retrieves the configuration of the collector.
+type Provider interface {
+	// Retrieve returns the YAML configuration. It returns ErrNotModified when the configuration did not change since
+	// the previous successful call.
+	Retrieve(ctx context.Context) ([]byte, error)
+}
+
+// Settings configures the providers created by New.
+type Settings struct {
+	// HTTPClientSettings configures the HTTP client retrieving the configuration. The endpoint is the URL of the
+	// configuration, its scheme selects the provider:
+	//  - http and https: the configuration is the body of the response to a GET request.
+	//  - opamp+http and opamp+https: the configuration is offered by an OpAMP-style management server.
+	// The authenticator extensions are not started when the configuration is retrieved, the credentials have to be
+	// set in the headers or in the URL.
+	confighttp.HTTPClientSettings
+
+	// InstanceID identifies the collector to the OpAMP-style management servers, a random ID is generated when it
+	// is not set.
+	InstanceID string
+}
+
+// New creates the provider selected by the scheme of the endpoint.
+func New(settings Settings) (Provider, error) {
+	u, err := url.Parse(settings.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration endpoint %q: %w", settings.Endpoint, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return NewHTTPProvider(settings.HTTPClientSettings)
+	case "opamp+http", "opamp+https":
+		settings.Endpoint = settings.Endpoint[len("opamp+"):]
+		return NewOpAMPProvider(settings.HTTPClientSettings, settings.InstanceID)
+	default:
+		return nil, fmt.Errorf("unsupported configuration endpoint scheme %q", u.Scheme)
+	}
+}
+
+// readResponse reads the body of a response of a configuration endpoint, failing when it exceeds maxResponseSize.
+func readResponse(body io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("response larger than %d bytes", maxResponseSize)
+	}
+	return data, nil
+}
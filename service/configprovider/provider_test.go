@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configprovider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		provider Provider
+		err      string
+	}{
+		{
+			endpoint: "https://config.example.com/collector.yaml",
+			provider: &httpProvider{endpoint: "https://config.example.com/collector.yaml"},
+		},
+		{
+			endpoint: "opamp+https://opamp.example.com/v1/config",
+			provider: &opampProvider{endpoint: "https://opamp.example.com/v1/config", instanceID: "instance"},
+		},
+		{
+			endpoint: "s3://bucket/path/collector.yaml",
+			err:      `unsupported configuration endpoint scheme "s3"`,
+		},
+		{
+			endpoint: "ftp://config.example.com/collector.yaml",
+			err:      `unsupported configuration endpoint scheme "ftp"`,
+		},
+		{
+			endpoint: "://config",
+			err:      `invalid configuration endpoint "://config": parse "://config": missing protocol scheme`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.endpoint, func(t *testing.T) {
+			provider, err := New(Settings{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: test.endpoint},
+				InstanceID:         "instance",
+			})
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			switch p := provider.(type) {
+			case *httpProvider:
+				assert.Equal(t, test.provider.(*httpProvider).endpoint, p.endpoint)
+			case *opampProvider:
+				assert.Equal(t, test.provider.(*opampProvider).endpoint, p.endpoint)
+				assert.Equal(t, test.provider.(*opampProvider).instanceID, p.instanceID)
+			default:
+				t.Fatalf("unexpected provider %T", provider)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidHTTPClientSettings(t *testing.T) {
+	_, err := New(Settings{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint:    "https://config.example.com/collector.yaml",
+			Compression: "lz4",
+		},
+	})
+	assert.EqualError(t, err, `unsupported compression type "lz4"`)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaultcomponents
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestDefaultConnectors(t *testing.T) {
+	allFactories, err := Components()
+	require.NoError(t, err)
+
+	connFactories := allFactories.Connectors
+
+	tests := []struct {
+		connector configmodels.Type
+	}{
+		{
+			connector: "forward",
+		},
+		{
+			connector: "logmetrics",
+		},
+		{
+			connector: "spanmetrics",
+		},
+	}
+
+	assert.Equal(t, len(tests), len(connFactories))
+	for _, tt := range tests {
+		t.Run(string(tt.connector), func(t *testing.T) {
+			factory, ok := connFactories[tt.connector]
+			require.True(t, ok)
+			assert.Equal(t, tt.connector, factory.Type())
+			assert.Equal(t, tt.connector, factory.CreateDefaultConfig().Type())
+
+			verifyConnectorLifecycle(t, factory)
+		})
+	}
+}
+
+// verifyConnectorLifecycle is used to test if a connector type can handle the typical
+// lifecycle of a component, for all the pairs of data types it supports.
+func verifyConnectorLifecycle(t *testing.T, factory component.ConnectorFactory) {
+	ctx := context.Background()
+	host := newAssertNoErrorHost(t)
+	connectorCreateParams := component.ConnectorCreateParams{
+		Logger:               zap.NewNop(),
+		ApplicationStartInfo: component.DefaultApplicationStartInfo(),
+	}
+
+	createFns := []func() (component.Connector, error){
+		func() (component.Connector, error) {
+			return factory.CreateTracesToTracesConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewTracesNop())
+		},
+		func() (component.Connector, error) {
+			return factory.CreateTracesToMetricsConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewMetricsNop())
+		},
+		func() (component.Connector, error) {
+			return factory.CreateTracesToLogsConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewLogsNop())
+		},
+		func() (component.Connector, error) {
+			return factory.CreateMetricsToTracesConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewTracesNop())
+		},
+		func() (component.Connector, error) {
+			return factory.CreateMetricsToMetricsConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewMetricsNop())
+		},
+		func() (component.Connector, error) {
+			return factory.CreateMetricsToLogsConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewLogsNop())
+		},
+		func() (component.Connector, error) {
+			return factory.CreateLogsToTracesConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewTracesNop())
+		},
+		func() (component.Connector, error) {
+			return factory.CreateLogsToMetricsConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewMetricsNop())
+		},
+		func() (component.Connector, error) {
+			return factory.CreateLogsToLogsConnector(ctx, connectorCreateParams, factory.CreateDefaultConfig(), consumertest.NewLogsNop())
+		},
+	}
+
+	for _, createFn := range createFns {
+		firstConn, err := createFn()
+		if errors.Is(err, configerror.ErrDataTypeIsNotSupported) {
+			continue
+		}
+		require.NoError(t, err)
+		require.NoError(t, firstConn.Start(ctx, host))
+		require.NoError(t, firstConn.Shutdown(ctx))
+
+		secondConn, err := createFn()
+		require.NoError(t, err)
+		require.NoError(t, secondConn.Start(ctx, host))
+		require.NoError(t, secondConn.Shutdown(ctx))
+	}
+}
@@ -29,9 +29,11 @@ import (
 	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/carbonexporter"
 	"go.opentelemetry.io/collector/exporter/fileexporter"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
 	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+	"go.opentelemetry.io/collector/exporter/loadbalancingexporter"
 	"go.opentelemetry.io/collector/exporter/opencensusexporter"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
@@ -52,6 +54,14 @@ func TestDefaultExporters(t *testing.T) {
 		getConfigFn   getExporterConfigFn
 		skipLifecycle bool
 	}{
+		{
+			exporter: "carbon",
+			getConfigFn: func() configmodels.Exporter {
+				cfg := expFactories["carbon"].CreateDefaultConfig().(*carbonexporter.Config)
+				cfg.Endpoint = endpoint
+				return cfg
+			},
+		},
 		{
 			exporter: "file",
 			getConfigFn: func() configmodels.Exporter {
@@ -81,6 +91,14 @@ func TestDefaultExporters(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			exporter: "loadbalancing",
+			getConfigFn: func() configmodels.Exporter {
+				cfg := expFactories["loadbalancing"].CreateDefaultConfig().(*loadbalancingexporter.Config)
+				cfg.Resolver.Static = &loadbalancingexporter.StaticResolver{Hostnames: []string{endpoint}}
+				return cfg
+			},
+		},
 		{
 			exporter:      "logging",
 			skipLifecycle: runtime.GOOS == "darwin", // TODO: investigate why this fails on darwin.
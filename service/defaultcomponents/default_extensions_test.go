@@ -16,6 +16,10 @@ package defaultcomponents
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,7 +29,11 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/extension/bearertokenauthextension"
+	"go.opentelemetry.io/collector/extension/filestorageextension"
 	"go.opentelemetry.io/collector/extension/healthcheckextension"
+	"go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+	"go.opentelemetry.io/collector/extension/oidcauthextension"
 	"go.opentelemetry.io/collector/extension/pprofextension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/testutil"
@@ -39,6 +47,13 @@ func TestDefaultExtensions(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	port := testutil.GetAvailablePort(t)
 
+	// The oidc extension fetches the configuration of the provider when it starts.
+	var oidcServer *httptest.Server
+	oidcServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": oidcServer.URL, "jwks_uri": oidcServer.URL + "/jwks"})
+	}))
+	defer oidcServer.Close()
+
 	tests := []struct {
 		extension   configmodels.Type
 		getConfigFn getExtensionConfigFn
@@ -70,6 +85,44 @@ func TestDefaultExtensions(t *testing.T) {
 		{
 			extension: "fluentbit",
 		},
+		{
+			extension: "file_storage",
+			getConfigFn: func() configmodels.Extension {
+				cfg := extFactories["file_storage"].CreateDefaultConfig().(*filestorageextension.Config)
+				cfg.Directory = os.TempDir()
+				return cfg
+			},
+		},
+		{
+			extension: "bearertokenauth",
+			getConfigFn: func() configmodels.Extension {
+				cfg := extFactories["bearertokenauth"].CreateDefaultConfig().(*bearertokenauthextension.Config)
+				cfg.Tokens = []bearertokenauthextension.TokenConfig{{Token: "token", Subject: "subject"}}
+				return cfg
+			},
+		},
+		{
+			extension: "mtlsauth",
+		},
+		{
+			extension: "oidc",
+			getConfigFn: func() configmodels.Extension {
+				cfg := extFactories["oidc"].CreateDefaultConfig().(*oidcauthextension.Config)
+				cfg.IssuerURL = oidcServer.URL
+				cfg.Audience = "unit-test"
+				return cfg
+			},
+		},
+		{
+			extension: "oauth2client",
+			getConfigFn: func() configmodels.Extension {
+				cfg := extFactories["oauth2client"].CreateDefaultConfig().(*oauth2clientauthextension.Config)
+				cfg.ClientID = "unit-test"
+				cfg.ClientSecret = "unit-test"
+				cfg.TokenURL = oidcServer.URL
+				return cfg
+			},
+		},
 	}
 
 	assert.Equal(t, len(tests), len(extFactories))
@@ -80,7 +133,7 @@ func TestDefaultExtensions(t *testing.T) {
 			assert.Equal(t, tt.extension, factory.Type())
 			assert.Equal(t, tt.extension, factory.CreateDefaultConfig().Type())
 
-			verifyExtensionLifecycle(t, factory, nil)
+			verifyExtensionLifecycle(t, factory, tt.getConfigFn)
 		})
 	}
 }
@@ -30,8 +30,12 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/attributesprocessor"
 	"go.opentelemetry.io/collector/processor/memorylimiter"
+	"go.opentelemetry.io/collector/processor/metricstransformprocessor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor"
+	"go.opentelemetry.io/collector/processor/resourcelabelsprocessor"
 	"go.opentelemetry.io/collector/processor/resourceprocessor"
+	"go.opentelemetry.io/collector/processor/routingprocessor"
 	"go.opentelemetry.io/collector/processor/spanprocessor"
 )
 
@@ -58,9 +62,21 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "batch",
 		},
+		{
+			processor: "cumulativetodelta",
+		},
+		{
+			processor: "deltatocumulative",
+		},
+		{
+			processor: "failover",
+		},
 		{
 			processor: "filter",
 		},
+		{
+			processor: "logdedup",
+		},
 		{
 			processor: "memory_limiter",
 			getConfigFn: func() configmodels.Processor {
@@ -70,9 +86,20 @@ func TestDefaultProcessors(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			processor: "metricstransform",
+			getConfigFn: func() configmodels.Processor {
+				cfg := procFactories["metricstransform"].CreateDefaultConfig().(*metricstransformprocessor.Config)
+				cfg.Transforms = []metricstransformprocessor.Transform{{Include: "system.cpu.usage", NewName: "cpu_usage"}}
+				return cfg
+			},
+		},
 		{
 			processor: "probabilistic_sampler",
 		},
+		{
+			processor: "redaction",
+		},
 		{
 			processor: "resource",
 			getConfigFn: func() configmodels.Processor {
@@ -83,6 +110,30 @@ func TestDefaultProcessors(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			processor: "resourcedetection",
+			getConfigFn: func() configmodels.Processor {
+				cfg := procFactories["resourcedetection"].CreateDefaultConfig().(*resourcedetectionprocessor.Config)
+				cfg.Detectors = []string{"env"}
+				return cfg
+			},
+		},
+		{
+			processor: "resourcelabels",
+			getConfigFn: func() configmodels.Processor {
+				cfg := procFactories["resourcelabels"].CreateDefaultConfig().(*resourcelabelsprocessor.Config)
+				cfg.ResourceToLabels = []resourcelabelsprocessor.KeyAction{{Key: "service.name"}}
+				return cfg
+			},
+		},
+		{
+			processor: "routing",
+			getConfigFn: func() configmodels.Processor {
+				cfg := procFactories["routing"].CreateDefaultConfig().(*routingprocessor.Config)
+				cfg.FromAttribute = "X-Tenant"
+				return cfg
+			},
+		},
 		{
 			processor: "span",
 			getConfigFn: func() configmodels.Processor {
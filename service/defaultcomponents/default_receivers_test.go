@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/filereceiver"
 	"go.opentelemetry.io/collector/receiver/prometheusreceiver"
 )
 
@@ -42,6 +43,14 @@ func TestDefaultReceivers(t *testing.T) {
 		skipLifecyle bool
 		getConfigFn  getReceiverConfigFn
 	}{
+		{
+			receiver: "file",
+			getConfigFn: func() configmodels.Receiver {
+				cfg := rcvrFactories["file"].CreateDefaultConfig().(*filereceiver.Config)
+				cfg.Path = "./filename.json"
+				return cfg
+			},
+		},
 		{
 			receiver: "fluentforward",
 		},
@@ -74,6 +83,12 @@ func TestDefaultReceivers(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			receiver: "statsd",
+		},
+		{
+			receiver: "syslog",
+		},
 		{
 			receiver: "zipkin",
 		},
@@ -17,10 +17,15 @@ package defaultcomponents
 
 import (
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector/forwardconnector"
+	"go.opentelemetry.io/collector/connector/logmetricsconnector"
+	"go.opentelemetry.io/collector/connector/spanmetricsconnector"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/carbonexporter"
 	"go.opentelemetry.io/collector/exporter/fileexporter"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
 	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+	"go.opentelemetry.io/collector/exporter/loadbalancingexporter"
 	"go.opentelemetry.io/collector/exporter/loggingexporter"
 	"go.opentelemetry.io/collector/exporter/opencensusexporter"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
@@ -28,17 +33,32 @@ import (
 	"go.opentelemetry.io/collector/exporter/prometheusexporter"
 	"go.opentelemetry.io/collector/exporter/prometheusremotewriteexporter"
 	"go.opentelemetry.io/collector/exporter/zipkinexporter"
+	"go.opentelemetry.io/collector/extension/bearertokenauthextension"
+	"go.opentelemetry.io/collector/extension/filestorageextension"
 	"go.opentelemetry.io/collector/extension/fluentbitextension"
 	"go.opentelemetry.io/collector/extension/healthcheckextension"
+	"go.opentelemetry.io/collector/extension/mtlsauthextension"
+	"go.opentelemetry.io/collector/extension/oauth2clientauthextension"
+	"go.opentelemetry.io/collector/extension/oidcauthextension"
 	"go.opentelemetry.io/collector/extension/pprofextension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/processor/attributesprocessor"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/processor/cumulativetodeltaprocessor"
+	"go.opentelemetry.io/collector/processor/deltatocumulativeprocessor"
+	"go.opentelemetry.io/collector/processor/failoverprocessor"
 	"go.opentelemetry.io/collector/processor/filterprocessor"
+	"go.opentelemetry.io/collector/processor/logdedupprocessor"
 	"go.opentelemetry.io/collector/processor/memorylimiter"
+	"go.opentelemetry.io/collector/processor/metricstransformprocessor"
 	"go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+	"go.opentelemetry.io/collector/processor/redactionprocessor"
+	"go.opentelemetry.io/collector/processor/resourcedetectionprocessor"
+	"go.opentelemetry.io/collector/processor/resourcelabelsprocessor"
 	"go.opentelemetry.io/collector/processor/resourceprocessor"
+	"go.opentelemetry.io/collector/processor/routingprocessor"
 	"go.opentelemetry.io/collector/processor/spanprocessor"
+	"go.opentelemetry.io/collector/receiver/filereceiver"
 	"go.opentelemetry.io/collector/receiver/fluentforwardreceiver"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver"
 	"go.opentelemetry.io/collector/receiver/jaegerreceiver"
@@ -46,6 +66,8 @@ import (
 	"go.opentelemetry.io/collector/receiver/opencensusreceiver"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver"
 	"go.opentelemetry.io/collector/receiver/prometheusreceiver"
+	"go.opentelemetry.io/collector/receiver/statsdreceiver"
+	"go.opentelemetry.io/collector/receiver/syslogreceiver"
 	"go.opentelemetry.io/collector/receiver/zipkinreceiver"
 )
 
@@ -62,6 +84,11 @@ func Components() (
 		pprofextension.NewFactory(),
 		zpagesextension.NewFactory(),
 		fluentbitextension.NewFactory(),
+		filestorageextension.NewFactory(),
+		bearertokenauthextension.NewFactory(),
+		mtlsauthextension.NewFactory(),
+		oidcauthextension.NewFactory(),
+		oauth2clientauthextension.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -76,6 +103,9 @@ func Components() (
 		otlpreceiver.NewFactory(),
 		hostmetricsreceiver.NewFactory(),
 		kafkareceiver.NewFactory(),
+		filereceiver.NewFactory(),
+		syslogreceiver.NewFactory(),
+		statsdreceiver.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -92,6 +122,8 @@ func Components() (
 		otlpexporter.NewFactory(),
 		otlphttpexporter.NewFactory(),
 		kafkaexporter.NewFactory(),
+		loadbalancingexporter.NewFactory(),
+		carbonexporter.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -105,6 +137,24 @@ func Components() (
 		probabilisticsamplerprocessor.NewFactory(),
 		spanprocessor.NewFactory(),
 		filterprocessor.NewFactory(),
+		routingprocessor.NewFactory(),
+		failoverprocessor.NewFactory(),
+		metricstransformprocessor.NewFactory(),
+		logdedupprocessor.NewFactory(),
+		redactionprocessor.NewFactory(),
+		cumulativetodeltaprocessor.NewFactory(),
+		deltatocumulativeprocessor.NewFactory(),
+		resourcelabelsprocessor.NewFactory(),
+		resourcedetectionprocessor.NewFactory(),
+	)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	connectors, err := component.MakeConnectorFactoryMap(
+		forwardconnector.NewFactory(),
+		logmetricsconnector.NewFactory(),
+		spanmetricsconnector.NewFactory(),
 	)
 	if err != nil {
 		errs = append(errs, err)
@@ -115,6 +165,7 @@ func Components() (
 		Receivers:  receivers,
 		Processors: processors,
 		Exporters:  exporters,
+		Connectors: connectors,
 	}
 
 	return factories, consumererror.Combine(errs)
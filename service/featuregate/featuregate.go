@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package featuregate allows new or risky behaviors of the components to ship disabled, and to be enabled per
+// deployment with the --feature-gates flag or the feature_gates of the service configuration.
+package featuregate
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const featureGatesFlag = "feature-gates"
+
+// Gate is a feature that can be enabled or disabled per deployment.
+type Gate struct {
+	// ID is the unique identifier of the gate, e.g. "exporter.otlp.newTranslation".
+	ID string
+	// Description describes the behavior enabled by the gate.
+	Description string
+	// Enabled is the state of the gate when it is not set by the flag or the configuration.
+	Enabled bool
+}
+
+type registry struct {
+	mu      sync.RWMutex
+	gates   map[string]Gate
+	enabled map[string]bool
+}
+
+var (
+	reg = &registry{
+		gates:   make(map[string]Gate),
+		enabled: make(map[string]bool),
+	}
+	flagGates gatesValue
+)
+
+// Register registers a gate, it is usually called by the components from an init function.
+func Register(g Gate) error {
+	if g.ID == "" {
+		return fmt.Errorf("feature gate must have an id")
+	}
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if _, ok := reg.gates[g.ID]; ok {
+		return fmt.Errorf("feature gate %q is already registered", g.ID)
+	}
+	reg.gates[g.ID] = g
+	reg.enabled[g.ID] = g.Enabled
+	return nil
+}
+
+// IsEnabled returns whether the gate is enabled, false if it is not registered.
+func IsEnabled(id string) bool {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	return reg.enabled[id]
+}
+
+// List returns the registered gates sorted by id, with their current state.
+func List() []Gate {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	gates := make([]Gate, 0, len(reg.gates))
+	for id, g := range reg.gates {
+		g.Enabled = reg.enabled[id]
+		gates = append(gates, g)
+	}
+	sort.Slice(gates, func(i, j int) bool { return gates[i].ID < gates[j].ID })
+	return gates
+}
+
+// Flags adds the --feature-gates flag to the given flagset.
+func Flags(flags *flag.FlagSet) {
+	flagGates = gatesValue{}
+	flags.Var(flagGates, featureGatesFlag,
+		"Comma-delimited list of feature gate identifiers to enable, the identifiers prefixed with '-' are disabled. "+
+			"The flag takes precedence over the feature_gates of the service configuration.")
+}
+
+// Apply resets the gates to their registered state, then sets the configured gates and finally the gates of the
+// --feature-gates flag. A configured identifier enables the gate, or disables it when it is prefixed with '-'.
+// It returns an error if an identifier does not match a registered gate.
+func Apply(configured []string) error {
+	states := gatesValue{}
+	for _, id := range configured {
+		if err := states.Set(id); err != nil {
+			return err
+		}
+	}
+	for id, enabled := range flagGates {
+		states[id] = enabled
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	for id := range states {
+		if _, ok := reg.gates[id]; !ok {
+			return fmt.Errorf("unknown feature gate %q", id)
+		}
+	}
+	for id, g := range reg.gates {
+		reg.enabled[id] = g.Enabled
+	}
+	for id, enabled := range states {
+		reg.enabled[id] = enabled
+	}
+	return nil
+}
+
+// gatesValue is a flag.Value holding the state of the gates set by a comma-delimited list of identifiers.
+type gatesValue map[string]bool
+
+var _ flag.Value = (gatesValue)(nil)
+
+func (g gatesValue) String() string {
+	ids := make([]string, 0, len(g))
+	for id, enabled := range g {
+		if !enabled {
+			id = "-" + id
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func (g gatesValue) Set(s string) error {
+	for _, id := range strings.Split(s, ",") {
+		id = strings.TrimSpace(id)
+		enabled := true
+		switch {
+		case strings.HasPrefix(id, "-"):
+			id, enabled = id[1:], false
+		case strings.HasPrefix(id, "+"):
+			id = id[1:]
+		}
+		if id == "" {
+			return fmt.Errorf("empty feature gate identifier in %q", s)
+		}
+		g[id] = enabled
+	}
+	return nil
+}
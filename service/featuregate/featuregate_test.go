@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featuregate
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resetRegistry(t *testing.T) {
+	reg = &registry{gates: make(map[string]Gate), enabled: make(map[string]bool)}
+	flagGates = gatesValue{}
+	t.Cleanup(func() {
+		reg = &registry{gates: make(map[string]Gate), enabled: make(map[string]bool)}
+		flagGates = gatesValue{}
+	})
+}
+
+func TestRegister(t *testing.T) {
+	resetRegistry(t)
+
+	require.NoError(t, Register(Gate{ID: "b", Description: "second", Enabled: true}))
+	require.NoError(t, Register(Gate{ID: "a", Description: "first"}))
+	assert.EqualError(t, Register(Gate{ID: "a"}), `feature gate "a" is already registered`)
+	assert.Error(t, Register(Gate{}))
+
+	assert.False(t, IsEnabled("a"))
+	assert.True(t, IsEnabled("b"))
+	assert.False(t, IsEnabled("unknown"))
+	assert.Equal(t, []Gate{
+		{ID: "a", Description: "first"},
+		{ID: "b", Description: "second", Enabled: true},
+	}, List())
+}
+
+func TestApply(t *testing.T) {
+	resetRegistry(t)
+	require.NoError(t, Register(Gate{ID: "a"}))
+	require.NoError(t, Register(Gate{ID: "b", Enabled: true}))
+	require.NoError(t, Register(Gate{ID: "c"}))
+
+	require.NoError(t, Apply([]string{"a", "-b"}))
+	assert.True(t, IsEnabled("a"))
+	assert.False(t, IsEnabled("b"))
+	assert.False(t, IsEnabled("c"))
+
+	// The flag takes precedence over the configuration.
+	flags := new(flag.FlagSet)
+	Flags(flags)
+	require.NoError(t, flags.Parse([]string{"--feature-gates=-a, +c"}))
+	assert.Equal(t, "-a,c", flagGates.String())
+	require.NoError(t, Apply([]string{"a", "-b"}))
+	assert.False(t, IsEnabled("a"))
+	assert.False(t, IsEnabled("b"))
+	assert.True(t, IsEnabled("c"))
+
+	// The gates not set any more are back to their registered state.
+	flagGates = gatesValue{}
+	require.NoError(t, Apply(nil))
+	assert.False(t, IsEnabled("a"))
+	assert.True(t, IsEnabled("b"))
+	assert.False(t, IsEnabled("c"))
+
+	// The state is not changed on error.
+	assert.EqualError(t, Apply([]string{"-b", "unknown"}), `unknown feature gate "unknown"`)
+	assert.True(t, IsEnabled("b"))
+	assert.EqualError(t, Apply([]string{"a,-"}), `empty feature gate identifier in "a,-"`)
+}
@@ -17,41 +17,70 @@ package builder
 import (
 	"flag"
 	"fmt"
+	"strings"
+	"time"
 )
 
 const (
 	// flags
 	configCfg      = "config"
 	memBallastFlag = "mem-ballast-size-mib"
+	configWatchCfg = "config-watch-interval"
 
 	kindLogKey        = "component_kind"
 	kindLogsReceiver  = "receiver"
 	kindLogsProcessor = "processor"
 	kindLogsExporter  = "exporter"
 	kindLogExtension  = "extension"
+	kindLogsConnector = "connector"
 	typeLogKey        = "component_type"
 	nameLogKey        = "component_name"
 )
 
 var (
-	configFile     *string
-	memBallastSize *uint
+	configFiles         *stringArrayValue
+	memBallastSize      *uint
+	configWatchInterval *time.Duration
 )
 
 // Flags adds flags related to basic building of the collector application to the given flagset.
 func Flags(flags *flag.FlagSet) {
-	configFile = flags.String(configCfg, "", "Path to the config file")
+	configFiles = &stringArrayValue{}
+	flags.Var(configFiles, configCfg, "Path to the config file, or to a directory of config files. "+
+		"It can be repeated, the files are merged in order, maps are merged and the other values are overridden.")
 	memBallastSize = flags.Uint(memBallastFlag, 0,
 		fmt.Sprintf("Flag to specify size of memory (MiB) ballast to set. Ballast is not used when this is not specified. "+
 			"default settings: 0"))
+	configWatchInterval = flags.Duration(configWatchCfg, 0,
+		"Interval at which the config files are checked for changes, the configuration is reloaded when they changed. "+
+			"The files are not watched when this is not specified, the configuration is still reloaded on SIGHUP.")
 }
 
-// GetConfigFile gets the config file from the config file flag.
-func GetConfigFile() string {
-	return *configFile
+// GetConfigFiles gets the config files and directories from the config file flags, in order.
+func GetConfigFiles() []string {
+	return configFiles.values
 }
 
 // MemBallastSize returns the size of memory ballast to use in MBs
 func MemBallastSize() int {
 	return int(*memBallastSize)
 }
+
+// ConfigWatchInterval returns the interval at which the config file is checked for changes, zero if it is not watched.
+func ConfigWatchInterval() time.Duration {
+	return *configWatchInterval
+}
+
+// stringArrayValue is a flag.Value accumulating the values of a repeated flag.
+type stringArrayValue struct {
+	values []string
+}
+
+func (s *stringArrayValue) Set(value string) error {
+	s.values = append(s.values, value)
+	return nil
+}
+
+func (s *stringArrayValue) String() string {
+	return "[" + strings.Join(s.values, ", ") + "]"
+}
@@ -0,0 +1,325 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/fanoutconsumer"
+)
+
+// builtConnector is a connector that is built based on a config. A connector is created for each pair of the data
+// type of the pipelines exporting to it and of the data type of the pipelines receiving from it.
+type builtConnector struct {
+	logger *zap.Logger
+	name   string
+	// connByDataTypes maps the data type of the pipelines exporting to the connector, then the data type of the
+	// pipelines receiving from it, to the connector created for these data types.
+	connByDataTypes map[configmodels.DataType]map[configmodels.DataType]component.Connector
+}
+
+// components returns the distinct connectors, a factory may return the same connector for several pairs of data
+// types.
+func (bc *builtConnector) components() []component.Connector {
+	var conns []component.Connector
+	seen := make(map[component.Connector]bool)
+	for _, dataType := range []configmodels.DataType{configmodels.TracesDataType, configmodels.MetricsDataType, configmodels.LogsDataType} {
+		for _, conn := range bc.connByDataTypes[dataType] {
+			if !seen[conn] {
+				seen[conn] = true
+				conns = append(conns, conn)
+			}
+		}
+	}
+	return conns
+}
+
+// Start the connector.
+func (bc *builtConnector) Start(ctx context.Context, host component.Host) error {
+	for _, conn := range bc.components() {
+		if err := conn.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown the connector.
+func (bc *builtConnector) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, conn := range bc.components() {
+		if err := conn.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (bc *builtConnector) getTracesConsumer() consumer.Traces {
+	var consumers []consumer.Traces
+	for _, conn := range bc.connByDataTypes[configmodels.TracesDataType] {
+		consumers = append(consumers, conn.(component.TracesConnector))
+	}
+	if len(consumers) == 1 {
+		return consumers[0]
+	}
+	return fanoutconsumer.NewTraces(consumers)
+}
+
+func (bc *builtConnector) getMetricsConsumer() consumer.Metrics {
+	var consumers []consumer.Metrics
+	for _, conn := range bc.connByDataTypes[configmodels.MetricsDataType] {
+		consumers = append(consumers, conn.(component.MetricsConnector))
+	}
+	if len(consumers) == 1 {
+		return consumers[0]
+	}
+	return fanoutconsumer.NewMetrics(consumers)
+}
+
+func (bc *builtConnector) getLogsConsumer() consumer.Logs {
+	var consumers []consumer.Logs
+	for _, conn := range bc.connByDataTypes[configmodels.LogsDataType] {
+		consumers = append(consumers, conn.(component.LogsConnector))
+	}
+	if len(consumers) == 1 {
+		return consumers[0]
+	}
+	return fanoutconsumer.NewLogs(consumers)
+}
+
+// buildConnector creates the connectors consuming the data type of the pipeline exporting to the connector, and
+// plugs them into the pipelines receiving from the connector. These pipelines must be already built.
+func (pb *pipelinesBuilder) buildConnector(
+	ctx context.Context,
+	config configmodels.Connector,
+	exporterDataType configmodels.DataType,
+	builtPipelines BuiltPipelines,
+) (*builtConnector, error) {
+	bc := pb.connectors[config]
+	if bc == nil {
+		bc = &builtConnector{
+			logger: pb.logger.With(zap.String(kindLogKey, kindLogsConnector), zap.String(typeLogKey, string(config.Type())),
+				zap.String(nameLogKey, config.Name())),
+			name:            config.Name(),
+			connByDataTypes: make(map[configmodels.DataType]map[configmodels.DataType]component.Connector),
+		}
+		pb.connectors[config] = bc
+	}
+	if bc.connByDataTypes[exporterDataType] != nil {
+		// Another pipeline of the same data type exports to the connector.
+		return bc, nil
+	}
+
+	factory := pb.connectorFactories[config.Type()]
+	if factory == nil {
+		return nil, fmt.Errorf("connector factory not found for type: %s", config.Type())
+	}
+
+	// Group the pipelines receiving from the connector by data type, the connector created for a data type fans out
+	// to all of them.
+	receiverPipelines := make(map[configmodels.DataType][]*builtPipeline)
+	for _, name := range sortedPipelineNames(pb.config.Service.Pipelines) {
+		pipelineCfg := pb.config.Service.Pipelines[name]
+		if !hasReceiver(pipelineCfg, config.Name()) {
+			continue
+		}
+		bp := builtPipelines[pipelineCfg]
+		if bp == nil {
+			return nil, fmt.Errorf("cannot find pipeline processor for pipeline %s", pipelineCfg.Name)
+		}
+		receiverPipelines[pipelineCfg.InputType] = append(receiverPipelines[pipelineCfg.InputType], bp)
+	}
+
+	creationParams := component.ConnectorCreateParams{
+		Logger:               bc.logger,
+		ApplicationStartInfo: pb.appInfo,
+	}
+
+	conns := make(map[configmodels.DataType]component.Connector, len(receiverPipelines))
+	for receiverDataType, pipelines := range receiverPipelines {
+		conn, err := createConnector(ctx, factory, creationParams, config, exporterDataType, receiverDataType, pipelines)
+		if err != nil {
+			if err == configerror.ErrDataTypeIsNotSupported {
+				return nil, fmt.Errorf("connector %q does not support connecting %s pipelines to %s pipelines",
+					config.Name(), exporterDataType, receiverDataType)
+			}
+			return nil, fmt.Errorf("cannot create connector %s: %v", config.Name(), err)
+		}
+
+		// Check if the factory really created the connector.
+		if conn == nil {
+			return nil, fmt.Errorf("factory for %q produced a nil connector", config.Name())
+		}
+		conns[receiverDataType] = conn
+		bc.logger.Info("Connector was built.", zap.String("exporter_datatype", string(exporterDataType)),
+			zap.String("receiver_datatype", string(receiverDataType)))
+	}
+	bc.connByDataTypes[exporterDataType] = conns
+
+	return bc, nil
+}
+
+func createConnector(
+	ctx context.Context,
+	factory component.ConnectorFactory,
+	params component.ConnectorCreateParams,
+	config configmodels.Connector,
+	exporterDataType configmodels.DataType,
+	receiverDataType configmodels.DataType,
+	pipelines []*builtPipeline,
+) (component.Connector, error) {
+	var conn component.Connector
+	var err error
+
+	// The typed connectors are only assigned when they are not nil, so that the returned interface is nil when the
+	// factory did not create the connector.
+	switch exporterDataType {
+	case configmodels.TracesDataType:
+		var tc component.TracesConnector
+		switch receiverDataType {
+		case configmodels.TracesDataType:
+			tc, err = factory.CreateTracesToTracesConnector(ctx, params, config, buildFanoutTraceConsumer(pipelines))
+		case configmodels.MetricsDataType:
+			tc, err = factory.CreateTracesToMetricsConnector(ctx, params, config, buildFanoutMetricConsumer(pipelines))
+		case configmodels.LogsDataType:
+			tc, err = factory.CreateTracesToLogsConnector(ctx, params, config, buildFanoutLogConsumer(pipelines))
+		default:
+			err = configerror.ErrDataTypeIsNotSupported
+		}
+		if tc != nil {
+			conn = tc
+		}
+
+	case configmodels.MetricsDataType:
+		var mc component.MetricsConnector
+		switch receiverDataType {
+		case configmodels.TracesDataType:
+			mc, err = factory.CreateMetricsToTracesConnector(ctx, params, config, buildFanoutTraceConsumer(pipelines))
+		case configmodels.MetricsDataType:
+			mc, err = factory.CreateMetricsToMetricsConnector(ctx, params, config, buildFanoutMetricConsumer(pipelines))
+		case configmodels.LogsDataType:
+			mc, err = factory.CreateMetricsToLogsConnector(ctx, params, config, buildFanoutLogConsumer(pipelines))
+		default:
+			err = configerror.ErrDataTypeIsNotSupported
+		}
+		if mc != nil {
+			conn = mc
+		}
+
+	case configmodels.LogsDataType:
+		var lc component.LogsConnector
+		switch receiverDataType {
+		case configmodels.TracesDataType:
+			lc, err = factory.CreateLogsToTracesConnector(ctx, params, config, buildFanoutTraceConsumer(pipelines))
+		case configmodels.MetricsDataType:
+			lc, err = factory.CreateLogsToMetricsConnector(ctx, params, config, buildFanoutMetricConsumer(pipelines))
+		case configmodels.LogsDataType:
+			lc, err = factory.CreateLogsToLogsConnector(ctx, params, config, buildFanoutLogConsumer(pipelines))
+		default:
+			err = configerror.ErrDataTypeIsNotSupported
+		}
+		if lc != nil {
+			conn = lc
+		}
+
+	default:
+		err = configerror.ErrDataTypeIsNotSupported
+	}
+
+	return conn, err
+}
+
+// sortedPipelineNames returns the names of the pipelines sorted, so that they are built in a deterministic order.
+func sortedPipelineNames(pipelines configmodels.Pipelines) []string {
+	names := make([]string, 0, len(pipelines))
+	for name := range pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// orderPipelines sorts the pipelines topologically: a pipeline exporting to a connector comes before the pipelines
+// receiving from it. An error is returned when the connectors create a cycle, the data would loop forever.
+func orderPipelines(config *configmodels.Config) ([]*configmodels.Pipeline, error) {
+	names := sortedPipelineNames(config.Service.Pipelines)
+
+	// downstream maps a pipeline to the pipelines it feeds through connectors, inDegree counts the pipelines feeding
+	// each pipeline.
+	downstream := make(map[string][]string)
+	inDegree := make(map[string]int)
+	for _, from := range names {
+		fromCfg := config.Service.Pipelines[from]
+		for _, to := range names {
+			toCfg := config.Service.Pipelines[to]
+			if connected(config, fromCfg, toCfg) {
+				downstream[from] = append(downstream[from], to)
+				inDegree[to]++
+			}
+		}
+	}
+
+	var ready []string
+	for _, name := range names {
+		if inDegree[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	ordered := make([]*configmodels.Pipeline, 0, len(names))
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, config.Service.Pipelines[name])
+		for _, to := range downstream[name] {
+			inDegree[to]--
+			if inDegree[to] == 0 {
+				ready = append(ready, to)
+			}
+		}
+	}
+
+	if len(ordered) != len(names) {
+		var cycle []string
+		for _, name := range names {
+			if inDegree[name] > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		return nil, fmt.Errorf("cycle detected in the pipelines connected by connectors: %s", strings.Join(cycle, ", "))
+	}
+	return ordered, nil
+}
+
+// connected returns true if a connector is an exporter of the first pipeline and a receiver of the second one.
+func connected(config *configmodels.Config, from, to *configmodels.Pipeline) bool {
+	for _, name := range from.Exporters {
+		if config.Connectors[name] != nil && hasReceiver(to, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/testcomponents"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestBuildPipelines_Connectors(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := configtest.LoadConfigFile(t, path.Join("testdata", "connectors.yaml"), factories)
+	require.NoError(t, err)
+
+	allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	pipelines, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
+	require.NoError(t, err)
+	require.Len(t, pipelines, 4)
+
+	// The pipelines exporting to the connector come before the pipelines receiving from it.
+	assert.Less(t, pipelines[cfg.Service.Pipelines["traces"]].order, pipelines[cfg.Service.Pipelines["traces/forwarded"]].order)
+	assert.Less(t, pipelines[cfg.Service.Pipelines["traces"]].order, pipelines[cfg.Service.Pipelines["metrics"]].order)
+	assert.Less(t, pipelines[cfg.Service.Pipelines["traces/2"]].order, pipelines[cfg.Service.Pipelines["traces/forwarded"]].order)
+
+	// Both pipelines exporting to the connector share the same instances.
+	tracesPipeline := pipelines[cfg.Service.Pipelines["traces"]]
+	traces2Pipeline := pipelines[cfg.Service.Pipelines["traces/2"]]
+	require.Len(t, tracesPipeline.connectors, 1)
+	require.Len(t, traces2Pipeline.connectors, 1)
+	assert.Same(t, tracesPipeline.connectors[0], traces2Pipeline.connectors[0])
+	conns := tracesPipeline.connectors[0].components()
+	require.Len(t, conns, 2)
+
+	require.NoError(t, pipelines.StartProcessors(context.Background(), componenttest.NewNopHost()))
+	for _, conn := range conns {
+		assert.True(t, conn.(*testcomponents.ExampleConnector).ConnectorStarted)
+	}
+
+	tracesExporter := allExporters[cfg.Exporters["exampleexporter"]].getTraceExporter().(*testcomponents.ExampleExporterConsumer)
+	metricsExporter := allExporters[cfg.Exporters["exampleexporter/2"]].getMetricExporter().(*testcomponents.ExampleExporterConsumer)
+
+	td := testdata.GenerateTraceDataOneSpan()
+	require.NoError(t, tracesPipeline.firstTC.ConsumeTraces(context.Background(), td))
+	assert.Len(t, tracesExporter.Traces, 1)
+	assert.EqualValues(t, td, tracesExporter.Traces[0])
+	assert.Len(t, metricsExporter.Metrics, 1)
+
+	require.NoError(t, traces2Pipeline.firstTC.ConsumeTraces(context.Background(), td))
+	assert.Len(t, tracesExporter.Traces, 2)
+	assert.Len(t, metricsExporter.Metrics, 2)
+
+	require.NoError(t, pipelines.ShutdownProcessors(context.Background()))
+	for _, conn := range conns {
+		assert.True(t, conn.(*testcomponents.ExampleConnector).ConnectorShutdown)
+	}
+}
+
+func TestBuildPipelines_ConnectorsErrors(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	tests := []struct {
+		configFile string
+		expected   string
+	}{
+		{
+			configFile: "connectors_cycle.yaml",
+			expected:   "cycle detected in the pipelines connected by connectors",
+		},
+		{
+			configFile: "connectors_not_supported.yaml",
+			expected:   `connector "exampleconnector" does not support connecting metrics pipelines to traces pipelines`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.configFile, func(t *testing.T) {
+			cfg, err := configtest.LoadConfigFile(t, path.Join("testdata", test.configFile), factories)
+			require.NoError(t, err)
+
+			allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+			require.NoError(t, err)
+
+			pipelines, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expected)
+			assert.Zero(t, len(pipelines))
+		})
+	}
+}
@@ -88,10 +88,11 @@ type Exporters map[configmodels.Exporter]*builtExporter
 
 // StartAll starts all exporters.
 func (exps Exporters) StartAll(ctx context.Context, host component.Host) error {
-	for _, exp := range exps {
+	for cfg, exp := range exps {
 		exp.logger.Info("Exporter is starting...")
 
-		if err := exp.Start(ctx, newHostWrapper(host, exp.logger)); err != nil {
+		source := component.StatusSource{Kind: component.KindExporter, Name: cfg.Name()}
+		if err := exp.Start(ctx, newHostWrapper(host, exp.logger, source)); err != nil {
 			return err
 		}
 		exp.logger.Info("Exporter started.")
@@ -193,8 +194,11 @@ func (eb *exportersBuilder) calcExportersRequiredDataTypes() exportersRequiredDa
 	for _, pipeline := range eb.config.Service.Pipelines {
 		// Iterate over all exporters for this pipeline.
 		for _, expName := range pipeline.Exporters {
-			// Find the exporter config by name.
+			// Find the exporter config by name, the connectors are built with the pipelines.
 			exporter := eb.config.Exporters[expName]
+			if exporter == nil {
+				continue
+			}
 
 			// Create the data type requirement for the exporter if it does not exist.
 			if result[exporter] == nil {
@@ -49,10 +49,11 @@ type Extensions map[configmodels.Extension]*builtExtension
 
 // StartAll starts all exporters.
 func (exts Extensions) StartAll(ctx context.Context, host component.Host) error {
-	for _, ext := range exts {
+	for cfg, ext := range exts {
 		ext.logger.Info("Extension is starting...")
 
-		if err := ext.Start(ctx, newHostWrapper(host, ext.logger)); err != nil {
+		source := component.StatusSource{Kind: component.KindExtension, Name: cfg.Name()}
+		if err := ext.Start(ctx, newHostWrapper(host, ext.logger, source)); err != nil {
 			return err
 		}
 
@@ -102,6 +103,15 @@ func (exts Extensions) NotifyPipelineNotReady() error {
 	return consumererror.Combine(errs)
 }
 
+// NotifyComponentStatusChanged notifies the extensions implementing component.StatusWatcher of a status event.
+func (exts Extensions) NotifyComponentStatusChanged(source component.StatusSource, event *component.StatusEvent) {
+	for _, ext := range exts {
+		if sw, ok := ext.extension.(component.StatusWatcher); ok {
+			sw.ComponentStatusChanged(source, event)
+		}
+	}
+}
+
 func (exts Extensions) ToMap() map[configmodels.NamedEntity]component.Extension {
 	result := make(map[configmodels.NamedEntity]component.Extension, len(exts))
 	for k, v := range exts {
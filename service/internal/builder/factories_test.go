@@ -19,6 +19,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/extension/extensionhelper"
 	"go.opentelemetry.io/collector/internal/testcomponents"
@@ -93,3 +95,34 @@ func newBadExtensionFactory() component.ExtensionFactory {
 		},
 	)
 }
+
+// passthroughProcessor sends the data unchanged to the next consumer.
+type passthroughProcessor struct{}
+
+func (passthroughProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	return td, nil
+}
+
+func (passthroughProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	return md, nil
+}
+
+// newMetricsMutatingProcessorFactory returns a factory of processors declaring that they mutate the metrics only.
+func newMetricsMutatingProcessorFactory() component.ProcessorFactory {
+	capabilities := processorhelper.WithCapabilities(component.ProcessorCapabilities{MutatesConsumedMetrics: true})
+	return processorhelper.NewFactory(
+		"metricsmutating",
+		func() configmodels.Processor {
+			return &configmodels.ProcessorSettings{
+				TypeVal: "metricsmutating",
+				NameVal: "metricsmutating",
+			}
+		},
+		processorhelper.WithTraces(func(_ context.Context, _ component.ProcessorCreateParams, cfg configmodels.Processor, next consumer.Traces) (component.TracesProcessor, error) {
+			return processorhelper.NewTraceProcessor(cfg, next, passthroughProcessor{}, capabilities)
+		}),
+		processorhelper.WithMetrics(func(_ context.Context, _ component.ProcessorCreateParams, cfg configmodels.Processor, next consumer.Metrics) (component.MetricsProcessor, error) {
+			return processorhelper.NewMetricsProcessor(cfg, next, passthroughProcessor{}, capabilities)
+		}),
+	)
+}
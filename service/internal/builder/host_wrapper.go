@@ -15,26 +15,75 @@
 package builder
 
 import (
+	"net/http"
+	"time"
+
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
 )
 
 // hostWrapper adds behavior on top of the component.Host being passed when starting the built components.
 type hostWrapper struct {
 	component.Host
 	*zap.Logger
+	// source identifies the wrapped component in the status events it reports.
+	source component.StatusSource
 }
 
-func newHostWrapper(host component.Host, logger *zap.Logger) component.Host {
+func newHostWrapper(host component.Host, logger *zap.Logger, source component.StatusSource) component.Host {
 	return &hostWrapper{
 		host,
 		logger,
+		source,
 	}
 }
 
+// statusHost is implemented by the service host, it records the status events with the component reporting them.
+type statusHost interface {
+	ReportComponentStatusFrom(source component.StatusSource, event *component.StatusEvent)
+}
+
 func (hw *hostWrapper) ReportFatalError(err error) {
 	// The logger from the built component already identifies the component.
 	hw.Logger.Error("Component fatal error", zap.Error(err))
 	hw.Host.ReportFatalError(err)
 }
+
+func (hw *hostWrapper) ReportComponentStatus(event *component.StatusEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	// The logger from the built component already identifies the component.
+	if event.Err != nil {
+		hw.Logger.Warn("Component status changed", zap.Stringer("status", event.Status), zap.Error(event.Err))
+	} else {
+		hw.Logger.Info("Component status changed", zap.Stringer("status", event.Status))
+	}
+	if sh, ok := hw.Host.(statusHost); ok {
+		sh.ReportComponentStatusFrom(hw.source, event)
+		return
+	}
+	hw.Host.ReportComponentStatus(event)
+}
+
+// RegisterZPages is used by the zpages extension to register the service zPages, the type assertion done by the
+// extension does not see the methods of the wrapped host.
+func (hw *hostWrapper) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
+	if zpagesHost, ok := hw.Host.(interface {
+		RegisterZPages(mux *http.ServeMux, pathPrefix string)
+	}); ok {
+		zpagesHost.RegisterZPages(mux, pathPrefix)
+	}
+}
+
+// GetPipelines returns the pipelines of the wrapped host, or nil when it does not expose them.
+func (hw *hostWrapper) GetPipelines() configmodels.Pipelines {
+	if pipelinesHost, ok := hw.Host.(interface {
+		GetPipelines() configmodels.Pipelines
+	}); ok {
+		return pipelinesHost.GetPipelines()
+	}
+	return nil
+}
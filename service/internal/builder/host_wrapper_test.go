@@ -16,14 +16,87 @@ package builder
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
 )
 
 func Test_newHostWrapper(t *testing.T) {
-	hw := newHostWrapper(componenttest.NewNopHost(), zap.NewNop())
+	hw := newHostWrapper(componenttest.NewNopHost(), zap.NewNop(), component.StatusSource{})
 	hw.ReportFatalError(errors.New("test error"))
 }
+
+type pipelinesHost struct {
+	component.Host
+	pipelines configmodels.Pipelines
+}
+
+func (h *pipelinesHost) GetPipelines() configmodels.Pipelines {
+	return h.pipelines
+}
+
+func Test_hostWrapperGetPipelines(t *testing.T) {
+	pipelines := configmodels.Pipelines{"traces": &configmodels.Pipeline{Name: "traces"}}
+	hw := newHostWrapper(&pipelinesHost{Host: componenttest.NewNopHost(), pipelines: pipelines}, zap.NewNop(), component.StatusSource{})
+	assert.Equal(t, pipelines, hw.(interface{ GetPipelines() configmodels.Pipelines }).GetPipelines())
+
+	hw = newHostWrapper(componenttest.NewNopHost(), zap.NewNop(), component.StatusSource{})
+	assert.Nil(t, hw.(interface{ GetPipelines() configmodels.Pipelines }).GetPipelines())
+}
+
+type zpagesHost struct {
+	component.Host
+	registered bool
+}
+
+func (h *zpagesHost) RegisterZPages(*http.ServeMux, string) {
+	h.registered = true
+}
+
+func Test_hostWrapperRegisterZPages(t *testing.T) {
+	host := &zpagesHost{Host: componenttest.NewNopHost()}
+	hw := newHostWrapper(host, zap.NewNop(), component.StatusSource{})
+	zpages, ok := hw.(interface {
+		RegisterZPages(mux *http.ServeMux, pathPrefix string)
+	})
+	assert.True(t, ok)
+	zpages.RegisterZPages(http.NewServeMux(), "/debug")
+	assert.True(t, host.registered)
+
+	// hosts without zPages are ignored
+	hw = newHostWrapper(componenttest.NewNopHost(), zap.NewNop(), component.StatusSource{})
+	hw.(interface {
+		RegisterZPages(mux *http.ServeMux, pathPrefix string)
+	}).RegisterZPages(http.NewServeMux(), "/debug")
+}
+
+type statusSourceHost struct {
+	component.Host
+	source component.StatusSource
+	event  *component.StatusEvent
+}
+
+func (h *statusSourceHost) ReportComponentStatusFrom(source component.StatusSource, event *component.StatusEvent) {
+	h.source = source
+	h.event = event
+}
+
+func Test_hostWrapperReportComponentStatus(t *testing.T) {
+	host := &statusSourceHost{Host: componenttest.NewNopHost()}
+	source := component.StatusSource{Kind: component.KindExporter, Name: "otlp"}
+	hw := newHostWrapper(host, zap.NewNop(), source)
+	hw.ReportComponentStatus(&component.StatusEvent{Status: component.StatusRecoverableError, Err: errors.New("unavailable")})
+	assert.Equal(t, source, host.source)
+	assert.Equal(t, component.StatusRecoverableError, host.event.Status)
+	assert.False(t, host.event.Timestamp.IsZero())
+
+	// hosts not recording the status sources receive the status event alone
+	hw = newHostWrapper(componenttest.NewNopHost(), zap.NewNop(), source)
+	hw.ReportComponentStatus(&component.StatusEvent{Status: component.StatusOK})
+}
@@ -17,6 +17,7 @@ package builder
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"go.uber.org/zap"
 
@@ -32,30 +33,105 @@ import (
 // processor in the pipeline or the exporter if pipeline has no processors).
 type builtPipeline struct {
 	logger  *zap.Logger
+	config  *configmodels.Pipeline
 	firstTC consumer.Traces
 	firstMC consumer.Metrics
 	firstLC consumer.Logs
 
 	// MutatesConsumedData is set to true if any processors in the pipeline
-	// can mutate the TraceData or MetricsData input argument.
+	// can mutate the input data of the data type of the pipeline.
 	MutatesConsumedData bool
 
+	// processors are the processors of the pipeline, without the processors of the chain.
+	processors []component.Processor
+
+	// chain is the processor chain the pipeline shares with the other pipelines referencing it, nil if none.
+	chain *builtChain
+
+	// connectors are the connectors the pipeline exports to.
+	connectors []*builtConnector
+
+	// order is the position of the pipeline when the pipelines are sorted topologically, a pipeline exporting to a
+	// connector comes before the pipelines receiving from it.
+	order int
+}
+
+// builtChain is a processor chain built once per data type and shared by the pipelines referencing it. The data of
+// the pipelines flows from their own processors into the first processor of the chain.
+type builtChain struct {
+	name    string
+	firstTC consumer.Traces
+	firstMC consumer.Metrics
+	firstLC consumer.Logs
+
+	// mutatesConsumedData is set to true if any processors in the chain can mutate the data.
+	mutatesConsumedData bool
+
 	processors []component.Processor
 }
 
+// chainKey identifies a built processor chain, a chain being built once for each data type.
+type chainKey struct {
+	name     string
+	dataType configmodels.DataType
+}
+
 // BuiltPipelines is a map of build pipelines created from pipeline configs.
 type BuiltPipelines map[*configmodels.Pipeline]*builtPipeline
 
-func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Host) error {
+// ordered returns the pipelines sorted topologically.
+func (bps BuiltPipelines) ordered() []*builtPipeline {
+	pipelines := make([]*builtPipeline, 0, len(bps))
 	for _, bp := range bps {
+		pipelines = append(pipelines, bp)
+	}
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].order < pipelines[j].order
+	})
+	return pipelines
+}
+
+// StartProcessors starts the processors and the connectors of the pipelines. The pipelines receiving from a
+// connector are started before the connector, which is started before the pipelines exporting to it.
+func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Host) error {
+	started := make(map[*builtConnector]bool)
+	startedChains := make(map[*builtChain]bool)
+	pipelines := bps.ordered()
+	for i := len(pipelines) - 1; i >= 0; i-- {
+		bp := pipelines[i]
+		for _, bc := range bp.connectors {
+			if started[bc] {
+				continue
+			}
+			bc.logger.Info("Connector is starting...")
+			source := component.StatusSource{Kind: component.KindConnector, Name: bc.name}
+			if err := bc.Start(ctx, newHostWrapper(host, bc.logger, source)); err != nil {
+				return err
+			}
+			bc.logger.Info("Connector started.")
+			started[bc] = true
+		}
+
 		bp.logger.Info("Pipeline is starting...")
-		hostWrapper := newHostWrapper(host, bp.logger)
 		// Start in reverse order, starting from the back of processors pipeline.
 		// This is important so that processors that are earlier in the pipeline and
 		// reference processors that are later in the pipeline do not start sending
 		// data to later pipelines which are not yet started.
+		if bp.chain != nil && !startedChains[bp.chain] {
+			chainProcessors := bp.config.Processors[len(bp.processors):]
+			for i := len(bp.chain.processors) - 1; i >= 0; i-- {
+				source := component.StatusSource{Kind: component.KindProcessor, Name: chainProcessors[i], Pipeline: bp.chain.name}
+				logger := bp.logger.With(zap.String(kindLogKey, kindLogsProcessor), zap.String(nameLogKey, source.Name))
+				if err := bp.chain.processors[i].Start(ctx, newHostWrapper(host, logger, source)); err != nil {
+					return err
+				}
+			}
+			startedChains[bp.chain] = true
+		}
 		for i := len(bp.processors) - 1; i >= 0; i-- {
-			if err := bp.processors[i].Start(ctx, hostWrapper); err != nil {
+			source := component.StatusSource{Kind: component.KindProcessor, Name: bp.config.Processors[i], Pipeline: bp.config.Name}
+			logger := bp.logger.With(zap.String(kindLogKey, kindLogsProcessor), zap.String(nameLogKey, source.Name))
+			if err := bp.processors[i].Start(ctx, newHostWrapper(host, logger, source)); err != nil {
 				return err
 			}
 		}
@@ -64,16 +140,53 @@ func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Ho
 	return nil
 }
 
+// ShutdownProcessors shuts down the processors and the connectors of the pipelines. A connector is shut down after
+// all the pipelines exporting to it, and before the pipelines receiving from it, so that the data is flushed through.
+// A processor chain is shut down after all the pipelines sharing it.
 func (bps BuiltPipelines) ShutdownProcessors(ctx context.Context) error {
-	var errs []error
+	// exporting counts the pipelines exporting to each connector that are not shut down yet.
+	exporting := make(map[*builtConnector]int)
+	// sharing counts the pipelines sharing each processor chain that are not shut down yet.
+	sharing := make(map[*builtChain]int)
 	for _, bp := range bps {
+		for _, bc := range bp.connectors {
+			exporting[bc]++
+		}
+		if bp.chain != nil {
+			sharing[bp.chain]++
+		}
+	}
+
+	var errs []error
+	for _, bp := range bps.ordered() {
 		bp.logger.Info("Pipeline is shutting down...")
 		for _, p := range bp.processors {
 			if err := p.Shutdown(ctx); err != nil {
 				errs = append(errs, err)
 			}
 		}
+		if bp.chain != nil {
+			sharing[bp.chain]--
+			if sharing[bp.chain] == 0 {
+				for _, p := range bp.chain.processors {
+					if err := p.Shutdown(ctx); err != nil {
+						errs = append(errs, err)
+					}
+				}
+			}
+		}
 		bp.logger.Info("Pipeline is shutdown.")
+
+		for _, bc := range bp.connectors {
+			exporting[bc]--
+			if exporting[bc] > 0 {
+				continue
+			}
+			if err := bc.Shutdown(ctx); err != nil {
+				errs = append(errs, err)
+			}
+			bc.logger.Info("Connector is shutdown.")
+		}
 	}
 
 	return consumererror.Combine(errs)
@@ -81,30 +194,44 @@ func (bps BuiltPipelines) ShutdownProcessors(ctx context.Context) error {
 
 // pipelinesBuilder builds Pipelines from config.
 type pipelinesBuilder struct {
-	logger    *zap.Logger
-	appInfo   component.ApplicationStartInfo
-	config    *configmodels.Config
-	exporters Exporters
-	factories map[configmodels.Type]component.ProcessorFactory
+	logger             *zap.Logger
+	appInfo            component.ApplicationStartInfo
+	config             *configmodels.Config
+	exporters          Exporters
+	factories          map[configmodels.Type]component.ProcessorFactory
+	connectorFactories map[configmodels.Type]component.ConnectorFactory
+	connectors         map[configmodels.Connector]*builtConnector
+	chains             map[chainKey]*builtChain
 }
 
-// BuildPipelines builds pipeline processors from config. Requires exporters to be already
-// built via BuildExporters.
+// BuildPipelines builds pipeline processors and the connectors between the pipelines from config.
+// Requires exporters to be already built via BuildExporters.
 func BuildPipelines(
 	logger *zap.Logger,
 	appInfo component.ApplicationStartInfo,
 	config *configmodels.Config,
 	exporters Exporters,
 	factories map[configmodels.Type]component.ProcessorFactory,
+	connectorFactories map[configmodels.Type]component.ConnectorFactory,
 ) (BuiltPipelines, error) {
-	pb := &pipelinesBuilder{logger, appInfo, config, exporters, factories}
+	pb := &pipelinesBuilder{logger, appInfo, config, exporters, factories, connectorFactories,
+		make(map[configmodels.Connector]*builtConnector), make(map[chainKey]*builtChain)}
+
+	// A connector is plugged into the pipelines receiving from it when it is created, build these pipelines before
+	// the pipelines exporting to the connector.
+	pipelines, err := orderPipelines(pb.config)
+	if err != nil {
+		return nil, err
+	}
 
 	pipelineProcessors := make(BuiltPipelines)
-	for _, pipeline := range pb.config.Service.Pipelines {
-		firstProcessor, err := pb.buildPipeline(context.Background(), pipeline)
+	for i := len(pipelines) - 1; i >= 0; i-- {
+		pipeline := pipelines[i]
+		firstProcessor, err := pb.buildPipeline(context.Background(), pipeline, pipelineProcessors)
 		if err != nil {
 			return nil, err
 		}
+		firstProcessor.order = i
 		pipelineProcessors[pipeline] = firstProcessor
 	}
 
@@ -114,34 +241,115 @@ func BuildPipelines(
 // Builds a pipeline of processors. Returns the first processor in the pipeline.
 // The last processor in the pipeline will be plugged to fan out the data into exporters
 // that are configured for this pipeline.
-func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *configmodels.Pipeline) (*builtPipeline, error) {
+func (pb *pipelinesBuilder) buildPipeline(
+	ctx context.Context,
+	pipelineCfg *configmodels.Pipeline,
+	builtPipelines BuiltPipelines,
+) (*builtPipeline, error) {
 
 	// BuildProcessors the pipeline backwards.
 
-	// First create a consumer junction point that fans out the data to all exporters.
+	// First create the connectors the pipeline exports to.
+	var connectors []*builtConnector
+	for _, name := range pipelineCfg.Exporters {
+		connCfg := pb.config.Connectors[name]
+		if connCfg == nil {
+			continue
+		}
+		bc, err := pb.buildConnector(ctx, connCfg, pipelineCfg.InputType, builtPipelines)
+		if err != nil {
+			return nil, err
+		}
+		connectors = append(connectors, bc)
+	}
+
+	// The processors of the pipeline exclude the processors of the chain it references.
+	numProcessors := len(pipelineCfg.Processors)
+	var chain *builtChain
+	if pipelineCfg.ProcessorChain != "" {
+		numProcessors -= len(pb.config.Service.ProcessorChains[pipelineCfg.ProcessorChain])
+		chain = pb.chains[chainKey{pipelineCfg.ProcessorChain, pipelineCfg.InputType}]
+	}
+
 	var tc consumer.Traces
 	var mc consumer.Metrics
 	var lc consumer.Logs
 
-	switch pipelineCfg.InputType {
-	case configmodels.TracesDataType:
-		tc = pb.buildFanoutExportersTraceConsumer(pipelineCfg.Exporters)
-	case configmodels.MetricsDataType:
-		mc = pb.buildFanoutExportersMetricsConsumer(pipelineCfg.Exporters)
-	case configmodels.LogsDataType:
-		lc = pb.buildFanoutExportersLogConsumer(pipelineCfg.Exporters)
+	if chain != nil {
+		// The chain is already built by another pipeline, which sends the data to the same exporters.
+		tc, mc, lc = chain.firstTC, chain.firstMC, chain.firstLC
+	} else {
+		// Then create a consumer junction point that fans out the data to all exporters and connectors.
+		switch pipelineCfg.InputType {
+		case configmodels.TracesDataType:
+			tc = pb.buildFanoutExportersTraceConsumer(pipelineCfg)
+		case configmodels.MetricsDataType:
+			mc = pb.buildFanoutExportersMetricsConsumer(pipelineCfg)
+		case configmodels.LogsDataType:
+			lc = pb.buildFanoutExportersLogConsumer(pipelineCfg)
+		}
+
+		if pipelineCfg.ProcessorChain != "" {
+			chain = &builtChain{name: pipelineCfg.ProcessorChain}
+			var err error
+			chain.processors, chain.mutatesConsumedData, err = pb.buildProcessors(
+				ctx, pipelineCfg, pipelineCfg.Processors[numProcessors:], &tc, &mc, &lc)
+			if err != nil {
+				return nil, err
+			}
+			chain.firstTC, chain.firstMC, chain.firstLC = tc, mc, lc
+			pb.chains[chainKey{pipelineCfg.ProcessorChain, pipelineCfg.InputType}] = chain
+		}
+	}
+
+	processors, mutatesConsumedData, err := pb.buildProcessors(
+		ctx, pipelineCfg, pipelineCfg.Processors[:numProcessors], &tc, &mc, &lc)
+	if err != nil {
+		return nil, err
+	}
+	if chain != nil {
+		mutatesConsumedData = mutatesConsumedData || chain.mutatesConsumedData
+	}
+
+	pipelineLogger := pb.logger.With(zap.String("pipeline_name", pipelineCfg.Name),
+		zap.String("pipeline_datatype", string(pipelineCfg.InputType)))
+	pipelineLogger.Info("Pipeline was built.")
+
+	bp := &builtPipeline{
+		logger:              pipelineLogger,
+		config:              pipelineCfg,
+		firstTC:             tc,
+		firstMC:             mc,
+		firstLC:             lc,
+		MutatesConsumedData: mutatesConsumedData,
+		processors:          processors,
+		chain:               chain,
+		connectors:          connectors,
 	}
 
+	return bp, nil
+}
+
+// buildProcessors builds the given processors of the pipeline backwards onto the consumers, which are updated to
+// the first processor built. It returns the processors and whether any of them can mutate the consumed data.
+func (pb *pipelinesBuilder) buildProcessors(
+	ctx context.Context,
+	pipelineCfg *configmodels.Pipeline,
+	names []string,
+	tc *consumer.Traces,
+	mc *consumer.Metrics,
+	lc *consumer.Logs,
+) ([]component.Processor, bool, error) {
 	mutatesConsumedData := false
 
-	processors := make([]component.Processor, len(pipelineCfg.Processors))
+	processors := make([]component.Processor, len(names))
 
 	// Now build the processors backwards, starting from the last one.
 	// The last processor points to consumer which fans out to exporters, then
 	// the processor itself becomes a consumer for the one that precedes it in
 	// in the pipeline and so on.
-	for i := len(pipelineCfg.Processors) - 1; i >= 0; i-- {
-		procName := pipelineCfg.Processors[i]
+	for i := len(names) - 1; i >= 0; i-- {
+		procName := names[i]
 		procCfg := pb.config.Processors[procName]
 
 		factory := pb.factories[procCfg.Type()]
@@ -159,105 +367,87 @@ func (pb *pipelinesBuilder) buildPipeline(ctx context.Context, pipelineCfg *conf
 		switch pipelineCfg.InputType {
 		case configmodels.TracesDataType:
 			var proc component.TracesProcessor
-			proc, err = factory.CreateTracesProcessor(ctx, creationParams, procCfg, tc)
+			proc, err = factory.CreateTracesProcessor(ctx, creationParams, procCfg, *tc)
 			if proc != nil {
-				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
+				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumed(pipelineCfg.InputType)
 			}
 			processors[i] = proc
-			tc = proc
+			*tc = proc
 		case configmodels.MetricsDataType:
 			var proc component.MetricsProcessor
-			proc, err = factory.CreateMetricsProcessor(ctx, creationParams, procCfg, mc)
+			proc, err = factory.CreateMetricsProcessor(ctx, creationParams, procCfg, *mc)
 			if proc != nil {
-				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
+				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumed(pipelineCfg.InputType)
 			}
 			processors[i] = proc
-			mc = proc
+			*mc = proc
 
 		case configmodels.LogsDataType:
 			var proc component.LogsProcessor
-			proc, err = factory.CreateLogsProcessor(ctx, creationParams, procCfg, lc)
+			proc, err = factory.CreateLogsProcessor(ctx, creationParams, procCfg, *lc)
 			if proc != nil {
-				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
+				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumed(pipelineCfg.InputType)
 			}
 			processors[i] = proc
-			lc = proc
+			*lc = proc
 
 		default:
-			return nil, fmt.Errorf("error creating processor %q in pipeline %q, data type %s is not supported",
+			return nil, false, fmt.Errorf("error creating processor %q in pipeline %q, data type %s is not supported",
 				procName, pipelineCfg.Name, pipelineCfg.InputType)
 		}
 
 		if err != nil {
-			return nil, fmt.Errorf("error creating processor %q in pipeline %q: %v",
+			return nil, false, fmt.Errorf("error creating processor %q in pipeline %q: %v",
 				procName, pipelineCfg.Name, err)
 		}
 
 		// Check if the factory really created the processor.
-		if tc == nil && mc == nil && lc == nil {
-			return nil, fmt.Errorf("factory for %q produced a nil processor", procCfg.Name())
+		if *tc == nil && *mc == nil && *lc == nil {
+			return nil, false, fmt.Errorf("factory for %q produced a nil processor", procCfg.Name())
 		}
 	}
 
-	pipelineLogger := pb.logger.With(zap.String("pipeline_name", pipelineCfg.Name),
-		zap.String("pipeline_datatype", string(pipelineCfg.InputType)))
-	pipelineLogger.Info("Pipeline was built.")
-
-	bp := &builtPipeline{
-		pipelineLogger,
-		tc,
-		mc,
-		lc,
-		mutatesConsumedData,
-		processors,
-	}
-
-	return bp, nil
+	return processors, mutatesConsumedData, nil
 }
 
-// Converts the list of exporter names to a list of corresponding builtExporters.
-func (pb *pipelinesBuilder) getBuiltExportersByNames(exporterNames []string) []*builtExporter {
-	var result []*builtExporter
-	for _, name := range exporterNames {
-		exporter := pb.exporters[pb.config.Exporters[name]]
-		result = append(result, exporter)
-	}
-
-	return result
-}
-
-func (pb *pipelinesBuilder) buildFanoutExportersTraceConsumer(exporterNames []string) consumer.Traces {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
-
+func (pb *pipelinesBuilder) buildFanoutExportersTraceConsumer(pipelineCfg *configmodels.Pipeline) consumer.Traces {
 	var exporters []consumer.Traces
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.getTraceExporter())
+	for _, name := range pipelineCfg.Exporters {
+		if connCfg := pb.config.Connectors[name]; connCfg != nil {
+			exporters = append(exporters, pb.connectors[connCfg].getTracesConsumer())
+			continue
+		}
+		exporters = append(exporters, pb.exporters[pb.config.Exporters[name]].getTraceExporter())
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewTraces(exporters)
+	return fanoutconsumer.NewTracesWithErrorPolicy(pipelineCfg.FanoutErrorPolicy, pipelineCfg.Exporters, exporters)
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersMetricsConsumer(exporterNames []string) consumer.Metrics {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
-
+func (pb *pipelinesBuilder) buildFanoutExportersMetricsConsumer(pipelineCfg *configmodels.Pipeline) consumer.Metrics {
 	var exporters []consumer.Metrics
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.getMetricExporter())
+	for _, name := range pipelineCfg.Exporters {
+		if connCfg := pb.config.Connectors[name]; connCfg != nil {
+			exporters = append(exporters, pb.connectors[connCfg].getMetricsConsumer())
+			continue
+		}
+		exporters = append(exporters, pb.exporters[pb.config.Exporters[name]].getMetricExporter())
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewMetrics(exporters)
+	return fanoutconsumer.NewMetricsWithErrorPolicy(pipelineCfg.FanoutErrorPolicy, pipelineCfg.Exporters, exporters)
 }
 
-func (pb *pipelinesBuilder) buildFanoutExportersLogConsumer(exporterNames []string) consumer.Logs {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
-
-	exporters := make([]consumer.Logs, len(builtExporters))
-	for i, builtExp := range builtExporters {
-		exporters[i] = builtExp.getLogExporter()
+func (pb *pipelinesBuilder) buildFanoutExportersLogConsumer(pipelineCfg *configmodels.Pipeline) consumer.Logs {
+	exporters := make([]consumer.Logs, len(pipelineCfg.Exporters))
+	for i, name := range pipelineCfg.Exporters {
+		if connCfg := pb.config.Connectors[name]; connCfg != nil {
+			exporters[i] = pb.connectors[connCfg].getLogsConsumer()
+			continue
+		}
+		exporters[i] = pb.exporters[pb.config.Exporters[name]].getLogExporter()
 	}
 
 	// Create a junction point that fans out to all exporters.
-	return fanoutconsumer.NewLogs(exporters)
+	return fanoutconsumer.NewLogsWithErrorPolicy(pipelineCfg.FanoutErrorPolicy, pipelineCfg.Exporters, exporters)
 }
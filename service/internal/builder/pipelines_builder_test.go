@@ -121,7 +121,7 @@ func TestBuildPipelines_BuildVarious(t *testing.T) {
 
 			require.NoError(t, err)
 			require.EqualValues(t, 1, len(allExporters))
-			pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors)
+			pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
 
 			assert.NoError(t, err)
 			require.NotNil(t, pipelineProcessors)
@@ -187,7 +187,7 @@ func testPipeline(t *testing.T, pipelineName string, exporterNames []string) {
 	// BuildProcessors the pipeline
 	allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
 	assert.NoError(t, err)
-	pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors)
+	pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
 
 	assert.NoError(t, err)
 	require.NotNil(t, pipelineProcessors)
@@ -236,6 +236,64 @@ func testPipeline(t *testing.T, pipelineName string, exporterNames []string) {
 	assert.NoError(t, err)
 }
 
+func TestBuildPipelines_ProcessorChains(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg, err := configtest.LoadConfigFile(t, "testdata/processor_chains.yaml", factories)
+	require.NoError(t, err)
+
+	allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+	require.NoError(t, err)
+	pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
+	require.NoError(t, err)
+
+	traces := pipelineProcessors[cfg.Service.Pipelines["traces"]]
+	traces2 := pipelineProcessors[cfg.Service.Pipelines["traces/2"]]
+	metrics := pipelineProcessors[cfg.Service.Pipelines["metrics"]]
+
+	// The traces pipelines share the processors of the chain, the metrics pipeline has its own.
+	require.NotNil(t, traces.chain)
+	assert.Same(t, traces.chain, traces2.chain)
+	assert.Len(t, traces.chain.processors, 1)
+	assert.Len(t, traces.processors, 0)
+	assert.Len(t, traces2.processors, 1)
+	require.NotNil(t, metrics.chain)
+	assert.NotSame(t, traces.chain, metrics.chain)
+	assert.Same(t, traces.firstTC, traces.chain.firstTC)
+
+	assert.NoError(t, pipelineProcessors.StartProcessors(context.Background(), componenttest.NewNopHost()))
+
+	// The data of both traces pipelines flows through the chain to the exporter.
+	expConsumer := allExporters[cfg.Exporters["exampleexporter"]].getTraceExporter().(*testcomponents.ExampleExporterConsumer)
+	require.NoError(t, traces.firstTC.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	require.NoError(t, traces2.firstTC.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+	assert.Len(t, expConsumer.Traces, 2)
+
+	assert.NoError(t, pipelineProcessors.ShutdownProcessors(context.Background()))
+}
+
+func TestBuildPipelines_MutatesConsumedDataPerSignal(t *testing.T) {
+	factories := createTestFactories()
+	metricsMutatingFactory := newMetricsMutatingProcessorFactory()
+	factories.Processors[metricsMutatingFactory.Type()] = metricsMutatingFactory
+
+	for _, dataType := range []string{"traces", "metrics"} {
+		t.Run(dataType, func(t *testing.T) {
+			cfg := createExampleConfig(dataType)
+			cfg.Processors = map[string]configmodels.Processor{"metricsmutating": metricsMutatingFactory.CreateDefaultConfig()}
+			cfg.Service.Pipelines[dataType].Processors = []string{"metricsmutating"}
+
+			allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
+			require.NoError(t, err)
+			pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
+			require.NoError(t, err)
+
+			// The processor mutates the metrics only, the traces pipeline does not need a clone of the data.
+			assert.Equal(t, dataType == "metrics", pipelineProcessors[cfg.Service.Pipelines[dataType]].MutatesConsumedData)
+		})
+	}
+}
+
 func TestBuildPipelines_NotSupportedDataType(t *testing.T) {
 	factories := createTestFactories()
 
@@ -262,7 +320,7 @@ func TestBuildPipelines_NotSupportedDataType(t *testing.T) {
 			allExporters, err := BuildExporters(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, factories.Exporters)
 			assert.NoError(t, err)
 
-			pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors)
+			pipelineProcessors, err := BuildPipelines(zap.NewNop(), component.DefaultApplicationStartInfo(), cfg, allExporters, factories.Processors, factories.Connectors)
 			assert.Error(t, err)
 			assert.Zero(t, len(pipelineProcessors))
 		})
@@ -66,10 +66,11 @@ func (rcvs Receivers) ShutdownAll(ctx context.Context) error {
 
 // StartAll starts all receivers.
 func (rcvs Receivers) StartAll(ctx context.Context, host component.Host) error {
-	for _, rcv := range rcvs {
+	for cfg, rcv := range rcvs {
 		rcv.logger.Info("Receiver is starting...")
 
-		if err := rcv.Start(ctx, newHostWrapper(host, rcv.logger)); err != nil {
+		source := component.StatusSource{Kind: component.KindReceiver, Name: cfg.Name()}
+		if err := rcv.Start(ctx, newHostWrapper(host, rcv.logger, source)); err != nil {
 			return err
 		}
 		rcv.logger.Info("Receiver started.")
@@ -277,23 +278,19 @@ func buildFanoutTraceConsumer(pipelines []*builtPipeline) consumer.Traces {
 		return pipelines[0].firstTC
 	}
 
-	var pipelineConsumers []consumer.Traces
-	anyPipelineMutatesData := false
+	var readOnlyConsumers []consumer.Traces
+	var mutatingConsumers []consumer.Traces
 	for _, pipeline := range pipelines {
-		pipelineConsumers = append(pipelineConsumers, pipeline.firstTC)
-		anyPipelineMutatesData = anyPipelineMutatesData || pipeline.MutatesConsumedData
+		if pipeline.MutatesConsumedData {
+			mutatingConsumers = append(mutatingConsumers, pipeline.firstTC)
+		} else {
+			readOnlyConsumers = append(readOnlyConsumers, pipeline.firstTC)
+		}
 	}
 
-	// Create a junction point that fans out to all pipelines.
-	if anyPipelineMutatesData {
-		// If any pipeline mutates data use a cloning fan out connector
-		// so that it is safe to modify fanned out data.
-		// TODO: if there are more than 2 pipelines only clone data for pipelines that
-		// declare the intent to mutate the data. Pipelines that do not mutate the data
-		// can consume shared data.
-		return fanoutconsumer.NewTracesCloning(pipelineConsumers)
-	}
-	return fanoutconsumer.NewTraces(pipelineConsumers)
+	// Create a junction point that fans out to all pipelines. The data is cloned only for the
+	// pipelines that mutate it, the pipelines that do not mutate the data share a single clone.
+	return fanoutconsumer.NewTracesCloningMutating(readOnlyConsumers, mutatingConsumers)
 }
 
 func buildFanoutMetricConsumer(pipelines []*builtPipeline) consumer.Metrics {
@@ -302,23 +299,19 @@ func buildFanoutMetricConsumer(pipelines []*builtPipeline) consumer.Metrics {
 		return pipelines[0].firstMC
 	}
 
-	var pipelineConsumers []consumer.Metrics
-	anyPipelineMutatesData := false
+	var readOnlyConsumers []consumer.Metrics
+	var mutatingConsumers []consumer.Metrics
 	for _, pipeline := range pipelines {
-		pipelineConsumers = append(pipelineConsumers, pipeline.firstMC)
-		anyPipelineMutatesData = anyPipelineMutatesData || pipeline.MutatesConsumedData
+		if pipeline.MutatesConsumedData {
+			mutatingConsumers = append(mutatingConsumers, pipeline.firstMC)
+		} else {
+			readOnlyConsumers = append(readOnlyConsumers, pipeline.firstMC)
+		}
 	}
 
-	// Create a junction point that fans out to all pipelines.
-	if anyPipelineMutatesData {
-		// If any pipeline mutates data use a cloning fan out connector
-		// so that it is safe to modify fanned out data.
-		// TODO: if there are more than 2 pipelines only clone data for pipelines that
-		// declare the intent to mutate the data. Pipelines that do not mutate the data
-		// can consume shared data.
-		return fanoutconsumer.NewMetricsCloning(pipelineConsumers)
-	}
-	return fanoutconsumer.NewMetrics(pipelineConsumers)
+	// Create a junction point that fans out to all pipelines. The data is cloned only for the
+	// pipelines that mutate it, the pipelines that do not mutate the data share a single clone.
+	return fanoutconsumer.NewMetricsCloningMutating(readOnlyConsumers, mutatingConsumers)
 }
 
 func buildFanoutLogConsumer(pipelines []*builtPipeline) consumer.Logs {
@@ -327,21 +320,17 @@ func buildFanoutLogConsumer(pipelines []*builtPipeline) consumer.Logs {
 		return pipelines[0].firstLC
 	}
 
-	var pipelineConsumers []consumer.Logs
-	anyPipelineMutatesData := false
+	var readOnlyConsumers []consumer.Logs
+	var mutatingConsumers []consumer.Logs
 	for _, pipeline := range pipelines {
-		pipelineConsumers = append(pipelineConsumers, pipeline.firstLC)
-		anyPipelineMutatesData = anyPipelineMutatesData || pipeline.MutatesConsumedData
+		if pipeline.MutatesConsumedData {
+			mutatingConsumers = append(mutatingConsumers, pipeline.firstLC)
+		} else {
+			readOnlyConsumers = append(readOnlyConsumers, pipeline.firstLC)
+		}
 	}
 
-	// Create a junction point that fans out to all pipelines.
-	if anyPipelineMutatesData {
-		// If any pipeline mutates data use a cloning fan out connector
-		// so that it is safe to modify fanned out data.
-		// TODO: if there are more than 2 pipelines only clone data for pipelines that
-		// declare the intent to mutate the data. Pipelines that do not mutate the data
-		// can consume shared data.
-		return fanoutconsumer.NewLogsCloning(pipelineConsumers)
-	}
-	return fanoutconsumer.NewLogs(pipelineConsumers)
+	// Create a junction point that fans out to all pipelines. The data is cloned only for the
+	// pipelines that mutate it, the pipelines that do not mutate the data share a single clone.
+	return fanoutconsumer.NewLogsCloningMutating(readOnlyConsumers, mutatingConsumers)
 }
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricexport"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/translator/internaldata"
+)
+
+// MetricsPusher periodically reads the OpenCensus metrics of the collector and pushes them to a metrics exporter.
+type MetricsPusher struct {
+	exporter  component.MetricsExporter
+	namespace string
+	resource  *resourcepb.Resource
+	interval  time.Duration
+	reader    *metricexport.IntervalReader
+}
+
+var _ metricexport.Exporter = (*MetricsPusher)(nil)
+
+// NewMetricsPusher creates a MetricsPusher pushing the metrics every interval to the exporter. The names of the
+// metrics are prefixed with the namespace, as when they are served in the Prometheus format, and the metrics have
+// the given resource attributes.
+func NewMetricsPusher(exporter component.MetricsExporter, namespace string, resourceAttributes map[string]string, interval time.Duration) *MetricsPusher {
+	return &MetricsPusher{
+		exporter:  exporter,
+		namespace: namespace,
+		resource:  &resourcepb.Resource{Labels: resourceAttributes},
+		interval:  interval,
+	}
+}
+
+// Start starts the exporter, then pushes the metrics every interval.
+func (mp *MetricsPusher) Start(ctx context.Context, host component.Host) error {
+	if err := mp.exporter.Start(ctx, host); err != nil {
+		return err
+	}
+
+	reader, err := metricexport.NewIntervalReader(metricexport.NewReader(), mp)
+	if err != nil {
+		return err
+	}
+	reader.ReportingInterval = mp.interval
+	if err = reader.Start(); err != nil {
+		return err
+	}
+	mp.reader = reader
+	return nil
+}
+
+// Shutdown stops the periodic push, pushes the metrics a last time, then shuts down the exporter.
+func (mp *MetricsPusher) Shutdown(ctx context.Context) error {
+	if mp.reader != nil {
+		mp.reader.Stop()
+		mp.reader.Flush()
+	}
+	return mp.exporter.Shutdown(ctx)
+}
+
+// ExportMetrics implements metricexport.Exporter.
+func (mp *MetricsPusher) ExportMetrics(ctx context.Context, metrics []*metricdata.Metric) error {
+	ocMetrics := make([]*metricspb.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		if ocMetric := mp.toOCMetric(metric); ocMetric != nil {
+			ocMetrics = append(ocMetrics, ocMetric)
+		}
+	}
+	if len(ocMetrics) == 0 {
+		return nil
+	}
+
+	return mp.exporter.ConsumeMetrics(ctx, internaldata.OCToMetrics(internaldata.MetricsData{
+		Resource: mp.resource,
+		Metrics:  ocMetrics,
+	}))
+}
+
+// toOCMetric converts the metric read from OpenCensus to its proto representation, it returns nil for the summaries
+// that are not produced by the views.
+func (mp *MetricsPusher) toOCMetric(metric *metricdata.Metric) *metricspb.Metric {
+	descriptorType, ok := descriptorTypes[metric.Descriptor.Type]
+	if !ok {
+		return nil
+	}
+
+	labelKeys := make([]*metricspb.LabelKey, 0, len(metric.Descriptor.LabelKeys))
+	for _, key := range metric.Descriptor.LabelKeys {
+		labelKeys = append(labelKeys, &metricspb.LabelKey{Key: key.Key, Description: key.Description})
+	}
+
+	timeseries := make([]*metricspb.TimeSeries, 0, len(metric.TimeSeries))
+	for _, ts := range metric.TimeSeries {
+		labelValues := make([]*metricspb.LabelValue, 0, len(ts.LabelValues))
+		for _, value := range ts.LabelValues {
+			labelValues = append(labelValues, &metricspb.LabelValue{Value: value.Value, HasValue: value.Present})
+		}
+
+		points := make([]*metricspb.Point, 0, len(ts.Points))
+		for _, point := range ts.Points {
+			points = append(points, toOCPoint(point))
+		}
+
+		ocTimeSeries := &metricspb.TimeSeries{
+			LabelValues: labelValues,
+			Points:      points,
+		}
+		if !ts.StartTime.IsZero() {
+			ocTimeSeries.StartTimestamp = timestamppb.New(ts.StartTime)
+		}
+		timeseries = append(timeseries, ocTimeSeries)
+	}
+
+	return &metricspb.Metric{
+		MetricDescriptor: &metricspb.MetricDescriptor{
+			Name:        SanitizeMetricName(mp.namespace + "_" + metric.Descriptor.Name),
+			Description: metric.Descriptor.Description,
+			Unit:        string(metric.Descriptor.Unit),
+			Type:        descriptorType,
+			LabelKeys:   labelKeys,
+		},
+		Timeseries: timeseries,
+	}
+}
+
+var descriptorTypes = map[metricdata.Type]metricspb.MetricDescriptor_Type{
+	metricdata.TypeGaugeInt64:             metricspb.MetricDescriptor_GAUGE_INT64,
+	metricdata.TypeGaugeFloat64:           metricspb.MetricDescriptor_GAUGE_DOUBLE,
+	metricdata.TypeGaugeDistribution:      metricspb.MetricDescriptor_GAUGE_DISTRIBUTION,
+	metricdata.TypeCumulativeInt64:        metricspb.MetricDescriptor_CUMULATIVE_INT64,
+	metricdata.TypeCumulativeFloat64:      metricspb.MetricDescriptor_CUMULATIVE_DOUBLE,
+	metricdata.TypeCumulativeDistribution: metricspb.MetricDescriptor_CUMULATIVE_DISTRIBUTION,
+}
+
+func toOCPoint(point metricdata.Point) *metricspb.Point {
+	ocPoint := &metricspb.Point{Timestamp: timestamppb.New(point.Time)}
+	switch value := point.Value.(type) {
+	case int64:
+		ocPoint.Value = &metricspb.Point_Int64Value{Int64Value: value}
+	case float64:
+		ocPoint.Value = &metricspb.Point_DoubleValue{DoubleValue: value}
+	case *metricdata.Distribution:
+		buckets := make([]*metricspb.DistributionValue_Bucket, 0, len(value.Buckets))
+		for _, bucket := range value.Buckets {
+			buckets = append(buckets, &metricspb.DistributionValue_Bucket{Count: bucket.Count})
+		}
+		distribution := &metricspb.DistributionValue{
+			Count:                 value.Count,
+			Sum:                   value.Sum,
+			SumOfSquaredDeviation: value.SumOfSquaredDeviation,
+			Buckets:               buckets,
+		}
+		if value.BucketOptions != nil {
+			distribution.BucketOptions = &metricspb.DistributionValue_BucketOptions{
+				Type: &metricspb.DistributionValue_BucketOptions_Explicit_{
+					Explicit: &metricspb.DistributionValue_BucketOptions_Explicit{Bounds: value.BucketOptions.Bounds},
+				},
+			}
+		}
+		ocPoint.Value = &metricspb.Point_DistributionValue{DistributionValue: distribution}
+	}
+	return ocPoint
+}
+
+// SanitizeMetricName replaces the characters that are not letters, digits or '_' by '_', as done for the metric
+// names and labels served in the Prometheus format.
+func SanitizeMetricName(str string) string {
+	runeFilterMap := func(r rune) rune {
+		if unicode.IsDigit(r) || unicode.IsLetter(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}
+	return strings.Map(runeFilterMap, str)
+}
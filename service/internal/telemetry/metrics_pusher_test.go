@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type sinkExporter struct {
+	component.Component
+	*consumertest.MetricsSink
+}
+
+func newSinkExporter() *sinkExporter {
+	return &sinkExporter{
+		Component:   componenthelper.New(),
+		MetricsSink: new(consumertest.MetricsSink),
+	}
+}
+
+func TestMetricsPusher(t *testing.T) {
+	keyExporter, err := tag.NewKey("exporter")
+	require.NoError(t, err)
+	mSent := stats.Int64("test/sent_items", "Number of items sent.", stats.UnitDimensionless)
+	mLatency := stats.Float64("test/latency", "Latency of the requests.", stats.UnitMilliseconds)
+	views := []*view.View{
+		{Name: mSent.Name(), Description: mSent.Description(), Measure: mSent, Aggregation: view.Sum(), TagKeys: []tag.Key{keyExporter}},
+		{Name: mLatency.Name(), Description: mLatency.Description(), Measure: mLatency, Aggregation: view.Distribution(10, 100)},
+	}
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	ctx, err := tag.New(context.Background(), tag.Insert(keyExporter, "otlp"))
+	require.NoError(t, err)
+	stats.Record(ctx, mSent.M(5), mLatency.M(42))
+	// The measurements are recorded asynchronously, retrieving the data waits for them.
+	_, err = view.RetrieveData(mSent.Name())
+	require.NoError(t, err)
+
+	exp := newSinkExporter()
+	pusher := NewMetricsPusher(exp, "otelcol", map[string]string{"service.version": "1.0.0"}, time.Hour)
+	require.NoError(t, pusher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The metrics are pushed a last time at shutdown.
+	require.NoError(t, pusher.Shutdown(context.Background()))
+	require.Len(t, exp.AllMetrics(), 1)
+
+	rms := exp.AllMetrics()[0].ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	version, ok := rms.At(0).Resource().Attributes().Get("service.version")
+	require.True(t, ok)
+	assert.Equal(t, "1.0.0", version.StringVal())
+
+	metrics := make(map[string]pdata.Metric)
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	for i := 0; i < ilms.Len(); i++ {
+		for j := 0; j < ilms.At(i).Metrics().Len(); j++ {
+			metric := ilms.At(i).Metrics().At(j)
+			metrics[metric.Name()] = metric
+		}
+	}
+
+	sent, ok := metrics["otelcol_test_sent_items"]
+	require.True(t, ok)
+	require.Equal(t, pdata.MetricDataTypeIntSum, sent.DataType())
+	require.Equal(t, 1, sent.IntSum().DataPoints().Len())
+	dp := sent.IntSum().DataPoints().At(0)
+	assert.EqualValues(t, 5, dp.Value())
+	assert.Equal(t, map[string]string{"exporter": "otlp"}, labels(dp.LabelsMap()))
+
+	latency, ok := metrics["otelcol_test_latency"]
+	require.True(t, ok)
+	require.Equal(t, pdata.MetricDataTypeDoubleHistogram, latency.DataType())
+	require.Equal(t, 1, latency.DoubleHistogram().DataPoints().Len())
+	hdp := latency.DoubleHistogram().DataPoints().At(0)
+	assert.EqualValues(t, 1, hdp.Count())
+	assert.EqualValues(t, 42, hdp.Sum())
+	assert.Equal(t, []float64{10, 100}, hdp.ExplicitBounds())
+	assert.Equal(t, []uint64{0, 1, 0}, hdp.BucketCounts())
+}
+
+func TestSanitizeMetricName(t *testing.T) {
+	assert.Equal(t, "otelcol_exporter_sent_spans", SanitizeMetricName("otelcol_exporter/sent_spans"))
+	assert.Equal(t, "service_instance_id", SanitizeMetricName("service.instance.id"))
+}
+
+func labels(sm pdata.StringMap) map[string]string {
+	out := make(map[string]string, sm.Len())
+	sm.ForEach(func(k string, v string) {
+		out[k] = v
+	})
+	return out
+}
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/translator/internaldata"
+)
+
+// maxSpansBatchSize is the number of spans after which the spans are exported before the flush interval.
+const maxSpansBatchSize = 512
+
+// SpansExporter batches the OpenCensus spans of the collector operations and exports them to a traces exporter.
+type SpansExporter struct {
+	exporter      component.TracesExporter
+	resource      *resourcepb.Resource
+	flushInterval time.Duration
+	logger        *zap.Logger
+
+	// exportTraceID is the trace of the operations exporting the spans, their spans are dropped so that exporting the
+	// spans does not create new spans to export.
+	exportTraceID trace.TraceID
+
+	mu    sync.Mutex
+	spans []*tracepb.Span
+
+	flushCh chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+var _ trace.Exporter = (*SpansExporter)(nil)
+
+// NewSpansExporter creates a SpansExporter exporting the spans every flushInterval, or as soon as a batch is full,
+// with the given resource attributes.
+func NewSpansExporter(exporter component.TracesExporter, resourceAttributes map[string]string, flushInterval time.Duration, logger *zap.Logger) *SpansExporter {
+	se := &SpansExporter{
+		exporter:      exporter,
+		resource:      &resourcepb.Resource{Labels: resourceAttributes},
+		flushInterval: flushInterval,
+		logger:        logger,
+		flushCh:       make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+	_, _ = rand.Read(se.exportTraceID[:])
+	return se
+}
+
+// Start starts the exporter and the periodic export of the spans.
+func (se *SpansExporter) Start(ctx context.Context, host component.Host) error {
+	if err := se.exporter.Start(ctx, host); err != nil {
+		return err
+	}
+
+	se.wg.Add(1)
+	go se.run()
+	return nil
+}
+
+// Shutdown exports the remaining spans, then shuts down the exporter.
+func (se *SpansExporter) Shutdown(ctx context.Context) error {
+	close(se.done)
+	se.wg.Wait()
+	se.flush()
+	return se.exporter.Shutdown(ctx)
+}
+
+// ExportSpan implements trace.Exporter, it is called when a sampled span ends.
+func (se *SpansExporter) ExportSpan(sd *trace.SpanData) {
+	if sd.TraceID == se.exportTraceID {
+		return
+	}
+
+	span := toOCSpan(sd)
+	se.mu.Lock()
+	se.spans = append(se.spans, span)
+	full := len(se.spans) >= maxSpansBatchSize
+	se.mu.Unlock()
+
+	if full {
+		select {
+		case se.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (se *SpansExporter) run() {
+	defer se.wg.Done()
+	ticker := time.NewTicker(se.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-se.done:
+			return
+		case <-ticker.C:
+		case <-se.flushCh:
+		}
+		se.flush()
+	}
+}
+
+func (se *SpansExporter) flush() {
+	se.mu.Lock()
+	spans := se.spans
+	se.spans = nil
+	se.mu.Unlock()
+
+	if len(spans) == 0 {
+		return
+	}
+
+	// The spans created while exporting belong to the export trace, and are dropped.
+	var spanID trace.SpanID
+	_, _ = rand.Read(spanID[:])
+	ctx, span := trace.StartSpanWithRemoteParent(context.Background(), "telemetry/export", trace.SpanContext{
+		TraceID: se.exportTraceID,
+		SpanID:  spanID,
+	})
+	defer span.End()
+
+	if err := se.exporter.ConsumeTraces(ctx, internaldata.OCToTraces(nil, se.resource, spans)); err != nil {
+		se.logger.Warn("Failed to export the spans of the collector", zap.Int("dropped_spans", len(spans)), zap.Error(err))
+	}
+}
+
+func toOCSpan(sd *trace.SpanData) *tracepb.Span {
+	span := &tracepb.Span{
+		TraceId:    sd.TraceID[:],
+		SpanId:     sd.SpanID[:],
+		Name:       &tracepb.TruncatableString{Value: sd.Name},
+		Kind:       tracepb.Span_SpanKind(sd.SpanKind),
+		StartTime:  timestamppb.New(sd.StartTime),
+		EndTime:    timestamppb.New(sd.EndTime),
+		Attributes: toOCAttributes(sd.Attributes),
+		Status:     &tracepb.Status{Code: sd.Status.Code, Message: sd.Status.Message},
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		span.ParentSpanId = sd.ParentSpanID[:]
+	}
+
+	if len(sd.Annotations) > 0 {
+		span.TimeEvents = &tracepb.Span_TimeEvents{}
+		for _, annotation := range sd.Annotations {
+			span.TimeEvents.TimeEvent = append(span.TimeEvents.TimeEvent, &tracepb.Span_TimeEvent{
+				Time: timestamppb.New(annotation.Time),
+				Value: &tracepb.Span_TimeEvent_Annotation_{
+					Annotation: &tracepb.Span_TimeEvent_Annotation{
+						Description: &tracepb.TruncatableString{Value: annotation.Message},
+						Attributes:  toOCAttributes(annotation.Attributes),
+					},
+				},
+			})
+		}
+	}
+
+	if len(sd.Links) > 0 {
+		span.Links = &tracepb.Span_Links{}
+		for _, link := range sd.Links {
+			traceID, spanID := link.TraceID, link.SpanID
+			span.Links.Link = append(span.Links.Link, &tracepb.Span_Link{
+				TraceId:    traceID[:],
+				SpanId:     spanID[:],
+				Type:       tracepb.Span_Link_Type(link.Type),
+				Attributes: toOCAttributes(link.Attributes),
+			})
+		}
+	}
+	return span
+}
+
+func toOCAttributes(attributes map[string]interface{}) *tracepb.Span_Attributes {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	attributeMap := make(map[string]*tracepb.AttributeValue, len(attributes))
+	for key, value := range attributes {
+		switch v := value.(type) {
+		case string:
+			attributeMap[key] = &tracepb.AttributeValue{Value: &tracepb.AttributeValue_StringValue{StringValue: &tracepb.TruncatableString{Value: v}}}
+		case bool:
+			attributeMap[key] = &tracepb.AttributeValue{Value: &tracepb.AttributeValue_BoolValue{BoolValue: v}}
+		case int64:
+			attributeMap[key] = &tracepb.AttributeValue{Value: &tracepb.AttributeValue_IntValue{IntValue: v}}
+		case float64:
+			attributeMap[key] = &tracepb.AttributeValue{Value: &tracepb.AttributeValue_DoubleValue{DoubleValue: v}}
+		}
+	}
+	return &tracepb.Span_Attributes{AttributeMap: attributeMap}
+}
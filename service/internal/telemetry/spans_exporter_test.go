@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type sinkTracesExporter struct {
+	component.Component
+	*consumertest.TracesSink
+}
+
+// exportingTracesExporter creates a span when exporting, as the exporters do.
+type exportingTracesExporter struct {
+	*sinkTracesExporter
+}
+
+func (ete *exportingTracesExporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	_, span := trace.StartSpan(ctx, "exporter/otlp/traces", trace.WithSampler(trace.AlwaysSample()))
+	span.End()
+	return ete.sinkTracesExporter.ConsumeTraces(ctx, td)
+}
+
+func TestSpansExporter(t *testing.T) {
+	exp := &exportingTracesExporter{&sinkTracesExporter{
+		Component:  componenthelper.New(),
+		TracesSink: new(consumertest.TracesSink),
+	}}
+	se := NewSpansExporter(exp, map[string]string{"service.version": "1.0.0"}, time.Hour, zap.NewNop())
+	require.NoError(t, se.Start(context.Background(), componenttest.NewNopHost()))
+	trace.RegisterExporter(se)
+	defer trace.UnregisterExporter(se)
+
+	ctx, parent := trace.StartSpan(context.Background(), "receiver/otlp/TraceDataReceived", trace.WithSampler(trace.AlwaysSample()))
+	_, child := trace.StartSpan(ctx, "exporter/otlp/traces")
+	child.Annotate([]trace.Attribute{trace.Int64Attribute("retry_num", 1)}, "Sending request.")
+	child.SetStatus(trace.Status{Code: trace.StatusCodeUnavailable, Message: errors.New("unavailable").Error()})
+	child.End()
+	parent.AddAttributes(trace.StringAttribute("transport", "grpc"))
+	parent.End()
+
+	// The remaining spans are exported at shutdown.
+	require.NoError(t, se.Shutdown(context.Background()))
+
+	// The span created when exporting the spans is not exported.
+	require.Equal(t, 2, exp.SpansCount())
+	require.Len(t, exp.AllTraces(), 1)
+
+	rss := exp.AllTraces()[0].ResourceSpans()
+	require.Equal(t, 1, rss.Len())
+	version, ok := rss.At(0).Resource().Attributes().Get("service.version")
+	require.True(t, ok)
+	assert.Equal(t, "1.0.0", version.StringVal())
+
+	spans := rss.At(0).InstrumentationLibrarySpans().At(0).Spans()
+	require.Equal(t, 2, spans.Len())
+	exported, received := spans.At(0), spans.At(1)
+	assert.Equal(t, "exporter/otlp/traces", exported.Name())
+	assert.Equal(t, "receiver/otlp/TraceDataReceived", received.Name())
+	assert.Equal(t, received.SpanID(), exported.ParentSpanID())
+	assert.Equal(t, received.TraceID(), exported.TraceID())
+	assert.Equal(t, pdata.StatusCodeError, exported.Status().Code())
+	require.Equal(t, 1, exported.Events().Len())
+	assert.Equal(t, "Sending request.", exported.Events().At(0).Name())
+	transport, ok := received.Attributes().Get("transport")
+	require.True(t, ok)
+	assert.Equal(t, "grpc", transport.StringVal())
+}
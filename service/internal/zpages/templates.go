@@ -35,6 +35,7 @@ var (
 	headerTemplate          = parseTemplate("header")
 	footerTemplate          = parseTemplate("footer")
 	pipelinesTableTemplate  = parseTemplate("pipelines_table")
+	pipelinesGraphTemplate  = parseTemplate("pipelines_graph")
 	propertiesTableTemplate = parseTemplate("properties_table")
 )
 
@@ -107,6 +108,40 @@ func WriteHTMLPipelinesSummaryTable(w io.Writer, spd SummaryPipelinesTableData)
 	}
 }
 
+// PipelinesGraphData contains data for pipelines data flow graph template.
+type PipelinesGraphData struct {
+	ComponentEndpoint string
+	// CountersAvailable is false when the observability views are not registered.
+	CountersAvailable bool
+	Pipelines         []PipelineGraphData
+}
+
+// PipelineGraphData contains data for the data flow graph of one pipeline.
+type PipelineGraphData struct {
+	FullName   string
+	InputType  string
+	Receivers  []ComponentGraphData
+	Processors []ComponentGraphData
+	Exporters  []ComponentGraphData
+}
+
+// ComponentGraphData contains data for one component in the data flow graph, Counters are pairs of name and value.
+type ComponentGraphData struct {
+	ComponentEndpoint string
+	PipelineName      string
+	Name              string
+	Kind              string
+	Counters          [][2]string
+}
+
+// WriteHTMLPipelinesGraph writes the data flow graph of the pipelines.
+// Id does not write the header or footer.
+func WriteHTMLPipelinesGraph(w io.Writer, pgd PipelinesGraphData) {
+	if err := pipelinesGraphTemplate.Execute(w, pgd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
 // ComponentHeaderData contains data for component header template.
 type ComponentHeaderData struct {
 	Name              string
@@ -71,6 +71,19 @@ func TestNoCrash(t *testing.T) {
 			}},
 		})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLPipelinesGraph(buf, PipelinesGraphData{
+			ComponentEndpoint: "pagez",
+			CountersAvailable: true,
+			Pipelines: []PipelineGraphData{{
+				FullName:   "test",
+				InputType:  "metrics",
+				Receivers:  []ComponentGraphData{{Name: "oc", Kind: "receiver", Counters: [][2]string{{"accepted_metric_points", "1"}}}},
+				Processors: []ComponentGraphData{{Name: "nop", Kind: "processor"}},
+				Exporters:  []ComponentGraphData{{Name: "oc", Kind: "exporter"}},
+			}},
+		})
+	})
 	assert.NotPanics(t, func() {
 		WriteHTMLExtensionsSummaryTable(buf, SummaryExtensionsTableData{
 			ComponentEndpoint: "pagez",
@@ -12,6 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+
 // Code generated by "esc -pkg tmplgen -o resources.go -modtime 0 ../templates/"; DO NOT EDIT.
 
 package tmplgen
@@ -231,7 +232,7 @@ var _escData = map[string]*_escFile{
 		compressed: `
 H4sIAAAAAAAC/1SMsQqDMBRFd7/iIq7q5lBiltKt9B8CPklQX6R1e9x/L6ZQ2vXcc65ZE3AZ0V3ztmcV
 PW467TnpQVZmzZp0Kfs96VJQizTjw1uyAgAXB+8C4lPmsT4fydqbdY+wCen64F0fB19iWV/yF/54X0en
-U3kHAAD//zT+SdCcAAAA
+U3kPADT+SdCcAAAA
 `,
 	},
 
@@ -244,7 +245,7 @@ U3kHAAD//zT+SdCcAAAA
 H4sIAAAAAAAC/2SQwU7DMBBE7/2KlemRNJwjxxwQHDnwB248DRbOOnK2tGD531HTQIvqk1fzZjU7Wuw2
 gCb5CmjVNiaHVE2j7Tz3DT0osyIiynltqWlp8xSHMTJYntmN0bOUsgDJcg9ap3jw7HC8n7+z5y0epgU7
 oxX5HeETfMGv9NPTkv4i2e6jT3HPrqE7AEui8yaECbdWkzPYUXWlaHFkg++5VR1YkJTRlt4Tdq06HVfK
-4zeOAp58ZLYD2pw3L/sQXu2AUpT5N+raGl2Lu0TRtaTfqsCulJWu52bNTwAAAP//sz5qjmEBAAA=
+4zeOAp58ZLYD2pw3L/sQXu2AUpT5N+raGl2Lu0TRtaTfqsCulJWu52bNzwCzPmqOYQEAAA==
 `,
 	},
 
@@ -254,7 +255,7 @@ oxX5HeETfMGv9NPTkv4i2e6jT3HPrqE7AEui8yaECbdWkzPYUXWlaHFkg++5VR1YkJTRlt4Tdq06HVfK
 		size:    15,
 		modtime: 0,
 		compressed: `
-H4sIAAAAAAAC/7LRT8pPqbTjstHPKMnNsQMEAAD//wEFevAPAAAA
+H4sIAAAAAAAC/7LRT8pPqbTjstHPKMnNsQMMAAEFevAPAAAA
 `,
 	},
 
@@ -268,7 +269,26 @@ H4sIAAAAAAAC/5TRMU8sIRAH8P4+BY/25eC9szGGxUItLIwW11giO7uMB8wG5rxsLvfdDdnTxNhoBeFP
 fpnM3/y5fbzZPj/dicAp2pVph4guj52ELK0J4Hq7EkIIk4Cd8MGVCtzJPQ/rS3mOGDmCPR7Vtl1OJ6OX
 lyWNmHeiQOxkDVTY71mgpyxFKDB0UuvD4aBogswQIQGXWSHpwb21Xwo9Sf1d4jlCDQD8wQTmqV5pPVDm
 qkaiMYKbsCpPSTfpenAJ49w9OIaCLv6995Sr/AXtqQc1Aqc+tgn/qwv1T6czpzD3ONJ6wrxTCbPy9ROv
-vuDEoocBiqjF/5RszGuV1uhFsCujl0bMC/Vz62vzZe1hY98DAAD//7qRGmLTAQAA
+vuDEoocBiqjF/5RszGuV1uhFsCujl0bMC/Vz62vzZe1hY98HALqRGmLTAQAA
+`,
+	},
+
+	"/templates/pipelines_graph.html": {
+		name:    "pipelines_graph.html",
+		local:   "../templates/pipelines_graph.html",
+		size:    1523,
+		modtime: 0,
+		compressed: `
+H4sIAAAAAAAC/5xU3WrcPBC936cYnBC+7yJxW0ogXtultAmUQAml9F4rT2wReSRkebMbVe9ebEfeOnZ/
+tnuxWNKZ0ZkzR+PcKYMkg4sPqtaKkOw1FVoJst6v0k3+kVkGN1I9Jmm8yVfOnYO4B1K2i2jJomneb5mQ
+bCPR+xUAQKrzcATMICiSe2ABBI8VEtgKgSspkVtloEZrBG9A4hYliKbPH5EijC7SWA/XIhXer5wzjEqE
+Uy00SkHYk797XjSBQnWZOzdiLm5aKT+zGr2H/37e/0S6tV/3Gr3/P42ry3yItj3Rxu4lZtFGmQLNeaMZ
+F1Qm8CoaUAPSHBbDRgFbJkVJWVSLopAYTQHdb6zBIO/oH/h8QY5iiybUMY+0WGvJLELEQ8OiPtFChHOD
+ZhOCsS1mlPMz2jR6fWaYMevhe447KG/UlPWdURybRi3SXlLkV2Voo7yf33wcy4Waj2oK7vS0vOudVsYe
+3xTc6X9qShoHV6Vx78R8FYKcK/Be0OSiYPkF0ybwWu+gUVIUcHJ1dbWGmplSUAJv9W4Nf3D2mIvxh9Ko
+looEThBfitepy5VsNKPsDQwyc+ye/0IXGVQG77PIuaWB8+4piE6sxsy58WEPj/fsaSw7AGYHD4KK7uBW
+dIpF+YhJY5b/leoTN/Bhkj2PyJYWfDAbAsGvzoXw7yXaW9z/xtzPwhlRVjYYvf+fJvnGZItLaV7SP5hs
+ZqIfAwAfo7Th8wUAAA==
 `,
 	},
 
@@ -278,14 +298,14 @@ vuDEoocBiqjF/5RszGuV1uhFsCujl0bMC/Vz62vzZe1hY98DAAD//7qRGmLTAQAA
 		size:    1946,
 		modtime: 0,
 		compressed: `
-H4sIAAAAAAAC/7SVwXLTMBCG7zyFxnRyIjVcU1scSpnhAMN0eAFZ2gRNlZVmJbdujd+dsWyrTp0LtL5k
-rOjX/tlv/8hFEJUB5sOjgTKrLCmgrXdCajzs2MeMv2OMsSLQ8DAsFJPWeCew/MSE0QcsDewDLyr+tTbm
-hzhCkVe8yIM6OcU3WHl3NXz+mS8W0oWBBAxAvcU3dHX49ejW9PheBxHAX1v09RHUFxHEim63IEHfA/kV
-PX6SleC9XdXkpnGWwqKRIp/i07YXgu1Kdnltj84iYLhB5azG0HWjgAQegF2QfdCooPkQH+OZW/vgR9kg
-3TK9Z3AP+Cyf7Y+5TdEW8u5Atka1Y+8BIOOzSmA8LI/ytgVUbDvb6VG1bW93OUW962Kn/wZxKpLC/Koq
-Z+L6X/XGgWbDRGeETkcDMo0GZD+a+CNSil+AjLUF+02wL7M+AF33+clpB0YjoDhCuQC6eZJTPpIA5Mn3
-dxpVSaNlxidFkQu+dK/oZSuAaj7VN0a1IUF0dZ6eIzvRc2QTvef/5yr4HNklPjd5Rn5Rcpbf2XbWJZhw
-QeMmXNC4hCvdNKvQgsYtacFoGWFFxSvCNl+lu3HQFXl8JfO/AQAA//9We3KLmgcAAA==
+H4sIAAAAAAAC/7SVTW7bMBCF9z0FoQZe1VG7dSR2kaZAFy2KoBegyLFLhB4SQypRouruhX7IyJE3bSIv
+DNJ+nOf55pkqgqgMMB8eDZRZZUkBbb0TUuNhxz5m/B1jjBWBxsW4UUxa453A8hMTRh+wNLAPvKj419qY
+H+IIRV7xIg/q5BTfYOXd1fj+Z75ZSBcGEjAA9Rbf0NXh16Nb0+N7HUQAf23R10dQX0QQK7rdggR9D+RX
+9PhJVoL3dlWTm8ZZCotGijzGp20vBNuV7PLaHp1FwHCDylmNoesmAQk8ALsg+6BRQfNhWA5nbu2Dn2Sj
+dMv0nsE94LN89v2U2xRtIe8OZGtUO/YeADI+qwTGw/Iob1tAxbZdd4KqbXu7yxj1rhs6/TeIsUgK86uq
+nInrf9WbBpqNE50ROh0NyDQakP1ohh+RUvwCZP8qBPtNsC+zPgBd9/nJaQdGI6A4QrkAunmSMR9JAPLk
+8zuNqqTJMuNRUeSCL90retkKoJpP9Y1RbUgQXZ2n58hGeo5sovf8/1wFnyO7xOeiZ8aj5Cy/s+2sSzDh
+gsZFXNC4hCvdNKvQgsYtacFkmfGoeEXY5rt0N466Ih8eyfzvAFZ7couaBwAA
 `,
 	},
 
@@ -295,11 +315,10 @@ QeMmXNC4hCvdNKvQgsYtacFoGWFFxSvCNl+lu3HQFXl8JfO/AQAA//9We3KLmgcAAA==
 		size:    420,
 		modtime: 0,
 		compressed: `
-H4sIAAAAAAAC/2SRwW7DIBBE7/6KVRr1VMc5u5gfqFT11Ds2U8sqWVuwqRoR/r1yTCpb4YAEO48ZDarV
-MR7ezQkp1apqdaHEtA4U5OLQ7NrRW/gyTKYbuK/puNMFEVGMtB/Y4pfqho6UUr71hnvk0Qvt4XACyyw6
-fPhxgpcBIasXoqThi/ADztRqOC8l/j+L6b57P57Z1vQEIEdZnoELeER1jGBL5WqixJJxQ89NBxZ4favg
-nvTaQ95wSWnuQlVi9RrUz9yG6XXZr+vDg3TrsTX4NO6M2WLDVOLv3YJtSoWqbl+h/wIAAP//aLmk3KQB
-AAA=
+H4sIAAAAAAAC/2SRwW6DMBBE73zFKo16KiFnavwDlaqeejd4ilCdBZlN1cjZf68IpCKJD5bsmecdjU1t
+U9q9uwNUS1PUNjPi6gAa5RRQbeo+esR8HFzTcVvSfmMzIqKUaNuxxy+VFe1JdbmNjlss0gttEXAAy2Ta
+fcR+QJQO4+KeiZy6L8IPeKFW4rSMxP8srvluY39kX9ITgCXK/AzCiEfUpgT2lK8UI55c6FquGrAg2ksF
+16TnFvKGk+rUhSnE2zVon7keh9d5P68PD9bbGbcDPl04QvWOKSReuwV71cwUl6+wfwMAaLmk3KQBAAA=
 `,
 	},
 
@@ -317,6 +336,7 @@ var _escDirs = map[string][]os.FileInfo{
 		_escData["/templates/extensions_table.html"],
 		_escData["/templates/footer.html"],
 		_escData["/templates/header.html"],
+		_escData["/templates/pipelines_graph.html"],
 		_escData["/templates/pipelines_table.html"],
 		_escData["/templates/properties_table.html"],
 	},
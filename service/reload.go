@@ -0,0 +1,507 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/service/internal/builder"
+)
+
+// errRestoreFailed is returned when the previous configuration cannot be restored after a failed reload, the
+// collector is then in an inconsistent state.
+var errRestoreFailed = errors.New("cannot restore the previous configuration")
+
+// reloadConfiguration loads the configuration again and applies the changes to the running service. The current
+// configuration keeps running when the new one is invalid or fails to start, the returned error means that it could
+// not be restored and that the collector must terminate.
+func (app *Application) reloadConfiguration(ctx context.Context, factory ConfigFactory) error {
+	app.logger.Info("Reloading configuration...")
+
+	cfg, err := factory(config.NewViper(), app.rootCmd, app.factories)
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		app.logger.Error("Cannot load configuration, keeping the current configuration", zap.Error(err))
+		return nil
+	}
+
+	diff := diffConfigs(app.service.config, cfg)
+	if diff.empty() {
+		app.logger.Info("Configuration unchanged")
+		return nil
+	}
+	if len(diff.restartRequired) > 0 {
+		app.logger.Warn("Settings changed that are only applied when the collector restarts",
+			zap.Strings("settings", diff.restartRequired))
+	}
+	switch {
+	case diff.extensionsChanged:
+		app.logger.Info("Extensions changed, restarting service...")
+		err = app.restartService(ctx, cfg)
+	case len(diff.pipelines) > 0:
+		app.logger.Info("Reloading pipelines...", zap.Int("pipelines", len(diff.pipelines)))
+		err = app.service.reloadPipelines(ctx, cfg, diff.pipelines)
+	default:
+		app.service.setConfig(cfg)
+	}
+	if errors.Is(err, errRestoreFailed) {
+		return err
+	}
+	if err != nil {
+		app.logger.Error("Cannot apply configuration, keeping the current configuration", zap.Error(err))
+		return nil
+	}
+
+	app.logger.Info("Configuration reloaded")
+	return nil
+}
+
+// restartService replaces the running service by a service built from the configuration, the current service is
+// restored when the new one fails to start.
+func (app *Application) restartService(ctx context.Context, cfg *configmodels.Config) error {
+	newSettings := func(cfg *configmodels.Config) *settings {
+		return &settings{
+			Factories:         app.factories,
+			StartInfo:         app.info,
+			Config:            cfg,
+			Logger:            app.logger,
+			AsyncErrorChannel: app.asyncErrorChannel,
+		}
+	}
+
+	next, err := newService(newSettings(cfg))
+	if err != nil {
+		return err
+	}
+
+	current := app.service
+	if err = current.Shutdown(ctx); err != nil {
+		app.logger.Warn("Failed to shutdown service", zap.Error(err))
+	}
+	if err = next.Start(ctx); err == nil {
+		app.service = next
+		return nil
+	}
+
+	app.logger.Error("Failed to start service, restoring the previous service", zap.Error(err))
+	if shutdownErr := next.Shutdown(ctx); shutdownErr != nil {
+		app.logger.Warn("Failed to shutdown service", zap.Error(shutdownErr))
+	}
+	previous, restoreErr := newService(newSettings(current.config))
+	if restoreErr != nil {
+		return fmt.Errorf("%w: %v", errRestoreFailed, restoreErr)
+	}
+	app.service = previous
+	if restoreErr = previous.Start(ctx); restoreErr != nil {
+		return fmt.Errorf("%w: %v", errRestoreFailed, restoreErr)
+	}
+	return err
+}
+
+// watchConfigFiles checks the modification time and the size of the config files at the given interval and
+// requests a reload when they changed, or when a config file was added to or removed from a directory. The files are
+// not watched when the interval is not positive.
+func (app *Application) watchConfigFiles(paths []string, interval time.Duration) (stop func()) {
+	if len(paths) == 0 || interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		last, _ := configFilesState(paths)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			state, err := configFilesState(paths)
+			if err != nil {
+				// A file may be missing while it is being replaced.
+				continue
+			}
+			if state == last {
+				continue
+			}
+			last = state
+			select {
+			case app.reloadChannel <- struct{}{}:
+			default:
+				// A reload is already pending.
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// configDiff describes what has to be rebuilt to apply a new configuration.
+type configDiff struct {
+	// extensionsChanged is set when the extensions changed, the whole service is then rebuilt since every component
+	// may depend on them.
+	extensionsChanged bool
+	// pipelines are the names of the pipelines to rebuild, from the current and the new configuration. A pipeline is
+	// rebuilt when it was added, removed or changed, when one of its components changed or when it shares a receiver,
+	// an exporter, a connector or a processor chain with another rebuilt pipeline.
+	pipelines map[string]bool
+	// serviceChanged is set when the other settings of the service changed, the new configuration is then stored
+	// even when no component is rebuilt so that the new shutdown_timeout is used.
+	serviceChanged bool
+	// restartRequired are the changed settings of the service that are only applied when the collector starts.
+	restartRequired []string
+}
+
+func (d configDiff) empty() bool {
+	return !d.extensionsChanged && len(d.pipelines) == 0 && !d.serviceChanged
+}
+
+func diffConfigs(current, updated *configmodels.Config) configDiff {
+	diff := configDiff{
+		extensionsChanged: !reflect.DeepEqual(current.Extensions, updated.Extensions) ||
+			!reflect.DeepEqual(current.Service.Extensions, updated.Service.Extensions),
+		pipelines: map[string]bool{},
+	}
+
+	if !reflect.DeepEqual(current.Service.Telemetry, updated.Service.Telemetry) {
+		diff.restartRequired = append(diff.restartRequired, "service::telemetry")
+	}
+	if !reflect.DeepEqual(current.Service.FeatureGates, updated.Service.FeatureGates) {
+		diff.restartRequired = append(diff.restartRequired, "service::feature_gates")
+	}
+	diff.serviceChanged = current.Service.ShutdownTimeout != updated.Service.ShutdownTimeout ||
+		len(diff.restartRequired) > 0
+
+	changed := func(cfg *configmodels.Config, p *configmodels.Pipeline) bool {
+		other := updated
+		if cfg == updated {
+			other = current
+		}
+		op := other.Service.Pipelines[p.Name]
+		if op == nil || !reflect.DeepEqual(p, op) {
+			return true
+		}
+		for _, name := range p.Receivers {
+			if !reflect.DeepEqual(cfg.Receivers[name], other.Receivers[name]) {
+				return true
+			}
+		}
+		for _, name := range p.Processors {
+			if !reflect.DeepEqual(cfg.Processors[name], other.Processors[name]) {
+				return true
+			}
+		}
+		for _, name := range p.Exporters {
+			if !reflect.DeepEqual(cfg.Exporters[name], other.Exporters[name]) {
+				return true
+			}
+		}
+		for _, names := range [][]string{p.Receivers, p.Exporters} {
+			for _, name := range names {
+				if !reflect.DeepEqual(cfg.Connectors[name], other.Connectors[name]) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, cfg := range []*configmodels.Config{current, updated} {
+		for name, p := range cfg.Service.Pipelines {
+			if changed(cfg, p) {
+				diff.pipelines[name] = true
+			}
+		}
+	}
+
+	// A receiver feeds all its pipelines and an exporter is shared by all its pipelines, rebuilding them rebuilds
+	// every pipeline using them. A connector is both, it bridges all the pipelines exporting to and receiving from it.
+	// The processors of a chain are shared like an exporter by all the pipelines referencing the chain.
+	for {
+		receivers, exporters, chains := map[string]bool{}, map[string]bool{}, map[string]bool{}
+		for _, cfg := range []*configmodels.Config{current, updated} {
+			for name, p := range cfg.Service.Pipelines {
+				if !diff.pipelines[name] {
+					continue
+				}
+				if p.ProcessorChain != "" {
+					chains[p.ProcessorChain] = true
+				}
+				for _, r := range p.Receivers {
+					receivers[r] = true
+					if cfg.Connectors[r] != nil {
+						exporters[r] = true
+					}
+				}
+				for _, e := range p.Exporters {
+					exporters[e] = true
+					if cfg.Connectors[e] != nil {
+						receivers[e] = true
+					}
+				}
+			}
+		}
+
+		added := false
+		for _, cfg := range []*configmodels.Config{current, updated} {
+			for name, p := range cfg.Service.Pipelines {
+				if !diff.pipelines[name] && (usesAny(p, receivers, exporters) || chains[p.ProcessorChain]) {
+					diff.pipelines[name] = true
+					added = true
+				}
+			}
+		}
+		if !added {
+			return diff
+		}
+	}
+}
+
+func usesAny(p *configmodels.Pipeline, receivers, exporters map[string]bool) bool {
+	for _, r := range p.Receivers {
+		if receivers[r] {
+			return true
+		}
+	}
+	for _, e := range p.Exporters {
+		if exporters[e] {
+			return true
+		}
+	}
+	return false
+}
+
+// subConfig returns the configuration restricted to the given pipelines and their components.
+func subConfig(cfg *configmodels.Config, pipelines map[string]bool) *configmodels.Config {
+	sub := &configmodels.Config{
+		Receivers:  configmodels.Receivers{},
+		Processors: configmodels.Processors{},
+		Exporters:  configmodels.Exporters{},
+		Connectors: configmodels.Connectors{},
+		Extensions: cfg.Extensions,
+		Service: configmodels.Service{
+			Extensions:      cfg.Service.Extensions,
+			ProcessorChains: cfg.Service.ProcessorChains,
+			Pipelines:       configmodels.Pipelines{},
+		},
+	}
+	for name, p := range cfg.Service.Pipelines {
+		if !pipelines[name] {
+			continue
+		}
+		sub.Service.Pipelines[name] = p
+		for _, r := range p.Receivers {
+			if c := cfg.Connectors[r]; c != nil {
+				sub.Connectors[r] = c
+				continue
+			}
+			sub.Receivers[r] = cfg.Receivers[r]
+		}
+		for _, proc := range p.Processors {
+			sub.Processors[proc] = cfg.Processors[proc]
+		}
+		for _, e := range p.Exporters {
+			if c := cfg.Connectors[e]; c != nil {
+				sub.Connectors[e] = c
+				continue
+			}
+			sub.Exporters[e] = cfg.Exporters[e]
+		}
+	}
+	return sub
+}
+
+// builtComponents are the components of a set of pipelines.
+type builtComponents struct {
+	exporters builder.Exporters
+	pipelines builder.BuiltPipelines
+	receivers builder.Receivers
+}
+
+func (srv *service) buildComponents(cfg *configmodels.Config) (*builtComponents, error) {
+	var bc builtComponents
+	var err error
+	if bc.exporters, err = builder.BuildExporters(srv.logger, srv.startInfo, cfg, srv.factories.Exporters); err != nil {
+		return nil, fmt.Errorf("cannot build builtExporters: %w", err)
+	}
+	if bc.pipelines, err = builder.BuildPipelines(srv.logger, srv.startInfo, cfg, bc.exporters, srv.factories.Processors, srv.factories.Connectors); err != nil {
+		return nil, fmt.Errorf("cannot build pipelines: %w", err)
+	}
+	if bc.receivers, err = builder.BuildReceivers(srv.logger, srv.startInfo, cfg, bc.pipelines, srv.factories.Receivers); err != nil {
+		return nil, fmt.Errorf("cannot build receivers: %w", err)
+	}
+	return &bc, nil
+}
+
+func (srv *service) startComponents(ctx context.Context, bc *builtComponents) error {
+	if err := bc.exporters.StartAll(ctx, srv); err != nil {
+		return fmt.Errorf("cannot start builtExporters: %w", err)
+	}
+	if err := bc.pipelines.StartProcessors(ctx, srv); err != nil {
+		return fmt.Errorf("cannot start processors: %w", err)
+	}
+	if err := bc.receivers.StartAll(ctx, srv); err != nil {
+		return fmt.Errorf("cannot start receivers: %w", err)
+	}
+	return nil
+}
+
+// shutdownComponents stops the receivers first, then drains the processors and the exporters.
+func (srv *service) shutdownComponents(ctx context.Context, bc *builtComponents) error {
+	var errs []error
+	if err := bc.receivers.ShutdownAll(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to stop receivers: %w", err))
+	}
+	if err := bc.pipelines.ShutdownProcessors(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to shutdown processors: %w", err))
+	}
+	if err := bc.exporters.ShutdownAll(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to shutdown exporters: %w", err))
+	}
+	return consumererror.Combine(errs)
+}
+
+// runningComponents returns the running components of the given pipelines.
+func (srv *service) runningComponents(cfg *configmodels.Config) *builtComponents {
+	bc := &builtComponents{
+		exporters: builder.Exporters{},
+		pipelines: builder.BuiltPipelines{},
+		receivers: builder.Receivers{},
+	}
+	for c, e := range srv.builtExporters {
+		if _, ok := cfg.Exporters[c.Name()]; ok {
+			bc.exporters[c] = e
+		}
+	}
+	for c, p := range srv.builtPipelines {
+		if _, ok := cfg.Service.Pipelines[c.Name]; ok {
+			bc.pipelines[c] = p
+		}
+	}
+	for c, r := range srv.builtReceivers {
+		if _, ok := cfg.Receivers[c.Name()]; ok {
+			bc.receivers[c] = r
+		}
+	}
+	return bc
+}
+
+// reloadPipelines replaces the given pipelines of the running service by the ones of the new configuration, the
+// other pipelines keep running. The new pipelines are built before the current ones are drained, and the current
+// pipelines are restored when the new ones fail to start. The returned error wraps errRestoreFailed when they cannot
+// be restored.
+func (srv *service) reloadPipelines(ctx context.Context, updated *configmodels.Config, pipelines map[string]bool) error {
+	currentSub := subConfig(srv.config, pipelines)
+	updatedSub := subConfig(updated, pipelines)
+
+	next, err := srv.buildComponents(updatedSub)
+	if err != nil {
+		return err
+	}
+
+	if err = srv.builtExtensions.NotifyPipelineNotReady(); err != nil {
+		srv.logger.Warn("Failed to notify that pipeline is not ready", zap.Error(err))
+	}
+	defer func() {
+		if notifyErr := srv.builtExtensions.NotifyPipelineReady(); notifyErr != nil {
+			srv.logger.Warn("Failed to notify that pipeline is ready", zap.Error(notifyErr))
+		}
+	}()
+
+	srv.logger.Info("Draining pipelines...", zap.Int("pipelines", len(currentSub.Service.Pipelines)))
+	running := srv.runningComponents(currentSub)
+	if err = srv.shutdownComponents(ctx, running); err != nil {
+		srv.logger.Warn("Failed to drain pipelines", zap.Error(err))
+	}
+
+	srv.logger.Info("Starting pipelines...", zap.Int("pipelines", len(updatedSub.Service.Pipelines)))
+	if err = srv.startComponents(ctx, next); err == nil {
+		srv.replaceComponents(running, next, updated)
+		return nil
+	}
+
+	srv.logger.Error("Failed to start pipelines, restoring the previous pipelines", zap.Error(err))
+	if shutdownErr := srv.shutdownComponents(ctx, next); shutdownErr != nil {
+		srv.logger.Warn("Failed to stop pipelines", zap.Error(shutdownErr))
+	}
+	previous, restoreErr := srv.buildComponents(currentSub)
+	if restoreErr != nil {
+		srv.replaceComponents(running, &builtComponents{}, srv.config)
+		return fmt.Errorf("%w: %v", errRestoreFailed, restoreErr)
+	}
+	// The restored components replace the running ones even when they fail to start, so that they are stopped when
+	// the collector shuts down.
+	srv.replaceComponents(running, previous, srv.config)
+	if restoreErr = srv.startComponents(ctx, previous); restoreErr != nil {
+		return fmt.Errorf("%w: %v", errRestoreFailed, restoreErr)
+	}
+	return err
+}
+
+// replaceComponents replaces the components of the running service.
+func (srv *service) replaceComponents(removed, added *builtComponents, cfg *configmodels.Config) {
+	exporters := builder.Exporters{}
+	for c, e := range srv.builtExporters {
+		if _, ok := removed.exporters[c]; !ok {
+			exporters[c] = e
+		}
+	}
+	for c, e := range added.exporters {
+		exporters[c] = e
+	}
+	pipelines := builder.BuiltPipelines{}
+	for c, p := range srv.builtPipelines {
+		if _, ok := removed.pipelines[c]; !ok {
+			pipelines[c] = p
+		}
+	}
+	for c, p := range added.pipelines {
+		pipelines[c] = p
+	}
+	receivers := builder.Receivers{}
+	for c, r := range srv.builtReceivers {
+		if _, ok := removed.receivers[c]; !ok {
+			receivers[c] = r
+		}
+	}
+	for c, r := range added.receivers {
+		receivers[c] = r
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.builtExporters = exporters
+	srv.builtPipelines = pipelines
+	srv.builtReceivers = receivers
+	srv.config = cfg
+}
+
+// setConfig replaces the configuration of the running service when only the settings of the service changed.
+func (srv *service) setConfig(cfg *configmodels.Config) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.config = cfg
+}
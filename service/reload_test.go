@@ -0,0 +1,482 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/service/defaultcomponents"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+const reloadBaseConfig = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: %s
+exporters:
+  logging:
+  logging/2:
+    loglevel: debug
+processors:
+  batch:
+  batch/2:
+    timeout: 2s
+
+extensions:
+  health_check:
+    port: %d
+
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]
+    logs:
+      receivers: [otlp]
+      exporters: [logging/2]
+`
+
+func loadConfigString(t *testing.T, factories component.Factories, configStr string) *configmodels.Config {
+	v := config.NewViper()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(configStr)))
+	cfg, err := config.Load(v, factories)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func TestDiffConfigs(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	current := loadConfigString(t, factories, fmt.Sprintf(reloadBaseConfig, "localhost:4317", 13133))
+
+	tests := []struct {
+		name              string
+		update            func(cfg *configmodels.Config)
+		extensionsChanged bool
+		pipelines         []string
+		serviceChanged    bool
+		restartRequired   []string
+	}{
+		{
+			name:   "unchanged",
+			update: func(cfg *configmodels.Config) {},
+		},
+		{
+			name: "extension_changed",
+			update: func(cfg *configmodels.Config) {
+				cfg.Service.Extensions = nil
+			},
+			extensionsChanged: true,
+		},
+		{
+			name: "processor_changed",
+			update: func(cfg *configmodels.Config) {
+				cfg.Service.Pipelines["traces"].Processors = []string{"batch/2"}
+			},
+			pipelines: []string{"traces", "metrics", "logs"},
+		},
+		{
+			name: "unused_component_changed",
+			update: func(cfg *configmodels.Config) {
+				delete(cfg.Processors, "batch/2")
+			},
+		},
+		{
+			name: "exporter_changed",
+			update: func(cfg *configmodels.Config) {
+				cfg.Service.Pipelines["logs"].Receivers = nil
+				cfg.Service.Pipelines["logs"].Exporters = []string{"logging"}
+			},
+			pipelines: []string{"traces", "metrics", "logs"},
+		},
+		{
+			name: "pipeline_removed",
+			update: func(cfg *configmodels.Config) {
+				delete(cfg.Service.Pipelines, "metrics")
+			},
+			pipelines: []string{"traces", "metrics", "logs"},
+		},
+		{
+			name: "shutdown_timeout_changed",
+			update: func(cfg *configmodels.Config) {
+				cfg.Service.ShutdownTimeout = time.Minute
+			},
+			serviceChanged: true,
+		},
+		{
+			name: "telemetry_and_feature_gates_changed",
+			update: func(cfg *configmodels.Config) {
+				cfg.Service.Telemetry.Metrics.Level = "detailed"
+				cfg.Service.FeatureGates = []string{"-gate"}
+			},
+			serviceChanged:  true,
+			restartRequired: []string{"service::telemetry", "service::feature_gates"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			updated := loadConfigString(t, factories, fmt.Sprintf(reloadBaseConfig, "localhost:4317", 13133))
+			test.update(updated)
+
+			diff := diffConfigs(current, updated)
+			assert.Equal(t, test.extensionsChanged, diff.extensionsChanged)
+			assert.Equal(t, test.serviceChanged, diff.serviceChanged)
+			assert.Equal(t, test.restartRequired, diff.restartRequired)
+			assert.Equal(t, len(test.pipelines) == 0 && !test.extensionsChanged && !test.serviceChanged, diff.empty())
+			pipelines := map[string]bool{}
+			for _, name := range test.pipelines {
+				pipelines[name] = true
+			}
+			assert.Equal(t, pipelines, diff.pipelines)
+		})
+	}
+}
+
+func TestDiffConfigs_IndependentPipelines(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	configStr := `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+  jaeger:
+    protocols:
+      grpc:
+exporters:
+  logging:
+  logging/2:
+processors:
+  batch:
+    timeout: %s
+
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [logging]
+    traces/2:
+      receivers: [jaeger]
+      exporters: [logging/2]
+`
+	current := loadConfigString(t, factories, fmt.Sprintf(configStr, "1s"))
+	updated := loadConfigString(t, factories, fmt.Sprintf(configStr, "2s"))
+
+	diff := diffConfigs(current, updated)
+	assert.False(t, diff.extensionsChanged)
+	assert.Equal(t, map[string]bool{"traces": true}, diff.pipelines)
+
+	sub := subConfig(updated, diff.pipelines)
+	assert.Equal(t, configmodels.Pipelines{"traces": updated.Service.Pipelines["traces"]}, sub.Service.Pipelines)
+	assert.Equal(t, configmodels.Receivers{"otlp": updated.Receivers["otlp"]}, sub.Receivers)
+	assert.Equal(t, configmodels.Processors{"batch": updated.Processors["batch"]}, sub.Processors)
+	assert.Equal(t, configmodels.Exporters{"logging": updated.Exporters["logging"]}, sub.Exporters)
+}
+
+func TestService_ReloadPipelines(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	current := loadConfigString(t, factories, fmt.Sprintf(reloadBaseConfig, endpoint, testutil.GetAvailablePort(t)))
+	srv, err := newService(&settings{
+		Factories: factories,
+		StartInfo: component.DefaultApplicationStartInfo(),
+		Config:    current,
+		Logger:    zap.NewNop(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	}()
+
+	updated := loadConfigString(t, factories, fmt.Sprintf(reloadBaseConfig, endpoint, testutil.GetAvailablePort(t)))
+	delete(updated.Service.Pipelines, "metrics")
+	diff := diffConfigs(current, updated)
+	require.NoError(t, srv.reloadPipelines(context.Background(), updated, diff.pipelines))
+	assert.Equal(t, updated.Service.Pipelines, srv.GetPipelines())
+	assert.Len(t, srv.getBuiltPipelines(), 2)
+	assert.Len(t, srv.GetExporters()[configmodels.LogsDataType], 1)
+
+	// The receiver is listening again.
+	conn, err := net.Dial("tcp", endpoint)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+const reloadChainConfig = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: %s
+  otlp/2:
+    protocols:
+      grpc:
+        endpoint: %s
+exporters:
+  logging:
+  logging/2:
+processors:
+  batch:
+  batch/2:
+    timeout: %s
+
+service:
+  processor_chains:
+    common: [batch]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch/2, common]
+      exporters: [logging]
+    metrics:
+      receivers: [otlp/2]
+      processors: [common]
+      exporters: [logging/2]
+`
+
+func TestService_ReloadPipelinesProcessorChain(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	otlpEndpoint := testutil.GetAvailableLocalAddress(t)
+	metricsEndpoint := testutil.GetAvailableLocalAddress(t)
+	current := loadConfigString(t, factories, fmt.Sprintf(reloadChainConfig, otlpEndpoint, metricsEndpoint, "1s"))
+	srv, err := newService(&settings{
+		Factories: factories,
+		StartInfo: component.DefaultApplicationStartInfo(),
+		Config:    current,
+		Logger:    zap.NewNop(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	}()
+
+	// Only the processors of the traces pipeline changed, the metrics pipeline shares the chain and is rebuilt too.
+	updated := loadConfigString(t, factories, fmt.Sprintf(reloadChainConfig, otlpEndpoint, metricsEndpoint, "2s"))
+	diff := diffConfigs(current, updated)
+	assert.Equal(t, map[string]bool{"traces": true, "metrics": true}, diff.pipelines)
+	assert.Equal(t, updated.Service.ProcessorChains, subConfig(updated, diff.pipelines).Service.ProcessorChains)
+
+	require.NoError(t, srv.reloadPipelines(context.Background(), updated, diff.pipelines))
+	assert.Equal(t, updated.Service.Pipelines, srv.GetPipelines())
+	assert.Len(t, srv.getBuiltPipelines(), 2)
+
+	conn, err := net.Dial("tcp", otlpEndpoint)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+func TestService_ReloadPipelinesRestore(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	current := loadConfigString(t, factories, fmt.Sprintf(reloadBaseConfig, endpoint, testutil.GetAvailablePort(t)))
+	srv, err := newService(&settings{
+		Factories: factories,
+		StartInfo: component.DefaultApplicationStartInfo(),
+		Config:    current,
+		Logger:    zap.NewNop(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	}()
+
+	// The new receiver cannot listen on an address that is in use.
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	updated := loadConfigString(t, factories, fmt.Sprintf(reloadBaseConfig, ln.Addr().String(), testutil.GetAvailablePort(t)))
+	diff := diffConfigs(current, updated)
+	require.Len(t, diff.pipelines, 3)
+	err = srv.reloadPipelines(context.Background(), updated, diff.pipelines)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, errRestoreFailed))
+	assert.Equal(t, current.Service.Pipelines, srv.GetPipelines())
+	assert.Len(t, srv.getBuiltPipelines(), 3)
+
+	// The previous receiver is listening again.
+	conn, err := net.Dial("tcp", endpoint)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+func TestApplication_ReloadConfiguration(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	healthCheckPort := testutil.GetAvailablePort(t)
+	configStr := fmt.Sprintf(reloadBaseConfig, testutil.GetAvailableLocalAddress(t), healthCheckPort)
+	var configErr error
+	loads := 0
+	setConfig := func(str string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		configStr, configErr = str, err
+	}
+
+	params := Parameters{
+		ApplicationStartInfo: component.DefaultApplicationStartInfo(),
+		ConfigFactory: func(_ *viper.Viper, _ *cobra.Command, factories component.Factories) (*configmodels.Config, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			loads++
+			if configErr != nil {
+				return nil, configErr
+			}
+			return loadConfigString(t, factories, configStr), nil
+		},
+		Factories: factories,
+	}
+	app, err := New(params)
+	require.NoError(t, err)
+	app.Command().SetArgs([]string{"--metrics-level=NONE"})
+
+	appDone := make(chan struct{})
+	go func() {
+		defer close(appDone)
+		assert.NoError(t, app.Run())
+	}()
+
+	assert.Equal(t, Starting, <-app.GetStateChannel())
+	assert.Equal(t, Running, <-app.GetStateChannel())
+
+	// An invalid configuration is not applied.
+	setConfig("", errors.New("invalid configuration"))
+	app.signalsChannel <- syscall.SIGHUP
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return loads == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The pipelines are reloaded.
+	setConfig(strings.Replace(fmt.Sprintf(reloadBaseConfig, testutil.GetAvailableLocalAddress(t), healthCheckPort),
+		"      exporters: [logging/2]", "      exporters: [logging, logging/2]", 1), nil)
+	app.reloadChannel <- struct{}{}
+	assert.Eventually(t, func() bool {
+		return len(app.service.GetPipelines()["logs"].Exporters) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The service is restarted with the new extensions.
+	healthCheckPort = testutil.GetAvailablePort(t)
+	restartedConfig := fmt.Sprintf(reloadBaseConfig, testutil.GetAvailableLocalAddress(t), healthCheckPort)
+	setConfig(restartedConfig, nil)
+	app.signalsChannel <- syscall.SIGHUP
+	assert.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", healthCheckPort))
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The new shutdown timeout is applied without rebuilding the pipelines.
+	pipelines := app.service.getBuiltPipelines()
+	setConfig(restartedConfig+"  shutdown_timeout: 3s\n", nil)
+	app.reloadChannel <- struct{}{}
+	assert.Eventually(t, func() bool {
+		return app.service.shutdownTimeout() == 3*time.Second
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, pipelines, app.service.getBuiltPipelines())
+
+	app.Shutdown()
+	<-appDone
+	assert.Equal(t, Closing, <-app.GetStateChannel())
+	assert.Equal(t, Closed, <-app.GetStateChannel())
+}
+
+func TestApplication_WatchConfigFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(file, []byte("receivers:"), 0600))
+	overlays := filepath.Join(dir, "overlays")
+	require.NoError(t, os.Mkdir(overlays, 0700))
+
+	app := &Application{reloadChannel: make(chan struct{}, 1)}
+	stop := app.watchConfigFiles([]string{file, overlays}, 10*time.Millisecond)
+	defer stop()
+
+	assertReload := func(t *testing.T, expected bool) {
+		select {
+		case <-app.reloadChannel:
+			assert.True(t, expected, "reload requested for unchanged files")
+		case <-time.After(100 * time.Millisecond):
+			assert.False(t, expected, "reload not requested for changed files")
+		}
+	}
+
+	assertReload(t, false)
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("receivers:\nexporters:"), 0600))
+	assertReload(t, true)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(overlays, "overlay.yaml"), []byte("receivers:"), 0600))
+	assertReload(t, true)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(overlays, "README.md"), []byte("overlays"), 0600))
+	assertReload(t, false)
+}
+
+func TestApplication_WatchConfigFilesDisabled(t *testing.T) {
+	app := &Application{reloadChannel: make(chan struct{}, 1)}
+	app.watchConfigFiles([]string{"config.yaml"}, 0)()
+	app.watchConfigFiles(nil, time.Second)()
+}
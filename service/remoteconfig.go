@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/service/configprovider"
+	"go.opentelemetry.io/collector/service/internal/builder"
+)
+
+// remoteConfig keeps the last configuration retrieved by a configprovider.Provider.
+type remoteConfig struct {
+	provider configprovider.Provider
+
+	mu        sync.Mutex
+	retrieved []byte
+}
+
+// fetch retrieves the configuration and returns true when it changed.
+func (rc *remoteConfig) fetch(ctx context.Context) (bool, error) {
+	retrieved, err := rc.provider.Retrieve(ctx)
+	if errors.Is(err, configprovider.ErrNotModified) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.retrieved = retrieved
+	return true, nil
+}
+
+// configFactory implements ConfigFactory, the config files, if any, and the --set flags override the retrieved
+// configuration. The configuration is retrieved the first time, then it is updated by the polling.
+func (rc *remoteConfig) configFactory(v *viper.Viper, cmd *cobra.Command, factories component.Factories) (*configmodels.Config, error) {
+	rc.mu.Lock()
+	retrieved := rc.retrieved
+	rc.mu.Unlock()
+	if retrieved == nil {
+		if _, err := rc.fetch(context.Background()); err != nil {
+			return nil, fmt.Errorf("cannot retrieve configuration: %w", err)
+		}
+		rc.mu.Lock()
+		retrieved = rc.retrieved
+		rc.mu.Unlock()
+	}
+	if retrieved == nil {
+		return nil, errors.New("no configuration retrieved")
+	}
+
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(retrieved)); err != nil {
+		return nil, fmt.Errorf("error loading retrieved configuration: %v", err)
+	}
+
+	if err := mergeConfigFiles(v, builder.GetConfigFiles()); err != nil {
+		return nil, err
+	}
+
+	if err := AddSetFlagProperties(v, cmd); err != nil {
+		return nil, fmt.Errorf("failed to process set flag: %v", err)
+	}
+	return config.Load(v, factories)
+}
+
+// pollConfigProvider retrieves the configuration at the given interval and requests a reload when it changed. The
+// configuration is not polled when there is no provider or when the interval is not positive.
+func (app *Application) pollConfigProvider(interval time.Duration) (stop func()) {
+	if app.remoteConfig == nil || interval <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			changed, err := app.remoteConfig.fetch(ctx)
+			if err != nil {
+				app.logger.Warn("Cannot retrieve configuration", zap.Error(err))
+				continue
+			}
+			if !changed {
+				continue
+			}
+			select {
+			case app.reloadChannel <- struct{}{}:
+			default:
+				// A reload is already pending.
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
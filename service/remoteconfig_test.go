@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/service/configprovider"
+	"go.opentelemetry.io/collector/service/defaultcomponents"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+const remoteConfigStr = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: %s
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+    logs:
+      receivers: [otlp]
+      exporters: [logging]
+`
+
+type testProvider struct {
+	mu       sync.Mutex
+	config   []byte
+	modified bool
+}
+
+func (tp *testProvider) Retrieve(context.Context) ([]byte, error) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	if !tp.modified {
+		return nil, configprovider.ErrNotModified
+	}
+	tp.modified = false
+	return tp.config, nil
+}
+
+func (tp *testProvider) set(config string) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.config = []byte(config)
+	tp.modified = true
+}
+
+func TestApplication_ConfigProvider(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`
+exporters:
+  logging/2:
+service:
+  pipelines:
+    logs:
+      exporters: [logging/2]
+`), 0600))
+
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	provider := &testProvider{}
+	provider.set(fmt.Sprintf(remoteConfigStr, endpoint))
+
+	app, err := New(Parameters{
+		ApplicationStartInfo: component.DefaultApplicationStartInfo(),
+		ConfigProvider:       provider,
+		ConfigPollInterval:   10 * time.Millisecond,
+		Factories:            factories,
+	})
+	require.NoError(t, err)
+	app.Command().SetArgs([]string{"--config=" + file, "--metrics-level=NONE"})
+
+	appDone := make(chan struct{})
+	go func() {
+		defer close(appDone)
+		assert.NoError(t, app.Run())
+	}()
+
+	assert.Equal(t, Starting, <-app.GetStateChannel())
+	assert.Equal(t, Running, <-app.GetStateChannel())
+
+	// The config file overrides the retrieved configuration.
+	pipelines := app.service.GetPipelines()
+	assert.Len(t, pipelines, 2)
+	assert.Equal(t, []string{"logging/2"}, pipelines["logs"].Exporters)
+
+	// The configuration is reloaded when the retrieved configuration changed.
+	provider.set(fmt.Sprintf(remoteConfigStr, endpoint) + `
+    metrics:
+      receivers: [otlp]
+      exporters: [logging]
+`)
+	assert.Eventually(t, func() bool {
+		return len(app.service.GetPipelines()) == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"logging/2"}, app.service.GetPipelines()["logs"].Exporters)
+
+	app.Shutdown()
+	<-appDone
+	assert.Equal(t, Closing, <-app.GetStateChannel())
+	assert.Equal(t, Closed, <-app.GetStateChannel())
+}
+
+func TestApplication_ConfigProviderAndFactory(t *testing.T) {
+	_, err := New(Parameters{
+		ConfigProvider: &testProvider{},
+		ConfigFactory: func(*viper.Viper, *cobra.Command, component.Factories) (*configmodels.Config, error) {
+			return nil, nil
+		},
+	})
+	assert.EqualError(t, err, "ConfigFactory and ConfigProvider cannot be both set")
+}
+
+func TestApplication_ConfigProviderNotModified(t *testing.T) {
+	rc := &remoteConfig{provider: &testProvider{}}
+	_, err := rc.configFactory(nil, nil, component.Factories{})
+	assert.EqualError(t, err, "no configuration retrieved")
+}
@@ -15,17 +15,23 @@
 package service
 
 import (
+	"context"
 	"net/http"
-	"strings"
-	"unicode"
+	"time"
 
 	"contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/google/uuid"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
+	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor"
@@ -39,21 +45,72 @@ import (
 // applicationTelemetry is application's own telemetry.
 var applicationTelemetry appTelemetryExporter = &appTelemetry{}
 
+const (
+	// defaultOTLPMetricsInterval is the interval at which the metrics are pushed when
+	// service::telemetry::metrics::otlp::interval is not set.
+	defaultOTLPMetricsInterval = 60 * time.Second
+
+	// spansFlushInterval is the interval at which the spans of the collector operations are exported.
+	spansFlushInterval = 5 * time.Second
+
+	// defaultSamplingProbability is the probability of the OpenCensus default sampler, restored when the tracing of
+	// the collector is shut down.
+	defaultSamplingProbability = 1e-4
+)
+
 type appTelemetryExporter interface {
-	init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger) error
+	init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger, info component.ApplicationStartInfo, cfg configmodels.ServiceTelemetry) error
 	shutdown() error
 }
 
 type appTelemetry struct {
-	views  []*view.View
-	server *http.Server
+	views         []*view.View
+	server        *http.Server
+	pusher        *telemetry2.MetricsPusher
+	spansExporter *telemetry2.SpansExporter
 }
 
-func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger) error {
+// init sets up the telemetry of the collector. The metrics views are registered, served in the Prometheus format and
+// pushed to the OTLP endpoint if configured, the level must be already set to the --metrics-level flag. The spans of
+// the collector operations are exported if configured.
+func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes uint64, logger *zap.Logger, info component.ApplicationStartInfo, cfg configmodels.ServiceTelemetry) error {
+	// The same resource attributes are added to the metrics, served in the Prometheus format or pushed with OTLP,
+	// and to the spans.
+	resourceAttributes := map[string]string{
+		conventions.AttributeServiceVersion: info.Version,
+	}
+	var instanceID string
+	if telemetry.GetAddInstanceID() {
+		instanceUUID, _ := uuid.NewRandom()
+		instanceID = instanceUUID.String()
+		resourceAttributes[conventions.AttributeServiceInstance] = instanceID
+	}
+
+	if cfg.Traces.OTLP != nil {
+		if err := tel.initTraces(asyncErrorChannel, logger, info, cfg.Traces, resourceAttributes); err != nil {
+			return err
+		}
+	}
+
+	return tel.initMetrics(asyncErrorChannel, ballastSizeBytes, logger, info, cfg.Metrics, instanceID, resourceAttributes)
+}
+
+func (tel *appTelemetry) initMetrics(
+	asyncErrorChannel chan<- error,
+	ballastSizeBytes uint64,
+	logger *zap.Logger,
+	info component.ApplicationStartInfo,
+	cfg configmodels.MetricsTelemetry,
+	instanceID string,
+	resourceAttributes map[string]string,
+) error {
 	level := configtelemetry.GetMetricsLevelFlagValue()
-	metricsAddr := telemetry.GetMetricsAddr()
+	metricsAddr := cfg.Address
+	if metricsAddr == "" {
+		metricsAddr = telemetry.GetMetricsAddr()
+	}
 
-	if level == configtelemetry.LevelNone || metricsAddr == "" {
+	if level == configtelemetry.LevelNone || (metricsAddr == "" && cfg.OTLP == nil) {
 		return nil
 	}
 
@@ -66,6 +123,7 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	views = append(views, batchprocessor.MetricViews()...)
 	views = append(views, fluentobserv.MetricViews()...)
 	views = append(views, jaegerexporter.MetricViews()...)
+	views = append(views, kafkaexporter.MetricViews()...)
 	views = append(views, kafkareceiver.MetricViews()...)
 	views = append(views, obsreport.Configure(level)...)
 	views = append(views, processMetricsViews.Views()...)
@@ -78,18 +136,108 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 
 	processMetricsViews.StartCollection()
 
-	// Until we can use a generic metrics exporter, default to Prometheus.
-	opts := prometheus.Options{
-		Namespace: telemetry.GetMetricsPrefix(),
+	if cfg.OTLP != nil {
+		if err = tel.initOTLPMetrics(asyncErrorChannel, logger, info, cfg.OTLP, resourceAttributes); err != nil {
+			return err
+		}
 	}
 
-	var instanceID string
-	if telemetry.GetAddInstanceID() {
-		instanceUUID, _ := uuid.NewRandom()
-		instanceID = instanceUUID.String()
-		opts.ConstLabels = map[string]string{
-			sanitizePrometheusKey(conventions.AttributeServiceInstance): instanceID,
-		}
+	if metricsAddr == "" {
+		return nil
+	}
+	return tel.initPrometheus(asyncErrorChannel, logger, level, metricsAddr, instanceID, resourceAttributes)
+}
+
+// initTraces samples the collector operations and exports their spans to the OTLP endpoint. The spans are batched
+// and exported every spansFlushInterval, through an OTLP exporter that neither queues nor retries them.
+func (tel *appTelemetry) initTraces(
+	asyncErrorChannel chan<- error,
+	logger *zap.Logger,
+	info component.ApplicationStartInfo,
+	cfg configmodels.TracesTelemetry,
+	resourceAttributes map[string]string,
+) error {
+	factory := otlpexporter.NewFactory()
+	exp, err := factory.CreateTracesExporter(
+		context.Background(),
+		component.ExporterCreateParams{Logger: logger, ApplicationStartInfo: info},
+		newOTLPTelemetryExporterConfig(factory, "otlp/telemetry_traces", cfg.OTLP))
+	if err != nil {
+		return err
+	}
+
+	spansExporter := telemetry2.NewSpansExporter(exp, resourceAttributes, spansFlushInterval, logger)
+	if err = spansExporter.Start(context.Background(), &telemetryHost{asyncErrorChannel: asyncErrorChannel}); err != nil {
+		return err
+	}
+	tel.spansExporter = spansExporter
+
+	samplingRatio := cfg.SamplingRatio
+	if samplingRatio == 0 {
+		samplingRatio = 1
+	}
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(samplingRatio)})
+	trace.RegisterExporter(spansExporter)
+
+	logger.Info(
+		"Exporting the spans of the collector with OTLP",
+		zap.String("endpoint", cfg.OTLP.Endpoint),
+		zap.Float64("sampling_ratio", samplingRatio),
+	)
+	return nil
+}
+
+// initOTLPMetrics starts pushing the metrics to the OTLP endpoint, through an OTLP exporter that neither queues nor
+// retries the metrics, the next push sends the cumulative values again.
+func (tel *appTelemetry) initOTLPMetrics(
+	asyncErrorChannel chan<- error,
+	logger *zap.Logger,
+	info component.ApplicationStartInfo,
+	cfg *configmodels.OTLPMetricsTelemetry,
+	resourceAttributes map[string]string,
+) error {
+	factory := otlpexporter.NewFactory()
+	exp, err := factory.CreateMetricsExporter(
+		context.Background(),
+		component.ExporterCreateParams{Logger: logger, ApplicationStartInfo: info},
+		newOTLPTelemetryExporterConfig(factory, "otlp/telemetry", &cfg.OTLPTelemetryEndpoint))
+	if err != nil {
+		return err
+	}
+
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultOTLPMetricsInterval
+	}
+	pusher := telemetry2.NewMetricsPusher(exp, telemetry.GetMetricsPrefix(), resourceAttributes, interval)
+	if err = pusher.Start(context.Background(), &telemetryHost{asyncErrorChannel: asyncErrorChannel}); err != nil {
+		return err
+	}
+	tel.pusher = pusher
+
+	logger.Info(
+		"Pushing metrics with OTLP",
+		zap.String("endpoint", cfg.Endpoint),
+		zap.Duration("interval", interval),
+	)
+	return nil
+}
+
+// initPrometheus serves the metrics in the Prometheus format, the resource attributes are added as labels.
+func (tel *appTelemetry) initPrometheus(
+	asyncErrorChannel chan<- error,
+	logger *zap.Logger,
+	level configtelemetry.Level,
+	metricsAddr string,
+	instanceID string,
+	resourceAttributes map[string]string,
+) error {
+	opts := prometheus.Options{
+		Namespace:   telemetry.GetMetricsPrefix(),
+		ConstLabels: make(map[string]string, len(resourceAttributes)),
+	}
+	for key, value := range resourceAttributes {
+		opts.ConstLabels[telemetry2.SanitizeMetricName(key)] = value
 	}
 
 	pe, err := prometheus.NewExporter(opts)
@@ -102,7 +250,7 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	logger.Info(
 		"Serving Prometheus metrics",
 		zap.String("address", metricsAddr),
-		zap.Int8("level", int8(level)), // TODO: make it human friendly
+		zap.Stringer("level", &level),
 		zap.String(conventions.AttributeServiceInstance, instanceID),
 	)
 
@@ -124,22 +272,73 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	return nil
 }
 
+// newOTLPTelemetryExporterConfig returns the configuration of an OTLP exporter sending the telemetry of the collector
+// to the endpoint. The sending queue and the retries are disabled.
+func newOTLPTelemetryExporterConfig(factory component.ExporterFactory, name string, endpoint *configmodels.OTLPTelemetryEndpoint) *otlpexporter.Config {
+	expCfg := factory.CreateDefaultConfig().(*otlpexporter.Config)
+	expCfg.SetName(name)
+	expCfg.Endpoint = endpoint.Endpoint
+	expCfg.TLSSetting.Insecure = endpoint.Insecure
+	expCfg.Headers = endpoint.Headers
+	expCfg.QueueSettings.Enabled = false
+	expCfg.RetrySettings.Enabled = false
+	return expCfg
+}
+
 func (tel *appTelemetry) shutdown() error {
+	var errs []error
+
+	// Export the remaining spans, the spans ended later are not exported.
+	if tel.spansExporter != nil {
+		trace.UnregisterExporter(tel.spansExporter)
+		trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(defaultSamplingProbability)})
+		if err := tel.spansExporter.Shutdown(context.Background()); err != nil {
+			errs = append(errs, err)
+		}
+		tel.spansExporter = nil
+	}
+
+	// Push the metrics a last time before unregistering the views.
+	if tel.pusher != nil {
+		if err := tel.pusher.Shutdown(context.Background()); err != nil {
+			errs = append(errs, err)
+		}
+		tel.pusher = nil
+	}
+
 	view.Unregister(tel.views...)
 
 	if tel.server != nil {
-		return tel.server.Close()
+		if err := tel.server.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
+	return consumererror.Combine(errs)
+}
+
+// telemetryHost is the host of the exporter pushing the metrics of the collector, the exporter is started before
+// the service and only uses the host to report fatal errors.
+type telemetryHost struct {
+	asyncErrorChannel chan<- error
+}
+
+var _ component.Host = (*telemetryHost)(nil)
+
+func (th *telemetryHost) ReportFatalError(err error) {
+	th.asyncErrorChannel <- err
+}
+
+func (th *telemetryHost) ReportComponentStatus(*component.StatusEvent) {}
+
+func (th *telemetryHost) GetFactory(component.Kind, configmodels.Type) component.Factory {
 	return nil
 }
 
-func sanitizePrometheusKey(str string) string {
-	runeFilterMap := func(r rune) rune {
-		if unicode.IsDigit(r) || unicode.IsLetter(r) || r == '_' {
-			return r
-		}
-		return '_'
-	}
-	return strings.Map(runeFilterMap, str)
+func (th *telemetryHost) GetExtensions() map[configmodels.NamedEntity]component.Extension {
+	return nil
+}
+
+func (th *telemetryHost) GetExporters() map[configmodels.DataType]map[configmodels.NamedEntity]component.Exporter {
+	return nil
 }
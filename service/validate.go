@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/service/internal/builder"
+)
+
+// newValidateCommand returns the command validating the configuration without running the collector.
+func (app *Application) newValidateCommand(factory ConfigFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the configuration without running the collector",
+		Long: "Loads the configuration, validates the settings of the components and checks that the components " +
+			"support the data types of their pipelines. The components are created but not started.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := app.validateConfiguration(cmd, factory); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "The configuration is valid.")
+			return nil
+		},
+	}
+}
+
+// validateConfiguration loads and validates the configuration, then builds the components, without starting them, to
+// check that they can be created and that they support the data types of their pipelines.
+func (app *Application) validateConfiguration(cmd *cobra.Command, factory ConfigFactory) error {
+	if err := configcheck.ValidateConfigFromFactories(app.factories); err != nil {
+		return err
+	}
+
+	cfg, err := factory(config.NewViper(), cmd, app.factories)
+	if err != nil {
+		return fmt.Errorf("cannot load configuration: %w", err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	logger := zap.NewNop()
+	if _, err = builder.BuildExtensions(logger, app.info, cfg, app.factories.Extensions); err != nil {
+		return fmt.Errorf("cannot build extensions: %w", err)
+	}
+	exporters, err := builder.BuildExporters(logger, app.info, cfg, app.factories.Exporters)
+	if err != nil {
+		return fmt.Errorf("cannot build exporters: %w", err)
+	}
+	pipelines, err := builder.BuildPipelines(logger, app.info, cfg, exporters, app.factories.Processors, app.factories.Connectors)
+	if err != nil {
+		return fmt.Errorf("cannot build pipelines: %w", err)
+	}
+	if _, err = builder.BuildReceivers(logger, app.info, cfg, pipelines, app.factories.Receivers); err != nil {
+		return fmt.Errorf("cannot build receivers: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/defaultcomponents"
+)
+
+func TestApplication_Validate(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "valid",
+			args: []string{"--config=" + filepath.Join("testdata", "otelcol-config-minimal.yaml")},
+		},
+		{
+			name: "set_flag",
+			args: []string{
+				"--config=" + filepath.Join("testdata", "otelcol-config-minimal.yaml"),
+				"--set=exporters.otlp.compression=lz4",
+			},
+			err: `cannot build exporters: error creating otlp exporter: unsupported compression type "lz4"`,
+		},
+		{
+			name: "invalid_component",
+			args: []string{"--config=" + filepath.Join("testdata", "validate", "invalid-component.yaml")},
+			err:  `cannot load configuration: invalid configuration of processor "batch": send_batch_max_size must be greater or equal to send_batch_size`,
+		},
+		{
+			name: "unsupported_data_type",
+			args: []string{"--config=" + filepath.Join("testdata", "validate", "unsupported-data-type.yaml")},
+			err:  `cannot build receivers: receiver jaeger does not support logs but it was used in a logs pipeline`,
+		},
+		{
+			name: "missing_pipeline",
+			args: []string{"--config=" + filepath.Join("testdata", "otelcol-config-minimal.yaml"), "--set=service.pipelines.traces.exporters=logging"},
+			err:  `invalid configuration: pipeline "traces" references exporter "logging" which does not exist`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app, err := New(Parameters{ApplicationStartInfo: component.DefaultApplicationStartInfo(), Factories: factories})
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			app.Command().SetOut(&out)
+			app.Command().SetErr(&out)
+			app.Command().SetArgs(append([]string{"validate"}, test.args...))
+
+			err = app.Run()
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "The configuration is valid.\n", out.String())
+		})
+	}
+}
@@ -18,9 +18,15 @@ import (
 	"net/http"
 	"path"
 	"sort"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats/view"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/internal/version"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -37,6 +43,7 @@ func (srv *service) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), func(w http.ResponseWriter, r *http.Request) {
 		handleExtensionzRequest(srv, w, r)
 	})
+	mux.HandleFunc(path.Join(pathPrefix, statuszPath), srv.handleStatuszRequest)
 }
 
 func (srv *service) handleServicezRequest(w http.ResponseWriter, r *http.Request) {
@@ -53,6 +60,11 @@ func (srv *service) handleServicezRequest(w http.ResponseWriter, r *http.Request
 		ComponentEndpoint: extensionzPath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Component Status",
+		ComponentEndpoint: statuszPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Build And Runtime", Properties: version.RuntimeVar()})
 	zpages.WriteHTMLFooter(w)
 }
@@ -65,6 +77,7 @@ func (srv *service) handlePipelinezRequest(w http.ResponseWriter, r *http.Reques
 	componentKind := r.Form.Get(zComponentKind)
 	zpages.WriteHTMLHeader(w, zpages.HeaderData{Title: "Pipelines"})
 	zpages.WriteHTMLPipelinesSummaryTable(w, srv.getPipelinesSummaryTableData())
+	zpages.WriteHTMLPipelinesGraph(w, srv.getPipelinesGraphData())
 	if pipelineName != "" && componentName != "" && componentKind != "" {
 		fullName := componentName
 		if componentKind == "processor" {
@@ -83,8 +96,9 @@ func (srv *service) getPipelinesSummaryTableData() zpages.SummaryPipelinesTableD
 		ComponentEndpoint: pipelinezPath,
 	}
 
-	data.Rows = make([]zpages.SummaryPipelinesTableRowData, 0, len(srv.builtPipelines))
-	for c, p := range srv.builtPipelines {
+	builtPipelines := srv.getBuiltPipelines()
+	data.Rows = make([]zpages.SummaryPipelinesTableRowData, 0, len(builtPipelines))
+	for c, p := range builtPipelines {
 		row := zpages.SummaryPipelinesTableRowData{
 			FullName:            c.Name,
 			InputType:           string(c.InputType),
@@ -102,6 +116,135 @@ func (srv *service) getPipelinesSummaryTableData() zpages.SummaryPipelinesTableD
 	return data
 }
 
+// dataTypeCounterKeys are the obsreport measure names of the counters displayed for every component kind.
+type dataTypeCounterKeys struct {
+	receiver  []string
+	processor []string
+	exporter  []string
+}
+
+var counterKeysByDataType = map[configmodels.DataType]dataTypeCounterKeys{
+	configmodels.TracesDataType: {
+		receiver:  []string{obsreport.AcceptedSpansKey, obsreport.RefusedSpansKey},
+		processor: []string{obsreport.AcceptedSpansKey, obsreport.RefusedSpansKey, obsreport.DroppedSpansKey},
+		exporter:  []string{obsreport.SentSpansKey, obsreport.FailedToSendSpansKey},
+	},
+	configmodels.MetricsDataType: {
+		receiver:  []string{obsreport.AcceptedMetricPointsKey, obsreport.RefusedMetricPointsKey},
+		processor: []string{obsreport.AcceptedMetricPointsKey, obsreport.RefusedMetricPointsKey, obsreport.DroppedMetricPointsKey},
+		exporter:  []string{obsreport.SentMetricPointsKey, obsreport.FailedToSendMetricPointsKey},
+	},
+	configmodels.LogsDataType: {
+		receiver:  []string{obsreport.AcceptedLogRecordsKey, obsreport.RefusedLogRecordsKey},
+		processor: []string{obsreport.AcceptedLogRecordsKey, obsreport.RefusedLogRecordsKey, obsreport.DroppedLogRecordsKey},
+		exporter:  []string{obsreport.SentLogRecordsKey, obsreport.FailedToSendLogRecordsKey},
+	},
+}
+
+// queueSizer is implemented by the exporters built with the exporterhelper.
+type queueSizer interface {
+	QueueSize() (size int, capacity int)
+}
+
+func (srv *service) getPipelinesGraphData() zpages.PipelinesGraphData {
+	counters := newCounterReader()
+	data := zpages.PipelinesGraphData{
+		ComponentEndpoint: pipelinezPath,
+		CountersAvailable: counters.available,
+	}
+
+	exporters := make(map[configmodels.DataType]map[string]component.Exporter)
+	for dataType, exps := range srv.GetExporters() {
+		exporters[dataType] = make(map[string]component.Exporter, len(exps))
+		for cfg, exp := range exps {
+			exporters[dataType][cfg.Name()] = exp
+		}
+	}
+
+	builtPipelines := srv.getBuiltPipelines()
+	data.Pipelines = make([]zpages.PipelineGraphData, 0, len(builtPipelines))
+	for c := range builtPipelines {
+		keys := counterKeysByDataType[c.InputType]
+		newComponent := func(name, kind string, counterKeys []string) zpages.ComponentGraphData {
+			return zpages.ComponentGraphData{
+				ComponentEndpoint: pipelinezPath,
+				PipelineName:      c.Name,
+				Name:              name,
+				Kind:              kind,
+				Counters:          counters.read(kind, name, counterKeys),
+			}
+		}
+
+		pipeline := zpages.PipelineGraphData{FullName: c.Name, InputType: string(c.InputType)}
+		for _, name := range c.Receivers {
+			pipeline.Receivers = append(pipeline.Receivers, newComponent(name, obsreport.ReceiverKey, keys.receiver))
+		}
+		for _, name := range c.Processors {
+			pipeline.Processors = append(pipeline.Processors, newComponent(name, obsreport.ProcessorKey, keys.processor))
+		}
+		for _, name := range c.Exporters {
+			exp := newComponent(name, obsreport.ExporterKey, keys.exporter)
+			if qs, ok := exporters[c.InputType][name].(queueSizer); ok {
+				if size, capacity := qs.QueueSize(); capacity > 0 {
+					exp.Counters = append(exp.Counters, [2]string{"queue_size", strconv.Itoa(size) + "/" + strconv.Itoa(capacity)})
+				}
+			}
+			pipeline.Exporters = append(pipeline.Exporters, exp)
+		}
+		data.Pipelines = append(data.Pipelines, pipeline)
+	}
+
+	sort.Slice(data.Pipelines, func(i, j int) bool {
+		return data.Pipelines[i].FullName < data.Pipelines[j].FullName
+	})
+	return data
+}
+
+// counterReader reads the obsreport counters aggregated by component name.
+type counterReader struct {
+	available bool
+	// sums by view name then component name.
+	sums map[string]map[string]int64
+}
+
+func newCounterReader() *counterReader {
+	cr := &counterReader{sums: map[string]map[string]int64{}}
+	for _, v := range obsreport.AllViews() {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			// The view is not registered when the telemetry level is none.
+			continue
+		}
+		cr.available = true
+		sums := map[string]int64{}
+		for _, row := range rows {
+			sumData, ok := row.Data.(*view.SumData)
+			if !ok {
+				continue
+			}
+			for _, t := range row.Tags {
+				if t.Key.Name() == obsreport.ReceiverKey || t.Key.Name() == obsreport.ProcessorKey || t.Key.Name() == obsreport.ExporterKey {
+					sums[t.Value] += int64(sumData.Value)
+				}
+			}
+		}
+		cr.sums[v.Name] = sums
+	}
+	return cr
+}
+
+func (cr *counterReader) read(kind, name string, keys []string) [][2]string {
+	if !cr.available {
+		return nil
+	}
+	counters := make([][2]string, 0, len(keys))
+	for _, key := range keys {
+		value := cr.sums[kind+"/"+key][name]
+		counters = append(counters, [2]string{key, strconv.FormatInt(value, 10)})
+	}
+	return counters
+}
+
 func handleExtensionzRequest(host component.Host, w http.ResponseWriter, r *http.Request) {
 	r.ParseForm()
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -134,3 +277,30 @@ func getExtensionsSummaryTableData(host component.Host) zpages.SummaryExtensions
 	})
 	return data
 }
+
+func (srv *service) handleStatuszRequest(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLHeader(w, zpages.HeaderData{Title: "Component Status"})
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Last reported status", Properties: srv.getStatuszProperties()})
+	zpages.WriteHTMLFooter(w)
+}
+
+// getStatuszProperties returns the last status reported by each component, sorted by component.
+func (srv *service) getStatuszProperties() [][2]string {
+	var properties [][2]string
+	for source, event := range srv.getComponentStatuses() {
+		name := source.Kind.String() + "/" + source.Name
+		if source.Pipeline != "" {
+			name = "pipeline/" + source.Pipeline + "/" + name
+		}
+		status := event.Status.String()
+		if event.Err != nil {
+			status += ": " + event.Err.Error()
+		}
+		status += " (" + event.Timestamp.UTC().Format(time.RFC3339) + ")"
+		properties = append(properties, [2]string{name, status})
+	}
+	sort.Slice(properties, func(i, j int) bool { return properties[i][0] < properties[j][0] })
+	return properties
+}
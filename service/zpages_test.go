@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+func TestService_PipelinesGraphData(t *testing.T) {
+	srv := createExampleService(t)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	data := srv.getPipelinesGraphData()
+	assert.False(t, data.CountersAvailable)
+	require.Len(t, data.Pipelines, 3)
+	assert.Equal(t, "logs", data.Pipelines[0].FullName)
+	assert.Nil(t, data.Pipelines[0].Receivers[0].Counters)
+
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	ctx := obsreport.ReceiverContext(context.Background(), "nop", "grpc")
+	ctx = obsreport.StartTraceDataReceiveOp(ctx, "nop", "grpc")
+	obsreport.EndTraceDataReceiveOp(ctx, "proto", 7, nil)
+
+	data = srv.getPipelinesGraphData()
+	assert.True(t, data.CountersAvailable)
+	traces := data.Pipelines[2]
+	assert.Equal(t, "traces", traces.FullName)
+	assert.Equal(t, []zpages.ComponentGraphData{{
+		ComponentEndpoint: pipelinezPath,
+		PipelineName:      "traces",
+		Name:              "nop",
+		Kind:              obsreport.ReceiverKey,
+		Counters:          [][2]string{{obsreport.AcceptedSpansKey, "7"}, {obsreport.RefusedSpansKey, "0"}},
+	}}, traces.Receivers)
+	require.Len(t, traces.Processors, 1)
+	assert.Len(t, traces.Processors[0].Counters, 3)
+	require.Len(t, traces.Exporters, 1)
+	assert.Equal(t, [][2]string{{obsreport.SentSpansKey, "0"}, {obsreport.FailedToSendSpansKey, "0"}}, traces.Exporters[0].Counters)
+}
+
+func TestService_HandlePipelinezRequest(t *testing.T) {
+	srv := createExampleService(t)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	mux := http.NewServeMux()
+	srv.RegisterZPages(mux, "/debug")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/debug/pipelinez", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Data Flow")
+	assert.Contains(t, rr.Body.String(), "zpipelinename=traces&zcomponentname=nop&zcomponentkind=exporter")
+}
+
+func TestService_HandleStatuszRequest(t *testing.T) {
+	srv := createExampleService(t)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	timestamp := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	srv.ReportComponentStatusFrom(
+		component.StatusSource{Kind: component.KindExporter, Name: "nop"},
+		&component.StatusEvent{Status: component.StatusRecoverableError, Err: errors.New("unavailable"), Timestamp: timestamp})
+	srv.ReportComponentStatusFrom(
+		component.StatusSource{Kind: component.KindProcessor, Name: "nop", Pipeline: "traces"},
+		&component.StatusEvent{Status: component.StatusOK, Timestamp: timestamp})
+	assert.Equal(t, [][2]string{
+		{"exporter/nop", "RecoverableError: unavailable (2021-03-01T10:00:00Z)"},
+		{"pipeline/traces/processor/nop", "OK (2021-03-01T10:00:00Z)"},
+	}, srv.getStatuszProperties())
+
+	mux := http.NewServeMux()
+	srv.RegisterZPages(mux, "/debug")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/debug/statusz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "RecoverableError: unavailable")
+}
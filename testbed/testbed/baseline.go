@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbed
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The environment variables configuring the resource usage baseline of the performance tests.
+const (
+	// baselineFileEnvVarName is the path of the JSON baseline file. If unset the baseline is disabled.
+	baselineFileEnvVarName = "TESTBED_BASELINE_FILE"
+	// baselineModeEnvVarName is either "record", to write the results of the run to the baseline file,
+	// or "compare", the default, to fail the tests regressing compared to the baseline file.
+	baselineModeEnvVarName = "TESTBED_BASELINE_MODE"
+	// baselineToleranceEnvVarName is the regression allowed in percent, 10 by default.
+	baselineToleranceEnvVarName = "TESTBED_BASELINE_TOLERANCE"
+)
+
+const (
+	baselineModeRecord  = "record"
+	baselineModeCompare = "compare"
+
+	defaultBaselineTolerance = 10
+)
+
+// BaselineResult is the resource usage and throughput of a performance test recorded in the baseline file.
+type BaselineResult struct {
+	CPUPercentAvg  float64 `json:"cpu_percent_avg"`
+	CPUPercentMax  float64 `json:"cpu_percent_max"`
+	RAMMiBAvg      uint32  `json:"ram_mib_avg"`
+	RAMMiBMax      uint32  `json:"ram_mib_max"`
+	ItemsPerSecond float64 `json:"items_per_second"`
+}
+
+// baseline records or compares the results of the performance tests to a baseline file.
+type baseline struct {
+	fileName  string
+	mode      string
+	tolerance float64
+
+	mu      sync.Mutex
+	results map[string]BaselineResult
+}
+
+// activeBaseline is the baseline configured by the environment variables, nil if disabled.
+var activeBaseline *baseline
+
+// newBaselineFromEnv returns the baseline configured by the environment variables, nil if disabled. In
+// compare mode the baseline file is loaded.
+func newBaselineFromEnv() (*baseline, error) {
+	fileName := os.Getenv(baselineFileEnvVarName)
+	if fileName == "" {
+		return nil, nil
+	}
+	b := &baseline{
+		fileName:  fileName,
+		mode:      baselineModeCompare,
+		tolerance: defaultBaselineTolerance,
+		results:   map[string]BaselineResult{},
+	}
+	if mode := os.Getenv(baselineModeEnvVarName); mode != "" {
+		if mode != baselineModeRecord && mode != baselineModeCompare {
+			return nil, fmt.Errorf("invalid %s %q, expecting %q or %q",
+				baselineModeEnvVarName, mode, baselineModeRecord, baselineModeCompare)
+		}
+		b.mode = mode
+	}
+	if tolerance := os.Getenv(baselineToleranceEnvVarName); tolerance != "" {
+		var err error
+		if b.tolerance, err = strconv.ParseFloat(tolerance, 64); err != nil || b.tolerance < 0 {
+			return nil, fmt.Errorf("invalid %s %q, expecting a positive percentage", baselineToleranceEnvVarName, tolerance)
+		}
+	}
+	if b.mode == baselineModeCompare {
+		content, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the baseline: %w", err)
+		}
+		if err = json.Unmarshal(content, &b.results); err != nil {
+			return nil, fmt.Errorf("cannot parse the baseline %s: %w", fileName, err)
+		}
+	}
+	return b, nil
+}
+
+// check records the result of the test in record mode, in compare mode it returns a description of the
+// regressions of the result compared to the baseline, empty if there is none or the test has no baseline.
+func (b *baseline) check(testName string, result BaselineResult) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mode == baselineModeRecord {
+		b.results[testName] = result
+		return ""
+	}
+	expected, ok := b.results[testName]
+	if !ok {
+		return ""
+	}
+
+	var regressions []string
+	limit := 1 + b.tolerance/100
+	if expected.CPUPercentAvg > 0 && result.CPUPercentAvg > expected.CPUPercentAvg*limit {
+		regressions = append(regressions, fmt.Sprintf("CPU avg %.1f%% exceeds baseline %.1f%%",
+			result.CPUPercentAvg, expected.CPUPercentAvg))
+	}
+	if expected.CPUPercentMax > 0 && result.CPUPercentMax > expected.CPUPercentMax*limit {
+		regressions = append(regressions, fmt.Sprintf("CPU max %.1f%% exceeds baseline %.1f%%",
+			result.CPUPercentMax, expected.CPUPercentMax))
+	}
+	if expected.RAMMiBAvg > 0 && float64(result.RAMMiBAvg) > float64(expected.RAMMiBAvg)*limit {
+		regressions = append(regressions, fmt.Sprintf("RAM avg %d MiB exceeds baseline %d MiB",
+			result.RAMMiBAvg, expected.RAMMiBAvg))
+	}
+	if expected.RAMMiBMax > 0 && float64(result.RAMMiBMax) > float64(expected.RAMMiBMax)*limit {
+		regressions = append(regressions, fmt.Sprintf("RAM max %d MiB exceeds baseline %d MiB",
+			result.RAMMiBMax, expected.RAMMiBMax))
+	}
+	if expected.ItemsPerSecond > 0 && result.ItemsPerSecond*limit < expected.ItemsPerSecond {
+		regressions = append(regressions, fmt.Sprintf("throughput %.0f items/sec is below baseline %.0f items/sec",
+			result.ItemsPerSecond, expected.ItemsPerSecond))
+	}
+	if len(regressions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s regressed by more than %g%%: %s", testName, b.tolerance, strings.Join(regressions, ", "))
+}
+
+// save writes the recorded results to the baseline file in record mode.
+func (b *baseline) save() error {
+	if b.mode != baselineModeRecord {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// The tests are sorted by name, so that the changes of the file are easy to review.
+	content, err := json.MarshalIndent(b.results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(b.fileName, append(content, '\n'), 0600)
+}
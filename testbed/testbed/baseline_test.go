@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbed
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setBaselineEnv(t *testing.T, fileName string, mode string, tolerance string) {
+	for name, value := range map[string]string{
+		baselineFileEnvVarName:      fileName,
+		baselineModeEnvVarName:      mode,
+		baselineToleranceEnvVarName: tolerance,
+	} {
+		require.NoError(t, os.Setenv(name, value))
+	}
+	t.Cleanup(func() {
+		os.Unsetenv(baselineFileEnvVarName)
+		os.Unsetenv(baselineModeEnvVarName)
+		os.Unsetenv(baselineToleranceEnvVarName)
+	})
+}
+
+func TestBaselineDisabled(t *testing.T) {
+	b, err := newBaselineFromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, b)
+}
+
+func TestBaselineRecordAndCompare(t *testing.T) {
+	dir, err := ioutil.TempDir("", "baseline")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "baseline.json")
+
+	setBaselineEnv(t, fileName, "record", "")
+	b, err := newBaselineFromEnv()
+	require.NoError(t, err)
+	expected := BaselineResult{
+		CPUPercentAvg:  20,
+		CPUPercentMax:  30,
+		RAMMiBAvg:      50,
+		RAMMiBMax:      80,
+		ItemsPerSecond: 10000,
+	}
+	assert.Empty(t, b.check("Trace10kSPS/OTLP", expected))
+	require.NoError(t, b.save())
+
+	setBaselineEnv(t, fileName, "compare", "20")
+	b, err = newBaselineFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]BaselineResult{"Trace10kSPS/OTLP": expected}, b.results)
+
+	// The usage within the tolerance and the tests without baseline do not regress.
+	assert.Empty(t, b.check("Trace10kSPS/OTLP", BaselineResult{
+		CPUPercentAvg:  23,
+		CPUPercentMax:  35,
+		RAMMiBAvg:      60,
+		RAMMiBMax:      90,
+		ItemsPerSecond: 9000,
+	}))
+	assert.Empty(t, b.check("Trace10kSPS/Jaeger", BaselineResult{CPUPercentAvg: 100}))
+
+	assert.Equal(t, "Trace10kSPS/OTLP regressed by more than 20%: CPU avg 25.0% exceeds baseline 20.0%, "+
+		"RAM max 97 MiB exceeds baseline 80 MiB, throughput 8000 items/sec is below baseline 10000 items/sec",
+		b.check("Trace10kSPS/OTLP", BaselineResult{
+			CPUPercentAvg:  25,
+			CPUPercentMax:  30,
+			RAMMiBAvg:      50,
+			RAMMiBMax:      97,
+			ItemsPerSecond: 8000,
+		}))
+}
+
+func TestBaselineInvalidEnv(t *testing.T) {
+	setBaselineEnv(t, "baseline.json", "update", "")
+	_, err := newBaselineFromEnv()
+	assert.Error(t, err)
+
+	setBaselineEnv(t, "baseline.json", "record", "-5")
+	_, err = newBaselineFromEnv()
+	assert.Error(t, err)
+
+	setBaselineEnv(t, filepath.Join("testdata", "missing.json"), "compare", "")
+	_, err = newBaselineFromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbed
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The errors returned by the MockBackend for the rejected data. They are UNAVAILABLE gRPC statuses so that
+// the exporters retry sending the data.
+var (
+	errChaosDropped = status.Error(codes.Unavailable, "mock backend dropped the data")
+	errChaosOutage  = status.Error(codes.Unavailable, "mock backend is in an outage")
+)
+
+// ChaosSpec defines the network conditions and failures simulated by the MockBackend, so that the retry and
+// queuing of the exporters can be validated. The zero value simulates nothing.
+type ChaosSpec struct {
+	// Latency is added before handling each received batch.
+	Latency time.Duration
+
+	// Jitter is the maximum random delay added to Latency.
+	Jitter time.Duration
+
+	// DropRate is the fraction, between 0 and 1, of the received batches rejected with a retryable error.
+	DropRate float64
+
+	// OutageInterval is the time the backend is up between two outages. If 0 there are no outages.
+	OutageInterval time.Duration
+
+	// OutageDuration is the time of each outage, during which all the received batches are rejected
+	// with a retryable error.
+	OutageDuration time.Duration
+}
+
+// isSpecified returns true if any part of ChaosSpec is specified, i.e. has non-zero value.
+func (cs *ChaosSpec) isSpecified() bool {
+	return cs.Latency != 0 || cs.Jitter != 0 || cs.DropRate != 0 || (cs.OutageInterval != 0 && cs.OutageDuration != 0)
+}
+
+// chaos applies a ChaosSpec to the batches received by the MockBackend.
+type chaos struct {
+	spec ChaosSpec
+
+	mu        sync.Mutex
+	rand      *rand.Rand
+	startedAt time.Time
+}
+
+func newChaos(spec ChaosSpec, seed int64) *chaos {
+	return &chaos{
+		spec: spec,
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// start sets the time from which the outages are scheduled.
+func (c *chaos) start(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.startedAt = now
+}
+
+// apply delays the handling of a received batch and returns an error if the batch must be rejected.
+func (c *chaos) apply() error {
+	if c.inOutage(time.Now()) {
+		return errChaosOutage
+	}
+
+	c.mu.Lock()
+	delay := c.spec.Latency
+	if c.spec.Jitter > 0 {
+		delay += time.Duration(c.rand.Int63n(int64(c.spec.Jitter)))
+	}
+	dropped := c.spec.DropRate > 0 && c.rand.Float64() < c.spec.DropRate
+	c.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	if dropped {
+		return errChaosDropped
+	}
+	return nil
+}
+
+// inOutage returns true if now is in one of the outages, which start every OutageInterval of uptime.
+func (c *chaos) inOutage(now time.Time) bool {
+	if c.spec.OutageInterval <= 0 || c.spec.OutageDuration <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	elapsed := now.Sub(c.startedAt)
+	c.mu.Unlock()
+	return elapsed%(c.spec.OutageInterval+c.spec.OutageDuration) >= c.spec.OutageInterval
+}
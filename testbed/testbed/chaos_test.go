@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosDisabled(t *testing.T) {
+	mb := NewMockBackend("mockbackend.log", nil)
+	mb.SetChaos(ChaosSpec{OutageInterval: time.Second})
+	assert.Nil(t, mb.chaos)
+	assert.NoError(t, mb.applyChaos(10))
+}
+
+func TestChaosLatency(t *testing.T) {
+	c := newChaos(ChaosSpec{Latency: 20 * time.Millisecond, Jitter: 10 * time.Millisecond}, 1)
+	c.start(time.Now())
+	start := time.Now()
+	require.NoError(t, c.apply())
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(20*time.Millisecond))
+}
+
+func TestChaosDropRate(t *testing.T) {
+	c := newChaos(ChaosSpec{DropRate: 0.25}, 1)
+	c.start(time.Now())
+	dropped := 0
+	for i := 0; i < 1000; i++ {
+		if err := c.apply(); err != nil {
+			assert.Equal(t, errChaosDropped, err)
+			dropped++
+		}
+	}
+	assert.InDelta(t, 250, dropped, 50)
+}
+
+func TestChaosOutages(t *testing.T) {
+	c := newChaos(ChaosSpec{OutageInterval: 10 * time.Second, OutageDuration: 5 * time.Second}, 1)
+	startedAt := time.Now()
+	c.start(startedAt)
+	assert.False(t, c.inOutage(startedAt))
+	assert.False(t, c.inOutage(startedAt.Add(9*time.Second)))
+	assert.True(t, c.inOutage(startedAt.Add(10*time.Second)))
+	assert.True(t, c.inOutage(startedAt.Add(14*time.Second)))
+	assert.False(t, c.inOutage(startedAt.Add(15*time.Second)))
+	assert.True(t, c.inOutage(startedAt.Add(26*time.Second)))
+
+	// All the data is rejected during the outages.
+	c.start(startedAt.Add(-12 * time.Second))
+	assert.Equal(t, errChaosOutage, c.apply())
+}
+
+func TestMockBackendChaos(t *testing.T) {
+	port := GetAvailablePort(t)
+	mb := NewMockBackend("mockbackend.log", NewOTLPDataReceiver(port))
+	mb.SetChaos(ChaosSpec{DropRate: 1})
+	require.NoError(t, mb.Start(), "Cannot start backend")
+	defer mb.Stop()
+
+	options := LoadOptions{DataItemsPerSecond: 1000, ItemsPerBatch: 10}
+	lg, err := NewLoadGenerator(NewPerfTestDataProvider(options), NewOTLPTraceDataSender(DefaultHost, port))
+	require.NoError(t, err, "Cannot start load generator")
+	lg.Start(options)
+	WaitFor(t, func() bool { return mb.DataItemsRejected() > 50 }, "DataItemsRejected > 50")
+	lg.Stop()
+
+	// The sender does not retry, all the data is lost.
+	assert.EqualValues(t, 0, mb.DataItemsReceived())
+	assert.Equal(t, lg.DataItemsSent(), mb.DataItemsRejected())
+}
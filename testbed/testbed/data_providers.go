@@ -32,6 +32,7 @@ import (
 	otlplogscol "go.opentelemetry.io/collector/internal/data/protogen/collector/logs/v1"
 	otlpmetricscol "go.opentelemetry.io/collector/internal/data/protogen/collector/metrics/v1"
 	otlptracecol "go.opentelemetry.io/collector/internal/data/protogen/collector/trace/v1"
+	otlplogs "go.opentelemetry.io/collector/internal/data/protogen/logs/v1"
 	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"
 	"go.opentelemetry.io/collector/internal/goldendataset"
 )
@@ -49,10 +50,13 @@ type DataProvider interface {
 	GetGeneratedSpan(traceID pdata.TraceID, spanID pdata.SpanID) *otlptrace.Span
 	// GenerateLogs returns the internal pdata.Logs format
 	GenerateLogs() (pdata.Logs, bool)
+	// GetGeneratedLog returns the generated LogRecord matching the provided name or else nil if no match found.
+	GetGeneratedLog(name string) *otlplogs.LogRecord
 }
 
 // PerfTestDataProvider in an implementation of the DataProvider for use in performance tests.
-// Tracing IDs are based on the incremented batch and data items counters.
+// Tracing IDs are based on the incremented batch and data items counters. The generation time
+// is added to the data so that the MockBackend can measure the end-to-end latency.
 type PerfTestDataProvider struct {
 	options            LoadOptions
 	batchesGenerated   *atomic.Uint64
@@ -82,6 +86,7 @@ func (dp *PerfTestDataProvider) GenerateTraces() (pdata.Traces, bool) {
 	spans.Resize(dp.options.ItemsPerBatch)
 
 	traceID := dp.batchesGenerated.Inc()
+	sendTimestamp := time.Now().UnixNano()
 	for i := 0; i < dp.options.ItemsPerBatch; i++ {
 
 		startTime := time.Now()
@@ -99,6 +104,7 @@ func (dp *PerfTestDataProvider) GenerateTraces() (pdata.Traces, bool) {
 		attrs := span.Attributes()
 		attrs.UpsertInt("load_generator.span_seq_num", int64(spanID))
 		attrs.UpsertInt("load_generator.trace_seq_num", int64(traceID))
+		attrs.UpsertInt(sendTimestampAttr, sendTimestamp)
 		// Additional attributes.
 		for k, v := range dp.options.Attributes {
 			attrs.UpsertString(k, v)
@@ -129,13 +135,13 @@ func (dp *PerfTestDataProvider) GenerateMetrics() (pdata.Metrics, bool) {
 	md := pdata.NewMetrics()
 	md.ResourceMetrics().Resize(1)
 	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
-	if dp.options.Attributes != nil {
-		attrs := md.ResourceMetrics().At(0).Resource().Attributes()
-		attrs.InitEmptyWithCapacity(len(dp.options.Attributes))
-		for k, v := range dp.options.Attributes {
-			attrs.UpsertString(k, v)
-		}
+	attrs := md.ResourceMetrics().At(0).Resource().Attributes()
+	attrs.InitEmptyWithCapacity(len(dp.options.Attributes) + 1)
+	for k, v := range dp.options.Attributes {
+		attrs.UpsertString(k, v)
 	}
+	// The data points are not tagged with the send timestamp to avoid creating a new time series for each batch.
+	attrs.UpsertInt(sendTimestampAttr, time.Now().UnixNano())
 	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
 	metrics.Resize(dp.options.ItemsPerBatch)
 
@@ -170,6 +176,11 @@ func (dp *PerfTestDataProvider) GetGeneratedSpan(pdata.TraceID, pdata.SpanID) *o
 	return nil
 }
 
+func (dp *PerfTestDataProvider) GetGeneratedLog(string) *otlplogs.LogRecord {
+	// function not supported for this data provider
+	return nil
+}
+
 func (dp *PerfTestDataProvider) GenerateLogs() (pdata.Logs, bool) {
 	logs := pdata.NewLogs()
 	logs.ResourceLogs().Resize(1)
@@ -184,7 +195,8 @@ func (dp *PerfTestDataProvider) GenerateLogs() (pdata.Logs, bool) {
 	logRecords := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
 	logRecords.Resize(dp.options.ItemsPerBatch)
 
-	now := pdata.TimestampFromTime(time.Now())
+	now := time.Now()
+	timestamp := pdata.TimestampFromTime(now)
 
 	batchIndex := dp.batchesGenerated.Inc()
 
@@ -196,7 +208,7 @@ func (dp *PerfTestDataProvider) GenerateLogs() (pdata.Logs, bool) {
 		record.SetName("load_generator_" + strconv.Itoa(i))
 		record.Body().SetStringVal("Load Generator Counter #" + strconv.Itoa(i))
 		record.SetFlags(uint32(2))
-		record.SetTimestamp(now)
+		record.SetTimestamp(timestamp)
 
 		attrs := record.Attributes()
 		attrs.UpsertString("batch_index", "batch_"+strconv.Itoa(int(batchIndex)))
@@ -205,6 +217,7 @@ func (dp *PerfTestDataProvider) GenerateLogs() (pdata.Logs, bool) {
 		attrs.UpsertDouble("b", 5.0)
 		attrs.UpsertInt("c", 3)
 		attrs.UpsertBool("d", true)
+		attrs.UpsertInt(sendTimestampAttr, now.UnixNano())
 	}
 	return logs, false
 }
@@ -224,15 +237,22 @@ type GoldenDataProvider struct {
 	metricPairsFile  string
 	metricsGenerated []pdata.Metrics
 	metricsIndex     int
+
+	logPairsFile  string
+	logsGenerated []pdata.Logs
+	logsIndex     int
+	logsMap       map[string]*otlplogs.LogRecord
 }
 
 // NewGoldenDataProvider creates a new instance of GoldenDataProvider which generates test data based
-// on the pairwise combinations specified in the tracePairsFile and spanPairsFile input variables.
-func NewGoldenDataProvider(tracePairsFile string, spanPairsFile string, metricPairsFile string) *GoldenDataProvider {
+// on the pairwise combinations specified in the tracePairsFile, spanPairsFile, metricPairsFile and
+// logPairsFile input variables.
+func NewGoldenDataProvider(tracePairsFile string, spanPairsFile string, metricPairsFile string, logPairsFile string) *GoldenDataProvider {
 	return &GoldenDataProvider{
 		tracePairsFile:  tracePairsFile,
 		spanPairsFile:   spanPairsFile,
 		metricPairsFile: metricPairsFile,
+		logPairsFile:    logPairsFile,
 	}
 }
 
@@ -280,7 +300,37 @@ func (dp *GoldenDataProvider) GenerateMetrics() (pdata.Metrics, bool) {
 }
 
 func (dp *GoldenDataProvider) GenerateLogs() (pdata.Logs, bool) {
-	return pdata.NewLogs(), true
+	if dp.logsGenerated == nil {
+		var err error
+		dp.logsGenerated, err = goldendataset.GenerateLogs(dp.logPairsFile)
+		if err != nil {
+			log.Printf("cannot generate logs: %s", err)
+		}
+	}
+	dp.batchesGenerated.Inc()
+	if dp.logsIndex >= len(dp.logsGenerated) {
+		return pdata.NewLogs(), true
+	}
+	ld := dp.logsGenerated[dp.logsIndex]
+	dp.logsIndex++
+	dp.dataItemsGenerated.Add(uint64(ld.LogRecordCount()))
+	return ld, false
+}
+
+func (dp *GoldenDataProvider) GetGeneratedLog(name string) *otlplogs.LogRecord {
+	if dp.logsMap == nil {
+		dp.logsMap = make(map[string]*otlplogs.LogRecord)
+		for _, ld := range dp.logsGenerated {
+			for _, rl := range internal.LogsToOtlp(ld.InternalRep()).ResourceLogs {
+				for _, ill := range rl.InstrumentationLibraryLogs {
+					for _, lr := range ill.Logs {
+						dp.logsMap[lr.Name] = lr
+					}
+				}
+			}
+		}
+	}
+	return dp.logsMap[name]
 }
 
 func (dp *GoldenDataProvider) GetGeneratedSpan(traceID pdata.TraceID, spanID pdata.SpanID) *otlptrace.Span {
@@ -401,6 +451,11 @@ func (dp *FileDataProvider) GetGeneratedSpan(pdata.TraceID, pdata.SpanID) *otlpt
 	return nil
 }
 
+func (dp *FileDataProvider) GetGeneratedLog(string) *otlplogs.LogRecord {
+	// Nothing to do. This function is only used by data providers used in correctness tests for logs.
+	return nil
+}
+
 func (dp *FileDataProvider) GenerateLogs() (pdata.Logs, bool) {
 	// TODO: implement similar to GenerateMetrics.
 	return pdata.NewLogs(), true
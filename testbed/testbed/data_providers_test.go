@@ -17,6 +17,7 @@ package testbed
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 
@@ -26,7 +27,7 @@ import (
 const metricsPictPairsFile = "../../internal/goldendataset/testdata/generated_pict_pairs_metrics.txt"
 
 func TestGoldenDataProvider(t *testing.T) {
-	dp := NewGoldenDataProvider("", "", metricsPictPairsFile)
+	dp := NewGoldenDataProvider("", "", metricsPictPairsFile, "")
 	dp.SetLoadGeneratorCounters(atomic.NewUint64(0), atomic.NewUint64(0))
 	var ms []pdata.Metrics
 	for {
@@ -38,3 +39,25 @@ func TestGoldenDataProvider(t *testing.T) {
 	}
 	require.Equal(t, len(dp.metricsGenerated), len(ms))
 }
+
+const logsPictPairsFile = "../../internal/goldendataset/testdata/generated_pict_pairs_logs.txt"
+
+func TestGoldenDataProviderLogs(t *testing.T) {
+	dp := NewGoldenDataProvider("", "", "", logsPictPairsFile)
+	dp.SetLoadGeneratorCounters(atomic.NewUint64(0), atomic.NewUint64(0))
+	var lds []pdata.Logs
+	for {
+		ld, done := dp.GenerateLogs()
+		if done {
+			break
+		}
+		lds = append(lds, ld)
+	}
+	require.Equal(t, len(dp.logsGenerated), len(lds))
+	require.Equal(t, uint64(len(lds)), dp.dataItemsGenerated.Load())
+
+	lr := dp.GetGeneratedLog("pict_1")
+	require.NotNil(t, lr)
+	assert.Equal(t, "pict_1", lr.Name)
+	assert.Nil(t, dp.GetGeneratedLog("unknown"))
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbed
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// sendTimestampAttr is the attribute set by PerfTestDataProvider to the time, in nanoseconds since the Unix epoch,
+// at which the data was generated and sent. It is set on the spans, the log records and the metric resources.
+const sendTimestampAttr = "load_generator.send_timestamp"
+
+// LatencySpec defines the expected end-to-end latency of the data items, from the LoadGenerator to the MockBackend.
+// The test fails if a percentile of the latencies measured by the MockBackend exceeds the limit, the zero-value
+// limits are not checked.
+type LatencySpec struct {
+	ExpectedMaxP50 time.Duration
+	ExpectedMaxP95 time.Duration
+	ExpectedMaxP99 time.Duration
+}
+
+// isSpecified returns true if any part of LatencySpec is specified, i.e. has non-zero value.
+func (ls *LatencySpec) isSpecified() bool {
+	return ls.ExpectedMaxP50 != 0 || ls.ExpectedMaxP95 != 0 || ls.ExpectedMaxP99 != 0
+}
+
+// LatencyStats reports the percentiles of the end-to-end latencies measured by the MockBackend.
+type LatencyStats struct {
+	// Count is the number of data items for which a latency was measured.
+	Count int
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+func (ls LatencyStats) String() string {
+	return fmt.Sprintf("Latency p50:%v p95:%v p99:%v max:%v",
+		ls.P50.Round(time.Microsecond), ls.P95.Round(time.Microsecond),
+		ls.P99.Round(time.Microsecond), ls.Max.Round(time.Microsecond))
+}
+
+// latencyRecorder collects the end-to-end latencies of the data items received by the MockBackend.
+type latencyRecorder struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+}
+
+// record adds the latency of count data items sent with the sendTimestampAttr found in attrs. The attribute is
+// ignored if it is missing, some receivers and exporters convert the attributes to strings so both are accepted.
+func (lr *latencyRecorder) record(attrs pdata.AttributeMap, count int, receivedAt time.Time) {
+	attr, ok := attrs.Get(sendTimestampAttr)
+	if !ok {
+		return
+	}
+	var sentAt int64
+	switch attr.Type() {
+	case pdata.AttributeValueINT:
+		sentAt = attr.IntVal()
+	case pdata.AttributeValueSTRING:
+		var err error
+		if sentAt, err = strconv.ParseInt(attr.StringVal(), 10, 64); err != nil {
+			return
+		}
+	default:
+		return
+	}
+	latency := receivedAt.Sub(time.Unix(0, sentAt))
+
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	for i := 0; i < count; i++ {
+		lr.latencies = append(lr.latencies, latency)
+	}
+}
+
+// stats returns the percentiles of the recorded latencies.
+func (lr *latencyRecorder) stats() LatencyStats {
+	lr.mu.Lock()
+	latencies := make([]time.Duration, len(lr.latencies))
+	copy(latencies, lr.latencies)
+	lr.mu.Unlock()
+
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p int) time.Duration {
+		// Nearest-rank percentile.
+		rank := (p*len(latencies) + 99) / 100
+		return latencies[rank-1]
+	}
+	return LatencyStats{
+		Count: len(latencies),
+		P50:   percentile(50),
+		P95:   percentile(95),
+		P99:   percentile(99),
+		Max:   latencies[len(latencies)-1],
+	}
+}
+
+// resourceMetricsDataPointCount returns the number of data points of rm.
+func resourceMetricsDataPointCount(rm pdata.ResourceMetrics) int {
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().Append(rm)
+	_, dataPoints := md.MetricAndDataPointCount()
+	return dataPoints
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbed
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestLatencyRecorder(t *testing.T) {
+	lr := &latencyRecorder{}
+	assert.Equal(t, LatencyStats{}, lr.stats())
+
+	receivedAt := time.Now()
+	attrs := pdata.NewAttributeMap()
+	// The data without the send timestamp is ignored.
+	lr.record(attrs, 10, receivedAt)
+	for i := 1; i <= 98; i++ {
+		attrs.UpsertInt(sendTimestampAttr, receivedAt.Add(-time.Duration(i)*time.Millisecond).UnixNano())
+		lr.record(attrs, 1, receivedAt)
+	}
+	// The send timestamp converted to a string by a receiver is accepted.
+	attrs.UpsertString(sendTimestampAttr, strconv.FormatInt(receivedAt.Add(-time.Second).UnixNano(), 10))
+	lr.record(attrs, 2, receivedAt)
+
+	assert.Equal(t, LatencyStats{
+		Count: 100,
+		P50:   50 * time.Millisecond,
+		P95:   95 * time.Millisecond,
+		P99:   time.Second,
+		Max:   time.Second,
+	}, lr.stats())
+}
+
+func TestPerfTestDataProviderSendTimestamp(t *testing.T) {
+	dp := NewPerfTestDataProvider(LoadOptions{ItemsPerBatch: 2})
+	dp.SetLoadGeneratorCounters(atomic.NewUint64(0), atomic.NewUint64(0))
+	mb := NewMockBackend("mockbackend.log", nil)
+
+	td, _ := dp.GenerateTraces()
+	assert.NoError(t, mb.tc.ConsumeTraces(context.Background(), td))
+	md, _ := dp.GenerateMetrics()
+	assert.NoError(t, mb.mc.ConsumeMetrics(context.Background(), md))
+	ld, _ := dp.GenerateLogs()
+	assert.NoError(t, mb.lc.ConsumeLogs(context.Background(), ld))
+
+	assert.EqualValues(t, mb.DataItemsReceived(), mb.LatencyStats().Count)
+}
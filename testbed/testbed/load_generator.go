@@ -65,8 +65,16 @@ type LoadOptions struct {
 
 	// Parallel specifies how many goroutines to send from.
 	Parallel int
+
+	// Profile varies the number of data items generated each second over time.
+	// DataItemsPerSecond is ignored if the profile is set.
+	Profile LoadProfile
 }
 
+// idleLoadInterval is the interval to check again the load to generate when the
+// LoadProfile does not generate any data item.
+const idleLoadInterval = 100 * time.Millisecond
+
 // NewLoadGenerator creates a load generator that sends data using specified sender.
 func NewLoadGenerator(dataProvider DataProvider, sender DataSender) (*LoadGenerator, error) {
 	if sender == nil {
@@ -91,7 +99,12 @@ func (lg *LoadGenerator) Start(options LoadOptions) {
 		lg.options.ItemsPerBatch = 10
 	}
 
-	log.Printf("Starting load generator at %d items/sec.", lg.options.DataItemsPerSecond)
+	if len(lg.options.Profile) > 0 {
+		log.Printf("Starting load generator with a load profile of %d segments over %v.",
+			len(lg.options.Profile), lg.options.Profile.Duration())
+	} else {
+		log.Printf("Starting load generator at %d items/sec.", lg.options.DataItemsPerSecond)
+	}
 
 	// Indicate that generation is in progress.
 	lg.stopWait.Add(1)
@@ -137,7 +150,7 @@ func (lg *LoadGenerator) generate() {
 	// Indicate that generation is done at the end
 	defer lg.stopWait.Done()
 
-	if lg.options.DataItemsPerSecond == 0 {
+	if lg.options.DataItemsPerSecond == 0 && len(lg.options.Profile) == 0 {
 		return
 	}
 
@@ -157,16 +170,31 @@ func (lg *LoadGenerator) generate() {
 
 	var workers sync.WaitGroup
 
+	startTime := time.Now()
 	for i := 0; i < numWorkers; i++ {
 		workers.Add(1)
 
 		go func() {
 			defer workers.Done()
-			t := time.NewTicker(time.Second / time.Duration(lg.options.DataItemsPerSecond/lg.options.ItemsPerBatch/numWorkers))
+			next := startTime.Add(lg.batchInterval(0, numWorkers))
+			t := time.NewTimer(time.Until(next))
 			defer t.Stop()
 			for {
 				select {
 				case <-t.C:
+					now := time.Now()
+					// Schedule the next batch from the expected time of this one so that the
+					// time spent sending does not lower the load, skipping the batches that
+					// could not be sent in time.
+					next = next.Add(lg.batchInterval(now.Sub(startTime), numWorkers))
+					if next.Before(now) {
+						next = now
+					}
+					t.Reset(next.Sub(now))
+
+					if lg.dataItemsPerSecond(now.Sub(startTime)) <= 0 {
+						continue
+					}
 					switch lg.sender.(type) {
 					case TraceDataSender:
 						lg.generateTrace()
@@ -190,6 +218,25 @@ func (lg *LoadGenerator) generate() {
 	lg.sender.Flush()
 }
 
+// dataItemsPerSecond returns the data items per second to generate at the given time
+// since the start of the load.
+func (lg *LoadGenerator) dataItemsPerSecond(elapsed time.Duration) float64 {
+	if len(lg.options.Profile) > 0 {
+		return lg.options.Profile.DataItemsPerSecond(elapsed)
+	}
+	return float64(lg.options.DataItemsPerSecond)
+}
+
+// batchInterval returns the interval between the batches sent by each of the numWorkers
+// workers to generate the load at the given time since the start of the load.
+func (lg *LoadGenerator) batchInterval(elapsed time.Duration, numWorkers int) time.Duration {
+	itemsPerSecond := lg.dataItemsPerSecond(elapsed)
+	if itemsPerSecond <= 0 {
+		return idleLoadInterval
+	}
+	return time.Duration(float64(time.Second) * float64(lg.options.ItemsPerBatch*numWorkers) / itemsPerSecond)
+}
+
 func (lg *LoadGenerator) generateTrace() {
 	traceSender := lg.sender.(TraceDataSender)
 
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbed
+
+import (
+	"math"
+	"time"
+)
+
+// LoadSegment is a part of a LoadProfile generating a load that varies during its Duration.
+// Segments are created with StepLoad, RampLoad, SineLoad and BurstLoad.
+type LoadSegment struct {
+	// Duration of the segment.
+	Duration time.Duration
+
+	// rate returns the data items per second to generate at the given time since the
+	// start of the segment.
+	rate func(elapsed time.Duration) float64
+}
+
+// LoadProfile is a sequence of segments varying the data items generated each second
+// over time. The profile is repeated when it is shorter than the test.
+type LoadProfile []LoadSegment
+
+// StepLoad creates a segment generating a constant load of itemsPerSecond. A sequence of
+// StepLoad segments creates a step load profile.
+func StepLoad(itemsPerSecond int, duration time.Duration) LoadSegment {
+	return LoadSegment{
+		Duration: duration,
+		rate: func(time.Duration) float64 {
+			return float64(itemsPerSecond)
+		},
+	}
+}
+
+// RampLoad creates a segment linearly increasing, or decreasing, the load from
+// fromItemsPerSecond to toItemsPerSecond over its duration.
+func RampLoad(fromItemsPerSecond, toItemsPerSecond int, duration time.Duration) LoadSegment {
+	return LoadSegment{
+		Duration: duration,
+		rate: func(elapsed time.Duration) float64 {
+			progress := float64(elapsed) / float64(duration)
+			return float64(fromItemsPerSecond) + progress*float64(toItemsPerSecond-fromItemsPerSecond)
+		},
+	}
+}
+
+// SineLoad creates a segment generating a sinusoidal load oscillating by amplitude
+// around baseItemsPerSecond, with the given period.
+func SineLoad(baseItemsPerSecond, amplitude int, period time.Duration, duration time.Duration) LoadSegment {
+	return LoadSegment{
+		Duration: duration,
+		rate: func(elapsed time.Duration) float64 {
+			angle := 2 * math.Pi * float64(elapsed) / float64(period)
+			return float64(baseItemsPerSecond) + float64(amplitude)*math.Sin(angle)
+		},
+	}
+}
+
+// BurstLoad creates a segment generating a load of baseItemsPerSecond with bursts of
+// burstItemsPerSecond, lasting burstDuration at the start of each interval.
+func BurstLoad(
+	baseItemsPerSecond int,
+	burstItemsPerSecond int,
+	interval time.Duration,
+	burstDuration time.Duration,
+	duration time.Duration,
+) LoadSegment {
+	return LoadSegment{
+		Duration: duration,
+		rate: func(elapsed time.Duration) float64 {
+			if elapsed%interval < burstDuration {
+				return float64(burstItemsPerSecond)
+			}
+			return float64(baseItemsPerSecond)
+		},
+	}
+}
+
+// Duration returns the total duration of the segments of the profile.
+func (lp LoadProfile) Duration() time.Duration {
+	var duration time.Duration
+	for _, segment := range lp {
+		duration += segment.Duration
+	}
+	return duration
+}
+
+// DataItemsPerSecond returns the data items per second to generate at the given time
+// since the start of the load.
+func (lp LoadProfile) DataItemsPerSecond(elapsed time.Duration) float64 {
+	duration := lp.Duration()
+	if duration <= 0 {
+		return 0
+	}
+	elapsed %= duration
+	for _, segment := range lp {
+		if elapsed < segment.Duration {
+			return math.Max(segment.rate(elapsed), 0)
+		}
+		elapsed -= segment.Duration
+	}
+	return 0
+}
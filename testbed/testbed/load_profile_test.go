@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testbed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadProfileSegments(t *testing.T) {
+	tests := []struct {
+		name     string
+		segment  LoadSegment
+		expected map[time.Duration]float64
+	}{
+		{
+			name:    "Step",
+			segment: StepLoad(1000, 10*time.Second),
+			expected: map[time.Duration]float64{
+				0:               1000,
+				9 * time.Second: 1000,
+			},
+		},
+		{
+			name:    "RampUp",
+			segment: RampLoad(1000, 5000, 10*time.Second),
+			expected: map[time.Duration]float64{
+				0:                       1000,
+				2500 * time.Millisecond: 2000,
+				5 * time.Second:         3000,
+			},
+		},
+		{
+			name:    "RampDown",
+			segment: RampLoad(5000, 1000, 10*time.Second),
+			expected: map[time.Duration]float64{
+				0:               5000,
+				5 * time.Second: 3000,
+			},
+		},
+		{
+			name:    "Sine",
+			segment: SineLoad(1000, 500, 4*time.Second, 10*time.Second),
+			expected: map[time.Duration]float64{
+				0:               1000,
+				1 * time.Second: 1500,
+				2 * time.Second: 1000,
+				3 * time.Second: 500,
+				5 * time.Second: 1500,
+			},
+		},
+		{
+			name:    "NegativeSine",
+			segment: SineLoad(1000, 2000, 4*time.Second, 10*time.Second),
+			expected: map[time.Duration]float64{
+				1 * time.Second: 3000,
+				3 * time.Second: 0,
+			},
+		},
+		{
+			name:    "Burst",
+			segment: BurstLoad(1000, 10000, 5*time.Second, time.Second, 10*time.Second),
+			expected: map[time.Duration]float64{
+				0:                      10000,
+				999 * time.Millisecond: 10000,
+				time.Second:            1000,
+				5 * time.Second:        10000,
+				7 * time.Second:        1000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := LoadProfile{test.segment}
+			for elapsed, expected := range test.expected {
+				assert.InDelta(t, expected, profile.DataItemsPerSecond(elapsed), 0.001, "at %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestLoadProfileSequence(t *testing.T) {
+	profile := LoadProfile{
+		RampLoad(0, 10000, 10*time.Second),
+		StepLoad(10000, 5*time.Second),
+		RampLoad(10000, 0, 10*time.Second),
+	}
+	assert.Equal(t, 25*time.Second, profile.Duration())
+	assert.InDelta(t, 5000, profile.DataItemsPerSecond(5*time.Second), 0.001)
+	assert.InDelta(t, 10000, profile.DataItemsPerSecond(12*time.Second), 0.001)
+	assert.InDelta(t, 8000, profile.DataItemsPerSecond(17*time.Second), 0.001)
+
+	// The profile is repeated after its duration.
+	assert.InDelta(t, 5000, profile.DataItemsPerSecond(30*time.Second), 0.001)
+
+	assert.Zero(t, LoadProfile{}.DataItemsPerSecond(time.Second))
+}
+
+func TestLoadGeneratorBatchInterval(t *testing.T) {
+	lg := &LoadGenerator{options: LoadOptions{DataItemsPerSecond: 10000, ItemsPerBatch: 10}}
+	assert.Equal(t, time.Millisecond, lg.batchInterval(0, 1))
+	assert.Equal(t, 4*time.Millisecond, lg.batchInterval(0, 4))
+
+	lg.options.Profile = LoadProfile{
+		StepLoad(0, time.Second),
+		StepLoad(1000, time.Second),
+	}
+	assert.Equal(t, idleLoadInterval, lg.batchInterval(0, 1))
+	assert.Equal(t, 10*time.Millisecond, lg.batchInterval(time.Second, 1))
+}
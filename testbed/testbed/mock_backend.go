@@ -51,6 +51,13 @@ type MockBackend struct {
 	ReceivedTraces  []pdata.Traces
 	ReceivedMetrics []pdata.Metrics
 	ReceivedLogs    []pdata.Logs
+
+	// End-to-end latencies of the data items generated by the PerfTestDataProvider.
+	latencies latencyRecorder
+
+	// Simulated network conditions and failures, nil if disabled.
+	chaos             *chaos
+	dataItemsRejected atomic.Uint64
 }
 
 // NewMockBackend creates a new mock backend that receives data using specified receiver.
@@ -91,6 +98,9 @@ func (mb *MockBackend) Start() error {
 
 	mb.isStarted = true
 	mb.startedAt = time.Now()
+	if mb.chaos != nil {
+		mb.chaos.start(mb.startedAt)
+	}
 	return nil
 }
 
@@ -107,7 +117,7 @@ func (mb *MockBackend) Stop() {
 		mb.receiver.Stop()
 
 		// Print stats.
-		log.Printf("Stopped backend. %s", mb.GetStats())
+		log.Printf("Stopped backend. %s %s", mb.GetStats(), mb.LatencyStats())
 	})
 }
 
@@ -118,9 +128,23 @@ func (mb *MockBackend) EnableRecording() {
 	mb.isRecording = true
 }
 
+// SetChaos makes the backend simulate the network conditions and failures of the spec,
+// it must be called before Start.
+func (mb *MockBackend) SetChaos(spec ChaosSpec) {
+	if !spec.isSpecified() {
+		mb.chaos = nil
+		return
+	}
+	mb.chaos = newChaos(spec, time.Now().UnixNano())
+}
+
 func (mb *MockBackend) GetStats() string {
 	received := mb.DataItemsReceived()
-	return printer.Sprintf("Received:%10d items (%d/sec)", received, int(float64(received)/time.Since(mb.startedAt).Seconds()))
+	stats := printer.Sprintf("Received:%10d items (%d/sec)", received, int(float64(received)/time.Since(mb.startedAt).Seconds()))
+	if mb.chaos != nil {
+		stats += printer.Sprintf(" Rejected:%10d items", mb.DataItemsRejected())
+	}
+	return stats
 }
 
 // DataItemsReceived returns total number of received spans and metrics.
@@ -128,6 +152,30 @@ func (mb *MockBackend) DataItemsReceived() uint64 {
 	return mb.tc.numSpansReceived.Load() + mb.mc.numMetricsReceived.Load() + mb.lc.numLogRecordsReceived.Load()
 }
 
+// DataItemsRejected returns total number of data items rejected by the simulated failures,
+// the exporters are expected to send them again.
+func (mb *MockBackend) DataItemsRejected() uint64 {
+	return mb.dataItemsRejected.Load()
+}
+
+// applyChaos simulates the network conditions for a received batch of count data items, it
+// returns an error if the batch is rejected.
+func (mb *MockBackend) applyChaos(count int) error {
+	if mb.chaos == nil {
+		return nil
+	}
+	err := mb.chaos.apply()
+	if err != nil {
+		mb.dataItemsRejected.Add(uint64(count))
+	}
+	return err
+}
+
+// LatencyStats returns the percentiles of the end-to-end latencies of the received data items.
+func (mb *MockBackend) LatencyStats() LatencyStats {
+	return mb.latencies.stats()
+}
+
 // ClearReceivedItems clears the list of received traces and metrics. Note: counters
 // return by DataItemsReceived() are not cleared, they are cumulative.
 func (mb *MockBackend) ClearReceivedItems() {
@@ -170,7 +218,11 @@ type MockTraceConsumer struct {
 }
 
 func (tc *MockTraceConsumer) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	if err := tc.backend.applyChaos(td.SpanCount()); err != nil {
+		return err
+	}
 	tc.numSpansReceived.Add(uint64(td.SpanCount()))
+	receivedAt := time.Now()
 
 	rs := td.ResourceSpans()
 	for i := 0; i < rs.Len(); i++ {
@@ -196,6 +248,7 @@ func (tc *MockTraceConsumer) ConsumeTraces(_ context.Context, td pdata.Traces) e
 				_ = spanSeqnum
 				_ = traceSeqnum
 
+				tc.backend.latencies.record(span.Attributes(), 1, receivedAt)
 			}
 		}
 	}
@@ -214,7 +267,16 @@ type MockMetricConsumer struct {
 
 func (mc *MockMetricConsumer) ConsumeMetrics(_ context.Context, md pdata.Metrics) error {
 	_, dataPoints := md.MetricAndDataPointCount()
+	if err := mc.backend.applyChaos(dataPoints); err != nil {
+		return err
+	}
 	mc.numMetricsReceived.Add(uint64(dataPoints))
+	receivedAt := time.Now()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		mc.backend.latencies.record(rm.Resource().Attributes(), resourceMetricsDataPointCount(rm), receivedAt)
+	}
 	mc.backend.ConsumeMetric(md)
 	return nil
 }
@@ -236,7 +298,21 @@ type MockLogConsumer struct {
 
 func (mc *MockLogConsumer) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
 	recordCount := ld.LogRecordCount()
+	if err := mc.backend.applyChaos(recordCount); err != nil {
+		return err
+	}
 	mc.numLogRecordsReceived.Add(uint64(recordCount))
+	receivedAt := time.Now()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				mc.backend.latencies.record(logs.At(k).Attributes(), 1, receivedAt)
+			}
+		}
+	}
 	mc.backend.ConsumeLogs(ld)
 	return nil
 }
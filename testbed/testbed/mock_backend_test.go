@@ -68,6 +68,10 @@ func TestGeneratorAndBackend(t *testing.T) {
 
 			// The backend should receive everything generated.
 			assert.Equal(t, lg.DataItemsSent(), mb.DataItemsReceived())
+			// The latency is measured for every data item.
+			latency := mb.LatencyStats()
+			assert.EqualValues(t, mb.DataItemsReceived(), latency.Count)
+			assert.Greater(t, int64(latency.P99), int64(0))
 		})
 	}
 }
@@ -34,6 +34,24 @@ func WithSkipResults() TestCaseOption {
 	}}
 }
 
+// WithChaos makes the MockBackend of the TestCase simulate the network conditions and
+// failures of the ChaosSpec.
+func WithChaos(spec ChaosSpec) TestCaseOption {
+	return TestCaseOption{func(t *TestCase) {
+		t.chaosSpec = spec
+	}}
+}
+
+// WithGateway adds a gateway to the TestCase, a second otelcol instance the agent exports
+// to and which exports to the MockBackend. The gatewayEndpoint is the address the gateway
+// receives the data from the agent on, used to wait for the gateway to start.
+func WithGateway(gatewayProc OtelcolRunner, gatewayEndpoint string) TestCaseOption {
+	return TestCaseOption{func(t *TestCase) {
+		t.gatewayProc = gatewayProc
+		t.gatewayEndpoint = gatewayEndpoint
+	}}
+}
+
 // WithConfigFile allows a custom configuration file for TestCase.
 func WithConfigFile(file string) TestCaseOption {
 	return TestCaseOption{func(t *TestCase) {
@@ -66,6 +66,10 @@ func (mb *DataReceiverBase) ReportFatalError(err error) {
 	log.Printf("Fatal error reported: %v", err)
 }
 
+func (mb *DataReceiverBase) ReportComponentStatus(event *component.StatusEvent) {
+	log.Printf("Component status reported: %v %v", event.Status, event.Err)
+}
+
 // GetFactory of the specified kind. Returns the factory for a component type.
 func (mb *DataReceiverBase) GetFactory(_ component.Kind, _ configmodels.Type) component.Factory {
 	return nil
@@ -53,7 +53,11 @@ type PerformanceTestResult struct {
 	ramMibMax         uint32
 	sentSpanCount     uint64
 	receivedSpanCount uint64
+	latencyP50        time.Duration
+	latencyP99        time.Duration
 	errorCause        string
+	// gateway is set for the results of the gateway of a test, reported in addition to the agent ones.
+	gateway bool
 }
 
 func (r *PerformanceResults) Init(resultsDir string) {
@@ -72,8 +76,8 @@ func (r *PerformanceResults) Init(resultsDir string) {
 	_, _ = io.WriteString(r.resultsFile,
 		"# Test PerformanceResults\n"+
 			fmt.Sprintf("Started: %s\n\n", time.Now().Format(time.RFC1123Z))+
-			"Test                                    |Result|Duration|CPU Avg%|CPU Max%|RAM Avg MiB|RAM Max MiB|Sent Items|Received Items|\n"+
-			"----------------------------------------|------|-------:|-------:|-------:|----------:|----------:|---------:|-------------:|\n")
+			"Test                                    |Result|Duration|CPU Avg%|CPU Max%|RAM Avg MiB|RAM Max MiB|Sent Items|Received Items|Latency p50 ms|Latency p99 ms|\n"+
+			"----------------------------------------|------|-------:|-------:|-------:|----------:|----------:|---------:|-------------:|-------------:|-------------:|\n")
 }
 
 // Save the total results and close the file.
@@ -90,7 +94,7 @@ func (r *PerformanceResults) Add(_ string, result interface{}) {
 		return
 	}
 	_, _ = io.WriteString(r.resultsFile,
-		fmt.Sprintf("%-40s|%-6s|%7.0fs|%8.1f|%8.1f|%11d|%11d|%10d|%14d|%s\n",
+		fmt.Sprintf("%-40s|%-6s|%7.0fs|%8.1f|%8.1f|%11d|%11d|%10d|%14d|%14.1f|%14.1f|%s\n",
 			testResult.testName,
 			testResult.result,
 			testResult.duration.Seconds(),
@@ -100,10 +104,14 @@ func (r *PerformanceResults) Add(_ string, result interface{}) {
 			testResult.ramMibMax,
 			testResult.sentSpanCount,
 			testResult.receivedSpanCount,
+			testResult.latencyP50.Seconds()*1000,
+			testResult.latencyP99.Seconds()*1000,
 			testResult.errorCause,
 		),
 	)
-	r.totalDuration += testResult.duration
+	if !testResult.gateway {
+		r.totalDuration += testResult.duration
+	}
 }
 
 // CorrectnessResults implements the TestResultsSummary interface with fields suitable for reporting data translation
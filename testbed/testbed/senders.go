@@ -96,6 +96,10 @@ func (dsb *DataSenderBase) ReportFatalError(err error) {
 	log.Printf("Fatal error reported: %v", err)
 }
 
+func (dsb *DataSenderBase) ReportComponentStatus(event *component.StatusEvent) {
+	log.Printf("Component status reported: %v %v", event.Status, event.Err)
+}
+
 // GetFactory of the specified kind. Returns the factory for a component type.
 func (dsb *DataSenderBase) GetFactory(_ component.Kind, _ configmodels.Type) component.Factory {
 	return nil
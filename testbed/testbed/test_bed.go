@@ -37,11 +37,18 @@ func Start(resultsSummary TestResultsSummary) error {
 	}
 	resultsSummary.Init(dir)
 
+	activeBaseline, err = newBaselineFromEnv()
 	return err
 }
 
 func SaveResults(resultsSummary TestResultsSummary) {
 	resultsSummary.Save()
+
+	if activeBaseline != nil {
+		if err := activeBaseline.save(); err != nil {
+			log.Printf("Cannot save the baseline: %s", err.Error())
+		}
+	}
 }
 
 const testBedEnableEnvVarName = "RUN_TESTBED"
@@ -15,11 +15,13 @@
 package testbed
 
 import (
+	"errors"
 	"log"
 	"net"
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -45,9 +47,20 @@ type TestCase struct {
 	// Resource spec for agent.
 	resourceSpec ResourceSpec
 
+	// Expected end-to-end latency of the data items.
+	latencySpec LatencySpec
+
+	// Network conditions and failures simulated by the MockBackend.
+	chaosSpec ChaosSpec
+
 	// Agent process.
 	agentProc OtelcolRunner
 
+	// Optional gateway process the agent exports to, and the endpoint it receives the data on.
+	gatewayProc         OtelcolRunner
+	gatewayEndpoint     string
+	gatewayResourceSpec ResourceSpec
+
 	Sender   DataSender
 	Receiver DataReceiver
 
@@ -68,7 +81,8 @@ type TestCase struct {
 
 	doneSignal chan struct{}
 
-	errorCause string
+	errorCause  string
+	errorSignal sync.Once
 
 	resultsSummary TestResultsSummary
 }
@@ -126,11 +140,13 @@ func NewTestCase(
 		// Resource check period should not be longer than entire test duration.
 		tc.resourceSpec.ResourceCheckPeriod = tc.Duration
 	}
+	tc.gatewayResourceSpec.ResourceCheckPeriod = tc.resourceSpec.ResourceCheckPeriod
 
 	tc.LoadGenerator, err = NewLoadGenerator(dataProvider, sender)
 	require.NoError(t, err, "Cannot create generator")
 
 	tc.MockBackend = NewMockBackend(tc.composeTestResultFileName("backend.log"), receiver)
+	tc.MockBackend.SetChaos(tc.chaosSpec)
 
 	go tc.logStats()
 
@@ -159,6 +175,36 @@ func (tc *TestCase) SetResourceLimits(resourceSpec ResourceSpec) {
 	}
 }
 
+// SetGatewayResourceLimits sets expected limits for resource consumption of the gateway,
+// with the same semantics as SetResourceLimits.
+func (tc *TestCase) SetGatewayResourceLimits(resourceSpec ResourceSpec) {
+	if resourceSpec.ExpectedMaxCPU > 0 {
+		tc.gatewayResourceSpec.ExpectedMaxCPU = resourceSpec.ExpectedMaxCPU
+	}
+	if resourceSpec.ExpectedMaxRAM > 0 {
+		tc.gatewayResourceSpec.ExpectedMaxRAM = resourceSpec.ExpectedMaxRAM
+	}
+	if resourceSpec.ResourceCheckPeriod > 0 {
+		tc.gatewayResourceSpec.ResourceCheckPeriod = resourceSpec.ResourceCheckPeriod
+	}
+}
+
+// SetLatencyLimits sets expected limits for the end-to-end latency of the data items,
+// checked by the PerfTestValidator. Limits are modified only for non-zero fields of
+// latencySpec, all zero-value fields of latencySpec are ignored and their previous
+// values remain in effect.
+func (tc *TestCase) SetLatencyLimits(latencySpec LatencySpec) {
+	if latencySpec.ExpectedMaxP50 > 0 {
+		tc.latencySpec.ExpectedMaxP50 = latencySpec.ExpectedMaxP50
+	}
+	if latencySpec.ExpectedMaxP95 > 0 {
+		tc.latencySpec.ExpectedMaxP95 = latencySpec.ExpectedMaxP95
+	}
+	if latencySpec.ExpectedMaxP99 > 0 {
+		tc.latencySpec.ExpectedMaxP99 = latencySpec.ExpectedMaxP99
+	}
+}
+
 // StartAgent starts the agent and redirects its standard output and standard error
 // to "agent.log" file located in the test directory.
 func (tc *TestCase) StartAgent(args ...string) {
@@ -206,6 +252,51 @@ func (tc *TestCase) StopAgent() {
 	tc.agentProc.Stop()
 }
 
+// StartGateway starts the gateway configured with WithGateway and redirects its standard
+// output and standard error to "gateway.log" file located in the test directory. The gateway
+// must be started before the agent, which exports to it.
+func (tc *TestCase) StartGateway(args ...string) {
+	if tc.gatewayProc == nil {
+		tc.indicateError(errors.New("no gateway configured for the test case"))
+		return
+	}
+
+	err := tc.gatewayProc.Start(StartParams{
+		Name:         "Gateway",
+		LogFilePath:  tc.composeTestResultFileName("gateway.log"),
+		CmdArgs:      args,
+		resourceSpec: &tc.gatewayResourceSpec,
+	})
+
+	if err != nil {
+		tc.indicateError(err)
+		return
+	}
+
+	// Start watching resource consumption.
+	go func() {
+		err := tc.gatewayProc.WatchResourceConsumption()
+		if err != nil {
+			tc.indicateError(err)
+		}
+	}()
+
+	if tc.gatewayEndpoint != "" {
+		// Wait for gateway to start, the same way as for the agent.
+		tc.WaitFor(func() bool {
+			_, err := net.Dial("tcp", tc.gatewayEndpoint)
+			return err == nil
+		})
+	}
+}
+
+// StopGateway stops gateway process, if any.
+func (tc *TestCase) StopGateway() {
+	if tc.gatewayProc != nil {
+		tc.gatewayProc.Stop()
+	}
+}
+
 // StartLoad starts the load generator and redirects its standard output and standard error
 // to "load-generator.log" file located in the test directory.
 func (tc *TestCase) StartLoad(options LoadOptions) {
@@ -247,6 +338,7 @@ func (tc *TestCase) Stop() {
 	// Stop all components
 	tc.StopLoad()
 	tc.StopAgent()
+	tc.StopGateway()
 	tc.StopBackend()
 
 	// Stop logging
@@ -329,8 +421,8 @@ func (tc *TestCase) indicateError(err error) {
 
 	tc.errorCause = err.Error()
 
-	// Signal the error via channel
-	close(tc.ErrorSignal)
+	// Signal the error via channel, the agent and the gateway can both fail.
+	tc.errorSignal.Do(func() { close(tc.ErrorSignal) })
 }
 
 func (tc *TestCase) logStats() {
@@ -348,6 +440,14 @@ func (tc *TestCase) logStats() {
 }
 
 func (tc *TestCase) logStatsOnce() {
+	if tc.gatewayProc != nil {
+		log.Printf("%s | %s | %s | %s",
+			tc.agentProc.GetResourceConsumption(),
+			tc.gatewayProc.GetResourceConsumption(),
+			tc.LoadGenerator.GetStats(),
+			tc.MockBackend.GetStats())
+		return
+	}
 	log.Printf("%s | %s | %s",
 		tc.agentProc.GetResourceConsumption(),
 		tc.LoadGenerator.GetStats(),
@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal"
 	otlpcommon "go.opentelemetry.io/collector/internal/data/protogen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/internal/data/protogen/logs/v1"
 	otlptrace "go.opentelemetry.io/collector/internal/data/protogen/trace/v1"
 )
 
@@ -48,10 +49,49 @@ func (v *PerfTestValidator) Validate(tc *TestCase) {
 		"Received and sent counters do not match.") {
 		log.Printf("Sent and received data matches.")
 	}
+	v.validateLatency(tc)
+}
+
+// validateLatency checks the end-to-end latency percentiles against the limits of the TestCase.
+func (v *PerfTestValidator) validateLatency(tc *TestCase) {
+	if !tc.latencySpec.isSpecified() {
+		return
+	}
+	stats := tc.MockBackend.LatencyStats()
+	if !assert.NotZero(tc.t, stats.Count, "No latency measured, the send timestamp was not received.") {
+		return
+	}
+	log.Printf("%s", stats)
+	limits := []struct {
+		name     string
+		actual   time.Duration
+		expected time.Duration
+	}{
+		{"p50", stats.P50, tc.latencySpec.ExpectedMaxP50},
+		{"p95", stats.P95, tc.latencySpec.ExpectedMaxP95},
+		{"p99", stats.P99, tc.latencySpec.ExpectedMaxP99},
+	}
+	for _, limit := range limits {
+		if limit.expected > 0 {
+			assert.LessOrEqualf(tc.t, limit.actual, limit.expected, "Latency %s exceeds the limit.", limit.name)
+		}
+	}
 }
 
 func (v *PerfTestValidator) RecordResults(tc *TestCase) {
 	rc := tc.agentProc.GetTotalConsumption()
+	latency := tc.MockBackend.LatencyStats()
+
+	// Remove "Test" prefix from test name.
+	testName := tc.t.Name()[4:]
+
+	itemsPerSecond := float64(tc.MockBackend.DataItemsReceived()) / time.Since(tc.startTime).Seconds()
+	v.checkBaseline(tc, testName, rc, itemsPerSecond)
+	var grc *ResourceConsumption
+	if tc.gatewayProc != nil {
+		grc = tc.gatewayProc.GetTotalConsumption()
+		v.checkBaseline(tc, testName+"/Gateway", grc, itemsPerSecond)
+	}
 
 	var result string
 	if tc.t.Failed() {
@@ -60,9 +100,6 @@ func (v *PerfTestValidator) RecordResults(tc *TestCase) {
 		result = "PASS"
 	}
 
-	// Remove "Test" prefix from test name.
-	testName := tc.t.Name()[4:]
-
 	tc.resultsSummary.Add(tc.t.Name(), &PerformanceTestResult{
 		testName:          testName,
 		result:            result,
@@ -73,8 +110,51 @@ func (v *PerfTestValidator) RecordResults(tc *TestCase) {
 		cpuPercentageMax:  rc.CPUPercentMax,
 		ramMibAvg:         rc.RAMMiBAvg,
 		ramMibMax:         rc.RAMMiBMax,
+		latencyP50:        latency.P50,
+		latencyP99:        latency.P99,
 		errorCause:        tc.errorCause,
 	})
+
+	if grc == nil {
+		return
+	}
+	// The gateway resource consumption is reported on its own line.
+	tc.resultsSummary.Add(tc.t.Name(), &PerformanceTestResult{
+		testName:          testName + "/Gateway",
+		result:            result,
+		receivedSpanCount: tc.MockBackend.DataItemsReceived(),
+		sentSpanCount:     tc.LoadGenerator.DataItemsSent(),
+		duration:          time.Since(tc.startTime),
+		cpuPercentageAvg:  grc.CPUPercentAvg,
+		cpuPercentageMax:  grc.CPUPercentMax,
+		ramMibAvg:         grc.RAMMiBAvg,
+		ramMibMax:         grc.RAMMiBMax,
+		latencyP50:        latency.P50,
+		latencyP99:        latency.P99,
+		errorCause:        tc.errorCause,
+		gateway:           true,
+	})
+}
+
+// checkBaseline records the results of the test in the baseline or fails the test if they regressed
+// compared to the baseline, when a baseline is configured.
+func (v *PerfTestValidator) checkBaseline(tc *TestCase, testName string, rc *ResourceConsumption, itemsPerSecond float64) {
+	if activeBaseline == nil {
+		return
+	}
+	regression := activeBaseline.check(testName, BaselineResult{
+		CPUPercentAvg:  rc.CPUPercentAvg,
+		CPUPercentMax:  rc.CPUPercentMax,
+		RAMMiBAvg:      rc.RAMMiBAvg,
+		RAMMiBMax:      rc.RAMMiBMax,
+		ItemsPerSecond: itemsPerSecond,
+	})
+	if regression != "" {
+		tc.t.Error(regression)
+		if tc.errorCause == "" {
+			tc.errorCause = regression
+		}
+	}
 }
 
 // CorrectnessTestValidator implements TestCaseValidator for test suites using CorrectnessResults for summarizing results.
@@ -98,7 +178,10 @@ func (v *CorrectnessTestValidator) Validate(tc *TestCase) {
 	if len(tc.MockBackend.ReceivedTraces) > 0 {
 		v.assertSentRecdTracingDataEqual(tc.MockBackend.ReceivedTraces)
 	}
-	assert.EqualValues(tc.t, 0, len(v.assertionFailures), "There are span data mismatches.")
+	if len(tc.MockBackend.ReceivedLogs) > 0 {
+		v.assertSentRecdLogsDataEqual(tc.MockBackend.ReceivedLogs)
+	}
+	assert.EqualValues(tc.t, 0, len(v.assertionFailures), "There are span or log data mismatches.")
 }
 
 func (v *CorrectnessTestValidator) RecordResults(tc *TestCase) {
@@ -402,6 +485,69 @@ func (v *CorrectnessTestValidator) diffSpanStatus(sentSpan *otlptrace.Span, recd
 	}
 }
 
+func (v *CorrectnessTestValidator) assertSentRecdLogsDataEqual(logsList []pdata.Logs) {
+	for _, ld := range logsList {
+		for _, rl := range internal.LogsToOtlp(ld.InternalRep()).ResourceLogs {
+			for _, ill := range rl.InstrumentationLibraryLogs {
+				for _, recdLog := range ill.Logs {
+					sentLog := v.dataProvider.GetGeneratedLog(recdLog.Name)
+					v.diffLog(sentLog, recdLog)
+				}
+			}
+		}
+	}
+}
+
+func (v *CorrectnessTestValidator) diffLog(sentLog *otlplogs.LogRecord, recdLog *otlplogs.LogRecord) {
+	if sentLog == nil {
+		af := &TraceAssertionFailure{
+			typeName:      "LogRecord",
+			dataComboName: recdLog.Name,
+		}
+		v.assertionFailures = append(v.assertionFailures, af)
+		return
+	}
+	v.diffLogField(sentLog.Name, "TimeUnixNano", sentLog.TimeUnixNano, recdLog.TimeUnixNano)
+	v.diffLogField(sentLog.Name, "SeverityNumber", sentLog.SeverityNumber, recdLog.SeverityNumber)
+	v.diffLogField(sentLog.Name, "SeverityText", sentLog.SeverityText, recdLog.SeverityText)
+	v.diffLogField(sentLog.Name, "TraceId", sentLog.TraceId.HexString(), recdLog.TraceId.HexString())
+	v.diffLogField(sentLog.Name, "SpanId", sentLog.SpanId.HexString(), recdLog.SpanId.HexString())
+	v.diffLogField(sentLog.Name, "Flags", sentLog.Flags, recdLog.Flags)
+	v.diffLogField(sentLog.Name, "DroppedAttributesCount", sentLog.DroppedAttributesCount, recdLog.DroppedAttributesCount)
+	v.diffLogBody(sentLog, recdLog)
+	if len(sentLog.Attributes) != len(recdLog.Attributes) {
+		v.diffLogField(sentLog.Name, "Attributes", len(sentLog.Attributes), len(recdLog.Attributes))
+	} else {
+		v.diffAttributesSlice(sentLog.Name, recdLog.Attributes, sentLog.Attributes, "Attributes[%s]")
+	}
+}
+
+func (v *CorrectnessTestValidator) diffLogField(logName string, fieldPath string, sentVal interface{}, recdVal interface{}) {
+	if !reflect.DeepEqual(sentVal, recdVal) {
+		af := &TraceAssertionFailure{
+			typeName:      "LogRecord",
+			dataComboName: logName,
+			fieldPath:     fieldPath,
+			expectedValue: sentVal,
+			actualValue:   recdVal,
+		}
+		v.assertionFailures = append(v.assertionFailures, af)
+	}
+}
+
+func (v *CorrectnessTestValidator) diffLogBody(sentLog *otlplogs.LogRecord, recdLog *otlplogs.LogRecord) {
+	sentVal := retrieveAttributeValue(otlpcommon.KeyValue{Value: sentLog.Body})
+	recdVal := retrieveAttributeValue(otlpcommon.KeyValue{Value: recdLog.Body})
+	switch val := sentVal.(type) {
+	case *otlpcommon.KeyValueList:
+		v.compareKeyValueList(sentLog.Name, val, recdVal, "Body%s", "")
+	case *otlpcommon.ArrayValue:
+		v.compareArrayList(sentLog.Name, val, recdVal, "Body%s", "")
+	default:
+		v.compareSimpleValues(sentLog.Name, sentVal, recdVal, "Body%s", "")
+	}
+}
+
 func (v *CorrectnessTestValidator) diffAttributesSlice(spanName string, recdAttrs []otlpcommon.KeyValue,
 	sentAttrs []otlpcommon.KeyValue, fmtStr string) {
 	recdAttrsMap := convertAttributesSliceToMap(recdAttrs)
@@ -23,6 +23,7 @@ import (
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -134,6 +135,65 @@ service:
 	)
 }
 
+// createGatewayConfigYaml creates the config of a gateway receiving the data sent by the agent with the
+// gatewaySender protocol and exporting it with the receiver protocol. The gateway has no extension so
+// that it does not conflict with the ports of the agent.
+func createGatewayConfigYaml(
+	t *testing.T,
+	gatewaySender testbed.DataSender,
+	receiver testbed.DataReceiver,
+	processors map[string]string,
+) string {
+	processorsSections := ""
+	processorsList := ""
+	first := true
+	for name, cfg := range processors {
+		processorsSections += cfg + "\n"
+		if !first {
+			processorsList += ","
+		}
+		processorsList += name
+		first = false
+	}
+
+	var pipeline string
+	switch gatewaySender.(type) {
+	case testbed.TraceDataSender:
+		pipeline = "traces"
+	case testbed.MetricDataSender:
+		pipeline = "metrics"
+	case testbed.LogDataSender:
+		pipeline = "logs"
+	default:
+		t.Error("Invalid DataSender type")
+	}
+
+	format := `
+receivers:%v
+exporters:%v
+processors:
+  %s
+
+service:
+  pipelines:
+    %s:
+      receivers: [%v]
+      processors: [%s]
+      exporters: [%v]
+`
+
+	return fmt.Sprintf(
+		format,
+		gatewaySender.GenConfigYAMLStr(),
+		receiver.GenConfigYAMLStr(),
+		processorsSections,
+		pipeline,
+		gatewaySender.ProtocolName(),
+		processorsList,
+		receiver.ProtocolName(),
+	)
+}
+
 // Run 10k data items/sec test using specified sender and receiver protocols.
 func Scenario10kItemsPerSecond(
 	t *testing.T,
@@ -190,6 +250,194 @@ func Scenario10kItemsPerSecond(
 	tc.ValidateData()
 }
 
+// ScenarioChaos runs a 1k data items/sec test with the backend simulating the network conditions and
+// failures of the ChaosSpec, it validates that the data rejected by the backend is eventually retried
+// by the exporter of the collector.
+func ScenarioChaos(
+	t *testing.T,
+	sender testbed.DataSender,
+	receiver testbed.DataReceiver,
+	chaosSpec testbed.ChaosSpec,
+	resultsSummary testbed.TestResultsSummary,
+	processors map[string]string,
+) {
+	resultDir, err := filepath.Abs(path.Join("results", t.Name()))
+	require.NoError(t, err)
+
+	options := testbed.LoadOptions{
+		DataItemsPerSecond: 1_000,
+		ItemsPerBatch:      10,
+		Parallel:           1,
+	}
+	agentProc := &testbed.ChildProcess{}
+
+	configStr := createConfigYaml(t, sender, receiver, resultDir, processors, nil)
+	configCleanup, err := agentProc.PrepareConfig(configStr)
+	require.NoError(t, err)
+	defer configCleanup()
+
+	tc := testbed.NewTestCase(
+		t,
+		testbed.NewPerfTestDataProvider(options),
+		sender,
+		receiver,
+		agentProc,
+		&testbed.PerfTestValidator{},
+		resultsSummary,
+		testbed.WithChaos(chaosSpec),
+	)
+	defer tc.Stop()
+
+	tc.StartBackend()
+	tc.StartAgent()
+
+	tc.StartLoad(options)
+
+	tc.Sleep(tc.Duration)
+
+	tc.StopLoad()
+
+	tc.WaitFor(func() bool { return tc.LoadGenerator.DataItemsSent() > 0 }, "load generator started")
+	// The rejected data is retried with an exponential backoff.
+	tc.WaitForN(func() bool { return tc.LoadGenerator.DataItemsSent() == tc.MockBackend.DataItemsReceived() },
+		2*time.Minute, "all data items received")
+	if chaosSpec.DropRate > 0 || chaosSpec.OutageDuration > 0 {
+		assert.NotZero(t, tc.MockBackend.DataItemsRejected(), "no data items rejected by the backend")
+	}
+
+	tc.StopAgent()
+
+	tc.ValidateData()
+}
+
+// ScenarioLoadProfile runs a test generating the varying load of the LoadProfile, to validate the
+// behavior of the collector, e.g. of its memory limiter and queues, under ramps and bursts of load.
+func ScenarioLoadProfile(
+	t *testing.T,
+	sender testbed.DataSender,
+	receiver testbed.DataReceiver,
+	profile testbed.LoadProfile,
+	resourceSpec testbed.ResourceSpec,
+	resultsSummary testbed.TestResultsSummary,
+	processors map[string]string,
+) {
+	resultDir, err := filepath.Abs(path.Join("results", t.Name()))
+	require.NoError(t, err)
+
+	options := testbed.LoadOptions{
+		ItemsPerBatch: 100,
+		Parallel:      1,
+		Profile:       profile,
+	}
+	agentProc := &testbed.ChildProcess{}
+
+	configStr := createConfigYaml(t, sender, receiver, resultDir, processors, nil)
+	configCleanup, err := agentProc.PrepareConfig(configStr)
+	require.NoError(t, err)
+	defer configCleanup()
+
+	tc := testbed.NewTestCase(
+		t,
+		testbed.NewPerfTestDataProvider(options),
+		sender,
+		receiver,
+		agentProc,
+		&testbed.PerfTestValidator{},
+		resultsSummary,
+	)
+	defer tc.Stop()
+
+	tc.SetResourceLimits(resourceSpec)
+	tc.StartBackend()
+	tc.StartAgent()
+
+	tc.StartLoad(options)
+
+	tc.Sleep(tc.Duration)
+
+	tc.StopLoad()
+
+	tc.WaitFor(func() bool { return tc.LoadGenerator.DataItemsSent() > 0 }, "load generator started")
+	tc.WaitFor(func() bool { return tc.LoadGenerator.DataItemsSent() == tc.MockBackend.DataItemsReceived() },
+		"all data items received")
+
+	tc.StopAgent()
+
+	tc.ValidateData()
+}
+
+// ScenarioAgentGateway runs a 10k data items/sec test through an agent exporting to a gateway, which
+// exports to the backend. The agent exports to the gateway with the protocol of the gatewayReceiver,
+// received by the gateway with the protocol of the gatewaySender, both using the same port. The
+// resources of the agent and of the gateway are monitored independently.
+func ScenarioAgentGateway(
+	t *testing.T,
+	sender testbed.DataSender,
+	gatewayReceiver testbed.DataReceiver,
+	gatewaySender testbed.DataSender,
+	receiver testbed.DataReceiver,
+	agentResourceSpec testbed.ResourceSpec,
+	gatewayResourceSpec testbed.ResourceSpec,
+	resultsSummary testbed.TestResultsSummary,
+	agentProcessors map[string]string,
+	gatewayProcessors map[string]string,
+) {
+	resultDir, err := filepath.Abs(path.Join("results", t.Name()))
+	require.NoError(t, err)
+
+	options := testbed.LoadOptions{
+		DataItemsPerSecond: 10_000,
+		ItemsPerBatch:      100,
+		Parallel:           1,
+	}
+
+	agentProc := &testbed.ChildProcess{}
+	agentConfigCleanup, err := agentProc.PrepareConfig(
+		createConfigYaml(t, sender, gatewayReceiver, resultDir, agentProcessors, nil))
+	require.NoError(t, err)
+	defer agentConfigCleanup()
+
+	gatewayProc := &testbed.ChildProcess{}
+	gatewayConfigCleanup, err := gatewayProc.PrepareConfig(
+		createGatewayConfigYaml(t, gatewaySender, receiver, gatewayProcessors))
+	require.NoError(t, err)
+	defer gatewayConfigCleanup()
+
+	tc := testbed.NewTestCase(
+		t,
+		testbed.NewPerfTestDataProvider(options),
+		sender,
+		receiver,
+		agentProc,
+		&testbed.PerfTestValidator{},
+		resultsSummary,
+		testbed.WithGateway(gatewayProc, gatewaySender.GetEndpoint()),
+	)
+	defer tc.Stop()
+
+	tc.SetResourceLimits(agentResourceSpec)
+	tc.SetGatewayResourceLimits(gatewayResourceSpec)
+	tc.StartBackend()
+	// The gateway serves its own metrics on another port than the agent.
+	tc.StartGateway(fmt.Sprintf("--metrics-addr=localhost:%d", testbed.GetAvailablePort(t)))
+	tc.StartAgent()
+
+	tc.StartLoad(options)
+
+	tc.Sleep(tc.Duration)
+
+	tc.StopLoad()
+
+	tc.WaitFor(func() bool { return tc.LoadGenerator.DataItemsSent() > 0 }, "load generator started")
+	tc.WaitFor(func() bool { return tc.LoadGenerator.DataItemsSent() == tc.MockBackend.DataItemsReceived() },
+		"all data items received")
+
+	tc.StopAgent()
+	tc.StopGateway()
+
+	tc.ValidateData()
+}
+
 // TestCase for Scenario1kSPSWithAttrs func.
 type TestCase struct {
 	attrCount      int
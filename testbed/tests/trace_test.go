@@ -25,6 +25,7 @@ import (
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,6 +134,144 @@ func TestTrace10kSPS(t *testing.T) {
 	}
 }
 
+func TestTraceChaos(t *testing.T) {
+	tests := []struct {
+		name      string
+		chaosSpec testbed.ChaosSpec
+	}{
+		{
+			name: "Latency",
+			chaosSpec: testbed.ChaosSpec{
+				Latency: 50 * time.Millisecond,
+				Jitter:  50 * time.Millisecond,
+			},
+		},
+		{
+			name: "PacketLoss",
+			chaosSpec: testbed.ChaosSpec{
+				DropRate: 0.1,
+			},
+		},
+		{
+			name: "BackendFlapping",
+			chaosSpec: testbed.ChaosSpec{
+				OutageInterval: 5 * time.Second,
+				OutageDuration: 3 * time.Second,
+			},
+		},
+	}
+
+	processors := map[string]string{
+		"batch": `
+  batch:
+`,
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ScenarioChaos(
+				t,
+				testbed.NewOTLPTraceDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+				testbed.NewOTLPDataReceiver(testbed.GetAvailablePort(t)),
+				test.chaosSpec,
+				performanceResultsSummary,
+				processors,
+			)
+		})
+	}
+}
+
+func TestTraceLoadProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile testbed.LoadProfile
+	}{
+		{
+			name: "Ramp",
+			profile: testbed.LoadProfile{
+				testbed.RampLoad(1_000, 20_000, 10*time.Second),
+				testbed.RampLoad(20_000, 1_000, 10*time.Second),
+			},
+		},
+		{
+			name: "Step",
+			profile: testbed.LoadProfile{
+				testbed.StepLoad(1_000, 5*time.Second),
+				testbed.StepLoad(10_000, 5*time.Second),
+				testbed.StepLoad(20_000, 5*time.Second),
+			},
+		},
+		{
+			name: "Sine",
+			profile: testbed.LoadProfile{
+				testbed.SineLoad(10_000, 8_000, 10*time.Second, 10*time.Second),
+			},
+		},
+		{
+			name: "Burst",
+			profile: testbed.LoadProfile{
+				testbed.BurstLoad(1_000, 30_000, 5*time.Second, time.Second, 10*time.Second),
+			},
+		},
+	}
+
+	processors := map[string]string{
+		"memory_limiter": `
+  memory_limiter:
+    check_interval: 100ms
+    limit_mib: 100
+`,
+		"batch": `
+  batch:
+`,
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ScenarioLoadProfile(
+				t,
+				testbed.NewOTLPTraceDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+				testbed.NewOTLPDataReceiver(testbed.GetAvailablePort(t)),
+				test.profile,
+				testbed.ResourceSpec{
+					ExpectedMaxCPU: 80,
+					ExpectedMaxRAM: 120,
+				},
+				performanceResultsSummary,
+				processors,
+			)
+		})
+	}
+}
+
+func TestTraceAgentGateway(t *testing.T) {
+	gatewayPort := testbed.GetAvailablePort(t)
+	processors := map[string]string{
+		"batch": `
+  batch:
+`,
+	}
+
+	ScenarioAgentGateway(
+		t,
+		testbed.NewOTLPTraceDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+		testbed.NewOTLPDataReceiver(gatewayPort),
+		testbed.NewOTLPTraceDataSender(testbed.DefaultHost, gatewayPort),
+		testbed.NewOTLPDataReceiver(testbed.GetAvailablePort(t)),
+		testbed.ResourceSpec{
+			ExpectedMaxCPU: 20,
+			ExpectedMaxRAM: 70,
+		},
+		testbed.ResourceSpec{
+			ExpectedMaxCPU: 20,
+			ExpectedMaxRAM: 70,
+		},
+		performanceResultsSummary,
+		processors,
+		processors,
+	)
+}
+
 func TestTraceNoBackend10kSPS(t *testing.T) {
 
 	limitProcessors := map[string]string{
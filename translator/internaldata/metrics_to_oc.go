@@ -15,7 +15,10 @@
 package internaldata
 
 import (
+	"math"
 	"sort"
+	"strconv"
+	"strings"
 
 	ocmetrics "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -23,6 +26,13 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
+const (
+	// ExemplarTraceIDAttachment is the OC exemplar attachment holding the hex encoded trace id of the exemplar.
+	ExemplarTraceIDAttachment = "trace_id"
+	// ExemplarSpanIDAttachment is the OC exemplar attachment holding the hex encoded span id of the exemplar.
+	ExemplarSpanIDAttachment = "span_id"
+)
+
 type labelKeys struct {
 	// ordered OC label keys
 	keys []*ocmetrics.LabelKey
@@ -433,13 +443,33 @@ func summaryPercentilesToOC(qtls pdata.ValueAtQuantileSlice) []*ocmetrics.Summar
 	for i := 0; i < qtls.Len(); i++ {
 		quantile := qtls.At(i)
 		ocPercentiles = append(ocPercentiles, &ocmetrics.SummaryValue_Snapshot_ValueAtPercentile{
-			Percentile: quantile.Quantile() * 100,
+			Percentile: shiftDecimalPoint(quantile.Quantile(), 2),
 			Value:      quantile.Value(),
 		})
 	}
 	return ocPercentiles
 }
 
+// shiftDecimalPoint multiplies v by 10^n shifting the decimal point of its shortest decimal representation,
+// so that the quantiles and the percentiles round-trip exactly, e.g. 0.9 is not translated to 0.009000000000000001
+// and back to 0.9000000000000001 as it would be by a floating point division and multiplication.
+func shiftDecimalPoint(v float64, n int) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) || v == 0 {
+		return v
+	}
+	s := strconv.FormatFloat(v, 'e', -1, 64)
+	i := strings.IndexByte(s, 'e')
+	exp, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return v * math.Pow10(n)
+	}
+	shifted, err := strconv.ParseFloat(s[:i+1]+strconv.Itoa(exp+n), 64)
+	if err != nil {
+		return v * math.Pow10(n)
+	}
+	return shifted
+}
+
 func doubleExemplarsToOC(bounds []float64, ocBuckets []*ocmetrics.DistributionValue_Bucket, exemplars pdata.DoubleExemplarSlice) {
 	if exemplars.Len() == 0 {
 		return
@@ -455,7 +485,7 @@ func doubleExemplarsToOC(bounds []float64, ocBuckets []*ocmetrics.DistributionVa
 			}
 			break
 		}
-		ocBuckets[pos].Exemplar = exemplarToOC(exemplar.FilteredLabels(), val, exemplar.Timestamp())
+		ocBuckets[pos].Exemplar = exemplarToOC(exemplar.FilteredLabels(), val, exemplar.Timestamp(), exemplar.TraceID(), exemplar.SpanID())
 	}
 }
 
@@ -474,18 +504,24 @@ func intExemplarsToOC(bounds []float64, ocBuckets []*ocmetrics.DistributionValue
 			}
 			break
 		}
-		ocBuckets[pos].Exemplar = exemplarToOC(exemplar.FilteredLabels(), val, exemplar.Timestamp())
+		ocBuckets[pos].Exemplar = exemplarToOC(exemplar.FilteredLabels(), val, exemplar.Timestamp(), exemplar.TraceID(), exemplar.SpanID())
 	}
 }
 
-func exemplarToOC(filteredLabels pdata.StringMap, value float64, timestamp pdata.Timestamp) *ocmetrics.DistributionValue_Exemplar {
+func exemplarToOC(filteredLabels pdata.StringMap, value float64, timestamp pdata.Timestamp, traceID pdata.TraceID, spanID pdata.SpanID) *ocmetrics.DistributionValue_Exemplar {
 	var labels map[string]string
-	if filteredLabels.Len() != 0 {
-		labels = make(map[string]string, filteredLabels.Len())
+	if filteredLabels.Len() != 0 || !traceID.IsEmpty() || !spanID.IsEmpty() {
+		labels = make(map[string]string, filteredLabels.Len()+2)
 		filteredLabels.ForEach(func(k string, v string) {
 			labels[k] = v
 		})
 	}
+	if !traceID.IsEmpty() {
+		labels[ExemplarTraceIDAttachment] = traceID.HexString()
+	}
+	if !spanID.IsEmpty() {
+		labels[ExemplarSpanIDAttachment] = spanID.HexString()
+	}
 
 	return &ocmetrics.DistributionValue_Exemplar{
 		Value:       value,
@@ -127,6 +127,27 @@ func TestMetricsToOC(t *testing.T) {
 	}
 }
 
+func TestMetricsToOCAndBack(t *testing.T) {
+	// OC has no int histograms, the histograms are always translated back to double histograms.
+	md := testdata.GeneratMetricsAllTypesWithSampleDatapoints()
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	ih := metrics.At(3)
+	ih.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	metrics.At(2).DoubleHistogram().CopyTo(ih.DoubleHistogram())
+	ih.DoubleHistogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+
+	quantiles := metrics.At(4).Summary().DataPoints().At(0).QuantileValues()
+	for _, q := range []float64{0.007, 0.009, 0.5, 0.999, 0.9999} {
+		quantile := quantiles.AppendEmpty()
+		quantile.SetQuantile(q)
+		quantile.SetValue(q * 10)
+	}
+
+	got := OCSliceToMetrics(MetricsToOC(md))
+	gotMetrics := got.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Empty(t, metrics.Diff(gotMetrics))
+}
+
 func TestMetricsToOC_InvalidDataType(t *testing.T) {
 	internal := testdata.GenerateMetricsMetricTypeInvalid()
 	want := []MetricsData{
@@ -181,3 +202,16 @@ func generateOCTestData() MetricsData {
 		},
 	}
 }
+
+func TestExemplarToOC_TraceContext(t *testing.T) {
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	spanID := pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	got := exemplarToOC(pdata.NewStringMap(), 1.5, pdata.Timestamp(0), traceID, spanID)
+	assert.Equal(t, map[string]string{
+		ExemplarTraceIDAttachment: "0102030405060708090a0b0c0d0e0f10",
+		ExemplarSpanIDAttachment:  "0102030405060708",
+	}, got.Attachments)
+
+	got = exemplarToOC(pdata.NewStringMap(), 1.5, pdata.Timestamp(0), pdata.InvalidTraceID(), pdata.InvalidSpanID())
+	assert.Nil(t, got.Attachments)
+}
@@ -15,6 +15,8 @@
 package internaldata
 
 import (
+	"encoding/hex"
+
 	occommon "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	ocmetrics "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 
@@ -212,6 +214,12 @@ func descriptorTypeToMetrics(t ocmetrics.MetricDescriptor_Type, metric pdata.Met
 		histo := metric.DoubleHistogram()
 		histo.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		return pdata.MetricDataTypeDoubleHistogram
+	case ocmetrics.MetricDescriptor_GAUGE_DISTRIBUTION:
+		// The histograms that are not cumulative are translated to GAUGE_DISTRIBUTION by MetricsToOC.
+		metric.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+		histo := metric.DoubleHistogram()
+		histo.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		return pdata.MetricDataTypeDoubleHistogram
 	case ocmetrics.MetricDescriptor_SUMMARY:
 		metric.SetDataType(pdata.MetricDataTypeSummary)
 		// no temporality specified for summary metric
@@ -387,7 +395,7 @@ func ocHistogramBucketsToMetrics(ocBuckets []*ocmetrics.DistributionValue_Bucket
 		buckets[i] = uint64(ocBuckets[i].GetCount())
 		if ocBuckets[i].GetExemplar() != nil {
 			exemplar := pdata.NewDoubleExemplar()
-			exemplarToMetrics(ocBuckets[i].GetExemplar(), exemplar)
+			OCExemplarToMetrics(ocBuckets[i].GetExemplar(), exemplar)
 			dp.Exemplars().Append(exemplar)
 		}
 	}
@@ -403,14 +411,16 @@ func ocSummaryPercentilesToMetrics(ocPercentiles []*ocmetrics.SummaryValue_Snaps
 	quantiles.Resize(len(ocPercentiles))
 
 	for i, percentile := range ocPercentiles {
-		quantiles.At(i).SetQuantile(percentile.GetPercentile() / 100)
+		quantiles.At(i).SetQuantile(shiftDecimalPoint(percentile.GetPercentile(), -2))
 		quantiles.At(i).SetValue(percentile.GetValue())
 	}
 
 	quantiles.CopyTo(dp.QuantileValues())
 }
 
-func exemplarToMetrics(ocExemplar *ocmetrics.DistributionValue_Exemplar, exemplar pdata.DoubleExemplar) {
+// OCExemplarToMetrics converts an OC exemplar into the given pdata.DoubleExemplar. The trace_id and span_id
+// attachments, when holding valid hex encoded ids, are moved to the exemplar trace context.
+func OCExemplarToMetrics(ocExemplar *ocmetrics.DistributionValue_Exemplar, exemplar pdata.DoubleExemplar) {
 	if ocExemplar.GetTimestamp() != nil {
 		exemplar.SetTimestamp(pdata.TimestampFromTime(ocExemplar.GetTimestamp().AsTime()))
 	}
@@ -419,10 +429,46 @@ func exemplarToMetrics(ocExemplar *ocmetrics.DistributionValue_Exemplar, exempla
 	ocAttachments := ocExemplar.GetAttachments()
 	attachments.InitEmptyWithCapacity(len(ocAttachments))
 	for k, v := range ocAttachments {
+		switch k {
+		case ExemplarTraceIDAttachment:
+			if traceID, ok := traceIDFromHex(v); ok {
+				exemplar.SetTraceID(traceID)
+				continue
+			}
+		case ExemplarSpanIDAttachment:
+			if spanID, ok := spanIDFromHex(v); ok {
+				exemplar.SetSpanID(spanID)
+				continue
+			}
+		}
 		attachments.Upsert(k, v)
 	}
 }
 
+// traceIDFromHex decodes a hex encoded 16 bytes trace id, ok is false for any other input.
+func traceIDFromHex(s string) (pdata.TraceID, bool) {
+	var id [16]byte
+	if hex.DecodedLen(len(s)) != len(id) {
+		return pdata.InvalidTraceID(), false
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return pdata.InvalidTraceID(), false
+	}
+	return pdata.NewTraceID(id), true
+}
+
+// spanIDFromHex decodes a hex encoded 8 bytes span id, ok is false for any other input.
+func spanIDFromHex(s string) (pdata.SpanID, bool) {
+	var id [8]byte
+	if hex.DecodedLen(len(s)) != len(id) {
+		return pdata.InvalidSpanID(), false
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return pdata.InvalidSpanID(), false
+	}
+	return pdata.NewSpanID(id), true
+}
+
 func getPointsCount(ocMetric *ocmetrics.Metric) int {
 	timeseriesSlice := ocMetric.GetTimeseries()
 	var count int
@@ -15,6 +15,7 @@
 package internaldata
 
 import (
+	"math"
 	"testing"
 
 	occommon "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
@@ -185,6 +186,98 @@ func TestOCToMetrics_ResourceInMetricOnly(t *testing.T) {
 	assert.EqualValues(t, want, got)
 }
 
+func TestOCToMetricsAndBack(t *testing.T) {
+	withType := func(ocMetric *ocmetrics.Metric, descType ocmetrics.MetricDescriptor_Type) *ocmetrics.Metric {
+		ocMetric.MetricDescriptor.Type = descType
+		return ocMetric
+	}
+	summaryWithPercentiles := generateOCTestMetricDoubleSummary()
+	summaryWithPercentiles.Timeseries[1].Points[0].GetSummaryValue().Snapshot.PercentileValues = []*ocmetrics.SummaryValue_Snapshot_ValueAtPercentile{
+		{Percentile: 0.7, Value: 1},
+		{Percentile: 0.9, Value: 2},
+		{Percentile: 1.7, Value: 3},
+		{Percentile: 50, Value: 4},
+		{Percentile: 99.9, Value: 5},
+		{Percentile: 99.99, Value: 6},
+		{Percentile: 100, Value: 7},
+	}
+
+	tests := []struct {
+		name     string
+		ocMetric *ocmetrics.Metric
+	}{
+		{
+			name:     "gauge-int64",
+			ocMetric: withType(generateOCTestMetricInt(), ocmetrics.MetricDescriptor_GAUGE_INT64),
+		},
+		{
+			name:     "cumulative-int64",
+			ocMetric: generateOCTestMetricInt(),
+		},
+		{
+			name:     "gauge-double",
+			ocMetric: withType(generateOCTestMetricDouble(), ocmetrics.MetricDescriptor_GAUGE_DOUBLE),
+		},
+		{
+			name:     "cumulative-double",
+			ocMetric: generateOCTestMetricDouble(),
+		},
+		{
+			name:     "gauge-distribution",
+			ocMetric: withType(generateOCTestMetricDoubleHistogram(), ocmetrics.MetricDescriptor_GAUGE_DISTRIBUTION),
+		},
+		{
+			name:     "cumulative-distribution",
+			ocMetric: generateOCTestMetricDoubleHistogram(),
+		},
+		{
+			name:     "summary",
+			ocMetric: generateOCTestMetricDoubleSummary(),
+		},
+		{
+			name:     "summary-percentiles",
+			ocMetric: summaryWithPercentiles,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			md := MetricsData{
+				Resource: generateOCTestResource(),
+				Metrics:  []*ocmetrics.Metric{test.ocMetric},
+			}
+			got := MetricsToOC(OCToMetrics(md))
+			assert.Len(t, got, 1)
+			assert.EqualValues(t, md.Metrics, got[0].Metrics)
+		})
+	}
+}
+
+func TestOCToMetrics_GaugeDistribution(t *testing.T) {
+	ocMetric := generateOCTestMetricDoubleHistogram()
+	ocMetric.MetricDescriptor.Type = ocmetrics.MetricDescriptor_GAUGE_DISTRIBUTION
+
+	md := OCToMetrics(MetricsData{Metrics: []*ocmetrics.Metric{ocMetric}})
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pdata.MetricDataTypeDoubleHistogram, metric.DataType())
+	assert.Equal(t, pdata.AggregationTemporalityDelta, metric.DoubleHistogram().AggregationTemporality())
+	assert.Equal(t, len(ocMetric.Timeseries), metric.DoubleHistogram().DataPoints().Len())
+}
+
+func TestShiftDecimalPoint(t *testing.T) {
+	for i := 0; i <= 10000; i++ {
+		quantile := float64(i) / 10000
+		percentile := shiftDecimalPoint(quantile, 2)
+		assert.Equal(t, float64(i)/100, percentile)
+		assert.Equal(t, quantile, shiftDecimalPoint(percentile, -2))
+	}
+	assert.Equal(t, 0.5, shiftDecimalPoint(50, -2))
+	assert.Equal(t, -12.5, shiftDecimalPoint(-0.125, 2))
+	assert.Equal(t, 1e-300, shiftDecimalPoint(1e-298, -2))
+	assert.True(t, math.IsNaN(shiftDecimalPoint(math.NaN(), 2)))
+	assert.True(t, math.IsInf(shiftDecimalPoint(math.Inf(1), 2), 1))
+}
+
 func BenchmarkMetricIntOCToMetrics(b *testing.B) {
 	ocMetric := MetricsData{
 		Resource: generateOCTestResource(),
@@ -240,3 +333,37 @@ func generateOCTestResource() *ocresource.Resource {
 		},
 	}
 }
+
+func TestExemplarToMetrics_TraceContext(t *testing.T) {
+	ocExemplar := &ocmetrics.DistributionValue_Exemplar{
+		Value: 1.5,
+		Attachments: map[string]string{
+			ExemplarTraceIDAttachment: "0102030405060708090a0b0c0d0e0f10",
+			ExemplarSpanIDAttachment:  "0102030405060708",
+			"key":                     "value",
+		},
+	}
+	exemplar := pdata.NewDoubleExemplar()
+	OCExemplarToMetrics(ocExemplar, exemplar)
+
+	assert.EqualValues(t, 1.5, exemplar.Value())
+	assert.Equal(t, pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), exemplar.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), exemplar.SpanID())
+	assert.Equal(t, pdata.NewStringMap().InitFromMap(map[string]string{"key": "value"}), exemplar.FilteredLabels())
+}
+
+func TestExemplarToMetrics_InvalidTraceContext(t *testing.T) {
+	ocExemplar := &ocmetrics.DistributionValue_Exemplar{
+		Attachments: map[string]string{
+			ExemplarTraceIDAttachment: "not-a-trace-id",
+			ExemplarSpanIDAttachment:  "0102",
+		},
+	}
+	exemplar := pdata.NewDoubleExemplar()
+	OCExemplarToMetrics(ocExemplar, exemplar)
+
+	assert.True(t, exemplar.TraceID().IsEmpty())
+	assert.True(t, exemplar.SpanID().IsEmpty())
+	// invalid ids are kept as regular attachments
+	assert.Equal(t, 2, exemplar.FilteredLabels().Len())
+}
@@ -145,7 +145,7 @@ func TestAttributeValueToString(t *testing.T) {
 	v.ArrayVal().Append(av)
 	av = pdata.NewAttributeValueArray()
 	v.ArrayVal().Append(av)
-	assert.EqualValues(t, `["b\"\\",123,null,"\u003cInvalid array value\u003e"]`, tracetranslator.AttributeValueToString(v, false))
+	assert.EqualValues(t, `["b\"\\",123,null,[]]`, tracetranslator.AttributeValueToString(v, false))
 }
 
 func TestInferResourceType(t *testing.T) {
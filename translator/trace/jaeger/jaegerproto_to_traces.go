@@ -211,7 +211,7 @@ func jTagsToInternalAttributes(tags []model.KeyValue, dest pdata.AttributeMap) {
 	for _, tag := range tags {
 		switch tag.GetVType() {
 		case model.ValueType_STRING:
-			dest.UpsertString(tag.Key, tag.GetVStr())
+			tracetranslator.UpsertStringToAttributeMap(tag.Key, tag.GetVStr(), dest, true)
 		case model.ValueType_BOOL:
 			dest.UpsertBool(tag.Key, tag.GetVBool())
 		case model.ValueType_INT64:
@@ -341,29 +341,31 @@ func jLogsToSpanEvents(logs []model.Log, dest pdata.SpanEventSlice) {
 	}
 }
 
-// jReferencesToSpanLinks sets internal span links based on jaeger span references skipping excludeParentID
+// jReferencesToSpanLinks sets internal span links based on jaeger span references skipping excludeParentID.
+// The type of the references is kept in the TagRefType attribute of the links.
 func jReferencesToSpanLinks(refs []model.SpanRef, excludeParentID model.SpanID, dest pdata.SpanLinkSlice) {
 	if len(refs) == 0 || len(refs) == 1 && refs[0].SpanID == excludeParentID && refs[0].RefType == model.ChildOf {
 		return
 	}
 
-	dest.Resize(len(refs))
-	i := 0
+	dest.EnsureCapacity(len(refs))
 	for _, ref := range refs {
-		link := dest.At(i)
 		if ref.SpanID == excludeParentID && ref.RefType == model.ChildOf {
 			continue
 		}
 
+		link := dest.AppendEmpty()
 		link.SetTraceID(tracetranslator.UInt64ToTraceID(ref.TraceID.High, ref.TraceID.Low))
 		link.SetSpanID(tracetranslator.UInt64ToSpanID(uint64(ref.SpanID)))
-		i++
+		link.Attributes().InsertString(tracetranslator.TagRefType, jRefTypeToInternal(ref.RefType))
 	}
+}
 
-	// Reduce slice size in case if excludeParentID was skipped
-	if i < len(refs) {
-		dest.Resize(i)
+func jRefTypeToInternal(refType model.SpanRefType) string {
+	if refType == model.ChildOf {
+		return tracetranslator.RefTypeChildOf
 	}
+	return tracetranslator.RefTypeFollowsFrom
 }
 
 func getTraceStateFromAttrs(attrs pdata.AttributeMap) pdata.TraceState {
@@ -459,6 +459,26 @@ func TestProtoBatchesToInternalTraces(t *testing.T) {
 	assert.EqualValues(t, expected, got)
 }
 
+func TestProtoBatchToInternalTracesWithReferences(t *testing.T) {
+	span := generateProtoSpanWithReferences()
+	td := ProtoBatchToInternalTraces(model.Batch{Spans: []*model.Span{span}})
+
+	got := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, pdata.NewSpanID([8]byte{0xAF, 0xAE, 0xAD, 0xAC, 0xAB, 0xAA, 0xA9, 0xA8}), got.ParentSpanID())
+
+	links := got.Links()
+	require.Equal(t, 2, links.Len())
+	expectedRefTypes := []string{tracetranslator.RefTypeChildOf, tracetranslator.RefTypeFollowsFrom}
+	for i, ref := range span.References[1:] {
+		link := links.At(i)
+		assert.Equal(t, tracetranslator.UInt64ToTraceID(ref.TraceID.High, ref.TraceID.Low), link.TraceID())
+		assert.Equal(t, tracetranslator.UInt64ToSpanID(uint64(ref.SpanID)), link.SpanID())
+		refType, ok := link.Attributes().Get(tracetranslator.TagRefType)
+		require.True(t, ok)
+		assert.Equal(t, expectedRefTypes[i], refType.StringVal())
+	}
+}
+
 func TestJSpanKindToInternal(t *testing.T) {
 	tests := []struct {
 		jSpanKind    string
@@ -777,9 +797,32 @@ func generateTraceDataTwoSpansWithFollower() pdata.Traces {
 	span.Links().Resize(1)
 	span.Links().At(0).SetTraceID(span.TraceID())
 	span.Links().At(0).SetSpanID(spans.At(0).SpanID())
+	span.Links().At(0).Attributes().InsertString(tracetranslator.TagRefType, tracetranslator.RefTypeFollowsFrom)
 	return td
 }
 
+// generateProtoSpanWithReferences generates a jaeger span with a parent reference followed by
+// one more CHILD_OF reference and a FOLLOWS_FROM reference.
+func generateProtoSpanWithReferences() *model.Span {
+	span := generateProtoChildSpan()
+	span.References = append(span.References,
+		model.SpanRef{
+			TraceID: span.TraceID,
+			SpanID:  model.NewSpanID(binary.BigEndian.Uint64([]byte{0x2F, 0x2E, 0x2D, 0x2C, 0x2B, 0x2A, 0x29, 0x28})),
+			RefType: model.SpanRefType_CHILD_OF,
+		},
+		model.SpanRef{
+			TraceID: model.NewTraceID(
+				binary.BigEndian.Uint64([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}),
+				binary.BigEndian.Uint64([]byte{0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10}),
+			),
+			SpanID:  model.NewSpanID(binary.BigEndian.Uint64([]byte{0x3F, 0x3E, 0x3D, 0x3C, 0x3B, 0x3A, 0x39, 0x38})),
+			RefType: model.SpanRefType_FOLLOWS_FROM,
+		},
+	)
+	return span
+}
+
 func generateProtoFollowerSpan() *model.Span {
 	traceID := model.NewTraceID(
 		binary.BigEndian.Uint64([]byte{0xF1, 0xF2, 0xF3, 0xF4, 0xF5, 0xF6, 0xF7, 0xF8}),
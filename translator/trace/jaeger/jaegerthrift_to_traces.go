@@ -131,7 +131,7 @@ func jThriftTagsToInternalAttributes(tags []*jaeger.Tag, dest pdata.AttributeMap
 	for _, tag := range tags {
 		switch tag.GetVType() {
 		case jaeger.TagType_STRING:
-			dest.UpsertString(tag.Key, tag.GetVStr())
+			tracetranslator.UpsertStringToAttributeMap(tag.Key, tag.GetVStr(), dest, true)
 		case jaeger.TagType_BOOL:
 			dest.UpsertBool(tag.Key, tag.GetVBool())
 		case jaeger.TagType_LONG:
@@ -171,28 +171,31 @@ func jThriftLogsToSpanEvents(logs []*jaeger.Log, dest pdata.SpanEventSlice) {
 	}
 }
 
+// jThriftReferencesToSpanLinks sets internal span links based on jaeger span references skipping excludeParentID.
+// The type of the references is kept in the TagRefType attribute of the links.
 func jThriftReferencesToSpanLinks(refs []*jaeger.SpanRef, excludeParentID int64, dest pdata.SpanLinkSlice) {
 	if len(refs) == 0 || len(refs) == 1 && refs[0].SpanId == excludeParentID && refs[0].RefType == jaeger.SpanRefType_CHILD_OF {
 		return
 	}
 
-	dest.Resize(len(refs))
-	i := 0
+	dest.EnsureCapacity(len(refs))
 	for _, ref := range refs {
-		link := dest.At(i)
 		if ref.SpanId == excludeParentID && ref.RefType == jaeger.SpanRefType_CHILD_OF {
 			continue
 		}
 
+		link := dest.AppendEmpty()
 		link.SetTraceID(tracetranslator.UInt64ToTraceID(uint64(ref.TraceIdHigh), uint64(ref.TraceIdLow)))
 		link.SetSpanID(tracetranslator.UInt64ToSpanID(uint64(ref.SpanId)))
-		i++
+		link.Attributes().InsertString(tracetranslator.TagRefType, jThriftRefTypeToInternal(ref.RefType))
 	}
+}
 
-	// Reduce slice size in case if excludeParentID was skipped
-	if i < len(refs) {
-		dest.Resize(i)
+func jThriftRefTypeToInternal(refType jaeger.SpanRefType) string {
+	if refType == jaeger.SpanRefType_CHILD_OF {
+		return tracetranslator.RefTypeChildOf
 	}
+	return tracetranslator.RefTypeFollowsFrom
 }
 
 // microsecondsToUnixNano converts epoch microseconds to pdata.Timestamp
@@ -316,17 +316,22 @@ func makeJaegerProtoReferences(
 		refs = append(refs, model.SpanRef{
 			TraceID: traceID,
 			SpanID:  spanID,
-
-			// Since Jaeger RefType is not captured in internal data,
-			// use SpanRefType_FOLLOWS_FROM by default.
-			// SpanRefType_CHILD_OF supposed to be set only from parentSpanID.
-			RefType: model.SpanRefType_FOLLOWS_FROM,
+			RefType: linkToJaegerProtoRefType(link),
 		})
 	}
 
 	return refs, nil
 }
 
+// linkToJaegerProtoRefType returns the type of the reference set in the TagRefType attribute of the link, the links
+// without it are converted to SpanRefType_FOLLOWS_FROM. SpanRefType_CHILD_OF is otherwise only set from parentSpanID.
+func linkToJaegerProtoRefType(link pdata.SpanLink) model.SpanRefType {
+	if refType, ok := link.Attributes().Get(tracetranslator.TagRefType); ok && refType.StringVal() == tracetranslator.RefTypeChildOf {
+		return model.SpanRefType_CHILD_OF
+	}
+	return model.SpanRefType_FOLLOWS_FROM
+}
+
 func spanEventsToJaegerProtoLogs(events pdata.SpanEventSlice) []model.Log {
 	if events.Len() == 0 {
 		return nil
@@ -330,9 +330,65 @@ func TestInternalTracesToJaegerProtoBatchesAndBack(t *testing.T) {
 		tdFromPB := ProtoBatchesToInternalTraces(protoBatches)
 		assert.NotNil(t, tdFromPB)
 		assert.Equal(t, td.SpanCount(), tdFromPB.SpanCount())
+		assert.Equal(t, linkCount(td), linkCount(tdFromPB))
 	}
 }
 
+func TestInternalComplexAttributesToJaegerProtoAndBack(t *testing.T) {
+	td := testdata.GenerateTraceDataOneSpan()
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.SetTraceID(pdata.NewTraceID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}))
+	attrs := span.Attributes()
+	mapVal := pdata.NewAttributeValueMap()
+	mapVal.MapVal().InsertString("http.method", "GET")
+	mapVal.MapVal().InsertInt("retries", 3)
+	arrVal := pdata.NewAttributeValueArray()
+	arrVal.ArrayVal().Append(pdata.NewAttributeValueString("abc"))
+	arrVal.ArrayVal().Append(mapVal)
+	attrs.Insert("map-attr", mapVal)
+	attrs.Insert("array-attr", arrVal)
+	attrs.InsertString("string-attr", "[not an array]")
+
+	protoBatches, err := InternalTracesToJaegerProto(td)
+	require.NoError(t, err)
+	tdFromPB := ProtoBatchesToInternalTraces(protoBatches)
+	spanFromPB := tdFromPB.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	for _, key := range []string{"map-attr", "array-attr", "string-attr"} {
+		expected, _ := attrs.Get(key)
+		actual, ok := spanFromPB.Attributes().Get(key)
+		require.True(t, ok, key)
+		assert.True(t, expected.Equal(actual), key)
+	}
+}
+
+func TestInternalLinksToJaegerProtoReferencesAndBack(t *testing.T) {
+	span := generateProtoSpanWithReferences()
+	td := ProtoBatchToInternalTraces(model.Batch{Spans: []*model.Span{span}})
+
+	protoBatches, err := InternalTracesToJaegerProto(td)
+	require.NoError(t, err)
+	require.Len(t, protoBatches, 1)
+	require.Len(t, protoBatches[0].Spans, 1)
+	assert.EqualValues(t, span.References, protoBatches[0].Spans[0].References)
+}
+
+func linkCount(td pdata.Traces) int {
+	count := 0
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				count += spans.At(k).Links().Len()
+			}
+		}
+	}
+	return count
+}
+
 // generateProtoChildSpanWithErrorTags generates a jaeger span to be used in
 // internal->jaeger translation test. It supposed to be the same as generateProtoChildSpan
 // that used in jaeger->internal, but jaeger->internal translation infers status code from http status if
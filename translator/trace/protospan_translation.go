@@ -43,6 +43,13 @@ const (
 	TagServiceNameSource = "otlp.service.name.source"
 )
 
+// The attribute of the span links converted from the OpenTracing span references (e.g. Jaeger), and its values.
+const (
+	TagRefType         = "opentracing.ref_type"
+	RefTypeChildOf     = "child_of"
+	RefTypeFollowsFrom = "follows_from"
+)
+
 // Constants used for signifying batch-level attribute values where not supplied by OTLP data but required
 // by other protocols.
 const (
@@ -83,7 +90,7 @@ func getAttrValDescripts() []*attrValDescript {
 	descriptions = append(descriptions, constructAttrValDescript(`^-?\d+$`, pdata.AttributeValueINT))
 	descriptions = append(descriptions, constructAttrValDescript(`^-?\d+\.\d+$`, pdata.AttributeValueDOUBLE))
 	descriptions = append(descriptions, constructAttrValDescript(`^(true|false)$`, pdata.AttributeValueBOOL))
-	descriptions = append(descriptions, constructAttrValDescript(`^\{"\w+":.+\}$`, pdata.AttributeValueMAP))
+	descriptions = append(descriptions, constructAttrValDescript(`^\{.*\}$`, pdata.AttributeValueMAP))
 	descriptions = append(descriptions, constructAttrValDescript(`^\[.*\]$`, pdata.AttributeValueARRAY))
 	return descriptions
 }
@@ -176,6 +183,10 @@ func AttributeArrayToSlice(attrArray pdata.AnyValueArray) []interface{} {
 			rawSlice = append(rawSlice, v.BoolVal())
 		case pdata.AttributeValueNULL:
 			rawSlice = append(rawSlice, nil)
+		case pdata.AttributeValueMAP:
+			rawSlice = append(rawSlice, AttributeMapToMap(v.MapVal()))
+		case pdata.AttributeValueARRAY:
+			rawSlice = append(rawSlice, AttributeArrayToSlice(v.ArrayVal()))
 		default:
 			rawSlice = append(rawSlice, "<Invalid array value>")
 		}
@@ -183,7 +194,9 @@ func AttributeArrayToSlice(attrArray pdata.AnyValueArray) []interface{} {
 	return rawSlice
 }
 
-// UpsertStringToAttributeMap upserts a string value to the specified key as it's native OTLP type
+// UpsertStringToAttributeMap upserts a string value to the specified key as it's native OTLP type,
+// the maps and arrays are expected as JSON objects and arrays. The strings that cannot be parsed
+// as their native type are upserted as strings.
 func UpsertStringToAttributeMap(key string, val string, dest pdata.AttributeMap, omitSimpleTypes bool) {
 	switch DetermineValueType(val, omitSimpleTypes) {
 	case pdata.AttributeValueINT:
@@ -203,7 +216,7 @@ func UpsertStringToAttributeMap(key string, val string, dest pdata.AttributeMap,
 			jsonMapToAttributeMap(attrs, attrMap.MapVal())
 			dest.Upsert(key, attrMap)
 		} else {
-			dest.UpsertString(key, "")
+			dest.UpsertString(key, val)
 		}
 	case pdata.AttributeValueARRAY:
 		var jArray []interface{}
@@ -213,7 +226,7 @@ func UpsertStringToAttributeMap(key string, val string, dest pdata.AttributeMap,
 			jsonArrayToAttributeArray(jArray, attrArr.ArrayVal())
 			dest.Upsert(key, attrArr)
 		} else {
-			dest.UpsertString(key, "")
+			dest.UpsertString(key, val)
 		}
 	default:
 		dest.UpsertString(key, val)
@@ -282,6 +295,14 @@ func jsonArrayToAttributeArray(jArray []interface{}, dest pdata.AnyValueArray) {
 			}
 		} else if b, ok := val.(bool); ok {
 			dest.Append(pdata.NewAttributeValueBool(b))
+		} else if m, ok := val.(map[string]interface{}); ok {
+			value := pdata.NewAttributeValueMap()
+			jsonMapToAttributeMap(m, value.MapVal())
+			dest.Append(value)
+		} else if a, ok := val.([]interface{}); ok {
+			value := pdata.NewAttributeValueArray()
+			jsonArrayToAttributeArray(a, value.ArrayVal())
+			dest.Append(value)
 		} else {
 			dest.Append(pdata.NewAttributeValueString("<Invalid array value>"))
 		}
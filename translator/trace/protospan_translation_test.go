@@ -103,8 +103,8 @@ func TestAttributeValueToString(t *testing.T) {
 				switch test.input.Type() {
 				case pdata.AttributeValueINT, pdata.AttributeValueDOUBLE, pdata.AttributeValueBOOL:
 					assert.EqualValues(t, test.input, val)
-				case pdata.AttributeValueARRAY:
-					assert.NotNil(t, val)
+				case pdata.AttributeValueMAP, pdata.AttributeValueARRAY:
+					assert.True(t, test.input.Equal(val))
 				default:
 					assert.Equal(t, test.expected, val.StringVal())
 				}
@@ -139,6 +139,8 @@ func TestAttributeArrayToStringAndBack(t *testing.T) {
 	attrArr.Append(pdata.NewAttributeValueDouble(18.6))
 	attrArr.Append(pdata.NewAttributeValueBool(false))
 	attrArr.Append(pdata.NewAttributeValueNull())
+	attrArr.Append(constructTestAttributeSubmap())
+	attrArr.Append(constructTestAttributeSubarray())
 	strVal := AttributeValueToString(expected, false)
 	dest := pdata.NewAttributeMap()
 	UpsertStringToAttributeMap("parent", strVal, dest, false)
@@ -147,6 +149,16 @@ func TestAttributeArrayToStringAndBack(t *testing.T) {
 	compareArrays(t, attrArr, actual.ArrayVal())
 }
 
+func TestInvalidJSONStringToAttributeMap(t *testing.T) {
+	dest := pdata.NewAttributeMap()
+	UpsertStringToAttributeMap("map", "{not json}", dest, true)
+	UpsertStringToAttributeMap("array", "[not json]", dest, true)
+	assert.EqualValues(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"map":   pdata.NewAttributeValueString("{not json}"),
+		"array": pdata.NewAttributeValueString("[not json]"),
+	}).Sort(), dest.Sort())
+}
+
 func compareMaps(t *testing.T, expected pdata.AttributeMap, actual pdata.AttributeMap) {
 	expected.ForEach(func(k string, e pdata.AttributeValue) {
 		a, ok := actual.Get(k)
@@ -18,4 +18,7 @@ package zipkin
 // format to the internal collector data format.
 const (
 	StartTimeAbsent = "otel.zipkin.absentField.startTime"
+	// SharedSpan is set to true on the spans with the Zipkin shared flag, the server side of a span
+	// sharing its ID with the client side.
+	SharedSpan = "otel.zipkin.shared"
 )
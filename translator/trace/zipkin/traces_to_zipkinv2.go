@@ -130,6 +130,11 @@ func spanToZipkinSpan(
 		tags[tracetranslator.TagSpanKind] = "internal"
 	}
 
+	if shared, ok := tags[SharedSpan]; ok {
+		zs.Shared = shared == "true"
+		delete(tags, SharedSpan)
+	}
+
 	redundantKeys := make(map[string]bool, 8)
 	zs.LocalEndpoint = zipkinEndpointFromTags(tags, localServiceName, false, redundantKeys)
 	zs.RemoteEndpoint = zipkinEndpointFromTags(tags, "", true, redundantKeys)
@@ -20,6 +20,7 @@ import (
 
 	zipkinmodel "github.com/openzipkin/zipkin-go/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/goldendataset"
@@ -99,6 +100,35 @@ func TestInternalTracesToZipkinSpansAndBack(t *testing.T) {
 	}
 }
 
+func TestInternalComplexAttributesToZipkinSpansAndBack(t *testing.T) {
+	td := generateTraceOneSpanOneTraceID()
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	attrs := span.Attributes()
+	mapVal := pdata.NewAttributeValueMap()
+	mapVal.MapVal().InsertString("http.method", "GET")
+	mapVal.MapVal().InsertInt("retries", 3)
+	arrVal := pdata.NewAttributeValueArray()
+	arrVal.ArrayVal().Append(pdata.NewAttributeValueString("abc"))
+	arrVal.ArrayVal().Append(mapVal)
+	attrs.Insert("map-attr", mapVal)
+	attrs.Insert("array-attr", arrVal)
+	attrs.InsertString("string-attr", "[not an array]")
+
+	for _, parseStringTags := range []bool{false, true} {
+		zipkinSpans, err := InternalTracesToZipkinSpans(td)
+		require.NoError(t, err)
+		tdFromZS, err := V2SpansToInternalTraces(zipkinSpans, parseStringTags)
+		require.NoError(t, err)
+		spanFromZS := tdFromZS.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+		for _, key := range []string{"map-attr", "array-attr", "string-attr"} {
+			expected, _ := attrs.Get(key)
+			actual, ok := spanFromZS.Attributes().Get(key)
+			require.True(t, ok, key)
+			assert.True(t, expected.Equal(actual), key)
+		}
+	}
+}
+
 func generateTraceOneSpanOneTraceID() pdata.Traces {
 	td := testdata.GenerateTraceDataOneSpan()
 	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	zipkinmodel "github.com/openzipkin/zipkin-go/model"
 
@@ -66,6 +67,89 @@ func (b byOTLPTypes) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
 
+// v2JSONSpan decodes a Zipkin v2 JSON span, accepting the fractional timestamps and durations
+// (in microseconds) of the tracers measuring the time with a sub-microsecond precision.
+type v2JSONSpan struct {
+	zipkinmodel.SpanModel
+}
+
+func (s *v2JSONSpan) UnmarshalJSON(b []byte) error {
+	type Alias zipkinmodel.SpanModel
+	span := &struct {
+		T           float64            `json:"timestamp,omitempty"`
+		D           float64            `json:"duration,omitempty"`
+		Annotations []v2JSONAnnotation `json:"annotations,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(&s.SpanModel),
+	}
+	if err := json.Unmarshal(b, &span); err != nil {
+		return err
+	}
+	if s.ID < 1 {
+		return zipkinmodel.ErrValidIDRequired
+	}
+	if span.T > 0 {
+		s.Timestamp = time.Unix(0, microsecondsToNanoseconds(span.T))
+	}
+	s.Duration = time.Duration(microsecondsToNanoseconds(span.D))
+	if len(span.Annotations) > 0 {
+		s.Annotations = make([]zipkinmodel.Annotation, len(span.Annotations))
+		for i, anno := range span.Annotations {
+			s.Annotations[i] = zipkinmodel.Annotation(anno)
+		}
+	}
+	if s.LocalEndpoint.Empty() {
+		s.LocalEndpoint = nil
+	}
+	if s.RemoteEndpoint.Empty() {
+		s.RemoteEndpoint = nil
+	}
+	return nil
+}
+
+// v2JSONAnnotation decodes a Zipkin v2 JSON annotation, accepting a fractional timestamp.
+type v2JSONAnnotation zipkinmodel.Annotation
+
+func (a *v2JSONAnnotation) UnmarshalJSON(b []byte) error {
+	annotation := &struct {
+		Timestamp float64 `json:"timestamp"`
+		Value     string  `json:"value"`
+	}{}
+	if err := json.Unmarshal(b, &annotation); err != nil {
+		return err
+	}
+	if annotation.Timestamp < 1 {
+		return zipkinmodel.ErrValidTimestampRequired
+	}
+	a.Timestamp = time.Unix(0, microsecondsToNanoseconds(annotation.Timestamp))
+	a.Value = annotation.Value
+	return nil
+}
+
+// microsecondsToNanoseconds converts the whole and the fractional microseconds separately, the epoch
+// nanoseconds being beyond the precision of float64.
+func microsecondsToNanoseconds(micros float64) int64 {
+	whole := math.Floor(micros)
+	return int64(whole)*1e3 + int64(math.Round((micros-whole)*1e3))
+}
+
+// V2JSONBatchToInternalTraces translates a Zipkin v2 JSON batch of spans into internal trace data.
+func V2JSONBatchToInternalTraces(blob []byte, parseStringTags bool) (pdata.Traces, error) {
+	var jsonSpans []*v2JSONSpan
+	if err := json.Unmarshal(blob, &jsonSpans); err != nil {
+		return pdata.NewTraces(), err
+	}
+
+	zipkinSpans := make([]*zipkinmodel.SpanModel, 0, len(jsonSpans))
+	for _, jsonSpan := range jsonSpans {
+		if jsonSpan != nil {
+			zipkinSpans = append(zipkinSpans, &jsonSpan.SpanModel)
+		}
+	}
+	return V2SpansToInternalTraces(zipkinSpans, parseStringTags)
+}
+
 // V2SpansToInternalTraces translates Zipkin v2 spans into internal trace data.
 func V2SpansToInternalTraces(zipkinSpans []*zipkinmodel.SpanModel, parseStringTags bool) (pdata.Traces, error) {
 	traceData := pdata.NewTraces()
@@ -147,6 +231,9 @@ func zSpanToInternal(zspan *zipkinmodel.SpanModel, tags map[string]string, dest
 	if err := zTagsToInternalAttrs(zspan, tags, attrs, parseStringTags); err != nil {
 		return err
 	}
+	if zspan.Shared {
+		attrs.UpsertBool(SharedSpan, true)
+	}
 
 	err := populateSpanEvents(zspan, dest.Events())
 	return err
@@ -260,8 +347,8 @@ func populateSpanEvents(zspan *zipkinmodel.SpanModel, events pdata.SpanEventSlic
 
 		parts := strings.Split(anno.Value, "|")
 		partCnt := len(parts)
-		event.SetName(parts[0])
 		if partCnt < 3 {
+			event.SetName(anno.Value)
 			continue
 		}
 
@@ -273,17 +360,17 @@ func populateSpanEvents(zspan *zipkinmodel.SpanModel, events pdata.SpanEventSlic
 			jsonStr = strings.Join(jsonParts, "|")
 		}
 		var attrs map[string]interface{}
-		if err := json.Unmarshal([]byte(jsonStr), &attrs); err != nil {
-			return err
+		dropped, errDropped := strconv.ParseUint(parts[partCnt-1], 10, 32)
+		if errDropped != nil || json.Unmarshal([]byte(jsonStr), &attrs) != nil {
+			// Not an annotation encoded from a span event with attributes, the whole value is kept as the name.
+			event.SetName(anno.Value)
+			continue
 		}
+
+		event.SetName(parts[0])
 		if err := jsonMapToAttributeMap(attrs, event.Attributes()); err != nil {
 			return err
 		}
-
-		dropped, errDropped := strconv.ParseUint(parts[partCnt-1], 10, 32)
-		if errDropped != nil {
-			return errDropped
-		}
 		event.SetDroppedAttributesCount(uint32(dropped))
 	}
 	return nil
@@ -343,23 +430,8 @@ func tagsToAttributeMap(tags map[string]string, dest pdata.AttributeMap, parseSt
 			continue
 		}
 
-		if parseStringTags {
-			switch tracetranslator.DetermineValueType(val, false) {
-			case pdata.AttributeValueINT:
-				iValue, _ := strconv.ParseInt(val, 10, 64)
-				dest.UpsertInt(key, iValue)
-			case pdata.AttributeValueDOUBLE:
-				fValue, _ := strconv.ParseFloat(val, 64)
-				dest.UpsertDouble(key, fValue)
-			case pdata.AttributeValueBOOL:
-				bValue, _ := strconv.ParseBool(val)
-				dest.UpsertBool(key, bValue)
-			default:
-				dest.UpsertString(key, val)
-			}
-		} else {
-			dest.UpsertString(key, val)
-		}
+		// The maps and arrays are always parsed, the other types only if parseStringTags is enabled.
+		tracetranslator.UpsertStringToAttributeMap(key, val, dest, !parseStringTags)
 	}
 	return parseErr
 }
@@ -20,6 +20,7 @@ import (
 
 	zipkinmodel "github.com/openzipkin/zipkin-go/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/testdata"
@@ -76,6 +77,92 @@ func TestZipkinSpansToInternalTraces(t *testing.T) {
 	}
 }
 
+func TestV2JSONBatchToInternalTracesFractionalTimestamps(t *testing.T) {
+	blob := []byte(`[{
+		"traceId": "f1f2f3f4f5f6f7f8f9fafbfcfdfeff80",
+		"id": "afaeadacabaaa9a8",
+		"name": "fractional",
+		"timestamp": 1596911098294000.25,
+		"duration": 1000.5,
+		"annotations": [{"timestamp": 1596911098294500.75, "value": "event"}]
+	}]`)
+
+	td, err := V2JSONBatchToInternalTraces(blob, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, td.SpanCount())
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.Equal(t, "fractional", span.Name())
+	assert.Equal(t, pdata.Timestamp(1596911098294000250), span.StartTime())
+	assert.Equal(t, pdata.Timestamp(1596911098295000750), span.EndTime())
+	require.Equal(t, 1, span.Events().Len())
+	assert.Equal(t, "event", span.Events().At(0).Name())
+	assert.Equal(t, pdata.Timestamp(1596911098294500750), span.Events().At(0).Timestamp())
+}
+
+func TestV2JSONBatchToInternalTracesInvalid(t *testing.T) {
+	_, err := V2JSONBatchToInternalTraces([]byte(`[{"traceId": "f1f2f3f4f5f6f7f8f9fafbfcfdfeff80"}]`), false)
+	assert.Equal(t, zipkinmodel.ErrValidIDRequired, err)
+
+	_, err = V2JSONBatchToInternalTraces([]byte(`[{
+		"traceId": "f1f2f3f4f5f6f7f8f9fafbfcfdfeff80",
+		"id": "afaeadacabaaa9a8",
+		"annotations": [{"value": "event"}]
+	}]`), false)
+	assert.Equal(t, zipkinmodel.ErrValidTimestampRequired, err)
+
+	_, err = V2JSONBatchToInternalTraces([]byte(`{}`), false)
+	assert.Error(t, err)
+}
+
+func TestZipkinSharedSpanToInternalAndBack(t *testing.T) {
+	zs := generateSpanNoTags()
+	zs[0].Shared = true
+
+	td, err := V2SpansToInternalTraces(zs, false)
+	require.NoError(t, err)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	shared, ok := span.Attributes().Get(SharedSpan)
+	require.True(t, ok)
+	assert.True(t, shared.BoolVal())
+
+	back, err := InternalTracesToZipkinSpans(td)
+	require.NoError(t, err)
+	require.Len(t, back, 1)
+	assert.True(t, back[0].Shared)
+	assert.NotContains(t, back[0].Tags, SharedSpan)
+}
+
+func TestZipkinAnnotationsToSpanEvents(t *testing.T) {
+	zs := generateSpanNoEndpoints()
+	ts := time.Unix(1596911098, 294500000)
+	zs[0].Annotations = []zipkinmodel.Annotation{
+		{Timestamp: ts, Value: "cs"},
+		{Timestamp: ts, Value: "retry|1"},
+		{Timestamp: ts, Value: "query|not json|0"},
+		{Timestamp: ts, Value: `event|{"key":"value","count":2}|3`},
+	}
+
+	td, err := V2SpansToInternalTraces(zs, false)
+	require.NoError(t, err)
+	events := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Events()
+	require.Equal(t, 4, events.Len())
+	assert.Equal(t, "cs", events.At(0).Name())
+	assert.Equal(t, "retry|1", events.At(1).Name())
+	assert.Equal(t, "query|not json|0", events.At(2).Name())
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 0, events.At(i).Attributes().Len())
+		assert.Equal(t, pdata.TimestampFromTime(ts), events.At(i).Timestamp())
+	}
+
+	event := events.At(3)
+	assert.Equal(t, "event", event.Name())
+	assert.Equal(t, uint32(3), event.DroppedAttributesCount())
+	expected := pdata.NewAttributeMap()
+	expected.InsertString("key", "value")
+	expected.InsertInt("count", 2)
+	assert.EqualValues(t, expected.Sort(), event.Attributes().Sort())
+}
+
 func generateNilSpan() []*zipkinmodel.SpanModel {
 	return make([]*zipkinmodel.SpanModel, 1)
 }